@@ -31,6 +31,7 @@
 		KeyValueUpdate
 		KeyValueUpdateResult
 		QueryLimits
+		SourceQueryLimits
 		QueryLimit
 */
 package kvpb
@@ -117,10 +118,11 @@ func (m *KeyValueUpdateResult) GetNew() string {
 }
 
 type QueryLimits struct {
-	MaxRecentlyQueriedSeriesBlocks        *QueryLimit `protobuf:"bytes,1,opt,name=maxRecentlyQueriedSeriesBlocks" json:"maxRecentlyQueriedSeriesBlocks,omitempty"`
-	MaxRecentlyQueriedSeriesDiskBytesRead *QueryLimit `protobuf:"bytes,2,opt,name=maxRecentlyQueriedSeriesDiskBytesRead" json:"maxRecentlyQueriedSeriesDiskBytesRead,omitempty"`
-	MaxRecentlyQueriedSeriesDiskRead      *QueryLimit `protobuf:"bytes,3,opt,name=maxRecentlyQueriedSeriesDiskRead" json:"maxRecentlyQueriedSeriesDiskRead,omitempty"`
-	MaxRecentlyQueriedMetadataRead        *QueryLimit `protobuf:"bytes,4,opt,name=maxRecentlyQueriedMetadataRead" json:"maxRecentlyQueriedMetadataRead,omitempty"`
+	MaxRecentlyQueriedSeriesBlocks        *QueryLimit          `protobuf:"bytes,1,opt,name=maxRecentlyQueriedSeriesBlocks" json:"maxRecentlyQueriedSeriesBlocks,omitempty"`
+	MaxRecentlyQueriedSeriesDiskBytesRead *QueryLimit          `protobuf:"bytes,2,opt,name=maxRecentlyQueriedSeriesDiskBytesRead" json:"maxRecentlyQueriedSeriesDiskBytesRead,omitempty"`
+	MaxRecentlyQueriedSeriesDiskRead      *QueryLimit          `protobuf:"bytes,3,opt,name=maxRecentlyQueriedSeriesDiskRead" json:"maxRecentlyQueriedSeriesDiskRead,omitempty"`
+	MaxRecentlyQueriedMetadataRead        *QueryLimit          `protobuf:"bytes,4,opt,name=maxRecentlyQueriedMetadataRead" json:"maxRecentlyQueriedMetadataRead,omitempty"`
+	SourceLimits                          []*SourceQueryLimits `protobuf:"bytes,5,rep,name=sourceLimits" json:"sourceLimits,omitempty"`
 }
 
 func (m *QueryLimits) Reset()                    { *m = QueryLimits{} }
@@ -156,6 +158,55 @@ func (m *QueryLimits) GetMaxRecentlyQueriedMetadataRead() *QueryLimit {
 	return nil
 }
 
+func (m *QueryLimits) GetSourceLimits() []*SourceQueryLimits {
+	if m != nil {
+		return m.SourceLimits
+	}
+	return nil
+}
+
+// SourceQueryLimits are enforced on the queries from a single source in
+// addition to the global query limits.
+type SourceQueryLimits struct {
+	Source                                string      `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	MaxRecentlyQueriedSeriesBlocks        *QueryLimit `protobuf:"bytes,2,opt,name=maxRecentlyQueriedSeriesBlocks" json:"maxRecentlyQueriedSeriesBlocks,omitempty"`
+	MaxRecentlyQueriedSeriesDiskBytesRead *QueryLimit `protobuf:"bytes,3,opt,name=maxRecentlyQueriedSeriesDiskBytesRead" json:"maxRecentlyQueriedSeriesDiskBytesRead,omitempty"`
+	MaxRecentlyQueriedMetadataRead        *QueryLimit `protobuf:"bytes,4,opt,name=maxRecentlyQueriedMetadataRead" json:"maxRecentlyQueriedMetadataRead,omitempty"`
+}
+
+func (m *SourceQueryLimits) Reset()                    { *m = SourceQueryLimits{} }
+func (m *SourceQueryLimits) String() string            { return proto.CompactTextString(m) }
+func (*SourceQueryLimits) ProtoMessage()               {}
+func (*SourceQueryLimits) Descriptor() ([]byte, []int) { return fileDescriptorKv, []int{3} }
+
+func (m *SourceQueryLimits) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *SourceQueryLimits) GetMaxRecentlyQueriedSeriesBlocks() *QueryLimit {
+	if m != nil {
+		return m.MaxRecentlyQueriedSeriesBlocks
+	}
+	return nil
+}
+
+func (m *SourceQueryLimits) GetMaxRecentlyQueriedSeriesDiskBytesRead() *QueryLimit {
+	if m != nil {
+		return m.MaxRecentlyQueriedSeriesDiskBytesRead
+	}
+	return nil
+}
+
+func (m *SourceQueryLimits) GetMaxRecentlyQueriedMetadataRead() *QueryLimit {
+	if m != nil {
+		return m.MaxRecentlyQueriedMetadataRead
+	}
+	return nil
+}
+
 type QueryLimit struct {
 	Limit           int64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
 	LookbackSeconds int64 `protobuf:"varint,2,opt,name=lookbackSeconds,proto3" json:"lookbackSeconds,omitempty"`
@@ -166,7 +217,7 @@ type QueryLimit struct {
 func (m *QueryLimit) Reset()                    { *m = QueryLimit{} }
 func (m *QueryLimit) String() string            { return proto.CompactTextString(m) }
 func (*QueryLimit) ProtoMessage()               {}
-func (*QueryLimit) Descriptor() ([]byte, []int) { return fileDescriptorKv, []int{3} }
+func (*QueryLimit) Descriptor() ([]byte, []int) { return fileDescriptorKv, []int{4} }
 
 func (m *QueryLimit) GetLimit() int64 {
 	if m != nil {
@@ -200,6 +251,7 @@ func init() {
 	proto.RegisterType((*KeyValueUpdate)(nil), "kvpb.KeyValueUpdate")
 	proto.RegisterType((*KeyValueUpdateResult)(nil), "kvpb.KeyValueUpdateResult")
 	proto.RegisterType((*QueryLimits)(nil), "kvpb.QueryLimits")
+	proto.RegisterType((*SourceQueryLimits)(nil), "kvpb.SourceQueryLimits")
 	proto.RegisterType((*QueryLimit)(nil), "kvpb.QueryLimit")
 }
 func (m *KeyValueUpdate) Marshal() (dAtA []byte, err error) {
@@ -333,6 +385,72 @@ func (m *QueryLimits) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n4
 	}
+	if len(m.SourceLimits) > 0 {
+		for _, msg := range m.SourceLimits {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintKv(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *SourceQueryLimits) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SourceQueryLimits) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Source) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintKv(dAtA, i, uint64(len(m.Source)))
+		i += copy(dAtA[i:], m.Source)
+	}
+	if m.MaxRecentlyQueriedSeriesBlocks != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintKv(dAtA, i, uint64(m.MaxRecentlyQueriedSeriesBlocks.Size()))
+		n5, err := m.MaxRecentlyQueriedSeriesBlocks.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.MaxRecentlyQueriedSeriesDiskBytesRead != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintKv(dAtA, i, uint64(m.MaxRecentlyQueriedSeriesDiskBytesRead.Size()))
+		n6, err := m.MaxRecentlyQueriedSeriesDiskBytesRead.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.MaxRecentlyQueriedMetadataRead != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintKv(dAtA, i, uint64(m.MaxRecentlyQueriedMetadataRead.Size()))
+		n7, err := m.MaxRecentlyQueriedMetadataRead.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
 	return i, nil
 }
 
@@ -447,6 +565,34 @@ func (m *QueryLimits) Size() (n int) {
 		l = m.MaxRecentlyQueriedMetadataRead.Size()
 		n += 1 + l + sovKv(uint64(l))
 	}
+	if len(m.SourceLimits) > 0 {
+		for _, e := range m.SourceLimits {
+			l = e.Size()
+			n += 1 + l + sovKv(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SourceQueryLimits) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovKv(uint64(l))
+	}
+	if m.MaxRecentlyQueriedSeriesBlocks != nil {
+		l = m.MaxRecentlyQueriedSeriesBlocks.Size()
+		n += 1 + l + sovKv(uint64(l))
+	}
+	if m.MaxRecentlyQueriedSeriesDiskBytesRead != nil {
+		l = m.MaxRecentlyQueriedSeriesDiskBytesRead.Size()
+		n += 1 + l + sovKv(uint64(l))
+	}
+	if m.MaxRecentlyQueriedMetadataRead != nil {
+		l = m.MaxRecentlyQueriedMetadataRead.Size()
+		n += 1 + l + sovKv(uint64(l))
+	}
 	return n
 }
 
@@ -907,6 +1053,215 @@ func (m *QueryLimits) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceLimits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKv
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceLimits = append(m.SourceLimits, &SourceQueryLimits{})
+			if err := m.SourceLimits[len(m.SourceLimits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKv(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthKv
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SourceQueryLimits) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKv
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SourceQueryLimits: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SourceQueryLimits: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthKv
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRecentlyQueriedSeriesBlocks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKv
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxRecentlyQueriedSeriesBlocks == nil {
+				m.MaxRecentlyQueriedSeriesBlocks = &QueryLimit{}
+			}
+			if err := m.MaxRecentlyQueriedSeriesBlocks.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRecentlyQueriedSeriesDiskBytesRead", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKv
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxRecentlyQueriedSeriesDiskBytesRead == nil {
+				m.MaxRecentlyQueriedSeriesDiskBytesRead = &QueryLimit{}
+			}
+			if err := m.MaxRecentlyQueriedSeriesDiskBytesRead.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRecentlyQueriedMetadataRead", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKv
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxRecentlyQueriedMetadataRead == nil {
+				m.MaxRecentlyQueriedMetadataRead = &QueryLimit{}
+			}
+			if err := m.MaxRecentlyQueriedMetadataRead.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipKv(dAtA[iNdEx:])
@@ -1166,30 +1521,33 @@ func init() {
 }
 
 var fileDescriptorKv = []byte{
-	// 391 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x4d, 0xab, 0xd3, 0x40,
-	0x14, 0x35, 0xe6, 0xf9, 0x78, 0xef, 0x16, 0x35, 0x0c, 0x0f, 0xe9, 0x2a, 0x84, 0xa0, 0xd0, 0x55,
-	0x06, 0xec, 0xd6, 0x55, 0xd1, 0x95, 0x15, 0x74, 0x8a, 0x1f, 0x0b, 0x37, 0x93, 0x99, 0xdb, 0x1a,
-	0x32, 0xc9, 0x94, 0xcc, 0xa4, 0x36, 0xbf, 0xc0, 0xad, 0x0b, 0x7f, 0x94, 0x4b, 0x7f, 0x82, 0xd4,
-	0x3f, 0x22, 0x33, 0x29, 0xb4, 0x95, 0xd6, 0x76, 0x13, 0xee, 0x39, 0xb9, 0xe7, 0xdc, 0x3b, 0x87,
-	0x0b, 0x2f, 0x16, 0x85, 0xfd, 0xd2, 0xe6, 0x99, 0xd0, 0x15, 0xad, 0xc6, 0x32, 0xa7, 0xd5, 0x98,
-	0x9a, 0x46, 0x50, 0xa1, 0x5a, 0x63, 0xb1, 0xa1, 0x0b, 0xac, 0xb1, 0xe1, 0x16, 0x25, 0x5d, 0x36,
-	0xda, 0x6a, 0x5a, 0xae, 0x96, 0x39, 0x2d, 0x57, 0x99, 0x47, 0xe4, 0xca, 0xc1, 0xf4, 0x2d, 0x3c,
-	0x7a, 0x8d, 0xdd, 0x07, 0xae, 0x5a, 0x7c, 0xbf, 0x94, 0xdc, 0x22, 0x89, 0x20, 0x2c, 0xb1, 0x1b,
-	0x06, 0x49, 0x30, 0xba, 0x65, 0xae, 0x24, 0x77, 0xf0, 0x60, 0xe5, 0x1a, 0x86, 0xf7, 0x3d, 0xd7,
-	0x03, 0xf2, 0x04, 0xae, 0x85, 0xae, 0xaa, 0xc2, 0x0e, 0xc3, 0x24, 0x18, 0xdd, 0xb0, 0x2d, 0x4a,
-	0xa7, 0x70, 0x77, 0xe8, 0xc8, 0xd0, 0xb4, 0xca, 0x1e, 0xf1, 0x8d, 0x20, 0xd4, 0x4a, 0x6e, 0x5d,
-	0x5d, 0xe9, 0x98, 0x1a, 0xbf, 0x7a, 0xc3, 0x5b, 0xe6, 0xca, 0xf4, 0x5b, 0x08, 0x83, 0x77, 0x2d,
-	0x36, 0xdd, 0xb4, 0xa8, 0x0a, 0x6b, 0xc8, 0x27, 0x88, 0x2b, 0xbe, 0x66, 0x28, 0xb0, 0xb6, 0xaa,
-	0x73, 0x7f, 0x0a, 0x94, 0x33, 0xf7, 0x35, 0x13, 0xa5, 0x45, 0x69, 0xfc, 0x80, 0xc1, 0xf3, 0x28,
-	0x73, 0xcf, 0xcb, 0x76, 0x52, 0x76, 0x46, 0x47, 0xe6, 0xf0, 0xec, 0x54, 0xc7, 0xcb, 0xc2, 0x94,
-	0x93, 0xce, 0xa2, 0x61, 0xc8, 0xfb, 0x7d, 0x8f, 0x0d, 0xb8, 0x4c, 0x4e, 0x3e, 0x43, 0xf2, 0xbf,
-	0x46, 0x3f, 0x22, 0x3c, 0x31, 0xe2, 0xac, 0xf2, 0x78, 0x3e, 0x6f, 0xd0, 0x72, 0xc9, 0x2d, 0xf7,
-	0xde, 0x57, 0x97, 0xe7, 0xb3, 0xaf, 0x4b, 0x7f, 0x04, 0x00, 0xbb, 0x76, 0x77, 0x14, 0xca, 0x15,
-	0x3e, 0xef, 0x90, 0xf5, 0x80, 0x8c, 0xe0, 0xb1, 0xd2, 0xba, 0xcc, 0xb9, 0x28, 0x67, 0x28, 0x74,
-	0x2d, 0x8d, 0x8f, 0x2b, 0x64, 0xff, 0xd2, 0xe4, 0x29, 0x3c, 0x9c, 0xeb, 0x46, 0xe0, 0xab, 0xb5,
-	0x40, 0x94, 0x28, 0xb7, 0x57, 0x74, 0x48, 0x92, 0x04, 0x06, 0x9e, 0xf8, 0xc8, 0x0b, 0x8b, 0xfd,
-	0xee, 0x37, 0x6c, 0x9f, 0x9a, 0x44, 0x3f, 0x37, 0x71, 0xf0, 0x6b, 0x13, 0x07, 0xbf, 0x37, 0x71,
-	0xf0, 0xfd, 0x4f, 0x7c, 0x2f, 0xbf, 0xf6, 0xf7, 0x3d, 0xfe, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x1c,
-	0x39, 0x9e, 0xf7, 0x1f, 0x03, 0x00, 0x00,
+	// 447 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0xcd, 0x6e, 0x13, 0x31,
+	0x10, 0x66, 0xe3, 0xb6, 0x6a, 0x27, 0xfc, 0x04, 0xab, 0x82, 0x9c, 0x56, 0xab, 0x15, 0x48, 0x39,
+	0x65, 0xa5, 0xe6, 0x08, 0xa7, 0x08, 0x4e, 0x14, 0x09, 0x1c, 0xf1, 0x73, 0xe0, 0xe2, 0xb5, 0xa7,
+	0x65, 0xb5, 0xde, 0x38, 0x5a, 0x7b, 0x43, 0xf7, 0x2d, 0x38, 0xf0, 0x48, 0x48, 0x70, 0xe4, 0x11,
+	0x50, 0x78, 0x11, 0x64, 0x7b, 0xa5, 0x26, 0x90, 0xd2, 0x48, 0xa8, 0x97, 0xd5, 0xcc, 0xe7, 0x6f,
+	0xbe, 0xf1, 0xce, 0x67, 0x1b, 0x9e, 0x9e, 0x17, 0xf6, 0x63, 0x93, 0x8f, 0x85, 0xae, 0xb2, 0x6a,
+	0x22, 0xf3, 0xac, 0x9a, 0x64, 0xa6, 0x16, 0x99, 0x50, 0x8d, 0xb1, 0x58, 0x67, 0xe7, 0x38, 0xc7,
+	0x9a, 0x5b, 0x94, 0xd9, 0xa2, 0xd6, 0x56, 0x67, 0xe5, 0x72, 0x91, 0x67, 0xe5, 0x72, 0xec, 0x33,
+	0xba, 0xe7, 0xd2, 0xf4, 0x15, 0xdc, 0x7d, 0x81, 0xed, 0x5b, 0xae, 0x1a, 0x7c, 0xb3, 0x90, 0xdc,
+	0x22, 0x1d, 0x00, 0x29, 0xb1, 0x1d, 0x46, 0x49, 0x34, 0x3a, 0x62, 0x2e, 0xa4, 0xc7, 0xb0, 0xbf,
+	0x74, 0x84, 0x61, 0xcf, 0x63, 0x21, 0xa1, 0x0f, 0xe0, 0x40, 0xe8, 0xaa, 0x2a, 0xec, 0x90, 0x24,
+	0xd1, 0xe8, 0x90, 0x75, 0x59, 0x7a, 0x0a, 0xc7, 0x9b, 0x8a, 0x0c, 0x4d, 0xa3, 0xec, 0x16, 0xdd,
+	0x01, 0x10, 0xad, 0x64, 0xa7, 0xea, 0x42, 0x87, 0xcc, 0xf1, 0x93, 0x17, 0x3c, 0x62, 0x2e, 0x4c,
+	0xbf, 0x12, 0xe8, 0xbf, 0x6e, 0xb0, 0x6e, 0x4f, 0x8b, 0xaa, 0xb0, 0x86, 0xbe, 0x87, 0xb8, 0xe2,
+	0x17, 0x0c, 0x05, 0xce, 0xad, 0x6a, 0xdd, 0x4a, 0x81, 0x72, 0xe6, 0xbe, 0x66, 0xaa, 0xb4, 0x28,
+	0x8d, 0x6f, 0xd0, 0x3f, 0x19, 0x8c, 0xdd, 0xef, 0x8d, 0x2f, 0x4b, 0xd9, 0x35, 0x75, 0xf4, 0x0c,
+	0x1e, 0x5f, 0xc5, 0x78, 0x56, 0x98, 0x72, 0xda, 0x5a, 0x34, 0x0c, 0x79, 0xd8, 0xef, 0xb6, 0x06,
+	0xbb, 0x95, 0xd3, 0x0f, 0x90, 0xfc, 0x8b, 0xe8, 0x5b, 0x90, 0x2b, 0x5a, 0x5c, 0x5b, 0xb9, 0x7d,
+	0x3e, 0x2f, 0xd1, 0x72, 0xc9, 0x2d, 0xf7, 0xda, 0x7b, 0xbb, 0xcf, 0x67, 0xbd, 0x8e, 0x3e, 0x81,
+	0xdb, 0x46, 0x37, 0xb5, 0xc0, 0xe0, 0xc4, 0x70, 0x3f, 0x21, 0xa3, 0xfe, 0xc9, 0xc3, 0xa0, 0x33,
+	0xf3, 0x2b, 0x6b, 0x46, 0xb1, 0x0d, 0x72, 0xfa, 0xad, 0x07, 0xf7, 0xff, 0xe2, 0xb8, 0x23, 0x14,
+	0x58, 0xdd, 0xa9, 0xe8, 0xb2, 0x1d, 0x4c, 0xee, 0xdd, 0xb4, 0xc9, 0xe4, 0xff, 0x4c, 0xbe, 0x31,
+	0x1b, 0xd2, 0x2f, 0x11, 0xc0, 0x25, 0xdd, 0xdd, 0x4d, 0xe5, 0x02, 0x3f, 0x41, 0xc2, 0x42, 0x42,
+	0x47, 0x70, 0x4f, 0x69, 0x5d, 0xe6, 0x5c, 0x94, 0x33, 0x14, 0x7a, 0x2e, 0xc3, 0xc4, 0x08, 0xfb,
+	0x13, 0xa6, 0x8f, 0xe0, 0xce, 0x99, 0xae, 0x05, 0x3e, 0xbf, 0x10, 0x88, 0x12, 0x65, 0x77, 0x99,
+	0x37, 0x41, 0x9a, 0x40, 0xdf, 0x03, 0xef, 0x78, 0x61, 0x31, 0xec, 0xfd, 0x90, 0xad, 0x43, 0xd3,
+	0xc1, 0xf7, 0x55, 0x1c, 0xfd, 0x58, 0xc5, 0xd1, 0xcf, 0x55, 0x1c, 0x7d, 0xfe, 0x15, 0xdf, 0xca,
+	0x0f, 0xfc, 0x33, 0x33, 0xf9, 0x3d, 0x00, 0xe7, 0x36, 0x20, 0x8a, 0xa6, 0x04, 0x00, 0x00,
 }
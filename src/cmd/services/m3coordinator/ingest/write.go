@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
@@ -498,9 +499,14 @@ func (d *downsamplerAndWriter) writeAggregatedBatch(
 	defer appender.Finalize()
 
 	for iter.Next() {
-		appender.NextMetric()
-
 		value := iter.Current()
+		if histogram.IsHistogram(value.Annotation) {
+			// NB: native histograms cannot be aggregated, they are only
+			// written to unaggregated namespaces.
+			continue
+		}
+
+		appender.NextMetric()
 		if err := value.Tags.Validate(); err != nil {
 			multiErr = multiErr.Add(err)
 			continue
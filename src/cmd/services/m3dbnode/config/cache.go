@@ -20,7 +20,11 @@
 
 package config
 
-import "github.com/m3db/m3/src/dbnode/storage/series"
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/series"
+)
 
 var (
 	defaultPostingsListCacheSize   = 2 << 15 // ~65k
@@ -89,6 +93,10 @@ type PostingsListCacheConfiguration struct {
 	CacheRegexp *bool `yaml:"cacheRegexp"`
 	CacheTerms  *bool `yaml:"cacheTerms"`
 	CacheSearch *bool `yaml:"cacheSearch"`
+
+	// Warmup persists the cache keys to disk and replays them against the
+	// sealed index segments after a restart to pre-warm the cache.
+	Warmup *PostingsListCacheWarmupConfiguration `yaml:"warmup"`
 }
 
 // SizeOrDefault returns the provided size or the default value is none is
@@ -131,6 +139,31 @@ func (p PostingsListCacheConfiguration) CacheSearchOrDefault() bool {
 	return *p.CacheSearch
 }
 
+// WarmupConfiguration returns the warm up configuration or default if none
+// is specified.
+func (p PostingsListCacheConfiguration) WarmupConfiguration() PostingsListCacheWarmupConfiguration {
+	if p.Warmup == nil {
+		return PostingsListCacheWarmupConfiguration{}
+	}
+	return *p.Warmup
+}
+
+// PostingsListCacheWarmupConfiguration is the postings list cache warm up
+// configuration.
+type PostingsListCacheWarmupConfiguration struct {
+	// Enabled enables persisting and replaying the cache keys.
+	Enabled bool `yaml:"enabled"`
+
+	// PersistInterval is how often the cache keys are persisted.
+	PersistInterval time.Duration `yaml:"persistInterval"`
+
+	// MaxKeys is the maximum number of most recently used keys persisted.
+	MaxKeys int `yaml:"maxKeys"`
+
+	// Budget is the maximum total time spent replaying keys after startup.
+	Budget time.Duration `yaml:"budget"`
+}
+
 // RegexpCacheConfiguration is a compiled regexp cache for query regexps.
 type RegexpCacheConfiguration struct {
 	Size *int `yaml:"size"`
@@ -33,6 +33,10 @@ import (
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/discovery"
 	"github.com/m3db/m3/src/dbnode/environment"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tiering"
+	"github.com/m3db/m3/src/dbnode/replication"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/x/config/hostid"
@@ -164,6 +168,10 @@ type DBConfiguration struct {
 	// The HTTP host and port on which to listen for the node service.
 	HTTPNodeListenAddress *string `yaml:"httpNodeListenAddress"`
 
+	// The gRPC host and port on which to listen for the node service, the
+	// gRPC node service is disabled if not set.
+	GRPCNodeListenAddress *string `yaml:"grpcNodeListenAddress"`
+
 	// The HTTP host and port on which to listen for the cluster service.
 	HTTPClusterListenAddress *string `yaml:"httpClusterListenAddress"`
 
@@ -221,6 +229,10 @@ type DBConfiguration struct {
 	// Proto contains the configuration specific to running in the ProtoDataMode.
 	Proto *ProtoConfiguration `yaml:"proto"`
 
+	// Histogram contains the configuration for storing Prometheus native
+	// histograms.
+	Histogram *HistogramConfiguration `yaml:"histogram"`
+
 	// Tracing configures opentracing. If not provided, tracing is disabled.
 	Tracing *opentracing.TracingConfiguration `yaml:"tracing"`
 
@@ -237,6 +249,18 @@ type DBConfiguration struct {
 	// ForceColdWritesEnabled will force enable cold writes for all namespaces
 	// if set.
 	ForceColdWritesEnabled *bool `yaml:"forceColdWritesEnabled"`
+
+	// Tiering configures tiering of sealed filesets to object storage for
+	// namespaces with tiering enabled.
+	Tiering *tiering.Configuration `yaml:"tiering"`
+
+	// Backup configures where the backup and restore subcommands store
+	// backups of namespaces.
+	Backup *backup.Configuration `yaml:"backup"`
+
+	// CommitLogReplication configures replication of the commitlog to a
+	// standby cluster, or receiving replicated writes on the standby cluster.
+	CommitLogReplication *replication.Configuration `yaml:"commitlogReplication"`
 }
 
 // LoggingOrDefault returns the logging configuration or defaults.
@@ -284,6 +308,16 @@ func (c *DBConfiguration) HTTPNodeListenAddressOrDefault() string {
 	return *c.HTTPNodeListenAddress
 }
 
+// GRPCNodeListenAddressOrDefault returns the listen address or default,
+// which is empty since the gRPC node service is disabled by default.
+func (c *DBConfiguration) GRPCNodeListenAddressOrDefault() string {
+	if c.GRPCNodeListenAddress == nil {
+		return ""
+	}
+
+	return *c.GRPCNodeListenAddress
+}
+
 // HTTPClusterListenAddressOrDefault returns the listen address or default.
 func (c *DBConfiguration) HTTPClusterListenAddressOrDefault() string {
 	if c.HTTPClusterListenAddress == nil {
@@ -389,6 +423,10 @@ func (c *DBConfiguration) Validate() error {
 		return err
 	}
 
+	if c.Histogram != nil && c.Histogram.Enabled && c.Proto != nil && c.Proto.Enabled {
+		return errors.New("histogram and proto encoding cannot both be enabled")
+	}
+
 	if err := c.Transforms.Validate(); err != nil {
 		return err
 	}
@@ -543,6 +581,11 @@ type CommitLogPolicy struct {
 	// works in most cases because the default size of the QueueChannel should be large
 	// enough for almost all workloads assuming a reasonable batch size is used.
 	QueueChannel *CommitLogQueuePolicy `yaml:"queueChannel"`
+
+	// The compression applied to each chunk written to commit log files, one of
+	// none, snappy or zstd. Files written with any compression can be read
+	// regardless of this setting.
+	Compression commitlog.ChunkCompression `yaml:"compression"`
 }
 
 // CalculationType is a type of configuration parameter.
@@ -596,6 +639,10 @@ type RepairPolicy struct {
 	// If enabled, what percentage of metadata should perform a detailed debug
 	// shadow comparison.
 	DebugShadowComparisonsPercentage float64 `yaml:"debugShadowComparisonsPercentage"`
+
+	// MerkleTreeDepth if set compares merkle trees of this depth with peers
+	// and only compares the metadata of series in the leaves that differ.
+	MerkleTreeDepth int `yaml:"merkleTreeDepth"`
 }
 
 // ReplicationPolicy is the replication policy.
@@ -691,6 +738,17 @@ func (c *ProtoConfiguration) Validate() error {
 	return nil
 }
 
+// HistogramConfiguration is the configuration for the native histogram
+// encoding scheme.
+type HistogramConfiguration struct {
+	// Enabled specifies whether series are encoded with the histogram
+	// encoding scheme, which stores both float datapoints and native histograms.
+	// The encoding is detected per block when reading, so enabling it on a node
+	// with existing M3TSZ data is safe. Disabling it once histogram blocks have
+	// been written makes those blocks unreadable until they expire.
+	Enabled bool `yaml:"enabled"`
+}
+
 // NewEtcdEmbedConfig creates a new embedded etcd config from kv config.
 func NewEtcdEmbedConfig(cfg DBConfiguration) (*embed.Config, error) {
 	newKVCfg := embed.NewConfig()
@@ -366,6 +366,7 @@ func TestConfiguration(t *testing.T) {
   listenAddress: 0.0.0.0:9000
   clusterListenAddress: 0.0.0.0:9001
   httpNodeListenAddress: 0.0.0.0:9002
+  grpcNodeListenAddress: null
   httpClusterListenAddress: 0.0.0.0:9003
   debugListenAddress: 0.0.0.0:9004
   hostID:
@@ -402,6 +403,7 @@ func TestConfiguration(t *testing.T) {
     hashing:
       seed: 42
     proto: null
+    histogram: null
     asyncWriteWorkerPoolSize: null
     asyncWriteMaxConcurrency: null
     useV2BatchAPIs: null
@@ -411,6 +413,10 @@ func TestConfiguration(t *testing.T) {
     writeShardsInitializing: null
     shardsLeavingCountTowardsConsistency: null
     iterateEqualTimestampStrategy: null
+    hedgedReads: null
+    writeOverloadBackoff: null
+    transport: null
+    grpcTransport: null
   gcPercentage: 100
   tick: null
   bootstrap:
@@ -432,6 +438,7 @@ func TestConfiguration(t *testing.T) {
       cacheRegexp: false
       cacheTerms: false
       cacheSearch: null
+      warmup: null
     regexp: null
   filesystem:
     filePathPrefix: /var/lib/m3db
@@ -454,6 +461,7 @@ func TestConfiguration(t *testing.T) {
       calculationType: fixed
       size: 2097152
     queueChannel: null
+    compression: none
   repair:
     enabled: false
     type: default
@@ -464,6 +472,7 @@ func TestConfiguration(t *testing.T) {
     concurrency: 0
     debugShadowComparisonsEnabled: false
     debugShadowComparisonsPercentage: 0
+    merkleTreeDepth: 0
   replication: null
   pooling:
     blockAllocSize: 16
@@ -695,6 +704,7 @@ func TestConfiguration(t *testing.T) {
   writeNewSeriesAsync: true
   writeNewSeriesBackoffDuration: 2ms
   proto: null
+  histogram: null
   tracing:
     serviceName: ""
     backend: jaeger
@@ -753,11 +763,16 @@ func TestConfiguration(t *testing.T) {
     maxOutstandingRepairedBytes: 0
     maxEncodersPerBlock: 0
     writeNewSeriesPerSecond: 0
+    sourceLimits: []
+    writeAdmission: null
   tchannel: null
   debug:
     mutexProfileFraction: 0
     blockProfileRate: 0
   forceColdWritesEnabled: null
+  tiering: null
+  backup: null
+  commitlogReplication: null
 coordinator: null
 `
 
@@ -20,7 +20,12 @@
 
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/limits/admission"
+	"github.com/m3db/m3/src/x/instrument"
+)
 
 // LimitsConfiguration contains configuration for configurable limits that can be applied to M3DB.
 type LimitsConfiguration struct {
@@ -72,6 +77,37 @@ type LimitsConfiguration struct {
 
 	// Write new series limit per second to limit overwhelming during new ID bursts.
 	WriteNewSeriesPerSecond int `yaml:"writeNewSeriesPerSecond" validate:"min=0"`
+
+	// SourceLimits sets limits on the resources consumed by the queries from a single
+	// source, as sent by the client with each fetch. A source is charged against its
+	// own limit in addition to the global limit of the same name, so that a noisy
+	// tenant is bounded by a budget of its own before exhausting the global limits.
+	SourceLimits []SourceLimitsConfiguration `yaml:"sourceLimits"`
+
+	// WriteAdmission configures admission control that delays and then rejects
+	// writes with a retryable overloaded error as the node falls behind, before
+	// the commitlog queue fills and writes fail in a burst.
+	WriteAdmission *WriteAdmissionConfiguration `yaml:"writeAdmission"`
+}
+
+// SourceLimitsConfiguration sets upper limits on resources consumed by the queries from
+// a single source within a dbnode per some lookback period of time.
+type SourceLimitsConfiguration struct {
+	// Source is the source of the queries, matched exactly against the source of
+	// each fetch.
+	Source string `yaml:"source" validate:"nonzero"`
+
+	// MaxRecentlyQueriedSeriesBlocks sets the upper limit on time series blocks count
+	// of the source within a given lookback period.
+	MaxRecentlyQueriedSeriesBlocks *MaxRecentQueryResourceLimitConfiguration `yaml:"maxRecentlyQueriedSeriesBlocks"`
+
+	// MaxRecentlyQueriedSeriesDiskBytesRead sets the upper limit on time series bytes
+	// read from disk by the source within a given lookback period.
+	MaxRecentlyQueriedSeriesDiskBytesRead *MaxRecentQueryResourceLimitConfiguration `yaml:"maxRecentlyQueriedSeriesDiskBytesRead"`
+
+	// MaxRecentlyQueriedMetadata sets the upper limit on metadata counts of the source
+	// within a given lookback period.
+	MaxRecentlyQueriedMetadata *MaxRecentQueryResourceLimitConfiguration `yaml:"maxRecentlyQueriedMetadata"`
 }
 
 // MaxRecentQueryResourceLimitConfiguration sets an upper limit on resources consumed by all queries
@@ -83,3 +119,59 @@ type MaxRecentQueryResourceLimitConfiguration struct {
 	// Lookback is the period in which a given resource limit is enforced.
 	Lookback time.Duration `yaml:"lookback" validate:"min=0"`
 }
+
+// WriteAdmissionConfiguration configures write admission control. Each signal
+// reports pressure as a fraction of its capacity and the highest pressure
+// decides whether writes are admitted, delayed or rejected.
+type WriteAdmissionConfiguration struct {
+	// Enabled enables write admission control.
+	Enabled bool `yaml:"enabled"`
+
+	// DelayThreshold is the pressure at which writes start being delayed.
+	DelayThreshold *float64 `yaml:"delayThreshold"`
+
+	// RejectThreshold is the pressure at which writes are rejected.
+	RejectThreshold *float64 `yaml:"rejectThreshold"`
+
+	// MaxDelay is the delay applied to writes just below the reject threshold,
+	// writes are delayed proportionally between the delay and reject thresholds.
+	MaxDelay *time.Duration `yaml:"maxDelay"`
+
+	// HeapLimitBytes is the heap size considered at capacity, if not set
+	// then heap usage is not considered.
+	HeapLimitBytes uint64 `yaml:"heapLimitBytes"`
+
+	// HeapSampleInterval is how often heap usage is sampled.
+	HeapSampleInterval *time.Duration `yaml:"heapSampleInterval"`
+
+	// FlushBacklogLimit is the number of block starts pending a warm flush,
+	// summed across namespaces, that is considered at capacity, zero disables
+	// considering the flush backlog.
+	FlushBacklogLimit *int `yaml:"flushBacklogLimit"`
+}
+
+// NewOptions returns the write admission options for the configuration.
+func (c WriteAdmissionConfiguration) NewOptions(
+	iOpts instrument.Options,
+) admission.Options {
+	opts := admission.NewOptions().
+		SetEnabled(c.Enabled).
+		SetHeapLimitBytes(c.HeapLimitBytes).
+		SetInstrumentOptions(iOpts)
+	if v := c.DelayThreshold; v != nil {
+		opts = opts.SetDelayThreshold(*v)
+	}
+	if v := c.RejectThreshold; v != nil {
+		opts = opts.SetRejectThreshold(*v)
+	}
+	if v := c.MaxDelay; v != nil {
+		opts = opts.SetMaxDelay(*v)
+	}
+	if v := c.HeapSampleInterval; v != nil {
+		opts = opts.SetHeapSampleInterval(*v)
+	}
+	if v := c.FlushBacklogLimit; v != nil {
+		opts = opts.SetFlushBacklogLimit(*v)
+	}
+	return opts
+}
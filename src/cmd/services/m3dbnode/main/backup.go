@@ -0,0 +1,178 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"sort"
+
+	aggsharding "github.com/m3db/m3/src/aggregator/sharding"
+	"github.com/m3db/m3/src/cmd/services/m3dbnode/config"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tiering"
+	xconfig "github.com/m3db/m3/src/x/config"
+	"github.com/m3db/m3/src/x/config/configflag"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"go.uber.org/zap"
+)
+
+const (
+	backupCommand  = "backup"
+	restoreCommand = "restore"
+)
+
+var errBackupNotConfigured = errors.New("db.backup must be configured to back up or restore")
+
+// runCommand runs the subcommand named by the first argument, returning
+// false if there is no such subcommand.
+func runCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case backupCommand:
+		runBackup(args[1:])
+	case restoreCommand:
+		runRestore(args[1:])
+	default:
+		return false
+	}
+	return true
+}
+
+func runBackup(args []string) {
+	var (
+		cmd       = flag.NewFlagSet(backupCommand, flag.ExitOnError)
+		cfgOpts   configflag.Options
+		namespace = cmd.String("namespace", "", "Namespace to back up")
+		numShards = cmd.Uint("num-shards", 0, "Number of shards of the placement")
+	)
+	cfgOpts.RegisterFlagSet(cmd)
+	_ = cmd.Parse(args)
+	if *namespace == "" || *numShards == 0 {
+		cmd.Usage()
+		os.Exit(1)
+	}
+
+	mgr, logger := newBackupManager(cfgOpts)
+	manifest, err := mgr.Backup(ident.StringID(*namespace), uint32(*numShards))
+	if err != nil {
+		logger.Fatal("could not back up namespace", zap.Error(err))
+	}
+	logger.Info("backed up namespace",
+		zap.String("namespace", *namespace),
+		zap.String("backupID", manifest.ID))
+}
+
+func runRestore(args []string) {
+	var (
+		cmd       = flag.NewFlagSet(restoreCommand, flag.ExitOnError)
+		cfgOpts   configflag.Options
+		namespace = cmd.String("namespace", "", "Namespace to restore")
+		backupID  = cmd.String("backup-id", "", "Backup to restore, defaults to the latest backup")
+		numShards = cmd.Uint("num-shards", 0,
+			"Number of shards of the target placement, defaults to that of the backup")
+		shards = cmd.String("shards", "",
+			"Shards owned by this node in the target placement [e.g. 0..63], defaults to all shards")
+	)
+	cfgOpts.RegisterFlagSet(cmd)
+	_ = cmd.Parse(args)
+	if *namespace == "" {
+		cmd.Usage()
+		os.Exit(1)
+	}
+
+	var ownedShards []uint32
+	if *shards != "" {
+		shardSet, err := aggsharding.ParseShardSet(*shards)
+		if err != nil {
+			log.Fatalf("could not parse shards: %v", err)
+		}
+		for shard := range shardSet {
+			ownedShards = append(ownedShards, shard)
+		}
+		sort.Slice(ownedShards, func(i, j int) bool {
+			return ownedShards[i] < ownedShards[j]
+		})
+	}
+
+	// NB: restores must be run while the node is stopped as they write
+	// filesets and commit logs that are read when bootstrapping.
+	mgr, logger := newBackupManager(cfgOpts)
+	manifest, err := mgr.Restore(ident.StringID(*namespace), backup.RestoreOptions{
+		BackupID:  *backupID,
+		NumShards: uint32(*numShards),
+		Shards:    ownedShards,
+	})
+	if err != nil {
+		logger.Fatal("could not restore namespace", zap.Error(err))
+	}
+	logger.Info("restored namespace",
+		zap.String("namespace", *namespace),
+		zap.String("backupID", manifest.ID))
+}
+
+func newBackupManager(cfgOpts configflag.Options) (backup.Manager, *zap.Logger) {
+	var cfg config.Configuration
+	if err := cfgOpts.MainLoad(&cfg, xconfig.Options{}); err != nil {
+		log.Fatalf("error loading config: %v", err)
+	}
+	if cfg.DB == nil || cfg.DB.Backup == nil {
+		log.Fatal(errBackupNotConfigured)
+	}
+
+	logger, err := cfg.DB.LoggingOrDefault().BuildLogger()
+	if err != nil {
+		log.Fatalf("unable to create logger: %v", err)
+	}
+
+	var (
+		iOpts  = instrument.NewOptions().SetLogger(logger)
+		fsOpts = fs.NewOptions().
+			SetFilePathPrefix(cfg.DB.Filesystem.FilePathPrefixOrDefault()).
+			SetInstrumentOptions(iOpts)
+	)
+	var tieredStore tiering.ObjectStore
+	if cfg.DB.Tiering != nil {
+		// Tiered filesets are copied straight from their object store so
+		// that backups never write to the data directory of a running node.
+		tieredStore, err = cfg.DB.Tiering.ObjectStore.NewObjectStore()
+		if err != nil {
+			logger.Fatal("could not create tiered object store", zap.Error(err))
+		}
+	}
+
+	commitLogOpts := commitlog.NewOptions().
+		SetFilesystemOptions(fsOpts).
+		SetInstrumentOptions(iOpts)
+	mgr, err := cfg.DB.Backup.NewManager(fsOpts, commitLogOpts, tieredStore, iOpts)
+	if err != nil {
+		logger.Fatal("could not create backup manager", zap.Error(err))
+	}
+	return mgr, logger
+}
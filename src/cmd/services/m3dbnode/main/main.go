@@ -23,6 +23,7 @@ package main
 import (
 	"flag"
 	"log"
+	"os"
 
 	"github.com/m3db/m3/src/cmd/services/m3dbnode/config"
 	"github.com/m3db/m3/src/cmd/services/m3dbnode/server"
@@ -32,6 +33,10 @@ import (
 )
 
 func main() {
+	if runCommand(os.Args[1:]) {
+		return
+	}
+
 	var cfgOpts configflag.Options
 	cfgOpts.Register()
 
@@ -32,8 +32,11 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/server/m3msg"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/resultscache"
 	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/querylog"
+	"github.com/m3db/m3/src/query/ruler"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
@@ -182,6 +185,10 @@ type Configuration struct {
 	// Carbon is the carbon configuration.
 	Carbon *CarbonConfiguration `yaml:"carbon"`
 
+	// Ruler is the Prometheus recording and alerting rule evaluator
+	// configuration, rules are only evaluated if set.
+	Ruler *ruler.Configuration `yaml:"ruler"`
+
 	// Middleware is middleware-specific configuration.
 	Middleware MiddlewareConfiguration `yaml:"middleware"`
 
@@ -315,6 +322,22 @@ type QueryConfiguration struct {
 	// RequireSeriesEndpointStartEndTime requires requests to /series endpoint
 	// to specify a start and end time to prevent unbounded queries.
 	RequireSeriesEndpointStartEndTime bool `yaml:"requireSeriesEndpointStartEndTime"`
+	// FetchPageSize is the number of series fetched at a time from each
+	// database node, pages are consolidated as they are received rather than
+	// fetching all series matched by a query in a single response. Zero, the
+	// default, disables paging.
+	FetchPageSize int `yaml:"fetchPageSize"`
+	// FetchPagesMaxBytes is the max estimated bytes of the pages fetched for
+	// a query from a namespace, since every page is held until the query
+	// completes. Paging stops once it is exceeded and the result is marked
+	// as not exhaustive with a max_fetch_bytes_limit_applied warning.
+	// Defaults to 1GiB, zero is unlimited.
+	FetchPagesMaxBytes *int `yaml:"fetchPagesMaxBytes"`
+	// ResultsCache configures caching the results of range queries so that
+	// only the parts of queries that are not cached are executed.
+	ResultsCache resultscache.Configuration `yaml:"resultsCache"`
+	// Log configures the structured log of slow and sampled queries.
+	Log *querylog.Configuration `yaml:"log"`
 }
 
 // TimeoutOrDefault returns the configured timeout or default value.
@@ -0,0 +1,176 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"errors"
+	golog "log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/export"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/pborman/getopt"
+	"github.com/prometheus/prometheus/model/labels"
+	"go.uber.org/zap"
+)
+
+func main() {
+	var (
+		optPathPrefix = getopt.StringLong("path-prefix", 'p', "/var/lib/m3db", "Path prefix [e.g. /var/lib/m3db]")
+		optNamespace  = getopt.StringLong("namespace", 'n', "", "Namespace to export")
+		optOutputDir  = getopt.StringLong("output", 'o', "", "Output directory for the Parquet files")
+		optShards     = getopt.StringLong("shards", 's', "",
+			"Comma separated shards to export (optional), all shards on disk are exported if not set")
+		optStart = getopt.Int64Long("start", 'b', 0, "Export blocks starting at or after [in nsec] (optional)")
+		optEnd   = getopt.Int64Long("end", 'e', 0, "Export blocks starting before [in nsec] (optional)")
+		optQuery = getopt.StringLong("query", 'q', "",
+			"Query to match the time series to export (optional), as a PromQL selector")
+	)
+	getopt.Parse()
+
+	logConfig := zap.NewDevelopmentConfig()
+	log, err := logConfig.Build()
+	if err != nil {
+		golog.Fatalf("unable to create logger: %+v", err)
+	}
+
+	if *optPathPrefix == "" || *optNamespace == "" || *optOutputDir == "" {
+		getopt.Usage()
+		os.Exit(1)
+	}
+
+	shards, err := parseShards(*optShards)
+	if err != nil {
+		log.Fatal("could not parse shards", zap.Error(err))
+	}
+
+	var filter idx.Query
+	if *optQuery != "" {
+		filter, err = parseQuery(*optQuery)
+		if err != nil {
+			log.Fatal("could not parse query", zap.Error(err))
+		}
+	}
+
+	log.Info("starting export",
+		zap.String("namespace", *optNamespace),
+		zap.String("output", *optOutputDir),
+		zap.Uint32s("shards", shards),
+		zap.String("query", *optQuery))
+
+	start := time.Now()
+	result, err := export.Export(export.Options{
+		FilesystemOptions: fs.NewOptions().SetFilePathPrefix(*optPathPrefix),
+		Namespace:         ident.StringID(*optNamespace),
+		Shards:            shards,
+		Start:             xtime.UnixNano(*optStart),
+		End:               xtime.UnixNano(*optEnd),
+		Filter:            filter,
+		OutputDir:         *optOutputDir,
+	})
+	if err != nil {
+		log.Fatal("export failed", zap.Error(err))
+	}
+
+	log.Info("export complete",
+		zap.Int("files", result.Files),
+		zap.Int("series", result.Series),
+		zap.Int("datapoints", result.Datapoints),
+		zap.Duration("took", time.Since(start)))
+}
+
+func parseShards(value string) ([]uint32, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var shards []uint32
+	for _, s := range strings.Split(value, ",") {
+		shard, err := strconv.ParseUint(strings.TrimSpace(s), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		shards = append(shards, uint32(shard))
+	}
+	return shards, nil
+}
+
+func parseQuery(query string) (idx.Query, error) {
+	parse := promql.NewParseOptions().MetricSelectorFn()
+	matchers, err := parse(query)
+	if err != nil {
+		return idx.Query{}, err
+	}
+
+	labelMatchers, err := toLabelMatchers(matchers)
+	if err != nil {
+		return idx.Query{}, err
+	}
+
+	fetchQuery, err := storage.PromReadQueryToM3(&prompb.Query{
+		Matchers:         labelMatchers,
+		StartTimestampMs: 0,
+		EndTimestampMs:   time.Now().UnixNano() / int64(time.Millisecond),
+	})
+	if err != nil {
+		return idx.Query{}, err
+	}
+
+	indexQuery, err := storage.FetchQueryToM3Query(fetchQuery, storage.NewFetchOptions())
+	if err != nil {
+		return idx.Query{}, err
+	}
+	return indexQuery.Query, nil
+}
+
+func toLabelMatchers(matchers []*labels.Matcher) ([]*prompb.LabelMatcher, error) {
+	pbMatchers := make([]*prompb.LabelMatcher, 0, len(matchers))
+	for _, m := range matchers {
+		var mType prompb.LabelMatcher_Type
+		switch m.Type {
+		case labels.MatchEqual:
+			mType = prompb.LabelMatcher_EQ
+		case labels.MatchNotEqual:
+			mType = prompb.LabelMatcher_NEQ
+		case labels.MatchRegexp:
+			mType = prompb.LabelMatcher_RE
+		case labels.MatchNotRegexp:
+			mType = prompb.LabelMatcher_NRE
+		default:
+			return nil, errors.New("invalid matcher type")
+		}
+		pbMatchers = append(pbMatchers, &prompb.LabelMatcher{
+			Type:  mType,
+			Name:  []byte(m.Name),
+			Value: []byte(m.Value),
+		})
+	}
+	return pbMatchers, nil
+}
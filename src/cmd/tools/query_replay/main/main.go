@@ -0,0 +1,108 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// query_replay is a tool for load testing a coordinator by replaying the
+// queries of a query log.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/m3db/m3/src/query/querylog"
+)
+
+func main() {
+	var (
+		file        = flag.String("file", "", "Query log file to replay")
+		target      = flag.String("url", "http://localhost:7201", "Base URL of the coordinator")
+		concurrency = flag.Int("concurrency", 10, "Number of queries issued concurrently")
+		qps         = flag.Float64("qps", 0, "Target QPS, unlimited if zero")
+		shiftToNow  = flag.Bool("shift-to-now", true, "Shift query ranges to end at the time they are replayed")
+		timeout     = flag.Duration("timeout", time.Minute, "Timeout of each query")
+	)
+
+	flag.Parse()
+	if len(*file) == 0 || len(*target) == 0 {
+		flag.Usage()
+		os.Exit(-1)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not open query log: %v\n", err)
+		os.Exit(1)
+	}
+	entries, err := querylog.ReadEntries(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not read query log: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(),
+		os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	fmt.Printf("replaying %d queries against %s\n", len(entries), *target)
+	start := time.Now()
+	result, err := querylog.Replay(ctx, entries, querylog.ReplayOptions{
+		URL:         *target,
+		Client:      &http.Client{Timeout: *timeout},
+		Concurrency: *concurrency,
+		QPS:         *qps,
+		ShiftToNow:  *shiftToNow,
+	})
+	if err != nil {
+		fmt.Printf("replay stopped early: %v\n", err)
+	}
+
+	elapsed := time.Since(start)
+	fmt.Printf("queries: %d, errors: %d, duration: %v, average QPS: %.2f\n",
+		result.Queries, result.Errors, elapsed,
+		float64(result.Queries)/elapsed.Seconds())
+
+	latencies := result.Latencies
+	if len(latencies) == 0 {
+		return
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	fmt.Printf("latency p50: %v, p90: %v, p99: %v, max: %v\n",
+		percentile(latencies, 0.5), percentile(latencies, 0.9),
+		percentile(latencies, 0.99), latencies[len(latencies)-1])
+}
+
+// percentile returns the percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	idx := int(p * float64(len(sorted)))
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"sync"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+)
+
+type cardinalityOp struct {
+	request      rpc.CardinalityRequest
+	completionFn completionFn
+}
+
+func (c *cardinalityOp) Size() int {
+	// Cardinality is always a single op
+	return 1
+}
+
+func (c *cardinalityOp) CompletionFn() completionFn {
+	return c.completionFn
+}
+
+// cardinalityAccumulator merges the cardinality statistics returned by
+// every host. Hosts only count the series of shards available on them, so
+// each series is counted by at most every replica of its shard and series
+// counts are summed across hosts and divided by the number of replicas,
+// while the number of distinct values of a label is the maximum seen by any
+// host. The series of a shard being moved are counted by one replica less,
+// values of a label indexed on different hosts are not merged, and hosts
+// only return their top entries, so the merged statistics are lower bounds
+// of the cardinality.
+type cardinalityAccumulator struct {
+	sync.Mutex
+
+	seriesCountByMetricName     map[string]int64
+	labelValueCountByLabelName  map[string]int64
+	seriesCountByLabelValuePair map[string]int64
+	exhaustive                  bool
+}
+
+func newCardinalityAccumulator() *cardinalityAccumulator {
+	return &cardinalityAccumulator{
+		seriesCountByMetricName:     make(map[string]int64),
+		labelValueCountByLabelName:  make(map[string]int64),
+		seriesCountByLabelValuePair: make(map[string]int64),
+		exhaustive:                  true,
+	}
+}
+
+func (a *cardinalityAccumulator) add(res *rpc.CardinalityResult_) {
+	result := convert.FromRPCCardinalityResult(res)
+
+	a.Lock()
+	defer a.Unlock()
+
+	for _, e := range result.Stats.SeriesCountByMetricName {
+		a.seriesCountByMetricName[e.Name] += e.Value
+	}
+	for _, e := range result.Stats.LabelValueCountByLabelName {
+		if e.Value > a.labelValueCountByLabelName[e.Name] {
+			a.labelValueCountByLabelName[e.Name] = e.Value
+		}
+	}
+	for _, e := range result.Stats.SeriesCountByLabelValuePair {
+		a.seriesCountByLabelValuePair[e.Name] += e.Value
+	}
+	a.exhaustive = a.exhaustive && result.Exhaustive
+}
+
+func (a *cardinalityAccumulator) result(replicas, topN int) index.CardinalityQueryResult {
+	a.Lock()
+	defer a.Unlock()
+
+	if replicas < 1 {
+		replicas = 1
+	}
+	return index.CardinalityQueryResult{
+		Stats: index.CardinalityStats{
+			SeriesCountByMetricName: cardinalityEntries(a.seriesCountByMetricName,
+				int64(replicas), topN),
+			LabelValueCountByLabelName: cardinalityEntries(a.labelValueCountByLabelName,
+				1, topN),
+			SeriesCountByLabelValuePair: cardinalityEntries(a.seriesCountByLabelValuePair,
+				int64(replicas), topN),
+		},
+		Exhaustive: a.exhaustive,
+	}
+}
+
+func cardinalityEntries(values map[string]int64, divisor int64, topN int) []index.CardinalityEntry {
+	entries := make([]index.CardinalityEntry, 0, len(values))
+	for name, value := range values {
+		entries = append(entries, index.CardinalityEntry{
+			Name:  name,
+			Value: (value + divisor - 1) / divisor,
+		})
+	}
+	return index.TopCardinalityEntries(entries, topN)
+}
@@ -263,6 +263,21 @@ func (mr *MockSessionMockRecorder) FetchTaggedIDs(ctx, namespace, q, opts interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTaggedIDs", reflect.TypeOf((*MockSession)(nil).FetchTaggedIDs), ctx, namespace, q, opts)
 }
 
+// FetchTaggedPages mocks base method.
+func (m *MockSession) FetchTaggedPages(ctx context.Context, namespace ident.ID, q index.Query, opts index.QueryOptions) (FetchTaggedPagesIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTaggedPages", ctx, namespace, q, opts)
+	ret0, _ := ret[0].(FetchTaggedPagesIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTaggedPages indicates an expected call of FetchTaggedPages.
+func (mr *MockSessionMockRecorder) FetchTaggedPages(ctx, namespace, q, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTaggedPages", reflect.TypeOf((*MockSession)(nil).FetchTaggedPages), ctx, namespace, q, opts)
+}
+
 // IteratorPools mocks base method.
 func (m *MockSession) IteratorPools() (encoding.IteratorPools, error) {
 	m.ctrl.T.Helper()
@@ -351,6 +366,72 @@ func (mr *MockSessionMockRecorder) WriteTagged(namespace, id, tags, t, value, un
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteTagged", reflect.TypeOf((*MockSession)(nil).WriteTagged), namespace, id, tags, t, value, unit, annotation)
 }
 
+// MockFetchTaggedPagesIterator is a mock of FetchTaggedPagesIterator interface.
+type MockFetchTaggedPagesIterator struct {
+	ctrl     *gomock.Controller
+	recorder *MockFetchTaggedPagesIteratorMockRecorder
+}
+
+// MockFetchTaggedPagesIteratorMockRecorder is the mock recorder for MockFetchTaggedPagesIterator.
+type MockFetchTaggedPagesIteratorMockRecorder struct {
+	mock *MockFetchTaggedPagesIterator
+}
+
+// NewMockFetchTaggedPagesIterator creates a new mock instance.
+func NewMockFetchTaggedPagesIterator(ctrl *gomock.Controller) *MockFetchTaggedPagesIterator {
+	mock := &MockFetchTaggedPagesIterator{ctrl: ctrl}
+	mock.recorder = &MockFetchTaggedPagesIteratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFetchTaggedPagesIterator) EXPECT() *MockFetchTaggedPagesIteratorMockRecorder {
+	return m.recorder
+}
+
+// Current mocks base method.
+func (m *MockFetchTaggedPagesIterator) Current() (encoding.SeriesIterators, FetchResponseMetadata) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Current")
+	ret0, _ := ret[0].(encoding.SeriesIterators)
+	ret1, _ := ret[1].(FetchResponseMetadata)
+	return ret0, ret1
+}
+
+// Current indicates an expected call of Current.
+func (mr *MockFetchTaggedPagesIteratorMockRecorder) Current() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Current", reflect.TypeOf((*MockFetchTaggedPagesIterator)(nil).Current))
+}
+
+// Err mocks base method.
+func (m *MockFetchTaggedPagesIterator) Err() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Err")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Err indicates an expected call of Err.
+func (mr *MockFetchTaggedPagesIteratorMockRecorder) Err() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Err", reflect.TypeOf((*MockFetchTaggedPagesIterator)(nil).Err))
+}
+
+// Next mocks base method.
+func (m *MockFetchTaggedPagesIterator) Next() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockFetchTaggedPagesIteratorMockRecorder) Next() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockFetchTaggedPagesIterator)(nil).Next))
+}
+
 // MockAggregatedTagsIterator is a mock of AggregatedTagsIterator interface.
 type MockAggregatedTagsIterator struct {
 	ctrl     *gomock.Controller
@@ -850,6 +931,21 @@ func (mr *MockAdminSessionMockRecorder) BorrowConnections(shardID, fn, opts inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BorrowConnections", reflect.TypeOf((*MockAdminSession)(nil).BorrowConnections), shardID, fn, opts)
 }
 
+// Cardinality mocks base method.
+func (m *MockAdminSession) Cardinality(namespace ident.ID, q index.Query, opts index.CardinalityOptions) (index.CardinalityQueryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", namespace, q, opts)
+	ret0, _ := ret[0].(index.CardinalityQueryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockAdminSessionMockRecorder) Cardinality(namespace, q, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockAdminSession)(nil).Cardinality), namespace, q, opts)
+}
+
 // Close mocks base method.
 func (m *MockAdminSession) Close() error {
 	m.ctrl.T.Helper()
@@ -880,6 +976,21 @@ func (mr *MockAdminSessionMockRecorder) DedicatedConnection(shardID, opts interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DedicatedConnection", reflect.TypeOf((*MockAdminSession)(nil).DedicatedConnection), shardID, opts)
 }
 
+// DeleteTagged mocks base method.
+func (m *MockAdminSession) DeleteTagged(namespace ident.ID, q index.Query, start, end time0.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", namespace, q, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged.
+func (mr *MockAdminSessionMockRecorder) DeleteTagged(namespace, q, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*MockAdminSession)(nil).DeleteTagged), namespace, q, start, end)
+}
+
 // Fetch mocks base method.
 func (m *MockAdminSession) Fetch(namespace, id ident.ID, startInclusive, endExclusive time0.UnixNano) (encoding.SeriesIterator, error) {
 	m.ctrl.T.Helper()
@@ -970,6 +1081,36 @@ func (mr *MockAdminSessionMockRecorder) FetchIDs(namespace, ids, startInclusive,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchIDs", reflect.TypeOf((*MockAdminSession)(nil).FetchIDs), namespace, ids, startInclusive, endExclusive)
 }
 
+// FetchMerkleLeavesFromPeer mocks base method.
+func (m *MockAdminSession) FetchMerkleLeavesFromPeer(peer topology.Host, namespace ident.ID, shard uint32, blockStart time0.UnixNano, depth int, leaves []int) (PeerBlockMetadataIter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMerkleLeavesFromPeer", peer, namespace, shard, blockStart, depth, leaves)
+	ret0, _ := ret[0].(PeerBlockMetadataIter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMerkleLeavesFromPeer indicates an expected call of FetchMerkleLeavesFromPeer.
+func (mr *MockAdminSessionMockRecorder) FetchMerkleLeavesFromPeer(peer, namespace, shard, blockStart, depth, leaves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMerkleLeavesFromPeer", reflect.TypeOf((*MockAdminSession)(nil).FetchMerkleLeavesFromPeer), peer, namespace, shard, blockStart, depth, leaves)
+}
+
+// FetchMerkleNodesFromPeer mocks base method.
+func (m *MockAdminSession) FetchMerkleNodesFromPeer(peer topology.Host, namespace ident.ID, shard uint32, blockStart time0.UnixNano, depth int, nodes []int) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMerkleNodesFromPeer", peer, namespace, shard, blockStart, depth, nodes)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMerkleNodesFromPeer indicates an expected call of FetchMerkleNodesFromPeer.
+func (mr *MockAdminSessionMockRecorder) FetchMerkleNodesFromPeer(peer, namespace, shard, blockStart, depth, nodes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMerkleNodesFromPeer", reflect.TypeOf((*MockAdminSession)(nil).FetchMerkleNodesFromPeer), peer, namespace, shard, blockStart, depth, nodes)
+}
+
 // FetchTagged mocks base method.
 func (m *MockAdminSession) FetchTagged(ctx context.Context, namespace ident.ID, q index.Query, opts index.QueryOptions) (encoding.SeriesIterators, FetchResponseMetadata, error) {
 	m.ctrl.T.Helper()
@@ -1002,6 +1143,21 @@ func (mr *MockAdminSessionMockRecorder) FetchTaggedIDs(ctx, namespace, q, opts i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTaggedIDs", reflect.TypeOf((*MockAdminSession)(nil).FetchTaggedIDs), ctx, namespace, q, opts)
 }
 
+// FetchTaggedPages mocks base method.
+func (m *MockAdminSession) FetchTaggedPages(ctx context.Context, namespace ident.ID, q index.Query, opts index.QueryOptions) (FetchTaggedPagesIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTaggedPages", ctx, namespace, q, opts)
+	ret0, _ := ret[0].(FetchTaggedPagesIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTaggedPages indicates an expected call of FetchTaggedPages.
+func (mr *MockAdminSessionMockRecorder) FetchTaggedPages(ctx, namespace, q, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTaggedPages", reflect.TypeOf((*MockAdminSession)(nil).FetchTaggedPages), ctx, namespace, q, opts)
+}
+
 // IteratorPools mocks base method.
 func (m *MockAdminSession) IteratorPools() (encoding.IteratorPools, error) {
 	m.ctrl.T.Helper()
@@ -1451,6 +1607,20 @@ func (mr *MockOptionsMockRecorder) FetchRetrier() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRetrier", reflect.TypeOf((*MockOptions)(nil).FetchRetrier))
 }
 
+// HedgedReadOptions mocks base method.
+func (m *MockOptions) HedgedReadOptions() HedgedReadOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HedgedReadOptions")
+	ret0, _ := ret[0].(HedgedReadOptions)
+	return ret0
+}
+
+// HedgedReadOptions indicates an expected call of HedgedReadOptions.
+func (mr *MockOptionsMockRecorder) HedgedReadOptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HedgedReadOptions", reflect.TypeOf((*MockOptions)(nil).HedgedReadOptions))
+}
+
 // HostConnectTimeout mocks base method.
 func (m *MockOptions) HostConnectTimeout() time.Duration {
 	m.ctrl.T.Helper()
@@ -2039,6 +2209,20 @@ func (mr *MockOptionsMockRecorder) SetFetchRetrier(value interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFetchRetrier", reflect.TypeOf((*MockOptions)(nil).SetFetchRetrier), value)
 }
 
+// SetHedgedReadOptions mocks base method.
+func (m *MockOptions) SetHedgedReadOptions(value HedgedReadOptions) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHedgedReadOptions", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetHedgedReadOptions indicates an expected call of SetHedgedReadOptions.
+func (mr *MockOptionsMockRecorder) SetHedgedReadOptions(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHedgedReadOptions", reflect.TypeOf((*MockOptions)(nil).SetHedgedReadOptions), value)
+}
+
 // SetHostConnectTimeout mocks base method.
 func (m *MockOptions) SetHostConnectTimeout(value time.Duration) Options {
 	m.ctrl.T.Helper()
@@ -2473,6 +2657,20 @@ func (mr *MockOptionsMockRecorder) SetWriteOpPoolSize(value interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWriteOpPoolSize", reflect.TypeOf((*MockOptions)(nil).SetWriteOpPoolSize), value)
 }
 
+// SetWriteOverloadBackoffOptions mocks base method.
+func (m *MockOptions) SetWriteOverloadBackoffOptions(value WriteOverloadBackoffOptions) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWriteOverloadBackoffOptions", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetWriteOverloadBackoffOptions indicates an expected call of SetWriteOverloadBackoffOptions.
+func (mr *MockOptionsMockRecorder) SetWriteOverloadBackoffOptions(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWriteOverloadBackoffOptions", reflect.TypeOf((*MockOptions)(nil).SetWriteOverloadBackoffOptions), value)
+}
+
 // SetWriteRequestTimeout mocks base method.
 func (m *MockOptions) SetWriteRequestTimeout(value time.Duration) Options {
 	m.ctrl.T.Helper()
@@ -2725,6 +2923,20 @@ func (mr *MockOptionsMockRecorder) WriteOpPoolSize() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteOpPoolSize", reflect.TypeOf((*MockOptions)(nil).WriteOpPoolSize))
 }
 
+// WriteOverloadBackoffOptions mocks base method.
+func (m *MockOptions) WriteOverloadBackoffOptions() WriteOverloadBackoffOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteOverloadBackoffOptions")
+	ret0, _ := ret[0].(WriteOverloadBackoffOptions)
+	return ret0
+}
+
+// WriteOverloadBackoffOptions indicates an expected call of WriteOverloadBackoffOptions.
+func (mr *MockOptionsMockRecorder) WriteOverloadBackoffOptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteOverloadBackoffOptions", reflect.TypeOf((*MockOptions)(nil).WriteOverloadBackoffOptions))
+}
+
 // WriteRequestTimeout mocks base method.
 func (m *MockOptions) WriteRequestTimeout() time.Duration {
 	m.ctrl.T.Helper()
@@ -3182,6 +3394,20 @@ func (mr *MockAdminOptionsMockRecorder) FetchSeriesBlocksMetadataBatchTimeout()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSeriesBlocksMetadataBatchTimeout", reflect.TypeOf((*MockAdminOptions)(nil).FetchSeriesBlocksMetadataBatchTimeout))
 }
 
+// HedgedReadOptions mocks base method.
+func (m *MockAdminOptions) HedgedReadOptions() HedgedReadOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HedgedReadOptions")
+	ret0, _ := ret[0].(HedgedReadOptions)
+	return ret0
+}
+
+// HedgedReadOptions indicates an expected call of HedgedReadOptions.
+func (mr *MockAdminOptionsMockRecorder) HedgedReadOptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HedgedReadOptions", reflect.TypeOf((*MockAdminOptions)(nil).HedgedReadOptions))
+}
+
 // HostConnectTimeout mocks base method.
 func (m *MockAdminOptions) HostConnectTimeout() time.Duration {
 	m.ctrl.T.Helper()
@@ -3868,6 +4094,20 @@ func (mr *MockAdminOptionsMockRecorder) SetFetchSeriesBlocksMetadataBatchTimeout
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFetchSeriesBlocksMetadataBatchTimeout", reflect.TypeOf((*MockAdminOptions)(nil).SetFetchSeriesBlocksMetadataBatchTimeout), value)
 }
 
+// SetHedgedReadOptions mocks base method.
+func (m *MockAdminOptions) SetHedgedReadOptions(value HedgedReadOptions) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHedgedReadOptions", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetHedgedReadOptions indicates an expected call of SetHedgedReadOptions.
+func (mr *MockAdminOptionsMockRecorder) SetHedgedReadOptions(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHedgedReadOptions", reflect.TypeOf((*MockAdminOptions)(nil).SetHedgedReadOptions), value)
+}
+
 // SetHostConnectTimeout mocks base method.
 func (m *MockAdminOptions) SetHostConnectTimeout(value time.Duration) Options {
 	m.ctrl.T.Helper()
@@ -4330,6 +4570,20 @@ func (mr *MockAdminOptionsMockRecorder) SetWriteOpPoolSize(value interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWriteOpPoolSize", reflect.TypeOf((*MockAdminOptions)(nil).SetWriteOpPoolSize), value)
 }
 
+// SetWriteOverloadBackoffOptions mocks base method.
+func (m *MockAdminOptions) SetWriteOverloadBackoffOptions(value WriteOverloadBackoffOptions) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWriteOverloadBackoffOptions", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetWriteOverloadBackoffOptions indicates an expected call of SetWriteOverloadBackoffOptions.
+func (mr *MockAdminOptionsMockRecorder) SetWriteOverloadBackoffOptions(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWriteOverloadBackoffOptions", reflect.TypeOf((*MockAdminOptions)(nil).SetWriteOverloadBackoffOptions), value)
+}
+
 // SetWriteRequestTimeout mocks base method.
 func (m *MockAdminOptions) SetWriteRequestTimeout(value time.Duration) Options {
 	m.ctrl.T.Helper()
@@ -4596,6 +4850,20 @@ func (mr *MockAdminOptionsMockRecorder) WriteOpPoolSize() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteOpPoolSize", reflect.TypeOf((*MockAdminOptions)(nil).WriteOpPoolSize))
 }
 
+// WriteOverloadBackoffOptions mocks base method.
+func (m *MockAdminOptions) WriteOverloadBackoffOptions() WriteOverloadBackoffOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteOverloadBackoffOptions")
+	ret0, _ := ret[0].(WriteOverloadBackoffOptions)
+	return ret0
+}
+
+// WriteOverloadBackoffOptions indicates an expected call of WriteOverloadBackoffOptions.
+func (mr *MockAdminOptionsMockRecorder) WriteOverloadBackoffOptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteOverloadBackoffOptions", reflect.TypeOf((*MockAdminOptions)(nil).WriteOverloadBackoffOptions))
+}
+
 // WriteRequestTimeout mocks base method.
 func (m *MockAdminOptions) WriteRequestTimeout() time.Duration {
 	m.ctrl.T.Helper()
@@ -4720,6 +4988,21 @@ func (mr *MockclientSessionMockRecorder) BorrowConnections(shardID, fn, opts int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BorrowConnections", reflect.TypeOf((*MockclientSession)(nil).BorrowConnections), shardID, fn, opts)
 }
 
+// Cardinality mocks base method.
+func (m *MockclientSession) Cardinality(namespace ident.ID, q index.Query, opts index.CardinalityOptions) (index.CardinalityQueryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", namespace, q, opts)
+	ret0, _ := ret[0].(index.CardinalityQueryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockclientSessionMockRecorder) Cardinality(namespace, q, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockclientSession)(nil).Cardinality), namespace, q, opts)
+}
+
 // Close mocks base method.
 func (m *MockclientSession) Close() error {
 	m.ctrl.T.Helper()
@@ -4750,6 +5033,21 @@ func (mr *MockclientSessionMockRecorder) DedicatedConnection(shardID, opts inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DedicatedConnection", reflect.TypeOf((*MockclientSession)(nil).DedicatedConnection), shardID, opts)
 }
 
+// DeleteTagged mocks base method.
+func (m *MockclientSession) DeleteTagged(namespace ident.ID, q index.Query, start, end time0.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", namespace, q, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged.
+func (mr *MockclientSessionMockRecorder) DeleteTagged(namespace, q, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*MockclientSession)(nil).DeleteTagged), namespace, q, start, end)
+}
+
 // Fetch mocks base method.
 func (m *MockclientSession) Fetch(namespace, id ident.ID, startInclusive, endExclusive time0.UnixNano) (encoding.SeriesIterator, error) {
 	m.ctrl.T.Helper()
@@ -4840,6 +5138,36 @@ func (mr *MockclientSessionMockRecorder) FetchIDs(namespace, ids, startInclusive
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchIDs", reflect.TypeOf((*MockclientSession)(nil).FetchIDs), namespace, ids, startInclusive, endExclusive)
 }
 
+// FetchMerkleLeavesFromPeer mocks base method.
+func (m *MockclientSession) FetchMerkleLeavesFromPeer(peer topology.Host, namespace ident.ID, shard uint32, blockStart time0.UnixNano, depth int, leaves []int) (PeerBlockMetadataIter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMerkleLeavesFromPeer", peer, namespace, shard, blockStart, depth, leaves)
+	ret0, _ := ret[0].(PeerBlockMetadataIter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMerkleLeavesFromPeer indicates an expected call of FetchMerkleLeavesFromPeer.
+func (mr *MockclientSessionMockRecorder) FetchMerkleLeavesFromPeer(peer, namespace, shard, blockStart, depth, leaves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMerkleLeavesFromPeer", reflect.TypeOf((*MockclientSession)(nil).FetchMerkleLeavesFromPeer), peer, namespace, shard, blockStart, depth, leaves)
+}
+
+// FetchMerkleNodesFromPeer mocks base method.
+func (m *MockclientSession) FetchMerkleNodesFromPeer(peer topology.Host, namespace ident.ID, shard uint32, blockStart time0.UnixNano, depth int, nodes []int) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMerkleNodesFromPeer", peer, namespace, shard, blockStart, depth, nodes)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMerkleNodesFromPeer indicates an expected call of FetchMerkleNodesFromPeer.
+func (mr *MockclientSessionMockRecorder) FetchMerkleNodesFromPeer(peer, namespace, shard, blockStart, depth, nodes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMerkleNodesFromPeer", reflect.TypeOf((*MockclientSession)(nil).FetchMerkleNodesFromPeer), peer, namespace, shard, blockStart, depth, nodes)
+}
+
 // FetchTagged mocks base method.
 func (m *MockclientSession) FetchTagged(ctx context.Context, namespace ident.ID, q index.Query, opts index.QueryOptions) (encoding.SeriesIterators, FetchResponseMetadata, error) {
 	m.ctrl.T.Helper()
@@ -4872,6 +5200,21 @@ func (mr *MockclientSessionMockRecorder) FetchTaggedIDs(ctx, namespace, q, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTaggedIDs", reflect.TypeOf((*MockclientSession)(nil).FetchTaggedIDs), ctx, namespace, q, opts)
 }
 
+// FetchTaggedPages mocks base method.
+func (m *MockclientSession) FetchTaggedPages(ctx context.Context, namespace ident.ID, q index.Query, opts index.QueryOptions) (FetchTaggedPagesIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTaggedPages", ctx, namespace, q, opts)
+	ret0, _ := ret[0].(FetchTaggedPagesIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTaggedPages indicates an expected call of FetchTaggedPages.
+func (mr *MockclientSessionMockRecorder) FetchTaggedPages(ctx, namespace, q, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTaggedPages", reflect.TypeOf((*MockclientSession)(nil).FetchTaggedPages), ctx, namespace, q, opts)
+}
+
 // IteratorPools mocks base method.
 func (m *MockclientSession) IteratorPools() (encoding.IteratorPools, error) {
 	m.ctrl.T.Helper()
@@ -5198,25 +5541,6 @@ func (mr *MockChannelMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockChannel)(nil).Close))
 }
 
-// GetSubChannel mocks base method.
-func (m *MockChannel) GetSubChannel(serviceName string, opts ...tchannel_go.SubChannelOption) *tchannel_go.SubChannel {
-	m.ctrl.T.Helper()
-	varargs := []interface{}{serviceName}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "GetSubChannel", varargs...)
-	ret0, _ := ret[0].(*tchannel_go.SubChannel)
-	return ret0
-}
-
-// GetSubChannel indicates an expected call of GetSubChannel.
-func (mr *MockChannelMockRecorder) GetSubChannel(serviceName interface{}, opts ...interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{serviceName}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubChannel", reflect.TypeOf((*MockChannel)(nil).GetSubChannel), varargs...)
-}
-
 // MockconnectionPool is a mock of connectionPool interface.
 type MockconnectionPool struct {
 	ctrl     *gomock.Controller
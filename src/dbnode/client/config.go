@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/environment"
 	"github.com/m3db/m3/src/dbnode/namespace"
@@ -89,6 +90,10 @@ type Configuration struct {
 	// Proto contains the configuration specific to running in the ProtoDataMode.
 	Proto *ProtoConfiguration `yaml:"proto"`
 
+	// Histogram contains the configuration for reading series stored with
+	// the native histogram encoding scheme.
+	Histogram *HistogramConfiguration `yaml:"histogram"`
+
 	// AsyncWriteWorkerPoolSize is the worker pool size for async write requests.
 	AsyncWriteWorkerPoolSize *int `yaml:"asyncWriteWorkerPoolSize"`
 
@@ -120,6 +125,20 @@ type Configuration struct {
 
 	// IterateEqualTimestampStrategy specifies the iterate equal timestamp strategy.
 	IterateEqualTimestampStrategy *encoding.IterateEqualTimestampStrategy `yaml:"iterateEqualTimestampStrategy"`
+
+	// HedgedReads contains the configuration for hedged reads.
+	HedgedReads *HedgedReadsConfiguration `yaml:"hedgedReads"`
+
+	// WriteOverloadBackoff contains the configuration for backing off writes
+	// to hosts that reject writes as overloaded.
+	WriteOverloadBackoff *WriteOverloadBackoffConfiguration `yaml:"writeOverloadBackoff"`
+
+	// Transport is the transport used to connect to nodes, either tchannel
+	// (the default) or grpc.
+	Transport *Transport `yaml:"transport"`
+
+	// GRPCTransport contains the configuration for the grpc transport.
+	GRPCTransport *GRPCTransportConfiguration `yaml:"grpcTransport"`
 }
 
 // ProtoConfiguration is the configuration for running with ProtoDataMode enabled.
@@ -165,6 +184,66 @@ func (c *ProtoConfiguration) Validate() error {
 	return nil
 }
 
+// HistogramConfiguration is the configuration for reading series stored with
+// the native histogram encoding scheme.
+type HistogramConfiguration struct {
+	// Enabled specifies whether the histogram encoding scheme is used.
+	Enabled bool `yaml:"enabled"`
+}
+
+// HedgedReadsConfiguration is the configuration for hedged reads, which
+// first query only as many replicas as the read consistency level requires
+// and query the remaining replicas once a read is slower than usual.
+type HedgedReadsConfiguration struct {
+	// Enabled specifies whether reads are hedged.
+	Enabled bool `yaml:"enabled"`
+
+	// LatencyPercentile is the percentile of recent replica fetch latencies
+	// after which the remaining replicas are queried.
+	LatencyPercentile *float64 `yaml:"latencyPercentile"`
+
+	// MinDelay is the minimum time to wait before querying the remaining
+	// replicas.
+	MinDelay *time.Duration `yaml:"minDelay"`
+}
+
+// WriteOverloadBackoffConfiguration is the configuration for backing off
+// writes to hosts that reject writes as overloaded, during which writes to
+// the host fail immediately rather than being sent.
+type WriteOverloadBackoffConfiguration struct {
+	// Enabled specifies whether writes to overloaded hosts are backed off.
+	Enabled *bool `yaml:"enabled"`
+
+	// InitialBackoff is the backoff after a host first rejects writes.
+	InitialBackoff *time.Duration `yaml:"initialBackoff"`
+
+	// MaxBackoff is the maximum backoff from a host that keeps rejecting writes.
+	MaxBackoff *time.Duration `yaml:"maxBackoff"`
+}
+
+// GRPCTransportConfiguration is the configuration for the grpc transport,
+// which resolves the gRPC node listen address of each node from the
+// endpoint the node is registered with in the placement.
+type GRPCTransportConfiguration struct {
+	// Port is the port of the gRPC node listen address, the host of the
+	// placement endpoint is kept. Placement endpoints are dialed unchanged
+	// if not set.
+	Port int `yaml:"port"`
+
+	// Endpoints maps placement endpoints to gRPC node listen addresses and
+	// takes precedence over Port.
+	Endpoints map[string]string `yaml:"endpoints"`
+}
+
+// NewEndpointFn returns the function that resolves the gRPC node listen
+// address of a node from its placement endpoint.
+func (c *GRPCTransportConfiguration) NewEndpointFn() GRPCEndpointFn {
+	if c == nil {
+		return nil
+	}
+	return NewGRPCEndpointFn(c.Endpoints, c.Port)
+}
+
 // Validate validates the configuration.
 func (c *Configuration) Validate() error {
 	if c.WriteTimeout != nil && *c.WriteTimeout < 0 {
@@ -211,6 +290,11 @@ func (c *Configuration) Validate() error {
 		return fmt.Errorf("error validating M3DB client proto configuration: %v", err)
 	}
 
+	if c.GRPCTransport != nil && (c.GRPCTransport.Port < 0 || c.GRPCTransport.Port > 65535) {
+		return fmt.Errorf("m3db client grpc transport port was: %d but must be >= 0 and <= 65535",
+			c.GRPCTransport.Port)
+	}
+
 	return nil
 }
 
@@ -419,6 +503,36 @@ func (c Configuration) NewAdminClient(
 		v = v.SetIterationOptions(o)
 	}
 
+	if c.HedgedReads != nil {
+		o := v.HedgedReadOptions()
+		o.Enabled = c.HedgedReads.Enabled
+		if c.HedgedReads.LatencyPercentile != nil {
+			o.LatencyPercentile = *c.HedgedReads.LatencyPercentile
+		}
+		if c.HedgedReads.MinDelay != nil {
+			o.MinDelay = *c.HedgedReads.MinDelay
+		}
+		v = v.SetHedgedReadOptions(o)
+	}
+
+	if c.WriteOverloadBackoff != nil {
+		o := v.WriteOverloadBackoffOptions()
+		if c.WriteOverloadBackoff.Enabled != nil {
+			o.Enabled = *c.WriteOverloadBackoff.Enabled
+		}
+		if c.WriteOverloadBackoff.InitialBackoff != nil {
+			o.InitialBackoff = *c.WriteOverloadBackoff.InitialBackoff
+		}
+		if c.WriteOverloadBackoff.MaxBackoff != nil {
+			o.MaxBackoff = *c.WriteOverloadBackoff.MaxBackoff
+		}
+		v = v.SetWriteOverloadBackoffOptions(o)
+	}
+
+	if c.Transport != nil {
+		v = v.SetNewConnectionFn(c.Transport.NewConnectionFn(c.GRPCTransport.NewEndpointFn()))
+	}
+
 	encodingOpts := params.EncodingOptions
 	if encodingOpts == nil {
 		encodingOpts = encoding.NewOptions()
@@ -426,6 +540,10 @@ func (c Configuration) NewAdminClient(
 
 	v = v.SetReaderIteratorAllocate(m3tsz.DefaultReaderIteratorAllocFn(encodingOpts))
 
+	if c.Histogram != nil && c.Histogram.Enabled {
+		v = v.SetReaderIteratorAllocate(histogram.DefaultReaderIteratorAllocFn(encodingOpts))
+	}
+
 	if c.Proto != nil && c.Proto.Enabled {
 		v = v.SetEncodingProto(encodingOpts)
 		schemaRegistry := namespace.NewSchemaRegistry(true, nil)
@@ -67,6 +67,19 @@ proto:
     ns2:
       schemaDeployID: "deployID-345"
       messageName: "ns2_msg_name"
+hedgedReads:
+  enabled: true
+  latencyPercentile: 0.99
+  minDelay: 5ms
+writeOverloadBackoff:
+  enabled: true
+  initialBackoff: 50ms
+  maxBackoff: 2s
+transport: grpc
+grpcTransport:
+  port: 9010
+  endpoints:
+    "host1:9000": "host1:9100"
 `
 
 	fd, err := ioutil.TempFile("", "config.yaml")
@@ -94,6 +107,11 @@ proto:
 		num4                 = 4
 		numHalf              = 0.5
 		boolTrue             = true
+		percentile99         = 0.99
+		millisecond5         = 5 * time.Millisecond
+		millisecond50        = 50 * time.Millisecond
+		second2              = 2 * time.Second
+		grpcTransport        = GRPCTransport
 	)
 
 	expected := Configuration{
@@ -128,6 +146,21 @@ proto:
 				"ns2":    {SchemaDeployID: "deployID-345", MessageName: "ns2_msg_name"},
 			},
 		},
+		HedgedReads: &HedgedReadsConfiguration{
+			Enabled:           true,
+			LatencyPercentile: &percentile99,
+			MinDelay:          &millisecond5,
+		},
+		WriteOverloadBackoff: &WriteOverloadBackoffConfiguration{
+			Enabled:        &boolTrue,
+			InitialBackoff: &millisecond50,
+			MaxBackoff:     &second2,
+		},
+		Transport: &grpcTransport,
+		GRPCTransport: &GRPCTransportConfiguration{
+			Port:      9010,
+			Endpoints: map[string]string{"host1:9000": "host1:9100"},
+		},
 	}
 
 	assert.Equal(t, expected, cfg)
@@ -33,7 +33,6 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
-	"github.com/uber/tchannel-go"
 )
 
 const (
@@ -66,13 +65,6 @@ func (c *noopPooledChannel) Close() {
 	atomic.AddInt32(&c.closeCount, 1)
 }
 
-func (c *noopPooledChannel) GetSubChannel(
-	serviceName string,
-	opts ...tchannel.SubChannelOption,
-) *tchannel.SubChannel {
-	return nil
-}
-
 func newConnectionPoolTestOptions() Options {
 	return newSessionTestOptions().
 		SetBackgroundConnectInterval(5 * time.Millisecond).
@@ -0,0 +1,131 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/topology"
+)
+
+type deleteTaggedOp struct {
+	request      rpc.DeleteTaggedRequest
+	completionFn completionFn
+}
+
+func (d *deleteTaggedOp) Size() int {
+	// DeleteTagged is always a single op
+	return 1
+}
+
+func (d *deleteTaggedOp) CompletionFn() completionFn {
+	return d.completionFn
+}
+
+// deleteTaggedAccumulator accumulates the per shard results of a delete
+// from every host, so that each deleted series is counted once rather than
+// once per replica.
+type deleteTaggedAccumulator struct {
+	sync.Mutex
+
+	topoMap   topology.Map
+	responded int
+	errs      []error
+	success   map[uint32]int
+	numSeries map[uint32]int64
+}
+
+func newDeleteTaggedAccumulator(topoMap topology.Map) *deleteTaggedAccumulator {
+	return &deleteTaggedAccumulator{
+		topoMap:   topoMap,
+		success:   make(map[uint32]int),
+		numSeries: make(map[uint32]int64),
+	}
+}
+
+func (a *deleteTaggedAccumulator) add(
+	host topology.Host,
+	result *rpc.DeleteTaggedResult_,
+	err error,
+) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.responded++
+	if err != nil {
+		a.errs = append(a.errs, fmt.Errorf("error deleting on host %s: %w", host.ID(), err))
+		return
+	}
+
+	hostShardSet, ok := a.topoMap.LookupHostShardSet(host.ID())
+	if !ok {
+		a.errs = append(a.errs, fmt.Errorf("missing host shard set for host %s", host.ID()))
+		return
+	}
+
+	// NB: only count available shards towards consistency, the same as
+	// writes, and take the largest count reported by a replica of a shard
+	// since replicas may not have indexed exactly the same series.
+	for _, s := range hostShardSet.ShardSet().All() {
+		if s.State() != shard.Available {
+			continue
+		}
+		a.success[s.ID()]++
+		if n := result.NumSeriesByShard[int32(s.ID())]; n > a.numSeries[s.ID()] {
+			a.numSeries[s.ID()] = n
+		}
+	}
+}
+
+// result returns the number of series deleted across all shards, or an error
+// if any shard did not reach the given consistency level.
+func (a *deleteTaggedAccumulator) result(
+	level topology.ConsistencyLevel,
+	enqueued int,
+) (int64, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	var (
+		numSeries int64
+		majority  = a.topoMap.MajorityReplicas()
+	)
+	for _, shardID := range a.topoMap.ShardSet().AllIDs() {
+		hosts, err := a.topoMap.RouteShard(shardID)
+		if err != nil {
+			return 0, err
+		}
+		if !topology.WriteConsistencyAchieved(level, majority,
+			len(hosts), a.success[shardID]) {
+			errs := a.errs
+			if len(errs) == 0 {
+				errs = []error{fmt.Errorf(
+					"delete not applied to enough replicas of shard %d", shardID)}
+			}
+			return 0, newConsistencyResultError(level, enqueued, a.responded, errs)
+		}
+		numSeries += a.numSeries[shardID]
+	}
+	return numSeries, nil
+}
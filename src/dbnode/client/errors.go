@@ -70,6 +70,18 @@ func IsResourceExhaustedError(err error) bool {
 	return false
 }
 
+// IsOverloadedError determines if the error is an overloaded error, returned
+// when a host rejects writes because it is under pressure.
+func IsOverloadedError(err error) bool {
+	for err != nil {
+		if e, ok := err.(*rpc.Error); ok && tterrors.IsOverloadedErrorFlag(e) { //nolint:errorlint
+			return true
+		}
+		err = xerrors.InnerError(err)
+	}
+	return false
+}
+
 // IsTimeoutError determines if the error is a timeout.
 func IsTimeoutError(err error) bool {
 	for err != nil {
@@ -93,3 +93,20 @@ func TestConsistencyResultTchannelTimeoutError(t *testing.T) {
 	assert.Equal(t, 1, NumSuccess(err))
 	assert.Equal(t, 2, NumError(err))
 }
+
+func TestConsistencyResultOverloadedError(t *testing.T) {
+	overloadedErr := xerrors.NewRenamedError(
+		errors.NewOverloadedError(fmt.Errorf("overloaded")),
+		fmt.Errorf("error writing to host"))
+
+	level := topology.ConsistencyLevelMajority
+	enqueued := 3
+	responded := 3
+	errs := []error{overloadedErr, overloadedErr}
+
+	err := error(newConsistencyResultError(level, enqueued, responded, errs))
+
+	assert.True(t, IsOverloadedError(err))
+	assert.False(t, IsBadRequestError(err))
+	assert.False(t, IsOverloadedError(fmt.Errorf("another error")))
+}
@@ -25,6 +25,8 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/uber-go/tally"
+
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/index"
@@ -71,6 +73,9 @@ type fetchState struct {
 	// is used for - fetchTagged or Aggregate.
 	stateType fetchStateType
 
+	// hedge is set if the fetch tagged is hedged.
+	hedge *hedgedFetchTagged
+
 	done bool
 }
 
@@ -99,6 +104,7 @@ func (f *fetchState) close() {
 	}
 	f.err = nil
 	f.done = false
+	f.hedge = nil
 	f.tagResultAccumulator.Clear()
 
 	if f.pool == nil {
@@ -156,6 +162,16 @@ func (f *fetchState) completionFn(
 		return
 	}
 
+	if h := f.hedge; h != nil && len(h.queues) > 0 {
+		if resultErr != nil {
+			// Query the reserved replicas right away rather than waiting for
+			// the hedge delay.
+			f.hedgeWithLock(h.session.metrics.fetchHedgedOnError)
+		} else {
+			h.session.hedgedReadLatencies.record(h.session.nowFn().Sub(h.start))
+		}
+	}
+
 	var (
 		done bool
 		err  error
@@ -179,6 +195,52 @@ func (f *fetchState) completionFn(
 	}
 }
 
+// hedgeOnDelay hedges the fetch once it has been outstanding for longer
+// than the hedge delay, it is called by the hedge timer which holds a ref.
+func (f *fetchState) hedgeOnDelay() {
+	f.Lock()
+	if !f.done {
+		f.hedgeWithLock(f.hedge.session.metrics.fetchHedgedOnDelay)
+	}
+	f.Unlock()
+	f.decRef() // release the ref held by the hedge timer
+}
+
+// hedgeWithLock enqueues the fetch to the host queues reserved for hedging
+// it, if it has not been hedged yet.
+func (f *fetchState) hedgeWithLock(issued tally.Counter) {
+	h := f.hedge
+	if len(h.queues) == 0 {
+		return
+	}
+	queues := h.queues
+	h.queues = nil
+	issued.Inc(1)
+
+	for _, hq := range queues {
+		// inc to indicate the hostQueue has a reference to the op
+		f.incRef()
+		if err := hq.Enqueue(h.op); err != nil {
+			// The host is accounted for as failed, the other refs held to
+			// the fetchState ensure this cannot release it.
+			done, accumErr := f.tagResultAccumulator.AddFetchTaggedResponse(
+				fetchTaggedResultAccumulatorOpts{host: hq.Host()}, err)
+			f.decRef()
+			if done {
+				f.markDoneWithLock(accumErr)
+				return
+			}
+		}
+	}
+}
+
+// stopHedge stops the hedge timer once the fetch is done.
+func (f *fetchState) stopHedge() {
+	if h := f.hedge; h != nil && h.timer.Stop() {
+		f.decRef() // release the ref held by the hedge timer
+	}
+}
+
 func (f *fetchState) markDoneWithLock(err error) {
 	f.done = true
 	f.err = err
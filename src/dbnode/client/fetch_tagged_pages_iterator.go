@@ -0,0 +1,131 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	gocontext "context"
+	"errors"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+)
+
+var errFetchTaggedPagesNoPageSize = errors.New("fetch tagged pages requires a page size")
+
+type fetchTaggedFn func(
+	ctx gocontext.Context,
+	namespace ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+) (encoding.SeriesIterators, FetchResponseMetadata, error)
+
+type fetchTaggedPagesIterator struct {
+	ctx       gocontext.Context
+	namespace ident.ID
+	query     index.Query
+	opts      index.QueryOptions
+	fetchFn   fetchTaggedFn
+
+	iters    encoding.SeriesIterators
+	metadata FetchResponseMetadata
+	fetched  int
+	done     bool
+	err      error
+}
+
+func newFetchTaggedPagesIterator(
+	ctx gocontext.Context,
+	namespace ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+	fetchFn fetchTaggedFn,
+) (FetchTaggedPagesIterator, error) {
+	if opts.PageSize <= 0 {
+		return nil, xerrors.NewInvalidParamsError(errFetchTaggedPagesNoPageSize)
+	}
+	return &fetchTaggedPagesIterator{
+		ctx:       ctx,
+		namespace: namespace,
+		query:     q,
+		opts:      opts,
+		fetchFn:   fetchFn,
+	}, nil
+}
+
+func (i *fetchTaggedPagesIterator) Next() bool {
+	i.iters, i.metadata = nil, FetchResponseMetadata{}
+	if i.done || i.err != nil {
+		return false
+	}
+
+	iters, metadata, err := i.fetchFn(i.ctx, i.namespace, i.query, i.opts)
+	if err != nil {
+		i.err = err
+		return false
+	}
+
+	i.iters, i.metadata = iters, metadata
+	i.fetched += iters.Len()
+	switch {
+	case metadata.NextPageToken == nil:
+		i.done = true
+	case i.opts.SeriesLimit > 0 && i.fetched >= i.opts.SeriesLimit:
+		// NB: the series limit applies to the query as a whole, so stop
+		// paging once it has been reached.
+		i.done = true
+		i.metadata.Exhaustive = false
+		i.metadata.NextPageToken = nil
+	default:
+		if err := i.setNextPageToken(metadata.NextPageToken); err != nil {
+			i.err = err
+			return false
+		}
+	}
+	return true
+}
+
+// setNextPageToken sets the token of the next page, keeping the cursors of the
+// previous pages since a host that returned its last page omits its cursor
+// but may be asked for the series after the end of the page again.
+func (i *fetchTaggedPagesIterator) setNextPageToken(encoded []byte) error {
+	next, err := convert.DecodeFetchTaggedPageToken(encoded)
+	if err != nil {
+		return err
+	}
+	prev, err := convert.DecodeFetchTaggedPageToken(i.opts.PageToken)
+	if err != nil {
+		return err
+	}
+	next.AddCursors(prev.Cursors)
+	i.opts.PageToken = convert.EncodeFetchTaggedPageToken(next)
+	return nil
+}
+
+func (i *fetchTaggedPagesIterator) Current() (encoding.SeriesIterators, FetchResponseMetadata) {
+	return i.iters, i.metadata
+}
+
+func (i *fetchTaggedPagesIterator) Err() error {
+	return i.err
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	gocontext "context"
+	"errors"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testFetchTaggedPage struct {
+	numSeries     int
+	nextPageToken *convert.FetchTaggedPageToken
+}
+
+func newTestFetchTaggedPagesFn(
+	t *testing.T,
+	pages []testFetchTaggedPage,
+	err error,
+) fetchTaggedFn {
+	var (
+		idx       int
+		pageToken convert.FetchTaggedPageToken
+	)
+	return func(
+		_ gocontext.Context,
+		_ ident.ID,
+		_ index.Query,
+		opts index.QueryOptions,
+	) (encoding.SeriesIterators, FetchResponseMetadata, error) {
+		token, decodeErr := convert.DecodeFetchTaggedPageToken(opts.PageToken)
+		require.NoError(t, decodeErr)
+		require.Equal(t, pageToken, token)
+		if idx == len(pages) {
+			return nil, FetchResponseMetadata{}, err
+		}
+		page := pages[idx]
+		idx++
+
+		metadata := FetchResponseMetadata{Exhaustive: true}
+		if page.nextPageToken != nil {
+			metadata.NextPageToken = convert.EncodeFetchTaggedPageToken(*page.nextPageToken)
+			// The cursors of previous pages are kept in the token.
+			cursors := pageToken.Cursors
+			pageToken = *page.nextPageToken
+			pageToken.AddCursors(cursors)
+		}
+		return encoding.NewSeriesIterators(make([]encoding.SeriesIterator, page.numSeries)),
+			metadata, nil
+	}
+}
+
+func TestFetchTaggedPagesIterator(t *testing.T) {
+	fetchFn := newTestFetchTaggedPagesFn(t, []testFetchTaggedPage{
+		{numSeries: 2, nextPageToken: &convert.FetchTaggedPageToken{
+			LastID: []byte("b"), Cursors: []uint64{1, 2},
+		}},
+		{numSeries: 2, nextPageToken: &convert.FetchTaggedPageToken{
+			LastID: []byte("d"), Cursors: []uint64{2},
+		}},
+		{numSeries: 1},
+	}, nil)
+	iter, err := newFetchTaggedPagesIterator(gocontext.Background(), ident.StringID("ns"),
+		index.Query{}, index.QueryOptions{PageSize: 2}, fetchFn)
+	require.NoError(t, err)
+
+	var numSeries []int
+	for iter.Next() {
+		iters, metadata := iter.Current()
+		require.True(t, metadata.Exhaustive)
+		numSeries = append(numSeries, iters.Len())
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, []int{2, 2, 1}, numSeries)
+}
+
+func TestFetchTaggedPagesIteratorSeriesLimit(t *testing.T) {
+	fetchFn := newTestFetchTaggedPagesFn(t, []testFetchTaggedPage{
+		{numSeries: 2, nextPageToken: &convert.FetchTaggedPageToken{
+			LastID: []byte("b"), Cursors: []uint64{1, 2},
+		}},
+		{numSeries: 2, nextPageToken: &convert.FetchTaggedPageToken{
+			LastID: []byte("d"), Cursors: []uint64{2},
+		}},
+		{numSeries: 1},
+	}, nil)
+	iter, err := newFetchTaggedPagesIterator(gocontext.Background(), ident.StringID("ns"),
+		index.Query{}, index.QueryOptions{PageSize: 2, SeriesLimit: 3}, fetchFn)
+	require.NoError(t, err)
+
+	require.True(t, iter.Next())
+	_, metadata := iter.Current()
+	require.True(t, metadata.Exhaustive)
+
+	require.True(t, iter.Next())
+	_, metadata = iter.Current()
+	require.False(t, metadata.Exhaustive)
+	require.Nil(t, metadata.NextPageToken)
+
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Err())
+}
+
+func TestFetchTaggedPagesIteratorError(t *testing.T) {
+	fetchErr := errors.New("fetch error")
+	fetchFn := newTestFetchTaggedPagesFn(t, []testFetchTaggedPage{
+		{numSeries: 2, nextPageToken: &convert.FetchTaggedPageToken{LastID: []byte("b")}},
+	}, fetchErr)
+	iter, err := newFetchTaggedPagesIterator(gocontext.Background(), ident.StringID("ns"),
+		index.Query{}, index.QueryOptions{PageSize: 2}, fetchFn)
+	require.NoError(t, err)
+
+	require.True(t, iter.Next())
+	require.False(t, iter.Next())
+	require.Equal(t, fetchErr, iter.Err())
+}
+
+func TestFetchTaggedPagesIteratorRequiresPageSize(t *testing.T) {
+	_, err := newFetchTaggedPagesIterator(gocontext.Background(), ident.StringID("ns"),
+		index.Query{}, index.QueryOptions{}, nil)
+	require.Error(t, err)
+	require.True(t, xerrors.IsInvalidParams(err))
+}
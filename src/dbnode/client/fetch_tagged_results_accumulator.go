@@ -22,6 +22,7 @@ package client
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"sort"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/topology"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -68,6 +70,11 @@ type fetchTaggedResultAccumulator struct {
 	exhaustive       bool
 	waitedIndex      int
 	waitedSeriesRead int
+	docsRead         int
+	bytesRead        int
+	blocksRead       int
+	indexExplain     []index.QueryExplain
+	nextPageToken    *convert.FetchTaggedPageToken
 
 	startTime        xtime.UnixNano
 	endTime          xtime.UnixNano
@@ -93,6 +100,10 @@ func (accum *fetchTaggedResultAccumulator) AddFetchTaggedResponse(
 	opts fetchTaggedResultAccumulatorOpts,
 	resultErr error,
 ) (bool, error) {
+	var pageToken convert.FetchTaggedPageToken
+	if opts.response != nil && resultErr == nil && opts.response.NextPageToken != nil {
+		pageToken, resultErr = convert.DecodeFetchTaggedPageToken(opts.response.NextPageToken)
+	}
 	if opts.response != nil && resultErr == nil {
 		accum.exhaustive = accum.exhaustive && opts.response.Exhaustive
 		if v := opts.response.WaitedIndex; v != nil {
@@ -101,6 +112,22 @@ func (accum *fetchTaggedResultAccumulator) AddFetchTaggedResponse(
 		if v := opts.response.WaitedSeriesRead; v != nil {
 			accum.waitedSeriesRead += int(*v)
 		}
+		if stats := opts.response.Stats; stats != nil {
+			accum.docsRead += int(stats.DocsRead)
+			accum.bytesRead += int(stats.BytesRead)
+			accum.blocksRead += int(stats.BlocksRead)
+		}
+		if len(opts.response.Explain) > 0 {
+			accum.addIndexExplain(opts.host, opts.response.Explain)
+		}
+		if opts.response.NextPageToken != nil {
+			// NB: the page ends at the smallest of the hosts' last IDs since
+			// only the series up to it have been returned by every host.
+			if accum.nextPageToken == nil {
+				accum.nextPageToken = &convert.FetchTaggedPageToken{}
+			}
+			accum.nextPageToken.Merge(pageToken)
+		}
 		for _, elem := range opts.response.Elements {
 			accum.fetchResponses = append(accum.fetchResponses, elem)
 		}
@@ -112,6 +139,22 @@ func (accum *fetchTaggedResultAccumulator) AddFetchTaggedResponse(
 	return accum.accumulatedResult(opts.host, resultErr)
 }
 
+func (accum *fetchTaggedResultAccumulator) addIndexExplain(
+	host topology.Host,
+	data []byte,
+) {
+	var explain index.QueryExplain
+	if err := json.Unmarshal(data, &explain); err != nil {
+		// NB: the explain is purely diagnostic, do not fail the fetch if a
+		// host returns one that cannot be decoded.
+		return
+	}
+	if host != nil {
+		explain.Host = host.ID()
+	}
+	accum.indexExplain = append(accum.indexExplain, explain)
+}
+
 func (accum *fetchTaggedResultAccumulator) AddAggregateResponse(
 	opts aggregateResultAccumulatorOpts,
 	resultErr error,
@@ -252,6 +295,11 @@ func (accum *fetchTaggedResultAccumulator) Clear() {
 	accum.exhaustive = true
 	accum.waitedIndex = 0
 	accum.waitedSeriesRead = 0
+	accum.docsRead = 0
+	accum.bytesRead = 0
+	accum.blocksRead = 0
+	accum.indexExplain = nil
+	accum.nextPageToken = nil
 	accum.calcTransport.Reset()
 }
 
@@ -265,6 +313,11 @@ func (accum *fetchTaggedResultAccumulator) Reset(
 	accum.exhaustive = true
 	accum.waitedIndex = 0
 	accum.waitedSeriesRead = 0
+	accum.docsRead = 0
+	accum.bytesRead = 0
+	accum.blocksRead = 0
+	accum.indexExplain = nil
+	accum.nextPageToken = nil
 	accum.startTime = startTime
 	accum.endTime = endTime
 	accum.topoMap = topoMap
@@ -337,6 +390,7 @@ func (accum *fetchTaggedResultAccumulator) AsEncodingSeriesIterators(
 	results := fetchTaggedIDResultsSortedByID(accum.fetchResponses)
 	sort.Sort(results)
 	accum.fetchResponses = fetchTaggedIDResults(results)
+	accum.truncateToPage()
 
 	numElements := 0
 	accum.fetchResponses.forEachID(func(_ fetchTaggedIDResults, _ bool) bool {
@@ -362,9 +416,37 @@ func (accum *fetchTaggedResultAccumulator) AsEncodingSeriesIterators(
 		EstimateTotalBytes: accum.calcTransport.GetSize(),
 		WaitedIndex:        accum.waitedIndex,
 		WaitedSeriesRead:   accum.waitedSeriesRead,
+		DocsRead:           accum.docsRead,
+		BytesRead:          accum.bytesRead,
+		BlocksRead:         accum.blocksRead,
+		IndexExplain:       accum.indexExplain,
+		NextPageToken:      accum.encodedNextPageToken(),
 	}, nil
 }
 
+// truncateToPage drops the sorted responses for series ordered after the end
+// of the page, these were only returned by some of the hosts and are fetched
+// again with the next page.
+func (accum *fetchTaggedResultAccumulator) truncateToPage() {
+	if accum.nextPageToken == nil {
+		return
+	}
+	n := sort.Search(len(accum.fetchResponses), func(i int) bool {
+		return bytes.Compare(accum.fetchResponses[i].ID, accum.nextPageToken.LastID) > 0
+	})
+	for i := n; i < len(accum.fetchResponses); i++ {
+		accum.fetchResponses[i] = nil
+	}
+	accum.fetchResponses = accum.fetchResponses[:n]
+}
+
+func (accum *fetchTaggedResultAccumulator) encodedNextPageToken() []byte {
+	if accum.nextPageToken == nil {
+		return nil
+	}
+	return convert.EncodeFetchTaggedPageToken(*accum.nextPageToken)
+}
+
 func (accum *fetchTaggedResultAccumulator) AsTaggedIDsIterator(
 	limit int,
 	pools fetchTaggedPools,
@@ -377,6 +459,7 @@ func (accum *fetchTaggedResultAccumulator) AsTaggedIDsIterator(
 	results := fetchTaggedIDResultsSortedByID(accum.fetchResponses)
 	sort.Sort(results)
 	accum.fetchResponses = fetchTaggedIDResults(results)
+	accum.truncateToPage()
 	accum.fetchResponses.forEachID(func(elems fetchTaggedIDResults, hasMore bool) bool {
 		iter.addBacking(elems[0].NameSpace, elems[0].ID, elems[0].EncodedTags)
 		count++
@@ -391,6 +474,11 @@ func (accum *fetchTaggedResultAccumulator) AsTaggedIDsIterator(
 		EstimateTotalBytes: accum.calcTransport.GetSize(),
 		WaitedIndex:        accum.waitedIndex,
 		WaitedSeriesRead:   accum.waitedSeriesRead,
+		DocsRead:           accum.docsRead,
+		BytesRead:          accum.bytesRead,
+		BlocksRead:         accum.blocksRead,
+		IndexExplain:       accum.indexExplain,
+		NextPageToken:      accum.encodedNextPageToken(),
 	}, nil
 }
 
@@ -469,6 +557,10 @@ func (accum *fetchTaggedResultAccumulator) AsAggregatedTagsIterator(
 		EstimateTotalBytes: accum.calcTransport.GetSize(),
 		WaitedIndex:        accum.waitedIndex,
 		WaitedSeriesRead:   accum.waitedSeriesRead,
+		DocsRead:           accum.docsRead,
+		BytesRead:          accum.bytesRead,
+		BlocksRead:         accum.blocksRead,
+		IndexExplain:       accum.indexExplain,
 	}, nil
 }
 
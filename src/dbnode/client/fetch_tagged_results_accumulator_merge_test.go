@@ -35,6 +35,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/topology/testutil"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/m3ninx/search"
 	"github.com/m3db/m3/src/x/context"
 	"github.com/m3db/m3/src/x/ident"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -106,6 +107,166 @@ func TestFetchTaggedResultsAccumulatorIdsMerge(t *testing.T) {
 	require.True(t, matcher.Matches(resultsIter))
 }
 
+func TestFetchTaggedResultsAccumulatorIndexExplain(t *testing.T) {
+	topoMap := testutil.MustNewTopologyMap(2, map[string][]shard.Shard{
+		"testhost0": testutil.ShardsRange(0, 29, shard.Available),
+		"testhost1": testutil.ShardsRange(0, 29, shard.Available),
+	})
+
+	th := newTestFetchTaggedHelper(t)
+	ts1 := newTestSeries(1)
+
+	withExplain := testSerieses{ts1}.toRPCResult(th, testStartTime, true)
+	withExplain.Explain = []byte(`{"blocks":[{"blockStart":0,"waitTime":0,` +
+		`"processingTime":10,"segments":[{"segment":"mutable/0","cacheHit":false,` +
+		`"postings":1,"duration":5}]}]}`)
+	withInvalidExplain := testSerieses{ts1}.toRPCResult(th, testStartTime, true)
+	withInvalidExplain.Explain = []byte("{")
+
+	workflow := testFetchStateWorkflow{
+		t:         t,
+		topoMap:   topoMap,
+		level:     topology.ReadConsistencyLevelAll,
+		startTime: testStartTime,
+		endTime:   testEndTime,
+		steps: []testFetchStateWorklowStep{
+			{
+				hostname:          "testhost0",
+				fetchTaggedResult: withExplain,
+			},
+			{
+				hostname:          "testhost1",
+				fetchTaggedResult: withInvalidExplain,
+				expectedDone:      true,
+			},
+		},
+	}
+
+	accum := workflow.run()
+
+	_, resultsMetadata, err := accum.AsTaggedIDsIterator(10, th.pools)
+	require.NoError(t, err)
+	require.Equal(t, []index.QueryExplain{
+		{
+			Host: "testhost0",
+			Blocks: []index.BlockExplain{
+				{
+					ProcessingTime: 10,
+					Segments: []search.SearchTrace{
+						{Segment: "mutable/0", Postings: 1, Duration: 5},
+					},
+				},
+			},
+		},
+	}, resultsMetadata.IndexExplain)
+
+	accum.Clear()
+	_, resultsMetadata, err = accum.AsTaggedIDsIterator(10, th.pools)
+	require.NoError(t, err)
+	require.Nil(t, resultsMetadata.IndexExplain)
+}
+
+func TestFetchTaggedResultsAccumulatorStats(t *testing.T) {
+	topoMap := testutil.MustNewTopologyMap(2, map[string][]shard.Shard{
+		"testhost0": testutil.ShardsRange(0, 29, shard.Available),
+		"testhost1": testutil.ShardsRange(0, 29, shard.Available),
+	})
+
+	th := newTestFetchTaggedHelper(t)
+	ts1 := newTestSeries(1)
+
+	withStats := testSerieses{ts1}.toRPCResult(th, testStartTime, true)
+	withStats.Stats = &rpc.FetchTaggedStats{DocsRead: 3, BytesRead: 100, BlocksRead: 2}
+	withoutStats := testSerieses{ts1}.toRPCResult(th, testStartTime, true)
+
+	workflow := testFetchStateWorkflow{
+		t:         t,
+		topoMap:   topoMap,
+		level:     topology.ReadConsistencyLevelAll,
+		startTime: testStartTime,
+		endTime:   testEndTime,
+		steps: []testFetchStateWorklowStep{
+			{
+				hostname:          "testhost0",
+				fetchTaggedResult: withStats,
+			},
+			{
+				hostname:          "testhost1",
+				fetchTaggedResult: withoutStats,
+				expectedDone:      true,
+			},
+		},
+	}
+
+	accum := workflow.run()
+
+	_, resultsMetadata, err := accum.AsTaggedIDsIterator(10, th.pools)
+	require.NoError(t, err)
+	require.Equal(t, 3, resultsMetadata.DocsRead)
+	require.Equal(t, 100, resultsMetadata.BytesRead)
+	require.Equal(t, 2, resultsMetadata.BlocksRead)
+
+	accum.Clear()
+	_, resultsMetadata, err = accum.AsTaggedIDsIterator(10, th.pools)
+	require.NoError(t, err)
+	require.Equal(t, 0, resultsMetadata.DocsRead)
+	require.Equal(t, 0, resultsMetadata.BytesRead)
+	require.Equal(t, 0, resultsMetadata.BlocksRead)
+}
+
+func TestFetchTaggedResultsAccumulatorPaged(t *testing.T) {
+	topoMap := testutil.MustNewTopologyMap(2, map[string][]shard.Shard{
+		"testhost0": testutil.ShardsRange(0, 29, shard.Available),
+		"testhost1": testutil.ShardsRange(0, 29, shard.Available),
+	})
+
+	th := newTestFetchTaggedHelper(t)
+	host0Page := newTestSerieses(1, 8).toRPCResult(th, testStartTime, true)
+	host0Page.NextPageToken = convert.EncodeFetchTaggedPageToken(
+		convert.FetchTaggedPageToken{LastID: []byte("id008"), Cursors: []uint64{1}})
+	host1Page := newTestSerieses(1, 5).toRPCResult(th, testStartTime, true)
+	host1Page.NextPageToken = convert.EncodeFetchTaggedPageToken(
+		convert.FetchTaggedPageToken{LastID: []byte("id005"), Cursors: []uint64{2}})
+
+	workflow := testFetchStateWorkflow{
+		t:         t,
+		topoMap:   topoMap,
+		level:     topology.ReadConsistencyLevelAll,
+		startTime: testStartTime,
+		endTime:   testEndTime,
+		steps: []testFetchStateWorklowStep{
+			{
+				hostname:          "testhost0",
+				fetchTaggedResult: host0Page,
+			},
+			{
+				hostname:          "testhost1",
+				fetchTaggedResult: host1Page,
+				expectedDone:      true,
+			},
+		},
+	}
+	accum := workflow.run()
+
+	// Only the series returned by every host are part of the page.
+	resultsIter, resultsMetadata, err := accum.AsTaggedIDsIterator(10, th.pools)
+	require.NoError(t, err)
+	require.True(t, resultsMetadata.Exhaustive)
+	nextPageToken, err := convert.DecodeFetchTaggedPageToken(resultsMetadata.NextPageToken)
+	require.NoError(t, err)
+	require.Equal(t, convert.FetchTaggedPageToken{
+		LastID:  []byte("id005"),
+		Cursors: []uint64{1, 2},
+	}, nextPageToken)
+	matcher := newTestSerieses(1, 5).indexMatcher()
+	require.True(t, matcher.Matches(resultsIter))
+
+	accum.Clear()
+	_, resultsMetadata, err = accum.AsTaggedIDsIterator(10, th.pools)
+	require.NoError(t, err)
+	require.Nil(t, resultsMetadata.NextPageToken)
+}
+
 func TestFetchTaggedResultsAccumulatorIdsMergeUnstrictMajority(t *testing.T) {
 	// rf=3, 3 identical hosts, with same shards
 	topoMap := testutil.MustNewTopologyMap(3, map[string][]shard.Shard{
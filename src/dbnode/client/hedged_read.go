@@ -0,0 +1,285 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/topology"
+)
+
+const (
+	// defaultHedgedReadLatencyPercentile is the default percentile of recent
+	// replica fetch latencies after which a read is hedged.
+	defaultHedgedReadLatencyPercentile = 0.95
+
+	// defaultHedgedReadMinDelay is the default minimum delay before a read
+	// is hedged.
+	defaultHedgedReadMinDelay = 10 * time.Millisecond
+
+	// hedgedReadLatencySamples is the number of recent replica fetch
+	// latencies the hedge delay is computed from.
+	hedgedReadLatencySamples = 1024
+
+	// hedgedReadRecomputeEvery is the number of latencies recorded between
+	// recomputing the hedge delay.
+	hedgedReadRecomputeEvery = 64
+)
+
+var errHedgedReadInvalidLatencyPercentile = errors.New(
+	"hedged read latency percentile must be in the range (0, 1]")
+
+// HedgedReadOptions are the options for hedged reads. When enabled, reads
+// at the One and UnstrictMajority consistency levels are first issued to
+// only as many replicas as are required to satisfy the level. The read is
+// issued to the remaining replicas once it has been outstanding longer than
+// the configured percentile of recent replica latencies, or as soon as one
+// of the first replicas returns an error.
+type HedgedReadOptions struct {
+	// Enabled enables hedged reads.
+	Enabled bool
+	// LatencyPercentile is the percentile of recent replica fetch latencies
+	// after which the remaining replicas are queried.
+	LatencyPercentile float64
+	// MinDelay is the minimum time to wait before querying the remaining
+	// replicas, also used until enough latencies have been observed.
+	MinDelay time.Duration
+}
+
+// NewHedgedReadOptions returns the default hedged read options.
+func NewHedgedReadOptions() HedgedReadOptions {
+	return HedgedReadOptions{
+		LatencyPercentile: defaultHedgedReadLatencyPercentile,
+		MinDelay:          defaultHedgedReadMinDelay,
+	}
+}
+
+// Validate validates the hedged read options.
+func (o HedgedReadOptions) Validate() error {
+	if !o.Enabled {
+		return nil
+	}
+	if o.LatencyPercentile <= 0 || o.LatencyPercentile > 1 {
+		return errHedgedReadInvalidLatencyPercentile
+	}
+	return nil
+}
+
+// hedgedReadInitialReplicas returns the number of replicas a hedged read
+// is first issued to.
+func hedgedReadInitialReplicas(
+	level topology.ReadConsistencyLevel,
+	replicas, majority int,
+) int {
+	var initial int
+	switch level {
+	case topology.ReadConsistencyLevelOne:
+		initial = 1
+	case topology.ReadConsistencyLevelUnstrictMajority:
+		initial = majority
+	default:
+		return replicas
+	}
+	if initial < 1 || initial > replicas {
+		return replicas
+	}
+	return initial
+}
+
+// hedgedReadLatencies tracks recent replica fetch latencies to derive the
+// delay after which reads are hedged.
+type hedgedReadLatencies struct {
+	sync.Mutex
+
+	percentile float64
+	minDelay   time.Duration
+	samples    []time.Duration
+	sorted     []time.Duration
+	next       int
+	recorded   int
+	delayNanos int64
+}
+
+func newHedgedReadLatencies(opts HedgedReadOptions) *hedgedReadLatencies {
+	return &hedgedReadLatencies{
+		percentile: opts.LatencyPercentile,
+		minDelay:   opts.MinDelay,
+		samples:    make([]time.Duration, 0, hedgedReadLatencySamples),
+		sorted:     make([]time.Duration, 0, hedgedReadLatencySamples),
+		delayNanos: int64(opts.MinDelay),
+	}
+}
+
+func (l *hedgedReadLatencies) record(latency time.Duration) {
+	l.Lock()
+	if len(l.samples) < cap(l.samples) {
+		l.samples = append(l.samples, latency)
+	} else {
+		l.samples[l.next] = latency
+		l.next = (l.next + 1) % len(l.samples)
+	}
+	l.recorded++
+	if l.recorded%hedgedReadRecomputeEvery == 0 {
+		l.recomputeWithLock()
+	}
+	l.Unlock()
+}
+
+func (l *hedgedReadLatencies) recomputeWithLock() {
+	l.sorted = append(l.sorted[:0], l.samples...)
+	sort.Slice(l.sorted, func(i, j int) bool {
+		return l.sorted[i] < l.sorted[j]
+	})
+	idx := int(math.Ceil(l.percentile*float64(len(l.sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	delay := l.sorted[idx]
+	if delay < l.minDelay {
+		delay = l.minDelay
+	}
+	atomic.StoreInt64(&l.delayNanos, int64(delay))
+}
+
+// delay returns the time after which outstanding reads are hedged.
+func (l *hedgedReadLatencies) delay() time.Duration {
+	return time.Duration(atomic.LoadInt64(&l.delayNanos))
+}
+
+// hedgedFetchBatch batches the fetches issued to the remaining replicas of
+// hedged reads by host queue.
+type hedgedFetchBatch struct {
+	session    *session
+	rangeStart int64
+	rangeEnd   int64
+	queues     []hostQueue
+	ops        []*fetchBatchOp
+}
+
+func (s *session) newHedgedFetchBatch(rangeStart, rangeEnd int64) *hedgedFetchBatch {
+	return &hedgedFetchBatch{
+		session:    s,
+		rangeStart: rangeStart,
+		rangeEnd:   rangeEnd,
+	}
+}
+
+// releaseHedgedFetches releases the reserved replicas of the hedged reads
+// that were not issued to them.
+func (s *session) releaseHedgedFetches(
+	hedges []func(issue bool, batch *hedgedFetchBatch) bool,
+) {
+	for _, hedge := range hedges {
+		hedge(false, nil)
+	}
+}
+
+func (b *hedgedFetchBatch) append(
+	queue hostQueue,
+	namespace, id []byte,
+	completionFn completionFn,
+) {
+	var f *fetchBatchOp
+	for i := len(b.queues) - 1; i >= 0; i-- {
+		if b.queues[i] == queue {
+			f = b.ops[i]
+			break
+		}
+	}
+	if f == nil || f.Size() >= b.session.fetchBatchSize {
+		f = b.session.pools.fetchBatchOp.Get()
+		f.IncRef()
+		f.request.RangeStart = b.rangeStart
+		f.request.RangeEnd = b.rangeEnd
+		f.request.RangeTimeType = rpc.TimeType_UNIX_NANOSECONDS
+		b.queues = append(b.queues, queue)
+		b.ops = append(b.ops, f)
+	}
+	f.append(namespace, id, completionFn)
+}
+
+func (b *hedgedFetchBatch) enqueue() {
+	for i, f := range b.ops {
+		// Passing ownership of the op itself to the host queue
+		f.DecRef()
+		if err := b.queues[i].Enqueue(f); err != nil {
+			f.completeAll(nil, err)
+		}
+	}
+}
+
+// hedgedFetchTagged holds the host queues reserved for hedging a fetch
+// tagged, the fetch is only enqueued to them once hedged.
+type hedgedFetchTagged struct {
+	session *session
+	op      op
+	queues  []hostQueue
+	start   time.Time
+	timer   *time.Timer
+}
+
+// hedgedFetchTaggedQueues splits the host queues into the queues a hedged
+// fetch tagged is first enqueued to, chosen so that each shard is served by
+// the initial number of replicas, and the queues reserved for hedging it.
+// The hosts are considered starting from the offset so that load is spread
+// evenly.
+func hedgedFetchTaggedQueues(
+	queues []hostQueue,
+	topoMap topology.Map,
+	initial, offset int,
+) ([]hostQueue, []hostQueue) {
+	var (
+		covered  = make(map[uint32]int)
+		issued   = make([]hostQueue, 0, len(queues))
+		reserved []hostQueue
+	)
+	for i := range queues {
+		queue := queues[(i+offset)%len(queues)]
+		hostShardSet, ok := topoMap.LookupHostShardSet(queue.Host().ID())
+		if ok {
+			needed := false
+			for _, s := range hostShardSet.ShardSet().All() {
+				if s.State() == shard.Available && covered[s.ID()] < initial {
+					needed = true
+					break
+				}
+			}
+			if !needed {
+				reserved = append(reserved, queue)
+				continue
+			}
+			for _, s := range hostShardSet.ShardSet().All() {
+				if s.State() == shard.Available {
+					covered[s.ID()]++
+				}
+			}
+		}
+		issued = append(issued, queue)
+	}
+	return issued, reserved
+}
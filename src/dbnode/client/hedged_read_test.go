@@ -0,0 +1,357 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func TestHedgedReadInitialReplicas(t *testing.T) {
+	tests := []struct {
+		level    topology.ReadConsistencyLevel
+		expected int
+	}{
+		{level: topology.ReadConsistencyLevelNone, expected: 3},
+		{level: topology.ReadConsistencyLevelOne, expected: 1},
+		{level: topology.ReadConsistencyLevelUnstrictMajority, expected: 2},
+		{level: topology.ReadConsistencyLevelMajority, expected: 3},
+		{level: topology.ReadConsistencyLevelUnstrictAll, expected: 3},
+		{level: topology.ReadConsistencyLevelAll, expected: 3},
+	}
+	for _, test := range tests {
+		t.Run(test.level.String(), func(t *testing.T) {
+			assert.Equal(t, test.expected, hedgedReadInitialReplicas(test.level, 3, 2))
+		})
+	}
+
+	// Fewer replicas routed than the majority requires.
+	assert.Equal(t, 1,
+		hedgedReadInitialReplicas(topology.ReadConsistencyLevelUnstrictMajority, 1, 2))
+}
+
+func TestHedgedReadLatenciesDelay(t *testing.T) {
+	opts := NewHedgedReadOptions()
+	opts.Enabled = true
+	opts.LatencyPercentile = 0.5
+	opts.MinDelay = 10 * time.Millisecond
+	require.NoError(t, opts.Validate())
+
+	latencies := newHedgedReadLatencies(opts)
+	assert.Equal(t, 10*time.Millisecond, latencies.delay())
+
+	for i := 1; i <= 2*hedgedReadRecomputeEvery; i++ {
+		latencies.record(time.Duration(i) * time.Millisecond)
+	}
+	assert.Equal(t, hedgedReadRecomputeEvery*time.Millisecond, latencies.delay())
+
+	// Never hedge sooner than the min delay.
+	for i := 0; i < hedgedReadLatencySamples; i++ {
+		latencies.record(time.Millisecond)
+	}
+	assert.Equal(t, 10*time.Millisecond, latencies.delay())
+}
+
+func TestHedgedReadOptionsValidate(t *testing.T) {
+	opts := NewHedgedReadOptions()
+	opts.LatencyPercentile = 0
+	assert.NoError(t, opts.Validate())
+
+	opts.Enabled = true
+	assert.Equal(t, errHedgedReadInvalidLatencyPercentile, opts.Validate())
+
+	opts.LatencyPercentile = 1
+	assert.NoError(t, opts.Validate())
+}
+
+func TestSessionFetchHedgedReadOnError(t *testing.T) {
+	issued := testSessionFetchHedgedRead(t, time.Minute,
+		func(enqueued int, op *fetchBatchOp, fulfill func(*fetchBatchOp)) {
+			if enqueued == 0 {
+				// Fail the first replica so the reserved replicas are
+				// queried without waiting for the hedge delay.
+				go op.completeAll(nil, fmt.Errorf("random failure"))
+				return
+			}
+			go fulfill(op)
+		})
+	assert.Equal(t, int64(1), issued["error"])
+	assert.Equal(t, int64(0), issued["delay"])
+}
+
+func TestSessionFetchHedgedReadOnDelay(t *testing.T) {
+	var (
+		stalledLock sync.Mutex
+		stalled     []*fetchBatchOp
+	)
+	issued := testSessionFetchHedgedRead(t, time.Millisecond,
+		func(enqueued int, op *fetchBatchOp, fulfill func(*fetchBatchOp)) {
+			if enqueued == 0 {
+				// Stall the first replica so the read is hedged.
+				stalledLock.Lock()
+				stalled = append(stalled, op)
+				stalledLock.Unlock()
+				return
+			}
+			go fulfill(op)
+		})
+	assert.Equal(t, int64(0), issued["error"])
+	assert.Equal(t, int64(1), issued["delay"])
+
+	stalledLock.Lock()
+	defer stalledLock.Unlock()
+	require.Equal(t, 1, len(stalled))
+	stalled[0].completeAll(nil, fmt.Errorf("timed out"))
+}
+
+func testSessionFetchHedgedRead(
+	t *testing.T,
+	minDelay time.Duration,
+	enqueueFn func(enqueued int, op *fetchBatchOp, fulfill func(*fetchBatchOp)),
+) map[string]int64 {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scope := tally.NewTestScope("", nil)
+	hedgedReadOpts := NewHedgedReadOptions()
+	hedgedReadOpts.Enabled = true
+	hedgedReadOpts.MinDelay = minDelay
+	opts := newSessionTestOptions().
+		SetReadConsistencyLevel(topology.ReadConsistencyLevelOne).
+		SetHedgedReadOptions(hedgedReadOpts)
+	opts = opts.SetInstrumentOptions(opts.InstrumentOptions().
+		SetMetricsScope(scope))
+	testOpts := testOptions{nsID: ident.StringID(testNamespaceName), opts: opts}
+
+	s, err := newSession(opts)
+	require.NoError(t, err)
+	session := s.(*session)
+
+	start := xtime.Now().Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+	fetches := testFetches([]testFetch{
+		{"foo", []testValue{
+			{1.0, start.Add(1 * time.Second), xtime.Second, nil},
+			{2.0, start.Add(2 * time.Second), xtime.Second, nil},
+		}},
+	})
+
+	var (
+		enqueuedLock sync.Mutex
+		enqueued     int
+	)
+	session.newHostQueueFn = func(
+		host topology.Host,
+		hostOpts hostQueueOpts,
+	) (hostQueue, error) {
+		hostQueue := NewMockhostQueue(ctrl)
+		hostQueue.EXPECT().Open()
+		hostQueue.EXPECT().Host().Return(host).AnyTimes()
+		hostQueue.EXPECT().ConnectionCount().Return(0).Times(sessionTestShards)
+		hostQueue.EXPECT().ConnectionCount().
+			Return(hostOpts.opts.MinConnectionCount()).Times(sessionTestShards)
+		hostQueue.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(o op) error {
+			fetch, ok := o.(*fetchBatchOp)
+			require.True(t, ok)
+
+			enqueuedLock.Lock()
+			n := enqueued
+			enqueued++
+			enqueuedLock.Unlock()
+
+			enqueueFn(n, fetch, func(op *fetchBatchOp) {
+				fulfillFetchBatchOps(t, testOpts, fetches, []*fetchBatchOp{op}, 0)
+			})
+			return nil
+		}).AnyTimes()
+		hostQueue.EXPECT().Close()
+		return hostQueue, nil
+	}
+
+	require.NoError(t, session.Open())
+
+	results, err := session.FetchIDs(ident.StringID(testNamespaceName),
+		fetches.IDsIter(), start, end)
+	require.NoError(t, err)
+	assertFetchResults(t, start, end, fetches, results, nil)
+
+	enqueuedLock.Lock()
+	// The first replica and the two reserved replicas.
+	assert.Equal(t, sessionTestReplicas, enqueued)
+	enqueuedLock.Unlock()
+
+	require.NoError(t, session.Close())
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["fetch.hedge.candidates+"].Value())
+	issued := make(map[string]int64)
+	for _, reason := range []string{"delay", "error"} {
+		if c, ok := counters["fetch.hedge.issued+reason="+reason]; ok {
+			issued[reason] = c.Value()
+		}
+	}
+	return issued
+}
+
+func TestSessionFetchTaggedHedgedReadOnError(t *testing.T) {
+	issued := testSessionFetchTaggedHedgedRead(t, time.Minute,
+		func(enqueued int, host topology.Host, op op, fulfill func()) {
+			if enqueued == 0 {
+				// Fail the first replica so the reserved replicas are
+				// queried without waiting for the hedge delay.
+				go op.CompletionFn()(fetchTaggedResultAccumulatorOpts{host: host},
+					fmt.Errorf("random failure"))
+				return
+			}
+			go fulfill()
+		})
+	assert.Equal(t, int64(1), issued["error"])
+	assert.Equal(t, int64(0), issued["delay"])
+}
+
+func TestSessionFetchTaggedHedgedReadOnDelay(t *testing.T) {
+	var (
+		stalledLock sync.Mutex
+		stalled     []func()
+	)
+	issued := testSessionFetchTaggedHedgedRead(t, time.Millisecond,
+		func(enqueued int, host topology.Host, op op, fulfill func()) {
+			if enqueued == 0 {
+				// Stall the first replica so the read is hedged.
+				stalledLock.Lock()
+				stalled = append(stalled, func() {
+					op.CompletionFn()(fetchTaggedResultAccumulatorOpts{host: host},
+						fmt.Errorf("timed out"))
+				})
+				stalledLock.Unlock()
+				return
+			}
+			go fulfill()
+		})
+	assert.Equal(t, int64(0), issued["error"])
+	assert.Equal(t, int64(1), issued["delay"])
+
+	stalledLock.Lock()
+	defer stalledLock.Unlock()
+	require.Equal(t, 1, len(stalled))
+	stalled[0]()
+}
+
+func testSessionFetchTaggedHedgedRead(
+	t *testing.T,
+	minDelay time.Duration,
+	enqueueFn func(enqueued int, host topology.Host, op op, fulfill func()),
+) map[string]int64 {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scope := tally.NewTestScope("", nil)
+	hedgedReadOpts := NewHedgedReadOptions()
+	hedgedReadOpts.Enabled = true
+	hedgedReadOpts.MinDelay = minDelay
+	opts := newSessionTestOptions().
+		SetReadConsistencyLevel(topology.ReadConsistencyLevelOne).
+		SetHedgedReadOptions(hedgedReadOpts)
+	opts = opts.SetInstrumentOptions(opts.InstrumentOptions().
+		SetMetricsScope(scope))
+
+	s, err := newSession(opts)
+	require.NoError(t, err)
+	session := s.(*session)
+
+	start := xtime.Now().Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	var (
+		sg = newTestSerieses(1, 5)
+		th = newTestFetchTaggedHelper(t)
+	)
+	sg.addDatapoints(100, start, end)
+
+	var (
+		enqueuedLock sync.Mutex
+		enqueued     int
+	)
+	session.newHostQueueFn = func(
+		host topology.Host,
+		hostOpts hostQueueOpts,
+	) (hostQueue, error) {
+		hostQueue := NewMockhostQueue(ctrl)
+		hostQueue.EXPECT().Open()
+		hostQueue.EXPECT().Host().Return(host).AnyTimes()
+		hostQueue.EXPECT().ConnectionCount().Return(0).Times(sessionTestShards)
+		hostQueue.EXPECT().ConnectionCount().
+			Return(hostOpts.opts.MinConnectionCount()).Times(sessionTestShards)
+		hostQueue.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(o op) error {
+			enqueuedLock.Lock()
+			n := enqueued
+			enqueued++
+			enqueuedLock.Unlock()
+
+			enqueueFn(n, host, o, func() {
+				o.CompletionFn()(fetchTaggedResultAccumulatorOpts{
+					host:     host,
+					response: sg.toRPCResult(th, start, true),
+				}, nil)
+			})
+			return nil
+		}).AnyTimes()
+		hostQueue.EXPECT().Close()
+		return hostQueue, nil
+	}
+
+	require.NoError(t, session.Open())
+
+	iters, meta, err := session.FetchTagged(testContext(),
+		ident.StringID(testNamespaceName), testSessionFetchTaggedQuery,
+		testSessionFetchTaggedQueryOpts(start, end))
+	require.NoError(t, err)
+	assert.True(t, meta.Exhaustive)
+	sg.assertMatchesEncodingIters(t, iters)
+
+	enqueuedLock.Lock()
+	// The first replica and the two reserved replicas.
+	assert.Equal(t, sessionTestReplicas, enqueued)
+	enqueuedLock.Unlock()
+
+	require.NoError(t, session.Close())
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["fetch.hedge.candidates+"].Value())
+	issued := make(map[string]int64)
+	for _, reason := range []string{"delay", "error"} {
+		if c, ok := counters["fetch.hedge.issued+reason="+reason]; ok {
+			issued[reason] = c.Value()
+		}
+	}
+	return issued
+}
@@ -76,6 +76,7 @@ type queue struct {
 	drainIn                                      chan []op
 	writeOpBatchSize                             tally.Histogram
 	fetchOpBatchSize                             tally.Histogram
+	writeOverloadBackoff                         *writeOverloadBackoff
 	status                                       status
 	serverSupportsV2APIs                         bool
 }
@@ -154,6 +155,7 @@ func newHostQueue(
 		opsArrayPool:                                 opArrayPool,
 		writeOpBatchSize:                             scope.Histogram("write-op-batch-size", writeOpBatchSizeBuckets),
 		fetchOpBatchSize:                             scope.Histogram("fetch-op-batch-size", fetchOpBatchSizeBuckets),
+		writeOverloadBackoff:                         newWriteOverloadBackoff(host.ID(), opts, scope),
 		drainIn:                                      make(chan []op, opsArrayLen),
 		serverSupportsV2APIs:                         opts.UseV2BatchAPIs(),
 	}, nil
@@ -279,6 +281,10 @@ func (q *queue) drain() {
 				}
 			case *truncateOp:
 				q.asyncTruncate(v)
+			case *deleteTaggedOp:
+				q.asyncDeleteTagged(v)
+			case *cardinalityOp:
+				q.asyncCardinality(v)
 			default:
 				completionFn := ops[i].CompletionFn()
 				completionFn(nil, errQueueUnknownOperation(q.host.ID()))
@@ -537,6 +543,11 @@ func (q *queue) asyncTaggedWrite(
 			q.Done()
 		}
 
+		if q.shedIfBackingOff(ops) {
+			cleanup()
+			return
+		}
+
 		// NB(bl): host is passed to writeState to determine the state of the
 		// shard on the node we're writing to
 
@@ -550,6 +561,7 @@ func (q *queue) asyncTaggedWrite(
 
 		ctx, _ := thrift.NewContext(q.opts.WriteRequestTimeout())
 		err = client.WriteTaggedBatchRaw(ctx, req)
+		q.writeOverloadBackoff.update(err)
 		if err == nil {
 			// All succeeded
 			callAllCompletionFns(ops, q.host, nil)
@@ -598,6 +610,11 @@ func (q *queue) asyncTaggedWriteV2(
 			q.Done()
 		}
 
+		if q.shedIfBackingOff(ops) {
+			cleanup()
+			return
+		}
+
 		// NB(bl): host is passed to writeState to determine the state of the
 		// shard on the node we're writing to.
 		client, _, err := q.connPool.NextClient()
@@ -610,6 +627,7 @@ func (q *queue) asyncTaggedWriteV2(
 
 		ctx, _ := thrift.NewContext(q.opts.WriteRequestTimeout())
 		err = client.WriteTaggedBatchRawV2(ctx, req)
+		q.writeOverloadBackoff.update(err)
 		if err == nil {
 			// All succeeded
 			callAllCompletionFns(ops, q.host, nil)
@@ -642,6 +660,17 @@ func (q *queue) asyncTaggedWriteV2(
 	})
 }
 
+// shedIfBackingOff fails the ops with the backoff error and returns true if
+// writes are backing off from the host being overloaded.
+func (q *queue) shedIfBackingOff(ops []op) bool {
+	err := q.writeOverloadBackoff.check()
+	if err == nil {
+		return false
+	}
+	callAllCompletionFns(ops, q.host, err)
+	return true
+}
+
 func (q *queue) asyncWrite(
 	namespace ident.ID,
 	ops []op,
@@ -662,6 +691,11 @@ func (q *queue) asyncWrite(
 			q.Done()
 		}
 
+		if q.shedIfBackingOff(ops) {
+			cleanup()
+			return
+		}
+
 		// NB(bl): host is passed to writeState to determine the state of the
 		// shard on the node we're writing to
 
@@ -675,6 +709,7 @@ func (q *queue) asyncWrite(
 
 		ctx, _ := thrift.NewContext(q.opts.WriteRequestTimeout())
 		err = client.WriteBatchRaw(ctx, req)
+		q.writeOverloadBackoff.update(err)
 		if err == nil {
 			// All succeeded
 			callAllCompletionFns(ops, q.host, nil)
@@ -722,6 +757,11 @@ func (q *queue) asyncWriteV2(
 			q.Done()
 		}
 
+		if q.shedIfBackingOff(ops) {
+			cleanup()
+			return
+		}
+
 		// NB(bl): host is passed to writeState to determine the state of the
 		// shard on the node we're writing to.
 		client, _, err := q.connPool.NextClient()
@@ -734,6 +774,7 @@ func (q *queue) asyncWriteV2(
 
 		ctx, _ := thrift.NewContext(q.opts.WriteRequestTimeout())
 		err = client.WriteBatchRawV2(ctx, req)
+		q.writeOverloadBackoff.update(err)
 		if err == nil {
 			// All succeeded.
 			callAllCompletionFns(ops, q.host, nil)
@@ -983,6 +1024,56 @@ func (q *queue) asyncTruncate(op *truncateOp) {
 	})
 }
 
+func (q *queue) asyncDeleteTagged(op *deleteTaggedOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, _, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.TruncateRequestTimeout())
+		if res, err := client.DeleteTagged(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
+func (q *queue) asyncCardinality(op *cardinalityOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, _, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		if res, err := client.Cardinality(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
 func (q *queue) mustWrapAndCheckContext(
 	callingContext context.Context,
 	method string,
@@ -291,6 +291,8 @@ type options struct {
 	asyncWriteMaxConcurrency                int
 	useV2BatchAPIs                          bool
 	iterationOptions                        index.IterationOptions
+	hedgedReadOptions                       HedgedReadOptions
+	writeOverloadBackoffOptions             WriteOverloadBackoffOptions
 	writeTimestampOffset                    time.Duration
 	namespaceInitializer                    namespace.Initializer
 	thriftContextFn                         ThriftContextFn
@@ -442,6 +444,8 @@ func newOptions() *options {
 		asyncTopologyInitializers:               []topology.Initializer{},
 		asyncWriteMaxConcurrency:                defaultAsyncWriteMaxConcurrency,
 		useV2BatchAPIs:                          defaultUseV2BatchAPIs,
+		hedgedReadOptions:                       NewHedgedReadOptions(),
+		writeOverloadBackoffOptions:             NewWriteOverloadBackoffOptions(),
 		thriftContextFn:                         defaultThriftContextFn,
 	}
 	return opts.SetEncodingM3TSZ().(*options)
@@ -474,6 +478,12 @@ func validate(opts *options) error {
 	); err != nil {
 		return err
 	}
+	if err := opts.hedgedReadOptions.Validate(); err != nil {
+		return err
+	}
+	if err := opts.writeOverloadBackoffOptions.Validate(); err != nil {
+		return err
+	}
 	return opts.logErrorSampleRate.Validate()
 }
 
@@ -1114,6 +1124,26 @@ func (o *options) IterationOptions() index.IterationOptions {
 	return o.iterationOptions
 }
 
+func (o *options) SetHedgedReadOptions(value HedgedReadOptions) Options {
+	opts := *o
+	opts.hedgedReadOptions = value
+	return &opts
+}
+
+func (o *options) HedgedReadOptions() HedgedReadOptions {
+	return o.hedgedReadOptions
+}
+
+func (o *options) SetWriteOverloadBackoffOptions(value WriteOverloadBackoffOptions) Options {
+	opts := *o
+	opts.writeOverloadBackoffOptions = value
+	return &opts
+}
+
+func (o *options) WriteOverloadBackoffOptions() WriteOverloadBackoffOptions {
+	return o.writeOverloadBackoffOptions
+}
+
 func (o *options) SetWriteTimestampOffset(value time.Duration) AdminOptions {
 	opts := *o
 	opts.writeTimestampOffset = value
@@ -292,6 +292,17 @@ func (s replicatedSession) FetchTagged(
 	return s.session.FetchTagged(ctx, namespace, q, opts)
 }
 
+// FetchTaggedPages resolves the provided query to known IDs, and fetches the
+// data for them a page of series at a time.
+func (s replicatedSession) FetchTaggedPages(
+	ctx context.Context,
+	namespace ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+) (FetchTaggedPagesIterator, error) {
+	return s.session.FetchTaggedPages(ctx, namespace, q, opts)
+}
+
 // FetchTaggedIDs resolves the provided query to known IDs.
 func (s replicatedSession) FetchTaggedIDs(
 	ctx context.Context,
@@ -348,6 +359,26 @@ func (s replicatedSession) Truncate(namespace ident.ID) (int64, error) {
 	return s.session.Truncate(namespace)
 }
 
+// DeleteTagged will delete the data within [start, end) of the series
+// matching the query on every host of the primary session.
+func (s replicatedSession) DeleteTagged(
+	namespace ident.ID,
+	q index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	return s.session.DeleteTagged(namespace, q, start, end)
+}
+
+// Cardinality returns the cardinality statistics of the series matching
+// the query, merged across every host of the primary session.
+func (s replicatedSession) Cardinality(
+	namespace ident.ID,
+	q index.Query,
+	opts index.CardinalityOptions,
+) (index.CardinalityQueryResult, error) {
+	return s.session.Cardinality(namespace, q, opts)
+}
+
 // FetchBootstrapBlocksFromPeers will fetch the most fulfilled block
 // for each series using the runtime configurable bootstrap level consistency.
 func (s replicatedSession) FetchBootstrapBlocksFromPeers(
@@ -382,6 +413,32 @@ func (s replicatedSession) FetchBlocksMetadataFromPeers(
 	return s.session.FetchBlocksMetadataFromPeers(namespace, shard, start, end, consistencyLevel, result)
 }
 
+// FetchMerkleNodesFromPeer will fetch the hashes of the nodes of the
+// merkle tree of a shard block from a peer.
+func (s replicatedSession) FetchMerkleNodesFromPeer(
+	peer topology.Host,
+	namespace ident.ID,
+	shard uint32,
+	blockStart xtime.UnixNano,
+	depth int,
+	nodes []int,
+) ([]uint64, error) {
+	return s.session.FetchMerkleNodesFromPeer(peer, namespace, shard, blockStart, depth, nodes)
+}
+
+// FetchMerkleLeavesFromPeer will fetch the blocks metadata of the series
+// belonging to the leaves of the merkle tree of a shard block from a peer.
+func (s replicatedSession) FetchMerkleLeavesFromPeer(
+	peer topology.Host,
+	namespace ident.ID,
+	shard uint32,
+	blockStart xtime.UnixNano,
+	depth int,
+	leaves []int,
+) (PeerBlockMetadataIter, error) {
+	return s.session.FetchMerkleLeavesFromPeer(peer, namespace, shard, blockStart, depth, leaves)
+}
+
 // FetchBlocksFromPeers will fetch the required blocks from the
 // peers specified.
 func (s replicatedSession) FetchBlocksFromPeers(
@@ -172,6 +172,8 @@ type session struct {
 	streamBlocksBatchTimeout             time.Duration
 	writeShardsInitializing              bool
 	shardsLeavingCountTowardsConsistency bool
+	hedgedReadLatencies                  *hedgedReadLatencies
+	hedgedReadOffset                     uint32
 	metrics                              sessionMetrics
 }
 
@@ -194,6 +196,9 @@ type sessionMetrics struct {
 	fetchLatencyHistogram                tally.Histogram
 	fetchNodesRespondingErrors           []tally.Counter
 	fetchNodesRespondingBadRequestErrors []tally.Counter
+	fetchHedgeCandidates                 tally.Counter
+	fetchHedgedOnDelay                   tally.Counter
+	fetchHedgedOnError                   tally.Counter
 	topologyUpdatedSuccess               tally.Counter
 	topologyUpdatedError                 tally.Counter
 	streamFromPeersMetrics               map[shardMetricsKey]streamFromPeersMetrics
@@ -216,7 +221,14 @@ func newSessionMetrics(scope tally.Scope) sessionMetrics {
 		fetchErrorsInternalError: scope.Tagged(map[string]string{
 			"error_type": "internal_error",
 		}).Counter("fetch.errors"),
-		fetchLatencyHistogram:  histogramWithDurationBuckets(scope, "fetch.latency"),
+		fetchLatencyHistogram: histogramWithDurationBuckets(scope, "fetch.latency"),
+		fetchHedgeCandidates:  scope.Counter("fetch.hedge.candidates"),
+		fetchHedgedOnDelay: scope.Tagged(map[string]string{
+			"reason": "delay",
+		}).Counter("fetch.hedge.issued"),
+		fetchHedgedOnError: scope.Tagged(map[string]string{
+			"reason": "error",
+		}).Counter("fetch.hedge.issued"),
 		topologyUpdatedSuccess: scope.Counter("topology.updated-success"),
 		topologyUpdatedError:   scope.Counter("topology.updated-error"),
 		streamFromPeersMetrics: make(map[shardMetricsKey]streamFromPeersMetrics),
@@ -307,6 +319,9 @@ func newSession(opts Options) (clientSession, error) {
 		shardsLeavingCountTowardsConsistency: opts.ShardsLeavingCountTowardsConsistency(),
 		metrics:                              newSessionMetrics(scope),
 	}
+	if hedgedReadOpts := opts.HedgedReadOptions(); hedgedReadOpts.Enabled {
+		s.hedgedReadLatencies = newHedgedReadLatencies(hedgedReadOpts)
+	}
 	s.reattemptStreamBlocksFromPeersFn = s.streamBlocksReattemptFromPeers
 	s.pickBestPeerFn = s.streamBlocksPickBestPeer
 	writeAttemptPoolOpts := pool.NewObjectPoolOptions().
@@ -1542,6 +1557,7 @@ func (s *session) aggregateAttempt(
 	// must Unlock before calling `asEncodingSeriesIterators` as the latter needs to acquire
 	// the fetchState Lock
 	fetchState.Unlock()
+	fetchState.stopHedge()
 	iters, meta, err := fetchState.asAggregatedTagsIterator(s.pools, opts.SeriesLimit)
 
 	// must Unlock() before decRef'ing, as the latter releases the fetchState back into a
@@ -1568,6 +1584,15 @@ func (s *session) FetchTagged(
 	return iters, metadata, err
 }
 
+func (s *session) FetchTaggedPages(
+	ctx gocontext.Context,
+	ns ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+) (FetchTaggedPagesIterator, error) {
+	return newFetchTaggedPagesIterator(ctx, ns, q, opts, s.FetchTagged)
+}
+
 func (s *session) FetchTaggedIDs(
 	ctx gocontext.Context,
 	ns ident.ID,
@@ -1717,6 +1742,7 @@ func (s *session) fetchTaggedIDsAttempt(
 	// must Unlock before calling `asTaggedIDsIterator` as the latter needs to acquire
 	// the fetchState Lock
 	fetchState.Unlock()
+	fetchState.stopHedge()
 	iter, metadata, err := fetchState.asTaggedIDsIterator(s.pools, opts.SeriesLimit)
 
 	// must Unlock() before decRef'ing, as the latter releases the fetchState back into a
@@ -1788,8 +1814,30 @@ func (s *session) newFetchStateWithRLock(
 			"unknown fetchState type: %v", opts.stateType))
 	}
 
+	queues := s.state.queues
+	if opts.stateType == fetchTaggedFetchState && s.hedgedReadLatencies != nil {
+		replicas := topoMap.Replicas()
+		initial := hedgedReadInitialReplicas(readLevel, replicas, s.state.majority)
+		if initial < replicas {
+			var (
+				offset   = int(atomic.AddUint32(&s.hedgedReadOffset, 1) % uint32(len(queues)))
+				reserved []hostQueue
+			)
+			queues, reserved = hedgedFetchTaggedQueues(queues, topoMap, initial, offset)
+			if len(reserved) > 0 {
+				s.metrics.fetchHedgeCandidates.Inc(1)
+				fetchState.hedge = &hedgedFetchTagged{
+					session: s,
+					op:      op,
+					queues:  reserved,
+					start:   s.nowFn(),
+				}
+			}
+		}
+	}
+
 	fetchState.Lock()
-	for _, hq := range s.state.queues {
+	for _, hq := range queues {
 		// inc to indicate the hostQueue has a reference to `op` which has a ref to the fetchState
 		fetchState.incRef()
 		if err := hq.Enqueue(op); err != nil {
@@ -1810,6 +1858,12 @@ func (s *session) newFetchStateWithRLock(
 
 	closer() // release the ref for the current go-routine
 
+	if h := fetchState.hedge; h != nil {
+		// inc to indicate the hedge timer has a reference to the fetchState
+		fetchState.incRef()
+		h.timer = time.AfterFunc(s.hedgedReadLatencies.delay(), fetchState.hedgeOnDelay)
+	}
+
 	// NB(prateek): the calling go-routine still holds the lock and a ref
 	// on the returned fetchState object.
 	return fetchState, nil
@@ -1894,6 +1948,15 @@ func (s *session) fetchIDsAttempt(
 	// once it's value reaches 0.
 	namespaceAccessors := int32(0)
 
+	var (
+		// hedges issue the read of each hedged ID to its reserved replicas,
+		// or release the reserved replicas if the read has completed.
+		hedges     []func(issue bool, batch *hedgedFetchBatch) bool
+		hedgeTimer *time.Timer
+		hedging    = s.hedgedReadLatencies != nil
+		routes     = make([]int, 0, numReplicas)
+	)
+
 	for idx := 0; ids.Next(); idx++ {
 		var (
 			idx  = idx // capture loop variable
@@ -1914,12 +1977,26 @@ func (s *session) fetchIDsAttempt(
 			success          int32
 			errors           []error
 			errs             int32
+			hedgeQueues      []hostQueue
+			hedged           int32
 		)
 
 		// increment namespaceAccesors by 1 to indicate it still needs to be handled by the
 		// allCompletionFn for tsID.
 		atomic.AddInt32(&namespaceAccessors, 1)
 
+		releaseFn := func() {
+			if atomic.AddInt32(&resultsAccessors, -1) == 0 {
+				s.pools.multiReaderIteratorArray.Put(results)
+			}
+			if atomic.AddInt32(&idAccessors, -1) == 0 {
+				tsID.Finalize()
+			}
+			if atomic.AddInt32(&namespaceAccessors, -1) == 0 {
+				namespace.Finalize()
+			}
+		}
+
 		wg.Add(1)
 		allCompletionFn := func() {
 			var reportErrors []error
@@ -1970,15 +2047,7 @@ func (s *session) fetchIDsAttempt(
 				})
 				iters.SetAt(idx, iter)
 			}
-			if atomic.AddInt32(&resultsAccessors, -1) == 0 {
-				s.pools.multiReaderIteratorArray.Put(results)
-			}
-			if atomic.AddInt32(&idAccessors, -1) == 0 {
-				tsID.Finalize()
-			}
-			if atomic.AddInt32(&namespaceAccessors, -1) == 0 {
-				namespace.Finalize()
-			}
+			releaseFn()
 			wg.Done()
 		}
 		completionFn := func(result interface{}, err error) {
@@ -2023,23 +2092,70 @@ func (s *session) fetchIDsAttempt(
 				allCompletionFn()
 			}
 
-			if atomic.AddInt32(&resultsAccessors, -1) == 0 {
-				s.pools.multiReaderIteratorArray.Put(results)
-			}
-			if atomic.AddInt32(&idAccessors, -1) == 0 {
-				tsID.Finalize()
-			}
-			if atomic.AddInt32(&namespaceAccessors, -1) == 0 {
-				namespace.Finalize()
-			}
+			releaseFn()
 		}
 
+		routes = routes[:0]
 		if err := s.state.topoMap.RouteForEach(tsID, func(
 			hostIdx int,
-			hostShard shard.Shard,
-			host topology.Host,
+			_ shard.Shard,
+			_ topology.Host,
 		) {
-			// Inc safely as this for each is sequential
+			routes = append(routes, hostIdx)
+		}); err != nil {
+			routeErr = err
+			break
+		}
+
+		var (
+			initial             = len(routes)
+			offset              int
+			initialCompletionFn = completionFn
+		)
+		if hedging {
+			initial = hedgedReadInitialReplicas(readLevel, len(routes), int(majority))
+		}
+		if initial < len(routes) {
+			// Rotate the replicas queried first so that load is spread evenly.
+			offset = int(atomic.AddUint32(&s.hedgedReadOffset, 1) % uint32(len(routes)))
+			s.metrics.fetchHedgeCandidates.Inc(1)
+
+			hedge := func(issue bool, batch *hedgedFetchBatch) bool {
+				if !atomic.CompareAndSwapInt32(&hedged, 0, 1) {
+					return false
+				}
+				if issue && atomic.LoadInt32(&wgIsDone) == 0 {
+					for _, queue := range hedgeQueues {
+						batch.append(queue, namespace.Bytes(), tsID.Bytes(), completionFn)
+					}
+					return true
+				}
+				// The read completed without the reserved replicas.
+				for range hedgeQueues {
+					releaseFn()
+				}
+				return false
+			}
+			hedges = append(hedges, hedge)
+
+			initialCompletionFn = func(result interface{}, err error) {
+				if err != nil {
+					// Query the reserved replicas right away rather than
+					// waiting for the hedge delay.
+					batch := s.newHedgedFetchBatch(rangeStart, rangeEnd)
+					if hedge(true, batch) {
+						s.metrics.fetchHedgedOnError.Inc(1)
+					}
+					batch.enqueue()
+				} else {
+					s.hedgedReadLatencies.record(s.nowFn().Sub(startFetchAttempt))
+				}
+				completionFn(result, err)
+			}
+		}
+
+		for i, hostIdx := range routes {
+			// Inc safely as this loop is sequential
 			enqueued++
 			pending++
 			allPending++
@@ -2047,6 +2163,12 @@ func (s *session) fetchIDsAttempt(
 			namespaceAccessors++
 			idAccessors++
 
+			if (i+len(routes)-offset)%len(routes) >= initial {
+				// Reserve the replica to be queried if the read is hedged.
+				hedgeQueues = append(hedgeQueues, s.state.queues[hostIdx])
+				continue
+			}
+
 			ops := fetchBatchOpsByHostIdx[hostIdx]
 
 			var f *fetchBatchOp
@@ -2068,10 +2190,7 @@ func (s *session) fetchIDsAttempt(
 			}
 
 			// Append IDWithNamespace to this request
-			f.append(namespace.Bytes(), tsID.Bytes(), completionFn)
-		}); err != nil {
-			routeErr = err
-			break
+			f.append(namespace.Bytes(), tsID.Bytes(), initialCompletionFn)
 		}
 
 		// Once we've enqueued we know how many to expect so retrieve and set length
@@ -2102,12 +2221,30 @@ func (s *session) fetchIDsAttempt(
 	s.state.RUnlock()
 
 	if enqueueErr != nil {
+		s.releaseHedgedFetches(hedges)
 		s.log.Error("failed to enqueue fetch", zap.Error(enqueueErr))
 		return nil, enqueueErr
 	}
 
+	if len(hedges) > 0 {
+		hedgeTimer = time.AfterFunc(s.hedgedReadLatencies.delay(), func() {
+			batch := s.newHedgedFetchBatch(rangeStart, rangeEnd)
+			for _, hedge := range hedges {
+				if hedge(true, batch) {
+					s.metrics.fetchHedgedOnDelay.Inc(1)
+				}
+			}
+			batch.enqueue()
+		})
+	}
+
 	wg.Wait()
 
+	if hedgeTimer != nil {
+		hedgeTimer.Stop()
+		s.releaseHedgedFetches(hedges)
+	}
+
 	resultErrLock.RLock()
 	retErr := resultErr
 	resultErrLock.RUnlock()
@@ -2257,6 +2394,111 @@ func (s *session) Truncate(namespace ident.ID) (int64, error) {
 	return truncated, resultErr.FinalError()
 }
 
+func (s *session) DeleteTagged(
+	namespace ident.ID,
+	q index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	var (
+		wg         sync.WaitGroup
+		enqueueErr xerrors.MultiError
+	)
+
+	req, err := convert.ToRPCDeleteTaggedRequest(namespace, q, start, end)
+	if err != nil {
+		return 0, xerrors.NewInvalidParamsError(err)
+	}
+
+	s.state.RLock()
+	var (
+		level       = s.state.writeLevel
+		enqueued    = len(s.state.queues)
+		accumulator = newDeleteTaggedAccumulator(s.state.topoMap)
+	)
+	for _, queue := range s.state.queues {
+		host := queue.Host()
+		d := &deleteTaggedOp{request: req}
+		d.completionFn = func(result interface{}, err error) {
+			res, _ := result.(*rpc.DeleteTaggedResult_)
+			accumulator.add(host, res, err)
+			wg.Done()
+		}
+
+		wg.Add(1)
+		if err := queue.Enqueue(d); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Error("failed to enqueue request", zap.Error(err))
+		return 0, err
+	}
+
+	// Wait for the series to be deleted on all replicas, the delete is
+	// sent to every replica regardless of the write consistency level.
+	wg.Wait()
+
+	return accumulator.result(level, enqueued)
+}
+
+func (s *session) Cardinality(
+	namespace ident.ID,
+	q index.Query,
+	opts index.CardinalityOptions,
+) (index.CardinalityQueryResult, error) {
+	var (
+		wg            sync.WaitGroup
+		enqueueErr    xerrors.MultiError
+		resultErrLock sync.Mutex
+		resultErr     xerrors.MultiError
+		accumulator   = newCardinalityAccumulator()
+	)
+
+	req, err := convert.ToRPCCardinalityRequest(namespace, q, opts)
+	if err != nil {
+		return index.CardinalityQueryResult{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	c := &cardinalityOp{request: req}
+	c.completionFn = func(result interface{}, err error) {
+		if err != nil {
+			resultErrLock.Lock()
+			resultErr = resultErr.Add(err)
+			resultErrLock.Unlock()
+		} else {
+			accumulator.add(result.(*rpc.CardinalityResult_))
+		}
+		wg.Done()
+	}
+
+	s.state.RLock()
+	replicas := s.state.replicas
+	for idx := range s.state.queues {
+		wg.Add(1)
+		if err := s.state.queues[idx].Enqueue(c); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Error("failed to enqueue request", zap.Error(err))
+		return index.CardinalityQueryResult{}, err
+	}
+
+	// Wait for every host to return its statistics
+	wg.Wait()
+
+	if err := resultErr.FinalError(); err != nil {
+		return index.CardinalityQueryResult{}, err
+	}
+	return accumulator.result(replicas, opts.TopN), nil
+}
+
 // NB(r): Excluding maligned struct check here as we can
 // live with a few extra bytes since this struct is only
 // ever passed by stack, its much more readable not optimized
@@ -2338,6 +2580,129 @@ func (s *session) FetchBlocksMetadataFromPeers(
 		shard, start, end, level, resultOpts)
 }
 
+func (s *session) FetchMerkleNodesFromPeer(
+	peer topology.Host,
+	namespace ident.ID,
+	shard uint32,
+	blockStart xtime.UnixNano,
+	depth int,
+	nodes []int,
+) ([]uint64, error) {
+	req := rpc.NewFetchMerkleNodesRawRequest()
+	req.NameSpace = namespace.Bytes()
+	req.Shard = int32(shard)
+	req.BlockStart = int64(blockStart)
+	req.Depth = int32(depth)
+	req.Nodes = make([]int64, 0, len(nodes))
+	for _, node := range nodes {
+		req.Nodes = append(req.Nodes, int64(node))
+	}
+
+	var result *rpc.FetchMerkleNodesRawResult_
+	err := s.attemptPeerRequest(peer, func(client rpc.TChanNode) error {
+		tctx, _ := thrift.NewContext(s.streamBlocksMetadataBatchTimeout)
+		var err error
+		result, err = client.FetchMerkleNodesRaw(tctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Hashes) != len(nodes) {
+		return nil, fmt.Errorf("expected %d merkle node hashes from peer %s, received %d",
+			len(nodes), peer.ID(), len(result.Hashes))
+	}
+
+	hashes := make([]uint64, 0, len(result.Hashes))
+	for _, hash := range result.Hashes {
+		hashes = append(hashes, uint64(hash))
+	}
+	return hashes, nil
+}
+
+func (s *session) FetchMerkleLeavesFromPeer(
+	peer topology.Host,
+	namespace ident.ID,
+	shard uint32,
+	blockStart xtime.UnixNano,
+	depth int,
+	leaves []int,
+) (PeerBlockMetadataIter, error) {
+	req := rpc.NewFetchMerkleLeavesRawRequest()
+	req.NameSpace = namespace.Bytes()
+	req.Shard = int32(shard)
+	req.BlockStart = int64(blockStart)
+	req.Depth = int32(depth)
+	req.Leaves = make([]int64, 0, len(leaves))
+	for _, leaf := range leaves {
+		req.Leaves = append(req.Leaves, int64(leaf))
+	}
+
+	var result *rpc.FetchMerkleLeavesRawResult_
+	err := s.attemptPeerRequest(peer, func(client rpc.TChanNode) error {
+		tctx, _ := thrift.NewContext(s.streamBlocksMetadataBatchTimeout)
+		var err error
+		result, err = client.FetchMerkleLeavesRaw(tctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The metadata has been fetched in full so buffer it for the iterator.
+	var (
+		p          = newPeer(s, peer)
+		metadataCh = make(chan receivedBlockMetadata, len(result.Elements))
+		errCh      = make(chan error, 1)
+	)
+	for _, elem := range result.Elements {
+		var encodedTags checked.Bytes
+		if len(elem.EncodedTags) != 0 {
+			encodedTags = checked.NewBytes(elem.EncodedTags, nil)
+		}
+
+		m := receivedBlockMetadata{
+			peer:        p,
+			id:          ident.BytesID(elem.ID),
+			encodedTags: encodedTags,
+			block: blockMetadata{
+				start: xtime.UnixNano(elem.Start),
+			},
+		}
+		// Leave an errored block with a zeroed checksum so it mismatches.
+		if elem.Err == nil {
+			if elem.Size != nil {
+				m.block.size = *elem.Size
+			}
+			if elem.Checksum != nil {
+				value := uint32(*elem.Checksum)
+				m.block.checksum = &value
+			}
+		}
+		metadataCh <- m
+	}
+	close(metadataCh)
+	close(errCh)
+
+	return newMetadataIter(metadataCh, errCh,
+		s.pools.tagDecoder, s.pools.id), nil
+}
+
+// attemptPeerRequest borrows a connection to the peer to execute a request
+// with retries.
+func (s *session) attemptPeerRequest(
+	peer topology.Host,
+	fn func(client rpc.TChanNode) error,
+) error {
+	return s.streamBlocksRetrier.Attempt(func() error {
+		var attemptErr error
+		borrowErr := s.BorrowConnection(peer.ID(), func(client rpc.TChanNode, _ Channel) {
+			attemptErr = fn(client)
+		})
+		return xerrors.FirstError(borrowErr, attemptErr)
+	})
+}
+
 func (s *session) fetchBlocksMetadataFromPeers(
 	namespace ident.ID,
 	shard uint32,
@@ -3343,7 +3708,7 @@ func (s *session) streamBlocksBatchFromPeer(
 		reqBlocksLen uint
 
 		nowFn              = opts.ClockOptions().NowFn()
-		ropts              = namespaceMetadata.Options().RetentionOptions()
+		ropts              = namespace.BlockRetentionOptions(namespaceMetadata.Options())
 		retention          = ropts.RetentionPeriod()
 		earliestBlockStart = xtime.ToUnixNano(nowFn()).
 					Add(-retention).
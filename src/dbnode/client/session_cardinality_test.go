@@ -0,0 +1,103 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardinality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	var (
+		end   = xtime.Now()
+		start = end.Add(-time.Hour)
+		q     = index.Query{Query: idx.NewTermQuery([]byte("foo"), []byte("bar"))}
+	)
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			cardinality, ok := op.(*cardinalityOp)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("metrics"), cardinality.request.NameSpace)
+			assert.Equal(t, int64(start), cardinality.request.RangeStart)
+			assert.Equal(t, int64(end), cardinality.request.RangeEnd)
+			assert.Equal(t, int64(2), cardinality.request.Limit)
+
+			// Every host owns one replica of each shard so series counts
+			// are split unevenly but sum to the replicated total.
+			result := &rpc.CardinalityResult_{
+				SeriesCountByMetricName: []*rpc.CardinalityEntry{
+					{Name: []byte("cpu"), Value: int64(2 + idx)},
+					{Name: []byte("mem"), Value: 1},
+				},
+				LabelValueCountByLabelName: []*rpc.CardinalityEntry{
+					{Name: []byte("host"), Value: int64(3 + idx)},
+				},
+				SeriesCountByLabelValuePair: []*rpc.CardinalityEntry{
+					{Name: []byte("host=a"), Value: 2},
+				},
+				Exhaustive: idx != 1,
+			}
+			cardinality.completionFn(result, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	res, err := s.Cardinality(ident.StringID("metrics"), q, index.CardinalityOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+		},
+		TopN: 2,
+	})
+	require.NoError(t, err)
+	assert.False(t, res.Exhaustive)
+	assert.Equal(t, []index.CardinalityEntry{
+		{Name: "cpu", Value: 3},
+		{Name: "mem", Value: 1},
+	}, res.Stats.SeriesCountByMetricName)
+	assert.Equal(t, []index.CardinalityEntry{
+		{Name: "host", Value: 5},
+	}, res.Stats.LabelValueCountByLabelName)
+	assert.Equal(t, []index.CardinalityEntry{
+		{Name: "host=a", Value: 2},
+	}, res.Stats.SeriesCountByLabelValuePair)
+
+	assert.NoError(t, session.Close())
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteTagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	var (
+		end   = xtime.Now()
+		start = end.Add(-time.Hour)
+		q     = index.Query{Query: idx.NewTermQuery([]byte("foo"), []byte("bar"))}
+
+		byShard  = make(map[int32]int64)
+		expected int64
+	)
+	for i := int32(0); i < sessionTestShards; i++ {
+		n := rand.Int63n(128)
+		byShard[i] = n
+		expected += n
+	}
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			deleteTagged, ok := op.(*deleteTaggedOp)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("metrics"), deleteTagged.request.NameSpace)
+			assert.Equal(t, int64(start), deleteTagged.request.RangeStart)
+			assert.Equal(t, int64(end), deleteTagged.request.RangeEnd)
+			assert.Equal(t, rpc.TimeType_UNIX_NANOSECONDS, deleteTagged.request.RangeTimeType)
+
+			// Every replica reports the series of all its shards, which must
+			// only be counted once.
+			result := &rpc.DeleteTaggedResult_{NumSeries: expected, NumSeriesByShard: byShard}
+			deleteTagged.completionFn(result, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	n, err := s.DeleteTagged(ident.StringID("metrics"), q, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, expected, n)
+
+	assert.NoError(t, session.Close())
+}
+
+func TestDeleteTaggedConsistency(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		failures int
+		success  bool
+	}{
+		{name: "one replica failed", failures: 1, success: true},
+		{name: "majority of replicas failed", failures: 2, success: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			opts := newSessionTestOptions().
+				SetWriteConsistencyLevel(topology.ConsistencyLevelMajority)
+			s, err := newSession(opts)
+			assert.NoError(t, err)
+			session := s.(*session)
+
+			var (
+				end   = xtime.Now()
+				start = end.Add(-time.Hour)
+				q     = index.Query{Query: idx.NewTermQuery([]byte("foo"), []byte("bar"))}
+			)
+			mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+				func(idx int, op op) {
+					deleteTagged := op.(*deleteTaggedOp)
+					if idx < test.failures {
+						deleteTagged.completionFn(nil, errors.New("an error"))
+						return
+					}
+					deleteTagged.completionFn(&rpc.DeleteTaggedResult_{
+						NumSeries:        2,
+						NumSeriesByShard: map[int32]int64{0: 2},
+					}, nil)
+				},
+			})
+
+			assert.NoError(t, session.Open())
+
+			n, err := s.DeleteTagged(ident.StringID("metrics"), q, start, end)
+			if test.success {
+				require.NoError(t, err)
+				assert.Equal(t, int64(2), n)
+			} else {
+				require.Error(t, err)
+			}
+
+			assert.NoError(t, session.Close())
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionFetchMerkleNodesAndLeavesFromPeer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestAdminOptions()
+	s, err := newSession(opts)
+	require.NoError(t, err)
+	session := s.(*session)
+
+	mockHostQueues, mockClients := mockHostQueuesAndClientsForFetchBootstrapBlocks(ctrl, opts)
+	session.newHostQueueFn = mockHostQueues.newHostQueueFn()
+	require.NoError(t, session.Open())
+	defer func() {
+		require.NoError(t, session.Close())
+	}()
+
+	var (
+		peer       = sessionTestHostAndShards(sessionTestShardSet())[1].Host()
+		nsID       = ident.StringID("metrics")
+		blockStart = xtime.Now().Truncate(2 * time.Hour)
+		size       = int64(16)
+		checksum   = int64(111)
+	)
+	mockClients[1].EXPECT().
+		FetchMerkleNodesRaw(gomock.Any(), &rpc.FetchMerkleNodesRawRequest{
+			NameSpace:  nsID.Bytes(),
+			Shard:      3,
+			BlockStart: int64(blockStart),
+			Depth:      2,
+			Nodes:      []int64{1, 2},
+		}).
+		Return(&rpc.FetchMerkleNodesRawResult_{Hashes: []int64{-1, 2}}, nil)
+	mockClients[1].EXPECT().
+		FetchMerkleLeavesRaw(gomock.Any(), &rpc.FetchMerkleLeavesRawRequest{
+			NameSpace:  nsID.Bytes(),
+			Shard:      3,
+			BlockStart: int64(blockStart),
+			Depth:      2,
+			Leaves:     []int64{3},
+		}).
+		Return(&rpc.FetchMerkleLeavesRawResult_{
+			Elements: []*rpc.BlockMetadataV2{
+				{
+					ID:       []byte("foo"),
+					Start:    int64(blockStart),
+					Size:     &size,
+					Checksum: &checksum,
+				},
+			},
+		}, nil)
+
+	hashes, err := session.FetchMerkleNodesFromPeer(peer, nsID, 3, blockStart, 2, []int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{^uint64(0), 2}, hashes)
+
+	iter, err := session.FetchMerkleLeavesFromPeer(peer, nsID, 3, blockStart, 2, []int{3})
+	require.NoError(t, err)
+
+	require.True(t, iter.Next())
+	host, metadata := iter.Current()
+	assert.Equal(t, peer.ID(), host.ID())
+	assert.Equal(t, "foo", metadata.ID.String())
+	assert.Equal(t, blockStart, metadata.Start)
+	assert.Equal(t, size, metadata.Size)
+	require.NotNil(t, metadata.Checksum)
+	assert.Equal(t, uint32(checksum), *metadata.Checksum)
+
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Err())
+}
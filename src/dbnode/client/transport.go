@@ -0,0 +1,137 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/network/server/grpcthrift"
+	grpcnode "github.com/m3db/m3/src/dbnode/network/server/grpcthrift/node"
+
+	"google.golang.org/grpc"
+)
+
+// Transport is the transport used to connect to nodes.
+type Transport string
+
+const (
+	// TChannelTransport connects to nodes using TChannel.
+	TChannelTransport Transport = "tchannel"
+	// GRPCTransport connects to nodes using gRPC, the gRPC node listen
+	// address of each node is resolved from its placement endpoint with a
+	// GRPCEndpointFn.
+	GRPCTransport Transport = "grpc"
+)
+
+var validTransports = []Transport{
+	TChannelTransport,
+	GRPCTransport,
+}
+
+// UnmarshalYAML unmarshals a Transport into a valid type from string.
+func (t *Transport) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	for _, valid := range validTransports {
+		if str == string(valid) {
+			*t = valid
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid transport '%s' valid types are: %v",
+		str, validTransports)
+}
+
+// NewConnectionFn returns the function used to create connections to
+// nodes for the transport, endpointFn is only used by the gRPC transport.
+func (t Transport) NewConnectionFn(endpointFn GRPCEndpointFn) NewConnectionFn {
+	if t == GRPCTransport {
+		return NewGRPCConnectionFn(endpointFn)
+	}
+	return defaultNewConnectionFn
+}
+
+// GRPCEndpointFn resolves the gRPC node listen address of a node from its
+// placement endpoint, which is the node's TChannel listen address.
+type GRPCEndpointFn func(endpoint string) (string, error)
+
+// NewGRPCEndpointFn returns a GRPCEndpointFn that resolves endpoints present
+// in endpoints to their mapped address and otherwise replaces the port of
+// the endpoint with port. Endpoints are used unchanged if port is zero.
+func NewGRPCEndpointFn(endpoints map[string]string, port int) GRPCEndpointFn {
+	return func(endpoint string) (string, error) {
+		if address, ok := endpoints[endpoint]; ok {
+			return address, nil
+		}
+		if port == 0 {
+			return endpoint, nil
+		}
+		host, _, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return "", fmt.Errorf("could not resolve grpc address of endpoint %s: %w",
+				endpoint, err)
+		}
+		return net.JoinHostPort(host, strconv.Itoa(port)), nil
+	}
+}
+
+type grpcChannel struct {
+	conn *grpc.ClientConn
+}
+
+func (c *grpcChannel) Close() {
+	c.conn.Close()
+}
+
+// NewGRPCConnectionFn returns a function that creates connections to nodes
+// serving the node service over gRPC, the address dialed for each node is
+// resolved from its placement endpoint with endpointFn. Placement endpoints
+// are dialed unchanged if endpointFn is nil.
+func NewGRPCConnectionFn(endpointFn GRPCEndpointFn) NewConnectionFn {
+	return func(
+		channelName string, endpoint string, clientOpts Options,
+	) (Channel, rpc.TChanNode, error) {
+		address := endpoint
+		if endpointFn != nil {
+			var err error
+			address, err = endpointFn(endpoint)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		conn, err := grpc.Dial(address,
+			grpc.WithInsecure(),
+			grpc.WithUserAgent(channelName),
+			grpc.WithDefaultCallOptions(
+				grpc.MaxCallRecvMsgSize(grpcthrift.DefaultMaxMessageSize),
+				grpc.MaxCallSendMsgSize(grpcthrift.DefaultMaxMessageSize),
+			))
+		if err != nil {
+			return nil, nil, err
+		}
+		return &grpcChannel{conn: conn}, grpcnode.NewClient(conn), nil
+	}
+}
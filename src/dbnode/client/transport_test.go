@@ -0,0 +1,107 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	grpcnode "github.com/m3db/m3/src/dbnode/network/server/grpcthrift/node"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/tchannel-go/thrift"
+	"gopkg.in/yaml.v2"
+)
+
+func TestTransportUnmarshalYAML(t *testing.T) {
+	type config struct {
+		Transport Transport `yaml:"transport"`
+	}
+
+	for _, value := range validTransports {
+		var cfg config
+		require.NoError(t, yaml.Unmarshal([]byte("transport: "+string(value)+"\n"), &cfg))
+		require.Equal(t, value, cfg.Transport)
+	}
+
+	var cfg config
+	require.Error(t, yaml.Unmarshal([]byte("transport: not_a_transport\n"), &cfg))
+}
+
+func TestGRPCConnectionFn(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	service := rpc.NewMockTChanNode(ctrl)
+	closer, err := grpcnode.NewServer(service, address, context.NewPool(context.NewOptions()),
+		instrument.NewOptions()).
+		ListenAndServe()
+	require.NoError(t, err)
+	defer closer()
+
+	// The placement endpoint is the TChannel listen address, the gRPC listen
+	// address is resolved from it.
+	endpoint := "127.0.0.1:9000"
+	endpointFn := NewGRPCEndpointFn(map[string]string{endpoint: address}, 0)
+	channel, client, err := GRPCTransport.NewConnectionFn(endpointFn)(channelName, endpoint, NewOptions())
+	require.NoError(t, err)
+	defer channel.Close()
+
+	service.EXPECT().Health(gomock.Any()).
+		Return(&rpc.NodeHealthResult_{Ok: true, Status: "up", Bootstrapped: true}, nil)
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+
+	result, err := client.Health(ctx)
+	require.NoError(t, err)
+	require.True(t, result.Bootstrapped)
+}
+
+func TestGRPCEndpointFn(t *testing.T) {
+	endpointFn := NewGRPCEndpointFn(map[string]string{"host1:9000": "host1:9100"}, 9010)
+
+	address, err := endpointFn("host1:9000")
+	require.NoError(t, err)
+	require.Equal(t, "host1:9100", address)
+
+	address, err = endpointFn("host2:9000")
+	require.NoError(t, err)
+	require.Equal(t, "host2:9010", address)
+
+	_, err = endpointFn("host2")
+	require.Error(t, err)
+
+	address, err = NewGRPCEndpointFn(nil, 0)("host2:9000")
+	require.NoError(t, err)
+	require.Equal(t, "host2:9000", address)
+}
@@ -119,6 +119,17 @@ type Session interface {
 		opts index.QueryOptions,
 	) (encoding.SeriesIterators, FetchResponseMetadata, error)
 
+	// FetchTaggedPages resolves the provided query to known IDs, and fetches
+	// the data for them a page of series at a time using the page size set
+	// in the query options, each page is only fetched once the previous
+	// page has been iterated past.
+	FetchTaggedPages(
+		ctx gocontext.Context,
+		namespace ident.ID,
+		q index.Query,
+		opts index.QueryOptions,
+	) (FetchTaggedPagesIterator, error)
+
 	// FetchTaggedIDs resolves the provided query to known IDs.
 	FetchTaggedIDs(
 		ctx gocontext.Context,
@@ -160,6 +171,36 @@ type FetchResponseMetadata struct {
 	WaitedIndex int
 	// WaitedSeriesRead counts how many times series being read had to wait for permits.
 	WaitedSeriesRead int
+	// DocsRead is the number of index documents read by the hosts, summed
+	// across the replicas of each shard that responded.
+	DocsRead int
+	// BytesRead is the number of bytes read from disk by the hosts, counted
+	// the same as for the bytes read limit of the hosts.
+	BytesRead int
+	// BlocksRead is the number of blocks returned by the hosts, whether the
+	// blocks were read from disk or from memory.
+	BlocksRead int
+	// IndexExplain are the per host traces of the index query, only set if
+	// an explain was requested.
+	IndexExplain []index.QueryExplain
+	// NextPageToken is the token to fetch the next page of series with,
+	// only set if a page size was requested and more series remain.
+	NextPageToken []byte
+}
+
+// FetchTaggedPagesIterator iterates over the pages of series fetched for a
+// query.
+type FetchTaggedPagesIterator interface {
+	// Next fetches the next page, returning false once all pages have been
+	// fetched or an error occurred.
+	Next() bool
+
+	// Current returns the series and metadata of the current page, the
+	// caller takes ownership of the series iterators and must close them.
+	Current() (encoding.SeriesIterators, FetchResponseMetadata)
+
+	// Err returns any error that occurred fetching a page.
+	Err() error
 }
 
 // AggregatedTagsIterator iterates over a collection of tag names with optionally
@@ -258,6 +299,24 @@ type AdminSession interface {
 	// Truncate will truncate the namespace for a given shard.
 	Truncate(namespace ident.ID) (int64, error)
 
+	// DeleteTagged will delete the data within [start, end) of the series
+	// matching the query on every host, returning the number of series
+	// deleted summed across all replicas.
+	DeleteTagged(
+		namespace ident.ID,
+		q index.Query,
+		start, end xtime.UnixNano,
+	) (int64, error)
+
+	// Cardinality returns the cardinality statistics of the series matching
+	// the query, merged across every host. Series counts are deduplicated
+	// by the replication factor and trimmed to the top N entries.
+	Cardinality(
+		namespace ident.ID,
+		q index.Query,
+		opts index.CardinalityOptions,
+	) (index.CardinalityQueryResult, error)
+
 	// FetchBootstrapBlocksFromPeers will fetch the most fulfilled block
 	// for each series using the runtime configurable bootstrap level consistency.
 	FetchBootstrapBlocksFromPeers(
@@ -286,6 +345,28 @@ type AdminSession interface {
 		result result.Options,
 	) (PeerBlockMetadataIter, error)
 
+	// FetchMerkleNodesFromPeer will fetch the hashes of the nodes of the
+	// merkle tree of a shard block from a peer.
+	FetchMerkleNodesFromPeer(
+		peer topology.Host,
+		namespace ident.ID,
+		shard uint32,
+		blockStart xtime.UnixNano,
+		depth int,
+		nodes []int,
+	) ([]uint64, error)
+
+	// FetchMerkleLeavesFromPeer will fetch the blocks metadata of the series
+	// belonging to the leaves of the merkle tree of a shard block from a peer.
+	FetchMerkleLeavesFromPeer(
+		peer topology.Host,
+		namespace ident.ID,
+		shard uint32,
+		blockStart xtime.UnixNano,
+		depth int,
+		leaves []int,
+	) (PeerBlockMetadataIter, error)
+
 	// FetchBlocksFromPeers will fetch the required blocks from the
 	// peers specified.
 	FetchBlocksFromPeers(
@@ -695,6 +776,20 @@ type Options interface {
 	// IterationOptions returns experimental iteration options.
 	IterationOptions() index.IterationOptions
 
+	// SetHedgedReadOptions sets the hedged read options.
+	SetHedgedReadOptions(value HedgedReadOptions) Options
+
+	// HedgedReadOptions returns the hedged read options.
+	HedgedReadOptions() HedgedReadOptions
+
+	// SetWriteOverloadBackoffOptions sets the options for backing off writes
+	// to hosts that reject writes as overloaded.
+	SetWriteOverloadBackoffOptions(value WriteOverloadBackoffOptions) Options
+
+	// WriteOverloadBackoffOptions returns the options for backing off writes
+	// to hosts that reject writes as overloaded.
+	WriteOverloadBackoffOptions() WriteOverloadBackoffOptions
+
 	// SetWriteTimestampOffset sets the write timestamp offset.
 	SetWriteTimestampOffset(value time.Duration) AdminOptions
 
@@ -818,9 +913,8 @@ type hostQueue interface {
 // WithConnectionFn is a callback for a connection to a host.
 type WithConnectionFn func(client rpc.TChanNode, ch Channel)
 
-// Channel is an interface for tchannel.Channel struct.
+// Channel is the connection of a node client, such as a tchannel.Channel.
 type Channel interface {
-	GetSubChannel(serviceName string, opts ...tchannel.SubChannelOption) *tchannel.SubChannel
 	Close()
 }
 
@@ -0,0 +1,183 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/uber-go/tally"
+
+	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	"github.com/m3db/m3/src/x/clock"
+	xretry "github.com/m3db/m3/src/x/retry"
+)
+
+const (
+	// defaultWriteOverloadInitialBackoff is the default backoff after a host
+	// first rejects writes as overloaded.
+	defaultWriteOverloadInitialBackoff = 100 * time.Millisecond
+
+	// defaultWriteOverloadMaxBackoff is the default maximum backoff from a
+	// host that keeps rejecting writes as overloaded.
+	defaultWriteOverloadMaxBackoff = 5 * time.Second
+
+	// writeOverloadBackoffFactor is the factor the backoff grows by each time
+	// a host rejects writes as overloaded in a row.
+	writeOverloadBackoffFactor = 2
+)
+
+var errWriteOverloadInvalidMaxBackoff = errors.New(
+	"write overload max backoff must not be less than the initial backoff")
+
+// WriteOverloadBackoffOptions are the options for backing off writes to a
+// host that rejects writes as overloaded. While backing off, writes to the
+// host fail immediately with an overloaded error instead of being sent so
+// that the host can recover, the backoff grows exponentially each time the
+// host rejects writes in a row and resets once a write to the host succeeds.
+type WriteOverloadBackoffOptions struct {
+	// Enabled enables backing off writes to overloaded hosts.
+	Enabled bool
+	// InitialBackoff is the backoff after a host first rejects writes.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum backoff from a host.
+	MaxBackoff time.Duration
+}
+
+// NewWriteOverloadBackoffOptions returns the default write overload backoff options.
+func NewWriteOverloadBackoffOptions() WriteOverloadBackoffOptions {
+	return WriteOverloadBackoffOptions{
+		Enabled:        true,
+		InitialBackoff: defaultWriteOverloadInitialBackoff,
+		MaxBackoff:     defaultWriteOverloadMaxBackoff,
+	}
+}
+
+// Validate validates the write overload backoff options.
+func (o WriteOverloadBackoffOptions) Validate() error {
+	if !o.Enabled {
+		return nil
+	}
+	if o.MaxBackoff < o.InitialBackoff {
+		return errWriteOverloadInvalidMaxBackoff
+	}
+	return nil
+}
+
+type writeOverloadBackoffMetrics struct {
+	overloaded tally.Counter
+	shed       tally.Counter
+}
+
+func newWriteOverloadBackoffMetrics(scope tally.Scope) writeOverloadBackoffMetrics {
+	scope = scope.SubScope("write-overload")
+	return writeOverloadBackoffMetrics{
+		overloaded: scope.Counter("overloaded"),
+		shed:       scope.Counter("shed"),
+	}
+}
+
+// writeOverloadBackoff tracks writes to a single host rejected as overloaded.
+type writeOverloadBackoff struct {
+	sync.RWMutex
+
+	hostID  string
+	opts    WriteOverloadBackoffOptions
+	nowFn   clock.NowFn
+	rngFn   xretry.RngFn
+	metrics writeOverloadBackoffMetrics
+
+	overloaded   int
+	backoffUntil time.Time
+}
+
+func newWriteOverloadBackoff(
+	hostID string,
+	opts Options,
+	scope tally.Scope,
+) *writeOverloadBackoff {
+	return &writeOverloadBackoff{
+		hostID:  hostID,
+		opts:    opts.WriteOverloadBackoffOptions(),
+		nowFn:   opts.ClockOptions().NowFn(),
+		rngFn:   rand.Int63n,
+		metrics: newWriteOverloadBackoffMetrics(scope),
+	}
+}
+
+// check returns an overloaded error if writes to the host are being backed
+// off and should not be sent.
+func (b *writeOverloadBackoff) check() error {
+	if !b.opts.Enabled {
+		return nil
+	}
+
+	b.RLock()
+	backoffUntil := b.backoffUntil
+	b.RUnlock()
+
+	if !b.nowFn().Before(backoffUntil) {
+		return nil
+	}
+
+	b.metrics.shed.Inc(1)
+	return tterrors.NewOverloadedError(fmt.Errorf(
+		"host %s is overloaded, backing off writes until %s",
+		b.hostID, backoffUntil.Format(time.RFC3339Nano)))
+}
+
+// update records the result of sending writes to the host.
+func (b *writeOverloadBackoff) update(err error) {
+	if !b.opts.Enabled {
+		return
+	}
+
+	if err == nil {
+		b.RLock()
+		overloaded := b.overloaded
+		b.RUnlock()
+		if overloaded == 0 {
+			return
+		}
+
+		b.Lock()
+		b.overloaded = 0
+		b.Unlock()
+		return
+	}
+
+	if !IsOverloadedError(err) {
+		return
+	}
+
+	b.metrics.overloaded.Inc(1)
+
+	b.Lock()
+	b.overloaded++
+	backoff := xretry.BackoffNanos(b.overloaded, true,
+		writeOverloadBackoffFactor, b.opts.InitialBackoff,
+		b.opts.MaxBackoff, b.rngFn)
+	b.backoffUntil = b.nowFn().Add(time.Duration(backoff))
+	b.Unlock()
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+)
+
+func newTestWriteOverloadBackoff(now *time.Time) *writeOverloadBackoff {
+	opts := newSessionTestOptions().
+		SetWriteOverloadBackoffOptions(WriteOverloadBackoffOptions{
+			Enabled:        true,
+			InitialBackoff: time.Second,
+			MaxBackoff:     3 * time.Second,
+		})
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return *now
+	}))
+	b := newWriteOverloadBackoff("testhost", opts, tally.NoopScope)
+	// Disable jitter so the backoff is deterministic.
+	b.rngFn = func(n int64) int64 { return n }
+	return b
+}
+
+func TestWriteOverloadBackoff(t *testing.T) {
+	var (
+		now           = time.Now()
+		b             = newTestWriteOverloadBackoff(&now)
+		overloadedErr = tterrors.NewOverloadedError(errors.New("overloaded"))
+	)
+
+	require.NoError(t, b.check())
+
+	// Errors other than overloaded errors do not back off.
+	b.update(tterrors.NewInternalError(errors.New("internal")))
+	require.NoError(t, b.check())
+
+	b.update(overloadedErr)
+	err := b.check()
+	require.Error(t, err)
+	require.True(t, IsOverloadedError(err))
+
+	now = now.Add(time.Second)
+	require.NoError(t, b.check())
+
+	// Backoff grows while the host keeps rejecting writes up to the max.
+	b.update(overloadedErr)
+	now = now.Add(1500 * time.Millisecond)
+	require.Error(t, b.check())
+	now = now.Add(500 * time.Millisecond)
+	require.NoError(t, b.check())
+
+	b.update(overloadedErr)
+	now = now.Add(2999 * time.Millisecond)
+	require.Error(t, b.check())
+	now = now.Add(time.Millisecond)
+	require.NoError(t, b.check())
+
+	// A successful write resets the backoff.
+	b.update(nil)
+	b.update(overloadedErr)
+	now = now.Add(time.Second)
+	require.NoError(t, b.check())
+}
+
+func TestWriteOverloadBackoffDisabled(t *testing.T) {
+	now := time.Now()
+	b := newTestWriteOverloadBackoff(&now)
+	b.opts.Enabled = false
+
+	b.update(tterrors.NewOverloadedError(errors.New("overloaded")))
+	require.NoError(t, b.check())
+}
+
+func TestHostQueueWriteShedWhileOverloaded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	opts := newHostQueueTestOptions().
+		SetHostQueueOpsFlushSize(1).
+		SetUseV2BatchAPIs(true)
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return now
+	}))
+
+	mockConnPool := NewMockconnectionPool(ctrl)
+	queue := newTestHostQueue(opts)
+	queue.connPool = mockConnPool
+
+	mockConnPool.EXPECT().Open()
+	queue.Open()
+
+	var (
+		results []hostQueueResult
+		wg      sync.WaitGroup
+	)
+	callback := func(r interface{}, err error) {
+		results = append(results, hostQueueResult{r, err})
+		wg.Done()
+	}
+	write := func() {
+		wg.Add(1)
+		assert.NoError(t, queue.Enqueue(testWriteOp("testNs", "foo", 1.0, 1000,
+			rpc.TimeType_UNIX_SECONDS, callback)))
+		wg.Wait()
+	}
+
+	overloadedErr := tterrors.NewOverloadedError(errors.New("overloaded"))
+	mockClient := rpc.NewMockTChanNode(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().WriteBatchRawV2(gomock.Any(), gomock.Any()).Return(overloadedErr),
+		mockClient.EXPECT().WriteBatchRawV2(gomock.Any(), gomock.Any()).Return(nil),
+	)
+	mockConnPool.EXPECT().NextClient().Return(mockClient, &noopPooledChannel{}, nil).Times(2)
+
+	// The host rejects the first write, the second is shed without being
+	// sent and the third is sent once the backoff has elapsed.
+	write()
+	write()
+	now = now.Add(opts.WriteOverloadBackoffOptions().MaxBackoff)
+	write()
+
+	require.Len(t, results, 3)
+	assert.Equal(t, overloadedErr, results[0].err)
+	assert.True(t, IsOverloadedError(results[1].err))
+	assert.NotEqual(t, overloadedErr, results[1].err)
+	assert.NoError(t, results[2].err)
+
+	var closeWg sync.WaitGroup
+	closeWg.Add(1)
+	mockConnPool.EXPECT().Close().Do(func() {
+		closeWg.Done()
+	})
+	queue.Close()
+	closeWg.Wait()
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package digest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+const (
+	// MaxMerkleTreeDepth is the maximum depth of a merkle tree, which bounds
+	// the size of a tree to 2^(MaxMerkleTreeDepth+1)-1 nodes.
+	MaxMerkleTreeDepth = 20
+
+	merkleTreeHeaderLenBytes = 4
+	merkleTreeNodeLenBytes   = 8
+)
+
+var (
+	errMerkleTreeTooShort         = errors.New("merkle tree encoding is too short")
+	errMerkleTreeChecksumMismatch = errors.New("merkle tree encoding checksum mismatch")
+)
+
+// MerkleTree is a fixed shape binary hash tree over the checksums of the
+// series of a shard block. Series are assigned to leaves by the hash of their
+// ID so that trees built independently by each replica have the same shape
+// and can be compared top down, descending only into the nodes that differ.
+//
+// Each node is the sum of the hashes of the series beneath it, which makes a
+// tree independent of the order series are added in and means a tree of a
+// smaller depth is exactly the top levels of a deeper tree.
+//
+// Nodes are indexed breadth first from the root at index zero, the children
+// of node i are nodes 2i+1 and 2i+2.
+type MerkleTree struct {
+	depth int
+	nodes []uint64
+}
+
+// NewMerkleTree creates a new empty merkle tree with the given depth, a tree
+// of depth zero consists of just the root.
+func NewMerkleTree(depth int) (*MerkleTree, error) {
+	if err := validateMerkleTreeDepth(depth); err != nil {
+		return nil, err
+	}
+	return &MerkleTree{
+		depth: depth,
+		nodes: make([]uint64, merkleTreeNumNodes(depth)),
+	}, nil
+}
+
+func validateMerkleTreeDepth(depth int) error {
+	if depth < 0 || depth > MaxMerkleTreeDepth {
+		return fmt.Errorf("merkle tree depth %d must be between 0 and %d",
+			depth, MaxMerkleTreeDepth)
+	}
+	return nil
+}
+
+func merkleTreeNumNodes(depth int) int {
+	return 1<<(depth+1) - 1
+}
+
+// MerkleTreeChildren returns the indexes of the children of a node.
+func MerkleTreeChildren(node int) (int, int) {
+	return 2*node + 1, 2*node + 2
+}
+
+// Depth returns the depth of the tree.
+func (t *MerkleTree) Depth() int {
+	return t.depth
+}
+
+// NumNodes returns the number of nodes of the tree.
+func (t *MerkleTree) NumNodes() int {
+	return len(t.nodes)
+}
+
+// NumLeaves returns the number of leaves of the tree.
+func (t *MerkleTree) NumLeaves() int {
+	return 1 << t.depth
+}
+
+// Root returns the hash of the root of the tree.
+func (t *MerkleTree) Root() uint64 {
+	return t.nodes[0]
+}
+
+// Node returns the hash of a node and whether the node exists in the tree.
+func (t *MerkleTree) Node(node int) (uint64, bool) {
+	if node < 0 || node >= len(t.nodes) {
+		return 0, false
+	}
+	return t.nodes[node], true
+}
+
+// IsLeaf returns whether a node is a leaf of the tree.
+func (t *MerkleTree) IsLeaf(node int) bool {
+	return node >= t.leafOffset() && node < len(t.nodes)
+}
+
+// LeafNode returns the node index of a leaf.
+func (t *MerkleTree) LeafNode(leaf int) int {
+	return t.leafOffset() + leaf
+}
+
+// NodeLeaf returns the leaf of a leaf node.
+func (t *MerkleTree) NodeLeaf(node int) int {
+	return node - t.leafOffset()
+}
+
+// Leaf returns the leaf a series belongs to.
+func (t *MerkleTree) Leaf(id []byte) int {
+	return merkleTreeLeaf(xxhash.Sum64(id), t.depth)
+}
+
+func (t *MerkleTree) leafOffset() int {
+	return 1<<t.depth - 1
+}
+
+func merkleTreeLeaf(idHash uint64, depth int) int {
+	if depth == 0 {
+		return 0
+	}
+	return int(idHash >> (64 - uint(depth)))
+}
+
+// Add adds a series with the given block checksum to the tree.
+func (t *MerkleTree) Add(id []byte, checksum uint32) {
+	var (
+		idHash = xxhash.Sum64(id)
+		hash   = merkleTreeEntryHash(idHash, checksum)
+		node   = t.LeafNode(merkleTreeLeaf(idHash, t.depth))
+	)
+	for {
+		t.nodes[node] += hash
+		if node == 0 {
+			return
+		}
+		node = (node - 1) / 2
+	}
+}
+
+// merkleTreeEntryHash mixes the checksum into the ID hash with the splitmix64
+// finalizer so that sums of entries do not cancel out.
+func merkleTreeEntryHash(idHash uint64, checksum uint32) uint64 {
+	h := idHash + uint64(checksum)*0x9e3779b97f4a7c15
+	h = (h ^ (h >> 30)) * 0xbf58476d1ce4e5b9
+	h = (h ^ (h >> 27)) * 0x94d049bb133111eb
+	return h ^ (h >> 31)
+}
+
+// Reset clears the hashes of every node of the tree.
+func (t *MerkleTree) Reset() {
+	for i := range t.nodes {
+		t.nodes[i] = 0
+	}
+}
+
+// Truncate returns the top levels of the tree as a tree of a smaller depth.
+func (t *MerkleTree) Truncate(depth int) (*MerkleTree, error) {
+	if depth < 0 || depth > t.depth {
+		return nil, fmt.Errorf("cannot truncate merkle tree of depth %d to depth %d",
+			t.depth, depth)
+	}
+	return &MerkleTree{
+		depth: depth,
+		nodes: t.nodes[:merkleTreeNumNodes(depth)],
+	}, nil
+}
+
+// MarshalBinary encodes the tree as its depth followed by the hashes of its
+// leaves and a checksum of the encoding.
+func (t *MerkleTree) MarshalBinary() ([]byte, error) {
+	var (
+		leaves = t.nodes[t.leafOffset():]
+		size   = merkleTreeHeaderLenBytes + len(leaves)*merkleTreeNodeLenBytes
+		b      = make([]byte, size, size+DigestLenBytes)
+	)
+	endianness.PutUint32(b, uint32(t.depth))
+	for i, leaf := range leaves {
+		endianness.PutUint64(b[merkleTreeHeaderLenBytes+i*merkleTreeNodeLenBytes:], leaf)
+	}
+	b = b[:size+DigestLenBytes]
+	endianness.PutUint32(b[size:], Checksum(b[:size]))
+	return b, nil
+}
+
+// UnmarshalMerkleTree decodes a tree encoded with MarshalBinary.
+func UnmarshalMerkleTree(b []byte) (*MerkleTree, error) {
+	if len(b) < merkleTreeHeaderLenBytes+DigestLenBytes {
+		return nil, errMerkleTreeTooShort
+	}
+	size := len(b) - DigestLenBytes
+	if Checksum(b[:size]) != endianness.Uint32(b[size:]) {
+		return nil, errMerkleTreeChecksumMismatch
+	}
+
+	t, err := NewMerkleTree(int(endianness.Uint32(b)))
+	if err != nil {
+		return nil, err
+	}
+	numLeaves := t.NumLeaves()
+	if expected := merkleTreeHeaderLenBytes + numLeaves*merkleTreeNodeLenBytes; size != expected {
+		return nil, fmt.Errorf("merkle tree encoding of depth %d has size %d, expected %d",
+			t.depth, size, expected)
+	}
+
+	offset := t.leafOffset()
+	for i := 0; i < numLeaves; i++ {
+		t.nodes[offset+i] = endianness.Uint64(b[merkleTreeHeaderLenBytes+i*merkleTreeNodeLenBytes:])
+	}
+	for node := offset - 1; node >= 0; node-- {
+		left, right := MerkleTreeChildren(node)
+		t.nodes[node] = t.nodes[left] + t.nodes[right]
+	}
+	return t, nil
+}
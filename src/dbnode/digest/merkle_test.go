@@ -0,0 +1,152 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package digest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerkleTreeOrderIndependent(t *testing.T) {
+	a, err := NewMerkleTree(4)
+	require.NoError(t, err)
+	b, err := NewMerkleTree(4)
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		a.Add([]byte(fmt.Sprintf("foo.%d", i)), uint32(i))
+	}
+	for i := 99; i >= 0; i-- {
+		b.Add([]byte(fmt.Sprintf("foo.%d", i)), uint32(i))
+	}
+
+	require.Equal(t, 31, a.NumNodes())
+	require.Equal(t, 16, a.NumLeaves())
+	require.Equal(t, a.nodes, b.nodes)
+	require.NotZero(t, a.Root())
+}
+
+func TestMerkleTreeDifferingChecksum(t *testing.T) {
+	a, err := NewMerkleTree(3)
+	require.NoError(t, err)
+	b, err := NewMerkleTree(3)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		id := []byte(fmt.Sprintf("foo.%d", i))
+		a.Add(id, 1)
+		if i == 5 {
+			b.Add(id, 2)
+			continue
+		}
+		b.Add(id, 1)
+	}
+
+	require.NotEqual(t, a.Root(), b.Root())
+
+	// Only the nodes on the path from the root to the leaf of the differing
+	// series should differ.
+	var (
+		leafNode = a.LeafNode(a.Leaf([]byte("foo.5")))
+		onPath   = map[int]bool{}
+	)
+	for node := leafNode; ; node = (node - 1) / 2 {
+		onPath[node] = true
+		if node == 0 {
+			break
+		}
+	}
+	for node := 0; node < a.NumNodes(); node++ {
+		hashA, ok := a.Node(node)
+		require.True(t, ok)
+		hashB, ok := b.Node(node)
+		require.True(t, ok)
+		require.Equal(t, !onPath[node], hashA == hashB, "node %d", node)
+	}
+}
+
+func TestMerkleTreeTruncate(t *testing.T) {
+	deep, err := NewMerkleTree(6)
+	require.NoError(t, err)
+	shallow, err := NewMerkleTree(2)
+	require.NoError(t, err)
+
+	for i := 0; i < 50; i++ {
+		id := []byte(fmt.Sprintf("foo.%d", i))
+		deep.Add(id, uint32(i))
+		shallow.Add(id, uint32(i))
+	}
+
+	truncated, err := deep.Truncate(2)
+	require.NoError(t, err)
+	require.Equal(t, shallow.nodes, truncated.nodes)
+
+	_, err = shallow.Truncate(3)
+	require.Error(t, err)
+}
+
+func TestMerkleTreeLeaves(t *testing.T) {
+	tree, err := NewMerkleTree(2)
+	require.NoError(t, err)
+
+	require.False(t, tree.IsLeaf(2))
+	require.True(t, tree.IsLeaf(3))
+	require.True(t, tree.IsLeaf(6))
+	require.False(t, tree.IsLeaf(7))
+	require.Equal(t, 3, tree.LeafNode(0))
+	require.Equal(t, 3, tree.NodeLeaf(6))
+
+	left, right := MerkleTreeChildren(1)
+	require.Equal(t, 3, left)
+	require.Equal(t, 4, right)
+
+	_, ok := tree.Node(7)
+	require.False(t, ok)
+
+	_, err = NewMerkleTree(MaxMerkleTreeDepth + 1)
+	require.Error(t, err)
+}
+
+func TestMerkleTreeMarshalRoundTrip(t *testing.T) {
+	tree, err := NewMerkleTree(5)
+	require.NoError(t, err)
+	for i := 0; i < 200; i++ {
+		tree.Add([]byte(fmt.Sprintf("foo.%d", i)), uint32(i))
+	}
+
+	b, err := tree.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, b, 4+32*8+4)
+
+	decoded, err := UnmarshalMerkleTree(b)
+	require.NoError(t, err)
+	require.Equal(t, tree.depth, decoded.depth)
+	require.Equal(t, tree.nodes, decoded.nodes)
+
+	b[10]++
+	_, err = UnmarshalMerkleTree(b)
+	require.Equal(t, errMerkleTreeChecksumMismatch, err)
+
+	_, err = UnmarshalMerkleTree(b[:3])
+	require.Equal(t, errMerkleTreeTooShort, err)
+}
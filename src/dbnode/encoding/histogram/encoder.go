@@ -0,0 +1,446 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/context"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+const (
+	opcodeFloatSample     = 0x0
+	opcodeHistogramSample = 0x1
+
+	opcodeLayoutUnchanged = 0x0
+	opcodeLayoutChanged   = 0x1
+
+	// opcodeHistogramStream is the leading bit of every histogram stream.
+	// M3TSZ streams start with a non-negative 64 bit start time, so their
+	// leading bit is always zero and the two encodings can be told apart.
+	opcodeHistogramStream = 0x1
+
+	numResetHintBits = 2
+)
+
+var (
+	errEncoderClosed       = errors.New("histogram encoder is closed")
+	errNoEncodedDatapoints = errors.New("histogram encoder has no encoded datapoints")
+)
+
+// Make sure encoder implements encoding.Encoder.
+var _ encoding.Encoder = &encoder{}
+
+// encoder encodes a stream of float datapoints and native histograms.
+//
+// Timestamps and regular annotations are written with the M3TSZ timestamp
+// encoder so that markers and time units behave exactly as they do for M3TSZ.
+// Every sample is then prefixed with a single bit that says whether it is a
+// float or a histogram. Floats are XOR compressed, histograms are written as
+// deltas against the previous histogram in the stream whenever the bucket
+// layout (schema, zero threshold and spans) is unchanged. The stream itself
+// starts with a single marker bit so that readers can tell it apart from
+// M3TSZ blocks written before the histogram encoding was enabled.
+type encoder struct {
+	os                   encoding.OStream
+	opts                 encoding.Options
+	markerEncodingScheme *encoding.MarkerEncodingScheme
+
+	tsEncoderState m3tsz.TimestampEncoder
+	floatEnc       m3tsz.FloatEncoderAndIterator
+	sumEnc         m3tsz.FloatEncoderAndIterator
+
+	prev          Histogram // last encoded histogram
+	hasPrev       bool      // whether prev holds a histogram
+	curr          Histogram // scratch histogram for decoding annotations
+	lastValue     float64
+	lastChecksum  uint64
+	numEncoded    uint32
+	wroteHeader   bool
+	closed        bool
+	varintScratch [binary.MaxVarintLen64]byte
+}
+
+// NewEncoder creates a new histogram encoder.
+func NewEncoder(
+	start xtime.UnixNano,
+	bytes checked.Bytes,
+	opts encoding.Options,
+) encoding.Encoder {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	// NB: only perform an initial allocation if there is no pool that
+	// will be used for this encoder. If a pool is being used alloc when the
+	// `Reset` method is called.
+	initAllocIfEmpty := opts.EncoderPool() == nil
+	return &encoder{
+		os:                   encoding.NewOStream(bytes, initAllocIfEmpty, opts.BytesPool()),
+		opts:                 opts,
+		markerEncodingScheme: opts.MarkerEncodingScheme(),
+		tsEncoderState:       m3tsz.NewTimestampEncoder(start, opts.DefaultTimeUnit(), opts),
+	}
+}
+
+func (enc *encoder) SetSchema(descr namespace.SchemaDescr) {}
+
+// Encode encodes a datapoint. If the annotation holds a marshaled histogram
+// the datapoint is encoded as a histogram sample and its value is ignored,
+// otherwise the datapoint is encoded as a float sample with the annotation.
+func (enc *encoder) Encode(dp ts.Datapoint, tu xtime.Unit, ant ts.Annotation) error {
+	if enc.closed {
+		return errEncoderClosed
+	}
+
+	if !enc.wroteHeader {
+		enc.os.WriteBit(opcodeHistogramStream)
+		enc.wroteHeader = true
+	}
+
+	if !IsHistogram(ant) {
+		if err := enc.tsEncoderState.WriteTime(enc.os, dp.TimestampNanos, ant, tu); err != nil {
+			return err
+		}
+		enc.os.WriteBit(opcodeFloatSample)
+		enc.floatEnc.WriteFloat(enc.os, dp.Value)
+		enc.lastValue = dp.Value
+		enc.lastChecksum = enc.tsEncoderState.PrevAnnotationChecksum
+		enc.numEncoded++
+		return nil
+	}
+
+	if err := enc.curr.Unmarshal(ant); err != nil {
+		return err
+	}
+	if err := enc.curr.Validate(); err != nil {
+		return err
+	}
+
+	if err := enc.tsEncoderState.WriteTime(enc.os, dp.TimestampNanos, nil, tu); err != nil {
+		return err
+	}
+	enc.os.WriteBit(opcodeHistogramSample)
+	enc.writeHistogram(&enc.curr)
+
+	enc.prev, enc.curr = enc.curr, enc.prev
+	enc.hasPrev = true
+	enc.lastValue = float64(enc.prev.Count)
+	enc.lastChecksum = xxhash.Sum64(ant)
+	enc.numEncoded++
+	return nil
+}
+
+func (enc *encoder) writeHistogram(h *Histogram) {
+	layoutChanged := !enc.hasPrev || !sameLayout(&enc.prev, h)
+	if layoutChanged {
+		enc.os.WriteBit(opcodeLayoutChanged)
+		enc.writeVarint(int64(h.Schema))
+		enc.os.WriteBits(math.Float64bits(h.ZeroThreshold), 64)
+		enc.writeSpans(h.NegativeSpans)
+		enc.writeSpans(h.PositiveSpans)
+	} else {
+		enc.os.WriteBit(opcodeLayoutUnchanged)
+	}
+
+	enc.os.WriteBits(uint64(h.CounterResetHint), numResetHintBits)
+	enc.writeVarint(int64(h.Count - enc.prev.Count))
+	enc.writeVarint(int64(h.ZeroCount - enc.prev.ZeroCount))
+	enc.sumEnc.WriteFloat(enc.os, h.Sum)
+
+	if layoutChanged {
+		enc.writeBuckets(h.NegativeBuckets, nil)
+		enc.writeBuckets(h.PositiveBuckets, nil)
+		return
+	}
+	enc.writeBuckets(h.NegativeBuckets, enc.prev.NegativeBuckets)
+	enc.writeBuckets(h.PositiveBuckets, enc.prev.PositiveBuckets)
+}
+
+func (enc *encoder) writeSpans(spans []Span) {
+	enc.writeUvarint(uint64(len(spans)))
+	for _, s := range spans {
+		enc.writeVarint(int64(s.Offset))
+		enc.writeUvarint(uint64(s.Length))
+	}
+}
+
+// writeBuckets writes the bucket counts as deltas against the same bucket in
+// the previous histogram, or against the neighbouring bucket if there is no
+// previous histogram with the same layout.
+func (enc *encoder) writeBuckets(buckets, prev []uint64) {
+	var last uint64
+	for i, b := range buckets {
+		if prev != nil {
+			last = prev[i]
+		}
+		enc.writeVarint(int64(b - last))
+		last = b
+	}
+}
+
+func (enc *encoder) writeVarint(v int64) {
+	n := binary.PutVarint(enc.varintScratch[:], v)
+	enc.os.WriteBytes(enc.varintScratch[:n])
+}
+
+func (enc *encoder) writeUvarint(v uint64) {
+	n := binary.PutUvarint(enc.varintScratch[:], v)
+	enc.os.WriteBytes(enc.varintScratch[:n])
+}
+
+func sameLayout(a, b *Histogram) bool {
+	return a.Schema == b.Schema &&
+		math.Float64bits(a.ZeroThreshold) == math.Float64bits(b.ZeroThreshold) &&
+		sameSpans(a.NegativeSpans, b.NegativeSpans) &&
+		sameSpans(a.PositiveSpans, b.PositiveSpans)
+}
+
+func sameSpans(a, b []Span) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (enc *encoder) newBuffer(capacity int) checked.Bytes {
+	if bytesPool := enc.opts.BytesPool(); bytesPool != nil {
+		return bytesPool.Get(capacity)
+	}
+	return checked.NewBytes(make([]byte, 0, capacity), nil)
+}
+
+// Reset resets the encoder for reuse.
+func (enc *encoder) Reset(
+	start xtime.UnixNano,
+	capacity int,
+	schema namespace.SchemaDescr,
+) {
+	enc.reset(start, enc.newBuffer(capacity))
+}
+
+func (enc *encoder) reset(start xtime.UnixNano, bytes checked.Bytes) {
+	enc.os.Reset(bytes)
+	enc.tsEncoderState = m3tsz.NewTimestampEncoder(start, enc.opts.DefaultTimeUnit(), enc.opts)
+	enc.floatEnc = m3tsz.FloatEncoderAndIterator{}
+	enc.sumEnc = m3tsz.FloatEncoderAndIterator{}
+	enc.prev = resetHistogram(enc.prev)
+	enc.curr = resetHistogram(enc.curr)
+	enc.hasPrev = false
+	enc.lastValue = 0
+	enc.lastChecksum = 0
+	enc.numEncoded = 0
+	enc.wroteHeader = false
+	enc.closed = false
+}
+
+// resetHistogram zeroes the histogram while retaining its slices for reuse.
+func resetHistogram(h Histogram) Histogram {
+	return Histogram{
+		PositiveSpans:   h.PositiveSpans[:0],
+		NegativeSpans:   h.NegativeSpans[:0],
+		PositiveBuckets: h.PositiveBuckets[:0],
+		NegativeBuckets: h.NegativeBuckets[:0],
+	}
+}
+
+// Stream returns a copy of the underlying data stream.
+func (enc *encoder) Stream(ctx context.Context) (xio.SegmentReader, bool) {
+	segment := enc.segmentZeroCopy(ctx)
+	if segment.Len() == 0 {
+		return nil, false
+	}
+
+	if readerPool := enc.opts.SegmentReaderPool(); readerPool != nil {
+		reader := readerPool.Get()
+		reader.Reset(segment)
+		return reader, true
+	}
+	return xio.NewSegmentReader(segment), true
+}
+
+// NumEncoded returns the number of encoded datapoints.
+func (enc *encoder) NumEncoded() int {
+	return int(enc.numEncoded)
+}
+
+// LastEncoded returns the last encoded datapoint, for histogram samples the
+// value is the histogram's total count.
+func (enc *encoder) LastEncoded() (ts.Datapoint, error) {
+	if enc.numEncoded == 0 {
+		return ts.Datapoint{}, errNoEncodedDatapoints
+	}
+
+	return ts.Datapoint{
+		TimestampNanos: enc.tsEncoderState.PrevTime,
+		Value:          enc.lastValue,
+	}, nil
+}
+
+// LastAnnotationChecksum returns the checksum of the last annotation, for
+// histogram samples this is the checksum of the marshaled histogram.
+func (enc *encoder) LastAnnotationChecksum() (uint64, error) {
+	if enc.numEncoded == 0 {
+		return 0, errNoEncodedDatapoints
+	}
+
+	return enc.lastChecksum, nil
+}
+
+// Empty returns true when underlying stream is empty.
+func (enc *encoder) Empty() bool {
+	return enc.os.Empty()
+}
+
+// Len returns the length of the final data stream that would be generated
+// by a call to Stream().
+func (enc *encoder) Len() int {
+	raw, pos := enc.os.RawBytes()
+	if len(raw) == 0 {
+		return 0
+	}
+
+	// Calculate how long the stream would be once it was "capped" with a tail.
+	var (
+		lastIdx  = len(raw) - 1
+		lastByte = raw[lastIdx]
+		scheme   = enc.markerEncodingScheme
+		tail     = scheme.Tail(lastByte, pos)
+	)
+	tail.IncRef()
+	tailLen := tail.Len()
+	tail.DecRef()
+
+	return len(raw[:lastIdx]) + tailLen
+}
+
+// Close closes the encoder.
+func (enc *encoder) Close() {
+	if enc.closed {
+		return
+	}
+
+	enc.closed = true
+
+	// Ensure to free ref to ostream bytes.
+	enc.os.Reset(nil)
+
+	if pool := enc.opts.EncoderPool(); pool != nil {
+		pool.Put(enc)
+	}
+}
+
+// Discard closes the encoder and transfers ownership of the data stream to
+// the caller.
+func (enc *encoder) Discard() ts.Segment {
+	segment := enc.segmentTakeOwnership()
+
+	// Close the encoder no longer needed.
+	enc.Close()
+
+	return segment
+}
+
+// DiscardReset does the same thing as Discard except it does not close the
+// encoder but resets it for reuse.
+func (enc *encoder) DiscardReset(
+	start xtime.UnixNano,
+	capacity int,
+	descr namespace.SchemaDescr,
+) ts.Segment {
+	segment := enc.segmentTakeOwnership()
+	enc.Reset(start, capacity, descr)
+	return segment
+}
+
+func (enc *encoder) segmentZeroCopy(ctx context.Context) ts.Segment {
+	length := enc.os.Len()
+	if length == 0 {
+		return ts.Segment{}
+	}
+
+	// We need a multibyte tail to capture an immutable snapshot
+	// of the encoder data.
+	rawBuffer, pos := enc.os.RawBytes()
+	lastByte := rawBuffer[length-1]
+
+	// Take ref up to last byte.
+	headBytes := rawBuffer[:length-1]
+
+	// Zero copy from the output stream.
+	var head checked.Bytes
+	if pool := enc.opts.CheckedBytesWrapperPool(); pool != nil {
+		head = pool.Get(headBytes)
+	} else {
+		head = checked.NewBytes(headBytes, nil)
+	}
+
+	// Make sure the ostream bytes ref is delayed from finalizing
+	// until this operation is complete (since this is zero copy).
+	buffer, _ := enc.os.CheckedBytes()
+	ctx.RegisterCloser(buffer.DelayFinalizer())
+
+	// Take a shared ref to a known good tail.
+	scheme := enc.markerEncodingScheme
+	tail := scheme.Tail(lastByte, pos)
+
+	return ts.NewSegment(head, tail, 0, ts.FinalizeHead)
+}
+
+func (enc *encoder) segmentTakeOwnership() ts.Segment {
+	length := enc.os.Len()
+	if length == 0 {
+		return ts.Segment{}
+	}
+
+	// We need a multibyte tail since the tail isn't set correctly midstream.
+	rawBuffer, pos := enc.os.RawBytes()
+	lastByte := rawBuffer[length-1]
+
+	// Take ref from the ostream.
+	head := enc.os.Discard()
+
+	// Resize to crop out last byte.
+	head.IncRef()
+	head.Resize(length - 1)
+	head.DecRef()
+
+	// Take a shared ref to a known good tail.
+	scheme := enc.markerEncodingScheme
+	tail := scheme.Tail(lastByte, pos)
+
+	return ts.NewSegment(head, tail, 0, ts.FinalizeHead)
+}
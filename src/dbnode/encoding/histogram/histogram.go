@@ -0,0 +1,374 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package histogram implements an encoding scheme for Prometheus native
+// (sparse exponential) histograms alongside regular float datapoints.
+package histogram
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// magicByte is the first byte of every marshaled histogram. A zero byte
+	// can never start a valid protobuf message (field number zero is
+	// reserved) which means histogram payloads can be told apart from the
+	// protobuf annotations written by the coordinator.
+	magicByte = 0x00
+
+	// currentVersion is the version of the marshaled histogram format.
+	currentVersion = 1
+
+	// MinSchema is the lowest supported histogram schema.
+	MinSchema = -4
+	// MaxSchema is the highest supported histogram schema.
+	MaxSchema = 8
+)
+
+var (
+	errTooShort         = errors.New("histogram payload too short")
+	errNotHistogram     = errors.New("payload is not a histogram")
+	errUnknownVersion   = errors.New("unknown histogram payload version")
+	errTruncatedPayload = errors.New("histogram payload truncated")
+)
+
+// CounterResetHint describes whether a counter reset is known to have
+// happened between the previous and the current histogram.
+type CounterResetHint uint8
+
+const (
+	// UnknownCounterReset means it is unknown whether a reset happened.
+	UnknownCounterReset CounterResetHint = iota
+	// CounterReset means a counter reset is known to have happened.
+	CounterReset
+	// NotCounterReset means no counter reset happened.
+	NotCounterReset
+	// GaugeType means the histogram is a gauge histogram.
+	GaugeType
+)
+
+// Span describes a run of consecutive populated buckets. The offset of the
+// first span is the index of its first bucket, the offset of subsequent spans
+// is relative to the end of the previous span.
+type Span struct {
+	Offset int32
+	Length uint32
+}
+
+// Histogram is a sparse exponential histogram with integer bucket counts.
+// Unlike the Prometheus remote write representation bucket counts are held
+// as absolute values rather than deltas between neighbouring buckets.
+type Histogram struct {
+	CounterResetHint CounterResetHint
+	Schema           int32
+	ZeroThreshold    float64
+	ZeroCount        uint64
+	Count            uint64
+	Sum              float64
+	PositiveSpans    []Span
+	NegativeSpans    []Span
+	PositiveBuckets  []uint64
+	NegativeBuckets  []uint64
+}
+
+// Bucket is a classic cumulative histogram bucket.
+type Bucket struct {
+	UpperBound float64
+	Count      uint64
+}
+
+// IsHistogram returns whether the given annotation holds a marshaled
+// histogram.
+func IsHistogram(b []byte) bool {
+	return len(b) >= 2 && b[0] == magicByte && b[1] == currentVersion
+}
+
+// Validate validates the histogram.
+func (h *Histogram) Validate() error {
+	if h.Schema < MinSchema || h.Schema > MaxSchema {
+		return fmt.Errorf("histogram schema %d out of range [%d, %d]",
+			h.Schema, MinSchema, MaxSchema)
+	}
+	if h.ZeroThreshold < 0 || math.IsNaN(h.ZeroThreshold) {
+		return fmt.Errorf("invalid histogram zero threshold: %v", h.ZeroThreshold)
+	}
+	if err := validateSpans(h.PositiveSpans, len(h.PositiveBuckets)); err != nil {
+		return fmt.Errorf("positive side: %w", err)
+	}
+	if err := validateSpans(h.NegativeSpans, len(h.NegativeBuckets)); err != nil {
+		return fmt.Errorf("negative side: %w", err)
+	}
+
+	total := h.ZeroCount
+	for _, c := range h.PositiveBuckets {
+		total += c
+	}
+	for _, c := range h.NegativeBuckets {
+		total += c
+	}
+	if total > h.Count {
+		return fmt.Errorf("histogram bucket counts %d exceed total count %d",
+			total, h.Count)
+	}
+	return nil
+}
+
+func validateSpans(spans []Span, numBuckets int) error {
+	var expected int
+	for i, s := range spans {
+		if i > 0 && s.Offset < 0 {
+			return fmt.Errorf("span %d has negative offset %d", i, s.Offset)
+		}
+		expected += int(s.Length)
+	}
+	if expected != numBuckets {
+		return fmt.Errorf("spans describe %d buckets but %d are present",
+			expected, numBuckets)
+	}
+	return nil
+}
+
+// Copy returns a deep copy of the histogram.
+func (h *Histogram) Copy() Histogram {
+	c := *h
+	c.PositiveSpans = append([]Span(nil), h.PositiveSpans...)
+	c.NegativeSpans = append([]Span(nil), h.NegativeSpans...)
+	c.PositiveBuckets = append([]uint64(nil), h.PositiveBuckets...)
+	c.NegativeBuckets = append([]uint64(nil), h.NegativeBuckets...)
+	return c
+}
+
+// Marshal marshals the histogram into a new byte slice.
+func (h *Histogram) Marshal() []byte {
+	return h.AppendMarshal(nil)
+}
+
+// AppendMarshal appends the marshaled histogram to dst and returns the
+// extended buffer.
+func (h *Histogram) AppendMarshal(dst []byte) []byte {
+	dst = append(dst, magicByte, currentVersion, byte(h.CounterResetHint))
+	dst = appendVarint(dst, int64(h.Schema))
+	dst = appendUint64(dst, math.Float64bits(h.ZeroThreshold))
+	dst = appendUvarint(dst, h.ZeroCount)
+	dst = appendUvarint(dst, h.Count)
+	dst = appendUint64(dst, math.Float64bits(h.Sum))
+	dst = appendSpansAndBuckets(dst, h.NegativeSpans, h.NegativeBuckets)
+	dst = appendSpansAndBuckets(dst, h.PositiveSpans, h.PositiveBuckets)
+	return dst
+}
+
+func appendSpansAndBuckets(dst []byte, spans []Span, buckets []uint64) []byte {
+	dst = appendUvarint(dst, uint64(len(spans)))
+	for _, s := range spans {
+		dst = appendVarint(dst, int64(s.Offset))
+		dst = appendUvarint(dst, uint64(s.Length))
+	}
+	dst = appendUvarint(dst, uint64(len(buckets)))
+	for _, b := range buckets {
+		dst = appendUvarint(dst, b)
+	}
+	return dst
+}
+
+func appendVarint(dst []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], v)
+	return append(dst, buf[:n]...)
+}
+
+func appendUvarint(dst []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(dst, buf[:n]...)
+}
+
+func appendUint64(dst []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(dst, buf[:]...)
+}
+
+// Unmarshal unmarshals the histogram from b, reusing any slices already
+// held by the histogram.
+func (h *Histogram) Unmarshal(b []byte) error {
+	if len(b) < 3 {
+		return errTooShort
+	}
+	if b[0] != magicByte {
+		return errNotHistogram
+	}
+	if b[1] != currentVersion {
+		return errUnknownVersion
+	}
+
+	d := decoder{buf: b[3:]}
+	h.CounterResetHint = CounterResetHint(b[2])
+	h.Schema = int32(d.varint())
+	h.ZeroThreshold = math.Float64frombits(d.uint64())
+	h.ZeroCount = d.uvarint()
+	h.Count = d.uvarint()
+	h.Sum = math.Float64frombits(d.uint64())
+	h.NegativeSpans, h.NegativeBuckets = d.spansAndBuckets(
+		h.NegativeSpans[:0], h.NegativeBuckets[:0])
+	h.PositiveSpans, h.PositiveBuckets = d.spansAndBuckets(
+		h.PositiveSpans[:0], h.PositiveBuckets[:0])
+	return d.err
+}
+
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.err = errTruncatedPayload
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errTruncatedPayload
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) uint64() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf) < 8 {
+		d.err = errTruncatedPayload
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(d.buf)
+	d.buf = d.buf[8:]
+	return v
+}
+
+func (d *decoder) spansAndBuckets(spans []Span, buckets []uint64) ([]Span, []uint64) {
+	numSpans := d.uvarint()
+	if numSpans > uint64(len(d.buf)) {
+		// Every span takes at least two bytes, guard against allocating
+		// based on a corrupt length.
+		d.err = errTruncatedPayload
+		return spans, buckets
+	}
+	for i := uint64(0); i < numSpans && d.err == nil; i++ {
+		offset := d.varint()
+		length := d.uvarint()
+		spans = append(spans, Span{Offset: int32(offset), Length: uint32(length)})
+	}
+	numBuckets := d.uvarint()
+	if numBuckets > uint64(len(d.buf)) {
+		d.err = errTruncatedPayload
+		return spans, buckets
+	}
+	for i := uint64(0); i < numBuckets && d.err == nil; i++ {
+		buckets = append(buckets, d.uvarint())
+	}
+	return spans, buckets
+}
+
+// CumulativeBuckets appends the histogram's buckets to dst as classic
+// cumulative buckets ordered by ascending upper bound. The last bucket always
+// has an upper bound of +Inf and holds the total count.
+func (h *Histogram) CumulativeBuckets(dst []Bucket) []Bucket {
+	var cumulative uint64
+
+	// Negative buckets are visited from the most negative bound, i.e. the
+	// highest bucket index, towards zero.
+	var (
+		negIdx = bucketIndexes(h.NegativeSpans)
+		posIdx = bucketIndexes(h.PositiveSpans)
+	)
+	for i := len(h.NegativeBuckets) - 1; i >= 0; i-- {
+		cumulative += h.NegativeBuckets[i]
+		dst = append(dst, Bucket{
+			UpperBound: -bucketBound(negIdx[i]-1, h.Schema),
+			Count:      cumulative,
+		})
+	}
+
+	cumulative += h.ZeroCount
+	if h.ZeroCount > 0 || len(h.NegativeBuckets) > 0 {
+		dst = append(dst, Bucket{UpperBound: h.ZeroThreshold, Count: cumulative})
+	}
+
+	for i, c := range h.PositiveBuckets {
+		cumulative += c
+		dst = append(dst, Bucket{
+			UpperBound: bucketBound(posIdx[i], h.Schema),
+			Count:      cumulative,
+		})
+	}
+
+	return append(dst, Bucket{UpperBound: math.Inf(1), Count: h.Count})
+}
+
+// bucketIndexes returns the absolute bucket index of every bucket described
+// by the spans.
+func bucketIndexes(spans []Span) []int32 {
+	var (
+		n   int
+		idx int32
+	)
+	for _, s := range spans {
+		n += int(s.Length)
+	}
+	result := make([]int32, 0, n)
+	for i, s := range spans {
+		if i == 0 {
+			idx = s.Offset
+		} else {
+			idx += s.Offset
+		}
+		for j := uint32(0); j < s.Length; j++ {
+			result = append(result, idx)
+			idx++
+		}
+	}
+	return result
+}
+
+// bucketBound returns the upper bound of the bucket with the given index,
+// which is base^index where base = 2^(2^-schema).
+func bucketBound(index int32, schema int32) float64 {
+	if schema <= 0 {
+		return math.Ldexp(1, int(index)<<uint(-schema))
+	}
+	return math.Exp2(float64(index) / float64(int32(1)<<uint(schema)))
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testHistogram() Histogram {
+	return Histogram{
+		CounterResetHint: NotCounterReset,
+		Schema:           0,
+		ZeroThreshold:    0.001,
+		ZeroCount:        2,
+		Count:            12,
+		Sum:              18.4,
+		PositiveSpans:    []Span{{Offset: 0, Length: 2}, {Offset: 1, Length: 1}},
+		PositiveBuckets:  []uint64{1, 2, 4},
+		NegativeSpans:    []Span{{Offset: 0, Length: 1}},
+		NegativeBuckets:  []uint64{3},
+	}
+}
+
+func TestHistogramMarshalRoundTrip(t *testing.T) {
+	h := testHistogram()
+	require.NoError(t, h.Validate())
+
+	b := h.Marshal()
+	require.True(t, IsHistogram(b))
+
+	var decoded Histogram
+	require.NoError(t, decoded.Unmarshal(b))
+	assert.Equal(t, h, decoded)
+
+	// Unmarshal must reuse and fully overwrite existing state.
+	other := Histogram{Schema: 3, PositiveBuckets: []uint64{5, 6, 7, 8}}
+	require.NoError(t, other.Unmarshal(b))
+	assert.Equal(t, h, other)
+}
+
+func TestHistogramUnmarshalErrors(t *testing.T) {
+	var h Histogram
+	assert.Error(t, h.Unmarshal(nil))
+	assert.Error(t, h.Unmarshal([]byte{0x08, 0x01, 0x00}))
+	assert.Error(t, h.Unmarshal([]byte{magicByte, 0x7f, 0x00}))
+
+	src := testHistogram()
+	b := src.Marshal()
+	for i := 3; i < len(b); i++ {
+		assert.Error(t, h.Unmarshal(b[:i]), "truncated at %d", i)
+	}
+}
+
+func TestIsHistogram(t *testing.T) {
+	assert.False(t, IsHistogram(nil))
+	assert.False(t, IsHistogram([]byte("foo")))
+	// Protobuf annotation payloads always start with a non-zero tag.
+	assert.False(t, IsHistogram([]byte{0x18, 0x01}))
+	assert.True(t, IsHistogram([]byte{magicByte, currentVersion}))
+}
+
+func TestHistogramValidate(t *testing.T) {
+	h := testHistogram()
+	h.Schema = MaxSchema + 1
+	assert.Error(t, h.Validate())
+
+	h = testHistogram()
+	h.PositiveBuckets = h.PositiveBuckets[:1]
+	assert.Error(t, h.Validate())
+
+	h = testHistogram()
+	h.Count = 1
+	assert.Error(t, h.Validate())
+
+	h = testHistogram()
+	h.PositiveSpans[1].Offset = -1
+	assert.Error(t, h.Validate())
+}
+
+func TestHistogramCumulativeBuckets(t *testing.T) {
+	h := testHistogram()
+	buckets := h.CumulativeBuckets(nil)
+
+	// Schema 0 has a base of 2, positive bucket indexes are 0, 1 and 3 and
+	// negative bucket index 0 covers [-1, -0.5).
+	assert.Equal(t, []Bucket{
+		{UpperBound: -0.5, Count: 3},
+		{UpperBound: 0.001, Count: 5},
+		{UpperBound: 1, Count: 6},
+		{UpperBound: 2, Count: 8},
+		{UpperBound: 8, Count: 12},
+		{UpperBound: math.Inf(1), Count: 12},
+	}, buckets)
+}
+
+func TestBucketBound(t *testing.T) {
+	assert.Equal(t, 16.0, bucketBound(1, -2))
+	assert.Equal(t, 4.0, bucketBound(2, 0))
+	assert.InDelta(t, math.Sqrt2, bucketBound(1, 1), 1e-12)
+	assert.InDelta(t, 0.5, bucketBound(-2, 1), 1e-12)
+}
@@ -0,0 +1,339 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var (
+	errIteratorClosed   = errors.New("histogram iterator is closed")
+	errBucketsMismatch  = errors.New("histogram spans do not match bucket count")
+	errCorruptSpanCount = errors.New("histogram span count exceeds stream size")
+)
+
+// maxSpansPerSide bounds the number of spans decoded for one side of a
+// histogram to protect against allocating on corrupt streams.
+const maxSpansPerSide = 1 << 16
+
+// DefaultReaderIteratorAllocFn returns a function for allocating NewReaderIterator.
+func DefaultReaderIteratorAllocFn(
+	opts encoding.Options,
+) func(r xio.Reader64, _ namespace.SchemaDescr) encoding.ReaderIterator {
+	return func(r xio.Reader64, _ namespace.SchemaDescr) encoding.ReaderIterator {
+		return NewReaderIterator(r, opts)
+	}
+}
+
+// streamKind is the encoding of the stream being read, which is only known
+// once its leading bit has been inspected.
+type streamKind int
+
+const (
+	streamKindUnknown streamKind = iota
+	streamKindHistogram
+	streamKindM3TSZ
+)
+
+// readerIterator reads float datapoints and histograms off an encoded stream.
+// Streams that were written with the M3TSZ encoder, for instance before the
+// histogram encoding was enabled on a node, are read with an M3TSZ iterator.
+type readerIterator struct {
+	reader xio.Reader64
+	is     *encoding.IStream
+	opts   encoding.Options
+
+	kind      streamKind
+	m3tszIter encoding.ReaderIterator
+
+	err        error
+	tsIterator m3tsz.TimestampIterator
+	floatIter  m3tsz.FloatEncoderAndIterator
+	sumIter    m3tsz.FloatEncoderAndIterator
+
+	curr        ts.Datapoint
+	histo       Histogram // last decoded histogram
+	isHistogram bool      // whether the current datapoint is a histogram
+	histoBytes  []byte    // current histogram marshaled as an annotation
+
+	closed bool
+}
+
+// NewReaderIterator returns a new iterator for a given reader.
+func NewReaderIterator(
+	reader xio.Reader64,
+	opts encoding.Options,
+) encoding.ReaderIterator {
+	return &readerIterator{
+		reader:     reader,
+		is:         encoding.NewIStream(reader),
+		opts:       opts,
+		tsIterator: m3tsz.NewTimestampIterator(opts, false),
+	}
+}
+
+// Next moves to the next item.
+func (it *readerIterator) Next() bool {
+	if !it.hasNext() {
+		return false
+	}
+
+	if it.kind == streamKindUnknown {
+		it.detectStreamKind()
+	}
+	if it.kind == streamKindM3TSZ {
+		return it.m3tszIter.Next()
+	}
+
+	_, done, err := it.tsIterator.ReadTimestamp(it.is)
+	if err != nil || done {
+		it.err = err
+		return false
+	}
+
+	kind, err := it.is.ReadBit()
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.curr.TimestampNanos = it.tsIterator.PrevTime
+	if kind == opcodeFloatSample {
+		if err := it.floatIter.ReadFloat(it.is); err != nil {
+			it.err = err
+			return false
+		}
+		it.isHistogram = false
+		it.curr.Value = math.Float64frombits(it.floatIter.PrevFloatBits)
+		return it.hasNext()
+	}
+
+	if err := it.readHistogram(); err != nil {
+		it.err = err
+		return false
+	}
+	it.isHistogram = true
+	it.curr.Value = float64(it.histo.Count)
+	it.histoBytes = it.histo.AppendMarshal(it.histoBytes[:0])
+	return it.hasNext()
+}
+
+// detectStreamKind peeks at the leading bit of the underlying reader, before
+// anything has been consumed by the bit stream, to pick the decoder to use.
+func (it *readerIterator) detectStreamKind() {
+	word, n, err := it.reader.Peek64()
+	if err != nil || n == 0 || word>>63 != opcodeHistogramStream {
+		it.kind = streamKindM3TSZ
+		if it.m3tszIter == nil {
+			// NB: the inner iterator is never returned to a pool, it is owned
+			// by this iterator and reset along with it.
+			it.m3tszIter = m3tsz.NewReaderIterator(it.reader,
+				m3tsz.DefaultIntOptimizationEnabled,
+				it.opts.SetReaderIteratorPool(nil))
+		} else {
+			it.m3tszIter.Reset(it.reader, nil)
+		}
+		return
+	}
+
+	it.kind = streamKindHistogram
+	if _, err := it.is.ReadBit(); err != nil {
+		it.err = err
+	}
+}
+
+func (it *readerIterator) readHistogram() error {
+	layoutBit, err := it.is.ReadBit()
+	if err != nil {
+		return err
+	}
+
+	h := &it.histo
+	layoutChanged := layoutBit == opcodeLayoutChanged
+	if layoutChanged {
+		schema, err := binary.ReadVarint(it.is)
+		if err != nil {
+			return err
+		}
+		zeroThreshold, err := it.is.ReadBits(64)
+		if err != nil {
+			return err
+		}
+		h.Schema = int32(schema)
+		h.ZeroThreshold = math.Float64frombits(zeroThreshold)
+		if h.NegativeSpans, err = it.readSpans(h.NegativeSpans[:0]); err != nil {
+			return err
+		}
+		if h.PositiveSpans, err = it.readSpans(h.PositiveSpans[:0]); err != nil {
+			return err
+		}
+	}
+
+	hint, err := it.is.ReadBits(numResetHintBits)
+	if err != nil {
+		return err
+	}
+	h.CounterResetHint = CounterResetHint(hint)
+
+	countDelta, err := binary.ReadVarint(it.is)
+	if err != nil {
+		return err
+	}
+	zeroCountDelta, err := binary.ReadVarint(it.is)
+	if err != nil {
+		return err
+	}
+	h.Count += uint64(countDelta)
+	h.ZeroCount += uint64(zeroCountDelta)
+
+	if err := it.sumIter.ReadFloat(it.is); err != nil {
+		return err
+	}
+	h.Sum = math.Float64frombits(it.sumIter.PrevFloatBits)
+
+	if h.NegativeBuckets, err = it.readBuckets(
+		h.NegativeBuckets, h.NegativeSpans, layoutChanged); err != nil {
+		return err
+	}
+	h.PositiveBuckets, err = it.readBuckets(
+		h.PositiveBuckets, h.PositiveSpans, layoutChanged)
+	return err
+}
+
+func (it *readerIterator) readSpans(spans []Span) ([]Span, error) {
+	n, err := binary.ReadUvarint(it.is)
+	if err != nil {
+		return nil, err
+	}
+	if n > maxSpansPerSide {
+		return nil, errCorruptSpanCount
+	}
+	for i := uint64(0); i < n; i++ {
+		offset, err := binary.ReadVarint(it.is)
+		if err != nil {
+			return nil, err
+		}
+		length, err := binary.ReadUvarint(it.is)
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, Span{Offset: int32(offset), Length: uint32(length)})
+	}
+	return spans, nil
+}
+
+func (it *readerIterator) readBuckets(
+	buckets []uint64,
+	spans []Span,
+	layoutChanged bool,
+) ([]uint64, error) {
+	var n int
+	for _, s := range spans {
+		n += int(s.Length)
+	}
+	if !layoutChanged && len(buckets) != n {
+		return nil, errBucketsMismatch
+	}
+	if layoutChanged {
+		buckets = buckets[:0]
+		for i := 0; i < n; i++ {
+			buckets = append(buckets, 0)
+		}
+	}
+
+	var last uint64
+	for i := range buckets {
+		delta, err := binary.ReadVarint(it.is)
+		if err != nil {
+			return nil, err
+		}
+		if !layoutChanged {
+			last = buckets[i]
+		}
+		buckets[i] = last + uint64(delta)
+		last = buckets[i]
+	}
+	return buckets, nil
+}
+
+// Current returns the value as well as the annotation associated with the
+// current datapoint. For histogram samples the annotation holds the marshaled
+// histogram, which can be decoded with Histogram.Unmarshal. Users should not
+// hold on to the returned Annotation object as it may get invalidated when
+// the iterator calls Next().
+func (it *readerIterator) Current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
+	if it.kind == streamKindM3TSZ {
+		return it.m3tszIter.Current()
+	}
+	if it.isHistogram {
+		return it.curr, it.tsIterator.TimeUnit, it.histoBytes
+	}
+	return it.curr, it.tsIterator.TimeUnit, it.tsIterator.PrevAnt
+}
+
+// Err returns the error encountered.
+func (it *readerIterator) Err() error {
+	if it.err == nil && it.kind == streamKindM3TSZ {
+		return it.m3tszIter.Err()
+	}
+	return it.err
+}
+
+func (it *readerIterator) hasNext() bool {
+	return it.err == nil && !it.tsIterator.Done
+}
+
+// Reset resets the ReadIterator for reuse.
+func (it *readerIterator) Reset(reader xio.Reader64, schema namespace.SchemaDescr) {
+	it.reader = reader
+	it.kind = streamKindUnknown
+	it.is.Reset(reader)
+	it.tsIterator = m3tsz.NewTimestampIterator(it.opts, it.tsIterator.SkipMarkers)
+	it.floatIter = m3tsz.FloatEncoderAndIterator{}
+	it.sumIter = m3tsz.FloatEncoderAndIterator{}
+	it.histo = resetHistogram(it.histo)
+	it.isHistogram = false
+	it.curr = ts.Datapoint{}
+	it.err = nil
+	it.closed = false
+}
+
+// Close closes the ReaderIterator.
+func (it *readerIterator) Close() {
+	if it.closed {
+		return
+	}
+
+	it.closed = true
+	it.err = errIteratorClosed
+	if pool := it.opts.ReaderIteratorPool(); pool != nil {
+		pool.Put(it)
+	}
+}
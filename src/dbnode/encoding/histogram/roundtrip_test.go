@@ -0,0 +1,243 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/x/context"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testStartTime = xtime.FromSeconds(1427162400)
+
+type testSample struct {
+	dp         ts.Datapoint
+	annotation ts.Annotation
+	histogram  *Histogram
+}
+
+func TestRoundTripHistograms(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 50; i++ {
+		validateRoundTrip(t, generateSamples(rng, 500, false))
+	}
+}
+
+func TestRoundTripMixedFloatsAndHistograms(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 50; i++ {
+		validateRoundTrip(t, generateSamples(rng, 500, true))
+	}
+}
+
+func TestEncoderRejectsInvalidHistogram(t *testing.T) {
+	enc := NewEncoder(testStartTime, nil, nil)
+	h := testHistogram()
+	h.PositiveBuckets = nil
+
+	err := enc.Encode(ts.Datapoint{TimestampNanos: testStartTime}, xtime.Second, h.Marshal())
+	require.Error(t, err)
+	require.Equal(t, 0, enc.NumEncoded())
+}
+
+func TestEncoderLastEncoded(t *testing.T) {
+	enc := NewEncoder(testStartTime, nil, nil)
+	_, err := enc.LastEncoded()
+	require.Error(t, err)
+
+	h := testHistogram()
+	ant := h.Marshal()
+	at := testStartTime.Add(time.Second)
+	require.NoError(t, enc.Encode(ts.Datapoint{TimestampNanos: at}, xtime.Second, ant))
+
+	last, err := enc.LastEncoded()
+	require.NoError(t, err)
+	require.Equal(t, ts.Datapoint{TimestampNanos: at, Value: float64(h.Count)}, last)
+
+	checksum, err := enc.LastAnnotationChecksum()
+	require.NoError(t, err)
+	require.NotZero(t, checksum)
+}
+
+func TestReaderIteratorReadsM3TSZStreams(t *testing.T) {
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	var (
+		opts     = encoding.NewOptions()
+		m3tszEnc = m3tsz.NewEncoder(testStartTime, nil,
+			m3tsz.DefaultIntOptimizationEnabled, opts)
+		histoEnc = NewEncoder(testStartTime, nil, opts)
+		expected []ts.Datapoint
+	)
+	for i := 0; i < 100; i++ {
+		dp := ts.Datapoint{
+			TimestampNanos: testStartTime.Add(time.Duration(i) * time.Second),
+			Value:          float64(i) * 1.5,
+		}
+		require.NoError(t, m3tszEnc.Encode(dp, xtime.Second, nil))
+		require.NoError(t, histoEnc.Encode(dp, xtime.Second, nil))
+		expected = append(expected, dp)
+	}
+
+	// Reset the same iterator across both encodings to make sure the
+	// encoding is detected per stream rather than once per iterator.
+	var it encoding.ReaderIterator
+	for _, enc := range []encoding.Encoder{m3tszEnc, histoEnc, m3tszEnc} {
+		stream, ok := enc.Stream(ctx)
+		require.True(t, ok)
+		if it == nil {
+			it = NewReaderIterator(stream, opts)
+			defer it.Close()
+		} else {
+			it.Reset(stream, nil)
+		}
+
+		var actual []ts.Datapoint
+		for it.Next() {
+			dp, unit, ant := it.Current()
+			require.Equal(t, xtime.Second, unit)
+			require.Nil(t, ant)
+			actual = append(actual, dp)
+		}
+		require.NoError(t, it.Err())
+		require.Equal(t, expected, actual)
+	}
+}
+
+func generateSamples(rng *rand.Rand, n int, mixFloats bool) []testSample {
+	var (
+		samples = make([]testSample, 0, n)
+		curr    = testStartTime
+		h       = testHistogram()
+	)
+	for i := 0; i < n; i++ {
+		curr = curr.Add(time.Duration(rng.Intn(10)+1) * time.Second)
+		if mixFloats && rng.Intn(4) == 0 {
+			var ant ts.Annotation
+			if rng.Intn(10) == 0 {
+				ant = ts.Annotation("foo")
+			}
+			samples = append(samples, testSample{
+				dp:         ts.Datapoint{TimestampNanos: curr, Value: rng.NormFloat64() * 100},
+				annotation: ant,
+			})
+			continue
+		}
+
+		switch r := rng.Intn(20); {
+		case r == 0:
+			// Counter reset.
+			h = testHistogram()
+			h.CounterResetHint = CounterReset
+		case r == 1:
+			// Layout change, add a new positive bucket.
+			h.Schema = int32(rng.Intn(MaxSchema-MinSchema+1) + MinSchema)
+			h.PositiveSpans = append(h.PositiveSpans, Span{Offset: 2, Length: 1})
+			h.PositiveBuckets = append(h.PositiveBuckets, 0)
+		default:
+			h.CounterResetHint = NotCounterReset
+		}
+
+		for j := range h.PositiveBuckets {
+			inc := uint64(rng.Intn(5))
+			h.PositiveBuckets[j] += inc
+			h.Count += inc
+			h.Sum += float64(inc) * rng.Float64()
+		}
+		for j := range h.NegativeBuckets {
+			inc := uint64(rng.Intn(3))
+			h.NegativeBuckets[j] += inc
+			h.Count += inc
+			h.Sum -= float64(inc) * rng.Float64()
+		}
+		zeroInc := uint64(rng.Intn(2))
+		h.ZeroCount += zeroInc
+		h.Count += zeroInc
+
+		copied := h.Copy()
+		samples = append(samples, testSample{
+			dp:         ts.Datapoint{TimestampNanos: curr, Value: float64(h.Count)},
+			annotation: copied.Marshal(),
+			histogram:  &copied,
+		})
+	}
+	return samples
+}
+
+func validateRoundTrip(t *testing.T, samples []testSample) {
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	enc := NewEncoder(testStartTime, nil, encoding.NewOptions())
+	for _, s := range samples {
+		require.NoError(t, enc.Encode(s.dp, xtime.Second, s.annotation))
+	}
+	require.Equal(t, len(samples), enc.NumEncoded())
+
+	stream, ok := enc.Stream(ctx)
+	require.True(t, ok)
+
+	it := NewReaderIterator(stream, encoding.NewOptions())
+	defer it.Close()
+
+	var (
+		i           int
+		decoded     Histogram
+		prevFloatAn ts.Annotation
+	)
+	for it.Next() {
+		require.True(t, i < len(samples))
+		dp, unit, ant := it.Current()
+		expected := samples[i]
+
+		require.Equal(t, expected.dp.TimestampNanos, dp.TimestampNanos, "sample #%d", i)
+		require.Equal(t, expected.dp.Value, dp.Value, "sample #%d", i)
+		require.Equal(t, xtime.Second, unit)
+
+		if expected.histogram != nil {
+			require.True(t, IsHistogram(ant), "sample #%d", i)
+			require.NoError(t, decoded.Unmarshal(ant))
+			require.Equal(t, *expected.histogram, decoded.Copy(), "sample #%d", i)
+		} else {
+			// Repeated float annotations are elided just like with M3TSZ.
+			expectedAnt := expected.annotation
+			if string(prevFloatAn) == string(expectedAnt) {
+				expectedAnt = nil
+			}
+			require.Equal(t, expectedAnt, ant, "sample #%d", i)
+			if expected.annotation != nil {
+				prevFloatAn = expected.annotation
+			}
+		}
+		i++
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, len(samples), i)
+}
@@ -37,6 +37,9 @@
 		AggregatedAttributes
 		DownsampleOptions
 		StagingState
+		TieringOptions
+		RollupOptions
+		RetentionRule
 		Registry
 		NamespaceRuntimeOptions
 		ExtendedOptions
@@ -182,20 +185,24 @@ func (m *IndexOptions) GetBlockSizeNanos() int64 {
 }
 
 type NamespaceOptions struct {
-	BootstrapEnabled      bool                        `protobuf:"varint,1,opt,name=bootstrapEnabled,proto3" json:"bootstrapEnabled,omitempty"`
-	FlushEnabled          bool                        `protobuf:"varint,2,opt,name=flushEnabled,proto3" json:"flushEnabled,omitempty"`
-	WritesToCommitLog     bool                        `protobuf:"varint,3,opt,name=writesToCommitLog,proto3" json:"writesToCommitLog,omitempty"`
-	CleanupEnabled        bool                        `protobuf:"varint,4,opt,name=cleanupEnabled,proto3" json:"cleanupEnabled,omitempty"`
-	RepairEnabled         bool                        `protobuf:"varint,5,opt,name=repairEnabled,proto3" json:"repairEnabled,omitempty"`
-	RetentionOptions      *RetentionOptions           `protobuf:"bytes,6,opt,name=retentionOptions" json:"retentionOptions,omitempty"`
-	SnapshotEnabled       bool                        `protobuf:"varint,7,opt,name=snapshotEnabled,proto3" json:"snapshotEnabled,omitempty"`
-	IndexOptions          *IndexOptions               `protobuf:"bytes,8,opt,name=indexOptions" json:"indexOptions,omitempty"`
-	SchemaOptions         *SchemaOptions              `protobuf:"bytes,9,opt,name=schemaOptions" json:"schemaOptions,omitempty"`
-	ColdWritesEnabled     bool                        `protobuf:"varint,10,opt,name=coldWritesEnabled,proto3" json:"coldWritesEnabled,omitempty"`
-	RuntimeOptions        *NamespaceRuntimeOptions    `protobuf:"bytes,11,opt,name=runtimeOptions" json:"runtimeOptions,omitempty"`
-	CacheBlocksOnRetrieve *google_protobuf1.BoolValue `protobuf:"bytes,12,opt,name=cacheBlocksOnRetrieve" json:"cacheBlocksOnRetrieve,omitempty"`
-	AggregationOptions    *AggregationOptions         `protobuf:"bytes,13,opt,name=aggregationOptions" json:"aggregationOptions,omitempty"`
-	StagingState          *StagingState               `protobuf:"bytes,14,opt,name=stagingState" json:"stagingState,omitempty"`
+	BootstrapEnabled           bool                        `protobuf:"varint,1,opt,name=bootstrapEnabled,proto3" json:"bootstrapEnabled,omitempty"`
+	FlushEnabled               bool                        `protobuf:"varint,2,opt,name=flushEnabled,proto3" json:"flushEnabled,omitempty"`
+	WritesToCommitLog          bool                        `protobuf:"varint,3,opt,name=writesToCommitLog,proto3" json:"writesToCommitLog,omitempty"`
+	CleanupEnabled             bool                        `protobuf:"varint,4,opt,name=cleanupEnabled,proto3" json:"cleanupEnabled,omitempty"`
+	RepairEnabled              bool                        `protobuf:"varint,5,opt,name=repairEnabled,proto3" json:"repairEnabled,omitempty"`
+	RetentionOptions           *RetentionOptions           `protobuf:"bytes,6,opt,name=retentionOptions" json:"retentionOptions,omitempty"`
+	SnapshotEnabled            bool                        `protobuf:"varint,7,opt,name=snapshotEnabled,proto3" json:"snapshotEnabled,omitempty"`
+	IndexOptions               *IndexOptions               `protobuf:"bytes,8,opt,name=indexOptions" json:"indexOptions,omitempty"`
+	SchemaOptions              *SchemaOptions              `protobuf:"bytes,9,opt,name=schemaOptions" json:"schemaOptions,omitempty"`
+	ColdWritesEnabled          bool                        `protobuf:"varint,10,opt,name=coldWritesEnabled,proto3" json:"coldWritesEnabled,omitempty"`
+	RuntimeOptions             *NamespaceRuntimeOptions    `protobuf:"bytes,11,opt,name=runtimeOptions" json:"runtimeOptions,omitempty"`
+	CacheBlocksOnRetrieve      *google_protobuf1.BoolValue `protobuf:"bytes,12,opt,name=cacheBlocksOnRetrieve" json:"cacheBlocksOnRetrieve,omitempty"`
+	AggregationOptions         *AggregationOptions         `protobuf:"bytes,13,opt,name=aggregationOptions" json:"aggregationOptions,omitempty"`
+	StagingState               *StagingState               `protobuf:"bytes,14,opt,name=stagingState" json:"stagingState,omitempty"`
+	TieringOptions             *TieringOptions             `protobuf:"bytes,15,opt,name=tieringOptions" json:"tieringOptions,omitempty"`
+	RollupOptions              *RollupOptions              `protobuf:"bytes,16,opt,name=rollupOptions" json:"rollupOptions,omitempty"`
+	LateWriteQuarantineEnabled bool                        `protobuf:"varint,17,opt,name=lateWriteQuarantineEnabled,proto3" json:"lateWriteQuarantineEnabled,omitempty"`
+	RetentionRules             []*RetentionRule            `protobuf:"bytes,18,rep,name=retentionRules" json:"retentionRules,omitempty"`
 	// Use larger field ID to ensure new fields are always added before extended options.
 	ExtendedOptions *ExtendedOptions `protobuf:"bytes,1000,opt,name=extendedOptions" json:"extendedOptions,omitempty"`
 }
@@ -303,6 +310,34 @@ func (m *NamespaceOptions) GetStagingState() *StagingState {
 	return nil
 }
 
+func (m *NamespaceOptions) GetTieringOptions() *TieringOptions {
+	if m != nil {
+		return m.TieringOptions
+	}
+	return nil
+}
+
+func (m *NamespaceOptions) GetRollupOptions() *RollupOptions {
+	if m != nil {
+		return m.RollupOptions
+	}
+	return nil
+}
+
+func (m *NamespaceOptions) GetLateWriteQuarantineEnabled() bool {
+	if m != nil {
+		return m.LateWriteQuarantineEnabled
+	}
+	return false
+}
+
+func (m *NamespaceOptions) GetRetentionRules() []*RetentionRule {
+	if m != nil {
+		return m.RetentionRules
+	}
+	return nil
+}
+
 func (m *NamespaceOptions) GetExtendedOptions() *ExtendedOptions {
 	if m != nil {
 		return m.ExtendedOptions
@@ -423,6 +458,99 @@ func (m *StagingState) GetStatus() StagingStatus {
 	return StagingStatus_UNKNOWN
 }
 
+// TieringOptions is a set of options related to tiering sealed filesets
+// to object storage.
+type TieringOptions struct {
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// tierAfterNanos is how long after the end of a block its filesets are
+	// uploaded to object storage and evicted from local disk.
+	TierAfterNanos int64 `protobuf:"varint,2,opt,name=tierAfterNanos,proto3" json:"tierAfterNanos,omitempty"`
+}
+
+func (m *TieringOptions) Reset()                    { *m = TieringOptions{} }
+func (m *TieringOptions) String() string            { return proto.CompactTextString(m) }
+func (*TieringOptions) ProtoMessage()               {}
+func (*TieringOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{8} }
+
+func (m *TieringOptions) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *TieringOptions) GetTierAfterNanos() int64 {
+	if m != nil {
+		return m.TierAfterNanos
+	}
+	return 0
+}
+
+// RollupOptions is a set of options related to compacting old blocks
+// into rollups at a coarser resolution.
+type RollupOptions struct {
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// rollupAfterNanos is how long after the end of a block it is
+	// compacted into rollups.
+	RollupAfterNanos int64 `protobuf:"varint,2,opt,name=rollupAfterNanos,proto3" json:"rollupAfterNanos,omitempty"`
+	// resolutionNanos is the resolution of the rollups.
+	ResolutionNanos int64 `protobuf:"varint,3,opt,name=resolutionNanos,proto3" json:"resolutionNanos,omitempty"`
+}
+
+func (m *RollupOptions) Reset()                    { *m = RollupOptions{} }
+func (m *RollupOptions) String() string            { return proto.CompactTextString(m) }
+func (*RollupOptions) ProtoMessage()               {}
+func (*RollupOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{9} }
+
+func (m *RollupOptions) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *RollupOptions) GetRollupAfterNanos() int64 {
+	if m != nil {
+		return m.RollupAfterNanos
+	}
+	return 0
+}
+
+func (m *RollupOptions) GetResolutionNanos() int64 {
+	if m != nil {
+		return m.ResolutionNanos
+	}
+	return 0
+}
+
+// RetentionRule overrides the retention period of the series whose tags
+// match a filter.
+type RetentionRule struct {
+	// filter is the tags filter in the metrics filters syntax.
+	Filter string `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// retentionPeriodNanos is the retention period of the matching series.
+	RetentionPeriodNanos int64 `protobuf:"varint,2,opt,name=retentionPeriodNanos,proto3" json:"retentionPeriodNanos,omitempty"`
+}
+
+func (m *RetentionRule) Reset()                    { *m = RetentionRule{} }
+func (m *RetentionRule) String() string            { return proto.CompactTextString(m) }
+func (*RetentionRule) ProtoMessage()               {}
+func (*RetentionRule) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{10} }
+
+func (m *RetentionRule) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *RetentionRule) GetRetentionPeriodNanos() int64 {
+	if m != nil {
+		return m.RetentionPeriodNanos
+	}
+	return 0
+}
+
 type Registry struct {
 	Namespaces map[string]*NamespaceOptions `protobuf:"bytes,1,rep,name=namespaces" json:"namespaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
 }
@@ -430,7 +558,7 @@ type Registry struct {
 func (m *Registry) Reset()                    { *m = Registry{} }
 func (m *Registry) String() string            { return proto.CompactTextString(m) }
 func (*Registry) ProtoMessage()               {}
-func (*Registry) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{8} }
+func (*Registry) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{11} }
 
 func (m *Registry) GetNamespaces() map[string]*NamespaceOptions {
 	if m != nil {
@@ -444,10 +572,12 @@ type NamespaceRuntimeOptions struct {
 	FlushIndexingPerCPUConcurrency *google_protobuf1.DoubleValue `protobuf:"bytes,2,opt,name=flushIndexingPerCPUConcurrency" json:"flushIndexingPerCPUConcurrency,omitempty"`
 }
 
-func (m *NamespaceRuntimeOptions) Reset()                    { *m = NamespaceRuntimeOptions{} }
-func (m *NamespaceRuntimeOptions) String() string            { return proto.CompactTextString(m) }
-func (*NamespaceRuntimeOptions) ProtoMessage()               {}
-func (*NamespaceRuntimeOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{9} }
+func (m *NamespaceRuntimeOptions) Reset()         { *m = NamespaceRuntimeOptions{} }
+func (m *NamespaceRuntimeOptions) String() string { return proto.CompactTextString(m) }
+func (*NamespaceRuntimeOptions) ProtoMessage()    {}
+func (*NamespaceRuntimeOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptorNamespace, []int{12}
+}
 
 func (m *NamespaceRuntimeOptions) GetWriteIndexingPerCPUConcurrency() *google_protobuf1.DoubleValue {
 	if m != nil {
@@ -471,7 +601,7 @@ type ExtendedOptions struct {
 func (m *ExtendedOptions) Reset()                    { *m = ExtendedOptions{} }
 func (m *ExtendedOptions) String() string            { return proto.CompactTextString(m) }
 func (*ExtendedOptions) ProtoMessage()               {}
-func (*ExtendedOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{10} }
+func (*ExtendedOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{13} }
 
 func (m *ExtendedOptions) GetType() string {
 	if m != nil {
@@ -496,6 +626,9 @@ func init() {
 	proto.RegisterType((*AggregatedAttributes)(nil), "namespace.AggregatedAttributes")
 	proto.RegisterType((*DownsampleOptions)(nil), "namespace.DownsampleOptions")
 	proto.RegisterType((*StagingState)(nil), "namespace.StagingState")
+	proto.RegisterType((*TieringOptions)(nil), "namespace.TieringOptions")
+	proto.RegisterType((*RollupOptions)(nil), "namespace.RollupOptions")
+	proto.RegisterType((*RetentionRule)(nil), "namespace.RetentionRule")
 	proto.RegisterType((*Registry)(nil), "namespace.Registry")
 	proto.RegisterType((*NamespaceRuntimeOptions)(nil), "namespace.NamespaceRuntimeOptions")
 	proto.RegisterType((*ExtendedOptions)(nil), "namespace.ExtendedOptions")
@@ -747,17 +880,65 @@ func (m *NamespaceOptions) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n7
 	}
+	if m.TieringOptions != nil {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.TieringOptions.Size()))
+		n8, err := m.TieringOptions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if m.RollupOptions != nil {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.RollupOptions.Size()))
+		n9, err := m.RollupOptions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if m.LateWriteQuarantineEnabled {
+		dAtA[i] = 0x88
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.LateWriteQuarantineEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.RetentionRules) > 0 {
+		for _, msg := range m.RetentionRules {
+			dAtA[i] = 0x92
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintNamespace(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.ExtendedOptions != nil {
 		dAtA[i] = 0xc2
 		i++
 		dAtA[i] = 0x3e
 		i++
 		i = encodeVarintNamespace(dAtA, i, uint64(m.ExtendedOptions.Size()))
-		n8, err := m.ExtendedOptions.MarshalTo(dAtA[i:])
+		n10, err := m.ExtendedOptions.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n10
 	}
 	return i, nil
 }
@@ -821,11 +1002,11 @@ func (m *Aggregation) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintNamespace(dAtA, i, uint64(m.Attributes.Size()))
-		n9, err := m.Attributes.MarshalTo(dAtA[i:])
+		n11, err := m.Attributes.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n9
+		i += n11
 	}
 	return i, nil
 }
@@ -854,11 +1035,11 @@ func (m *AggregatedAttributes) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintNamespace(dAtA, i, uint64(m.DownsampleOptions.Size()))
-		n10, err := m.DownsampleOptions.MarshalTo(dAtA[i:])
+		n12, err := m.DownsampleOptions.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n10
+		i += n12
 	}
 	return i, nil
 }
@@ -914,6 +1095,106 @@ func (m *StagingState) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *TieringOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TieringOptions) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Enabled {
+		dAtA[i] = 0x8
+		i++
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.TierAfterNanos != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.TierAfterNanos))
+	}
+	return i, nil
+}
+
+func (m *RollupOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RollupOptions) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Enabled {
+		dAtA[i] = 0x8
+		i++
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.RollupAfterNanos != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.RollupAfterNanos))
+	}
+	if m.ResolutionNanos != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.ResolutionNanos))
+	}
+	return i, nil
+}
+
+func (m *RetentionRule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RetentionRule) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Filter) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.Filter)))
+		i += copy(dAtA[i:], m.Filter)
+	}
+	if m.RetentionPeriodNanos != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.RetentionPeriodNanos))
+	}
+	return i, nil
+}
+
 func (m *Registry) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -949,11 +1230,11 @@ func (m *Registry) MarshalTo(dAtA []byte) (int, error) {
 				dAtA[i] = 0x12
 				i++
 				i = encodeVarintNamespace(dAtA, i, uint64(v.Size()))
-				n11, err := v.MarshalTo(dAtA[i:])
+				n13, err := v.MarshalTo(dAtA[i:])
 				if err != nil {
 					return 0, err
 				}
-				i += n11
+				i += n13
 			}
 		}
 	}
@@ -979,21 +1260,21 @@ func (m *NamespaceRuntimeOptions) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintNamespace(dAtA, i, uint64(m.WriteIndexingPerCPUConcurrency.Size()))
-		n12, err := m.WriteIndexingPerCPUConcurrency.MarshalTo(dAtA[i:])
+		n14, err := m.WriteIndexingPerCPUConcurrency.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n14
 	}
 	if m.FlushIndexingPerCPUConcurrency != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintNamespace(dAtA, i, uint64(m.FlushIndexingPerCPUConcurrency.Size()))
-		n13, err := m.FlushIndexingPerCPUConcurrency.MarshalTo(dAtA[i:])
+		n15, err := m.FlushIndexingPerCPUConcurrency.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n15
 	}
 	return i, nil
 }
@@ -1023,11 +1304,11 @@ func (m *ExtendedOptions) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintNamespace(dAtA, i, uint64(m.Options.Size()))
-		n14, err := m.Options.MarshalTo(dAtA[i:])
+		n16, err := m.Options.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n14
+		i += n16
 	}
 	return i, nil
 }
@@ -1132,6 +1413,23 @@ func (m *NamespaceOptions) Size() (n int) {
 		l = m.StagingState.Size()
 		n += 1 + l + sovNamespace(uint64(l))
 	}
+	if m.TieringOptions != nil {
+		l = m.TieringOptions.Size()
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	if m.RollupOptions != nil {
+		l = m.RollupOptions.Size()
+		n += 2 + l + sovNamespace(uint64(l))
+	}
+	if m.LateWriteQuarantineEnabled {
+		n += 3
+	}
+	if len(m.RetentionRules) > 0 {
+		for _, e := range m.RetentionRules {
+			l = e.Size()
+			n += 2 + l + sovNamespace(uint64(l))
+		}
+	}
 	if m.ExtendedOptions != nil {
 		l = m.ExtendedOptions.Size()
 		n += 2 + l + sovNamespace(uint64(l))
@@ -1195,6 +1493,46 @@ func (m *StagingState) Size() (n int) {
 	return n
 }
 
+func (m *TieringOptions) Size() (n int) {
+	var l int
+	_ = l
+	if m.Enabled {
+		n += 2
+	}
+	if m.TierAfterNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.TierAfterNanos))
+	}
+	return n
+}
+
+func (m *RollupOptions) Size() (n int) {
+	var l int
+	_ = l
+	if m.Enabled {
+		n += 2
+	}
+	if m.RollupAfterNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.RollupAfterNanos))
+	}
+	if m.ResolutionNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.ResolutionNanos))
+	}
+	return n
+}
+
+func (m *RetentionRule) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Filter)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	if m.RetentionPeriodNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.RetentionPeriodNanos))
+	}
+	return n
+}
+
 func (m *Registry) Size() (n int) {
 	var l int
 	_ = l
@@ -1928,9 +2266,9 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 1000:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExtendedOptions", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TieringOptions", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1954,29 +2292,146 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ExtendedOptions == nil {
-				m.ExtendedOptions = &ExtendedOptions{}
+			if m.TieringOptions == nil {
+				m.TieringOptions = &TieringOptions{}
 			}
-			if err := m.ExtendedOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.TieringOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipNamespace(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthNamespace
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RollupOptions", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RollupOptions == nil {
+				m.RollupOptions = &RollupOptions{}
+			}
+			if err := m.RollupOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LateWriteQuarantineEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LateWriteQuarantineEnabled = bool(v != 0)
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetentionRules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RetentionRules = append(m.RetentionRules, &RetentionRule{})
+			if err := m.RetentionRules[len(m.RetentionRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1000:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtendedOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExtendedOptions == nil {
+				m.ExtendedOptions = &ExtendedOptions{}
+			}
+			if err := m.ExtendedOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
@@ -2407,6 +2862,301 @@ func (m *StagingState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *TieringOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TieringOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TieringOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TierAfterNanos", wireType)
+			}
+			m.TierAfterNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TierAfterNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RollupOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RollupOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RollupOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RollupAfterNanos", wireType)
+			}
+			m.RollupAfterNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RollupAfterNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResolutionNanos", wireType)
+			}
+			m.ResolutionNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResolutionNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RetentionRule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RetentionRule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RetentionRule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Filter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Filter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetentionPeriodNanos", wireType)
+			}
+			m.RetentionPeriodNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetentionPeriodNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Registry) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2918,68 +3668,77 @@ func init() {
 }
 
 var fileDescriptorNamespace = []byte{
-	// 1001 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x96, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0x80, 0xbb, 0xf9, 0x73, 0x72, 0xec, 0x24, 0xce, 0xa8, 0x10, 0x2b, 0x2d, 0xa6, 0x5a, 0x7e,
-	0x14, 0x55, 0xc8, 0xa6, 0xc9, 0x0d, 0x14, 0xa9, 0xe0, 0x24, 0x26, 0x72, 0x29, 0x8e, 0x35, 0x69,
-	0x29, 0xe4, 0x6e, 0x76, 0xf7, 0x78, 0xb3, 0xea, 0x7a, 0x66, 0x35, 0x33, 0xdb, 0x24, 0x3c, 0x43,
-	0x2f, 0x78, 0x0f, 0x5e, 0x84, 0x4b, 0x1e, 0x01, 0x05, 0x21, 0x71, 0xc3, 0x3b, 0xa0, 0x9d, 0xf5,
-	0x3a, 0xfb, 0xe3, 0x96, 0x88, 0x9b, 0x68, 0x72, 0xce, 0x77, 0x7e, 0xe6, 0xfc, 0xcc, 0x1a, 0x8e,
-	0xfd, 0x40, 0x9f, 0xc7, 0x4e, 0xc7, 0x15, 0x93, 0xee, 0x64, 0xdf, 0x73, 0xba, 0x93, 0xfd, 0xae,
-	0x92, 0x6e, 0xd7, 0x73, 0xb8, 0xf0, 0xb0, 0xeb, 0x23, 0x47, 0xc9, 0x34, 0x7a, 0xdd, 0x48, 0x0a,
-	0x2d, 0xba, 0x9c, 0x4d, 0x50, 0x45, 0xcc, 0xc5, 0x9b, 0x53, 0xc7, 0x68, 0xc8, 0xda, 0x4c, 0xb0,
-	0x73, 0xdf, 0x17, 0xc2, 0x0f, 0x31, 0x35, 0x71, 0xe2, 0x71, 0x57, 0x69, 0x19, 0xbb, 0x3a, 0x05,
-	0x77, 0xda, 0x65, 0xed, 0x85, 0x64, 0x51, 0x84, 0x52, 0x4d, 0xf5, 0x47, 0xff, 0x37, 0x23, 0xe5,
-	0x9e, 0xe3, 0x84, 0xa5, 0x5e, 0xec, 0x37, 0x8b, 0xd0, 0xa4, 0xa8, 0x91, 0xeb, 0x40, 0xf0, 0x93,
-	0x28, 0xf9, 0xab, 0xc8, 0x1e, 0xdc, 0x95, 0x99, 0x6c, 0x84, 0x32, 0x10, 0xde, 0x90, 0x71, 0xa1,
-	0x5a, 0xd6, 0x03, 0x6b, 0x77, 0x91, 0xce, 0xd5, 0x91, 0x4f, 0x61, 0xc3, 0x09, 0x85, 0xfb, 0xea,
-	0x34, 0xf8, 0x19, 0x53, 0x7a, 0xc1, 0xd0, 0x25, 0x29, 0xf9, 0x0c, 0xb6, 0x9c, 0x78, 0x3c, 0x46,
-	0xf9, 0x6d, 0xac, 0x63, 0x39, 0x45, 0x17, 0x0d, 0x5a, 0x55, 0x90, 0x5d, 0xd8, 0x4c, 0x85, 0x23,
-	0xa6, 0x74, 0xca, 0x2e, 0x19, 0xb6, 0x2c, 0x36, 0x64, 0x12, 0xe9, 0x88, 0x69, 0xd6, 0xbf, 0x8c,
-	0x02, 0x79, 0xd5, 0x5a, 0x7e, 0x60, 0xed, 0xae, 0xd2, 0xb2, 0x98, 0x9c, 0xc1, 0x6e, 0x49, 0xd4,
-	0x1b, 0x6b, 0x94, 0x43, 0xa1, 0x7b, 0xae, 0x8b, 0x4a, 0xe5, 0x6f, 0xbc, 0x62, 0x82, 0xdd, 0x9a,
-	0x27, 0x4f, 0x60, 0x67, 0x6c, 0xd2, 0xa7, 0xf3, 0xea, 0x57, 0x33, 0xde, 0xde, 0x41, 0xd8, 0x23,
-	0x68, 0x0c, 0xb8, 0x87, 0x97, 0x59, 0x27, 0x5a, 0x50, 0x43, 0xce, 0x9c, 0x10, 0x3d, 0x53, 0xfc,
-	0x55, 0x9a, 0xfd, 0x7b, 0xdb, 0x7a, 0xdb, 0xff, 0xac, 0x40, 0x73, 0x98, 0xf5, 0x3e, 0x73, 0xfb,
-	0x10, 0x9a, 0x8e, 0x10, 0x5a, 0x69, 0xc9, 0xa2, 0x7e, 0xc1, 0x7f, 0x45, 0x4e, 0x6c, 0x68, 0x8c,
-	0xc3, 0x58, 0x9d, 0x67, 0xdc, 0x82, 0xe1, 0x0a, 0xb2, 0xa4, 0xa9, 0x17, 0x32, 0xd0, 0xa8, 0x9e,
-	0x8b, 0x43, 0x31, 0x99, 0x04, 0xfa, 0x99, 0xf0, 0x4d, 0x53, 0x57, 0x69, 0x55, 0x91, 0xa4, 0xee,
-	0x86, 0xc8, 0x78, 0x3c, 0x8b, 0xbd, 0x64, 0xd0, 0x92, 0x94, 0x7c, 0x0c, 0xeb, 0x12, 0x23, 0x16,
-	0xc8, 0x0c, 0x4b, 0x1b, 0x5a, 0x14, 0x92, 0x63, 0x68, 0xca, 0xd2, 0x00, 0x9b, 0xb6, 0xd5, 0xf7,
-	0xee, 0x75, 0x6e, 0x96, 0xaf, 0x3c, 0xe3, 0xb4, 0x62, 0x94, 0x4c, 0x90, 0xe2, 0x2c, 0x52, 0xe7,
-	0x42, 0x67, 0x01, 0x6b, 0xe9, 0x04, 0x95, 0xc4, 0xe4, 0x2b, 0x68, 0x04, 0xb9, 0x2e, 0xb5, 0x56,
-	0x4d, 0xb8, 0xed, 0x5c, 0xb8, 0x7c, 0x13, 0x69, 0x01, 0x26, 0x4f, 0x60, 0x3d, 0xdd, 0xc0, 0xcc,
-	0x7a, 0xcd, 0x58, 0xb7, 0x72, 0xd6, 0xa7, 0x79, 0x3d, 0x2d, 0xe2, 0x49, 0xad, 0x5d, 0x11, 0x7a,
-	0x2f, 0x4d, 0x59, 0xb3, 0x44, 0x21, 0xad, 0x75, 0x45, 0x41, 0x9e, 0xc2, 0x86, 0x8c, 0xb9, 0x0e,
-	0x26, 0x59, 0xef, 0x5b, 0x75, 0x13, 0xce, 0xce, 0x85, 0x9b, 0x8d, 0x07, 0x2d, 0x90, 0xb4, 0x64,
-	0x49, 0x46, 0xf0, 0x9e, 0xcb, 0xdc, 0x73, 0x3c, 0x48, 0x26, 0x4c, 0x9d, 0x70, 0x8a, 0x5a, 0x06,
-	0xf8, 0x1a, 0x5b, 0x0d, 0xe3, 0x72, 0xa7, 0x93, 0xbe, 0x58, 0x9d, 0xec, 0xc5, 0xea, 0x1c, 0x08,
-	0x11, 0xfe, 0xc0, 0xc2, 0x18, 0xe9, 0x7c, 0x43, 0xf2, 0x3d, 0x10, 0xe6, 0xfb, 0x12, 0x7d, 0x96,
-	0xef, 0xde, 0xba, 0x71, 0xf7, 0x41, 0x2e, 0xc3, 0x5e, 0x05, 0xa2, 0x73, 0x0c, 0x93, 0xbe, 0x28,
-	0xcd, 0xfc, 0x80, 0xfb, 0xa7, 0x9a, 0x69, 0x6c, 0x6d, 0x54, 0xfa, 0x72, 0x9a, 0x53, 0xd3, 0x02,
-	0x4c, 0xfa, 0xb0, 0x89, 0x97, 0x1a, 0xb9, 0x87, 0x5e, 0x96, 0xc8, 0xdf, 0xb5, 0xe9, 0xc5, 0x6e,
-	0x1c, 0xf4, 0x8b, 0x08, 0x2d, 0xdb, 0xd8, 0x23, 0x20, 0xd5, 0x6c, 0xc9, 0x63, 0x68, 0xe4, 0xf2,
-	0x4d, 0x5e, 0xd2, 0xc5, 0xdd, 0xfa, 0xde, 0xfb, 0xf3, 0xaf, 0x48, 0x0b, 0xac, 0xcd, 0xa1, 0x9e,
-	0x53, 0x92, 0x36, 0x40, 0xa6, 0x9e, 0x6d, 0x6d, 0x4e, 0x42, 0xbe, 0x06, 0x60, 0x5a, 0xcb, 0xc0,
-	0x89, 0x35, 0xa6, 0x8f, 0x42, 0x7d, 0xef, 0xc3, 0x39, 0x81, 0xd0, 0xeb, 0xcd, 0x30, 0x9a, 0x33,
-	0xb1, 0xdf, 0x58, 0x70, 0x77, 0x1e, 0x94, 0x2c, 0x88, 0x44, 0x25, 0xc2, 0x38, 0xc9, 0x23, 0xff,
-	0x45, 0x28, 0x8b, 0xc9, 0x53, 0xd8, 0xf2, 0xc4, 0x05, 0x57, 0x6c, 0x12, 0x85, 0xb3, 0xc1, 0x4b,
-	0x53, 0xb9, 0x9f, 0x4b, 0xe5, 0xa8, 0xcc, 0xd0, 0xaa, 0x99, 0xfd, 0x09, 0x6c, 0x55, 0x38, 0xd2,
-	0x84, 0x45, 0x16, 0x86, 0xd3, 0xdb, 0x27, 0x47, 0xfb, 0x1b, 0x68, 0xe4, 0x9b, 0x4b, 0x3e, 0x87,
-	0x15, 0xa5, 0x99, 0x8e, 0xd3, 0x1c, 0x37, 0x8a, 0xfb, 0x75, 0x03, 0xc6, 0x8a, 0x4e, 0x39, 0xfb,
-	0x57, 0x0b, 0x56, 0x29, 0xfa, 0x81, 0xd2, 0xf2, 0x8a, 0x1c, 0x02, 0xcc, 0xf8, 0xac, 0x5d, 0x1f,
-	0x15, 0xde, 0x93, 0x14, 0xbc, 0x59, 0x1e, 0xd5, 0xe7, 0x5a, 0x5e, 0xd1, 0x9c, 0xd9, 0xce, 0x19,
-	0x6c, 0x96, 0xd4, 0x49, 0xe2, 0xaf, 0xf0, 0xca, 0xe4, 0xb4, 0x46, 0x93, 0x23, 0x79, 0x04, 0xcb,
-	0xaf, 0x93, 0x1d, 0x99, 0xd6, 0xe7, 0xde, 0xbc, 0xc5, 0xcc, 0xca, 0x93, 0x92, 0x8f, 0x17, 0xbe,
-	0xb0, 0xec, 0xbf, 0x2c, 0xd8, 0x7e, 0xcb, 0xe2, 0x12, 0x0f, 0xda, 0xe6, 0xd5, 0x35, 0xaf, 0x50,
-	0xc0, 0xfd, 0x11, 0xca, 0xc3, 0xd1, 0x8b, 0x43, 0xc1, 0xdd, 0x58, 0x4a, 0xe4, 0x6e, 0x1a, 0x3f,
-	0xe9, 0x45, 0x79, 0x63, 0x8f, 0x44, 0xec, 0x84, 0x98, 0xee, 0xec, 0x7f, 0xf8, 0x48, 0xa2, 0x98,
-	0x8f, 0xc0, 0xdb, 0xa3, 0x2c, 0xdc, 0x26, 0xca, 0xbb, 0x7d, 0xd8, 0x3f, 0xc2, 0x66, 0x69, 0xe7,
-	0x08, 0x81, 0x25, 0x7d, 0x15, 0xe1, 0xb4, 0x88, 0xe6, 0x4c, 0x1e, 0x41, 0x4d, 0x14, 0xe6, 0x6c,
-	0xbb, 0x12, 0xf5, 0xd4, 0xfc, 0xba, 0xa2, 0x19, 0xf7, 0xf0, 0x4b, 0x58, 0x2f, 0x0c, 0x02, 0xa9,
-	0x43, 0xed, 0xc5, 0xf0, 0xbb, 0xe1, 0xc9, 0xcb, 0x61, 0xf3, 0x0e, 0x69, 0x42, 0x63, 0x30, 0x1c,
-	0x3c, 0x1f, 0xf4, 0x9e, 0x0d, 0xce, 0x06, 0xc3, 0xe3, 0xa6, 0x45, 0xd6, 0x60, 0x99, 0xf6, 0x7b,
-	0x47, 0x3f, 0x35, 0x17, 0x0e, 0x9a, 0xbf, 0x5d, 0xb7, 0xad, 0xdf, 0xaf, 0xdb, 0xd6, 0x1f, 0xd7,
-	0x6d, 0xeb, 0x97, 0x3f, 0xdb, 0x77, 0x9c, 0x15, 0x13, 0x66, 0xff, 0xdf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x48, 0x8b, 0xb8, 0x2d, 0x28, 0x0a, 0x00, 0x00,
+	// 1144 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x6e, 0xdb, 0xc6,
+	0x13, 0x8e, 0xa4, 0xc4, 0x92, 0xc7, 0x92, 0x4c, 0x2f, 0xf2, 0x4b, 0xf4, 0x73, 0x53, 0xd5, 0x60,
+	0xff, 0xc0, 0x30, 0x0a, 0xa9, 0xb1, 0x2f, 0x6d, 0x0a, 0xa4, 0x91, 0x6d, 0xd5, 0x50, 0x9a, 0xca,
+	0xea, 0xda, 0x69, 0x5a, 0xf7, 0xb4, 0x22, 0x47, 0x34, 0x11, 0x8a, 0x4b, 0xec, 0x2e, 0x63, 0xbb,
+	0x97, 0xbe, 0x40, 0x0e, 0x7d, 0x8f, 0xbe, 0x48, 0x8f, 0x7d, 0x84, 0xc2, 0x45, 0x81, 0x9e, 0xfb,
+	0x04, 0x05, 0x97, 0xa2, 0xcc, 0x3f, 0xb2, 0x6b, 0xf4, 0x22, 0x50, 0x33, 0xdf, 0xcc, 0x37, 0xdc,
+	0x6f, 0x76, 0x86, 0x70, 0xe0, 0xb8, 0xea, 0x34, 0x1c, 0x77, 0x2c, 0x3e, 0xed, 0x4e, 0x77, 0xec,
+	0x71, 0x77, 0xba, 0xd3, 0x95, 0xc2, 0xea, 0xda, 0x63, 0x9f, 0xdb, 0xd8, 0x75, 0xd0, 0x47, 0xc1,
+	0x14, 0xda, 0xdd, 0x40, 0x70, 0xc5, 0xbb, 0x3e, 0x9b, 0xa2, 0x0c, 0x98, 0x85, 0x57, 0x4f, 0x1d,
+	0xed, 0x21, 0xcb, 0x73, 0xc3, 0xfa, 0x23, 0x87, 0x73, 0xc7, 0xc3, 0x38, 0x64, 0x1c, 0x4e, 0xba,
+	0x52, 0x89, 0xd0, 0x52, 0x31, 0x70, 0xbd, 0x9d, 0xf7, 0x9e, 0x09, 0x16, 0x04, 0x28, 0xe4, 0xcc,
+	0xbf, 0xff, 0x5f, 0x2b, 0x92, 0xd6, 0x29, 0x4e, 0x59, 0x9c, 0xc5, 0x7c, 0x5b, 0x01, 0x83, 0xa2,
+	0x42, 0x5f, 0xb9, 0xdc, 0x3f, 0x0c, 0xa2, 0x5f, 0x49, 0xb6, 0xe1, 0xbe, 0x48, 0x6c, 0x23, 0x14,
+	0x2e, 0xb7, 0x87, 0xcc, 0xe7, 0xb2, 0x55, 0xda, 0x28, 0x6d, 0x56, 0xe8, 0x42, 0x1f, 0xf9, 0x08,
+	0x9a, 0x63, 0x8f, 0x5b, 0xaf, 0x8f, 0xdc, 0x1f, 0x31, 0x46, 0x97, 0x35, 0x3a, 0x67, 0x25, 0x1f,
+	0xc3, 0xda, 0x38, 0x9c, 0x4c, 0x50, 0x7c, 0x19, 0xaa, 0x50, 0xcc, 0xa0, 0x15, 0x0d, 0x2d, 0x3a,
+	0xc8, 0x26, 0xac, 0xc6, 0xc6, 0x11, 0x93, 0x2a, 0xc6, 0xde, 0xd5, 0xd8, 0xbc, 0x59, 0x23, 0x23,
+	0xa6, 0x7d, 0xa6, 0x58, 0xff, 0x3c, 0x70, 0xc5, 0x45, 0xeb, 0xde, 0x46, 0x69, 0xb3, 0x46, 0xf3,
+	0x66, 0x72, 0x02, 0x9b, 0x39, 0x53, 0x6f, 0xa2, 0x50, 0x0c, 0xb9, 0xea, 0x59, 0x16, 0x4a, 0x99,
+	0x7e, 0xe3, 0x25, 0x4d, 0x76, 0x6b, 0x3c, 0x79, 0x0a, 0xeb, 0x13, 0x5d, 0x3e, 0x5d, 0x74, 0x7e,
+	0x55, 0x9d, 0xed, 0x06, 0x84, 0x39, 0x82, 0xfa, 0xc0, 0xb7, 0xf1, 0x3c, 0x51, 0xa2, 0x05, 0x55,
+	0xf4, 0xd9, 0xd8, 0x43, 0x5b, 0x1f, 0x7e, 0x8d, 0x26, 0x7f, 0x6f, 0x7b, 0xde, 0xe6, 0xdf, 0x35,
+	0x30, 0x86, 0x89, 0xf6, 0x49, 0xda, 0x2d, 0x30, 0xc6, 0x9c, 0x2b, 0xa9, 0x04, 0x0b, 0xfa, 0x99,
+	0xfc, 0x05, 0x3b, 0x31, 0xa1, 0x3e, 0xf1, 0x42, 0x79, 0x9a, 0xe0, 0xca, 0x1a, 0x97, 0xb1, 0x45,
+	0xa2, 0x9e, 0x09, 0x57, 0xa1, 0x3c, 0xe6, 0x7b, 0x7c, 0x3a, 0x75, 0xd5, 0x0b, 0xee, 0x68, 0x51,
+	0x6b, 0xb4, 0xe8, 0x88, 0x4a, 0xb7, 0x3c, 0x64, 0x7e, 0x38, 0xe7, 0xbe, 0xab, 0xa1, 0x39, 0x2b,
+	0xf9, 0x00, 0x1a, 0x02, 0x03, 0xe6, 0x8a, 0x04, 0x16, 0x0b, 0x9a, 0x35, 0x92, 0x03, 0x30, 0x44,
+	0xae, 0x81, 0xb5, 0x6c, 0x2b, 0xdb, 0xef, 0x74, 0xae, 0x2e, 0x5f, 0xbe, 0xc7, 0x69, 0x21, 0x28,
+	0xea, 0x20, 0xe9, 0xb3, 0x40, 0x9e, 0x72, 0x95, 0x10, 0x56, 0xe3, 0x0e, 0xca, 0x99, 0xc9, 0xe7,
+	0x50, 0x77, 0x53, 0x2a, 0xb5, 0x6a, 0x9a, 0xee, 0x61, 0x8a, 0x2e, 0x2d, 0x22, 0xcd, 0x80, 0xc9,
+	0x53, 0x68, 0xc4, 0x37, 0x30, 0x89, 0x5e, 0xd6, 0xd1, 0xad, 0x54, 0xf4, 0x51, 0xda, 0x4f, 0xb3,
+	0xf0, 0xe8, 0xac, 0x2d, 0xee, 0xd9, 0xaf, 0xf4, 0xb1, 0x26, 0x85, 0x42, 0x7c, 0xd6, 0x05, 0x07,
+	0x79, 0x0e, 0x4d, 0x11, 0xfa, 0xca, 0x9d, 0x26, 0xda, 0xb7, 0x56, 0x34, 0x9d, 0x99, 0xa2, 0x9b,
+	0xb7, 0x07, 0xcd, 0x20, 0x69, 0x2e, 0x92, 0x8c, 0xe0, 0x7f, 0x16, 0xb3, 0x4e, 0x71, 0x37, 0xea,
+	0x30, 0x79, 0xe8, 0x53, 0x54, 0xc2, 0xc5, 0x37, 0xd8, 0xaa, 0xeb, 0x94, 0xeb, 0x9d, 0x78, 0x62,
+	0x75, 0x92, 0x89, 0xd5, 0xd9, 0xe5, 0xdc, 0xfb, 0x96, 0x79, 0x21, 0xd2, 0xc5, 0x81, 0xe4, 0x6b,
+	0x20, 0xcc, 0x71, 0x04, 0x3a, 0x2c, 0xad, 0x5e, 0x43, 0xa7, 0x7b, 0x37, 0x55, 0x61, 0xaf, 0x00,
+	0xa2, 0x0b, 0x02, 0x23, 0x5d, 0xa4, 0x62, 0x8e, 0xeb, 0x3b, 0x47, 0x8a, 0x29, 0x6c, 0x35, 0x0b,
+	0xba, 0x1c, 0xa5, 0xdc, 0x34, 0x03, 0x26, 0x3d, 0x68, 0x2a, 0x17, 0x85, 0xeb, 0x3b, 0x49, 0x1d,
+	0xab, 0x3a, 0xfc, 0xff, 0xa9, 0xf0, 0xe3, 0x0c, 0x80, 0xe6, 0x02, 0x22, 0x69, 0x05, 0xf7, 0xbc,
+	0x30, 0x48, 0x32, 0x18, 0x05, 0x69, 0x69, 0xda, 0x4f, 0xb3, 0xf0, 0x68, 0x7a, 0x78, 0x4c, 0xa1,
+	0x56, 0xf0, 0x9b, 0x90, 0x09, 0xe6, 0x2b, 0xd7, 0xc7, 0x44, 0xe3, 0x35, 0xad, 0xf1, 0x0d, 0x08,
+	0xf2, 0x0c, 0x9a, 0xf3, 0xae, 0xa6, 0xa1, 0x87, 0xb2, 0x45, 0x36, 0x2a, 0xf9, 0x02, 0xd2, 0x00,
+	0x9a, 0xc3, 0x93, 0x3e, 0xac, 0xe2, 0xb9, 0x42, 0xdf, 0x46, 0x3b, 0x79, 0x87, 0xbf, 0xaa, 0x33,
+	0x75, 0xaf, 0x72, 0xf4, 0xb3, 0x10, 0x9a, 0x8f, 0x31, 0x47, 0x40, 0x8a, 0x92, 0x91, 0x27, 0x50,
+	0x4f, 0x89, 0x16, 0xad, 0x93, 0xa8, 0xb8, 0x07, 0x8b, 0x75, 0xa6, 0x19, 0xac, 0xe9, 0xc3, 0x4a,
+	0xca, 0x49, 0xda, 0x00, 0x89, 0x7b, 0x3e, 0xba, 0x52, 0x16, 0xf2, 0x05, 0x00, 0x53, 0x4a, 0xb8,
+	0xe3, 0x50, 0x61, 0x3c, 0x19, 0x57, 0xb6, 0xdf, 0x5b, 0x40, 0x84, 0x76, 0x6f, 0x0e, 0xa3, 0xa9,
+	0x10, 0xf3, 0x6d, 0x09, 0xee, 0x2f, 0x02, 0x45, 0x53, 0x42, 0xa0, 0xe4, 0x5e, 0x18, 0xd5, 0x91,
+	0x5e, 0x8b, 0x79, 0x33, 0x79, 0x0e, 0x6b, 0x36, 0x3f, 0xf3, 0x25, 0x9b, 0x06, 0xde, 0xfc, 0xf6,
+	0xc5, 0xa5, 0x3c, 0x4a, 0x95, 0xb2, 0x9f, 0xc7, 0xd0, 0x62, 0x98, 0xf9, 0x21, 0xac, 0x15, 0x70,
+	0xc4, 0x80, 0x0a, 0xf3, 0xbc, 0xd9, 0xdb, 0x47, 0x8f, 0xe6, 0x33, 0xa8, 0xa7, 0x3b, 0x9c, 0x7c,
+	0x02, 0x4b, 0x52, 0x31, 0x15, 0xc6, 0x35, 0x36, 0xb3, 0x43, 0xe6, 0x0a, 0x18, 0x4a, 0x3a, 0xc3,
+	0x99, 0x14, 0x9a, 0xd9, 0x26, 0xbf, 0x79, 0x05, 0x45, 0x17, 0x20, 0xde, 0x86, 0xe9, 0x15, 0x94,
+	0xb5, 0x9a, 0x3f, 0x41, 0x23, 0xd3, 0xf6, 0x37, 0xa4, 0xdc, 0x02, 0x23, 0xbe, 0x12, 0x85, 0xa4,
+	0x05, 0xfb, 0x22, 0x25, 0x2a, 0x0b, 0x95, 0x30, 0x7f, 0x80, 0x46, 0xa6, 0xed, 0xc9, 0x03, 0x58,
+	0x9a, 0xb8, 0x9e, 0x42, 0xa1, 0xf9, 0x97, 0xe9, 0xec, 0xdf, 0xb5, 0x1f, 0x3e, 0xe5, 0xeb, 0x3f,
+	0x7c, 0xcc, 0x5f, 0x4a, 0x50, 0xa3, 0xe8, 0xb8, 0x52, 0x89, 0x0b, 0xb2, 0x07, 0x30, 0x3f, 0xe1,
+	0xa4, 0xc1, 0xdf, 0xcf, 0xdc, 0xbe, 0x18, 0x78, 0x35, 0x73, 0x65, 0xdf, 0x57, 0xe2, 0x82, 0xa6,
+	0xc2, 0xd6, 0x4f, 0x60, 0x35, 0xe7, 0x8e, 0xa4, 0x7e, 0x8d, 0x17, 0xb3, 0x6a, 0xa3, 0x47, 0xf2,
+	0x18, 0xee, 0xbd, 0x89, 0x46, 0x6b, 0xab, 0x5c, 0xd8, 0x75, 0xf9, 0x75, 0x4f, 0x63, 0xe4, 0x93,
+	0xf2, 0xa7, 0x25, 0xf3, 0xcf, 0x12, 0x3c, 0xbc, 0x66, 0xde, 0x13, 0x1b, 0xda, 0x7a, 0x59, 0xeb,
+	0xe5, 0xe5, 0xfa, 0xce, 0x08, 0xc5, 0xde, 0xe8, 0xe5, 0x1e, 0xf7, 0xad, 0x50, 0x08, 0xf4, 0xad,
+	0x98, 0x3f, 0xea, 0xde, 0xfc, 0xa0, 0xdf, 0xe7, 0xe1, 0xd8, 0xc3, 0x78, 0xd4, 0xff, 0x4b, 0x8e,
+	0x88, 0x45, 0x7f, 0x3b, 0x5c, 0xcf, 0x52, 0xbe, 0x0d, 0xcb, 0xcd, 0x39, 0xcc, 0xef, 0x60, 0x35,
+	0x37, 0xa5, 0x08, 0x81, 0xbb, 0xea, 0x22, 0xc0, 0xd9, 0x21, 0xea, 0x67, 0xf2, 0x18, 0xaa, 0x3c,
+	0x73, 0x33, 0x1f, 0x16, 0x58, 0x8f, 0xf4, 0x47, 0x39, 0x4d, 0x70, 0x5b, 0x9f, 0x41, 0x23, 0x73,
+	0x75, 0xc8, 0x0a, 0x54, 0x5f, 0x0e, 0xbf, 0x1a, 0x1e, 0xbe, 0x1a, 0x1a, 0x77, 0x88, 0x01, 0xf5,
+	0xc1, 0x70, 0x70, 0x3c, 0xe8, 0xbd, 0x18, 0x9c, 0x0c, 0x86, 0x07, 0x46, 0x89, 0x2c, 0xc3, 0x3d,
+	0xda, 0xef, 0xed, 0x7f, 0x6f, 0x94, 0x77, 0x8d, 0x5f, 0x2f, 0xdb, 0xa5, 0xdf, 0x2e, 0xdb, 0xa5,
+	0xdf, 0x2f, 0xdb, 0xa5, 0x9f, 0xff, 0x68, 0xdf, 0x19, 0x2f, 0x69, 0x9a, 0x9d, 0x7f, 0x06, 0x00,
+	0x9f, 0x85, 0x6e, 0x05, 0x5f, 0x0c, 0x00, 0x00,
 }
@@ -35,7 +35,8 @@ enum ErrorType {
 enum ErrorFlags {
     NONE               = 0x00,
     RESOURCE_EXHAUSTED = 0x01,
-    SERVER_TIMEOUT     = 0x02
+    SERVER_TIMEOUT     = 0x02,
+    OVERLOADED         = 0x04
 }
 
 exception Error {
@@ -69,6 +70,13 @@ service Node {
 	void                           writeTaggedBatchRawV2(1: WriteTaggedBatchRawV2Request req) throws (1: WriteBatchRawErrors err)
 	void                           repair() throws (1: Error err)
 	TruncateResult                 truncate(1: TruncateRequest req) throws (1: Error err)
+	DeleteTaggedResult             deleteTagged(1: DeleteTaggedRequest req) throws (1: Error err)
+	QuarantineListResult           quarantineList(1: QuarantineListRequest req) throws (1: Error err)
+	QuarantineResult               quarantinePromote(1: QuarantineRequest req) throws (1: Error err)
+	QuarantineResult               quarantineDiscard(1: QuarantineRequest req) throws (1: Error err)
+	CardinalityResult              cardinality(1: CardinalityRequest req) throws (1: Error err)
+	FetchMerkleNodesRawResult      fetchMerkleNodesRaw(1: FetchMerkleNodesRawRequest req) throws (1: Error err)
+	FetchMerkleLeavesRawResult     fetchMerkleLeavesRaw(1: FetchMerkleLeavesRawRequest req) throws (1: Error err)
 
 	AggregateTilesResult aggregateTiles(1: AggregateTilesRequest req) throws (1: Error err)
 
@@ -185,6 +193,13 @@ struct FetchTaggedRequest {
 	9: optional i64 docsLimit
 	10: optional binary source
 	11: optional bool requireNoWait = false
+	12: optional i64 resolutionNanos
+	13: optional bool explain
+	14: optional i64 pageSize
+	15: optional binary pageToken
+	// rollupAggregation is the aggregation of the rollups read for blocks
+	// compacted into rollups when resolutionNanos is set, 0 is the average.
+	16: optional i32 rollupAggregation
 }
 
 struct FetchTaggedResult {
@@ -192,6 +207,9 @@ struct FetchTaggedResult {
 	2: required bool exhaustive
 	3: optional i64 waitedIndex
 	4: optional i64 waitedSeriesRead
+	5: optional binary explain
+	6: optional binary nextPageToken
+	7: optional FetchTaggedStats stats
 }
 
 struct FetchTaggedIDResult {
@@ -204,6 +222,12 @@ struct FetchTaggedIDResult {
 	5: optional Error err
 }
 
+struct FetchTaggedStats {
+	1: required i64 docsRead
+	2: required i64 bytesRead
+	3: required i64 blocksRead
+}
+
 struct FetchBlocksRawRequest {
 	1: required binary nameSpace
 	2: required i32 shard
@@ -322,6 +346,96 @@ struct TruncateResult {
 	1: required i64 numSeries
 }
 
+struct DeleteTaggedRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: required i64 rangeStart
+	4: required i64 rangeEnd
+	5: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+	6: optional binary source
+}
+
+struct DeleteTaggedResult {
+	1: required i64 numSeries
+	2: optional map<i32,i64> numSeriesByShard
+}
+
+struct QuarantineListRequest {
+	1: required string nameSpace
+	2: optional i64 limit = 0
+	3: optional TimeType resultTimeType = TimeType.UNIX_SECONDS
+}
+
+struct QuarantineListResult {
+	1: required i64 numWrites
+	2: required list<QuarantinedWrite> writes
+}
+
+struct QuarantinedWrite {
+	1: required string id
+	2: required list<Tag> tags
+	3: required Datapoint datapoint
+	4: required i64 quarantinedAt
+}
+
+struct QuarantineRequest {
+	1: required string nameSpace
+}
+
+struct QuarantineResult {
+	1: required i64 numWrites
+}
+
+struct CardinalityRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: required i64 rangeStart
+	4: required i64 rangeEnd
+	5: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+	6: optional i64 limit = 0
+	7: optional binary metricNameTag
+	8: optional i64 seriesLimit
+	9: optional i64 docsLimit
+	10: optional bool requireExhaustive
+	11: optional binary source
+}
+
+struct CardinalityResult {
+	1: required list<CardinalityEntry> seriesCountByMetricName
+	2: required list<CardinalityEntry> labelValueCountByLabelName
+	3: required list<CardinalityEntry> seriesCountByLabelValuePair
+	4: required bool exhaustive
+}
+
+struct CardinalityEntry {
+	1: required binary name
+	2: required i64 value
+}
+
+struct FetchMerkleNodesRawRequest {
+	1: required binary nameSpace
+	2: required i32 shard
+	3: required i64 blockStart
+	4: required i32 depth
+	5: required list<i64> nodes
+}
+
+struct FetchMerkleNodesRawResult {
+	1: required list<i64> hashes
+}
+
+struct FetchMerkleLeavesRawRequest {
+	1: required binary nameSpace
+	2: required i32 shard
+	3: required i64 blockStart
+	4: required i32 depth
+	5: required list<i64> leaves
+}
+
+struct FetchMerkleLeavesRawResult {
+	1: required list<BlockMetadataV2> elements
+}
+
 struct NodeHealthResult {
 	1: required bool ok
 	2: required string status
@@ -255,6 +255,21 @@ func (mr *MockTChanNodeMockRecorder) BootstrappedInPlacementOrNoPlacement(ctx in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrappedInPlacementOrNoPlacement", reflect.TypeOf((*MockTChanNode)(nil).BootstrappedInPlacementOrNoPlacement), ctx)
 }
 
+// Cardinality mocks base method.
+func (m *MockTChanNode) Cardinality(ctx thrift.Context, req *CardinalityRequest) (*CardinalityResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, req)
+	ret0, _ := ret[0].(*CardinalityResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockTChanNodeMockRecorder) Cardinality(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockTChanNode)(nil).Cardinality), ctx, req)
+}
+
 // DebugIndexMemorySegments mocks base method.
 func (m *MockTChanNode) DebugIndexMemorySegments(ctx thrift.Context, req *DebugIndexMemorySegmentsRequest) (*DebugIndexMemorySegmentsResult_, error) {
 	m.ctrl.T.Helper()
@@ -300,6 +315,21 @@ func (mr *MockTChanNodeMockRecorder) DebugProfileStop(ctx, req interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebugProfileStop", reflect.TypeOf((*MockTChanNode)(nil).DebugProfileStop), ctx, req)
 }
 
+// DeleteTagged mocks base method.
+func (m *MockTChanNode) DeleteTagged(ctx thrift.Context, req *DeleteTaggedRequest) (*DeleteTaggedResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", ctx, req)
+	ret0, _ := ret[0].(*DeleteTaggedResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged.
+func (mr *MockTChanNodeMockRecorder) DeleteTagged(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*MockTChanNode)(nil).DeleteTagged), ctx, req)
+}
+
 // Fetch mocks base method.
 func (m *MockTChanNode) Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error) {
 	m.ctrl.T.Helper()
@@ -375,6 +405,36 @@ func (mr *MockTChanNodeMockRecorder) FetchBlocksRaw(ctx, req interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlocksRaw", reflect.TypeOf((*MockTChanNode)(nil).FetchBlocksRaw), ctx, req)
 }
 
+// FetchMerkleLeavesRaw mocks base method.
+func (m *MockTChanNode) FetchMerkleLeavesRaw(ctx thrift.Context, req *FetchMerkleLeavesRawRequest) (*FetchMerkleLeavesRawResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMerkleLeavesRaw", ctx, req)
+	ret0, _ := ret[0].(*FetchMerkleLeavesRawResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMerkleLeavesRaw indicates an expected call of FetchMerkleLeavesRaw.
+func (mr *MockTChanNodeMockRecorder) FetchMerkleLeavesRaw(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMerkleLeavesRaw", reflect.TypeOf((*MockTChanNode)(nil).FetchMerkleLeavesRaw), ctx, req)
+}
+
+// FetchMerkleNodesRaw mocks base method.
+func (m *MockTChanNode) FetchMerkleNodesRaw(ctx thrift.Context, req *FetchMerkleNodesRawRequest) (*FetchMerkleNodesRawResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMerkleNodesRaw", ctx, req)
+	ret0, _ := ret[0].(*FetchMerkleNodesRawResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMerkleNodesRaw indicates an expected call of FetchMerkleNodesRaw.
+func (mr *MockTChanNodeMockRecorder) FetchMerkleNodesRaw(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMerkleNodesRaw", reflect.TypeOf((*MockTChanNode)(nil).FetchMerkleNodesRaw), ctx, req)
+}
+
 // FetchTagged mocks base method.
 func (m *MockTChanNode) FetchTagged(ctx thrift.Context, req *FetchTaggedRequest) (*FetchTaggedResult_, error) {
 	m.ctrl.T.Helper()
@@ -465,6 +525,51 @@ func (mr *MockTChanNodeMockRecorder) Health(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockTChanNode)(nil).Health), ctx)
 }
 
+// QuarantineDiscard mocks base method.
+func (m *MockTChanNode) QuarantineDiscard(ctx thrift.Context, req *QuarantineRequest) (*QuarantineResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuarantineDiscard", ctx, req)
+	ret0, _ := ret[0].(*QuarantineResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuarantineDiscard indicates an expected call of QuarantineDiscard.
+func (mr *MockTChanNodeMockRecorder) QuarantineDiscard(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuarantineDiscard", reflect.TypeOf((*MockTChanNode)(nil).QuarantineDiscard), ctx, req)
+}
+
+// QuarantineList mocks base method.
+func (m *MockTChanNode) QuarantineList(ctx thrift.Context, req *QuarantineListRequest) (*QuarantineListResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuarantineList", ctx, req)
+	ret0, _ := ret[0].(*QuarantineListResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuarantineList indicates an expected call of QuarantineList.
+func (mr *MockTChanNodeMockRecorder) QuarantineList(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuarantineList", reflect.TypeOf((*MockTChanNode)(nil).QuarantineList), ctx, req)
+}
+
+// QuarantinePromote mocks base method.
+func (m *MockTChanNode) QuarantinePromote(ctx thrift.Context, req *QuarantineRequest) (*QuarantineResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuarantinePromote", ctx, req)
+	ret0, _ := ret[0].(*QuarantineResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuarantinePromote indicates an expected call of QuarantinePromote.
+func (mr *MockTChanNodeMockRecorder) QuarantinePromote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuarantinePromote", reflect.TypeOf((*MockTChanNode)(nil).QuarantinePromote), ctx, req)
+}
+
 // Query mocks base method.
 func (m *MockTChanNode) Query(ctx thrift.Context, req *QueryRequest) (*QueryResult_, error) {
 	m.ctrl.T.Helper()
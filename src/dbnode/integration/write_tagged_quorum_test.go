@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/integration/generate"
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/test"
 	"github.com/m3db/m3/src/dbnode/topology"
@@ -330,7 +331,7 @@ func nodeHasTaggedWrite(t *testing.T, s TestSetup) bool {
 	id := ident.StringID("quorumTest")
 	start := s.NowFn()()
 	end := s.NowFn()().Add(5 * time.Minute)
-	iter, err := s.DB().ReadEncoded(ctx, nsCtx.ID, id, start, end)
+	iter, err := s.DB().ReadEncoded(ctx, nsCtx.ID, id, start, end, storage.ReadEncodedOptions{})
 	require.NoError(t, err)
 	readers, err := iter.ToSlices(ctx)
 	require.NoError(t, err)
@@ -48,16 +48,20 @@ func (m *MapConfiguration) Map() (Map, error) {
 
 // MetadataConfiguration is the configuration for a single namespace
 type MetadataConfiguration struct {
-	ID                    string                  `yaml:"id" validate:"nonzero"`
-	BootstrapEnabled      *bool                   `yaml:"bootstrapEnabled"`
-	FlushEnabled          *bool                   `yaml:"flushEnabled"`
-	WritesToCommitLog     *bool                   `yaml:"writesToCommitLog"`
-	CleanupEnabled        *bool                   `yaml:"cleanupEnabled"`
-	RepairEnabled         *bool                   `yaml:"repairEnabled"`
-	ColdWritesEnabled     *bool                   `yaml:"coldWritesEnabled"`
-	CacheBlocksOnRetrieve *bool                   `yaml:"cacheBlocksOnRetrieve"`
-	Retention             retention.Configuration `yaml:"retention" validate:"nonzero"`
-	Index                 IndexConfiguration      `yaml:"index"`
+	ID                         string                       `yaml:"id" validate:"nonzero"`
+	BootstrapEnabled           *bool                        `yaml:"bootstrapEnabled"`
+	FlushEnabled               *bool                        `yaml:"flushEnabled"`
+	WritesToCommitLog          *bool                        `yaml:"writesToCommitLog"`
+	CleanupEnabled             *bool                        `yaml:"cleanupEnabled"`
+	RepairEnabled              *bool                        `yaml:"repairEnabled"`
+	ColdWritesEnabled          *bool                        `yaml:"coldWritesEnabled"`
+	LateWriteQuarantineEnabled *bool                        `yaml:"lateWriteQuarantineEnabled"`
+	CacheBlocksOnRetrieve      *bool                        `yaml:"cacheBlocksOnRetrieve"`
+	Retention                  retention.Configuration      `yaml:"retention" validate:"nonzero"`
+	Index                      IndexConfiguration           `yaml:"index"`
+	Tiering                    *TieringConfiguration        `yaml:"tiering"`
+	Rollup                     *RollupConfiguration         `yaml:"rollup"`
+	RetentionRules             []RetentionRuleConfiguration `yaml:"retentionRules"`
 }
 
 // Metadata returns a Metadata corresponding to the receiver struct
@@ -85,9 +89,25 @@ func (mc *MetadataConfiguration) Metadata() (Metadata, error) {
 	if v := mc.ColdWritesEnabled; v != nil {
 		opts = opts.SetColdWritesEnabled(*v)
 	}
+	if v := mc.LateWriteQuarantineEnabled; v != nil {
+		opts = opts.SetLateWriteQuarantineEnabled(*v)
+	}
 	if v := mc.CacheBlocksOnRetrieve; v != nil {
 		opts = opts.SetCacheBlocksOnRetrieve(*v)
 	}
+	if v := mc.Tiering; v != nil {
+		opts = opts.SetTieringOptions(v.Options())
+	}
+	if v := mc.Rollup; v != nil {
+		opts = opts.SetRollupOptions(v.Options())
+	}
+	if len(mc.RetentionRules) > 0 {
+		rules := make([]RetentionRule, 0, len(mc.RetentionRules))
+		for _, rc := range mc.RetentionRules {
+			rules = append(rules, rc.Rule())
+		}
+		opts = opts.SetRetentionRules(rules)
+	}
 	return NewMetadata(ident.StringID(mc.ID), opts)
 }
 
@@ -103,3 +123,49 @@ func (ic *IndexConfiguration) Options() IndexOptions {
 		SetEnabled(ic.Enabled).
 		SetBlockSize(ic.BlockSize)
 }
+
+// TieringConfiguration controls tiering of sealed filesets to object storage.
+type TieringConfiguration struct {
+	Enabled   bool          `yaml:"enabled"`
+	TierAfter time.Duration `yaml:"tierAfter"`
+}
+
+// Options returns the TieringOptions corresponding to the receiver struct.
+func (tc *TieringConfiguration) Options() TieringOptions {
+	return NewTieringOptions().
+		SetEnabled(tc.Enabled).
+		SetTierAfter(tc.TierAfter)
+}
+
+// RollupConfiguration controls compaction of old blocks into rollups.
+type RollupConfiguration struct {
+	Enabled     bool          `yaml:"enabled"`
+	RollupAfter time.Duration `yaml:"rollupAfter"`
+	Resolution  time.Duration `yaml:"resolution"`
+}
+
+// Options returns the RollupOptions corresponding to the receiver struct.
+func (rc *RollupConfiguration) Options() RollupOptions {
+	opts := NewRollupOptions().
+		SetEnabled(rc.Enabled).
+		SetRollupAfter(rc.RollupAfter)
+	if rc.Resolution > 0 {
+		opts = opts.SetResolution(rc.Resolution)
+	}
+	return opts
+}
+
+// RetentionRuleConfiguration overrides the retention period of the series
+// matching a tags filter.
+type RetentionRuleConfiguration struct {
+	Filter          string        `yaml:"filter" validate:"nonzero"`
+	RetentionPeriod time.Duration `yaml:"retentionPeriod" validate:"nonzero"`
+}
+
+// Rule returns the RetentionRule corresponding to the receiver struct.
+func (rc *RetentionRuleConfiguration) Rule() RetentionRule {
+	return RetentionRule{
+		Filter:          rc.Filter,
+		RetentionPeriod: rc.RetentionPeriod,
+	}
+}
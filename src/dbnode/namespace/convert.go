@@ -92,6 +92,47 @@ func ToIndexOptions(
 	return iopts, nil
 }
 
+// ToTieringOptions converts nsproto.TieringOptions to TieringOptions.
+func ToTieringOptions(to *nsproto.TieringOptions) TieringOptions {
+	topts := NewTieringOptions()
+	if to == nil {
+		return topts
+	}
+
+	return topts.SetEnabled(to.Enabled).
+		SetTierAfter(FromNanos(to.TierAfterNanos))
+}
+
+// ToRollupOptions converts nsproto.RollupOptions to RollupOptions.
+func ToRollupOptions(ro *nsproto.RollupOptions) RollupOptions {
+	ropts := NewRollupOptions()
+	if ro == nil {
+		return ropts
+	}
+
+	ropts = ropts.SetEnabled(ro.Enabled).
+		SetRollupAfter(FromNanos(ro.RollupAfterNanos))
+	if ro.ResolutionNanos > 0 {
+		ropts = ropts.SetResolution(FromNanos(ro.ResolutionNanos))
+	}
+	return ropts
+}
+
+// ToRetentionRules converts nsproto.RetentionRule to RetentionRule.
+func ToRetentionRules(rules []*nsproto.RetentionRule) []RetentionRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]RetentionRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, RetentionRule{
+			Filter:          rule.Filter,
+			RetentionPeriod: FromNanos(rule.RetentionPeriodNanos),
+		})
+	}
+	return result
+}
+
 // ToRuntimeOptions converts nsproto.NamespaceRuntimeOptions to RuntimeOptions.
 func ToRuntimeOptions(
 	opts *nsproto.NamespaceRuntimeOptions,
@@ -207,10 +248,14 @@ func ToMetadata(
 		SetRetentionOptions(rOpts).
 		SetIndexOptions(iOpts).
 		SetColdWritesEnabled(opts.ColdWritesEnabled).
+		SetLateWriteQuarantineEnabled(opts.LateWriteQuarantineEnabled).
 		SetRuntimeOptions(runtimeOpts).
 		SetExtendedOptions(extendedOpts).
 		SetAggregationOptions(aggOpts).
-		SetStagingState(stagingState)
+		SetStagingState(stagingState).
+		SetTieringOptions(ToTieringOptions(opts.TieringOptions)).
+		SetRollupOptions(ToRollupOptions(opts.RollupOptions)).
+		SetRetentionRules(ToRetentionRules(opts.RetentionRules))
 
 	if opts.CacheBlocksOnRetrieve != nil {
 		mOpts = mOpts.SetCacheBlocksOnRetrieve(opts.CacheBlocksOnRetrieve.Value)
@@ -334,12 +379,16 @@ func OptionsToProto(opts Options) (*nsproto.NamespaceOptions, error) {
 			Enabled:        iopts.Enabled(),
 			BlockSizeNanos: iopts.BlockSize().Nanoseconds(),
 		},
-		ColdWritesEnabled:     opts.ColdWritesEnabled(),
-		RuntimeOptions:        toRuntimeOptions(opts.RuntimeOptions()),
-		CacheBlocksOnRetrieve: &protobuftypes.BoolValue{Value: opts.CacheBlocksOnRetrieve()},
-		ExtendedOptions:       extendedOpts,
-		AggregationOptions:    toProtoAggregationOptions(opts.AggregationOptions()),
-		StagingState:          stagingState,
+		ColdWritesEnabled:          opts.ColdWritesEnabled(),
+		RuntimeOptions:             toRuntimeOptions(opts.RuntimeOptions()),
+		CacheBlocksOnRetrieve:      &protobuftypes.BoolValue{Value: opts.CacheBlocksOnRetrieve()},
+		ExtendedOptions:            extendedOpts,
+		AggregationOptions:         toProtoAggregationOptions(opts.AggregationOptions()),
+		StagingState:               stagingState,
+		TieringOptions:             toProtoTieringOptions(opts.TieringOptions()),
+		RollupOptions:              toProtoRollupOptions(opts.RollupOptions()),
+		LateWriteQuarantineEnabled: opts.LateWriteQuarantineEnabled(),
+		RetentionRules:             toProtoRetentionRules(opts.RetentionRules()),
 	}
 
 	return nsOpts, nil
@@ -361,6 +410,41 @@ func toProtoStagingState(state StagingState) (*nsproto.StagingState, error) {
 	return &nsproto.StagingState{Status: protoStatus}, nil
 }
 
+func toProtoTieringOptions(topts TieringOptions) *nsproto.TieringOptions {
+	if topts == nil || !topts.Enabled() {
+		return nil
+	}
+	return &nsproto.TieringOptions{
+		Enabled:        topts.Enabled(),
+		TierAfterNanos: topts.TierAfter().Nanoseconds(),
+	}
+}
+
+func toProtoRollupOptions(ropts RollupOptions) *nsproto.RollupOptions {
+	if ropts == nil || !ropts.Enabled() {
+		return nil
+	}
+	return &nsproto.RollupOptions{
+		Enabled:          ropts.Enabled(),
+		RollupAfterNanos: ropts.RollupAfter().Nanoseconds(),
+		ResolutionNanos:  ropts.Resolution().Nanoseconds(),
+	}
+}
+
+func toProtoRetentionRules(rules []RetentionRule) []*nsproto.RetentionRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	protoRules := make([]*nsproto.RetentionRule, 0, len(rules))
+	for _, rule := range rules {
+		protoRules = append(protoRules, &nsproto.RetentionRule{
+			Filter:               rule.Filter,
+			RetentionPeriodNanos: rule.RetentionPeriod.Nanoseconds(),
+		})
+	}
+	return protoRules
+}
+
 func toProtoAggregationOptions(aggOpts AggregationOptions) *nsproto.AggregationOptions {
 	if aggOpts == nil || len(aggOpts.Aggregations()) == 0 {
 		return nil
@@ -84,7 +84,7 @@ func genMap() gopter.Gen {
 func genMetadata() gopter.Gen {
 	return gopter.CombineGens(
 		gen.Identifier(),
-		gen.SliceOfN(7, gen.Bool()),
+		gen.SliceOfN(8, gen.Bool()),
 		genRetention(),
 	).Map(func(values []interface{}) namespace.Metadata {
 		var (
@@ -100,6 +100,7 @@ func genMetadata() gopter.Gen {
 			SetRepairEnabled(bools[3]).
 			SetWritesToCommitLog(bools[4]).
 			SetSnapshotEnabled(bools[5]).
+			SetLateWriteQuarantineEnabled(bools[7]).
 			SetSchemaHistory(testSchemaReg).
 			SetRetentionOptions(retention).
 			SetIndexOptions(namespace.NewIndexOptions().
@@ -445,3 +445,79 @@ func assertEqualStagingState(t *testing.T, expected *nsproto.StagingState, obser
 
 	require.Equal(t, state, observed)
 }
+
+func TestTieringOptionsRoundTrip(t *testing.T) {
+	tOpts := namespace.NewTieringOptions().
+		SetEnabled(true).
+		SetTierAfter(6 * time.Hour)
+	md, err := namespace.NewMetadata(ident.StringID("ns1"),
+		namespace.NewOptions().SetTieringOptions(tOpts))
+	require.NoError(t, err)
+
+	nsOpts, err := namespace.OptionsToProto(md.Options())
+	require.NoError(t, err)
+	require.Equal(t, &nsproto.TieringOptions{
+		Enabled:        true,
+		TierAfterNanos: int64(6 * time.Hour),
+	}, nsOpts.TieringOptions)
+
+	observed, err := namespace.ToMetadata("ns1", nsOpts)
+	require.NoError(t, err)
+	require.True(t, tOpts.Equal(observed.Options().TieringOptions()))
+}
+
+func TestToTieringOptionsNil(t *testing.T) {
+	tOpts := namespace.ToTieringOptions(nil)
+	require.True(t, namespace.NewTieringOptions().Equal(tOpts))
+	require.False(t, tOpts.Enabled())
+}
+
+func TestRollupOptionsRoundTrip(t *testing.T) {
+	rOpts := namespace.NewRollupOptions().
+		SetEnabled(true).
+		SetRollupAfter(6 * time.Hour).
+		SetResolution(5 * time.Minute)
+	md, err := namespace.NewMetadata(ident.StringID("ns1"),
+		namespace.NewOptions().SetRollupOptions(rOpts))
+	require.NoError(t, err)
+
+	nsOpts, err := namespace.OptionsToProto(md.Options())
+	require.NoError(t, err)
+	require.Equal(t, &nsproto.RollupOptions{
+		Enabled:          true,
+		RollupAfterNanos: int64(6 * time.Hour),
+		ResolutionNanos:  int64(5 * time.Minute),
+	}, nsOpts.RollupOptions)
+
+	observed, err := namespace.ToMetadata("ns1", nsOpts)
+	require.NoError(t, err)
+	require.True(t, rOpts.Equal(observed.Options().RollupOptions()))
+}
+
+func TestToRollupOptionsNil(t *testing.T) {
+	rOpts := namespace.ToRollupOptions(nil)
+	require.True(t, namespace.NewRollupOptions().Equal(rOpts))
+	require.False(t, rOpts.Enabled())
+}
+
+func TestRetentionRulesRoundTrip(t *testing.T) {
+	rules := []namespace.RetentionRule{
+		{Filter: "env:staging", RetentionPeriod: 24 * time.Hour},
+		{Filter: "service:api*", RetentionPeriod: 30 * 24 * time.Hour},
+	}
+	md, err := namespace.NewMetadata(ident.StringID("ns1"),
+		namespace.NewOptions().SetRetentionRules(rules))
+	require.NoError(t, err)
+
+	nsOpts, err := namespace.OptionsToProto(md.Options())
+	require.NoError(t, err)
+	require.Equal(t, []*nsproto.RetentionRule{
+		{Filter: "env:staging", RetentionPeriodNanos: int64(24 * time.Hour)},
+		{Filter: "service:api*", RetentionPeriodNanos: int64(30 * 24 * time.Hour)},
+	}, nsOpts.RetentionRules)
+
+	observed, err := namespace.ToMetadata("ns1", nsOpts)
+	require.NoError(t, err)
+	require.Equal(t, rules, observed.Options().RetentionRules())
+	require.True(t, md.Equal(observed))
+}
@@ -187,6 +187,20 @@ func (mr *MockOptionsMockRecorder) IndexOptions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexOptions", reflect.TypeOf((*MockOptions)(nil).IndexOptions))
 }
 
+// LateWriteQuarantineEnabled mocks base method.
+func (m *MockOptions) LateWriteQuarantineEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LateWriteQuarantineEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// LateWriteQuarantineEnabled indicates an expected call of LateWriteQuarantineEnabled.
+func (mr *MockOptionsMockRecorder) LateWriteQuarantineEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LateWriteQuarantineEnabled", reflect.TypeOf((*MockOptions)(nil).LateWriteQuarantineEnabled))
+}
+
 // RepairEnabled mocks base method.
 func (m *MockOptions) RepairEnabled() bool {
 	m.ctrl.T.Helper()
@@ -215,6 +229,34 @@ func (mr *MockOptionsMockRecorder) RetentionOptions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetentionOptions", reflect.TypeOf((*MockOptions)(nil).RetentionOptions))
 }
 
+// RetentionRules mocks base method.
+func (m *MockOptions) RetentionRules() []RetentionRule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetentionRules")
+	ret0, _ := ret[0].([]RetentionRule)
+	return ret0
+}
+
+// RetentionRules indicates an expected call of RetentionRules.
+func (mr *MockOptionsMockRecorder) RetentionRules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetentionRules", reflect.TypeOf((*MockOptions)(nil).RetentionRules))
+}
+
+// RollupOptions mocks base method.
+func (m *MockOptions) RollupOptions() RollupOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollupOptions")
+	ret0, _ := ret[0].(RollupOptions)
+	return ret0
+}
+
+// RollupOptions indicates an expected call of RollupOptions.
+func (mr *MockOptionsMockRecorder) RollupOptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollupOptions", reflect.TypeOf((*MockOptions)(nil).RollupOptions))
+}
+
 // RuntimeOptions mocks base method.
 func (m *MockOptions) RuntimeOptions() RuntimeOptions {
 	m.ctrl.T.Helper()
@@ -355,6 +397,20 @@ func (mr *MockOptionsMockRecorder) SetIndexOptions(value interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIndexOptions", reflect.TypeOf((*MockOptions)(nil).SetIndexOptions), value)
 }
 
+// SetLateWriteQuarantineEnabled mocks base method.
+func (m *MockOptions) SetLateWriteQuarantineEnabled(value bool) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLateWriteQuarantineEnabled", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetLateWriteQuarantineEnabled indicates an expected call of SetLateWriteQuarantineEnabled.
+func (mr *MockOptionsMockRecorder) SetLateWriteQuarantineEnabled(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLateWriteQuarantineEnabled", reflect.TypeOf((*MockOptions)(nil).SetLateWriteQuarantineEnabled), value)
+}
+
 // SetRepairEnabled mocks base method.
 func (m *MockOptions) SetRepairEnabled(value bool) Options {
 	m.ctrl.T.Helper()
@@ -383,6 +439,34 @@ func (mr *MockOptionsMockRecorder) SetRetentionOptions(value interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRetentionOptions", reflect.TypeOf((*MockOptions)(nil).SetRetentionOptions), value)
 }
 
+// SetRetentionRules mocks base method.
+func (m *MockOptions) SetRetentionRules(value []RetentionRule) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRetentionRules", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetRetentionRules indicates an expected call of SetRetentionRules.
+func (mr *MockOptionsMockRecorder) SetRetentionRules(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRetentionRules", reflect.TypeOf((*MockOptions)(nil).SetRetentionRules), value)
+}
+
+// SetRollupOptions mocks base method.
+func (m *MockOptions) SetRollupOptions(value RollupOptions) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRollupOptions", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetRollupOptions indicates an expected call of SetRollupOptions.
+func (mr *MockOptionsMockRecorder) SetRollupOptions(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRollupOptions", reflect.TypeOf((*MockOptions)(nil).SetRollupOptions), value)
+}
+
 // SetRuntimeOptions mocks base method.
 func (m *MockOptions) SetRuntimeOptions(value RuntimeOptions) Options {
 	m.ctrl.T.Helper()
@@ -439,6 +523,20 @@ func (mr *MockOptionsMockRecorder) SetStagingState(value interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStagingState", reflect.TypeOf((*MockOptions)(nil).SetStagingState), value)
 }
 
+// SetTieringOptions mocks base method.
+func (m *MockOptions) SetTieringOptions(value TieringOptions) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTieringOptions", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetTieringOptions indicates an expected call of SetTieringOptions.
+func (mr *MockOptionsMockRecorder) SetTieringOptions(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTieringOptions", reflect.TypeOf((*MockOptions)(nil).SetTieringOptions), value)
+}
+
 // SetWritesToCommitLog mocks base method.
 func (m *MockOptions) SetWritesToCommitLog(value bool) Options {
 	m.ctrl.T.Helper()
@@ -495,6 +593,20 @@ func (mr *MockOptionsMockRecorder) Validate() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockOptions)(nil).Validate))
 }
 
+// TieringOptions mocks base method.
+func (m *MockOptions) TieringOptions() TieringOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TieringOptions")
+	ret0, _ := ret[0].(TieringOptions)
+	return ret0
+}
+
+// TieringOptions indicates an expected call of TieringOptions.
+func (mr *MockOptionsMockRecorder) TieringOptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TieringOptions", reflect.TypeOf((*MockOptions)(nil).TieringOptions))
+}
+
 // WritesToCommitLog mocks base method.
 func (m *MockOptions) WritesToCommitLog() bool {
 	m.ctrl.T.Helper()
@@ -602,6 +714,220 @@ func (mr *MockIndexOptionsMockRecorder) SetEnabled(value interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnabled", reflect.TypeOf((*MockIndexOptions)(nil).SetEnabled), value)
 }
 
+// MockTieringOptions is a mock of TieringOptions interface.
+type MockTieringOptions struct {
+	ctrl     *gomock.Controller
+	recorder *MockTieringOptionsMockRecorder
+}
+
+// MockTieringOptionsMockRecorder is the mock recorder for MockTieringOptions.
+type MockTieringOptionsMockRecorder struct {
+	mock *MockTieringOptions
+}
+
+// NewMockTieringOptions creates a new mock instance.
+func NewMockTieringOptions(ctrl *gomock.Controller) *MockTieringOptions {
+	mock := &MockTieringOptions{ctrl: ctrl}
+	mock.recorder = &MockTieringOptionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTieringOptions) EXPECT() *MockTieringOptionsMockRecorder {
+	return m.recorder
+}
+
+// Enabled mocks base method.
+func (m *MockTieringOptions) Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockTieringOptionsMockRecorder) Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockTieringOptions)(nil).Enabled))
+}
+
+// Equal mocks base method.
+func (m *MockTieringOptions) Equal(value TieringOptions) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Equal", value)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Equal indicates an expected call of Equal.
+func (mr *MockTieringOptionsMockRecorder) Equal(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Equal", reflect.TypeOf((*MockTieringOptions)(nil).Equal), value)
+}
+
+// SetEnabled mocks base method.
+func (m *MockTieringOptions) SetEnabled(value bool) TieringOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnabled", value)
+	ret0, _ := ret[0].(TieringOptions)
+	return ret0
+}
+
+// SetEnabled indicates an expected call of SetEnabled.
+func (mr *MockTieringOptionsMockRecorder) SetEnabled(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnabled", reflect.TypeOf((*MockTieringOptions)(nil).SetEnabled), value)
+}
+
+// SetTierAfter mocks base method.
+func (m *MockTieringOptions) SetTierAfter(value time.Duration) TieringOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTierAfter", value)
+	ret0, _ := ret[0].(TieringOptions)
+	return ret0
+}
+
+// SetTierAfter indicates an expected call of SetTierAfter.
+func (mr *MockTieringOptionsMockRecorder) SetTierAfter(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTierAfter", reflect.TypeOf((*MockTieringOptions)(nil).SetTierAfter), value)
+}
+
+// TierAfter mocks base method.
+func (m *MockTieringOptions) TierAfter() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TierAfter")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// TierAfter indicates an expected call of TierAfter.
+func (mr *MockTieringOptionsMockRecorder) TierAfter() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TierAfter", reflect.TypeOf((*MockTieringOptions)(nil).TierAfter))
+}
+
+// MockRollupOptions is a mock of RollupOptions interface.
+type MockRollupOptions struct {
+	ctrl     *gomock.Controller
+	recorder *MockRollupOptionsMockRecorder
+}
+
+// MockRollupOptionsMockRecorder is the mock recorder for MockRollupOptions.
+type MockRollupOptionsMockRecorder struct {
+	mock *MockRollupOptions
+}
+
+// NewMockRollupOptions creates a new mock instance.
+func NewMockRollupOptions(ctrl *gomock.Controller) *MockRollupOptions {
+	mock := &MockRollupOptions{ctrl: ctrl}
+	mock.recorder = &MockRollupOptionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRollupOptions) EXPECT() *MockRollupOptionsMockRecorder {
+	return m.recorder
+}
+
+// Enabled mocks base method.
+func (m *MockRollupOptions) Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockRollupOptionsMockRecorder) Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockRollupOptions)(nil).Enabled))
+}
+
+// Equal mocks base method.
+func (m *MockRollupOptions) Equal(value RollupOptions) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Equal", value)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Equal indicates an expected call of Equal.
+func (mr *MockRollupOptionsMockRecorder) Equal(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Equal", reflect.TypeOf((*MockRollupOptions)(nil).Equal), value)
+}
+
+// Resolution mocks base method.
+func (m *MockRollupOptions) Resolution() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolution")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// Resolution indicates an expected call of Resolution.
+func (mr *MockRollupOptionsMockRecorder) Resolution() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolution", reflect.TypeOf((*MockRollupOptions)(nil).Resolution))
+}
+
+// RollupAfter mocks base method.
+func (m *MockRollupOptions) RollupAfter() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollupAfter")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// RollupAfter indicates an expected call of RollupAfter.
+func (mr *MockRollupOptionsMockRecorder) RollupAfter() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollupAfter", reflect.TypeOf((*MockRollupOptions)(nil).RollupAfter))
+}
+
+// SetEnabled mocks base method.
+func (m *MockRollupOptions) SetEnabled(value bool) RollupOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnabled", value)
+	ret0, _ := ret[0].(RollupOptions)
+	return ret0
+}
+
+// SetEnabled indicates an expected call of SetEnabled.
+func (mr *MockRollupOptionsMockRecorder) SetEnabled(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnabled", reflect.TypeOf((*MockRollupOptions)(nil).SetEnabled), value)
+}
+
+// SetResolution mocks base method.
+func (m *MockRollupOptions) SetResolution(value time.Duration) RollupOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetResolution", value)
+	ret0, _ := ret[0].(RollupOptions)
+	return ret0
+}
+
+// SetResolution indicates an expected call of SetResolution.
+func (mr *MockRollupOptionsMockRecorder) SetResolution(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetResolution", reflect.TypeOf((*MockRollupOptions)(nil).SetResolution), value)
+}
+
+// SetRollupAfter mocks base method.
+func (m *MockRollupOptions) SetRollupAfter(value time.Duration) RollupOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRollupAfter", value)
+	ret0, _ := ret[0].(RollupOptions)
+	return ret0
+}
+
+// SetRollupAfter indicates an expected call of SetRollupAfter.
+func (mr *MockRollupOptionsMockRecorder) SetRollupAfter(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRollupAfter", reflect.TypeOf((*MockRollupOptions)(nil).SetRollupAfter), value)
+}
+
 // MockSchemaDescr is a mock of SchemaDescr interface.
 type MockSchemaDescr struct {
 	ctrl     *gomock.Controller
@@ -22,7 +22,9 @@ package namespace
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/m3db/m3/src/dbnode/namespace/tagsfilter"
 	"github.com/m3db/m3/src/dbnode/retention"
 )
 
@@ -48,6 +50,9 @@ const (
 	// Namespace with cold writes disabled by default.
 	defaultColdWritesEnabled = false
 
+	// Namespace rejects writes outside of the buffer window by default.
+	defaultLateWriteQuarantineEnabled = false
+
 	// Namespace does not cache retrieved blocks by default since this is only
 	// useful specifically for usage patterns tending towards heavy historical reads.
 	defaultCacheBlocksOnRetrieve = false
@@ -59,24 +64,37 @@ var (
 	errIndexBlockSizeMustBeAMultipleOfDataBlockSize = errors.New("index block size must be a multiple of data block size")
 	errNamespaceRuntimeOptionsNotSet                = errors.New("namespace runtime options is not set")
 	errAggregationOptionsNotSet                     = errors.New("aggregation options is not set")
+	errTieringOptionsNotSet                         = errors.New("tiering options is not set")
+	errTierAfterPositive                            = errors.New("tier after must be positive when tiering is enabled")
+	errTierAfterTooLarge                            = errors.New("tier after needs to be < namespace retention period")
+	errRollupOptionsNotSet                          = errors.New("rollup options is not set")
+	errRollupAfterPositive                          = errors.New("rollup after must be positive when rollups are enabled")
+	errRollupAfterTooLarge                          = errors.New("rollup after needs to be < namespace retention period")
+	errRollupResolutionPositive                     = errors.New("rollup resolution must be positive when rollups are enabled")
+	errRollupResolutionMustDivideBlockSize          = errors.New("rollup resolution must evenly divide the namespace block size")
+	errRollupSchemaNotSupported                     = errors.New("rollups are not supported for namespaces with a schema")
 )
 
 type options struct {
-	bootstrapEnabled      bool
-	flushEnabled          bool
-	snapshotEnabled       bool
-	writesToCommitLog     bool
-	cleanupEnabled        bool
-	repairEnabled         bool
-	coldWritesEnabled     bool
-	cacheBlocksOnRetrieve bool
-	retentionOpts         retention.Options
-	indexOpts             IndexOptions
-	schemaHis             SchemaHistory
-	runtimeOpts           RuntimeOptions
-	extendedOpts          ExtendedOptions
-	aggregationOpts       AggregationOptions
-	stagingState          StagingState
+	bootstrapEnabled           bool
+	flushEnabled               bool
+	snapshotEnabled            bool
+	writesToCommitLog          bool
+	cleanupEnabled             bool
+	repairEnabled              bool
+	coldWritesEnabled          bool
+	lateWriteQuarantineEnabled bool
+	cacheBlocksOnRetrieve      bool
+	retentionOpts              retention.Options
+	indexOpts                  IndexOptions
+	schemaHis                  SchemaHistory
+	runtimeOpts                RuntimeOptions
+	extendedOpts               ExtendedOptions
+	aggregationOpts            AggregationOptions
+	stagingState               StagingState
+	tieringOpts                TieringOptions
+	rollupOpts                 RollupOptions
+	retentionRules             []RetentionRule
 }
 
 // NewSchemaHistory returns an empty schema history.
@@ -87,19 +105,22 @@ func NewSchemaHistory() SchemaHistory {
 // NewOptions creates a new namespace options
 func NewOptions() Options {
 	return &options{
-		bootstrapEnabled:      defaultBootstrapEnabled,
-		flushEnabled:          defaultFlushEnabled,
-		snapshotEnabled:       defaultSnapshotEnabled,
-		writesToCommitLog:     defaultWritesToCommitLog,
-		cleanupEnabled:        defaultCleanupEnabled,
-		repairEnabled:         defaultRepairEnabled,
-		coldWritesEnabled:     defaultColdWritesEnabled,
-		cacheBlocksOnRetrieve: defaultCacheBlocksOnRetrieve,
-		retentionOpts:         retention.NewOptions(),
-		indexOpts:             NewIndexOptions(),
-		schemaHis:             NewSchemaHistory(),
-		runtimeOpts:           NewRuntimeOptions(),
-		aggregationOpts:       NewAggregationOptions(),
+		bootstrapEnabled:           defaultBootstrapEnabled,
+		flushEnabled:               defaultFlushEnabled,
+		snapshotEnabled:            defaultSnapshotEnabled,
+		writesToCommitLog:          defaultWritesToCommitLog,
+		cleanupEnabled:             defaultCleanupEnabled,
+		repairEnabled:              defaultRepairEnabled,
+		coldWritesEnabled:          defaultColdWritesEnabled,
+		lateWriteQuarantineEnabled: defaultLateWriteQuarantineEnabled,
+		cacheBlocksOnRetrieve:      defaultCacheBlocksOnRetrieve,
+		retentionOpts:              retention.NewOptions(),
+		indexOpts:                  NewIndexOptions(),
+		schemaHis:                  NewSchemaHistory(),
+		runtimeOpts:                NewRuntimeOptions(),
+		aggregationOpts:            NewAggregationOptions(),
+		tieringOpts:                NewTieringOptions(),
+		rollupOpts:                 NewRollupOptions(),
 	}
 }
 
@@ -118,6 +139,18 @@ func (o *options) Validate() error {
 		return err
 	}
 
+	if err := o.validateTieringOptions(); err != nil {
+		return err
+	}
+
+	if err := o.validateRollupOptions(); err != nil {
+		return err
+	}
+
+	if err := o.validateRetentionRules(); err != nil {
+		return err
+	}
+
 	if !o.indexOpts.Enabled() {
 		return nil
 	}
@@ -154,13 +187,73 @@ func (o *options) Equal(value Options) bool {
 		o.cleanupEnabled == value.CleanupEnabled() &&
 		o.repairEnabled == value.RepairEnabled() &&
 		o.coldWritesEnabled == value.ColdWritesEnabled() &&
+		o.lateWriteQuarantineEnabled == value.LateWriteQuarantineEnabled() &&
 		o.cacheBlocksOnRetrieve == value.CacheBlocksOnRetrieve() &&
 		o.retentionOpts.Equal(value.RetentionOptions()) &&
 		o.indexOpts.Equal(value.IndexOptions()) &&
 		o.schemaHis.Equal(value.SchemaHistory()) &&
 		o.runtimeOpts.Equal(value.RuntimeOptions()) &&
 		o.aggregationOpts.Equal(value.AggregationOptions()) &&
-		o.stagingState == value.StagingState()
+		o.stagingState == value.StagingState() &&
+		o.tieringOpts.Equal(value.TieringOptions()) &&
+		o.rollupOpts.Equal(value.RollupOptions()) &&
+		retentionRulesEqual(o.retentionRules, value.RetentionRules())
+}
+
+func (o *options) validateTieringOptions() error {
+	if o.tieringOpts == nil {
+		return errTieringOptionsNotSet
+	}
+	if !o.tieringOpts.Enabled() {
+		return nil
+	}
+	tierAfter := o.tieringOpts.TierAfter()
+	if tierAfter <= 0 {
+		return errTierAfterPositive
+	}
+	if tierAfter >= o.retentionOpts.RetentionPeriod() {
+		return errTierAfterTooLarge
+	}
+	return nil
+}
+
+func (o *options) validateRetentionRules() error {
+	for _, rule := range o.retentionRules {
+		if err := tagsfilter.Validate(rule.Filter); err != nil {
+			return fmt.Errorf("invalid retention rule filter %s: %w", rule.Filter, err)
+		}
+		if rule.RetentionPeriod < o.retentionOpts.BlockSize() {
+			return errRetentionRulePeriodTooSmall
+		}
+	}
+	return nil
+}
+
+func (o *options) validateRollupOptions() error {
+	if o.rollupOpts == nil {
+		return errRollupOptionsNotSet
+	}
+	if !o.rollupOpts.Enabled() {
+		return nil
+	}
+	rollupAfter := o.rollupOpts.RollupAfter()
+	if rollupAfter <= 0 {
+		return errRollupAfterPositive
+	}
+	if rollupAfter >= o.retentionOpts.RetentionPeriod() {
+		return errRollupAfterTooLarge
+	}
+	resolution := o.rollupOpts.Resolution()
+	if resolution <= 0 {
+		return errRollupResolutionPositive
+	}
+	if o.retentionOpts.BlockSize()%resolution != 0 {
+		return errRollupResolutionMustDivideBlockSize
+	}
+	if _, ok := o.schemaHis.GetLatest(); ok {
+		return errRollupSchemaNotSupported
+	}
+	return nil
 }
 
 func (o *options) SetBootstrapEnabled(value bool) Options {
@@ -233,6 +326,16 @@ func (o *options) ColdWritesEnabled() bool {
 	return o.coldWritesEnabled
 }
 
+func (o *options) SetLateWriteQuarantineEnabled(value bool) Options {
+	opts := *o
+	opts.lateWriteQuarantineEnabled = value
+	return &opts
+}
+
+func (o *options) LateWriteQuarantineEnabled() bool {
+	return o.lateWriteQuarantineEnabled
+}
+
 func (o *options) SetCacheBlocksOnRetrieve(value bool) Options {
 	opts := *o
 	opts.cacheBlocksOnRetrieve = value
@@ -312,3 +415,33 @@ func (o *options) SetStagingState(value StagingState) Options {
 func (o *options) StagingState() StagingState {
 	return o.stagingState
 }
+
+func (o *options) SetTieringOptions(value TieringOptions) Options {
+	opts := *o
+	opts.tieringOpts = value
+	return &opts
+}
+
+func (o *options) TieringOptions() TieringOptions {
+	return o.tieringOpts
+}
+
+func (o *options) SetRollupOptions(value RollupOptions) Options {
+	opts := *o
+	opts.rollupOpts = value
+	return &opts
+}
+
+func (o *options) RollupOptions() RollupOptions {
+	return o.rollupOpts
+}
+
+func (o *options) SetRetentionRules(value []RetentionRule) Options {
+	opts := *o
+	opts.retentionRules = value
+	return &opts
+}
+
+func (o *options) RetentionRules() []RetentionRule {
+	return o.retentionRules
+}
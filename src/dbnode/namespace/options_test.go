@@ -217,3 +217,108 @@ func TestOptionsValidateStagingStatus(t *testing.T) {
 	o1 = o1.SetStagingState(StagingState{status: StagingStatus(12)})
 	require.Error(t, o1.Validate())
 }
+
+func TestOptionsValidateTieringOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rOpts := retention.NewMockOptions(ctrl)
+	iOpts := NewMockIndexOptions(ctrl)
+	o1 := NewOptions().
+		SetRetentionOptions(rOpts).
+		SetIndexOptions(iOpts)
+
+	iOpts.EXPECT().Enabled().Return(true).AnyTimes()
+
+	rOpts.EXPECT().Validate().Return(nil).AnyTimes()
+	rOpts.EXPECT().RetentionPeriod().Return(48 * time.Hour).AnyTimes()
+	rOpts.EXPECT().FutureRetentionPeriod().Return(time.Duration(0)).AnyTimes()
+	rOpts.EXPECT().BlockSize().Return(time.Hour).AnyTimes()
+	iOpts.EXPECT().BlockSize().Return(time.Hour).AnyTimes()
+
+	tOpts := NewTieringOptions().SetEnabled(true).SetTierAfter(24 * time.Hour)
+	require.NoError(t, o1.SetTieringOptions(tOpts).Validate())
+
+	o2 := o1.SetTieringOptions(tOpts.SetTierAfter(0))
+	require.Equal(t, errTierAfterPositive, o2.Validate())
+
+	o2 = o1.SetTieringOptions(tOpts.SetTierAfter(48 * time.Hour))
+	require.Equal(t, errTierAfterTooLarge, o2.Validate())
+
+	o2 = o1.SetTieringOptions(nil)
+	require.Equal(t, errTieringOptionsNotSet, o2.Validate())
+
+	// Tier after is ignored while tiering is disabled.
+	o2 = o1.SetTieringOptions(NewTieringOptions().SetTierAfter(0))
+	require.NoError(t, o2.Validate())
+}
+
+func TestOptionsValidateRollupOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rOpts := retention.NewMockOptions(ctrl)
+	iOpts := NewMockIndexOptions(ctrl)
+	o1 := NewOptions().
+		SetRetentionOptions(rOpts).
+		SetIndexOptions(iOpts)
+
+	iOpts.EXPECT().Enabled().Return(true).AnyTimes()
+
+	rOpts.EXPECT().Validate().Return(nil).AnyTimes()
+	rOpts.EXPECT().RetentionPeriod().Return(48 * time.Hour).AnyTimes()
+	rOpts.EXPECT().FutureRetentionPeriod().Return(time.Duration(0)).AnyTimes()
+	rOpts.EXPECT().BlockSize().Return(time.Hour).AnyTimes()
+	iOpts.EXPECT().BlockSize().Return(time.Hour).AnyTimes()
+
+	ro := NewRollupOptions().SetEnabled(true).SetRollupAfter(24 * time.Hour)
+	require.Equal(t, time.Minute, ro.Resolution())
+	require.NoError(t, o1.SetRollupOptions(ro).Validate())
+
+	o2 := o1.SetRollupOptions(ro.SetRollupAfter(0))
+	require.Equal(t, errRollupAfterPositive, o2.Validate())
+
+	o2 = o1.SetRollupOptions(ro.SetRollupAfter(48 * time.Hour))
+	require.Equal(t, errRollupAfterTooLarge, o2.Validate())
+
+	o2 = o1.SetRollupOptions(ro.SetResolution(0))
+	require.Equal(t, errRollupResolutionPositive, o2.Validate())
+
+	o2 = o1.SetRollupOptions(ro.SetResolution(7 * time.Minute))
+	require.Equal(t, errRollupResolutionMustDivideBlockSize, o2.Validate())
+
+	o2 = o1.SetRollupOptions(nil)
+	require.Equal(t, errRollupOptionsNotSet, o2.Validate())
+
+	// Rollup after is ignored while rollups are disabled.
+	o2 = o1.SetRollupOptions(NewRollupOptions().SetRollupAfter(0))
+	require.NoError(t, o2.Validate())
+}
+
+func TestOptionsValidateRetentionRules(t *testing.T) {
+	o1 := NewOptions().
+		SetRetentionOptions(retention.NewOptions().
+			SetRetentionPeriod(48 * time.Hour).
+			SetBlockSize(2 * time.Hour)).
+		SetIndexOptions(NewIndexOptions().
+			SetEnabled(true).
+			SetBlockSize(4 * time.Hour))
+
+	rules := []RetentionRule{
+		{Filter: "env:staging", RetentionPeriod: 6 * time.Hour},
+		{Filter: "service:{api,web} tier:gold", RetentionPeriod: 30 * 24 * time.Hour},
+	}
+	o2 := o1.SetRetentionRules(rules)
+	require.NoError(t, o2.Validate())
+	require.Equal(t, rules, o2.RetentionRules())
+	require.False(t, o1.Equal(o2))
+	require.True(t, o2.Equal(o1.SetRetentionRules([]RetentionRule{rules[0], rules[1]})))
+
+	o2 = o1.SetRetentionRules([]RetentionRule{{Filter: "env:staging", RetentionPeriod: time.Hour}})
+	require.Equal(t, errRetentionRulePeriodTooSmall, o2.Validate())
+
+	for _, filter := range []string{"env", "env:staging env:prod", "service:{api", "service:[z-a]"} {
+		o2 = o1.SetRetentionRules([]RetentionRule{{Filter: filter, RetentionPeriod: 6 * time.Hour}})
+		require.Error(t, o2.Validate(), filter)
+	}
+}
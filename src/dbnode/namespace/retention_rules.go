@@ -0,0 +1,87 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/m3ninx/doc"
+)
+
+var errRetentionRulePeriodTooSmall = errors.New("retention rule period needs to be >= namespace block size")
+
+// RetentionRule overrides the retention period of the series of a namespace
+// whose tags match a filter.
+type RetentionRule struct {
+	// Filter is the tags filter in the metrics filters syntax,
+	// e.g. "env:staging service:{api,web}".
+	Filter string
+	// RetentionPeriod is the retention period of the matching series.
+	RetentionPeriod time.Duration
+}
+
+// RetentionRulesMatcher resolves the retention period of a series of a
+// namespace from its tags.
+type RetentionRulesMatcher interface {
+	// RetentionPeriod returns the retention period of the series with the
+	// given tags, which is the period of the first matching retention rule
+	// or the namespace retention period if no rule matches.
+	RetentionPeriod(fields []doc.Field) time.Duration
+
+	// MinRetentionPeriod returns the shortest retention period of any series
+	// of the namespace.
+	MinRetentionPeriod() time.Duration
+
+	// MaxRetentionPeriod returns the longest retention period of any series
+	// of the namespace.
+	MaxRetentionPeriod() time.Duration
+}
+
+// BlockRetentionOptions returns the retention options of a namespace with the
+// retention period extended to its longest retention rule, since that bounds
+// how long the blocks and filesets of the namespace need to be kept.
+func BlockRetentionOptions(opts Options) retention.Options {
+	ropts := opts.RetentionOptions()
+	retentionPeriod := ropts.RetentionPeriod()
+	for _, rule := range opts.RetentionRules() {
+		if rule.RetentionPeriod > retentionPeriod {
+			retentionPeriod = rule.RetentionPeriod
+		}
+	}
+	if retentionPeriod == ropts.RetentionPeriod() {
+		return ropts
+	}
+	return ropts.SetRetentionPeriod(retentionPeriod)
+}
+
+func retentionRulesEqual(a, b []RetentionRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
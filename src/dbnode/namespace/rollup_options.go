@@ -0,0 +1,83 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"time"
+)
+
+var (
+	// defaultRollupEnabled disables rollup compaction by default.
+	defaultRollupEnabled = false
+
+	// defaultRollupResolution is the default resolution of rollups.
+	defaultRollupResolution = time.Minute
+)
+
+type rollupOpts struct {
+	enabled     bool
+	rollupAfter time.Duration
+	resolution  time.Duration
+}
+
+// NewRollupOptions returns a new RollupOptions.
+func NewRollupOptions() RollupOptions {
+	return &rollupOpts{
+		enabled:    defaultRollupEnabled,
+		resolution: defaultRollupResolution,
+	}
+}
+
+func (r *rollupOpts) Equal(value RollupOptions) bool {
+	return r.Enabled() == value.Enabled() &&
+		r.RollupAfter() == value.RollupAfter() &&
+		r.Resolution() == value.Resolution()
+}
+
+func (r *rollupOpts) SetEnabled(value bool) RollupOptions {
+	ro := *r
+	ro.enabled = value
+	return &ro
+}
+
+func (r *rollupOpts) Enabled() bool {
+	return r.enabled
+}
+
+func (r *rollupOpts) SetRollupAfter(value time.Duration) RollupOptions {
+	ro := *r
+	ro.rollupAfter = value
+	return &ro
+}
+
+func (r *rollupOpts) RollupAfter() time.Duration {
+	return r.rollupAfter
+}
+
+func (r *rollupOpts) SetResolution(value time.Duration) RollupOptions {
+	ro := *r
+	ro.resolution = value
+	return &ro
+}
+
+func (r *rollupOpts) Resolution() time.Duration {
+	return r.resolution
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package tagsfilter validates tags filters in the metrics filters syntax
+// without depending on the metrics filters package, so that namespace
+// options can be validated without an import cycle through the query models.
+package tagsfilter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	tagFilterListSeparator = " "
+
+	wildcardChar         = '*'
+	negationChar         = '!'
+	singleAnyChar        = '?'
+	singleRangeStartChar = '['
+	singleRangeEndChar   = ']'
+	rangeChar            = '-'
+	multiRangeStartChar  = '{'
+	multiRangeEndChar    = '}'
+	invalidNestedChars   = "?[{"
+)
+
+var (
+	errInvalidFilterPattern = errors.New("invalid filter pattern defined")
+
+	// validFilterSeparators are tried in order, as in the metrics filters.
+	validFilterSeparators = []string{"#", ":"}
+)
+
+// Validate returns an error if the tags filter would be rejected by the
+// metrics filters, e.g. "env:staging service:{api,web}".
+func Validate(str string) error {
+	tagPairs := strings.Split(strings.TrimSpace(str), tagFilterListSeparator)
+	names := make(map[string]struct{}, len(tagPairs))
+	for _, p := range tagPairs {
+		sanitized := strings.TrimSpace(p)
+		if sanitized == "" {
+			continue
+		}
+		name, pattern, err := parseTagFilter(sanitized)
+		if err != nil {
+			return fmt.Errorf("tags filter %s is malformed: %w", str, err)
+		}
+		if _, exists := names[name]; exists {
+			return fmt.Errorf("tags filter %s is malformed: duplicate tag %s found", str, name)
+		}
+		names[name] = struct{}{}
+		if err := validatePattern([]byte(pattern)); err != nil {
+			return fmt.Errorf("tags filter %s contains invalid filter pattern %s for tag %s: %w",
+				str, pattern, name, err)
+		}
+	}
+	return nil
+}
+
+func parseTagFilter(str string) (string, string, error) {
+	var err error
+	for _, separator := range validFilterSeparators {
+		items := strings.Split(str, separator)
+		switch {
+		case len(items) != 2:
+			err = fmt.Errorf("invalid filter %s: expecting tag pattern pairs", str)
+		case items[0] == "":
+			err = fmt.Errorf("invalid filter %s: empty tag name", str)
+		case items[1] == "":
+			err = fmt.Errorf("invalid filter %s: empty filter pattern", str)
+		default:
+			return items[0], items[1], nil
+		}
+	}
+	return "", "", err
+}
+
+func validatePattern(pattern []byte) error {
+	if len(pattern) == 0 || pattern[0] != negationChar {
+		return validateWildcardPattern(pattern)
+	}
+	if len(pattern) == 1 {
+		// Only negation symbol.
+		return errInvalidFilterPattern
+	}
+	return validateWildcardPattern(pattern[1:])
+}
+
+func validateWildcardPattern(pattern []byte) error {
+	wIdx := bytes.IndexRune(pattern, wildcardChar)
+	switch {
+	case wIdx == -1:
+		return validateRangePattern(pattern)
+	case len(pattern) == 1:
+		return nil
+	case wIdx == len(pattern)-1:
+		return validateRangePattern(pattern[:wIdx])
+	}
+
+	secondWIdx := bytes.IndexRune(pattern[wIdx+1:], wildcardChar)
+	if secondWIdx == -1 {
+		if err := validateRangePattern(pattern[:wIdx]); err != nil {
+			return err
+		}
+		return validateRangePattern(pattern[wIdx+1:])
+	}
+
+	if wIdx == 0 && secondWIdx == len(pattern)-2 && len(pattern) > 2 {
+		// Wildcard at beginning and end.
+		if bytes.ContainsAny(pattern[1:len(pattern)-1], invalidNestedChars) {
+			return errInvalidFilterPattern
+		}
+		return nil
+	}
+
+	return errInvalidFilterPattern
+}
+
+func validateRangePattern(pattern []byte) error {
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case singleRangeStartChar:
+			endIdx := bytes.IndexRune(pattern[i+1:], singleRangeEndChar)
+			if endIdx == -1 {
+				return errInvalidFilterPattern
+			}
+			if err := validateSingleRangePattern(pattern[i+1 : i+1+endIdx]); err != nil {
+				return err
+			}
+			i += endIdx + 1
+		case multiRangeStartChar:
+			endIdx := bytes.IndexRune(pattern[i+1:], multiRangeEndChar)
+			if endIdx <= 0 {
+				return errInvalidFilterPattern
+			}
+			i += endIdx + 1
+		}
+	}
+	return nil
+}
+
+func validateSingleRangePattern(pattern []byte) error {
+	if len(pattern) == 0 {
+		return errInvalidFilterPattern
+	}
+	if pattern[0] == negationChar {
+		pattern = pattern[1:]
+	}
+	if len(pattern) <= 1 || pattern[1] != rangeChar {
+		return nil
+	}
+	if len(pattern)%3 != 0 {
+		return errInvalidFilterPattern
+	}
+	for i := 0; i < len(pattern); i += 3 {
+		if pattern[i+1] != rangeChar || pattern[i] > pattern[i+2] {
+			return errInvalidFilterPattern
+		}
+	}
+	return nil
+}
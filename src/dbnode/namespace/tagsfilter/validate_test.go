@@ -0,0 +1,62 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tagsfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	valid := []string{
+		"",
+		"env:staging",
+		" env:staging  service:api* ",
+		"service#{api,web} tier:!gold",
+		"host:*db* region:us-[a-cx-z]? zone:[!abc]",
+		"name:foo*bar",
+		"name:*",
+	}
+	for _, filter := range valid {
+		require.NoError(t, Validate(filter), filter)
+	}
+
+	invalid := []string{
+		"env",
+		"env:",
+		":staging",
+		"env:a:b",
+		"env:staging env:prod",
+		"tier:!",
+		"name:*a*b*",
+		"name:*a?b*",
+		"service:{api",
+		"service:a{}",
+		"region:us-[a-c",
+		"region:[]",
+		"region:[z-a]",
+		"region:[a-cd]",
+	}
+	for _, filter := range invalid {
+		require.Error(t, Validate(filter), filter)
+	}
+}
@@ -82,6 +82,16 @@ type Options interface {
 	// ColdWritesEnabled returns whether cold writes are enabled for this namespace.
 	ColdWritesEnabled() bool
 
+	// SetLateWriteQuarantineEnabled sets whether writes outside of the buffer
+	// window are quarantined for this namespace instead of being rejected
+	// while cold writes are disabled.
+	SetLateWriteQuarantineEnabled(value bool) Options
+
+	// LateWriteQuarantineEnabled returns whether writes outside of the buffer
+	// window are quarantined for this namespace instead of being rejected
+	// while cold writes are disabled.
+	LateWriteQuarantineEnabled() bool
+
 	// SetCacheBlocksOnRetrieve sets whether to cache blocks from this namespace when retrieved.
 	// If global CacheBlocksOnRetrieve option in config.BlockRetrievePolicy is set to false,
 	// then that will override any namespace-specific CacheBlocksOnRetrieve options set to true.
@@ -131,6 +141,26 @@ type Options interface {
 
 	// StagingState returns the state related to a namespace's availability for use.
 	StagingState() StagingState
+
+	// SetTieringOptions sets the object storage tiering options.
+	SetTieringOptions(value TieringOptions) Options
+
+	// TieringOptions returns the object storage tiering options.
+	TieringOptions() TieringOptions
+
+	// SetRollupOptions sets the rollup compaction options.
+	SetRollupOptions(value RollupOptions) Options
+
+	// RollupOptions returns the rollup compaction options.
+	RollupOptions() RollupOptions
+
+	// SetRetentionRules sets the tag-based retention rules, which override
+	// the retention period of the series matching them.
+	SetRetentionRules(value []RetentionRule) Options
+
+	// RetentionRules returns the tag-based retention rules, which override
+	// the retention period of the series matching them.
+	RetentionRules() []RetentionRule
 }
 
 // IndexOptions controls the indexing options for a namespace.
@@ -151,6 +181,56 @@ type IndexOptions interface {
 	BlockSize() time.Duration
 }
 
+// TieringOptions controls tiering of sealed filesets of a namespace
+// to object storage.
+type TieringOptions interface {
+	// Equal returns true if the provide value is equal to this one.
+	Equal(value TieringOptions) bool
+
+	// SetEnabled sets whether tiering is enabled.
+	SetEnabled(value bool) TieringOptions
+
+	// Enabled returns whether tiering is enabled.
+	Enabled() bool
+
+	// SetTierAfter sets how long after the end of a block its filesets are
+	// uploaded to object storage and evicted from local disk.
+	SetTierAfter(value time.Duration) TieringOptions
+
+	// TierAfter returns how long after the end of a block its filesets are
+	// uploaded to object storage and evicted from local disk.
+	TierAfter() time.Duration
+}
+
+// RollupOptions controls background compaction of old blocks of a namespace
+// into rollups at a coarser resolution. Blocks are compacted into the min,
+// max, sum and count of each resolution, read by the matching over time
+// functions, and a counter rollup read by rate, irate, increase and resets.
+type RollupOptions interface {
+	// Equal returns true if the provide value is equal to this one.
+	Equal(value RollupOptions) bool
+
+	// SetEnabled sets whether rollup compaction is enabled.
+	SetEnabled(value bool) RollupOptions
+
+	// Enabled returns whether rollup compaction is enabled.
+	Enabled() bool
+
+	// SetRollupAfter sets how long after the end of a block it is
+	// compacted into rollups.
+	SetRollupAfter(value time.Duration) RollupOptions
+
+	// RollupAfter returns how long after the end of a block it is
+	// compacted into rollups.
+	RollupAfter() time.Duration
+
+	// SetResolution sets the resolution of the rollups.
+	SetResolution(value time.Duration) RollupOptions
+
+	// Resolution returns the resolution of the rollups.
+	Resolution() time.Duration
+}
+
 // SchemaDescr describes the schema for a complex type value.
 type SchemaDescr interface {
 	// DeployId returns the deploy id of the schema.
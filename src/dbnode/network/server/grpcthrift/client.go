@@ -0,0 +1,83 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpcthrift
+
+import (
+	stdctx "context"
+
+	apachethrift "github.com/uber/tchannel-go/thirdparty/github.com/apache/thrift/lib/go/thrift"
+	"github.com/uber/tchannel-go/thrift"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type client struct {
+	conn grpc.ClientConnInterface
+}
+
+// NewTChanClient returns a thrift client that issues calls over a gRPC
+// connection to a server registered with RegisterServer, it can be used
+// with the generated thrift clients.
+func NewTChanClient(conn grpc.ClientConnInterface) thrift.TChanClient {
+	return &client{conn: conn}
+}
+
+func (c *client) Call(
+	ctx thrift.Context,
+	serviceName string,
+	methodName string,
+	req apachethrift.TStruct,
+	resp apachethrift.TStruct,
+) (bool, error) {
+	payload, err := marshalStruct(req)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		result []byte
+		header metadata.MD
+	)
+	err = c.conn.Invoke(NewClientContext(ctx), "/"+serviceName+"/"+methodName, payload, &result,
+		grpc.CallContentSubtype(codecName), grpc.Header(&header))
+	if err != nil {
+		return false, err
+	}
+
+	if err := resp.Read(newReadProtocol(result)); err != nil {
+		return false, err
+	}
+
+	success := true
+	if values := header.Get(successHeader); len(values) > 0 && values[0] == "false" {
+		success = false
+	}
+	return success, nil
+}
+
+// NewClientContext returns the context used to issue a gRPC call for a
+// thrift context, the thrift headers are sent as request metadata.
+func NewClientContext(ctx thrift.Context) stdctx.Context {
+	if headers := ctx.Headers(); len(headers) > 0 {
+		return metadata.NewOutgoingContext(ctx, metadata.New(headers))
+	}
+	return ctx
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpcthrift
+
+import (
+	"bytes"
+	"fmt"
+
+	apachethrift "github.com/uber/tchannel-go/thirdparty/github.com/apache/thrift/lib/go/thrift"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// DefaultMaxMessageSize is the default max size of a message sent or
+	// received over the gRPC transport.
+	DefaultMaxMessageSize = 1 << 30
+
+	codecName     = "thrift"
+	successHeader = "m3-thrift-success"
+)
+
+func init() {
+	// Registered for the thrift content-subtype so that servers select it
+	// per request and serve protobuf services alongside thrift services.
+	encoding.RegisterCodec(codec{})
+}
+
+type codec struct{}
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("grpcthrift: cannot marshal type %T", v)
+	}
+	return b, nil
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("grpcthrift: cannot unmarshal into type %T", v)
+	}
+	// The gRPC receive buffer may be reused, take a copy.
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+func (codec) Name() string {
+	return codecName
+}
+
+func marshalStruct(v apachethrift.TStruct) ([]byte, error) {
+	transport := apachethrift.NewTMemoryBuffer()
+	if err := v.Write(apachethrift.NewTBinaryProtocolTransport(transport)); err != nil {
+		return nil, err
+	}
+	return transport.Bytes(), nil
+}
+
+func newReadProtocol(data []byte) apachethrift.TProtocol {
+	transport := &apachethrift.TMemoryBuffer{Buffer: bytes.NewBuffer(data)}
+	return apachethrift.NewTBinaryProtocolTransport(transport)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package grpcthrift provides a gRPC transport for thrift services so that
+// the generated thrift clients and servers can be used unchanged.
+//
+// The data path of the node service, batch writes, FetchTagged, AggregateRaw
+// and FetchBlocksRaw, is defined in protobuf by the Node service in
+// src/dbnode/generated/proto/nodepb and served by the node package. Clients
+// in other languages should use it. The other methods of the node service
+// are only available with the thrift codec, which is selected with the
+// "thrift" content-subtype, i.e. the application/grpc+thrift content-type:
+//
+//   - Each thrift method is a unary gRPC method named
+//     /<thrift service>/<thrift method>, e.g. /Node/health.
+//   - Messages are not protobuf encoded. The request is the thrift method's
+//     args struct, e.g. NodeHealthArgs, and the response is its result
+//     struct, e.g. NodeHealthResult, each serialized with the thrift binary
+//     protocol. The thrift IDL in src/dbnode/generated/thrift/rpc.thrift is
+//     the schema of these messages.
+//   - Thrift headers are sent as gRPC request metadata.
+//   - Thrift exceptions declared by the method, such as rpc.Error, are set
+//     on the result struct and the response carries the m3-thrift-success
+//     header set to false. Other failures are returned as gRPC status errors.
+//
+// The thrift codec is not understood by generic gRPC tooling such as
+// reflection, grpcurl or gateways, and messages are limited to
+// DefaultMaxMessageSize like those of the protobuf service.
+package grpcthrift
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package node
+
+import (
+	"github.com/m3db/m3/src/dbnode/generated/proto/nodepb"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/network/server/grpcthrift"
+
+	"github.com/uber/tchannel-go/thrift"
+	"google.golang.org/grpc"
+)
+
+type client struct {
+	// TChanNode issues the methods without a protobuf definition over the
+	// thrift content-subtype.
+	rpc.TChanNode
+
+	node nodepb.NodeClient
+}
+
+// NewClient returns a node client for a gRPC connection to a node serving
+// the node service with NewServer. Writes, FetchTagged, AggregateRaw and
+// FetchBlocksRaw are issued with the protobuf node service and the other
+// methods with the thrift codec.
+func NewClient(conn *grpc.ClientConn) rpc.TChanNode {
+	return &client{
+		TChanNode: rpc.NewTChanNodeClient(grpcthrift.NewTChanClient(conn)),
+		node:      nodepb.NewNodeClient(conn),
+	}
+}
+
+func (c *client) WriteBatchRaw(ctx thrift.Context, req *rpc.WriteBatchRawRequest) error {
+	resp, err := c.node.WriteBatchRaw(grpcthrift.NewClientContext(ctx),
+		writeBatchRawRequestToProto(req))
+	if err != nil {
+		return err
+	}
+	return writeBatchRawResponseFromProto(resp)
+}
+
+func (c *client) WriteBatchRawV2(ctx thrift.Context, req *rpc.WriteBatchRawV2Request) error {
+	resp, err := c.node.WriteBatchRawV2(grpcthrift.NewClientContext(ctx),
+		writeBatchRawV2RequestToProto(req))
+	if err != nil {
+		return err
+	}
+	return writeBatchRawResponseFromProto(resp)
+}
+
+func (c *client) WriteTaggedBatchRaw(ctx thrift.Context, req *rpc.WriteTaggedBatchRawRequest) error {
+	resp, err := c.node.WriteTaggedBatchRaw(grpcthrift.NewClientContext(ctx),
+		writeTaggedBatchRawRequestToProto(req))
+	if err != nil {
+		return err
+	}
+	return writeBatchRawResponseFromProto(resp)
+}
+
+func (c *client) WriteTaggedBatchRawV2(ctx thrift.Context, req *rpc.WriteTaggedBatchRawV2Request) error {
+	resp, err := c.node.WriteTaggedBatchRawV2(grpcthrift.NewClientContext(ctx),
+		writeTaggedBatchRawV2RequestToProto(req))
+	if err != nil {
+		return err
+	}
+	return writeBatchRawResponseFromProto(resp)
+}
+
+func (c *client) FetchTagged(
+	ctx thrift.Context,
+	req *rpc.FetchTaggedRequest,
+) (*rpc.FetchTaggedResult_, error) {
+	resp, err := c.node.FetchTagged(grpcthrift.NewClientContext(ctx),
+		fetchTaggedRequestToProto(req))
+	if err != nil {
+		return nil, err
+	}
+	if resp.Err != nil {
+		return nil, errorFromProto(resp.Err)
+	}
+	return fetchTaggedResultFromProto(resp), nil
+}
+
+func (c *client) AggregateRaw(
+	ctx thrift.Context,
+	req *rpc.AggregateQueryRawRequest,
+) (*rpc.AggregateQueryRawResult_, error) {
+	resp, err := c.node.AggregateRaw(grpcthrift.NewClientContext(ctx),
+		aggregateRawRequestToProto(req))
+	if err != nil {
+		return nil, err
+	}
+	if resp.Err != nil {
+		return nil, errorFromProto(resp.Err)
+	}
+	return aggregateRawResultFromProto(resp), nil
+}
+
+func (c *client) FetchBlocksRaw(
+	ctx thrift.Context,
+	req *rpc.FetchBlocksRawRequest,
+) (*rpc.FetchBlocksRawResult_, error) {
+	resp, err := c.node.FetchBlocksRaw(grpcthrift.NewClientContext(ctx),
+		fetchBlocksRawRequestToProto(req))
+	if err != nil {
+		return nil, err
+	}
+	if resp.Err != nil {
+		return nil, errorFromProto(resp.Err)
+	}
+	return fetchBlocksRawResultFromProto(resp), nil
+}
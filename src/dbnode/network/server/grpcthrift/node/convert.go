@@ -0,0 +1,627 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package node
+
+import (
+	"github.com/m3db/m3/src/dbnode/generated/proto/nodepb"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Unset optional thrift fields are sent as the zero value of their protobuf
+// field and zero values are converted back to unset fields, the node treats
+// both the same.
+
+func errorToProto(err *rpc.Error) *nodepb.Error {
+	if err == nil {
+		return nil
+	}
+	return &nodepb.Error{
+		Type:    nodepb.ErrorType(err.Type),
+		Message: err.Message,
+		Flags:   err.Flags,
+	}
+}
+
+func errorFromProto(err *nodepb.Error) *rpc.Error {
+	if err == nil {
+		return nil
+	}
+	return &rpc.Error{
+		Type:    rpc.ErrorType(err.Type),
+		Message: err.Message,
+		Flags:   err.Flags,
+	}
+}
+
+// responseError returns the error set on a response for an error returned
+// by the node service, errors other than rpc.Error are returned as gRPC
+// status errors.
+func responseError(err error) (*nodepb.Error, error) {
+	if rpcErr, ok := err.(*rpc.Error); ok {
+		return errorToProto(rpcErr), nil
+	}
+	return nil, status.Error(codes.Unknown, err.Error())
+}
+
+func optionalInt64(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+func optionalInt32(v int32) *int32 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+func optionalBool(v bool) *bool {
+	if !v {
+		return nil
+	}
+	return &v
+}
+
+func int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func int32Value(v *int32) int32 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func boolValue(v *bool) bool {
+	return v != nil && *v
+}
+
+// copyBytes copies bytes of results that are owned by the M3DB context of
+// the request, it is closed before gRPC serializes the response.
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(b)), b...)
+}
+
+func datapointToProto(dp *rpc.Datapoint) *nodepb.Datapoint {
+	if dp == nil {
+		return nil
+	}
+	return &nodepb.Datapoint{
+		Timestamp:         dp.Timestamp,
+		Value:             dp.Value,
+		Annotation:        dp.Annotation,
+		TimestampTimeType: nodepb.TimeType(dp.TimestampTimeType),
+	}
+}
+
+func datapointFromProto(dp *nodepb.Datapoint) *rpc.Datapoint {
+	if dp == nil {
+		// Required by the node service, an empty datapoint fails validation.
+		return &rpc.Datapoint{}
+	}
+	return &rpc.Datapoint{
+		Timestamp:         dp.Timestamp,
+		Value:             dp.Value,
+		Annotation:        dp.Annotation,
+		TimestampTimeType: rpc.TimeType(dp.TimestampTimeType),
+	}
+}
+
+func writeBatchRawRequestToProto(req *rpc.WriteBatchRawRequest) *nodepb.WriteBatchRawRequest {
+	elements := make([]*nodepb.WriteBatchRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &nodepb.WriteBatchRawRequestElement{
+			Id:        elem.ID,
+			Datapoint: datapointToProto(elem.Datapoint),
+		})
+	}
+	return &nodepb.WriteBatchRawRequest{
+		NameSpace: req.NameSpace,
+		Elements:  elements,
+	}
+}
+
+func writeBatchRawRequestFromProto(req *nodepb.WriteBatchRawRequest) *rpc.WriteBatchRawRequest {
+	elements := make([]*rpc.WriteBatchRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &rpc.WriteBatchRawRequestElement{
+			ID:        elem.Id,
+			Datapoint: datapointFromProto(elem.Datapoint),
+		})
+	}
+	return &rpc.WriteBatchRawRequest{
+		NameSpace: req.NameSpace,
+		Elements:  elements,
+	}
+}
+
+func writeBatchRawV2RequestToProto(req *rpc.WriteBatchRawV2Request) *nodepb.WriteBatchRawV2Request {
+	elements := make([]*nodepb.WriteBatchRawV2RequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &nodepb.WriteBatchRawV2RequestElement{
+			Id:        elem.ID,
+			Datapoint: datapointToProto(elem.Datapoint),
+			NameSpace: elem.NameSpace,
+		})
+	}
+	return &nodepb.WriteBatchRawV2Request{
+		NameSpaces: req.NameSpaces,
+		Elements:   elements,
+	}
+}
+
+func writeBatchRawV2RequestFromProto(req *nodepb.WriteBatchRawV2Request) *rpc.WriteBatchRawV2Request {
+	elements := make([]*rpc.WriteBatchRawV2RequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &rpc.WriteBatchRawV2RequestElement{
+			ID:        elem.Id,
+			Datapoint: datapointFromProto(elem.Datapoint),
+			NameSpace: elem.NameSpace,
+		})
+	}
+	return &rpc.WriteBatchRawV2Request{
+		NameSpaces: req.NameSpaces,
+		Elements:   elements,
+	}
+}
+
+func writeTaggedBatchRawRequestToProto(
+	req *rpc.WriteTaggedBatchRawRequest,
+) *nodepb.WriteTaggedBatchRawRequest {
+	elements := make([]*nodepb.WriteTaggedBatchRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &nodepb.WriteTaggedBatchRawRequestElement{
+			Id:          elem.ID,
+			EncodedTags: elem.EncodedTags,
+			Datapoint:   datapointToProto(elem.Datapoint),
+		})
+	}
+	return &nodepb.WriteTaggedBatchRawRequest{
+		NameSpace: req.NameSpace,
+		Elements:  elements,
+	}
+}
+
+func writeTaggedBatchRawRequestFromProto(
+	req *nodepb.WriteTaggedBatchRawRequest,
+) *rpc.WriteTaggedBatchRawRequest {
+	elements := make([]*rpc.WriteTaggedBatchRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &rpc.WriteTaggedBatchRawRequestElement{
+			ID:          elem.Id,
+			EncodedTags: elem.EncodedTags,
+			Datapoint:   datapointFromProto(elem.Datapoint),
+		})
+	}
+	return &rpc.WriteTaggedBatchRawRequest{
+		NameSpace: req.NameSpace,
+		Elements:  elements,
+	}
+}
+
+func writeTaggedBatchRawV2RequestToProto(
+	req *rpc.WriteTaggedBatchRawV2Request,
+) *nodepb.WriteTaggedBatchRawV2Request {
+	elements := make([]*nodepb.WriteTaggedBatchRawV2RequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &nodepb.WriteTaggedBatchRawV2RequestElement{
+			Id:          elem.ID,
+			EncodedTags: elem.EncodedTags,
+			Datapoint:   datapointToProto(elem.Datapoint),
+			NameSpace:   elem.NameSpace,
+		})
+	}
+	return &nodepb.WriteTaggedBatchRawV2Request{
+		NameSpaces: req.NameSpaces,
+		Elements:   elements,
+	}
+}
+
+func writeTaggedBatchRawV2RequestFromProto(
+	req *nodepb.WriteTaggedBatchRawV2Request,
+) *rpc.WriteTaggedBatchRawV2Request {
+	elements := make([]*rpc.WriteTaggedBatchRawV2RequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &rpc.WriteTaggedBatchRawV2RequestElement{
+			ID:          elem.Id,
+			EncodedTags: elem.EncodedTags,
+			Datapoint:   datapointFromProto(elem.Datapoint),
+			NameSpace:   elem.NameSpace,
+		})
+	}
+	return &rpc.WriteTaggedBatchRawV2Request{
+		NameSpaces: req.NameSpaces,
+		Elements:   elements,
+	}
+}
+
+func writeBatchRawResponseToProto(err error) (*nodepb.WriteBatchRawResponse, error) {
+	if err == nil {
+		return &nodepb.WriteBatchRawResponse{}, nil
+	}
+	batchErrs, ok := err.(*rpc.WriteBatchRawErrors)
+	if !ok {
+		respErr, err := responseError(err)
+		if err != nil {
+			return nil, err
+		}
+		return &nodepb.WriteBatchRawResponse{Err: respErr}, nil
+	}
+	errs := make([]*nodepb.WriteBatchRawError, 0, len(batchErrs.Errors))
+	for _, batchErr := range batchErrs.Errors {
+		errs = append(errs, &nodepb.WriteBatchRawError{
+			Index: batchErr.Index,
+			Err:   errorToProto(batchErr.Err),
+		})
+	}
+	return &nodepb.WriteBatchRawResponse{Errors: errs}, nil
+}
+
+func writeBatchRawResponseFromProto(resp *nodepb.WriteBatchRawResponse) error {
+	if resp.Err != nil {
+		return errorFromProto(resp.Err)
+	}
+	if len(resp.Errors) == 0 {
+		return nil
+	}
+	errs := make([]*rpc.WriteBatchRawError, 0, len(resp.Errors))
+	for _, batchErr := range resp.Errors {
+		errs = append(errs, &rpc.WriteBatchRawError{
+			Index: batchErr.Index,
+			Err:   errorFromProto(batchErr.Err),
+		})
+	}
+	return &rpc.WriteBatchRawErrors{Errors: errs}
+}
+
+func fetchTaggedRequestToProto(req *rpc.FetchTaggedRequest) *nodepb.FetchTaggedRequest {
+	return &nodepb.FetchTaggedRequest{
+		NameSpace:         req.NameSpace,
+		Query:             req.Query,
+		RangeStart:        req.RangeStart,
+		RangeEnd:          req.RangeEnd,
+		FetchData:         req.FetchData,
+		SeriesLimit:       int64Value(req.SeriesLimit),
+		RangeTimeType:     nodepb.TimeType(req.RangeTimeType),
+		RequireExhaustive: req.RequireExhaustive,
+		DocsLimit:         int64Value(req.DocsLimit),
+		Source:            req.Source,
+		RequireNoWait:     req.RequireNoWait,
+		ResolutionNanos:   int64Value(req.ResolutionNanos),
+		Explain:           boolValue(req.Explain),
+		PageSize:          int64Value(req.PageSize),
+		PageToken:         req.PageToken,
+		RollupAggregation: int32Value(req.RollupAggregation),
+	}
+}
+
+func fetchTaggedRequestFromProto(req *nodepb.FetchTaggedRequest) *rpc.FetchTaggedRequest {
+	return &rpc.FetchTaggedRequest{
+		NameSpace:         req.NameSpace,
+		Query:             req.Query,
+		RangeStart:        req.RangeStart,
+		RangeEnd:          req.RangeEnd,
+		FetchData:         req.FetchData,
+		SeriesLimit:       optionalInt64(req.SeriesLimit),
+		RangeTimeType:     rpc.TimeType(req.RangeTimeType),
+		RequireExhaustive: req.RequireExhaustive,
+		DocsLimit:         optionalInt64(req.DocsLimit),
+		Source:            req.Source,
+		RequireNoWait:     req.RequireNoWait,
+		ResolutionNanos:   optionalInt64(req.ResolutionNanos),
+		Explain:           optionalBool(req.Explain),
+		PageSize:          optionalInt64(req.PageSize),
+		PageToken:         req.PageToken,
+		RollupAggregation: optionalInt32(req.RollupAggregation),
+	}
+}
+
+func fetchTaggedResultToProto(result *rpc.FetchTaggedResult_) *nodepb.FetchTaggedResponse {
+	elements := make([]*nodepb.FetchTaggedIDResult, 0, len(result.Elements))
+	for _, elem := range result.Elements {
+		segments := make([]*nodepb.Segments, 0, len(elem.Segments))
+		for _, segs := range elem.Segments {
+			segments = append(segments, segmentsToProto(segs))
+		}
+		elements = append(elements, &nodepb.FetchTaggedIDResult{
+			Id:          copyBytes(elem.ID),
+			NameSpace:   copyBytes(elem.NameSpace),
+			EncodedTags: copyBytes(elem.EncodedTags),
+			Segments:    segments,
+		})
+	}
+	resp := &nodepb.FetchTaggedResponse{
+		Elements:         elements,
+		Exhaustive:       result.Exhaustive,
+		WaitedIndex:      int64Value(result.WaitedIndex),
+		WaitedSeriesRead: int64Value(result.WaitedSeriesRead),
+		Explain:          result.Explain,
+		NextPageToken:    result.NextPageToken,
+	}
+	if stats := result.Stats; stats != nil {
+		resp.Stats = &nodepb.FetchTaggedStats{
+			DocsRead:   stats.DocsRead,
+			BytesRead:  stats.BytesRead,
+			BlocksRead: stats.BlocksRead,
+		}
+	}
+	return resp
+}
+
+func fetchTaggedResultFromProto(resp *nodepb.FetchTaggedResponse) *rpc.FetchTaggedResult_ {
+	elements := make([]*rpc.FetchTaggedIDResult_, 0, len(resp.Elements))
+	for _, elem := range resp.Elements {
+		var segments []*rpc.Segments
+		if len(elem.Segments) > 0 {
+			segments = make([]*rpc.Segments, 0, len(elem.Segments))
+			for _, segs := range elem.Segments {
+				segments = append(segments, segmentsFromProto(segs))
+			}
+		}
+		elements = append(elements, &rpc.FetchTaggedIDResult_{
+			ID:          elem.Id,
+			NameSpace:   elem.NameSpace,
+			EncodedTags: elem.EncodedTags,
+			Segments:    segments,
+		})
+	}
+	result := &rpc.FetchTaggedResult_{
+		Elements:         elements,
+		Exhaustive:       resp.Exhaustive,
+		WaitedIndex:      optionalInt64(resp.WaitedIndex),
+		WaitedSeriesRead: optionalInt64(resp.WaitedSeriesRead),
+		Explain:          resp.Explain,
+		NextPageToken:    resp.NextPageToken,
+	}
+	if stats := resp.Stats; stats != nil {
+		result.Stats = &rpc.FetchTaggedStats{
+			DocsRead:   stats.DocsRead,
+			BytesRead:  stats.BytesRead,
+			BlocksRead: stats.BlocksRead,
+		}
+	}
+	return result
+}
+
+func segmentsToProto(segs *rpc.Segments) *nodepb.Segments {
+	if segs == nil {
+		return nil
+	}
+	result := &nodepb.Segments{Merged: segmentToProto(segs.Merged)}
+	if len(segs.Unmerged) > 0 {
+		result.Unmerged = make([]*nodepb.Segment, 0, len(segs.Unmerged))
+		for _, seg := range segs.Unmerged {
+			result.Unmerged = append(result.Unmerged, segmentToProto(seg))
+		}
+	}
+	return result
+}
+
+func segmentsFromProto(segs *nodepb.Segments) *rpc.Segments {
+	if segs == nil {
+		return nil
+	}
+	result := &rpc.Segments{Merged: segmentFromProto(segs.Merged)}
+	if len(segs.Unmerged) > 0 {
+		result.Unmerged = make([]*rpc.Segment, 0, len(segs.Unmerged))
+		for _, seg := range segs.Unmerged {
+			result.Unmerged = append(result.Unmerged, segmentFromProto(seg))
+		}
+	}
+	return result
+}
+
+func segmentToProto(seg *rpc.Segment) *nodepb.Segment {
+	if seg == nil {
+		return nil
+	}
+	return &nodepb.Segment{
+		Head:      copyBytes(seg.Head),
+		Tail:      copyBytes(seg.Tail),
+		StartTime: int64Value(seg.StartTime),
+		BlockSize: int64Value(seg.BlockSize),
+		Checksum:  int64Value(seg.Checksum),
+	}
+}
+
+func segmentFromProto(seg *nodepb.Segment) *rpc.Segment {
+	if seg == nil {
+		return nil
+	}
+	return &rpc.Segment{
+		Head:      seg.Head,
+		Tail:      seg.Tail,
+		StartTime: optionalInt64(seg.StartTime),
+		BlockSize: optionalInt64(seg.BlockSize),
+		Checksum:  optionalInt64(seg.Checksum),
+	}
+}
+
+func aggregateRawRequestToProto(
+	req *rpc.AggregateQueryRawRequest,
+) *nodepb.AggregateQueryRawRequest {
+	return &nodepb.AggregateQueryRawRequest{
+		Query:              req.Query,
+		RangeStart:         req.RangeStart,
+		RangeEnd:           req.RangeEnd,
+		NameSpace:          req.NameSpace,
+		SeriesLimit:        int64Value(req.SeriesLimit),
+		TagNameFilter:      req.TagNameFilter,
+		AggregateQueryType: nodepb.AggregateQueryType(req.AggregateQueryType),
+		RangeType:          nodepb.TimeType(req.RangeType),
+		Source:             req.Source,
+		DocsLimit:          int64Value(req.DocsLimit),
+		RequireExhaustive:  boolValue(req.RequireExhaustive),
+		RequireNoWait:      boolValue(req.RequireNoWait),
+	}
+}
+
+func aggregateRawRequestFromProto(
+	req *nodepb.AggregateQueryRawRequest,
+) *rpc.AggregateQueryRawRequest {
+	return &rpc.AggregateQueryRawRequest{
+		Query:              req.Query,
+		RangeStart:         req.RangeStart,
+		RangeEnd:           req.RangeEnd,
+		NameSpace:          req.NameSpace,
+		SeriesLimit:        optionalInt64(req.SeriesLimit),
+		TagNameFilter:      req.TagNameFilter,
+		AggregateQueryType: rpc.AggregateQueryType(req.AggregateQueryType),
+		RangeType:          rpc.TimeType(req.RangeType),
+		Source:             req.Source,
+		DocsLimit:          optionalInt64(req.DocsLimit),
+		RequireExhaustive:  optionalBool(req.RequireExhaustive),
+		RequireNoWait:      optionalBool(req.RequireNoWait),
+	}
+}
+
+func aggregateRawResultToProto(
+	result *rpc.AggregateQueryRawResult_,
+) *nodepb.AggregateQueryRawResponse {
+	results := make([]*nodepb.AggregateQueryRawResultTagNameElement, 0, len(result.Results))
+	for _, elem := range result.Results {
+		values := make([]*nodepb.AggregateQueryRawResultTagValueElement, 0, len(elem.TagValues))
+		for _, value := range elem.TagValues {
+			values = append(values, &nodepb.AggregateQueryRawResultTagValueElement{
+				TagValue: copyBytes(value.TagValue),
+			})
+		}
+		results = append(results, &nodepb.AggregateQueryRawResultTagNameElement{
+			TagName:   copyBytes(elem.TagName),
+			TagValues: values,
+		})
+	}
+	return &nodepb.AggregateQueryRawResponse{
+		Results:     results,
+		Exhaustive:  result.Exhaustive,
+		WaitedIndex: int64Value(result.WaitedIndex),
+	}
+}
+
+func aggregateRawResultFromProto(
+	resp *nodepb.AggregateQueryRawResponse,
+) *rpc.AggregateQueryRawResult_ {
+	results := make([]*rpc.AggregateQueryRawResultTagNameElement, 0, len(resp.Results))
+	for _, elem := range resp.Results {
+		var values []*rpc.AggregateQueryRawResultTagValueElement
+		if len(elem.TagValues) > 0 {
+			values = make([]*rpc.AggregateQueryRawResultTagValueElement, 0, len(elem.TagValues))
+			for _, value := range elem.TagValues {
+				values = append(values, &rpc.AggregateQueryRawResultTagValueElement{
+					TagValue: value.TagValue,
+				})
+			}
+		}
+		results = append(results, &rpc.AggregateQueryRawResultTagNameElement{
+			TagName:   elem.TagName,
+			TagValues: values,
+		})
+	}
+	return &rpc.AggregateQueryRawResult_{
+		Results:     results,
+		Exhaustive:  resp.Exhaustive,
+		WaitedIndex: optionalInt64(resp.WaitedIndex),
+	}
+}
+
+func fetchBlocksRawRequestToProto(req *rpc.FetchBlocksRawRequest) *nodepb.FetchBlocksRawRequest {
+	elements := make([]*nodepb.FetchBlocksRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &nodepb.FetchBlocksRawRequestElement{
+			Id:     elem.ID,
+			Starts: elem.Starts,
+		})
+	}
+	return &nodepb.FetchBlocksRawRequest{
+		NameSpace: req.NameSpace,
+		Shard:     req.Shard,
+		Elements:  elements,
+		Source:    req.Source,
+	}
+}
+
+func fetchBlocksRawRequestFromProto(req *nodepb.FetchBlocksRawRequest) *rpc.FetchBlocksRawRequest {
+	elements := make([]*rpc.FetchBlocksRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &rpc.FetchBlocksRawRequestElement{
+			ID:     elem.Id,
+			Starts: elem.Starts,
+		})
+	}
+	return &rpc.FetchBlocksRawRequest{
+		NameSpace: req.NameSpace,
+		Shard:     req.Shard,
+		Elements:  elements,
+		Source:    req.Source,
+	}
+}
+
+func fetchBlocksRawResultToProto(result *rpc.FetchBlocksRawResult_) *nodepb.FetchBlocksRawResponse {
+	elements := make([]*nodepb.Blocks, 0, len(result.Elements))
+	for _, elem := range result.Elements {
+		blocks := make([]*nodepb.Block, 0, len(elem.Blocks))
+		for _, block := range elem.Blocks {
+			blocks = append(blocks, &nodepb.Block{
+				Start:    block.Start,
+				Segments: segmentsToProto(block.Segments),
+				Err:      errorToProto(block.Err),
+				Checksum: int64Value(block.Checksum),
+			})
+		}
+		elements = append(elements, &nodepb.Blocks{
+			Id:     copyBytes(elem.ID),
+			Blocks: blocks,
+		})
+	}
+	return &nodepb.FetchBlocksRawResponse{Elements: elements}
+}
+
+func fetchBlocksRawResultFromProto(resp *nodepb.FetchBlocksRawResponse) *rpc.FetchBlocksRawResult_ {
+	elements := make([]*rpc.Blocks, 0, len(resp.Elements))
+	for _, elem := range resp.Elements {
+		blocks := make([]*rpc.Block, 0, len(elem.Blocks))
+		for _, block := range elem.Blocks {
+			blocks = append(blocks, &rpc.Block{
+				Start:    block.Start,
+				Segments: segmentsFromProto(block.Segments),
+				Err:      errorFromProto(block.Err),
+				Checksum: optionalInt64(block.Checksum),
+			})
+		}
+		elements = append(elements, &rpc.Blocks{
+			ID:     elem.Id,
+			Blocks: blocks,
+		})
+	}
+	return &rpc.FetchBlocksRawResult_{Elements: elements}
+}
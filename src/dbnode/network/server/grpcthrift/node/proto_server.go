@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package node
+
+import (
+	stdctx "context"
+
+	"github.com/m3db/m3/src/dbnode/generated/proto/nodepb"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/network/server/grpcthrift"
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
+	"github.com/m3db/m3/src/x/context"
+)
+
+// protoServer serves the protobuf node service by converting requests to
+// and results from the thrift structs of the node service.
+type protoServer struct {
+	service     rpc.TChanNode
+	contextPool context.Pool
+}
+
+var _ nodepb.NodeServer = (*protoServer)(nil)
+
+func (s *protoServer) WriteBatchRaw(
+	ctx stdctx.Context,
+	req *nodepb.WriteBatchRawRequest,
+) (*nodepb.WriteBatchRawResponse, error) {
+	tctx := grpcthrift.NewServerContext(ctx, s.contextPool)
+	defer tchannelthrift.CloseServerContext(tctx)
+
+	err := s.service.WriteBatchRaw(tctx, writeBatchRawRequestFromProto(req))
+	return writeBatchRawResponseToProto(err)
+}
+
+func (s *protoServer) WriteBatchRawV2(
+	ctx stdctx.Context,
+	req *nodepb.WriteBatchRawV2Request,
+) (*nodepb.WriteBatchRawResponse, error) {
+	tctx := grpcthrift.NewServerContext(ctx, s.contextPool)
+	defer tchannelthrift.CloseServerContext(tctx)
+
+	err := s.service.WriteBatchRawV2(tctx, writeBatchRawV2RequestFromProto(req))
+	return writeBatchRawResponseToProto(err)
+}
+
+func (s *protoServer) WriteTaggedBatchRaw(
+	ctx stdctx.Context,
+	req *nodepb.WriteTaggedBatchRawRequest,
+) (*nodepb.WriteBatchRawResponse, error) {
+	tctx := grpcthrift.NewServerContext(ctx, s.contextPool)
+	defer tchannelthrift.CloseServerContext(tctx)
+
+	err := s.service.WriteTaggedBatchRaw(tctx, writeTaggedBatchRawRequestFromProto(req))
+	return writeBatchRawResponseToProto(err)
+}
+
+func (s *protoServer) WriteTaggedBatchRawV2(
+	ctx stdctx.Context,
+	req *nodepb.WriteTaggedBatchRawV2Request,
+) (*nodepb.WriteBatchRawResponse, error) {
+	tctx := grpcthrift.NewServerContext(ctx, s.contextPool)
+	defer tchannelthrift.CloseServerContext(tctx)
+
+	err := s.service.WriteTaggedBatchRawV2(tctx, writeTaggedBatchRawV2RequestFromProto(req))
+	return writeBatchRawResponseToProto(err)
+}
+
+func (s *protoServer) FetchTagged(
+	ctx stdctx.Context,
+	req *nodepb.FetchTaggedRequest,
+) (*nodepb.FetchTaggedResponse, error) {
+	tctx := grpcthrift.NewServerContext(ctx, s.contextPool)
+	// Results reference resources owned by the M3DB context, the conversion
+	// copies them since the context is closed before gRPC serializes the
+	// response.
+	defer tchannelthrift.CloseServerContext(tctx)
+
+	result, err := s.service.FetchTagged(tctx, fetchTaggedRequestFromProto(req))
+	if err != nil {
+		respErr, err := responseError(err)
+		if err != nil {
+			return nil, err
+		}
+		return &nodepb.FetchTaggedResponse{Err: respErr}, nil
+	}
+	return fetchTaggedResultToProto(result), nil
+}
+
+func (s *protoServer) AggregateRaw(
+	ctx stdctx.Context,
+	req *nodepb.AggregateQueryRawRequest,
+) (*nodepb.AggregateQueryRawResponse, error) {
+	tctx := grpcthrift.NewServerContext(ctx, s.contextPool)
+	defer tchannelthrift.CloseServerContext(tctx)
+
+	result, err := s.service.AggregateRaw(tctx, aggregateRawRequestFromProto(req))
+	if err != nil {
+		respErr, err := responseError(err)
+		if err != nil {
+			return nil, err
+		}
+		return &nodepb.AggregateQueryRawResponse{Err: respErr}, nil
+	}
+	return aggregateRawResultToProto(result), nil
+}
+
+func (s *protoServer) FetchBlocksRaw(
+	ctx stdctx.Context,
+	req *nodepb.FetchBlocksRawRequest,
+) (*nodepb.FetchBlocksRawResponse, error) {
+	tctx := grpcthrift.NewServerContext(ctx, s.contextPool)
+	defer tchannelthrift.CloseServerContext(tctx)
+
+	result, err := s.service.FetchBlocksRaw(tctx, fetchBlocksRawRequestFromProto(req))
+	if err != nil {
+		respErr, err := responseError(err)
+		if err != nil {
+			return nil, err
+		}
+		return &nodepb.FetchBlocksRawResponse{Err: respErr}, nil
+	}
+	return fetchBlocksRawResultToProto(result), nil
+}
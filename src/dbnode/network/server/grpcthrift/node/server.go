@@ -0,0 +1,89 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package node
+
+import (
+	"errors"
+	"net"
+
+	"github.com/m3db/m3/src/dbnode/generated/proto/nodepb"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	ns "github.com/m3db/m3/src/dbnode/network/server"
+	"github.com/m3db/m3/src/dbnode/network/server/grpcthrift"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+type server struct {
+	service     rpc.TChanNode
+	address     string
+	contextPool context.Pool
+	iOpts       instrument.Options
+}
+
+// NewServer creates a node gRPC network service, it serves the protobuf
+// node service and every method of the thrift node service with the thrift
+// codec.
+func NewServer(
+	service rpc.TChanNode,
+	address string,
+	contextPool context.Pool,
+	iOpts instrument.Options,
+) ns.NetworkService {
+	return &server{
+		service:     service,
+		address:     address,
+		contextPool: contextPool,
+		iOpts:       iOpts,
+	}
+}
+
+func (s *server) ListenAndServe() (ns.Close, error) {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return nil, err
+	}
+
+	server := grpc.NewServer(
+		grpc.MaxRecvMsgSize(grpcthrift.DefaultMaxMessageSize),
+		grpc.MaxSendMsgSize(grpcthrift.DefaultMaxMessageSize),
+	)
+	nodepb.RegisterNodeServer(server, &protoServer{
+		service:     s.service,
+		contextPool: s.contextPool,
+	})
+	grpcthrift.RegisterServer(server, rpc.NewTChanNodeServer(s.service), s.contextPool)
+
+	logger := s.iOpts.Logger()
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			logger.Error("node grpc: serve error",
+				zap.String("address", s.address), zap.Error(err))
+		}
+	}()
+
+	// Let in flight requests complete so that their M3DB contexts are
+	// closed before the service is torn down.
+	return server.GracefulStop, nil
+}
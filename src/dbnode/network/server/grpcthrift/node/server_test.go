@@ -0,0 +1,344 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package node
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
+	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/tchannel-go/thrift"
+	"google.golang.org/grpc"
+)
+
+var errTestBadRequest = errors.New("bad request")
+
+func newTestClient(t *testing.T, service rpc.TChanNode) (rpc.TChanNode, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	closer, err := NewServer(service, address, context.NewPool(context.NewOptions()),
+		instrument.NewOptions()).
+		ListenAndServe()
+	require.NoError(t, err)
+
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	require.NoError(t, err)
+
+	return NewClient(conn), func() {
+		require.NoError(t, conn.Close())
+		closer()
+	}
+}
+
+func TestServerRoundTrip(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	service := rpc.NewMockTChanNode(ctrl)
+	client, closer := newTestClient(t, service)
+	defer closer()
+
+	service.EXPECT().Health(gomock.Any()).DoAndReturn(
+		func(ctx thrift.Context) (*rpc.NodeHealthResult_, error) {
+			require.NotNil(t, tchannelthrift.Context(ctx))
+			require.Equal(t, "bar", ctx.Headers()["foo"])
+			return &rpc.NodeHealthResult_{Ok: true, Status: "up", Bootstrapped: true}, nil
+		})
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+
+	result, err := client.Health(thrift.WithHeaders(ctx, map[string]string{"foo": "bar"}))
+	require.NoError(t, err)
+	require.Equal(t, &rpc.NodeHealthResult_{Ok: true, Status: "up", Bootstrapped: true}, result)
+}
+
+func TestServerReturnsThriftErrors(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	service := rpc.NewMockTChanNode(ctrl)
+	client, closer := newTestClient(t, service)
+	defer closer()
+
+	service.EXPECT().Fetch(gomock.Any(), gomock.Any()).
+		Return(nil, tterrors.NewBadRequestError(errTestBadRequest))
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+
+	_, err := client.Fetch(ctx, &rpc.FetchRequest{NameSpace: "ns", ID: "foo"})
+	require.Error(t, err)
+
+	rpcErr, ok := err.(*rpc.Error)
+	require.True(t, ok)
+	require.True(t, tterrors.IsBadRequestError(rpcErr))
+	require.Equal(t, errTestBadRequest.Error(), rpcErr.Message)
+}
+
+func TestServerWriteTaggedBatchRawRoundTrip(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	service := rpc.NewMockTChanNode(ctrl)
+	client, closer := newTestClient(t, service)
+	defer closer()
+
+	req := &rpc.WriteTaggedBatchRawRequest{
+		NameSpace: []byte("ns"),
+		Elements: []*rpc.WriteTaggedBatchRawRequestElement{
+			{
+				ID:          []byte("foo"),
+				EncodedTags: []byte("tags"),
+				Datapoint: &rpc.Datapoint{
+					Timestamp:         1,
+					Value:             42,
+					Annotation:        []byte("annotation"),
+					TimestampTimeType: rpc.TimeType_UNIX_NANOSECONDS,
+				},
+			},
+			{
+				ID:          []byte("bar"),
+				EncodedTags: []byte("tags"),
+				Datapoint:   &rpc.Datapoint{Timestamp: 2, Value: 43},
+			},
+		},
+	}
+	writeErrs := &rpc.WriteBatchRawErrors{
+		Errors: []*rpc.WriteBatchRawError{
+			{Index: 1, Err: tterrors.NewBadRequestError(errTestBadRequest)},
+		},
+	}
+	service.EXPECT().WriteTaggedBatchRaw(gomock.Any(), req).DoAndReturn(
+		func(ctx thrift.Context, _ *rpc.WriteTaggedBatchRawRequest) error {
+			require.Equal(t, "bar", ctx.Headers()["foo"])
+			return writeErrs
+		})
+	service.EXPECT().WriteTaggedBatchRaw(gomock.Any(), req).
+		Return(tterrors.NewInternalError(errTestBadRequest))
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+
+	err := client.WriteTaggedBatchRaw(thrift.WithHeaders(ctx, map[string]string{"foo": "bar"}), req)
+	require.Equal(t, writeErrs, err)
+
+	err = client.WriteTaggedBatchRaw(ctx, req)
+	rpcErr, ok := err.(*rpc.Error)
+	require.True(t, ok)
+	require.Equal(t, rpc.ErrorType_INTERNAL_ERROR, rpcErr.Type)
+}
+
+func TestServerFetchTaggedRoundTrip(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	service := rpc.NewMockTChanNode(ctrl)
+	client, closer := newTestClient(t, service)
+	defer closer()
+
+	var (
+		seriesLimit       = int64(10)
+		docsLimit         = int64(20)
+		resolution        = int64(time.Minute)
+		explain           = true
+		pageSize          = int64(5)
+		rollupAggregation = int32(2)
+		startTime         = int64(3)
+		blockSize         = int64(time.Hour)
+		checksum          = int64(4)
+		waitedIndex       = int64(1)
+	)
+	req := &rpc.FetchTaggedRequest{
+		NameSpace:         []byte("ns"),
+		Query:             []byte("query"),
+		RangeStart:        1,
+		RangeEnd:          2,
+		FetchData:         true,
+		SeriesLimit:       &seriesLimit,
+		RangeTimeType:     rpc.TimeType_UNIX_NANOSECONDS,
+		RequireExhaustive: true,
+		DocsLimit:         &docsLimit,
+		Source:            []byte("source"),
+		RequireNoWait:     true,
+		ResolutionNanos:   &resolution,
+		Explain:           &explain,
+		PageSize:          &pageSize,
+		PageToken:         []byte("token"),
+		RollupAggregation: &rollupAggregation,
+	}
+	result := &rpc.FetchTaggedResult_{
+		Elements: []*rpc.FetchTaggedIDResult_{
+			{
+				ID:          []byte("foo"),
+				NameSpace:   []byte("ns"),
+				EncodedTags: []byte("tags"),
+				Segments: []*rpc.Segments{
+					{Merged: &rpc.Segment{Head: []byte("head"), Tail: []byte("tail"), Checksum: &checksum}},
+					{Unmerged: []*rpc.Segment{
+						{
+							Head:      []byte("head"),
+							Tail:      []byte("tail"),
+							StartTime: &startTime,
+							BlockSize: &blockSize,
+							Checksum:  &checksum,
+						},
+					}},
+				},
+			},
+			{
+				ID:          []byte("bar"),
+				NameSpace:   []byte("ns"),
+				EncodedTags: []byte("tags"),
+			},
+		},
+		Exhaustive:    true,
+		WaitedIndex:   &waitedIndex,
+		Explain:       []byte("explain"),
+		NextPageToken: []byte("next"),
+		Stats:         &rpc.FetchTaggedStats{DocsRead: 1, BytesRead: 2, BlocksRead: 3},
+	}
+	service.EXPECT().FetchTagged(gomock.Any(), req).Return(result, nil)
+	service.EXPECT().FetchTagged(gomock.Any(), req).
+		Return(nil, tterrors.NewBadRequestError(errTestBadRequest))
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+
+	actual, err := client.FetchTagged(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, result, actual)
+
+	_, err = client.FetchTagged(ctx, req)
+	rpcErr, ok := err.(*rpc.Error)
+	require.True(t, ok)
+	require.True(t, tterrors.IsBadRequestError(rpcErr))
+	require.Equal(t, errTestBadRequest.Error(), rpcErr.Message)
+}
+
+func TestServerAggregateRawRoundTrip(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	service := rpc.NewMockTChanNode(ctrl)
+	client, closer := newTestClient(t, service)
+	defer closer()
+
+	var (
+		seriesLimit       = int64(10)
+		requireExhaustive = true
+	)
+	req := &rpc.AggregateQueryRawRequest{
+		Query:              []byte("query"),
+		RangeStart:         1,
+		RangeEnd:           2,
+		NameSpace:          []byte("ns"),
+		SeriesLimit:        &seriesLimit,
+		TagNameFilter:      [][]byte{[]byte("foo")},
+		AggregateQueryType: rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME_VALUE,
+		RequireExhaustive:  &requireExhaustive,
+	}
+	result := &rpc.AggregateQueryRawResult_{
+		Results: []*rpc.AggregateQueryRawResultTagNameElement{
+			{
+				TagName: []byte("foo"),
+				TagValues: []*rpc.AggregateQueryRawResultTagValueElement{
+					{TagValue: []byte("bar")},
+					{TagValue: []byte("baz")},
+				},
+			},
+			{TagName: []byte("qux")},
+		},
+		Exhaustive: true,
+	}
+	service.EXPECT().AggregateRaw(gomock.Any(), req).Return(result, nil)
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+
+	actual, err := client.AggregateRaw(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, result, actual)
+}
+
+func TestServerFetchBlocksRawRoundTrip(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	service := rpc.NewMockTChanNode(ctrl)
+	client, closer := newTestClient(t, service)
+	defer closer()
+
+	checksum := int64(4)
+	req := &rpc.FetchBlocksRawRequest{
+		NameSpace: []byte("ns"),
+		Shard:     3,
+		Elements: []*rpc.FetchBlocksRawRequestElement{
+			{ID: []byte("foo"), Starts: []int64{1, 2}},
+		},
+		Source: []byte("source"),
+	}
+	result := &rpc.FetchBlocksRawResult_{
+		Elements: []*rpc.Blocks{
+			{
+				ID: []byte("foo"),
+				Blocks: []*rpc.Block{
+					{
+						Start: 1,
+						Segments: &rpc.Segments{
+							Merged: &rpc.Segment{Head: []byte("head"), Tail: []byte("tail")},
+						},
+						Checksum: &checksum,
+					},
+					{Start: 2, Err: tterrors.NewInternalError(errTestBadRequest)},
+				},
+			},
+		},
+	}
+	service.EXPECT().FetchBlocksRaw(gomock.Any(), req).Return(result, nil)
+	service.EXPECT().FetchBlocksRaw(gomock.Any(), req).Return(nil, errTestBadRequest)
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+
+	actual, err := client.FetchBlocksRaw(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, result, actual)
+
+	// Errors other than rpc.Error are returned as gRPC status errors.
+	_, err = client.FetchBlocksRaw(ctx, req)
+	require.Error(t, err)
+	_, ok := err.(*rpc.Error)
+	require.False(t, ok)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpcthrift
+
+import (
+	stdctx "context"
+
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
+	"github.com/m3db/m3/src/x/context"
+
+	"github.com/uber/tchannel-go/thrift"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RegisterServer registers a thrift service with a gRPC server. Each thrift
+// method is exposed as a unary gRPC method of a service named after the
+// thrift service, requests must be sent with the thrift content-subtype.
+func RegisterServer(server *grpc.Server, service thrift.TChanServer, contextPool context.Pool) {
+	desc := grpc.ServiceDesc{
+		ServiceName: service.Service(),
+		HandlerType: (*thrift.TChanServer)(nil),
+	}
+	for _, method := range service.Methods() {
+		method := method
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: method,
+			Handler: func(
+				srv interface{},
+				ctx stdctx.Context,
+				dec func(interface{}) error,
+				_ grpc.UnaryServerInterceptor,
+			) (interface{}, error) {
+				return handle(ctx, srv.(thrift.TChanServer), method, dec, contextPool)
+			},
+		})
+	}
+	server.RegisterService(&desc, service)
+}
+
+func handle(
+	ctx stdctx.Context,
+	service thrift.TChanServer,
+	method string,
+	dec func(interface{}) error,
+	contextPool context.Pool,
+) (interface{}, error) {
+	var req []byte
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+
+	tctx := NewServerContext(ctx, contextPool)
+	// Results may reference resources owned by the M3DB context so only
+	// close it once the response has been serialized.
+	defer tchannelthrift.CloseServerContext(tctx)
+
+	success, resp, err := service.Handle(tctx, method, newReadProtocol(req))
+	if err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	if !success {
+		if err := grpc.SetHeader(ctx, metadata.Pairs(successHeader, "false")); err != nil {
+			return nil, err
+		}
+	}
+
+	return marshalStruct(resp)
+}
+
+// NewServerContext returns the thrift context used to call a thrift service
+// for a gRPC request, the request metadata is set as the thrift headers. It
+// must be closed with tchannelthrift.CloseServerContext once the response
+// has been serialized.
+func NewServerContext(ctx stdctx.Context, contextPool context.Pool) thrift.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	headers := make(map[string]string, len(md))
+	for k, v := range md {
+		if len(v) > 0 {
+			headers[k] = v[0]
+		}
+	}
+	return tchannelthrift.NewServerContext(ctx, contextPool, headers)
+}
@@ -40,13 +40,29 @@ func RegisterServer(channel *tchannel.Channel, service thrift.TChanServer, conte
 	server := thrift.NewServer(channel)
 	server.Register(service, thrift.OptPostResponse(postResponseFn))
 	server.SetContextFn(func(ctx stdctx.Context, method string, headers map[string]string) thrift.Context {
-		xCtx := contextPool.Get()
-		xCtx.SetGoContext(ctx)
-		ctxWithValue := stdctx.WithValue(ctx, contextKey, xCtx) //nolint: staticcheck
-		return thrift.WithHeaders(ctxWithValue, headers)
+		return NewServerContext(ctx, contextPool, headers)
 	})
 }
 
+// NewServerContext returns a thrift context with an embedded M3DB context
+// for a request received by a server, the M3DB context must be closed with
+// CloseServerContext once the response has been written.
+func NewServerContext(
+	ctx stdctx.Context,
+	contextPool context.Pool,
+	headers map[string]string,
+) thrift.Context {
+	xCtx := contextPool.Get()
+	xCtx.SetGoContext(ctx)
+	ctxWithValue := stdctx.WithValue(ctx, contextKey, xCtx) //nolint: staticcheck
+	return thrift.WithHeaders(ctxWithValue, headers)
+}
+
+// CloseServerContext closes the M3DB context embedded by NewServerContext.
+func CloseServerContext(ctx stdctx.Context) {
+	postResponseFn(ctx, "", nil)
+}
+
 // NewContext returns a new thrift context and cancel func with embedded M3DB context
 func NewContext(timeout time.Duration) (thrift.Context, stdctx.CancelFunc) {
 	tctx, cancel := thrift.NewContext(timeout)
@@ -28,8 +28,10 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/dbnode/storage/limits/admission"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
 	"github.com/m3db/m3/src/m3ninx/generated/proto/querypb"
@@ -215,6 +217,9 @@ func ToRPCError(err error) *rpc.Error {
 	if limits.IsQueryLimitExceededError(err) {
 		return tterrors.NewResourceExhaustedError(err)
 	}
+	if admission.IsOverloadedError(err) {
+		return tterrors.NewOverloadedError(err)
+	}
 	if xerrors.IsInvalidParams(err) {
 		return tterrors.NewBadRequestError(err)
 	}
@@ -263,6 +268,25 @@ func FromRPCFetchTaggedRequest(
 	if len(req.Source) > 0 {
 		opts.Source = req.Source
 	}
+	if r := req.ResolutionNanos; r != nil {
+		opts.Resolution = time.Duration(*r)
+	}
+	if a := req.RollupAggregation; a != nil {
+		aggregation := rollup.Aggregation(*a)
+		if err := aggregation.Validate(); err != nil {
+			return nil, index.Query{}, index.QueryOptions{}, false, err
+		}
+		opts.RollupAggregation = aggregation
+	}
+	if e := req.Explain; e != nil {
+		opts.Explain = *e
+	}
+	if l := req.PageSize; l != nil {
+		opts.PageSize = int(*l)
+	}
+	if len(req.PageToken) > 0 {
+		opts.PageToken = req.PageToken
+	}
 
 	q, err := idx.Unmarshal(req.Query)
 	if err != nil {
@@ -326,9 +350,226 @@ func ToRPCFetchTaggedRequest(
 		request.Source = opts.Source
 	}
 
+	if opts.Resolution > 0 {
+		r := int64(opts.Resolution)
+		request.ResolutionNanos = &r
+		if opts.RollupAggregation != rollup.Avg {
+			a := int32(opts.RollupAggregation)
+			request.RollupAggregation = &a
+		}
+	}
+
+	if opts.Explain {
+		explain := true
+		request.Explain = &explain
+	}
+
+	if opts.PageSize > 0 {
+		l := int64(opts.PageSize)
+		request.PageSize = &l
+	}
+
+	if len(opts.PageToken) > 0 {
+		request.PageToken = opts.PageToken
+	}
+
 	return request, nil
 }
 
+// FromRPCDeleteTaggedRequest converts the rpc request type for DeleteTaggedRequest into corresponding Go API types.
+func FromRPCDeleteTaggedRequest(
+	req *rpc.DeleteTaggedRequest,
+) (ident.ID, index.Query, xtime.UnixNano, xtime.UnixNano, error) {
+	start, rangeStartErr := ToTime(req.RangeStart, req.RangeTimeType)
+	if rangeStartErr != nil {
+		return nil, index.Query{}, 0, 0, rangeStartErr
+	}
+
+	end, rangeEndErr := ToTime(req.RangeEnd, req.RangeTimeType)
+	if rangeEndErr != nil {
+		return nil, index.Query{}, 0, 0, rangeEndErr
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, 0, 0, err
+	}
+
+	ns := ident.StringID(string(req.NameSpace))
+	return ns, index.Query{Query: q}, start, end, nil
+}
+
+// ToRPCDeleteTaggedRequest converts the Go `client/` types into rpc request type
+// for DeleteTaggedRequest.
+func ToRPCDeleteTaggedRequest(
+	ns ident.ID,
+	q index.Query,
+	start, end xtime.UnixNano,
+) (rpc.DeleteTaggedRequest, error) {
+	rangeStart, tsErr := ToValue(start, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.DeleteTaggedRequest{}, tsErr
+	}
+
+	rangeEnd, tsErr := ToValue(end, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.DeleteTaggedRequest{}, tsErr
+	}
+
+	query, queryErr := idx.Marshal(q.Query)
+	if queryErr != nil {
+		return rpc.DeleteTaggedRequest{}, queryErr
+	}
+
+	return rpc.DeleteTaggedRequest{
+		NameSpace:     ns.Bytes(),
+		Query:         query,
+		RangeStart:    rangeStart,
+		RangeEnd:      rangeEnd,
+		RangeTimeType: fetchTaggedTimeType,
+	}, nil
+}
+
+// FromRPCCardinalityRequest converts the rpc request type for CardinalityRequest into corresponding Go API types.
+func FromRPCCardinalityRequest(
+	req *rpc.CardinalityRequest,
+) (ident.ID, index.Query, index.CardinalityOptions, error) {
+	start, rangeStartErr := ToTime(req.RangeStart, req.RangeTimeType)
+	if rangeStartErr != nil {
+		return nil, index.Query{}, index.CardinalityOptions{}, rangeStartErr
+	}
+
+	end, rangeEndErr := ToTime(req.RangeEnd, req.RangeTimeType)
+	if rangeEndErr != nil {
+		return nil, index.Query{}, index.CardinalityOptions{}, rangeEndErr
+	}
+
+	opts := index.CardinalityOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+		},
+		TopN:          int(req.Limit),
+		MetricNameTag: req.MetricNameTag,
+	}
+	if l := req.SeriesLimit; l != nil {
+		opts.SeriesLimit = int(*l)
+	}
+	if l := req.DocsLimit; l != nil {
+		opts.DocsLimit = int(*l)
+	}
+	if r := req.RequireExhaustive; r != nil {
+		opts.RequireExhaustive = *r
+	}
+	if len(req.Source) > 0 {
+		opts.Source = req.Source
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, index.CardinalityOptions{}, err
+	}
+
+	ns := ident.StringID(string(req.NameSpace))
+	return ns, index.Query{Query: q}, opts, nil
+}
+
+// ToRPCCardinalityRequest converts the Go `client/` types into rpc request type
+// for CardinalityRequest.
+func ToRPCCardinalityRequest(
+	ns ident.ID,
+	q index.Query,
+	opts index.CardinalityOptions,
+) (rpc.CardinalityRequest, error) {
+	rangeStart, tsErr := ToValue(opts.StartInclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.CardinalityRequest{}, tsErr
+	}
+
+	rangeEnd, tsErr := ToValue(opts.EndExclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.CardinalityRequest{}, tsErr
+	}
+
+	query, queryErr := idx.Marshal(q.Query)
+	if queryErr != nil {
+		return rpc.CardinalityRequest{}, queryErr
+	}
+
+	request := rpc.CardinalityRequest{
+		NameSpace:     ns.Bytes(),
+		Query:         query,
+		RangeStart:    rangeStart,
+		RangeEnd:      rangeEnd,
+		RangeTimeType: fetchTaggedTimeType,
+		Limit:         int64(opts.TopN),
+		MetricNameTag: opts.MetricNameTag,
+	}
+	if opts.SeriesLimit > 0 {
+		l := int64(opts.SeriesLimit)
+		request.SeriesLimit = &l
+	}
+	if opts.DocsLimit > 0 {
+		l := int64(opts.DocsLimit)
+		request.DocsLimit = &l
+	}
+	if opts.RequireExhaustive {
+		r := opts.RequireExhaustive
+		request.RequireExhaustive = &r
+	}
+	if len(opts.Source) > 0 {
+		request.Source = opts.Source
+	}
+
+	return request, nil
+}
+
+// ToRPCCardinalityResult converts the cardinality query result into the
+// rpc result type.
+func ToRPCCardinalityResult(result index.CardinalityQueryResult) *rpc.CardinalityResult_ {
+	res := rpc.NewCardinalityResult_()
+	res.SeriesCountByMetricName = toRPCCardinalityEntries(result.Stats.SeriesCountByMetricName)
+	res.LabelValueCountByLabelName = toRPCCardinalityEntries(result.Stats.LabelValueCountByLabelName)
+	res.SeriesCountByLabelValuePair = toRPCCardinalityEntries(result.Stats.SeriesCountByLabelValuePair)
+	res.Exhaustive = result.Exhaustive
+	return res
+}
+
+func toRPCCardinalityEntries(entries []index.CardinalityEntry) []*rpc.CardinalityEntry {
+	rpcEntries := make([]*rpc.CardinalityEntry, 0, len(entries))
+	for _, e := range entries {
+		rpcEntries = append(rpcEntries, &rpc.CardinalityEntry{
+			Name:  []byte(e.Name),
+			Value: e.Value,
+		})
+	}
+	return rpcEntries
+}
+
+// FromRPCCardinalityResult converts the rpc result type into the
+// cardinality query result.
+func FromRPCCardinalityResult(res *rpc.CardinalityResult_) index.CardinalityQueryResult {
+	return index.CardinalityQueryResult{
+		Stats: index.CardinalityStats{
+			SeriesCountByMetricName:     fromRPCCardinalityEntries(res.SeriesCountByMetricName),
+			LabelValueCountByLabelName:  fromRPCCardinalityEntries(res.LabelValueCountByLabelName),
+			SeriesCountByLabelValuePair: fromRPCCardinalityEntries(res.SeriesCountByLabelValuePair),
+		},
+		Exhaustive: res.Exhaustive,
+	}
+}
+
+func fromRPCCardinalityEntries(rpcEntries []*rpc.CardinalityEntry) []index.CardinalityEntry {
+	entries := make([]index.CardinalityEntry, 0, len(rpcEntries))
+	for _, e := range rpcEntries {
+		entries = append(entries, index.CardinalityEntry{
+			Name:  string(e.Name),
+			Value: e.Value,
+		})
+	}
+	return entries
+}
+
 // FromRPCAggregateQueryRequest converts the rpc request type for AggregateRawQueryRequest into corresponding Go API types.
 func FromRPCAggregateQueryRequest(
 	req *rpc.AggregateQueryRequest,
@@ -30,8 +30,10 @@ import (
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/dbnode/storage/limits/admission"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -110,6 +112,10 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 	var (
 		seriesLimit int64 = 10
 		docsLimit   int64 = 10
+		resolution        = int64(time.Minute)
+		aggregation       = int32(rollup.Max)
+		explain           = true
+		pageSize    int64 = 100
 	)
 	ns := ident.StringID("abc")
 	opts := index.QueryOptions{
@@ -119,6 +125,11 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 		DocsLimit:         int(docsLimit),
 		RequireExhaustive: true,
 		RequireNoWait:     true,
+		Resolution:        time.Minute,
+		RollupAggregation: rollup.Max,
+		Explain:           true,
+		PageSize:          int(pageSize),
+		PageToken:         []byte("foo"),
 	}
 	fetchData := true
 	requestSkeleton := &rpc.FetchTaggedRequest{
@@ -130,6 +141,11 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 		DocsLimit:         &docsLimit,
 		RequireExhaustive: true,
 		RequireNoWait:     true,
+		ResolutionNanos:   &resolution,
+		RollupAggregation: &aggregation,
+		Explain:           &explain,
+		PageSize:          &pageSize,
+		PageToken:         []byte("foo"),
 	}
 	requireEqual := func(a, b interface{}) {
 		d := cmp.Diff(a, b)
@@ -266,9 +282,80 @@ func TestConvertAggregateRawQueryRequest(t *testing.T) {
 	}
 }
 
+func TestConvertCardinalityRequest(t *testing.T) {
+	var (
+		seriesLimit       int64 = 10
+		docsLimit         int64 = 20
+		requireExhaustive       = true
+		ns                      = ident.StringID("abc")
+		q, rpcQ                 = termQueryTestCase(t)
+	)
+	opts := index.CardinalityOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive:    xtime.Now().Add(-900 * time.Hour),
+			EndExclusive:      xtime.Now(),
+			SeriesLimit:       int(seriesLimit),
+			DocsLimit:         int(docsLimit),
+			RequireExhaustive: requireExhaustive,
+			Source:            []byte("source"),
+		},
+		TopN:          5,
+		MetricNameTag: []byte("__name__"),
+	}
+	expectedReq := &rpc.CardinalityRequest{
+		NameSpace:         ns.Bytes(),
+		Query:             rpcQ,
+		RangeStart:        mustToRPCTime(t, opts.StartInclusive),
+		RangeEnd:          mustToRPCTime(t, opts.EndExclusive),
+		RangeTimeType:     rpc.TimeType_UNIX_NANOSECONDS,
+		Limit:             5,
+		MetricNameTag:     []byte("__name__"),
+		SeriesLimit:       &seriesLimit,
+		DocsLimit:         &docsLimit,
+		RequireExhaustive: &requireExhaustive,
+		Source:            []byte("source"),
+	}
+
+	observedReq, err := convert.ToRPCCardinalityRequest(ns, index.Query{Query: q}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "", cmp.Diff(expectedReq, &observedReq))
+
+	id, observedQuery, observedOpts, err := convert.FromRPCCardinalityRequest(expectedReq)
+	require.NoError(t, err)
+	require.Equal(t, ns.String(), id.String())
+	require.True(t, index.NewQueryMatcher(index.Query{Query: q}).Matches(observedQuery))
+	assert.Equal(t, "", cmp.Diff(opts, observedOpts))
+}
+
+func TestConvertCardinalityResult(t *testing.T) {
+	result := index.CardinalityQueryResult{
+		Stats: index.CardinalityStats{
+			SeriesCountByMetricName: []index.CardinalityEntry{
+				{Name: "http_requests_total", Value: 10},
+			},
+			LabelValueCountByLabelName: []index.CardinalityEntry{
+				{Name: "pod", Value: 8},
+				{Name: "__name__", Value: 1},
+			},
+			SeriesCountByLabelValuePair: []index.CardinalityEntry{
+				{Name: "__name__=http_requests_total", Value: 10},
+			},
+		},
+		Exhaustive: true,
+	}
+
+	rpcResult := convert.ToRPCCardinalityResult(result)
+	require.Len(t, rpcResult.LabelValueCountByLabelName, 2)
+	assert.Equal(t, []byte("pod"), rpcResult.LabelValueCountByLabelName[0].Name)
+	assert.Equal(t, int64(8), rpcResult.LabelValueCountByLabelName[0].Value)
+
+	assert.Equal(t, result, convert.FromRPCCardinalityResult(rpcResult))
+}
+
 func TestToRPCError(t *testing.T) {
 	limitErr := limits.NewQueryLimitExceededError("limit")
 	invalidParamsErr := xerrors.NewInvalidParamsError(errors.New("param"))
+	overloadedErr := admission.NewOverloadedError("overloaded")
 
 	require.Equal(t, tterrors.NewResourceExhaustedError(limitErr), convert.ToRPCError(limitErr))
 	require.Equal(
@@ -277,6 +364,13 @@ func TestToRPCError(t *testing.T) {
 		convert.ToRPCError(xerrors.Wrap(limitErr, "wrap")),
 	)
 
+	require.Equal(t, tterrors.NewOverloadedError(overloadedErr), convert.ToRPCError(overloadedErr))
+	require.Equal(
+		t,
+		tterrors.NewOverloadedError(xerrors.Wrap(overloadedErr, "wrap")),
+		convert.ToRPCError(xerrors.Wrap(overloadedErr, "wrap")),
+	)
+
 	require.Equal(t, tterrors.NewBadRequestError(invalidParamsErr), convert.ToRPCError(invalidParamsErr))
 	require.Equal(
 		t,
@@ -319,3 +413,33 @@ var _ convert.FetchTaggedConversionPools = &testPools{}
 
 func (t *testPools) ID() ident.Pool                                     { return t.id }
 func (t *testPools) CheckedBytesWrapper() xpool.CheckedBytesWrapperPool { return t.wrapper }
+
+func TestFetchTaggedPageTokenRoundTrip(t *testing.T) {
+	token := convert.FetchTaggedPageToken{
+		LastID:  []byte("foo"),
+		Cursors: []uint64{1, 1 << 40},
+	}
+	decoded, err := convert.DecodeFetchTaggedPageToken(convert.EncodeFetchTaggedPageToken(token))
+	require.NoError(t, err)
+	require.Equal(t, token, decoded)
+
+	decoded, err = convert.DecodeFetchTaggedPageToken(nil)
+	require.NoError(t, err)
+	require.Equal(t, convert.FetchTaggedPageToken{}, decoded)
+
+	encoded := convert.EncodeFetchTaggedPageToken(token)
+	_, err = convert.DecodeFetchTaggedPageToken(encoded[:len(encoded)-1])
+	require.Error(t, err)
+	_, err = convert.DecodeFetchTaggedPageToken([]byte("foo"))
+	require.Error(t, err)
+}
+
+func TestFetchTaggedPageTokenMerge(t *testing.T) {
+	token := convert.FetchTaggedPageToken{LastID: []byte("foo"), Cursors: []uint64{1}}
+	token.Merge(convert.FetchTaggedPageToken{LastID: []byte("bar"), Cursors: []uint64{2}})
+	token.Merge(convert.FetchTaggedPageToken{LastID: []byte("qux"), Cursors: []uint64{1}})
+	require.Equal(t, convert.FetchTaggedPageToken{
+		LastID:  []byte("bar"),
+		Cursors: []uint64{1, 2},
+	}, token)
+}
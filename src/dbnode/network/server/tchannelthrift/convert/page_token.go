@@ -0,0 +1,129 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package convert
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+const fetchTaggedPageTokenVersion = 1
+
+var errInvalidFetchTaggedPageToken = errors.New("invalid fetch tagged page token")
+
+// FetchTaggedPageToken is the continuation token of a paged fetch tagged.
+type FetchTaggedPageToken struct {
+	// LastID is the ID of the last series of the previous page, the next
+	// page starts after it.
+	LastID []byte
+	// Cursors are the IDs of the cursors the hosts hold for the query, each
+	// host resumes from its own cursor if it still holds it.
+	Cursors []uint64
+}
+
+// Merge merges the token returned by another host into the token, the page
+// ends at the smaller of the last IDs since only the series up to it have
+// been returned by both hosts.
+func (t *FetchTaggedPageToken) Merge(other FetchTaggedPageToken) {
+	if t.LastID == nil || bytes.Compare(other.LastID, t.LastID) < 0 {
+		t.LastID = other.LastID
+	}
+	t.AddCursors(other.Cursors)
+}
+
+// AddCursors adds the cursors not yet in the token.
+func (t *FetchTaggedPageToken) AddCursors(cursors []uint64) {
+	for _, cursor := range cursors {
+		found := false
+		for _, existing := range t.Cursors {
+			if existing == cursor {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Cursors = append(t.Cursors, cursor)
+		}
+	}
+}
+
+// EncodeFetchTaggedPageToken encodes a fetch tagged page token.
+func EncodeFetchTaggedPageToken(token FetchTaggedPageToken) []byte {
+	b := make([]byte, 0, 1+2*binary.MaxVarintLen64+len(token.LastID)+
+		len(token.Cursors)*binary.MaxVarintLen64)
+	b = append(b, fetchTaggedPageTokenVersion)
+	b = appendUvarint(b, uint64(len(token.LastID)))
+	b = append(b, token.LastID...)
+	b = appendUvarint(b, uint64(len(token.Cursors)))
+	for _, cursor := range token.Cursors {
+		b = appendUvarint(b, cursor)
+	}
+	return b
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+// DecodeFetchTaggedPageToken decodes a fetch tagged page token, the zero
+// token is returned for an empty token.
+func DecodeFetchTaggedPageToken(b []byte) (FetchTaggedPageToken, error) {
+	var token FetchTaggedPageToken
+	if len(b) == 0 {
+		return token, nil
+	}
+	if b[0] != fetchTaggedPageTokenVersion {
+		return token, errInvalidFetchTaggedPageToken
+	}
+	b = b[1:]
+
+	n, size := binary.Uvarint(b)
+	if size <= 0 || uint64(len(b)-size) < n {
+		return token, errInvalidFetchTaggedPageToken
+	}
+	b = b[size:]
+	token.LastID = append([]byte(nil), b[:n]...)
+	b = b[n:]
+
+	n, size = binary.Uvarint(b)
+	if size <= 0 || uint64(len(b)-size) < n {
+		return token, errInvalidFetchTaggedPageToken
+	}
+	b = b[size:]
+	if n > 0 {
+		token.Cursors = make([]uint64, 0, n)
+	}
+	for i := uint64(0); i < n; i++ {
+		cursor, size := binary.Uvarint(b)
+		if size <= 0 {
+			return token, errInvalidFetchTaggedPageToken
+		}
+		token.Cursors = append(token.Cursors, cursor)
+		b = b[size:]
+	}
+	if len(b) > 0 {
+		return token, errInvalidFetchTaggedPageToken
+	}
+	return token, nil
+}
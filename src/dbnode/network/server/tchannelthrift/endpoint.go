@@ -61,6 +61,8 @@ func (e Endpoint) String() string {
 		return "FetchTagged"
 	case Query:
 		return "Query"
+	case Cardinality:
+		return "Cardinality"
 	case Unknown:
 		fallthrough
 	default:
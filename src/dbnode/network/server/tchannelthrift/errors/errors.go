@@ -54,6 +54,11 @@ func IsTimeoutError(err *rpc.Error) bool {
 	return err != nil && err.Flags&int64(rpc.ErrorFlags_SERVER_TIMEOUT) != 0
 }
 
+// IsOverloadedErrorFlag returns whether error has the overloaded flag.
+func IsOverloadedErrorFlag(err *rpc.Error) bool {
+	return err != nil && err.Flags&int64(rpc.ErrorFlags_OVERLOADED) != 0
+}
+
 // NewInternalError creates a new internal error
 func NewInternalError(err error) *rpc.Error {
 	return newError(rpc.ErrorType_INTERNAL_ERROR, err, int64(rpc.ErrorFlags_NONE))
@@ -74,6 +79,12 @@ func NewTimeoutError(err error) *rpc.Error {
 	return newError(rpc.ErrorType_INTERNAL_ERROR, err, int64(rpc.ErrorFlags_SERVER_TIMEOUT))
 }
 
+// NewOverloadedError creates a new overloaded error, which is an internal
+// error that callers may retry after backing off.
+func NewOverloadedError(err error) *rpc.Error {
+	return newError(rpc.ErrorType_INTERNAL_ERROR, err, int64(rpc.ErrorFlags_OVERLOADED))
+}
+
 // NewWriteBatchRawError creates a new write batch error
 func NewWriteBatchRawError(index int, err error) *rpc.WriteBatchRawError {
 	batchErr := rpc.NewWriteBatchRawError()
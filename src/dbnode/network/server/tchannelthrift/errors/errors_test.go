@@ -50,6 +50,14 @@ func TestErrorsAreRecognized(t *testing.T) {
 			name:  "resource exhausted flag",
 			value: IsResourceExhaustedErrorFlag(NewResourceExhaustedError(someError)),
 		},
+		{
+			name:  "overloaded error",
+			value: IsInternalError(NewOverloadedError(someError)),
+		},
+		{
+			name:  "overloaded flag",
+			value: IsOverloadedErrorFlag(NewOverloadedError(someError)),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -132,7 +132,7 @@ func setup(mocks *gomock.Controller) (
 		for j := 0; j < 10; j++ {
 			blockReaders = append(blockReaders, []xio.BlockReader{})
 		}
-		db.EXPECT().ReadEncoded(ctx, nsID, id, start, end).Return(&series.FakeBlockReaderIter{
+		db.EXPECT().ReadEncoded(ctx, nsID, id, start, end, gomock.Any()).Return(&series.FakeBlockReaderIter{
 			Readers: blockReaders,
 		}, nil)
 		resMap.Map().Set(id.Bytes(), doc.Document{})
@@ -0,0 +1,230 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package node
+
+import (
+	"bytes"
+	"container/list"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/x/clock"
+)
+
+const (
+	// fetchTaggedCursorTTL is how long the cursor of a paged fetch tagged is
+	// kept after its last page was fetched.
+	fetchTaggedCursorTTL = time.Minute
+
+	// maxFetchTaggedCursorSeries is the max number of series held across all
+	// cursors, the least recently used cursors are evicted beyond it.
+	maxFetchTaggedCursorSeries = 1 << 20
+)
+
+// fetchTaggedEntry is a series matched by a fetch tagged query.
+type fetchTaggedEntry struct {
+	id  []byte
+	doc doc.Document
+}
+
+// fetchTaggedEntries returns the entries of the query results.
+func fetchTaggedEntries(results index.QueryResults, after []byte) []fetchTaggedEntry {
+	resultsMap := results.Map()
+	entries := make([]fetchTaggedEntry, 0, resultsMap.Len())
+	for _, entry := range resultsMap.Iter() { // nolint: gocritic
+		if after != nil && bytes.Compare(entry.Key(), after) <= 0 {
+			continue
+		}
+		entries = append(entries, fetchTaggedEntry{id: entry.Key(), doc: entry.Value()})
+	}
+	return entries
+}
+
+// fetchTaggedCursor holds the series matched by a paged fetch tagged sorted by
+// ID, so that the index is queried once for all the pages of the query.
+type fetchTaggedCursor struct {
+	id         uint64
+	key        string
+	entries    []fetchTaggedEntry
+	exhaustive bool
+	expiresAt  time.Time
+	elem       *list.Element
+}
+
+// newFetchTaggedCursor returns a cursor over the sorted entries, their IDs and
+// documents are copied since the cursor outlives the query results. The tags
+// of the series are only encoded when the page holding them is served.
+func newFetchTaggedCursor(
+	key string,
+	entries []fetchTaggedEntry,
+	exhaustive bool,
+) *fetchTaggedCursor {
+	cursor := &fetchTaggedCursor{
+		key:        key,
+		entries:    make([]fetchTaggedEntry, 0, len(entries)),
+		exhaustive: exhaustive,
+	}
+	for _, entry := range entries {
+		cursor.entries = append(cursor.entries, fetchTaggedEntry{
+			id:  append([]byte(nil), entry.id...),
+			doc: cloneDocument(entry.doc),
+		})
+	}
+	return cursor
+}
+
+// cloneDocument returns a copy of a document that does not reference the
+// memory of the query results.
+func cloneDocument(d doc.Document) doc.Document {
+	if encoded, ok := d.Encoded(); ok {
+		return doc.NewDocumentFromEncoded(doc.Encoded{
+			Bytes: append([]byte(nil), encoded.Bytes...),
+		})
+	}
+	metadata, _ := d.Metadata()
+	fields := make([]doc.Field, 0, len(metadata.Fields))
+	for _, field := range metadata.Fields {
+		fields = append(fields, doc.Field{
+			Name:  append([]byte(nil), field.Name...),
+			Value: append([]byte(nil), field.Value...),
+		})
+	}
+	return doc.NewDocumentFromMetadata(doc.Metadata{
+		ID:     append([]byte(nil), metadata.ID...),
+		Fields: fields,
+	})
+}
+
+// page returns the entries of the page after lastID and whether more entries
+// remain after it.
+func (c *fetchTaggedCursor) page(lastID []byte, pageSize int) ([]fetchTaggedEntry, bool) {
+	return pageFetchTaggedEntries(c.entries, lastID, pageSize)
+}
+
+// pageFetchTaggedEntries returns the sorted entries of the page after lastID
+// and whether more entries remain after it.
+func pageFetchTaggedEntries(
+	entries []fetchTaggedEntry,
+	lastID []byte,
+	pageSize int,
+) ([]fetchTaggedEntry, bool) {
+	start := sort.Search(len(entries), func(i int) bool {
+		return bytes.Compare(entries[i].id, lastID) > 0
+	})
+	if end := start + pageSize; end < len(entries) {
+		return entries[start:end], true
+	}
+	return entries[start:], false
+}
+
+func sortFetchTaggedEntries(entries []fetchTaggedEntry) {
+	sort.Slice(entries, func(a, b int) bool {
+		return bytes.Compare(entries[a].id, entries[b].id) < 0
+	})
+}
+
+// fetchTaggedCursors is an LRU cache of the cursors of paged fetch tagged
+// queries bounded by the number of series they hold.
+type fetchTaggedCursors struct {
+	sync.Mutex
+
+	nowFn     clock.NowFn
+	maxSeries int
+	numSeries int
+	lru       *list.List
+	cursors   map[uint64]*fetchTaggedCursor
+}
+
+func newFetchTaggedCursors(nowFn clock.NowFn, maxSeries int) *fetchTaggedCursors {
+	return &fetchTaggedCursors{
+		nowFn:     nowFn,
+		maxSeries: maxSeries,
+		lru:       list.New(),
+		cursors:   make(map[uint64]*fetchTaggedCursor),
+	}
+}
+
+// get returns the first unexpired cursor of the query out of the cursors of
+// a page token, each host only holds its own cursors.
+func (c *fetchTaggedCursors) get(key string, ids []uint64) (*fetchTaggedCursor, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	now := c.nowFn()
+	for _, id := range ids {
+		cursor, ok := c.cursors[id]
+		if !ok || cursor.key != key {
+			continue
+		}
+		if now.After(cursor.expiresAt) {
+			c.removeWithLock(cursor)
+			continue
+		}
+		cursor.expiresAt = now.Add(fetchTaggedCursorTTL)
+		c.lru.MoveToFront(cursor.elem)
+		return cursor, true
+	}
+	return nil, false
+}
+
+// put adds a cursor and assigns its ID, returning false if the cursor holds
+// more series than the cache can.
+func (c *fetchTaggedCursors) put(cursor *fetchTaggedCursor) bool {
+	if len(cursor.entries) > c.maxSeries {
+		return false
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	now := c.nowFn()
+	for back := c.lru.Back(); back != nil; back = c.lru.Back() {
+		oldest := back.Value.(*fetchTaggedCursor)
+		if c.numSeries+len(cursor.entries) <= c.maxSeries && !now.After(oldest.expiresAt) {
+			break
+		}
+		c.removeWithLock(oldest)
+	}
+
+	for {
+		// NB: cursor IDs are random so that the IDs of the cursors held by
+		// different hosts for the same query do not collide.
+		cursor.id = rand.Uint64() // nolint: gosec
+		if _, ok := c.cursors[cursor.id]; !ok {
+			break
+		}
+	}
+	cursor.expiresAt = now.Add(fetchTaggedCursorTTL)
+	cursor.elem = c.lru.PushFront(cursor)
+	c.cursors[cursor.id] = cursor
+	c.numSeries += len(cursor.entries)
+	return true
+}
+
+func (c *fetchTaggedCursors) removeWithLock(cursor *fetchTaggedCursor) {
+	c.lru.Remove(cursor.elem)
+	delete(c.cursors, cursor.id)
+	c.numSeries -= len(cursor.entries)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFetchTaggedCursor(key string, ids ...string) *fetchTaggedCursor {
+	cursor := &fetchTaggedCursor{key: key}
+	for _, id := range ids {
+		cursor.entries = append(cursor.entries, fetchTaggedEntry{id: []byte(id)})
+	}
+	return cursor
+}
+
+func TestFetchTaggedCursorPage(t *testing.T) {
+	cursor := newTestFetchTaggedCursor("query", "a", "b", "c", "d", "e")
+
+	entries, more := cursor.page(nil, 2)
+	require.Equal(t, []fetchTaggedEntry{{id: []byte("a")}, {id: []byte("b")}}, entries)
+	require.True(t, more)
+
+	// Resumes after the last ID even if it is not in the cursor.
+	entries, more = cursor.page([]byte("bb"), 2)
+	require.Equal(t, []fetchTaggedEntry{{id: []byte("c")}, {id: []byte("d")}}, entries)
+	require.True(t, more)
+
+	entries, more = cursor.page([]byte("d"), 2)
+	require.Equal(t, []fetchTaggedEntry{{id: []byte("e")}}, entries)
+	require.False(t, more)
+}
+
+func TestFetchTaggedCursorsEviction(t *testing.T) {
+	now := time.Now()
+	cursors := newFetchTaggedCursors(func() time.Time { return now }, 4)
+
+	first := newTestFetchTaggedCursor("first", "a", "b")
+	second := newTestFetchTaggedCursor("second", "a", "b")
+	require.True(t, cursors.put(first))
+	require.True(t, cursors.put(second))
+	require.False(t, cursors.put(newTestFetchTaggedCursor("large", "a", "b", "c", "d", "e")))
+
+	// Only the cursor of the same query is returned.
+	_, ok := cursors.get("second", []uint64{first.id})
+	require.False(t, ok)
+	cursor, ok := cursors.get("first", []uint64{second.id, first.id})
+	require.True(t, ok)
+	require.Equal(t, first, cursor)
+
+	// The least recently used cursor is evicted to make room.
+	require.True(t, cursors.put(newTestFetchTaggedCursor("third", "a")))
+	_, ok = cursors.get("second", []uint64{second.id})
+	require.False(t, ok)
+	_, ok = cursors.get("first", []uint64{first.id})
+	require.True(t, ok)
+
+	// Expired cursors are not returned.
+	now = now.Add(2 * fetchTaggedCursorTTL)
+	_, ok = cursors.get("first", []uint64{first.id})
+	require.False(t, ok)
+}
+
+func TestFetchTaggedCursorCopiesDocuments(t *testing.T) {
+	var (
+		id      = []byte("foo")
+		name    = []byte("name")
+		value   = []byte("value")
+		encoded = []byte("encoded")
+	)
+	cursor := newFetchTaggedCursor("query", []fetchTaggedEntry{
+		{
+			id: id,
+			doc: doc.NewDocumentFromMetadata(doc.Metadata{
+				ID:     id,
+				Fields: []doc.Field{{Name: name, Value: value}},
+			}),
+		},
+		{
+			id:  []byte("bar"),
+			doc: doc.NewDocumentFromEncoded(doc.Encoded{Bytes: encoded}),
+		},
+	}, true)
+
+	// Reusing the memory of the query results does not change the cursor.
+	copy(id, "xxx")
+	copy(name, "xxxx")
+	copy(value, "xxxxx")
+	copy(encoded, "xxxxxxx")
+
+	require.Equal(t, 2, len(cursor.entries))
+	require.Equal(t, "foo", string(cursor.entries[0].id))
+	metadata, ok := cursor.entries[0].doc.Metadata()
+	require.True(t, ok)
+	require.Equal(t, doc.Metadata{
+		ID:     []byte("foo"),
+		Fields: []doc.Field{{Name: []byte("name"), Value: []byte("value")}},
+	}, metadata)
+	encodedDoc, ok := cursor.entries[1].doc.Encoded()
+	require.True(t, ok)
+	require.Equal(t, "encoded", string(encodedDoc.Bytes))
+}
@@ -22,6 +22,7 @@ package node
 
 import (
 	goctx "context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"runtime"
@@ -64,6 +65,7 @@ import (
 	opentracinglog "github.com/opentracing/opentracing-go/log"
 	"github.com/uber-go/tally"
 	"github.com/uber/tchannel-go/thrift"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -112,8 +114,15 @@ type serviceMetrics struct {
 	writeTagged             instrument.MethodMetrics
 	fetchBlocks             instrument.MethodMetrics
 	fetchBlocksMetadata     instrument.MethodMetrics
+	fetchMerkleNodes        instrument.MethodMetrics
+	fetchMerkleLeaves       instrument.MethodMetrics
 	repair                  instrument.MethodMetrics
 	truncate                instrument.MethodMetrics
+	deleteTagged            instrument.MethodMetrics
+	quarantineList          instrument.MethodMetrics
+	quarantinePromote       instrument.MethodMetrics
+	quarantineDiscard       instrument.MethodMetrics
+	cardinality             instrument.MethodMetrics
 	fetchBatchRawRPCS       tally.Counter
 	fetchBatchRaw           instrument.BatchMethodMetrics
 	writeBatchRawRPCs       tally.Counter
@@ -136,8 +145,15 @@ func newServiceMetrics(scope tally.Scope, opts instrument.TimerOptions) serviceM
 		writeTagged:             instrument.NewMethodMetrics(scope, "writeTagged", opts),
 		fetchBlocks:             instrument.NewMethodMetrics(scope, "fetchBlocks", opts),
 		fetchBlocksMetadata:     instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", opts),
+		fetchMerkleNodes:        instrument.NewMethodMetrics(scope, "fetchMerkleNodes", opts),
+		fetchMerkleLeaves:       instrument.NewMethodMetrics(scope, "fetchMerkleLeaves", opts),
 		repair:                  instrument.NewMethodMetrics(scope, "repair", opts),
 		truncate:                instrument.NewMethodMetrics(scope, "truncate", opts),
+		deleteTagged:            instrument.NewMethodMetrics(scope, "deleteTagged", opts),
+		quarantineList:          instrument.NewMethodMetrics(scope, "quarantineList", opts),
+		quarantinePromote:       instrument.NewMethodMetrics(scope, "quarantinePromote", opts),
+		quarantineDiscard:       instrument.NewMethodMetrics(scope, "quarantineDiscard", opts),
+		cardinality:             instrument.NewMethodMetrics(scope, "cardinality", opts),
 		fetchBatchRawRPCS:       scope.Counter("fetchBatchRaw-rpcs"),
 		fetchBatchRaw:           instrument.NewBatchMethodMetrics(scope, "fetchBatchRaw", opts),
 		writeBatchRawRPCs:       scope.Counter("writeBatchRaw-rpcs"),
@@ -167,6 +183,7 @@ type service struct {
 	metrics           serviceMetrics
 	queryLimits       limits.QueryLimits
 	seriesReadPermits permits.Manager
+	cursors           *fetchTaggedCursors
 }
 
 type serviceState struct {
@@ -346,6 +363,8 @@ func NewService(db storage.Database, opts tchannelthrift.Options) Service {
 		},
 		queryLimits:       opts.QueryLimits(),
 		seriesReadPermits: opts.PermitsOptions().SeriesReadPermitsManager(),
+		cursors: newFetchTaggedCursors(opts.ClockOptions().NowFn(),
+			maxFetchTaggedCursorSeries),
 	}
 }
 
@@ -440,9 +459,10 @@ func (s *service) Bootstrapped(ctx thrift.Context) (*rpc.NodeBootstrappedResult_
 // BootstrappedInPlacementOrNoPlacement is designed to be used with cluster
 // management tools like k8s that expected an endpoint that will return
 // success if the node either:
-// 1) Has no cluster placement set yet.
-// 2) Is bootstrapped and durable, meaning it is bootstrapped and is able
-//    to bootstrap the shards it owns from it's own local disk.
+//  1. Has no cluster placement set yet.
+//  2. Is bootstrapped and durable, meaning it is bootstrapped and is able
+//     to bootstrap the shards it owns from it's own local disk.
+//
 // This is useful in addition to the Bootstrapped RPC method as it helps
 // progress node addition/removal/modifications when no placement is set
 // at all and therefore the node has not been able to bootstrap yet.
@@ -685,7 +705,7 @@ func (s *service) readDatapoints(
 	start, end xtime.UnixNano,
 	timeType rpc.TimeType,
 ) ([]*rpc.Datapoint, error) {
-	iter, err := db.ReadEncoded(ctx, nsID, tsID, start, end)
+	iter, err := db.ReadEncoded(ctx, nsID, tsID, start, end, storage.ReadEncodedOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -755,6 +775,9 @@ func (s *service) fetchTaggedResult(ctx context.Context,
 	response := &rpc.FetchTaggedResult_{
 		Elements:   make([]*rpc.FetchTaggedIDResult_, 0, iter.NumIDs()),
 		Exhaustive: iter.Exhaustive(),
+		Stats: &rpc.FetchTaggedStats{
+			DocsRead: int64(iter.DocsRead()),
+		},
 	}
 
 	for iter.Next(ctx) {
@@ -767,6 +790,7 @@ func (s *service) fetchTaggedResult(ctx context.Context,
 		if err != nil {
 			return nil, err
 		}
+		addSegmentsStats(response.Stats, segments)
 		response.Elements = append(response.Elements, &rpc.FetchTaggedIDResult_{
 			ID:          cur.ID(),
 			NameSpace:   iter.Namespace().Bytes(),
@@ -777,6 +801,9 @@ func (s *service) fetchTaggedResult(ctx context.Context,
 	if iter.Err() != nil {
 		return nil, iter.Err()
 	}
+	// NB: only read once the segments of every series were written since
+	// blocks are read from disk as the series are iterated.
+	response.Stats.BytesRead = int64(iter.BytesRead())
 
 	if v := int64(iter.WaitedIndex()); v > 0 {
 		response.WaitedIndex = &v
@@ -784,13 +811,41 @@ func (s *service) fetchTaggedResult(ctx context.Context,
 	if v := int64(iter.WaitedSeriesRead()); v > 0 {
 		response.WaitedSeriesRead = &v
 	}
+	if explain := iter.Explain(); explain != nil {
+		b, err := json.Marshal(explain)
+		if err != nil {
+			return nil, err
+		}
+		response.Explain = b
+	}
+	if token := iter.NextPageToken(); token != nil {
+		response.NextPageToken = token
+	}
 
 	return response, nil
 }
 
+// addSegmentsStats adds the blocks returned for a series to the stats of a
+// fetch tagged response, regardless of whether they were read from disk or
+// from memory.
+func addSegmentsStats(stats *rpc.FetchTaggedStats, segments []*rpc.Segments) {
+	for _, seg := range segments {
+		if seg.Merged != nil {
+			stats.BlocksRead++
+		}
+		stats.BlocksRead += int64(len(seg.Unmerged))
+	}
+}
+
 func (s *service) FetchTaggedIter(ctx context.Context, req *rpc.FetchTaggedRequest) (FetchTaggedResultsIter, error) {
 	callStart := s.nowFn()
 	ctx = addRequestDataToM3Context(ctx, req.Source, tchannelthrift.FetchTagged)
+	// Count the bytes the query reads from disk as they are counted by the
+	// bytes read limit.
+	bytesRead := atomic.NewInt64(0)
+	if goCtx := ctx.GoContext(); goCtx != nil {
+		ctx.SetGoContext(goctx.WithValue(goCtx, limits.BytesReadContextKey, bytesRead))
+	}
 	ctx, sp, sampled := ctx.StartSampledTraceSpan(tracepoint.FetchTagged)
 	if sampled {
 		sp.LogFields(
@@ -809,7 +864,7 @@ func (s *service) FetchTaggedIter(ctx context.Context, req *rpc.FetchTaggedReque
 
 		s.metrics.fetchTagged.ReportSuccessOrError(err, s.nowFn().Sub(callStart))
 	}
-	iter, err := s.fetchTaggedIter(ctx, req, instrumentClose)
+	iter, err := s.fetchTaggedIter(ctx, req, bytesRead, instrumentClose)
 	if err != nil {
 		instrumentClose(err)
 	}
@@ -819,6 +874,7 @@ func (s *service) FetchTaggedIter(ctx context.Context, req *rpc.FetchTaggedReque
 func (s *service) fetchTaggedIter(
 	ctx context.Context,
 	req *rpc.FetchTaggedRequest,
+	bytesRead *atomic.Int64,
 	instrumentClose func(error),
 ) (FetchTaggedResultsIter, error) {
 	db, err := s.startReadRPCWithDB()
@@ -834,7 +890,21 @@ func (s *service) fetchTaggedIter(
 		return nil, tterrors.NewBadRequestError(err)
 	}
 
-	queryResult, err := db.QueryIDs(ctx, ns, query, opts)
+	tagEncoder := s.pools.tagEncoder.Get()
+	ctx.RegisterFinalizer(tagEncoder)
+	docReader := docs.NewEncodedDocumentReader()
+
+	var (
+		queryResult   index.QueryResult
+		entries       []fetchTaggedEntry
+		nextPageToken []byte
+	)
+	if opts.PageSize > 0 {
+		queryResult, entries, nextPageToken, err = s.fetchTaggedPage(ctx, db, ns,
+			query, opts)
+	} else {
+		queryResult, err = db.QueryIDs(ctx, ns, query, opts)
+	}
 	if err != nil {
 		return nil, convert.ToRPCError(err)
 	}
@@ -844,15 +914,14 @@ func (s *service) fetchTaggedIter(
 		return nil, convert.ToRPCError(err)
 	}
 
-	tagEncoder := s.pools.tagEncoder.Get()
-	ctx.RegisterFinalizer(tagEncoder)
-
 	return newFetchTaggedResultsIter(fetchTaggedResultsIterOpts{
 		queryResult:     queryResult,
 		queryOpts:       opts,
+		entries:         entries,
+		nextPageToken:   nextPageToken,
 		fetchData:       fetchData,
 		db:              db,
-		docReader:       docs.NewEncodedDocumentReader(),
+		docReader:       docReader,
 		nsID:            ns,
 		tagEncoder:      tagEncoder,
 		iOpts:           s.opts.InstrumentOptions(),
@@ -860,9 +929,70 @@ func (s *service) fetchTaggedIter(
 		blockPermits:    permits,
 		requireNoWait:   req.RequireNoWait,
 		indexWaited:     queryResult.Waited,
+		bytesRead:       bytesRead,
 	}), nil
 }
 
+// fetchTaggedPage returns the entries of the page of a paged fetch tagged
+// and the token of the next page if more entries remain. The index is only
+// queried for the first page, the matched series are then held by a cursor
+// which serves the later pages of the query from the same results.
+func (s *service) fetchTaggedPage(
+	ctx context.Context,
+	db storage.Database,
+	ns ident.ID,
+	query index.Query,
+	opts index.QueryOptions,
+) (index.QueryResult, []fetchTaggedEntry, []byte, error) {
+	token, err := convert.DecodeFetchTaggedPageToken(opts.PageToken)
+	if err != nil {
+		return index.QueryResult{}, nil, nil, tterrors.NewBadRequestError(err)
+	}
+
+	key := fmt.Sprintf("%s/%s/%d/%d", ns.String(), query.String(),
+		opts.StartInclusive, opts.EndExclusive)
+	if cursor, ok := s.cursors.get(key, token.Cursors); ok {
+		entries, more := cursor.page(token.LastID, opts.PageSize)
+		return index.QueryResult{Exhaustive: cursor.exhaustive}, entries,
+			nextFetchTaggedPageToken(cursor, entries, more), nil
+	}
+
+	// NB: the cursor was evicted or expired, or this is the first page of the
+	// query, so run the query and only keep the series after the page token.
+	queryResult, err := db.QueryIDs(ctx, ns, query, opts)
+	if err != nil {
+		return index.QueryResult{}, nil, nil, err
+	}
+	entries := fetchTaggedEntries(queryResult.Results, token.LastID)
+	sortFetchTaggedEntries(entries)
+	if len(entries) <= opts.PageSize {
+		return queryResult, entries, nil, nil
+	}
+
+	cursor := newFetchTaggedCursor(key, entries, queryResult.Exhaustive)
+	if !s.cursors.put(cursor) {
+		// Too many series to hold, the next page queries the index again.
+		cursor.id = 0
+	}
+	entries, more := cursor.page(nil, opts.PageSize)
+	return queryResult, entries, nextFetchTaggedPageToken(cursor, entries, more), nil
+}
+
+func nextFetchTaggedPageToken(
+	cursor *fetchTaggedCursor,
+	entries []fetchTaggedEntry,
+	more bool,
+) []byte {
+	if !more {
+		return nil
+	}
+	token := convert.FetchTaggedPageToken{LastID: entries[len(entries)-1].id}
+	if cursor.id != 0 {
+		token.Cursors = []uint64{cursor.id}
+	}
+	return convert.EncodeFetchTaggedPageToken(token)
+}
+
 // FetchTaggedResultsIter iterates over the results from FetchTagged
 // The iterator is not thread safe and must only be accessed from a single goroutine.
 type FetchTaggedResultsIter interface {
@@ -878,6 +1008,20 @@ type FetchTaggedResultsIter interface {
 	// WaitedSeriesRead counts how many times series being read had to wait for permits.
 	WaitedSeriesRead() int
 
+	// DocsRead returns the number of index documents read by the query.
+	DocsRead() int
+
+	// BytesRead returns the number of bytes read from disk by the query so
+	// far, the same bytes counted by the bytes read limit.
+	BytesRead() int
+
+	// Explain returns the trace of the index query, only set if requested.
+	Explain() *index.QueryExplain
+
+	// NextPageToken returns the token to fetch the next page of results with,
+	// only set if the results were paged and more results remain.
+	NextPageToken() []byte
+
 	// Namespace is the namespace.
 	Namespace() ident.ID
 
@@ -914,6 +1058,8 @@ type fetchTaggedResultsIter struct {
 type fetchTaggedResultsIterOpts struct {
 	queryResult     index.QueryResult
 	queryOpts       index.QueryOptions
+	entries         []fetchTaggedEntry
+	nextPageToken   []byte
 	fetchData       bool
 	db              storage.Database
 	docReader       *docs.EncodedDocumentReader
@@ -924,18 +1070,22 @@ type fetchTaggedResultsIterOpts struct {
 	blockPermits    permits.Permits
 	requireNoWait   bool
 	indexWaited     int
+	bytesRead       *atomic.Int64
 }
 
 func newFetchTaggedResultsIter(opts fetchTaggedResultsIterOpts) FetchTaggedResultsIter { //nolint: gocritic
+	if opts.queryOpts.PageSize <= 0 {
+		opts.entries = fetchTaggedEntries(opts.queryResult.Results, nil)
+	}
 	return &fetchTaggedResultsIter{
 		fetchTaggedResultsIterOpts: opts,
-		idResults:                  make([]idResult, 0, opts.queryResult.Results.Map().Len()),
+		idResults:                  make([]idResult, 0, len(opts.entries)),
 		permits:                    make([]permits.Permit, 0),
 	}
 }
 
 func (i *fetchTaggedResultsIter) NumIDs() int {
-	return i.queryResult.Results.Map().Len()
+	return len(i.entries)
 }
 
 func (i *fetchTaggedResultsIter) Exhaustive() bool {
@@ -950,6 +1100,29 @@ func (i *fetchTaggedResultsIter) WaitedSeriesRead() int {
 	return i.seriesReadWaited
 }
 
+func (i *fetchTaggedResultsIter) DocsRead() int {
+	if i.queryResult.Results == nil {
+		// NB: pages served from a cursor do not read any docs.
+		return 0
+	}
+	return i.queryResult.Results.TotalDocsCount()
+}
+
+func (i *fetchTaggedResultsIter) BytesRead() int {
+	if i.bytesRead == nil {
+		return 0
+	}
+	return int(i.bytesRead.Load())
+}
+
+func (i *fetchTaggedResultsIter) Explain() *index.QueryExplain {
+	return i.queryResult.Explain
+}
+
+func (i *fetchTaggedResultsIter) NextPageToken() []byte {
+	return i.nextPageToken
+}
+
 func (i *fetchTaggedResultsIter) Namespace() ident.ID {
 	return i.nsID
 }
@@ -957,23 +1130,23 @@ func (i *fetchTaggedResultsIter) Namespace() ident.ID {
 func (i *fetchTaggedResultsIter) Next(ctx context.Context) bool {
 	// initialize the iterator state on the first fetch.
 	if i.idx == 0 {
-		for _, entry := range i.queryResult.Results.Map().Iter() { // nolint: gocritic
+		for _, entry := range i.entries { // nolint: gocritic
 			result := idResult{
-				queryResult: entry,
-				docReader:   i.docReader,
-				tagEncoder:  i.tagEncoder,
-				iOpts:       i.iOpts,
+				entry:      entry,
+				docReader:  i.docReader,
+				tagEncoder: i.tagEncoder,
+				iOpts:      i.iOpts,
 			}
 			if i.fetchData {
 				// NB(r): Use a bytes ID here so that this ID doesn't need to be
 				// copied by the blockRetriever in the streamRequest method when
 				// it checks if the ID is finalizeable or not with IsNoFinalize.
-				id := ident.BytesID(result.queryResult.Key())
-				result.blockReadersIter, i.err = i.db.ReadEncoded(ctx,
-					i.nsID,
-					id,
-					i.queryOpts.StartInclusive,
-					i.queryOpts.EndExclusive)
+				id := ident.BytesID(result.entry.id)
+				result.blockReadersIter, i.err = i.db.ReadEncoded(ctx, i.nsID, id,
+					i.queryOpts.StartInclusive, i.queryOpts.EndExclusive, storage.ReadEncodedOptions{
+						Resolution:        i.queryOpts.Resolution,
+						RollupAggregation: i.queryOpts.RollupAggregation,
+					})
 				if i.err != nil {
 					return false
 				}
@@ -986,7 +1159,7 @@ func (i *fetchTaggedResultsIter) Next(ctx context.Context) bool {
 		i.idResults[i.idx-1].blockReaders = nil
 	}
 
-	if i.idx == i.queryResult.Results.Map().Len() {
+	if i.idx == len(i.entries) {
 		return false
 	}
 
@@ -994,7 +1167,7 @@ func (i *fetchTaggedResultsIter) Next(ctx context.Context) bool {
 		// ensure the blockReaders exist for the current series ID. additionally try to prefetch additional blockReaders
 		// for future seriesID to pipeline the disk reads.
 	readBlocks:
-		for i.blockReadIdx < i.queryResult.Results.Map().Len() {
+		for i.blockReadIdx < len(i.entries) {
 			currResult := &i.idResults[i.blockReadIdx]
 			blockIter := currResult.blockReadersIter
 
@@ -1116,7 +1289,7 @@ type IDResult interface {
 }
 
 type idResult struct {
-	queryResult      index.ResultsMapEntry
+	entry            fetchTaggedEntry
 	docReader        *docs.EncodedDocumentReader
 	tagEncoder       serialize.TagEncoder
 	blockReadersIter series.BlockReaderIter
@@ -1126,11 +1299,11 @@ type idResult struct {
 }
 
 func (i *idResult) ID() []byte {
-	return i.queryResult.Key()
+	return i.entry.id
 }
 
 func (i *idResult) WriteTags(dst []byte) ([]byte, error) {
-	metadata, err := docs.MetadataFromDocument(i.queryResult.Value(), i.docReader)
+	metadata, err := docs.MetadataFromDocument(i.entry.doc, i.docReader)
 	if err != nil {
 		return nil, err
 	}
@@ -1317,7 +1490,7 @@ func (s *service) FetchBatchRaw(tctx thrift.Context, req *rpc.FetchBatchRawReque
 	}, len(req.Ids))
 	for i := range req.Ids {
 		tsID := s.newID(ctx, req.Ids[i])
-		iter, err := db.ReadEncoded(ctx, nsID, tsID, start, end)
+		iter, err := db.ReadEncoded(ctx, nsID, tsID, start, end, storage.ReadEncodedOptions{})
 		if err != nil {
 			encodedResults[i].err = err
 			continue
@@ -1406,7 +1579,7 @@ func (s *service) FetchBatchRawV2(tctx thrift.Context, req *rpc.FetchBatchRawV2R
 		tsID := s.newID(ctx, elem.ID)
 
 		nsIdx := nsIDs[int(elem.NameSpace)]
-		iter, err := db.ReadEncoded(ctx, nsIdx, tsID, start, end)
+		iter, err := db.ReadEncoded(ctx, nsIdx, tsID, start, end, storage.ReadEncodedOptions{})
 		if err != nil {
 			rawResult.Err = convert.ToRPCError(err)
 			if tterrors.IsBadRequestError(rawResult.Err) {
@@ -1473,7 +1646,7 @@ func (s *service) FetchBlocksRaw(tctx thrift.Context, req *rpc.FetchBlocksRawReq
 
 	// Preallocate starts to maximum size since at least one element will likely
 	// be fetching most blocks for peer bootstrapping
-	ropts := nsMetadata.Options().RetentionOptions()
+	ropts := namespace.BlockRetentionOptions(nsMetadata.Options())
 	blockStarts := make([]xtime.UnixNano, 0,
 		(ropts.RetentionPeriod()+ropts.FutureRetentionPeriod())/ropts.BlockSize())
 
@@ -1661,8 +1834,91 @@ func (s *service) getBlocksMetadataV2FromResult(
 	return blocks, nil
 }
 
+func (s *service) FetchMerkleNodesRaw(
+	tctx thrift.Context,
+	req *rpc.FetchMerkleNodesRawRequest,
+) (*rpc.FetchMerkleNodesRawResult_, error) {
+	db, err := s.startReadRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+	defer s.readRPCCompleted(tctx)
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+
+	nsID := s.newID(ctx, req.NameSpace)
+	tree, err := db.FetchMerkleTree(ctx, nsID, uint32(req.Shard),
+		xtime.UnixNano(req.BlockStart), int(req.Depth))
+	if err != nil {
+		s.metrics.fetchMerkleNodes.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	result := rpc.NewFetchMerkleNodesRawResult_()
+	result.Hashes = make([]int64, 0, len(req.Nodes))
+	for _, node := range req.Nodes {
+		hash, ok := tree.Node(int(node))
+		if !ok {
+			s.metrics.fetchMerkleNodes.ReportError(s.nowFn().Sub(callStart))
+			return nil, tterrors.NewBadRequestError(fmt.Errorf(
+				"node %d does not exist in merkle tree of depth %d", node, req.Depth))
+		}
+		result.Hashes = append(result.Hashes, int64(hash))
+	}
+
+	s.metrics.fetchMerkleNodes.ReportSuccess(s.nowFn().Sub(callStart))
+	return result, nil
+}
+
+func (s *service) FetchMerkleLeavesRaw(
+	tctx thrift.Context,
+	req *rpc.FetchMerkleLeavesRawRequest,
+) (*rpc.FetchMerkleLeavesRawResult_, error) {
+	db, err := s.startReadRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+	defer s.readRPCCompleted(tctx)
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+
+	leaves := make([]int, 0, len(req.Leaves))
+	for _, leaf := range req.Leaves {
+		leaves = append(leaves, int(leaf))
+	}
+
+	nsID := s.newID(ctx, req.NameSpace)
+	fetchedMetadata, err := db.FetchMerkleLeavesMetadata(ctx, nsID, uint32(req.Shard),
+		xtime.UnixNano(req.BlockStart), int(req.Depth), leaves)
+	if err != nil {
+		s.metrics.fetchMerkleLeaves.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	ctx.RegisterCloser(fetchedMetadata)
+
+	opts := block.FetchBlocksMetadataOptions{
+		IncludeSizes:     true,
+		IncludeChecksums: true,
+	}
+	elements, err := s.getBlocksMetadataV2FromResult(ctx, opts, fetchedMetadata)
+	if err != nil {
+		s.metrics.fetchMerkleLeaves.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	result := rpc.NewFetchMerkleLeavesRawResult_()
+	result.Elements = elements
+	ctx.RegisterFinalizer(s.newCloseableMetadataV2Elements(elements))
+
+	s.metrics.fetchMerkleLeaves.ReportSuccess(s.nowFn().Sub(callStart))
+	return result, nil
+}
+
 func (s *service) Write(tctx thrift.Context, req *rpc.WriteRequest) error {
-	db, err := s.startWriteRPCWithDB()
+	db, err := s.startAdmittedWriteRPCWithDB(tctx)
 	if err != nil {
 		return err
 	}
@@ -1709,7 +1965,7 @@ func (s *service) Write(tctx thrift.Context, req *rpc.WriteRequest) error {
 }
 
 func (s *service) WriteTagged(tctx thrift.Context, req *rpc.WriteTaggedRequest) error {
-	db, err := s.startWriteRPCWithDB()
+	db, err := s.startAdmittedWriteRPCWithDB(tctx)
 	if err != nil {
 		return err
 	}
@@ -1765,7 +2021,7 @@ func (s *service) WriteTagged(tctx thrift.Context, req *rpc.WriteTaggedRequest)
 
 func (s *service) WriteBatchRaw(tctx thrift.Context, req *rpc.WriteBatchRawRequest) error {
 	s.metrics.writeBatchRawRPCs.Inc(1)
-	db, err := s.startWriteRPCWithDB()
+	db, err := s.startAdmittedWriteRPCWithDB(tctx)
 	if err != nil {
 		return err
 	}
@@ -1851,7 +2107,7 @@ func (s *service) WriteBatchRaw(tctx thrift.Context, req *rpc.WriteBatchRawReque
 
 func (s *service) WriteBatchRawV2(tctx thrift.Context, req *rpc.WriteBatchRawV2Request) error {
 	s.metrics.writeBatchRawRPCs.Inc(1)
-	db, err := s.startWriteRPCWithDB()
+	db, err := s.startAdmittedWriteRPCWithDB(tctx)
 	if err != nil {
 		return err
 	}
@@ -1966,7 +2222,7 @@ func (s *service) WriteBatchRawV2(tctx thrift.Context, req *rpc.WriteBatchRawV2R
 
 func (s *service) WriteTaggedBatchRaw(tctx thrift.Context, req *rpc.WriteTaggedBatchRawRequest) error {
 	s.metrics.writeTaggedBatchRawRPCs.Inc(1)
-	db, err := s.startWriteRPCWithDB()
+	db, err := s.startAdmittedWriteRPCWithDB(tctx)
 	if err != nil {
 		return err
 	}
@@ -2054,7 +2310,7 @@ func (s *service) WriteTaggedBatchRaw(tctx thrift.Context, req *rpc.WriteTaggedB
 
 func (s *service) WriteTaggedBatchRawV2(tctx thrift.Context, req *rpc.WriteTaggedBatchRawV2Request) error {
 	s.metrics.writeBatchRawRPCs.Inc(1)
-	db, err := s.startWriteRPCWithDB()
+	db, err := s.startAdmittedWriteRPCWithDB(tctx)
 	if err != nil {
 		return err
 	}
@@ -2210,6 +2466,185 @@ func (s *service) Truncate(tctx thrift.Context, req *rpc.TruncateRequest) (r *rp
 	return res, nil
 }
 
+func (s *service) DeleteTagged(tctx thrift.Context, req *rpc.DeleteTaggedRequest) (*rpc.DeleteTaggedResult_, error) {
+	db, err := s.startRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	ns, query, start, end, err := convert.FromRPCDeleteTaggedRequest(req)
+	if err != nil {
+		s.metrics.deleteTagged.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	deletedByShard, err := db.DeleteTagged(ctx, ns, query, start, end)
+	if err != nil {
+		s.metrics.deleteTagged.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewDeleteTaggedResult_()
+	res.NumSeriesByShard = make(map[int32]int64, len(deletedByShard))
+	for shard, n := range deletedByShard {
+		res.NumSeries += n
+		res.NumSeriesByShard[int32(shard)] = n
+	}
+
+	// Deletes are destructive so always leave a record of them.
+	s.logger.Info("deleted series",
+		zap.Stringer("namespace", ns),
+		zap.Stringer("query", query),
+		zap.Time("start", start.ToTime()),
+		zap.Time("end", end.ToTime()),
+		zap.ByteString("source", req.Source),
+		zap.Int64("numSeries", res.NumSeries))
+
+	s.metrics.deleteTagged.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) QuarantineList(
+	tctx thrift.Context,
+	req *rpc.QuarantineListRequest,
+) (*rpc.QuarantineListResult_, error) {
+	db, err := s.startRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	callStart := s.nowFn()
+	writes, total, err := db.QuarantinedWrites(ident.StringID(req.NameSpace), int(req.Limit))
+	if err != nil {
+		s.metrics.quarantineList.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewQuarantineListResult_()
+	res.NumWrites = int64(total)
+	res.Writes = make([]*rpc.QuarantinedWrite, 0, len(writes))
+	for _, w := range writes {
+		timestamp, err := convert.ToValue(w.Datapoint.TimestampNanos, req.ResultTimeType)
+		if err != nil {
+			s.metrics.quarantineList.ReportError(s.nowFn().Sub(callStart))
+			return nil, tterrors.NewBadRequestError(err)
+		}
+
+		write := rpc.NewQuarantinedWrite()
+		write.ID = w.ID.String()
+		write.Tags = make([]*rpc.Tag, 0, len(w.Tags.Values()))
+		for _, tag := range w.Tags.Values() {
+			write.Tags = append(write.Tags, &rpc.Tag{
+				Name:  tag.Name.String(),
+				Value: tag.Value.String(),
+			})
+		}
+		write.Datapoint = &rpc.Datapoint{
+			Timestamp:         timestamp,
+			TimestampTimeType: req.ResultTimeType,
+			Value:             w.Datapoint.Value,
+			Annotation:        w.Annotation,
+		}
+		write.QuarantinedAt = int64(w.QuarantinedAt)
+		res.Writes = append(res.Writes, write)
+	}
+
+	s.metrics.quarantineList.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) QuarantinePromote(
+	tctx thrift.Context,
+	req *rpc.QuarantineRequest,
+) (*rpc.QuarantineResult_, error) {
+	db, err := s.startRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	promoted, err := db.PromoteQuarantinedWrites(ctx, ident.StringID(req.NameSpace))
+	if err != nil {
+		s.metrics.quarantinePromote.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	s.logger.Info("promoted quarantined late writes",
+		zap.String("namespace", req.NameSpace),
+		zap.Int("numWrites", promoted))
+
+	res := rpc.NewQuarantineResult_()
+	res.NumWrites = int64(promoted)
+
+	s.metrics.quarantinePromote.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) QuarantineDiscard(
+	tctx thrift.Context,
+	req *rpc.QuarantineRequest,
+) (*rpc.QuarantineResult_, error) {
+	db, err := s.startRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	callStart := s.nowFn()
+	discarded, err := db.DiscardQuarantinedWrites(ident.StringID(req.NameSpace))
+	if err != nil {
+		s.metrics.quarantineDiscard.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	// Discards drop data so always leave a record of them.
+	s.logger.Info("discarded quarantined late writes",
+		zap.String("namespace", req.NameSpace),
+		zap.Int("numWrites", discarded))
+
+	res := rpc.NewQuarantineResult_()
+	res.NumWrites = int64(discarded)
+
+	s.metrics.quarantineDiscard.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) Cardinality(
+	tctx thrift.Context,
+	req *rpc.CardinalityRequest,
+) (*rpc.CardinalityResult_, error) {
+	db, err := s.startReadRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+	defer s.readRPCCompleted(tctx)
+
+	callStart := s.nowFn()
+	ctx := addRequestDataToContext(tctx, req.Source, tchannelthrift.Cardinality)
+
+	ns, query, opts, err := convert.FromRPCCardinalityRequest(req)
+	if err != nil {
+		s.metrics.cardinality.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	result, err := db.Cardinality(ctx, ns, query, opts)
+	if err != nil {
+		s.metrics.cardinality.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	s.metrics.cardinality.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return convert.ToRPCCardinalityResult(result), nil
+}
+
 func (s *service) GetPersistRateLimit(
 	ctx thrift.Context,
 ) (*rpc.NodePersistRateLimitResult_, error) {
@@ -2549,6 +2984,24 @@ func (s *service) startWriteRPCWithDB() (storage.Database, error) {
 	return db, nil
 }
 
+// startAdmittedWriteRPCWithDB starts a write RPC that must also be admitted
+// by the database given the current write pressure.
+func (s *service) startAdmittedWriteRPCWithDB(tctx thrift.Context) (storage.Database, error) {
+	db, err := s.startWriteRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.AdmitWrite(tchannelthrift.Context(tctx)); err != nil {
+		// Callers only defer completing the write RPC once started successfully.
+		s.writeRPCCompleted()
+		s.metrics.overloadRejected.Inc(1)
+		return nil, convert.ToRPCError(err)
+	}
+
+	return db, nil
+}
+
 func (s *service) writeRPCCompleted() {
 	if s.state.maxOutstandingWriteRPCs == 0 {
 		// Nothing to do since we're not tracking the number outstanding RPCs.
@@ -2669,19 +3122,25 @@ func readEncodedResultSegment(
 func (s *service) newCloseableMetadataV2Result(
 	res *rpc.FetchBlocksMetadataRawV2Result_,
 ) closeableMetadataV2Result {
-	return closeableMetadataV2Result{s: s, result: res}
+	return s.newCloseableMetadataV2Elements(res.Elements)
+}
+
+func (s *service) newCloseableMetadataV2Elements(
+	elements []*rpc.BlockMetadataV2,
+) closeableMetadataV2Result {
+	return closeableMetadataV2Result{s: s, elements: elements}
 }
 
 type closeableMetadataV2Result struct {
-	s      *service
-	result *rpc.FetchBlocksMetadataRawV2Result_
+	s        *service
+	elements []*rpc.BlockMetadataV2
 }
 
 func (c closeableMetadataV2Result) Finalize() {
-	for _, blockMetadata := range c.result.Elements {
+	for _, blockMetadata := range c.elements {
 		c.s.pools.blockMetadataV2.Put(blockMetadata)
 	}
-	c.s.pools.blockMetadataV2Slice.Put(c.result.Elements)
+	c.s.pools.blockMetadataV2Slice.Put(c.elements)
 }
 
 type writeBatchPooledReq struct {
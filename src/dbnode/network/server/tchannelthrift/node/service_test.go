@@ -23,6 +23,7 @@ package node
 import (
 	"bytes"
 	gocontext "context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -30,6 +31,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
@@ -41,6 +43,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	conv "github.com/m3db/m3/src/dbnode/storage/index/convert"
 	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/dbnode/storage/limits/admission"
 	"github.com/m3db/m3/src/dbnode/storage/limits/permits"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/topology"
@@ -50,9 +53,12 @@ import (
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/m3ninx/search"
 	"github.com/m3db/m3/src/x/checked"
 	"github.com/m3db/m3/src/x/context"
 	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/pool"
+	"github.com/m3db/m3/src/x/serialize"
 	xtest "github.com/m3db/m3/src/x/test"
 	xtime "github.com/m3db/m3/src/x/time"
 
@@ -62,6 +68,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/uber/tchannel-go/thrift"
+	"go.uber.org/atomic"
 )
 
 // Create opts once to avoid recreating a lot of default pools, etc
@@ -279,7 +286,7 @@ func TestServiceQuery(t *testing.T) {
 		stream, _ := enc.Stream(ctx)
 		streams[id] = stream
 		mockDB.EXPECT().
-			ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end).
+			ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end, gomock.Any()).
 			Return(&series.FakeBlockReaderIter{
 				Readers: [][]xio.BlockReader{{
 					xio.BlockReader{
@@ -590,7 +597,7 @@ func TestServiceFetch(t *testing.T) {
 
 	stream, _ := enc.Stream(ctx)
 	mockDB.EXPECT().
-		ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo"), start, end).
+		ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo"), start, end, gomock.Any()).
 		Return(&series.FakeBlockReaderIter{
 			Readers: [][]xio.BlockReader{
 				{
@@ -703,7 +710,7 @@ func TestServiceFetchUnknownErr(t *testing.T) {
 	unknownErr := fmt.Errorf("unknown-err")
 
 	mockDB.EXPECT().
-		ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo"), start, end).
+		ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo"), start, end, gomock.Any()).
 		Return(nil, unknownErr)
 
 	_, err := service.Fetch(tctx, &rpc.FetchRequest{
@@ -767,7 +774,7 @@ func TestServiceFetchBatchRaw(t *testing.T) {
 		stream, _ := enc.Stream(ctx)
 		streams[id] = stream
 		mockDB.EXPECT().
-			ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end).
+			ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end, gomock.Any()).
 			Return(&series.FakeBlockReaderIter{
 				Readers: [][]xio.BlockReader{
 					{
@@ -874,7 +881,7 @@ func TestServiceFetchBatchRawV2MultiNS(t *testing.T) {
 			nsID = nsID2
 		}
 		mockDB.EXPECT().
-			ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end).
+			ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end, gomock.Any()).
 			Return(&series.FakeBlockReaderIter{
 				Readers: [][]xio.BlockReader{
 					{
@@ -993,8 +1000,8 @@ func TestServiceFetchBatchRawOverMaxOutstandingRequests(t *testing.T) {
 		stream, _ := enc.Stream(ctx)
 		streams[id] = stream
 		mockDB.EXPECT().
-			ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end).
-			Do(func(ctx interface{}, nsID ident.ID, seriesID ident.ID, start xtime.UnixNano, end xtime.UnixNano) {
+			ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end, gomock.Any()).
+			Do(func(ctx interface{}, nsID ident.ID, seriesID ident.ID, start xtime.UnixNano, end xtime.UnixNano, _ storage.ReadEncodedOptions) {
 				close(requestIsOutstanding)
 				<-testIsComplete
 			}).
@@ -1073,7 +1080,7 @@ func TestServiceFetchBatchRawUnknownError(t *testing.T) {
 	}
 	for id := range series {
 		mockDB.EXPECT().
-			ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end).
+			ReadEncoded(ctx, ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end, gomock.Any()).
 			Return(nil, unknownErr)
 	}
 
@@ -1666,15 +1673,22 @@ func TestServiceFetchTagged(t *testing.T) {
 				stream, _ := enc.Stream(ctx)
 				streams[id] = stream
 				mockDB.EXPECT().
-					ReadEncoded(gomock.Any(), ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end).
+					ReadEncoded(gomock.Any(), ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end, gomock.Any()).
 					DoAndReturn(func(
 						ctx context.Context,
 						namespace ident.ID,
 						id ident.ID,
-						start, end xtime.UnixNano) (series.BlockReaderIter, error) {
+						start, end xtime.UnixNano,
+						_ storage.ReadEncodedOptions,
+					) (series.BlockReaderIter, error) {
 						if tc.blockReadCancel {
 							cancel()
 						}
+						// NB: the block retriever counts the bytes read from
+						// disk in the context of the query.
+						bytesRead, ok := ctx.GoContext().Value(limits.BytesReadContextKey).(*atomic.Int64)
+						require.True(t, ok)
+						bytesRead.Add(100)
 						return &series.FakeBlockReaderIter{
 							Readers: [][]xio.BlockReader{{
 								xio.BlockReader{
@@ -1793,6 +1807,11 @@ func TestServiceFetchTagged(t *testing.T) {
 				assert.Equal(t, expectTail, seg.Merged.Tail)
 			}
 
+			require.NotNil(t, r.Stats)
+			assert.Equal(t, int64(resMap.TotalDocsCount()), r.Stats.DocsRead)
+			assert.Equal(t, int64(len(ids)), r.Stats.BlocksRead)
+			assert.Equal(t, int64(100*len(ids)), r.Stats.BytesRead)
+
 			sp.Finish()
 			spans := mtr.FinishedSpans()
 
@@ -2017,6 +2036,192 @@ func TestServiceFetchTaggedNoData(t *testing.T) {
 	}
 }
 
+func TestServiceFetchTaggedPaged(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxSeries     int
+		expectQueries int
+	}{
+		{name: "resume from cursor", maxSeries: maxFetchTaggedCursorSeries, expectQueries: 1},
+		{name: "cursor evicted", maxSeries: 0, expectQueries: 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := xtest.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := storage.NewMockDatabase(ctrl)
+			mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+			mockDB.EXPECT().IsOverloaded().Return(false).AnyTimes()
+
+			service := NewService(mockDB, testTChannelThriftOptions).(*service)
+			service.cursors = newFetchTaggedCursors(time.Now, test.maxSeries)
+
+			tctx, _ := tchannelthrift.NewContext(time.Minute)
+			ctx := tchannelthrift.Context(tctx)
+			defer ctx.Close()
+
+			start := xtime.Now().Add(-2 * time.Hour)
+			end := start.Add(2 * time.Hour)
+			start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+			nsID := "metrics"
+
+			req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+			require.NoError(t, err)
+			qry := index.Query{Query: req}
+
+			ids := []string{"a", "b", "c", "d", "e"}
+			resMap := index.NewQueryResults(ident.StringID(nsID),
+				index.QueryResultsOptions{}, testIndexOptions)
+			for _, id := range ids {
+				md := doc.Metadata{
+					ID:     []byte(id),
+					Fields: []doc.Field{{Name: []byte("foo"), Value: []byte("bar-" + id)}},
+				}
+				resMap.Map().Set(md.ID, doc.NewDocumentFromMetadata(md))
+			}
+			mockDB.EXPECT().QueryIDs(ctx, ident.NewIDMatcher(nsID),
+				index.NewQueryMatcher(qry), gomock.Any()).
+				Return(index.QueryResult{Results: resMap, Exhaustive: true}, nil).
+				Times(test.expectQueries)
+
+			startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+			require.NoError(t, err)
+			endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+			require.NoError(t, err)
+			data, err := idx.Marshal(req)
+			require.NoError(t, err)
+
+			decoderPool := serialize.NewTagDecoderPool(
+				serialize.NewTagDecoderOptions(serialize.TagDecoderOptionsConfig{}),
+				pool.NewObjectPoolOptions())
+			decoderPool.Init()
+
+			var (
+				pageSize  int64 = 2
+				pageToken []byte
+				pages     int
+				fetched   []string
+			)
+			for {
+				r, err := service.FetchTagged(tctx, &rpc.FetchTaggedRequest{
+					NameSpace:  []byte(nsID),
+					Query:      data,
+					RangeStart: startNanos,
+					RangeEnd:   endNanos,
+					PageSize:   &pageSize,
+					PageToken:  pageToken,
+				})
+				require.NoError(t, err)
+				require.True(t, r.Exhaustive)
+				pages++
+
+				for _, elem := range r.Elements {
+					fetched = append(fetched, string(elem.ID))
+					decoder := decoderPool.Get()
+					decoder.Reset(checked.NewBytes(elem.EncodedTags, nil))
+					require.True(t, decoder.Next())
+					require.Equal(t, "bar-"+string(elem.ID), decoder.Current().Value.String())
+					require.NoError(t, decoder.Err())
+					decoder.Close()
+				}
+				if r.NextPageToken == nil {
+					break
+				}
+				pageToken = r.NextPageToken
+			}
+			require.Equal(t, 3, pages)
+			require.Equal(t, ids, fetched)
+		})
+	}
+}
+
+func TestServiceFetchTaggedExplain(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := xtime.Now().Add(-2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+	nsID := "metrics"
+
+	req := idx.NewTermQuery([]byte("foo"), []byte("bar"))
+	qry := index.Query{Query: req}
+
+	md := doc.Metadata{
+		ID:     ident.BytesID("foo"),
+		Fields: []doc.Field{},
+	}
+	resMap := index.NewQueryResults(ident.StringID(nsID),
+		index.QueryResultsOptions{}, testIndexOptions)
+	resMap.Map().Set(md.ID, doc.NewDocumentFromMetadata(md))
+
+	explain := &index.QueryExplain{
+		Blocks: []index.BlockExplain{
+			{
+				BlockStart:     start,
+				ProcessingTime: time.Millisecond,
+				Segments: []search.SearchTrace{
+					{
+						Segment:  "mutable/0",
+						Postings: 1,
+						Matches: []search.MatchTrace{
+							{Match: "term(foo,bar)", Postings: 1},
+						},
+					},
+				},
+			},
+		},
+	}
+	mockDB.EXPECT().QueryIDs(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+			Explain:        true,
+		}).Return(index.QueryResult{
+		Results:    resMap,
+		Exhaustive: true,
+		Explain:    explain,
+	}, nil)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	explainReq := true
+	r, err := service.FetchTagged(tctx, &rpc.FetchTaggedRequest{
+		NameSpace:  []byte(nsID),
+		Query:      data,
+		RangeStart: startNanos,
+		RangeEnd:   endNanos,
+		FetchData:  false,
+		Explain:    &explainReq,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(r.Elements))
+
+	var actual index.QueryExplain
+	require.NoError(t, json.Unmarshal(r.Explain, &actual))
+	require.Equal(t, *explain, actual)
+}
+
 func TestServiceFetchTaggedErrs(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
@@ -2117,7 +2322,7 @@ func TestServiceFetchTaggedReturnOnFirstErr(t *testing.T) {
 
 	stream, _ := enc.Stream(ctx)
 	mockDB.EXPECT().
-		ReadEncoded(gomock.Any(), ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end).
+		ReadEncoded(gomock.Any(), ident.NewIDMatcher(nsID), ident.NewIDMatcher(id), start, end, gomock.Any()).
 		Return(&series.FakeBlockReaderIter{
 			Readers: [][]xio.BlockReader{{
 				xio.BlockReader{
@@ -2383,6 +2588,7 @@ func TestServiceWrite(t *testing.T) {
 		Return(nil)
 
 	mockDB.EXPECT().IsOverloaded().Return(false)
+	mockDB.EXPECT().AdmitWrite(gomock.Any()).Return(nil)
 	err := service.Write(tctx, &rpc.WriteRequest{
 		NameSpace: nsID,
 		ID:        id,
@@ -2421,6 +2627,35 @@ func TestServiceWriteOverloaded(t *testing.T) {
 	require.Equal(t, tterrors.NewInternalError(errServerIsOverloaded), err)
 }
 
+func TestServiceWriteAdmissionRejected(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	overloadedErr := admission.NewOverloadedError("write rejected")
+	mockDB.EXPECT().IsOverloaded().Return(false)
+	mockDB.EXPECT().AdmitWrite(ctx).Return(overloadedErr)
+	err := service.Write(tctx, &rpc.WriteRequest{
+		NameSpace: "metrics",
+		ID:        "foo",
+		Datapoint: &rpc.Datapoint{
+			Timestamp:         time.Now().Unix(),
+			TimestampTimeType: rpc.TimeType_UNIX_SECONDS,
+			Value:             42.42,
+		},
+	})
+	require.Equal(t, tterrors.NewOverloadedError(overloadedErr), err)
+	require.True(t, tterrors.IsOverloadedErrorFlag(err.(*rpc.Error)))
+}
+
 func TestServiceWriteDatabaseNotSet(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
@@ -2492,6 +2727,7 @@ func TestServiceWriteTagged(t *testing.T) {
 		})
 	}
 	mockDB.EXPECT().IsOverloaded().Return(false)
+	mockDB.EXPECT().AdmitWrite(gomock.Any()).Return(nil)
 	err := service.WriteTagged(tctx, request)
 	require.NoError(t, err)
 }
@@ -2592,6 +2828,7 @@ func TestServiceWriteBatchRaw(t *testing.T) {
 	}
 
 	mockDB.EXPECT().IsOverloaded().Return(false)
+	mockDB.EXPECT().AdmitWrite(gomock.Any()).Return(nil)
 	err := service.WriteBatchRaw(tctx, &rpc.WriteBatchRawRequest{
 		NameSpace: []byte(nsID),
 		Elements:  elements,
@@ -2647,6 +2884,7 @@ func TestServiceWriteBatchRawV2SingleNS(t *testing.T) {
 	}
 
 	mockDB.EXPECT().IsOverloaded().Return(false)
+	mockDB.EXPECT().AdmitWrite(gomock.Any()).Return(nil)
 	err := service.WriteBatchRawV2(tctx, &rpc.WriteBatchRawV2Request{
 		NameSpaces: [][]byte{[]byte(nsID)},
 		Elements:   elements,
@@ -2715,6 +2953,7 @@ func TestServiceWriteBatchRawV2MultiNS(t *testing.T) {
 	}
 
 	mockDB.EXPECT().IsOverloaded().Return(false)
+	mockDB.EXPECT().AdmitWrite(gomock.Any()).Return(nil)
 	err := service.WriteBatchRawV2(tctx, &rpc.WriteBatchRawV2Request{
 		NameSpaces: [][]byte{[]byte(nsID1), []byte(nsID2)},
 		Elements:   elements,
@@ -2805,6 +3044,7 @@ func TestServiceWriteBatchRawOverMaxOutstandingRequests(t *testing.T) {
 	}
 
 	mockDB.EXPECT().IsOverloaded().Return(false).AnyTimes()
+	mockDB.EXPECT().AdmitWrite(gomock.Any()).Return(nil)
 
 	// First request will hang until the test is over (so a request is outstanding).
 	outstandingRequestIsComplete := make(chan struct{}, 0)
@@ -2898,6 +3138,7 @@ func TestServiceWriteTaggedBatchRaw(t *testing.T) {
 	}
 
 	mockDB.EXPECT().IsOverloaded().Return(false)
+	mockDB.EXPECT().AdmitWrite(gomock.Any()).Return(nil)
 	err := service.WriteTaggedBatchRaw(tctx, &rpc.WriteTaggedBatchRawRequest{
 		NameSpace: []byte(nsID),
 		Elements:  elements,
@@ -2957,6 +3198,7 @@ func TestServiceWriteTaggedBatchRawV2(t *testing.T) {
 	}
 
 	mockDB.EXPECT().IsOverloaded().Return(false)
+	mockDB.EXPECT().AdmitWrite(gomock.Any()).Return(nil)
 	err := service.WriteTaggedBatchRawV2(tctx, &rpc.WriteTaggedBatchRawV2Request{
 		NameSpaces: [][]byte{[]byte(nsID)},
 		Elements:   elements,
@@ -3027,6 +3269,7 @@ func TestServiceWriteTaggedBatchRawV2MultiNS(t *testing.T) {
 	}
 
 	mockDB.EXPECT().IsOverloaded().Return(false)
+	mockDB.EXPECT().AdmitWrite(gomock.Any()).Return(nil)
 	err := service.WriteTaggedBatchRawV2(tctx, &rpc.WriteTaggedBatchRawV2Request{
 		NameSpaces: [][]byte{[]byte(nsID1), []byte(nsID2)},
 		Elements:   elements,
@@ -3117,6 +3360,7 @@ func TestServiceWriteTaggedBatchRawUnknownError(t *testing.T) {
 	}
 
 	mockDB.EXPECT().IsOverloaded().Return(false)
+	mockDB.EXPECT().AdmitWrite(gomock.Any()).Return(nil)
 	err := service.WriteTaggedBatchRaw(tctx, &rpc.WriteTaggedBatchRawRequest{
 		NameSpace: []byte(nsID),
 		Elements:  elements,
@@ -3170,6 +3414,236 @@ func TestServiceTruncate(t *testing.T) {
 	assert.Equal(t, truncated, r.NumSeries)
 }
 
+func TestServiceQuarantine(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).AnyTimes()
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	var (
+		nsID = "metrics"
+		now  = xtime.Now().Truncate(time.Second)
+	)
+	mockDB.EXPECT().QuarantinedWrites(ident.NewIDMatcher(nsID), 10).Return([]storage.QuarantinedWrite{
+		{
+			ID:   ident.StringID("foo"),
+			Tags: ident.NewTags(ident.StringTag("city", "nyc")),
+			Datapoint: ts.Datapoint{
+				TimestampNanos: now.Add(-time.Hour),
+				Value:          42,
+			},
+			Unit:          xtime.Second,
+			QuarantinedAt: now,
+		},
+	}, 3, nil)
+
+	list, err := service.QuarantineList(tctx, &rpc.QuarantineListRequest{
+		NameSpace:      nsID,
+		Limit:          10,
+		ResultTimeType: rpc.TimeType_UNIX_SECONDS,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), list.NumWrites)
+	require.Len(t, list.Writes, 1)
+	write := list.Writes[0]
+	assert.Equal(t, "foo", write.ID)
+	assert.Equal(t, []*rpc.Tag{{Name: "city", Value: "nyc"}}, write.Tags)
+	assert.Equal(t, now.Add(-time.Hour).Seconds(), write.Datapoint.Timestamp)
+	assert.Equal(t, 42.0, write.Datapoint.Value)
+	assert.Equal(t, int64(now), write.QuarantinedAt)
+
+	mockDB.EXPECT().PromoteQuarantinedWrites(gomock.Any(), ident.NewIDMatcher(nsID)).Return(2, nil)
+	promoted, err := service.QuarantinePromote(tctx, &rpc.QuarantineRequest{NameSpace: nsID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), promoted.NumWrites)
+
+	mockDB.EXPECT().DiscardQuarantinedWrites(ident.NewIDMatcher(nsID)).Return(1, nil)
+	discarded, err := service.QuarantineDiscard(tctx, &rpc.QuarantineRequest{NameSpace: nsID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), discarded.NumWrites)
+}
+
+func TestServiceCardinality(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).AnyTimes()
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	var (
+		nsID  = "metrics"
+		end   = xtime.Now().Truncate(time.Second)
+		start = end.Add(-time.Hour)
+	)
+	query := idx.NewTermQuery([]byte("city"), []byte("nyc"))
+	data, err := idx.Marshal(query)
+	require.NoError(t, err)
+
+	mockDB.EXPECT().Cardinality(gomock.Any(), ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(index.Query{Query: query}), index.CardinalityOptions{
+			QueryOptions: index.QueryOptions{
+				StartInclusive: start,
+				EndExclusive:   end,
+				SeriesLimit:    100,
+			},
+			TopN: 5,
+		}).Return(index.CardinalityQueryResult{
+		Stats: index.CardinalityStats{
+			SeriesCountByMetricName: []index.CardinalityEntry{
+				{Name: "requests", Value: 4},
+			},
+			LabelValueCountByLabelName: []index.CardinalityEntry{
+				{Name: "city", Value: 1},
+			},
+			SeriesCountByLabelValuePair: []index.CardinalityEntry{
+				{Name: "city=nyc", Value: 4},
+			},
+		},
+		Exhaustive: true,
+	}, nil)
+
+	seriesLimit := int64(100)
+	res, err := service.Cardinality(tctx, &rpc.CardinalityRequest{
+		NameSpace:     []byte(nsID),
+		Query:         data,
+		RangeStart:    start.Seconds(),
+		RangeEnd:      end.Seconds(),
+		RangeTimeType: rpc.TimeType_UNIX_SECONDS,
+		Limit:         5,
+		SeriesLimit:   &seriesLimit,
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Exhaustive)
+	assert.Equal(t, []*rpc.CardinalityEntry{{Name: []byte("requests"), Value: 4}},
+		res.SeriesCountByMetricName)
+	assert.Equal(t, []*rpc.CardinalityEntry{{Name: []byte("city"), Value: 1}},
+		res.LabelValueCountByLabelName)
+	assert.Equal(t, []*rpc.CardinalityEntry{{Name: []byte("city=nyc"), Value: 4}},
+		res.SeriesCountByLabelValuePair)
+}
+
+func TestServiceFetchMerkleNodesRaw(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).AnyTimes()
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	var (
+		nsID       = "metrics"
+		blockStart = xtime.Now().Truncate(2 * time.Hour)
+	)
+	tree, err := digest.NewMerkleTree(2)
+	require.NoError(t, err)
+	tree.Add([]byte("foo"), 1)
+	tree.Add([]byte("bar"), 2)
+
+	mockDB.EXPECT().
+		FetchMerkleTree(gomock.Any(), ident.NewIDMatcher(nsID), uint32(3), blockStart, 2).
+		Return(tree, nil).Times(2)
+
+	left, right := digest.MerkleTreeChildren(0)
+	res, err := service.FetchMerkleNodesRaw(tctx, &rpc.FetchMerkleNodesRawRequest{
+		NameSpace:  []byte(nsID),
+		Shard:      3,
+		BlockStart: int64(blockStart),
+		Depth:      2,
+		Nodes:      []int64{0, int64(left), int64(right)},
+	})
+	require.NoError(t, err)
+
+	leftHash, _ := tree.Node(left)
+	rightHash, _ := tree.Node(right)
+	assert.Equal(t, []int64{int64(tree.Root()), int64(leftHash), int64(rightHash)}, res.Hashes)
+
+	_, err = service.FetchMerkleNodesRaw(tctx, &rpc.FetchMerkleNodesRawRequest{
+		NameSpace:  []byte(nsID),
+		Shard:      3,
+		BlockStart: int64(blockStart),
+		Depth:      2,
+		Nodes:      []int64{int64(tree.NumNodes())},
+	})
+	require.Error(t, err)
+	rpcErr, ok := err.(*rpc.Error)
+	require.True(t, ok)
+	assert.True(t, tterrors.IsBadRequestError(rpcErr))
+}
+
+func TestServiceFetchMerkleLeavesRaw(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).AnyTimes()
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	var (
+		nsID       = "metrics"
+		blockStart = xtime.Now().Truncate(2 * time.Hour)
+		checksum   = uint32(111)
+	)
+	blocks := block.NewFetchBlockMetadataResults()
+	blocks.Add(block.FetchBlockMetadataResult{
+		Start:    blockStart,
+		Size:     16,
+		Checksum: &checksum,
+	})
+	mockResult := block.NewFetchBlocksMetadataResults()
+	mockResult.Add(block.NewFetchBlocksMetadataResult(ident.StringID("foo"),
+		ident.EmptyTagIterator, blocks))
+
+	mockDB.EXPECT().
+		FetchMerkleLeavesMetadata(gomock.Any(), ident.NewIDMatcher(nsID), uint32(3),
+			blockStart, 2, []int{1, 3}).
+		Return(mockResult, nil)
+
+	res, err := service.FetchMerkleLeavesRaw(tctx, &rpc.FetchMerkleLeavesRawRequest{
+		NameSpace:  []byte(nsID),
+		Shard:      3,
+		BlockStart: int64(blockStart),
+		Depth:      2,
+		Leaves:     []int64{1, 3},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.Elements))
+
+	elem := res.Elements[0]
+	assert.Equal(t, []byte("foo"), elem.ID)
+	assert.Equal(t, int64(blockStart), elem.Start)
+	require.NotNil(t, elem.Size)
+	assert.Equal(t, int64(16), *elem.Size)
+	require.NotNil(t, elem.Checksum)
+	assert.Equal(t, int64(checksum), *elem.Checksum)
+}
+
 func TestServiceSetPersistRateLimit(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
@@ -57,6 +57,8 @@ const (
 	FetchTagged
 	// Query represents the Query endpoint.
 	Query
+	// Cardinality represents the Cardinality endpoint.
+	Cardinality
 )
 
 // Options controls server behavior
@@ -0,0 +1,624 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tiering"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/pborman/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxStageAttempts is the number of times staging is attempted when
+	// filesets are removed or replaced while being staged.
+	maxStageAttempts = 3
+)
+
+var (
+	errNumShardsNotSet = errors.New("number of shards must be positive")
+
+	// errFileSetsChanged is returned when filesets are removed or replaced
+	// while being staged, in which case they are staged again.
+	errFileSetsChanged = errors.New("filesets changed while staging")
+)
+
+// fileSetToBackup is a fileset captured for a backup along with the local
+// paths of its files and the keys of any of its files that were tiered.
+type fileSetToBackup struct {
+	fileSet    ManifestFileSet
+	filePaths  []string
+	tieredKeys []string
+}
+
+// stagedFile is a file hard linked into the staging directory of a backup
+// so that it outlives cleanup of the original while it is uploaded, or a
+// tiered file that was copied straight from the tiered object store.
+type stagedFile struct {
+	relPath    string
+	stagedPath string
+	size       int64
+	copied     bool
+}
+
+// stagedFiles are the files of a backup staged as of a single point in time.
+type stagedFiles struct {
+	shards     []uint32
+	fileSets   []fileSetToBackup
+	staged     [][]stagedFile
+	commitLogs []stagedFile
+}
+
+func (m *manager) Backup(namespace ident.ID, numShards uint32) (Manifest, error) {
+	manifest, err := m.backup(namespace, numShards)
+	if err != nil {
+		m.metrics.backupErrors.Inc(1)
+		return Manifest{}, err
+	}
+	m.metrics.backups.Inc(1)
+	return manifest, nil
+}
+
+func (m *manager) backup(namespace ident.ID, numShards uint32) (Manifest, error) {
+	if numShards == 0 {
+		return Manifest{}, errNumShardsNotSet
+	}
+
+	now := xtime.ToUnixNano(m.nowFn())
+	manifest := Manifest{
+		ID:        backupID(now),
+		Namespace: namespace.String(),
+		CreatedAt: now,
+		NumShards: numShards,
+	}
+
+	stagingDir := filepath.Join(m.filePathPrefix, stagingDirName, manifest.ID)
+	defer os.RemoveAll(stagingDir)
+
+	existing, err := m.existingVolumeKeys(namespace)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	var staged stagedFiles
+	for attempt := 1; ; attempt++ {
+		staged, err = m.stage(stagingDir, namespace, numShards, existing)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errFileSetsChanged) || attempt == maxStageAttempts {
+			return Manifest{}, err
+		}
+		m.metrics.stageRetries.Inc(1)
+		m.logger.Info("filesets changed while staging backup, staging again",
+			zap.String("namespace", manifest.Namespace),
+			zap.String("backupID", manifest.ID),
+			zap.Int("attempt", attempt),
+			zap.Error(err))
+	}
+	manifest.Shards = staged.shards
+
+	for i, fileSet := range staged.fileSets {
+		for _, file := range staged.staged[i] {
+			key := volumeKey(file.relPath)
+			_, exists := existing[key]
+			switch {
+			case file.copied:
+				// Already copied from the tiered object store when staged.
+			case exists:
+				m.metrics.filesSkipped.Inc(1)
+			default:
+				if err := m.upload(key, file); err != nil {
+					return Manifest{}, err
+				}
+			}
+			fileSet.fileSet.Files = append(fileSet.fileSet.Files, ManifestFile{
+				Path: file.relPath,
+				Key:  key,
+				Size: file.size,
+			})
+		}
+		manifest.FileSets = append(manifest.FileSets, fileSet.fileSet)
+	}
+
+	// Commit logs are appended to until rotated so are uploaded as of the
+	// time they were staged with every backup.
+	for _, file := range staged.commitLogs {
+		key := commitLogKey(manifest.Namespace, manifest.ID, filepath.Base(file.relPath))
+		if err := m.upload(key, file); err != nil {
+			return Manifest{}, err
+		}
+		manifest.CommitLogs = append(manifest.CommitLogs, ManifestFile{
+			Path: file.relPath,
+			Key:  key,
+			Size: file.size,
+		})
+	}
+
+	if err := m.writeManifest(manifest); err != nil {
+		return Manifest{}, fmt.Errorf("could not write manifest: %w", err)
+	}
+
+	m.logger.Info("backed up namespace",
+		zap.String("namespace", manifest.Namespace),
+		zap.String("backupID", manifest.ID),
+		zap.Int("fileSets", len(manifest.FileSets)),
+		zap.Int("commitLogs", len(manifest.CommitLogs)))
+	return manifest, nil
+}
+
+// stage stages every file of a backup before any of them are uploaded. The
+// filesets are listed again once staged and errFileSetsChanged is returned
+// if any were removed or replaced in the meantime, so that the staged files
+// reflect a single point in time across all shards.
+func (m *manager) stage(
+	stagingDir string,
+	namespace ident.ID,
+	numShards uint32,
+	existing map[string]struct{},
+) (stagedFiles, error) {
+	if err := os.RemoveAll(stagingDir); err != nil {
+		return stagedFiles{}, err
+	}
+	if err := os.MkdirAll(stagingDir, stagingDirMode); err != nil {
+		return stagedFiles{}, err
+	}
+
+	shards, fileSets, commitLogPaths, err := m.filesToBackup(namespace, numShards)
+	if err != nil {
+		return stagedFiles{}, err
+	}
+
+	result := stagedFiles{
+		shards:   shards,
+		fileSets: fileSets,
+		staged:   make([][]stagedFile, 0, len(fileSets)),
+	}
+	for _, fileSet := range fileSets {
+		staged, err := m.stageFiles(stagingDir, fileSet.filePaths, existing)
+		if err != nil {
+			return stagedFiles{}, err
+		}
+		for _, key := range fileSet.tieredKeys {
+			file, err := m.copyTiered(key, existing)
+			if err != nil {
+				return stagedFiles{}, err
+			}
+			staged = append(staged, file)
+		}
+		result.staged = append(result.staged, staged)
+	}
+	result.commitLogs, err = m.stageFiles(stagingDir, commitLogPaths, existing)
+	if err != nil {
+		return stagedFiles{}, err
+	}
+
+	_, current, _, err := m.filesToBackup(namespace, numShards)
+	if err != nil {
+		return stagedFiles{}, err
+	}
+	if !sameFileSets(fileSets, current) {
+		return stagedFiles{}, errFileSetsChanged
+	}
+	return result, nil
+}
+
+// filesToBackup returns the shards of the namespace on local disk, the
+// filesets to back up and the commit logs written since the latest snapshot.
+func (m *manager) filesToBackup(
+	namespace ident.ID,
+	numShards uint32,
+) ([]uint32, []fileSetToBackup, []string, error) {
+	snapshots, _, err := fs.SortedSnapshotMetadataFiles(m.fsOpts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var latestSnapshot *fs.SnapshotMetadata
+	if len(snapshots) > 0 {
+		latestSnapshot = &snapshots[len(snapshots)-1]
+	}
+
+	shards, err := m.shards(namespace)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var fileSets []fileSetToBackup
+	for _, shard := range shards {
+		if shard >= numShards {
+			return nil, nil, nil, fmt.Errorf(
+				"shard %d is out of range for %d shards", shard, numShards)
+		}
+
+		dataFiles, err := fs.DataFiles(m.filePathPrefix, namespace, shard)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		dataFileSetsStart := len(fileSets)
+		for _, file := range dataFiles.LatestVolumes() {
+			fileSets = append(fileSets, newFileSetToBackup(DataFileSetType, file))
+		}
+		if err := m.addTieredKeys(
+			fs.ShardDataDirPath(m.filePathPrefix, namespace, shard),
+			fileSets[dataFileSetsStart:],
+			fs.TimeAndVolumeIndexFromDataFileSetFilename,
+		); err != nil {
+			return nil, nil, nil, err
+		}
+
+		snapshotFiles, err := fs.SnapshotFiles(m.filePathPrefix, namespace, shard)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, file := range snapshotFiles.LatestVolumes() {
+			if latestSnapshot != nil {
+				// Only include the snapshots taken by the latest snapshot
+				// since the commit logs are only included from then.
+				_, snapshotID, err := file.SnapshotTimeAndID()
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				if !uuid.Equal(snapshotID, latestSnapshot.ID.UUID) {
+					continue
+				}
+			}
+			fileSets = append(fileSets, newFileSetToBackup(SnapshotFileSetType, file))
+		}
+	}
+
+	indexFiles, err := fs.IndexFiles(m.filePathPrefix, namespace)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	indexFileSetsStart := len(fileSets)
+	for i := range indexFiles {
+		// Every complete index volume is live as volumes hold disjoint segments.
+		if indexFiles[i].HasCompleteCheckpointFile() {
+			fileSets = append(fileSets, newFileSetToBackup(IndexFileSetType, indexFiles[i]))
+		}
+	}
+	if err := m.addTieredKeys(
+		fs.NamespaceIndexDataDirPath(m.filePathPrefix, namespace),
+		fileSets[indexFileSetsStart:],
+		fs.TimeAndVolumeIndexFromFileSetFilename,
+	); err != nil {
+		return nil, nil, nil, err
+	}
+
+	commitLogs, _, err := commitlog.Files(m.commitLogOpts.SetFilesystemOptions(m.fsOpts))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var commitLogPaths []string
+	for _, commitLog := range commitLogs {
+		if latestSnapshot != nil && commitLog.Index < latestSnapshot.CommitlogIdentifier.Index {
+			continue
+		}
+		commitLogPaths = append(commitLogPaths, commitLog.FilePath)
+	}
+
+	return shards, fileSets, commitLogPaths, nil
+}
+
+// addTieredKeys adds the keys of the files of the given filesets that are
+// in the tiered object store rather than on local disk. Filesets are listed
+// locally before the object store so that files tiered in between are not
+// missed.
+func (m *manager) addTieredKeys(
+	dir string,
+	fileSets []fileSetToBackup,
+	parseFileName func(name string) (xtime.UnixNano, int, error),
+) error {
+	if m.tieredStore == nil || len(fileSets) == 0 {
+		return nil
+	}
+	prefix, err := m.relativePath(dir)
+	if err != nil {
+		return err
+	}
+	keys, err := m.tieredStore.List(prefix + "/")
+	if err != nil {
+		return err
+	}
+
+	keysByVolume := make(map[fileSetVolume][]string)
+	for _, key := range keys {
+		blockStart, volume, err := parseFileName(path.Base(key))
+		if err != nil {
+			// Not a fileset file.
+			continue
+		}
+		id := fileSetVolume{blockStart: blockStart, volume: volume}
+		keysByVolume[id] = append(keysByVolume[id], key)
+	}
+
+	for i := range fileSets {
+		fileSet := &fileSets[i]
+		local := make(map[string]struct{}, len(fileSet.filePaths))
+		for _, filePath := range fileSet.filePaths {
+			local[filepath.Base(filePath)] = struct{}{}
+		}
+		id := fileSetVolume{
+			blockStart: fileSet.fileSet.BlockStart,
+			volume:     fileSet.fileSet.VolumeIndex,
+		}
+		for _, key := range keysByVolume[id] {
+			if _, ok := local[path.Base(key)]; ok {
+				// Paged in or not yet removed after being tiered.
+				continue
+			}
+			fileSet.tieredKeys = append(fileSet.tieredKeys, key)
+		}
+	}
+	return nil
+}
+
+// shards returns the shards of a namespace that have data or snapshot
+// filesets on local disk.
+func (m *manager) shards(namespace ident.ID) ([]uint32, error) {
+	seen := make(map[uint32]struct{})
+	for _, dir := range []string{
+		fs.NamespaceDataDirPath(m.filePathPrefix, namespace),
+		fs.NamespaceSnapshotsDirPath(m.filePathPrefix, namespace),
+	} {
+		entries, err := ioutil.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			shard, err := strconv.ParseUint(entry.Name(), 10, 32)
+			if err != nil {
+				continue
+			}
+			seen[uint32(shard)] = struct{}{}
+		}
+	}
+
+	shards := make([]uint32, 0, len(seen))
+	for shard := range seen {
+		shards = append(shards, shard)
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i] < shards[j]
+	})
+	return shards, nil
+}
+
+// stageFiles hard links files into the staging directory, copying them
+// instead if linking is not possible. Files that were tiered since being
+// listed are copied straight from the tiered object store.
+func (m *manager) stageFiles(
+	stagingDir string,
+	filePaths []string,
+	existing map[string]struct{},
+) ([]stagedFile, error) {
+	staged := make([]stagedFile, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		relPath, err := m.relativePath(filePath)
+		if err != nil {
+			return nil, err
+		}
+		stagedPath := filepath.Join(stagingDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(stagedPath), stagingDirMode); err != nil {
+			return nil, err
+		}
+
+		err = os.Link(filePath, stagedPath)
+		if err != nil && !os.IsNotExist(err) {
+			err = copyFile(filePath, stagedPath)
+		}
+		if os.IsNotExist(err) {
+			if m.tieredStore != nil && fs.IsTierableFileSetFile(filePath) {
+				file, err := m.copyTiered(relPath, existing)
+				if err != nil {
+					return nil, err
+				}
+				staged = append(staged, file)
+				continue
+			}
+			return nil, fmt.Errorf("%w: %s was removed", errFileSetsChanged, filePath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not stage %s: %w", filePath, err)
+		}
+
+		info, err := os.Stat(stagedPath)
+		if err != nil {
+			return nil, err
+		}
+		staged = append(staged, stagedFile{
+			relPath:    relPath,
+			stagedPath: stagedPath,
+			size:       info.Size(),
+		})
+	}
+	return staged, nil
+}
+
+// copyTiered copies a tiered fileset file from the tiered object store to
+// the backup object store unless it was already backed up, the object key
+// of a tiered file is its path relative to the filesystem prefix.
+func (m *manager) copyTiered(relPath string, existing map[string]struct{}) (stagedFile, error) {
+	key := volumeKey(relPath)
+	r, size, err := m.tieredStore.Get(relPath)
+	if err == tiering.ErrObjectNotFound {
+		return stagedFile{}, fmt.Errorf("%w: tiered %s was removed", errFileSetsChanged, relPath)
+	}
+	if err != nil {
+		return stagedFile{}, err
+	}
+	defer r.Close()
+
+	file := stagedFile{
+		relPath: relPath,
+		size:    size,
+		copied:  true,
+	}
+	if _, ok := existing[key]; ok {
+		m.metrics.filesSkipped.Inc(1)
+		return file, nil
+	}
+	if err := m.store.Put(key, r, size); err != nil {
+		return stagedFile{}, fmt.Errorf("could not copy tiered %s: %w", relPath, err)
+	}
+	existing[key] = struct{}{}
+	m.metrics.filesUploaded.Inc(1)
+	m.metrics.bytesUploaded.Inc(size)
+	return file, nil
+}
+
+// existingVolumeKeys returns the keys of the fileset files of a namespace
+// that are already in the object store.
+func (m *manager) existingVolumeKeys(namespace ident.ID) (map[string]struct{}, error) {
+	existing := make(map[string]struct{})
+	for _, dir := range []string{
+		fs.NamespaceDataDirPath(m.filePathPrefix, namespace),
+		fs.NamespaceSnapshotsDirPath(m.filePathPrefix, namespace),
+		fs.NamespaceIndexDataDirPath(m.filePathPrefix, namespace),
+	} {
+		relPath, err := m.relativePath(dir)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := m.store.List(volumeKey(relPath) + "/")
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			existing[key] = struct{}{}
+		}
+	}
+	return existing, nil
+}
+
+func (m *manager) upload(key string, file stagedFile) error {
+	f, err := os.Open(file.stagedPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Limit the upload to the size when staged as commit logs may still be
+	// appended to.
+	if err := m.store.Put(key, io.LimitReader(f, file.size), file.size); err != nil {
+		return fmt.Errorf("could not upload %s: %w", file.relPath, err)
+	}
+	m.metrics.filesUploaded.Inc(1)
+	m.metrics.bytesUploaded.Inc(file.size)
+	return nil
+}
+
+func newFileSetToBackup(fileSetType FileSetType, file fs.FileSetFile) fileSetToBackup {
+	return fileSetToBackup{
+		fileSet: ManifestFileSet{
+			Type:        fileSetType,
+			Shard:       file.ID.Shard,
+			BlockStart:  file.ID.BlockStart,
+			VolumeIndex: file.ID.VolumeIndex,
+		},
+		filePaths: file.AbsoluteFilePaths,
+	}
+}
+
+// fileSetVolume identifies a volume of a fileset within a directory.
+type fileSetVolume struct {
+	blockStart xtime.UnixNano
+	volume     int
+}
+
+// sameFileSets returns whether two listings contain the same filesets.
+func sameFileSets(a, b []fileSetToBackup) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ids := make(map[fileSetID]struct{}, len(a))
+	for _, fileSet := range a {
+		ids[fileSetIdentity(fileSet.fileSet)] = struct{}{}
+	}
+	for _, fileSet := range b {
+		if _, ok := ids[fileSetIdentity(fileSet.fileSet)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// fileSetID identifies a fileset across listings.
+type fileSetID struct {
+	fileSetType FileSetType
+	shard       uint32
+	volume      fileSetVolume
+}
+
+func fileSetIdentity(fileSet ManifestFileSet) fileSetID {
+	return fileSetID{
+		fileSetType: fileSet.Type,
+		shard:       fileSet.Shard,
+		volume: fileSetVolume{
+			blockStart: fileSet.BlockStart,
+			volume:     fileSet.VolumeIndex,
+		},
+	}
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// backupID returns the ID of a backup taken at the given time, IDs are
+// fixed width so that they sort chronologically.
+func backupID(t xtime.UnixNano) string {
+	return fmt.Sprintf("%020d", int64(t))
+}
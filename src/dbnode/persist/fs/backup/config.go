@@ -0,0 +1,55 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tiering"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Configuration is the configuration for backups of namespaces.
+type Configuration struct {
+	// ObjectStore is the object store backups are stored in.
+	ObjectStore tiering.ObjectStoreConfiguration `yaml:"objectStore"`
+}
+
+// NewManager returns a backup manager for the configuration, the tiered
+// object store is nil unless filesets are tiered.
+func (c Configuration) NewManager(
+	fsOpts fs.Options,
+	commitLogOpts commitlog.Options,
+	tieredStore tiering.ObjectStore,
+	iOpts instrument.Options,
+) (Manager, error) {
+	store, err := c.ObjectStore.NewObjectStore()
+	if err != nil {
+		return nil, err
+	}
+	opts := NewOptions().
+		SetObjectStore(store).
+		SetTieredObjectStore(tieredStore).
+		SetFilesystemOptions(fsOpts).
+		SetCommitLogOptions(commitLogOpts).
+		SetInstrumentOptions(iOpts)
+	return NewManager(opts)
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tiering"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	// volumesKeyPrefix is the prefix of the keys of fileset files, which are
+	// immutable and so shared between the backups that include them.
+	volumesKeyPrefix = "volumes/"
+	// backupsKeyPrefix is the prefix of the keys of the manifests and
+	// commit logs of each backup.
+	backupsKeyPrefix = "backups/"
+	manifestKeyName  = "manifest.json"
+	commitLogsDir    = "commitlogs"
+
+	// stagingDirName is the directory under the file path prefix that files
+	// are staged in while being backed up or restored.
+	stagingDirName = "backup-staging"
+	stagingDirMode = os.FileMode(0755)
+	stagingTmpExt  = ".tmp"
+)
+
+type managerMetrics struct {
+	backups          tally.Counter
+	backupErrors     tally.Counter
+	stageRetries     tally.Counter
+	restores         tally.Counter
+	restoreErrors    tally.Counter
+	filesUploaded    tally.Counter
+	filesSkipped     tally.Counter
+	bytesUploaded    tally.Counter
+	filesDownloaded  tally.Counter
+	seriesResharded  tally.Counter
+	commitLogEntries tally.Counter
+}
+
+func newManagerMetrics(scope tally.Scope) managerMetrics {
+	return managerMetrics{
+		backups:          scope.Counter("backups"),
+		backupErrors:     scope.Counter("backup-errors"),
+		stageRetries:     scope.Counter("stage-retries"),
+		restores:         scope.Counter("restores"),
+		restoreErrors:    scope.Counter("restore-errors"),
+		filesUploaded:    scope.Counter("files-uploaded"),
+		filesSkipped:     scope.Counter("files-skipped"),
+		bytesUploaded:    scope.Counter("bytes-uploaded"),
+		filesDownloaded:  scope.Counter("files-downloaded"),
+		seriesResharded:  scope.Counter("series-resharded"),
+		commitLogEntries: scope.Counter("commitlog-entries"),
+	}
+}
+
+type manager struct {
+	store          tiering.ObjectStore
+	tieredStore    tiering.ObjectStore
+	fsOpts         fs.Options
+	commitLogOpts  commitlog.Options
+	filePathPrefix string
+	nowFn          clock.NowFn
+	metrics        managerMetrics
+	logger         *zap.Logger
+}
+
+// NewManager returns a new backup manager.
+func NewManager(opts Options) (Manager, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	iOpts := opts.InstrumentOptions()
+	return &manager{
+		store:          opts.ObjectStore(),
+		tieredStore:    opts.TieredObjectStore(),
+		fsOpts:         opts.FilesystemOptions(),
+		commitLogOpts:  opts.CommitLogOptions(),
+		filePathPrefix: opts.FilesystemOptions().FilePathPrefix(),
+		nowFn:          opts.ClockOptions().NowFn(),
+		metrics:        newManagerMetrics(iOpts.MetricsScope().SubScope("backup")),
+		logger:         iOpts.Logger(),
+	}, nil
+}
+
+func (m *manager) Backups(namespace ident.ID) ([]string, error) {
+	prefix := namespaceBackupsKeyPrefix(namespace.String())
+	keys, err := m.store.List(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, key := range keys {
+		id := strings.TrimPrefix(key, prefix)
+		if !strings.HasSuffix(id, "/"+manifestKeyName) {
+			continue
+		}
+		id = strings.TrimSuffix(id, "/"+manifestKeyName)
+		if strings.Contains(id, "/") {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	// IDs are fixed width timestamps so sort chronologically.
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (m *manager) readManifest(namespace, id string) (Manifest, error) {
+	r, _, err := m.store.Get(manifestKey(namespace, id))
+	if err != nil {
+		return Manifest{}, fmt.Errorf("could not read manifest of backup %s: %w", id, err)
+	}
+	defer r.Close()
+
+	var manifest Manifest
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return Manifest{}, fmt.Errorf("could not decode manifest of backup %s: %w", id, err)
+	}
+	return manifest, nil
+}
+
+func (m *manager) writeManifest(manifest Manifest) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	return m.store.Put(manifestKey(manifest.Namespace, manifest.ID),
+		bytes.NewReader(data), int64(len(data)))
+}
+
+// relativePath returns the slash separated path of a file relative to the
+// file path prefix.
+func (m *manager) relativePath(filePath string) (string, error) {
+	rel, err := filepath.Rel(m.filePathPrefix, filePath)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("file %s is not under %s", filePath, m.filePathPrefix)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// download writes the object stored under the key to the file path,
+// replacing the file atomically once the object has been read in full.
+func (m *manager) download(key, filePath string) error {
+	r, _, err := m.store.Get(key)
+	if err != nil {
+		return fmt.Errorf("could not download %s: %w", key, err)
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(filepath.Dir(filePath), stagingDirMode); err != nil {
+		return err
+	}
+	tmpPath := filePath + stagingTmpExt
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath)
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	m.metrics.filesDownloaded.Inc(1)
+	return os.Rename(tmpPath, filePath)
+}
+
+func namespaceBackupsKeyPrefix(namespace string) string {
+	return backupsKeyPrefix + namespace + "/"
+}
+
+func manifestKey(namespace, id string) string {
+	return path.Join(backupsKeyPrefix, namespace, id, manifestKeyName)
+}
+
+func commitLogKey(namespace, id, name string) string {
+	return path.Join(backupsKeyPrefix, namespace, id, commitLogsDir, name)
+}
+
+func volumeKey(relPath string) string {
+	return volumesKeyPrefix + relPath
+}
@@ -0,0 +1,658 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tiering"
+	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBlockSize = 2 * time.Hour
+	testNumShards = 4
+)
+
+var (
+	testNamespaceID = ident.StringID("testns")
+	testSeriesIDs   = []string{"foo", "bar", "baz", "qux", "quux", "corge", "grault", "garply"}
+	testBlockStart  = xtime.UnixNano(0).Add(10 * testBlockSize)
+)
+
+// recordingStore records the keys of the objects put into an object store.
+type recordingStore struct {
+	sync.Mutex
+	tiering.ObjectStore
+
+	puts []string
+}
+
+func (s *recordingStore) Put(key string, r io.Reader, size int64) error {
+	s.Lock()
+	s.puts = append(s.puts, key)
+	s.Unlock()
+	return s.ObjectStore.Put(key, r, size)
+}
+
+func (s *recordingStore) reset() []string {
+	s.Lock()
+	defer s.Unlock()
+	puts := s.puts
+	s.puts = nil
+	return puts
+}
+
+// hookStore calls a hook before getting objects from an object store.
+type hookStore struct {
+	tiering.ObjectStore
+
+	onGet func(key string)
+}
+
+func (s *hookStore) Get(key string) (io.ReadCloser, int64, error) {
+	if s.onGet != nil {
+		s.onGet(key)
+	}
+	return s.ObjectStore.Get(key)
+}
+
+type testSetup struct {
+	dir         string
+	store       *recordingStore
+	tieredStore tiering.ObjectStore
+	now         time.Time
+}
+
+func newTestSetup(t *testing.T) *testSetup {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	return &testSetup{
+		dir:   dir,
+		store: &recordingStore{ObjectStore: tiering.NewDirectoryObjectStore(filepath.Join(dir, "store"))},
+		now:   time.Unix(0, 0).Add(100 * testBlockSize),
+	}
+}
+
+func (s *testSetup) close() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *testSetup) fsOpts(node string) fs.Options {
+	return fs.NewOptions().SetFilePathPrefix(filepath.Join(s.dir, node))
+}
+
+func (s *testSetup) newManager(t *testing.T, node string) Manager {
+	mgr, err := NewManager(NewOptions().
+		SetObjectStore(s.store).
+		SetTieredObjectStore(s.tieredStore).
+		SetFilesystemOptions(s.fsOpts(node)).
+		SetClockOptions(clock.NewOptions().SetNowFn(func() time.Time {
+			return s.now
+		})))
+	require.NoError(t, err)
+	return mgr
+}
+
+func writeTestFileSets(
+	t *testing.T,
+	fsOpts fs.Options,
+	fileSetType persist.FileSetType,
+	blockStart xtime.UnixNano,
+	volume int,
+	snapshotID uuid.UUID,
+	seriesIDs []string,
+) {
+	hashFn := sharding.DefaultHashFn(testNumShards)
+	byShard := make(map[uint32][]string)
+	for _, id := range seriesIDs {
+		shard := hashFn(ident.StringID(id))
+		byShard[shard] = append(byShard[shard], id)
+	}
+
+	for shard, ids := range byShard {
+		w, err := fs.NewWriter(fsOpts)
+		require.NoError(t, err)
+		require.NoError(t, w.Open(fs.DataWriterOpenOptions{
+			Identifier: fs.FileSetFileIdentifier{
+				Namespace:   testNamespaceID,
+				Shard:       shard,
+				BlockStart:  blockStart,
+				VolumeIndex: volume,
+			},
+			BlockSize:   testBlockSize,
+			FileSetType: fileSetType,
+			Snapshot: fs.DataWriterSnapshotOptions{
+				SnapshotTime: blockStart,
+				SnapshotID:   snapshotID,
+			},
+		}))
+		for _, id := range ids {
+			data := []byte("data-" + id)
+			bytes := checked.NewBytes(data, nil)
+			bytes.IncRef()
+			metadata := persist.NewMetadataFromIDAndTags(ident.StringID(id),
+				ident.NewTags(ident.StringTag("name", id)), persist.MetadataOptions{})
+			require.NoError(t, w.Write(metadata, bytes, digest.Checksum(data)))
+		}
+		require.NoError(t, w.Close())
+	}
+}
+
+func writeTestIndexFileSet(t *testing.T, fsOpts fs.Options, blockStart xtime.UnixNano) {
+	w, err := fs.NewIndexWriter(fsOpts)
+	require.NoError(t, err)
+	shards := make(map[uint32]struct{})
+	for shard := uint32(0); shard < testNumShards; shard++ {
+		shards[shard] = struct{}{}
+	}
+	require.NoError(t, w.Open(fs.IndexWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			FileSetContentType: persist.FileSetIndexContentType,
+			Namespace:          testNamespaceID,
+			BlockStart:         blockStart,
+		},
+		BlockSize:   testBlockSize,
+		FileSetType: persist.FileSetFlushType,
+		Shards:      shards,
+	}))
+	require.NoError(t, w.Close())
+}
+
+func writeTestCommitLog(t *testing.T, fsOpts fs.Options, writes map[string][]string) {
+	commitLog, err := commitlog.NewCommitLog(commitlog.NewOptions().
+		SetFilesystemOptions(fsOpts))
+	require.NoError(t, err)
+	require.NoError(t, commitLog.Open())
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	var uniqueIndex uint64
+	for namespace, ids := range writes {
+		for _, id := range ids {
+			series := ts.Series{
+				UniqueIndex: uniqueIndex,
+				Namespace:   ident.StringID(namespace),
+				ID:          ident.StringID(id),
+				// The shard is deliberately invalid to check restores
+				// assign shards from the target placement.
+				Shard: 1000,
+			}
+			uniqueIndex++
+			require.NoError(t, commitLog.Write(ctx, series, ts.Datapoint{
+				TimestampNanos: testBlockStart.Add(time.Minute),
+				Value:          42,
+			}, xtime.Second, nil))
+		}
+	}
+	require.NoError(t, commitLog.Close())
+}
+
+// readTestFileSets returns the series IDs in the latest volumes of the
+// filesets of each shard.
+func readTestFileSets(
+	t *testing.T,
+	fsOpts fs.Options,
+	fileSetType persist.FileSetType,
+	numShards uint32,
+) map[uint32][]string {
+	results := make(map[uint32][]string)
+	for shard := uint32(0); shard < numShards; shard++ {
+		var (
+			files fs.FileSetFilesSlice
+			err   error
+		)
+		if fileSetType == persist.FileSetSnapshotType {
+			files, err = fs.SnapshotFiles(fsOpts.FilePathPrefix(), testNamespaceID, shard)
+		} else {
+			files, err = fs.DataFiles(fsOpts.FilePathPrefix(), testNamespaceID, shard)
+		}
+		require.NoError(t, err)
+
+		for _, file := range files.LatestVolumes() {
+			r, err := fs.NewReader(nil, fsOpts)
+			require.NoError(t, err)
+			require.NoError(t, r.Open(fs.DataReaderOpenOptions{
+				Identifier:  file.ID,
+				FileSetType: fileSetType,
+			}))
+			for {
+				id, tags, data, _, err := r.Read()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				require.True(t, tags.Next())
+				assert.Equal(t, id.String(), tags.Current().Value.String())
+				data.IncRef()
+				assert.Equal(t, "data-"+id.String(), string(data.Bytes()))
+				data.DecRef()
+				results[shard] = append(results[shard], id.String())
+			}
+			require.NoError(t, r.Close())
+		}
+		sort.Strings(results[shard])
+	}
+	return results
+}
+
+// readTestCommitLogs returns the series IDs of the commit log entries of
+// the test namespace by shard.
+func readTestCommitLogs(t *testing.T, fsOpts fs.Options) map[uint32][]string {
+	iter, _, err := commitlog.NewIterator(commitlog.IteratorOpts{
+		CommitLogOptions:    commitlog.NewOptions().SetFilesystemOptions(fsOpts),
+		FileFilterPredicate: commitlog.ReadAllPredicate(),
+	})
+	require.NoError(t, err)
+	defer iter.Close()
+
+	results := make(map[uint32][]string)
+	for iter.Next() {
+		entry := iter.Current()
+		require.True(t, entry.Series.Namespace.Equal(testNamespaceID))
+		assert.Equal(t, 42.0, entry.Datapoint.Value)
+		results[entry.Series.Shard] = append(results[entry.Series.Shard], entry.Series.ID.String())
+	}
+	require.NoError(t, iter.Err())
+	for shard := range results {
+		sort.Strings(results[shard])
+	}
+	return results
+}
+
+func expectedByShard(ids []string, numShards uint32, owned func(uint32) bool) map[uint32][]string {
+	hashFn := sharding.DefaultHashFn(int(numShards))
+	results := make(map[uint32][]string)
+	for _, id := range ids {
+		shard := hashFn(ident.StringID(id))
+		if owned(shard) {
+			results[shard] = append(results[shard], id)
+		}
+	}
+	for shard := range results {
+		sort.Strings(results[shard])
+	}
+	return results
+}
+
+func allShards(uint32) bool { return true }
+
+// tierTestFileSets moves the tierable files of the data filesets to the
+// object store the way tiering does, returning the keys of the files moved.
+func tierTestFileSets(t *testing.T, fsOpts fs.Options, store tiering.ObjectStore) []string {
+	var keys []string
+	for shard := uint32(0); shard < testNumShards; shard++ {
+		files, err := fs.DataFiles(fsOpts.FilePathPrefix(), testNamespaceID, shard)
+		require.NoError(t, err)
+		for _, file := range files {
+			for _, filePath := range file.AbsoluteFilePaths {
+				if !fs.IsTierableFileSetFile(filePath) {
+					continue
+				}
+				rel, err := filepath.Rel(fsOpts.FilePathPrefix(), filePath)
+				require.NoError(t, err)
+				key := filepath.ToSlash(rel)
+
+				f, err := os.Open(filePath)
+				require.NoError(t, err)
+				info, err := f.Stat()
+				require.NoError(t, err)
+				require.NoError(t, store.Put(key, f, info.Size()))
+				require.NoError(t, f.Close())
+				require.NoError(t, os.Remove(filePath))
+				keys = append(keys, key)
+			}
+		}
+	}
+	require.NotEmpty(t, keys)
+	return keys
+}
+
+func writeTestNode(t *testing.T, fsOpts fs.Options) {
+	writeTestFileSets(t, fsOpts, persist.FileSetFlushType, testBlockStart, 0, nil, testSeriesIDs)
+	writeTestIndexFileSet(t, fsOpts, testBlockStart)
+
+	// Only the snapshot taken by the latest snapshot should be backed up.
+	var (
+		snapshotBlock = testBlockStart.Add(testBlockSize)
+		staleID       = uuid.NewRandom()
+		latestID      = uuid.NewRandom()
+	)
+	writeTestFileSets(t, fsOpts, persist.FileSetSnapshotType, snapshotBlock.Add(-testBlockSize),
+		0, staleID, testSeriesIDs[:2])
+	writeTestFileSets(t, fsOpts, persist.FileSetSnapshotType, snapshotBlock, 0, latestID, testSeriesIDs[2:])
+	require.NoError(t, fs.NewSnapshotMetadataWriter(fsOpts).Write(fs.SnapshotMetadataWriteArgs{
+		ID: fs.SnapshotMetadataIdentifier{Index: 0, UUID: latestID},
+		CommitlogIdentifier: persist.CommitLogFile{
+			FilePath: fs.CommitLogFilePath(fsOpts.FilePathPrefix(), 0),
+			Index:    0,
+		},
+	}))
+
+	writeTestCommitLog(t, fsOpts, map[string][]string{
+		testNamespaceID.String(): testSeriesIDs,
+		"otherns":                {"other"},
+	})
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.close()
+
+	srcOpts := setup.fsOpts("src")
+	writeTestNode(t, srcOpts)
+
+	manifest, err := setup.newManager(t, "src").Backup(testNamespaceID, testNumShards)
+	require.NoError(t, err)
+	assert.Equal(t, testNamespaceID.String(), manifest.Namespace)
+	assert.Equal(t, uint32(testNumShards), manifest.NumShards)
+	assert.NotEmpty(t, manifest.CommitLogs)
+
+	var snapshotBlocks []xtime.UnixNano
+	numIndexFileSets := 0
+	for _, fileSet := range manifest.FileSets {
+		switch fileSet.Type {
+		case SnapshotFileSetType:
+			snapshotBlocks = append(snapshotBlocks, fileSet.BlockStart)
+		case IndexFileSetType:
+			numIndexFileSets++
+		}
+	}
+	assert.Equal(t, 1, numIndexFileSets)
+	require.NotEmpty(t, snapshotBlocks)
+	for _, blockStart := range snapshotBlocks {
+		assert.Equal(t, testBlockStart.Add(testBlockSize), blockStart)
+	}
+
+	// The staging directory is removed once the backup completes.
+	_, err = os.Stat(filepath.Join(srcOpts.FilePathPrefix(), stagingDirName))
+	require.NoError(t, err)
+	entries, err := ioutil.ReadDir(filepath.Join(srcOpts.FilePathPrefix(), stagingDirName))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	dstOpts := setup.fsOpts("dst")
+	restored, err := setup.newManager(t, "dst").Restore(testNamespaceID, RestoreOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, manifest.ID, restored.ID)
+
+	assert.Equal(t,
+		readTestFileSets(t, srcOpts, persist.FileSetFlushType, testNumShards),
+		readTestFileSets(t, dstOpts, persist.FileSetFlushType, testNumShards))
+	assert.Equal(t,
+		expectedByShard(testSeriesIDs[2:], testNumShards, allShards),
+		readTestFileSets(t, dstOpts, persist.FileSetSnapshotType, testNumShards))
+
+	indexFiles, err := fs.IndexFiles(dstOpts.FilePathPrefix(), testNamespaceID)
+	require.NoError(t, err)
+	require.Len(t, indexFiles, 1)
+	assert.True(t, indexFiles[0].HasCompleteCheckpointFile())
+
+	assert.Equal(t,
+		expectedByShard(testSeriesIDs, testNumShards, allShards),
+		readTestCommitLogs(t, dstOpts))
+}
+
+func TestBackupIncremental(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.close()
+
+	srcOpts := setup.fsOpts("src")
+	writeTestNode(t, srcOpts)
+
+	mgr := setup.newManager(t, "src")
+	first, err := mgr.Backup(testNamespaceID, testNumShards)
+	require.NoError(t, err)
+	firstPuts := setup.store.reset()
+
+	// A new volume of an existing block and a new block.
+	nextBlock := testBlockStart.Add(-testBlockSize)
+	writeTestFileSets(t, srcOpts, persist.FileSetFlushType, testBlockStart, 1, nil, testSeriesIDs)
+	writeTestFileSets(t, srcOpts, persist.FileSetFlushType, nextBlock, 0, nil, testSeriesIDs[:1])
+
+	setup.now = setup.now.Add(time.Minute)
+	second, err := mgr.Backup(testNamespaceID, testNumShards)
+	require.NoError(t, err)
+	require.NotEqual(t, first.ID, second.ID)
+	secondPuts := setup.store.reset()
+	require.True(t, len(secondPuts) < len(firstPuts))
+
+	newVolumeKeys := make(map[string]struct{})
+	for _, fileSet := range second.FileSets {
+		isNewVolume := fileSet.Type == DataFileSetType &&
+			(fileSet.BlockStart == nextBlock || fileSet.VolumeIndex == 1)
+		if !isNewVolume {
+			continue
+		}
+		for _, file := range fileSet.Files {
+			newVolumeKeys[file.Key] = struct{}{}
+		}
+	}
+	require.NotEmpty(t, newVolumeKeys)
+	for _, key := range secondPuts {
+		if !strings.HasPrefix(key, volumesKeyPrefix) {
+			// Commit logs and the manifest are uploaded with every backup.
+			assert.True(t, strings.HasPrefix(key, backupsKeyPrefix+"testns/"+second.ID+"/"), key)
+			continue
+		}
+		assert.Contains(t, newVolumeKeys, key)
+	}
+
+	// Only the latest volume of each block is in the backup.
+	for _, fileSet := range second.FileSets {
+		if fileSet.Type == DataFileSetType && fileSet.BlockStart == testBlockStart {
+			assert.Equal(t, 1, fileSet.VolumeIndex)
+		}
+	}
+
+	ids, err := mgr.Backups(testNamespaceID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.ID, second.ID}, ids)
+
+	// Restoring an older backup restores its volumes.
+	dstOpts := setup.fsOpts("dst")
+	_, err = setup.newManager(t, "dst").Restore(testNamespaceID, RestoreOptions{BackupID: first.ID})
+	require.NoError(t, err)
+	dataFiles, err := fs.DataFiles(dstOpts.FilePathPrefix(), testNamespaceID,
+		sharding.DefaultHashFn(testNumShards)(ident.StringID(testSeriesIDs[0])))
+	require.NoError(t, err)
+	require.Len(t, dataFiles, 1)
+	assert.Equal(t, 0, dataFiles[0].ID.VolumeIndex)
+}
+
+func TestBackupTieredFileSets(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.close()
+
+	srcOpts := setup.fsOpts("src")
+	writeTestNode(t, srcOpts)
+	tieredStore := tiering.NewDirectoryObjectStore(filepath.Join(setup.dir, "tiered"))
+	setup.tieredStore = tieredStore
+	tieredKeys := tierTestFileSets(t, srcOpts, tieredStore)
+
+	manifest, err := setup.newManager(t, "src").Backup(testNamespaceID, testNumShards)
+	require.NoError(t, err)
+
+	// Tiered files are copied to the backup without paging them in.
+	for _, key := range tieredKeys {
+		_, err := os.Stat(filepath.Join(srcOpts.FilePathPrefix(), filepath.FromSlash(key)))
+		assert.True(t, os.IsNotExist(err), key)
+	}
+	backedUp := make(map[string]struct{})
+	for _, fileSet := range manifest.FileSets {
+		for _, file := range fileSet.Files {
+			backedUp[file.Path] = struct{}{}
+		}
+	}
+	for _, key := range tieredKeys {
+		assert.Contains(t, backedUp, key)
+	}
+
+	dstOpts := setup.fsOpts("dst")
+	_, err = setup.newManager(t, "dst").Restore(testNamespaceID, RestoreOptions{})
+	require.NoError(t, err)
+	assert.Equal(t,
+		expectedByShard(testSeriesIDs, testNumShards, allShards),
+		readTestFileSets(t, dstOpts, persist.FileSetFlushType, testNumShards))
+}
+
+func TestBackupFileSetReplacedWhileStaging(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.close()
+
+	srcOpts := setup.fsOpts("src")
+	writeTestNode(t, srcOpts)
+	tieredStore := &hookStore{
+		ObjectStore: tiering.NewDirectoryObjectStore(filepath.Join(setup.dir, "tiered")),
+	}
+	setup.tieredStore = tieredStore
+	tierTestFileSets(t, srcOpts, tieredStore)
+
+	// Replace the volume of a shard while the first backup attempt stages,
+	// the way a cold flush followed by cleanup would.
+	shard := sharding.DefaultHashFn(testNumShards)(ident.StringID(testSeriesIDs[0]))
+	replaced := false
+	tieredStore.onGet = func(string) {
+		if replaced {
+			return
+		}
+		replaced = true
+		writeTestFileSets(t, srcOpts, persist.FileSetFlushType, testBlockStart, 1, nil, testSeriesIDs)
+		files, err := fs.DataFiles(srcOpts.FilePathPrefix(), testNamespaceID, shard)
+		require.NoError(t, err)
+		for _, file := range files {
+			if file.ID.VolumeIndex != 0 {
+				continue
+			}
+			for _, filePath := range file.AbsoluteFilePaths {
+				require.NoError(t, os.Remove(filePath))
+			}
+		}
+	}
+
+	manifest, err := setup.newManager(t, "src").Backup(testNamespaceID, testNumShards)
+	require.NoError(t, err)
+	require.True(t, replaced)
+
+	for _, fileSet := range manifest.FileSets {
+		if fileSet.Type == DataFileSetType && fileSet.BlockStart == testBlockStart {
+			assert.Equal(t, 1, fileSet.VolumeIndex)
+		}
+	}
+
+	dstOpts := setup.fsOpts("dst")
+	_, err = setup.newManager(t, "dst").Restore(testNamespaceID, RestoreOptions{})
+	require.NoError(t, err)
+	assert.Equal(t,
+		expectedByShard(testSeriesIDs, testNumShards, allShards),
+		readTestFileSets(t, dstOpts, persist.FileSetFlushType, testNumShards))
+}
+
+func TestRestoreReshard(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.close()
+
+	srcOpts := setup.fsOpts("src")
+	writeTestNode(t, srcOpts)
+	_, err := setup.newManager(t, "src").Backup(testNamespaceID, testNumShards)
+	require.NoError(t, err)
+
+	// Restore half of the shards of a placement with twice as many shards.
+	const numShards = 2 * testNumShards
+	var (
+		shards []uint32
+		owned  = func(shard uint32) bool { return shard%2 == 0 }
+	)
+	for shard := uint32(0); shard < numShards; shard++ {
+		if owned(shard) {
+			shards = append(shards, shard)
+		}
+	}
+
+	dstOpts := setup.fsOpts("dst")
+	_, err = setup.newManager(t, "dst").Restore(testNamespaceID, RestoreOptions{
+		NumShards: numShards,
+		Shards:    shards,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		expectedByShard(testSeriesIDs, numShards, owned),
+		readTestFileSets(t, dstOpts, persist.FileSetFlushType, numShards))
+	assert.Equal(t,
+		expectedByShard(testSeriesIDs[2:], numShards, owned),
+		readTestFileSets(t, dstOpts, persist.FileSetSnapshotType, numShards))
+	assert.Equal(t,
+		expectedByShard(testSeriesIDs, numShards, owned),
+		readTestCommitLogs(t, dstOpts))
+
+	// The index is rebuilt from the data filesets when bootstrapping.
+	indexFiles, err := fs.IndexFiles(dstOpts.FilePathPrefix(), testNamespaceID)
+	require.NoError(t, err)
+	assert.Empty(t, indexFiles)
+}
+
+func TestRestoreErrors(t *testing.T) {
+	setup := newTestSetup(t)
+	defer setup.close()
+
+	mgr := setup.newManager(t, "src")
+	_, err := mgr.Restore(testNamespaceID, RestoreOptions{})
+	require.Equal(t, ErrNoBackups, err)
+
+	_, err = mgr.Backup(testNamespaceID, 0)
+	require.Equal(t, errNumShardsNotSet, err)
+
+	writeTestNode(t, setup.fsOpts("src"))
+	_, err = mgr.Backup(testNamespaceID, testNumShards)
+	require.NoError(t, err)
+
+	_, err = mgr.Restore(testNamespaceID, RestoreOptions{})
+	require.Equal(t, errRestoreTargetNotEmpty, err)
+
+	_, err = setup.newManager(t, "dst").Restore(testNamespaceID, RestoreOptions{
+		Shards: []uint32{testNumShards},
+	})
+	require.Error(t, err)
+}
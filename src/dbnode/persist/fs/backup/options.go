@@ -0,0 +1,128 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"errors"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tiering"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+var (
+	errObjectStoreNotSet       = errors.New("object store is not set")
+	errFilesystemOptionsNotSet = errors.New("filesystem options are not set")
+	errCommitLogOptionsNotSet  = errors.New("commit log options are not set")
+)
+
+type options struct {
+	objectStore    tiering.ObjectStore
+	tieredStore    tiering.ObjectStore
+	fsOpts         fs.Options
+	commitLogOpts  commitlog.Options
+	clockOpts      clock.Options
+	instrumentOpts instrument.Options
+}
+
+// NewOptions creates a new set of backup options.
+func NewOptions() Options {
+	return &options{
+		commitLogOpts:  commitlog.NewOptions(),
+		clockOpts:      clock.NewOptions(),
+		instrumentOpts: instrument.NewOptions(),
+	}
+}
+
+func (o *options) Validate() error {
+	if o.objectStore == nil {
+		return errObjectStoreNotSet
+	}
+	if o.fsOpts == nil {
+		return errFilesystemOptionsNotSet
+	}
+	if o.commitLogOpts == nil {
+		return errCommitLogOptionsNotSet
+	}
+	return nil
+}
+
+func (o *options) SetObjectStore(value tiering.ObjectStore) Options {
+	opts := *o
+	opts.objectStore = value
+	return &opts
+}
+
+func (o *options) ObjectStore() tiering.ObjectStore {
+	return o.objectStore
+}
+
+func (o *options) SetTieredObjectStore(value tiering.ObjectStore) Options {
+	opts := *o
+	opts.tieredStore = value
+	return &opts
+}
+
+func (o *options) TieredObjectStore() tiering.ObjectStore {
+	return o.tieredStore
+}
+
+func (o *options) SetFilesystemOptions(value fs.Options) Options {
+	opts := *o
+	opts.fsOpts = value
+	return &opts
+}
+
+func (o *options) FilesystemOptions() fs.Options {
+	return o.fsOpts
+}
+
+func (o *options) SetCommitLogOptions(value commitlog.Options) Options {
+	opts := *o
+	opts.commitLogOpts = value
+	return &opts
+}
+
+func (o *options) CommitLogOptions() commitlog.Options {
+	return o.commitLogOpts
+}
+
+func (o *options) SetClockOptions(value clock.Options) Options {
+	opts := *o
+	opts.clockOpts = value
+	return &opts
+}
+
+func (o *options) ClockOptions() clock.Options {
+	return o.clockOpts
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
@@ -0,0 +1,471 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// commitLogQueueFullBackoff is how long to wait before retrying a write
+	// to the restored commit log when its queue is full.
+	commitLogQueueFullBackoff = time.Millisecond
+)
+
+var errRestoreTargetNotEmpty = errors.New(
+	"restore target already has filesets for the namespace")
+
+type reshardKey struct {
+	fileSetType FileSetType
+	blockStart  xtime.UnixNano
+}
+
+func (m *manager) Restore(namespace ident.ID, opts RestoreOptions) (Manifest, error) {
+	manifest, err := m.restore(namespace, opts)
+	if err != nil {
+		m.metrics.restoreErrors.Inc(1)
+		return Manifest{}, err
+	}
+	m.metrics.restores.Inc(1)
+	return manifest, nil
+}
+
+func (m *manager) restore(namespace ident.ID, opts RestoreOptions) (Manifest, error) {
+	id := opts.BackupID
+	if id == "" {
+		ids, err := m.Backups(namespace)
+		if err != nil {
+			return Manifest{}, err
+		}
+		if len(ids) == 0 {
+			return Manifest{}, ErrNoBackups
+		}
+		id = ids[len(ids)-1]
+	}
+
+	manifest, err := m.readManifest(namespace.String(), id)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	numShards := opts.NumShards
+	if numShards == 0 {
+		numShards = manifest.NumShards
+	}
+	shards := opts.Shards
+	if len(shards) == 0 {
+		shards = make([]uint32, 0, numShards)
+		for shard := uint32(0); shard < numShards; shard++ {
+			shards = append(shards, shard)
+		}
+	}
+	owned := make(map[uint32]struct{}, len(shards))
+	for _, shard := range shards {
+		if shard >= numShards {
+			return Manifest{}, fmt.Errorf(
+				"shard %d is out of range for %d shards", shard, numShards)
+		}
+		owned[shard] = struct{}{}
+	}
+
+	if err := m.checkRestoreTargetEmpty(namespace, shards); err != nil {
+		return Manifest{}, err
+	}
+
+	stagingDir := filepath.Join(m.filePathPrefix, stagingDirName, "restore-"+manifest.ID)
+	if err := os.MkdirAll(stagingDir, stagingDirMode); err != nil {
+		return Manifest{}, err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	var (
+		reshard        = numShards != manifest.NumShards
+		restoreIndex   = !reshard && sameShards(manifest.Shards, shards)
+		reshardFileSet []ManifestFileSet
+	)
+	for _, fileSet := range manifest.FileSets {
+		switch {
+		case fileSet.Type == IndexFileSetType:
+			// Index filesets cover every shard of the node that wrote them so
+			// are rebuilt from the data filesets unless the shards match.
+			if !restoreIndex {
+				continue
+			}
+		case reshard:
+			reshardFileSet = append(reshardFileSet, fileSet)
+			continue
+		default:
+			if _, ok := owned[fileSet.Shard]; !ok {
+				continue
+			}
+		}
+		if err := m.downloadFileSet(fileSet, m.filePathPrefix); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	hashFn := sharding.DefaultHashFn(int(numShards))
+	if err := m.reshardFileSets(
+		manifest, reshardFileSet, stagingDir, hashFn, owned); err != nil {
+		return Manifest{}, err
+	}
+	if err := m.restoreCommitLogs(manifest, stagingDir, hashFn, owned); err != nil {
+		return Manifest{}, err
+	}
+
+	m.logger.Info("restored namespace",
+		zap.String("namespace", manifest.Namespace),
+		zap.String("backupID", manifest.ID),
+		zap.Uint32("numShards", numShards),
+		zap.Int("shards", len(shards)),
+		zap.Bool("resharded", reshard),
+		zap.Bool("indexRestored", restoreIndex))
+	return manifest, nil
+}
+
+func (m *manager) checkRestoreTargetEmpty(namespace ident.ID, shards []uint32) error {
+	for _, shard := range shards {
+		dataFiles, err := fs.DataFiles(m.filePathPrefix, namespace, shard)
+		if err != nil {
+			return err
+		}
+		snapshotFiles, err := fs.SnapshotFiles(m.filePathPrefix, namespace, shard)
+		if err != nil {
+			return err
+		}
+		if len(dataFiles) > 0 || len(snapshotFiles) > 0 {
+			return errRestoreTargetNotEmpty
+		}
+	}
+	indexFiles, err := fs.IndexFiles(m.filePathPrefix, namespace)
+	if err != nil {
+		return err
+	}
+	if len(indexFiles) > 0 {
+		return errRestoreTargetNotEmpty
+	}
+	return nil
+}
+
+// downloadFileSet downloads the files of a fileset under the file path
+// prefix, downloading the checkpoint file last so that the fileset is only
+// complete once all of its files have been downloaded.
+func (m *manager) downloadFileSet(fileSet ManifestFileSet, filePathPrefix string) error {
+	files := append([]ManifestFile(nil), fileSet.Files...)
+	sort.SliceStable(files, func(i, j int) bool {
+		return !isCheckpointFile(files[i].Path) && isCheckpointFile(files[j].Path)
+	})
+	for _, file := range files {
+		filePath := filepath.Join(filePathPrefix, filepath.FromSlash(file.Path))
+		if err := m.download(file.Key, filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reshardFileSets rewrites the data and snapshot filesets of a backup into
+// the shards of the target placement that the node owns.
+func (m *manager) reshardFileSets(
+	manifest Manifest,
+	fileSets []ManifestFileSet,
+	stagingDir string,
+	hashFn sharding.HashFn,
+	owned map[uint32]struct{},
+) error {
+	var (
+		keys   []reshardKey
+		groups = make(map[reshardKey][]ManifestFileSet)
+	)
+	for _, fileSet := range fileSets {
+		key := reshardKey{fileSetType: fileSet.Type, blockStart: fileSet.BlockStart}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], fileSet)
+	}
+
+	for _, key := range keys {
+		if err := m.reshardBlock(
+			manifest, key, groups[key], stagingDir, hashFn, owned); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *manager) reshardBlock(
+	manifest Manifest,
+	key reshardKey,
+	fileSets []ManifestFileSet,
+	stagingDir string,
+	hashFn sharding.HashFn,
+	owned map[uint32]struct{},
+) error {
+	var (
+		namespace   = ident.StringID(manifest.Namespace)
+		stagingOpts = m.fsOpts.SetFilePathPrefix(stagingDir)
+		fileSetType = persist.FileSetFlushType
+		writers     = make(map[uint32]fs.DataFileSetWriter)
+	)
+	if key.fileSetType == SnapshotFileSetType {
+		fileSetType = persist.FileSetSnapshotType
+	}
+
+	reader, err := fs.NewReader(nil, stagingOpts)
+	if err != nil {
+		return err
+	}
+
+	for _, fileSet := range fileSets {
+		if err := m.downloadFileSet(fileSet, stagingDir); err != nil {
+			return err
+		}
+
+		id := fs.FileSetFileIdentifier{
+			Namespace:   namespace,
+			Shard:       fileSet.Shard,
+			BlockStart:  fileSet.BlockStart,
+			VolumeIndex: fileSet.VolumeIndex,
+		}
+		var snapshotOpts fs.DataWriterSnapshotOptions
+		if fileSetType == persist.FileSetSnapshotType {
+			snapshotTime, snapshotID, err := fs.SnapshotTimeAndID(stagingDir, id)
+			if err != nil {
+				return err
+			}
+			snapshotOpts = fs.DataWriterSnapshotOptions{
+				SnapshotTime: snapshotTime,
+				SnapshotID:   snapshotID,
+			}
+		}
+
+		if err := reader.Open(fs.DataReaderOpenOptions{
+			Identifier:  id,
+			FileSetType: fileSetType,
+		}); err != nil {
+			return fmt.Errorf("could not open backed up fileset %v: %w", id, err)
+		}
+
+		for {
+			seriesID, tags, data, checksum, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				reader.Close()
+				return err
+			}
+
+			shard := hashFn(seriesID)
+			if _, ok := owned[shard]; !ok {
+				continue
+			}
+
+			writer, ok := writers[shard]
+			if !ok {
+				writer, err = fs.NewWriter(m.fsOpts)
+				if err != nil {
+					reader.Close()
+					return err
+				}
+				if err := writer.Open(fs.DataWriterOpenOptions{
+					FileSetType: fileSetType,
+					Identifier: fs.FileSetFileIdentifier{
+						Namespace:  namespace,
+						Shard:      shard,
+						BlockStart: key.blockStart,
+					},
+					BlockSize: reader.Status().BlockSize,
+					Snapshot:  snapshotOpts,
+				}); err != nil {
+					reader.Close()
+					return err
+				}
+				writers[shard] = writer
+			}
+
+			metadata := persist.NewMetadataFromIDAndTagIterator(seriesID, tags,
+				persist.MetadataOptions{})
+			data.IncRef()
+			err = writer.Write(metadata, data, checksum)
+			data.DecRef()
+			if err != nil {
+				reader.Close()
+				return err
+			}
+			m.metrics.seriesResharded.Inc(1)
+		}
+
+		if err := reader.Close(); err != nil {
+			return err
+		}
+		for _, file := range fileSet.Files {
+			os.Remove(filepath.Join(stagingDir, filepath.FromSlash(file.Path)))
+		}
+	}
+
+	// Closing the writers writes their checkpoint files, so only close them
+	// once every fileset of the block has been resharded.
+	for _, writer := range writers {
+		if err := writer.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreCommitLogs rewrites the entries of the backed up commit logs that
+// belong to the namespace and the owned shards into a new commit log, so
+// that they are bootstrapped along with the restored snapshots.
+func (m *manager) restoreCommitLogs(
+	manifest Manifest,
+	stagingDir string,
+	hashFn sharding.HashFn,
+	owned map[uint32]struct{},
+) error {
+	if len(manifest.CommitLogs) == 0 {
+		return nil
+	}
+
+	sourcePrefix := filepath.Join(stagingDir, commitLogsDir)
+	for _, file := range manifest.CommitLogs {
+		filePath := filepath.Join(fs.CommitLogsDirPath(sourcePrefix), filepath.Base(file.Path))
+		if err := m.download(file.Key, filePath); err != nil {
+			return err
+		}
+	}
+
+	iter, corruptFiles, err := commitlog.NewIterator(commitlog.IteratorOpts{
+		CommitLogOptions: m.commitLogOpts.SetFilesystemOptions(
+			m.fsOpts.SetFilePathPrefix(sourcePrefix)),
+		FileFilterPredicate: commitlog.ReadAllPredicate(),
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	for _, corruptFile := range corruptFiles {
+		m.logger.Warn("skipping corrupt backed up commit log",
+			zap.String("path", corruptFile.Path()), zap.Error(corruptFile))
+	}
+
+	commitLog, err := commitlog.NewCommitLog(
+		m.commitLogOpts.SetFilesystemOptions(m.fsOpts))
+	if err != nil {
+		return err
+	}
+	if err := commitLog.Open(); err != nil {
+		return err
+	}
+
+	var (
+		ctx           = context.NewBackground()
+		namespace     = ident.StringID(manifest.Namespace)
+		uniqueIndexes = make(map[string]uint64)
+	)
+	defer ctx.Close()
+
+	for iter.Next() {
+		entry := iter.Current()
+		if !entry.Series.Namespace.Equal(namespace) {
+			continue
+		}
+		shard := hashFn(entry.Series.ID)
+		if _, ok := owned[shard]; !ok {
+			continue
+		}
+
+		id := append([]byte(nil), entry.Series.ID.Bytes()...)
+		uniqueIndex, ok := uniqueIndexes[string(id)]
+		if !ok {
+			uniqueIndex = uint64(len(uniqueIndexes))
+			uniqueIndexes[string(id)] = uniqueIndex
+		}
+		series := ts.Series{
+			UniqueIndex: uniqueIndex,
+			Namespace:   namespace,
+			ID:          ident.BytesID(id),
+			EncodedTags: append(ts.EncodedTags(nil), entry.Series.EncodedTags...),
+			Shard:       shard,
+		}
+		annotation := append(ts.Annotation(nil), entry.Annotation...)
+
+		for {
+			err = commitLog.Write(ctx, series, entry.Datapoint, entry.Unit, annotation)
+			if err != commitlog.ErrCommitLogQueueFull {
+				break
+			}
+			time.Sleep(commitLogQueueFullBackoff)
+		}
+		if err != nil {
+			commitLog.Close()
+			return err
+		}
+		m.metrics.commitLogEntries.Inc(1)
+	}
+	if err := iter.Err(); err != nil {
+		// The newest commit log may have been staged mid write and so end
+		// with a partial chunk.
+		m.logger.Warn("stopped reading backed up commit logs", zap.Error(err))
+	}
+
+	return commitLog.Close()
+}
+
+func sameShards(a, b []uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[uint32]struct{}, len(a))
+	for _, shard := range a {
+		set[shard] = struct{}{}
+	}
+	for _, shard := range b {
+		if _, ok := set[shard]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func isCheckpointFile(filePath string) bool {
+	return strings.Contains(path.Base(filePath), "-"+fs.CheckpointFileSuffix+".")
+}
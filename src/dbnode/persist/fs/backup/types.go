@@ -0,0 +1,160 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package backup takes point-in-time backups of namespaces to an object
+// store and restores them, optionally onto a different shard layout.
+package backup
+
+import (
+	"errors"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tiering"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// ErrNoBackups is returned when restoring the latest backup of a namespace
+// that has never been backed up.
+var ErrNoBackups = errors.New("no backups found for namespace")
+
+// FileSetType is the type of a backed up fileset.
+type FileSetType string
+
+const (
+	// DataFileSetType is the type of data filesets.
+	DataFileSetType FileSetType = "data"
+	// IndexFileSetType is the type of index filesets.
+	IndexFileSetType FileSetType = "index"
+	// SnapshotFileSetType is the type of snapshot filesets.
+	SnapshotFileSetType FileSetType = "snapshot"
+)
+
+// Manifest describes a backup of a namespace, a backup is only complete
+// once its manifest has been written.
+type Manifest struct {
+	ID         string            `json:"id"`
+	Namespace  string            `json:"namespace"`
+	CreatedAt  xtime.UnixNano    `json:"createdAt"`
+	NumShards  uint32            `json:"numShards"`
+	Shards     []uint32          `json:"shards"`
+	FileSets   []ManifestFileSet `json:"fileSets"`
+	CommitLogs []ManifestFile    `json:"commitLogs"`
+}
+
+// ManifestFileSet is a fileset included in a backup.
+type ManifestFileSet struct {
+	Type        FileSetType    `json:"type"`
+	Shard       uint32         `json:"shard"`
+	BlockStart  xtime.UnixNano `json:"blockStart"`
+	VolumeIndex int            `json:"volumeIndex"`
+	Files       []ManifestFile `json:"files"`
+}
+
+// ManifestFile is a file included in a backup.
+type ManifestFile struct {
+	// Path is the path of the file relative to the file path prefix.
+	Path string `json:"path"`
+	// Key is the key of the object the file is stored in.
+	Key string `json:"key"`
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+}
+
+// RestoreOptions are the options for restoring a backup.
+type RestoreOptions struct {
+	// BackupID is the backup to restore, the latest backup is restored
+	// if empty.
+	BackupID string
+
+	// NumShards is the number of shards of the target placement, the number
+	// of shards of the backup is used if zero. Data is resharded if this
+	// differs from the number of shards of the backup.
+	NumShards uint32
+
+	// Shards are the shards owned by the node being restored, all shards
+	// are restored if empty.
+	Shards []uint32
+}
+
+// Manager takes and restores backups of namespaces.
+type Manager interface {
+	// Backup takes a consistent backup of all shards of a namespace on local
+	// disk, uploading only the volumes not already in the object store.
+	// Filesets are staged again if any were removed or replaced while being
+	// staged so that the backup reflects a single point in time.
+	Backup(namespace ident.ID, numShards uint32) (Manifest, error)
+
+	// Restore restores a backup of a namespace to local disk. Index filesets
+	// are only restored when the shard layout is unchanged, otherwise they
+	// are rebuilt from the restored data filesets when bootstrapping.
+	Restore(namespace ident.ID, opts RestoreOptions) (Manifest, error)
+
+	// Backups returns the IDs of the complete backups of a namespace from
+	// oldest to newest.
+	Backups(namespace ident.ID) ([]string, error)
+}
+
+// Options represents the options for backups.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetObjectStore sets the object store backups are stored in.
+	SetObjectStore(value tiering.ObjectStore) Options
+
+	// ObjectStore returns the object store backups are stored in.
+	ObjectStore() tiering.ObjectStore
+
+	// SetTieredObjectStore sets the object store filesets are tiered to, if
+	// any. Tiered fileset files are copied from it to the backup object
+	// store rather than paged back in to local disk.
+	SetTieredObjectStore(value tiering.ObjectStore) Options
+
+	// TieredObjectStore returns the object store filesets are tiered to.
+	TieredObjectStore() tiering.ObjectStore
+
+	// SetFilesystemOptions sets the filesystem options.
+	SetFilesystemOptions(value fs.Options) Options
+
+	// FilesystemOptions returns the filesystem options.
+	FilesystemOptions() fs.Options
+
+	// SetCommitLogOptions sets the commit log options.
+	SetCommitLogOptions(value commitlog.Options) Options
+
+	// CommitLogOptions returns the commit log options.
+	CommitLogOptions() commitlog.Options
+
+	// SetClockOptions sets the clock options.
+	SetClockOptions(value clock.Options) Options
+
+	// ClockOptions returns the clock options.
+	ClockOptions() clock.Options
+
+	// SetInstrumentOptions sets the instrumentation options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrumentation options.
+	InstrumentOptions() instrument.Options
+}
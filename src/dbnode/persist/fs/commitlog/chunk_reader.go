@@ -22,6 +22,7 @@ package commitlog
 
 import (
 	"bufio"
+	"errors"
 	"io"
 	"os"
 
@@ -37,12 +38,17 @@ const (
 	checksumDataEnd   = checksumDataStart + chunkHeaderChecksumDataLen
 )
 
+var errCommitLogReaderChunkCompressionMissing = errors.New(
+	"commit log reader encountered compressed chunk without compression")
+
 type chunkReader struct {
 	fd                 *os.File
 	buffer             *bufio.Reader
 	chunkData          []byte
 	chunkDataRemaining int
 	charBuff           []byte
+	compressedData     []byte
+	codecs             map[ChunkCompression]chunkCodec
 }
 
 func newChunkReader(bufferLen int) *chunkReader {
@@ -66,6 +72,8 @@ func (r *chunkReader) readHeader() error {
 	}
 
 	size := endianness.Uint32(header[sizeStart:sizeEnd])
+	compressed := size&chunkHeaderCompressedFlag != 0
+	size &^= chunkHeaderCompressedFlag
 	checksumSize := digest.
 		Buffer(header[checksumSizeStart:checksumSizeEnd]).
 		ReadDigest()
@@ -84,39 +92,91 @@ func (r *chunkReader) readHeader() error {
 	}
 
 	// Setup a chunk data buffer so that chunk data can be loaded into it.
-	chunkDataSize := int(size)
-	if chunkDataSize > cap(r.chunkData) {
-		// Increase chunkData capacity so that it can fit the new chunkData.
-		chunkDataCap := cap(r.chunkData)
-		for chunkDataCap < chunkDataSize {
-			chunkDataCap *= 2
-		}
-		r.chunkData = make([]byte, chunkDataSize, chunkDataCap)
+	// Compressed chunk data is loaded into a separate buffer and then
+	// decompressed into the chunk data buffer.
+	var (
+		chunkDataSize = int(size)
+		data          []byte
+	)
+	if compressed {
+		r.compressedData = resizeChunkBuffer(r.compressedData, chunkDataSize)
+		data = r.compressedData
 	} else {
-		// Reuse existing chunk data buffer if possible.
-		r.chunkData = r.chunkData[:chunkDataSize]
+		r.chunkData = resizeChunkBuffer(r.chunkData, chunkDataSize)
+		data = r.chunkData
 	}
 
 	// To validate checksum of chunk data all the chunk data needs to be loaded into memory at once. Chunk data size is // not bounded to the flush size so peeking chunk data in order to compute checksum may result in bufio's buffer
 	// full error. To circumnavigate this issue load the chunk data into chunk reader's buffer to compute checksum
 	// instead of trying to compute checksum off of fixed size r.buffer by peeking.
 	// See https://github.com/m3db/m3/pull/2148 for details.
-	_, err = io.ReadFull(r.buffer, r.chunkData)
+	_, err = io.ReadFull(r.buffer, data)
 	if err != nil {
 		return err
 	}
 
 	// Verify data checksum
-	if digest.Checksum(r.chunkData) != checksumData {
+	if digest.Checksum(data) != checksumData {
 		return errCommitLogReaderChunkSizeChecksumMismatch
 	}
 
+	if compressed {
+		if err := r.decompress(); err != nil {
+			return err
+		}
+	}
+
 	// Set remaining data to be consumed
-	r.chunkDataRemaining = int(size)
+	r.chunkDataRemaining = len(r.chunkData)
 
 	return nil
 }
 
+// decompress decompresses the compressed data of a chunk into the chunk
+// data buffer.
+func (r *chunkReader) decompress() error {
+	if len(r.compressedData) == 0 {
+		return errCommitLogReaderChunkCompressionMissing
+	}
+	compression := ChunkCompression(r.compressedData[0])
+	codec, ok := r.codecs[compression]
+	if !ok {
+		var err error
+		codec, err = newChunkCodec(compression)
+		if err != nil {
+			return err
+		}
+		if codec == nil {
+			return errCommitLogReaderChunkCompressionMissing
+		}
+		if r.codecs == nil {
+			r.codecs = make(map[ChunkCompression]chunkCodec)
+		}
+		r.codecs[compression] = codec
+	}
+
+	var err error
+	r.chunkData, err = codec.decode(r.chunkData[:0], r.compressedData[1:])
+	return err
+}
+
+// resizeChunkBuffer returns a buffer of the given size, reusing buf if it
+// has enough capacity.
+func resizeChunkBuffer(buf []byte, size int) []byte {
+	if size <= cap(buf) {
+		return buf[:size]
+	}
+	// Increase capacity so that it can fit the new chunk.
+	bufCap := cap(buf)
+	if bufCap == 0 {
+		bufCap = size
+	}
+	for bufCap < size {
+		bufCap *= 2
+	}
+	return make([]byte, size, bufCap)
+}
+
 func (r *chunkReader) Read(p []byte) (int, error) {
 	size := len(p)
 	read := 0
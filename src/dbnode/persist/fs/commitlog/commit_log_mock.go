@@ -321,6 +321,20 @@ func (mr *MockOptionsMockRecorder) BytesPool() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesPool", reflect.TypeOf((*MockOptions)(nil).BytesPool))
 }
 
+// ChunkCompression mocks base method.
+func (m *MockOptions) ChunkCompression() ChunkCompression {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChunkCompression")
+	ret0, _ := ret[0].(ChunkCompression)
+	return ret0
+}
+
+// ChunkCompression indicates an expected call of ChunkCompression.
+func (mr *MockOptionsMockRecorder) ChunkCompression() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChunkCompression", reflect.TypeOf((*MockOptions)(nil).ChunkCompression))
+}
+
 // ClockOptions mocks base method.
 func (m *MockOptions) ClockOptions() clock.Options {
 	m.ctrl.T.Helper()
@@ -503,6 +517,20 @@ func (mr *MockOptionsMockRecorder) SetBytesPool(value interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBytesPool", reflect.TypeOf((*MockOptions)(nil).SetBytesPool), value)
 }
 
+// SetChunkCompression mocks base method.
+func (m *MockOptions) SetChunkCompression(value ChunkCompression) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChunkCompression", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetChunkCompression indicates an expected call of SetChunkCompression.
+func (mr *MockOptionsMockRecorder) SetChunkCompression(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChunkCompression", reflect.TypeOf((*MockOptions)(nil).SetChunkCompression), value)
+}
+
 // SetClockOptions mocks base method.
 func (m *MockOptions) SetClockOptions(value clock.Options) Options {
 	m.ctrl.T.Helper()
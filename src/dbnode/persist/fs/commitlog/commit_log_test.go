@@ -138,7 +138,7 @@ type testWrite struct {
 }
 
 func testSeries(
-	t *testing.T,
+	t testing.TB,
 	opts Options,
 	uniqueIndex uint64,
 	id string,
@@ -0,0 +1,210 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package commitlog
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// ChunkCompression is the codec commitlog chunks are compressed with.
+type ChunkCompression byte
+
+const (
+	// NoChunkCompression writes chunks uncompressed.
+	NoChunkCompression ChunkCompression = iota
+	// SnappyChunkCompression compresses chunks with snappy.
+	SnappyChunkCompression
+	// ZstdChunkCompression compresses chunks with zstd.
+	ZstdChunkCompression
+)
+
+var errChunkCompressionUnspecified = errors.New("chunk compression unspecified")
+
+// ValidChunkCompressions returns the valid chunk compressions.
+func ValidChunkCompressions() []ChunkCompression {
+	return []ChunkCompression{
+		NoChunkCompression,
+		SnappyChunkCompression,
+		ZstdChunkCompression,
+	}
+}
+
+func (c ChunkCompression) String() string {
+	switch c {
+	case NoChunkCompression:
+		return "none"
+	case SnappyChunkCompression:
+		return "snappy"
+	case ZstdChunkCompression:
+		return "zstd"
+	}
+	return "unknown"
+}
+
+// ValidateChunkCompression validates a chunk compression.
+func ValidateChunkCompression(v ChunkCompression) error {
+	for _, valid := range ValidChunkCompressions() {
+		if valid == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid chunk compression '%d' valid types are: %v",
+		uint8(v), ValidChunkCompressions())
+}
+
+// ParseChunkCompression parses a chunk compression from a string.
+func ParseChunkCompression(str string) (ChunkCompression, error) {
+	var r ChunkCompression
+	if str == "" {
+		return r, errChunkCompressionUnspecified
+	}
+	for _, valid := range ValidChunkCompressions() {
+		if str == valid.String() {
+			return valid, nil
+		}
+	}
+	return r, fmt.Errorf("invalid chunk compression '%s' valid types are: %v",
+		str, ValidChunkCompressions())
+}
+
+// MarshalYAML returns the YAML representation of the ChunkCompression.
+func (c ChunkCompression) MarshalYAML() (interface{}, error) {
+	return c.String(), nil
+}
+
+// UnmarshalYAML unmarshals a ChunkCompression into a valid type from string.
+func (c *ChunkCompression) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	r, err := ParseChunkCompression(str)
+	if err != nil {
+		return err
+	}
+	*c = r
+	return nil
+}
+
+// chunkCodec compresses and decompresses the data of chunks. Codecs are not
+// safe for concurrent use, each writer and reader creates its own.
+type chunkCodec interface {
+	// encode appends the compressed src to dst.
+	encode(dst, src []byte) ([]byte, error)
+
+	// decode appends the decompressed src to dst.
+	decode(dst, src []byte) ([]byte, error)
+}
+
+// newChunkCodec returns the codec for a compression, or nil for chunks that
+// are not compressed.
+func newChunkCodec(c ChunkCompression) (chunkCodec, error) {
+	switch c {
+	case NoChunkCompression:
+		return nil, nil
+	case SnappyChunkCompression:
+		return snappyChunkCodec{}, nil
+	case ZstdChunkCompression:
+		return newZstdChunkCodec()
+	}
+	return nil, ValidateChunkCompression(c)
+}
+
+type snappyChunkCodec struct{}
+
+func (snappyChunkCodec) encode(dst, src []byte) ([]byte, error) {
+	n := len(dst)
+	dst = growBytes(dst, snappy.MaxEncodedLen(len(src)))
+	encoded := snappy.Encode(dst[n:cap(dst)], src)
+	return dst[:n+len(encoded)], nil
+}
+
+func (snappyChunkCodec) decode(dst, src []byte) ([]byte, error) {
+	size, err := snappy.DecodedLen(src)
+	if err != nil {
+		return nil, err
+	}
+	n := len(dst)
+	dst = growBytes(dst, size)
+	decoded, err := snappy.Decode(dst[n:cap(dst)], src)
+	if err != nil {
+		return nil, err
+	}
+	return dst[:n+len(decoded)], nil
+}
+
+var (
+	zstdDecoderOnce sync.Once
+	zstdDecoder     *zstd.Decoder
+	zstdDecoderErr  error
+)
+
+// sharedZstdDecoder returns a decoder shared by all zstd codecs, decoders
+// start goroutines that are only stopped on close and DecodeAll is safe for
+// concurrent use.
+func sharedZstdDecoder() (*zstd.Decoder, error) {
+	zstdDecoderOnce.Do(func() {
+		zstdDecoder, zstdDecoderErr = zstd.NewReader(nil)
+	})
+	return zstdDecoder, zstdDecoderErr
+}
+
+type zstdChunkCodec struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newZstdChunkCodec() (chunkCodec, error) {
+	encoder, err := zstd.NewWriter(nil,
+		zstd.WithEncoderConcurrency(1),
+		zstd.WithEncoderLevel(zstd.SpeedFastest))
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := sharedZstdDecoder()
+	if err != nil {
+		return nil, err
+	}
+	return &zstdChunkCodec{encoder: encoder, decoder: decoder}, nil
+}
+
+func (c *zstdChunkCodec) encode(dst, src []byte) ([]byte, error) {
+	return c.encoder.EncodeAll(src, dst), nil
+}
+
+func (c *zstdChunkCodec) decode(dst, src []byte) ([]byte, error) {
+	return c.decoder.DecodeAll(src, dst)
+}
+
+// growBytes returns b with capacity for at least n more bytes.
+func growBytes(b []byte, n int) []byte {
+	if cap(b)-len(b) >= n {
+		return b
+	}
+	grown := make([]byte, len(b), len(b)+n)
+	copy(grown, b)
+	return grown
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package commitlog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const benchmarkSeriesCount = 1000
+
+func newBenchmarkOptions(b *testing.B, compression ChunkCompression) Options {
+	dir, err := ioutil.TempDir("", "commitlog-benchmark")
+	require.NoError(b, err)
+
+	return NewOptions().
+		SetFilesystemOptions(fs.NewOptions().SetFilePathPrefix(dir)).
+		SetStrategy(StrategyWriteBehind).
+		SetFlushInterval(time.Second).
+		SetChunkCompression(compression)
+}
+
+func newBenchmarkSeries(b *testing.B, opts Options) []ts.Series {
+	series := make([]ts.Series, 0, benchmarkSeriesCount)
+	for i := 0; i < benchmarkSeriesCount; i++ {
+		tags := ident.NewTags(
+			ident.StringTag("__name__", "http_requests_total"),
+			ident.StringTag("service", fmt.Sprintf("service-%d", i%20)),
+			ident.StringTag("instance", fmt.Sprintf("instance-%d", i)))
+		id := fmt.Sprintf("http_requests_total{service=service-%d,instance=instance-%d}", i%20, i)
+		series = append(series, testSeries(b, opts, uint64(i), id, tags, uint32(i%64)))
+	}
+	return series
+}
+
+// writeBenchmarkCommitLog writes n datapoints round robin across the series
+// and returns the total size of the commit log files written.
+func writeBenchmarkCommitLog(b *testing.B, opts Options, series []ts.Series, n int) int64 {
+	commitLog, err := NewCommitLog(opts)
+	require.NoError(b, err)
+	require.NoError(b, commitLog.Open())
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	start := xtime.Now()
+	for i := 0; i < n; i++ {
+		dp := ts.Datapoint{
+			TimestampNanos: start.Add(time.Duration(i) * time.Second),
+			Value:          float64(i % 100),
+		}
+		require.NoError(b, commitLog.Write(ctx, series[i%len(series)], dp, xtime.Second, nil))
+	}
+	require.NoError(b, commitLog.Close())
+
+	files, err := fs.SortedCommitLogFiles(
+		fs.CommitLogsDirPath(opts.FilesystemOptions().FilePathPrefix()))
+	require.NoError(b, err)
+
+	var size int64
+	for _, file := range files {
+		info, err := os.Stat(file)
+		require.NoError(b, err)
+		size += info.Size()
+	}
+	return size
+}
+
+func BenchmarkCommitLogWrite(b *testing.B) {
+	for _, compression := range ValidChunkCompressions() {
+		b.Run(compression.String(), func(b *testing.B) {
+			opts := newBenchmarkOptions(b, compression)
+			defer os.RemoveAll(opts.FilesystemOptions().FilePathPrefix())
+			series := newBenchmarkSeries(b, opts)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			size := writeBenchmarkCommitLog(b, opts, series, b.N)
+			b.StopTimer()
+
+			b.ReportMetric(float64(size)/float64(b.N), "disk-bytes/op")
+		})
+	}
+}
+
+func BenchmarkCommitLogReplay(b *testing.B) {
+	const writes = 100000
+
+	for _, compression := range ValidChunkCompressions() {
+		b.Run(compression.String(), func(b *testing.B) {
+			opts := newBenchmarkOptions(b, compression)
+			defer os.RemoveAll(opts.FilesystemOptions().FilePathPrefix())
+			series := newBenchmarkSeries(b, opts)
+			size := writeBenchmarkCommitLog(b, opts, series, writes)
+
+			b.ReportAllocs()
+			b.SetBytes(size)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				iter, corruptFiles, err := NewIterator(IteratorOpts{
+					CommitLogOptions:    opts,
+					FileFilterPredicate: ReadAllPredicate(),
+				})
+				require.NoError(b, err)
+				require.Equal(b, 0, len(corruptFiles))
+
+				read := 0
+				for iter.Next() {
+					read++
+				}
+				require.NoError(b, iter.Err())
+				require.Equal(b, writes, read)
+				iter.Close()
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package commitlog
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestParseChunkCompression(t *testing.T) {
+	for _, c := range ValidChunkCompressions() {
+		parsed, err := ParseChunkCompression(c.String())
+		require.NoError(t, err)
+		require.Equal(t, c, parsed)
+	}
+
+	_, err := ParseChunkCompression("")
+	require.Error(t, err)
+	_, err = ParseChunkCompression("gzip")
+	require.Error(t, err)
+}
+
+func TestChunkCompressionUnmarshalYAML(t *testing.T) {
+	var cfg struct {
+		Compression ChunkCompression `yaml:"compression"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte("compression: zstd"), &cfg))
+	require.Equal(t, ZstdChunkCompression, cfg.Compression)
+
+	require.Error(t, yaml.Unmarshal([]byte("compression: lz4"), &cfg))
+}
+
+func TestOptionsValidateChunkCompression(t *testing.T) {
+	require.NoError(t, NewOptions().SetChunkCompression(SnappyChunkCompression).Validate())
+	require.Error(t, NewOptions().SetChunkCompression(ChunkCompression(42)).Validate())
+}
+
+func TestChunkWriterReaderCompressionRoundTrip(t *testing.T) {
+	var (
+		compressible   = bytes.Repeat([]byte("foo.bar.baz"), 1024)
+		incompressible = randomByteSlice(4096)
+		chunks         = [][]byte{compressible, incompressible, {1}, compressible}
+		expected       = bytes.Join(chunks, nil)
+	)
+
+	for _, compression := range ValidChunkCompressions() {
+		t.Run(compression.String(), func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "commitlog-compression")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "chunks")
+			fd, err := os.Create(path)
+			require.NoError(t, err)
+
+			writer := newChunkWriter(func(err error) {}, false, compression)
+			writer.reset(fd)
+			for _, chunk := range chunks {
+				n, err := writer.Write(chunk)
+				require.NoError(t, err)
+				require.Equal(t, len(chunk), n)
+			}
+			require.NoError(t, writer.close())
+
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			uncompressedSize := int64(len(expected) + len(chunks)*chunkHeaderLen)
+			if compression == NoChunkCompression {
+				require.Equal(t, uncompressedSize, info.Size())
+			} else {
+				require.True(t, info.Size() < uncompressedSize)
+			}
+
+			fd, err = os.Open(path)
+			require.NoError(t, err)
+			defer fd.Close()
+
+			reader := newChunkReader(16)
+			reader.reset(fd)
+			actual, err := ioutil.ReadAll(reader)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestChunkReaderUnknownCompression(t *testing.T) {
+	dir, err := ioutil.TempDir("", "commitlog-compression")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Build a compressed chunk with an unknown compression by hand.
+	var (
+		data   = []byte{42, 1, 2, 3}
+		header = make([]byte, chunkHeaderLen)
+	)
+	endianness.PutUint32(header[sizeStart:sizeEnd], uint32(len(data))|chunkHeaderCompressedFlag)
+	digest.Buffer(header[checksumSizeStart:checksumSizeEnd]).
+		WriteDigest(digest.Checksum(header[sizeStart:sizeEnd]))
+	digest.Buffer(header[checksumDataStart:checksumDataEnd]).
+		WriteDigest(digest.Checksum(data))
+
+	path := filepath.Join(dir, "chunks")
+	require.NoError(t, ioutil.WriteFile(path, append(header, data...), 0600))
+
+	fd, err := os.Open(path)
+	require.NoError(t, err)
+	defer fd.Close()
+
+	reader := newChunkReader(16)
+	reader.reset(fd)
+	_, err = io.ReadFull(reader, make([]byte, 1))
+	require.Error(t, err)
+}
+
+func TestCommitLogWriteCompressed(t *testing.T) {
+	for _, compression := range ValidChunkCompressions() {
+		t.Run(compression.String(), func(t *testing.T) {
+			opts, scope := newTestOptions(t, overrides{
+				strategy: StrategyWriteWait,
+			})
+			opts = opts.SetChunkCompression(compression)
+			defer cleanup(t, opts)
+
+			var writes []testWrite
+			for i := 0; i < 100; i++ {
+				writes = append(writes, testWrite{
+					testSeries(t, opts, uint64(i%10), "foo.bar"+string(rune('a'+i%10)),
+						ident.NewTags(ident.StringTag("name", "val")), uint32(i%10)),
+					xtime.Now(), float64(i), xtime.Second, bytes.Repeat([]byte{byte(i)}, 128), nil,
+				})
+			}
+
+			commitLog := newTestCommitLog(t, opts)
+			writeCommitLogs(t, scope, commitLog, writes).Wait()
+			require.NoError(t, commitLog.Close())
+
+			assertCommitLogWritesByIterating(t, commitLog, writes)
+		})
+	}
+}
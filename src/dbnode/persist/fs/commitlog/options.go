@@ -92,6 +92,7 @@ type options struct {
 	readConcurrency         int
 	failureMode             FailureStrategy
 	failureCallback         FailureCallback
+	chunkCompression        ChunkCompression
 }
 
 type optionsInput struct {
@@ -186,6 +187,10 @@ func (o *options) Validate() error {
 		return errMissingFailureCallback
 	}
 
+	if err := ValidateChunkCompression(o.ChunkCompression()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -328,3 +333,13 @@ func (o *options) SetFailureCallback(value FailureCallback) Options {
 func (o *options) FailureCallback() FailureCallback {
 	return o.failureCallback
 }
+
+func (o *options) SetChunkCompression(value ChunkCompression) Options {
+	opts := *o
+	opts.chunkCompression = value
+	return &opts
+}
+
+func (o *options) ChunkCompression() ChunkCompression {
+	return o.chunkCompression
+}
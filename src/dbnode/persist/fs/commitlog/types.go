@@ -247,6 +247,14 @@ type Options interface {
 
 	// FailureCallback returns the strategy.
 	FailureCallback() FailureCallback
+
+	// SetChunkCompression sets the compression applied to chunks written
+	// to commit log files.
+	SetChunkCompression(value ChunkCompression) Options
+
+	// ChunkCompression returns the compression applied to chunks written
+	// to commit log files.
+	ChunkCompression() ChunkCompression
 }
 
 // FileFilterInfo contains information about a commitog file that can be used to
@@ -51,6 +51,13 @@ const (
 		chunkHeaderChecksumSizeLen +
 		chunkHeaderChecksumDataLen
 
+	// chunkHeaderCompressedFlag is set in the size of compressed chunks. The
+	// data of a compressed chunk starts with its ChunkCompression followed
+	// by the compressed data. Uncompressed chunks are never large enough to
+	// have the flag set, so files written without compression can still be
+	// read.
+	chunkHeaderCompressedFlag = 1 << 31
+
 	defaultBitSetLength = 65536
 
 	defaultEncoderBuffSize = 16384
@@ -127,7 +134,7 @@ func newCommitLogWriter(
 		newFileMode:         opts.FilesystemOptions().NewFileMode(),
 		newDirectoryMode:    opts.FilesystemOptions().NewDirectoryMode(),
 		nowFn:               opts.ClockOptions().NowFn(),
-		chunkWriter:         newChunkWriter(flushFn, shouldFsync),
+		chunkWriter:         newChunkWriter(flushFn, shouldFsync, opts.ChunkCompression()),
 		chunkReserveHeader:  make([]byte, chunkHeaderLen),
 		buffer:              bufio.NewWriterSize(nil, opts.FlushSize()),
 		sizeBuffer:          make([]byte, binary.MaxVarintLen64),
@@ -300,17 +307,21 @@ func (w *writer) write(data []byte) error {
 }
 
 type fsChunkWriter struct {
-	fd      xos.File
-	flushFn flushFn
-	buff    []byte
-	fsync   bool
+	fd          xos.File
+	flushFn     flushFn
+	buff        []byte
+	fsync       bool
+	compression ChunkCompression
+	codec       chunkCodec
+	compressed  []byte
 }
 
-func newChunkWriter(flushFn flushFn, fsync bool) chunkWriter {
+func newChunkWriter(flushFn flushFn, fsync bool, compression ChunkCompression) chunkWriter {
 	return &fsChunkWriter{
-		flushFn: flushFn,
-		buff:    make([]byte, chunkHeaderLen),
-		fsync:   fsync,
+		flushFn:     flushFn,
+		buff:        make([]byte, chunkHeaderLen),
+		fsync:       fsync,
+		compression: compression,
 	}
 }
 
@@ -338,9 +349,19 @@ func (w *fsChunkWriter) sync() error {
 
 // Writes a custom header in front of p to a file and returns number of bytes of p successfully written to the file.
 // If the header or p is not fully written to the file, then this method returns number of bytes of p actually written
-// to the file and an error explaining the reason of failure to write fully to the file.
+// to the file and an error explaining the reason of failure to write fully to the file. If p is compressed then either
+// all or none of it is considered written.
 func (w *fsChunkWriter) Write(p []byte) (int, error) {
-	size := len(p)
+	data, compressed, err := w.compress(p)
+	if err != nil {
+		w.flushFn(err)
+		return 0, err
+	}
+
+	size := uint32(len(data))
+	if compressed {
+		size |= chunkHeaderCompressedFlag
+	}
 
 	sizeStart, sizeEnd :=
 		0, chunkHeaderSizeLen
@@ -350,11 +371,11 @@ func (w *fsChunkWriter) Write(p []byte) (int, error) {
 		checksumSizeEnd, checksumSizeEnd+chunkHeaderChecksumDataLen
 
 	// Write size
-	endianness.PutUint32(w.buff[sizeStart:sizeEnd], uint32(size))
+	endianness.PutUint32(w.buff[sizeStart:sizeEnd], size)
 
 	// Calculate checksums
 	checksumSize := digest.Checksum(w.buff[sizeStart:sizeEnd])
-	checksumData := digest.Checksum(p)
+	checksumData := digest.Checksum(data)
 
 	// Write checksums
 	digest.
@@ -365,7 +386,7 @@ func (w *fsChunkWriter) Write(p []byte) (int, error) {
 		WriteDigest(checksumData)
 
 	// Combine buffers to reduce to a single syscall
-	w.buff = append(w.buff[:chunkHeaderLen], p...)
+	w.buff = append(w.buff[:chunkHeaderLen], data...)
 
 	// Write contents to file descriptor
 	n, err := w.fd.Write(w.buff)
@@ -374,6 +395,12 @@ func (w *fsChunkWriter) Write(p []byte) (int, error) {
 	if pBytesWritten < 0 {
 		pBytesWritten = 0
 	}
+	if compressed {
+		pBytesWritten = 0
+		if n == len(w.buff) {
+			pBytesWritten = len(p)
+		}
+	}
 
 	if err != nil {
 		w.flushFn(err)
@@ -389,3 +416,29 @@ func (w *fsChunkWriter) Write(p []byte) (int, error) {
 	w.flushFn(err)
 	return pBytesWritten, err
 }
+
+// compress returns the data to write for p and whether it is compressed. The
+// data is p itself if compression is disabled or does not make p smaller.
+func (w *fsChunkWriter) compress(p []byte) ([]byte, bool, error) {
+	if w.compression == NoChunkCompression || len(p) == 0 {
+		return p, false, nil
+	}
+	if w.codec == nil {
+		codec, err := newChunkCodec(w.compression)
+		if err != nil {
+			return nil, false, err
+		}
+		w.codec = codec
+	}
+
+	var err error
+	w.compressed = append(w.compressed[:0], byte(w.compression))
+	w.compressed, err = w.codec.encode(w.compressed, p)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(w.compressed) >= len(p) {
+		return p, false, nil
+	}
+	return w.compressed, true, nil
+}
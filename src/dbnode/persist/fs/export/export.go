@@ -0,0 +1,456 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package export exports the data filesets of a namespace to Parquet files
+// for batch analytics outside of the query path.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/x/checked"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/serialize"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// FileName is the name of the Parquet file written to each partition.
+	FileName = "data.parquet"
+
+	tmpFileSuffix = ".tmp"
+)
+
+var (
+	errFilesystemOptionsNotSet = errors.New("filesystem options must be set")
+	errNamespaceNotSet         = errors.New("namespace must be set")
+	errOutputDirNotSet         = errors.New("output directory must be set")
+	errInvalidTimeRange        = errors.New("end must be after start")
+)
+
+// Options are the options for exporting a namespace.
+type Options struct {
+	// FilesystemOptions are the filesystem options used to read filesets.
+	FilesystemOptions fs.Options
+
+	// EncodingOptions are the options used to decode series data, the
+	// defaults are used if not set.
+	EncodingOptions encoding.Options
+
+	// Namespace is the namespace to export.
+	Namespace ident.ID
+
+	// Shards restricts the export to the given shards, all shards found on
+	// disk are exported if empty.
+	Shards []uint32
+
+	// Start restricts the export to blocks starting at or after it.
+	Start xtime.UnixNano
+
+	// End restricts the export to blocks starting before it, there is no
+	// upper bound if it is zero.
+	End xtime.UnixNano
+
+	// Filter restricts the export to the series matching the query against
+	// the index filesets of the namespace, all series are exported if unset.
+	Filter idx.Query
+
+	// OutputDir is the directory the Parquet files are written to.
+	OutputDir string
+}
+
+// Validate validates the options.
+func (o Options) Validate() error {
+	if o.FilesystemOptions == nil {
+		return errFilesystemOptionsNotSet
+	}
+	if o.Namespace == nil || len(o.Namespace.Bytes()) == 0 {
+		return errNamespaceNotSet
+	}
+	if o.OutputDir == "" {
+		return errOutputDirNotSet
+	}
+	if o.End != 0 && !o.End.After(o.Start) {
+		return errInvalidTimeRange
+	}
+	return nil
+}
+
+// Result is the result of an export.
+type Result struct {
+	// Files is the number of Parquet files written.
+	Files int
+	// Series is the number of series exported.
+	Series int
+	// Datapoints is the number of datapoints exported.
+	Datapoints int
+}
+
+// PartitionDir returns the directory of the partition for a namespace,
+// block start and shard. Partitions are laid out as Hive style
+// key=value directories so they can be discovered by most query engines.
+func PartitionDir(
+	outputDir string,
+	namespace ident.ID,
+	blockStart xtime.UnixNano,
+	shard uint32,
+) string {
+	return filepath.Join(outputDir,
+		"namespace="+namespace.String(),
+		"block_start="+strconv.FormatInt(int64(blockStart), 10),
+		"shard="+strconv.FormatUint(uint64(shard), 10))
+}
+
+// Export writes one Parquet file per data fileset of the namespace, holding
+// one row per datapoint of every matching series. Only the latest complete
+// volume of each block is exported.
+func Export(opts Options) (Result, error) {
+	if err := opts.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	fileSets, err := dataFileSets(opts)
+	if err != nil {
+		return Result{}, err
+	}
+
+	e, err := newExporter(opts)
+	if err != nil {
+		return Result{}, err
+	}
+	defer e.close()
+
+	var result Result
+	for _, file := range fileSets {
+		fileResult, err := e.exportFileSet(file.ID)
+		if err != nil {
+			return result, fmt.Errorf("unable to export shard %d block %s: %w",
+				file.ID.Shard, file.ID.BlockStart, err)
+		}
+		if fileResult.Series > 0 {
+			result.Files++
+		}
+		result.Series += fileResult.Series
+		result.Datapoints += fileResult.Datapoints
+	}
+	return result, nil
+}
+
+type exporter struct {
+	opts         Options
+	encodingOpts encoding.Options
+	reader       fs.DataFileSetReader
+	tagDecoder   serialize.TagDecoder
+	filter       *seriesFilter
+}
+
+func newExporter(opts Options) (*exporter, error) {
+	// Not using a bytes pool with streaming reads to avoid the fixed memory overhead.
+	reader, err := fs.NewReader(nil, opts.FilesystemOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	encodingOpts := opts.EncodingOptions
+	if encodingOpts == nil {
+		encodingOpts = encoding.NewOptions()
+	}
+
+	var filter *seriesFilter
+	if opts.Filter.SearchQuery() != nil {
+		filter = newSeriesFilter(opts.FilesystemOptions, opts.Namespace, opts.Filter)
+	}
+
+	return &exporter{
+		opts:         opts,
+		encodingOpts: encodingOpts,
+		reader:       reader,
+		tagDecoder:   opts.FilesystemOptions.TagDecoderPool().Get(),
+		filter:       filter,
+	}, nil
+}
+
+func (e *exporter) exportFileSet(id fs.FileSetFileIdentifier) (Result, error) {
+	// Data filesets hold series with different tags so read the metadata
+	// first to build the schema of the file.
+	tagNames, err := e.matchingTagNames(id)
+	if err != nil {
+		return Result{}, err
+	}
+	if tagNames == nil {
+		// No series matched, skip writing an empty file.
+		return Result{}, nil
+	}
+
+	dir := PartitionDir(e.opts.OutputDir, id.Namespace, id.BlockStart, id.Shard)
+	if err := os.MkdirAll(dir, e.opts.FilesystemOptions.NewDirectoryMode()); err != nil {
+		return Result{}, err
+	}
+
+	var (
+		path    = filepath.Join(dir, FileName)
+		tmpPath = path + tmpFileSuffix
+	)
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY,
+		e.opts.FilesystemOptions.NewFileMode())
+	if err != nil {
+		return Result{}, err
+	}
+
+	result, err := e.writeFileSet(id, tagNames, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return Result{}, err
+	}
+	return result, os.Rename(tmpPath, path)
+}
+
+// matchingTagNames returns the sorted tag names of the series matching the
+// filter, or nil if no series matched.
+func (e *exporter) matchingTagNames(id fs.FileSetFileIdentifier) ([]string, error) {
+	if err := e.open(id); err != nil {
+		return nil, err
+	}
+
+	var (
+		names   = make(map[string]struct{})
+		matched bool
+	)
+	for {
+		entry, err := e.reader.StreamingReadMetadata()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, xerrors.FirstError(err, e.reader.Close())
+		}
+
+		ok, err := e.matches(id.BlockStart, entry.ID)
+		if err != nil {
+			return nil, xerrors.FirstError(err, e.reader.Close())
+		}
+		if !ok {
+			continue
+		}
+
+		matched = true
+		err = e.forEachTag(entry.EncodedTags, func(name, _ []byte) {
+			if _, ok := names[string(name)]; !ok {
+				names[string(name)] = struct{}{}
+			}
+		})
+		if err != nil {
+			return nil, xerrors.FirstError(err, e.reader.Close())
+		}
+	}
+	if err := e.reader.Close(); err != nil {
+		return nil, err
+	}
+	if !matched {
+		return nil, nil
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+func (e *exporter) writeFileSet(
+	id fs.FileSetFileIdentifier,
+	tagNames []string,
+	w io.Writer,
+) (Result, error) {
+	pw, err := newParquetWriter(tagNames, w)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if err := e.open(id); err != nil {
+		return Result{}, err
+	}
+
+	result, err := e.writeSeries(id, pw)
+	if err != nil {
+		return Result{}, xerrors.FirstError(err, e.reader.Close())
+	}
+	if err := e.reader.Close(); err != nil {
+		return Result{}, err
+	}
+	return result, pw.Close()
+}
+
+func (e *exporter) writeSeries(id fs.FileSetFileIdentifier, pw *parquetWriter) (Result, error) {
+	var result Result
+	for {
+		entry, err := e.reader.StreamingRead()
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return result, err
+		}
+
+		ok, err := e.matches(id.BlockStart, entry.ID)
+		if err != nil {
+			return result, err
+		}
+		if !ok {
+			continue
+		}
+
+		pw.resetSeries(entry.ID)
+		err = e.forEachTag(entry.EncodedTags, pw.setTag)
+		if err != nil {
+			return result, err
+		}
+
+		iter := m3tsz.NewReaderIterator(xio.NewBytesReader64(entry.Data),
+			m3tsz.DefaultIntOptimizationEnabled, e.encodingOpts)
+		for iter.Next() {
+			dp, _, annotation := iter.Current()
+			if err := pw.write(dp, annotation); err != nil {
+				iter.Close()
+				return result, err
+			}
+			result.Datapoints++
+		}
+		err = iter.Err()
+		iter.Close()
+		if err != nil {
+			return result, err
+		}
+		result.Series++
+	}
+}
+
+func (e *exporter) open(id fs.FileSetFileIdentifier) error {
+	return e.reader.Open(fs.DataReaderOpenOptions{
+		Identifier:       id,
+		FileSetType:      persist.FileSetFlushType,
+		StreamingEnabled: true,
+	})
+}
+
+func (e *exporter) matches(blockStart xtime.UnixNano, id []byte) (bool, error) {
+	if e.filter == nil {
+		return true, nil
+	}
+	return e.filter.matches(blockStart, id)
+}
+
+func (e *exporter) forEachTag(encodedTags []byte, fn func(name, value []byte)) error {
+	if len(encodedTags) == 0 {
+		return nil
+	}
+	e.tagDecoder.Reset(checked.NewBytes(encodedTags, nil))
+	for e.tagDecoder.Next() {
+		tag := e.tagDecoder.Current()
+		fn(tag.Name.Bytes(), tag.Value.Bytes())
+	}
+	return e.tagDecoder.Err()
+}
+
+func (e *exporter) close() {
+	e.tagDecoder.Close()
+}
+
+// dataFileSets returns the latest complete volume of each data fileset to
+// export, ordered by block start and then shard.
+func dataFileSets(opts Options) ([]fs.FileSetFile, error) {
+	shards := opts.Shards
+	if len(shards) == 0 {
+		var err error
+		shards, err = shardsOnDisk(opts.FilesystemOptions.FilePathPrefix(), opts.Namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result []fs.FileSetFile
+	for _, shard := range shards {
+		files, err := fs.DataFiles(opts.FilesystemOptions.FilePathPrefix(),
+			opts.Namespace, shard)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files.LatestVolumes() {
+			blockStart := file.ID.BlockStart
+			if blockStart.Before(opts.Start) {
+				continue
+			}
+			if opts.End != 0 && !blockStart.Before(opts.End) {
+				continue
+			}
+			result = append(result, file)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ID.BlockStart != result[j].ID.BlockStart {
+			return result[i].ID.BlockStart.Before(result[j].ID.BlockStart)
+		}
+		return result[i].ID.Shard < result[j].ID.Shard
+	})
+	return result, nil
+}
+
+// shardsOnDisk returns the shards of a namespace that have data filesets.
+func shardsOnDisk(filePathPrefix string, namespace ident.ID) ([]uint32, error) {
+	entries, err := ioutil.ReadDir(fs.NamespaceDataDirPath(filePathPrefix, namespace))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var shards []uint32
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		shard, err := strconv.ParseUint(entry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		shards = append(shards, uint32(shard))
+	}
+	return shards, nil
+}
@@ -0,0 +1,429 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package export
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/m3ninx/index/segment/builder"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
+	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+const testBlockSize = 2 * time.Hour
+
+var (
+	testNamespaceID = ident.StringID("testns")
+	testBlockStart  = xtime.UnixNano(0).Add(10 * testBlockSize)
+)
+
+type testSeries struct {
+	id         string
+	tags       map[string]string
+	values     []float64
+	annotation string
+}
+
+func newTestOptions(t *testing.T) (Options, func()) {
+	dir, err := ioutil.TempDir("", "export")
+	require.NoError(t, err)
+	return Options{
+		FilesystemOptions: fs.NewOptions().SetFilePathPrefix(filepath.Join(dir, "data")),
+		Namespace:         testNamespaceID,
+		OutputDir:         filepath.Join(dir, "export"),
+	}, func() { os.RemoveAll(dir) }
+}
+
+func testTags(tags map[string]string) ident.Tags {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := ident.NewTags()
+	for _, name := range names {
+		result.Append(ident.StringTag(name, tags[name]))
+	}
+	return result
+}
+
+func writeTestDataFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	shard uint32,
+	blockStart xtime.UnixNano,
+	series []testSeries,
+) {
+	w, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, w.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  testNamespaceID,
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+		BlockSize:   testBlockSize,
+		FileSetType: persist.FileSetFlushType,
+	}))
+
+	for _, s := range series {
+		enc := m3tsz.NewEncoder(blockStart, nil,
+			m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+		for i, v := range s.values {
+			var annotation ts.Annotation
+			if s.annotation != "" {
+				annotation = ts.Annotation(s.annotation)
+			}
+			require.NoError(t, enc.Encode(ts.Datapoint{
+				TimestampNanos: blockStart.Add(time.Duration(i) * time.Minute),
+				Value:          v,
+			}, xtime.Second, annotation))
+		}
+		seg := enc.Discard()
+		data := append(append([]byte(nil), seg.Head.Bytes()...), seg.Tail.Bytes()...)
+
+		bytes := checked.NewBytes(data, nil)
+		bytes.IncRef()
+		metadata := persist.NewMetadataFromIDAndTags(ident.StringID(s.id),
+			testTags(s.tags), persist.MetadataOptions{})
+		require.NoError(t, w.Write(metadata, bytes, digest.Checksum(data)))
+	}
+	require.NoError(t, w.Close())
+}
+
+func writeTestIndexFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	blockStart xtime.UnixNano,
+	series []testSeries,
+) {
+	b, err := builder.NewBuilderFromDocuments(builder.NewOptions())
+	require.NoError(t, err)
+	defer b.Close()
+	for _, s := range series {
+		var fields []doc.Field
+		for _, tag := range testTags(s.tags).Values() {
+			fields = append(fields, doc.Field{
+				Name:  tag.Name.Bytes(),
+				Value: tag.Value.Bytes(),
+			})
+		}
+		_, err := b.Insert(doc.Metadata{ID: []byte(s.id), Fields: fields})
+		require.NoError(t, err)
+	}
+
+	segWriter, err := idxpersist.NewMutableSegmentFileSetWriter(fst.WriterOptions{})
+	require.NoError(t, err)
+	require.NoError(t, segWriter.Reset(b))
+
+	w, err := fs.NewIndexWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, w.Open(fs.IndexWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			FileSetContentType: persist.FileSetIndexContentType,
+			Namespace:          testNamespaceID,
+			BlockStart:         blockStart,
+		},
+		BlockSize:   testBlockSize,
+		FileSetType: persist.FileSetFlushType,
+		Shards:      map[uint32]struct{}{1: {}, 2: {}},
+	}))
+	require.NoError(t, w.WriteSegmentFileSet(segWriter))
+	require.NoError(t, w.Close())
+}
+
+// readTestParquetFile returns the rows of a Parquet file keyed by column,
+// with binary values as strings and null values as nil, and the file
+// metadata.
+func readTestParquetFile(t *testing.T, path string) ([]map[string]interface{}, map[string]string) {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	r, err := goparquet.NewFileReader(f)
+	require.NoError(t, err)
+
+	var rows []map[string]interface{}
+	for {
+		values, err := r.NextRow()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		row := make(map[string]interface{}, len(r.Columns()))
+		for _, col := range r.Columns() {
+			v, ok := values[col.Name()]
+			if b, isBytes := v.([]byte); isBytes {
+				v = string(b)
+			}
+			if !ok {
+				v = nil
+			}
+			row[col.Name()] = v
+		}
+		rows = append(rows, row)
+	}
+	return rows, r.MetaData()
+}
+
+var testShardSeries = map[uint32][]testSeries{
+	1: {
+		{
+			id:         "foo",
+			tags:       map[string]string{"city": "nyc", "host": "a"},
+			values:     []float64{1, 2},
+			annotation: "note",
+		},
+		{
+			id:     "bar",
+			tags:   map[string]string{"city": "sf"},
+			values: []float64{3},
+		},
+	},
+	2: {
+		{
+			id:     "baz",
+			tags:   map[string]string{"city": "nyc", "data.center": "east"},
+			values: []float64{4},
+		},
+	},
+}
+
+func writeTestShards(t *testing.T, fsOpts fs.Options, blockStart xtime.UnixNano) {
+	var all []testSeries
+	for shard, series := range testShardSeries {
+		writeTestDataFileSet(t, fsOpts, shard, blockStart, series)
+		all = append(all, series...)
+	}
+	writeTestIndexFileSet(t, fsOpts, blockStart, all)
+}
+
+func TestExport(t *testing.T) {
+	opts, cleanup := newTestOptions(t)
+	defer cleanup()
+
+	writeTestShards(t, opts.FilesystemOptions, testBlockStart)
+
+	result, err := Export(opts)
+	require.NoError(t, err)
+	require.Equal(t, Result{Files: 2, Series: 3, Datapoints: 4}, result)
+
+	var (
+		ts0 = int64(testBlockStart)
+		ts1 = int64(testBlockStart.Add(time.Minute))
+	)
+	rows, metadata := readTestParquetFile(t, filepath.Join(
+		PartitionDir(opts.OutputDir, testNamespaceID, testBlockStart, 1), FileName))
+	require.JSONEq(t, `{"tag_city":"city","tag_host":"host"}`,
+		metadata[TagColumnsMetadataKey])
+	// Series are written in the order of the data fileset which is by ID.
+	require.Equal(t, []map[string]interface{}{
+		{"id": "bar", "timestamp": ts0, "value": float64(3), "annotation": nil,
+			"tag_city": "sf", "tag_host": nil},
+		{"id": "foo", "timestamp": ts0, "value": float64(1), "annotation": "note",
+			"tag_city": "nyc", "tag_host": "a"},
+		// Annotations are only encoded when they change.
+		{"id": "foo", "timestamp": ts1, "value": float64(2), "annotation": nil,
+			"tag_city": "nyc", "tag_host": "a"},
+	}, rows)
+
+	rows, metadata = readTestParquetFile(t, filepath.Join(
+		PartitionDir(opts.OutputDir, testNamespaceID, testBlockStart, 2), FileName))
+	require.JSONEq(t, `{"tag_city":"city","tag_data_center":"data.center"}`,
+		metadata[TagColumnsMetadataKey])
+	require.Equal(t, []map[string]interface{}{
+		{"id": "baz", "timestamp": ts0, "value": float64(4), "annotation": nil,
+			"tag_city": "nyc", "tag_data_center": "east"},
+	}, rows)
+}
+
+func TestExportCollidingTagColumns(t *testing.T) {
+	opts, cleanup := newTestOptions(t)
+	defer cleanup()
+
+	writeTestDataFileSet(t, opts.FilesystemOptions, 1, testBlockStart, []testSeries{
+		{
+			id:     "a",
+			tags:   map[string]string{"data.center": "east", "data_center": "west"},
+			values: []float64{1},
+		},
+		{
+			id:     "b",
+			tags:   map[string]string{"data-center": "north"},
+			values: []float64{2},
+		},
+	})
+
+	_, err := Export(opts)
+	require.NoError(t, err)
+
+	rows, metadata := readTestParquetFile(t, filepath.Join(
+		PartitionDir(opts.OutputDir, testNamespaceID, testBlockStart, 1), FileName))
+	require.JSONEq(t, `{
+		"tag_data_center": "data_center",
+		"tag_data_center_2": "data-center",
+		"tag_data_center_3": "data.center"
+	}`, metadata[TagColumnsMetadataKey])
+
+	ts0 := int64(testBlockStart)
+	require.Equal(t, []map[string]interface{}{
+		{"id": "a", "timestamp": ts0, "value": float64(1), "annotation": nil,
+			"tag_data_center": "west", "tag_data_center_2": nil, "tag_data_center_3": "east"},
+		{"id": "b", "timestamp": ts0, "value": float64(2), "annotation": nil,
+			"tag_data_center": nil, "tag_data_center_2": "north", "tag_data_center_3": nil},
+	}, rows)
+}
+
+func TestExportFilter(t *testing.T) {
+	opts, cleanup := newTestOptions(t)
+	defer cleanup()
+
+	writeTestShards(t, opts.FilesystemOptions, testBlockStart)
+
+	opts.Filter = idx.NewConjunctionQuery(
+		idx.NewTermQuery([]byte("city"), []byte("nyc")),
+		idx.NewNegationQuery(idx.NewFieldQuery([]byte("data.center"))))
+	result, err := Export(opts)
+	require.NoError(t, err)
+	require.Equal(t, Result{Files: 1, Series: 1, Datapoints: 2}, result)
+
+	rows, _ := readTestParquetFile(t, filepath.Join(
+		PartitionDir(opts.OutputDir, testNamespaceID, testBlockStart, 1), FileName))
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		require.Equal(t, "foo", row["id"])
+		// Only the tags of matching series are columns.
+		require.Equal(t, []string{"annotation", "id", "tag_city", "tag_host", "timestamp", "value"},
+			sortedKeys(row))
+	}
+
+	_, err = os.Stat(PartitionDir(opts.OutputDir, testNamespaceID, testBlockStart, 2))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestExportFilterWithoutIndex(t *testing.T) {
+	opts, cleanup := newTestOptions(t)
+	defer cleanup()
+
+	writeTestShards(t, opts.FilesystemOptions, testBlockStart)
+	// Series in blocks without an index volume can't be matched.
+	writeTestDataFileSet(t, opts.FilesystemOptions, 1, testBlockStart.Add(testBlockSize),
+		testShardSeries[1])
+
+	opts.Filter = idx.NewTermQuery([]byte("city"), []byte("sf"))
+	result, err := Export(opts)
+	require.NoError(t, err)
+	require.Equal(t, Result{Files: 1, Series: 1, Datapoints: 1}, result)
+}
+
+func TestExportTimeRangeAndShards(t *testing.T) {
+	opts, cleanup := newTestOptions(t)
+	defer cleanup()
+
+	for i := 0; i < 3; i++ {
+		for shard, series := range testShardSeries {
+			writeTestDataFileSet(t, opts.FilesystemOptions, shard,
+				testBlockStart.Add(time.Duration(i)*testBlockSize), series)
+		}
+	}
+
+	opts.Start = testBlockStart.Add(testBlockSize)
+	opts.End = testBlockStart.Add(2 * testBlockSize)
+	opts.Shards = []uint32{2}
+	result, err := Export(opts)
+	require.NoError(t, err)
+	require.Equal(t, Result{Files: 1, Series: 1, Datapoints: 1}, result)
+
+	_, err = os.Stat(filepath.Join(PartitionDir(opts.OutputDir, testNamespaceID,
+		testBlockStart.Add(testBlockSize), 2), FileName))
+	require.NoError(t, err)
+}
+
+func TestOptionsValidate(t *testing.T) {
+	opts, cleanup := newTestOptions(t)
+	defer cleanup()
+	require.NoError(t, opts.Validate())
+
+	invalid := opts
+	invalid.FilesystemOptions = nil
+	require.Equal(t, errFilesystemOptionsNotSet, invalid.Validate())
+
+	invalid = opts
+	invalid.Namespace = nil
+	require.Equal(t, errNamespaceNotSet, invalid.Validate())
+
+	invalid = opts
+	invalid.OutputDir = ""
+	require.Equal(t, errOutputDirNotSet, invalid.Validate())
+
+	invalid = opts
+	invalid.Start = testBlockStart
+	invalid.End = testBlockStart
+	require.Equal(t, errInvalidTimeRange, invalid.Validate())
+}
+
+func TestTagColumn(t *testing.T) {
+	require.Equal(t, "tag___name__", TagColumn("__name__"))
+	require.Equal(t, "tag_data_center", TagColumn("data.center"))
+	require.Equal(t, "tag_a_b_c", TagColumn("a=b,c"))
+}
+
+func TestTagColumns(t *testing.T) {
+	require.Equal(t, map[string]string{
+		"a.b":   "tag_a_b_3",
+		"a:b":   "tag_a_b_4",
+		"a_b":   "tag_a_b",
+		"a_b_2": "tag_a_b_2",
+		"host":  "tag_host",
+	}, TagColumns([]string{"a.b", "a:b", "a_b", "a_b_2", "host"}))
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
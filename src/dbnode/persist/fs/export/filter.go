@@ -0,0 +1,165 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package export
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst/encoding/docs"
+	"github.com/m3db/m3/src/m3ninx/search/executor"
+	"github.com/m3db/m3/src/x/context"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// seriesFilter matches series IDs against a query run on the index
+// filesets of a namespace. The IDs matching in one index block are held in
+// memory, which relies on data filesets being exported in block order.
+type seriesFilter struct {
+	fsOpts    fs.Options
+	namespace ident.ID
+	query     idx.Query
+
+	volumes    []fs.ReadIndexInfoFileResult
+	volumesErr error
+	loaded     bool
+
+	matchedStart xtime.UnixNano
+	matchedEnd   xtime.UnixNano
+	matched      map[string]struct{}
+}
+
+func newSeriesFilter(fsOpts fs.Options, namespace ident.ID, query idx.Query) *seriesFilter {
+	return &seriesFilter{
+		fsOpts:    fsOpts,
+		namespace: namespace,
+		query:     query,
+	}
+}
+
+func (f *seriesFilter) matches(blockStart xtime.UnixNano, id []byte) (bool, error) {
+	if f.matched == nil ||
+		blockStart.Before(f.matchedStart) ||
+		!blockStart.Before(f.matchedEnd) {
+		if err := f.load(blockStart); err != nil {
+			return false, err
+		}
+	}
+	_, ok := f.matched[string(id)]
+	return ok, nil
+}
+
+// load runs the query on all the index volumes covering the block start.
+func (f *seriesFilter) load(blockStart xtime.UnixNano) error {
+	if !f.loaded {
+		f.loaded = true
+		f.volumes = fs.ReadIndexInfoFiles(fs.ReadIndexInfoFilesOptions{
+			FilePathPrefix:   f.fsOpts.FilePathPrefix(),
+			Namespace:        f.namespace,
+			ReaderBufferSize: f.fsOpts.InfoReaderBufferSize(),
+		})
+		for _, volume := range f.volumes {
+			if err := volume.Err.Error(); err != nil {
+				f.volumesErr = err
+				break
+			}
+		}
+	}
+	if f.volumesErr != nil {
+		return f.volumesErr
+	}
+
+	f.matched = make(map[string]struct{})
+	// Data blocks never span index blocks, so without a covering index
+	// volume no series match until the next data block.
+	f.matchedStart = blockStart
+	f.matchedEnd = blockStart + 1
+	for _, volume := range f.volumes {
+		var (
+			start = xtime.UnixNano(volume.Info.BlockStart)
+			end   = start.Add(time.Duration(volume.Info.BlockSize))
+		)
+		if blockStart.Before(start) || !blockStart.Before(end) {
+			continue
+		}
+		f.matchedStart, f.matchedEnd = start, end
+		if err := f.search(volume.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *seriesFilter) search(id fs.FileSetFileIdentifier) error {
+	result, err := fs.ReadIndexSegments(fs.ReadIndexSegmentsOptions{
+		ReaderOptions: fs.IndexReaderOpenOptions{
+			Identifier:  id,
+			FileSetType: persist.FileSetFlushType,
+		},
+		FilesystemOptions: f.fsOpts,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = f.searchSegments(result.Segments)
+	for _, seg := range result.Segments {
+		err = xerrors.FirstError(err, seg.Close())
+	}
+	return err
+}
+
+func (f *seriesFilter) searchSegments(segments []segment.Segment) error {
+	readers := make(index.Readers, 0, len(segments))
+	for _, seg := range segments {
+		reader, err := seg.Reader()
+		if err != nil {
+			return xerrors.FirstError(err, readers.Close())
+		}
+		readers = append(readers, reader)
+	}
+
+	exec := executor.NewExecutor(readers)
+	defer exec.Close() // nolint: errcheck
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	iter, err := exec.Execute(ctx, f.query.SearchQuery())
+	if err != nil {
+		return err
+	}
+
+	for iter.Next() {
+		id, err := docs.ReadIDFromDocument(iter.Current())
+		if err != nil {
+			return xerrors.FirstError(err, iter.Close())
+		}
+		f.matched[string(id)] = struct{}{}
+	}
+	return xerrors.FirstError(iter.Err(), iter.Close())
+}
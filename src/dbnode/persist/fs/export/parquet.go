@@ -0,0 +1,201 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/fraugster/parquet-go/parquetschema"
+)
+
+const (
+	// IDColumn is the column holding the series ID.
+	IDColumn = "id"
+	// TimestampColumn is the column holding the datapoint timestamp in
+	// nanoseconds since the epoch.
+	TimestampColumn = "timestamp"
+	// ValueColumn is the column holding the datapoint value.
+	ValueColumn = "value"
+	// AnnotationColumn is the column holding the datapoint annotation, it is
+	// null for datapoints without one. Annotations are only encoded on the
+	// datapoints where they change.
+	AnnotationColumn = "annotation"
+	// TagColumnPrefix is the prefix of the columns holding tag values, tags
+	// missing from a series are null.
+	TagColumnPrefix = "tag_"
+
+	// TagColumnsMetadataKey is the key of the file metadata holding the JSON
+	// object mapping each tag column of the file to the tag name it holds.
+	TagColumnsMetadataKey = "m3.tag_columns"
+
+	// parquetMaxRowGroupSize is the rough size at which buffered rows are
+	// flushed as a row group.
+	parquetMaxRowGroupSize = 128 * 1024 * 1024
+)
+
+// TagColumn returns the column name for a tag name. Characters other than
+// letters, digits and underscores are replaced with underscores, see
+// TagColumns for how tag names that collide once replaced are told apart.
+func TagColumn(name string) string {
+	var b strings.Builder
+	b.Grow(len(TagColumnPrefix) + len(name))
+	b.WriteString(TagColumnPrefix)
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+			(c >= '0' && c <= '9') || c == '_' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('_')
+	}
+	return b.String()
+}
+
+// TagColumns returns the column of each of the given tag names. Tag names
+// made only of letters, digits and underscores always get the column
+// returned by TagColumn. Other tag names whose column collides with that of
+// another tag name get a _2, _3 and so on suffix, assigned in tag name
+// order.
+func TagColumns(tagNames []string) map[string]string {
+	var (
+		columns = make(map[string]string, len(tagNames))
+		used    = make(map[string]struct{}, len(tagNames))
+		renamed []string
+	)
+	for _, name := range tagNames {
+		column := TagColumn(name)
+		if column != TagColumnPrefix+name {
+			renamed = append(renamed, name)
+			continue
+		}
+		columns[name] = column
+		used[column] = struct{}{}
+	}
+	// Tag names are sorted so the renamed ones are too.
+	for _, name := range renamed {
+		base := TagColumn(name)
+		column := base
+		for n := 2; ; n++ {
+			if _, ok := used[column]; !ok {
+				break
+			}
+			column = base + "_" + strconv.Itoa(n)
+		}
+		columns[name] = column
+		used[column] = struct{}{}
+	}
+	return columns
+}
+
+// parquetWriter writes one row per datapoint with the tags of the series
+// being written as columns.
+type parquetWriter struct {
+	writer *goparquet.FileWriter
+	// columns maps tag names to their column.
+	columns map[string]string
+	tags    map[string][]byte
+	id      []byte
+}
+
+func newParquetWriter(tagNames []string, w io.Writer) (*parquetWriter, error) {
+	var (
+		columns   = TagColumns(tagNames)
+		tagsByCol = make(map[string]string, len(columns))
+		schema    strings.Builder
+	)
+	schema.WriteString("message m3 {\n")
+	schema.WriteString("  required binary " + IDColumn + " (STRING);\n")
+	schema.WriteString("  required int64 " + TimestampColumn + " (TIMESTAMP(NANOS, true));\n")
+	schema.WriteString("  required double " + ValueColumn + ";\n")
+	schema.WriteString("  optional binary " + AnnotationColumn + ";\n")
+	for _, name := range tagNames {
+		column := columns[name]
+		tagsByCol[column] = name
+		schema.WriteString("  optional binary " + column + " (STRING);\n")
+	}
+	schema.WriteString("}\n")
+
+	schemaDef, err := parquetschema.ParseSchemaDefinition(schema.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid parquet schema: %w", err)
+	}
+	tagColumns, err := json.Marshal(tagsByCol)
+	if err != nil {
+		return nil, err
+	}
+
+	pw := goparquet.NewFileWriter(w,
+		goparquet.WithSchemaDefinition(schemaDef),
+		goparquet.WithCompressionCodec(parquet.CompressionCodec_SNAPPY),
+		goparquet.WithMaxRowGroupSize(parquetMaxRowGroupSize),
+		goparquet.WithMetaData(map[string]string{
+			TagColumnsMetadataKey: string(tagColumns),
+		}))
+	return &parquetWriter{
+		writer:  pw,
+		columns: columns,
+		tags:    make(map[string][]byte, len(columns)),
+	}, nil
+}
+
+// resetSeries resets the row to a new series with no tags set.
+func (w *parquetWriter) resetSeries(id []byte) {
+	for column := range w.tags {
+		delete(w.tags, column)
+	}
+	w.id = append([]byte(nil), id...)
+}
+
+func (w *parquetWriter) setTag(name, value []byte) {
+	if column, ok := w.columns[string(name)]; ok {
+		w.tags[column] = append([]byte(nil), value...)
+	}
+}
+
+func (w *parquetWriter) write(dp ts.Datapoint, annotation ts.Annotation) error {
+	// The writer buffers rows until a row group is flushed so each row and
+	// the values in it must not be modified once written.
+	row := make(map[string]interface{}, 4+len(w.tags))
+	row[IDColumn] = w.id
+	row[TimestampColumn] = int64(dp.TimestampNanos)
+	row[ValueColumn] = dp.Value
+	if len(annotation) > 0 {
+		row[AnnotationColumn] = append([]byte(nil), annotation...)
+	}
+	for column, value := range w.tags {
+		row[column] = value
+	}
+	return w.writer.AddData(row)
+}
+
+// Close flushes the buffered rows and writes the file footer.
+func (w *parquetWriter) Close() error {
+	return w.writer.Close()
+}
@@ -245,6 +245,29 @@ func (f FileSetFilesSlice) LatestVolumeForBlock(blockStart xtime.UnixNano) (File
 	return FileSetFile{}, false
 }
 
+// LatestVolumes returns the latest (highest index) FileSetFile that has a
+// complete checkpoint file for each block start in the slice, ordered by
+// block start.
+func (f FileSetFilesSlice) LatestVolumes() []FileSetFile {
+	// Make sure we're already sorted.
+	f.sortByTimeAndVolumeIndexAscending()
+
+	var latest []FileSetFile
+	for _, curr := range f {
+		if !curr.HasCompleteCheckpointFile() {
+			continue
+		}
+		n := len(latest)
+		if n > 0 && latest[n-1].ID.BlockStart.Equal(curr.ID.BlockStart) {
+			latest[n-1] = curr
+			continue
+		}
+		latest = append(latest, curr)
+	}
+
+	return latest
+}
+
 // VolumeExistsForBlock returns whether there is a valid FileSetFile for the
 // given block start and volume index.
 func (f FileSetFilesSlice) VolumeExistsForBlock(blockStart xtime.UnixNano, volume int) bool {
@@ -1021,6 +1044,18 @@ func IndexSnapshotFiles(filePathPrefix string, namespace ident.ID) (FileSetFiles
 	})
 }
 
+// IndexFiles returns a slice of all the names for all the index fileset files
+// for a given namespace.
+func IndexFiles(filePathPrefix string, namespace ident.ID) (FileSetFilesSlice, error) {
+	return filesetFiles(filesetFilesSelector{
+		fileSetType:    persist.FileSetFlushType,
+		contentType:    persist.FileSetIndexContentType,
+		filePathPrefix: filePathPrefix,
+		namespace:      namespace,
+		pattern:        filesetFilePattern,
+	})
+}
+
 // FileSetAt returns a FileSetFile for the given namespace/shard/blockStart/volume combination if it exists.
 func FileSetAt(
 	filePathPrefix string,
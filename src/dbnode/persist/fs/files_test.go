@@ -865,6 +865,41 @@ func TestMultipleForBlockStart(t *testing.T) {
 	require.Equal(t, numSnapshotsPerBlock-1, latestSnapshot.ID.VolumeIndex)
 }
 
+func TestFileSetFilesSliceLatestVolumes(t *testing.T) {
+	file := func(blockStart xtime.UnixNano, volume int, complete bool) FileSetFile {
+		f := NewFileSetFile(FileSetFileIdentifier{
+			Namespace:   testNs1ID,
+			BlockStart:  blockStart,
+			VolumeIndex: volume,
+		}, "")
+		f.CachedHasCompleteCheckpointFile = EvalFalse
+		if complete {
+			f.CachedHasCompleteCheckpointFile = EvalTrue
+		}
+		return f
+	}
+
+	// Unsorted, with the highest volume of the second block incomplete and
+	// no complete volume for the third block.
+	files := FileSetFilesSlice{
+		file(2, 1, true),
+		file(1, 1, true),
+		file(2, 2, false),
+		file(3, 0, false),
+		file(1, 0, true),
+		file(2, 0, true),
+	}
+
+	latest := files.LatestVolumes()
+	require.Equal(t, 2, len(latest))
+	require.Equal(t, xtime.UnixNano(1), latest[0].ID.BlockStart)
+	require.Equal(t, 1, latest[0].ID.VolumeIndex)
+	require.Equal(t, xtime.UnixNano(2), latest[1].ID.BlockStart)
+	require.Equal(t, 1, latest[1].ID.VolumeIndex)
+
+	require.Empty(t, FileSetFilesSlice(nil).LatestVolumes())
+}
+
 func TestSnapshotFileHasCompleteCheckpointFile(t *testing.T) {
 	dir := createTempDir(t)
 	defer os.RemoveAll(dir)
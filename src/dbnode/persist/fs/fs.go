@@ -32,6 +32,9 @@ const (
 	// CheckpointFileSuffix is the suffix of checkpoint file.
 	CheckpointFileSuffix = "checkpoint"
 
+	// MerkleFileSuffix is the suffix of merkle tree file.
+	MerkleFileSuffix = "merkle"
+
 	indexFileSuffix          = "index"
 	summariesFileSuffix      = "summaries"
 	bloomFilterFileSuffix    = "bloomfilter"
@@ -1277,6 +1277,21 @@ func (m *MockMergeWith) EXPECT() *MockMergeWithMockRecorder {
 	return m.recorder
 }
 
+// Expired mocks base method.
+func (m *MockMergeWith) Expired(arg0 ident.TagIterator, arg1 time.UnixNano) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expired", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expired indicates an expected call of Expired.
+func (mr *MockMergeWithMockRecorder) Expired(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expired", reflect.TypeOf((*MockMergeWith)(nil).Expired), arg0, arg1)
+}
+
 // ForEachRemaining mocks base method.
 func (m *MockMergeWith) ForEachRemaining(arg0 context.Context, arg1 time.UnixNano, arg2 ForEachRemainingFn, arg3 namespace.Context) error {
 	m.ctrl.T.Helper()
@@ -1307,6 +1322,20 @@ func (mr *MockMergeWithMockRecorder) Read(arg0, arg1, arg2, arg3 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockMergeWith)(nil).Read), arg0, arg1, arg2, arg3)
 }
 
+// Tombstones mocks base method.
+func (m *MockMergeWith) Tombstones(arg0 ident.ID) time.Ranges {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tombstones", arg0)
+	ret0, _ := ret[0].(time.Ranges)
+	return ret0
+}
+
+// Tombstones indicates an expected call of Tombstones.
+func (mr *MockMergeWithMockRecorder) Tombstones(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tombstones", reflect.TypeOf((*MockMergeWith)(nil).Tombstones), arg0)
+}
+
 // MockStreamingWriter is a mock of StreamingWriter interface.
 type MockStreamingWriter struct {
 	ctrl     *gomock.Controller
@@ -106,7 +106,7 @@ func (i *indexClaimsManager) ClaimNextIndexFileSetVolumeIndex(
 	i.Lock()
 	now := xtime.ToUnixNano(i.nowFn())
 	earliestBlockStart := retention.FlushTimeStartForRetentionPeriod(
-		md.Options().RetentionOptions().RetentionPeriod(),
+		namespace.BlockRetentionOptions(md.Options()).RetentionPeriod(),
 		md.Options().IndexOptions().BlockSize(),
 		now,
 	)
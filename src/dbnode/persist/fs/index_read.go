@@ -230,6 +230,15 @@ func (r *indexReader) ReadSegmentFileSet() (
 			return nil, fmt.Errorf("unknown fileset type: %s", r.fileSetType)
 		}
 
+		var release func()
+		if r.fileSetType == persist.FileSetFlushType {
+			if err := pageInFileSetFiles(r.opts, filePath); err != nil {
+				return nil, err
+			}
+			// The file stays pinned on local disk for as long as it is mmapped.
+			release = func() { releaseFileSetFiles(r.opts, filePath) }
+		}
+
 		var (
 			fd   *os.File
 			desc mmap.Descriptor
@@ -251,13 +260,16 @@ func (r *indexReader) ReadSegmentFileSet() (
 			},
 		})
 		if err != nil {
+			if release != nil {
+				release()
+			}
 			return nil, err
 		}
 		if warning := mmapResult.Warning; warning != nil {
 			r.logger.Warn("warning while mmapping files in reader", zap.Error(warning))
 		}
 
-		file := newReadableIndexSegmentFileMmap(segFileType, fd, desc)
+		file := newReadableIndexSegmentFileMmap(segFileType, fd, desc, release)
 		result.files = append(result.files, file)
 
 		if r.opts.IndexReaderAutovalidateIndexSegments() {
@@ -400,17 +412,20 @@ type readableIndexSegmentFileMmap struct {
 	fd        *os.File
 	bytesMmap mmap.Descriptor
 	reader    bytes.Reader
+	release   func()
 }
 
 func newReadableIndexSegmentFileMmap(
 	fileType idxpersist.IndexSegmentFileType,
 	fd *os.File,
 	bytesMmap mmap.Descriptor,
+	release func(),
 ) idxpersist.IndexSegmentFile {
 	r := &readableIndexSegmentFileMmap{
 		fileType:  fileType,
 		fd:        fd,
 		bytesMmap: bytesMmap,
+		release:   release,
 	}
 	r.reader.Reset(r.bytesMmap.Bytes)
 	return r
@@ -442,6 +457,10 @@ func (f *readableIndexSegmentFileMmap) Close() error {
 		}
 		f.fd = nil
 	}
+	if f.release != nil {
+		f.release()
+		f.release = nil
+	}
 	f.reader.Reset(nil)
 	return nil
 }
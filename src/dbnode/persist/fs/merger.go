@@ -110,6 +110,7 @@ func (m *merger) Merge(
 		volume     = fileID.VolumeIndex
 		blockSize  = nsOpts.RetentionOptions().BlockSize()
 		blockStart = startTime
+		blockRange = xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)}
 		openOpts   = DataReaderOpenOptions{
 			Identifier: FileSetFileIdentifier{
 				Namespace:   nsID,
@@ -197,6 +198,18 @@ func (m *merger) Merge(
 		if hasInMemoryData {
 			segmentReaders = appendBlockReadersToSegmentReaders(segmentReaders, mergeWithData)
 		}
+		expired, err := mergeWith.Expired(tagsIter, blockStart)
+		if err != nil {
+			return closer, err
+		}
+		if expired {
+			// Series is past its retention period, drop it from the volume.
+			id.Finalize()
+			tagsIter.Close()
+			ctx.BlockingCloseReset()
+			continue
+		}
+		tombstones := tombstonesForBlock(mergeWith, id, blockRange)
 
 		// Inform the writer to finalize the ID and tag iterator once
 		// the volume is written.
@@ -209,7 +222,7 @@ func (m *merger) Merge(
 		// In the special (but common) case that we're just copying the series data from the old file
 		// into the new one without merging or adding any additional data we can avoid recalculating
 		// the checksum.
-		if len(segmentReaders) == 1 && hasInMemoryData == false {
+		if len(segmentReaders) == 1 && hasInMemoryData == false && tombstones == nil {
 			segment, err := segmentReaders[0].Segment()
 			if err != nil {
 				return closer, err
@@ -219,7 +232,8 @@ func (m *merger) Merge(
 				return closer, err
 			}
 		} else {
-			if err := persistSegmentReaders(metadata, segmentReaders, iterResources, prepared.Persist); err != nil {
+			if err := persistSegmentReaders(metadata, segmentReaders, iterResources,
+				tombstones, prepared.Persist); err != nil {
 				return closer, err
 			}
 		}
@@ -239,7 +253,9 @@ func (m *merger) Merge(
 			segmentReaders = appendBlockReadersToSegmentReaders(segmentReaders, mergeWithData.Blocks)
 
 			metadata := persist.NewMetadata(seriesMetadata)
-			err := persistSegmentReaders(metadata, segmentReaders, iterResources, prepared.Persist)
+			tombstones := tombstonesForBlock(mergeWith, ident.BytesID(seriesMetadata.ID), blockRange)
+			err := persistSegmentReaders(metadata, segmentReaders, iterResources,
+				tombstones, prepared.Persist)
 
 			if err == nil {
 				err = onFlush.OnFlushNewSeries(persist.OnFlushNewSeriesEvent{
@@ -313,27 +329,43 @@ func segmentReaderFromData(
 	return segReader
 }
 
+// tombstonesForBlock returns the tombstones of a series from the merge target
+// if any of them overlap the block being merged, and nil otherwise.
+func tombstonesForBlock(
+	mergeWith MergeWith,
+	id ident.ID,
+	blockRange xtime.Range,
+) xtime.Ranges {
+	tombstones := mergeWith.Tombstones(id)
+	if tombstones == nil || !tombstones.Overlaps(blockRange) {
+		return nil
+	}
+	return tombstones
+}
+
 func persistSegmentReaders(
 	metadata persist.Metadata,
 	segReaders []xio.SegmentReader,
 	ir iterResources,
+	tombstones xtime.Ranges,
 	persistFn persist.DataFn,
 ) error {
 	if len(segReaders) == 0 {
 		return nil
 	}
 
-	if len(segReaders) == 1 {
+	if len(segReaders) == 1 && tombstones == nil {
 		return persistSegmentReader(metadata, segReaders[0], persistFn)
 	}
 
-	return persistIter(metadata, segReaders, ir, persistFn)
+	return persistIter(metadata, segReaders, ir, tombstones, persistFn)
 }
 
 func persistIter(
 	metadata persist.Metadata,
 	segReaders []xio.SegmentReader,
 	ir iterResources,
+	tombstones xtime.Ranges,
 	persistFn persist.DataFn,
 ) error {
 	it := ir.multiIter
@@ -341,7 +373,15 @@ func persistIter(
 	encoder := ir.encoderPool.Get()
 	encoder.Reset(ir.blockStart, ir.blockAllocSize, ir.schema)
 	for it.Next() {
-		if err := encoder.Encode(it.Current()); err != nil {
+		dp, unit, annotation := it.Current()
+		if tombstones != nil && tombstones.Overlaps(xtime.Range{
+			Start: dp.TimestampNanos,
+			End:   dp.TimestampNanos + 1,
+		}) {
+			// Datapoint was deleted, drop it from the rewritten volume.
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
 			encoder.Close()
 			return err
 		}
@@ -351,6 +391,14 @@ func persistIter(
 		return err
 	}
 
+	if encoder.NumEncoded() == 0 {
+		// Every datapoint of the series in this block was deleted so the
+		// series is omitted from the volume entirely.
+		encoder.Close()
+		metadata.Finalize()
+		return nil
+	}
+
 	segment := encoder.Discard()
 	return persistSegment(metadata, segment, persistFn)
 }
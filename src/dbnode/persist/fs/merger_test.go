@@ -526,11 +526,107 @@ func writeFilesetToDisk(t *testing.T, fsID FileSetFileIdentifier, fsOpts Options
 	require.NoError(t, err)
 }
 
+func TestMergeWithTombstones(t *testing.T) {
+	// This test scenario is when series have deleted ranges in the merge
+	// target. id0 is only on disk and has part of its data deleted, id1 is
+	// on disk and in the merge target and is deleted entirely, and id2 is
+	// only in the merge target and has part of its data deleted.
+	diskData := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	diskData.Set(id0, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(0 * time.Second), Value: 0},
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 1},
+		{TimestampNanos: startTime.Add(2 * time.Second), Value: 2},
+		{TimestampNanos: startTime.Add(3 * time.Second), Value: 3},
+	}))
+	diskData.Set(id1, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(2 * time.Second), Value: 4},
+	}))
+
+	mergeTargetData := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	mergeTargetData.Set(id1, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(4 * time.Second), Value: 5},
+	}))
+	mergeTargetData.Set(id2, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 6},
+		{TimestampNanos: startTime.Add(5 * time.Second), Value: 7},
+	}))
+
+	tombstones := map[string]xtime.Ranges{
+		id0.String(): xtime.NewRanges(xtime.Range{
+			Start: startTime.Add(1 * time.Second),
+			End:   startTime.Add(3 * time.Second),
+		}),
+		id1.String(): xtime.NewRanges(xtime.Range{
+			Start: startTime,
+			End:   startTime.Add(blockSize),
+		}),
+		id2.String(): xtime.NewRanges(xtime.Range{
+			Start: startTime.Add(5 * time.Second),
+			End:   startTime.Add(blockSize),
+		}),
+	}
+
+	expected := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	expected.Set(id0, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(0 * time.Second), Value: 0},
+		{TimestampNanos: startTime.Add(3 * time.Second), Value: 3},
+	}))
+	expected.Set(id2, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 6},
+	}))
+
+	testMergeWithTombstones(t, diskData, mergeTargetData, tombstones, nil, expected)
+}
+
+func TestMergeWithExpired(t *testing.T) {
+	// This test scenario is when series on disk are past their retention
+	// period. id0 is only on disk and expired, id1 is on disk and in the
+	// merge target and expired, and id2 is only on disk and not expired.
+	diskData := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	diskData.Set(id0, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(0 * time.Second), Value: 0},
+	}))
+	diskData.Set(id1, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 1},
+	}))
+	diskData.Set(id2, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(2 * time.Second), Value: 2},
+	}))
+
+	mergeTargetData := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	mergeTargetData.Set(id1, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(3 * time.Second), Value: 3},
+	}))
+
+	expired := map[string]struct{}{
+		id0.String(): {},
+		id1.String(): {},
+	}
+
+	expected := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	expected.Set(id2, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(2 * time.Second), Value: 2},
+	}))
+
+	testMergeWithTombstones(t, diskData, mergeTargetData, nil, expired, expected)
+}
+
 func testMergeWith(
 	t *testing.T,
 	diskData *checkedBytesMap,
 	mergeTargetData *checkedBytesMap,
 	expectedData *checkedBytesMap,
+) {
+	testMergeWithTombstones(t, diskData, mergeTargetData, nil, nil, expectedData)
+}
+
+func testMergeWithTombstones(
+	t *testing.T,
+	diskData *checkedBytesMap,
+	mergeTargetData *checkedBytesMap,
+	tombstones map[string]xtime.Ranges,
+	expired map[string]struct{},
+	expectedData *checkedBytesMap,
 ) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -569,7 +665,7 @@ func testMergeWith(
 		Shard:      uint32(8),
 		BlockStart: startTime,
 	}
-	mergeWith := mockMergeWithFromData(t, ctrl, diskData, mergeTargetData)
+	mergeWith := mockMergeWithFromData(t, ctrl, diskData, mergeTargetData, tombstones, expired)
 	close, err := merger.Merge(fsID, mergeWith, 1, preparer, nsCtx, &persist.NoOpColdFlushNamespace{})
 	require.NoError(t, err)
 	require.False(t, deferClosed)
@@ -631,13 +727,15 @@ func mockReaderFromData(
 	reader := NewMockDataFileSetReader(ctrl)
 	reader.EXPECT().Open(gomock.Any()).Return(nil)
 	reader.EXPECT().Close().Return(nil)
-	tagIter := ident.NewTagsIterator(ident.NewTags(ident.StringTag("tag-key0", "tag-val0")))
 	fakeChecksum := uint32(42)
 
 	var inOrderCalls []*gomock.Call
 	for _, val := range diskData.Iter() {
 		id := val.Key()
 		data := val.Value()
+		tagIter := ident.NewTagsIterator(ident.NewTags(
+			ident.StringTag("tag-key0", "tag-val0"),
+			ident.StringTag("id", id.String())))
 		inOrderCalls = append(inOrderCalls,
 			reader.EXPECT().Read().Return(id, tagIter, data, fakeChecksum, nil))
 	}
@@ -654,8 +752,28 @@ func mockMergeWithFromData(
 	ctrl *gomock.Controller,
 	diskData *checkedBytesMap,
 	mergeTargetData *checkedBytesMap,
+	tombstones map[string]xtime.Ranges,
+	expired map[string]struct{},
 ) *MockMergeWith {
 	mergeWith := NewMockMergeWith(ctrl)
+	mergeWith.EXPECT().Tombstones(gomock.Any()).
+		DoAndReturn(func(id ident.ID) xtime.Ranges {
+			return tombstones[id.String()]
+		}).
+		AnyTimes()
+	mergeWith.EXPECT().Expired(gomock.Any(), startTime).
+		DoAndReturn(func(tags ident.TagIterator, _ xtime.UnixNano) (bool, error) {
+			iter := tags.Duplicate()
+			defer iter.Close()
+			for iter.Next() {
+				if tag := iter.Current(); tag.Name.String() == "id" {
+					_, ok := expired[tag.Value.String()]
+					return ok, nil
+				}
+			}
+			return false, iter.Err()
+		}).
+		AnyTimes()
 
 	// Get the series IDs in the merge target that does not exist in disk data.
 	// This logic is not tested here because it should be part of tests of the
@@ -53,3 +53,11 @@ func (m *noopMergeWith) ForEachRemaining(
 ) error {
 	return nil
 }
+
+func (m *noopMergeWith) Tombstones(_ ident.ID) xtime.Ranges {
+	return nil
+}
+
+func (m *noopMergeWith) Expired(_ ident.TagIterator, _ xtime.UnixNano) (bool, error) {
+	return false, nil
+}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
@@ -72,6 +73,10 @@ const (
 	// whether or not the index reader should autovalidate the index segments when
 	// opening segments. This is an expensive operation and should be done post-open.
 	defaultIndexReaderAutovalidateIndexSegments = false
+
+	// defaultMerkleTreeDepth is the default depth of the merkle trees written
+	// with data filesets
+	defaultMerkleTreeDepth = 10
 )
 
 var (
@@ -109,6 +114,8 @@ type options struct {
 	mmapReporter                         mmap.Reporter
 	indexReaderAutovalidateIndexSegments bool
 	encodingOptions                      msgpack.LegacyEncodingOptions
+	fileSetPager                         FileSetPager
+	merkleTreeDepth                      int
 }
 
 type optionsInput struct {
@@ -207,6 +214,7 @@ func NewOptions(setters ...OptionSetter) Options {
 		fstWriterOptions:                     defaultFSTWriterOptions,
 		indexReaderAutovalidateIndexSegments: defaultIndexReaderAutovalidateIndexSegments,
 		encodingOptions:                      msgpack.DefaultLegacyEncodingOptions,
+		merkleTreeDepth:                      defaultMerkleTreeDepth,
 	}
 }
 
@@ -221,6 +229,11 @@ func (o *options) Validate() error {
 			"invalid index bloom filter false positive percent, must be >= 0 and <= 1: instead %f",
 			o.indexBloomFilterFalsePositivePercent)
 	}
+	if o.merkleTreeDepth < 0 || o.merkleTreeDepth > digest.MaxMerkleTreeDepth {
+		return fmt.Errorf(
+			"invalid merkle tree depth, must be >= 0 and <= %d: instead %d",
+			digest.MaxMerkleTreeDepth, o.merkleTreeDepth)
+	}
 	if o.tagEncoderPool == nil {
 		return errTagEncoderPoolNotSet
 	}
@@ -469,3 +482,23 @@ func (o *options) SetEncodingOptions(value msgpack.LegacyEncodingOptions) Option
 func (o *options) EncodingOptions() msgpack.LegacyEncodingOptions {
 	return o.encodingOptions
 }
+
+func (o *options) SetFileSetPager(value FileSetPager) Options {
+	opts := *o
+	opts.fileSetPager = value
+	return &opts
+}
+
+func (o *options) FileSetPager() FileSetPager {
+	return o.fileSetPager
+}
+
+func (o *options) SetMerkleTreeDepth(value int) Options {
+	opts := *o
+	opts.merkleTreeDepth = value
+	return &opts
+}
+
+func (o *options) MerkleTreeDepth() int {
+	return o.merkleTreeDepth
+}
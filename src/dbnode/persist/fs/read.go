@@ -87,6 +87,10 @@ type reader struct {
 
 	bloomFilterFd *os.File
 
+	// pinned are the fileset files paged in for the reader, which must not
+	// be evicted from local disk until the reader is closed.
+	pinned []string
+
 	entries         int
 	bloomFilterInfo schema.IndexBloomFilterInfo
 	entriesRead     int
@@ -207,6 +211,14 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 	}
 	r.expectedDigestOfDigest = digest
 
+	if opts.FileSetType == persist.FileSetFlushType {
+		pinned := []string{bloomFilterFilepath, indexFilepath, dataFilepath}
+		if err := pageInFileSetFiles(r.opts, pinned...); err != nil {
+			return err
+		}
+		r.pinned = pinned
+	}
+
 	var infoFd, digestFd *os.File
 	err = openFiles(os.Open, map[string]**os.File{
 		infoFilepath:        &infoFd,
@@ -214,6 +226,7 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 		bloomFilterFilepath: &r.bloomFilterFd,
 	})
 	if err != nil {
+		r.releasePinned()
 		return err
 	}
 
@@ -257,6 +270,7 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 		},
 	})
 	if err != nil {
+		r.releasePinned()
 		return err
 	}
 
@@ -600,6 +614,7 @@ func (r *reader) Close() error {
 	multiErr = multiErr.Add(r.indexFd.Close())
 	multiErr = multiErr.Add(r.dataFd.Close())
 	multiErr = multiErr.Add(r.bloomFilterFd.Close())
+	r.releasePinned()
 	r.indexDecoderStream.Reset(nil)
 	r.dataReader.Reset(nil)
 	for i := 0; i < len(r.indexEntriesByOffsetAsc); i++ {
@@ -643,6 +658,13 @@ func (r *reader) Close() error {
 	return multiErr.FinalError()
 }
 
+func (r *reader) releasePinned() {
+	if r.pinned != nil {
+		releaseFileSetFiles(r.opts, r.pinned...)
+		r.pinned = nil
+	}
+}
+
 // indexEntriesByOffsetAsc implements sort.Sort
 type indexEntriesByOffsetAsc []schema.IndexEntry
 
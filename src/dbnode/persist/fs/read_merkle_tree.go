@@ -0,0 +1,71 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// ReadMerkleTree reads the merkle tree of the series checksums written with
+// the latest complete volume of the data fileset of a shard block, returning
+// false if there is no such fileset or it was written without a merkle tree.
+func ReadMerkleTree(
+	filePathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+	blockStart xtime.UnixNano,
+) (*digest.MerkleTree, bool, error) {
+	filesets, err := DataFiles(filePathPrefix, namespace, shard)
+	if err != nil {
+		return nil, false, err
+	}
+
+	fileset, ok := filesets.LatestVolumeForBlock(blockStart)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var filePath string
+	for _, path := range fileset.AbsoluteFilePaths {
+		if strings.HasSuffix(path, separator+MerkleFileSuffix+fileSuffix) {
+			filePath = path
+		}
+	}
+	if filePath == "" {
+		return nil, false, nil
+	}
+
+	data, err := ioutil.ReadFile(filePath) // nolint: gosec
+	if err != nil {
+		return nil, false, err
+	}
+
+	tree, err := digest.UnmarshalMerkleTree(data)
+	if err != nil {
+		return nil, false, err
+	}
+	return tree, true, nil
+}
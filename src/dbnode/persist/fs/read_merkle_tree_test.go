@@ -0,0 +1,90 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+)
+
+func TestReadMerkleTree(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	entries := []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+		{"bar", nil, []byte{4, 5, 6}},
+		{"baz", nil, []byte{7, 8, 9}},
+	}
+
+	_, ok, err := ReadMerkleTree(filePathPrefix, testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+
+	tree, ok, err := ReadMerkleTree(filePathPrefix, testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, defaultMerkleTreeDepth, tree.Depth())
+
+	expected, err := digest.NewMerkleTree(defaultMerkleTreeDepth)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		expected.Add([]byte(entry.id), digest.Checksum(entry.data))
+	}
+	require.Equal(t, expected.Root(), tree.Root())
+
+	// A later volume takes precedence.
+	writeTestDataWithVolume(t, w, 0, testWriterStart, 1, entries[:1], persist.FileSetFlushType)
+
+	tree, ok, err = ReadMerkleTree(filePathPrefix, testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NotEqual(t, expected.Root(), tree.Root())
+}
+
+func TestReadMerkleTreeDisabled(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	w, err := NewWriter(testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetWriterBufferSize(testWriterBufferSize).
+		SetMerkleTreeDepth(0))
+	require.NoError(t, err)
+	writeTestData(t, w, 0, testWriterStart, []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+	}, persist.FileSetFlushType)
+
+	_, ok, err := ReadMerkleTree(filePathPrefix, testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/x/ident"
+	xos "github.com/m3db/m3/src/x/os"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	retentionCutoffsFileName    = "retention-cutoffs.json"
+	retentionCutoffsTmpFileName = retentionCutoffsFileName + ".tmp"
+)
+
+type retentionCutoffEntry struct {
+	RetentionPeriodNanos int64 `json:"retentionPeriodNanos"`
+	CutoffNanos          int64 `json:"cutoffNanos"`
+}
+
+// ShardRetentionCutoffsFilePath returns the path to the retention cutoffs
+// file of a shard.
+func ShardRetentionCutoffsFilePath(prefix string, namespace ident.ID, shard uint32) string {
+	return path.Join(ShardDataDirPath(prefix, namespace, shard), retentionCutoffsFileName)
+}
+
+// WriteShardRetentionCutoffs atomically replaces the retention cutoffs file
+// of a shard, which maps each retention period of the retention rules of a
+// namespace to the block start before which the filesets of the shard were
+// rewritten without the series expired by that retention period. The file
+// is removed if there are no cutoffs.
+func WriteShardRetentionCutoffs(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	cutoffs map[time.Duration]xtime.UnixNano,
+) error {
+	var (
+		prefix   = opts.FilePathPrefix()
+		shardDir = ShardDataDirPath(prefix, namespace, shard)
+		filePath = path.Join(shardDir, retentionCutoffsFileName)
+	)
+	if len(cutoffs) == 0 {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	entries := make([]retentionCutoffEntry, 0, len(cutoffs))
+	for retentionPeriod, cutoff := range cutoffs {
+		entries = append(entries, retentionCutoffEntry{
+			RetentionPeriodNanos: int64(retentionPeriod),
+			CutoffNanos:          int64(cutoff),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].RetentionPeriodNanos < entries[j].RetentionPeriodNanos
+	})
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(shardDir, opts.NewDirectoryMode()); err != nil {
+		return err
+	}
+	tmpPath := path.Join(shardDir, retentionCutoffsTmpFileName)
+	if err := xos.WriteFileSync(tmpPath, data, opts.NewFileMode()); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// ReadShardRetentionCutoffs reads the retention cutoffs file of a shard,
+// returning no cutoffs if the file does not exist.
+func ReadShardRetentionCutoffs(
+	prefix string,
+	namespace ident.ID,
+	shard uint32,
+) (map[time.Duration]xtime.UnixNano, error) {
+	filePath := ShardRetentionCutoffsFilePath(prefix, namespace, shard)
+	data, err := ioutil.ReadFile(filePath) // nolint: gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []retentionCutoffEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	cutoffs := make(map[time.Duration]xtime.UnixNano, len(entries))
+	for _, entry := range entries {
+		cutoffs[time.Duration(entry.RetentionPeriodNanos)] = xtime.UnixNano(entry.CutoffNanos)
+	}
+	return cutoffs, nil
+}
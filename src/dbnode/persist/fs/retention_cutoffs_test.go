@@ -0,0 +1,62 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardRetentionCutoffsRoundTrip(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts  = NewOptions().SetFilePathPrefix(dir)
+		nsID  = ident.StringID("testns")
+		shard = uint32(3)
+		start = xtime.Now().Truncate(time.Hour)
+	)
+
+	read, err := ReadShardRetentionCutoffs(dir, nsID, shard)
+	require.NoError(t, err)
+	require.Empty(t, read)
+
+	written := map[time.Duration]xtime.UnixNano{
+		6 * time.Hour:  start,
+		24 * time.Hour: start.Add(-18 * time.Hour),
+	}
+	require.NoError(t, WriteShardRetentionCutoffs(opts, nsID, shard, written))
+
+	read, err = ReadShardRetentionCutoffs(dir, nsID, shard)
+	require.NoError(t, err)
+	require.Equal(t, written, read)
+
+	require.NoError(t, WriteShardRetentionCutoffs(opts, nsID, shard, nil))
+	_, err = os.Stat(ShardRetentionCutoffsFilePath(dir, nsID, shard))
+	require.True(t, os.IsNotExist(err))
+}
@@ -51,6 +51,7 @@ import (
 	xtime "github.com/m3db/m3/src/x/time"
 
 	"github.com/uber-go/tally"
+	xatomic "go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -387,6 +388,9 @@ func (r *blockRetriever) fetchBatch(
 			continue
 		}
 
+		if req.bytesRead != nil {
+			req.bytesRead.Add(int64(entry.Size))
+		}
 		if err := r.bytesReadLimit.Inc(int(entry.Size), req.source); err != nil {
 			req.err = err
 			limitErr = err
@@ -598,6 +602,9 @@ func (r *blockRetriever) Stream(
 	if source, ok := req.stdCtx.Value(limits.SourceContextKey).([]byte); ok {
 		req.source = source
 	}
+	if bytesRead, ok := req.stdCtx.Value(limits.BytesReadContextKey).(*xatomic.Int64); ok {
+		req.bytesRead = bytesRead
+	}
 
 	err = r.streamRequest(ctx, req, shard, id, startTime)
 	if err != nil {
@@ -709,6 +716,7 @@ type retrieveRequest struct {
 	onRetrieve block.OnRetrieveBlock
 	nsCtx      namespace.Context
 	source     []byte
+	bytesRead  *xatomic.Int64
 	stdCtx     stdctx.Context
 
 	indexEntry IndexEntry
@@ -864,6 +872,7 @@ func (req *retrieveRequest) resetForReuse() {
 	req.finalized = false
 	req.finalizes = 0
 	req.source = nil
+	req.bytesRead = nil
 	req.shard = 0
 	req.id = nil
 	req.tags = ident.EmptyTagIterator
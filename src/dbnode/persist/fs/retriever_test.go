@@ -43,6 +43,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/x/checked"
@@ -57,6 +58,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	xatomic "go.uber.org/atomic"
 )
 
 type testBlockRetrieverOptions struct {
@@ -800,6 +802,53 @@ func testBlockRetrieverOnRetrieve(t *testing.T, globalFlag bool, nsFlag bool) {
 	}
 }
 
+func TestBlockRetrieverCountsBytesReadInContext(t *testing.T) {
+	// Make sure reader/writer are looking at the same test directory.
+	dir, err := ioutil.TempDir("", "testdb")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filePathPrefix := filepath.Join(dir, "")
+
+	// Setup constants and config.
+	fsOpts := testDefaultOpts.SetFilePathPrefix(filePathPrefix)
+	rOpts := testNs1Metadata(t).Options().RetentionOptions()
+	nsCtx := namespace.NewContextFrom(testNs1Metadata(t))
+	shard := uint32(0)
+	blockStart := xtime.Now().Truncate(rOpts.BlockSize())
+
+	// Setup the reader.
+	opts := testBlockRetrieverOptions{
+		retrieverOpts: defaultTestBlockRetrieverOptions,
+		fsOpts:        fsOpts,
+		shards:        []uint32{shard},
+	}
+	retriever, cleanup := newOpenTestBlockRetriever(t, testNs1Metadata(t), opts)
+	defer cleanup()
+
+	// Write out a test file.
+	w, closer := newOpenTestWriter(t, fsOpts, shard, blockStart, 0)
+	data := checked.NewBytes([]byte("Hello world!"), nil)
+	data.IncRef()
+	defer data.DecRef()
+
+	metadata := persist.NewMetadataFromIDAndTags(ident.StringID("foo"), ident.Tags{},
+		persist.MetadataOptions{})
+	require.NoError(t, w.Write(metadata, data, digest.Checksum(data.Bytes())))
+	closer()
+
+	bytesRead := xatomic.NewInt64(0)
+	ctx := context.NewWithGoContext(
+		stdctx.WithValue(stdctx.Background(), limits.BytesReadContextKey, bytesRead))
+	defer ctx.Close()
+
+	segmentReader, err := retriever.Stream(ctx, shard,
+		ident.StringID("foo"), blockStart, nil, nsCtx)
+	require.NoError(t, err)
+	_, err = segmentReader.Segment()
+	require.NoError(t, err)
+	require.Equal(t, int64(len("Hello world!")), bytesRead.Load())
+}
+
 // TestBlockRetrieverHandlesErrors verifies the behavior of the Stream() method
 // on the retriever in the case where the SeekIndexEntry function returns an
 // error.
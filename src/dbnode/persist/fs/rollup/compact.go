@@ -0,0 +1,427 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rollup
+
+import (
+	"errors"
+	"io"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/checked"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var errSumCountMismatch = errors.New("sum and count rollups do not match")
+
+// bucket holds the aggregations of the datapoints of a series within one
+// resolution.
+type bucket struct {
+	start xtime.UnixNano
+	min   float64
+	max   float64
+	sum   float64
+	count float64
+	// counter are the datapoints kept by the counter rollup, at their own
+	// timestamps rather than the start of the resolution.
+	counter []counterPoint
+}
+
+// counterPoint is a datapoint kept by the counter rollup along with the
+// unit it was written with.
+type counterPoint struct {
+	dp   ts.Datapoint
+	unit xtime.Unit
+}
+
+func (b bucket) value(aggregation Aggregation) float64 {
+	switch aggregation {
+	case Min:
+		return b.min
+	case Max:
+		return b.max
+	case Sum:
+		return b.sum
+	default:
+		return b.count
+	}
+}
+
+// compactBlock reads the raw fileset of a block and writes its rollups,
+// one fileset per aggregation with the same volume index as the raw one.
+func (m *manager) compactBlock(
+	id fs.FileSetFileIdentifier,
+	blockSize time.Duration,
+	resolution time.Duration,
+) error {
+	reader, err := fs.NewReader(nil, m.fsOpts)
+	if err != nil {
+		return err
+	}
+	if err := reader.Open(fs.DataReaderOpenOptions{
+		Identifier:  id,
+		FileSetType: persist.FileSetFlushType,
+	}); err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	writers := make([]fs.DataFileSetWriter, 0, len(Aggregations))
+	for _, aggregation := range Aggregations {
+		prefix := FilePathPrefix(m.filePathPrefix, resolution, aggregation)
+		writer, err := fs.NewWriter(m.fsOpts.SetFilePathPrefix(prefix))
+		if err == nil {
+			err = writer.Open(fs.DataWriterOpenOptions{
+				FileSetType: persist.FileSetFlushType,
+				Identifier:  id,
+				BlockSize:   blockSize,
+			})
+		}
+		if err != nil {
+			return m.abortCompaction(writers, id, resolution, err)
+		}
+		writers = append(writers, writer)
+	}
+
+	if err := m.writeRollups(reader, writers, resolution); err != nil {
+		return m.abortCompaction(writers, id, resolution, err)
+	}
+
+	// Closing a writer writes its checkpoint file. Count is closed last so a
+	// complete count fileset marks the block as compacted.
+	multiErr := xerrors.NewMultiError()
+	for _, writer := range writers {
+		multiErr = multiErr.Add(writer.Close())
+	}
+	if err := multiErr.FinalError(); err != nil {
+		return m.abortCompaction(nil, id, resolution, err)
+	}
+	return nil
+}
+
+// abortCompaction closes the writers of a failed compaction and removes
+// whatever they wrote.
+func (m *manager) abortCompaction(
+	writers []fs.DataFileSetWriter,
+	id fs.FileSetFileIdentifier,
+	resolution time.Duration,
+	err error,
+) error {
+	multiErr := xerrors.NewMultiError().Add(err)
+	for _, writer := range writers {
+		multiErr = multiErr.Add(writer.Close())
+	}
+	for _, aggregation := range Aggregations {
+		prefix := FilePathPrefix(m.filePathPrefix, resolution, aggregation)
+		filesets, err := fs.DataFiles(prefix, id.Namespace, id.Shard)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		for _, fileset := range filesets {
+			if fileset.ID.BlockStart.Equal(id.BlockStart) &&
+				fileset.ID.VolumeIndex == id.VolumeIndex {
+				multiErr = multiErr.Add(fs.DeleteFiles(fileset.AbsoluteFilePaths))
+			}
+		}
+	}
+	return multiErr.FinalError()
+}
+
+func (m *manager) writeRollups(
+	reader fs.DataFileSetReader,
+	writers []fs.DataFileSetWriter,
+	resolution time.Duration,
+) error {
+	unit := unitForResolution(resolution)
+	for {
+		id, tagsIter, data, _, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		tags, err := copyTags(tagsIter)
+		if err != nil {
+			return err
+		}
+
+		data.IncRef()
+		buckets, err := rollup(data.Bytes(), resolution, m.encodingOpts)
+		data.DecRef()
+		if err != nil {
+			return err
+		}
+		if len(buckets) == 0 {
+			continue
+		}
+
+		metadata := persist.NewMetadataFromIDAndTags(id, tags, persist.MetadataOptions{})
+		for i, aggregation := range Aggregations {
+			encoded, err := encodeBuckets(buckets, aggregation, unit, m.encodingOpts)
+			if err != nil {
+				return err
+			}
+			bytes := checked.NewBytes(encoded, nil)
+			bytes.IncRef()
+			err = writers[i].Write(metadata, bytes, digest.Checksum(encoded))
+			bytes.DecRef()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// rollup aggregates the datapoints of an encoded series into buckets of the
+// resolution, skipping NaN values such as staleness markers.
+func rollup(
+	data []byte,
+	resolution time.Duration,
+	opts encoding.Options,
+) ([]bucket, error) {
+	iter := m3tsz.NewReaderIterator(xio.NewBytesReader64(data),
+		m3tsz.DefaultIntOptimizationEnabled, opts)
+	defer iter.Close()
+
+	var buckets []bucket
+	for iter.Next() {
+		dp, unit, _ := iter.Current()
+		v := dp.Value
+		if math.IsNaN(v) {
+			continue
+		}
+		point := counterPoint{dp: dp, unit: unit}
+		start := dp.TimestampNanos.Truncate(resolution)
+		if n := len(buckets); n > 0 && buckets[n-1].start.Equal(start) {
+			b := &buckets[n-1]
+			b.min = math.Min(b.min, v)
+			b.max = math.Max(b.max, v)
+			b.sum += v
+			b.count++
+			b.counter = append(b.counter, point)
+			continue
+		}
+		buckets = append(buckets, bucket{
+			start:   start,
+			min:     v,
+			max:     v,
+			sum:     v,
+			count:   1,
+			counter: []counterPoint{point},
+		})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	rollupCounters(buckets)
+	return buckets, nil
+}
+
+// rollupCounters replaces the datapoints of each bucket with those kept by
+// the counter rollup. The first datapoint of the block is also kept so that
+// a counter reset between two blocks is seen.
+func rollupCounters(buckets []bucket) {
+	if len(buckets) == 0 {
+		return
+	}
+	var (
+		first = buckets[0].counter[0]
+		prev  = first.dp.Value
+	)
+	for i := range buckets {
+		points := buckets[i].counter
+		if i == 0 {
+			points = points[1:]
+		}
+		kept := counterRollup(prev, points)
+		if i == 0 {
+			kept = append([]counterPoint{first}, kept...)
+		}
+		prev = buckets[i].counter[len(buckets[i].counter)-1].dp.Value
+		buckets[i].counter = kept
+	}
+}
+
+// counterRollup returns the datapoints of a resolution kept by the counter
+// rollup, given the value of the datapoint kept before them. The last
+// datapoint is kept and, if the counter was reset, the datapoints before and
+// after the last reset. The value before the reset is raised by the
+// increases up to it, including those of earlier resets, so the increase
+// across the datapoints kept is the same as across all of them.
+func counterRollup(prev float64, points []counterPoint) []counterPoint {
+	n := len(points)
+	if n == 0 {
+		return nil
+	}
+
+	var (
+		last            = prev
+		increase        float64
+		beforeLastReset float64
+		lastReset       = -1
+	)
+	for i, p := range points {
+		v := p.dp.Value
+		if v < last {
+			// Counter reset, the whole value is an increase.
+			lastReset = i
+			beforeLastReset = increase
+			increase += v
+		} else {
+			increase += v - last
+		}
+		last = v
+	}
+
+	if lastReset < 0 {
+		return []counterPoint{points[n-1]}
+	}
+
+	kept := make([]counterPoint, 0, 3)
+	if lastReset > 0 {
+		p := points[lastReset-1]
+		p.dp.Value = prev + beforeLastReset
+		kept = append(kept, p)
+	}
+	kept = append(kept, points[lastReset])
+	if lastReset < n-1 {
+		kept = append(kept, points[n-1])
+	}
+	return kept
+}
+
+func encodeBuckets(
+	buckets []bucket,
+	aggregation Aggregation,
+	unit xtime.Unit,
+	opts encoding.Options,
+) ([]byte, error) {
+	encoder := m3tsz.NewEncoder(buckets[0].start, nil,
+		m3tsz.DefaultIntOptimizationEnabled, opts)
+	for _, b := range buckets {
+		if aggregation == Counter {
+			for _, p := range b.counter {
+				if err := encoder.Encode(p.dp, p.unit, nil); err != nil {
+					encoder.Close()
+					return nil, err
+				}
+			}
+			continue
+		}
+		dp := ts.Datapoint{TimestampNanos: b.start, Value: b.value(aggregation)}
+		if err := encoder.Encode(dp, unit, nil); err != nil {
+			encoder.Close()
+			return nil, err
+		}
+	}
+	return segmentBytes(encoder.Discard()), nil
+}
+
+// average decodes the sum and count rollups of a series and encodes their
+// quotient.
+func average(
+	blockStart xtime.UnixNano,
+	resolution time.Duration,
+	sum, count []byte,
+	opts encoding.Options,
+) (ts.Segment, error) {
+	sumIter := m3tsz.NewReaderIterator(xio.NewBytesReader64(sum),
+		m3tsz.DefaultIntOptimizationEnabled, opts)
+	defer sumIter.Close()
+	countIter := m3tsz.NewReaderIterator(xio.NewBytesReader64(count),
+		m3tsz.DefaultIntOptimizationEnabled, opts)
+	defer countIter.Close()
+
+	var (
+		unit    = unitForResolution(resolution)
+		encoder = m3tsz.NewEncoder(blockStart, nil,
+			m3tsz.DefaultIntOptimizationEnabled, opts)
+	)
+	for sumIter.Next() {
+		if !countIter.Next() {
+			encoder.Close()
+			return ts.Segment{}, errSumCountMismatch
+		}
+		s, _, _ := sumIter.Current()
+		c, _, _ := countIter.Current()
+		if !s.TimestampNanos.Equal(c.TimestampNanos) {
+			encoder.Close()
+			return ts.Segment{}, errSumCountMismatch
+		}
+		dp := ts.Datapoint{TimestampNanos: s.TimestampNanos, Value: s.Value / c.Value}
+		if err := encoder.Encode(dp, unit, nil); err != nil {
+			encoder.Close()
+			return ts.Segment{}, err
+		}
+	}
+	if err := xerrors.FirstError(sumIter.Err(), countIter.Err()); err != nil {
+		encoder.Close()
+		return ts.Segment{}, err
+	}
+	return encoder.Discard(), nil
+}
+
+// segmentBytes returns the contiguous bytes of an encoded segment.
+func segmentBytes(segment ts.Segment) []byte {
+	var result []byte
+	for _, b := range []checked.Bytes{segment.Head, segment.Tail} {
+		if b == nil {
+			continue
+		}
+		b.IncRef()
+		result = append(result, b.Bytes()...)
+		b.DecRef()
+	}
+	return result
+}
+
+func copyTags(iter ident.TagIterator) (ident.Tags, error) {
+	defer iter.Close()
+	tags := ident.NewTags()
+	for iter.Next() {
+		tag := iter.Current()
+		tags.Append(ident.StringTag(tag.Name.String(), tag.Value.String()))
+	}
+	return tags, iter.Err()
+}
+
+func unitForResolution(resolution time.Duration) xtime.Unit {
+	switch {
+	case resolution%time.Second == 0:
+		return xtime.Second
+	case resolution%time.Millisecond == 0:
+		return xtime.Millisecond
+	default:
+		return xtime.Nanosecond
+	}
+}
@@ -0,0 +1,564 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rollup
+
+import (
+	"container/list"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/checked"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/pool"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	// rollupsDirName is the directory under the file path prefix that holds
+	// the rollup filesets, laid out like the data directory under
+	// rollups/<resolution>/<aggregation>.
+	rollupsDirName = "rollups"
+)
+
+type managerMetrics struct {
+	compacted      tally.Counter
+	compactErrors  tally.Counter
+	deleted        tally.Counter
+	blocksRead     tally.Counter
+	fileSetsOpened tally.Counter
+}
+
+func newManagerMetrics(scope tally.Scope) managerMetrics {
+	return managerMetrics{
+		compacted:      scope.Counter("compacted"),
+		compactErrors:  scope.Counter("compact-errors"),
+		deleted:        scope.Counter("deleted"),
+		blocksRead:     scope.Counter("blocks-read"),
+		fileSetsOpened: scope.Counter("filesets-opened"),
+	}
+}
+
+type shardKey struct {
+	namespace  string
+	shard      uint32
+	resolution time.Duration
+}
+
+type fileSetKey struct {
+	shardKey
+	blockStart  xtime.UnixNano
+	aggregation Aggregation
+}
+
+// openFileSet is a rollup fileset held open for reads. The seeker is not
+// safe for concurrent use so seeks are serialized, and it is only closed
+// once it has been evicted and is no longer referenced.
+type openFileSet struct {
+	sync.Mutex
+
+	key     fileSetKey
+	volume  int
+	seeker  fs.DataFileSetSeeker
+	refs    int
+	evicted bool
+}
+
+type manager struct {
+	sync.Mutex
+
+	fsOpts          fs.Options
+	filePathPrefix  string
+	maxOpenFileSets int
+	encodingOpts    encoding.Options
+	bytesPool       pool.CheckedBytesPool
+	resources       sync.Pool
+	metrics         managerMetrics
+	logger          *zap.Logger
+
+	// compacted holds the volume index of the compacted blocks of each
+	// shard, loaded from disk on first use.
+	compacted map[shardKey]map[xtime.UnixNano]int
+
+	// open holds the filesets open for reads ordered from most to least
+	// recently used.
+	open      *list.List
+	openByKey map[fileSetKey]*list.Element
+}
+
+// NewManager returns a new rollup manager.
+func NewManager(opts Options) (Manager, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	var (
+		fsOpts    = opts.FilesystemOptions()
+		iOpts     = opts.InstrumentOptions()
+		bytesPool = pool.NewCheckedBytesPool([]pool.Bucket{
+			{Count: 4096, Capacity: 128},
+		}, nil, func(s []pool.Bucket) pool.BytesPool {
+			return pool.NewBytesPool(s, nil)
+		})
+	)
+	bytesPool.Init()
+	return &manager{
+		fsOpts:          fsOpts,
+		filePathPrefix:  fsOpts.FilePathPrefix(),
+		maxOpenFileSets: opts.MaxOpenFileSets(),
+		encodingOpts:    encoding.NewOptions(),
+		bytesPool:       bytesPool,
+		resources: sync.Pool{New: func() interface{} {
+			return fs.NewReusableSeekerResources(fsOpts)
+		}},
+		metrics:   newManagerMetrics(iOpts.MetricsScope().SubScope("rollup")),
+		logger:    iOpts.Logger(),
+		compacted: make(map[shardKey]map[xtime.UnixNano]int),
+		open:      list.New(),
+		openByKey: make(map[fileSetKey]*list.Element),
+	}, nil
+}
+
+// FilePathPrefix returns the file path prefix the rollup filesets of an
+// aggregation at a resolution are written under.
+func FilePathPrefix(
+	filePathPrefix string,
+	resolution time.Duration,
+	aggregation Aggregation,
+) string {
+	return filepath.Join(filePathPrefix, rollupsDirName,
+		resolution.String(), aggregation.String())
+}
+
+func (m *manager) Compact(md namespace.Metadata, shards []uint32, now xtime.UnixNano) error {
+	rollupOpts := md.Options().RollupOptions()
+	if !rollupOpts.Enabled() {
+		return nil
+	}
+
+	var (
+		nsID       = md.ID()
+		blockSize  = md.Options().RetentionOptions().BlockSize()
+		resolution = rollupOpts.Resolution()
+		cutoff     = now.Add(-rollupOpts.RollupAfter())
+		multiErr   = xerrors.NewMultiError()
+	)
+	for _, shard := range shards {
+		key := shardKey{namespace: nsID.String(), shard: shard, resolution: resolution}
+		multiErr = multiErr.Add(m.compactShard(nsID, key, blockSize, cutoff))
+	}
+	multiErr = multiErr.Add(m.deleteOtherResolutions(nsID, resolution))
+	return multiErr.FinalError()
+}
+
+// compactShard compacts the blocks of a shard that ended before the cutoff
+// and whose latest volume has not been compacted yet, then deletes any
+// rollup fileset that is not of the latest volume of an existing block.
+func (m *manager) compactShard(
+	nsID ident.ID,
+	key shardKey,
+	blockSize time.Duration,
+	cutoff xtime.UnixNano,
+) error {
+	raw, err := fs.DataFiles(m.filePathPrefix, nsID, key.shard)
+	if err != nil {
+		return err
+	}
+	compacted, err := m.loadCompacted(nsID, key)
+	if err != nil {
+		return err
+	}
+
+	var (
+		latest   = raw.LatestVolumes()
+		onDisk   = make(map[xtime.UnixNano]struct{}, len(latest))
+		multiErr = xerrors.NewMultiError()
+	)
+	for _, file := range latest {
+		onDisk[file.ID.BlockStart] = struct{}{}
+	}
+
+	for _, file := range latest {
+		blockStart, volume := file.ID.BlockStart, file.ID.VolumeIndex
+		if blockStart.Add(blockSize).After(cutoff) {
+			break
+		}
+		if v, ok := compacted[blockStart]; ok && v == volume {
+			continue
+		}
+
+		id := fs.FileSetFileIdentifier{
+			Namespace:   nsID,
+			Shard:       key.shard,
+			BlockStart:  blockStart,
+			VolumeIndex: volume,
+		}
+		if err := m.compactBlock(id, blockSize, key.resolution); err != nil {
+			m.metrics.compactErrors.Inc(1)
+			m.logger.Error("could not compact block into rollups",
+				zap.Stringer("namespace", nsID),
+				zap.Uint32("shard", key.shard),
+				zap.Time("blockStart", blockStart.ToTime()),
+				zap.Int("volume", volume),
+				zap.Error(err))
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		compacted[blockStart] = volume
+		m.metrics.compacted.Inc(1)
+	}
+
+	for blockStart := range compacted {
+		if _, ok := onDisk[blockStart]; !ok {
+			delete(compacted, blockStart)
+		}
+	}
+
+	m.Lock()
+	m.compacted[key] = compacted
+	for fileSetKey, elem := range m.openByKey {
+		if fileSetKey.shardKey != key {
+			continue
+		}
+		f := elem.Value.(*openFileSet)
+		if v, ok := compacted[fileSetKey.blockStart]; !ok || v != f.volume {
+			m.removeWithLock(elem)
+		}
+	}
+	m.Unlock()
+
+	for _, aggregation := range Aggregations {
+		prefix := FilePathPrefix(m.filePathPrefix, key.resolution, aggregation)
+		filesets, err := fs.DataFiles(prefix, nsID, key.shard)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		for _, fileset := range filesets {
+			v, ok := compacted[fileset.ID.BlockStart]
+			if ok && v == fileset.ID.VolumeIndex {
+				continue
+			}
+			if err := fs.DeleteFiles(fileset.AbsoluteFilePaths); err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+			m.metrics.deleted.Inc(1)
+		}
+	}
+
+	return multiErr.FinalError()
+}
+
+// deleteOtherResolutions removes the rollups of a namespace written at a
+// resolution other than the current one.
+func (m *manager) deleteOtherResolutions(nsID ident.ID, resolution time.Duration) error {
+	rollupsDir := filepath.Join(m.filePathPrefix, rollupsDirName)
+	entries, err := os.ReadDir(rollupsDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == resolution.String() {
+			continue
+		}
+		for _, aggregation := range Aggregations {
+			prefix := filepath.Join(rollupsDir, entry.Name(), aggregation.String())
+			nsDir := fs.NamespaceDataDirPath(prefix, nsID)
+			if _, err := os.Stat(nsDir); os.IsNotExist(err) {
+				continue
+			}
+			multiErr = multiErr.Add(os.RemoveAll(nsDir))
+		}
+	}
+	return multiErr.FinalError()
+}
+
+// loadCompacted returns a copy of the compacted blocks of a shard, listing
+// them from disk if they are not known yet.
+func (m *manager) loadCompacted(nsID ident.ID, key shardKey) (map[xtime.UnixNano]int, error) {
+	m.Lock()
+	compacted, ok := m.compacted[key]
+	m.Unlock()
+	if !ok {
+		prefix := FilePathPrefix(m.filePathPrefix, key.resolution, Count)
+		filesets, err := fs.DataFiles(prefix, nsID, key.shard)
+		if err != nil {
+			return nil, err
+		}
+		compacted = make(map[xtime.UnixNano]int, len(filesets))
+		for _, fileset := range filesets.LatestVolumes() {
+			compacted[fileset.ID.BlockStart] = fileset.ID.VolumeIndex
+		}
+
+		m.Lock()
+		if existing, ok := m.compacted[key]; ok {
+			compacted = existing
+		} else {
+			m.compacted[key] = compacted
+		}
+		m.Unlock()
+	}
+
+	m.Lock()
+	result := make(map[xtime.UnixNano]int, len(compacted))
+	for blockStart, volume := range compacted {
+		result[blockStart] = volume
+	}
+	m.Unlock()
+	return result, nil
+}
+
+func (m *manager) ReadBlock(
+	md namespace.Metadata,
+	shard uint32,
+	id ident.ID,
+	blockStart xtime.UnixNano,
+	aggregation Aggregation,
+) ([]xio.BlockReader, bool, error) {
+	rollupOpts := md.Options().RollupOptions()
+	if !rollupOpts.Enabled() {
+		return nil, false, nil
+	}
+
+	var (
+		nsID      = md.ID()
+		blockSize = md.Options().RetentionOptions().BlockSize()
+		key       = shardKey{
+			namespace:  nsID.String(),
+			shard:      shard,
+			resolution: rollupOpts.Resolution(),
+		}
+	)
+	volume, ok, err := m.compactedVolume(nsID, key, blockStart)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	fileSetKey := fileSetKey{shardKey: key, blockStart: blockStart}
+	reader, ok, err := m.readBlock(nsID, fileSetKey, volume, id, blockSize, aggregation)
+	if err != nil {
+		return nil, false, err
+	}
+	m.metrics.blocksRead.Inc(1)
+	if !ok {
+		return nil, true, nil
+	}
+	return []xio.BlockReader{reader}, true, nil
+}
+
+// compactedVolume returns the volume of a block that was compacted into
+// rollups, if it was.
+func (m *manager) compactedVolume(
+	nsID ident.ID,
+	key shardKey,
+	blockStart xtime.UnixNano,
+) (int, bool, error) {
+	m.Lock()
+	compacted, ok := m.compacted[key]
+	if ok {
+		volume, ok := compacted[blockStart]
+		m.Unlock()
+		return volume, ok, nil
+	}
+	m.Unlock()
+
+	compacted, err := m.loadCompacted(nsID, key)
+	if err != nil {
+		return 0, false, err
+	}
+	volume, ok := compacted[blockStart]
+	return volume, ok, nil
+}
+
+// readBlock returns a block reader over an aggregation of the rollups of a
+// series within a compacted block.
+func (m *manager) readBlock(
+	nsID ident.ID,
+	key fileSetKey,
+	volume int,
+	id ident.ID,
+	blockSize time.Duration,
+	aggregation Aggregation,
+) (xio.BlockReader, bool, error) {
+	var segment ts.Segment
+	if aggregation == Avg {
+		key.aggregation = Sum
+		sum, err := m.seek(nsID, key, volume, id)
+		if err != nil || sum == nil {
+			return xio.BlockReader{}, false, err
+		}
+		key.aggregation = Count
+		count, err := m.seek(nsID, key, volume, id)
+		if err != nil || count == nil {
+			return xio.BlockReader{}, false, err
+		}
+		segment, err = average(key.blockStart, key.resolution, sum, count, m.encodingOpts)
+		if err != nil {
+			return xio.BlockReader{}, false, err
+		}
+	} else {
+		key.aggregation = aggregation
+		data, err := m.seek(nsID, key, volume, id)
+		if err != nil || data == nil {
+			return xio.BlockReader{}, false, err
+		}
+		segment = ts.NewSegment(checked.NewBytes(data, nil), nil, 0, ts.FinalizeNone)
+	}
+
+	return xio.BlockReader{
+		SegmentReader: xio.NewSegmentReader(segment),
+		Start:         key.blockStart,
+		BlockSize:     blockSize,
+	}, true, nil
+}
+
+// seek returns the data of a series in a rollup fileset, or nil if the
+// series has no data in it.
+func (m *manager) seek(
+	nsID ident.ID,
+	key fileSetKey,
+	volume int,
+	id ident.ID,
+) ([]byte, error) {
+	f, err := m.acquire(nsID, key, volume)
+	if err != nil {
+		return nil, err
+	}
+	defer m.release(f)
+
+	if !f.seeker.ConcurrentIDBloomFilter().Test(id.Bytes()) {
+		return nil, nil
+	}
+
+	resources := m.resources.Get().(fs.ReusableSeekerResources)
+	f.Lock()
+	data, err := f.seeker.SeekByID(id, resources)
+	f.Unlock()
+	m.resources.Put(resources)
+	if fs.IsSeekIDNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data.IncRef()
+	result := append([]byte(nil), data.Bytes()...)
+	data.DecRef()
+	data.Finalize()
+	return result, nil
+}
+
+// acquire returns the open rollup fileset for the key and volume, opening
+// it if it is not open yet. It must be released once done with.
+func (m *manager) acquire(nsID ident.ID, key fileSetKey, volume int) (*openFileSet, error) {
+	m.Lock()
+	if elem, ok := m.openByKey[key]; ok {
+		f := elem.Value.(*openFileSet)
+		if f.volume == volume {
+			f.refs++
+			m.open.MoveToFront(elem)
+			m.Unlock()
+			return f, nil
+		}
+		m.removeWithLock(elem)
+	}
+	m.Unlock()
+
+	prefix := FilePathPrefix(m.filePathPrefix, key.resolution, key.aggregation)
+	seeker := fs.NewSeeker(prefix, m.fsOpts.DataReaderBufferSize(),
+		m.fsOpts.InfoReaderBufferSize(), m.bytesPool, false, m.fsOpts)
+	resources := m.resources.Get().(fs.ReusableSeekerResources)
+	err := seeker.Open(nsID, key.shard, key.blockStart, volume, resources)
+	m.resources.Put(resources)
+	if err != nil {
+		return nil, err
+	}
+	m.metrics.fileSetsOpened.Inc(1)
+
+	m.Lock()
+	defer m.Unlock()
+	if elem, ok := m.openByKey[key]; ok {
+		// Another read opened the same fileset concurrently.
+		if f := elem.Value.(*openFileSet); f.volume == volume {
+			seeker.Close()
+			f.refs++
+			m.open.MoveToFront(elem)
+			return f, nil
+		}
+		m.removeWithLock(elem)
+	}
+	f := &openFileSet{key: key, volume: volume, seeker: seeker, refs: 1}
+	m.openByKey[key] = m.open.PushFront(f)
+	for m.open.Len() > m.maxOpenFileSets {
+		m.removeWithLock(m.open.Back())
+	}
+	return f, nil
+}
+
+func (m *manager) release(f *openFileSet) {
+	m.Lock()
+	defer m.Unlock()
+	f.refs--
+	if f.refs == 0 && f.evicted {
+		m.closeFileSet(f)
+	}
+}
+
+func (m *manager) removeWithLock(elem *list.Element) {
+	f := elem.Value.(*openFileSet)
+	m.open.Remove(elem)
+	delete(m.openByKey, f.key)
+	f.evicted = true
+	if f.refs == 0 {
+		m.closeFileSet(f)
+	}
+}
+
+func (m *manager) closeFileSet(f *openFileSet) {
+	if err := f.seeker.Close(); err != nil {
+		m.logger.Warn("could not close rollup fileset", zap.Error(err))
+	}
+}
+
+func (m *manager) Close() error {
+	m.Lock()
+	defer m.Unlock()
+	for m.open.Len() > 0 {
+		m.removeWithLock(m.open.Back())
+	}
+	return nil
+}
@@ -0,0 +1,453 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rollup
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testBlockSize = 2 * time.Hour
+
+var testNamespaceID = ident.StringID("testns")
+
+func newTestNamespaceMetadata(t *testing.T, enabled bool) namespace.Metadata {
+	md, err := namespace.NewMetadata(testNamespaceID, namespace.NewOptions().
+		SetRetentionOptions(namespace.NewOptions().RetentionOptions().
+			SetBlockSize(testBlockSize).
+			SetRetentionPeriod(48*time.Hour)).
+		SetRollupOptions(namespace.NewRollupOptions().
+			SetEnabled(enabled).
+			SetRollupAfter(6*time.Hour).
+			SetResolution(time.Minute)))
+	require.NoError(t, err)
+	return md
+}
+
+func newTestManager(t *testing.T) (*manager, fs.Options, func()) {
+	dir, err := ioutil.TempDir("", "rollup")
+	require.NoError(t, err)
+
+	fsOpts := fs.NewOptions().SetFilePathPrefix(dir)
+	mgr, err := NewManager(NewOptions().SetFilesystemOptions(fsOpts))
+	require.NoError(t, err)
+	return mgr.(*manager), fsOpts, func() {
+		require.NoError(t, mgr.Close())
+		os.RemoveAll(dir)
+	}
+}
+
+// writeRawFileSet writes a data fileset with a series per entry of the
+// given datapoints.
+func writeRawFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	blockStart xtime.UnixNano,
+	volume int,
+	series map[string][]ts.Datapoint,
+) {
+	w, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, w.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:   testNamespaceID,
+			Shard:       0,
+			BlockStart:  blockStart,
+			VolumeIndex: volume,
+		},
+		BlockSize:   testBlockSize,
+		FileSetType: persist.FileSetFlushType,
+	}))
+
+	for id, dps := range series {
+		encoder := m3tsz.NewEncoder(blockStart, nil,
+			m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+		for _, dp := range dps {
+			require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+		}
+		data := segmentBytes(encoder.Discard())
+		bytes := checked.NewBytes(data, nil)
+		bytes.IncRef()
+		metadata := persist.NewMetadataFromIDAndTags(ident.StringID(id),
+			ident.NewTags(ident.StringTag("name", id)), persist.MetadataOptions{})
+		require.NoError(t, w.Write(metadata, bytes, digest.Checksum(data)))
+	}
+	require.NoError(t, w.Close())
+}
+
+func rawFileSetPaths(t *testing.T, fsOpts fs.Options, blockStart xtime.UnixNano) []string {
+	filesets, err := fs.DataFiles(fsOpts.FilePathPrefix(), testNamespaceID, 0)
+	require.NoError(t, err)
+	var paths []string
+	for _, fileset := range filesets {
+		if fileset.ID.BlockStart.Equal(blockStart) {
+			paths = append(paths, fileset.AbsoluteFilePaths...)
+		}
+	}
+	return paths
+}
+
+// rollupVolumes returns the volumes of the rollup filesets of an
+// aggregation by block start.
+func rollupVolumes(
+	t *testing.T,
+	fsOpts fs.Options,
+	aggregation Aggregation,
+) map[xtime.UnixNano][]int {
+	prefix := FilePathPrefix(fsOpts.FilePathPrefix(), time.Minute, aggregation)
+	filesets, err := fs.DataFiles(prefix, testNamespaceID, 0)
+	require.NoError(t, err)
+	volumes := make(map[xtime.UnixNano][]int)
+	for _, fileset := range filesets {
+		volumes[fileset.ID.BlockStart] = append(volumes[fileset.ID.BlockStart],
+			fileset.ID.VolumeIndex)
+	}
+	return volumes
+}
+
+// readRollup returns the rolled up datapoints of a series in a rollup
+// fileset.
+func readRollup(
+	t *testing.T,
+	fsOpts fs.Options,
+	aggregation Aggregation,
+	blockStart xtime.UnixNano,
+	volume int,
+) map[string][]ts.Datapoint {
+	prefix := FilePathPrefix(fsOpts.FilePathPrefix(), time.Minute, aggregation)
+	r, err := fs.NewReader(nil, fsOpts.SetFilePathPrefix(prefix))
+	require.NoError(t, err)
+	require.NoError(t, r.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:   testNamespaceID,
+			Shard:       0,
+			BlockStart:  blockStart,
+			VolumeIndex: volume,
+		},
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer r.Close()
+
+	result := make(map[string][]ts.Datapoint)
+	for {
+		id, tags, data, _, err := r.Read()
+		if err == io.EOF {
+			return result
+		}
+		require.NoError(t, err)
+		require.True(t, tags.Next())
+		require.Equal(t, id.String(), tags.Current().Value.String())
+		tags.Close()
+		data.IncRef()
+		result[id.String()] = decode(t, data.Bytes())
+		data.DecRef()
+	}
+}
+
+func decode(t *testing.T, data []byte) []ts.Datapoint {
+	iter := m3tsz.NewReaderIterator(xio.NewBytesReader64(data),
+		m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+	defer iter.Close()
+	var dps []ts.Datapoint
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		dps = append(dps, ts.Datapoint{TimestampNanos: dp.TimestampNanos, Value: dp.Value})
+	}
+	require.NoError(t, iter.Err())
+	return dps
+}
+
+func decodeBlockReader(t *testing.T, reader xio.BlockReader) []ts.Datapoint {
+	segment, err := reader.Segment()
+	require.NoError(t, err)
+	return decode(t, segmentBytes(segment))
+}
+
+// readBlock returns the datapoints of an aggregation of the rollups of a
+// series within a block and whether the block was compacted.
+func readBlock(
+	t *testing.T,
+	mgr Manager,
+	md namespace.Metadata,
+	id string,
+	blockStart xtime.UnixNano,
+	aggregation Aggregation,
+) ([]ts.Datapoint, bool) {
+	readers, compacted, err := mgr.ReadBlock(md, 0, ident.StringID(id), blockStart, aggregation)
+	require.NoError(t, err)
+	if len(readers) == 0 {
+		return nil, compacted
+	}
+	require.Len(t, readers, 1)
+	require.Equal(t, blockStart, readers[0].Start)
+	return decodeBlockReader(t, readers[0]), compacted
+}
+
+// testDatapoints returns a datapoint every 10 seconds over the first
+// minutes of a block with values counting up from start.
+func testDatapoints(blockStart xtime.UnixNano, minutes int, start float64) []ts.Datapoint {
+	var dps []ts.Datapoint
+	for i := 0; i < minutes*6; i++ {
+		dps = append(dps, ts.Datapoint{
+			TimestampNanos: blockStart.Add(time.Duration(i) * 10 * time.Second),
+			Value:          start + float64(i),
+		})
+	}
+	return dps
+}
+
+func TestCompactAndReadEncoded(t *testing.T) {
+	mgr, fsOpts, cleanup := newTestManager(t)
+	defer cleanup()
+
+	var (
+		md     = newTestNamespaceMetadata(t, true)
+		block0 = xtime.Now().Truncate(testBlockSize).Add(-5 * testBlockSize)
+		block1 = block0.Add(testBlockSize)
+		block2 = block1.Add(testBlockSize)
+		now    = block2.Add(testBlockSize).Add(6 * time.Hour)
+	)
+	writeRawFileSet(t, fsOpts, block0, 0, map[string][]ts.Datapoint{
+		"foo": testDatapoints(block0, 2, 0),
+		"bar": testDatapoints(block0, 1, 100),
+	})
+	writeRawFileSet(t, fsOpts, block1, 0, map[string][]ts.Datapoint{
+		"foo": testDatapoints(block1, 1, 0),
+	})
+	// Block 2 is not old enough to be compacted yet.
+	writeRawFileSet(t, fsOpts, block2, 0, map[string][]ts.Datapoint{
+		"foo": testDatapoints(block2, 1, 0),
+	})
+
+	require.NoError(t, mgr.Compact(md, []uint32{0}, now.Add(-time.Minute)))
+	for _, aggregation := range Aggregations {
+		require.Equal(t, map[xtime.UnixNano][]int{
+			block0: {0},
+			block1: {0},
+		}, rollupVolumes(t, fsOpts, aggregation))
+	}
+
+	expected := map[Aggregation][]float64{
+		Min:   {0, 6},
+		Max:   {5, 11},
+		Sum:   {15, 51},
+		Count: {6, 6},
+	}
+	for aggregation, values := range expected {
+		dps := readRollup(t, fsOpts, aggregation, block0, 0)
+		require.Equal(t, []ts.Datapoint{
+			{TimestampNanos: block0, Value: values[0]},
+			{TimestampNanos: block0.Add(time.Minute), Value: values[1]},
+		}, dps["foo"], aggregation.String())
+		require.Len(t, dps["bar"], 1)
+	}
+
+	for aggregation, values := range map[Aggregation][]float64{
+		Avg:   {2.5, 8.5},
+		Min:   {0, 6},
+		Max:   {5, 11},
+		Sum:   {15, 51},
+		Count: {6, 6},
+	} {
+		dps, compacted := readBlock(t, mgr, md, "foo", block0, aggregation)
+		require.True(t, compacted)
+		require.Equal(t, []ts.Datapoint{
+			{TimestampNanos: block0, Value: values[0]},
+			{TimestampNanos: block0.Add(time.Minute), Value: values[1]},
+		}, dps, aggregation.String())
+	}
+
+	dps, compacted := readBlock(t, mgr, md, "foo", block1, Avg)
+	require.True(t, compacted)
+	require.Equal(t, []ts.Datapoint{{TimestampNanos: block1, Value: 2.5}}, dps)
+
+	// Compacted blocks a series has no datapoints in have no readers.
+	dps, compacted = readBlock(t, mgr, md, "bar", block1, Max)
+	require.True(t, compacted)
+	require.Empty(t, dps)
+
+	// Blocks that were not compacted are read from raw data.
+	dps, compacted = readBlock(t, mgr, md, "foo", block2, Avg)
+	require.False(t, compacted)
+	require.Empty(t, dps)
+}
+
+// counterIncrease returns the increase of a counter across the datapoints,
+// counting the whole value after a reset as an increase like Prometheus.
+func counterIncrease(dps []ts.Datapoint) float64 {
+	var increase float64
+	for i := 1; i < len(dps); i++ {
+		if dps[i].Value < dps[i-1].Value {
+			increase += dps[i].Value
+			continue
+		}
+		increase += dps[i].Value - dps[i-1].Value
+	}
+	return increase
+}
+
+func TestCompactAndReadCounter(t *testing.T) {
+	mgr, fsOpts, cleanup := newTestManager(t)
+	defer cleanup()
+
+	var (
+		md     = newTestNamespaceMetadata(t, true)
+		block0 = xtime.Now().Truncate(testBlockSize).Add(-5 * testBlockSize)
+		values = []float64{
+			0, 1, 2, 3, 4, 5,
+			// Reset twice within a minute.
+			6, 7, 2, 3, 1, 4,
+			// Reset at the start of a minute.
+			0, 2, 4, 6, 8, 10,
+		}
+		raw []ts.Datapoint
+	)
+	at := func(d time.Duration) xtime.UnixNano {
+		return block0.Add(d)
+	}
+	for i, v := range values {
+		raw = append(raw, ts.Datapoint{
+			TimestampNanos: at(time.Duration(i) * 10 * time.Second),
+			Value:          v,
+		})
+	}
+	writeRawFileSet(t, fsOpts, block0, 0, map[string][]ts.Datapoint{"foo": raw})
+	require.NoError(t, mgr.Compact(md, []uint32{0}, xtime.Now()))
+
+	dps, compacted := readBlock(t, mgr, md, "foo", block0, Counter)
+	require.True(t, compacted)
+	require.Equal(t, []ts.Datapoint{
+		// The first datapoint of the block and the last of the minute.
+		{TimestampNanos: at(0), Value: 0},
+		{TimestampNanos: at(50 * time.Second), Value: 5},
+		// Either side of the last reset, the value before it raised by the
+		// increases up to it, then the last of the minute.
+		{TimestampNanos: at(90 * time.Second), Value: 10},
+		{TimestampNanos: at(100 * time.Second), Value: 1},
+		{TimestampNanos: at(110 * time.Second), Value: 4},
+		// The reset is against the last datapoint of the previous minute.
+		{TimestampNanos: at(120 * time.Second), Value: 0},
+		{TimestampNanos: at(170 * time.Second), Value: 10},
+	}, dps)
+	require.Equal(t, counterIncrease(raw), counterIncrease(dps))
+}
+
+func TestCompactRecompactsNewVolumes(t *testing.T) {
+	mgr, fsOpts, cleanup := newTestManager(t)
+	defer cleanup()
+
+	var (
+		md     = newTestNamespaceMetadata(t, true)
+		block0 = xtime.Now().Truncate(testBlockSize).Add(-5 * testBlockSize)
+		now    = xtime.Now()
+		id     = "foo"
+	)
+	writeRawFileSet(t, fsOpts, block0, 0, map[string][]ts.Datapoint{
+		"foo": testDatapoints(block0, 1, 0),
+	})
+	require.NoError(t, mgr.Compact(md, []uint32{0}, now))
+
+	dps, compacted := readBlock(t, mgr, md, id, block0, Avg)
+	require.True(t, compacted)
+	require.Equal(t, 2.5, dps[0].Value)
+
+	// A cold flush writes a new volume of the block.
+	writeRawFileSet(t, fsOpts, block0, 1, map[string][]ts.Datapoint{
+		"foo": testDatapoints(block0, 1, 10),
+	})
+	require.NoError(t, mgr.Compact(md, []uint32{0}, now))
+	for _, aggregation := range Aggregations {
+		require.Equal(t, map[xtime.UnixNano][]int{block0: {1}},
+			rollupVolumes(t, fsOpts, aggregation))
+	}
+
+	dps, compacted = readBlock(t, mgr, md, id, block0, Avg)
+	require.True(t, compacted)
+	require.Equal(t, 12.5, dps[0].Value)
+
+	// Rollups of blocks removed by retention are removed as well.
+	require.NoError(t, fs.DeleteFiles(rawFileSetPaths(t, fsOpts, block0)))
+	require.NoError(t, mgr.Compact(md, []uint32{0}, now))
+	for _, aggregation := range Aggregations {
+		require.Empty(t, rollupVolumes(t, fsOpts, aggregation))
+	}
+
+	_, compacted = readBlock(t, mgr, md, id, block0, Avg)
+	require.False(t, compacted)
+}
+
+func TestCompactLoadsCompactedBlocksFromDisk(t *testing.T) {
+	mgr, fsOpts, cleanup := newTestManager(t)
+	defer cleanup()
+
+	var (
+		md     = newTestNamespaceMetadata(t, true)
+		block0 = xtime.Now().Truncate(testBlockSize).Add(-5 * testBlockSize)
+		id     = "foo"
+	)
+	writeRawFileSet(t, fsOpts, block0, 0, map[string][]ts.Datapoint{
+		"foo": testDatapoints(block0, 1, 0),
+	})
+	require.NoError(t, mgr.Compact(md, []uint32{0}, xtime.Now()))
+
+	restarted, err := NewManager(NewOptions().SetFilesystemOptions(fsOpts))
+	require.NoError(t, err)
+	defer restarted.Close()
+
+	dps, compacted := readBlock(t, restarted, md, id, block0, Max)
+	require.True(t, compacted)
+	require.Len(t, dps, 1)
+}
+
+func TestRollupsDisabled(t *testing.T) {
+	mgr, fsOpts, cleanup := newTestManager(t)
+	defer cleanup()
+
+	var (
+		md     = newTestNamespaceMetadata(t, false)
+		block0 = xtime.Now().Truncate(testBlockSize).Add(-5 * testBlockSize)
+	)
+	writeRawFileSet(t, fsOpts, block0, 0, map[string][]ts.Datapoint{
+		"foo": testDatapoints(block0, 1, 0),
+	})
+	require.NoError(t, mgr.Compact(md, []uint32{0}, xtime.Now()))
+	require.Empty(t, rollupVolumes(t, fsOpts, Count))
+
+	_, compacted := readBlock(t, mgr, md, "foo", block0, Avg)
+	require.False(t, compacted)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rollup
+
+import (
+	"errors"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	// defaultMaxOpenFileSets is the default maximum number of rollup
+	// filesets held open for reads.
+	defaultMaxOpenFileSets = 1024
+)
+
+var (
+	errFilesystemOptionsNotSet = errors.New("filesystem options are not set")
+	errMaxOpenFileSetsPositive = errors.New("max open filesets must be positive")
+)
+
+type options struct {
+	fsOpts          fs.Options
+	maxOpenFileSets int
+	instrumentOpts  instrument.Options
+}
+
+// NewOptions creates a new set of rollup options.
+func NewOptions() Options {
+	return &options{
+		maxOpenFileSets: defaultMaxOpenFileSets,
+		instrumentOpts:  instrument.NewOptions(),
+	}
+}
+
+func (o *options) Validate() error {
+	if o.fsOpts == nil {
+		return errFilesystemOptionsNotSet
+	}
+	if o.maxOpenFileSets <= 0 {
+		return errMaxOpenFileSetsPositive
+	}
+	return nil
+}
+
+func (o *options) SetFilesystemOptions(value fs.Options) Options {
+	opts := *o
+	opts.fsOpts = value
+	return &opts
+}
+
+func (o *options) FilesystemOptions() fs.Options {
+	return o.fsOpts
+}
+
+func (o *options) SetMaxOpenFileSets(value int) Options {
+	opts := *o
+	opts.maxOpenFileSets = value
+	return &opts
+}
+
+func (o *options) MaxOpenFileSets() int {
+	return o.maxOpenFileSets
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package rollup compacts old blocks of a namespace into rollups at a
+// coarser resolution and reads them back in place of the raw data.
+package rollup
+
+import (
+	"fmt"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// Aggregation is an aggregation of the datapoints of a series within a
+// rollup resolution.
+type Aggregation uint8
+
+const (
+	// Avg is the average of the values within the resolution, read from the
+	// sum and count rollups.
+	Avg Aggregation = iota
+	// Min is the smallest value within the resolution.
+	Min
+	// Max is the largest value within the resolution.
+	Max
+	// Sum is the sum of the values within the resolution.
+	Sum
+	// Count is the number of values within the resolution.
+	Count
+	// Counter is the last value within the resolution, along with the
+	// values either side of the last counter reset within it, so that the
+	// increases and resets of a counter read from it are those of the raw
+	// values.
+	Counter
+)
+
+// Aggregations are the aggregations every block is compacted into. Count
+// is written last, so a block with a complete count fileset has been fully
+// compacted. Avg is derived from them when read.
+var Aggregations = []Aggregation{Min, Max, Sum, Counter, Count}
+
+// Validate returns an error if the aggregation is unknown.
+func (a Aggregation) Validate() error {
+	if a > Counter {
+		return fmt.Errorf("unknown rollup aggregation: %d", a)
+	}
+	return nil
+}
+
+func (a Aggregation) String() string {
+	switch a {
+	case Avg:
+		return "avg"
+	case Min:
+		return "min"
+	case Max:
+		return "max"
+	case Sum:
+		return "sum"
+	case Count:
+		return "count"
+	case Counter:
+		return "counter"
+	default:
+		return "unknown"
+	}
+}
+
+// Manager compacts flushed blocks into rollups and reads them back.
+type Manager interface {
+	// Compact compacts the flushed blocks of the given shards that are older
+	// than the namespace's rollup threshold into rollups, recompacts blocks
+	// that were flushed again since, and removes the rollups of blocks that
+	// no longer exist.
+	Compact(md namespace.Metadata, shards []uint32, now xtime.UnixNano) error
+
+	// ReadBlock returns a block reader over an aggregation of the rollups
+	// of a series within a block, along with whether the block was compacted
+	// into rollups. No block readers are returned for a compacted block the
+	// series has no datapoints in.
+	ReadBlock(
+		md namespace.Metadata,
+		shard uint32,
+		id ident.ID,
+		blockStart xtime.UnixNano,
+		aggregation Aggregation,
+	) ([]xio.BlockReader, bool, error)
+
+	// Close closes the rollup filesets held open for reads.
+	Close() error
+}
+
+// Options represents the options for rollups.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetFilesystemOptions sets the filesystem options.
+	SetFilesystemOptions(value fs.Options) Options
+
+	// FilesystemOptions returns the filesystem options.
+	FilesystemOptions() fs.Options
+
+	// SetMaxOpenFileSets sets the maximum number of rollup filesets held
+	// open for reads.
+	SetMaxOpenFileSets(value int) Options
+
+	// MaxOpenFileSets returns the maximum number of rollup filesets held
+	// open for reads.
+	MaxOpenFileSets() int
+
+	// SetInstrumentOptions sets the instrumentation options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrumentation options.
+	InstrumentOptions() instrument.Options
+}
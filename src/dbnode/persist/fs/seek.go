@@ -83,6 +83,10 @@ type seeker struct {
 	bloomFilter *ManagedConcurrentBloomFilter
 	indexLookup *nearestIndexOffsetLookup
 
+	// pinned are the fileset files paged in for the seeker, which must not
+	// be evicted from local disk while they are open.
+	pinned []string
+
 	isClone bool
 }
 
@@ -95,6 +99,12 @@ type IndexEntry struct {
 	EncodedTags  checked.Bytes
 }
 
+// IsSeekIDNotFoundError returns whether the error was returned by a seeker
+// because the ID does not exist in the fileset.
+func IsSeekIDNotFoundError(err error) bool {
+	return errors.Is(err, errSeekIDNotFound)
+}
+
 // NewSeeker returns a new seeker.
 func NewSeeker(
 	filePathPrefix string,
@@ -171,15 +181,34 @@ func (s *seeker) Open(
 		}
 	}
 
+	var (
+		infoFilePath        = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, InfoFileSuffix, isLegacy)
+		indexFilePath       = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix, isLegacy)
+		dataFilePath        = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix, isLegacy)
+		digestFilePath      = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, DigestFileSuffix, isLegacy)
+		bloomFilterFilePath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, bloomFilterFileSuffix, isLegacy)
+		summariesFilePath   = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, summariesFileSuffix, isLegacy)
+	)
+
+	// Restore any files that were evicted from local disk, they stay pinned
+	// until the seeker is closed.
+	pinned := []string{indexFilePath, dataFilePath, bloomFilterFilePath, summariesFilePath}
+	if err := pageInFileSetFiles(s.opts.opts, pinned...); err != nil {
+		return err
+	}
+	s.pinned = pinned
+
 	// Open necessary files
 	if err := openFiles(os.Open, map[string]**os.File{
-		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, InfoFileSuffix, isLegacy):        &infoFd,
-		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix, isLegacy):       &s.indexFd,
-		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix, isLegacy):        &s.dataFd,
-		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, DigestFileSuffix, isLegacy):      &digestFd,
-		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, bloomFilterFileSuffix, isLegacy): &bloomFilterFd,
-		dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, summariesFileSuffix, isLegacy):   &summariesFd,
+		infoFilePath:        &infoFd,
+		indexFilePath:       &s.indexFd,
+		dataFilePath:        &s.dataFd,
+		digestFilePath:      &digestFd,
+		bloomFilterFilePath: &bloomFilterFd,
+		summariesFilePath:   &summariesFd,
 	}); err != nil {
+		releaseFileSetFiles(s.opts.opts, pinned...)
+		s.pinned = nil
 		return err
 	}
 
@@ -491,6 +520,10 @@ func (s *seeker) Close() error {
 		multiErr = multiErr.Add(s.dataFd.Close())
 		s.dataFd = nil
 	}
+	if s.pinned != nil {
+		releaseFileSetFiles(s.opts.opts, s.pinned...)
+		s.pinned = nil
+	}
 	return multiErr.FinalError()
 }
 
@@ -44,6 +44,11 @@ const (
 	seekManagerCloseInterval        = time.Second
 	reusableSeekerResourcesPoolSize = 10
 	concurrentCacheShardIndices     = 16
+
+	// tieredSeekersIdleTimeout is how long seekers of blocks that are tiered
+	// to object storage are kept open without being borrowed. Open seekers
+	// pin their files on local disk, preventing them from being evicted.
+	tieredSeekersIdleTimeout = time.Minute
 )
 
 var (
@@ -193,6 +198,8 @@ type seekersByTime struct {
 type rotatableSeekers struct {
 	active   seekersAndBloom
 	inactive seekersAndBloom
+	// lastBorrowed is when the seekers were last opened or borrowed.
+	lastBorrowed xtime.UnixNano
 }
 
 type seekerManagerPendingClose struct {
@@ -390,6 +397,10 @@ func (m *seekerManager) Borrow(
 
 	availableSeeker.isBorrowed = true
 	seekers[availableSeekerIdx] = availableSeeker
+
+	rotatable := byTime.seekers[start]
+	rotatable.lastBorrowed = m.now()
+	byTime.seekers[start] = rotatable
 	return availableSeeker.seeker, nil
 }
 
@@ -729,6 +740,7 @@ func (m *seekerManager) getOrOpenSeekersWithLock(
 	}
 
 	seekers.active = activeSeekers
+	seekers.lastBorrowed = m.now()
 	byTime.seekers[start] = seekers
 	return activeSeekers, nil
 }
@@ -803,9 +815,16 @@ func (m *seekerManager) openAnyUnopenSeekers(byTime *seekersByTime) error {
 	start := m.earliestSeekableBlockStart()
 	end := m.latestSeekableBlockStart()
 	blockSize := m.namespaceMetadata.Options().RetentionOptions().BlockSize()
+	now := m.now()
 	multiErr := xerrors.NewMultiError()
 
 	for t := start; !t.After(end); t = t.Add(blockSize) {
+		if m.isTierable(t, now) {
+			// Opening the seekers would page the fileset back in from object
+			// storage, they are opened on first use instead.
+			continue
+		}
+
 		byTime.Lock()
 		_, err := m.getOrOpenSeekersWithLock(t, byTime)
 		byTime.Unlock()
@@ -957,10 +976,26 @@ func (m *seekerManager) Close() error {
 	return nil
 }
 
+func (m *seekerManager) now() xtime.UnixNano {
+	return xtime.ToUnixNano(m.opts.ClockOptions().NowFn()())
+}
+
+// isTierable returns whether the fileset of the block start is old enough to
+// be tiered to object storage.
+func (m *seekerManager) isTierable(blockStart, now xtime.UnixNano) bool {
+	nsOpts := m.namespaceMetadata.Options()
+	tieringOpts := nsOpts.TieringOptions()
+	if !tieringOpts.Enabled() {
+		return false
+	}
+	blockEnd := blockStart.Add(nsOpts.RetentionOptions().BlockSize())
+	return !blockEnd.After(now.Add(-tieringOpts.TierAfter()))
+}
+
 func (m *seekerManager) earliestSeekableBlockStart() xtime.UnixNano {
 	nowFn := m.opts.ClockOptions().NowFn()
 	now := xtime.ToUnixNano(nowFn())
-	ropts := m.namespaceMetadata.Options().RetentionOptions()
+	ropts := namespace.BlockRetentionOptions(m.namespaceMetadata.Options())
 	return retention.FlushTimeStart(ropts, now)
 }
 
@@ -971,6 +1006,19 @@ func (m *seekerManager) latestSeekableBlockStart() xtime.UnixNano {
 	return now.Truncate(ropts.BlockSize())
 }
 
+func (m *seekerManager) isIdleTieredWithLock(
+	blockStart xtime.UnixNano,
+	seekers rotatableSeekers,
+	now xtime.UnixNano,
+) bool {
+	if seekers.active.wg != nil {
+		// Still being opened.
+		return false
+	}
+	return m.isTierable(blockStart, now) &&
+		now.Sub(seekers.lastBorrowed) >= tieredSeekersIdleTimeout
+}
+
 // openCloseLoop ensures to keep seekers open for those times where they are
 // available and closes them when they fall out of retention and expire.
 func (m *seekerManager) openCloseLoop() {
@@ -996,6 +1044,7 @@ func (m *seekerManager) openCloseLoop() {
 
 	for {
 		earliestSeekableBlockStart := m.earliestSeekableBlockStart()
+		now := m.now()
 
 		m.RLock()
 		if m.status != seekerManagerOpen {
@@ -1022,11 +1071,14 @@ func (m *seekerManager) openCloseLoop() {
 		m.RLock()
 		for shard, byTime := range m.seekersByShardIdx {
 			byTime.RLock()
-			for blockStart := range byTime.seekers {
+			for blockStart, seekers := range byTime.seekers {
 				if blockStart.Before(earliestSeekableBlockStart) ||
 					// Close seekers for shards that are no longer available. This
 					// ensure that seekers are eventually consistent w/ shard state.
-					!m.shardExistsWithLock(uint32(shard)) {
+					!m.shardExistsWithLock(uint32(shard)) ||
+					// Close idle seekers of tiered blocks so that their files
+					// can be evicted from local disk.
+					m.isIdleTieredWithLock(blockStart, seekers, now) {
 					shouldClose = append(shouldClose, seekerManagerPendingClose{
 						shard:      uint32(shard),
 						blockStart: blockStart,
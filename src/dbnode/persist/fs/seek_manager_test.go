@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -733,3 +734,96 @@ func TestSeekerManagerDoNotOpenSeekersForOutOfRetentionBlocks(t *testing.T) {
 	require.NotContains(t, openSeekers, earliestBlockStart.Add(-blockSize))
 	require.NotContains(t, openSeekers, earliestBlockStart.Add(-2*blockSize))
 }
+
+func TestSeekerManagerOpenTieredSeekersLazily(t *testing.T) {
+	defer leaktest.CheckTimeout(t, 1*time.Minute)()
+	var (
+		ctrl      = xtest.NewController(t)
+		shards    = []uint32{0}
+		blockSize = testNs1Metadata(t).Options().RetentionOptions().BlockSize()
+		opened    = make(map[xtime.UnixNano]struct{})
+		openedMu  sync.Mutex
+		tickCh    = make(chan struct{})
+		nowMu     sync.Mutex
+		now       = time.Now()
+		opts      = NewOptions()
+	)
+	metadata, err := namespace.NewMetadata(testNs1ID, testNs1Metadata(t).Options().
+		SetTieringOptions(namespace.NewTieringOptions().
+			SetEnabled(true).
+			SetTierAfter(blockSize)))
+	require.NoError(t, err)
+	shardSet, err := sharding.NewShardSet(
+		sharding.NewShards(shards, shard.Available),
+		sharding.DefaultHashFn(1),
+	)
+	require.NoError(t, err)
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		nowMu.Lock()
+		defer nowMu.Unlock()
+		return now
+	}))
+	m := NewSeekerManager(nil, opts, defaultTestBlockRetrieverOptions).(*seekerManager)
+	m.sleepFn = func(_ time.Duration) {
+		select {
+		case tickCh <- struct{}{}:
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	m.newOpenSeekerFn = func(shard uint32, blockStart xtime.UnixNano, volume int) (DataFileSetSeeker, error) {
+		openedMu.Lock()
+		opened[blockStart] = struct{}{}
+		openedMu.Unlock()
+		mockSeeker := NewMockDataFileSetSeeker(ctrl)
+		mockConcurrentDataFileSetSeeker := NewMockConcurrentDataFileSetSeeker(ctrl)
+		mockConcurrentDataFileSetSeeker.EXPECT().Close().Return(nil)
+		mockSeeker.EXPECT().ConcurrentClone().Return(mockConcurrentDataFileSetSeeker, nil)
+		mockSeeker.EXPECT().ConcurrentIDBloomFilter().Return(nil)
+		mockSeeker.EXPECT().Close().Return(nil)
+		return mockSeeker, nil
+	}
+	require.NoError(t, m.Open(metadata, shardSet))
+
+	var (
+		latest = xtime.ToUnixNano(now).Truncate(blockSize)
+		tiered = latest.Add(-2 * blockSize)
+	)
+	require.NoError(t, m.CacheShardIndices(shards))
+	openedMu.Lock()
+	require.Contains(t, opened, latest)
+	require.Contains(t, opened, latest.Add(-blockSize))
+	require.NotContains(t, opened, tiered)
+	openedMu.Unlock()
+
+	// Seekers of tiered blocks are opened on first use.
+	seeker, err := m.Borrow(0, tiered)
+	require.NoError(t, err)
+	openedMu.Lock()
+	require.Contains(t, opened, tiered)
+	openedMu.Unlock()
+
+	// And closed once idle, so that their files can be evicted.
+	nowMu.Lock()
+	now = now.Add(tieredSeekersIdleTimeout)
+	nowMu.Unlock()
+	<-tickCh
+	<-tickCh
+	byTime, ok := m.seekersByTime(0)
+	require.True(t, ok)
+	byTime.RLock()
+	_, stillOpen := byTime.seekers[tiered]
+	byTime.RUnlock()
+	require.True(t, stillOpen, "borrowed seekers must not be closed")
+
+	require.NoError(t, m.Return(0, tiered, seeker))
+	<-tickCh
+	<-tickCh
+	byTime.RLock()
+	_, stillOpen = byTime.seekers[tiered]
+	_, recentOpen := byTime.seekers[latest]
+	byTime.RUnlock()
+	require.False(t, stillOpen)
+	require.True(t, recentOpen)
+
+	require.NoError(t, m.Close())
+}
@@ -150,6 +150,7 @@ func (w *streamingWriter) WriteAll(
 	}
 
 	if ok {
+		w.writer.addToMerkleTree(id, dataChecksum)
 		return w.writeIndexRelated(id, encodedTags, entry)
 	}
 
@@ -231,6 +232,11 @@ func (w *streamingWriter) Close() error {
 
 	w.bloomFilter = nil
 
+	if err := w.writer.writeMerkleFile(); err != nil {
+		w.writer.err = err
+		return err
+	}
+
 	err := w.writer.closeWOIndex()
 	if err != nil {
 		w.writer.err = err
@@ -0,0 +1,68 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// untierableFileSetFileSuffixes are the suffixes of the fileset files that
+// always remain on local disk so that filesets can be discovered and
+// validated without paging them in.
+var untierableFileSetFileSuffixes = []string{
+	CheckpointFileSuffix,
+	InfoFileSuffix,
+	DigestFileSuffix,
+	MerkleFileSuffix,
+}
+
+// IsTierableFileSetFile returns whether a fileset file may be evicted from
+// local disk once it has been uploaded to object storage.
+func IsTierableFileSetFile(filePath string) bool {
+	name := filepath.Base(filePath)
+	for _, suffix := range untierableFileSetFileSuffixes {
+		if strings.HasSuffix(name, separator+suffix+fileSuffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// pageInFileSetFiles restores any of the given fileset files that were
+// evicted from local disk and pins them until released with
+// releaseFileSetFiles, if a pager is configured.
+func pageInFileSetFiles(opts Options, filePaths ...string) error {
+	pager := opts.FileSetPager()
+	if pager == nil {
+		return nil
+	}
+	return pager.PageIn(filePaths)
+}
+
+// releaseFileSetFiles unpins fileset files pinned by pageInFileSetFiles.
+func releaseFileSetFiles(opts Options, filePaths ...string) {
+	pager := opts.FileSetPager()
+	if pager == nil {
+		return
+	}
+	pager.Release(filePaths)
+}
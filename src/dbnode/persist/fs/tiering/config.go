@@ -0,0 +1,102 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tiering
+
+import (
+	"errors"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+var errObjectStoreConfigInvalid = errors.New(
+	"object store requires exactly one of directory or s3 to be set")
+
+// Configuration is the configuration for tiering filesets to object
+// storage. Which filesets are tiered is controlled per namespace.
+type Configuration struct {
+	// ObjectStore is the object store filesets are tiered to.
+	ObjectStore ObjectStoreConfiguration `yaml:"objectStore"`
+
+	// CacheMaxBytes is the maximum size of the fileset files paged back in
+	// from the object store that are kept on local disk. Files held open by
+	// readers are kept regardless, seekers of tiered blocks are closed after
+	// a minute without reads.
+	CacheMaxBytes *int64 `yaml:"cacheMaxBytes"`
+}
+
+// ObjectStoreConfiguration is the configuration for an object store,
+// exactly one of the stores must be set.
+type ObjectStoreConfiguration struct {
+	// Directory is the path of a directory to store objects in.
+	Directory string `yaml:"directory"`
+
+	// S3 is the configuration for an S3 compatible object store.
+	S3 *S3Configuration `yaml:"s3"`
+}
+
+// S3Configuration is the configuration for an S3 compatible object store.
+type S3Configuration struct {
+	Endpoint        string `yaml:"endpoint" validate:"nonzero"`
+	Region          string `yaml:"region"`
+	Bucket          string `yaml:"bucket" validate:"nonzero"`
+	Prefix          string `yaml:"prefix"`
+	AccessKeyID     string `yaml:"accessKeyID"`
+	SecretAccessKey string `yaml:"secretAccessKey"`
+}
+
+// NewObjectStore returns the configured object store.
+func (c ObjectStoreConfiguration) NewObjectStore() (ObjectStore, error) {
+	switch {
+	case c.Directory != "" && c.S3 == nil:
+		return NewDirectoryObjectStore(c.Directory), nil
+	case c.Directory == "" && c.S3 != nil:
+		return NewS3ObjectStore(S3ObjectStoreOptions{
+			Endpoint:        c.S3.Endpoint,
+			Region:          c.S3.Region,
+			Bucket:          c.S3.Bucket,
+			Prefix:          c.S3.Prefix,
+			AccessKeyID:     c.S3.AccessKeyID,
+			SecretAccessKey: c.S3.SecretAccessKey,
+		})
+	default:
+		return nil, errObjectStoreConfigInvalid
+	}
+}
+
+// NewManager returns a tiering manager for the configuration.
+func (c Configuration) NewManager(
+	fsOpts fs.Options,
+	iOpts instrument.Options,
+) (Manager, error) {
+	store, err := c.ObjectStore.NewObjectStore()
+	if err != nil {
+		return nil, err
+	}
+	opts := NewOptions().
+		SetObjectStore(store).
+		SetFilesystemOptions(fsOpts).
+		SetInstrumentOptions(iOpts)
+	if v := c.CacheMaxBytes; v != nil {
+		opts = opts.SetCacheMaxBytes(*v)
+	}
+	return NewManager(opts)
+}
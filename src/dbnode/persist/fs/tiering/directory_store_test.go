@@ -0,0 +1,90 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tiering
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testObjectStore(t *testing.T, store ObjectStore) {
+	objects := map[string]string{
+		"data/ns/0/fileset-1-0-data.db":  "foo",
+		"data/ns/0/fileset-1-0-index.db": "bar",
+		"data/ns/1/fileset-1-0-data.db":  "baz",
+		"index/data/ns/fileset-1-0.db":   "qux",
+	}
+	for key, value := range objects {
+		require.NoError(t, store.Put(key, bytes.NewReader([]byte(value)), int64(len(value))))
+	}
+
+	for key, value := range objects {
+		r, size, err := store.Get(key)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(value)), size)
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		assert.Equal(t, value, string(data))
+	}
+
+	_, _, err := store.Get("data/ns/0/missing")
+	assert.Equal(t, ErrObjectNotFound, err)
+
+	keys, err := store.List("data/ns/0/")
+	require.NoError(t, err)
+	sort.Strings(keys)
+	assert.Equal(t, []string{
+		"data/ns/0/fileset-1-0-data.db",
+		"data/ns/0/fileset-1-0-index.db",
+	}, keys)
+
+	keys, err = store.List("")
+	require.NoError(t, err)
+	assert.Len(t, keys, len(objects))
+
+	require.NoError(t, store.Delete("data/ns/0/fileset-1-0-data.db"))
+	require.NoError(t, store.Delete("data/ns/0/missing"))
+	_, _, err = store.Get("data/ns/0/fileset-1-0-data.db")
+	assert.Equal(t, ErrObjectNotFound, err)
+
+	keys, err = store.List("data/ns/0/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"data/ns/0/fileset-1-0-index.db"}, keys)
+
+	keys, err = store.List("data/other/")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestDirectoryObjectStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "directory-store")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	testObjectStore(t, NewDirectoryObjectStore(dir))
+}
@@ -0,0 +1,443 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tiering
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	pageInTmpExt = ".pagein"
+)
+
+type managerMetrics struct {
+	uploaded      tally.Counter
+	uploadErrors  tally.Counter
+	evicted       tally.Counter
+	pagedIn       tally.Counter
+	pageInErrors  tally.Counter
+	cacheEvicted  tally.Counter
+	cacheBytes    tally.Gauge
+	orphanDeleted tally.Counter
+}
+
+func newManagerMetrics(scope tally.Scope) managerMetrics {
+	return managerMetrics{
+		uploaded:      scope.Counter("uploaded"),
+		uploadErrors:  scope.Counter("upload-errors"),
+		evicted:       scope.Counter("evicted"),
+		pagedIn:       scope.Counter("paged-in"),
+		pageInErrors:  scope.Counter("page-in-errors"),
+		cacheEvicted:  scope.Counter("cache-evicted"),
+		cacheBytes:    scope.Gauge("cache-bytes"),
+		orphanDeleted: scope.Counter("orphan-deleted"),
+	}
+}
+
+type cachedFile struct {
+	path string
+	size int64
+}
+
+type manager struct {
+	sync.Mutex
+
+	store          ObjectStore
+	fsOpts         fs.Options
+	filePathPrefix string
+	cacheMaxBytes  int64
+	metrics        managerMetrics
+	logger         *zap.Logger
+
+	// cached holds the files paged back in from the object store ordered
+	// from least to most recently used.
+	cached       *list.List
+	cachedByPath map[string]*list.Element
+	cachedBytes  int64
+
+	// pinned counts the open readers of each file, pinned files are neither
+	// evicted from the cache nor tiered out.
+	pinned map[string]int
+	// pagingIn holds the files being downloaded, closing the channel once
+	// the download completes.
+	pagingIn map[string]chan struct{}
+}
+
+// NewManager returns a new tiering manager.
+func NewManager(opts Options) (Manager, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	iOpts := opts.InstrumentOptions()
+	return &manager{
+		store:          opts.ObjectStore(),
+		fsOpts:         opts.FilesystemOptions(),
+		filePathPrefix: opts.FilesystemOptions().FilePathPrefix(),
+		cacheMaxBytes:  opts.CacheMaxBytes(),
+		metrics:        newManagerMetrics(iOpts.MetricsScope().SubScope("tiering")),
+		logger:         iOpts.Logger(),
+		cached:         list.New(),
+		cachedByPath:   make(map[string]*list.Element),
+		pinned:         make(map[string]int),
+		pagingIn:       make(map[string]chan struct{}),
+	}, nil
+}
+
+func (m *manager) PageIn(filePaths []string) error {
+	for i, filePath := range filePaths {
+		if err := m.pageIn(filePath); err != nil {
+			m.Release(filePaths[:i])
+			return err
+		}
+	}
+
+	m.Lock()
+	m.evictCachedWithLock()
+	m.Unlock()
+	return nil
+}
+
+// pageIn downloads the file if it was evicted from local disk and pins it.
+// The download happens without holding the lock, concurrent page ins of the
+// same file wait for it to complete instead.
+func (m *manager) pageIn(filePath string) error {
+	for {
+		m.Lock()
+		if done, ok := m.pagingIn[filePath]; ok {
+			m.Unlock()
+			<-done
+			continue
+		}
+
+		exists, err := fs.FileExists(filePath)
+		if err != nil {
+			m.Unlock()
+			return err
+		}
+		if exists {
+			if elem, ok := m.cachedByPath[filePath]; ok {
+				m.cached.MoveToBack(elem)
+			}
+			m.pinned[filePath]++
+			m.Unlock()
+			return nil
+		}
+
+		done := make(chan struct{})
+		m.pagingIn[filePath] = done
+		m.Unlock()
+
+		size, err := m.download(filePath)
+
+		m.Lock()
+		delete(m.pagingIn, filePath)
+		close(done)
+		if err != nil && err != ErrObjectNotFound {
+			m.Unlock()
+			m.metrics.pageInErrors.Inc(1)
+			return fmt.Errorf("could not page in %s: %w", filePath, err)
+		}
+		// Files that were never tiered are pinned too, leaving it to the
+		// caller to handle them missing.
+		if err == nil {
+			m.metrics.pagedIn.Inc(1)
+			if elem, ok := m.cachedByPath[filePath]; ok {
+				m.cachedBytes -= elem.Value.(cachedFile).size
+				m.cached.Remove(elem)
+			}
+			m.cachedByPath[filePath] = m.cached.PushBack(cachedFile{path: filePath, size: size})
+			m.cachedBytes += size
+		}
+		m.pinned[filePath]++
+		m.Unlock()
+		return nil
+	}
+}
+
+func (m *manager) Release(filePaths []string) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, filePath := range filePaths {
+		if n := m.pinned[filePath]; n > 1 {
+			m.pinned[filePath] = n - 1
+		} else {
+			delete(m.pinned, filePath)
+		}
+	}
+	m.evictCachedWithLock()
+}
+
+// evictCachedWithLock removes the least recently used files paged in from
+// the object store until the cache fits. Pinned files are never evicted, so
+// the cache exceeds its limit while more files than fit are open.
+func (m *manager) evictCachedWithLock() {
+	for elem := m.cached.Front(); elem != nil && m.cachedBytes > m.cacheMaxBytes; {
+		next := elem.Next()
+		file := elem.Value.(cachedFile)
+		if _, ok := m.pinned[file.path]; ok {
+			elem = next
+			continue
+		}
+		if err := os.Remove(file.path); err != nil && !os.IsNotExist(err) {
+			m.logger.Warn("could not evict paged in fileset file",
+				zap.String("path", file.path), zap.Error(err))
+		}
+		m.cached.Remove(elem)
+		delete(m.cachedByPath, file.path)
+		m.cachedBytes -= file.size
+		m.metrics.cacheEvicted.Inc(1)
+		elem = next
+	}
+	m.metrics.cacheBytes.Update(float64(m.cachedBytes))
+}
+
+func (m *manager) download(filePath string) (int64, error) {
+	key, err := m.key(filePath)
+	if err != nil {
+		return 0, err
+	}
+	r, _, err := m.store.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	tmpPath := filePath + pageInTmpExt
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, m.fsOpts.NewFileMode())
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmpPath)
+
+	size, err := io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+	return size, os.Rename(tmpPath, filePath)
+}
+
+func (m *manager) Tier(md namespace.Metadata, shards []uint32, now xtime.UnixNano) error {
+	tieringOpts := md.Options().TieringOptions()
+	if !tieringOpts.Enabled() {
+		return nil
+	}
+
+	var (
+		nsID      = md.ID()
+		blockSize = md.Options().RetentionOptions().BlockSize()
+		cutoff    = now.Add(-tieringOpts.TierAfter())
+		multiErr  = xerrors.NewMultiError()
+	)
+	for _, shard := range shards {
+		filesets, err := fs.DataFiles(m.filePathPrefix, nsID, shard)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		multiErr = multiErr.Add(m.tierFileSets(filesets, blockSize, cutoff))
+
+		shardDir := fs.ShardDataDirPath(m.filePathPrefix, nsID, shard)
+		multiErr = multiErr.Add(m.deleteOrphans(shardDir, func(name string) (bool, error) {
+			blockStart, volume, err := fs.TimeAndVolumeIndexFromDataFileSetFilename(name)
+			if err != nil {
+				return false, err
+			}
+			return fs.DataFileSetExists(m.filePathPrefix, nsID, shard, blockStart, volume)
+		}))
+	}
+
+	if md.Options().IndexOptions().Enabled() {
+		filesets, err := fs.IndexFiles(m.filePathPrefix, nsID)
+		if err != nil {
+			return multiErr.Add(err).FinalError()
+		}
+		indexBlockSize := md.Options().IndexOptions().BlockSize()
+		multiErr = multiErr.Add(m.tierFileSets(filesets, indexBlockSize, cutoff))
+
+		indexDir := fs.NamespaceIndexDataDirPath(m.filePathPrefix, nsID)
+		multiErr = multiErr.Add(m.deleteOrphans(indexDir, func(name string) (bool, error) {
+			blockStart, volume, err := fs.TimeAndVolumeIndexFromFileSetFilename(name)
+			if err != nil {
+				return false, err
+			}
+			return fs.CompleteCheckpointFileExists(fs.FilesetPathFromTimeAndIndex(
+				indexDir, blockStart, volume, fs.CheckpointFileSuffix))
+		}))
+	}
+
+	return multiErr.FinalError()
+}
+
+// tierFileSets uploads the tierable files of the complete filesets whose
+// block ended before the cutoff and removes them from local disk.
+func (m *manager) tierFileSets(
+	filesets fs.FileSetFilesSlice,
+	blockSize time.Duration,
+	cutoff xtime.UnixNano,
+) error {
+	multiErr := xerrors.NewMultiError()
+	for i := range filesets {
+		fileset := &filesets[i]
+		if fileset.ID.BlockStart.Add(blockSize).After(cutoff) {
+			continue
+		}
+		if !fileset.HasCompleteCheckpointFile() {
+			continue
+		}
+		for _, filePath := range fileset.AbsoluteFilePaths {
+			if !fs.IsTierableFileSetFile(filePath) {
+				continue
+			}
+			if err := m.tierFile(filePath); err != nil {
+				m.metrics.uploadErrors.Inc(1)
+				multiErr = multiErr.Add(fmt.Errorf("could not tier %s: %w", filePath, err))
+			}
+		}
+	}
+	return multiErr.FinalError()
+}
+
+func (m *manager) tierFile(filePath string) error {
+	m.Lock()
+	_, pagedIn := m.cachedByPath[filePath]
+	_, pinned := m.pinned[filePath]
+	m.Unlock()
+	if pagedIn {
+		// Already in the object store, the cache evicts it once unused.
+		return nil
+	}
+	if pinned {
+		// Open for reading, the file is tiered by a later run once closed.
+		return nil
+	}
+
+	key, err := m.key(filePath)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := m.store.Put(key, f, info.Size()); err != nil {
+		return err
+	}
+	m.metrics.uploaded.Inc(1)
+
+	// Remove the local copy while holding the lock so that it does not race
+	// with a concurrent page in of the same file.
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.cachedByPath[filePath]; ok {
+		return nil
+	}
+	if _, ok := m.pinned[filePath]; ok {
+		return nil
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	m.metrics.evicted.Inc(1)
+	return nil
+}
+
+// deleteOrphans deletes the objects under the given directory whose
+// fileset no longer exists locally, because it expired or was replaced by
+// a newer volume.
+func (m *manager) deleteOrphans(
+	dir string,
+	filesetExists func(name string) (bool, error),
+) error {
+	prefix, err := m.key(dir)
+	if err != nil {
+		return err
+	}
+	keys, err := m.store.List(prefix + "/")
+	if err != nil {
+		return err
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, key := range keys {
+		name := filepath.Base(filepath.FromSlash(key))
+		exists, err := filesetExists(name)
+		if err != nil {
+			m.logger.Warn("could not check fileset of tiered object",
+				zap.String("key", key), zap.Error(err))
+			continue
+		}
+		if exists {
+			continue
+		}
+		if err := m.store.Delete(key); err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		m.metrics.orphanDeleted.Inc(1)
+	}
+	return multiErr.FinalError()
+}
+
+// key returns the object key of a local path, which is its path relative
+// to the filesystem prefix.
+func (m *manager) key(filePath string) (string, error) {
+	rel, err := filepath.Rel(m.filePathPrefix, filePath)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is not under %s", filePath, m.filePathPrefix)
+	}
+	return filepath.ToSlash(rel), nil
+}
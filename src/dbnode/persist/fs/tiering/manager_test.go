@@ -0,0 +1,365 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tiering
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBlockSize = 2 * time.Hour
+
+var testNamespaceID = ident.StringID("testns")
+
+func newTestNamespaceMetadata(t *testing.T, tierAfter time.Duration) namespace.Metadata {
+	md, err := namespace.NewMetadata(testNamespaceID, namespace.NewOptions().
+		SetRetentionOptions(namespace.NewOptions().RetentionOptions().
+			SetBlockSize(testBlockSize).
+			SetRetentionPeriod(48*time.Hour)).
+		SetTieringOptions(namespace.NewTieringOptions().
+			SetEnabled(true).
+			SetTierAfter(tierAfter)))
+	require.NoError(t, err)
+	return md
+}
+
+func writeTestFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	blockStart xtime.UnixNano,
+	data []byte,
+) {
+	w, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, w.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  testNamespaceID,
+			Shard:      0,
+			BlockStart: blockStart,
+		},
+		BlockSize:   testBlockSize,
+		FileSetType: persist.FileSetFlushType,
+	}))
+
+	bytes := checked.NewBytes(data, nil)
+	bytes.IncRef()
+	metadata := persist.NewMetadataFromIDAndTags(ident.StringID("foo"),
+		ident.Tags{}, persist.MetadataOptions{})
+	require.NoError(t, w.Write(metadata, bytes, digest.Checksum(data)))
+	require.NoError(t, w.Close())
+}
+
+func readTestFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	blockStart xtime.UnixNano,
+) []byte {
+	r, err := fs.NewReader(nil, fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, r.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  testNamespaceID,
+			Shard:      0,
+			BlockStart: blockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer r.Close()
+
+	id, _, data, _, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, "foo", id.String())
+	data.IncRef()
+	defer data.DecRef()
+	return append([]byte(nil), data.Bytes()...)
+}
+
+func tierableFilePaths(
+	t *testing.T,
+	fsOpts fs.Options,
+	blockStart xtime.UnixNano,
+) []string {
+	fileset, ok, err := fs.FileSetAt(fsOpts.FilePathPrefix(), testNamespaceID, 0, blockStart, 0)
+	require.NoError(t, err)
+	require.True(t, ok)
+	var filePaths []string
+	for _, filePath := range fileset.AbsoluteFilePaths {
+		if fs.IsTierableFileSetFile(filePath) {
+			filePaths = append(filePaths, filePath)
+		}
+	}
+	require.NotEmpty(t, filePaths)
+	return filePaths
+}
+
+func listShardDir(t *testing.T, fsOpts fs.Options) []string {
+	shardDir := fs.ShardDataDirPath(fsOpts.FilePathPrefix(), testNamespaceID, 0)
+	infos, err := ioutil.ReadDir(shardDir)
+	require.NoError(t, err)
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	return names
+}
+
+func newTestManager(t *testing.T, cacheMaxBytes int64) (*manager, fs.Options, ObjectStore, func()) {
+	dir, err := ioutil.TempDir("", "tiering")
+	require.NoError(t, err)
+
+	var (
+		store  = NewDirectoryObjectStore(filepath.Join(dir, "store"))
+		fsOpts = fs.NewOptions().SetFilePathPrefix(filepath.Join(dir, "local"))
+	)
+	mgr, err := NewManager(NewOptions().
+		SetObjectStore(store).
+		SetFilesystemOptions(fsOpts).
+		SetCacheMaxBytes(cacheMaxBytes))
+	require.NoError(t, err)
+
+	return mgr.(*manager), fsOpts.SetFileSetPager(mgr), store, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestManagerTierAndPageIn(t *testing.T) {
+	mgr, fsOpts, store, cleanup := newTestManager(t, 1<<20)
+	defer cleanup()
+
+	var (
+		now         = xtime.Now().Truncate(testBlockSize)
+		oldBlock    = now.Add(-4 * testBlockSize)
+		recentBlock = now.Add(-testBlockSize)
+		md          = newTestNamespaceMetadata(t, 2*testBlockSize)
+	)
+	writeTestFileSet(t, fsOpts, oldBlock, []byte("old"))
+	writeTestFileSet(t, fsOpts, recentBlock, []byte("recent"))
+
+	require.NoError(t, mgr.Tier(md, []uint32{0}, now))
+
+	// Only the tierable files of the old block are uploaded and evicted.
+	keys, err := store.List("data/")
+	require.NoError(t, err)
+	require.NotEmpty(t, keys)
+	for _, key := range keys {
+		assert.True(t, fs.IsTierableFileSetFile(key), key)
+		blockStart, _, err := fs.TimeAndVolumeIndexFromDataFileSetFilename(filepath.Base(key))
+		require.NoError(t, err)
+		assert.Equal(t, oldBlock, blockStart)
+	}
+	for _, name := range listShardDir(t, fsOpts) {
+		blockStart, _, err := fs.TimeAndVolumeIndexFromDataFileSetFilename(name)
+		require.NoError(t, err)
+		if blockStart.Equal(oldBlock) {
+			assert.False(t, fs.IsTierableFileSetFile(name), name)
+		}
+	}
+
+	// The fileset is still discoverable and is paged back in when read.
+	exists, err := fs.DataFileSetExists(fsOpts.FilePathPrefix(), testNamespaceID, 0, oldBlock, 0)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, []byte("old"), readTestFileSet(t, fsOpts, oldBlock))
+	assert.Equal(t, []byte("recent"), readTestFileSet(t, fsOpts, recentBlock))
+	assert.True(t, mgr.cached.Len() > 0)
+
+	// Paged in files are not uploaded again.
+	require.NoError(t, mgr.Tier(md, []uint32{0}, now))
+	assert.Equal(t, []byte("old"), readTestFileSet(t, fsOpts, oldBlock))
+}
+
+func TestManagerPageInEvictsLeastRecentlyUsed(t *testing.T) {
+	mgr, fsOpts, _, cleanup := newTestManager(t, 1)
+	defer cleanup()
+
+	var (
+		now    = xtime.Now().Truncate(testBlockSize)
+		first  = now.Add(-5 * testBlockSize)
+		second = now.Add(-4 * testBlockSize)
+		md     = newTestNamespaceMetadata(t, testBlockSize)
+	)
+	writeTestFileSet(t, fsOpts, first, []byte("first"))
+	writeTestFileSet(t, fsOpts, second, []byte("second"))
+	firstFiles := tierableFilePaths(t, fsOpts, first)
+	secondFiles := tierableFilePaths(t, fsOpts, second)
+	require.NoError(t, mgr.Tier(md, []uint32{0}, now))
+
+	// Pinned files are kept even though they do not fit the cache.
+	require.NoError(t, mgr.PageIn(firstFiles))
+	require.NoError(t, mgr.PageIn(secondFiles))
+	assert.Equal(t, len(firstFiles)+len(secondFiles), mgr.cached.Len())
+
+	// Files are evicted once released.
+	mgr.Release(firstFiles)
+	for _, filePath := range firstFiles {
+		exists, err := fs.FileExists(filePath)
+		require.NoError(t, err)
+		assert.False(t, exists, filePath)
+	}
+	for _, filePath := range secondFiles {
+		exists, err := fs.FileExists(filePath)
+		require.NoError(t, err)
+		assert.True(t, exists, filePath)
+	}
+	mgr.Release(secondFiles)
+	assert.Equal(t, 0, mgr.cached.Len())
+	assert.Equal(t, int64(0), mgr.cachedBytes)
+
+	assert.Equal(t, []byte("first"), readTestFileSet(t, fsOpts, first))
+	assert.Equal(t, 0, mgr.cached.Len())
+}
+
+func TestManagerPageInConcurrent(t *testing.T) {
+	mgr, fsOpts, _, cleanup := newTestManager(t, 1<<20)
+	defer cleanup()
+
+	var (
+		now      = xtime.Now().Truncate(testBlockSize)
+		oldBlock = now.Add(-4 * testBlockSize)
+		md       = newTestNamespaceMetadata(t, testBlockSize)
+	)
+	writeTestFileSet(t, fsOpts, oldBlock, []byte("old"))
+	files := tierableFilePaths(t, fsOpts, oldBlock)
+	require.NoError(t, mgr.Tier(md, []uint32{0}, now))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, mgr.PageIn(files))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, len(files), mgr.cached.Len())
+	for _, filePath := range files {
+		assert.Equal(t, 8, mgr.pinned[filePath])
+	}
+	for i := 0; i < 8; i++ {
+		mgr.Release(files)
+	}
+	assert.Empty(t, mgr.pinned)
+	assert.Empty(t, mgr.pagingIn)
+}
+
+func TestManagerTierSkipsPinnedFiles(t *testing.T) {
+	mgr, fsOpts, store, cleanup := newTestManager(t, 1<<20)
+	defer cleanup()
+
+	var (
+		now      = xtime.Now().Truncate(testBlockSize)
+		oldBlock = now.Add(-4 * testBlockSize)
+		md       = newTestNamespaceMetadata(t, testBlockSize)
+	)
+	writeTestFileSet(t, fsOpts, oldBlock, []byte("old"))
+
+	// Files held open by a reader stay on local disk.
+	files := tierableFilePaths(t, fsOpts, oldBlock)
+	require.NoError(t, mgr.PageIn(files))
+	require.NoError(t, mgr.Tier(md, []uint32{0}, now))
+	keys, err := store.List("data/")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+	for _, filePath := range files {
+		exists, err := fs.FileExists(filePath)
+		require.NoError(t, err)
+		assert.True(t, exists, filePath)
+	}
+
+	mgr.Release(files)
+	require.NoError(t, mgr.Tier(md, []uint32{0}, now))
+	keys, err = store.List("data/")
+	require.NoError(t, err)
+	assert.Len(t, keys, len(files))
+	for _, filePath := range files {
+		exists, err := fs.FileExists(filePath)
+		require.NoError(t, err)
+		assert.False(t, exists, filePath)
+	}
+}
+
+func TestManagerTierDeletesOrphans(t *testing.T) {
+	mgr, fsOpts, store, cleanup := newTestManager(t, 1<<20)
+	defer cleanup()
+
+	var (
+		now      = xtime.Now().Truncate(testBlockSize)
+		oldBlock = now.Add(-4 * testBlockSize)
+		md       = newTestNamespaceMetadata(t, testBlockSize)
+	)
+	writeTestFileSet(t, fsOpts, oldBlock, []byte("old"))
+	require.NoError(t, mgr.Tier(md, []uint32{0}, now))
+
+	keys, err := store.List("data/")
+	require.NoError(t, err)
+	require.NotEmpty(t, keys)
+
+	// Remove the remaining local files as cleanup would once the block
+	// expires.
+	require.NoError(t, fs.DeleteFileSetAt(fsOpts.FilePathPrefix(), testNamespaceID, 0, oldBlock, 0))
+	require.NoError(t, mgr.Tier(md, []uint32{0}, now))
+
+	keys, err = store.List("data/")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestManagerTierDisabled(t *testing.T) {
+	mgr, fsOpts, store, cleanup := newTestManager(t, 1<<20)
+	defer cleanup()
+
+	now := xtime.Now().Truncate(testBlockSize)
+	writeTestFileSet(t, fsOpts, now.Add(-4*testBlockSize), []byte("old"))
+
+	md, err := namespace.NewMetadata(testNamespaceID, namespace.NewOptions())
+	require.NoError(t, err)
+	require.NoError(t, mgr.Tier(md, []uint32{0}, now))
+
+	keys, err := store.List("")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	names := listShardDir(t, fsOpts)
+	sort.Strings(names)
+	var hasData bool
+	for _, name := range names {
+		hasData = hasData || strings.HasSuffix(name, "-data.db")
+	}
+	assert.True(t, hasData)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tiering
+
+import (
+	"errors"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	// defaultCacheMaxBytes is the default maximum size of fileset files paged
+	// back in that are kept on local disk.
+	defaultCacheMaxBytes = 1 << 30
+)
+
+var (
+	errObjectStoreNotSet       = errors.New("object store is not set")
+	errFilesystemOptionsNotSet = errors.New("filesystem options are not set")
+	errCacheMaxBytesPositive   = errors.New("cache max bytes must be positive")
+)
+
+type options struct {
+	objectStore    ObjectStore
+	fsOpts         fs.Options
+	cacheMaxBytes  int64
+	instrumentOpts instrument.Options
+}
+
+// NewOptions creates a new set of tiering options.
+func NewOptions() Options {
+	return &options{
+		cacheMaxBytes:  defaultCacheMaxBytes,
+		instrumentOpts: instrument.NewOptions(),
+	}
+}
+
+func (o *options) Validate() error {
+	if o.objectStore == nil {
+		return errObjectStoreNotSet
+	}
+	if o.fsOpts == nil {
+		return errFilesystemOptionsNotSet
+	}
+	if o.cacheMaxBytes <= 0 {
+		return errCacheMaxBytesPositive
+	}
+	return nil
+}
+
+func (o *options) SetObjectStore(value ObjectStore) Options {
+	opts := *o
+	opts.objectStore = value
+	return &opts
+}
+
+func (o *options) ObjectStore() ObjectStore {
+	return o.objectStore
+}
+
+func (o *options) SetFilesystemOptions(value fs.Options) Options {
+	opts := *o
+	opts.fsOpts = value
+	return &opts
+}
+
+func (o *options) FilesystemOptions() fs.Options {
+	return o.fsOpts
+}
+
+func (o *options) SetCacheMaxBytes(value int64) Options {
+	opts := *o
+	opts.cacheMaxBytes = value
+	return &opts
+}
+
+func (o *options) CacheMaxBytes() int64 {
+	return o.cacheMaxBytes
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
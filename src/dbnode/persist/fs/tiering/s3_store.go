@@ -0,0 +1,172 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tiering
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+const s3DefaultRegion = "us-east-1"
+
+var (
+	errS3EndpointNotSet = errors.New("s3 endpoint is not set")
+	errS3BucketNotSet   = errors.New("s3 bucket is not set")
+)
+
+// S3ObjectStoreOptions are the options for an S3 compatible object store.
+type S3ObjectStoreOptions struct {
+	// Endpoint is the base URL of the S3 compatible service, for instance
+	// https://s3.us-east-1.amazonaws.com or http://minio:9000.
+	Endpoint string
+	// Region is the region requests are signed for.
+	Region string
+	// Bucket is the bucket objects are stored in.
+	Bucket string
+	// Prefix is prepended to the key of every object.
+	Prefix string
+	// AccessKeyID is the access key ID used to sign requests, the default
+	// AWS credentials chain is used if it is not set.
+	AccessKeyID string
+	// SecretAccessKey is the secret access key used to sign requests.
+	SecretAccessKey string
+	// HTTPClient is the client used to make requests, defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+type s3Store struct {
+	opts     S3ObjectStoreOptions
+	client   s3iface.S3API
+	uploader *s3manager.Uploader
+}
+
+// NewS3ObjectStore returns an object store backed by a bucket of an S3
+// compatible service. Requests use path style addressing.
+func NewS3ObjectStore(opts S3ObjectStoreOptions) (ObjectStore, error) {
+	if opts.Endpoint == "" {
+		return nil, errS3EndpointNotSet
+	}
+	if opts.Bucket == "" {
+		return nil, errS3BucketNotSet
+	}
+	if opts.Region == "" {
+		opts.Region = s3DefaultRegion
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	cfg := aws.NewConfig().
+		WithEndpoint(opts.Endpoint).
+		WithRegion(opts.Region).
+		WithS3ForcePathStyle(true).
+		WithHTTPClient(opts.HTTPClient)
+	if opts.AccessKeyID != "" {
+		cfg = cfg.WithCredentials(credentials.NewStaticCredentials(
+			opts.AccessKeyID, opts.SecretAccessKey, ""))
+	}
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not create s3 session: %w", err)
+	}
+	client := s3.New(sess)
+	return &s3Store{
+		opts:     opts,
+		client:   client,
+		uploader: s3manager.NewUploaderWithClient(client),
+	}, nil
+}
+
+func (s *s3Store) Put(key string, r io.Reader, size int64) error {
+	// NB: the uploader streams the object in parts so that large objects
+	// are not buffered whole in memory.
+	_, err := s.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(s.opts.Bucket),
+		Key:    aws.String(s.opts.Prefix + key),
+		Body:   io.LimitReader(r, size),
+	})
+	return err
+}
+
+func (s *s3Store) Get(key string) (io.ReadCloser, int64, error) {
+	out, err := s.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.opts.Bucket),
+		Key:    aws.String(s.opts.Prefix + key),
+	})
+	if isS3NotFound(err) {
+		return nil, 0, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return out.Body, aws.Int64Value(out.ContentLength), nil
+}
+
+func (s *s3Store) Delete(key string) error {
+	_, err := s.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.opts.Bucket),
+		Key:    aws.String(s.opts.Prefix + key),
+	})
+	if isS3NotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *s3Store) List(prefix string) ([]string, error) {
+	var keys []string
+	err := s.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(s.opts.Bucket),
+		Prefix: aws.String(s.opts.Prefix + prefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			keys = append(keys, strings.TrimPrefix(aws.StringValue(obj.Key), s.opts.Prefix))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// isS3NotFound returns whether the error is returned for a missing object,
+// services return either a NoSuchKey error or a bare 404 status.
+func isS3NotFound(err error) bool {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey
+}
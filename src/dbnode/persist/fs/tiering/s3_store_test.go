@@ -0,0 +1,163 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tiering
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3Server is a minimal in memory S3 compatible server that supports
+// path style object requests and paginated ListObjectsV2.
+type fakeS3Server struct {
+	sync.Mutex
+	t        *testing.T
+	bucket   string
+	pageSize int
+	objects  map[string][]byte
+}
+
+func newFakeS3Server(t *testing.T, bucket string) *fakeS3Server {
+	return &fakeS3Server{
+		t:        t,
+		bucket:   bucket,
+		pageSize: 2,
+		objects:  make(map[string][]byte),
+	}
+}
+
+func (s *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	assert.True(s.t, strings.HasPrefix(auth,
+		"AWS4-HMAC-SHA256 Credential=key/"), auth)
+	assert.Contains(s.t, auth, "/us-east-1/s3/aws4_request")
+	assert.NotEmpty(s.t, r.Header.Get("X-Amz-Date"))
+
+	bucketPrefix := "/" + s.bucket
+	if !strings.HasPrefix(r.URL.Path, bucketPrefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, bucketPrefix), "/")
+
+	s.Lock()
+	defer s.Unlock()
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		s.list(w, r)
+	case r.Method == http.MethodPut:
+		data, err := ioutil.ReadAll(r.Body)
+		require.NoError(s.t, err)
+		assert.Equal(s.t, int64(len(data)), r.ContentLength)
+		s.objects[key] = data
+	case r.Method == http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *fakeS3Server) list(w http.ResponseWriter, r *http.Request) {
+	var (
+		query  = r.URL.Query()
+		prefix = query.Get("prefix")
+		after  = query.Get("continuation-token")
+		keys   []string
+	)
+	assert.Equal(s.t, "2", query.Get("list-type"))
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) && key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var result fakeS3ListBucketResult
+	if len(keys) > s.pageSize {
+		keys = keys[:s.pageSize]
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[len(keys)-1]
+	}
+	for _, key := range keys {
+		result.Contents = append(result.Contents, fakeS3Object{Key: key})
+	}
+	require.NoError(s.t, xml.NewEncoder(w).Encode(result))
+}
+
+type fakeS3ListBucketResult struct {
+	XMLName               xml.Name       `xml:"ListBucketResult"`
+	Contents              []fakeS3Object `xml:"Contents"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	NextContinuationToken string         `xml:"NextContinuationToken"`
+}
+
+type fakeS3Object struct {
+	Key string `xml:"Key"`
+}
+
+func TestS3ObjectStore(t *testing.T) {
+	fake := newFakeS3Server(t, "bucket")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3ObjectStore(S3ObjectStoreOptions{
+		Endpoint:        server.URL,
+		Bucket:          "bucket",
+		Prefix:          "m3/",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+	})
+	require.NoError(t, err)
+
+	testObjectStore(t, store)
+
+	// Objects are stored under the configured prefix.
+	fake.Lock()
+	for key := range fake.objects {
+		assert.True(t, strings.HasPrefix(key, "m3/"), key)
+	}
+	fake.Unlock()
+}
+
+func TestS3ObjectStoreInvalidOptions(t *testing.T) {
+	_, err := NewS3ObjectStore(S3ObjectStoreOptions{Bucket: "bucket"})
+	require.Equal(t, errS3EndpointNotSet, err)
+
+	_, err = NewS3ObjectStore(S3ObjectStoreOptions{Endpoint: "http://localhost"})
+	require.Equal(t, errS3BucketNotSet, err)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package tiering tiers sealed filesets to object storage and pages them
+// back in on demand.
+package tiering
+
+import (
+	"errors"
+	"io"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// ErrObjectNotFound is returned when an object does not exist in the
+// object store.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStore is a store of objects addressed by slash separated keys,
+// such as an S3 bucket.
+type ObjectStore interface {
+	// Put stores the object read from the reader under the key, replacing
+	// any existing object.
+	Put(key string, r io.Reader, size int64) error
+
+	// Get returns a reader for the object stored under the key along with
+	// its size, or ErrObjectNotFound if there is none.
+	Get(key string) (io.ReadCloser, int64, error)
+
+	// Delete removes the object stored under the key if it exists.
+	Delete(key string) error
+
+	// List returns the keys of all objects whose key starts with prefix.
+	List(prefix string) ([]string, error)
+}
+
+// Manager uploads sealed filesets to an object store, evicts them from
+// local disk and pages them back in when they are read.
+type Manager interface {
+	fs.FileSetPager
+
+	// Tier uploads the data filesets of the given shards and the index
+	// filesets of a namespace that are older than the namespace's tiering
+	// threshold, evicts them from local disk and removes the objects of
+	// filesets that no longer exist locally.
+	Tier(md namespace.Metadata, shards []uint32, now xtime.UnixNano) error
+}
+
+// Options represents the options for tiering.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetObjectStore sets the object store filesets are tiered to.
+	SetObjectStore(value ObjectStore) Options
+
+	// ObjectStore returns the object store filesets are tiered to.
+	ObjectStore() ObjectStore
+
+	// SetFilesystemOptions sets the filesystem options.
+	SetFilesystemOptions(value fs.Options) Options
+
+	// FilesystemOptions returns the filesystem options.
+	FilesystemOptions() fs.Options
+
+	// SetCacheMaxBytes sets the maximum size of the fileset files paged back
+	// in from the object store that are kept on local disk.
+	SetCacheMaxBytes(value int64) Options
+
+	// CacheMaxBytes returns the maximum size of the fileset files paged back
+	// in from the object store that are kept on local disk.
+	CacheMaxBytes() int64
+
+	// SetInstrumentOptions sets the instrumentation options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrumentation options.
+	InstrumentOptions() instrument.Options
+}
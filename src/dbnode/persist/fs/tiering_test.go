@@ -0,0 +1,46 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTierableFileSetFile(t *testing.T) {
+	tests := []struct {
+		path     string
+		tierable bool
+	}{
+		{path: "/var/lib/m3db/data/ns/0/fileset-1-0-data.db", tierable: true},
+		{path: "/var/lib/m3db/data/ns/0/fileset-1-0-index.db", tierable: true},
+		{path: "/var/lib/m3db/data/ns/0/fileset-1-0-bloomfilter.db", tierable: true},
+		{path: "/var/lib/m3db/data/ns/0/fileset-1-0-summaries.db", tierable: true},
+		{path: "/var/lib/m3db/index/data/ns/fileset-1-0-segment-0.db", tierable: true},
+		{path: "/var/lib/m3db/data/ns/0/fileset-1-0-checkpoint.db", tierable: false},
+		{path: "/var/lib/m3db/data/ns/0/fileset-1-0-info.db", tierable: false},
+		{path: "/var/lib/m3db/data/ns/0/fileset-1-0-digest.db", tierable: false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.tierable, IsTierableFileSetFile(test.path), test.path)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/generated/proto/tombstone"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	xos "github.com/m3db/m3/src/x/os"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	tombstonesFileName    = "tombstones" + fileSuffix
+	tombstonesTmpFileName = tombstonesFileName + ".tmp"
+)
+
+var errTombstonesFileCorrupt = errors.New("tombstones file is corrupt")
+
+// SeriesTombstones are the deleted time ranges of a single series.
+type SeriesTombstones struct {
+	ID     ident.ID
+	Ranges []xtime.Range
+}
+
+// ShardTombstonesFilePath returns the path to the tombstones file of a shard.
+func ShardTombstonesFilePath(prefix string, namespace ident.ID, shard uint32) string {
+	return path.Join(ShardDataDirPath(prefix, namespace, shard), tombstonesFileName)
+}
+
+// WriteShardTombstones atomically replaces the tombstones file of a shard
+// with the given tombstones, removing the file if there are none.
+func WriteShardTombstones(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	tombstones []SeriesTombstones,
+) error {
+	var (
+		prefix   = opts.FilePathPrefix()
+		shardDir = ShardDataDirPath(prefix, namespace, shard)
+		filePath = path.Join(shardDir, tombstonesFileName)
+	)
+	if len(tombstones) == 0 {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := encodeTombstonesRecord(nil, tombstones)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(shardDir, opts.NewDirectoryMode()); err != nil {
+		return err
+	}
+	tmpPath := path.Join(shardDir, tombstonesTmpFileName)
+	if err := xos.WriteFileSync(tmpPath, data, opts.NewFileMode()); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// AppendShardTombstones appends the given tombstones to the tombstones file
+// of a shard, creating it if it does not exist. Appending only writes the
+// new tombstones, unlike WriteShardTombstones which rewrites all of them.
+func AppendShardTombstones(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	tombstones []SeriesTombstones,
+) error {
+	if len(tombstones) == 0 {
+		return nil
+	}
+
+	data, err := encodeTombstonesRecord(nil, tombstones)
+	if err != nil {
+		return err
+	}
+	shardDir := ShardDataDirPath(opts.FilePathPrefix(), namespace, shard)
+	if err := os.MkdirAll(shardDir, opts.NewDirectoryMode()); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path.Join(shardDir, tombstonesFileName), // nolint: gosec
+		os.O_WRONLY|os.O_CREATE|os.O_APPEND, opts.NewFileMode())
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return xerrors.FirstError(err, f.Sync(), f.Close())
+}
+
+// encodeTombstonesRecord appends a single record holding the tombstones to
+// the buffer. Each record is the length of its payload as a uvarint, the
+// payload and the digest of the payload.
+func encodeTombstonesRecord(
+	buf []byte,
+	tombstones []SeriesTombstones,
+) ([]byte, error) {
+	pb := &tombstone.ShardTombstones{
+		Series: make([]*tombstone.SeriesTombstone, 0, len(tombstones)),
+	}
+	for _, t := range tombstones {
+		series := &tombstone.SeriesTombstone{
+			Id:     t.ID.Bytes(),
+			Ranges: make([]*tombstone.Range, 0, len(t.Ranges)),
+		}
+		for _, r := range t.Ranges {
+			series.Ranges = append(series.Ranges, &tombstone.Range{
+				StartNanos: int64(r.Start),
+				EndNanos:   int64(r.End),
+			})
+		}
+		pb.Series = append(pb.Series, series)
+	}
+
+	payload, err := pb.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	var lenBuf [binary.MaxVarintLen64]byte
+	buf = append(buf, lenBuf[:binary.PutUvarint(lenBuf[:], uint64(len(payload)))]...)
+	buf = append(buf, payload...)
+	digestBuf := digest.NewBuffer()
+	digestBuf.WriteDigest(digest.Checksum(payload))
+	return append(buf, digestBuf...), nil
+}
+
+// ReadShardTombstones reads the tombstones file of a shard, returning no
+// tombstones if the file does not exist. A series may be returned more than
+// once if it was deleted from by several appends. A record that was only
+// partially appended at the end of the file is ignored.
+func ReadShardTombstones(
+	prefix string,
+	namespace ident.ID,
+	shard uint32,
+) ([]SeriesTombstones, error) {
+	filePath := ShardTombstonesFilePath(prefix, namespace, shard)
+	data, err := ioutil.ReadFile(filePath) // nolint: gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var tombstones []SeriesTombstones
+	for len(data) > 0 {
+		payloadLen, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < payloadLen ||
+			uint64(len(data)-n)-payloadLen < digest.DigestLenBytes {
+			// Torn append at the end of the file.
+			break
+		}
+		var (
+			payload  = data[n : n+int(payloadLen)]
+			expected = digest.ToBuffer(data[n+int(payloadLen):]).ReadDigest()
+		)
+		if actual := digest.Checksum(payload); actual != expected {
+			return nil, fmt.Errorf("%w: expected digest %d, actual %d",
+				errTombstonesFileCorrupt, expected, actual)
+		}
+		data = data[n+int(payloadLen)+digest.DigestLenBytes:]
+
+		var pb tombstone.ShardTombstones
+		if err := pb.Unmarshal(payload); err != nil {
+			return nil, err
+		}
+		for _, series := range pb.Series {
+			ranges := make([]xtime.Range, 0, len(series.Ranges))
+			for _, r := range series.Ranges {
+				ranges = append(ranges, xtime.Range{
+					Start: xtime.UnixNano(r.StartNanos),
+					End:   xtime.UnixNano(r.EndNanos),
+				})
+			}
+			tombstones = append(tombstones, SeriesTombstones{
+				ID:     ident.BytesID(series.Id),
+				Ranges: ranges,
+			})
+		}
+	}
+	return tombstones, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardTombstonesRoundTrip(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts  = NewOptions().SetFilePathPrefix(dir)
+		nsID  = ident.StringID("testns")
+		shard = uint32(3)
+		start = xtime.Now().Truncate(time.Hour)
+	)
+
+	read, err := ReadShardTombstones(dir, nsID, shard)
+	require.NoError(t, err)
+	require.Empty(t, read)
+
+	written := []SeriesTombstones{
+		{
+			ID: ident.StringID("foo"),
+			Ranges: []xtime.Range{
+				{Start: start, End: start.Add(time.Minute)},
+				{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)},
+			},
+		},
+		{
+			ID:     ident.StringID("bar"),
+			Ranges: []xtime.Range{{Start: start, End: start.Add(time.Second)}},
+		},
+	}
+	require.NoError(t, WriteShardTombstones(opts, nsID, shard, written))
+
+	read, err = ReadShardTombstones(dir, nsID, shard)
+	require.NoError(t, err)
+	require.Len(t, read, len(written))
+	for i := range written {
+		require.True(t, written[i].ID.Equal(read[i].ID))
+		require.Equal(t, written[i].Ranges, read[i].Ranges)
+	}
+
+	// Writing no tombstones removes the file.
+	require.NoError(t, WriteShardTombstones(opts, nsID, shard, nil))
+	_, err = os.Stat(ShardTombstonesFilePath(dir, nsID, shard))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestReadShardTombstonesCorrupt(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts  = NewOptions().SetFilePathPrefix(dir)
+		nsID  = ident.StringID("testns")
+		shard = uint32(0)
+		start = xtime.Now().Truncate(time.Hour)
+	)
+	require.NoError(t, WriteShardTombstones(opts, nsID, shard, []SeriesTombstones{
+		{
+			ID:     ident.StringID("foo"),
+			Ranges: []xtime.Range{{Start: start, End: start.Add(time.Minute)}},
+		},
+	}))
+
+	filePath := ShardTombstonesFilePath(dir, nsID, shard)
+	data, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	// Corrupt the payload rather than the length prefix of the record.
+	data[1]++
+	require.NoError(t, ioutil.WriteFile(filePath, data, 0600))
+
+	_, err = ReadShardTombstones(dir, nsID, shard)
+	require.Error(t, err)
+}
+
+func TestAppendShardTombstones(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts  = NewOptions().SetFilePathPrefix(dir)
+		nsID  = ident.StringID("testns")
+		shard = uint32(1)
+		start = xtime.Now().Truncate(time.Hour)
+		first = SeriesTombstones{
+			ID:     ident.StringID("foo"),
+			Ranges: []xtime.Range{{Start: start, End: start.Add(time.Minute)}},
+		}
+		second = SeriesTombstones{
+			ID:     ident.StringID("foo"),
+			Ranges: []xtime.Range{{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)}},
+		}
+	)
+	require.NoError(t, AppendShardTombstones(opts, nsID, shard, []SeriesTombstones{first}))
+	require.NoError(t, AppendShardTombstones(opts, nsID, shard, []SeriesTombstones{second}))
+
+	read, err := ReadShardTombstones(dir, nsID, shard)
+	require.NoError(t, err)
+	require.Len(t, read, 2)
+	require.Equal(t, first.Ranges, read[0].Ranges)
+	require.Equal(t, second.Ranges, read[1].Ranges)
+
+	// A partially appended record at the end of the file is ignored.
+	filePath := ShardTombstonesFilePath(dir, nsID, shard)
+	data, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filePath, data[:len(data)-1], 0600))
+
+	read, err = ReadShardTombstones(dir, nsID, shard)
+	require.NoError(t, err)
+	require.Len(t, read, 1)
+	require.Equal(t, first.Ranges, read[0].Ranges)
+
+	// Rewriting the file compacts the appended records.
+	require.NoError(t, WriteShardTombstones(opts, nsID, shard, []SeriesTombstones{second}))
+	read, err = ReadShardTombstones(dir, nsID, shard)
+	require.NoError(t, err)
+	require.Len(t, read, 1)
+	require.Equal(t, second.Ranges, read[0].Ranges)
+}
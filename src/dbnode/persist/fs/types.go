@@ -540,6 +540,37 @@ type Options interface {
 
 	// EncodingOptions returns the encoder options used by the encoder.
 	EncodingOptions() msgpack.LegacyEncodingOptions
+
+	// SetFileSetPager sets the pager used to restore fileset files that
+	// were evicted from local disk.
+	SetFileSetPager(value FileSetPager) Options
+
+	// FileSetPager returns the pager used to restore fileset files that
+	// were evicted from local disk.
+	FileSetPager() FileSetPager
+
+	// SetMerkleTreeDepth sets the depth of the merkle tree of series checksums
+	// written with each data fileset, zero disables writing merkle trees.
+	SetMerkleTreeDepth(value int) Options
+
+	// MerkleTreeDepth returns the depth of the merkle tree of series checksums
+	// written with each data fileset, zero disables writing merkle trees.
+	MerkleTreeDepth() int
+}
+
+// FileSetPager restores fileset files that were evicted from local disk,
+// for instance after being tiered to object storage, on demand.
+type FileSetPager interface {
+	// PageIn makes sure the given fileset files exist on local disk,
+	// restoring any that were evicted, and pins them so that they are not
+	// evicted again until released. Files that were never evicted and do
+	// not exist are left missing. Every successful call must be matched by
+	// a call to Release with the same files.
+	PageIn(filePaths []string) error
+
+	// Release unpins fileset files pinned by PageIn once they are no longer
+	// open, allowing them to be evicted from local disk.
+	Release(filePaths []string)
 }
 
 // BlockRetrieverOptions represents the options for block retrieval.
@@ -613,6 +644,15 @@ type MergeWith interface {
 		fn ForEachRemainingFn,
 		nsCtx namespace.Context,
 	) error
+
+	// Tombstones returns the deleted time ranges of the given series, or nil
+	// if the series has no deleted data.
+	Tombstones(seriesID ident.ID) xtime.Ranges
+
+	// Expired returns whether the series with the given tags is past its
+	// retention period for the given block start, in which case it is dropped
+	// from the merged fileset. The tags iterator must not be consumed.
+	Expired(tags ident.TagIterator, blockStart xtime.UnixNano) (bool, error)
 }
 
 // Merger is in charge of merging filesets with some target MergeWith interface.
@@ -69,6 +69,9 @@ type writer struct {
 	dataFdWithDigest           digest.FdWithDigestWriter
 	digestFdWithDigestContents digest.FdWithDigestContentsWriter
 	checkpointFilePath         string
+	merkleFilePath             string
+	merkleTreeDepth            int
+	merkleTree                 *digest.MerkleTree
 	indexEntries               indexEntries
 
 	start        xtime.UnixNano
@@ -149,6 +152,7 @@ func NewWriter(opts Options) (DataFileSetWriter, error) {
 		singleCheckedBytes:              make([]checked.Bytes, 1),
 		tagsIterator:                    ident.NewTagsIterator(ident.Tags{}),
 		tagEncoderPool:                  opts.TagEncoderPool(),
+		merkleTreeDepth:                 opts.MerkleTreeDepth(),
 	}, nil
 }
 
@@ -190,6 +194,8 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 		bloomFilterFilepath = FilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, bloomFilterFileSuffix)
 		dataFilepath = FilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix)
 		digestFilepath = FilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, DigestFileSuffix)
+		// Snapshots are never compared by repair so do not need a merkle tree.
+		w.merkleFilePath = ""
 	case persist.FileSetFlushType:
 		shardDir = ShardDataDirPath(w.filePathPrefix, namespace, shard)
 		if err := os.MkdirAll(shardDir, w.newDirectoryMode); err != nil {
@@ -203,6 +209,7 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 		bloomFilterFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, bloomFilterFileSuffix, false)
 		dataFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix, false)
 		digestFilepath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, DigestFileSuffix, false)
+		w.merkleFilePath = dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, MerkleFileSuffix, false)
 	default:
 		return fmt.Errorf("unable to open reader with fileset type: %s", opts.FileSetType)
 	}
@@ -246,6 +253,14 @@ func (w *writer) reset(opts DataWriterOpenOptions) {
 	// previous set of files which would have prevented them from being cleared.
 	w.indexEntries.releaseRefs()
 	w.indexEntries = w.indexEntries[:0]
+
+	if w.merkleTreeDepth > 0 {
+		if w.merkleTree == nil {
+			// Depth is validated by the options.
+			w.merkleTree, _ = digest.NewMerkleTree(w.merkleTreeDepth)
+		}
+		w.merkleTree.Reset()
+	}
 }
 
 func (w *writer) writeData(data []byte) error {
@@ -321,6 +336,7 @@ func (w *writer) writeAll(
 
 	w.indexEntries = append(w.indexEntries, entry)
 	w.currIdx++
+	w.addToMerkleTree(metadata.BytesID(), dataChecksum)
 
 	return nil
 }
@@ -375,6 +391,10 @@ func (w *writer) close() error {
 		return err
 	}
 
+	if err := w.writeMerkleFile(); err != nil {
+		return err
+	}
+
 	return w.closeWOIndex()
 }
 
@@ -635,6 +655,38 @@ func (w *writer) writeInfoFileContents(
 	return err
 }
 
+func (w *writer) addToMerkleTree(id []byte, dataChecksum uint32) {
+	if w.merkleTree != nil {
+		w.merkleTree.Add(id, dataChecksum)
+	}
+}
+
+// writeMerkleFile writes the merkle tree of the series checksums which, like
+// the other fileset files, is only considered complete once the checkpoint
+// file has been written.
+func (w *writer) writeMerkleFile() error {
+	if w.merkleTree == nil || w.merkleFilePath == "" {
+		return nil
+	}
+
+	data, err := w.merkleTree.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	fd, err := w.openWritable(w.merkleFilePath)
+	if err != nil {
+		return err
+	}
+	if _, err := fd.Write(data); err != nil {
+		// NB: intentionally skipping fd.Close() error, as failure to write
+		// takes precedence over failure to close the file.
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
+
 func writeCheckpointFile(
 	checkpointFilePath string,
 	digestChecksum uint32,
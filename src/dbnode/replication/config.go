@@ -0,0 +1,137 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"errors"
+	"time"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/msg/consumer"
+	producerconfig "github.com/m3db/m3/src/msg/producer/config"
+	"github.com/m3db/m3/src/x/instrument"
+	xio "github.com/m3db/m3/src/x/io"
+	xserver "github.com/m3db/m3/src/x/server"
+)
+
+var errProducerNotConfigured = errors.New("replication producer is not configured")
+
+// Configuration is the configuration for commitlog replication. The
+// producer is set on the replicating cluster and the consumer is set on the
+// standby cluster.
+type Configuration struct {
+	// Producer configures the m3msg producer that sends the writes in the
+	// commitlog to the standby cluster.
+	Producer *producerconfig.ProducerConfiguration `yaml:"producer"`
+
+	// PollInterval is the interval at which new commitlog files are looked
+	// for.
+	PollInterval *time.Duration `yaml:"pollInterval"`
+
+	// MaxRetainedFiles is the maximum number of sealed commitlog files kept
+	// until they are replicated, the oldest files are skipped beyond it.
+	MaxRetainedFiles *int `yaml:"maxRetainedFiles"`
+
+	// MaxRetainedBytes is the maximum total size of the sealed commitlog
+	// files kept until they are replicated, the oldest files are skipped
+	// beyond it.
+	MaxRetainedBytes *int64 `yaml:"maxRetainedBytes"`
+
+	// MaxRetainedAge is the maximum age of the sealed commitlog files kept
+	// until they are replicated, older files are skipped.
+	MaxRetainedAge *time.Duration `yaml:"maxRetainedAge"`
+
+	// Consumer configures the m3msg server that receives replicated writes
+	// and applies them to this cluster.
+	Consumer *ConsumerConfiguration `yaml:"consumer"`
+}
+
+// ConsumerConfiguration is the configuration for receiving replicated
+// writes.
+type ConsumerConfiguration struct {
+	// Server configures the m3msg server.
+	Server xserver.Configuration `yaml:"server"`
+
+	// Consumer configures the m3msg consumer.
+	Consumer consumer.Configuration `yaml:"consumer"`
+
+	// WriteConcurrency is the maximum number of replicated writes applied
+	// concurrently.
+	WriteConcurrency int `yaml:"writeConcurrency"`
+}
+
+// NewReplicator returns a replicator for the commitlog.
+func (c Configuration) NewReplicator(
+	cs clusterclient.Client,
+	commitLogOpts commitlog.Options,
+	iOpts instrument.Options,
+) (Replicator, error) {
+	if c.Producer == nil {
+		return nil, errProducerNotConfigured
+	}
+
+	scope := iOpts.MetricsScope().Tagged(map[string]string{
+		"component": "replication-producer",
+	})
+	p, err := c.Producer.NewProducer(cs, iOpts.SetMetricsScope(scope), xio.NewOptions())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Init(); err != nil {
+		return nil, err
+	}
+
+	opts := NewOptions().
+		SetCommitLogOptions(commitLogOpts).
+		SetProducer(p).
+		SetInstrumentOptions(iOpts)
+	if v := c.PollInterval; v != nil {
+		opts = opts.SetPollInterval(*v)
+	}
+	if v := c.MaxRetainedFiles; v != nil {
+		opts = opts.SetMaxRetainedFiles(*v)
+	}
+	if v := c.MaxRetainedBytes; v != nil {
+		opts = opts.SetMaxRetainedBytes(*v)
+	}
+	if v := c.MaxRetainedAge; v != nil {
+		opts = opts.SetMaxRetainedAge(*v)
+	}
+	return NewReplicator(opts)
+}
+
+// NewServer returns an m3msg server that applies the replicated writes it
+// receives with the writer.
+func (c ConsumerConfiguration) NewServer(
+	writer Writer,
+	iOpts instrument.Options,
+) xserver.Server {
+	scope := iOpts.MetricsScope().Tagged(map[string]string{
+		"server": "replication",
+	})
+	iOpts = iOpts.SetMetricsScope(scope)
+	cOpts := c.Consumer.NewOptions(iOpts.SetMetricsScope(scope.Tagged(
+		map[string]string{"component": "consumer"})))
+	p := NewMessageProcessor(writer, c.WriteConcurrency, iOpts)
+	handler := consumer.NewMessageHandler(consumer.SingletonMessageProcessor(p), cOpts)
+	return c.Server.NewServer(handler, iOpts)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/generated/proto/replicationpb"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	xos "github.com/m3db/m3/src/x/os"
+)
+
+const (
+	replicationDirName = "replication"
+	offsetsFileName    = "offsets.db"
+	offsetsTmpFileName = offsetsFileName + ".tmp"
+)
+
+var errOffsetsFileCorrupt = errors.New("replication offsets file is corrupt")
+
+// offsets is the replication state persisted across restarts.
+type offsets struct {
+	// resumeFileIndex is the index of the first commitlog file that has not
+	// been completely acknowledged.
+	resumeFileIndex int64
+	// acked is the last acknowledged offset of each shard.
+	acked map[uint32]Offset
+}
+
+// OffsetsFilePath returns the path to the file the acknowledged offsets are
+// persisted in.
+func OffsetsFilePath(prefix string) string {
+	return path.Join(prefix, replicationDirName, offsetsFileName)
+}
+
+// writeOffsets atomically replaces the offsets file with the given offsets.
+func writeOffsets(opts fs.Options, o offsets) error {
+	pb := &replicationpb.Offsets{
+		ResumeFileIndex: o.resumeFileIndex,
+		Shards:          make([]*replicationpb.ShardOffset, 0, len(o.acked)),
+	}
+	for shard, offset := range o.acked {
+		pb.Shards = append(pb.Shards, &replicationpb.ShardOffset{
+			Shard:     shard,
+			FileIndex: offset.FileIndex,
+			Entry:     offset.Entry,
+		})
+	}
+	sort.Slice(pb.Shards, func(i, j int) bool {
+		return pb.Shards[i].Shard < pb.Shards[j].Shard
+	})
+
+	data, err := pb.Marshal()
+	if err != nil {
+		return err
+	}
+	digestBuf := digest.NewBuffer()
+	digestBuf.WriteDigest(digest.Checksum(data))
+	data = append(data, digestBuf...)
+
+	dir := path.Join(opts.FilePathPrefix(), replicationDirName)
+	if err := os.MkdirAll(dir, opts.NewDirectoryMode()); err != nil {
+		return err
+	}
+	tmpPath := path.Join(dir, offsetsTmpFileName)
+	if err := xos.WriteFileSync(tmpPath, data, opts.NewFileMode()); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path.Join(dir, offsetsFileName))
+}
+
+// readOffsets reads the offsets file, returning empty offsets if the file
+// does not exist.
+func readOffsets(prefix string) (offsets, error) {
+	result := offsets{acked: make(map[uint32]Offset)}
+	data, err := ioutil.ReadFile(OffsetsFilePath(prefix)) // nolint: gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return result, nil
+		}
+		return offsets{}, err
+	}
+	if len(data) < digest.DigestLenBytes {
+		return offsets{}, errOffsetsFileCorrupt
+	}
+
+	var (
+		payloadLen = len(data) - digest.DigestLenBytes
+		payload    = data[:payloadLen]
+		expected   = digest.ToBuffer(data[payloadLen:]).ReadDigest()
+	)
+	if actual := digest.Checksum(payload); actual != expected {
+		return offsets{}, fmt.Errorf("%w: expected digest %d, actual %d",
+			errOffsetsFileCorrupt, expected, actual)
+	}
+
+	var pb replicationpb.Offsets
+	if err := pb.Unmarshal(payload); err != nil {
+		return offsets{}, err
+	}
+	result.resumeFileIndex = pb.ResumeFileIndex
+	for _, s := range pb.Shards {
+		result.acked[s.Shard] = Offset{FileIndex: s.FileIndex, Entry: s.Entry}
+	}
+	return result, nil
+}
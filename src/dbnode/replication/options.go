@@ -0,0 +1,160 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	// defaultPollInterval is the default interval at which new commitlog
+	// files are looked for.
+	defaultPollInterval = 10 * time.Second
+)
+
+var (
+	errCommitLogOptionsNotSet = errors.New("commitlog options are not set")
+	errProducerNotSet         = errors.New("producer is not set")
+	errPollIntervalPositive   = errors.New("poll interval must be positive")
+	errMaxRetainedNegative    = errors.New("max retained commitlog limits must not be negative")
+)
+
+type options struct {
+	commitLogOpts  commitlog.Options
+	producer       producer.Producer
+	pollInterval   time.Duration
+	maxFiles       int
+	maxBytes       int64
+	maxAge         time.Duration
+	clockOpts      clock.Options
+	instrumentOpts instrument.Options
+}
+
+// NewOptions creates a new set of replication options.
+func NewOptions() Options {
+	return &options{
+		pollInterval:   defaultPollInterval,
+		clockOpts:      clock.NewOptions(),
+		instrumentOpts: instrument.NewOptions(),
+	}
+}
+
+func (o *options) Validate() error {
+	if o.commitLogOpts == nil {
+		return errCommitLogOptionsNotSet
+	}
+	if o.producer == nil {
+		return errProducerNotSet
+	}
+	if o.pollInterval <= 0 {
+		return errPollIntervalPositive
+	}
+	if o.maxFiles < 0 || o.maxBytes < 0 || o.maxAge < 0 {
+		return errMaxRetainedNegative
+	}
+	return nil
+}
+
+func (o *options) SetCommitLogOptions(value commitlog.Options) Options {
+	opts := *o
+	opts.commitLogOpts = value
+	return &opts
+}
+
+func (o *options) CommitLogOptions() commitlog.Options {
+	return o.commitLogOpts
+}
+
+func (o *options) SetProducer(value producer.Producer) Options {
+	opts := *o
+	opts.producer = value
+	return &opts
+}
+
+func (o *options) Producer() producer.Producer {
+	return o.producer
+}
+
+func (o *options) SetPollInterval(value time.Duration) Options {
+	opts := *o
+	opts.pollInterval = value
+	return &opts
+}
+
+func (o *options) PollInterval() time.Duration {
+	return o.pollInterval
+}
+
+func (o *options) SetMaxRetainedFiles(value int) Options {
+	opts := *o
+	opts.maxFiles = value
+	return &opts
+}
+
+func (o *options) MaxRetainedFiles() int {
+	return o.maxFiles
+}
+
+func (o *options) SetMaxRetainedBytes(value int64) Options {
+	opts := *o
+	opts.maxBytes = value
+	return &opts
+}
+
+func (o *options) MaxRetainedBytes() int64 {
+	return o.maxBytes
+}
+
+func (o *options) SetMaxRetainedAge(value time.Duration) Options {
+	opts := *o
+	opts.maxAge = value
+	return &opts
+}
+
+func (o *options) MaxRetainedAge() time.Duration {
+	return o.maxAge
+}
+
+func (o *options) SetClockOptions(value clock.Options) Options {
+	opts := *o
+	opts.clockOpts = value
+	return &opts
+}
+
+func (o *options) ClockOptions() clock.Options {
+	return o.clockOpts
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
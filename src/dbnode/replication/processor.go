@@ -0,0 +1,146 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"sync"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/generated/proto/replicationpb"
+	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3/src/x/checked"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	"github.com/m3db/m3/src/x/pool"
+	"github.com/m3db/m3/src/x/serialize"
+	xsync "github.com/m3db/m3/src/x/sync"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const defaultWriteConcurrency = 64
+
+type processorMetrics struct {
+	written     tally.Counter
+	writeErrors tally.Counter
+	rejected    tally.Counter
+	malformed   tally.Counter
+}
+
+func newProcessorMetrics(scope tally.Scope) processorMetrics {
+	return processorMetrics{
+		written:     scope.Counter("written"),
+		writeErrors: scope.Counter("write-errors"),
+		rejected:    scope.Counter("rejected"),
+		malformed:   scope.Counter("malformed"),
+	}
+}
+
+type processor struct {
+	writer     Writer
+	workers    xsync.WorkerPool
+	tagDecoder serialize.TagDecoderPool
+	wg         sync.WaitGroup
+	logger     *zap.Logger
+	metrics    processorMetrics
+}
+
+// NewMessageProcessor returns an m3msg message processor that applies the
+// replicated writes to the standby cluster with the writer. Messages are
+// acknowledged once their write succeeds or is rejected, failed writes are
+// retried by the replicating cluster.
+func NewMessageProcessor(
+	writer Writer,
+	concurrency int,
+	iOpts instrument.Options,
+) consumer.MessageProcessor {
+	if concurrency <= 0 {
+		concurrency = defaultWriteConcurrency
+	}
+	workers := xsync.NewWorkerPool(concurrency)
+	workers.Init()
+	tagDecoder := serialize.NewTagDecoderPool(
+		serialize.NewTagDecoderOptions(serialize.TagDecoderOptionsConfig{}),
+		pool.NewObjectPoolOptions().SetSize(concurrency))
+	tagDecoder.Init()
+	return &processor{
+		writer:     writer,
+		workers:    workers,
+		tagDecoder: tagDecoder,
+		logger:     iOpts.Logger(),
+		metrics: newProcessorMetrics(
+			iOpts.MetricsScope().SubScope("replication-consumer")),
+	}
+}
+
+func (p *processor) Process(msg consumer.Message) {
+	var write replicationpb.Write
+	if err := write.Unmarshal(msg.Bytes()); err != nil {
+		// A malformed message can never be applied, acknowledge it so that
+		// it is not retried forever.
+		p.metrics.malformed.Inc(1)
+		p.logger.Error("could not decode replicated write", zap.Error(err))
+		msg.Ack()
+		return
+	}
+
+	p.wg.Add(1)
+	p.workers.Go(func() {
+		defer p.wg.Done()
+		if err := p.write(&write); err != nil {
+			if !client.IsBadRequestError(err) && !xerrors.IsNonRetryableError(err) {
+				p.metrics.writeErrors.Inc(1)
+				return
+			}
+			p.metrics.rejected.Inc(1)
+			p.logger.Error("replicated write rejected", zap.Error(err))
+		} else {
+			p.metrics.written.Inc(1)
+		}
+		msg.Ack()
+	})
+}
+
+func (p *processor) write(write *replicationpb.Write) error {
+	var (
+		namespace = ident.BytesID(write.Namespace)
+		id        = ident.BytesID(write.Id)
+		t         = xtime.UnixNano(write.TimestampNanos)
+		unit      = xtime.Unit(write.Unit)
+	)
+	if len(write.EncodedTags) == 0 {
+		return p.writer.Write(namespace, id, t, write.Value, unit, write.Annotation)
+	}
+
+	tags := p.tagDecoder.Get()
+	defer tags.Close()
+	tags.Reset(checked.NewBytes(write.EncodedTags, nil))
+	if err := tags.Err(); err != nil {
+		return xerrors.NewNonRetryableError(err)
+	}
+
+	return p.writer.WriteTagged(namespace, id, tags, t, write.Value, unit, write.Annotation)
+}
+
+func (p *processor) Close() { p.wg.Wait() }
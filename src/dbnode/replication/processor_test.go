@@ -0,0 +1,186 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/generated/proto/replicationpb"
+	"github.com/m3db/m3/src/msg/consumer"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	"github.com/m3db/m3/src/x/serialize"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWrite struct {
+	namespace string
+	id        string
+	tags      map[string]string
+	t         xtime.UnixNano
+	value     float64
+}
+
+type fakeWriter struct {
+	sync.Mutex
+	writes []fakeWrite
+	err    error
+}
+
+func (w *fakeWriter) Write(
+	namespace, id ident.ID,
+	t xtime.UnixNano,
+	value float64,
+	_ xtime.Unit,
+	_ []byte,
+) error {
+	w.Lock()
+	defer w.Unlock()
+	w.writes = append(w.writes, fakeWrite{
+		namespace: namespace.String(),
+		id:        id.String(),
+		t:         t,
+		value:     value,
+	})
+	return w.err
+}
+
+func (w *fakeWriter) WriteTagged(
+	namespace, id ident.ID,
+	tags ident.TagIterator,
+	t xtime.UnixNano,
+	value float64,
+	_ xtime.Unit,
+	_ []byte,
+) error {
+	w.Lock()
+	defer w.Unlock()
+	decoded := make(map[string]string)
+	for tags.Next() {
+		tag := tags.Current()
+		decoded[tag.Name.String()] = tag.Value.String()
+	}
+	if err := tags.Err(); err != nil {
+		return err
+	}
+	w.writes = append(w.writes, fakeWrite{
+		namespace: namespace.String(),
+		id:        id.String(),
+		tags:      decoded,
+		t:         t,
+		value:     value,
+	})
+	return w.err
+}
+
+func newTestMessage(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	write *replicationpb.Write,
+	expectAck bool,
+) consumer.Message {
+	data, err := write.Marshal()
+	require.NoError(t, err)
+	msg := consumer.NewMockMessage(ctrl)
+	msg.EXPECT().Bytes().Return(data)
+	if expectAck {
+		msg.EXPECT().Ack()
+	}
+	return msg
+}
+
+func encodeTestTags(t *testing.T, tags ...ident.Tag) []byte {
+	encoder := serialize.NewTagEncoderPool(serialize.NewTagEncoderOptions(), nil)
+	encoder.Init()
+	enc := encoder.Get()
+	require.NoError(t, enc.Encode(ident.NewTagsIterator(ident.NewTags(tags...))))
+	data, ok := enc.Data()
+	require.True(t, ok)
+	return append([]byte(nil), data.Bytes()...)
+}
+
+func TestMessageProcessorAppliesWrites(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	writer := &fakeWriter{}
+	p := NewMessageProcessor(writer, 1, instrument.NewOptions())
+	now := xtime.Now()
+
+	p.Process(newTestMessage(t, ctrl, &replicationpb.Write{
+		Namespace:      []byte("testns"),
+		Id:             []byte("foo"),
+		EncodedTags:    encodeTestTags(t, ident.StringTag("city", "nyc")),
+		TimestampNanos: int64(now),
+		Value:          1,
+		Unit:           uint32(xtime.Second),
+	}, true))
+	p.Process(newTestMessage(t, ctrl, &replicationpb.Write{
+		Namespace:      []byte("testns"),
+		Id:             []byte("bar"),
+		TimestampNanos: int64(now),
+		Value:          2,
+		Unit:           uint32(xtime.Second),
+	}, true))
+	p.Close()
+
+	require.Equal(t, []fakeWrite{
+		{namespace: "testns", id: "foo", tags: map[string]string{"city": "nyc"}, t: now, value: 1},
+		{namespace: "testns", id: "bar", t: now, value: 2},
+	}, writer.writes)
+}
+
+func TestMessageProcessorAcknowledgesRejectedWrites(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	write := &replicationpb.Write{
+		Namespace:      []byte("testns"),
+		Id:             []byte("foo"),
+		TimestampNanos: int64(xtime.Now()),
+		Unit:           uint32(xtime.Second),
+	}
+
+	// Retryable errors are not acknowledged so that the write is retried.
+	writer := &fakeWriter{err: errors.New("timeout")}
+	p := NewMessageProcessor(writer, 1, instrument.NewOptions())
+	p.Process(newTestMessage(t, ctrl, write, false))
+	p.Close()
+
+	writer = &fakeWriter{err: xerrors.NewInvalidParamsError(errors.New("invalid"))}
+	p = NewMessageProcessor(writer, 1, instrument.NewOptions())
+	p.Process(newTestMessage(t, ctrl, write, true))
+	p.Close()
+
+	// Malformed messages are acknowledged without being written.
+	msg := consumer.NewMockMessage(ctrl)
+	msg.EXPECT().Bytes().Return([]byte{0xff})
+	msg.EXPECT().Ack()
+	p.Process(msg)
+	require.Len(t, writer.writes, 1)
+}
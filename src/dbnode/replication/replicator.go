@@ -0,0 +1,622 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"container/list"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/proto/replicationpb"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+var (
+	errReplicatorAlreadyOpen = errors.New("replicator is already open")
+	errReplicatorNotOpen     = errors.New("replicator is not open")
+	errTopicHasNoShards      = errors.New("replication topic has no shards")
+
+	// noOffset is before the offset of any commitlog entry.
+	noOffset = Offset{FileIndex: -1, Entry: -1}
+)
+
+type replicatorState int
+
+const (
+	replicatorNotOpen replicatorState = iota
+	replicatorOpen
+	replicatorClosed
+)
+
+type replicatorMetrics struct {
+	scope             tally.Scope
+	produced          tally.Counter
+	acknowledged      tally.Counter
+	dropped           tally.Counter
+	errors            tally.Counter
+	skippedFiles      tally.Counter
+	unreplicatedFiles tally.Gauge
+}
+
+func newReplicatorMetrics(scope tally.Scope) replicatorMetrics {
+	return replicatorMetrics{
+		scope:             scope,
+		produced:          scope.Counter("produced"),
+		acknowledged:      scope.Counter("acknowledged"),
+		dropped:           scope.Counter("dropped"),
+		errors:            scope.Counter("errors"),
+		skippedFiles:      scope.Counter("skipped-files"),
+		unreplicatedFiles: scope.Gauge("unreplicated-files"),
+	}
+}
+
+// pendingWrite is a write that was produced but whose offset has not been
+// acknowledged yet, either because the write itself or an earlier write of
+// the same shard has not been consumed.
+type pendingWrite struct {
+	offset    Offset
+	timestamp xtime.UnixNano
+	consumed  bool
+	finalized bool
+}
+
+type shardState struct {
+	acked    Offset
+	produced Offset
+	// pending holds the pending writes of the shard in offset order.
+	pending *list.List
+	lag     tally.Gauge
+	waiting tally.Gauge
+}
+
+type replicator struct {
+	sync.Mutex
+
+	opts     Options
+	producer producer.Producer
+	nowFn    clock.NowFn
+	logger   *zap.Logger
+	metrics  replicatorMetrics
+
+	state      replicatorState
+	activeLogs ActiveLogs
+	// nextFileIndex is the index of the next commitlog file to produce.
+	nextFileIndex int64
+	shards        map[uint32]*shardState
+	inflight      int
+	dropped       bool
+	persisted     offsets
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+// NewReplicator returns a new commitlog replicator.
+func NewReplicator(opts Options) (Replicator, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	iOpts := opts.InstrumentOptions()
+	return &replicator{
+		opts:     opts,
+		producer: opts.Producer(),
+		nowFn:    opts.ClockOptions().NowFn(),
+		logger:   iOpts.Logger(),
+		metrics:  newReplicatorMetrics(iOpts.MetricsScope().SubScope("replication")),
+		shards:   make(map[uint32]*shardState),
+		closeCh:  make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}, nil
+}
+
+func (r *replicator) Open(activeLogs ActiveLogs) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.state != replicatorNotOpen {
+		return errReplicatorAlreadyOpen
+	}
+
+	prefix := r.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	persisted, err := readOffsets(prefix)
+	if err != nil {
+		return err
+	}
+	r.nextFileIndex = persisted.resumeFileIndex
+	for shard, offset := range persisted.acked {
+		s := r.shardStateWithLock(shard)
+		s.acked = offset
+		s.produced = offset
+	}
+	r.persisted = persisted
+	r.activeLogs = activeLogs
+	r.state = replicatorOpen
+
+	go r.run()
+	return nil
+}
+
+func (r *replicator) Close() error {
+	r.Lock()
+	if r.state != replicatorOpen {
+		r.Unlock()
+		return errReplicatorNotOpen
+	}
+	r.state = replicatorClosed
+	r.Unlock()
+
+	close(r.closeCh)
+	<-r.doneCh
+
+	// Writes that have not been consumed yet are produced again after a
+	// restart since they are after the acknowledged offsets.
+	r.producer.Close(producer.DropEverything)
+	return r.persistOffsets()
+}
+
+func (r *replicator) run() {
+	defer close(r.doneCh)
+
+	ticker := time.NewTicker(r.opts.PollInterval())
+	defer ticker.Stop()
+	for {
+		if err := r.replicate(); err != nil {
+			r.metrics.errors.Inc(1)
+			r.logger.Error("could not replicate commitlog", zap.Error(err))
+		}
+
+		select {
+		case <-r.closeCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// replicate produces the writes of all commitlog files that are no longer
+// active and have not been produced yet.
+func (r *replicator) replicate() error {
+	r.maybeRewind()
+
+	files, _, err := commitlog.Files(r.opts.CommitLogOptions())
+	if err != nil {
+		return err
+	}
+	activeLogs, err := r.activeLogs.ActiveLogs()
+	if err != nil {
+		return err
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, file := range files {
+		if activeLogs.Contains(file.FilePath) {
+			// Active files have the highest indexes and are replicated once
+			// they are rotated.
+			break
+		}
+		if file.Index < r.nextProducedFileIndex() {
+			continue
+		}
+		if err := r.replicateFile(file); err != nil {
+			multiErr = multiErr.Add(err)
+			break
+		}
+
+		r.Lock()
+		r.nextFileIndex = file.Index + 1
+		r.Unlock()
+
+		select {
+		case <-r.closeCh:
+			return r.persistOffsets()
+		default:
+		}
+	}
+
+	var unreplicated []persist.CommitLogFile
+	retained := r.RetainedCommitLogIndex()
+	for _, file := range files {
+		if file.Index >= retained && !activeLogs.Contains(file.FilePath) {
+			unreplicated = append(unreplicated, file)
+		}
+	}
+	if skipped := r.enforceRetainedLimits(unreplicated); skipped > 0 {
+		unreplicated = unreplicated[skipped:]
+	}
+	r.metrics.unreplicatedFiles.Update(float64(len(unreplicated)))
+
+	multiErr = multiErr.Add(r.persistOffsets())
+	return multiErr.FinalError()
+}
+
+// enforceRetainedLimits skips the oldest of the unreplicated files, ordered
+// by index, while they exceed the max retained limits so that they can be
+// removed, and returns the number of files skipped. The writes of skipped
+// files that were not acknowledged are lost to the standby.
+func (r *replicator) enforceRetainedLimits(unreplicated []persist.CommitLogFile) int {
+	var (
+		maxFiles = r.opts.MaxRetainedFiles()
+		maxBytes = r.opts.MaxRetainedBytes()
+		maxAge   = r.opts.MaxRetainedAge()
+	)
+	if maxFiles <= 0 && maxBytes <= 0 && maxAge <= 0 {
+		return 0
+	}
+
+	infos := make([]os.FileInfo, 0, len(unreplicated))
+	totalBytes := int64(0)
+	for _, file := range unreplicated {
+		info, err := os.Stat(file.FilePath)
+		if err != nil {
+			// The file is treated as empty and recent, it is skipped if
+			// needed for the number of files kept.
+			r.logger.Warn("could not stat unreplicated commitlog file",
+				zap.String("file", file.FilePath), zap.Error(err))
+		}
+		infos = append(infos, info)
+		if info != nil {
+			totalBytes += info.Size()
+		}
+	}
+
+	now := r.nowFn()
+	skipped := 0
+	for i, info := range infos {
+		var (
+			exceedsFiles = maxFiles > 0 && len(infos)-i > maxFiles
+			exceedsBytes = maxBytes > 0 && totalBytes > maxBytes
+			exceedsAge   = maxAge > 0 && info != nil && now.Sub(info.ModTime()) > maxAge
+		)
+		if !exceedsFiles && !exceedsBytes && !exceedsAge {
+			break
+		}
+		if info != nil {
+			totalBytes -= info.Size()
+		}
+		skipped++
+	}
+	if skipped == 0 {
+		return 0
+	}
+
+	resumeFileIndex := unreplicated[skipped-1].Index + 1
+	r.skipTo(resumeFileIndex)
+	r.metrics.skippedFiles.Inc(int64(skipped))
+	r.logger.Warn("unreplicated commitlog files exceed the max retained limits, "+
+		"skipping oldest files without replicating them to the standby",
+		zap.Int("skippedFiles", skipped),
+		zap.Int64("resumeFileIndex", resumeFileIndex),
+		zap.Int("maxRetainedFiles", maxFiles),
+		zap.Int64("maxRetainedBytes", maxBytes),
+		zap.Duration("maxRetainedAge", maxAge))
+	return skipped
+}
+
+// skipTo drops the replication progress of all commitlog files before the
+// index, treating their writes as acknowledged.
+func (r *replicator) skipTo(fileIndex int64) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.nextFileIndex < fileIndex {
+		r.nextFileIndex = fileIndex
+	}
+	skipped := Offset{FileIndex: fileIndex, Entry: -1}
+	for _, s := range r.shards {
+		if s.acked.Before(skipped) {
+			s.acked = skipped
+		}
+		if s.produced.Before(skipped) {
+			s.produced = skipped
+		}
+		// Writes still in flight are finalized as usual, but no longer hold
+		// back the acknowledged offset of the shard.
+		for front := s.pending.Front(); front != nil; front = s.pending.Front() {
+			if !front.Value.(*pendingWrite).offset.Before(skipped) {
+				break
+			}
+			s.pending.Remove(front)
+		}
+		for front := s.pending.Front(); front != nil; front = s.pending.Front() {
+			next := front.Value.(*pendingWrite)
+			if !next.consumed {
+				break
+			}
+			s.acked = next.offset
+			s.pending.Remove(front)
+		}
+	}
+}
+
+func (r *replicator) replicateFile(file persist.CommitLogFile) error {
+	iter, _, err := commitlog.NewIterator(commitlog.IteratorOpts{
+		CommitLogOptions: r.opts.CommitLogOptions(),
+		FileFilterPredicate: func(f commitlog.FileFilterInfo) bool {
+			return !f.IsCorrupt && f.File.FilePath == file.FilePath
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for entry := int64(0); iter.Next(); entry++ {
+		offset := Offset{FileIndex: file.Index, Entry: entry}
+		if err := r.produce(iter.Current(), offset); err != nil {
+			return err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		// Consistent with the commitlog bootstrapper, the writes that can be
+		// read from a partially corrupt file are replicated.
+		r.metrics.errors.Inc(1)
+		r.logger.Error("could not read all commitlog entries, skipping remaining entries",
+			zap.String("file", file.FilePath), zap.Error(err))
+	}
+	return nil
+}
+
+func (r *replicator) produce(entry commitlog.LogEntry, offset Offset) error {
+	numShards := r.producer.NumShards()
+	if numShards == 0 {
+		return errTopicHasNoShards
+	}
+
+	shard := entry.Series.Shard
+	r.Lock()
+	s := r.shardStateWithLock(shard)
+	if !s.produced.Before(offset) {
+		// Already produced before a restart or a failed produce.
+		r.Unlock()
+		return nil
+	}
+	prevProduced := s.produced
+	s.produced = offset
+	pw := &pendingWrite{
+		offset:    offset,
+		timestamp: entry.Datapoint.TimestampNanos,
+	}
+	el := s.pending.PushBack(pw)
+	r.inflight++
+	r.Unlock()
+
+	write := replicationpb.Write{
+		Namespace:      entry.Series.Namespace.Bytes(),
+		Id:             entry.Series.ID.Bytes(),
+		EncodedTags:    entry.Series.EncodedTags,
+		TimestampNanos: int64(entry.Datapoint.TimestampNanos),
+		Value:          entry.Datapoint.Value,
+		Unit:           uint32(entry.Unit),
+		Annotation:     entry.Annotation,
+	}
+	data, err := write.Marshal()
+	if err == nil {
+		err = r.producer.Produce(&message{
+			r:          r,
+			shard:      shard,
+			el:         el,
+			data:       data,
+			topicShard: shard % numShards,
+		})
+	}
+	if err != nil {
+		r.Lock()
+		defer r.Unlock()
+		if pw.finalized {
+			// The producer dropped the message, the rewind takes care of
+			// producing it again.
+			return err
+		}
+		s.pending.Remove(el)
+		s.produced = prevProduced
+		r.inflight--
+		return err
+	}
+	r.metrics.produced.Inc(1)
+	return nil
+}
+
+// finalize is called by the producer once a write was either consumed by
+// the standby or dropped.
+func (r *replicator) finalize(shard uint32, el *list.Element, reason producer.FinalizeReason) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.inflight--
+	pw := el.Value.(*pendingWrite)
+	pw.finalized = true
+	if reason != producer.Consumed {
+		// The write and any later writes of the shard cannot be acknowledged
+		// until the write is produced again.
+		r.dropped = true
+		r.metrics.dropped.Inc(1)
+		return
+	}
+
+	r.metrics.acknowledged.Inc(1)
+	pw.consumed = true
+	s := r.shards[shard]
+	for front := s.pending.Front(); front != nil; front = s.pending.Front() {
+		next := front.Value.(*pendingWrite)
+		if !next.consumed {
+			break
+		}
+		s.acked = next.offset
+		s.pending.Remove(front)
+	}
+}
+
+// maybeRewind produces all writes after the acknowledged offsets again if
+// any write was dropped, once no writes are in flight anymore.
+func (r *replicator) maybeRewind() {
+	r.Lock()
+	defer r.Unlock()
+
+	if !r.dropped || r.inflight > 0 {
+		return
+	}
+	r.nextFileIndex = r.retainedCommitLogIndexWithLock()
+	for _, s := range r.shards {
+		s.pending.Init()
+		s.produced = s.acked
+	}
+	r.dropped = false
+}
+
+func (r *replicator) nextProducedFileIndex() int64 {
+	r.Lock()
+	defer r.Unlock()
+	return r.nextFileIndex
+}
+
+func (r *replicator) persistOffsets() error {
+	r.Lock()
+	o := offsets{
+		resumeFileIndex: r.retainedCommitLogIndexWithLock(),
+		acked:           make(map[uint32]Offset, len(r.shards)),
+	}
+	for shard, s := range r.shards {
+		if s.acked != noOffset {
+			o.acked[shard] = s.acked
+		}
+	}
+	unchanged := o.resumeFileIndex == r.persisted.resumeFileIndex &&
+		offsetsEqual(o.acked, r.persisted.acked)
+	r.Unlock()
+
+	if unchanged {
+		return nil
+	}
+	if err := writeOffsets(r.opts.CommitLogOptions().FilesystemOptions(), o); err != nil {
+		return err
+	}
+
+	r.Lock()
+	r.persisted = o
+	r.Unlock()
+	return nil
+}
+
+func (r *replicator) Report() {
+	r.Lock()
+	defer r.Unlock()
+
+	now := xtime.ToUnixNano(r.nowFn())
+	for _, s := range r.shards {
+		var lag time.Duration
+		if front := s.pending.Front(); front != nil {
+			lag = now.Sub(front.Value.(*pendingWrite).timestamp)
+		}
+		s.lag.Update(lag.Seconds())
+		s.waiting.Update(float64(s.pending.Len()))
+	}
+}
+
+func (r *replicator) AcknowledgedOffsets() map[uint32]Offset {
+	r.Lock()
+	defer r.Unlock()
+
+	result := make(map[uint32]Offset, len(r.shards))
+	for shard, s := range r.shards {
+		if s.acked != noOffset {
+			result[shard] = s.acked
+		}
+	}
+	return result
+}
+
+func (r *replicator) RetainedCommitLogIndex() int64 {
+	r.Lock()
+	defer r.Unlock()
+	return r.retainedCommitLogIndexWithLock()
+}
+
+func (r *replicator) retainedCommitLogIndexWithLock() int64 {
+	index := r.nextFileIndex
+	for _, s := range r.shards {
+		if front := s.pending.Front(); front != nil {
+			if fileIndex := front.Value.(*pendingWrite).offset.FileIndex; fileIndex < index {
+				index = fileIndex
+			}
+		}
+	}
+	return index
+}
+
+func (r *replicator) shardStateWithLock(shard uint32) *shardState {
+	s, ok := r.shards[shard]
+	if !ok {
+		scope := r.metrics.scope.Tagged(map[string]string{
+			"shard": strconv.Itoa(int(shard)),
+		})
+		s = &shardState{
+			acked:    noOffset,
+			produced: noOffset,
+			pending:  list.New(),
+			lag:      scope.Gauge("lag-seconds"),
+			waiting:  scope.Gauge("pending"),
+		}
+		r.shards[shard] = s
+	}
+	return s
+}
+
+func offsetsEqual(a, b map[uint32]Offset) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for shard, offset := range a {
+		if other, ok := b[shard]; !ok || other != offset {
+			return false
+		}
+	}
+	return true
+}
+
+// message is a replicated write produced to the standby cluster.
+type message struct {
+	r          *replicator
+	shard      uint32
+	el         *list.Element
+	data       []byte
+	topicShard uint32
+}
+
+func (m *message) Shard() uint32 { return m.topicShard }
+
+func (m *message) Bytes() []byte { return m.data }
+
+func (m *message) Size() int { return len(m.data) }
+
+func (m *message) Finalize(reason producer.FinalizeReason) {
+	m.r.finalize(m.shard, m.el, reason)
+}
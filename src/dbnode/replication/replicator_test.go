@@ -0,0 +1,308 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/dbnode/generated/proto/replicationpb"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProducer struct {
+	sync.Mutex
+	messages []producer.Message
+}
+
+func (p *fakeProducer) Produce(m producer.Message) error {
+	p.Lock()
+	defer p.Unlock()
+	p.messages = append(p.messages, m)
+	return nil
+}
+
+func (p *fakeProducer) take() []producer.Message {
+	p.Lock()
+	defer p.Unlock()
+	messages := p.messages
+	p.messages = nil
+	return messages
+}
+
+func (p *fakeProducer) len() int {
+	p.Lock()
+	defer p.Unlock()
+	return len(p.messages)
+}
+
+func (p *fakeProducer) RegisterFilter(services.ServiceID, producer.FilterFunc) {}
+func (p *fakeProducer) UnregisterFilter(services.ServiceID)                    {}
+func (p *fakeProducer) NumShards() uint32                                      { return 2 }
+func (p *fakeProducer) Init() error                                            { return nil }
+func (p *fakeProducer) Close(producer.CloseType)                               {}
+
+type testWrite struct {
+	id    string
+	shard uint32
+	value float64
+}
+
+func newTestCommitLog(t *testing.T) (commitlog.CommitLog, commitlog.Options) {
+	opts := commitlog.NewOptions().
+		SetStrategy(commitlog.StrategyWriteWait).
+		SetFlushInterval(10 * time.Millisecond).
+		SetFilesystemOptions(commitlog.NewOptions().FilesystemOptions().
+			SetFilePathPrefix(t.TempDir()))
+	commitLog, err := commitlog.NewCommitLog(opts)
+	require.NoError(t, err)
+	require.NoError(t, commitLog.Open())
+	t.Cleanup(func() { require.NoError(t, commitLog.Close()) })
+	return commitLog, opts
+}
+
+func writeTestCommitLog(t *testing.T, commitLog commitlog.CommitLog, writes []testWrite) {
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	now := xtime.Now().Truncate(time.Second)
+	for i, w := range writes {
+		series := ts.Series{
+			UniqueIndex: uint64(i),
+			Namespace:   ident.StringID("testns"),
+			ID:          ident.StringID(w.id),
+			Shard:       w.shard,
+		}
+		dp := ts.Datapoint{TimestampNanos: now, Value: w.value}
+		require.NoError(t, commitLog.Write(ctx, series, dp, xtime.Second, nil))
+	}
+	_, err := commitLog.RotateLogs()
+	require.NoError(t, err)
+}
+
+func newTestReplicator(
+	t *testing.T,
+	commitLog commitlog.CommitLog,
+	commitLogOpts commitlog.Options,
+	p producer.Producer,
+) *replicator {
+	r, err := NewReplicator(NewOptions().
+		SetCommitLogOptions(commitLogOpts).
+		SetProducer(p).
+		SetPollInterval(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, r.Open(commitLog))
+	return r.(*replicator)
+}
+
+func decodeWrite(t *testing.T, m producer.Message) replicationpb.Write {
+	var w replicationpb.Write
+	require.NoError(t, w.Unmarshal(m.Bytes()))
+	return w
+}
+
+func waitForMessages(t *testing.T, p *fakeProducer, n int) []producer.Message {
+	for start := time.Now(); p.len() < n; time.Sleep(10 * time.Millisecond) {
+		require.True(t, time.Since(start) < 10*time.Second, "timed out waiting for messages")
+	}
+	messages := p.take()
+	require.Len(t, messages, n)
+	return messages
+}
+
+func TestReplicatorReplicatesSealedCommitLogs(t *testing.T) {
+	commitLog, commitLogOpts := newTestCommitLog(t)
+	writeTestCommitLog(t, commitLog, []testWrite{
+		{id: "foo", shard: 0, value: 1},
+		{id: "bar", shard: 1, value: 2},
+		{id: "baz", shard: 0, value: 3},
+	})
+
+	p := &fakeProducer{}
+	r := newTestReplicator(t, commitLog, commitLogOpts, p)
+	defer func() { require.NoError(t, r.Close()) }()
+
+	messages := waitForMessages(t, p, 3)
+	var ids []string
+	for _, m := range messages {
+		w := decodeWrite(t, m)
+		ids = append(ids, string(w.Id))
+		require.Equal(t, "testns", string(w.Namespace))
+	}
+	require.Equal(t, []string{"foo", "bar", "baz"}, ids)
+	require.Equal(t, uint32(0), messages[0].Shard())
+	require.Equal(t, uint32(1), messages[1].Shard())
+
+	fileIndex := decodeOffsetFileIndex(t, r)
+	require.Equal(t, fileIndex, r.RetainedCommitLogIndex())
+
+	// Acknowledging the last write of shard 0 does not advance its offset
+	// until the first write is acknowledged as well.
+	messages[2].Finalize(producer.Consumed)
+	messages[1].Finalize(producer.Consumed)
+	require.Equal(t, map[uint32]Offset{
+		1: {FileIndex: fileIndex, Entry: 1},
+	}, r.AcknowledgedOffsets())
+	require.Equal(t, fileIndex, r.RetainedCommitLogIndex())
+
+	messages[0].Finalize(producer.Consumed)
+	require.Equal(t, map[uint32]Offset{
+		0: {FileIndex: fileIndex, Entry: 2},
+		1: {FileIndex: fileIndex, Entry: 1},
+	}, r.AcknowledgedOffsets())
+	require.Equal(t, fileIndex+1, r.RetainedCommitLogIndex())
+
+	// Nothing is produced again until the commitlog is rotated.
+	require.NoError(t, r.replicate())
+	require.Equal(t, 0, p.len())
+}
+
+func TestReplicatorRewindsDroppedWrites(t *testing.T) {
+	commitLog, commitLogOpts := newTestCommitLog(t)
+	writeTestCommitLog(t, commitLog, []testWrite{
+		{id: "foo", shard: 0, value: 1},
+		{id: "bar", shard: 0, value: 2},
+		{id: "baz", shard: 1, value: 3},
+	})
+
+	p := &fakeProducer{}
+	r := newTestReplicator(t, commitLog, commitLogOpts, p)
+	defer func() { require.NoError(t, r.Close()) }()
+
+	messages := waitForMessages(t, p, 3)
+	messages[0].Finalize(producer.Consumed)
+	messages[1].Finalize(producer.Dropped)
+
+	// Writes are only produced again once no writes are in flight.
+	require.NoError(t, r.replicate())
+	require.Equal(t, 0, p.len())
+
+	messages[2].Finalize(producer.Consumed)
+	require.NoError(t, r.replicate())
+	messages = p.take()
+	require.Len(t, messages, 1)
+	require.Equal(t, "bar", string(decodeWrite(t, messages[0]).Id))
+
+	messages[0].Finalize(producer.Consumed)
+	fileIndex := decodeOffsetFileIndex(t, r)
+	require.Equal(t, map[uint32]Offset{
+		0: {FileIndex: fileIndex, Entry: 1},
+		1: {FileIndex: fileIndex, Entry: 2},
+	}, r.AcknowledgedOffsets())
+}
+
+func TestReplicatorResumesFromPersistedOffsets(t *testing.T) {
+	commitLog, commitLogOpts := newTestCommitLog(t)
+	writeTestCommitLog(t, commitLog, []testWrite{
+		{id: "foo", shard: 0, value: 1},
+		{id: "bar", shard: 1, value: 2},
+	})
+
+	p := &fakeProducer{}
+	r := newTestReplicator(t, commitLog, commitLogOpts, p)
+	messages := waitForMessages(t, p, 2)
+	messages[0].Finalize(producer.Consumed)
+	require.NoError(t, r.Close())
+	acked := r.AcknowledgedOffsets()
+
+	writeTestCommitLog(t, commitLog, []testWrite{
+		{id: "qux", shard: 0, value: 3},
+	})
+
+	p = &fakeProducer{}
+	r = newTestReplicator(t, commitLog, commitLogOpts, p)
+	defer func() { require.NoError(t, r.Close()) }()
+
+	require.Equal(t, acked, r.AcknowledgedOffsets())
+	messages = waitForMessages(t, p, 2)
+	require.Equal(t, "bar", string(decodeWrite(t, messages[0]).Id))
+	require.Equal(t, "qux", string(decodeWrite(t, messages[1]).Id))
+}
+
+func TestReplicatorSkipsFilesBeyondMaxRetained(t *testing.T) {
+	commitLog, commitLogOpts := newTestCommitLog(t)
+	writeTestCommitLog(t, commitLog, []testWrite{
+		{id: "foo", shard: 0, value: 1},
+	})
+	writeTestCommitLog(t, commitLog, []testWrite{
+		{id: "bar", shard: 1, value: 2},
+	})
+	files, _, err := commitlog.Files(commitLogOpts)
+	require.NoError(t, err)
+	require.True(t, len(files) >= 2)
+	second := files[1].Index
+
+	p := &fakeProducer{}
+	r, err := NewReplicator(NewOptions().
+		SetCommitLogOptions(commitLogOpts).
+		SetProducer(p).
+		SetPollInterval(time.Hour).
+		SetMaxRetainedFiles(1))
+	require.NoError(t, err)
+	require.NoError(t, r.Open(commitLog))
+	defer func() { require.NoError(t, r.Close()) }()
+
+	// The standby has not acknowledged any write, the first file is skipped
+	// to keep a single file for replication.
+	messages := waitForMessages(t, p, 2)
+	for start := time.Now(); r.RetainedCommitLogIndex() != second; time.Sleep(10 * time.Millisecond) {
+		require.True(t, time.Since(start) < 10*time.Second, "timed out waiting for skipped file")
+	}
+	require.Equal(t, map[uint32]Offset{
+		0: {FileIndex: second, Entry: -1},
+		1: {FileIndex: second, Entry: -1},
+	}, r.AcknowledgedOffsets())
+
+	// Acknowledging a write of a skipped file does not move offsets back.
+	messages[0].Finalize(producer.Consumed)
+	messages[1].Finalize(producer.Consumed)
+	require.Equal(t, map[uint32]Offset{
+		0: {FileIndex: second, Entry: -1},
+		1: {FileIndex: second, Entry: 0},
+	}, r.AcknowledgedOffsets())
+	require.Equal(t, second+1, r.RetainedCommitLogIndex())
+}
+
+// decodeOffsetFileIndex returns the index of the commitlog file the pending
+// or acknowledged writes are from.
+func decodeOffsetFileIndex(t *testing.T, r *replicator) int64 {
+	r.Lock()
+	defer r.Unlock()
+	for _, s := range r.shards {
+		if front := s.pending.Front(); front != nil {
+			return front.Value.(*pendingWrite).offset.FileIndex
+		}
+		if s.acked != noOffset {
+			return s.acked.FileIndex
+		}
+	}
+	require.FailNow(t, "no writes produced")
+	return 0
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package replication replicates the writes in sealed commitlog files to a
+// standby cluster over m3msg and applies them on the standby cluster.
+//
+// Only commitlog files that are no longer being written to are replicated.
+// The commitlog is rotated on every flush, so the writes that may be lost
+// when failing over to the standby are bounded by the flush interval plus
+// the replication poll interval.
+package replication
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// Offset is the position of an entry in the commitlog. Entries are ordered
+// by the index of their commitlog file and then by their position in it.
+type Offset struct {
+	FileIndex int64
+	Entry     int64
+}
+
+// Before returns whether the offset is before the other offset.
+func (o Offset) Before(other Offset) bool {
+	if o.FileIndex != other.FileIndex {
+		return o.FileIndex < other.FileIndex
+	}
+	return o.Entry < other.Entry
+}
+
+// ActiveLogs returns the commitlog files that are being written to.
+type ActiveLogs interface {
+	ActiveLogs() (persist.CommitLogFiles, error)
+}
+
+// Replicator tails the commitlog and produces its writes to the standby
+// cluster, tracking the offset acknowledged by the standby for each shard.
+type Replicator interface {
+	// Open starts replicating the commitlog files that are not active.
+	Open(activeLogs ActiveLogs) error
+
+	// Close stops replicating and persists the acknowledged offsets.
+	Close() error
+
+	// Report reports the replication lag metrics.
+	Report()
+
+	// AcknowledgedOffsets returns the offset of the last write acknowledged
+	// by the standby cluster for each shard.
+	AcknowledgedOffsets() map[uint32]Offset
+
+	// RetainedCommitLogIndex returns the index of the first commitlog file
+	// that has not been completely acknowledged, commitlog files with this
+	// index or a higher index must not be removed. Once the files kept exceed
+	// the max retained limits the oldest are skipped without being
+	// acknowledged so that a lagging standby cannot fill the disk.
+	RetainedCommitLogIndex() int64
+}
+
+// Writer writes replicated datapoints to the standby cluster.
+type Writer interface {
+	// Write writes a value for an ID without tags.
+	Write(
+		namespace,
+		id ident.ID,
+		t xtime.UnixNano,
+		value float64,
+		unit xtime.Unit,
+		annotation []byte,
+	) error
+
+	// WriteTagged writes a value for an ID and its tags.
+	WriteTagged(
+		namespace,
+		id ident.ID,
+		tags ident.TagIterator,
+		t xtime.UnixNano,
+		value float64,
+		unit xtime.Unit,
+		annotation []byte,
+	) error
+}
+
+// Options represents the options for replication.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetCommitLogOptions sets the options of the commitlog to replicate.
+	SetCommitLogOptions(value commitlog.Options) Options
+
+	// CommitLogOptions returns the options of the commitlog to replicate.
+	CommitLogOptions() commitlog.Options
+
+	// SetProducer sets the producer writes are sent to the standby with.
+	SetProducer(value producer.Producer) Options
+
+	// Producer returns the producer writes are sent to the standby with.
+	Producer() producer.Producer
+
+	// SetPollInterval sets the interval at which new commitlog files are
+	// looked for.
+	SetPollInterval(value time.Duration) Options
+
+	// PollInterval returns the interval at which new commitlog files are
+	// looked for.
+	PollInterval() time.Duration
+
+	// SetMaxRetainedFiles sets the maximum number of sealed commitlog files
+	// kept for replication, zero means unlimited.
+	SetMaxRetainedFiles(value int) Options
+
+	// MaxRetainedFiles returns the maximum number of sealed commitlog files
+	// kept for replication, zero means unlimited.
+	MaxRetainedFiles() int
+
+	// SetMaxRetainedBytes sets the maximum total size of the sealed commitlog
+	// files kept for replication, zero means unlimited.
+	SetMaxRetainedBytes(value int64) Options
+
+	// MaxRetainedBytes returns the maximum total size of the sealed commitlog
+	// files kept for replication, zero means unlimited.
+	MaxRetainedBytes() int64
+
+	// SetMaxRetainedAge sets the maximum age of the sealed commitlog files
+	// kept for replication, zero means unlimited.
+	SetMaxRetainedAge(value time.Duration) Options
+
+	// MaxRetainedAge returns the maximum age of the sealed commitlog files
+	// kept for replication, zero means unlimited.
+	MaxRetainedAge() time.Duration
+
+	// SetClockOptions sets the clock options.
+	SetClockOptions(value clock.Options) Options
+
+	// ClockOptions returns the clock options.
+	ClockOptions() clock.Options
+
+	// SetInstrumentOptions sets the instrumentation options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrumentation options.
+	InstrumentOptions() instrument.Options
+}
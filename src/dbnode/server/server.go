@@ -47,11 +47,13 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3dbnode/config"
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/encoding/proto"
 	"github.com/m3db/m3/src/dbnode/environment"
 	"github.com/m3db/m3/src/dbnode/kvconfig"
 	"github.com/m3db/m3/src/dbnode/namespace"
+	grpcnode "github.com/m3db/m3/src/dbnode/network/server/grpcthrift/node"
 	hjcluster "github.com/m3db/m3/src/dbnode/network/server/httpjson/cluster"
 	hjnode "github.com/m3db/m3/src/dbnode/network/server/httpjson/node"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
@@ -59,6 +61,7 @@ import (
 	ttnode "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/node"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
 	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/retention"
 	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
@@ -92,6 +95,7 @@ import (
 	xos "github.com/m3db/m3/src/x/os"
 	"github.com/m3db/m3/src/x/pool"
 	"github.com/m3db/m3/src/x/serialize"
+	xserver "github.com/m3db/m3/src/x/server"
 	tbinarypool "github.com/m3db/m3/src/x/thrift"
 
 	"github.com/m3dbx/vellum/levenshtein"
@@ -112,6 +116,7 @@ const (
 	maxBgProcessLimitMonitorDuration = 5 * time.Minute
 	cpuProfileDuration               = 5 * time.Second
 	filePathPrefixLockFile           = ".lock"
+	postingsListCacheWarmupDir       = "postings-list-cache"
 	defaultServiceName               = "m3dbnode"
 	skipRaiseProcessLimitsEnvVar     = "SKIP_PROCESS_LIMITS_RAISE"
 	skipRaiseProcessLimitsEnvVarTrue = "true"
@@ -401,6 +406,10 @@ func Run(runOpts RunOptions) {
 		opts = opts.SetMemoryTracker(memTracker)
 	}
 
+	if admissionCfg := cfg.Limits.WriteAdmission; admissionCfg != nil {
+		opts = opts.SetWriteAdmissionOptions(admissionCfg.NewOptions(iOpts))
+	}
+
 	opentracing.SetGlobalTracer(tracer)
 
 	// Set global index options.
@@ -480,6 +489,7 @@ func Run(runOpts RunOptions) {
 		SetBytesReadLimitOpts(bytesReadLimit).
 		SetDiskSeriesReadLimitOpts(diskSeriesReadLimit).
 		SetAggregateDocsLimitOpts(aggDocsLimit).
+		SetSourceLimitOpts(sourceLimitOptsFromConfig(runOpts.Config.Limits.SourceLimits)).
 		SetInstrumentOptions(iOpts)
 	if builder := opts.SourceLoggerBuilder(); builder != nil {
 		limitOpts = limitOpts.SetSourceLoggerBuilder(builder)
@@ -524,7 +534,22 @@ func Run(runOpts RunOptions) {
 				SetMetricsScope(scope.SubScope("postings-list-cache")),
 		}
 	)
-	segmentPostingsListCache, err := index.NewPostingsListCache(plCacheSize, plCacheOptions)
+	segmentPostingsListCacheOptions := plCacheOptions
+	searchPostingsListCacheOptions := plCacheOptions
+	if warmupCfg := plCacheConfig.WarmupConfiguration(); warmupCfg.Enabled {
+		warmupOpts := index.PostingsListCacheWarmupOptions{
+			PersistInterval: warmupCfg.PersistInterval,
+			MaxKeys:         warmupCfg.MaxKeys,
+			Budget:          warmupCfg.Budget,
+		}
+		warmupDir := path.Join(cfg.Filesystem.FilePathPrefixOrDefault(), postingsListCacheWarmupDir)
+		segmentPostingsListCacheOptions.Warmup = warmupOpts
+		segmentPostingsListCacheOptions.Warmup.FilePath = path.Join(warmupDir, "segment.json")
+		searchPostingsListCacheOptions.Warmup = warmupOpts
+		searchPostingsListCacheOptions.Warmup.FilePath = path.Join(warmupDir, "search.json")
+	}
+
+	segmentPostingsListCache, err := index.NewPostingsListCache(plCacheSize, segmentPostingsListCacheOptions)
 	if err != nil {
 		logger.Fatal("could not construct segment postings list cache", zap.Error(err))
 	}
@@ -532,7 +557,7 @@ func Run(runOpts RunOptions) {
 	segmentStopReporting := segmentPostingsListCache.Start()
 	defer segmentStopReporting()
 
-	searchPostingsListCache, err := index.NewPostingsListCache(plCacheSize, plCacheOptions)
+	searchPostingsListCache, err := index.NewPostingsListCache(plCacheSize, searchPostingsListCacheOptions)
 	if err != nil {
 		logger.Fatal("could not construct searches postings list cache", zap.Error(err))
 	}
@@ -658,6 +683,24 @@ func Run(runOpts RunOptions) {
 		SetIndexBloomFilterFalsePositivePercent(cfg.Filesystem.BloomFilterFalsePositivePercentOrDefault()).
 		SetMmapReporter(mmapReporter)
 
+	if cfg.Tiering != nil {
+		tieringManager, err := cfg.Tiering.NewManager(fsopts, opts.InstrumentOptions())
+		if err != nil {
+			logger.Fatal("could not create tiering manager", zap.Error(err))
+		}
+		fsopts = fsopts.SetFileSetPager(tieringManager)
+		opts = opts.SetTieringManager(tieringManager)
+	}
+
+	rollupManager, err := rollup.NewManager(rollup.NewOptions().
+		SetFilesystemOptions(fsopts).
+		SetInstrumentOptions(opts.InstrumentOptions()))
+	if err != nil {
+		logger.Fatal("could not create rollup manager", zap.Error(err))
+	}
+	defer rollupManager.Close()
+	opts = opts.SetRollupManager(rollupManager)
+
 	var commitLogQueueSize int
 	cfgCommitLog := cfg.CommitLogOrDefault()
 	specified := cfgCommitLog.Queue.Size
@@ -705,7 +748,8 @@ func Run(runOpts RunOptions) {
 		SetFlushSize(cfgCommitLog.FlushMaxBytes).
 		SetFlushInterval(cfgCommitLog.FlushEvery).
 		SetBacklogQueueSize(commitLogQueueSize).
-		SetBacklogQueueChannelSize(commitLogQueueChannelSize))
+		SetBacklogQueueChannelSize(commitLogQueueChannelSize).
+		SetChunkCompression(cfgCommitLog.Compression))
 
 	// Setup the block retriever
 	switch seriesCachePolicy {
@@ -862,6 +906,17 @@ func Run(runOpts RunOptions) {
 	defer httpjsonNodeClose()
 	logger.Info("node httpjson: listening", zap.String("address", httpListenAddress))
 
+	if grpcListenAddress := cfg.GRPCNodeListenAddressOrDefault(); grpcListenAddress != "" {
+		grpcNodeClose, err := grpcnode.NewServer(service,
+			grpcListenAddress, contextPool, iOpts).ListenAndServe()
+		if err != nil {
+			logger.Fatal("could not open grpc interface",
+				zap.String("address", grpcListenAddress), zap.Error(err))
+		}
+		defer grpcNodeClose()
+		logger.Info("node grpc: listening", zap.String("address", grpcListenAddress))
+	}
+
 	debugListenAddress := cfg.DebugListenAddressOrDefault()
 	if debugListenAddress != "" {
 		var debugWriter xdebug.ZipWriter
@@ -917,6 +972,7 @@ func Run(runOpts RunOptions) {
 	if cfg.Proto != nil && cfg.Proto.Enabled {
 		protoEnabled = true
 	}
+	histogramEnabled := cfg.Histogram != nil && cfg.Histogram.Enabled
 	schemaRegistry := namespace.NewSchemaRegistry(protoEnabled, logger)
 	// For application m3db client integration test convenience (where a local dbnode is started as a docker container),
 	// we allow loading user schema from local file into schema registry.
@@ -934,7 +990,7 @@ func Run(runOpts RunOptions) {
 	origin := topology.NewHost(hostID, "")
 	m3dbClient, err := newAdminClient(
 		cfg.Client, opts.ClockOptions(), iOpts, tchannelOpts, syncCfg.TopologyInitializer,
-		runtimeOptsMgr, origin, protoEnabled, schemaRegistry,
+		runtimeOptsMgr, origin, protoEnabled, histogramEnabled, schemaRegistry,
 		syncCfg.KVStore, opts.ContextPool(), opts.BytesPool(), opts.IdentifierPool(),
 		logger, runOpts.CustomOptions)
 	if err != nil {
@@ -972,7 +1028,7 @@ func Run(runOpts RunOptions) {
 			clientCfg := *cluster.Client
 			clusterClient, err := newAdminClient(
 				clientCfg, opts.ClockOptions(), iOpts, tchannelOpts, topologyInitializer,
-				runtimeOptsMgr, origin, protoEnabled, schemaRegistry,
+				runtimeOptsMgr, origin, protoEnabled, histogramEnabled, schemaRegistry,
 				syncCfg.KVStore, opts.ContextPool(), opts.BytesPool(),
 				opts.IdentifierPool(), logger, runOpts.CustomOptions)
 			if err != nil {
@@ -1009,6 +1065,9 @@ func Run(runOpts RunOptions) {
 				// Set conditionally to avoid stomping on the default value of 1.0.
 				repairOpts = repairOpts.SetDebugShadowComparisonsPercentage(cfg.Repair.DebugShadowComparisonsPercentage)
 			}
+			if cfg.Repair.MerkleTreeDepth > 0 {
+				repairOpts = repairOpts.SetMerkleTreeDepth(cfg.Repair.MerkleTreeDepth)
+			}
 		}
 
 		opts = opts.
@@ -1056,6 +1115,22 @@ func Run(runOpts RunOptions) {
 	defer httpjsonClusterClose()
 	logger.Info("cluster httpjson: listening", zap.String("address", httpClusterListenAddress))
 
+	// The commitlog replication server is only started once bootstrapped so
+	// guard it to close it with the other servers and not start it after.
+	var (
+		replicationServerLock   sync.Mutex
+		replicationServer       xserver.Server
+		replicationServerClosed bool
+	)
+	defer func() {
+		replicationServerLock.Lock()
+		defer replicationServerLock.Unlock()
+		replicationServerClosed = true
+		if replicationServer != nil {
+			replicationServer.Close()
+		}
+	}()
+
 	// Initialize clustered database.
 	clusterTopoWatch, err := topo.Watch()
 	if err != nil {
@@ -1065,6 +1140,15 @@ func Run(runOpts RunOptions) {
 	opts = opts.SetSchemaRegistry(schemaRegistry).
 		SetAdminClient(m3dbClient)
 
+	if replicationCfg := cfg.CommitLogReplication; replicationCfg != nil && replicationCfg.Producer != nil {
+		replicator, err := replicationCfg.NewReplicator(syncCfg.ClusterClient,
+			opts.CommitLogOptions(), opts.InstrumentOptions())
+		if err != nil {
+			logger.Fatal("could not create commitlog replicator", zap.Error(err))
+		}
+		opts = opts.SetCommitLogReplicator(replicator)
+	}
+
 	db, err := cluster.NewDatabase(hostID, topo, clusterTopoWatch, opts)
 	if err != nil {
 		logger.Fatal("could not construct database", zap.Error(err))
@@ -1096,6 +1180,25 @@ func Run(runOpts RunOptions) {
 		}
 		logger.Info("bootstrapped")
 
+		// Only apply replicated writes once bootstrapped so that they are
+		// not rejected while the shards of this cluster are initializing.
+		if replicationCfg := cfg.CommitLogReplication; replicationCfg != nil && replicationCfg.Consumer != nil {
+			session, err := m3dbClient.DefaultSession()
+			if err != nil {
+				logger.Fatal("could not create session for replicated writes", zap.Error(err))
+			}
+			replicationServerLock.Lock()
+			if !replicationServerClosed {
+				replicationServer = replicationCfg.Consumer.NewServer(session, iOpts)
+				if err := replicationServer.ListenAndServe(); err != nil {
+					logger.Fatal("could not start commitlog replication server", zap.Error(err))
+				}
+				logger.Info("commitlog replication server: listening",
+					zap.String("address", replicationCfg.Consumer.Server.ListenAddress))
+			}
+			replicationServerLock.Unlock()
+		}
+
 		// Only set the write new series limit after bootstrapping
 		kvWatchNewSeriesLimitPerShard(syncCfg.KVStore, logger, topo,
 			runtimeOptsMgr, cfg.Limits.WriteNewSeriesPerSecond)
@@ -1411,6 +1514,60 @@ func updateQueryLimits(
 	if err := updateQueryLimit(aggregateDocsLimit, aggDocsLimitOpts); err != nil {
 		logger.Error("error updating metadata read limit", zap.Error(err))
 	}
+
+	// Default to the config-based source limits if no source is limited in
+	// dynamic limits, otherwise only the sources in dynamic limits are limited.
+	sourceLimitOpts := configOpts.SourceLimitOpts()
+	if dynamicOpts != nil && len(dynamicOpts.SourceLimits) > 0 {
+		sourceLimitOpts = make(map[string]limits.SourceLimitOptions, len(dynamicOpts.SourceLimits))
+		for _, dynamicSourceOpts := range dynamicOpts.SourceLimits {
+			sourceLimitOpts[dynamicSourceOpts.Source] = dynamicSourceLimitToLimitOpts(dynamicSourceOpts)
+		}
+	}
+
+	var (
+		sourceDocsLimitOpts      = make(map[string]limits.LookbackLimitOptions, len(sourceLimitOpts))
+		sourceBytesReadLimitOpts = make(map[string]limits.LookbackLimitOptions, len(sourceLimitOpts))
+		sourceAggDocsLimitOpts   = make(map[string]limits.LookbackLimitOptions, len(sourceLimitOpts))
+	)
+	for source, opts := range sourceLimitOpts {
+		sourceDocsLimitOpts[source] = opts.DocsLimitOpts
+		sourceBytesReadLimitOpts[source] = opts.BytesReadLimitOpts
+		sourceAggDocsLimitOpts[source] = opts.AggregateDocsLimitOpts
+	}
+
+	if err := updateQuerySourceLimits(docsLimit, sourceDocsLimitOpts); err != nil {
+		logger.Error("error updating source docs limits", zap.Error(err))
+	}
+
+	if err := updateQuerySourceLimits(bytesReadLimit, sourceBytesReadLimitOpts); err != nil {
+		logger.Error("error updating source bytes read limits", zap.Error(err))
+	}
+
+	if err := updateQuerySourceLimits(aggregateDocsLimit, sourceAggDocsLimitOpts); err != nil {
+		logger.Error("error updating source metadata read limits", zap.Error(err))
+	}
+}
+
+func updateQuerySourceLimits(
+	limit limits.LookbackLimit,
+	newOpts map[string]limits.LookbackLimitOptions,
+) error {
+	old := limit.SourceOptions()
+	if len(old) == len(newOpts) {
+		equal := true
+		for source, opts := range newOpts {
+			if oldOpts, ok := old[source]; !ok || !oldOpts.Equals(opts) {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return nil
+		}
+	}
+
+	return limit.UpdateSources(newOpts)
 }
 
 func updateQueryLimit(
@@ -1434,6 +1591,51 @@ func dynamicLimitToLimitOpts(dynamicLimit *kvpb.QueryLimit) limits.LookbackLimit
 	}
 }
 
+func dynamicSourceLimitToLimitOpts(dynamicLimit *kvpb.SourceQueryLimits) limits.SourceLimitOptions {
+	opts := limits.SourceLimitOptions{
+		DocsLimitOpts:          limits.DefaultLookbackLimitOptions(),
+		BytesReadLimitOpts:     limits.DefaultLookbackLimitOptions(),
+		AggregateDocsLimitOpts: limits.DefaultLookbackLimitOptions(),
+	}
+	if dynamicLimit.MaxRecentlyQueriedSeriesBlocks != nil {
+		opts.DocsLimitOpts = dynamicLimitToLimitOpts(dynamicLimit.MaxRecentlyQueriedSeriesBlocks)
+	}
+	if dynamicLimit.MaxRecentlyQueriedSeriesDiskBytesRead != nil {
+		opts.BytesReadLimitOpts = dynamicLimitToLimitOpts(dynamicLimit.MaxRecentlyQueriedSeriesDiskBytesRead)
+	}
+	if dynamicLimit.MaxRecentlyQueriedMetadataRead != nil {
+		opts.AggregateDocsLimitOpts = dynamicLimitToLimitOpts(dynamicLimit.MaxRecentlyQueriedMetadataRead)
+	}
+	return opts
+}
+
+func sourceLimitOptsFromConfig(
+	cfg []config.SourceLimitsConfiguration,
+) map[string]limits.SourceLimitOptions {
+	sourceLimitOpts := make(map[string]limits.SourceLimitOptions, len(cfg))
+	for _, sourceCfg := range cfg {
+		opts := limits.SourceLimitOptions{
+			DocsLimitOpts:          limits.DefaultLookbackLimitOptions(),
+			BytesReadLimitOpts:     limits.DefaultLookbackLimitOptions(),
+			AggregateDocsLimitOpts: limits.DefaultLookbackLimitOptions(),
+		}
+		if limitConfig := sourceCfg.MaxRecentlyQueriedSeriesBlocks; limitConfig != nil {
+			opts.DocsLimitOpts.Limit = limitConfig.Value
+			opts.DocsLimitOpts.Lookback = limitConfig.Lookback
+		}
+		if limitConfig := sourceCfg.MaxRecentlyQueriedSeriesDiskBytesRead; limitConfig != nil {
+			opts.BytesReadLimitOpts.Limit = limitConfig.Value
+			opts.BytesReadLimitOpts.Lookback = limitConfig.Lookback
+		}
+		if limitConfig := sourceCfg.MaxRecentlyQueriedMetadata; limitConfig != nil {
+			opts.AggregateDocsLimitOpts.Limit = limitConfig.Value
+			opts.AggregateDocsLimitOpts.Lookback = limitConfig.Lookback
+		}
+		sourceLimitOpts[sourceCfg.Source] = opts
+	}
+	return sourceLimitOpts
+}
+
 func kvWatchClientConsistencyLevels(
 	store kv.Store,
 	logger *zap.Logger,
@@ -1794,6 +1996,9 @@ func withEncodingAndPoolingOptions(
 			enc := proto.NewEncoder(0, encodingOpts)
 			return enc
 		}
+		if cfg.Histogram != nil && cfg.Histogram.Enabled {
+			return histogram.NewEncoder(0, nil, encodingOpts)
+		}
 
 		return m3tsz.NewEncoder(0, nil, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
 	})
@@ -1802,6 +2007,9 @@ func withEncodingAndPoolingOptions(
 		if cfg.Proto != nil && cfg.Proto.Enabled {
 			return proto.NewIterator(r, descr, encodingOpts)
 		}
+		if cfg.Histogram != nil && cfg.Histogram.Enabled {
+			return histogram.NewReaderIterator(r, encodingOpts)
+		}
 		return m3tsz.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
 	})
 
@@ -1960,6 +2168,7 @@ func newAdminClient(
 	runtimeOptsMgr m3dbruntime.OptionsManager,
 	origin topology.Host,
 	protoEnabled bool,
+	histogramEnabled bool,
 	schemaRegistry namespace.SchemaRegistry,
 	kvStore kv.Store,
 	contextPool xcontext.Pool,
@@ -1998,6 +2207,11 @@ func newAdminClient(
 			if protoEnabled {
 				return opts.SetEncodingProto(encoding.NewOptions()).(client.AdminOptions)
 			}
+			if histogramEnabled {
+				return opts.SetReaderIteratorAllocate(
+					histogram.DefaultReaderIteratorAllocFn(encoding.NewOptions()),
+				).(client.AdminOptions)
+			}
 			return opts
 		},
 		func(opts client.AdminOptions) client.AdminOptions {
@@ -52,6 +52,9 @@ var (
 	// errShardNotBootstrappedToRead raised when trying to read data for a shard that's not yet bootstrapped.
 	errShardNotBootstrappedToRead = errors.New("shard is not yet bootstrapped to read")
 
+	// errShardNotBootstrappedToDelete raised when trying to delete data for a shard that's not yet bootstrapped.
+	errShardNotBootstrappedToDelete = errors.New("shard is not yet bootstrapped to delete")
+
 	// errIndexNotBootstrappedToRead raised when trying to read the index before being bootstrapped.
 	errIndexNotBootstrappedToRead = errors.New("index is not yet bootstrapped to read")
 
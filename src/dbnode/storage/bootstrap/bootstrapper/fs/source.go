@@ -510,7 +510,7 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 	if shouldBuildSegment {
 		var (
 			indexBlockSize            = ns.Options().IndexOptions().BlockSize()
-			retentionPeriod           = ns.Options().RetentionOptions().RetentionPeriod()
+			retentionPeriod           = namespace.BlockRetentionOptions(ns.Options()).RetentionPeriod()
 			beginningOfIndexRetention = retention.FlushTimeStartForRetentionPeriod(
 				retentionPeriod, indexBlockSize, xtime.ToUnixNano(s.nowFn()))
 			initialIndexRange = xtime.Range{
@@ -90,7 +90,7 @@ func PersistBootstrapIndexSegment(
 	//  Retention: 6 hours
 	//           [12PM->2PM][2PM->4PM][4PM->6PM] (Data Blocks)
 	// [10AM     ->     2PM][2PM     ->     6PM] (Index Blocks)
-	retentionOpts := ns.Options().RetentionOptions()
+	retentionOpts := namespace.BlockRetentionOptions(ns.Options())
 	nowFn := resultOpts.ClockOptions().NowFn()
 	now := xtime.ToUnixNano(nowFn())
 	earliestRetentionTime := retention.FlushTimeStart(retentionOpts, now)
@@ -258,7 +258,7 @@ func BuildBootstrapIndexSegment(
 	//  Retention: 6 hours
 	//           [12PM->2PM)[2PM->4PM)[4PM->6PM) (Data Blocks)
 	// [10AM     ->     2PM)[2PM     ->     6PM) (Index Blocks)
-	retentionOpts := ns.Options().RetentionOptions()
+	retentionOpts := namespace.BlockRetentionOptions(ns.Options())
 	nowFn := resultOpts.ClockOptions().NowFn()
 	now := xtime.ToUnixNano(nowFn())
 	earliestRetentionTime := retention.FlushTimeStart(retentionOpts, now)
@@ -445,7 +445,7 @@ func (b bootstrapProcess) targetRangesForData(
 	at xtime.UnixNano,
 	nsOpts namespace.Options,
 ) targetRangesResult {
-	ropts := nsOpts.RetentionOptions()
+	ropts := namespace.BlockRetentionOptions(nsOpts)
 	return b.targetRanges(at, targetRangesOptions{
 		retentionPeriod:       ropts.RetentionPeriod(),
 		futureRetentionPeriod: ropts.FutureRetentionPeriod(),
@@ -460,7 +460,7 @@ func (b bootstrapProcess) targetRangesForIndex(
 	at xtime.UnixNano,
 	nsOpts namespace.Options,
 ) targetRangesResult {
-	ropts := nsOpts.RetentionOptions()
+	ropts := namespace.BlockRetentionOptions(nsOpts)
 	return b.targetRanges(at, targetRangesOptions{
 		retentionPeriod:       ropts.RetentionPeriod(),
 		futureRetentionPeriod: ropts.FutureRetentionPeriod(),
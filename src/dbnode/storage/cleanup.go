@@ -22,9 +22,11 @@ package storage
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"sync"
 
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
@@ -212,6 +214,16 @@ func (m *cleanupManager) ColdFlushCleanup(t xtime.UnixNano) error {
 			"encountered errors when deleting inactive data files for %v: %v", t, err))
 	}
 
+	if err := m.compactRollups(t, namespaces); err != nil {
+		multiErr = multiErr.Add(fmt.Errorf(
+			"encountered errors when compacting rollups for %v: %v", t, err))
+	}
+
+	if err := m.tierFileSets(t, namespaces); err != nil {
+		multiErr = multiErr.Add(fmt.Errorf(
+			"encountered errors when tiering filesets for %v: %v", t, err))
+	}
+
 	return multiErr.FinalError()
 }
 
@@ -284,7 +296,7 @@ func (m *cleanupManager) cleanupDataFiles(t xtime.UnixNano, namespaces []databas
 		if !n.Options().CleanupEnabled() {
 			continue
 		}
-		earliestToRetain := retention.FlushTimeStart(n.Options().RetentionOptions(), t)
+		earliestToRetain := retention.FlushTimeStart(namespace.BlockRetentionOptions(n.Options()), t)
 		shards := n.OwnedShards()
 		multiErr = multiErr.Add(m.cleanupExpiredNamespaceDataFiles(earliestToRetain, shards))
 		multiErr = multiErr.Add(m.cleanupCompactedNamespaceDataFiles(shards))
@@ -292,6 +304,56 @@ func (m *cleanupManager) cleanupDataFiles(t xtime.UnixNano, namespaces []databas
 	return multiErr.FinalError()
 }
 
+// compactRollups compacts old blocks of namespaces with rollups enabled
+// into rollups. It runs before tiering so that blocks are compacted while
+// their raw filesets are still on local disk.
+func (m *cleanupManager) compactRollups(t xtime.UnixNano, namespaces []databaseNamespace) error {
+	rollupManager := m.opts.RollupManager()
+	if rollupManager == nil {
+		return nil
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, n := range namespaces {
+		if !n.Options().RollupOptions().Enabled() {
+			continue
+		}
+		var shards []uint32
+		for _, s := range n.OwnedShards() {
+			if s.IsBootstrapped() {
+				shards = append(shards, s.ID())
+			}
+		}
+		multiErr = multiErr.Add(rollupManager.Compact(n.Metadata(), shards, t))
+	}
+	return multiErr.FinalError()
+}
+
+// tierFileSets uploads sealed filesets of namespaces with tiering enabled
+// to object storage and evicts them from local disk. It runs after cold
+// flush cleanup so that only the latest volume of each block is tiered.
+func (m *cleanupManager) tierFileSets(t xtime.UnixNano, namespaces []databaseNamespace) error {
+	tieringManager := m.opts.TieringManager()
+	if tieringManager == nil {
+		return nil
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, n := range namespaces {
+		if !n.Options().TieringOptions().Enabled() {
+			continue
+		}
+		var shards []uint32
+		for _, s := range n.OwnedShards() {
+			if s.IsBootstrapped() {
+				shards = append(shards, s.ID())
+			}
+		}
+		multiErr = multiErr.Add(tieringManager.Tier(n.Metadata(), shards, t))
+	}
+	return multiErr.FinalError()
+}
+
 func (m *cleanupManager) cleanupExpiredIndexFiles(
 	t xtime.UnixNano, namespaces []databaseNamespace,
 ) error {
@@ -403,7 +465,8 @@ func (m *cleanupManager) cleanupCompactedNamespaceDataFiles(shards []databaseSha
 //     6. List all the commitlog files on disk.
 //     7. List all the commitlog files that are being actively written to.
 //     8. Delete all commitlog files whose index is lower than the index of the commitlog file referenced in the
-//        most recent snapshot metadata file (ignoring any commitlog files being actively written to.)
+//        most recent snapshot metadata file (ignoring any commitlog files being actively written to and any
+//        commitlog files that have not been replicated to the standby cluster yet.)
 //     9. Delete all corrupt commitlog files (ignoring any commitlog files being actively written to.)
 //
 // This process is also modeled formally in TLA+ in the file `SnapshotsSpec.tla`.
@@ -534,6 +597,13 @@ func (m *cleanupManager) cleanupSnapshotsAndCommitlogs(namespaces []databaseName
 		return err
 	}
 
+	// Commitlog files that have not been replicated to the standby cluster
+	// yet are retained regardless of snapshots.
+	retainedCommitlogIndex := int64(math.MaxInt64)
+	if replicator := m.opts.CommitLogReplicator(); replicator != nil {
+		retainedCommitlogIndex = replicator.RetainedCommitLogIndex()
+	}
+
 	// Delete all commitlog files prior to the one captured by the most recent snapshot.
 	for _, file := range files {
 		if activeCommitlogs.Contains(file.FilePath) {
@@ -541,6 +611,11 @@ func (m *cleanupManager) cleanupSnapshotsAndCommitlogs(namespaces []databaseName
 			continue
 		}
 
+		if file.Index >= retainedCommitlogIndex {
+			// Skip over any commitlog files that are still being replicated.
+			continue
+		}
+
 		if file.Index < mostRecentSnapshot.CommitlogIdentifier.Index {
 			m.metrics.deletedCommitlogFile.Inc(1)
 			filesToDelete = append(filesToDelete, file.FilePath)
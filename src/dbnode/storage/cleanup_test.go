@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/replication"
 	"github.com/m3db/m3/src/dbnode/retention"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/ident"
@@ -89,6 +90,7 @@ func TestCleanupManagerCleanupCommitlogsAndSnapshots(t *testing.T) {
 		snapshotMetadata     snapshotMetadataFilesFn
 		commitlogs           commitLogFilesFn
 		snapshots            snapshotFilesFn
+		replicator           replication.Replicator
 		expectedDeletedFiles []string
 		expectErr            bool
 	}{
@@ -237,6 +239,23 @@ func TestCleanupManagerCleanupCommitlogsAndSnapshots(t *testing.T) {
 			// Should only delete anything with an index lower than 1.
 			expectedDeletedFiles: []string{"commitlog-file-0"},
 		},
+		{
+			title: "Does not delete commitlog files that have not been replicated",
+			snapshotMetadata: func(fs.Options) ([]fs.SnapshotMetadata, []fs.SnapshotMetadataErrorWithPaths, error) {
+				return []fs.SnapshotMetadata{testSnapshotMetadata0}, nil, nil
+			},
+			snapshots: func(filePathPrefix string, namespace ident.ID, shard uint32) (fs.FileSetFilesSlice, error) {
+				return nil, nil
+			},
+			commitlogs: func(commitlog.Options) (persist.CommitLogFiles, []commitlog.ErrorWithPath, error) {
+				return persist.CommitLogFiles{
+					{FilePath: "commitlog-file-0", Index: 0},
+					testCommitlogFileIdentifier,
+					{FilePath: "commitlog-file-2", Index: 2},
+				}, nil, nil
+			},
+			replicator: fakeReplicator{retainedIndex: 0},
+		},
 		{
 			title: "Deletes all corrupt commitlog files",
 			snapshotMetadata: func(fs.Options) ([]fs.SnapshotMetadata, []fs.SnapshotMetadataErrorWithPaths, error) {
@@ -310,6 +329,10 @@ func TestCleanupManagerCleanupCommitlogsAndSnapshots(t *testing.T) {
 				mgr.opts.CommitLogOptions().
 					SetBlockSize(rOpts.BlockSize()))
 
+			if tc.replicator != nil {
+				mgr.opts = mgr.opts.SetCommitLogReplicator(tc.replicator)
+			}
+
 			mgr.snapshotMetadataFilesFn = tc.snapshotMetadata
 			mgr.commitLogFilesFn = tc.commitlogs
 			mgr.snapshotFilesFn = tc.snapshots
@@ -587,3 +610,19 @@ func cleanup(
 	multiErr = multiErr.Add(mgr.ColdFlushCleanup(t))
 	return multiErr.FinalError()
 }
+
+type fakeReplicator struct {
+	retainedIndex int64
+}
+
+func (r fakeReplicator) Open(replication.ActiveLogs) error { return nil }
+func (r fakeReplicator) Close() error                      { return nil }
+func (r fakeReplicator) Report()                           {}
+
+func (r fakeReplicator) AcknowledgedOffsets() map[uint32]replication.Offset {
+	return nil
+}
+
+func (r fakeReplicator) RetainedCommitLogIndex() int64 {
+	return r.retainedIndex
+}
@@ -192,8 +192,20 @@ func (m *coldFlushManager) coldFlush() error {
 	}
 
 	multiErr = multiErr.Add(flushPersist.DoneFlush())
-	err = multiErr.FinalError()
-	return err
+
+	// Index blocks are rebuilt once the data filesets that dropped deleted
+	// series are persisted, since they are rebuilt from those filesets.
+	indexFlush, err := m.pm.StartIndexPersist()
+	if err != nil {
+		return multiErr.Add(err).FinalError()
+	}
+	for _, ns := range namespaces {
+		if err := ns.FlushIndexDeletes(indexFlush); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+	multiErr = multiErr.Add(indexFlush.DoneIndex())
+	return multiErr.FinalError()
 }
 
 func (m *coldFlushManager) Report() {
@@ -40,6 +40,7 @@ func TestColdFlushManagerFlushAlreadyInProgress(t *testing.T) {
 	var (
 		mockPersistManager = persist.NewMockManager(ctrl)
 		mockFlushPersist   = persist.NewMockFlushPreparer(ctrl)
+		mockIndexFlush     = persist.NewMockIndexFlush(ctrl)
 
 		// Channels used to coordinate cold flushing
 		startCh = make(chan struct{}, 1)
@@ -55,6 +56,8 @@ func TestColdFlushManagerFlushAlreadyInProgress(t *testing.T) {
 		startCh <- struct{}{}
 		<-doneCh
 	}).Return(mockFlushPersist, nil)
+	mockIndexFlush.EXPECT().DoneIndex().Return(nil)
+	mockPersistManager.EXPECT().StartIndexPersist().Return(mockIndexFlush, nil)
 
 	testOpts := DefaultTestOptions().SetPersistManager(mockPersistManager)
 	db := newMockdatabase(ctrl)
@@ -103,10 +106,13 @@ func TestColdFlushManagerFlushDoneFlushError(t *testing.T) {
 		fakeErr            = errors.New("fake error while marking flush done")
 		mockPersistManager = persist.NewMockManager(ctrl)
 		mockFlushPersist   = persist.NewMockFlushPreparer(ctrl)
+		mockIndexFlush     = persist.NewMockIndexFlush(ctrl)
 	)
 
 	mockFlushPersist.EXPECT().DoneFlush().Return(fakeErr)
 	mockPersistManager.EXPECT().StartFlushPersist().Return(mockFlushPersist, nil)
+	mockIndexFlush.EXPECT().DoneIndex().Return(nil)
+	mockPersistManager.EXPECT().StartIndexPersist().Return(mockIndexFlush, nil)
 
 	testOpts := DefaultTestOptions().SetPersistManager(mockPersistManager)
 	db := newMockdatabase(ctrl)
@@ -39,6 +39,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
 	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/dbnode/storage/limits/admission"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -80,7 +81,11 @@ var (
 	// errWriterDoesNotImplementWriteBatch is raised when the provided ts.BatchWriter does not implement
 	// ts.WriteBatch.
 	errWriterDoesNotImplementWriteBatch = errors.New("provided writer does not implement ts.WriteBatch")
-	aggregationsInProgress              int32
+
+	// errDeleteTaggedInvalidRange raised when trying to delete data with an end before the start.
+	errDeleteTaggedInvalidRange = errors.New("delete tagged invalid time range specified")
+
+	aggregationsInProgress int32
 )
 
 type databaseState int
@@ -124,7 +129,9 @@ type db struct {
 
 	writeBatchPool *writes.WriteBatchPool
 
-	queryLimits limits.QueryLimits
+	queryLimits    limits.QueryLimits
+	writeAdmission admission.Controller
+	merkleTrees    *merkleTreeCache
 }
 
 type databaseMetrics struct {
@@ -137,6 +144,8 @@ type databaseMetrics struct {
 	unknownNamespaceFetchBlocks         tally.Counter
 	unknownNamespaceFetchBlocksMetadata tally.Counter
 	unknownNamespaceQueryIDs            tally.Counter
+	unknownNamespaceDeleteTagged        tally.Counter
+	unknownNamespaceQuarantine          tally.Counter
 	errQueryIDsIndexDisabled            tally.Counter
 	errWriteTaggedIndexDisabled         tally.Counter
 	pendingNamespaceChange              tally.Gauge
@@ -155,6 +164,8 @@ func newDatabaseMetrics(scope tally.Scope) databaseMetrics {
 		unknownNamespaceFetchBlocks:         unknownNamespaceScope.Counter("fetch-blocks"),
 		unknownNamespaceFetchBlocksMetadata: unknownNamespaceScope.Counter("fetch-blocks-metadata"),
 		unknownNamespaceQueryIDs:            unknownNamespaceScope.Counter("query-ids"),
+		unknownNamespaceDeleteTagged:        unknownNamespaceScope.Counter("delete-tagged"),
+		unknownNamespaceQuarantine:          unknownNamespaceScope.Counter("quarantine"),
 		errQueryIDsIndexDisabled:            indexDisabledScope.Counter("err-query-ids"),
 		errWriteTaggedIndexDisabled:         indexDisabledScope.Counter("err-write-tagged"),
 		pendingNamespaceChange:              scope.Gauge("pending-namespace-change"),
@@ -198,6 +209,8 @@ func NewDatabase(
 		log:                    logger,
 		writeBatchPool:         opts.WriteBatchPool(),
 		queryLimits:            opts.IndexOptions().QueryLimits(),
+		writeAdmission:         admission.NewNoopController(),
+		merkleTrees:            newMerkleTreeCache(nowFn, maxMerkleTreeCacheNodes),
 	}
 
 	databaseIOpts := iopts.SetMetricsScope(scope)
@@ -243,6 +256,13 @@ func NewDatabase(
 		return nil, err
 	}
 
+	if admissionOpts := opts.WriteAdmissionOptions(); admissionOpts.Enabled() {
+		d.writeAdmission, err = newWriteAdmissionController(d, admissionOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	d.repairer = newNoopDatabaseRepairer()
 	if opts.RepairEnabled() {
 		d.repairer, err = newDatabaseRepairer(d, opts)
@@ -255,6 +275,14 @@ func NewDatabase(
 		}
 	}
 
+	if replicator := opts.CommitLogReplicator(); replicator != nil {
+		err = d.mediator.RegisterBackgroundProcess(
+			newCommitLogReplication(replicator, commitLog, opts))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for _, fn := range opts.BackgroundProcessFns() {
 		process, err := fn(d, opts)
 		if err != nil {
@@ -269,6 +297,27 @@ func NewDatabase(
 	return d, nil
 }
 
+func newWriteAdmissionController(
+	d *db,
+	opts admission.Options,
+) (admission.Controller, error) {
+	signals := []admission.Signal{
+		admission.NewRatioSignal("commitlog-queue", func() float64 {
+			return float64(d.commitLog.QueueLength())
+		}, float64(d.opts.CommitLogOptions().BacklogQueueSize())),
+	}
+	if limit := opts.HeapLimitBytes(); limit > 0 {
+		signals = append(signals, admission.NewHeapSignal(limit,
+			opts.HeapSampleInterval(), opts.ClockOptions().NowFn()))
+	}
+	if limit := opts.FlushBacklogLimit(); limit > 0 {
+		signals = append(signals, admission.NewRatioSignal("flush-backlog", func() float64 {
+			return float64(d.mediator.WarmFlushBacklog())
+		}, float64(limit)))
+	}
+	return admission.NewController(opts, signals...)
+}
+
 func (d *db) UpdateOwnedNamespaces(newNamespaces namespace.Map) error {
 	if newNamespaces == nil {
 		return nil
@@ -1065,11 +1114,39 @@ func (d *db) AggregateQuery(
 	return n.AggregateQuery(ctx, query, aggResultOpts)
 }
 
+func (d *db) Cardinality(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	opts index.CardinalityOptions,
+) (index.CardinalityQueryResult, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceQueryIDs.Inc(1)
+		return index.CardinalityQueryResult{}, err
+	}
+
+	ctx, sp, sampled := ctx.StartSampledTraceSpan(tracepoint.DBCardinality)
+	if sampled {
+		sp.LogFields(
+			opentracinglog.String("query", query.String()),
+			opentracinglog.String("namespace", namespace.String()),
+			opentracinglog.Int("topN", opts.TopN),
+			xopentracing.Time("start", opts.StartInclusive.ToTime()),
+			xopentracing.Time("end", opts.EndExclusive.ToTime()),
+		)
+	}
+
+	defer sp.Finish()
+	return n.Cardinality(ctx, query, opts)
+}
+
 func (d *db) ReadEncoded(
 	ctx context.Context,
 	namespace ident.ID,
 	id ident.ID,
 	start, end xtime.UnixNano,
+	opts ReadEncodedOptions,
 ) (series.BlockReaderIter, error) {
 	n, err := d.namespaceFor(namespace)
 	if err != nil {
@@ -1077,7 +1154,7 @@ func (d *db) ReadEncoded(
 		return nil, err
 	}
 
-	return n.ReadEncoded(ctx, id, start, end)
+	return n.ReadEncoded(ctx, id, start, end, opts)
 }
 
 func (d *db) FetchBlocks(
@@ -1220,12 +1297,67 @@ func (d *db) Truncate(namespace ident.ID) (int64, error) {
 	return n.Truncate()
 }
 
+func (d *db) DeleteTagged(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	start, end xtime.UnixNano,
+) (map[uint32]int64, error) {
+	if end.Before(start) {
+		return nil, xerrors.NewInvalidParamsError(errDeleteTaggedInvalidRange)
+	}
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceDeleteTagged.Inc(1)
+		return nil, err
+	}
+	return n.DeleteTagged(ctx, query, start, end)
+}
+
+func (d *db) QuarantinedWrites(
+	namespace ident.ID,
+	limit int,
+) ([]QuarantinedWrite, int, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceQuarantine.Inc(1)
+		return nil, 0, err
+	}
+	writes, total := n.QuarantinedWrites(limit)
+	return writes, total, nil
+}
+
+func (d *db) PromoteQuarantinedWrites(
+	ctx context.Context,
+	namespace ident.ID,
+) (int, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceQuarantine.Inc(1)
+		return 0, err
+	}
+	return n.PromoteQuarantinedWrites(ctx)
+}
+
+func (d *db) DiscardQuarantinedWrites(namespace ident.ID) (int, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceQuarantine.Inc(1)
+		return 0, err
+	}
+	return n.DiscardQuarantinedWrites()
+}
+
 func (d *db) IsOverloaded() bool {
 	queueSize := float64(d.commitLog.QueueLength())
 	queueCapacity := float64(d.opts.CommitLogOptions().BacklogQueueSize())
 	return queueSize >= commitLogQueueCapacityOverloadedFactor*queueCapacity
 }
 
+func (d *db) AdmitWrite(ctx context.Context) error {
+	return d.writeAdmission.Admit(ctx.GoContext())
+}
+
 func (d *db) BootstrapState() DatabaseBootstrapState {
 	nsBootstrapStates := NamespaceBootstrapStates{}
 
@@ -40,6 +40,7 @@ import (
 	dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/dbnode/storage/limits/admission"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/testdata/prototest"
 	"github.com/m3db/m3/src/dbnode/topology"
@@ -267,7 +268,7 @@ func TestDatabaseReadEncodedNamespaceNonExistent(t *testing.T) {
 	}()
 	now := xtime.Now()
 	_, err := d.ReadEncoded(ctx, ident.StringID("nonexistent"),
-		ident.StringID("foo"), now, now)
+		ident.StringID("foo"), now, now, ReadEncodedOptions{})
 	require.True(t, dberrors.IsUnknownNamespaceError(err))
 }
 
@@ -288,10 +289,10 @@ func TestDatabaseReadEncoded(t *testing.T) {
 	end := xtime.Now()
 	start := end.Add(-time.Hour)
 	mockNamespace := NewMockdatabaseNamespace(ctrl)
-	mockNamespace.EXPECT().ReadEncoded(ctx, id, start, end).Return(nil, nil)
+	mockNamespace.EXPECT().ReadEncoded(ctx, id, start, end, ReadEncodedOptions{}).Return(nil, nil)
 	d.namespaces.Set(ns, mockNamespace)
 
-	res, err := d.ReadEncoded(ctx, ns, id, start, end)
+	res, err := d.ReadEncoded(ctx, ns, id, start, end, ReadEncodedOptions{})
 	require.Nil(t, res)
 	require.Nil(t, err)
 }
@@ -1603,6 +1604,55 @@ func TestDatabaseIsOverloaded(t *testing.T) {
 	require.Equal(t, true, d.IsOverloaded())
 }
 
+func TestDatabaseAdmitWrite(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := defaultTestDatabase(t, ctrl, BootstrapNotStarted)
+	defer func() {
+		close(mapCh)
+	}()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	// Write admission is disabled by default.
+	require.NoError(t, d.AdmitWrite(ctx))
+
+	d.opts = d.opts.SetCommitLogOptions(
+		d.opts.CommitLogOptions().SetBacklogQueueSize(100),
+	)
+
+	mockCL := commitlog.NewMockCommitLog(ctrl)
+	d.commitLog = mockCL
+
+	mediator := NewMockdatabaseMediator(ctrl)
+	d.mediator = mediator
+
+	var err error
+	d.writeAdmission, err = newWriteAdmissionController(d, admission.NewOptions().
+		SetEnabled(true).
+		SetDelayThreshold(0.5).
+		SetRejectThreshold(0.9).
+		SetFlushBacklogLimit(4))
+	require.NoError(t, err)
+
+	mockCL.EXPECT().QueueLength().Return(int64(10))
+	mediator.EXPECT().WarmFlushBacklog().Return(1)
+	require.NoError(t, d.AdmitWrite(ctx))
+
+	mockCL.EXPECT().QueueLength().Return(int64(95))
+	mediator.EXPECT().WarmFlushBacklog().Return(1)
+	err = d.AdmitWrite(ctx)
+	require.True(t, admission.IsOverloadedError(err))
+
+	mockCL.EXPECT().QueueLength().Return(int64(10))
+	mediator.EXPECT().WarmFlushBacklog().Return(4)
+	err = d.AdmitWrite(ctx)
+	require.True(t, admission.IsOverloadedError(err))
+	require.Contains(t, err.Error(), "flush-backlog")
+}
+
 func TestDatabaseAggregateTiles(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
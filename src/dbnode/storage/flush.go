@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/retention"
@@ -92,6 +93,7 @@ type flushManager struct {
 	metrics flushManagerMetrics
 
 	lastSuccessfulSnapshotStartTime atomic.Int64 // == xtime.UnixNano
+	warmFlushBacklog                atomic.Int64
 
 	logger *zap.Logger
 	nowFn  clock.NowFn
@@ -175,17 +177,27 @@ func (m *flushManager) dataWarmFlush(
 
 	m.setState(flushManagerFlushInProgress)
 	var (
-		start    = m.nowFn()
-		multiErr = xerrors.NewMultiError()
+		start        = m.nowFn()
+		multiErr     = xerrors.NewMultiError()
+		nsFlushTimes = make([][]xtime.UnixNano, len(namespaces))
+		backlog      int
 	)
-	for _, ns := range namespaces {
-		// Flush first because we will only snapshot if there are no outstanding flushes.
+	for i, ns := range namespaces {
 		flushTimes, err := m.namespaceFlushTimes(ns, startTime)
 		if err != nil {
 			multiErr = multiErr.Add(err)
 			continue
 		}
-		if err := m.flushNamespaceWithTimes(ns, flushTimes, flushPersist); err != nil {
+		nsFlushTimes[i] = flushTimes
+		backlog += len(flushTimes)
+	}
+	// NB: publish the backlog before flushing so that write admission sees
+	// the pending flushes for as long as they take to complete.
+	m.warmFlushBacklog.Store(int64(backlog))
+
+	for i, ns := range namespaces {
+		// Flush first because we will only snapshot if there are no outstanding flushes.
+		if err := m.flushNamespaceWithTimes(ns, nsFlushTimes[i], flushPersist); err != nil {
 			multiErr = multiErr.Add(err)
 		}
 	}
@@ -313,7 +325,7 @@ func (m *flushManager) flushRange(rOpts retention.Options, t xtime.UnixNano) (xt
 
 func (m *flushManager) namespaceFlushTimes(ns databaseNamespace, curr xtime.UnixNano) ([]xtime.UnixNano, error) {
 	var (
-		rOpts            = ns.Options().RetentionOptions()
+		rOpts            = namespace.BlockRetentionOptions(ns.Options())
 		blockSize        = rOpts.BlockSize()
 		earliest, latest = m.flushRange(rOpts, curr)
 	)
@@ -332,7 +344,7 @@ func (m *flushManager) namespaceFlushTimes(ns databaseNamespace, curr xtime.Unix
 
 func (m *flushManager) namespaceSnapshotTimes(ns databaseNamespace, curr xtime.UnixNano) []xtime.UnixNano {
 	var (
-		rOpts     = ns.Options().RetentionOptions()
+		rOpts     = namespace.BlockRetentionOptions(ns.Options())
 		blockSize = rOpts.BlockSize()
 		// Earliest possible snapshottable block is the earliest possible flushable
 		// blockStart which is the first block in the retention period.
@@ -376,3 +388,7 @@ func (m *flushManager) LastSuccessfulSnapshotStartTime() (xtime.UnixNano, bool)
 	snapTime := xtime.UnixNano(m.lastSuccessfulSnapshotStartTime.Load())
 	return snapTime, snapTime > 0
 }
+
+func (m *flushManager) WarmFlushBacklog() int {
+	return int(m.warmFlushBacklog.Load())
+}
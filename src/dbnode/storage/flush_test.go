@@ -236,6 +236,82 @@ func TestFlushManagerNamespaceFlushTimesErr(t *testing.T) {
 	require.True(t, strings.Contains(fm.Flush(now).Error(), fakeErr.Error()))
 }
 
+func TestFlushManagerWarmFlushBacklog(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		fakeErr             = errors.New("some-err")
+		mockPersistManager  = persist.NewMockManager(ctrl)
+		mockFlushPersist    = persist.NewMockFlushPreparer(ctrl)
+		mockSnapshotPersist = persist.NewMockSnapshotPreparer(ctrl)
+		mockIndexFlusher    = persist.NewMockIndexFlush(ctrl)
+	)
+
+	mockFlushPersist.EXPECT().DoneFlush().Return(nil).AnyTimes()
+	mockPersistManager.EXPECT().StartFlushPersist().Return(mockFlushPersist, nil).AnyTimes()
+	mockSnapshotPersist.EXPECT().DoneSnapshot(gomock.Any(), testCommitlogFile).Return(nil).AnyTimes()
+	mockPersistManager.EXPECT().StartSnapshotPersist(gomock.Any()).Return(mockSnapshotPersist, nil).AnyTimes()
+	mockIndexFlusher.EXPECT().DoneIndex().Return(nil).AnyTimes()
+	mockPersistManager.EXPECT().StartIndexPersist().Return(mockIndexFlusher, nil).AnyTimes()
+
+	testOpts := DefaultTestOptions().SetPersistManager(mockPersistManager)
+	db := newMockdatabase(ctrl)
+	db.EXPECT().Options().Return(testOpts).AnyTimes()
+
+	var (
+		needsFlush = true
+		namespaces []databaseNamespace
+		nsOpts     = defaultTestNs1Opts.SetIndexOptions(namespace.NewIndexOptions().SetEnabled(false))
+	)
+	for i := 0; i < 2; i++ {
+		ns := NewMockdatabaseNamespace(ctrl)
+		ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+		ns.EXPECT().ID().Return(defaultTestNs1ID).AnyTimes()
+		ns.EXPECT().NeedsFlush(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_, _ xtime.UnixNano) (bool, error) {
+				return needsFlush, nil
+			}).AnyTimes()
+		ns.EXPECT().Snapshot(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		namespaces = append(namespaces, ns)
+	}
+	db.EXPECT().OwnedNamespaces().Return(namespaces, nil).AnyTimes()
+
+	cl := commitlog.NewMockCommitLog(ctrl)
+	cl.EXPECT().RotateLogs().Return(testCommitlogFile, nil).AnyTimes()
+
+	fm := newFlushManager(db, cl, tally.NoopScope).(*flushManager)
+	fm.pm = mockPersistManager
+	require.Equal(t, 0, fm.WarmFlushBacklog())
+
+	now := xtime.UnixNano(0)
+	flushTimes, err := fm.namespaceFlushTimes(namespaces[0], now)
+	require.NoError(t, err)
+	require.True(t, len(flushTimes) > 1)
+
+	// The backlog is published before flushing and sums the pending block
+	// starts of all namespaces, whether or not their flushes fail.
+	expected := 2 * len(flushTimes)
+	for i, ns := range namespaces {
+		result := error(nil)
+		if i == 0 {
+			result = fakeErr
+		}
+		ns.(*MockdatabaseNamespace).EXPECT().WarmFlush(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ xtime.UnixNano, _ persist.FlushPreparer) error {
+				require.Equal(t, expected, fm.WarmFlushBacklog())
+				return result
+			}).Times(len(flushTimes))
+	}
+
+	require.Error(t, fm.Flush(now))
+	require.Equal(t, expected, fm.WarmFlushBacklog())
+
+	needsFlush = false
+	require.NoError(t, fm.Flush(now))
+	require.Equal(t, 0, fm.WarmFlushBacklog())
+}
+
 // TestFlushManagerFlushDoneSnapshotError makes sure that snapshot errors do not
 // impact flushing or index operations.
 func TestFlushManagerFlushDoneSnapshotError(t *testing.T) {
@@ -26,6 +26,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/x/context"
 	"github.com/m3db/m3/src/x/ident"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -42,7 +43,10 @@ type fsMergeWithMem struct {
 	retriever          series.QueryableBlockRetriever
 	dirtySeries        *dirtySeriesMap
 	dirtySeriesToWrite map[xtime.UnixNano]*idList
+	retentionCutoffs   *shardRetentionCutoffs
+	now                xtime.UnixNano
 	reusableID         *ident.ReusableBytesID
+	reusableFields     []doc.Field
 }
 
 func newFSMergeWithMem(
@@ -50,12 +54,16 @@ func newFSMergeWithMem(
 	retriever series.QueryableBlockRetriever,
 	dirtySeries *dirtySeriesMap,
 	dirtySeriesToWrite map[xtime.UnixNano]*idList,
+	retentionCutoffs *shardRetentionCutoffs,
+	now xtime.UnixNano,
 ) fs.MergeWith {
 	return &fsMergeWithMem{
 		shard:              shard,
 		retriever:          retriever,
 		dirtySeries:        dirtySeries,
 		dirtySeriesToWrite: dirtySeriesToWrite,
+		retentionCutoffs:   retentionCutoffs,
+		now:                now,
 		reusableID:         ident.NewReusableBytesID(),
 	}
 }
@@ -127,6 +135,10 @@ func (m *fsMergeWithMem) ForEachRemaining(
 
 	for seriesElement := seriesList.Front(); seriesElement != nil; seriesElement = seriesElement.Next() {
 		seriesMetadata := seriesElement.Value
+		if m.retentionCutoffs != nil &&
+			m.retentionCutoffs.Expired(seriesMetadata.Fields, blockStart, m.now) {
+			continue
+		}
 		reusableID.Reset(seriesMetadata.ID)
 		mergeWithData, hasData, err := m.fetchBlocks(ctx, reusableID, blockStart, nsCtx)
 		if err != nil {
@@ -143,3 +155,30 @@ func (m *fsMergeWithMem) ForEachRemaining(
 
 	return nil
 }
+
+func (m *fsMergeWithMem) Tombstones(seriesID ident.ID) xtime.Ranges {
+	return m.shard.SeriesTombstones(seriesID)
+}
+
+func (m *fsMergeWithMem) Expired(tags ident.TagIterator, blockStart xtime.UnixNano) (bool, error) {
+	if m.retentionCutoffs == nil {
+		return false, nil
+	}
+	// The fields reference the tag bytes so they are only valid until the
+	// duplicated iterator is closed.
+	iter := tags.Duplicate()
+	defer iter.Close()
+	fields := m.reusableFields[:0]
+	for iter.Next() {
+		tag := iter.Current()
+		fields = append(fields, doc.Field{
+			Name:  tag.Name.Bytes(),
+			Value: tag.Value.Bytes(),
+		})
+	}
+	m.reusableFields = fields[:0]
+	if err := iter.Err(); err != nil {
+		return false, err
+	}
+	return m.retentionCutoffs.Expired(fields, blockStart, m.now), nil
+}
@@ -81,7 +81,7 @@ func TestRead(t *testing.T) {
 			Return(result, nil)
 	}
 
-	mergeWith := newFSMergeWithMem(shard, retriever, dirtySeries, dirtySeriesToWrite)
+	mergeWith := newFSMergeWithMem(shard, retriever, dirtySeries, dirtySeriesToWrite, nil, 0)
 
 	for _, d := range data {
 		require.True(t, dirtySeries.Contains(idAndBlockStart{
@@ -170,7 +170,7 @@ func TestForEachRemaining(t *testing.T) {
 		addDirtySeries(dirtySeries, dirtySeriesToWrite, d.id, d.start)
 	}
 
-	mergeWith := newFSMergeWithMem(shard, retriever, dirtySeries, dirtySeriesToWrite)
+	mergeWith := newFSMergeWithMem(shard, retriever, dirtySeries, dirtySeriesToWrite, nil, 0)
 
 	var forEachCalls []doc.Metadata
 	shard.EXPECT().
@@ -32,6 +32,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
@@ -173,6 +174,10 @@ type nsIndexState struct {
 	// only return IDs that this node owns, and the shard responsible for that ID.
 	shardFilteredForID func(id ident.ID) (uint32, bool)
 
+	// shardsAvailableFilterID is set every time the shards change to only
+	// count IDs of shards that are available on this node.
+	shardsAvailableFilterID func(id []byte) bool
+
 	shardsAssigned map[uint32]struct{}
 }
 
@@ -360,7 +365,7 @@ func newNamespaceIndexWithOptions(
 
 		nowFn:                 nowFn,
 		blockSize:             nsMD.Options().IndexOptions().BlockSize(),
-		retentionPeriod:       nsMD.Options().RetentionOptions().RetentionPeriod(),
+		retentionPeriod:       namespace.BlockRetentionOptions(nsMD.Options()).RetentionPeriod(),
 		futureRetentionPeriod: nsMD.Options().RetentionOptions().FutureRetentionPeriod(),
 		bufferPast:            nsMD.Options().RetentionOptions().BufferPast(),
 		bufferFuture:          nsMD.Options().RetentionOptions().BufferFuture(),
@@ -1017,16 +1022,7 @@ func (i *nsIndex) WarmFlush(
 		return err
 	}
 
-	// Determine the current flush indexing concurrency.
-	namespaceRuntimeOpts := i.namespaceRuntimeOptsMgr.Get()
-	perCPUFraction := namespaceRuntimeOpts.FlushIndexingPerCPUConcurrencyOrDefault()
-	cpus := math.Ceil(perCPUFraction * float64(goruntime.GOMAXPROCS(0)))
-	concurrency := int(math.Max(1, cpus))
-
-	builderOpts := i.opts.IndexOptions().SegmentBuilderOptions().
-		SetConcurrency(concurrency)
-
-	builder, err := builder.NewBuilderFromDocuments(builderOpts)
+	builder, concurrency, err := i.newFlushBuilder()
 	if err != nil {
 		return err
 	}
@@ -1039,25 +1035,7 @@ func (i *nsIndex) WarmFlush(
 
 	var evicted int
 	for _, block := range flushable {
-		immutableSegments, err := i.flushBlock(flush, block, shards, builder)
-		if err != nil {
-			return err
-		}
-		// Make a result that covers the entire time ranges for the
-		// block for each shard
-		fulfilled := result.NewShardTimeRangesFromRange(block.StartTime(), block.EndTime(),
-			dbShards(shards).IDs()...)
-
-		// Add the results to the block.
-		persistedSegments := make([]result.Segment, 0, len(immutableSegments))
-		for _, elem := range immutableSegments {
-			persistedSegment := result.NewSegment(elem, true)
-			persistedSegments = append(persistedSegments, persistedSegment)
-		}
-		blockResult := result.NewIndexBlock(persistedSegments, fulfilled)
-		results := result.NewIndexBlockByVolumeType(block.StartTime())
-		results.SetBlock(idxpersist.DefaultIndexVolumeType, blockResult)
-		if err := block.AddResults(results); err != nil {
+		if err := i.flushBlockAndAddResults(flush, block, shards, builder); err != nil {
 			return err
 		}
 
@@ -1084,6 +1062,113 @@ func (i *nsIndex) WarmFlush(
 	return nil
 }
 
+// RebuildFlushedBlocks rebuilds the index blocks covering the given data
+// block starts that were already flushed to disk. Blocks are rebuilt from the
+// filesets of the owned shards, the same as when they were first flushed, so
+// that series removed from the filesets by a cold flush are dropped from the
+// index. The rebuilt segments are persisted as a new volume that supersedes
+// the previous volumes, which are removed by the cleanup of duplicate index
+// filesets.
+func (i *nsIndex) RebuildFlushedBlocks(
+	flush persist.IndexFlush,
+	shards []databaseShard,
+	blockStarts []xtime.UnixNano,
+) error {
+	if len(shards) == 0 || len(blockStarts) == 0 {
+		return nil
+	}
+
+	indexBlockStarts := make(map[xtime.UnixNano]struct{}, len(blockStarts))
+	for _, t := range blockStarts {
+		indexBlockStarts[t.Truncate(i.blockSize)] = struct{}{}
+	}
+
+	i.state.RLock()
+	if !i.isOpenWithRLock() {
+		i.state.RUnlock()
+		return errDbIndexUnableToFlushClosed
+	}
+	var (
+		infoFiles = i.readInfoFilesAsMap()
+		rebuild   = make([]index.Block, 0, len(indexBlockStarts))
+	)
+	for blockStart := range indexBlockStarts {
+		block, ok := i.state.blocksByTime[blockStart]
+		if !ok || !i.hasIndexWarmFlushedToDisk(infoFiles, blockStart) {
+			// Blocks that are yet to be flushed are built from the filesets
+			// when they are, expired blocks need no rebuild.
+			continue
+		}
+		rebuild = append(rebuild, block)
+	}
+	i.state.RUnlock()
+
+	if len(rebuild) == 0 {
+		return nil
+	}
+
+	builder, _, err := i.newFlushBuilder()
+	if err != nil {
+		return err
+	}
+	defer builder.Close()
+
+	for _, block := range rebuild {
+		if err := i.flushBlockAndAddResults(flush, block, shards, builder); err != nil {
+			return err
+		}
+		i.metrics.blocksRebuilt.Inc(1)
+	}
+	return nil
+}
+
+// newFlushBuilder returns a documents builder for flushing index blocks that
+// uses the current flush indexing concurrency.
+func (i *nsIndex) newFlushBuilder() (segment.CloseableDocumentsBuilder, int, error) {
+	namespaceRuntimeOpts := i.namespaceRuntimeOptsMgr.Get()
+	perCPUFraction := namespaceRuntimeOpts.FlushIndexingPerCPUConcurrencyOrDefault()
+	cpus := math.Ceil(perCPUFraction * float64(goruntime.GOMAXPROCS(0)))
+	concurrency := int(math.Max(1, cpus))
+
+	builderOpts := i.opts.IndexOptions().SegmentBuilderOptions().
+		SetConcurrency(concurrency)
+
+	builder, err := builder.NewBuilderFromDocuments(builderOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return builder, concurrency, nil
+}
+
+// flushBlockAndAddResults flushes the block from the filesets of the shards
+// and replaces the segments of the block with the flushed segments.
+func (i *nsIndex) flushBlockAndAddResults(
+	flush persist.IndexFlush,
+	block index.Block,
+	shards []databaseShard,
+	builder segment.DocumentsBuilder,
+) error {
+	immutableSegments, err := i.flushBlock(flush, block, shards, builder)
+	if err != nil {
+		return err
+	}
+	// Make a result that covers the entire time ranges for the
+	// block for each shard
+	fulfilled := result.NewShardTimeRangesFromRange(block.StartTime(), block.EndTime(),
+		dbShards(shards).IDs()...)
+
+	// Add the results to the block.
+	persistedSegments := make([]result.Segment, 0, len(immutableSegments))
+	for _, elem := range immutableSegments {
+		persistedSegment := result.NewSegment(elem, true)
+		persistedSegments = append(persistedSegments, persistedSegment)
+	}
+	blockResult := result.NewIndexBlock(persistedSegments, fulfilled)
+	results := result.NewIndexBlockByVolumeType(block.StartTime())
+	results.SetBlock(idxpersist.DefaultIndexVolumeType, blockResult)
+	return block.AddResults(results)
+}
+
 func (i *nsIndex) ColdFlush(shards []databaseShard) (OnColdFlushDone, error) {
 	if len(shards) == 0 {
 		// No-op if no shards currently owned.
@@ -1444,10 +1529,14 @@ func (i *nsIndex) AssignShardSet(shardSet sharding.ShardSet) {
 	// NB(r): Allocate the filter function once, it can be used outside
 	// of locks as it depends on no internal state.
 	set := bitset.NewBitSet(uint(shardSet.Max()))
+	available := bitset.NewBitSet(uint(shardSet.Max()))
 	assigned := make(map[uint32]struct{})
-	for _, shardID := range shardSet.AllIDs() {
-		set.Set(uint(shardID))
-		assigned[shardID] = struct{}{}
+	for _, s := range shardSet.All() {
+		set.Set(uint(s.ID()))
+		if s.State() == shard.Available {
+			available.Set(uint(s.ID()))
+		}
+		assigned[s.ID()] = struct{}{}
 	}
 
 	i.state.Lock()
@@ -1461,6 +1550,10 @@ func (i *nsIndex) AssignShardSet(shardSet sharding.ShardSet) {
 		return shard, set.Test(uint(shard))
 	}
 
+	i.state.shardsAvailableFilterID = func(id []byte) bool {
+		return available.Test(uint(shardSet.Lookup(ident.BytesID(id))))
+	}
+
 	i.state.shardsAssigned = assigned
 	i.state.Unlock()
 }
@@ -1472,6 +1565,13 @@ func (i *nsIndex) shardsFilterID() func(id ident.ID) bool {
 	return v
 }
 
+func (i *nsIndex) shardsAvailableFilterID() func(id []byte) bool {
+	i.state.RLock()
+	v := i.state.shardsAvailableFilterID
+	i.state.RUnlock()
+	return v
+}
+
 func (i *nsIndex) shardForID() func(id ident.ID) (uint32, bool) {
 	i.state.RLock()
 	v := i.state.shardFilteredForID
@@ -1507,8 +1607,12 @@ func (i *nsIndex) Query(
 		FilterID:  i.shardsFilterID(),
 	})
 	ctx.RegisterFinalizer(results)
+	newBlockIterFn := i.newBlockQueryIterFn
+	if opts.Explain {
+		newBlockIterFn = i.newBlockExplainQueryIterFn
+	}
 	queryRes, err := i.query(ctx, query, results, opts, i.execBlockQueryFn,
-		i.newBlockQueryIterFn, logFields)
+		newBlockIterFn, logFields)
 	if err != nil {
 		sp.LogFields(opentracinglog.Error(err))
 		return index.QueryResult{}, err
@@ -1518,6 +1622,7 @@ func (i *nsIndex) Query(
 		Results:    results,
 		Exhaustive: queryRes.exhaustive,
 		Waited:     queryRes.waited,
+		Explain:    queryRes.explain,
 	}, nil
 }
 
@@ -1580,9 +1685,97 @@ func (i *nsIndex) AggregateQuery(
 	}, nil
 }
 
+func (i *nsIndex) Cardinality(
+	ctx context.Context,
+	query index.Query,
+	opts index.CardinalityOptions,
+) (index.CardinalityQueryResult, error) {
+	ctx, sp := ctx.StartTraceSpan(tracepoint.NSIdxCardinality)
+	sp.LogFields(
+		opentracinglog.String("query", query.String()),
+		opentracinglog.String("namespace", i.nsMetadata.ID().String()),
+		opentracinglog.Int("seriesLimit", opts.SeriesLimit),
+		opentracinglog.Int("docsLimit", opts.DocsLimit),
+		xopentracing.Time("queryStart", opts.StartInclusive.ToTime()),
+		xopentracing.Time("queryEnd", opts.EndExclusive.ToTime()),
+	)
+	defer sp.Finish()
+
+	i.state.RLock()
+	if !i.isOpenWithRLock() {
+		i.state.RUnlock()
+		return index.CardinalityQueryResult{}, errDbIndexUnableToQueryClosed
+	}
+
+	// Track this as an inflight query that needs to finish
+	// when the index is closed.
+	i.queriesWg.Add(1)
+	defer i.queriesWg.Done()
+
+	opts.QueryOptions = i.overriddenOptsForQueryWithRLock(opts.QueryOptions)
+	qryRange := xtime.NewRanges(xtime.Range{
+		Start: opts.StartInclusive,
+		End:   opts.EndExclusive,
+	})
+	blocks := newBlocksIterStackAlloc(i.activeBlock, i.state.blocksDescOrderImmutable, qryRange)
+	i.state.RUnlock()
+
+	// Cardinality queries scan every term of the blocks so hold a single
+	// permit and process the blocks one at a time.
+	perms, err := i.permitsManager.NewPermits(ctx)
+	if err != nil {
+		return index.CardinalityQueryResult{}, err
+	}
+	defer perms.Close()
+
+	acquireResult, err := perms.Acquire(ctx)
+	if acquireResult.Permit != nil {
+		defer perms.Release(acquireResult.Permit)
+	}
+	if err != nil {
+		return index.CardinalityQueryResult{}, err
+	}
+
+	// Only count the series of shards available on this node so that the
+	// initializing and leaving copies of a shard being moved are not counted
+	// on top of its available replicas.
+	results := index.NewCardinalityResults(index.CardinalityResultsOptions{
+		FilterID: i.shardsAvailableFilterID(),
+	})
+	for b, ok := blocks.Next(); ok; b, ok = b.Next() {
+		if opts.LimitsExceeded(results.Size(), results.TotalDocsCount()) {
+			break
+		}
+		if err := b.Current().Cardinality(ctx, query, opts.QueryOptions, results); err != nil {
+			sp.LogFields(opentracinglog.Error(err))
+			return index.CardinalityQueryResult{}, err
+		}
+	}
+
+	exhaustive := opts.Exhaustive(results.Size(), results.TotalDocsCount())
+	if !exhaustive && opts.RequireExhaustive {
+		// NB(r): Make sure error is not retried and returns as bad request.
+		return index.CardinalityQueryResult{}, xerrors.NewInvalidParamsError(
+			limits.NewQueryLimitExceededError(fmt.Sprintf(
+				"cardinality query exceeded limit: require_exhaustive=%v, series_limit=%d, series_matched=%d, docs_limit=%d, docs_matched=%d",
+				opts.RequireExhaustive,
+				opts.SeriesLimit,
+				results.Size(),
+				opts.DocsLimit,
+				results.TotalDocsCount(),
+			)))
+	}
+
+	return index.CardinalityQueryResult{
+		Stats:      results.Stats(opts),
+		Exhaustive: exhaustive,
+	}, nil
+}
+
 type queryResult struct {
 	exhaustive bool
 	waited     int
+	explain    *index.QueryExplain
 }
 
 func (i *nsIndex) query(
@@ -1839,12 +2032,36 @@ func (i *nsIndex) queryWithSpan(
 	multiErr := state.multiErr
 	err = multiErr.FinalError()
 
+	var explain *index.QueryExplain
+	if opts.Explain {
+		explain = explainBlockIters(blockIters)
+	}
+
 	return queryResult{
 		exhaustive: exhaustive,
 		waited:     state.waited(),
+		explain:    explain,
 	}, err
 }
 
+func explainBlockIters(blockIters []*blockIter) *index.QueryExplain {
+	explain := &index.QueryExplain{
+		Blocks: make([]index.BlockExplain, 0, len(blockIters)),
+	}
+	for _, blockIter := range blockIters {
+		blockExplain := index.BlockExplain{
+			BlockStart:     blockIter.block.StartTime(),
+			WaitTime:       blockIter.waitTime,
+			ProcessingTime: blockIter.processingTime,
+		}
+		if iter, ok := blockIter.iter.(index.QueryIterator); ok {
+			blockExplain.Segments = iter.SearchTraces()
+		}
+		explain.Blocks = append(explain.Blocks, blockExplain)
+	}
+	return explain
+}
+
 func (i *nsIndex) newBlockQueryIterFn(
 	ctx context.Context,
 	block index.Block,
@@ -1854,7 +2071,16 @@ func (i *nsIndex) newBlockQueryIterFn(
 	return block.QueryIter(ctx, query)
 }
 
-//nolint: dupl
+func (i *nsIndex) newBlockExplainQueryIterFn(
+	ctx context.Context,
+	block index.Block,
+	query index.Query,
+	_ index.BaseResults,
+) (index.ResultIterator, error) {
+	return block.ExplainQueryIter(ctx, query)
+}
+
+// nolint: dupl
 func (i *nsIndex) execBlockQueryFn(
 	ctx context.Context,
 	block index.Block,
@@ -2452,6 +2678,7 @@ type nsIndexMetrics struct {
 	forwardIndexCounter              tally.Counter
 	insertEndToEndLatency            tally.Timer
 	blocksEvictedMutableSegments     tally.Counter
+	blocksRebuilt                    tally.Counter
 	blockMetrics                     nsIndexBlocksMetrics
 	indexingConcurrencyMin           tally.Gauge
 	indexingConcurrencyMax           tally.Gauge
@@ -2519,6 +2746,7 @@ func newNamespaceIndexMetrics(
 		insertEndToEndLatency: instrument.NewTimer(scope,
 			"insert-end-to-end-latency", iopts.TimerOptions()),
 		blocksEvictedMutableSegments: scope.Counter("blocks-evicted-mutable-segments"),
+		blocksRebuilt:                scope.Counter("blocks-rebuilt"),
 		blockMetrics:                 newNamespaceIndexBlocksMetrics(opts, blocksScope),
 		indexingConcurrencyMin: scope.Tagged(map[string]string{
 			"stat": "min",
@@ -35,6 +35,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/idx"
 	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
@@ -68,6 +69,8 @@ var (
 
 	errUnableToSealBlockIllegalStateFmtString  = "unable to seal, index block state: %v"
 	errUnableToWriteBlockUnknownStateFmtString = "unable to write, unknown index block state: %v"
+
+	cardinalityAllQuery = idx.NewAllQuery()
 )
 
 type blockState uint
@@ -389,7 +392,28 @@ func (b *block) executorWithRLock() (search.Executor, error) {
 	return executor.NewExecutor(indexReaders), nil
 }
 
+func (b *block) explainExecutorWithRLock() (search.Executor, error) {
+	var names []string
+	readers, err := b.namedSegmentReadersWithRLock(&names)
+	if err != nil {
+		return nil, err
+	}
+
+	indexReaders := make([]m3ninxindex.Reader, 0, len(readers))
+	for _, r := range readers {
+		indexReaders = append(indexReaders, r)
+	}
+
+	return executor.NewExplainExecutor(indexReaders, names), nil
+}
+
 func (b *block) segmentReadersWithRLock() ([]segment.Reader, error) {
+	return b.namedSegmentReadersWithRLock(nil)
+}
+
+// namedSegmentReadersWithRLock returns the segment readers and, if names is
+// non-nil, appends a description of each of the readers' segments to names.
+func (b *block) namedSegmentReadersWithRLock(names *[]string) ([]segment.Reader, error) {
 	expectedReaders := b.mutableSegments.Len()
 	for _, coldSeg := range b.coldMutableSegments {
 		expectedReaders += coldSeg.Len()
@@ -413,21 +437,34 @@ func (b *block) segmentReadersWithRLock() ([]segment.Reader, error) {
 		}
 	}()
 
+	addNames := func(prefix string, from int) {
+		if names == nil {
+			return
+		}
+		for i := from; i < len(readers); i++ {
+			*names = append(*names, fmt.Sprintf("%s/%d", prefix, i-from))
+		}
+	}
+
 	// Add mutable segments.
 	readers, err = b.mutableSegments.AddReaders(readers)
 	if err != nil {
 		return nil, err
 	}
+	addNames("mutable", 0)
 
 	// Add cold mutable segments.
-	for _, coldSeg := range b.coldMutableSegments {
+	for i, coldSeg := range b.coldMutableSegments {
+		from := len(readers)
 		readers, err = coldSeg.AddReaders(readers)
 		if err != nil {
 			return nil, err
 		}
+		addNames(fmt.Sprintf("cold-mutable/%d", i), from)
 	}
 
 	// Loop over the segments associated to shard time ranges.
+	from := len(readers)
 	if err := b.shardRangesSegmentsByVolumeType.forEachSegment(func(seg segment.Segment) error {
 		reader, err := seg.Reader()
 		if err != nil {
@@ -438,6 +475,7 @@ func (b *block) segmentReadersWithRLock() ([]segment.Reader, error) {
 	}); err != nil {
 		return nil, err
 	}
+	addNames("immutable", from)
 
 	success = true
 	return readers, nil
@@ -448,13 +486,27 @@ func (b *block) segmentReadersWithRLock() ([]segment.Reader, error) {
 // the ctx is finalized to ensure the mmaps are not freed until the ctx closes. This allows the returned results to
 // reference data in the mmap without copying.
 func (b *block) QueryIter(ctx context.Context, query Query) (QueryIterator, error) {
+	return b.queryIter(ctx, query, false)
+}
+
+// ExplainQueryIter is the same as QueryIter however the returned iterator also
+// records a trace of the search of each segment.
+func (b *block) ExplainQueryIter(ctx context.Context, query Query) (QueryIterator, error) {
+	return b.queryIter(ctx, query, true)
+}
+
+func (b *block) queryIter(ctx context.Context, query Query, explain bool) (QueryIterator, error) {
 	b.RLock()
 	defer b.RUnlock()
 
 	if b.state == blockStateClosed {
 		return nil, ErrUnableToQueryBlockClosed
 	}
-	exec, err := b.newExecutorWithRLockFn()
+	newExecutorFn := b.newExecutorWithRLockFn
+	if explain {
+		newExecutorFn = b.explainExecutorWithRLock
+	}
+	exec, err := newExecutorFn()
 	if err != nil {
 		return nil, err
 	}
@@ -722,6 +774,101 @@ func (b *block) AggregateIter(ctx context.Context, aggOpts AggregateResultsOptio
 	}, nil
 }
 
+// Cardinality adds the number of series matching the query of each field
+// and term in the block to the results. It stops early, without error, once
+// the results exceed the query limits.
+func (b *block) Cardinality(
+	ctx context.Context,
+	query Query,
+	opts QueryOptions,
+	results *CardinalityResults,
+) error {
+	ctx, sp := ctx.StartTraceSpan(tracepoint.BlockCardinality)
+	defer sp.Finish()
+
+	b.RLock()
+	if b.state == blockStateClosed {
+		b.RUnlock()
+		return ErrUnableToQueryBlockClosed
+	}
+	readers, err := b.segmentReadersWithRLock()
+	b.RUnlock()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, reader := range readers {
+			b.closeAsync(reader)
+		}
+	}()
+
+	iterateOpts := fieldsAndTermsIteratorOpts{
+		// Only count the series the results allow.
+		restrictByIDFn: results.opts.FilterID,
+		iterateTerms:   true,
+		allowFn: func(field []byte) bool {
+			return !bytes.Equal(field, doc.IDReservedFieldName)
+		},
+	}
+	if !query.Query.Equal(cardinalityAllQuery) {
+		// Only count the documents matching the query.
+		iterateOpts.restrictByQuery = &query
+	}
+
+	defer results.finishBlock()
+	for _, reader := range readers {
+		if err := b.cardinalityWithReader(ctx, reader, iterateOpts, opts, results); err != nil {
+			sp.LogFields(opentracinglog.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *block) cardinalityWithReader(
+	ctx context.Context,
+	reader segment.Reader,
+	iterateOpts fieldsAndTermsIteratorOpts,
+	opts QueryOptions,
+	results *CardinalityResults,
+) error {
+	iter, err := b.newFieldsAndTermsIteratorFn(ctx, reader, iterateOpts)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	var pending int
+	for iter.Next() {
+		if opts.LimitsExceeded(results.Size(), results.TotalDocsCount()) {
+			break
+		}
+
+		field, term := iter.Current()
+		results.addTerm(field, term, iter.CurrentPostingsCount())
+
+		// Only check for cancellation and charge the docs limit once per
+		// batch to limit the overhead.
+		pending++
+		if pending < defaultAggregateResultsEntryBatchSize {
+			continue
+		}
+		if err := b.fetchDocsLimit.Inc(pending, opts.Source); err != nil {
+			return err
+		}
+		pending = 0
+		select {
+		case <-ctx.GoContext().Done():
+			return ctx.GoContext().Err()
+		default:
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	return b.fetchDocsLimit.Inc(pending, opts.Source)
+}
+
 // nolint: dupl
 func (b *block) AggregateWithIter(
 	ctx context.Context,
@@ -1038,11 +1185,19 @@ func (b *block) addResults(
 		segments        = results.Segments()
 	)
 	readThroughSegments := make([]segment.Segment, 0, len(segments))
-	for _, seg := range segments {
+	for i, seg := range segments {
 		elem := seg.Segment()
 		if immSeg, ok := elem.(segment.ImmutableSegment); ok {
 			// only wrap the immutable segments with a read through cache.
-			elem = NewReadThroughSegment(immSeg, plCaches, readThroughOpts)
+			readThroughSeg := NewReadThroughSegment(immSeg, plCaches, readThroughOpts)
+			if seg.IsPersisted() {
+				// Sealed segments are loaded again after a restart so
+				// their cached postings lists can be persisted and replayed.
+				readThroughSeg.RegisterPersistedIdentity(PersistedSegmentIdentity(
+					b.nsMD.ID().String(), int64(b.blockStart), string(volumeType),
+					i, immSeg.Size()))
+			}
+			elem = readThroughSeg
 		}
 		readThroughSegments = append(readThroughSegments, elem)
 	}
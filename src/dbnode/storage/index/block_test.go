@@ -1471,6 +1471,191 @@ func TestBlockE2EInsertQuery(t *testing.T) {
 	require.Equal(t, tracepoint.BlockQuery, spans[2].OperationName)
 }
 
+func TestBlockE2EInsertExplainQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blockSize := time.Hour
+
+	testMD := newTestNSMetadata(t)
+	now := xtime.Now()
+	blockStart := now.Truncate(blockSize)
+
+	nowNotBlockStartAligned := now.
+		Truncate(blockSize).
+		Add(time.Minute)
+
+	blk, err := NewBlock(blockStart, testMD,
+		BlockOptions{
+			ForegroundCompactorMmapDocsData: true,
+			BackgroundCompactorMmapDocsData: true,
+		},
+		namespace.NewRuntimeOptionsManager("foo"),
+		testOpts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	h1 := doc.NewMockOnIndexSeries(ctrl)
+	h1.EXPECT().OnIndexFinalize(blockStart)
+	h1.EXPECT().OnIndexSuccess(blockStart)
+
+	h2 := doc.NewMockOnIndexSeries(ctrl)
+	h2.EXPECT().OnIndexFinalize(blockStart)
+	h2.EXPECT().OnIndexSuccess(blockStart)
+
+	batch := NewWriteBatch(testWriteBatchOptionsWithBlockSize(blockSize))
+	batch.Append(WriteBatchEntry{
+		Timestamp:     nowNotBlockStartAligned,
+		OnIndexSeries: h1,
+	}, testDoc1())
+	batch.Append(WriteBatchEntry{
+		Timestamp:     nowNotBlockStartAligned,
+		OnIndexSeries: h2,
+	}, testDoc2())
+
+	res, err := b.WriteBatch(batch)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.NumSuccess)
+
+	q := idx.NewTermQuery([]byte("bar"), []byte("baz"))
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	results := NewQueryResults(nil, QueryResultsOptions{}, testOpts)
+	queryIter, err := b.ExplainQueryIter(ctx, Query{q})
+	require.NoError(t, err)
+	require.Nil(t, queryIter.SearchTraces())
+
+	err = b.QueryWithIter(ctx, QueryOptions{Explain: true}, queryIter, results,
+		time.Now().Add(time.Minute), emptyLogFields)
+	require.NoError(t, err)
+	require.Equal(t, 2, results.Size())
+
+	traces := queryIter.SearchTraces()
+	require.True(t, len(traces) > 0)
+
+	var postings int
+	for i, trace := range traces {
+		require.Equal(t, fmt.Sprintf("mutable/%d", i), trace.Segment)
+		require.False(t, trace.CacheHit)
+		require.Equal(t, 1, len(trace.Matches))
+		require.Equal(t, "term(bar,baz)", trace.Matches[0].Match)
+		postings += trace.Postings
+	}
+	require.Equal(t, 2, postings)
+
+	// Regular query iterators do not record traces.
+	queryIter, err = b.QueryIter(ctx, Query{q})
+	require.NoError(t, err)
+	results = NewQueryResults(nil, QueryResultsOptions{}, testOpts)
+	err = b.QueryWithIter(ctx, QueryOptions{}, queryIter, results,
+		time.Now().Add(time.Minute), emptyLogFields)
+	require.NoError(t, err)
+	require.Nil(t, queryIter.SearchTraces())
+}
+
+func TestBlockE2EInsertCardinality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blockSize := time.Hour
+
+	testMD := newTestNSMetadata(t)
+	now := xtime.Now()
+	blockStart := now.Truncate(blockSize)
+
+	nowNotBlockStartAligned := now.
+		Truncate(blockSize).
+		Add(time.Minute)
+
+	blk, err := NewBlock(blockStart, testMD,
+		BlockOptions{
+			ForegroundCompactorMmapDocsData: true,
+			BackgroundCompactorMmapDocsData: true,
+		},
+		namespace.NewRuntimeOptionsManager("foo"),
+		testOpts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	batch := NewWriteBatch(testWriteBatchOptionsWithBlockSize(blockSize))
+	for _, d := range []doc.Metadata{testDoc1(), testDoc2(), testDoc3()} {
+		h := doc.NewMockOnIndexSeries(ctrl)
+		h.EXPECT().OnIndexFinalize(blockStart)
+		h.EXPECT().OnIndexSuccess(blockStart)
+		batch.Append(WriteBatchEntry{
+			Timestamp:     nowNotBlockStartAligned,
+			OnIndexSeries: h,
+		}, d)
+	}
+
+	res, err := b.WriteBatch(batch)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), res.NumSuccess)
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	opts := CardinalityOptions{MetricNameTag: []byte("bar")}
+	results := NewCardinalityResults(CardinalityResultsOptions{})
+	require.NoError(t, b.Cardinality(ctx, Query{idx.NewAllQuery()}, QueryOptions{}, results))
+	require.Equal(t, CardinalityStats{
+		SeriesCountByMetricName: []CardinalityEntry{
+			{Name: "baz", Value: 2},
+			{Name: "qux", Value: 1},
+		},
+		LabelValueCountByLabelName: []CardinalityEntry{
+			{Name: "bar", Value: 2},
+			{Name: "some", Value: 2},
+		},
+		SeriesCountByLabelValuePair: []CardinalityEntry{
+			{Name: "bar=baz", Value: 2},
+			{Name: "bar=qux", Value: 1},
+			{Name: "some=more", Value: 1},
+			{Name: "some=other", Value: 1},
+		},
+	}, results.Stats(opts))
+
+	// Only the series matching the query are counted.
+	results = NewCardinalityResults(CardinalityResultsOptions{})
+	q := idx.NewTermQuery([]byte("some"), []byte("more"))
+	require.NoError(t, b.Cardinality(ctx, Query{q}, QueryOptions{}, results))
+	require.Equal(t, []CardinalityEntry{
+		{Name: "bar=baz", Value: 1},
+		{Name: "some=more", Value: 1},
+	}, results.Stats(opts).SeriesCountByLabelValuePair)
+
+	// Only the series allowed by the filter are counted, combined with the
+	// query restriction.
+	results = NewCardinalityResults(CardinalityResultsOptions{
+		FilterID: func(id []byte) bool {
+			return string(id) != string(testDoc1().ID)
+		},
+	})
+	require.NoError(t, b.Cardinality(ctx, Query{idx.NewAllQuery()}, QueryOptions{}, results))
+	require.Equal(t, []CardinalityEntry{
+		{Name: "baz", Value: 1},
+		{Name: "qux", Value: 1},
+	}, results.Stats(opts).SeriesCountByMetricName)
+
+	results = NewCardinalityResults(CardinalityResultsOptions{
+		FilterID: func(id []byte) bool {
+			return string(id) != string(testDoc2().ID)
+		},
+	})
+	require.NoError(t, b.Cardinality(ctx, Query{q}, QueryOptions{}, results))
+	require.Empty(t, results.Stats(opts).SeriesCountByLabelValuePair)
+
+	// Counting stops once the limits are exceeded.
+	results = NewCardinalityResults(CardinalityResultsOptions{})
+	require.NoError(t, b.Cardinality(ctx, Query{idx.NewAllQuery()},
+		QueryOptions{SeriesLimit: 1}, results))
+	require.Equal(t, 1, results.Size())
+}
+
 func TestBlockE2EInsertQueryLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,180 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"sort"
+)
+
+const (
+	defaultCardinalityTopN          = 10
+	defaultCardinalityMetricNameTag = "__name__"
+)
+
+// CardinalityEntry is a single named value of a cardinality statistic.
+type CardinalityEntry struct {
+	Name  string `json:"name"`
+	Value int64  `json:"value"`
+}
+
+// CardinalityStats are the cardinality statistics of the series matching
+// a query, modeled on the Prometheus TSDB status. All values are lower bounds
+// since series spanning several index blocks, or shards on several hosts,
+// cannot be told apart without merging their IDs.
+type CardinalityStats struct {
+	// SeriesCountByMetricName is the number of series of each metric name.
+	SeriesCountByMetricName []CardinalityEntry `json:"seriesCountByMetricName"`
+	// LabelValueCountByLabelName is the number of distinct values of each
+	// label.
+	LabelValueCountByLabelName []CardinalityEntry `json:"labelValueCountByLabelName"`
+	// SeriesCountByLabelValuePair is the number of series of each label
+	// value pair.
+	SeriesCountByLabelValuePair []CardinalityEntry `json:"seriesCountByLabelValuePair"`
+}
+
+type cardinalityKey struct {
+	field string
+	term  string
+}
+
+// CardinalityResultsOptions is a set of options to use for cardinality
+// results.
+type CardinalityResultsOptions struct {
+	// FilterID, if provided, restricts the series counted to those with
+	// an ID it allows.
+	// NB: This is used to only count the series of shards that are
+	// available on the DB node, series of shards that are initializing or
+	// leaving are also counted by the hosts the shards are available on.
+	FilterID func(id []byte) bool
+}
+
+// CardinalityResults accumulates the number of series of each field and
+// term across the blocks of a cardinality query. Counts are summed across
+// the segments of a block, and the maximum is taken across blocks so that
+// series present in several blocks are not counted more than once. Series
+// only present in some of the blocks are therefore not all counted, and the
+// counts are a lower bound of the number of series.
+// CardinalityResults is not safe for concurrent use.
+type CardinalityResults struct {
+	opts        CardinalityResultsOptions
+	counts      map[cardinalityKey]int64
+	blockCounts map[cardinalityKey]int64
+	docsCount   int
+}
+
+// NewCardinalityResults returns new cardinality results.
+func NewCardinalityResults(opts CardinalityResultsOptions) *CardinalityResults {
+	return &CardinalityResults{
+		opts:        opts,
+		counts:      make(map[cardinalityKey]int64),
+		blockCounts: make(map[cardinalityKey]int64),
+	}
+}
+
+// Size returns the number of field and term pairs tracked.
+func (r *CardinalityResults) Size() int {
+	return len(r.counts) + len(r.blockCounts)
+}
+
+// TotalDocsCount returns the number of terms scanned.
+func (r *CardinalityResults) TotalDocsCount() int {
+	return r.docsCount
+}
+
+func (r *CardinalityResults) addTerm(field, term []byte, count int) {
+	key := cardinalityKey{field: string(field), term: string(term)}
+	r.blockCounts[key] += int64(count)
+	r.docsCount++
+}
+
+func (r *CardinalityResults) finishBlock() {
+	for key, count := range r.blockCounts {
+		if count > r.counts[key] {
+			r.counts[key] = count
+		}
+		delete(r.blockCounts, key)
+	}
+}
+
+// Stats returns the top N entries of each cardinality statistic.
+func (r *CardinalityResults) Stats(opts CardinalityOptions) CardinalityStats {
+	r.finishBlock()
+
+	topN := opts.TopN
+	if topN <= 0 {
+		topN = defaultCardinalityTopN
+	}
+	metricNameTag := string(opts.MetricNameTag)
+	if metricNameTag == "" {
+		metricNameTag = defaultCardinalityMetricNameTag
+	}
+
+	var (
+		metricNames     []CardinalityEntry
+		labelValuePairs = make([]CardinalityEntry, 0, len(r.counts))
+		labelValues     = make(map[string]int64)
+	)
+	for key, count := range r.counts {
+		if key.field == metricNameTag {
+			metricNames = append(metricNames, CardinalityEntry{
+				Name:  key.term,
+				Value: count,
+			})
+		}
+		labelValuePairs = append(labelValuePairs, CardinalityEntry{
+			Name:  key.field + "=" + key.term,
+			Value: count,
+		})
+		labelValues[key.field]++
+	}
+
+	labelValueCounts := make([]CardinalityEntry, 0, len(labelValues))
+	for field, count := range labelValues {
+		labelValueCounts = append(labelValueCounts, CardinalityEntry{
+			Name:  field,
+			Value: count,
+		})
+	}
+
+	return CardinalityStats{
+		SeriesCountByMetricName:     TopCardinalityEntries(metricNames, topN),
+		LabelValueCountByLabelName:  TopCardinalityEntries(labelValueCounts, topN),
+		SeriesCountByLabelValuePair: TopCardinalityEntries(labelValuePairs, topN),
+	}
+}
+
+// TopCardinalityEntries sorts the entries by descending value and returns
+// the first n of them.
+func TopCardinalityEntries(entries []CardinalityEntry, n int) []CardinalityEntry {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Value != entries[j].Value {
+			return entries[i].Value > entries[j].Value
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	if n > 0 && len(entries) > n {
+		entries = entries[:n]
+	}
+	if entries == nil {
+		entries = []CardinalityEntry{}
+	}
+	return entries
+}
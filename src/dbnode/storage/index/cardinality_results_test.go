@@ -0,0 +1,67 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardinalityResultsMaxAcrossBlocks(t *testing.T) {
+	results := NewCardinalityResults(CardinalityResultsOptions{})
+
+	// Counts of the segments of a block are summed.
+	results.addTerm([]byte("__name__"), []byte("requests"), 3)
+	results.addTerm([]byte("__name__"), []byte("requests"), 2)
+	results.addTerm([]byte("pod"), []byte("a"), 5)
+	results.finishBlock()
+
+	// The max is taken across blocks.
+	results.addTerm([]byte("__name__"), []byte("requests"), 4)
+	results.addTerm([]byte("__name__"), []byte("errors"), 1)
+	results.addTerm([]byte("pod"), []byte("b"), 1)
+	require.Equal(t, 5, results.Size())
+	require.Equal(t, 6, results.TotalDocsCount())
+
+	stats := results.Stats(CardinalityOptions{TopN: 2})
+	require.Equal(t, CardinalityStats{
+		SeriesCountByMetricName: []CardinalityEntry{
+			{Name: "requests", Value: 5},
+			{Name: "errors", Value: 1},
+		},
+		LabelValueCountByLabelName: []CardinalityEntry{
+			{Name: "__name__", Value: 2},
+			{Name: "pod", Value: 2},
+		},
+		SeriesCountByLabelValuePair: []CardinalityEntry{
+			{Name: "__name__=requests", Value: 5},
+			{Name: "pod=a", Value: 5},
+		},
+	}, stats)
+}
+
+func TestCardinalityResultsEmpty(t *testing.T) {
+	stats := NewCardinalityResults(CardinalityResultsOptions{}).Stats(CardinalityOptions{})
+	require.Equal(t, []CardinalityEntry{}, stats.SeriesCountByMetricName)
+	require.Equal(t, []CardinalityEntry{}, stats.LabelValueCountByLabelName)
+	require.Equal(t, []CardinalityEntry{}, stats.SeriesCountByLabelValuePair)
+}
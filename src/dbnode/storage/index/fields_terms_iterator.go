@@ -26,6 +26,7 @@ import (
 	pilosaroaring "github.com/m3dbx/pilosa/roaring"
 
 	"github.com/m3db/m3/src/dbnode/tracepoint"
+	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/postings"
 	"github.com/m3db/m3/src/m3ninx/postings/roaring"
@@ -42,6 +43,9 @@ type fieldsAndTermsIteratorOpts struct {
 	iterateTerms    bool
 	allowFn         allowFn
 	fieldIterFn     newFieldIterFn
+	// restrictByIDFn, if set, restricts the documents counted to those
+	// with an ID it allows.
+	restrictByIDFn func(id []byte) bool
 }
 
 func (o fieldsAndTermsIteratorOpts) allow(f []byte) bool {
@@ -74,6 +78,9 @@ type fieldsAndTermsIter struct {
 		field    []byte
 		term     []byte
 		postings postings.List
+		// restrictedCount is the number of restricted documents matching
+		// the term, only set if restricting by query.
+		restrictedCount int
 	}
 
 	restrictByPostings *pilosaroaring.Bitmap
@@ -104,13 +111,42 @@ func newFieldsAndTermsIterator(
 	}
 	iter.fieldIter = fiter
 
-	if opts.restrictByQuery == nil {
-		// No need to restrict results by query.
+	if opts.restrictByQuery == nil && opts.restrictByIDFn == nil {
+		// No need to restrict results.
 		return iter, nil
 	}
 
-	// If need to restrict by query, run the query on the segment first.
-	searchQuery := opts.restrictByQuery.SearchQuery()
+	var bitmap *pilosaroaring.Bitmap
+	if opts.restrictByQuery != nil {
+		// If need to restrict by query, run the query on the segment first.
+		bitmap, err = restrictByQueryPostings(ctx, reader, *opts.restrictByQuery)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.restrictByIDFn != nil {
+		idsBitmap, err := restrictByIDPostings(reader, opts.restrictByIDFn)
+		if err != nil {
+			return nil, err
+		}
+		if bitmap == nil {
+			bitmap = idsBitmap
+		} else {
+			bitmap = bitmap.Intersect(idsBitmap)
+		}
+	}
+
+	// Hold onto the postings bitmap to intersect against on a per term basis.
+	iter.restrictByPostings = bitmap
+	return iter, nil
+}
+
+func restrictByQueryPostings(
+	ctx context.Context,
+	reader segment.Reader,
+	query Query,
+) (*pilosaroaring.Bitmap, error) {
+	searchQuery := query.SearchQuery()
 	searcher, err := searchQuery.Searcher()
 	if err != nil {
 		return nil, err
@@ -130,14 +166,52 @@ func newFieldsAndTermsIterator(
 		return nil, err
 	}
 
-	// Hold onto the postings bitmap to intersect against on a per term basis.
 	bitmap, ok := roaring.BitmapFromPostingsList(pl)
 	if !ok {
 		return nil, errUnpackBitmapFromPostingsList
 	}
+	return bitmap, nil
+}
 
-	iter.restrictByPostings = bitmap
-	return iter, nil
+// restrictByIDPostings returns the postings of the documents with an ID
+// allowed by the given function. The IDs are read from the terms of the
+// reserved ID field so that the documents themselves are not read.
+func restrictByIDPostings(
+	reader segment.Reader,
+	allowFn func(id []byte) bool,
+) (*pilosaroaring.Bitmap, error) {
+	termIter, err := reader.Terms(doc.IDReservedFieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	bitmap := pilosaroaring.NewBitmap()
+	for termIter.Next() {
+		id, pl := termIter.Current()
+		if !allowFn(id) {
+			continue
+		}
+		iter := pl.Iterator()
+		for iter.Next() {
+			bitmap.DirectAdd(uint64(iter.Current()))
+		}
+		err := iter.Err()
+		if closeErr := iter.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = termIter.Close()
+			return nil, err
+		}
+	}
+	if err := termIter.Err(); err != nil {
+		_ = termIter.Close()
+		return nil, err
+	}
+	if err := termIter.Close(); err != nil {
+		return nil, err
+	}
+	return bitmap, nil
 }
 
 func (fti *fieldsAndTermsIter) setNextField() bool {
@@ -245,6 +319,7 @@ func (fti *fieldsAndTermsIter) nextTermsIterResult() (bool, error) {
 		// counting results and also does not allocate.
 		if n := fti.restrictByPostings.IntersectionCount(bitmap); n > 0 {
 			// Matches, this is next result.
+			fti.current.restrictedCount = int(n)
 			return true, nil
 		}
 	}
@@ -275,6 +350,16 @@ func (fti *fieldsAndTermsIter) Current() (field, term []byte) {
 	return fti.current.field, fti.current.term
 }
 
+func (fti *fieldsAndTermsIter) CurrentPostingsCount() int {
+	if fti.restrictByPostings != nil {
+		return fti.current.restrictedCount
+	}
+	if fti.current.postings == nil {
+		return 0
+	}
+	return fti.current.postings.Len()
+}
+
 func (fti *fieldsAndTermsIter) Err() error {
 	return fti.err
 }
@@ -35,6 +35,7 @@ import (
 	"github.com/m3db/m3/src/m3ninx/index/segment/builder"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	"github.com/m3db/m3/src/m3ninx/search"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/context"
 	"github.com/m3db/m3/src/x/ident"
@@ -868,6 +869,20 @@ func (mr *MockBlockMockRecorder) BackgroundCompact() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackgroundCompact", reflect.TypeOf((*MockBlock)(nil).BackgroundCompact))
 }
 
+// Cardinality mocks base method.
+func (m *MockBlock) Cardinality(ctx context.Context, query Query, opts QueryOptions, results *CardinalityResults) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, query, opts, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockBlockMockRecorder) Cardinality(ctx, query, opts, results interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockBlock)(nil).Cardinality), ctx, query, opts, results)
+}
+
 // Close mocks base method.
 func (m *MockBlock) Close() error {
 	m.ctrl.T.Helper()
@@ -924,6 +939,21 @@ func (mr *MockBlockMockRecorder) EvictMutableSegments() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvictMutableSegments", reflect.TypeOf((*MockBlock)(nil).EvictMutableSegments))
 }
 
+// ExplainQueryIter mocks base method.
+func (m *MockBlock) ExplainQueryIter(ctx context.Context, query Query) (QueryIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainQueryIter", ctx, query)
+	ret0, _ := ret[0].(QueryIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainQueryIter indicates an expected call of ExplainQueryIter.
+func (mr *MockBlockMockRecorder) ExplainQueryIter(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainQueryIter", reflect.TypeOf((*MockBlock)(nil).ExplainQueryIter), ctx, query)
+}
+
 // IsOpen mocks base method.
 func (m *MockBlock) IsOpen() bool {
 	m.ctrl.T.Helper()
@@ -1289,6 +1319,20 @@ func (mr *MockQueryIteratorMockRecorder) Next(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockQueryIterator)(nil).Next), ctx)
 }
 
+// SearchTraces mocks base method.
+func (m *MockQueryIterator) SearchTraces() []search.SearchTrace {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTraces")
+	ret0, _ := ret[0].([]search.SearchTrace)
+	return ret0
+}
+
+// SearchTraces indicates an expected call of SearchTraces.
+func (mr *MockQueryIteratorMockRecorder) SearchTraces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTraces", reflect.TypeOf((*MockQueryIterator)(nil).SearchTraces))
+}
+
 // MockAggregateIterator is a mock of AggregateIterator interface.
 type MockAggregateIterator struct {
 	ctrl     *gomock.Controller
@@ -1606,6 +1650,20 @@ func (mr *MockfieldsAndTermsIteratorMockRecorder) Current() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Current", reflect.TypeOf((*MockfieldsAndTermsIterator)(nil).Current))
 }
 
+// CurrentPostingsCount mocks base method.
+func (m *MockfieldsAndTermsIterator) CurrentPostingsCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CurrentPostingsCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// CurrentPostingsCount indicates an expected call of CurrentPostingsCount.
+func (mr *MockfieldsAndTermsIteratorMockRecorder) CurrentPostingsCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentPostingsCount", reflect.TypeOf((*MockfieldsAndTermsIterator)(nil).CurrentPostingsCount))
+}
+
 // Err mocks base method.
 func (m *MockfieldsAndTermsIterator) Err() error {
 	m.ctrl.T.Helper()
@@ -61,6 +61,9 @@ const (
 // PostingsListCacheOptions is the options struct for the query cache.
 type PostingsListCacheOptions struct {
 	InstrumentOptions instrument.Options
+	// Warmup optionally persists the cache keys so the cache can be
+	// pre-warmed after a restart.
+	Warmup PostingsListCacheWarmupOptions
 }
 
 // Validate will return an error if the options are not valid.
@@ -78,6 +81,7 @@ type PostingsListCache struct {
 	size    int
 	opts    PostingsListCacheOptions
 	metrics *postingsListCacheMetrics
+	warmup  *postingsListCacheWarmup
 
 	logger *zap.Logger
 }
@@ -108,13 +112,27 @@ func NewPostingsListCache(
 		metrics: newPostingsListCacheMetrics(opts.InstrumentOptions.MetricsScope()),
 		logger:  opts.InstrumentOptions.Logger(),
 	}
+	if opts.Warmup.Enabled() {
+		plc.warmup = newPostingsListCacheWarmup(opts.Warmup,
+			opts.InstrumentOptions.MetricsScope(), plc.logger)
+	}
 
 	return plc, nil
 }
 
-// Start the background report loop and return a Closer to cleanup.
+// Start the background report loop, and the warm up loops if enabled, and
+// return a Closer to cleanup.
 func (q *PostingsListCache) Start() Closer {
-	return q.startReportLoop()
+	stopReporting := q.startReportLoop()
+	if q.warmup == nil {
+		return stopReporting
+	}
+
+	stopWarmup := q.startWarmupLoops()
+	return func() {
+		stopWarmup()
+		stopReporting()
+	}
 }
 
 // GetRegexp returns the cached results for the provided regexp query, if any.
@@ -248,6 +266,7 @@ func (q *PostingsListCache) put(
 // segment from the cache.
 func (q *PostingsListCache) PurgeSegment(segmentUUID uuid.UUID) {
 	q.lru.PurgeSegment(segmentUUID)
+	q.unregisterSegment(segmentUUID)
 }
 
 // startReportLoop starts a background process that will call Report()
@@ -0,0 +1,454 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/m3ninx/generated/proto/querypb"
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/search/query"
+	xos "github.com/m3db/m3/src/x/os"
+
+	"github.com/pborman/uuid"
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	postingsListCacheWarmupFileVersion = 1
+
+	defaultPostingsListCacheWarmupPersistInterval = 5 * time.Minute
+	defaultPostingsListCacheWarmupMaxKeys         = 8192
+	defaultPostingsListCacheWarmupBudget          = 2 * time.Minute
+	postingsListCacheWarmupQueueSize              = 4096
+	postingsListCacheWarmupFileMode               = 0644
+	postingsListCacheWarmupDirMode                = 0755
+)
+
+var errPostingsListCacheWarmupFileVersion = errors.New(
+	"unsupported postings list cache warm up file version")
+
+// PostingsListCacheWarmupOptions configures persisting the postings list
+// cache keys to disk so that the cache can be pre-warmed after a restart.
+type PostingsListCacheWarmupOptions struct {
+	// FilePath is the file the cache keys are persisted to and replayed
+	// from, warm up is disabled if it is empty.
+	FilePath string
+	// PersistInterval is how often the cache keys are persisted.
+	PersistInterval time.Duration
+	// MaxKeys is the maximum number of most recently used keys persisted.
+	MaxKeys int
+	// Budget is the maximum total time spent replaying keys after startup.
+	Budget time.Duration
+}
+
+// Enabled returns whether the cache keys should be persisted and replayed.
+func (o PostingsListCacheWarmupOptions) Enabled() bool {
+	return o.FilePath != ""
+}
+
+func (o PostingsListCacheWarmupOptions) withDefaults() PostingsListCacheWarmupOptions {
+	if o.PersistInterval <= 0 {
+		o.PersistInterval = defaultPostingsListCacheWarmupPersistInterval
+	}
+	if o.MaxKeys <= 0 {
+		o.MaxKeys = defaultPostingsListCacheWarmupMaxKeys
+	}
+	if o.Budget <= 0 {
+		o.Budget = defaultPostingsListCacheWarmupBudget
+	}
+	return o
+}
+
+// PersistedSegmentIdentity returns an identity for a sealed segment that is
+// stable across restarts, used to match persisted cache keys back to the
+// segment once it is loaded again.
+func PersistedSegmentIdentity(
+	namespace string,
+	blockStart int64,
+	volumeType string,
+	index int,
+	size int64,
+) string {
+	return fmt.Sprintf("%s:%d:%s:%d:%d", namespace, blockStart, volumeType, index, size)
+}
+
+type postingsListCacheWarmupFile struct {
+	Version int                            `json:"version"`
+	Entries []postingsListCacheWarmupEntry `json:"entries"`
+}
+
+type postingsListCacheWarmupEntry struct {
+	Segment     string      `json:"segment"`
+	Field       string      `json:"field"`
+	Pattern     string      `json:"pattern,omitempty"`
+	PatternType PatternType `json:"patternType"`
+	SearchQuery []byte      `json:"searchQuery,omitempty"`
+}
+
+type postingsListCacheWarmupTask struct {
+	segment *ReadThroughSegment
+	entries []postingsListCacheWarmupEntry
+}
+
+type postingsListCacheWarmup struct {
+	sync.Mutex
+
+	opts PostingsListCacheWarmupOptions
+	// segments maps the UUID of registered segments to their persisted
+	// segment identity.
+	segments map[uuid.Array]string
+	// pending holds the keys read from disk that are yet to be replayed,
+	// grouped by persisted segment identity.
+	pending map[string][]postingsListCacheWarmupEntry
+	tasks   chan postingsListCacheWarmupTask
+	// spent is only accessed by the warm up worker.
+	spent time.Duration
+
+	metrics postingsListCacheWarmupMetrics
+}
+
+type postingsListCacheWarmupMetrics struct {
+	loaded        tally.Counter
+	loadErrors    tally.Counter
+	replayed      tally.Counter
+	replayErrors  tally.Counter
+	skipped       tally.Counter
+	replayLatency tally.Timer
+	persisted     tally.Gauge
+	persistErrors tally.Counter
+}
+
+func newPostingsListCacheWarmupMetrics(scope tally.Scope) postingsListCacheWarmupMetrics {
+	return postingsListCacheWarmupMetrics{
+		loaded:        scope.Counter("loaded"),
+		loadErrors:    scope.Counter("load_errors"),
+		replayed:      scope.Counter("replayed"),
+		replayErrors:  scope.Counter("replay_errors"),
+		skipped:       scope.Counter("skipped"),
+		replayLatency: scope.Timer("replay_latency"),
+		persisted:     scope.Gauge("persisted"),
+		persistErrors: scope.Counter("persist_errors"),
+	}
+}
+
+func newPostingsListCacheWarmup(
+	opts PostingsListCacheWarmupOptions,
+	scope tally.Scope,
+	logger *zap.Logger,
+) *postingsListCacheWarmup {
+	w := &postingsListCacheWarmup{
+		opts:     opts.withDefaults(),
+		segments: make(map[uuid.Array]string),
+		pending:  make(map[string][]postingsListCacheWarmupEntry),
+		tasks:    make(chan postingsListCacheWarmupTask, postingsListCacheWarmupQueueSize),
+		metrics:  newPostingsListCacheWarmupMetrics(scope.SubScope("warmup")),
+	}
+
+	entries, err := readPostingsListCacheWarmupFile(w.opts.FilePath)
+	if err != nil {
+		w.metrics.loadErrors.Inc(1)
+		logger.Warn("could not read postings list cache warm up file",
+			zap.String("path", w.opts.FilePath), zap.Error(err))
+		return w
+	}
+	for _, e := range entries {
+		w.pending[e.Segment] = append(w.pending[e.Segment], e)
+	}
+	w.metrics.loaded.Inc(int64(len(entries)))
+	return w
+}
+
+func readPostingsListCacheWarmupFile(filePath string) ([]postingsListCacheWarmupEntry, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file postingsListCacheWarmupFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if file.Version != postingsListCacheWarmupFileVersion {
+		return nil, errPostingsListCacheWarmupFileVersion
+	}
+	return file.Entries, nil
+}
+
+func writePostingsListCacheWarmupFile(
+	filePath string,
+	entries []postingsListCacheWarmupEntry,
+) error {
+	data, err := json.Marshal(postingsListCacheWarmupFile{
+		Version: postingsListCacheWarmupFileVersion,
+		Entries: entries,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), postingsListCacheWarmupDirMode); err != nil {
+		return err
+	}
+	tmpPath := filePath + ".tmp"
+	if err := xos.WriteFileSync(tmpPath, data, postingsListCacheWarmupFileMode); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// registerSegment records the persisted identity of a segment and queues
+// any keys persisted for it before the restart to be replayed.
+func (q *PostingsListCache) registerSegment(seg *ReadThroughSegment, identity string) {
+	w := q.warmup
+	if w == nil {
+		return
+	}
+
+	w.Lock()
+	w.segments[seg.uuid.Array()] = identity
+	entries, ok := w.pending[identity]
+	delete(w.pending, identity)
+	w.Unlock()
+
+	if !ok {
+		return
+	}
+
+	select {
+	case w.tasks <- postingsListCacheWarmupTask{segment: seg, entries: entries}:
+	default:
+		w.metrics.skipped.Inc(int64(len(entries)))
+	}
+}
+
+func (q *PostingsListCache) unregisterSegment(segmentUUID uuid.UUID) {
+	w := q.warmup
+	if w == nil {
+		return
+	}
+
+	w.Lock()
+	delete(w.segments, segmentUUID.Array())
+	w.Unlock()
+}
+
+// startWarmupLoops starts the background processes that replay persisted
+// keys and periodically persist the current keys, and returns a function
+// that ends them after persisting the keys a final time.
+func (q *PostingsListCache) startWarmupLoops() Closer {
+	var (
+		w      = q.warmup
+		doneCh = make(chan struct{})
+		wg     sync.WaitGroup
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-doneCh:
+				return
+			case task := <-w.tasks:
+				q.replayWarmupTask(task)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(w.opts.PersistInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-doneCh:
+				return
+			case <-ticker.C:
+				q.persistWarmupKeysAndLog()
+			}
+		}
+	}()
+
+	return func() {
+		close(doneCh)
+		wg.Wait()
+		q.persistWarmupKeysAndLog()
+	}
+}
+
+func (q *PostingsListCache) replayWarmupTask(task postingsListCacheWarmupTask) {
+	w := q.warmup
+	if w.spent >= w.opts.Budget {
+		w.metrics.skipped.Inc(int64(len(task.entries)))
+		return
+	}
+
+	reader, err := task.segment.Reader()
+	if err != nil {
+		// Segment was closed before its keys could be replayed.
+		w.metrics.skipped.Inc(int64(len(task.entries)))
+		return
+	}
+	defer reader.Close()
+
+	rtReader, ok := reader.(*readThroughSegmentReader)
+	if !ok {
+		w.metrics.skipped.Inc(int64(len(task.entries)))
+		return
+	}
+
+	for i, e := range task.entries {
+		if w.spent >= w.opts.Budget {
+			w.metrics.skipped.Inc(int64(len(task.entries) - i))
+			return
+		}
+
+		start := time.Now()
+		err := replayPostingsListCacheWarmupEntry(rtReader, e)
+		took := time.Since(start)
+		w.spent += took
+		w.metrics.replayLatency.Record(took)
+		if err != nil {
+			w.metrics.replayErrors.Inc(1)
+			q.logger.Debug("could not replay postings list cache key",
+				zap.String("segment", e.Segment),
+				zap.String("field", e.Field),
+				zap.String("patternType", string(e.PatternType)),
+				zap.Error(err))
+			continue
+		}
+		w.metrics.replayed.Inc(1)
+	}
+}
+
+func replayPostingsListCacheWarmupEntry(
+	r *readThroughSegmentReader,
+	e postingsListCacheWarmupEntry,
+) error {
+	switch e.PatternType {
+	case PatternTypeTerm:
+		_, err := r.MatchTerm([]byte(e.Field), []byte(e.Pattern))
+		return err
+	case PatternTypeField:
+		_, err := r.MatchField([]byte(e.Field))
+		return err
+	case PatternTypeRegexp:
+		compiled, err := index.CompileRegex([]byte(e.Pattern))
+		if err != nil {
+			return err
+		}
+		_, err = r.MatchRegexp([]byte(e.Field), compiled)
+		return err
+	case PatternTypeSearch:
+		var pb querypb.Query
+		if err := pb.Unmarshal(e.SearchQuery); err != nil {
+			return err
+		}
+		q, err := query.UnmarshalProto(&pb)
+		if err != nil {
+			return err
+		}
+		searcher, err := q.Searcher()
+		if err != nil {
+			return err
+		}
+		_, err = r.Search(q, searcher)
+		return err
+	default:
+		return fmt.Errorf("unknown pattern type: %s", e.PatternType)
+	}
+}
+
+func (q *PostingsListCache) persistWarmupKeysAndLog() {
+	if err := q.PersistWarmupKeys(); err != nil {
+		q.warmup.metrics.persistErrors.Inc(1)
+		q.logger.Warn("could not persist postings list cache keys",
+			zap.String("path", q.warmup.opts.FilePath), zap.Error(err))
+	}
+}
+
+// PersistWarmupKeys writes the most recently used keys of registered
+// segments to the warm up file, it is a no-op if warm up is disabled.
+func (q *PostingsListCache) PersistWarmupKeys() error {
+	w := q.warmup
+	if w == nil {
+		return nil
+	}
+
+	w.Lock()
+	segments := make(map[uuid.Array]string, len(w.segments))
+	for k, v := range w.segments {
+		segments[k] = v
+	}
+	w.Unlock()
+
+	var (
+		maxPerShard = (w.opts.MaxKeys + len(q.lru.shards) - 1) / len(q.lru.shards)
+		entries     = make([]postingsListCacheWarmupEntry, 0, w.opts.MaxKeys)
+	)
+	for _, shard := range q.lru.shards {
+		shard.RLock()
+		n := 0
+		// Walk from the front of the evict list to keep the most
+		// recently used keys.
+		for elem := shard.evictList.Front(); elem != nil && n < maxPerShard; elem = elem.Next() {
+			ent := elem.Value.(*entry)
+			identity, ok := segments[ent.uuid.Array()]
+			if !ok {
+				continue
+			}
+
+			warmupEntry := postingsListCacheWarmupEntry{
+				Segment:     identity,
+				Field:       ent.key.Field,
+				Pattern:     ent.key.Pattern,
+				PatternType: ent.key.PatternType,
+			}
+			if sq := ent.cachedPostings.searchQuery; sq != nil {
+				data, err := sq.Marshal()
+				if err != nil {
+					continue
+				}
+				warmupEntry.SearchQuery = data
+			}
+			entries = append(entries, warmupEntry)
+			n++
+		}
+		shard.RUnlock()
+	}
+
+	if err := writePostingsListCacheWarmupFile(w.opts.FilePath, entries); err != nil {
+		return err
+	}
+	w.metrics.persisted.Update(float64(len(entries)))
+	return nil
+}
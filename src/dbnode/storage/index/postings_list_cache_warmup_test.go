@@ -0,0 +1,181 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
+	"github.com/m3db/m3/src/m3ninx/postings/roaring"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWarmupPostingsListCache(
+	t *testing.T,
+	filePath string,
+	budget time.Duration,
+) *PostingsListCache {
+	cache, err := NewPostingsListCache(100, PostingsListCacheOptions{
+		InstrumentOptions: instrument.NewOptions(),
+		Warmup: PostingsListCacheWarmupOptions{
+			FilePath: filePath,
+			Budget:   budget,
+		},
+	})
+	require.NoError(t, err)
+	return cache
+}
+
+func TestPostingsListCacheWarmupPersistAndReplay(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		filePath = filepath.Join(t.TempDir(), "warmup.json")
+		identity = PersistedSegmentIdentity("ns", 0, "default", 0, 1024)
+		field    = []byte("city")
+		term     = []byte("nyc")
+		pl       = roaring.NewPostingsList()
+	)
+	require.NoError(t, pl.Insert(1))
+	compiledRegex, err := index.CompileRegex([]byte("ny.*"))
+	require.NoError(t, err)
+
+	// Populate the cache before the restart.
+	seg := fst.NewMockSegment(ctrl)
+	reader := segment.NewMockReader(ctrl)
+	seg.EXPECT().Reader().Return(reader, nil)
+	reader.EXPECT().MatchTerm(field, term).Return(pl, nil)
+	reader.EXPECT().MatchRegexp(field, gomock.Any()).Return(pl, nil)
+
+	cache := newTestWarmupPostingsListCache(t, filePath, time.Minute)
+	readThroughSeg := NewReadThroughSegment(seg,
+		testReadThroughSegmentCaches(cache), defaultReadThroughSegmentOptions)
+	readThroughSeg.RegisterPersistedIdentity(identity)
+
+	readThrough, err := readThroughSeg.Reader()
+	require.NoError(t, err)
+	_, err = readThrough.MatchTerm(field, term)
+	require.NoError(t, err)
+	_, err = readThrough.MatchRegexp(field, compiledRegex)
+	require.NoError(t, err)
+
+	require.NoError(t, cache.PersistWarmupKeys())
+
+	// Restart with an empty cache and replay the persisted keys against the
+	// same segment once it is loaded again.
+	restartedSeg := fst.NewMockSegment(ctrl)
+	restartedReader := segment.NewMockReader(ctrl)
+	restartedSeg.EXPECT().Reader().Return(restartedReader, nil)
+	restartedReader.EXPECT().MatchTerm(field, term).Return(pl, nil)
+	restartedReader.EXPECT().MatchRegexp(field, gomock.Any()).Return(pl, nil)
+	restartedReader.EXPECT().Close().Return(nil)
+
+	restarted := newTestWarmupPostingsListCache(t, filePath, time.Minute)
+	stop := restarted.Start()
+	defer stop()
+
+	restartedReadThroughSeg := NewReadThroughSegment(restartedSeg,
+		testReadThroughSegmentCaches(restarted), defaultReadThroughSegmentOptions)
+	restartedReadThroughSeg.RegisterPersistedIdentity(identity)
+
+	segUUID := restartedReadThroughSeg.uuid
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		_, termOK := restarted.GetTerm(segUUID, string(field), string(term))
+		_, regexpOK := restarted.GetRegexp(segUUID, string(field),
+			compiledRegex.FSTSyntax.String())
+		if termOK && regexpOK {
+			break
+		}
+		require.True(t, time.Now().Before(deadline), "cache was not warmed")
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPostingsListCacheWarmupBudgetExhausted(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		filePath = filepath.Join(t.TempDir(), "warmup.json")
+		identity = PersistedSegmentIdentity("ns", 0, "default", 0, 1024)
+	)
+	require.NoError(t, writePostingsListCacheWarmupFile(filePath,
+		[]postingsListCacheWarmupEntry{
+			{
+				Segment:     identity,
+				Field:       "city",
+				Pattern:     "nyc",
+				PatternType: PatternTypeTerm,
+			},
+		}))
+
+	// The segment must not be read once the budget has been spent.
+	seg := fst.NewMockSegment(ctrl)
+
+	cache := newTestWarmupPostingsListCache(t, filePath, time.Second)
+	cache.warmup.spent = time.Second
+
+	readThroughSeg := NewReadThroughSegment(seg,
+		testReadThroughSegmentCaches(cache), defaultReadThroughSegmentOptions)
+	readThroughSeg.RegisterPersistedIdentity(identity)
+
+	require.Len(t, cache.warmup.tasks, 1)
+	cache.replayWarmupTask(<-cache.warmup.tasks)
+
+	_, ok := cache.GetTerm(readThroughSeg.uuid, "city", "nyc")
+	require.False(t, ok)
+}
+
+func TestPostingsListCacheWarmupPurgeUnregistersSegment(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		filePath = filepath.Join(t.TempDir(), "warmup.json")
+		pl       = roaring.NewPostingsList()
+	)
+
+	seg := fst.NewMockSegment(ctrl)
+	seg.EXPECT().Close().Return(nil)
+
+	cache := newTestWarmupPostingsListCache(t, filePath, time.Minute)
+	readThroughSeg := NewReadThroughSegment(seg,
+		testReadThroughSegmentCaches(cache), defaultReadThroughSegmentOptions)
+	readThroughSeg.RegisterPersistedIdentity(
+		PersistedSegmentIdentity("ns", 0, "default", 0, 1024))
+	cache.PutTerm(readThroughSeg.uuid, "city", "nyc", pl)
+	require.NoError(t, readThroughSeg.Close())
+
+	require.NoError(t, cache.PersistWarmupKeys())
+	entries, err := readPostingsListCacheWarmupFile(filePath)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
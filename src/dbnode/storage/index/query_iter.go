@@ -22,6 +22,7 @@ package index
 
 import (
 	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/search"
 	"github.com/m3db/m3/src/x/context"
 )
 
@@ -73,3 +74,11 @@ func (q *queryIter) Counts() (series, docs int) {
 func (q *queryIter) Current() doc.Document {
 	return q.docIter.Current()
 }
+
+func (q *queryIter) SearchTraces() []search.SearchTrace {
+	tracer, ok := q.docIter.(search.SearchTracer)
+	if !ok {
+		return nil
+	}
+	return tracer.SearchTraces()
+}
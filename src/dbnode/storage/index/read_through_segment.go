@@ -139,6 +139,18 @@ func (r *ReadThroughSegment) Close() error {
 	return r.segment.Close()
 }
 
+// RegisterPersistedIdentity associates the segment with an identity that is
+// stable across restarts so that its cached postings lists can be persisted
+// and used to pre-warm the caches on the next startup.
+func (r *ReadThroughSegment) RegisterPersistedIdentity(identity string) {
+	if cache := r.caches.SegmentPostingsListCache; cache != nil {
+		cache.registerSegment(r, identity)
+	}
+	if cache := r.caches.SearchPostingsListCache; cache != nil {
+		cache.registerSegment(r, identity)
+	}
+}
+
 // FieldsIterable is a pass through call to the segment, since there's no
 // postings lists to cache for queries.
 func (r *ReadThroughSegment) FieldsIterable() segment.FieldsIterable {
@@ -322,6 +334,15 @@ func (s *readThroughSegmentReader) MatchField(field []byte) (postings.List, erro
 	return pl, err
 }
 
+// MatchNumericRange is a pass through call, the underlying segment caches
+// the sorted numeric terms needed to answer it.
+func (s *readThroughSegmentReader) MatchNumericRange(
+	field []byte,
+	nr index.NumericRange,
+) (postings.List, error) {
+	return s.reader.MatchNumericRange(field, nr)
+}
+
 // MatchAll is a pass through call, since there's no postings list to cache.
 // NB(r): The postings list returned by match all is just an iterator
 // from zero to the maximum document number indexed by the segment and as such
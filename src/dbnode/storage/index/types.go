@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/dbnode/storage/limits"
@@ -35,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/m3ninx/index/segment/builder"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	"github.com/m3db/m3/src/m3ninx/search"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/context"
 	"github.com/m3db/m3/src/x/ident"
@@ -94,6 +96,25 @@ type QueryOptions struct {
 	IterateEqualTimestampStrategy *encoding.IterateEqualTimestampStrategy
 	// Source is an optional query source.
 	Source []byte
+	// Resolution is an optional hint of the coarsest resolution the caller
+	// needs, allowing blocks compacted into rollups to be read instead of
+	// the raw data.
+	Resolution time.Duration
+	// RollupAggregation is the aggregation of the rollups read when the
+	// resolution allows, it should fit the function applied to the series.
+	RollupAggregation rollup.Aggregation
+	// Explain requests a trace of the index search performed for each block
+	// and segment be returned with the results.
+	Explain bool
+	// PageSize is an optional max number of series returned by a single
+	// fetch, when set the series are returned in ID order and the remaining
+	// series can be fetched by passing the returned page token as PageToken.
+	PageSize int
+	// PageToken is the optional opaque token returned by a previous fetch of
+	// the same query, only series ordered after the previous page are
+	// returned. Nodes resume from the matched series they hold for the query
+	// and only query the index again once those have been evicted.
+	PageToken []byte
 }
 
 // IterationOptions enables users to specify iteration preferences.
@@ -113,6 +134,16 @@ type AggregationOptions struct {
 	Type AggregationType
 }
 
+// CardinalityOptions enables users to specify constraints on a cardinality
+// query.
+type CardinalityOptions struct {
+	QueryOptions
+	// TopN is the number of entries returned for each statistic.
+	TopN int
+	// MetricNameTag is the tag holding the metric name.
+	MetricNameTag []byte
+}
+
 // QueryResult is the collection of results for a query.
 type QueryResult struct {
 	// Results are index query results.
@@ -121,6 +152,28 @@ type QueryResult struct {
 	Exhaustive bool
 	// Waited is a count of the times a query has waited for permits.
 	Waited int
+	// Explain is the trace of the query, only set if requested.
+	Explain *QueryExplain
+}
+
+// QueryExplain describes how a query was executed against the index.
+type QueryExplain struct {
+	// Host is the host that executed the query, set by the client.
+	Host string `json:"host,omitempty"`
+	// Blocks are the per block traces of the query.
+	Blocks []BlockExplain `json:"blocks"`
+}
+
+// BlockExplain describes how a query was executed against an index block.
+type BlockExplain struct {
+	// BlockStart is the start of the block.
+	BlockStart xtime.UnixNano `json:"blockStart"`
+	// WaitTime is the time spent waiting for permits to query the block.
+	WaitTime time.Duration `json:"waitTime"`
+	// ProcessingTime is the time spent processing the block results.
+	ProcessingTime time.Duration `json:"processingTime"`
+	// Segments are the per segment traces of the search.
+	Segments []search.SearchTrace `json:"segments"`
 }
 
 // AggregateQueryResult is the collection of results for an aggregate query.
@@ -133,6 +186,14 @@ type AggregateQueryResult struct {
 	Waited int
 }
 
+// CardinalityQueryResult is the result of a cardinality query.
+type CardinalityQueryResult struct {
+	// Stats are the cardinality statistics of the matched series.
+	Stats CardinalityStats
+	// Exhaustive indicates that the query was exhaustive.
+	Exhaustive bool
+}
+
 // BaseResults is a collection of basic results for a generic query, it is
 // synchronized when access to the results set is used as documented by the
 // methods.
@@ -373,6 +434,10 @@ type Block interface {
 	// QueryIter returns a new QueryIterator for the query.
 	QueryIter(ctx context.Context, query Query) (QueryIterator, error)
 
+	// ExplainQueryIter returns a new QueryIterator for the query which
+	// records a trace of the search of each segment.
+	ExplainQueryIter(ctx context.Context, query Query) (QueryIterator, error)
+
 	// AggregateWithIter aggregates N known tag names/values from the iterator.
 	AggregateWithIter(
 		ctx context.Context,
@@ -386,6 +451,15 @@ type Block interface {
 	// AggregateIter returns a new AggregatorIterator.
 	AggregateIter(ctx context.Context, aggOpts AggregateResultsOptions) (AggregateIterator, error)
 
+	// Cardinality adds the number of series matching the query of each
+	// field and term in the block to the results.
+	Cardinality(
+		ctx context.Context,
+		query Query,
+		opts QueryOptions,
+		results *CardinalityResults,
+	) error
+
 	// AddResults adds bootstrap results to the block.
 	AddResults(resultsByVolumeType result.IndexBlockByVolumeType) error
 
@@ -912,6 +986,10 @@ type QueryIterator interface {
 
 	// Current returns the current (field, term).
 	Current() doc.Document
+
+	// SearchTraces returns the per segment search traces, only set for
+	// iterators returned by ExplainQueryIter.
+	SearchTraces() []search.SearchTrace
 }
 
 // AggregateIterator iterates through the (field,term)s for a block.
@@ -957,6 +1035,10 @@ type fieldsAndTermsIterator interface {
 	// NB: the element returned is only valid until the subsequent call to Next().
 	Current() (field, term []byte)
 
+	// CurrentPostingsCount returns the number of documents matching the
+	// current term, restricted to the documents matching the query if any.
+	CurrentPostingsCount() int
+
 	// Err returns any errors encountered during iteration.
 	Err() error
 
@@ -26,7 +26,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/sharding"
 	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	idxconvert "github.com/m3db/m3/src/dbnode/storage/index/convert"
@@ -440,3 +442,53 @@ func TestNamespaceIndexInsertAggregateQuery(t *testing.T) {
 	require.Equal(t, 1, vMap.Len())
 	assert.True(t, vMap.Contains(ident.StringID("value")))
 }
+
+func TestNamespaceIndexInsertCardinalityQueryInitializingShard(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+	defer leaktest.CheckTimeout(t, 2*time.Second)()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	now := xtime.Now()
+	idx := setupIndex(t, ctrl, now, true)
+	defer idx.Close()
+
+	query := func() index.CardinalityStats {
+		res, err := idx.Cardinality(ctx, index.Query{Query: m3ninxidx.NewAllQuery()},
+			index.CardinalityOptions{
+				QueryOptions: index.QueryOptions{
+					StartInclusive: now.Add(-1 * time.Minute),
+					EndExclusive:   now.Add(1 * time.Minute),
+				},
+				MetricNameTag: []byte("name"),
+			},
+		)
+		require.NoError(t, err)
+		require.True(t, res.Exhaustive)
+		return res.Stats
+	}
+
+	// The series is counted while its shard is available.
+	assert.Equal(t, []index.CardinalityEntry{
+		{Name: "value", Value: 1},
+	}, query().SeriesCountByMetricName)
+
+	// The series is not counted once its shard is initializing on the node,
+	// it is counted by the replicas the shard is available on.
+	seriesShard := testShardSet.Lookup(ident.StringID("foo"))
+	shards := sharding.NewShards([]uint32{seriesShard}, shard.Initializing)
+	for _, id := range testShardSet.AllIDs() {
+		if id != seriesShard {
+			shards = append(shards, sharding.NewShards([]uint32{id}, shard.Available)...)
+		}
+	}
+	shardSet, err := sharding.NewShardSet(shards, testShardSet.HashFn())
+	require.NoError(t, err)
+	idx.AssignShardSet(shardSet)
+
+	stats := query()
+	assert.Empty(t, stats.SeriesCountByMetricName)
+	assert.Empty(t, stats.SeriesCountByLabelValuePair)
+}
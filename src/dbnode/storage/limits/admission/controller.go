@@ -0,0 +1,150 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package admission
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uber-go/tally"
+)
+
+type controllerMetrics struct {
+	admitted tally.Counter
+	delayed  tally.Counter
+	canceled tally.Counter
+	delay    tally.Timer
+	pressure tally.Gauge
+	rejected map[string]tally.Counter
+}
+
+func newControllerMetrics(scope tally.Scope, signals []Signal) controllerMetrics {
+	rejected := make(map[string]tally.Counter, len(signals))
+	for _, signal := range signals {
+		rejected[signal.Name()] = scope.Tagged(map[string]string{
+			"signal": signal.Name(),
+		}).Counter("rejected")
+	}
+	return controllerMetrics{
+		admitted: scope.Counter("admitted"),
+		delayed:  scope.Counter("delayed"),
+		canceled: scope.Counter("canceled"),
+		delay:    scope.Timer("delay"),
+		pressure: scope.Gauge("pressure"),
+		rejected: rejected,
+	}
+}
+
+type controller struct {
+	signals         []Signal
+	delayThreshold  float64
+	rejectThreshold float64
+	maxDelay        time.Duration
+	metrics         controllerMetrics
+}
+
+var _ Controller = (*controller)(nil)
+
+// NewController returns a new write admission controller that admits writes
+// based on the highest pressure reported by the given signals.
+func NewController(opts Options, signals ...Signal) (Controller, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	scope := opts.InstrumentOptions().MetricsScope().SubScope("write-admission")
+	return &controller{
+		signals:         signals,
+		delayThreshold:  opts.DelayThreshold(),
+		rejectThreshold: opts.RejectThreshold(),
+		maxDelay:        opts.MaxDelay(),
+		metrics:         newControllerMetrics(scope, signals),
+	}, nil
+}
+
+func (c *controller) Admit(ctx context.Context) error {
+	pressure, signal := c.maxPressure()
+	c.metrics.pressure.Update(pressure)
+
+	if pressure < c.delayThreshold {
+		c.metrics.admitted.Inc(1)
+		return nil
+	}
+
+	if pressure >= c.rejectThreshold {
+		c.metrics.rejected[signal.Name()].Inc(1)
+		return NewOverloadedError(fmt.Sprintf(
+			"write rejected: %s pressure %.2f exceeds threshold %.2f",
+			signal.Name(), pressure, c.rejectThreshold))
+	}
+
+	// Delay proportionally to how far the pressure is between the
+	// delay and reject thresholds.
+	ratio := (pressure - c.delayThreshold) / (c.rejectThreshold - c.delayThreshold)
+	delay := time.Duration(ratio * float64(c.maxDelay))
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		c.metrics.canceled.Inc(1)
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	c.metrics.delayed.Inc(1)
+	c.metrics.delay.Record(delay)
+	return nil
+}
+
+func (c *controller) Pressure() float64 {
+	pressure, _ := c.maxPressure()
+	return pressure
+}
+
+func (c *controller) maxPressure() (float64, Signal) {
+	var (
+		max    float64
+		signal Signal
+	)
+	for _, s := range c.signals {
+		if p := s.Pressure(); signal == nil || p > max {
+			max, signal = p, s
+		}
+	}
+	return max, signal
+}
+
+type noopController struct{}
+
+// NewNoopController returns a write admission controller that admits all writes.
+func NewNoopController() Controller {
+	return noopController{}
+}
+
+func (noopController) Admit(context.Context) error {
+	return nil
+}
+
+func (noopController) Pressure() float64 {
+	return 0
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+func newTestController(
+	t *testing.T,
+	scope tally.Scope,
+	signals ...Signal,
+) Controller {
+	opts := NewOptions().
+		SetEnabled(true).
+		SetDelayThreshold(0.5).
+		SetRejectThreshold(0.9).
+		SetMaxDelay(10 * time.Millisecond).
+		SetInstrumentOptions(instrument.NewOptions().SetMetricsScope(scope))
+	c, err := NewController(opts, signals...)
+	require.NoError(t, err)
+	return c
+}
+
+func newTestSignal(name string, pressure *float64) Signal {
+	return NewRatioSignal(name, func() float64 { return *pressure }, 1)
+}
+
+func TestControllerAdmit(t *testing.T) {
+	var (
+		scope   = tally.NewTestScope("", nil)
+		queue   = 0.1
+		backlog = 0.2
+		c       = newTestController(t, scope,
+			newTestSignal("queue", &queue), newTestSignal("backlog", &backlog))
+		ctx = context.Background()
+	)
+
+	require.NoError(t, c.Admit(ctx))
+	require.Equal(t, 0.2, c.Pressure())
+
+	backlog = 0.7
+	require.NoError(t, c.Admit(ctx))
+
+	queue = 0.95
+	err := c.Admit(ctx)
+	require.Error(t, err)
+	require.True(t, IsOverloadedError(err))
+	require.Contains(t, err.Error(), "queue")
+
+	snapshot := scope.Snapshot()
+	require.Equal(t, int64(1), snapshot.Counters()["write-admission.admitted+"].Value())
+	require.Equal(t, int64(1), snapshot.Counters()["write-admission.delayed+"].Value())
+	require.Equal(t, int64(1),
+		snapshot.Counters()["write-admission.rejected+signal=queue"].Value())
+	require.Equal(t, int64(0),
+		snapshot.Counters()["write-admission.rejected+signal=backlog"].Value())
+}
+
+func TestControllerAdmitCanceled(t *testing.T) {
+	pressure := 0.89
+	opts := NewOptions().SetMaxDelay(time.Minute)
+	c, err := NewController(opts, newTestSignal("queue", &pressure))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Equal(t, context.Canceled, c.Admit(ctx))
+}
+
+func TestControllerNoSignals(t *testing.T) {
+	c := newTestController(t, tally.NoopScope)
+	require.NoError(t, c.Admit(context.Background()))
+	require.Equal(t, float64(0), c.Pressure())
+}
+
+func TestNewControllerInvalidOptions(t *testing.T) {
+	_, err := NewController(NewOptions().SetRejectThreshold(0.1))
+	require.Equal(t, errInvalidRejectThreshold, err)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package admission
+
+import xerrors "github.com/m3db/m3/src/x/errors"
+
+type overloadedError struct {
+	msg string
+}
+
+// NewOverloadedError creates an error raised when a write is rejected
+// because the node is overloaded.
+func NewOverloadedError(msg string) error {
+	return &overloadedError{
+		msg: msg,
+	}
+}
+
+func (err *overloadedError) Error() string {
+	return err.msg
+}
+
+// IsOverloadedError returns true if the error is an overloaded error.
+func IsOverloadedError(err error) bool {
+	//nolint:errorlint
+	for err != nil {
+		if _, ok := err.(*overloadedError); ok {
+			return true
+		}
+		err = xerrors.InnerError(err)
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package admission
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultDelayThreshold     = 0.7
+	defaultRejectThreshold    = 0.9
+	defaultMaxDelay           = 50 * time.Millisecond
+	defaultHeapSampleInterval = time.Second
+	defaultFlushBacklogLimit  = 8
+)
+
+var (
+	errInvalidDelayThreshold     = errors.New("delay threshold must be positive")
+	errInvalidRejectThreshold    = errors.New("reject threshold must not be less than the delay threshold")
+	errInvalidMaxDelay           = errors.New("max delay must not be negative")
+	errInvalidHeapSampleInterval = errors.New("heap sample interval must be positive")
+	errInvalidFlushBacklogLimit  = errors.New("flush backlog limit must not be negative")
+)
+
+type options struct {
+	enabled            bool
+	delayThreshold     float64
+	rejectThreshold    float64
+	maxDelay           time.Duration
+	heapLimitBytes     uint64
+	heapSampleInterval time.Duration
+	flushBacklogLimit  int
+	clockOpts          clock.Options
+	iOpts              instrument.Options
+}
+
+// NewOptions creates new write admission options, write admission is
+// disabled by default.
+func NewOptions() Options {
+	return &options{
+		delayThreshold:     defaultDelayThreshold,
+		rejectThreshold:    defaultRejectThreshold,
+		maxDelay:           defaultMaxDelay,
+		heapSampleInterval: defaultHeapSampleInterval,
+		flushBacklogLimit:  defaultFlushBacklogLimit,
+		clockOpts:          clock.NewOptions(),
+		iOpts:              instrument.NewOptions(),
+	}
+}
+
+func (o *options) Validate() error {
+	if o.delayThreshold <= 0 {
+		return errInvalidDelayThreshold
+	}
+	if o.rejectThreshold < o.delayThreshold {
+		return errInvalidRejectThreshold
+	}
+	if o.maxDelay < 0 {
+		return errInvalidMaxDelay
+	}
+	if o.heapSampleInterval <= 0 {
+		return errInvalidHeapSampleInterval
+	}
+	if o.flushBacklogLimit < 0 {
+		return errInvalidFlushBacklogLimit
+	}
+	return nil
+}
+
+func (o *options) SetEnabled(value bool) Options {
+	opts := *o
+	opts.enabled = value
+	return &opts
+}
+
+func (o *options) Enabled() bool {
+	return o.enabled
+}
+
+func (o *options) SetDelayThreshold(value float64) Options {
+	opts := *o
+	opts.delayThreshold = value
+	return &opts
+}
+
+func (o *options) DelayThreshold() float64 {
+	return o.delayThreshold
+}
+
+func (o *options) SetRejectThreshold(value float64) Options {
+	opts := *o
+	opts.rejectThreshold = value
+	return &opts
+}
+
+func (o *options) RejectThreshold() float64 {
+	return o.rejectThreshold
+}
+
+func (o *options) SetMaxDelay(value time.Duration) Options {
+	opts := *o
+	opts.maxDelay = value
+	return &opts
+}
+
+func (o *options) MaxDelay() time.Duration {
+	return o.maxDelay
+}
+
+func (o *options) SetHeapLimitBytes(value uint64) Options {
+	opts := *o
+	opts.heapLimitBytes = value
+	return &opts
+}
+
+func (o *options) HeapLimitBytes() uint64 {
+	return o.heapLimitBytes
+}
+
+func (o *options) SetHeapSampleInterval(value time.Duration) Options {
+	opts := *o
+	opts.heapSampleInterval = value
+	return &opts
+}
+
+func (o *options) HeapSampleInterval() time.Duration {
+	return o.heapSampleInterval
+}
+
+func (o *options) SetFlushBacklogLimit(value int) Options {
+	opts := *o
+	opts.flushBacklogLimit = value
+	return &opts
+}
+
+func (o *options) FlushBacklogLimit() int {
+	return o.flushBacklogLimit
+}
+
+func (o *options) SetClockOptions(value clock.Options) Options {
+	opts := *o
+	opts.clockOpts = value
+	return &opts
+}
+
+func (o *options) ClockOptions() clock.Options {
+	return o.clockOpts
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.iOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.iOpts
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package admission
+
+import (
+	"runtime"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/m3db/m3/src/x/clock"
+)
+
+// ValueFn returns the current value of a resource.
+type ValueFn func() float64
+
+type ratioSignal struct {
+	name     string
+	valueFn  ValueFn
+	capacity float64
+}
+
+// NewRatioSignal returns a signal whose pressure is the current value of a
+// resource divided by its capacity, such as the length of a queue.
+func NewRatioSignal(name string, valueFn ValueFn, capacity float64) Signal {
+	return &ratioSignal{
+		name:     name,
+		valueFn:  valueFn,
+		capacity: capacity,
+	}
+}
+
+func (s *ratioSignal) Name() string {
+	return s.name
+}
+
+func (s *ratioSignal) Pressure() float64 {
+	if s.capacity <= 0 {
+		return 0
+	}
+	return s.valueFn() / s.capacity
+}
+
+type heapSignal struct {
+	limit          float64
+	sampleInterval int64
+	nowFn          clock.NowFn
+	readMemStats   func(*runtime.MemStats)
+
+	lastSampledAt atomic.Int64
+	pressure      atomic.Float64
+}
+
+// NewHeapSignal returns a signal whose pressure is the heap in use divided
+// by the given limit. Reading memory stats stops the world so the heap is
+// sampled at most once per sample interval by whichever caller observes
+// the sample to be stale.
+func NewHeapSignal(
+	limitBytes uint64,
+	sampleInterval time.Duration,
+	nowFn clock.NowFn,
+) Signal {
+	return &heapSignal{
+		limit:          float64(limitBytes),
+		sampleInterval: int64(sampleInterval),
+		nowFn:          nowFn,
+		readMemStats:   runtime.ReadMemStats,
+	}
+}
+
+func (s *heapSignal) Name() string {
+	return "heap"
+}
+
+func (s *heapSignal) Pressure() float64 {
+	var (
+		now         = s.nowFn().UnixNano()
+		lastSampled = s.lastSampledAt.Load()
+	)
+	if now-lastSampled >= s.sampleInterval &&
+		s.lastSampledAt.CAS(lastSampled, now) {
+		var stats runtime.MemStats
+		s.readMemStats(&stats)
+		s.pressure.Store(float64(stats.HeapInuse) / s.limit)
+	}
+	return s.pressure.Load()
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package admission
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatioSignal(t *testing.T) {
+	value := float64(25)
+	s := NewRatioSignal("queue", func() float64 { return value }, 100)
+	require.Equal(t, "queue", s.Name())
+	require.Equal(t, 0.25, s.Pressure())
+
+	value = 150
+	require.Equal(t, 1.5, s.Pressure())
+
+	s = NewRatioSignal("queue", func() float64 { return value }, 0)
+	require.Equal(t, float64(0), s.Pressure())
+}
+
+func TestHeapSignalSamplesAtInterval(t *testing.T) {
+	var (
+		now     = time.Unix(0, 0).Add(time.Hour)
+		inuse   = uint64(50)
+		samples int
+	)
+	s := NewHeapSignal(100, time.Second, func() time.Time { return now }).(*heapSignal)
+	s.readMemStats = func(stats *runtime.MemStats) {
+		samples++
+		stats.HeapInuse = inuse
+	}
+
+	require.Equal(t, 0.5, s.Pressure())
+	require.Equal(t, 1, samples)
+
+	// Stale reads until the sample interval has elapsed.
+	inuse = 80
+	now = now.Add(500 * time.Millisecond)
+	require.Equal(t, 0.5, s.Pressure())
+	require.Equal(t, 1, samples)
+
+	now = now.Add(500 * time.Millisecond)
+	require.Equal(t, 0.8, s.Pressure())
+	require.Equal(t, 2, samples)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package admission contains logic for admitting writes based on node pressure.
+package admission
+
+import (
+	"context"
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Controller decides whether writes are admitted based on the pressure
+// reported by a set of signals. Below the delay threshold writes are admitted
+// immediately, between the delay and reject thresholds writes are delayed
+// proportionally to the pressure and at or above the reject threshold writes
+// are rejected with an overloaded error.
+type Controller interface {
+	// Admit returns nil if the write may proceed, possibly after delaying the
+	// caller, or an overloaded error if the write is rejected.
+	Admit(ctx context.Context) error
+
+	// Pressure returns the current highest pressure across all signals.
+	Pressure() float64
+}
+
+// Signal is a source of write pressure.
+type Signal interface {
+	// Name returns the name of the signal, used to tag metrics and errors.
+	Name() string
+
+	// Pressure returns the current pressure where zero is idle and one
+	// is at capacity.
+	Pressure() float64
+}
+
+// Options is the write admission options.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetEnabled sets whether write admission is enabled.
+	SetEnabled(value bool) Options
+
+	// Enabled returns whether write admission is enabled.
+	Enabled() bool
+
+	// SetDelayThreshold sets the pressure at which writes start being delayed.
+	SetDelayThreshold(value float64) Options
+
+	// DelayThreshold returns the pressure at which writes start being delayed.
+	DelayThreshold() float64
+
+	// SetRejectThreshold sets the pressure at which writes are rejected.
+	SetRejectThreshold(value float64) Options
+
+	// RejectThreshold returns the pressure at which writes are rejected.
+	RejectThreshold() float64
+
+	// SetMaxDelay sets the delay applied to writes just below the reject threshold.
+	SetMaxDelay(value time.Duration) Options
+
+	// MaxDelay returns the delay applied to writes just below the reject threshold.
+	MaxDelay() time.Duration
+
+	// SetHeapLimitBytes sets the heap size considered at capacity, zero
+	// disables the heap signal.
+	SetHeapLimitBytes(value uint64) Options
+
+	// HeapLimitBytes returns the heap size considered at capacity, zero
+	// disables the heap signal.
+	HeapLimitBytes() uint64
+
+	// SetHeapSampleInterval sets how often heap usage is sampled.
+	SetHeapSampleInterval(value time.Duration) Options
+
+	// HeapSampleInterval returns how often heap usage is sampled.
+	HeapSampleInterval() time.Duration
+
+	// SetFlushBacklogLimit sets the number of block starts pending a
+	// warm flush across namespaces that is considered at capacity, zero
+	// disables the flush backlog signal.
+	SetFlushBacklogLimit(value int) Options
+
+	// FlushBacklogLimit returns the number of block starts pending a
+	// warm flush across namespaces that is considered at capacity, zero
+	// disables the flush backlog signal.
+	FlushBacklogLimit() int
+
+	// SetClockOptions sets the clock options.
+	SetClockOptions(value clock.Options) Options
+
+	// ClockOptions returns the clock options.
+	ClockOptions() clock.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+}
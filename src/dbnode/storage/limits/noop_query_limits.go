@@ -66,6 +66,14 @@ func (q *noOpLookbackLimit) Update(LookbackLimitOptions) error {
 	return nil
 }
 
+func (q *noOpLookbackLimit) SourceOptions() map[string]LookbackLimitOptions {
+	return nil
+}
+
+func (q *noOpLookbackLimit) UpdateSources(map[string]LookbackLimitOptions) error {
+	return nil
+}
+
 func (q *noOpLookbackLimit) Inc(int, []byte) error {
 	return nil
 }
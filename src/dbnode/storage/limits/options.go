@@ -33,6 +33,7 @@ type limitOpts struct {
 	bytesReadLimitOpts         LookbackLimitOptions
 	diskSeriesReadLimitOpts    LookbackLimitOptions
 	diskAggregateDocsLimitOpts LookbackLimitOptions
+	sourceLimitOpts            map[string]SourceLimitOptions
 	sourceLoggerBuilder        SourceLoggerBuilder
 }
 
@@ -57,6 +58,12 @@ func (o *limitOpts) Validate() error {
 		return fmt.Errorf("bytes limit options invalid: %w", err)
 	}
 
+	for source, sourceOpts := range o.sourceLimitOpts {
+		if err := sourceOpts.validate(); err != nil {
+			return fmt.Errorf("source limit options invalid: source=%s, %w", source, err)
+		}
+	}
+
 	return nil
 }
 
@@ -120,6 +127,18 @@ func (o *limitOpts) AggregateDocsLimitOpts() LookbackLimitOptions {
 	return o.diskAggregateDocsLimitOpts
 }
 
+// SetSourceLimitOpts sets the limit options of each separately limited source.
+func (o *limitOpts) SetSourceLimitOpts(value map[string]SourceLimitOptions) Options {
+	opts := *o
+	opts.sourceLimitOpts = value
+	return &opts
+}
+
+// SourceLimitOpts returns the limit options of each separately limited source.
+func (o *limitOpts) SourceLimitOpts() map[string]SourceLimitOptions {
+	return o.sourceLimitOpts
+}
+
 // SetSourceLoggerBuilder sets the source logger.
 func (o *limitOpts) SetSourceLoggerBuilder(value SourceLoggerBuilder) Options {
 	opts := *o
@@ -83,6 +83,14 @@ func (t *testLookbackLimit) Update(limits.LookbackLimitOptions) error {
 	panic("implement me")
 }
 
+func (t *testLookbackLimit) SourceOptions() map[string]limits.LookbackLimitOptions {
+	panic("implement me")
+}
+
+func (t *testLookbackLimit) UpdateSources(map[string]limits.LookbackLimitOptions) error {
+	panic("implement me")
+}
+
 func (t *testLookbackLimit) Start() {
 	panic("implement me")
 }
@@ -45,16 +45,21 @@ type queryLimits struct {
 }
 
 type lookbackLimit struct {
-	name      string
-	started   bool
-	options   LookbackLimitOptions
-	metrics   lookbackLimitMetrics
-	logger    *zap.Logger
-	recent    *atomic.Int64
-	stopCh    chan struct{}
-	stoppedCh chan struct{}
-	lock      sync.RWMutex
-	iOpts     instrument.Options
+	name                string
+	limitNames          limitNames
+	started             bool
+	options             LookbackLimitOptions
+	metrics             lookbackLimitMetrics
+	logger              *zap.Logger
+	recent              *atomic.Int64
+	stopCh              chan struct{}
+	stoppedCh           chan struct{}
+	lock                sync.RWMutex
+	iOpts               instrument.Options
+	sourceLoggerBuilder SourceLoggerBuilder
+	// sources holds the limits of sources limited separately in addition to
+	// the global limit.
+	sources map[string]*lookbackLimit
 }
 
 type lookbackLimitMetrics struct {
@@ -123,8 +128,27 @@ func NewQueryLimits(options Options) (QueryLimits, error) {
 			metricName: docsMatched,
 			metricType: "aggregate",
 		}, aggDocsLimitOpts, iOpts, sourceLoggerBuilder)
+
+		sourceDocsLimitOpts    = make(map[string]LookbackLimitOptions)
+		sourceBytesReadOpts    = make(map[string]LookbackLimitOptions)
+		sourceAggDocsLimitOpts = make(map[string]LookbackLimitOptions)
 	)
 
+	for source, opts := range options.SourceLimitOpts() {
+		sourceDocsLimitOpts[source] = opts.DocsLimitOpts
+		sourceBytesReadOpts[source] = opts.BytesReadLimitOpts
+		sourceAggDocsLimitOpts[source] = opts.AggregateDocsLimitOpts
+	}
+	if err := docsLimit.UpdateSources(sourceDocsLimitOpts); err != nil {
+		return nil, err
+	}
+	if err := bytesReadLimit.UpdateSources(sourceBytesReadOpts); err != nil {
+		return nil, err
+	}
+	if err := aggregatedDocsLimit.UpdateSources(sourceAggDocsLimitOpts); err != nil {
+		return nil, err
+	}
+
 	return &queryLimits{
 		docsLimit:           docsLimit,
 		bytesReadLimit:      bytesReadLimit,
@@ -165,17 +189,33 @@ func newLookbackLimit(
 	)
 
 	return &lookbackLimit{
-		name:      limitNames.limitName,
-		options:   opts,
-		metrics:   metrics,
-		logger:    instrumentOpts.Logger(),
-		recent:    atomic.NewInt64(0),
-		stopCh:    make(chan struct{}),
-		stoppedCh: make(chan struct{}),
-		iOpts:     instrumentOpts,
+		name:                limitNames.limitName,
+		limitNames:          limitNames,
+		options:             opts,
+		metrics:             metrics,
+		logger:              instrumentOpts.Logger(),
+		recent:              atomic.NewInt64(0),
+		stopCh:              make(chan struct{}),
+		stoppedCh:           make(chan struct{}),
+		iOpts:               instrumentOpts,
+		sourceLoggerBuilder: sourceLoggerBuilder,
 	}
 }
 
+// newSourceLimit returns a limit for a single source, its metrics are tagged
+// with the source.
+func (q *lookbackLimit) newSourceLimit(
+	source string,
+	opts LookbackLimitOptions,
+) *lookbackLimit {
+	scope := q.iOpts.MetricsScope().Tagged(map[string]string{"source": source})
+	return newLookbackLimit(limitNames{
+		limitName:  fmt.Sprintf("%s, source=%s", q.limitNames.limitName, source),
+		metricName: q.limitNames.metricName,
+		metricType: q.limitNames.metricType,
+	}, opts, q.iOpts.SetMetricsScope(scope), q.sourceLoggerBuilder)
+}
+
 func newLookbackLimitMetrics(
 	limitNames limitNames,
 	instrumentOpts instrument.Options,
@@ -268,28 +308,119 @@ func (q *lookbackLimit) Update(opts LookbackLimitOptions) error {
 	return nil
 }
 
+// SourceOptions returns the options of each separately limited source.
+func (q *lookbackLimit) SourceOptions() map[string]LookbackLimitOptions {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+
+	opts := make(map[string]LookbackLimitOptions, len(q.sources))
+	for source, limit := range q.sources {
+		opts[source] = limit.Options()
+	}
+	return opts
+}
+
+// UpdateSources updates the separately limited sources.
+func (q *lookbackLimit) UpdateSources(opts map[string]LookbackLimitOptions) error {
+	for source, sourceOpts := range opts {
+		if err := sourceOpts.validate(); err != nil {
+			return fmt.Errorf("invalid source limit options: source=%s, %w", source, err)
+		}
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	sources := make(map[string]*lookbackLimit, len(opts))
+	for source, sourceOpts := range opts {
+		limit, ok := q.sources[source]
+		if !ok {
+			limit = q.newSourceLimit(source, sourceOpts)
+			if q.started {
+				limit.Start()
+			}
+		} else if !limit.Options().Equals(sourceOpts) {
+			if err := limit.Update(sourceOpts); err != nil {
+				return err
+			}
+		}
+		sources[source] = limit
+	}
+
+	for source, limit := range q.sources {
+		if _, ok := sources[source]; !ok && q.started {
+			limit.Stop()
+		}
+	}
+	q.sources = sources
+
+	return nil
+}
+
 // Inc increments the current value and returns an error if above the limit.
+// Values from a source with its own limit are charged to both the limit of
+// the source and the global limit, so that a source is bounded by its own
+// budget without the sources together exceeding the global limit.
 func (q *lookbackLimit) Inc(val int, source []byte) error {
 	if val < 0 {
 		return fmt.Errorf("invalid negative query limit inc %d", val)
 	}
+
+	sourceLimit := q.sourceLimit(source)
 	if val == 0 {
+		if sourceLimit != nil {
+			if err := sourceLimit.exceeded(); err != nil {
+				return err
+			}
+		}
 		return q.exceeded()
 	}
 
-	// Add the new stats to the global state.
 	valI64 := int64(val)
-	recent := q.recent.Add(valI64)
+	q.metrics.sourceLogger.LogSourceValue(valI64, source)
+
+	// Add the new stats to the state.
+	err := q.inc(valI64)
+	if sourceLimit != nil {
+		if sourceErr := sourceLimit.inc(valI64); sourceErr != nil {
+			return sourceErr
+		}
+	}
+	return err
+}
+
+func (q *lookbackLimit) inc(val int64) error {
+	recent := q.recent.Add(val)
 
 	// Update metrics.
 	q.metrics.recentCount.Update(float64(recent))
-	q.metrics.total.Inc(valI64)
-	q.metrics.sourceLogger.LogSourceValue(valI64, source)
+	q.metrics.total.Inc(val)
 
 	// Enforce limit (if specified).
 	return q.checkLimit(recent)
 }
 
+// sourceLimit returns the limit of the source if the source is limited
+// separately, sources whose limit is disabled are subject to the global limit.
+func (q *lookbackLimit) sourceLimit(source []byte) *lookbackLimit {
+	if len(source) == 0 {
+		return nil
+	}
+
+	q.lock.RLock()
+	limit := q.sources[string(source)]
+	q.lock.RUnlock()
+	if limit == nil {
+		return nil
+	}
+
+	opts := limit.Options()
+	if opts.Limit == disabledLimitValue && !opts.ForceExceeded {
+		return nil
+	}
+	return limit
+}
+
 func (q *lookbackLimit) exceeded() error {
 	return q.checkLimit(q.recent.Load())
 }
@@ -327,6 +458,9 @@ func (q *lookbackLimit) Start() {
 	q.lock.Lock()
 	defer q.lock.Unlock()
 	q.start()
+	for _, limit := range q.sources {
+		limit.Start()
+	}
 }
 
 func (q *lookbackLimit) Stop() {
@@ -335,6 +469,10 @@ func (q *lookbackLimit) Stop() {
 	q.lock.Lock()
 	defer q.lock.Unlock()
 	q.stop()
+	for _, limit := range q.sources {
+		limit.Stop()
+	}
+	q.started = false
 }
 
 func (q *lookbackLimit) start() {
@@ -400,6 +538,19 @@ func (opts LookbackLimitOptions) Equals(other LookbackLimitOptions) bool {
 		opts.ForceWaited == other.ForceWaited
 }
 
+func (opts SourceLimitOptions) validate() error {
+	if err := opts.DocsLimitOpts.validate(); err != nil {
+		return fmt.Errorf("doc limit options invalid: %w", err)
+	}
+	if err := opts.BytesReadLimitOpts.validate(); err != nil {
+		return fmt.Errorf("bytes limit options invalid: %w", err)
+	}
+	if err := opts.AggregateDocsLimitOpts.validate(); err != nil {
+		return fmt.Errorf("aggregate doc limit options invalid: %w", err)
+	}
+	return nil
+}
+
 func (opts LookbackLimitOptions) validate() error {
 	if opts.Limit < 0 {
 		return fmt.Errorf("query limit requires limit >= 0 (%d)", opts.Limit)
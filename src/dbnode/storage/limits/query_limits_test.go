@@ -281,6 +281,116 @@ func TestLookbackReset(t *testing.T) {
 	require.True(t, success, "did not eventually reset to zero")
 }
 
+func TestLookbackLimitSources(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	iOpts := instrument.NewOptions().SetMetricsScope(scope)
+	name := "test"
+	limit := newLookbackLimit(limitNames{
+		limitName:  name,
+		metricName: name,
+		metricType: name,
+	}, LookbackLimitOptions{
+		Limit:    10,
+		Lookback: time.Second,
+	}, iOpts, &sourceLoggerBuilder{})
+
+	sourceOpts := map[string]LookbackLimitOptions{
+		"noisy":    {Limit: 3, Lookback: time.Second},
+		"large":    {Limit: 100, Lookback: time.Second},
+		"disabled": {Limit: 0, Lookback: time.Second},
+	}
+	require.NoError(t, limit.UpdateSources(sourceOpts))
+	require.Equal(t, sourceOpts, limit.SourceOptions())
+
+	limit.Start()
+	defer limit.Stop()
+
+	// The noisy source trips its own limit, charging the global one too.
+	require.NoError(t, limit.Inc(2, []byte("noisy")))
+	err := limit.Inc(2, []byte("noisy"))
+	require.Error(t, err)
+	require.True(t, IsQueryLimitExceededError(err))
+	require.Contains(t, err.Error(), "source=noisy")
+	require.Error(t, limit.Inc(0, []byte("noisy")))
+	require.NoError(t, limit.Inc(1, []byte("quiet")))
+	require.NoError(t, limit.Inc(1, nil))
+	require.Equal(t, int64(6), limit.current())
+
+	// A source whose limit is disabled is charged against the global limit.
+	require.NoError(t, limit.Inc(2, []byte("disabled")))
+	require.Equal(t, int64(8), limit.current())
+
+	tallytest.AssertCounterValue(t, 4, scope.Snapshot(), "query-limit.total-test",
+		map[string]string{"type": name, "source": "noisy"})
+	tallytest.AssertCounterValue(t, 8, scope.Snapshot(), "query-limit.total-test",
+		map[string]string{"type": name})
+
+	// Removing the source leaves it subject to the global limit.
+	require.NoError(t, limit.UpdateSources(nil))
+	require.Empty(t, limit.SourceOptions())
+	require.NoError(t, limit.Inc(1, []byte("noisy")))
+	require.Equal(t, int64(9), limit.current())
+
+	require.Error(t, limit.UpdateSources(map[string]LookbackLimitOptions{
+		"invalid": {Limit: -1, Lookback: time.Second},
+	}))
+}
+
+func TestLookbackLimitSourcesExceedGlobal(t *testing.T) {
+	name := "test"
+	limit := newLookbackLimit(limitNames{
+		limitName:  name,
+		metricName: name,
+		metricType: name,
+	}, LookbackLimitOptions{
+		Limit:    10,
+		Lookback: time.Second,
+	}, instrument.NewOptions(), &sourceLoggerBuilder{})
+	require.NoError(t, limit.UpdateSources(map[string]LookbackLimitOptions{
+		"a": {Limit: 8, Lookback: time.Second},
+		"b": {Limit: 8, Lookback: time.Second},
+	}))
+
+	limit.Start()
+	defer limit.Stop()
+
+	// Each source is within its own limit but together they exceed the
+	// global limit.
+	require.NoError(t, limit.Inc(6, []byte("a")))
+	err := limit.Inc(6, []byte("b"))
+	require.Error(t, err)
+	require.True(t, IsQueryLimitExceededError(err))
+	require.NotContains(t, err.Error(), "source=")
+	require.Equal(t, int64(12), limit.current())
+
+	// Both sources are then subject to the exceeded global limit.
+	require.Error(t, limit.Inc(0, []byte("a")))
+	require.Error(t, limit.Inc(1, []byte("a")))
+}
+
+func TestQueryLimitsSourceLimitOpts(t *testing.T) {
+	defaultOpts := DefaultLookbackLimitOptions()
+	opts := testQueryLimitOptions(defaultOpts, defaultOpts, defaultOpts, defaultOpts, instrument.NewOptions()).
+		SetSourceLimitOpts(map[string]SourceLimitOptions{
+			"noisy": {
+				DocsLimitOpts:          LookbackLimitOptions{Limit: 1, Lookback: time.Second},
+				BytesReadLimitOpts:     defaultOpts,
+				AggregateDocsLimitOpts: defaultOpts,
+			},
+		})
+	queryLimits, err := NewQueryLimits(opts)
+	require.NoError(t, err)
+
+	require.Error(t, queryLimits.FetchDocsLimit().Inc(2, []byte("noisy")))
+	require.NoError(t, queryLimits.FetchDocsLimit().Inc(2, []byte("quiet")))
+	require.NoError(t, queryLimits.BytesReadLimit().Inc(2, []byte("noisy")))
+
+	_, err = NewQueryLimits(opts.SetSourceLimitOpts(map[string]SourceLimitOptions{
+		"noisy": {},
+	}))
+	require.Error(t, err)
+}
+
 func TestValidateLookbackLimitOptions(t *testing.T) {
 	for _, test := range []struct {
 		name        string
@@ -33,6 +33,11 @@ type Key string
 // SourceContextKey is the key for setting and retrieving source from context.
 const SourceContextKey Key = "source"
 
+// BytesReadContextKey is the key for setting and retrieving from context the
+// go.uber.org/atomic Int64 that counts the bytes a query read from disk, the
+// same bytes counted by the bytes read limit.
+const BytesReadContextKey Key = "bytes-read"
+
 // QueryLimits provides an interface for managing query limits.
 type QueryLimits interface {
 	// FetchDocsLimit limits queries by a global concurrent count of index docs matched.
@@ -61,6 +66,12 @@ type LookbackLimit interface {
 	Inc(new int, source []byte) error
 	// Update changes the lookback limit settings.
 	Update(opts LookbackLimitOptions) error
+	// SourceOptions returns the current limit options of each source that is
+	// limited separately in addition to the global limit.
+	SourceOptions() map[string]LookbackLimitOptions
+	// UpdateSources changes the sources that are limited separately, sources
+	// missing from opts are only subject to the global limit.
+	UpdateSources(opts map[string]LookbackLimitOptions) error
 
 	// Start begins background resetting of the lookback limit.
 	Start()
@@ -81,6 +92,19 @@ type LookbackLimitOptions struct {
 	ForceWaited bool
 }
 
+// SourceLimitOptions holds the options for the limits enforced on the queries
+// from a single source. A source is charged against both its own limits and
+// the global limits.
+type SourceLimitOptions struct {
+	// DocsLimitOpts limits the index docs matched by fetches from the source.
+	DocsLimitOpts LookbackLimitOptions
+	// BytesReadLimitOpts limits the bytes read from disk by the source.
+	BytesReadLimitOpts LookbackLimitOptions
+	// AggregateDocsLimitOpts limits the index docs matched by aggregates
+	// from the source.
+	AggregateDocsLimitOpts LookbackLimitOptions
+}
+
 // SourceLoggerBuilder builds a SourceLogger given instrument options.
 type SourceLoggerBuilder interface {
 	// NewSourceLogger builds a source logger.
@@ -128,6 +152,14 @@ type Options interface {
 	// DiskSeriesReadLimitOpts returns the disk series read limit options.
 	DiskSeriesReadLimitOpts() LookbackLimitOptions
 
+	// SetSourceLimitOpts sets the limit options of each source that is
+	// limited separately, keyed by source.
+	SetSourceLimitOpts(value map[string]SourceLimitOptions) Options
+
+	// SourceLimitOpts returns the limit options of each source that is
+	// limited separately, keyed by source.
+	SourceLimitOpts() map[string]SourceLimitOptions
+
 	// SetSourceLoggerBuilder sets the source logger.
 	SetSourceLoggerBuilder(value SourceLoggerBuilder) Options
 
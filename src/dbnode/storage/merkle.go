@@ -0,0 +1,305 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/context"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// merkleTreeCacheTTL is how long a merkle tree is kept after it was built,
+	// long enough for a peer to descend the tree level by level.
+	merkleTreeCacheTTL = time.Minute
+
+	// maxMerkleTreeCacheNodes is the max number of nodes held across all
+	// cached merkle trees, enough to hold a tree of the max depth.
+	maxMerkleTreeCacheNodes = 1 << (digest.MaxMerkleTreeDepth + 1)
+)
+
+func (d *db) FetchMerkleTree(
+	ctx context.Context,
+	namespace ident.ID,
+	shardID uint32,
+	blockStart xtime.UnixNano,
+	depth int,
+) (*digest.MerkleTree, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return nil, xerrors.NewInvalidParamsError(err)
+	}
+	tree, err := digest.NewMerkleTree(depth)
+	if err != nil {
+		return nil, xerrors.NewInvalidParamsError(err)
+	}
+	if _, _, err := n.ReadableShardAt(shardID); err != nil {
+		return nil, err
+	}
+
+	// Peers fetch the nodes of a tree one level per request, so keep the tree
+	// rather than building it again for each level.
+	key := fmt.Sprintf("%s/%d/%d/%d", namespace.String(), shardID, blockStart, depth)
+	if cached, ok := d.merkleTrees.get(key); ok {
+		return cached, nil
+	}
+	tree, err = d.fetchMerkleTree(ctx, n, namespace, shardID, blockStart, tree)
+	if err != nil {
+		return nil, err
+	}
+	d.merkleTrees.put(key, tree)
+	return tree, nil
+}
+
+// fetchMerkleTree fills the given tree from the persisted tree of the block
+// if there is one deep enough and otherwise from the blocks metadata.
+func (d *db) fetchMerkleTree(
+	ctx context.Context,
+	n databaseNamespace,
+	namespace ident.ID,
+	shardID uint32,
+	blockStart xtime.UnixNano,
+	tree *digest.MerkleTree,
+) (*digest.MerkleTree, error) {
+	// Prefer the tree written with the fileset of the block to avoid reading
+	// the metadata of every series. Cold writes to the block are reflected
+	// once a cold flush writes a new volume of the fileset.
+	flushState, err := n.FlushState(shardID, blockStart)
+	if err == nil && flushState.WarmStatus.DataFlushed == fileOpSuccess {
+		filePathPrefix := d.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+		persisted, ok, err := fs.ReadMerkleTree(filePathPrefix, namespace, shardID, blockStart)
+		if err != nil {
+			d.log.Warn("could not read merkle tree, building from metadata",
+				zap.Stringer("namespace", namespace),
+				zap.Uint32("shard", shardID),
+				zap.Time("blockStart", blockStart.ToTime()),
+				zap.Error(err))
+		} else if ok && persisted.Depth() >= tree.Depth() {
+			return persisted.Truncate(tree.Depth())
+		}
+	}
+
+	err = d.fetchShardBlockMetadata(ctx, n, shardID, blockStart,
+		func(result block.FetchBlocksMetadataResult) bool {
+			for _, b := range result.Blocks.Results() {
+				if b.Start == blockStart && b.Err == nil && b.Checksum != nil {
+					tree.Add(result.ID.Bytes(), *b.Checksum)
+				}
+			}
+			return false
+		})
+	if err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func (d *db) FetchMerkleLeavesMetadata(
+	ctx context.Context,
+	namespace ident.ID,
+	shardID uint32,
+	blockStart xtime.UnixNano,
+	depth int,
+	leaves []int,
+) (block.FetchBlocksMetadataResults, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return nil, xerrors.NewInvalidParamsError(err)
+	}
+	tree, err := digest.NewMerkleTree(depth)
+	if err != nil {
+		return nil, xerrors.NewInvalidParamsError(err)
+	}
+
+	leafSet := make(map[int]struct{}, len(leaves))
+	for _, leaf := range leaves {
+		leafSet[leaf] = struct{}{}
+	}
+
+	results := d.opts.FetchBlocksMetadataResultsPool().Get()
+	err = d.fetchShardBlockMetadata(ctx, n, shardID, blockStart,
+		func(result block.FetchBlocksMetadataResult) bool {
+			if _, ok := leafSet[tree.Leaf(result.ID.Bytes())]; !ok {
+				return false
+			}
+			results.Add(result)
+			return true
+		})
+	if err != nil {
+		results.Close()
+		return nil, err
+	}
+	return results, nil
+}
+
+// fetchShardBlockMetadata calls fn with the blocks metadata of every series of
+// a shard block, fn returns whether it takes ownership of the result and
+// otherwise the result is finalized once fn returns.
+func (d *db) fetchShardBlockMetadata(
+	ctx context.Context,
+	n databaseNamespace,
+	shardID uint32,
+	blockStart xtime.UnixNano,
+	fn func(result block.FetchBlocksMetadataResult) bool,
+) error {
+	var (
+		blockSize = n.Options().RetentionOptions().BlockSize()
+		end       = blockStart.Add(blockSize)
+		opts      = block.FetchBlocksMetadataOptions{
+			IncludeSizes:     true,
+			IncludeChecksums: true,
+		}
+		pageToken PageToken
+	)
+	for {
+		// As with repair, loop until a nil page token is returned since not
+		// all metadata is necessarily returned at once even with no limit.
+		page, nextPageToken, err := n.FetchBlocksMetadataV2(ctx, shardID,
+			blockStart, end, math.MaxInt64, pageToken, opts)
+		if err != nil {
+			return err
+		}
+
+		if page != nil {
+			for _, result := range page.Results() {
+				if fn(result) {
+					continue
+				}
+				finalizeFetchBlocksMetadataResult(result)
+			}
+			// Ownership of each result has been taken or released.
+			page.Reset()
+			page.Close()
+		}
+
+		if nextPageToken == nil {
+			return nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+func finalizeFetchBlocksMetadataResult(result block.FetchBlocksMetadataResult) {
+	if result.ID != nil {
+		result.ID.Finalize()
+	}
+	if result.Tags != nil {
+		result.Tags.Close()
+	}
+	if result.Blocks != nil {
+		result.Blocks.Close()
+	}
+}
+
+type merkleTreeCacheEntry struct {
+	key       string
+	tree      *digest.MerkleTree
+	expiresAt time.Time
+	elem      *list.Element
+}
+
+// merkleTreeCache is an LRU of merkle trees that expire a TTL after they were
+// built, regardless of how often they are fetched, so writes to a block are
+// reflected in its tree at most a TTL later. Cached trees must not be
+// modified.
+type merkleTreeCache struct {
+	sync.Mutex
+
+	nowFn    clock.NowFn
+	maxNodes int
+	numNodes int
+	lru      *list.List
+	entries  map[string]*merkleTreeCacheEntry
+}
+
+func newMerkleTreeCache(nowFn clock.NowFn, maxNodes int) *merkleTreeCache {
+	return &merkleTreeCache{
+		nowFn:    nowFn,
+		maxNodes: maxNodes,
+		lru:      list.New(),
+		entries:  make(map[string]*merkleTreeCacheEntry),
+	}
+}
+
+func (c *merkleTreeCache) get(key string) (*digest.MerkleTree, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.nowFn().After(entry.expiresAt) {
+		c.removeWithLock(entry)
+		return nil, false
+	}
+	c.lru.MoveToFront(entry.elem)
+	return entry.tree, true
+}
+
+func (c *merkleTreeCache) put(key string, tree *digest.MerkleTree) {
+	if tree.NumNodes() > c.maxNodes {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		c.removeWithLock(entry)
+	}
+	now := c.nowFn()
+	for back := c.lru.Back(); back != nil; back = c.lru.Back() {
+		oldest := back.Value.(*merkleTreeCacheEntry)
+		if c.numNodes+tree.NumNodes() <= c.maxNodes && !now.After(oldest.expiresAt) {
+			break
+		}
+		c.removeWithLock(oldest)
+	}
+
+	entry := &merkleTreeCacheEntry{
+		key:       key,
+		tree:      tree,
+		expiresAt: now.Add(merkleTreeCacheTTL),
+	}
+	entry.elem = c.lru.PushFront(entry)
+	c.entries[key] = entry
+	c.numNodes += tree.NumNodes()
+}
+
+func (c *merkleTreeCache) removeWithLock(entry *merkleTreeCacheEntry) {
+	c.lru.Remove(entry.elem)
+	delete(c.entries, entry.key)
+	c.numNodes -= entry.tree.NumNodes()
+}
@@ -0,0 +1,329 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func newTestMerkleMetadataPage(
+	blockStart xtime.UnixNano,
+	ids []string,
+	checksums []uint32,
+) block.FetchBlocksMetadataResults {
+	page := block.NewFetchBlocksMetadataResults()
+	for i, id := range ids {
+		checksum := checksums[i]
+		blocks := block.NewFetchBlockMetadataResults()
+		blocks.Add(block.FetchBlockMetadataResult{
+			Start:    blockStart,
+			Size:     int64(i),
+			Checksum: &checksum,
+		})
+		page.Add(block.NewFetchBlocksMetadataResult(ident.StringID(id), nil, blocks))
+	}
+	return page
+}
+
+func expectMerkleMetadataPages(
+	ns *MockdatabaseNamespace,
+	blockStart xtime.UnixNano,
+	blockSize time.Duration,
+) {
+	pageToken := PageToken("next")
+	ns.EXPECT().
+		FetchBlocksMetadataV2(gomock.Any(), uint32(0), blockStart, blockStart.Add(blockSize),
+			gomock.Any(), PageToken(nil), gomock.Any()).
+		Return(newTestMerkleMetadataPage(blockStart,
+			[]string{"foo", "bar"}, []uint32{1, 2}), pageToken, nil)
+	ns.EXPECT().
+		FetchBlocksMetadataV2(gomock.Any(), uint32(0), blockStart, blockStart.Add(blockSize),
+			gomock.Any(), pageToken, gomock.Any()).
+		Return(newTestMerkleMetadataPage(blockStart,
+			[]string{"baz"}, []uint32{3}), nil, nil)
+}
+
+func TestDatabaseFetchMerkleTreeFromMetadata(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := defaultTestDatabase(t, ctrl, Bootstrapped)
+	defer func() {
+		close(mapCh)
+	}()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	var (
+		nsOpts     = namespace.NewOptions()
+		blockSize  = nsOpts.RetentionOptions().BlockSize()
+		blockStart = xtime.Now().Truncate(blockSize)
+		ns         = dbAddNewMockNamespace(ctrl, d, "testns")
+	)
+	ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+	ns.EXPECT().ReadableShardAt(uint32(0)).Return(nil, namespace.Context{}, nil)
+	ns.EXPECT().FlushState(uint32(0), blockStart).Return(fileOpState{}, nil)
+	expectMerkleMetadataPages(ns, blockStart, blockSize)
+
+	tree, err := d.FetchMerkleTree(ctx, ident.StringID("testns"), 0, blockStart, 4)
+	require.NoError(t, err)
+
+	expected, err := digest.NewMerkleTree(4)
+	require.NoError(t, err)
+	expected.Add([]byte("foo"), 1)
+	expected.Add([]byte("bar"), 2)
+	expected.Add([]byte("baz"), 3)
+	require.Equal(t, expected.Depth(), tree.Depth())
+	require.Equal(t, expected.Root(), tree.Root())
+
+	_, err = d.FetchMerkleTree(ctx, ident.StringID("testns"), 0, blockStart,
+		digest.MaxMerkleTreeDepth+1)
+	require.Error(t, err)
+}
+
+func TestDatabaseFetchMerkleTreeCached(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := defaultTestDatabase(t, ctrl, Bootstrapped)
+	defer func() {
+		close(mapCh)
+	}()
+
+	now := time.Now()
+	d.merkleTrees = newMerkleTreeCache(func() time.Time {
+		return now
+	}, maxMerkleTreeCacheNodes)
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	var (
+		nsOpts     = namespace.NewOptions()
+		blockSize  = nsOpts.RetentionOptions().BlockSize()
+		blockStart = xtime.Now().Truncate(blockSize)
+		ns         = dbAddNewMockNamespace(ctrl, d, "testns")
+	)
+	ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+	ns.EXPECT().ReadableShardAt(uint32(0)).Return(nil, namespace.Context{}, nil).AnyTimes()
+	ns.EXPECT().FlushState(uint32(0), blockStart).Return(fileOpState{}, nil).Times(2)
+	expectMerkleMetadataPages(ns, blockStart, blockSize)
+
+	// The tree is built from the metadata once for every level a peer fetches.
+	tree, err := d.FetchMerkleTree(ctx, ident.StringID("testns"), 0, blockStart, 4)
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		cached, err := d.FetchMerkleTree(ctx, ident.StringID("testns"), 0, blockStart, 4)
+		require.NoError(t, err)
+		require.True(t, tree == cached)
+	}
+
+	// The tree is built again once expired.
+	now = now.Add(merkleTreeCacheTTL + time.Second)
+	expectMerkleMetadataPages(ns, blockStart, blockSize)
+	rebuilt, err := d.FetchMerkleTree(ctx, ident.StringID("testns"), 0, blockStart, 4)
+	require.NoError(t, err)
+	require.False(t, tree == rebuilt)
+	require.Equal(t, tree.Root(), rebuilt.Root())
+}
+
+func TestMerkleTreeCacheExpiresFetchedTrees(t *testing.T) {
+	tree, err := digest.NewMerkleTree(1)
+	require.NoError(t, err)
+
+	now := time.Now()
+	cache := newMerkleTreeCache(func() time.Time {
+		return now
+	}, maxMerkleTreeCacheNodes)
+	cache.put("a", tree)
+
+	// Fetching the tree repeatedly does not keep it from expiring a TTL after
+	// it was built.
+	expiresAt := now.Add(merkleTreeCacheTTL)
+	for !now.After(expiresAt) {
+		cached, ok := cache.get("a")
+		require.True(t, ok)
+		require.True(t, tree == cached)
+		now = now.Add(merkleTreeCacheTTL / 4)
+	}
+	_, ok := cache.get("a")
+	require.False(t, ok)
+	require.Equal(t, 0, cache.numNodes)
+}
+
+func TestMerkleTreeCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	newTree := func(depth int) *digest.MerkleTree {
+		tree, err := digest.NewMerkleTree(depth)
+		require.NoError(t, err)
+		return tree
+	}
+
+	small := newTree(1)
+	cache := newMerkleTreeCache(time.Now, 2*small.NumNodes())
+	cache.put("a", small)
+	cache.put("b", newTree(1))
+
+	// Fetching a makes b the least recently used.
+	_, ok := cache.get("a")
+	require.True(t, ok)
+	cache.put("c", newTree(1))
+
+	_, ok = cache.get("b")
+	require.False(t, ok)
+	_, ok = cache.get("a")
+	require.True(t, ok)
+	_, ok = cache.get("c")
+	require.True(t, ok)
+
+	// Trees larger than the cache are not cached.
+	cache.put("d", newTree(2))
+	_, ok = cache.get("d")
+	require.False(t, ok)
+	require.Equal(t, 2*small.NumNodes(), cache.numNodes)
+}
+
+func TestDatabaseFetchMerkleTreeFromFileset(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "merkle")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	d, mapCh, _ := defaultTestDatabase(t, ctrl, Bootstrapped)
+	defer func() {
+		close(mapCh)
+	}()
+
+	fsOpts := d.opts.CommitLogOptions().FilesystemOptions().
+		SetFilePathPrefix(dir)
+	d.opts = d.opts.SetCommitLogOptions(
+		d.opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	var (
+		nsID       = ident.StringID("testns")
+		nsOpts     = namespace.NewOptions()
+		blockSize  = nsOpts.RetentionOptions().BlockSize()
+		blockStart = xtime.Now().Truncate(blockSize)
+		ns         = dbAddNewMockNamespace(ctrl, d, "testns")
+	)
+
+	w, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, w.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  nsID,
+			BlockStart: blockStart,
+		},
+		BlockSize:   blockSize,
+		FileSetType: persist.FileSetFlushType,
+	}))
+	data := []byte{1, 2, 3}
+	bytes := checked.NewBytes(data, nil)
+	bytes.IncRef()
+	require.NoError(t, w.Write(
+		persist.NewMetadataFromIDAndTags(ident.StringID("foo"), ident.Tags{},
+			persist.MetadataOptions{}),
+		bytes, digest.Checksum(data)))
+	require.NoError(t, w.Close())
+
+	ns.EXPECT().ReadableShardAt(uint32(0)).Return(nil, namespace.Context{}, nil).Times(2)
+	ns.EXPECT().FlushState(uint32(0), blockStart).Return(fileOpState{
+		WarmStatus: warmStatus{DataFlushed: fileOpSuccess},
+	}, nil).Times(2)
+
+	tree, err := d.FetchMerkleTree(ctx, nsID, 0, blockStart, 3)
+	require.NoError(t, err)
+
+	expected, err := digest.NewMerkleTree(3)
+	require.NoError(t, err)
+	expected.Add([]byte("foo"), digest.Checksum(data))
+	require.Equal(t, 3, tree.Depth())
+	require.Equal(t, expected.Root(), tree.Root())
+
+	// Trees deeper than the one written with the fileset are built from the
+	// metadata.
+	ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+	expectMerkleMetadataPages(ns, blockStart, blockSize)
+	tree, err = d.FetchMerkleTree(ctx, nsID, 0, blockStart, fsOpts.MerkleTreeDepth()+1)
+	require.NoError(t, err)
+	require.Equal(t, fsOpts.MerkleTreeDepth()+1, tree.Depth())
+}
+
+func TestDatabaseFetchMerkleLeavesMetadata(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := defaultTestDatabase(t, ctrl, Bootstrapped)
+	defer func() {
+		close(mapCh)
+	}()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	var (
+		nsOpts     = namespace.NewOptions()
+		blockSize  = nsOpts.RetentionOptions().BlockSize()
+		blockStart = xtime.Now().Truncate(blockSize)
+		ns         = dbAddNewMockNamespace(ctrl, d, "testns")
+	)
+	ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+	expectMerkleMetadataPages(ns, blockStart, blockSize)
+
+	tree, err := digest.NewMerkleTree(8)
+	require.NoError(t, err)
+	leaves := []int{tree.Leaf([]byte("foo")), tree.Leaf([]byte("baz"))}
+
+	results, err := d.FetchMerkleLeavesMetadata(ctx, ident.StringID("testns"), 0,
+		blockStart, 8, leaves)
+	require.NoError(t, err)
+	defer results.Close()
+
+	var ids []string
+	for _, result := range results.Results() {
+		ids = append(ids, result.ID.String())
+	}
+	sort.Strings(ids)
+	require.Equal(t, []string{"baz", "foo"}, ids)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
@@ -43,6 +44,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/ts/writes"
+	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
 	"github.com/m3db/m3/src/x/clock"
@@ -126,6 +128,12 @@ type dbNamespace struct {
 	bootstrapState     BootstrapState
 	repairsAny         bool
 
+	// lateWrites holds writes outside of the buffer window that were
+	// quarantined instead of rejected, lateWritesPromotedAny is set once any
+	// of them were promoted to cold writes that need a cold flush.
+	lateWrites            *lateWriteQuarantine
+	lateWritesPromotedAny bool
+
 	// schemaDescr caches the latest schema for the namespace.
 	// schemaDescr is updated whenever schema registry is updated.
 	schemaListener xresource.SimpleCloser
@@ -169,6 +177,7 @@ type databaseNamespaceMetrics struct {
 	flushWarmData       instrument.MethodMetrics
 	flushColdData       instrument.MethodMetrics
 	flushIndex          instrument.MethodMetrics
+	flushIndexDeletes   instrument.MethodMetrics
 	snapshot            instrument.MethodMetrics
 	write               instrument.MethodMetrics
 	writeTagged         instrument.MethodMetrics
@@ -177,6 +186,8 @@ type databaseNamespaceMetrics struct {
 	fetchBlocksMetadata instrument.MethodMetrics
 	queryIDs            instrument.MethodMetrics
 	aggregateQuery      instrument.MethodMetrics
+	cardinality         instrument.MethodMetrics
+	deleteTagged        instrument.MethodMetrics
 
 	unfulfilled             tally.Counter
 	bootstrapStart          tally.Counter
@@ -184,9 +195,10 @@ type databaseNamespaceMetrics struct {
 	snapshotSeriesPersist   tally.Counter
 	writesWithoutAnnotation tally.Counter
 
-	shards databaseNamespaceShardMetrics
-	tick   databaseNamespaceTickMetrics
-	status databaseNamespaceStatusMetrics
+	shards     databaseNamespaceShardMetrics
+	tick       databaseNamespaceTickMetrics
+	status     databaseNamespaceStatusMetrics
+	lateWrites databaseNamespaceLateWriteMetrics
 
 	repairDifferingPercent tally.Gauge
 	repairComparedBlocks   tally.Counter
@@ -196,6 +208,22 @@ type databaseNamespaceMetrics struct {
 	repairExtraBlocks      tally.Counter
 }
 
+// databaseNamespaceLateWriteMetrics track writes that land outside of the
+// buffer window and the quarantine they are held in when the namespace
+// quarantines late writes.
+type databaseNamespaceLateWriteMetrics struct {
+	tooPast         tally.Counter
+	tooFuture       tally.Counter
+	tooPastBy       tally.Histogram
+	tooFutureBy     tally.Histogram
+	quarantined     tally.Counter
+	quarantineFull  tally.Counter
+	promoted        tally.Counter
+	promoteErrors   tally.Counter
+	discarded       tally.Counter
+	quarantinedSize tally.Gauge
+}
+
 type databaseNamespaceShardMetrics struct {
 	add         tally.Counter
 	close       tally.Counter
@@ -256,11 +284,15 @@ func newDatabaseNamespaceMetrics(
 	bootstrapScope := scope.SubScope("bootstrap")
 	snapshotScope := scope.SubScope("snapshot")
 	repairScope := scope.SubScope("repair")
+	lateWritesScope := scope.SubScope("out-of-window-writes")
+	quarantineScope := scope.SubScope("late-write-quarantine")
+	offsetBuckets := tally.MustMakeExponentialDurationBuckets(time.Second, 2, 16)
 	return databaseNamespaceMetrics{
 		bootstrap:           instrument.NewMethodMetrics(scope, "bootstrap", opts),
 		flushWarmData:       instrument.NewMethodMetrics(scope, "flushWarmData", opts),
 		flushColdData:       instrument.NewMethodMetrics(scope, "flushColdData", opts),
 		flushIndex:          instrument.NewMethodMetrics(scope, "flushIndex", opts),
+		flushIndexDeletes:   instrument.NewMethodMetrics(scope, "flushIndexDeletes", opts),
 		snapshot:            instrument.NewMethodMetrics(scope, "snapshot", opts),
 		write:               instrument.NewMethodMetrics(scope, "write", opts),
 		writeTagged:         instrument.NewMethodMetrics(scope, "write-tagged", opts),
@@ -269,6 +301,8 @@ func newDatabaseNamespaceMetrics(
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", opts),
 		queryIDs:            instrument.NewMethodMetrics(scope, "queryIDs", opts),
 		aggregateQuery:      instrument.NewMethodMetrics(scope, "aggregateQuery", opts),
+		cardinality:         instrument.NewMethodMetrics(scope, "cardinality", opts),
+		deleteTagged:        instrument.NewMethodMetrics(scope, "deleteTagged", opts),
 
 		unfulfilled:             bootstrapScope.Counter("unfulfilled"),
 		bootstrapStart:          bootstrapScope.Counter("start"),
@@ -313,6 +347,18 @@ func newDatabaseNamespaceMetrics(
 				numSegments: indexStatusScope.Gauge("num-segments"),
 			},
 		},
+		lateWrites: databaseNamespaceLateWriteMetrics{
+			tooPast:         lateWritesScope.Counter("too-past"),
+			tooFuture:       lateWritesScope.Counter("too-future"),
+			tooPastBy:       lateWritesScope.Histogram("too-past-by", offsetBuckets),
+			tooFutureBy:     lateWritesScope.Histogram("too-future-by", offsetBuckets),
+			quarantined:     quarantineScope.Counter("quarantined"),
+			quarantineFull:  quarantineScope.Counter("quarantine-full"),
+			promoted:        quarantineScope.Counter("promoted"),
+			promoteErrors:   quarantineScope.Counter("promote-errors"),
+			discarded:       quarantineScope.Counter("discarded"),
+			quarantinedSize: quarantineScope.Gauge("size"),
+		},
 		repairDifferingPercent: repairScope.Gauge("differing-percent"),
 		repairComparedBlocks:   repairScope.Counter("compared-blocks"),
 		repairDifferingBlocks:  repairScope.Counter("differing-blocks"),
@@ -354,19 +400,41 @@ func newDatabaseNamespace(
 	tickWorkers := xsync.NewWorkerPool(tickWorkersConcurrency)
 	tickWorkers.Init()
 
-	seriesOpts := NewSeriesOptionsFromOptions(opts, nopts.RetentionOptions()).
+	seriesOpts := NewSeriesOptionsFromOptions(opts, namespace.BlockRetentionOptions(nopts)).
 		SetStats(series.NewStats(scope)).
 		SetColdWritesEnabled(nopts.ColdWritesEnabled())
+	if len(nopts.RetentionRules()) > 0 {
+		// Series resolve their own retention period from the retention rules,
+		// the series retention options only bound the lifetime of blocks.
+		retentionRules, err := series.NewRetentionRulesMatcher(nopts)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to create namespace %v, invalid retention rules: %v",
+				metadata.ID().String(), err)
+		}
+		seriesOpts = seriesOpts.SetRetentionRules(retentionRules)
+	}
 	if err := seriesOpts.Validate(); err != nil {
 		return nil, fmt.Errorf(
 			"unable to create namespace %v, invalid series options: %v",
 			metadata.ID().String(), err)
 	}
 
-	var (
-		index NamespaceIndex
-		err   error
-	)
+	fsOpts := opts.CommitLogOptions().FilesystemOptions()
+	lateWrites, dropped, err := newLateWriteQuarantine(opts.MaxQuarantinedWrites(),
+		quarantineFilePath(fsOpts.FilePathPrefix(), id),
+		fsOpts.NewFileMode(), fsOpts.NewDirectoryMode())
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to create namespace %v, could not load late write quarantine: %v",
+			metadata.ID().String(), err)
+	}
+	if dropped > 0 {
+		logger.Warn("dropped quarantined late writes over the limit or corrupt",
+			zap.Int("dropped", dropped))
+	}
+
+	var index NamespaceIndex
 	if metadata.Options().IndexOptions().Enabled() {
 		index, err = newNamespaceIndex(metadata, namespaceRuntimeOptsMgr,
 			shardSet, opts)
@@ -391,6 +459,7 @@ func newDatabaseNamespace(
 		increasingIndex:        increasingIndex,
 		commitLogWriter:        commitLogWriter,
 		reverseIndex:           index,
+		lateWrites:             lateWrites,
 		tickWorkers:            tickWorkers,
 		tickWorkersConcurrency: tickWorkersConcurrency,
 		metrics:                newDatabaseNamespaceMetrics(scope, iops.TimerOptions()),
@@ -455,6 +524,7 @@ func (n *dbNamespace) reportStatusLoop(reportInterval time.Duration) {
 			n.metrics.status.index.numBlocks.Update(float64(n.statsLastTick.index.numBlocks))
 			n.metrics.status.index.numSegments.Update(float64(n.statsLastTick.index.numSegments))
 			n.statsLastTick.RUnlock()
+			n.metrics.lateWrites.quarantinedSize.Update(float64(n.lateWrites.Len()))
 		}
 	}
 }
@@ -752,6 +822,12 @@ func (n *dbNamespace) Write(
 		return SeriesWrite{}, err
 	}
 
+	quarantined, err := n.quarantineLateWrite(id, nil, timestamp, value, unit, annotation)
+	if quarantined || err != nil {
+		n.metrics.write.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+		return SeriesWrite{}, err
+	}
+
 	opts := series.WriteOptions{
 		TruncateType: n.opts.TruncateType(),
 		SchemaDesc:   nsCtx.Schema,
@@ -792,6 +868,12 @@ func (n *dbNamespace) WriteTagged(
 		return SeriesWrite{}, err
 	}
 
+	quarantined, err := n.quarantineLateWrite(id, &tagResolver, timestamp, value, unit, annotation)
+	if quarantined || err != nil {
+		n.metrics.writeTagged.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+		return SeriesWrite{}, err
+	}
+
 	opts := series.WriteOptions{
 		TruncateType: n.opts.TruncateType(),
 		SchemaDesc:   nsCtx.Schema,
@@ -805,6 +887,159 @@ func (n *dbNamespace) WriteTagged(
 	return seriesWrite, err
 }
 
+// quarantineLateWrite records writes that land outside of the buffer window
+// and quarantines them when cold writes are disabled and the namespace
+// quarantines late writes, returning whether the write was quarantined.
+// Writes out of retention are never quarantined and are rejected as usual.
+func (n *dbNamespace) quarantineLateWrite(
+	id ident.ID,
+	tagResolver *convert.TagMetadataResolver,
+	timestamp xtime.UnixNano,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+) (bool, error) {
+	var (
+		ropts       = n.nopts.RetentionOptions()
+		now         = xtime.ToUnixNano(n.nowFn())
+		pastLimit   = now.Add(-1 * ropts.BufferPast()).Truncate(time.Second)
+		futureLimit = now.Add(ropts.BufferFuture()).Truncate(time.Second)
+		inRetention bool
+	)
+	switch {
+	case timestamp.Before(pastLimit):
+		n.metrics.lateWrites.tooPast.Inc(1)
+		n.metrics.lateWrites.tooPastBy.RecordDuration(pastLimit.Sub(timestamp))
+		inRetention = !now.Add(-ropts.RetentionPeriod()).After(timestamp)
+	case !futureLimit.After(timestamp):
+		n.metrics.lateWrites.tooFuture.Inc(1)
+		n.metrics.lateWrites.tooFutureBy.RecordDuration(timestamp.Sub(futureLimit))
+		inRetention = now.Add(ropts.FutureRetentionPeriod()).After(timestamp)
+	default:
+		return false, nil
+	}
+
+	if !inRetention || n.nopts.ColdWritesEnabled() || !n.nopts.LateWriteQuarantineEnabled() {
+		return false, nil
+	}
+
+	var metadata *doc.Metadata
+	if tagResolver != nil {
+		resolved, err := tagResolver.Resolve(id)
+		if err != nil {
+			return false, err
+		}
+		metadata = &resolved
+	}
+
+	write := newQuarantinedWrite(id, metadata, timestamp, value, unit, annotation, now)
+	if err := n.lateWrites.Add(write); err != nil {
+		n.metrics.lateWrites.quarantineFull.Inc(1)
+		return false, err
+	}
+	n.metrics.lateWrites.quarantined.Inc(1)
+	return true, nil
+}
+
+func (n *dbNamespace) QuarantinedWrites(limit int) ([]QuarantinedWrite, int) {
+	return n.lateWrites.Writes(limit)
+}
+
+func (n *dbNamespace) PromoteQuarantinedWrites(ctx context.Context) (int, error) {
+	if n.ReadOnly() {
+		return 0, errNamespaceReadOnly
+	}
+
+	var (
+		quarantined = n.lateWrites.Take()
+		failed      []QuarantinedWrite
+		pending     []writes.PendingIndexInsert
+		multiErr    xerrors.MultiError
+	)
+	for _, write := range quarantined {
+		seriesWrite, err := n.promoteQuarantinedWrite(ctx, write)
+		if err != nil {
+			failed = append(failed, write)
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		if seriesWrite.NeedsIndex {
+			pending = append(pending, seriesWrite.PendingIndexInsert)
+		}
+	}
+	if len(pending) > 0 {
+		if err := n.WritePendingIndexInserts(pending); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+
+	// Keep the writes that failed to be promoted so they can be retried or
+	// discarded.
+	dropped, err := n.lateWrites.Restore(failed)
+	if err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	if dropped > 0 {
+		n.log.Warn("dropped quarantined late writes that failed to be promoted, quarantine full",
+			zap.Int("dropped", dropped))
+		n.metrics.lateWrites.discarded.Inc(int64(dropped))
+	}
+
+	promoted := len(quarantined) - len(failed)
+	if promoted > 0 {
+		n.Lock()
+		n.lateWritesPromotedAny = true
+		n.Unlock()
+	}
+	n.metrics.lateWrites.promoted.Inc(int64(promoted))
+	n.metrics.lateWrites.promoteErrors.Inc(int64(len(failed)))
+	return promoted, multiErr.FinalError()
+}
+
+func (n *dbNamespace) promoteQuarantinedWrite(
+	ctx context.Context,
+	write QuarantinedWrite,
+) (SeriesWrite, error) {
+	shard, nsCtx, err := n.shardFor(write.ID)
+	if err != nil {
+		return SeriesWrite{}, err
+	}
+
+	var (
+		timestamp = write.Datapoint.TimestampNanos
+		value     = write.Datapoint.Value
+		opts      = series.WriteOptions{
+			TruncateType:   n.opts.TruncateType(),
+			SchemaDesc:     nsCtx.Schema,
+			ForceColdWrite: true,
+			// Writes that fell out of retention while quarantined are dropped.
+			SkipOutOfRetention: true,
+		}
+		seriesWrite SeriesWrite
+	)
+	if write.tagged {
+		seriesWrite, err = shard.WriteTagged(ctx, write.ID,
+			convert.NewTagsMetadataResolver(write.Tags), timestamp,
+			value, write.Unit, write.Annotation, opts)
+	} else {
+		seriesWrite, err = shard.Write(ctx, write.ID, timestamp,
+			value, write.Unit, write.Annotation, opts)
+	}
+	if err != nil || !seriesWrite.WasWritten {
+		return seriesWrite, err
+	}
+
+	err = n.commitLogWriter.Write(ctx, seriesWrite.Series,
+		write.Datapoint, write.Unit, write.Annotation)
+	return seriesWrite, err
+}
+
+func (n *dbNamespace) DiscardQuarantinedWrites() (int, error) {
+	discarded, err := n.lateWrites.Discard()
+	n.metrics.lateWrites.discarded.Inc(int64(discarded))
+	return discarded, err
+}
+
 func (n *dbNamespace) WritePendingIndexInserts(
 	pending []writes.PendingIndexInsert,
 ) error {
@@ -895,6 +1130,29 @@ func (n *dbNamespace) AggregateQuery(
 	return res, err
 }
 
+func (n *dbNamespace) Cardinality(
+	ctx context.Context,
+	query index.Query,
+	opts index.CardinalityOptions,
+) (index.CardinalityQueryResult, error) {
+	callStart := n.nowFn()
+	if n.reverseIndex == nil {
+		n.metrics.cardinality.ReportError(n.nowFn().Sub(callStart))
+		return index.CardinalityQueryResult{}, errNamespaceIndexingDisabled
+	}
+
+	if !n.reverseIndex.Bootstrapped() {
+		// Similar to reading shard data, return not bootstrapped
+		n.metrics.cardinality.ReportError(n.nowFn().Sub(callStart))
+		return index.CardinalityQueryResult{},
+			xerrors.NewRetryableError(errIndexNotBootstrappedToRead)
+	}
+
+	res, err := n.reverseIndex.Cardinality(ctx, query, opts)
+	n.metrics.cardinality.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return res, err
+}
+
 func (n *dbNamespace) PrepareBootstrap(ctx context.Context) ([]databaseShard, error) {
 	ctx, span, sampled := ctx.StartSampledTraceSpan(tracepoint.NSPrepareBootstrap)
 	defer span.Finish()
@@ -937,6 +1195,7 @@ func (n *dbNamespace) ReadEncoded(
 	ctx context.Context,
 	id ident.ID,
 	start, end xtime.UnixNano,
+	opts ReadEncodedOptions,
 ) (series.BlockReaderIter, error) {
 	callStart := n.nowFn()
 	shard, nsCtx, err := n.readableShardFor(id)
@@ -944,11 +1203,53 @@ func (n *dbNamespace) ReadEncoded(
 		n.metrics.read.ReportError(n.nowFn().Sub(callStart))
 		return nil, err
 	}
-	res, err := shard.ReadEncoded(ctx, id, start, end, nsCtx)
+	res, err := shard.ReadEncoded(ctx, id, start, end, nsCtx, n.seriesReadOptions(shard, opts))
 	n.metrics.read.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
 	return res, err
 }
 
+// seriesReadOptions returns the options for reading a series of a shard,
+// reading compacted blocks from their rollups if the read allows for the
+// resolution of the rollups of the namespace.
+func (n *dbNamespace) seriesReadOptions(shard databaseShard, opts ReadEncodedOptions) series.ReadOptions {
+	if opts.Resolution <= 0 {
+		return series.ReadOptions{}
+	}
+	rollupManager := n.opts.RollupManager()
+	if rollupManager == nil {
+		return series.ReadOptions{}
+	}
+	metadata := n.Metadata()
+	rollupOpts := metadata.Options().RollupOptions()
+	if !rollupOpts.Enabled() || rollupOpts.Resolution() > opts.Resolution {
+		return series.ReadOptions{}
+	}
+	return series.ReadOptions{
+		Rollups: rollupReader{
+			manager:     rollupManager,
+			metadata:    metadata,
+			shard:       shard.ID(),
+			aggregation: opts.RollupAggregation,
+		},
+	}
+}
+
+// rollupReader reads the compacted blocks of a shard of a namespace from an
+// aggregation of their rollups.
+type rollupReader struct {
+	manager     rollup.Manager
+	metadata    namespace.Metadata
+	shard       uint32
+	aggregation rollup.Aggregation
+}
+
+func (r rollupReader) ReadRollup(
+	id ident.ID,
+	blockStart xtime.UnixNano,
+) ([]xio.BlockReader, bool, error) {
+	return r.manager.ReadBlock(r.metadata, r.shard, id, blockStart, r.aggregation)
+}
+
 func (n *dbNamespace) FetchBlocks(
 	ctx context.Context,
 	shardID uint32,
@@ -1273,11 +1574,15 @@ func (n *dbNamespace) ColdFlush(flushPersist persist.FlushPreparer) error {
 	}
 	nsCtx := n.nsContextWithRLock()
 	repairsAny := n.repairsAny
+	lateWritesPromotedAny := n.lateWritesPromotedAny
 	n.RUnlock()
 
 	// If repair has run we still need cold flush regardless of whether cold writes is
 	// enabled since repairs are dependent on the cold flushing logic.
-	enabled := n.nopts.ColdWritesEnabled() || repairsAny
+	// Similarly, deletes rely on cold flushes to remove deleted data from disk
+	// and promoted late writes are persisted as cold writes.
+	enabled := n.nopts.ColdWritesEnabled() || repairsAny || lateWritesPromotedAny ||
+		n.tombstonesPendingAny()
 	if n.ReadOnly() || !enabled {
 		n.metrics.flushColdData.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
@@ -1352,6 +1657,15 @@ func (n *dbNamespace) ColdFlush(flushPersist persist.FlushPreparer) error {
 	return res
 }
 
+func (n *dbNamespace) tombstonesPendingAny() bool {
+	for _, shard := range n.OwnedShards() {
+		if shard.TombstonesPending() {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *dbNamespace) FlushIndex(flush persist.IndexFlush) error {
 	callStart := n.nowFn()
 	n.RLock()
@@ -1373,6 +1687,55 @@ func (n *dbNamespace) FlushIndex(flush persist.IndexFlush) error {
 	return err
 }
 
+func (n *dbNamespace) FlushIndexDeletes(flush persist.IndexFlush) error {
+	callStart := n.nowFn()
+	n.RLock()
+	if n.bootstrapState != Bootstrapped {
+		n.RUnlock()
+		n.metrics.flushIndexDeletes.ReportError(n.nowFn().Sub(callStart))
+		return errNamespaceNotBootstrapped
+	}
+	n.RUnlock()
+
+	if n.ReadOnly() || n.reverseIndex == nil {
+		n.metrics.flushIndexDeletes.ReportSuccess(n.nowFn().Sub(callStart))
+		return nil
+	}
+
+	var (
+		shards      = n.OwnedShards()
+		blockStarts = make(map[xtime.UnixNano]struct{})
+		unindexed   = make(map[databaseShard][]xtime.UnixNano)
+	)
+	for _, shard := range shards {
+		starts := shard.DeletedBlockStartsUnindexed()
+		if len(starts) == 0 {
+			continue
+		}
+		unindexed[shard] = starts
+		for _, t := range starts {
+			blockStarts[t] = struct{}{}
+		}
+	}
+	if len(blockStarts) == 0 {
+		n.metrics.flushIndexDeletes.ReportSuccess(n.nowFn().Sub(callStart))
+		return nil
+	}
+
+	rebuild := make([]xtime.UnixNano, 0, len(blockStarts))
+	for t := range blockStarts {
+		rebuild = append(rebuild, t)
+	}
+	err := n.reverseIndex.RebuildFlushedBlocks(flush, shards, rebuild)
+	if err == nil {
+		for shard, starts := range unindexed {
+			shard.MarkDeletedBlockStartsIndexed(starts)
+		}
+	}
+	n.metrics.flushIndexDeletes.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return err
+}
+
 func (n *dbNamespace) Snapshot(
 	blockStarts []xtime.UnixNano,
 	snapshotTime xtime.UnixNano,
@@ -1503,6 +1866,67 @@ func (n *dbNamespace) Truncate() (int64, error) {
 	return totalNumSeries, nil
 }
 
+func (n *dbNamespace) DeleteTagged(
+	ctx context.Context,
+	query index.Query,
+	start, end xtime.UnixNano,
+) (map[uint32]int64, error) {
+	callStart := n.nowFn()
+	if n.reverseIndex == nil {
+		n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+		return nil, errNamespaceIndexingDisabled
+	}
+	if !n.reverseIndex.Bootstrapped() {
+		n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+		return nil, xerrors.NewRetryableError(errIndexNotBootstrappedToRead)
+	}
+
+	res, err := n.reverseIndex.Query(ctx, query, index.QueryOptions{
+		StartInclusive: start,
+		EndExclusive:   end,
+	})
+	if err != nil {
+		n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+		return nil, err
+	}
+
+	// Group the matched series by shard so that each shard persists its
+	// tombstones once.
+	idsByShard := make(map[uint32][]ident.ID)
+	n.RLock()
+	for _, entry := range res.Results.Map().Iter() {
+		id := ident.BytesID(entry.Key())
+		shardID := n.shardSet.Lookup(id)
+		idsByShard[shardID] = append(idsByShard[shardID], id)
+	}
+	n.RUnlock()
+
+	var (
+		numSeries = make(map[uint32]int64, len(idsByShard))
+		multiErr  = xerrors.NewMultiError()
+		tr        = xtime.Range{Start: start, End: end}
+	)
+	for shardID, ids := range idsByShard {
+		n.RLock()
+		shard, _, err := n.shardAtWithRLock(shardID)
+		n.RUnlock()
+		if err != nil {
+			// The index may still contain series of shards that are no
+			// longer owned by this node.
+			continue
+		}
+		if err := shard.DeleteSeries(ids, tr); err != nil {
+			multiErr = multiErr.Add(fmt.Errorf("shard %d failed to delete series: %w", shardID, err))
+			continue
+		}
+		numSeries[shardID] = int64(len(ids))
+	}
+
+	err = multiErr.FinalError()
+	n.metrics.deleteTagged.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return numSeries, err
+}
+
 func (n *dbNamespace) Repair(
 	repairer databaseShardRepairer,
 	tr xtime.Range,
@@ -1729,6 +2153,10 @@ func (n *dbNamespace) Close() error {
 		}
 	}
 	close(n.shutdownCh)
+	if err := n.lateWrites.Close(); err != nil {
+		n.log.Error("error when closing late write quarantine",
+			zap.Error(err), zap.Stringer("namespace", n.id))
+	}
 	if n.reverseIndex != nil {
 		return n.reverseIndex.Close()
 	}
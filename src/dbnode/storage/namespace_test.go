@@ -24,6 +24,7 @@ import (
 	stdlibctx "context"
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"testing"
 	"time"
@@ -38,6 +39,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
@@ -275,6 +277,143 @@ func TestNamespaceWriteShardOwned(t *testing.T) {
 	}
 }
 
+func TestNamespaceWriteLateQuarantine(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	ns, closer := newTestNamespaceWithOpts(t,
+		defaultTestNs1Opts.SetLateWriteQuarantineEnabled(true),
+		newTestQuarantineOptions(t))
+	defer closer()
+
+	var (
+		id    = ident.StringID("foo")
+		now   = xtime.ToUnixNano(ns.nowFn())
+		ropts = ns.nopts.RetentionOptions()
+		late  = now.Add(-ropts.BufferPast() - time.Minute)
+		shard = NewMockdatabaseShard(ctrl)
+	)
+	ns.shards[testShardIDs[0].ID()] = shard
+
+	// Late writes are quarantined without reaching the shard.
+	seriesWrite, err := ns.Write(ctx, id, late, 1.0, xtime.Second, nil)
+	require.NoError(t, err)
+	require.False(t, seriesWrite.WasWritten)
+
+	writes, total := ns.QuarantinedWrites(0)
+	require.Equal(t, 1, total)
+	require.Len(t, writes, 1)
+	require.Equal(t, "foo", writes[0].ID.String())
+	require.Equal(t, late, writes[0].Datapoint.TimestampNanos)
+	require.Equal(t, 1.0, writes[0].Datapoint.Value)
+
+	// Writes within the buffer window still go to the shard.
+	opts := series.WriteOptions{TruncateType: ns.opts.TruncateType()}
+	shard.EXPECT().Write(ctx, id, now, 2.0, xtime.Second, nil, opts).
+		Return(SeriesWrite{WasWritten: true}, nil)
+	seriesWrite, err = ns.Write(ctx, id, now, 2.0, xtime.Second, nil)
+	require.NoError(t, err)
+	require.True(t, seriesWrite.WasWritten)
+
+	// Promoting forces the quarantined writes in as cold writes.
+	opts.ForceColdWrite = true
+	opts.SkipOutOfRetention = true
+	shard.EXPECT().Write(ctx, ident.NewIDMatcher("foo"), late, 1.0, xtime.Second, nil, opts).
+		Return(SeriesWrite{}, nil)
+	promoted, err := ns.PromoteQuarantinedWrites(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, promoted)
+	require.True(t, ns.lateWritesPromotedAny)
+
+	_, total = ns.QuarantinedWrites(0)
+	require.Equal(t, 0, total)
+
+	_, err = ns.Write(ctx, id, late, 3.0, xtime.Second, nil)
+	require.NoError(t, err)
+	discarded, err := ns.DiscardQuarantinedWrites()
+	require.NoError(t, err)
+	require.Equal(t, 1, discarded)
+	_, total = ns.QuarantinedWrites(0)
+	require.Equal(t, 0, total)
+}
+
+func newTestQuarantineOptions(t *testing.T) Options {
+	opts := DefaultTestOptions().SetRuntimeOptionsManager(runtime.NewOptionsManager())
+	fsOpts := opts.CommitLogOptions().FilesystemOptions().
+		SetFilePathPrefix(t.TempDir())
+	return opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+}
+
+func TestNamespaceWriteLateQuarantineRestart(t *testing.T) {
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	var (
+		nsOpts = defaultTestNs1Opts.SetLateWriteQuarantineEnabled(true)
+		opts   = newTestQuarantineOptions(t)
+	)
+	ns, closer := newTestNamespaceWithOpts(t, nsOpts, opts)
+	defer closer()
+
+	var (
+		now  = xtime.ToUnixNano(ns.nowFn())
+		late = now.Add(-ns.nopts.RetentionOptions().BufferPast() - time.Minute)
+	)
+	_, err := ns.Write(ctx, ident.StringID("foo"), late, 1.0, xtime.Second, []byte("a"))
+	require.NoError(t, err)
+	_, err = ns.Write(ctx, ident.StringID("bar"), late, math.NaN(), xtime.Second, nil)
+	require.NoError(t, err)
+	require.NoError(t, ns.lateWrites.Close())
+
+	// Acknowledged late writes are quarantined again after a restart.
+	restarted, restartedCloser := newTestNamespaceWithOpts(t, nsOpts, opts)
+	defer restartedCloser()
+
+	writes, total := restarted.QuarantinedWrites(0)
+	require.Equal(t, 2, total)
+	require.Equal(t, "foo", writes[0].ID.String())
+	require.Equal(t, late, writes[0].Datapoint.TimestampNanos)
+	require.Equal(t, 1.0, writes[0].Datapoint.Value)
+	require.Equal(t, ts.Annotation("a"), writes[0].Annotation)
+	require.Equal(t, "bar", writes[1].ID.String())
+	require.True(t, math.IsNaN(writes[1].Datapoint.Value))
+
+	discarded, err := restarted.DiscardQuarantinedWrites()
+	require.NoError(t, err)
+	require.Equal(t, 2, discarded)
+	require.NoError(t, restarted.lateWrites.Close())
+
+	restarted, restartedCloser = newTestNamespaceWithOpts(t, nsOpts, opts)
+	defer restartedCloser()
+	_, total = restarted.QuarantinedWrites(0)
+	require.Equal(t, 0, total)
+}
+
+func TestNamespaceWriteLateQuarantineFull(t *testing.T) {
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	ns, closer := newTestNamespaceWithOpts(t,
+		defaultTestNs1Opts.SetLateWriteQuarantineEnabled(true),
+		newTestQuarantineOptions(t).SetMaxQuarantinedWrites(1))
+	defer closer()
+
+	var (
+		id   = ident.StringID("foo")
+		now  = xtime.ToUnixNano(ns.nowFn())
+		late = now.Add(-ns.nopts.RetentionOptions().BufferPast() - time.Minute)
+	)
+	_, err := ns.Write(ctx, id, late, 1.0, xtime.Second, nil)
+	require.NoError(t, err)
+
+	_, err = ns.Write(ctx, id, late, 2.0, xtime.Second, nil)
+	require.Equal(t, errLateWriteQuarantineFull, err)
+	require.True(t, xerrors.IsInvalidParams(err))
+}
+
 func TestNamespaceReadEncodedShardNotOwned(t *testing.T) {
 	ctx := context.NewBackground()
 	defer ctx.Close()
@@ -287,7 +426,7 @@ func TestNamespaceReadEncodedShardNotOwned(t *testing.T) {
 	}
 
 	now := xtime.Now()
-	_, err := ns.ReadEncoded(ctx, ident.StringID("foo"), now, now)
+	_, err := ns.ReadEncoded(ctx, ident.StringID("foo"), now, now, ReadEncodedOptions{})
 	require.Error(t, err)
 }
 
@@ -306,15 +445,15 @@ func TestNamespaceReadEncodedShardOwned(t *testing.T) {
 	defer closer()
 
 	shard := NewMockdatabaseShard(ctrl)
-	shard.EXPECT().ReadEncoded(ctx, id, start, end, gomock.Any()).Return(nil, nil)
+	shard.EXPECT().ReadEncoded(ctx, id, start, end, gomock.Any(), series.ReadOptions{}).Return(nil, nil)
 	ns.shards[testShardIDs[0].ID()] = shard
 
 	shard.EXPECT().IsBootstrapped().Return(true)
-	_, err := ns.ReadEncoded(ctx, id, start, end)
+	_, err := ns.ReadEncoded(ctx, id, start, end, ReadEncodedOptions{})
 	require.NoError(t, err)
 
 	shard.EXPECT().IsBootstrapped().Return(false)
-	_, err = ns.ReadEncoded(ctx, id, start, end)
+	_, err = ns.ReadEncoded(ctx, id, start, end, ReadEncodedOptions{})
 	require.Error(t, err)
 	require.True(t, xerrors.IsRetryableError(err))
 	require.Equal(t, errShardNotBootstrappedToRead, xerrors.GetInnerRetryableError(err))
@@ -669,6 +808,7 @@ func TestNamespaceFlushSkipShardNotBootstrapped(t *testing.T) {
 	shard := NewMockdatabaseShard(ctrl)
 	shard.EXPECT().ID().Return(testShardIDs[0].ID()).AnyTimes()
 	shard.EXPECT().IsBootstrapped().Return(false)
+	shard.EXPECT().TombstonesPending().Return(false)
 	ns.shards[testShardIDs[0].ID()] = shard
 
 	err := ns.WarmFlush(blockStart, nil)
@@ -1522,6 +1662,42 @@ func TestNamespaceTicksIndex(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNamespaceFlushIndexDeletes(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMockNamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	ns.bootstrapState = Bootstrapped
+	for i := range ns.shards {
+		ns.shards[i] = nil
+	}
+
+	blockStart := xtime.Now().Truncate(ns.Options().RetentionOptions().BlockSize())
+	deleted := NewMockdatabaseShard(ctrl)
+	deleted.EXPECT().DeletedBlockStartsUnindexed().
+		Return([]xtime.UnixNano{blockStart}).Times(2)
+	untouched := NewMockdatabaseShard(ctrl)
+	untouched.EXPECT().DeletedBlockStartsUnindexed().Return(nil).Times(2)
+	ns.shards[testShardIDs[0].ID()] = deleted
+	ns.shards[testShardIDs[1].ID()] = untouched
+
+	// A failed rebuild leaves the block starts to be retried.
+	shards := ns.OwnedShards()
+	idx.EXPECT().
+		RebuildFlushedBlocks(nil, shards, []xtime.UnixNano{blockStart}).
+		Return(errors.New("an error"))
+	require.Error(t, ns.FlushIndexDeletes(nil))
+
+	idx.EXPECT().
+		RebuildFlushedBlocks(nil, shards, []xtime.UnixNano{blockStart}).
+		Return(nil)
+	deleted.EXPECT().MarkDeletedBlockStartsIndexed([]xtime.UnixNano{blockStart})
+	require.NoError(t, ns.FlushIndexDeletes(nil))
+}
+
 func TestNamespaceIndexDisabledQuery(t *testing.T) {
 	ns, closer := newTestNamespace(t)
 	defer closer()
@@ -32,12 +32,16 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tiering"
+	"github.com/m3db/m3/src/dbnode/replication"
 	"github.com/m3db/m3/src/dbnode/retention"
 	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/dbnode/storage/limits/admission"
 	"github.com/m3db/m3/src/dbnode/storage/limits/permits"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
@@ -77,6 +81,10 @@ const (
 	defaultNumLoadedBytesLimit = 2 << 30
 
 	defaultMediatorTickInterval = 5 * time.Second
+
+	// defaultMaxQuarantinedWrites is the default maximum number of late writes
+	// quarantined per namespace.
+	defaultMaxQuarantinedWrites = 100000
 )
 
 var (
@@ -96,6 +104,7 @@ var (
 	errBlockLeaserNotSet          = errors.New("block leaser is not set")
 	errOnColdFlushNotSet          = errors.New("on cold flush is not set, requires at least a no-op implementation")
 	errLimitsOptionsNotSet        = errors.New("limits options are not set")
+	errWriteAdmissionOptsNotSet   = errors.New("write admission options are not set")
 )
 
 // NewSeriesOptionsFromOptions creates a new set of database series options from provided options.
@@ -139,6 +148,9 @@ type options struct {
 	bootstrapProcessProvider        bootstrap.ProcessProvider
 	persistManager                  persist.Manager
 	indexClaimsManager              fs.IndexClaimsManager
+	tieringManager                  tiering.Manager
+	rollupManager                   rollup.Manager
+	commitLogReplicator             replication.Replicator
 	blockRetrieverManager           block.DatabaseBlockRetrieverManager
 	poolOpts                        pool.ObjectPoolOptions
 	contextPool                     context.Pool
@@ -162,6 +174,7 @@ type options struct {
 	blockLeaseManager               block.LeaseManager
 	onColdFlush                     OnColdFlush
 	forceColdWritesEnabled          bool
+	maxQuarantinedWrites            int
 	sourceLoggerBuilder             limits.SourceLoggerBuilder
 	iterationOptions                index.IterationOptions
 	memoryTracker                   MemoryTracker
@@ -175,6 +188,7 @@ type options struct {
 	tileAggregator                  TileAggregator
 	permitsOptions                  permits.Options
 	limitsOptions                   limits.Options
+	writeAdmissionOptions           admission.Options
 	coreFn                          xsync.CoreFn
 	tickOptions                     TickOptions
 }
@@ -247,10 +261,12 @@ func newOptions(poolOpts pool.ObjectPoolOptions) Options {
 		memoryTracker:                   NewMemoryTracker(NewMemoryTrackerOptions(defaultNumLoadedBytesLimit)),
 		namespaceRuntimeOptsMgrRegistry: namespace.NewRuntimeOptionsManagerRegistry(),
 		mediatorTickInterval:            defaultMediatorTickInterval,
+		maxQuarantinedWrites:            defaultMaxQuarantinedWrites,
 		namespaceHooks:                  &noopNamespaceHooks{},
 		tileAggregator:                  &noopTileAggregator{},
 		permitsOptions:                  permits.NewOptions(),
 		limitsOptions:                   limits.DefaultLimitsOptions(iOpts),
+		writeAdmissionOptions:           admission.NewOptions().SetInstrumentOptions(iOpts),
 		coreFn:                          xsync.CPUCore,
 	}
 	return o.SetEncodingM3TSZPooled()
@@ -318,6 +334,13 @@ func (o *options) Validate() error {
 		return errLimitsOptionsNotSet
 	}
 
+	if o.writeAdmissionOptions == nil {
+		return errWriteAdmissionOptsNotSet
+	}
+	if err := o.writeAdmissionOptions.Validate(); err != nil {
+		return fmt.Errorf("invalid write admission options: %w", err)
+	}
+
 	return nil
 }
 
@@ -570,6 +593,36 @@ func (o *options) IndexClaimsManager() fs.IndexClaimsManager {
 	return o.indexClaimsManager
 }
 
+func (o *options) SetTieringManager(value tiering.Manager) Options {
+	opts := *o
+	opts.tieringManager = value
+	return &opts
+}
+
+func (o *options) TieringManager() tiering.Manager {
+	return o.tieringManager
+}
+
+func (o *options) SetRollupManager(value rollup.Manager) Options {
+	opts := *o
+	opts.rollupManager = value
+	return &opts
+}
+
+func (o *options) RollupManager() rollup.Manager {
+	return o.rollupManager
+}
+
+func (o *options) SetCommitLogReplicator(value replication.Replicator) Options {
+	opts := *o
+	opts.commitLogReplicator = value
+	return &opts
+}
+
+func (o *options) CommitLogReplicator() replication.Replicator {
+	return o.commitLogReplicator
+}
+
 func (o *options) SetDatabaseBlockRetrieverManager(value block.DatabaseBlockRetrieverManager) Options {
 	opts := *o
 	opts.blockRetrieverManager = value
@@ -791,6 +844,16 @@ func (o *options) ForceColdWritesEnabled() bool {
 	return o.forceColdWritesEnabled
 }
 
+func (o *options) SetMaxQuarantinedWrites(value int) Options {
+	opts := *o
+	opts.maxQuarantinedWrites = value
+	return &opts
+}
+
+func (o *options) MaxQuarantinedWrites() int {
+	return o.maxQuarantinedWrites
+}
+
 func (o *options) SetSourceLoggerBuilder(value limits.SourceLoggerBuilder) Options {
 	opts := *o
 	opts.sourceLoggerBuilder = value
@@ -921,6 +984,16 @@ func (o *options) SetLimitsOptions(value limits.Options) Options {
 	return &opts
 }
 
+func (o *options) WriteAdmissionOptions() admission.Options {
+	return o.writeAdmissionOptions
+}
+
+func (o *options) SetWriteAdmissionOptions(value admission.Options) Options {
+	opts := *o
+	opts.writeAdmissionOptions = value
+	return &opts
+}
+
 func (o *options) TileAggregator() TileAggregator {
 	return o.tileAggregator
 }
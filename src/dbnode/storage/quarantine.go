@@ -0,0 +1,411 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	quarantineDirName    = "quarantine"
+	quarantineTmpFileExt = ".tmp"
+)
+
+var errLateWriteQuarantineFull = xerrors.NewInvalidParamsError(
+	errors.New("datapoint outside of buffer window and late write quarantine is full"))
+
+// QuarantinedWrite is a write that landed outside of the buffer window of a
+// namespace with cold writes disabled and was quarantined instead of being
+// rejected.
+type QuarantinedWrite struct {
+	// ID is the series ID.
+	ID ident.ID
+	// Tags are the series tags, nil if the write was not tagged.
+	Tags ident.Tags
+	// Datapoint is the datapoint written.
+	Datapoint ts.Datapoint
+	// Unit is the time unit of the datapoint.
+	Unit xtime.Unit
+	// Annotation is the annotation of the datapoint.
+	Annotation ts.Annotation
+	// QuarantinedAt is when the write was quarantined.
+	QuarantinedAt xtime.UnixNano
+
+	tagged bool
+}
+
+// newQuarantinedWrite returns a quarantined write which owns copies of the
+// given series ID, tags and annotation since those are only valid for the
+// lifetime of the write request.
+func newQuarantinedWrite(
+	id ident.ID,
+	metadata *doc.Metadata,
+	timestamp xtime.UnixNano,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+	now xtime.UnixNano,
+) QuarantinedWrite {
+	w := QuarantinedWrite{
+		ID: ident.BytesID(append([]byte(nil), id.Bytes()...)),
+		Datapoint: ts.Datapoint{
+			TimestampNanos: timestamp,
+			Value:          value,
+		},
+		Unit:          unit,
+		QuarantinedAt: now,
+	}
+	if len(annotation) > 0 {
+		w.Annotation = append([]byte(nil), annotation...)
+	}
+	if metadata != nil {
+		w.tagged = true
+		w.Tags = ident.NewTags()
+		for _, f := range metadata.Fields {
+			w.Tags.Append(ident.StringTag(string(f.Name), string(f.Value)))
+		}
+	}
+	return w
+}
+
+// quarantineFilePath returns the path of the file the quarantined late
+// writes of a namespace are persisted to.
+func quarantineFilePath(prefix string, namespace ident.ID) string {
+	return path.Join(prefix, quarantineDirName, namespace.String())
+}
+
+// quarantinedWriteEntry is the persisted form of a quarantined write, the
+// value is stored as its bits since JSON can not represent NaN values.
+type quarantinedWriteEntry struct {
+	ID            []byte           `json:"id"`
+	Tagged        bool             `json:"tagged,omitempty"`
+	Tags          []quarantinedTag `json:"tags,omitempty"`
+	Timestamp     xtime.UnixNano   `json:"timestamp"`
+	ValueBits     uint64           `json:"valueBits"`
+	Unit          xtime.Unit       `json:"unit"`
+	Annotation    []byte           `json:"annotation,omitempty"`
+	QuarantinedAt xtime.UnixNano   `json:"quarantinedAt"`
+}
+
+type quarantinedTag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func newQuarantinedWriteEntry(w QuarantinedWrite) quarantinedWriteEntry {
+	e := quarantinedWriteEntry{
+		ID:            w.ID.Bytes(),
+		Tagged:        w.tagged,
+		Timestamp:     w.Datapoint.TimestampNanos,
+		ValueBits:     math.Float64bits(w.Datapoint.Value),
+		Unit:          w.Unit,
+		Annotation:    w.Annotation,
+		QuarantinedAt: w.QuarantinedAt,
+	}
+	for _, tag := range w.Tags.Values() {
+		e.Tags = append(e.Tags, quarantinedTag{
+			Name:  tag.Name.String(),
+			Value: tag.Value.String(),
+		})
+	}
+	return e
+}
+
+func (e quarantinedWriteEntry) write() QuarantinedWrite {
+	w := QuarantinedWrite{
+		ID: ident.BytesID(e.ID),
+		Datapoint: ts.Datapoint{
+			TimestampNanos: e.Timestamp,
+			Value:          math.Float64frombits(e.ValueBits),
+		},
+		Unit:          e.Unit,
+		Annotation:    e.Annotation,
+		QuarantinedAt: e.QuarantinedAt,
+		tagged:        e.Tagged,
+	}
+	if e.Tagged {
+		w.Tags = ident.NewTags()
+		for _, tag := range e.Tags {
+			w.Tags.Append(ident.StringTag(tag.Name, tag.Value))
+		}
+	}
+	return w
+}
+
+// lateWriteQuarantine holds the quarantined late writes of a namespace in
+// memory until they are promoted or discarded. Quarantined writes are not
+// written to the commit log, instead each write is appended to a file and
+// synced before it is acknowledged so that the quarantine survives restarts.
+// The file is rewritten from memory once writes are promoted or discarded,
+// so writes taken for promotion are promoted again if the process exits
+// before then.
+type lateWriteQuarantine struct {
+	sync.Mutex
+
+	maxWrites int
+	writes    []QuarantinedWrite
+
+	filePath string
+	fileMode os.FileMode
+	dirMode  os.FileMode
+	file     *os.File
+}
+
+// newLateWriteQuarantine returns a quarantine persisted to the given file,
+// or held only in memory if the file path is empty, along with the writes
+// loaded from the file. Writes over the limit or that can not be decoded
+// are dropped, the number of which is returned.
+func newLateWriteQuarantine(
+	maxWrites int,
+	filePath string,
+	fileMode os.FileMode,
+	dirMode os.FileMode,
+) (*lateWriteQuarantine, int, error) {
+	q := &lateWriteQuarantine{
+		maxWrites: maxWrites,
+		filePath:  filePath,
+		fileMode:  fileMode,
+		dirMode:   dirMode,
+	}
+	if filePath == "" {
+		return q, 0, nil
+	}
+	dropped, err := q.load()
+	if err != nil {
+		return nil, 0, err
+	}
+	if dropped > 0 {
+		if err := q.rewriteWithLock(); err != nil {
+			return nil, 0, err
+		}
+	}
+	return q, dropped, nil
+}
+
+func (q *lateWriteQuarantine) load() (int, error) {
+	f, err := os.Open(q.filePath)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var (
+		dropped int
+		reader  = bufio.NewReader(f)
+	)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			// A write interrupted by a crash leaves a truncated last line,
+			// which was never acknowledged.
+			var entry quarantinedWriteEntry
+			if jsonErr := json.Unmarshal(line, &entry); jsonErr != nil {
+				dropped++
+			} else if len(q.writes) >= q.maxWrites {
+				dropped++
+			} else {
+				q.writes = append(q.writes, entry.write())
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return dropped, nil
+			}
+			return 0, err
+		}
+	}
+}
+
+// Add quarantines a write, returning an error if the quarantine is full or
+// the write could not be persisted.
+func (q *lateWriteQuarantine) Add(w QuarantinedWrite) error {
+	q.Lock()
+	defer q.Unlock()
+	if len(q.writes) >= q.maxWrites {
+		return errLateWriteQuarantineFull
+	}
+	if err := q.appendWithLock(w); err != nil {
+		return err
+	}
+	q.writes = append(q.writes, w)
+	return nil
+}
+
+func (q *lateWriteQuarantine) appendWithLock(w QuarantinedWrite) error {
+	if q.filePath == "" {
+		return nil
+	}
+	line, err := json.Marshal(newQuarantinedWriteEntry(w))
+	if err != nil {
+		return err
+	}
+	if q.file == nil {
+		if err := os.MkdirAll(filepath.Dir(q.filePath), q.dirMode); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(q.filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, q.fileMode)
+		if err != nil {
+			return err
+		}
+		q.file = f
+	}
+	if _, err := q.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return q.file.Sync()
+}
+
+// rewriteWithLock replaces the file with the writes held in memory.
+func (q *lateWriteQuarantine) rewriteWithLock() error {
+	if q.filePath == "" {
+		return nil
+	}
+	if q.file != nil {
+		err := q.file.Close()
+		q.file = nil
+		if err != nil {
+			return err
+		}
+	}
+	if len(q.writes) == 0 {
+		if err := os.Remove(q.filePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	tmpPath := q.filePath + quarantineTmpFileExt
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, q.fileMode)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath)
+
+	w := bufio.NewWriter(f)
+	for _, write := range q.writes {
+		line, err := json.Marshal(newQuarantinedWriteEntry(write))
+		if err != nil {
+			f.Close()
+			return err
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, q.filePath)
+}
+
+// Writes returns the oldest quarantined writes up to the limit, or all of
+// them if the limit is not positive, along with the number of quarantined
+// writes.
+func (q *lateWriteQuarantine) Writes(limit int) ([]QuarantinedWrite, int) {
+	q.Lock()
+	defer q.Unlock()
+	n := len(q.writes)
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	result := make([]QuarantinedWrite, n)
+	copy(result, q.writes)
+	return result, len(q.writes)
+}
+
+// Len returns the number of quarantined writes.
+func (q *lateWriteQuarantine) Len() int {
+	q.Lock()
+	defer q.Unlock()
+	return len(q.writes)
+}
+
+// Take removes and returns all quarantined writes, they remain persisted
+// until Restore is called with the writes that failed to be promoted.
+func (q *lateWriteQuarantine) Take() []QuarantinedWrite {
+	q.Lock()
+	defer q.Unlock()
+	writes := q.writes
+	q.writes = nil
+	return writes
+}
+
+// Restore puts writes that failed to be promoted back into the quarantine,
+// ahead of any writes quarantined since they were taken, and persists the
+// quarantine. The oldest writes are dropped if the quarantine would exceed
+// its limit, the number of which is returned.
+func (q *lateWriteQuarantine) Restore(writes []QuarantinedWrite) (int, error) {
+	q.Lock()
+	defer q.Unlock()
+	q.writes = append(writes, q.writes...)
+	dropped := 0
+	if len(q.writes) > q.maxWrites {
+		dropped = len(q.writes) - q.maxWrites
+		q.writes = q.writes[dropped:]
+	}
+	return dropped, q.rewriteWithLock()
+}
+
+// Discard removes all quarantined writes, returning the number removed.
+func (q *lateWriteQuarantine) Discard() (int, error) {
+	q.Lock()
+	defer q.Unlock()
+	discarded := len(q.writes)
+	q.writes = nil
+	return discarded, q.rewriteWithLock()
+}
+
+// Close closes the file the quarantine is persisted to.
+func (q *lateWriteQuarantine) Close() error {
+	q.Lock()
+	defer q.Unlock()
+	if q.file == nil {
+		return nil
+	}
+	err := q.file.Close()
+	q.file = nil
+	return err
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQuarantinedWrite(id string, value float64) QuarantinedWrite {
+	return newQuarantinedWrite(ident.StringID(id), nil, xtime.Now(), value,
+		xtime.Second, nil, xtime.Now())
+}
+
+func quarantinedIDs(writes []QuarantinedWrite) []string {
+	ids := make([]string, 0, len(writes))
+	for _, w := range writes {
+		ids = append(ids, w.ID.String())
+	}
+	return ids
+}
+
+func TestLateWriteQuarantineRestoreCapped(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "ns")
+	q, dropped, err := newLateWriteQuarantine(3, filePath, 0o666, 0o755)
+	require.NoError(t, err)
+	require.Equal(t, 0, dropped)
+
+	require.NoError(t, q.Add(newTestQuarantinedWrite("a", 1)))
+	require.NoError(t, q.Add(newTestQuarantinedWrite("b", 2)))
+	taken := q.Take()
+	require.NoError(t, q.Add(newTestQuarantinedWrite("c", 3)))
+	require.NoError(t, q.Add(newTestQuarantinedWrite("d", 4)))
+
+	// Restoring the writes that failed to be promoted keeps the quarantine
+	// within its limit, dropping the oldest writes.
+	dropped, err = q.Restore(taken)
+	require.NoError(t, err)
+	require.Equal(t, 1, dropped)
+	writes, total := q.Writes(0)
+	require.Equal(t, 3, total)
+	require.Equal(t, []string{"b", "c", "d"}, quarantinedIDs(writes))
+	require.NoError(t, q.Close())
+
+	loaded, dropped, err := newLateWriteQuarantine(3, filePath, 0o666, 0o755)
+	require.NoError(t, err)
+	require.Equal(t, 0, dropped)
+	writes, _ = loaded.Writes(0)
+	require.Equal(t, []string{"b", "c", "d"}, quarantinedIDs(writes))
+}
+
+func TestLateWriteQuarantineLoadTruncated(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "ns")
+	q, _, err := newLateWriteQuarantine(2, filePath, 0o666, 0o755)
+	require.NoError(t, err)
+	require.NoError(t, q.Add(newTestQuarantinedWrite("a", 1)))
+	require.NoError(t, q.Close())
+
+	// A write interrupted by a crash leaves a truncated last line.
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"id":"Yg==","times`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	loaded, dropped, err := newLateWriteQuarantine(2, filePath, 0o666, 0o755)
+	require.NoError(t, err)
+	require.Equal(t, 1, dropped)
+	writes, _ := loaded.Writes(0)
+	require.Equal(t, []string{"a"}, quarantinedIDs(writes))
+
+	// The file is rewritten without the truncated write.
+	require.NoError(t, loaded.Add(newTestQuarantinedWrite("c", 3)))
+	require.NoError(t, loaded.Close())
+	loaded, dropped, err = newLateWriteQuarantine(2, filePath, 0o666, 0o755)
+	require.NoError(t, err)
+	require.Equal(t, 0, dropped)
+	writes, _ = loaded.Writes(0)
+	require.Equal(t, []string{"a", "c"}, quarantinedIDs(writes))
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/x/clock"
@@ -75,8 +76,10 @@ type shardRepairer struct {
 }
 
 type shardRepairerMetrics struct {
-	runDefault     tally.Counter
-	runOnlyCompare tally.Counter
+	runDefault             tally.Counter
+	runOnlyCompare         tally.Counter
+	merkleMismatchedLeaves tally.Counter
+	merklePeerErrors       tally.Counter
 }
 
 func newShardRepairerMetrics(scope tally.Scope) shardRepairerMetrics {
@@ -87,6 +90,8 @@ func newShardRepairerMetrics(scope tally.Scope) shardRepairerMetrics {
 		runOnlyCompare: scope.Tagged(map[string]string{
 			"repair_type": "only_compare",
 		}).Counter("run"),
+		merkleMismatchedLeaves: scope.Counter("merkle-mismatched-leaves"),
+		merklePeerErrors:       scope.Counter("merkle-peer-errors"),
 	}
 }
 
@@ -212,26 +217,37 @@ func (r shardRepairer) Repair(
 		}
 	}
 
-	localIter := block.NewFilteredBlocksMetadataIter(accumLocalMetadata)
-	err = metadata.AddLocalMetadata(localIter)
-	if err != nil {
-		return repair.MetadataComparisonResult{}, err
-	}
-
 	var (
 		rsOpts = r.opts.RepairOptions().ResultOptions()
 		level  = r.rpopts.RepairConsistencyLevel()
 	)
-	for _, sesTopo := range sessions {
-		// Add peer metadata.
-		peerIter, err := sesTopo.session.FetchBlocksMetadataFromPeers(nsCtx.ID, shard.ID(), start, end,
-			level, rsOpts)
+	if depth := r.rpopts.MerkleTreeDepth(); depth > 0 {
+		// Only compare the metadata of the series whose merkle tree leaves
+		// differ from those of peers.
+		blockSize := nsMeta.Options().RetentionOptions().BlockSize()
+		err := r.addMerkleTreeDifferences(metadata, accumLocalMetadata, sessions, origin,
+			nsCtx.ID, shard.ID(), tr, blockSize, depth)
 		if err != nil {
 			return repair.MetadataComparisonResult{}, err
 		}
-		if err := metadata.AddPeerMetadata(peerIter); err != nil {
+	} else {
+		localIter := block.NewFilteredBlocksMetadataIter(accumLocalMetadata)
+		err = metadata.AddLocalMetadata(localIter)
+		if err != nil {
 			return repair.MetadataComparisonResult{}, err
 		}
+
+		for _, sesTopo := range sessions {
+			// Add peer metadata.
+			peerIter, err := sesTopo.session.FetchBlocksMetadataFromPeers(nsCtx.ID, shard.ID(), start, end,
+				level, rsOpts)
+			if err != nil {
+				return repair.MetadataComparisonResult{}, err
+			}
+			if err := metadata.AddPeerMetadata(peerIter); err != nil {
+				return repair.MetadataComparisonResult{}, err
+			}
+		}
 	}
 
 	var (
@@ -618,7 +634,7 @@ func (r *dbRepairer) run() {
 func (r *dbRepairer) namespaceRepairTimeRange(ns databaseNamespace) xtime.Range {
 	var (
 		now    = xtime.ToUnixNano(r.nowFn())
-		rtopts = ns.Options().RetentionOptions()
+		rtopts = namespace.BlockRetentionOptions(ns.Options())
 	)
 	return xtime.Range{
 		Start: retention.FlushTimeStart(rtopts, now),
@@ -847,7 +863,7 @@ func (r shardRepairer) shadowCompare(
 		readCtx.Reset()
 		defer readCtx.BlockingCloseReset()
 
-		iter, err := shard.ReadEncoded(readCtx, seriesID, start, end, nsCtx)
+		iter, err := shard.ReadEncoded(readCtx, seriesID, start, end, nsCtx, series.ReadOptions{})
 		if err != nil {
 			return err
 		}
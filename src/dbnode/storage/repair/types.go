@@ -410,6 +410,14 @@ type Options interface {
 	// DebugShadowComparisonsPercentage returns the debug shadow comparisons percentage.
 	DebugShadowComparisonsPercentage() float64
 
+	// SetMerkleTreeDepth sets the depth of the merkle trees compared with peers
+	// to find the series that differ, zero compares the metadata of every series.
+	SetMerkleTreeDepth(value int) Options
+
+	// MerkleTreeDepth returns the depth of the merkle trees compared with peers
+	// to find the series that differ, zero compares the metadata of every series.
+	MerkleTreeDepth() int
+
 	// Validate checks if the options are valid.
 	Validate() error
 }
@@ -0,0 +1,208 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"go.uber.org/zap"
+)
+
+type merkleRepairPeer struct {
+	session client.AdminSession
+	host    topology.Host
+}
+
+// addMerkleTreeDifferences compares the merkle tree of each block with the
+// trees of peers and adds to the comparer the local and peer metadata of only
+// the series that belong to leaves that differ with any of the peers.
+func (r shardRepairer) addMerkleTreeDifferences(
+	metadata repair.ReplicaMetadataComparer,
+	localMetadata block.FetchBlocksMetadataResults,
+	sessions []sessionAndTopo,
+	origin topology.Host,
+	nsID ident.ID,
+	shardID uint32,
+	tr xtime.Range,
+	blockSize time.Duration,
+	depth int,
+) error {
+	var peers []merkleRepairPeer
+	for _, sesTopo := range sessions {
+		session := sesTopo.session
+		err := sesTopo.topo.RouteShardForEach(shardID, func(
+			_ int,
+			_ shard.Shard,
+			host topology.Host,
+		) {
+			if host.ID() == origin.ID() {
+				return
+			}
+			peers = append(peers, merkleRepairPeer{session: session, host: host})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var (
+		trees               = make(map[xtime.UnixNano]*digest.MerkleTree)
+		mismatchedByBlock   = make(map[xtime.UnixNano]map[int]struct{})
+		filteredLocalBlocks = block.NewFetchBlocksMetadataResults()
+	)
+	for blockStart := tr.Start.Truncate(blockSize); blockStart.Before(tr.End); blockStart = blockStart.Add(blockSize) {
+		tree, err := digest.NewMerkleTree(depth)
+		if err != nil {
+			return err
+		}
+		trees[blockStart] = tree
+	}
+	for _, result := range localMetadata.Results() {
+		for _, b := range result.Blocks.Results() {
+			tree, ok := trees[b.Start]
+			if ok && b.Err == nil && b.Checksum != nil {
+				tree.Add(result.ID.Bytes(), *b.Checksum)
+			}
+		}
+	}
+
+	for blockStart, tree := range trees {
+		var (
+			mismatched    = make(map[int]struct{})
+			comparedPeers = make([]merkleRepairPeer, 0, len(peers))
+		)
+		for _, peer := range peers {
+			leaves, err := r.mismatchedMerkleLeaves(peer, tree, nsID, shardID, blockStart)
+			if err != nil {
+				// Peers that cannot be compared are skipped as with the
+				// consistency level of a metadata comparison.
+				r.metrics.merklePeerErrors.Inc(1)
+				r.logger.Warn("could not compare merkle tree with peer",
+					zap.String("peer", peer.host.ID()),
+					zap.Stringer("namespace", nsID),
+					zap.Uint32("shard", shardID),
+					zap.Time("blockStart", blockStart.ToTime()),
+					zap.Error(err))
+				continue
+			}
+			comparedPeers = append(comparedPeers, peer)
+			for _, leaf := range leaves {
+				mismatched[leaf] = struct{}{}
+			}
+		}
+		if len(mismatched) == 0 {
+			continue
+		}
+		r.metrics.merkleMismatchedLeaves.Inc(int64(len(mismatched)))
+		mismatchedByBlock[blockStart] = mismatched
+
+		leaves := make([]int, 0, len(mismatched))
+		for leaf := range mismatched {
+			leaves = append(leaves, leaf)
+		}
+		sort.Ints(leaves)
+
+		// Fetch the mismatching leaves from every compared peer so that each
+		// peer is compared against the same set of series.
+		for _, peer := range comparedPeers {
+			peerIter, err := peer.session.FetchMerkleLeavesFromPeer(peer.host, nsID,
+				shardID, blockStart, depth, leaves)
+			if err != nil {
+				return err
+			}
+			if err := metadata.AddPeerMetadata(peerIter); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, result := range localMetadata.Results() {
+		var blocks block.FetchBlockMetadataResults
+		for _, b := range result.Blocks.Results() {
+			mismatched, ok := mismatchedByBlock[b.Start]
+			if !ok {
+				continue
+			}
+			if _, ok := mismatched[trees[b.Start].Leaf(result.ID.Bytes())]; !ok {
+				continue
+			}
+			if blocks == nil {
+				blocks = block.NewFetchBlockMetadataResults()
+			}
+			blocks.Add(b)
+		}
+		if blocks == nil {
+			continue
+		}
+		// The ID and tags remain owned by the local metadata, tags are not
+		// required for the comparison of the origin.
+		filteredLocalBlocks.Add(block.NewFetchBlocksMetadataResult(result.ID, nil, blocks))
+	}
+
+	return metadata.AddLocalMetadata(block.NewFilteredBlocksMetadataIter(filteredLocalBlocks))
+}
+
+// mismatchedMerkleLeaves compares a merkle tree with the tree of a peer level
+// by level from the root, only descending into the nodes that differ.
+func (r shardRepairer) mismatchedMerkleLeaves(
+	peer merkleRepairPeer,
+	tree *digest.MerkleTree,
+	nsID ident.ID,
+	shardID uint32,
+	blockStart xtime.UnixNano,
+) ([]int, error) {
+	var (
+		leaves []int
+		nodes  = []int{0}
+	)
+	for len(nodes) > 0 {
+		hashes, err := peer.session.FetchMerkleNodesFromPeer(peer.host, nsID,
+			shardID, blockStart, tree.Depth(), nodes)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []int
+		for i, node := range nodes {
+			if hash, _ := tree.Node(node); hash == hashes[i] {
+				continue
+			}
+			if tree.IsLeaf(node) {
+				leaves = append(leaves, tree.NodeLeaf(node))
+				continue
+			}
+			left, right := digest.MerkleTreeChildren(node)
+			next = append(next, left, right)
+		}
+		nodes = next
+	}
+	return leaves, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseShardRepairerRepairMerkleTree(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		origin = topology.NewHost("0", "addr0")
+		peer   = topology.NewHost("1", "addr1")
+		depth  = 10
+	)
+	topoMap := topology.NewMockMap(ctrl)
+	topoMap.EXPECT().RouteShardForEach(uint32(0), gomock.Any()).
+		DoAndReturn(func(_ uint32, fn topology.RouteForEachFn) error {
+			fn(0, shard.NewShard(0), origin)
+			fn(1, shard.NewShard(0), peer)
+			return nil
+		})
+
+	session := client.NewMockAdminSession(ctrl)
+	session.EXPECT().Origin().Return(origin).AnyTimes()
+	session.EXPECT().TopologyMap().Return(topoMap, nil).AnyTimes()
+
+	mockClient := client.NewMockAdminClient(ctrl)
+	mockClient.EXPECT().DefaultAdminSession().Return(session, nil).AnyTimes()
+
+	nsMeta, err := namespace.NewMetadata(ident.StringID("testNamespace"), namespace.NewOptions())
+	require.NoError(t, err)
+
+	var (
+		rpOpts = testRepairOptions(ctrl).
+			SetAdminClients([]client.AdminClient{mockClient}).
+			SetType(repair.OnlyCompareRepair).
+			SetMerkleTreeDepth(depth)
+		blockSize  = nsMeta.Options().RetentionOptions().BlockSize()
+		start      = xtime.Now().Truncate(blockSize)
+		end        = start.Add(blockSize)
+		nsCtx      = namespace.Context{ID: nsMeta.ID()}
+		checksums  = []uint32{4, 5, 6}
+		localShard = NewMockdatabaseShard(ctrl)
+	)
+	localShard.EXPECT().ID().Return(uint32(0)).AnyTimes()
+
+	localResults := block.NewFetchBlocksMetadataResults()
+	for _, entry := range []struct {
+		id       string
+		checksum *uint32
+	}{
+		{id: "foo", checksum: &checksums[0]},
+		{id: "bar", checksum: &checksums[1]},
+	} {
+		results := block.NewFetchBlockMetadataResults()
+		results.Add(block.NewFetchBlockMetadataResult(start, 1, entry.checksum, 0, nil))
+		localResults.Add(block.NewFetchBlocksMetadataResult(ident.StringID(entry.id), nil, results))
+	}
+	localShard.EXPECT().
+		FetchBlocksMetadataV2(gomock.Any(), start, end, gomock.Any(), nil, gomock.Any()).
+		Return(localResults, nil, nil)
+
+	// The peer has a different checksum for series "bar".
+	peerTree, err := digest.NewMerkleTree(depth)
+	require.NoError(t, err)
+	peerTree.Add([]byte("foo"), checksums[0])
+	peerTree.Add([]byte("bar"), checksums[2])
+	barLeaf := peerTree.Leaf([]byte("bar"))
+	require.NotEqual(t, barLeaf, peerTree.Leaf([]byte("foo")))
+
+	numNodesFetched := 0
+	session.EXPECT().
+		FetchMerkleNodesFromPeer(peer, nsMeta.ID(), uint32(0), start, depth, gomock.Any()).
+		DoAndReturn(func(_ topology.Host, _ ident.ID, _ uint32, _ xtime.UnixNano,
+			_ int, nodes []int) ([]uint64, error) {
+			numNodesFetched += len(nodes)
+			hashes := make([]uint64, 0, len(nodes))
+			for _, node := range nodes {
+				hash, ok := peerTree.Node(node)
+				require.True(t, ok)
+				hashes = append(hashes, hash)
+			}
+			return hashes, nil
+		}).
+		Times(depth + 1)
+
+	barMetadata := block.NewMetadata(ident.StringID("bar"), ident.Tags{}, start, 1, &checksums[2], 0)
+	peerIter := client.NewMockPeerBlockMetadataIter(ctrl)
+	gomock.InOrder(
+		peerIter.EXPECT().Next().Return(true),
+		peerIter.EXPECT().Current().Return(peer, barMetadata),
+		peerIter.EXPECT().Next().Return(false),
+		peerIter.EXPECT().Err().Return(nil),
+	)
+	session.EXPECT().
+		FetchMerkleLeavesFromPeer(peer, nsMeta.ID(), uint32(0), start, depth, []int{barLeaf}).
+		Return(peerIter, nil)
+
+	repairer := newShardRepairer(DefaultTestOptions(), rpOpts).(shardRepairer)
+	var resDiff repair.MetadataComparisonResult
+	repairer.record = func(_ topology.Host, _ ident.ID, _ databaseShard,
+		diffRes repair.MetadataComparisonResult) {
+		resDiff = diffRes
+	}
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	_, err = repairer.Repair(ctx, nsCtx, nsMeta, xtime.Range{Start: start, End: end}, localShard)
+	require.NoError(t, err)
+
+	// Only the two children of each differing node were compared.
+	require.Equal(t, 1+2*depth, numNodesFetched)
+
+	// Only the series of the differing leaf were compared.
+	require.Equal(t, int64(1), resDiff.NumSeries)
+	checksumDiffSeries := resDiff.ChecksumDifferences.Series()
+	require.Equal(t, 1, checksumDiffSeries.Len())
+	_, exists := checksumDiffSeries.Get(ident.StringID("bar"))
+	require.True(t, exists)
+}
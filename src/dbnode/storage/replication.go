@@ -0,0 +1,64 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/replication"
+
+	"go.uber.org/zap"
+)
+
+// commitLogReplication runs the commitlog replicator as a background
+// process of the database.
+type commitLogReplication struct {
+	replicator replication.Replicator
+	commitLog  commitlog.CommitLog
+	logger     *zap.Logger
+}
+
+func newCommitLogReplication(
+	replicator replication.Replicator,
+	commitLog commitlog.CommitLog,
+	opts Options,
+) BackgroundProcess {
+	return &commitLogReplication{
+		replicator: replicator,
+		commitLog:  commitLog,
+		logger:     opts.InstrumentOptions().Logger(),
+	}
+}
+
+func (r *commitLogReplication) Start() {
+	if err := r.replicator.Open(r.commitLog); err != nil {
+		r.logger.Error("could not start commitlog replication", zap.Error(err))
+	}
+}
+
+func (r *commitLogReplication) Stop() {
+	if err := r.replicator.Close(); err != nil {
+		r.logger.Error("could not stop commitlog replication", zap.Error(err))
+	}
+}
+
+func (r *commitLogReplication) Report() {
+	r.replicator.Report()
+}
@@ -325,7 +325,7 @@ func (b *dbBuffer) Write(
 
 	case timestamp.Before(pastLimit):
 		writeType = ColdWrite
-		if !b.opts.ColdWritesEnabled() {
+		if !b.opts.ColdWritesEnabled() && !wOpts.ForceColdWrite {
 			return false, writeType, xerrors.NewInvalidParamsError(
 				fmt.Errorf("datapoint too far in past: "+
 					"id=%s, off_by=%s, timestamp=%s, past_limit=%s, "+
@@ -338,7 +338,7 @@ func (b *dbBuffer) Write(
 
 	case !futureLimit.After(timestamp):
 		writeType = ColdWrite
-		if !b.opts.ColdWritesEnabled() {
+		if !b.opts.ColdWritesEnabled() && !wOpts.ForceColdWrite {
 			return false, writeType, xerrors.NewInvalidParamsError(
 				fmt.Errorf("datapoint too far in future: "+
 					"id=%s, off_by=%s, timestamp=%s, future_limit=%s, "+
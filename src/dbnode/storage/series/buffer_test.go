@@ -166,6 +166,28 @@ func TestBufferWriteTooPast(t *testing.T) {
 	assert.True(t, strings.Contains(err.Error(), "past_limit="))
 }
 
+func TestBufferWriteTooPastForceColdWrite(t *testing.T) {
+	opts := newBufferTestOptions()
+	rops := opts.RetentionOptions()
+	curr := xtime.Now().Truncate(rops.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr.ToTime()
+	}))
+	buffer := newDatabaseBuffer().(*dbBuffer)
+	buffer.Reset(databaseBufferResetOptions{
+		Options: opts,
+	})
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	wasWritten, writeType, err := buffer.Write(ctx, testID,
+		curr.Add(-1*rops.BufferPast()-time.Second), 1, xtime.Second,
+		nil, WriteOptions{ForceColdWrite: true})
+	require.NoError(t, err)
+	assert.True(t, wasWritten)
+	assert.Equal(t, ColdWrite, writeType)
+}
+
 func maxDuration(a, b time.Duration) time.Duration {
 	if a > b {
 		return a
@@ -22,6 +22,7 @@ package series
 
 import (
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/retention"
 	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -45,6 +46,7 @@ type options struct {
 	identifierPool                ident.Pool
 	stats                         Stats
 	coldWritesEnabled             bool
+	retentionRules                namespace.RetentionRulesMatcher
 	bufferBucketPool              *BufferBucketPool
 	bufferBucketVersionsPool      *BufferBucketVersionsPool
 	runtimeOptsMgr                m3dbruntime.OptionsManager
@@ -201,6 +203,16 @@ func (o *options) ColdWritesEnabled() bool {
 	return o.coldWritesEnabled
 }
 
+func (o *options) SetRetentionRules(value namespace.RetentionRulesMatcher) Options {
+	opts := *o
+	opts.retentionRules = value
+	return &opts
+}
+
+func (o *options) RetentionRules() namespace.RetentionRulesMatcher {
+	return o.retentionRules
+}
+
 func (o *options) SetBufferBucketVersionsPool(value *BufferBucketVersionsPool) Options {
 	opts := *o
 	opts.bufferBucketVersionsPool = value
@@ -76,8 +76,9 @@ func (r *Reader) ReadEncoded(
 	ctx context.Context,
 	start, end xtime.UnixNano,
 	nsCtx namespace.Context,
+	opts ReadOptions,
 ) (BlockReaderIter, error) {
-	return r.readersWithBlocksMapAndBuffer(ctx, start, end, nil, nil, nsCtx)
+	return r.readersWithBlocksMapAndBuffer(ctx, start, end, nil, nil, nsCtx, opts)
 }
 
 func (r *Reader) readersWithBlocksMapAndBuffer(
@@ -86,6 +87,7 @@ func (r *Reader) readersWithBlocksMapAndBuffer(
 	seriesBlocks block.DatabaseSeriesBlocks,
 	seriesBuffer databaseBuffer,
 	nsCtx namespace.Context,
+	opts ReadOptions,
 ) (BlockReaderIter, error) {
 	if end.Before(start) {
 		return nil, xerrors.NewInvalidParamsError(errSeriesReadInvalidRange)
@@ -116,7 +118,7 @@ func (r *Reader) readersWithBlocksMapAndBuffer(
 	}
 
 	return r.readersWithBlocksMapAndBufferAligned(ctx, alignedStart, alignedEnd,
-		seriesBlocks, seriesBuffer, nsCtx)
+		seriesBlocks, seriesBuffer, nsCtx, opts)
 }
 
 // BlockReaderIter provides an Iterator interface to a collection of BlockReaders.
@@ -153,6 +155,7 @@ type blockReaderIterOpts struct {
 	blockSize time.Duration
 	reader    *Reader
 	nsCtx     namespace.Context
+	rollups   RollupReader
 	cached    []xio.BlockReader
 	buffer    [][]xio.BlockReader
 }
@@ -197,14 +200,27 @@ func (i *blockReaderIter) Next(ctx context.Context) bool {
 			i.curr = append(i.curr, i.cached[0])
 			i.cached = i.cached[1:]
 		} else {
-			// if not in the cache, request a load from disk.
-			blockReader, found, err := i.reader.streamBlock(ctx, i.blockAt, i.reader.onRetrieve, i.nsCtx)
-			if err != nil {
-				i.err = err
-				return false
+			// if not in the cache, read the rollups of the block if it was
+			// compacted into them, otherwise request a load from disk.
+			compacted := false
+			if i.rollups != nil {
+				rollups, ok, err := i.rollups.ReadRollup(i.reader.id, i.blockAt)
+				if err != nil {
+					i.err = err
+					return false
+				}
+				compacted = ok
+				i.curr = append(i.curr, rollups...)
 			}
-			if found {
-				i.curr = append(i.curr, blockReader)
+			if !compacted {
+				blockReader, found, err := i.reader.streamBlock(ctx, i.blockAt, i.reader.onRetrieve, i.nsCtx)
+				if err != nil {
+					i.err = err
+					return false
+				}
+				if found {
+					i.curr = append(i.curr, blockReader)
+				}
 			}
 		}
 		i.blockAt = i.blockAt.Add(i.blockSize)
@@ -229,6 +245,7 @@ func (r *Reader) readersWithBlocksMapAndBufferAligned(
 	seriesBlocks block.DatabaseSeriesBlocks,
 	seriesBuffer databaseBuffer,
 	nsCtx namespace.Context,
+	opts ReadOptions,
 ) (BlockReaderIter, error) {
 	var (
 		nowFn       = r.opts.ClockOptions().NowFn()
@@ -291,6 +308,7 @@ func (r *Reader) readersWithBlocksMapAndBufferAligned(
 			blockSize: blockSize,
 			reader:    r,
 			nsCtx:     nsCtx,
+			rollups:   opts.Rollups,
 			buffer:    buffer,
 			cached:    cached,
 		},
@@ -81,7 +81,7 @@ func TestReaderUsingRetrieverReadEncoded(t *testing.T) {
 		ident.StringID("foo"), retriever, onRetrieveBlock, nil, opts)
 
 	// Check reads as expected
-	iter, err := reader.ReadEncoded(ctx, start, end, namespace.Context{})
+	iter, err := reader.ReadEncoded(ctx, start, end, namespace.Context{}, ReadOptions{})
 	require.NoError(t, err)
 
 	count := 0
@@ -94,6 +94,58 @@ func TestReaderUsingRetrieverReadEncoded(t *testing.T) {
 	require.Equal(t, 2, count)
 }
 
+// testRollupReader serves the rollups of the blocks in it as compacted.
+type testRollupReader map[xtime.UnixNano][]xio.BlockReader
+
+func (r testRollupReader) ReadRollup(
+	_ ident.ID,
+	blockStart xtime.UnixNano,
+) ([]xio.BlockReader, bool, error) {
+	readers, ok := r[blockStart]
+	return readers, ok, nil
+}
+
+func TestReaderUsingRetrieverReadEncodedRollups(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSeriesTestOptions()
+	ropts := opts.RetentionOptions()
+
+	end := xtime.ToUnixNano(opts.ClockOptions().NowFn()().Truncate(ropts.BlockSize()))
+	start := end.Add(-3 * ropts.BlockSize())
+	rawStart := start.Add(2 * ropts.BlockSize())
+
+	rollup := xio.BlockReader{SegmentReader: xio.NewMockSegmentReader(ctrl), Start: start}
+	raw := xio.BlockReader{SegmentReader: xio.NewMockSegmentReader(ctrl), Start: rawStart}
+
+	ctx := opts.ContextPool().Get()
+	defer ctx.Close()
+
+	// Only the block that was not compacted is read from the raw filesets,
+	// compacted blocks the series has no datapoints in are skipped.
+	onRetrieveBlock := block.NewMockOnRetrieveBlock(ctrl)
+	retriever := NewMockQueryableBlockRetriever(ctrl)
+	retriever.EXPECT().IsBlockRetrievable(rawStart).Return(true, nil)
+	retriever.EXPECT().
+		Stream(ctx, ident.NewIDMatcher("foo"), rawStart, onRetrieveBlock, gomock.Any()).
+		Return(raw, nil)
+
+	reader := NewReaderUsingRetriever(
+		ident.StringID("foo"), retriever, onRetrieveBlock, nil, opts)
+	iter, err := reader.ReadEncoded(ctx, start, end, namespace.Context{}, ReadOptions{
+		Rollups: testRollupReader{
+			start:                       {rollup},
+			start.Add(ropts.BlockSize()): nil,
+		},
+	})
+	require.NoError(t, err)
+
+	results, err := iter.ToSlices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [][]xio.BlockReader{{rollup}, {raw}}, results)
+}
+
 type readTestCase struct {
 	title           string
 	times           []xtime.UnixNano
@@ -628,7 +680,7 @@ func TestReaderReadEncodedRobust(t *testing.T) {
 				// End is not inclusive so add blocksize to the last time.
 				end = tc.times[len(tc.times)-1].Add(blockSize)
 			)
-			iter, err := reader.readersWithBlocksMapAndBuffer(ctx, start, end, diskCache, buffer, namespace.Context{})
+			iter, err := reader.readersWithBlocksMapAndBuffer(ctx, start, end, diskCache, buffer, namespace.Context{}, ReadOptions{})
 
 			anyInMemErr := false
 			for _, sr := range tc.cachedBlocks {
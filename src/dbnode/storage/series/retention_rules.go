@@ -0,0 +1,113 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package series
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/query/models"
+)
+
+type retentionRuleFilter struct {
+	filter          filters.TagsFilter
+	retentionPeriod time.Duration
+}
+
+type retentionRulesMatcher struct {
+	rules              []retentionRuleFilter
+	retentionPeriod    time.Duration
+	minRetentionPeriod time.Duration
+	maxRetentionPeriod time.Duration
+	tagOpts            models.TagOptions
+}
+
+// NewRetentionRulesMatcher returns a new retention rules matcher for the
+// retention rules of the given namespace options.
+func NewRetentionRulesMatcher(opts namespace.Options) (namespace.RetentionRulesMatcher, error) {
+	var (
+		retentionPeriod = opts.RetentionOptions().RetentionPeriod()
+		m               = &retentionRulesMatcher{
+			rules:              make([]retentionRuleFilter, 0, len(opts.RetentionRules())),
+			retentionPeriod:    retentionPeriod,
+			minRetentionPeriod: retentionPeriod,
+			maxRetentionPeriod: retentionPeriod,
+			tagOpts:            models.NewTagOptions(),
+		}
+	)
+	for _, rule := range opts.RetentionRules() {
+		filter, err := newRetentionRuleFilter(rule)
+		if err != nil {
+			return nil, err
+		}
+		m.rules = append(m.rules, retentionRuleFilter{
+			filter:          filter,
+			retentionPeriod: rule.RetentionPeriod,
+		})
+		if rule.RetentionPeriod < m.minRetentionPeriod {
+			m.minRetentionPeriod = rule.RetentionPeriod
+		}
+		if rule.RetentionPeriod > m.maxRetentionPeriod {
+			m.maxRetentionPeriod = rule.RetentionPeriod
+		}
+	}
+	return m, nil
+}
+
+func newRetentionRuleFilter(rule namespace.RetentionRule) (filters.TagsFilter, error) {
+	filterValues, err := filters.ValidateTagsFilter(rule.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retention rule filter %s: %w", rule.Filter, err)
+	}
+	filter, err := filters.NewTagsFilter(filterValues, filters.Conjunction, filters.TagsFilterOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid retention rule filter %s: %w", rule.Filter, err)
+	}
+	return filter, nil
+}
+
+func (m *retentionRulesMatcher) RetentionPeriod(fields []doc.Field) time.Duration {
+	if len(m.rules) == 0 {
+		return m.retentionPeriod
+	}
+	tags := models.NewTags(len(fields), m.tagOpts)
+	for _, f := range fields {
+		tags = tags.AddTagWithoutNormalizing(models.Tag{Name: f.Name, Value: f.Value})
+	}
+	tags = tags.Normalize()
+	for _, rule := range m.rules {
+		if rule.filter.MatchTags(tags) {
+			return rule.retentionPeriod
+		}
+	}
+	return m.retentionPeriod
+}
+
+func (m *retentionRulesMatcher) MinRetentionPeriod() time.Duration {
+	return m.minRetentionPeriod
+}
+
+func (m *retentionRulesMatcher) MaxRetentionPeriod() time.Duration {
+	return m.maxRetentionPeriod
+}
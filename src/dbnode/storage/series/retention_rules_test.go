@@ -0,0 +1,117 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package series
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/namespace/tagsfilter"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/m3ninx/doc"
+)
+
+func testRetentionRuleFields(tags ...string) []doc.Field {
+	fields := make([]doc.Field, 0, len(tags)/2)
+	for i := 0; i < len(tags); i += 2 {
+		fields = append(fields, doc.Field{
+			Name:  []byte(tags[i]),
+			Value: []byte(tags[i+1]),
+		})
+	}
+	return fields
+}
+
+func TestRetentionRulesMatcher(t *testing.T) {
+	opts := namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetRetentionPeriod(48 * time.Hour)).
+		SetRetentionRules([]namespace.RetentionRule{
+			{Filter: "env:staging", RetentionPeriod: 6 * time.Hour},
+			{Filter: "service:{api,web} tier:gold", RetentionPeriod: 30 * 24 * time.Hour},
+			{Filter: "service:api*", RetentionPeriod: 24 * time.Hour},
+		})
+	m, err := NewRetentionRulesMatcher(opts)
+	require.NoError(t, err)
+
+	require.Equal(t, 6*time.Hour, m.MinRetentionPeriod())
+	require.Equal(t, 30*24*time.Hour, m.MaxRetentionPeriod())
+
+	for _, test := range []struct {
+		fields   []doc.Field
+		expected time.Duration
+	}{
+		{fields: testRetentionRuleFields("env", "staging"), expected: 6 * time.Hour},
+		{fields: testRetentionRuleFields("env", "prod"), expected: 48 * time.Hour},
+		{fields: testRetentionRuleFields("tier", "gold", "service", "web"), expected: 30 * 24 * time.Hour},
+		{fields: testRetentionRuleFields("service", "web"), expected: 48 * time.Hour},
+		{fields: testRetentionRuleFields("service", "api-gateway"), expected: 24 * time.Hour},
+		// The first matching rule wins.
+		{fields: testRetentionRuleFields("service", "api", "env", "staging"), expected: 6 * time.Hour},
+		{fields: nil, expected: 48 * time.Hour},
+	} {
+		require.Equal(t, test.expected, m.RetentionPeriod(test.fields))
+	}
+}
+
+func TestRetentionRulesMatcherNoRules(t *testing.T) {
+	m, err := NewRetentionRulesMatcher(namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetRetentionPeriod(48 * time.Hour)))
+	require.NoError(t, err)
+
+	require.Equal(t, 48*time.Hour, m.MinRetentionPeriod())
+	require.Equal(t, 48*time.Hour, m.MaxRetentionPeriod())
+	require.Equal(t, 48*time.Hour, m.RetentionPeriod(testRetentionRuleFields("env", "staging")))
+}
+
+func TestRetentionRulesMatcherInvalidFilter(t *testing.T) {
+	_, err := NewRetentionRulesMatcher(namespace.NewOptions().
+		SetRetentionRules([]namespace.RetentionRule{{Filter: "env", RetentionPeriod: time.Hour}}))
+	require.Error(t, err)
+}
+
+func TestRetentionRulesFilterValidationMatchesMetricsFilters(t *testing.T) {
+	// The namespace options validate the rule filters with a standalone
+	// parser, which needs to agree with the filters the matcher builds.
+	for _, filter := range []string{
+		"env:staging",
+		"service#{api,web} tier:!gold",
+		"host:*db* region:us-[a-cx-z]? zone:[!abc]",
+		"name:foo*bar",
+		"env",
+		"env:a:b",
+		"env:staging env:prod",
+		"tier:!",
+		"name:*a*b*",
+		"name:*a?b*",
+		"service:{api",
+		"service:a{}",
+		"region:[]",
+		"region:[z-a]",
+		"region:[a-cd]",
+	} {
+		_, err := NewRetentionRulesMatcher(namespace.NewOptions().
+			SetRetentionRules([]namespace.RetentionRule{{Filter: filter, RetentionPeriod: time.Hour}}))
+		require.Equal(t, err == nil, tagsfilter.Validate(filter) == nil, filter)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -67,6 +68,10 @@ type dbSeries struct {
 	metadata    doc.Metadata
 	uniqueIndex uint64
 
+	// retentionPeriod is the retention period resolved from the retention
+	// rules for the series, if zero the retention options apply.
+	retentionPeriod time.Duration
+
 	bootstrap dbSeriesBootstrap
 
 	buffer                      databaseBuffer
@@ -170,6 +175,13 @@ func (s *dbSeries) Tick(blockStates ShardBlockStateSnapshot, nsCtx namespace.Con
 	return r, ErrSeriesAllDatapointsExpired
 }
 
+func (s *dbSeries) retentionPeriodWithLock() time.Duration {
+	if s.retentionPeriod > 0 {
+		return s.retentionPeriod
+	}
+	return s.opts.RetentionOptions().RetentionPeriod()
+}
+
 type updateBlocksResult struct {
 	TickStatus
 	madeExpiredBlocks int
@@ -185,7 +197,7 @@ func (s *dbSeries) updateBlocksWithLock(
 		now          = s.now()
 		ropts        = s.opts.RetentionOptions()
 		cachePolicy  = s.opts.CachePolicy()
-		expireCutoff = now.Add(-ropts.RetentionPeriod()).Truncate(ropts.BlockSize())
+		expireCutoff = now.Add(-s.retentionPeriodWithLock()).Truncate(ropts.BlockSize())
 		wiredTimeout = ropts.BlockDataExpiryAfterNotAccessedPeriod()
 	)
 	for start, currBlock := range s.cachedBlocks.AllBlocks() {
@@ -389,10 +401,12 @@ func (s *dbSeries) ReadEncoded(
 	ctx context.Context,
 	start, end xtime.UnixNano,
 	nsCtx namespace.Context,
+	opts ReadOptions,
 ) (BlockReaderIter, error) {
 	s.RLock()
 	reader := NewReaderUsingRetriever(s.id, s.blockRetriever, s.onRetrieveBlock, s, s.opts)
-	iter, err := reader.readersWithBlocksMapAndBuffer(ctx, start, end, s.cachedBlocks, s.buffer, nsCtx)
+	iter, err := reader.readersWithBlocksMapAndBuffer(ctx, start, end,
+		s.cachedBlocks, s.buffer, nsCtx, opts)
 	s.RUnlock()
 	return iter, err
 }
@@ -716,6 +730,10 @@ func (s *dbSeries) Reset(opts DatabaseSeriesOptions) {
 	s.id = opts.ID
 	s.metadata = opts.Metadata
 	s.uniqueIndex = opts.UniqueIndex
+	s.retentionPeriod = 0
+	if rules := opts.Options.RetentionRules(); rules != nil {
+		s.retentionPeriod = rules.RetentionPeriod(opts.Metadata.Fields)
+	}
 	s.cachedBlocks.Reset()
 	s.buffer.Reset(databaseBufferResetOptions{
 		BlockRetriever: opts.BlockRetriever,
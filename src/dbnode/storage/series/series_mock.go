@@ -255,18 +255,18 @@ func (mr *MockDatabaseSeriesMockRecorder) OnRetrieveBlock(arg0, arg1, arg2, arg3
 }
 
 // ReadEncoded mocks base method.
-func (m *MockDatabaseSeries) ReadEncoded(arg0 context.Context, arg1, arg2 time.UnixNano, arg3 namespace.Context) (BlockReaderIter, error) {
+func (m *MockDatabaseSeries) ReadEncoded(arg0 context.Context, arg1, arg2 time.UnixNano, arg3 namespace.Context, arg4 ReadOptions) (BlockReaderIter, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadEncoded", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "ReadEncoded", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(BlockReaderIter)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadEncoded indicates an expected call of ReadEncoded.
-func (mr *MockDatabaseSeriesMockRecorder) ReadEncoded(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockDatabaseSeriesMockRecorder) ReadEncoded(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEncoded", reflect.TypeOf((*MockDatabaseSeries)(nil).ReadEncoded), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEncoded", reflect.TypeOf((*MockDatabaseSeries)(nil).ReadEncoded), arg0, arg1, arg2, arg3, arg4)
 }
 
 // Reset mocks base method.
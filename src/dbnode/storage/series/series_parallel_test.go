@@ -97,7 +97,7 @@ func TestSeriesWriteReadParallel(t *testing.T) {
 			for i := 0; i < numStepsPerWorker; i++ {
 				now := xtime.Now()
 				_, err := series.ReadEncoded(ctx, start.Add(-time.Minute),
-					now.Add(time.Minute), namespace.Context{})
+					now.Add(time.Minute), namespace.Context{}, ReadOptions{})
 				if err != nil {
 					panic(err)
 				}
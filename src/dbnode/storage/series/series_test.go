@@ -263,7 +263,7 @@ func TestSeriesWriteFlushRead(t *testing.T) {
 	nsCtx := namespace.Context{}
 
 	// Test fine grained range
-	iter, err := series.ReadEncoded(ctx, start, start.Add(mins(10)), nsCtx)
+	iter, err := series.ReadEncoded(ctx, start, start.Add(mins(10)), nsCtx, ReadOptions{})
 	assert.NoError(t, err)
 	results, err := iter.ToSlices(ctx)
 	assert.NoError(t, err)
@@ -271,7 +271,7 @@ func TestSeriesWriteFlushRead(t *testing.T) {
 	requireReaderValuesEqual(t, data, results, opts, nsCtx)
 
 	// Test wide range
-	iter, err = series.ReadEncoded(ctx, 0, timeDistantFuture, nsCtx)
+	iter, err = series.ReadEncoded(ctx, 0, timeDistantFuture, nsCtx, ReadOptions{})
 	assert.NoError(t, err)
 	results, err = iter.ToSlices(ctx)
 	assert.NoError(t, err)
@@ -399,7 +399,7 @@ func TestSeriesBootstrapAndLoad(t *testing.T) {
 				ctx := context.NewBackground()
 				defer ctx.Close()
 
-				iter, err := series.ReadEncoded(ctx, start, start.Add(10*blockSize), nsCtx)
+				iter, err := series.ReadEncoded(ctx, start, start.Add(10*blockSize), nsCtx, ReadOptions{})
 				require.NoError(t, err)
 				results, err := iter.ToSlices(ctx)
 				require.NoError(t, err)
@@ -471,7 +471,7 @@ func TestSeriesReadEndBeforeStart(t *testing.T) {
 	nsCtx := namespace.Context{}
 
 	now := xtime.Now()
-	iter, err := series.ReadEncoded(ctx, now, now.Add(-1*time.Second), nsCtx)
+	iter, err := series.ReadEncoded(ctx, now, now.Add(-1*time.Second), nsCtx, ReadOptions{})
 	assert.Error(t, err)
 	assert.True(t, xerrors.IsInvalidParams(err))
 	assert.Nil(t, iter)
@@ -668,6 +668,82 @@ func TestSeriesTickNeedsBlockExpiry(t *testing.T) {
 	require.True(t, exists)
 }
 
+func TestSeriesTickRetentionRuleExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSeriesTestOptions()
+	opts = opts.SetCachePolicy(CacheRecentlyRead)
+	ropts := opts.RetentionOptions()
+	curr := xtime.Now().Truncate(ropts.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr.ToTime()
+	}))
+	rules, err := NewRetentionRulesMatcher(namespace.NewOptions().
+		SetRetentionOptions(ropts).
+		SetRetentionRules([]namespace.RetentionRule{
+			{Filter: "env:staging", RetentionPeriod: 3 * ropts.BlockSize()},
+		}))
+	require.NoError(t, err)
+	opts = opts.SetRetentionRules(rules)
+
+	blockRetriever := NewMockQueryableBlockRetriever(ctrl)
+	blockRetriever.EXPECT().
+		IsBlockRetrievable(gomock.Any()).
+		Return(false, nil).
+		AnyTimes()
+
+	for _, test := range []struct {
+		tags    ident.Tags
+		expired bool
+	}{
+		{tags: ident.NewTags(ident.StringTag("env", "staging")), expired: true},
+		{tags: ident.NewTags(ident.StringTag("env", "prod")), expired: false},
+	} {
+		id := ident.StringID("foo")
+		metadata, err := convert.FromSeriesIDAndTags(id, test.tags)
+		require.NoError(t, err)
+		series := NewDatabaseSeries(DatabaseSeriesOptions{
+			ID:             id,
+			Metadata:       metadata,
+			BlockRetriever: blockRetriever,
+			Options:        opts,
+		}).(*dbSeries)
+
+		// The block is past the retention period of the rule but not past
+		// the retention period of the namespace.
+		blockStart := curr.Add(-4 * ropts.BlockSize())
+		b := block.NewMockDatabaseBlock(ctrl)
+		b.EXPECT().StartTime().Return(blockStart).AnyTimes()
+		if test.expired {
+			b.EXPECT().Close()
+		} else {
+			b.EXPECT().HasMergeTarget().Return(false)
+		}
+		series.cachedBlocks.AddBlock(b)
+
+		buffer := NewMockdatabaseBuffer(ctrl)
+		series.buffer = buffer
+		buffer.EXPECT().Tick(gomock.Any(), gomock.Any()).Return(bufferTickResult{})
+		buffer.EXPECT().Stats().Return(bufferStats{})
+		blockStates := BootstrappedBlockStateSnapshot{
+			Snapshot: map[xtime.UnixNano]BlockState{
+				blockStart: {WarmRetrievable: false},
+			},
+		}
+		r, err := series.Tick(NewShardBlockStateSnapshot(true, blockStates), namespace.Context{})
+		if test.expired {
+			require.Equal(t, ErrSeriesAllDatapointsExpired, err)
+			require.Equal(t, 1, r.MadeExpiredBlocks)
+			require.Equal(t, 0, series.cachedBlocks.Len())
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, 0, r.MadeExpiredBlocks)
+			require.Equal(t, 1, series.cachedBlocks.Len())
+		}
+	}
+}
+
 func TestSeriesTickRecentlyRead(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1196,7 +1272,7 @@ func TestSeriesOutOfOrderWritesAndRotate(t *testing.T) {
 		now = now.Add(blockSize)
 	}
 
-	iter, err := series.ReadEncoded(ctx, qStart, qEnd, namespace.Context{})
+	iter, err := series.ReadEncoded(ctx, qStart, qEnd, namespace.Context{}, ReadOptions{})
 	require.NoError(t, err)
 	encoded, err := iter.ToSlices(ctx)
 	require.NoError(t, err)
@@ -1283,7 +1359,7 @@ func TestSeriesWriteReadFromTheSameBucket(t *testing.T) {
 	assert.True(t, wasWritten)
 
 	iter, err := series.ReadEncoded(ctx, curr.Add(-5*time.Minute),
-		curr.Add(time.Minute), namespace.Context{})
+		curr.Add(time.Minute), namespace.Context{}, ReadOptions{})
 	require.NoError(t, err)
 	results, err := iter.ToSlices(ctx)
 	require.NoError(t, err)
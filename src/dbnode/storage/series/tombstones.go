@@ -0,0 +1,169 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package series
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/context"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// tombstonedBlockReaderIter wraps a BlockReaderIter and omits any datapoints
+// that fall within the deleted time ranges of the series.
+type tombstonedBlockReaderIter struct {
+	iter       BlockReaderIter
+	tombstones xtime.Ranges
+	opts       Options
+	nsCtx      namespace.Context
+
+	curr []xio.BlockReader
+	err  error
+}
+
+// NewTombstonedBlockReaderIter returns a BlockReaderIter that applies the
+// given tombstones to the blocks of iter. Blocks that do not overlap any
+// tombstone are returned as is, blocks that are entirely deleted are skipped
+// and blocks that are partially deleted are re-encoded without the deleted
+// datapoints.
+func NewTombstonedBlockReaderIter(
+	iter BlockReaderIter,
+	tombstones xtime.Ranges,
+	opts Options,
+	nsCtx namespace.Context,
+) BlockReaderIter {
+	if iter == nil || tombstones == nil || tombstones.IsEmpty() {
+		return iter
+	}
+	return &tombstonedBlockReaderIter{
+		iter:       iter,
+		tombstones: tombstones,
+		opts:       opts,
+		nsCtx:      nsCtx,
+	}
+}
+
+func (i *tombstonedBlockReaderIter) Err() error {
+	return i.err
+}
+
+func (i *tombstonedBlockReaderIter) Current() []xio.BlockReader {
+	return i.curr
+}
+
+func (i *tombstonedBlockReaderIter) Next(ctx context.Context) bool {
+	i.curr = nil
+	for i.iter.Next(ctx) {
+		var (
+			readers    = i.iter.Current()
+			blockStart = readers[0].Start
+			blockSize  = i.opts.RetentionOptions().BlockSize()
+			blockRange = xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)}
+		)
+		if !i.tombstones.Overlaps(blockRange) {
+			i.curr = readers
+			return true
+		}
+
+		remaining := xtime.NewRanges(blockRange)
+		remaining.RemoveRanges(i.tombstones)
+		if remaining.IsEmpty() {
+			// Entire block was deleted.
+			continue
+		}
+
+		reader, ok, err := i.filter(ctx, readers, blockStart, blockSize)
+		if err != nil {
+			i.err = err
+			return false
+		}
+		if ok {
+			i.curr = []xio.BlockReader{reader}
+			return true
+		}
+	}
+	i.err = i.iter.Err()
+	return false
+}
+
+// filter re-encodes the given block readers without the deleted datapoints,
+// returning false if no datapoints remain.
+func (i *tombstonedBlockReaderIter) filter(
+	ctx context.Context,
+	readers []xio.BlockReader,
+	blockStart xtime.UnixNano,
+	blockSize time.Duration,
+) (xio.BlockReader, bool, error) {
+	segReaders := make([]xio.SegmentReader, 0, len(readers))
+	for _, r := range readers {
+		segReaders = append(segReaders, r.SegmentReader)
+	}
+
+	iter := i.opts.MultiReaderIteratorPool().Get()
+	iter.Reset(segReaders, blockStart, blockSize, i.nsCtx.Schema)
+	defer iter.Close()
+
+	enc := i.opts.EncoderPool().Get()
+	enc.Reset(blockStart, 0, i.nsCtx.Schema)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if i.tombstones.Overlaps(xtime.Range{
+			Start: dp.TimestampNanos,
+			End:   dp.TimestampNanos + 1,
+		}) {
+			continue
+		}
+		if err := enc.Encode(dp, unit, annotation); err != nil {
+			enc.Close()
+			return xio.EmptyBlockReader, false, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		enc.Close()
+		return xio.EmptyBlockReader, false, err
+	}
+	if enc.NumEncoded() == 0 {
+		enc.Close()
+		return xio.EmptyBlockReader, false, nil
+	}
+
+	segReader := xio.NewSegmentReader(enc.Discard())
+	enc.Close()
+	ctx.RegisterFinalizer(segReader)
+	return xio.BlockReader{
+		SegmentReader: segReader,
+		Start:         blockStart,
+		BlockSize:     blockSize,
+	}, true, nil
+}
+
+func (i *tombstonedBlockReaderIter) ToSlices(ctx context.Context) ([][]xio.BlockReader, error) {
+	var results [][]xio.BlockReader
+	for i.Next(ctx) {
+		results = append(results, i.Current())
+	}
+	if i.Err() != nil {
+		return nil, i.Err()
+	}
+	return results, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package series
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/context"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sliceBlockReaderIter struct {
+	blocks [][]xio.BlockReader
+	curr   []xio.BlockReader
+}
+
+func (i *sliceBlockReaderIter) Next(_ context.Context) bool {
+	if len(i.blocks) == 0 {
+		return false
+	}
+	i.curr, i.blocks = i.blocks[0], i.blocks[1:]
+	return true
+}
+
+func (i *sliceBlockReaderIter) Current() []xio.BlockReader { return i.curr }
+func (i *sliceBlockReaderIter) Err() error                 { return nil }
+
+func (i *sliceBlockReaderIter) ToSlices(ctx context.Context) ([][]xio.BlockReader, error) {
+	var results [][]xio.BlockReader
+	for i.Next(ctx) {
+		results = append(results, i.Current())
+	}
+	return results, nil
+}
+
+func testBlockReader(
+	t *testing.T,
+	ctx context.Context,
+	opts Options,
+	start xtime.UnixNano,
+	values []DecodedTestValue,
+) xio.BlockReader {
+	enc := opts.EncoderPool().Get()
+	enc.Reset(start, 0, nil)
+	for _, v := range values {
+		dp := ts.Datapoint{TimestampNanos: v.Timestamp, Value: v.Value}
+		require.NoError(t, enc.Encode(dp, xtime.Second, nil))
+	}
+	reader, ok := enc.Stream(ctx)
+	require.True(t, ok)
+	ctx.RegisterCloser(enc)
+	return xio.BlockReader{
+		SegmentReader: reader,
+		Start:         start,
+		BlockSize:     opts.RetentionOptions().BlockSize(),
+	}
+}
+
+func TestTombstonedBlockReaderIter(t *testing.T) {
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	var (
+		opts      = newSeriesTestOptions()
+		nsCtx     = namespace.Context{}
+		blockSize = opts.RetentionOptions().BlockSize()
+		start     = xtime.Now().Truncate(blockSize)
+	)
+	values := [][]DecodedTestValue{
+		{
+			{Timestamp: start, Value: 1, Unit: xtime.Second},
+			{Timestamp: start.Add(secs(30)), Value: 2, Unit: xtime.Second},
+			{Timestamp: start.Add(secs(60)), Value: 3, Unit: xtime.Second},
+			{Timestamp: start.Add(secs(90)), Value: 4, Unit: xtime.Second},
+		},
+		{
+			{Timestamp: start.Add(blockSize), Value: 5, Unit: xtime.Second},
+			{Timestamp: start.Add(blockSize + secs(30)), Value: 6, Unit: xtime.Second},
+		},
+		{
+			{Timestamp: start.Add(2 * blockSize), Value: 7, Unit: xtime.Second},
+		},
+	}
+	var blocks [][]xio.BlockReader
+	for i, v := range values {
+		blockStart := start.Add(time.Duration(i) * blockSize)
+		blocks = append(blocks, []xio.BlockReader{testBlockReader(t, ctx, opts, blockStart, v)})
+	}
+
+	tombstones := xtime.NewRanges(
+		// Partially deletes the first block.
+		xtime.Range{Start: start.Add(secs(30)), End: start.Add(secs(61))},
+		// Entirely deletes the second block.
+		xtime.Range{Start: start.Add(blockSize), End: start.Add(2 * blockSize)},
+	)
+	iter := NewTombstonedBlockReaderIter(&sliceBlockReaderIter{blocks: blocks},
+		tombstones, opts, nsCtx)
+
+	results, err := iter.ToSlices(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	expected := []DecodedTestValue{values[0][0], values[0][3], values[2][0]}
+	requireReaderValuesEqual(t, expected, results, opts, nsCtx)
+}
+
+func TestTombstonedBlockReaderIterNoTombstones(t *testing.T) {
+	iter := &sliceBlockReaderIter{}
+	opts := newSeriesTestOptions()
+	require.Equal(t, BlockReaderIter(iter),
+		NewTombstonedBlockReaderIter(iter, nil, opts, namespace.Context{}))
+	require.Equal(t, BlockReaderIter(iter),
+		NewTombstonedBlockReaderIter(iter, xtime.NewRanges(), opts, namespace.Context{}))
+}
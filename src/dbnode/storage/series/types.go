@@ -29,6 +29,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/context"
@@ -83,6 +84,7 @@ type DatabaseSeries interface {
 		ctx context.Context,
 		start, end xtime.UnixNano,
 		nsCtx namespace.Context,
+		opts ReadOptions,
 	) (BlockReaderIter, error)
 
 	// FetchBlocks returns data blocks given a list of block start times.
@@ -371,6 +373,16 @@ type Options interface {
 	// ColdWritesEnabled returns whether cold writes are enabled.
 	ColdWritesEnabled() bool
 
+	// SetRetentionRules sets the retention rules that resolve the retention
+	// period of each series, if nil all series use the retention period
+	// of the retention options.
+	SetRetentionRules(value namespace.RetentionRulesMatcher) Options
+
+	// RetentionRules returns the retention rules that resolve the retention
+	// period of each series, if nil all series use the retention period
+	// of the retention options.
+	RetentionRules() namespace.RetentionRulesMatcher
+
 	// SetBufferBucketVersionsPool sets the BufferBucketVersionsPool.
 	SetBufferBucketVersionsPool(value *BufferBucketVersionsPool) Options
 
@@ -454,6 +466,21 @@ type WriteTransformOptions struct {
 	ForceValue float64
 }
 
+// ReadOptions provides a set of options for a read.
+type ReadOptions struct {
+	// Rollups, if set, reads the flushed blocks that were compacted into
+	// rollups from the rollups rather than from the raw filesets. Blocks
+	// still in memory are read as is.
+	Rollups RollupReader
+}
+
+// RollupReader reads flushed blocks that were compacted into rollups.
+type RollupReader interface {
+	// ReadRollup returns block readers over the rollup of a series within a
+	// block, along with whether the block was compacted into rollups.
+	ReadRollup(id ident.ID, blockStart xtime.UnixNano) ([]xio.BlockReader, bool, error)
+}
+
 // WriteOptions provides a set of options for a write.
 type WriteOptions struct {
 	// SchemaDesc is the schema description.
@@ -473,4 +500,8 @@ type WriteOptions struct {
 	// fall into retention but they do not care if it fails to write due to
 	// it just having fallen out of retention (time race).
 	SkipOutOfRetention bool
+	// ForceColdWrite accepts a write outside the time window as a cold write
+	// even when cold writes are disabled. This is used to promote late
+	// writes that were quarantined instead of being rejected.
+	ForceColdWrite bool
 }
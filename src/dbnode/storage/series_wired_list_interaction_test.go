@@ -154,7 +154,7 @@ func TestSeriesWiredListConcurrentInteractions(t *testing.T) {
 			blTime := getAndIncStart()
 			shard.OnRetrieveBlock(id, nil, blTime, ts.Segment{}, namespace.Context{})
 			// Simulate concurrent reads
-			_, err := shard.ReadEncoded(context.NewBackground(), id, blTime, blTime.Add(blockSize), namespace.Context{})
+			_, err := shard.ReadEncoded(context.NewBackground(), id, blTime, blTime.Add(blockSize), namespace.Context{}, series.ReadOptions{})
 			require.NoError(t, err)
 			wg.Done()
 		}()
@@ -142,6 +142,8 @@ type dbShard struct {
 	identifierPool           ident.Pool
 	contextPool              context.Pool
 	flushState               shardFlushState
+	tombstones               *shardTombstones
+	retentionCutoffs         *shardRetentionCutoffs
 	tickWg                   *sync.WaitGroup
 	runtimeOptsListenClosers []xresource.SimpleCloser
 	currRuntimeOptions       dbShardRuntimeOptions
@@ -290,6 +292,10 @@ func newDatabaseShard(
 		tileAggregator:       opts.TileAggregator(),
 		entryMetrics:         NewEntryMetrics(scope.SubScope("entries")),
 	}
+	s.tombstones = newShardTombstones(opts.CommitLogOptions().FilesystemOptions(),
+		namespaceMetadata.ID(), shard, namespaceMetadata.Options().RetentionOptions().BlockSize())
+	s.retentionCutoffs = newShardRetentionCutoffs(opts.CommitLogOptions().FilesystemOptions(),
+		namespaceMetadata, shard, seriesOpts.RetentionRules())
 	s.insertQueue = newDatabaseShardInsertQueue(s.insertSeriesBatch,
 		s.nowFn, opts.CoreFn(), scope, opts.InstrumentOptions().Logger())
 
@@ -1119,6 +1125,7 @@ func (s *dbShard) ReadEncoded(
 	id ident.ID,
 	start, end xtime.UnixNano,
 	nsCtx namespace.Context,
+	opts series.ReadOptions,
 ) (series.BlockReaderIter, error) {
 	s.RLock()
 	entry, err := s.lookupEntryWithLock(id)
@@ -1140,15 +1147,64 @@ func (s *dbShard) ReadEncoded(
 		return nil, err
 	}
 
+	var iter series.BlockReaderIter
 	if entry != nil {
-		return entry.Series.ReadEncoded(ctx, start, end, nsCtx)
+		iter, err = entry.Series.ReadEncoded(ctx, start, end, nsCtx, opts)
+	} else {
+		retriever := s.seriesBlockRetriever
+		onRetrieve := s.seriesOnRetrieveBlock
+		reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, s.seriesOpts)
+		iter, err = reader.ReadEncoded(ctx, start, end, nsCtx, opts)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	retriever := s.seriesBlockRetriever
-	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
-	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
-	return reader.ReadEncoded(ctx, start, end, nsCtx)
+	tombstones := s.tombstones.Get(id)
+	return series.NewTombstonedBlockReaderIter(iter, tombstones, s.seriesOpts, nsCtx), nil
+}
+
+func (s *dbShard) DeleteSeries(ids []ident.ID, tr xtime.Range) error {
+	if !s.IsBootstrapped() {
+		return errShardNotBootstrappedToDelete
+	}
+
+	// Deletes only apply to data that can exist at the time of the delete,
+	// so that the tombstones do not hide data written in the future and the
+	// block starts pending a rewrite stay bounded.
+	var (
+		ropts    = namespace.BlockRetentionOptions(s.namespace.Options())
+		now      = xtime.ToUnixNano(s.nowFn())
+		earliest = retention.FlushTimeStart(ropts, now)
+		latest   = now.Add(ropts.BufferFuture())
+	)
+	if tr.Start.Before(earliest) {
+		tr.Start = earliest
+	}
+	if tr.End.After(latest) {
+		tr.End = latest
+	}
+	if tr.IsEmpty() || len(ids) == 0 {
+		return nil
+	}
+
+	return s.tombstones.Add(ids, tr)
+}
+
+func (s *dbShard) SeriesTombstones(id ident.ID) xtime.Ranges {
+	return s.tombstones.Get(id)
+}
+
+func (s *dbShard) TombstonesPending() bool {
+	return len(s.tombstones.Pending()) > 0
+}
+
+func (s *dbShard) DeletedBlockStartsUnindexed() []xtime.UnixNano {
+	return s.tombstones.Unindexed()
+}
+
+func (s *dbShard) MarkDeletedBlockStartsIndexed(blockStarts []xtime.UnixNano) {
+	s.tombstones.MarkIndexed(blockStarts)
 }
 
 // lookupEntryWithLock returns the entry for a given id while holding a read lock or a write lock.
@@ -1815,7 +1871,7 @@ func (s *dbShard) FetchBlocksMetadataV2(
 	// flushed block and work backwards.
 	var (
 		result    = s.opts.FetchBlocksMetadataResultsPool().Get()
-		ropts     = s.namespace.Options().RetentionOptions()
+		ropts     = namespace.BlockRetentionOptions(s.namespace.Options())
 		blockSize = ropts.BlockSize()
 		// Subtract one blocksize because all fetch requests are exclusive on the end side.
 		blockStart      = end.Truncate(blockSize).Add(-1 * blockSize)
@@ -2067,6 +2123,16 @@ func (s *dbShard) Bootstrap(
 		multiErr = multiErr.Add(err)
 	}
 
+	if err := s.tombstones.Load(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+
+	if s.retentionCutoffs != nil {
+		if err := s.retentionCutoffs.Load(); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+
 	// Now that this shard has finished bootstrapping, attempt to cache all of its seekers. Cannot call
 	// this earlier as block lease verification will fail due to the shards not being bootstrapped
 	// (and as a result no leases can be verified since the flush state is not yet known).
@@ -2376,7 +2442,58 @@ func (s *dbShard) ColdFlush(
 		return shardColdFlush{}, loopErr
 	}
 
-	if dirtySeries.Len() == 0 {
+	// Block starts that had data deleted need their filesets rewritten
+	// without the deleted data, even if no series have cold writes for them.
+	tombstonesPending := make(map[xtime.UnixNano]uint64)
+	for blockStart, version := range s.tombstones.Pending() {
+		hasWarmFlushed, err := s.hasWarmFlushed(blockStart)
+		if err != nil {
+			return shardColdFlush{}, err
+		}
+		if !hasWarmFlushed {
+			// Cold flushes can only merge with warm flushed filesets, the
+			// block stays pending until a cold flush after its warm flush.
+			continue
+		}
+		if dirtySeriesToWrite[blockStart] == nil {
+			dirtySeriesToWrite[blockStart] = newIDList(idElementPool)
+		}
+		tombstonesPending[blockStart] = version
+	}
+
+	// Block starts past the retention period of some of the series according
+	// to the retention rules need their filesets rewritten without them.
+	var (
+		now                = xtime.ToUnixNano(s.nowFn())
+		blockSize          = s.namespace.Options().RetentionOptions().BlockSize()
+		retentionPending   map[time.Duration]xtime.Range
+		retentionRewrites  = make(map[xtime.UnixNano]struct{})
+		retentionUnflushed = make(map[xtime.UnixNano]struct{})
+	)
+	if s.retentionCutoffs != nil {
+		earliest := retention.FlushTimeStart(namespace.BlockRetentionOptions(s.namespace.Options()), now)
+		retentionPending = s.retentionCutoffs.Pending(earliest, now)
+	}
+	for _, r := range retentionPending {
+		for blockStart := r.Start; blockStart.Before(r.End); blockStart = blockStart.Add(blockSize) {
+			hasWarmFlushed, err := s.hasWarmFlushed(blockStart)
+			if err != nil {
+				return shardColdFlush{}, err
+			}
+			if !hasWarmFlushed {
+				// Nothing was flushed for the block start, so there is
+				// nothing on disk to rewrite.
+				retentionUnflushed[blockStart] = struct{}{}
+				continue
+			}
+			if dirtySeriesToWrite[blockStart] == nil {
+				dirtySeriesToWrite[blockStart] = newIDList(idElementPool)
+			}
+			retentionRewrites[blockStart] = struct{}{}
+		}
+	}
+
+	if dirtySeries.Len() == 0 && len(tombstonesPending) == 0 && len(retentionRewrites) == 0 {
 		// Early exit if there is nothing dirty to merge. dirtySeriesToWrite
 		// may be non-empty when dirtySeries is empty because we purposely
 		// leave empty seriesLists in the dirtySeriesToWrite map to avoid having
@@ -2385,14 +2502,17 @@ func (s *dbShard) ColdFlush(
 	}
 
 	flush := shardColdFlush{
-		shard:   s,
-		doneFns: make([]shardColdFlushDone, 0, len(dirtySeriesToWrite)),
+		shard:              s,
+		doneFns:            make([]shardColdFlushDone, 0, len(dirtySeriesToWrite)),
+		retentionPending:   retentionPending,
+		retentionUnflushed: retentionUnflushed,
 	}
 	merger := s.newMergerFn(resources.fsReader, s.opts.DatabaseBlockOptions().DatabaseBlockAllocSize(),
 		s.opts.SegmentReaderPool(), s.opts.MultiReaderIteratorPool(),
 		s.opts.IdentifierPool(), s.opts.EncoderPool(), s.opts.ContextPool(),
 		s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix(), s.namespace.Options())
-	mergeWithMem := s.newFSMergeWithMemFn(s, s, dirtySeries, dirtySeriesToWrite)
+	mergeWithMem := s.newFSMergeWithMemFn(s, s, dirtySeries, dirtySeriesToWrite,
+		s.retentionCutoffs, now)
 	// Loop through each block that we know has ColdWrites. Since each block
 	// has its own fileset, if we encounter an error while trying to persist
 	// a block, we continue to try persisting other blocks.
@@ -2417,10 +2537,13 @@ func (s *dbShard) ColdFlush(
 			multiErr = multiErr.Add(err)
 			continue
 		}
+		tombstonesVersion, tombstonesApplied := tombstonesPending[startTime]
 		flush.doneFns = append(flush.doneFns, shardColdFlushDone{
-			startTime:   startTime,
-			nextVersion: nextVersion,
-			close:       close,
+			startTime:         startTime,
+			nextVersion:       nextVersion,
+			close:             close,
+			tombstonesVersion: tombstonesVersion,
+			tombstonesApplied: tombstonesApplied,
 		})
 	}
 	return flush, multiErr.FinalError()
@@ -2649,7 +2772,7 @@ func (s *dbShard) setFlushStateColdVersionFlushed(blockStart xtime.UnixNano, ver
 
 func (s *dbShard) removeAnyFlushStatesTooEarly(startTime xtime.UnixNano) {
 	s.flushState.Lock()
-	earliestFlush := retention.FlushTimeStart(s.namespace.Options().RetentionOptions(), startTime)
+	earliestFlush := retention.FlushTimeStart(namespace.BlockRetentionOptions(s.namespace.Options()), startTime)
 	for t := range s.flushState.statesByTime {
 		if t.Before(earliestFlush) {
 			delete(s.flushState.statesByTime, t)
@@ -2666,7 +2789,10 @@ func (s *dbShard) CleanupExpiredFileSets(earliestToRetain xtime.UnixNano) error
 			filePathPrefix, s.namespace.ID(), s.ID(), err)
 	}
 
-	return s.deleteFilesFn(expired)
+	multiErr := xerrors.NewMultiError()
+	multiErr = multiErr.Add(s.deleteFilesFn(expired))
+	multiErr = multiErr.Add(s.tombstones.Prune(earliestToRetain))
+	return multiErr.FinalError()
 }
 
 func (s *dbShard) CleanupCompactedFileSets() error {
@@ -2859,18 +2985,30 @@ func (s *dbShard) finishWriting(
 }
 
 type shardColdFlushDone struct {
-	startTime   xtime.UnixNano
-	nextVersion int
-	close       persist.DataCloser
+	startTime         xtime.UnixNano
+	nextVersion       int
+	close             persist.DataCloser
+	tombstonesVersion uint64
+	tombstonesApplied bool
 }
 
 type shardColdFlush struct {
 	shard   *dbShard
 	doneFns []shardColdFlushDone
+	// retentionPending are the block starts pending a rewrite for each
+	// retention period of the retention rules when the cold flush started.
+	retentionPending   map[time.Duration]xtime.Range
+	retentionUnflushed map[xtime.UnixNano]struct{}
 }
 
 func (s shardColdFlush) Done() error {
-	multiErr := xerrors.NewMultiError()
+	var (
+		multiErr  = xerrors.NewMultiError()
+		rewritten = make(map[xtime.UnixNano]struct{}, len(s.doneFns)+len(s.retentionUnflushed))
+	)
+	for blockStart := range s.retentionUnflushed {
+		rewritten[blockStart] = struct{}{}
+	}
 	for _, done := range s.doneFns {
 		startTime := done.startTime
 		nextVersion := done.nextVersion
@@ -2883,7 +3021,16 @@ func (s shardColdFlush) Done() error {
 		err := s.shard.finishWriting(startTime, nextVersion, false)
 		if err != nil {
 			multiErr = multiErr.Add(err)
+			continue
 		}
+
+		if done.tombstonesApplied {
+			s.shard.tombstones.MarkApplied(startTime, done.tombstonesVersion)
+		}
+		rewritten[startTime] = struct{}{}
+	}
+	if len(s.retentionPending) > 0 {
+		multiErr = multiErr.Add(s.shard.retentionCutoffs.Advance(s.retentionPending, rewritten))
 	}
 	return multiErr.FinalError()
 }
@@ -0,0 +1,213 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// maxRetentionRewritesPerColdFlush bounds the number of block starts a
+// single cold flush of a shard rewrites per retention period, so that
+// enabling retention rules on a namespace with a long retention does not
+// rewrite all of its filesets at once.
+const maxRetentionRewritesPerColdFlush = 8
+
+// shardRetentionCutoffs tracks how far the filesets of a shard were
+// rewritten without the series that the retention rules of its namespace
+// expire before the block retention period of the namespace.
+//
+// A block start contains expired series for a retention period once it is
+// before the flush time start of that period, and since block starts expire
+// in order the rewritten ones are tracked as a cutoff per retention period
+// rather than per series or per block start. The cutoffs are persisted in the
+// shard's data directory so that blocks are not rewritten again on restart.
+type shardRetentionCutoffs struct {
+	sync.Mutex
+
+	// persistLock serializes persisting so that older cutoffs never
+	// overwrite newer ones.
+	persistLock sync.Mutex
+	fsOpts      fs.Options
+	nsID        ident.ID
+	shard       uint32
+	blockSize   time.Duration
+	rules       namespace.RetentionRulesMatcher
+	// periods are the distinct retention periods of the namespace and its
+	// retention rules shorter than the block retention period.
+	periods []time.Duration
+	cutoffs map[time.Duration]xtime.UnixNano
+}
+
+// newShardRetentionCutoffs returns nil if the namespace has no retention
+// rules that expire series before its blocks.
+func newShardRetentionCutoffs(
+	fsOpts fs.Options,
+	nsMetadata namespace.Metadata,
+	shard uint32,
+	rules namespace.RetentionRulesMatcher,
+) *shardRetentionCutoffs {
+	if rules == nil {
+		return nil
+	}
+	var (
+		nsOpts          = nsMetadata.Options()
+		blockRetention  = namespace.BlockRetentionOptions(nsOpts).RetentionPeriod()
+		retentionPeriod = nsOpts.RetentionOptions().RetentionPeriod()
+		periods         []time.Duration
+	)
+	addPeriod := func(period time.Duration) {
+		if period >= blockRetention {
+			return
+		}
+		for _, p := range periods {
+			if p == period {
+				return
+			}
+		}
+		periods = append(periods, period)
+	}
+	addPeriod(retentionPeriod)
+	for _, rule := range nsOpts.RetentionRules() {
+		addPeriod(rule.RetentionPeriod)
+	}
+	if len(periods) == 0 {
+		return nil
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i] < periods[j] })
+
+	return &shardRetentionCutoffs{
+		fsOpts:    fsOpts,
+		nsID:      nsMetadata.ID(),
+		shard:     shard,
+		blockSize: nsOpts.RetentionOptions().BlockSize(),
+		rules:     rules,
+		periods:   periods,
+		cutoffs:   make(map[time.Duration]xtime.UnixNano, len(periods)),
+	}
+}
+
+// Load replaces the cutoffs with those persisted on disk, dropping those of
+// retention periods that are no longer in use. Retention periods without a
+// persisted cutoff have all their expired block starts pending a rewrite.
+func (c *shardRetentionCutoffs) Load() error {
+	persisted, err := fs.ReadShardRetentionCutoffs(c.fsOpts.FilePathPrefix(), c.nsID, c.shard)
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.cutoffs = make(map[time.Duration]xtime.UnixNano, len(c.periods))
+	for _, period := range c.periods {
+		if cutoff, ok := persisted[period]; ok {
+			c.cutoffs[period] = cutoff
+		}
+	}
+	return nil
+}
+
+// Pending returns the range of block starts that need a rewrite for each
+// retention period at the given time, starting at the earliest block start
+// that is retained and bounded to maxRetentionRewritesPerColdFlush blocks.
+func (c *shardRetentionCutoffs) Pending(
+	earliest xtime.UnixNano,
+	now xtime.UnixNano,
+) map[time.Duration]xtime.Range {
+	c.Lock()
+	defer c.Unlock()
+	var pending map[time.Duration]xtime.Range
+	for _, period := range c.periods {
+		start := c.cutoffs[period]
+		if start.Before(earliest) {
+			start = earliest
+		}
+		end := retention.FlushTimeStartForRetentionPeriod(period, c.blockSize, now)
+		if limit := start.Add(maxRetentionRewritesPerColdFlush * c.blockSize); limit.Before(end) {
+			end = limit
+		}
+		if !start.Before(end) {
+			continue
+		}
+		if pending == nil {
+			pending = make(map[time.Duration]xtime.Range, len(c.periods))
+		}
+		pending[period] = xtime.Range{Start: start, End: end}
+	}
+	return pending
+}
+
+// Advance moves the cutoff of each pending retention period past the
+// contiguous block starts that were rewritten and persists the cutoffs if
+// any of them moved.
+func (c *shardRetentionCutoffs) Advance(
+	pending map[time.Duration]xtime.Range,
+	rewritten map[xtime.UnixNano]struct{},
+) error {
+	c.persistLock.Lock()
+	defer c.persistLock.Unlock()
+
+	c.Lock()
+	var advanced bool
+	for period, r := range pending {
+		cutoff := r.Start
+		for cutoff.Before(r.End) {
+			if _, ok := rewritten[cutoff]; !ok {
+				break
+			}
+			cutoff = cutoff.Add(c.blockSize)
+		}
+		if cutoff.After(c.cutoffs[period]) {
+			c.cutoffs[period] = cutoff
+			advanced = true
+		}
+	}
+	if !advanced {
+		c.Unlock()
+		return nil
+	}
+	cutoffs := make(map[time.Duration]xtime.UnixNano, len(c.cutoffs))
+	for period, cutoff := range c.cutoffs {
+		cutoffs[period] = cutoff
+	}
+	c.Unlock()
+
+	return fs.WriteShardRetentionCutoffs(c.fsOpts, c.nsID, c.shard, cutoffs)
+}
+
+// Expired returns whether the series with the given tags is past its
+// retention period in the given block start at the given time.
+func (c *shardRetentionCutoffs) Expired(
+	fields []doc.Field,
+	blockStart xtime.UnixNano,
+	now xtime.UnixNano,
+) bool {
+	retentionPeriod := c.rules.RetentionPeriod(fields)
+	return blockStart.Before(retention.FlushTimeStartForRetentionPeriod(retentionPeriod, c.blockSize, now))
+}
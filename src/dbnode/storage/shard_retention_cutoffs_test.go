@@ -0,0 +1,150 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/storage/series"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestShardRetentionCutoffs(
+	t *testing.T,
+	dir string,
+	rules []namespace.RetentionRule,
+) *shardRetentionCutoffs {
+	nsOpts := namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().
+			SetRetentionPeriod(48 * time.Hour).
+			SetBlockSize(time.Hour)).
+		SetRetentionRules(rules)
+	md, err := namespace.NewMetadata(ident.StringID("ns"), nsOpts)
+	require.NoError(t, err)
+	matcher, err := series.NewRetentionRulesMatcher(nsOpts)
+	require.NoError(t, err)
+
+	fsOpts := fs.NewOptions().SetFilePathPrefix(dir)
+	return newShardRetentionCutoffs(fsOpts, md, 0, matcher)
+}
+
+func TestShardRetentionCutoffsNoShorterPeriods(t *testing.T) {
+	cutoffs := newTestShardRetentionCutoffs(t, t.TempDir(), []namespace.RetentionRule{
+		{Filter: "tier:gold", RetentionPeriod: 96 * time.Hour},
+	})
+	require.NotNil(t, cutoffs)
+	assert.Equal(t, []time.Duration{48 * time.Hour}, cutoffs.periods)
+
+	cutoffs = newTestShardRetentionCutoffs(t, t.TempDir(), []namespace.RetentionRule{
+		{Filter: "tier:gold", RetentionPeriod: 48 * time.Hour},
+	})
+	assert.Nil(t, cutoffs)
+}
+
+func TestShardRetentionCutoffsPendingAndAdvance(t *testing.T) {
+	var (
+		dir   = t.TempDir()
+		rules = []namespace.RetentionRule{
+			{Filter: "env:staging", RetentionPeriod: 6 * time.Hour},
+			{Filter: "tier:gold", RetentionPeriod: 96 * time.Hour},
+		}
+	)
+	cutoffs := newTestShardRetentionCutoffs(t, dir, rules)
+	require.NotNil(t, cutoffs)
+	require.NoError(t, cutoffs.Load())
+
+	var (
+		now      = xtime.Now().Truncate(time.Hour)
+		earliest = now.Add(-96 * time.Hour)
+	)
+	pending := cutoffs.Pending(earliest, now)
+	require.Equal(t, map[time.Duration]xtime.Range{
+		6 * time.Hour:  {Start: earliest, End: earliest.Add(maxRetentionRewritesPerColdFlush * time.Hour)},
+		48 * time.Hour: {Start: earliest, End: earliest.Add(maxRetentionRewritesPerColdFlush * time.Hour)},
+	}, pending)
+
+	// Cutoffs only advance past contiguous rewritten block starts.
+	rewritten := map[xtime.UnixNano]struct{}{
+		earliest:                   {},
+		earliest.Add(time.Hour):     {},
+		earliest.Add(3 * time.Hour): {},
+	}
+	require.NoError(t, cutoffs.Advance(pending, rewritten))
+	pending = cutoffs.Pending(earliest, now)
+	assert.Equal(t, earliest.Add(2*time.Hour), pending[6*time.Hour].Start)
+	assert.Equal(t, earliest.Add(2*time.Hour), pending[48*time.Hour].Start)
+
+	// The cutoffs are restored on load, dropping those of retention periods
+	// no longer in use.
+	loaded := newTestShardRetentionCutoffs(t, dir, rules[1:])
+	require.NoError(t, loaded.Load())
+	assert.Equal(t, map[time.Duration]xtime.UnixNano{
+		48 * time.Hour: earliest.Add(2 * time.Hour),
+	}, loaded.cutoffs)
+
+	// Nothing is pending once the cutoffs reach the flush time start of
+	// their retention period.
+	rewritten = make(map[xtime.UnixNano]struct{})
+	for blockStart := earliest; blockStart.Before(now); blockStart = blockStart.Add(time.Hour) {
+		rewritten[blockStart] = struct{}{}
+	}
+	for i := 0; i < 96/maxRetentionRewritesPerColdFlush; i++ {
+		require.NoError(t, loaded.Advance(loaded.Pending(earliest, now), rewritten))
+	}
+	assert.Empty(t, loaded.Pending(earliest, now))
+	assert.Equal(t, now.Add(-48*time.Hour), loaded.cutoffs[48*time.Hour])
+}
+
+func TestShardRetentionCutoffsExpired(t *testing.T) {
+	cutoffs := newTestShardRetentionCutoffs(t, t.TempDir(), []namespace.RetentionRule{
+		{Filter: "env:staging", RetentionPeriod: 6 * time.Hour},
+		{Filter: "tier:gold", RetentionPeriod: 96 * time.Hour},
+	})
+	require.NotNil(t, cutoffs)
+
+	var (
+		now     = xtime.Now().Truncate(time.Hour)
+		staging = []doc.Field{{Name: []byte("env"), Value: []byte("staging")}}
+		gold    = []doc.Field{{Name: []byte("tier"), Value: []byte("gold")}}
+	)
+	for _, test := range []struct {
+		fields     []doc.Field
+		blockStart xtime.UnixNano
+		expected   bool
+	}{
+		{fields: staging, blockStart: now.Add(-5 * time.Hour), expected: false},
+		{fields: staging, blockStart: now.Add(-7 * time.Hour), expected: true},
+		{fields: nil, blockStart: now.Add(-47 * time.Hour), expected: false},
+		{fields: nil, blockStart: now.Add(-49 * time.Hour), expected: true},
+		{fields: gold, blockStart: now.Add(-95 * time.Hour), expected: false},
+	} {
+		assert.Equal(t, test.expected, cutoffs.Expired(test.fields, test.blockStart, now))
+	}
+}
@@ -795,6 +795,8 @@ func newFSMergeWithMemTestFn(
 	_ series.QueryableBlockRetriever,
 	_ *dirtySeriesMap,
 	_ map[xtime.UnixNano]*idList,
+	_ *shardRetentionCutoffs,
+	_ xtime.UnixNano,
 ) fs.MergeWith {
 	return fs.NewNoopMergeWith()
 }
@@ -1710,7 +1712,7 @@ func TestShardReadEncodedCachesSeriesWithRecentlyReadPolicy(t *testing.T) {
 		Return(blockReaders[1], nil)
 
 	// Check reads as expected
-	iter, err := shard.ReadEncoded(ctx, ident.StringID("foo"), start, end, namespace.Context{})
+	iter, err := shard.ReadEncoded(ctx, ident.StringID("foo"), start, end, namespace.Context{}, series.ReadOptions{})
 	require.NoError(t, err)
 	count := 0
 	for iter.Next(ctx) {
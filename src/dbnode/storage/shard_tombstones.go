@@ -0,0 +1,291 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// shardTombstones tracks the deleted time ranges of the series of a shard
+// as well as the block starts whose filesets still contain deleted data.
+// New tombstones are appended to a file in the shard's data directory so that
+// deletes survive restarts, the file is only rewritten as a whole when
+// tombstones are pruned.
+type shardTombstones struct {
+	sync.RWMutex
+
+	// persistLock serializes persisting so that an older set of tombstones
+	// never overwrites a newer one.
+	persistLock sync.Mutex
+	fsOpts      fs.Options
+	nsID        ident.ID
+	shard       uint32
+	blockSize   time.Duration
+
+	series map[string]xtime.Ranges
+	// pending maps block starts that need to be rewritten by a cold flush
+	// to the version of the most recent delete that touched them, so that a
+	// cold flush only clears block starts that were not deleted from since it
+	// started.
+	pending map[xtime.UnixNano]uint64
+	version uint64
+	// unindexed holds the block starts rewritten without deleted data whose
+	// index blocks have not been rebuilt since.
+	unindexed map[xtime.UnixNano]struct{}
+}
+
+func newShardTombstones(
+	fsOpts fs.Options,
+	nsID ident.ID,
+	shard uint32,
+	blockSize time.Duration,
+) *shardTombstones {
+	return &shardTombstones{
+		fsOpts:    fsOpts,
+		nsID:      nsID,
+		shard:     shard,
+		blockSize: blockSize,
+		series:    make(map[string]xtime.Ranges),
+		pending:   make(map[xtime.UnixNano]uint64),
+		unindexed: make(map[xtime.UnixNano]struct{}),
+	}
+}
+
+// Get returns a copy of the deleted time ranges of a series, or nil if the
+// series has no deleted data.
+func (t *shardTombstones) Get(id ident.ID) xtime.Ranges {
+	t.RLock()
+	defer t.RUnlock()
+	if len(t.series) == 0 {
+		return nil
+	}
+	ranges, ok := t.series[id.String()]
+	if !ok {
+		return nil
+	}
+	return ranges.Clone()
+}
+
+// Len returns the number of series with deleted data.
+func (t *shardTombstones) Len() int {
+	t.RLock()
+	n := len(t.series)
+	t.RUnlock()
+	return n
+}
+
+// Add appends tombstones for the given range of each series to the persisted
+// ones and only then marks the range as deleted, so that a delete that fails
+// to persist neither hides data from reads nor has it dropped by a cold flush.
+func (t *shardTombstones) Add(ids []ident.ID, tr xtime.Range) error {
+	t.persistLock.Lock()
+	defer t.persistLock.Unlock()
+
+	appended := make([]fs.SeriesTombstones, 0, len(ids))
+	for _, id := range ids {
+		appended = append(appended, fs.SeriesTombstones{
+			ID:     id,
+			Ranges: []xtime.Range{tr},
+		})
+	}
+	if err := fs.AppendShardTombstones(t.fsOpts, t.nsID, t.shard, appended); err != nil {
+		return err
+	}
+
+	t.Lock()
+	for _, id := range ids {
+		t.addWithLock(id.String(), tr)
+	}
+	t.addPendingWithLock(tr)
+	t.Unlock()
+	return nil
+}
+
+// Load replaces the tombstones with those persisted on disk. All block
+// starts covered by the tombstones are marked pending since it is not known
+// whether they were rewritten before the tombstones were last persisted.
+// The file is then rewritten to compact the appended tombstones and drop
+// any partially appended ones, which later appends would follow otherwise.
+func (t *shardTombstones) Load() error {
+	t.persistLock.Lock()
+	defer t.persistLock.Unlock()
+
+	persisted, err := fs.ReadShardTombstones(t.fsOpts.FilePathPrefix(), t.nsID, t.shard)
+	if err != nil {
+		return err
+	}
+	if len(persisted) == 0 {
+		t.Lock()
+		t.series = make(map[string]xtime.Ranges)
+		t.pending = make(map[xtime.UnixNano]uint64)
+		t.Unlock()
+		return nil
+	}
+
+	t.Lock()
+	t.series = make(map[string]xtime.Ranges, len(persisted))
+	t.pending = make(map[xtime.UnixNano]uint64)
+	for _, s := range persisted {
+		for _, r := range s.Ranges {
+			t.addWithLock(s.ID.String(), r)
+			t.addPendingWithLock(r)
+		}
+	}
+	compacted := t.toPersistWithLock()
+	t.Unlock()
+
+	return fs.WriteShardTombstones(t.fsOpts, t.nsID, t.shard, compacted)
+}
+
+// Prune drops the tombstones and pending block starts before the given time
+// and persists the resulting tombstones if any were dropped.
+func (t *shardTombstones) Prune(before xtime.UnixNano) error {
+	t.persistLock.Lock()
+	defer t.persistLock.Unlock()
+
+	persisted, pruned := t.prune(before)
+	if !pruned {
+		return nil
+	}
+	return fs.WriteShardTombstones(t.fsOpts, t.nsID, t.shard, persisted)
+}
+
+func (t *shardTombstones) prune(before xtime.UnixNano) ([]fs.SeriesTombstones, bool) {
+	t.Lock()
+	defer t.Unlock()
+	var (
+		expired = xtime.Range{Start: 0, End: before}
+		pruned  bool
+	)
+	for id, ranges := range t.series {
+		if !ranges.Overlaps(expired) {
+			continue
+		}
+		pruned = true
+		ranges.RemoveRange(expired)
+		if ranges.IsEmpty() {
+			delete(t.series, id)
+		}
+	}
+	for blockStart := range t.pending {
+		if blockStart.Before(before) {
+			delete(t.pending, blockStart)
+		}
+	}
+	for blockStart := range t.unindexed {
+		if blockStart.Before(before) {
+			delete(t.unindexed, blockStart)
+		}
+	}
+	if !pruned {
+		return nil, false
+	}
+	return t.toPersistWithLock(), true
+}
+
+// Pending returns the block starts whose filesets still contain deleted
+// data along with the version of their most recent delete.
+func (t *shardTombstones) Pending() map[xtime.UnixNano]uint64 {
+	t.RLock()
+	defer t.RUnlock()
+	if len(t.pending) == 0 {
+		return nil
+	}
+	pending := make(map[xtime.UnixNano]uint64, len(t.pending))
+	for blockStart, version := range t.pending {
+		pending[blockStart] = version
+	}
+	return pending
+}
+
+// MarkApplied marks the block start as rewritten, unless it was deleted from
+// again since the given version was read. The block start is then awaiting
+// a rebuild of its index block either way.
+func (t *shardTombstones) MarkApplied(blockStart xtime.UnixNano, version uint64) {
+	t.Lock()
+	if curr, ok := t.pending[blockStart]; ok && curr == version {
+		delete(t.pending, blockStart)
+	}
+	t.unindexed[blockStart] = struct{}{}
+	t.Unlock()
+}
+
+// Unindexed returns the block starts that were rewritten without deleted
+// data since their index blocks were last rebuilt.
+func (t *shardTombstones) Unindexed() []xtime.UnixNano {
+	t.RLock()
+	defer t.RUnlock()
+	if len(t.unindexed) == 0 {
+		return nil
+	}
+	result := make([]xtime.UnixNano, 0, len(t.unindexed))
+	for blockStart := range t.unindexed {
+		result = append(result, blockStart)
+	}
+	return result
+}
+
+// MarkIndexed marks the index blocks of the block starts as rebuilt.
+func (t *shardTombstones) MarkIndexed(blockStarts []xtime.UnixNano) {
+	t.Lock()
+	for _, blockStart := range blockStarts {
+		delete(t.unindexed, blockStart)
+	}
+	t.Unlock()
+}
+
+func (t *shardTombstones) addWithLock(id string, tr xtime.Range) {
+	ranges, ok := t.series[id]
+	if !ok {
+		ranges = xtime.NewRanges()
+		t.series[id] = ranges
+	}
+	ranges.AddRange(tr)
+}
+
+func (t *shardTombstones) addPendingWithLock(tr xtime.Range) {
+	t.version++
+	blockStart := tr.Start.Truncate(t.blockSize)
+	for ; blockStart.Before(tr.End); blockStart = blockStart.Add(t.blockSize) {
+		t.pending[blockStart] = t.version
+	}
+}
+
+func (t *shardTombstones) toPersistWithLock() []fs.SeriesTombstones {
+	result := make([]fs.SeriesTombstones, 0, len(t.series))
+	for id, ranges := range t.series {
+		persisted := fs.SeriesTombstones{
+			ID:     ident.StringID(id),
+			Ranges: make([]xtime.Range, 0, ranges.Len()),
+		}
+		it := ranges.Iter()
+		for it.Next() {
+			persisted.Ranges = append(persisted.Ranges, it.Value())
+		}
+		result = append(result, persisted)
+	}
+	return result
+}
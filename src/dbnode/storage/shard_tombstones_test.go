@@ -0,0 +1,166 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestShardTombstones(t *testing.T) (*shardTombstones, func()) {
+	dir, err := ioutil.TempDir("", "shard-tombstones")
+	require.NoError(t, err)
+
+	fsOpts := fs.NewOptions().SetFilePathPrefix(dir)
+	tombstones := newShardTombstones(fsOpts, ident.StringID("ns"), 0, time.Hour)
+	return tombstones, func() { os.RemoveAll(dir) }
+}
+
+func TestShardTombstonesAddAndLoad(t *testing.T) {
+	tombstones, cleanup := newTestShardTombstones(t)
+	defer cleanup()
+
+	start := xtime.Now().Truncate(time.Hour)
+	deleted := xtime.Range{Start: start.Add(30 * time.Minute), End: start.Add(90 * time.Minute)}
+	require.NoError(t, tombstones.Add([]ident.ID{ident.StringID("foo")}, deleted))
+
+	assert.Equal(t, 1, tombstones.Len())
+	assert.Nil(t, tombstones.Get(ident.StringID("bar")))
+	ranges := tombstones.Get(ident.StringID("foo"))
+	require.NotNil(t, ranges)
+	assert.True(t, ranges.Overlaps(deleted))
+
+	pending := tombstones.Pending()
+	require.Len(t, pending, 2)
+	assert.Contains(t, pending, start)
+	assert.Contains(t, pending, start.Add(time.Hour))
+
+	// Applying with a stale version leaves the block start pending.
+	tombstones.MarkApplied(start, pending[start]-1)
+	tombstones.MarkApplied(start.Add(time.Hour), pending[start.Add(time.Hour)])
+	assert.Len(t, tombstones.Pending(), 1)
+
+	// Loading from disk restores the tombstones and marks every covered
+	// block start pending again.
+	loaded := newShardTombstones(tombstones.fsOpts, tombstones.nsID, 0, time.Hour)
+	require.NoError(t, loaded.Load())
+	assert.Equal(t, 1, loaded.Len())
+	assert.True(t, loaded.Get(ident.StringID("foo")).Overlaps(deleted))
+	assert.Len(t, loaded.Pending(), 2)
+}
+
+func TestShardTombstonesAddNotAppliedIfNotPersisted(t *testing.T) {
+	tombstones, cleanup := newTestShardTombstones(t)
+	defer cleanup()
+
+	// Block the shard directory with a file so that appending fails.
+	shardDir := fs.ShardDataDirPath(tombstones.fsOpts.FilePathPrefix(), tombstones.nsID, 0)
+	require.NoError(t, os.MkdirAll(filepath.Dir(shardDir), 0o755))
+	require.NoError(t, ioutil.WriteFile(shardDir, nil, 0o644))
+
+	start := xtime.Now().Truncate(time.Hour)
+	require.Error(t, tombstones.Add([]ident.ID{ident.StringID("foo")},
+		xtime.Range{Start: start, End: start.Add(time.Hour)}))
+	assert.Equal(t, 0, tombstones.Len())
+	assert.Nil(t, tombstones.Get(ident.StringID("foo")))
+	assert.Len(t, tombstones.Pending(), 0)
+}
+
+func TestShardTombstonesPrune(t *testing.T) {
+	tombstones, cleanup := newTestShardTombstones(t)
+	defer cleanup()
+
+	start := xtime.Now().Truncate(time.Hour)
+	require.NoError(t, tombstones.Add([]ident.ID{ident.StringID("foo")},
+		xtime.Range{Start: start, End: start.Add(time.Hour)}))
+	require.NoError(t, tombstones.Add([]ident.ID{ident.StringID("bar")},
+		xtime.Range{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)}))
+
+	require.NoError(t, tombstones.Prune(start.Add(time.Hour)))
+	assert.Equal(t, 1, tombstones.Len())
+	assert.Nil(t, tombstones.Get(ident.StringID("foo")))
+	assert.NotNil(t, tombstones.Get(ident.StringID("bar")))
+	assert.Len(t, tombstones.Pending(), 1)
+
+	loaded := newShardTombstones(tombstones.fsOpts, tombstones.nsID, 0, time.Hour)
+	require.NoError(t, loaded.Load())
+	assert.Equal(t, 1, loaded.Len())
+	assert.Nil(t, loaded.Get(ident.StringID("foo")))
+}
+
+func TestShardTombstonesLoadCompactsAppends(t *testing.T) {
+	tombstones, cleanup := newTestShardTombstones(t)
+	defer cleanup()
+
+	start := xtime.Now().Truncate(time.Hour)
+	for i := 0; i < 3; i++ {
+		blockStart := start.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, tombstones.Add([]ident.ID{ident.StringID("foo")},
+			xtime.Range{Start: blockStart, End: blockStart.Add(time.Minute)}))
+	}
+
+	prefix := tombstones.fsOpts.FilePathPrefix()
+	persisted, err := fs.ReadShardTombstones(prefix, tombstones.nsID, 0)
+	require.NoError(t, err)
+	assert.Len(t, persisted, 3)
+
+	loaded := newShardTombstones(tombstones.fsOpts, tombstones.nsID, 0, time.Hour)
+	require.NoError(t, loaded.Load())
+	assert.Equal(t, 1, loaded.Len())
+	assert.Len(t, loaded.Pending(), 3)
+
+	persisted, err = fs.ReadShardTombstones(prefix, tombstones.nsID, 0)
+	require.NoError(t, err)
+	require.Len(t, persisted, 1)
+	assert.Len(t, persisted[0].Ranges, 3)
+}
+
+func TestShardTombstonesUnindexed(t *testing.T) {
+	tombstones, cleanup := newTestShardTombstones(t)
+	defer cleanup()
+
+	start := xtime.Now().Truncate(time.Hour)
+	require.NoError(t, tombstones.Add([]ident.ID{ident.StringID("foo")},
+		xtime.Range{Start: start, End: start.Add(2 * time.Hour)}))
+	assert.Len(t, tombstones.Unindexed(), 0)
+
+	// Only block starts whose filesets were rewritten need their index
+	// blocks rebuilt.
+	pending := tombstones.Pending()
+	tombstones.MarkApplied(start, pending[start])
+	assert.Equal(t, []xtime.UnixNano{start}, tombstones.Unindexed())
+
+	tombstones.MarkApplied(start.Add(time.Hour), pending[start.Add(time.Hour)])
+	assert.Len(t, tombstones.Unindexed(), 2)
+
+	tombstones.MarkIndexed([]xtime.UnixNano{start})
+	assert.Equal(t, []xtime.UnixNano{start.Add(time.Hour)}, tombstones.Unindexed())
+}
@@ -30,11 +30,15 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tiering"
+	"github.com/m3db/m3/src/dbnode/replication"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -43,6 +47,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
 	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/dbnode/storage/limits/admission"
 	"github.com/m3db/m3/src/dbnode/storage/limits/permits"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
@@ -120,6 +125,20 @@ func (m *MockDatabase) EXPECT() *MockDatabaseMockRecorder {
 	return m.recorder
 }
 
+// AdmitWrite mocks base method.
+func (m *MockDatabase) AdmitWrite(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdmitWrite", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdmitWrite indicates an expected call of AdmitWrite.
+func (mr *MockDatabaseMockRecorder) AdmitWrite(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdmitWrite", reflect.TypeOf((*MockDatabase)(nil).AdmitWrite), ctx)
+}
+
 // AggregateQuery mocks base method.
 func (m *MockDatabase) AggregateQuery(ctx context.Context, namespace ident.ID, query index.Query, opts index.AggregationOptions) (index.AggregateQueryResult, error) {
 	m.ctrl.T.Helper()
@@ -205,6 +224,21 @@ func (mr *MockDatabaseMockRecorder) BootstrapState() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapState", reflect.TypeOf((*MockDatabase)(nil).BootstrapState))
 }
 
+// Cardinality mocks base method.
+func (m *MockDatabase) Cardinality(ctx context.Context, namespace ident.ID, query index.Query, opts index.CardinalityOptions) (index.CardinalityQueryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, namespace, query, opts)
+	ret0, _ := ret[0].(index.CardinalityQueryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockDatabaseMockRecorder) Cardinality(ctx, namespace, query, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockDatabase)(nil).Cardinality), ctx, namespace, query, opts)
+}
+
 // Close mocks base method.
 func (m *MockDatabase) Close() error {
 	m.ctrl.T.Helper()
@@ -219,6 +253,36 @@ func (mr *MockDatabaseMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDatabase)(nil).Close))
 }
 
+// DeleteTagged mocks base method.
+func (m *MockDatabase) DeleteTagged(ctx context.Context, namespace ident.ID, query index.Query, start, end time0.UnixNano) (map[uint32]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", ctx, namespace, query, start, end)
+	ret0, _ := ret[0].(map[uint32]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged.
+func (mr *MockDatabaseMockRecorder) DeleteTagged(ctx, namespace, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*MockDatabase)(nil).DeleteTagged), ctx, namespace, query, start, end)
+}
+
+// DiscardQuarantinedWrites mocks base method.
+func (m *MockDatabase) DiscardQuarantinedWrites(namespace ident.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardQuarantinedWrites", namespace)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscardQuarantinedWrites indicates an expected call of DiscardQuarantinedWrites.
+func (mr *MockDatabaseMockRecorder) DiscardQuarantinedWrites(namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardQuarantinedWrites", reflect.TypeOf((*MockDatabase)(nil).DiscardQuarantinedWrites), namespace)
+}
+
 // FetchBlocks mocks base method.
 func (m *MockDatabase) FetchBlocks(ctx context.Context, namespace ident.ID, shard uint32, id ident.ID, starts []time0.UnixNano) ([]block.FetchBlockResult, error) {
 	m.ctrl.T.Helper()
@@ -250,6 +314,36 @@ func (mr *MockDatabaseMockRecorder) FetchBlocksMetadataV2(ctx, namespace, shard,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlocksMetadataV2", reflect.TypeOf((*MockDatabase)(nil).FetchBlocksMetadataV2), ctx, namespace, shard, start, end, limit, pageToken, opts)
 }
 
+// FetchMerkleLeavesMetadata mocks base method.
+func (m *MockDatabase) FetchMerkleLeavesMetadata(ctx context.Context, namespace ident.ID, shard uint32, blockStart time0.UnixNano, depth int, leaves []int) (block.FetchBlocksMetadataResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMerkleLeavesMetadata", ctx, namespace, shard, blockStart, depth, leaves)
+	ret0, _ := ret[0].(block.FetchBlocksMetadataResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMerkleLeavesMetadata indicates an expected call of FetchMerkleLeavesMetadata.
+func (mr *MockDatabaseMockRecorder) FetchMerkleLeavesMetadata(ctx, namespace, shard, blockStart, depth, leaves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMerkleLeavesMetadata", reflect.TypeOf((*MockDatabase)(nil).FetchMerkleLeavesMetadata), ctx, namespace, shard, blockStart, depth, leaves)
+}
+
+// FetchMerkleTree mocks base method.
+func (m *MockDatabase) FetchMerkleTree(ctx context.Context, namespace ident.ID, shard uint32, blockStart time0.UnixNano, depth int) (*digest.MerkleTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMerkleTree", ctx, namespace, shard, blockStart, depth)
+	ret0, _ := ret[0].(*digest.MerkleTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMerkleTree indicates an expected call of FetchMerkleTree.
+func (mr *MockDatabaseMockRecorder) FetchMerkleTree(ctx, namespace, shard, blockStart, depth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMerkleTree", reflect.TypeOf((*MockDatabase)(nil).FetchMerkleTree), ctx, namespace, shard, blockStart, depth)
+}
+
 // FlushState mocks base method.
 func (m *MockDatabase) FlushState(namespace ident.ID, shardID uint32, blockStart time0.UnixNano) (fileOpState, error) {
 	m.ctrl.T.Helper()
@@ -364,6 +458,37 @@ func (mr *MockDatabaseMockRecorder) Options() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Options", reflect.TypeOf((*MockDatabase)(nil).Options))
 }
 
+// PromoteQuarantinedWrites mocks base method.
+func (m *MockDatabase) PromoteQuarantinedWrites(ctx context.Context, namespace ident.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteQuarantinedWrites", ctx, namespace)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteQuarantinedWrites indicates an expected call of PromoteQuarantinedWrites.
+func (mr *MockDatabaseMockRecorder) PromoteQuarantinedWrites(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteQuarantinedWrites", reflect.TypeOf((*MockDatabase)(nil).PromoteQuarantinedWrites), ctx, namespace)
+}
+
+// QuarantinedWrites mocks base method.
+func (m *MockDatabase) QuarantinedWrites(namespace ident.ID, limit int) ([]QuarantinedWrite, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuarantinedWrites", namespace, limit)
+	ret0, _ := ret[0].([]QuarantinedWrite)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QuarantinedWrites indicates an expected call of QuarantinedWrites.
+func (mr *MockDatabaseMockRecorder) QuarantinedWrites(namespace, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuarantinedWrites", reflect.TypeOf((*MockDatabase)(nil).QuarantinedWrites), namespace, limit)
+}
+
 // QueryIDs mocks base method.
 func (m *MockDatabase) QueryIDs(ctx context.Context, namespace ident.ID, query index.Query, opts index.QueryOptions) (index.QueryResult, error) {
 	m.ctrl.T.Helper()
@@ -380,18 +505,18 @@ func (mr *MockDatabaseMockRecorder) QueryIDs(ctx, namespace, query, opts interfa
 }
 
 // ReadEncoded mocks base method.
-func (m *MockDatabase) ReadEncoded(ctx context.Context, namespace, id ident.ID, start, end time0.UnixNano) (series.BlockReaderIter, error) {
+func (m *MockDatabase) ReadEncoded(ctx context.Context, namespace, id ident.ID, start, end time0.UnixNano, opts ReadEncodedOptions) (series.BlockReaderIter, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadEncoded", ctx, namespace, id, start, end)
+	ret := m.ctrl.Call(m, "ReadEncoded", ctx, namespace, id, start, end, opts)
 	ret0, _ := ret[0].(series.BlockReaderIter)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadEncoded indicates an expected call of ReadEncoded.
-func (mr *MockDatabaseMockRecorder) ReadEncoded(ctx, namespace, id, start, end interface{}) *gomock.Call {
+func (mr *MockDatabaseMockRecorder) ReadEncoded(ctx, namespace, id, start, end, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEncoded", reflect.TypeOf((*MockDatabase)(nil).ReadEncoded), ctx, namespace, id, start, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEncoded", reflect.TypeOf((*MockDatabase)(nil).ReadEncoded), ctx, namespace, id, start, end, opts)
 }
 
 // Repair mocks base method.
@@ -530,6 +655,20 @@ func (m *Mockdatabase) EXPECT() *MockdatabaseMockRecorder {
 	return m.recorder
 }
 
+// AdmitWrite mocks base method.
+func (m *Mockdatabase) AdmitWrite(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdmitWrite", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdmitWrite indicates an expected call of AdmitWrite.
+func (mr *MockdatabaseMockRecorder) AdmitWrite(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdmitWrite", reflect.TypeOf((*Mockdatabase)(nil).AdmitWrite), ctx)
+}
+
 // AggregateQuery mocks base method.
 func (m *Mockdatabase) AggregateQuery(ctx context.Context, namespace ident.ID, query index.Query, opts index.AggregationOptions) (index.AggregateQueryResult, error) {
 	m.ctrl.T.Helper()
@@ -615,6 +754,21 @@ func (mr *MockdatabaseMockRecorder) BootstrapState() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapState", reflect.TypeOf((*Mockdatabase)(nil).BootstrapState))
 }
 
+// Cardinality mocks base method.
+func (m *Mockdatabase) Cardinality(ctx context.Context, namespace ident.ID, query index.Query, opts index.CardinalityOptions) (index.CardinalityQueryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, namespace, query, opts)
+	ret0, _ := ret[0].(index.CardinalityQueryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockdatabaseMockRecorder) Cardinality(ctx, namespace, query, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*Mockdatabase)(nil).Cardinality), ctx, namespace, query, opts)
+}
+
 // Close mocks base method.
 func (m *Mockdatabase) Close() error {
 	m.ctrl.T.Helper()
@@ -629,6 +783,36 @@ func (mr *MockdatabaseMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*Mockdatabase)(nil).Close))
 }
 
+// DeleteTagged mocks base method.
+func (m *Mockdatabase) DeleteTagged(ctx context.Context, namespace ident.ID, query index.Query, start, end time0.UnixNano) (map[uint32]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", ctx, namespace, query, start, end)
+	ret0, _ := ret[0].(map[uint32]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged.
+func (mr *MockdatabaseMockRecorder) DeleteTagged(ctx, namespace, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*Mockdatabase)(nil).DeleteTagged), ctx, namespace, query, start, end)
+}
+
+// DiscardQuarantinedWrites mocks base method.
+func (m *Mockdatabase) DiscardQuarantinedWrites(namespace ident.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardQuarantinedWrites", namespace)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscardQuarantinedWrites indicates an expected call of DiscardQuarantinedWrites.
+func (mr *MockdatabaseMockRecorder) DiscardQuarantinedWrites(namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardQuarantinedWrites", reflect.TypeOf((*Mockdatabase)(nil).DiscardQuarantinedWrites), namespace)
+}
+
 // FetchBlocks mocks base method.
 func (m *Mockdatabase) FetchBlocks(ctx context.Context, namespace ident.ID, shard uint32, id ident.ID, starts []time0.UnixNano) ([]block.FetchBlockResult, error) {
 	m.ctrl.T.Helper()
@@ -660,6 +844,36 @@ func (mr *MockdatabaseMockRecorder) FetchBlocksMetadataV2(ctx, namespace, shard,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlocksMetadataV2", reflect.TypeOf((*Mockdatabase)(nil).FetchBlocksMetadataV2), ctx, namespace, shard, start, end, limit, pageToken, opts)
 }
 
+// FetchMerkleLeavesMetadata mocks base method.
+func (m *Mockdatabase) FetchMerkleLeavesMetadata(ctx context.Context, namespace ident.ID, shard uint32, blockStart time0.UnixNano, depth int, leaves []int) (block.FetchBlocksMetadataResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMerkleLeavesMetadata", ctx, namespace, shard, blockStart, depth, leaves)
+	ret0, _ := ret[0].(block.FetchBlocksMetadataResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMerkleLeavesMetadata indicates an expected call of FetchMerkleLeavesMetadata.
+func (mr *MockdatabaseMockRecorder) FetchMerkleLeavesMetadata(ctx, namespace, shard, blockStart, depth, leaves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMerkleLeavesMetadata", reflect.TypeOf((*Mockdatabase)(nil).FetchMerkleLeavesMetadata), ctx, namespace, shard, blockStart, depth, leaves)
+}
+
+// FetchMerkleTree mocks base method.
+func (m *Mockdatabase) FetchMerkleTree(ctx context.Context, namespace ident.ID, shard uint32, blockStart time0.UnixNano, depth int) (*digest.MerkleTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMerkleTree", ctx, namespace, shard, blockStart, depth)
+	ret0, _ := ret[0].(*digest.MerkleTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMerkleTree indicates an expected call of FetchMerkleTree.
+func (mr *MockdatabaseMockRecorder) FetchMerkleTree(ctx, namespace, shard, blockStart, depth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMerkleTree", reflect.TypeOf((*Mockdatabase)(nil).FetchMerkleTree), ctx, namespace, shard, blockStart, depth)
+}
+
 // FlushState mocks base method.
 func (m *Mockdatabase) FlushState(namespace ident.ID, shardID uint32, blockStart time0.UnixNano) (fileOpState, error) {
 	m.ctrl.T.Helper()
@@ -789,6 +1003,37 @@ func (mr *MockdatabaseMockRecorder) OwnedNamespaces() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnedNamespaces", reflect.TypeOf((*Mockdatabase)(nil).OwnedNamespaces))
 }
 
+// PromoteQuarantinedWrites mocks base method.
+func (m *Mockdatabase) PromoteQuarantinedWrites(ctx context.Context, namespace ident.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteQuarantinedWrites", ctx, namespace)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteQuarantinedWrites indicates an expected call of PromoteQuarantinedWrites.
+func (mr *MockdatabaseMockRecorder) PromoteQuarantinedWrites(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteQuarantinedWrites", reflect.TypeOf((*Mockdatabase)(nil).PromoteQuarantinedWrites), ctx, namespace)
+}
+
+// QuarantinedWrites mocks base method.
+func (m *Mockdatabase) QuarantinedWrites(namespace ident.ID, limit int) ([]QuarantinedWrite, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuarantinedWrites", namespace, limit)
+	ret0, _ := ret[0].([]QuarantinedWrite)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QuarantinedWrites indicates an expected call of QuarantinedWrites.
+func (mr *MockdatabaseMockRecorder) QuarantinedWrites(namespace, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuarantinedWrites", reflect.TypeOf((*Mockdatabase)(nil).QuarantinedWrites), namespace, limit)
+}
+
 // QueryIDs mocks base method.
 func (m *Mockdatabase) QueryIDs(ctx context.Context, namespace ident.ID, query index.Query, opts index.QueryOptions) (index.QueryResult, error) {
 	m.ctrl.T.Helper()
@@ -805,18 +1050,18 @@ func (mr *MockdatabaseMockRecorder) QueryIDs(ctx, namespace, query, opts interfa
 }
 
 // ReadEncoded mocks base method.
-func (m *Mockdatabase) ReadEncoded(ctx context.Context, namespace, id ident.ID, start, end time0.UnixNano) (series.BlockReaderIter, error) {
+func (m *Mockdatabase) ReadEncoded(ctx context.Context, namespace, id ident.ID, start, end time0.UnixNano, opts ReadEncodedOptions) (series.BlockReaderIter, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadEncoded", ctx, namespace, id, start, end)
+	ret := m.ctrl.Call(m, "ReadEncoded", ctx, namespace, id, start, end, opts)
 	ret0, _ := ret[0].(series.BlockReaderIter)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadEncoded indicates an expected call of ReadEncoded.
-func (mr *MockdatabaseMockRecorder) ReadEncoded(ctx, namespace, id, start, end interface{}) *gomock.Call {
+func (mr *MockdatabaseMockRecorder) ReadEncoded(ctx, namespace, id, start, end, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEncoded", reflect.TypeOf((*Mockdatabase)(nil).ReadEncoded), ctx, namespace, id, start, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEncoded", reflect.TypeOf((*Mockdatabase)(nil).ReadEncoded), ctx, namespace, id, start, end, opts)
 }
 
 // Repair mocks base method.
@@ -1247,6 +1492,21 @@ func (mr *MockdatabaseNamespaceMockRecorder) BootstrapState() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapState", reflect.TypeOf((*MockdatabaseNamespace)(nil).BootstrapState))
 }
 
+// Cardinality mocks base method.
+func (m *MockdatabaseNamespace) Cardinality(ctx context.Context, query index.Query, opts index.CardinalityOptions) (index.CardinalityQueryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, query, opts)
+	ret0, _ := ret[0].(index.CardinalityQueryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockdatabaseNamespaceMockRecorder) Cardinality(ctx, query, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockdatabaseNamespace)(nil).Cardinality), ctx, query, opts)
+}
+
 // Close mocks base method.
 func (m *MockdatabaseNamespace) Close() error {
 	m.ctrl.T.Helper()
@@ -1275,6 +1535,36 @@ func (mr *MockdatabaseNamespaceMockRecorder) ColdFlush(flush interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColdFlush", reflect.TypeOf((*MockdatabaseNamespace)(nil).ColdFlush), flush)
 }
 
+// DeleteTagged mocks base method.
+func (m *MockdatabaseNamespace) DeleteTagged(ctx context.Context, query index.Query, start, end time0.UnixNano) (map[uint32]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", ctx, query, start, end)
+	ret0, _ := ret[0].(map[uint32]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged.
+func (mr *MockdatabaseNamespaceMockRecorder) DeleteTagged(ctx, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*MockdatabaseNamespace)(nil).DeleteTagged), ctx, query, start, end)
+}
+
+// DiscardQuarantinedWrites mocks base method.
+func (m *MockdatabaseNamespace) DiscardQuarantinedWrites() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardQuarantinedWrites")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscardQuarantinedWrites indicates an expected call of DiscardQuarantinedWrites.
+func (mr *MockdatabaseNamespaceMockRecorder) DiscardQuarantinedWrites() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardQuarantinedWrites", reflect.TypeOf((*MockdatabaseNamespace)(nil).DiscardQuarantinedWrites))
+}
+
 // DocRef mocks base method.
 func (m *MockdatabaseNamespace) DocRef(id ident.ID) (doc.Metadata, bool, error) {
 	m.ctrl.T.Helper()
@@ -1336,6 +1626,20 @@ func (mr *MockdatabaseNamespaceMockRecorder) FlushIndex(flush interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushIndex", reflect.TypeOf((*MockdatabaseNamespace)(nil).FlushIndex), flush)
 }
 
+// FlushIndexDeletes mocks base method.
+func (m *MockdatabaseNamespace) FlushIndexDeletes(flush persist.IndexFlush) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushIndexDeletes", flush)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlushIndexDeletes indicates an expected call of FlushIndexDeletes.
+func (mr *MockdatabaseNamespaceMockRecorder) FlushIndexDeletes(flush interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushIndexDeletes", reflect.TypeOf((*MockdatabaseNamespace)(nil).FlushIndexDeletes), flush)
+}
+
 // FlushState mocks base method.
 func (m *MockdatabaseNamespace) FlushState(shardID uint32, blockStart time0.UnixNano) (fileOpState, error) {
 	m.ctrl.T.Helper()
@@ -1466,6 +1770,36 @@ func (mr *MockdatabaseNamespaceMockRecorder) PrepareBootstrap(ctx interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareBootstrap", reflect.TypeOf((*MockdatabaseNamespace)(nil).PrepareBootstrap), ctx)
 }
 
+// PromoteQuarantinedWrites mocks base method.
+func (m *MockdatabaseNamespace) PromoteQuarantinedWrites(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteQuarantinedWrites", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteQuarantinedWrites indicates an expected call of PromoteQuarantinedWrites.
+func (mr *MockdatabaseNamespaceMockRecorder) PromoteQuarantinedWrites(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteQuarantinedWrites", reflect.TypeOf((*MockdatabaseNamespace)(nil).PromoteQuarantinedWrites), ctx)
+}
+
+// QuarantinedWrites mocks base method.
+func (m *MockdatabaseNamespace) QuarantinedWrites(limit int) ([]QuarantinedWrite, int) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuarantinedWrites", limit)
+	ret0, _ := ret[0].([]QuarantinedWrite)
+	ret1, _ := ret[1].(int)
+	return ret0, ret1
+}
+
+// QuarantinedWrites indicates an expected call of QuarantinedWrites.
+func (mr *MockdatabaseNamespaceMockRecorder) QuarantinedWrites(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuarantinedWrites", reflect.TypeOf((*MockdatabaseNamespace)(nil).QuarantinedWrites), limit)
+}
+
 // QueryIDs mocks base method.
 func (m *MockdatabaseNamespace) QueryIDs(ctx context.Context, query index.Query, opts index.QueryOptions) (index.QueryResult, error) {
 	m.ctrl.T.Helper()
@@ -1482,18 +1816,18 @@ func (mr *MockdatabaseNamespaceMockRecorder) QueryIDs(ctx, query, opts interface
 }
 
 // ReadEncoded mocks base method.
-func (m *MockdatabaseNamespace) ReadEncoded(ctx context.Context, id ident.ID, start, end time0.UnixNano) (series.BlockReaderIter, error) {
+func (m *MockdatabaseNamespace) ReadEncoded(ctx context.Context, id ident.ID, start, end time0.UnixNano, opts ReadEncodedOptions) (series.BlockReaderIter, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadEncoded", ctx, id, start, end)
+	ret := m.ctrl.Call(m, "ReadEncoded", ctx, id, start, end, opts)
 	ret0, _ := ret[0].(series.BlockReaderIter)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadEncoded indicates an expected call of ReadEncoded.
-func (mr *MockdatabaseNamespaceMockRecorder) ReadEncoded(ctx, id, start, end interface{}) *gomock.Call {
+func (mr *MockdatabaseNamespaceMockRecorder) ReadEncoded(ctx, id, start, end, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEncoded", reflect.TypeOf((*MockdatabaseNamespace)(nil).ReadEncoded), ctx, id, start, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEncoded", reflect.TypeOf((*MockdatabaseNamespace)(nil).ReadEncoded), ctx, id, start, end, opts)
 }
 
 // ReadOnly mocks base method.
@@ -2014,6 +2348,34 @@ func (mr *MockdatabaseShardMockRecorder) ColdFlush(flush, resources, nsCtx, onFl
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColdFlush", reflect.TypeOf((*MockdatabaseShard)(nil).ColdFlush), flush, resources, nsCtx, onFlush)
 }
 
+// DeleteSeries mocks base method.
+func (m *MockdatabaseShard) DeleteSeries(ids []ident.ID, tr time0.Range) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ids, tr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockdatabaseShardMockRecorder) DeleteSeries(ids, tr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockdatabaseShard)(nil).DeleteSeries), ids, tr)
+}
+
+// DeletedBlockStartsUnindexed mocks base method.
+func (m *MockdatabaseShard) DeletedBlockStartsUnindexed() []time0.UnixNano {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletedBlockStartsUnindexed")
+	ret0, _ := ret[0].([]time0.UnixNano)
+	return ret0
+}
+
+// DeletedBlockStartsUnindexed indicates an expected call of DeletedBlockStartsUnindexed.
+func (mr *MockdatabaseShardMockRecorder) DeletedBlockStartsUnindexed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletedBlockStartsUnindexed", reflect.TypeOf((*MockdatabaseShard)(nil).DeletedBlockStartsUnindexed))
+}
+
 // DocRef mocks base method.
 func (m *MockdatabaseShard) DocRef(id ident.ID) (doc.Metadata, bool, error) {
 	m.ctrl.T.Helper()
@@ -2162,6 +2524,18 @@ func (mr *MockdatabaseShardMockRecorder) LoadBlocks(series interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBlocks", reflect.TypeOf((*MockdatabaseShard)(nil).LoadBlocks), series)
 }
 
+// MarkDeletedBlockStartsIndexed mocks base method.
+func (m *MockdatabaseShard) MarkDeletedBlockStartsIndexed(blockStarts []time0.UnixNano) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkDeletedBlockStartsIndexed", blockStarts)
+}
+
+// MarkDeletedBlockStartsIndexed indicates an expected call of MarkDeletedBlockStartsIndexed.
+func (mr *MockdatabaseShardMockRecorder) MarkDeletedBlockStartsIndexed(blockStarts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDeletedBlockStartsIndexed", reflect.TypeOf((*MockdatabaseShard)(nil).MarkDeletedBlockStartsIndexed), blockStarts)
+}
+
 // MarkWarmIndexFlushStateSuccessOrError mocks base method.
 func (m *MockdatabaseShard) MarkWarmIndexFlushStateSuccessOrError(blockStart time0.UnixNano, err error) {
 	m.ctrl.T.Helper()
@@ -2230,18 +2604,18 @@ func (mr *MockdatabaseShardMockRecorder) PrepareBootstrap(ctx interface{}) *gomo
 }
 
 // ReadEncoded mocks base method.
-func (m *MockdatabaseShard) ReadEncoded(ctx context.Context, id ident.ID, start, end time0.UnixNano, nsCtx namespace.Context) (series.BlockReaderIter, error) {
+func (m *MockdatabaseShard) ReadEncoded(ctx context.Context, id ident.ID, start, end time0.UnixNano, nsCtx namespace.Context, opts series.ReadOptions) (series.BlockReaderIter, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadEncoded", ctx, id, start, end, nsCtx)
+	ret := m.ctrl.Call(m, "ReadEncoded", ctx, id, start, end, nsCtx, opts)
 	ret0, _ := ret[0].(series.BlockReaderIter)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadEncoded indicates an expected call of ReadEncoded.
-func (mr *MockdatabaseShardMockRecorder) ReadEncoded(ctx, id, start, end, nsCtx interface{}) *gomock.Call {
+func (mr *MockdatabaseShardMockRecorder) ReadEncoded(ctx, id, start, end, nsCtx, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEncoded", reflect.TypeOf((*MockdatabaseShard)(nil).ReadEncoded), ctx, id, start, end, nsCtx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEncoded", reflect.TypeOf((*MockdatabaseShard)(nil).ReadEncoded), ctx, id, start, end, nsCtx, opts)
 }
 
 // Repair mocks base method.
@@ -2274,6 +2648,20 @@ func (mr *MockdatabaseShardMockRecorder) SeriesRefResolver(id, tags interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeriesRefResolver", reflect.TypeOf((*MockdatabaseShard)(nil).SeriesRefResolver), id, tags)
 }
 
+// SeriesTombstones mocks base method.
+func (m *MockdatabaseShard) SeriesTombstones(id ident.ID) time0.Ranges {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeriesTombstones", id)
+	ret0, _ := ret[0].(time0.Ranges)
+	return ret0
+}
+
+// SeriesTombstones indicates an expected call of SeriesTombstones.
+func (mr *MockdatabaseShardMockRecorder) SeriesTombstones(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeriesTombstones", reflect.TypeOf((*MockdatabaseShard)(nil).SeriesTombstones), id)
+}
+
 // Snapshot mocks base method.
 func (m *MockdatabaseShard) Snapshot(blockStart, snapshotStart time0.UnixNano, flush persist.SnapshotPreparer, nsCtx namespace.Context) (ShardSnapshotResult, error) {
 	m.ctrl.T.Helper()
@@ -2304,6 +2692,20 @@ func (mr *MockdatabaseShardMockRecorder) Tick(c, startTime, nsCtx interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tick", reflect.TypeOf((*MockdatabaseShard)(nil).Tick), c, startTime, nsCtx)
 }
 
+// TombstonesPending mocks base method.
+func (m *MockdatabaseShard) TombstonesPending() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TombstonesPending")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TombstonesPending indicates an expected call of TombstonesPending.
+func (mr *MockdatabaseShardMockRecorder) TombstonesPending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TombstonesPending", reflect.TypeOf((*MockdatabaseShard)(nil).TombstonesPending))
+}
+
 // TryRetrieveSeriesAndIncrementReaderWriterCount mocks base method.
 func (m *MockdatabaseShard) TryRetrieveSeriesAndIncrementReaderWriterCount(id ident.ID) (*Entry, WritableSeriesOptions, error) {
 	m.ctrl.T.Helper()
@@ -2532,6 +2934,21 @@ func (mr *MockNamespaceIndexMockRecorder) Bootstrapped() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bootstrapped", reflect.TypeOf((*MockNamespaceIndex)(nil).Bootstrapped))
 }
 
+// Cardinality mocks base method.
+func (m *MockNamespaceIndex) Cardinality(ctx context.Context, query index.Query, opts index.CardinalityOptions) (index.CardinalityQueryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, query, opts)
+	ret0, _ := ret[0].(index.CardinalityQueryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockNamespaceIndexMockRecorder) Cardinality(ctx, query, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockNamespaceIndex)(nil).Cardinality), ctx, query, opts)
+}
+
 // CleanupCorruptedFileSets mocks base method.
 func (m *MockNamespaceIndex) CleanupCorruptedFileSets() error {
 	m.ctrl.T.Helper()
@@ -2632,6 +3049,20 @@ func (mr *MockNamespaceIndexMockRecorder) Query(ctx, query, opts interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockNamespaceIndex)(nil).Query), ctx, query, opts)
 }
 
+// RebuildFlushedBlocks mocks base method.
+func (m *MockNamespaceIndex) RebuildFlushedBlocks(flush persist.IndexFlush, shards []databaseShard, blockStarts []time0.UnixNano) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildFlushedBlocks", flush, shards, blockStarts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildFlushedBlocks indicates an expected call of RebuildFlushedBlocks.
+func (mr *MockNamespaceIndexMockRecorder) RebuildFlushedBlocks(flush, shards, blockStarts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildFlushedBlocks", reflect.TypeOf((*MockNamespaceIndex)(nil).RebuildFlushedBlocks), flush, shards, blockStarts)
+}
+
 // Tick mocks base method.
 func (m *MockNamespaceIndex) Tick(c context.Cancellable, startTime time0.UnixNano) (namespaceIndexTickResult, error) {
 	m.ctrl.T.Helper()
@@ -2939,6 +3370,20 @@ func (mr *MockdatabaseFlushManagerMockRecorder) Report() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockdatabaseFlushManager)(nil).Report))
 }
 
+// WarmFlushBacklog mocks base method.
+func (m *MockdatabaseFlushManager) WarmFlushBacklog() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WarmFlushBacklog")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// WarmFlushBacklog indicates an expected call of WarmFlushBacklog.
+func (mr *MockdatabaseFlushManagerMockRecorder) WarmFlushBacklog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WarmFlushBacklog", reflect.TypeOf((*MockdatabaseFlushManager)(nil).WarmFlushBacklog))
+}
+
 // MockdatabaseCleanupManager is a mock of databaseCleanupManager interface.
 type MockdatabaseCleanupManager struct {
 	ctrl     *gomock.Controller
@@ -3122,6 +3567,20 @@ func (mr *MockdatabaseFileSystemManagerMockRecorder) Status() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockdatabaseFileSystemManager)(nil).Status))
 }
 
+// WarmFlushBacklog mocks base method.
+func (m *MockdatabaseFileSystemManager) WarmFlushBacklog() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WarmFlushBacklog")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// WarmFlushBacklog indicates an expected call of WarmFlushBacklog.
+func (mr *MockdatabaseFileSystemManagerMockRecorder) WarmFlushBacklog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WarmFlushBacklog", reflect.TypeOf((*MockdatabaseFileSystemManager)(nil).WarmFlushBacklog))
+}
+
 // MockdatabaseColdFlushManager is a mock of databaseColdFlushManager interface.
 type MockdatabaseColdFlushManager struct {
 	ctrl     *gomock.Controller
@@ -3697,6 +4156,20 @@ func (mr *MockdatabaseMediatorMockRecorder) Tick(forceType, startTime interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tick", reflect.TypeOf((*MockdatabaseMediator)(nil).Tick), forceType, startTime)
 }
 
+// WarmFlushBacklog mocks base method.
+func (m *MockdatabaseMediator) WarmFlushBacklog() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WarmFlushBacklog")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// WarmFlushBacklog indicates an expected call of WarmFlushBacklog.
+func (mr *MockdatabaseMediatorMockRecorder) WarmFlushBacklog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WarmFlushBacklog", reflect.TypeOf((*MockdatabaseMediator)(nil).WarmFlushBacklog))
+}
+
 // MockColdFlushNsOpts is a mock of ColdFlushNsOpts interface.
 type MockColdFlushNsOpts struct {
 	ctrl     *gomock.Controller
@@ -3877,7 +4350,6 @@ func (m *MockOptions) TickOptions() TickOptions {
 	return TickOptions{}
 }
 
-
 // EXPECT returns an object that allows the caller to indicate expected use.
 func (m *MockOptions) EXPECT() *MockOptionsMockRecorder {
 	return m.recorder
@@ -4023,6 +4495,20 @@ func (mr *MockOptionsMockRecorder) CommitLogOptions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitLogOptions", reflect.TypeOf((*MockOptions)(nil).CommitLogOptions))
 }
 
+// CommitLogReplicator mocks base method.
+func (m *MockOptions) CommitLogReplicator() replication.Replicator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitLogReplicator")
+	ret0, _ := ret[0].(replication.Replicator)
+	return ret0
+}
+
+// CommitLogReplicator indicates an expected call of CommitLogReplicator.
+func (mr *MockOptionsMockRecorder) CommitLogReplicator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitLogReplicator", reflect.TypeOf((*MockOptions)(nil).CommitLogReplicator))
+}
+
 // ContextPool mocks base method.
 func (m *MockOptions) ContextPool() context.Pool {
 	m.ctrl.T.Helper()
@@ -4275,6 +4761,20 @@ func (mr *MockOptionsMockRecorder) LimitsOptions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LimitsOptions", reflect.TypeOf((*MockOptions)(nil).LimitsOptions))
 }
 
+// MaxQuarantinedWrites mocks base method.
+func (m *MockOptions) MaxQuarantinedWrites() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxQuarantinedWrites")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// MaxQuarantinedWrites indicates an expected call of MaxQuarantinedWrites.
+func (mr *MockOptionsMockRecorder) MaxQuarantinedWrites() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxQuarantinedWrites", reflect.TypeOf((*MockOptions)(nil).MaxQuarantinedWrites))
+}
+
 // MediatorTickInterval mocks base method.
 func (m *MockOptions) MediatorTickInterval() time.Duration {
 	m.ctrl.T.Helper()
@@ -4471,6 +4971,20 @@ func (mr *MockOptionsMockRecorder) RetrieveRequestPool() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveRequestPool", reflect.TypeOf((*MockOptions)(nil).RetrieveRequestPool))
 }
 
+// RollupManager mocks base method.
+func (m *MockOptions) RollupManager() rollup.Manager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollupManager")
+	ret0, _ := ret[0].(rollup.Manager)
+	return ret0
+}
+
+// RollupManager indicates an expected call of RollupManager.
+func (mr *MockOptionsMockRecorder) RollupManager() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollupManager", reflect.TypeOf((*MockOptions)(nil).RollupManager))
+}
+
 // RuntimeOptionsManager mocks base method.
 func (m *MockOptions) RuntimeOptionsManager() runtime.OptionsManager {
 	m.ctrl.T.Helper()
@@ -4681,6 +5195,20 @@ func (mr *MockOptionsMockRecorder) SetCommitLogOptions(value interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCommitLogOptions", reflect.TypeOf((*MockOptions)(nil).SetCommitLogOptions), value)
 }
 
+// SetCommitLogReplicator mocks base method.
+func (m *MockOptions) SetCommitLogReplicator(value replication.Replicator) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCommitLogReplicator", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetCommitLogReplicator indicates an expected call of SetCommitLogReplicator.
+func (mr *MockOptionsMockRecorder) SetCommitLogReplicator(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCommitLogReplicator", reflect.TypeOf((*MockOptions)(nil).SetCommitLogReplicator), value)
+}
+
 // SetContextPool mocks base method.
 func (m *MockOptions) SetContextPool(value context.Pool) Options {
 	m.ctrl.T.Helper()
@@ -4947,6 +5475,20 @@ func (mr *MockOptionsMockRecorder) SetLimitsOptions(value interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimitsOptions", reflect.TypeOf((*MockOptions)(nil).SetLimitsOptions), value)
 }
 
+// SetMaxQuarantinedWrites mocks base method.
+func (m *MockOptions) SetMaxQuarantinedWrites(value int) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxQuarantinedWrites", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetMaxQuarantinedWrites indicates an expected call of SetMaxQuarantinedWrites.
+func (mr *MockOptionsMockRecorder) SetMaxQuarantinedWrites(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxQuarantinedWrites", reflect.TypeOf((*MockOptions)(nil).SetMaxQuarantinedWrites), value)
+}
+
 // SetMediatorTickInterval mocks base method.
 func (m *MockOptions) SetMediatorTickInterval(value time.Duration) Options {
 	m.ctrl.T.Helper()
@@ -5143,6 +5685,20 @@ func (mr *MockOptionsMockRecorder) SetRetrieveRequestPool(value interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRetrieveRequestPool", reflect.TypeOf((*MockOptions)(nil).SetRetrieveRequestPool), value)
 }
 
+// SetRollupManager mocks base method.
+func (m *MockOptions) SetRollupManager(value rollup.Manager) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRollupManager", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetRollupManager indicates an expected call of SetRollupManager.
+func (mr *MockOptionsMockRecorder) SetRollupManager(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRollupManager", reflect.TypeOf((*MockOptions)(nil).SetRollupManager), value)
+}
+
 // SetRuntimeOptionsManager mocks base method.
 func (m *MockOptions) SetRuntimeOptionsManager(value runtime.OptionsManager) Options {
 	m.ctrl.T.Helper()
@@ -5241,6 +5797,20 @@ func (mr *MockOptionsMockRecorder) SetTileAggregator(aggregator interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTileAggregator", reflect.TypeOf((*MockOptions)(nil).SetTileAggregator), aggregator)
 }
 
+// SetTieringManager mocks base method.
+func (m *MockOptions) SetTieringManager(value tiering.Manager) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTieringManager", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetTieringManager indicates an expected call of SetTieringManager.
+func (mr *MockOptionsMockRecorder) SetTieringManager(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTieringManager", reflect.TypeOf((*MockOptions)(nil).SetTieringManager), value)
+}
+
 // SetTruncateType mocks base method.
 func (m *MockOptions) SetTruncateType(value series.TruncateType) Options {
 	m.ctrl.T.Helper()
@@ -5255,6 +5825,20 @@ func (mr *MockOptionsMockRecorder) SetTruncateType(value interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTruncateType", reflect.TypeOf((*MockOptions)(nil).SetTruncateType), value)
 }
 
+// SetWriteAdmissionOptions mocks base method.
+func (m *MockOptions) SetWriteAdmissionOptions(value admission.Options) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWriteAdmissionOptions", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetWriteAdmissionOptions indicates an expected call of SetWriteAdmissionOptions.
+func (mr *MockOptionsMockRecorder) SetWriteAdmissionOptions(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWriteAdmissionOptions", reflect.TypeOf((*MockOptions)(nil).SetWriteAdmissionOptions), value)
+}
+
 // SetWriteBatchPool mocks base method.
 func (m *MockOptions) SetWriteBatchPool(value *writes.WriteBatchPool) Options {
 	m.ctrl.T.Helper()
@@ -5297,6 +5881,20 @@ func (mr *MockOptionsMockRecorder) SourceLoggerBuilder() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SourceLoggerBuilder", reflect.TypeOf((*MockOptions)(nil).SourceLoggerBuilder))
 }
 
+// TieringManager mocks base method.
+func (m *MockOptions) TieringManager() tiering.Manager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TieringManager")
+	ret0, _ := ret[0].(tiering.Manager)
+	return ret0
+}
+
+// TieringManager indicates an expected call of TieringManager.
+func (mr *MockOptionsMockRecorder) TieringManager() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TieringManager", reflect.TypeOf((*MockOptions)(nil).TieringManager))
+}
+
 // TileAggregator mocks base method.
 func (m *MockOptions) TileAggregator() TileAggregator {
 	m.ctrl.T.Helper()
@@ -5339,6 +5937,20 @@ func (mr *MockOptionsMockRecorder) Validate() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockOptions)(nil).Validate))
 }
 
+// WriteAdmissionOptions mocks base method.
+func (m *MockOptions) WriteAdmissionOptions() admission.Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteAdmissionOptions")
+	ret0, _ := ret[0].(admission.Options)
+	return ret0
+}
+
+// WriteAdmissionOptions indicates an expected call of WriteAdmissionOptions.
+func (mr *MockOptionsMockRecorder) WriteAdmissionOptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteAdmissionOptions", reflect.TypeOf((*MockOptions)(nil).WriteAdmissionOptions))
+}
+
 // WriteBatchPool mocks base method.
 func (m *MockOptions) WriteBatchPool() *writes.WriteBatchPool {
 	m.ctrl.T.Helper()
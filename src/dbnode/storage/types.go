@@ -27,12 +27,16 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
+	"github.com/m3db/m3/src/dbnode/persist/fs/tiering"
+	"github.com/m3db/m3/src/dbnode/replication"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -41,6 +45,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
 	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/dbnode/storage/limits/admission"
 	"github.com/m3db/m3/src/dbnode/storage/limits/permits"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
@@ -62,6 +67,18 @@ import (
 // PageToken is an opaque paging token.
 type PageToken []byte
 
+// ReadEncodedOptions are the options for reading the encoded blocks of a
+// series.
+type ReadEncodedOptions struct {
+	// Resolution is an optional hint of the coarsest resolution the read
+	// needs. Blocks compacted into rollups at a resolution within it are
+	// read from the rollups instead of the raw filesets.
+	Resolution time.Duration
+	// RollupAggregation is the aggregation of the rollups read, which
+	// should suit the function the read datapoints are used for.
+	RollupAggregation rollup.Aggregation
+}
+
 // IndexedErrorHandler can handle individual errors based on their index. It
 // is used primarily in cases where we need to handle errors in batches, but
 // want to avoid an intermediary allocation of []error.
@@ -167,12 +184,22 @@ type Database interface {
 		opts index.AggregationOptions,
 	) (index.AggregateQueryResult, error)
 
+	// Cardinality returns the cardinality statistics of the series matching
+	// the given query.
+	Cardinality(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		opts index.CardinalityOptions,
+	) (index.CardinalityQueryResult, error)
+
 	// ReadEncoded retrieves encoded segments for an ID.
 	ReadEncoded(
 		ctx context.Context,
 		namespace ident.ID,
 		id ident.ID,
 		start, end xtime.UnixNano,
+		opts ReadEncodedOptions,
 	) (series.BlockReaderIter, error)
 
 	// FetchBlocks retrieves data blocks for a given id and a list of block
@@ -198,6 +225,29 @@ type Database interface {
 		opts block.FetchBlocksMetadataOptions,
 	) (block.FetchBlocksMetadataResults, PageToken, error)
 
+	// FetchMerkleTree returns the merkle tree of the checksums of the series
+	// of a shard block with the given depth, read from the fileset of the
+	// block where possible and otherwise built from the blocks metadata.
+	FetchMerkleTree(
+		ctx context.Context,
+		namespace ident.ID,
+		shard uint32,
+		blockStart xtime.UnixNano,
+		depth int,
+	) (*digest.MerkleTree, error)
+
+	// FetchMerkleLeavesMetadata retrieves the blocks metadata of the series of
+	// a shard block that belong to the given leaves of a merkle tree with the
+	// given depth.
+	FetchMerkleLeavesMetadata(
+		ctx context.Context,
+		namespace ident.ID,
+		shard uint32,
+		blockStart xtime.UnixNano,
+		depth int,
+		leaves []int,
+	) (block.FetchBlocksMetadataResults, error)
+
 	// Bootstrap bootstraps the database.
 	Bootstrap() error
 
@@ -212,12 +262,41 @@ type Database interface {
 	// IsOverloaded determines whether the database is overloaded.
 	IsOverloaded() bool
 
+	// AdmitWrite returns nil if a write should proceed given the current
+	// write pressure, possibly after delaying the caller, otherwise it
+	// returns an overloaded error.
+	AdmitWrite(ctx context.Context) error
+
 	// Repair will issue a repair and return nil on success or error on error.
 	Repair() error
 
 	// Truncate truncates data for the given namespace.
 	Truncate(namespace ident.ID) (int64, error)
 
+	// DeleteTagged deletes the data within [start, end) of the series of the
+	// given namespace that match the query, returning the number of series
+	// the delete was applied to by shard.
+	DeleteTagged(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		start, end xtime.UnixNano,
+	) (map[uint32]int64, error)
+
+	// QuarantinedWrites returns the oldest late writes quarantined for the
+	// given namespace up to the limit, or all of them if the limit is not
+	// positive, along with the number of quarantined late writes.
+	QuarantinedWrites(namespace ident.ID, limit int) ([]QuarantinedWrite, int, error)
+
+	// PromoteQuarantinedWrites writes the late writes quarantined for the
+	// given namespace as cold writes to be persisted by the next cold flush,
+	// returning the number of writes promoted.
+	PromoteQuarantinedWrites(ctx context.Context, namespace ident.ID) (int, error)
+
+	// DiscardQuarantinedWrites drops the late writes quarantined for the
+	// given namespace, returning the number of writes discarded.
+	DiscardQuarantinedWrites(namespace ident.ID) (int, error)
+
 	// BootstrapState captures and returns a snapshot of the databases'
 	// bootstrap state.
 	BootstrapState() DatabaseBootstrapState
@@ -349,11 +428,20 @@ type databaseNamespace interface {
 		opts index.AggregationOptions,
 	) (index.AggregateQueryResult, error)
 
+	// Cardinality returns the cardinality statistics of the series matching
+	// the given query.
+	Cardinality(
+		ctx context.Context,
+		query index.Query,
+		opts index.CardinalityOptions,
+	) (index.CardinalityQueryResult, error)
+
 	// ReadEncoded reads data for given id within [start, end).
 	ReadEncoded(
 		ctx context.Context,
 		id ident.ID,
 		start, end xtime.UnixNano,
+		opts ReadEncodedOptions,
 	) (series.BlockReaderIter, error)
 
 	// FetchBlocks retrieves data blocks for a given id and a list of block
@@ -392,6 +480,10 @@ type databaseNamespace interface {
 	// ColdFlush flushes unflushed in-memory ColdWrites.
 	ColdFlush(flush persist.FlushPreparer) error
 
+	// FlushIndexDeletes rebuilds the flushed index blocks that still hold
+	// series removed from the filesets by cold flushes applying deletes.
+	FlushIndexDeletes(flush persist.IndexFlush) error
+
 	// Snapshot snapshots unflushed in-memory warm and cold writes.
 	Snapshot(blockStarts []xtime.UnixNano, snapshotTime xtime.UnixNano, flush persist.SnapshotPreparer) error
 
@@ -403,6 +495,29 @@ type databaseNamespace interface {
 	// Truncate truncates the in-memory data for this namespace.
 	Truncate() (int64, error)
 
+	// DeleteTagged deletes the data within [start, end) of the series that
+	// match the query, returning the number of series the delete was
+	// applied to by shard.
+	DeleteTagged(
+		ctx context.Context,
+		query index.Query,
+		start, end xtime.UnixNano,
+	) (map[uint32]int64, error)
+
+	// QuarantinedWrites returns the oldest quarantined late writes up to the
+	// limit, or all of them if the limit is not positive, along with the
+	// number of quarantined late writes.
+	QuarantinedWrites(limit int) ([]QuarantinedWrite, int)
+
+	// PromoteQuarantinedWrites writes the quarantined late writes as cold
+	// writes to be persisted by the next cold flush, returning the number of
+	// writes promoted. Writes that fail to be promoted remain quarantined.
+	PromoteQuarantinedWrites(ctx context.Context) (int, error)
+
+	// DiscardQuarantinedWrites drops the quarantined late writes, returning
+	// the number of writes discarded.
+	DiscardQuarantinedWrites() (int, error)
+
 	// Repair repairs the namespace data for a given time range.
 	Repair(repairer databaseShardRepairer, tr xtime.Range, opts NamespaceRepairOptions) error
 
@@ -509,8 +624,31 @@ type databaseShard interface {
 		id ident.ID,
 		start, end xtime.UnixNano,
 		nsCtx namespace.Context,
+		opts series.ReadOptions,
 	) (series.BlockReaderIter, error)
 
+	// DeleteSeries writes tombstones for the data of the given series within
+	// the time range, omitting it from reads and removing it from the
+	// filesets on disk on the next cold flush.
+	DeleteSeries(ids []ident.ID, tr xtime.Range) error
+
+	// SeriesTombstones returns the deleted time ranges of a series, or nil
+	// if the series has no deleted data.
+	SeriesTombstones(id ident.ID) xtime.Ranges
+
+	// TombstonesPending returns whether any filesets of the shard still
+	// contain deleted data that a cold flush needs to remove.
+	TombstonesPending() bool
+
+	// DeletedBlockStartsUnindexed returns the block starts whose filesets
+	// were rewritten without deleted data since their index blocks were
+	// last rebuilt.
+	DeletedBlockStartsUnindexed() []xtime.UnixNano
+
+	// MarkDeletedBlockStartsIndexed marks the index blocks of the block
+	// starts as rebuilt.
+	MarkDeletedBlockStartsIndexed(blockStarts []xtime.UnixNano)
+
 	// FetchBlocks retrieves data blocks for a given id and a list of block
 	// start times.
 	FetchBlocks(
@@ -683,6 +821,14 @@ type NamespaceIndex interface {
 		opts index.AggregationOptions,
 	) (index.AggregateQueryResult, error)
 
+	// Cardinality returns the cardinality statistics of the series matching
+	// the given query.
+	Cardinality(
+		ctx context.Context,
+		query index.Query,
+		opts index.CardinalityOptions,
+	) (index.CardinalityQueryResult, error)
+
 	// Bootstrap bootstraps the index with the provided segments.
 	Bootstrap(
 		bootstrapResults result.IndexResults,
@@ -712,6 +858,15 @@ type NamespaceIndex interface {
 		shards []databaseShard,
 	) error
 
+	// RebuildFlushedBlocks rebuilds the index blocks covering the given data
+	// block starts that were already flushed to disk from the filesets of
+	// the owned shards of the database.
+	RebuildFlushedBlocks(
+		flush persist.IndexFlush,
+		shards []databaseShard,
+		blockStarts []xtime.UnixNano,
+	) error
+
 	// WarmFlushBlockStarts returns all index blockStarts which have been flushed to disk.
 	WarmFlushBlockStarts() []xtime.UnixNano
 
@@ -823,6 +978,10 @@ type databaseFlushManager interface {
 	// successful snapshot, if any.
 	LastSuccessfulSnapshotStartTime() (xtime.UnixNano, bool)
 
+	// WarmFlushBacklog returns the number of block starts pending a warm
+	// flush, summed across namespaces, as of the last warm flush.
+	WarmFlushBacklog() int
+
 	// Report reports runtime information.
 	Report()
 }
@@ -866,6 +1025,10 @@ type databaseFileSystemManager interface {
 	// LastSuccessfulSnapshotStartTime returns the start time of the last
 	// successful snapshot, if any.
 	LastSuccessfulSnapshotStartTime() (xtime.UnixNano, bool)
+
+	// WarmFlushBacklog returns the number of block starts pending a warm
+	// flush, summed across namespaces, as of the last warm flush.
+	WarmFlushBacklog() int
 }
 
 // databaseColdFlushManager manages the database related cold flush activities.
@@ -987,6 +1150,10 @@ type databaseMediator interface {
 	// LastSuccessfulSnapshotStartTime returns the start time of the last
 	// successful snapshot, if any.
 	LastSuccessfulSnapshotStartTime() (xtime.UnixNano, bool)
+
+	// WarmFlushBacklog returns the number of block starts pending a warm
+	// flush, summed across namespaces, as of the last warm flush.
+	WarmFlushBacklog() int
 }
 
 // ColdFlushNsOpts are options for OnColdFlush.ColdFlushNamespace.
@@ -1123,6 +1290,30 @@ type Options interface {
 	// IndexClaimsManager returns the index claims manager.
 	IndexClaimsManager() fs.IndexClaimsManager
 
+	// SetTieringManager sets the manager that tiers filesets to object
+	// storage, nil disables tiering.
+	SetTieringManager(value tiering.Manager) Options
+
+	// TieringManager returns the manager that tiers filesets to object
+	// storage, nil if tiering is disabled.
+	TieringManager() tiering.Manager
+
+	// SetRollupManager sets the manager that compacts old blocks into
+	// rollups, nil disables rollups.
+	SetRollupManager(value rollup.Manager) Options
+
+	// RollupManager returns the manager that compacts old blocks into
+	// rollups, nil if rollups are disabled.
+	RollupManager() rollup.Manager
+
+	// SetCommitLogReplicator sets the replicator that replicates the
+	// commitlog to a standby cluster, nil disables replication.
+	SetCommitLogReplicator(value replication.Replicator) Options
+
+	// CommitLogReplicator returns the replicator that replicates the
+	// commitlog to a standby cluster, nil if replication is disabled.
+	CommitLogReplicator() replication.Replicator
+
 	// SetDatabaseBlockRetrieverManager sets the block retriever manager to
 	// use when bootstrapping retrievable blocks instead of blocks
 	// containing data.
@@ -1272,6 +1463,14 @@ type Options interface {
 	// ForceColdWritesEnabled returns options for forcing cold writes.
 	ForceColdWritesEnabled() bool
 
+	// SetMaxQuarantinedWrites sets the maximum number of late writes
+	// quarantined per namespace before further late writes are rejected.
+	SetMaxQuarantinedWrites(value int) Options
+
+	// MaxQuarantinedWrites returns the maximum number of late writes
+	// quarantined per namespace before further late writes are rejected.
+	MaxQuarantinedWrites() int
+
 	// SetSourceLoggerBuilder sets the limit source logger builder.
 	SetSourceLoggerBuilder(value limits.SourceLoggerBuilder) Options
 
@@ -1346,6 +1545,12 @@ type Options interface {
 	// SetLimitsOptions sets the limits options.
 	SetLimitsOptions(value limits.Options) Options
 
+	// WriteAdmissionOptions returns the write admission options.
+	WriteAdmissionOptions() admission.Options
+
+	// SetWriteAdmissionOptions sets the write admission options.
+	SetWriteAdmissionOptions(value admission.Options) Options
+
 	// CoreFn gets the function for determining the current core.
 	CoreFn() xsync.CoreFn
 
@@ -1411,6 +1616,8 @@ type newFSMergeWithMemFn func(
 	retriever series.QueryableBlockRetriever,
 	dirtySeries *dirtySeriesMap,
 	dirtySeriesToWrite map[xtime.UnixNano]*idList,
+	retentionCutoffs *shardRetentionCutoffs,
+	now xtime.UnixNano,
 ) fs.MergeWith
 
 // NewBackgroundProcessFn is a function that creates and returns a new BackgroundProcess.
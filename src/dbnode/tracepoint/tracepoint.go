@@ -49,6 +49,9 @@ const (
 	// DBAggregateQuery is the operation name for the db AggregateQuery path.
 	DBAggregateQuery = "storage.db.AggregateQuery"
 
+	// DBCardinality is the operation name for the db Cardinality path.
+	DBCardinality = "storage.db.Cardinality"
+
 	// DBFetchBlocks is the operation name for the db FetchBlocks path.
 	DBFetchBlocks = "storage.db.FetchBlocks"
 
@@ -85,6 +88,9 @@ const (
 	// NSIdxAggregateQuery is the operation name for the nsIndex AggregateQuery path.
 	NSIdxAggregateQuery = "storage.nsIndex.AggregateQuery"
 
+	// NSIdxCardinality is the operation name for the nsIndex Cardinality path.
+	NSIdxCardinality = "storage.nsIndex.Cardinality"
+
 	// NSIdxQueryHelper is the operation name for the nsIndex query path.
 	NSIdxQueryHelper = "storage.nsIndex.query"
 
@@ -114,6 +120,9 @@ const (
 	// BlockAggregate is the operation name for the index block aggregate path.
 	BlockAggregate = "storage/index.block.Aggregate"
 
+	// BlockCardinality is the operation name for the index block cardinality path.
+	BlockCardinality = "storage/index.block.Cardinality"
+
 	// BootstrapProcessRun is the operation name for the bootstrap process Run path.
 	BootstrapProcessRun = "bootstrap.bootstrapProcess.Run"
 
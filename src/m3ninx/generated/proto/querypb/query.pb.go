@@ -35,6 +35,7 @@
 		ConjunctionQuery
 		DisjunctionQuery
 		AllQuery
+		NumericRangeQuery
 		Query
 */
 package querypb
@@ -43,6 +44,8 @@ import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 
+import binary "encoding/binary"
+
 import io "io"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -176,6 +179,54 @@ func (m *AllQuery) String() string            { return proto.CompactTextString(m
 func (*AllQuery) ProtoMessage()               {}
 func (*AllQuery) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{6} }
 
+type NumericRangeQuery struct {
+	Field        []byte  `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Min          float64 `protobuf:"fixed64,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max          float64 `protobuf:"fixed64,3,opt,name=max,proto3" json:"max,omitempty"`
+	MinInclusive bool    `protobuf:"varint,4,opt,name=minInclusive,proto3" json:"minInclusive,omitempty"`
+	MaxInclusive bool    `protobuf:"varint,5,opt,name=maxInclusive,proto3" json:"maxInclusive,omitempty"`
+}
+
+func (m *NumericRangeQuery) Reset()                    { *m = NumericRangeQuery{} }
+func (m *NumericRangeQuery) String() string            { return proto.CompactTextString(m) }
+func (*NumericRangeQuery) ProtoMessage()               {}
+func (*NumericRangeQuery) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{7} }
+
+func (m *NumericRangeQuery) GetField() []byte {
+	if m != nil {
+		return m.Field
+	}
+	return nil
+}
+
+func (m *NumericRangeQuery) GetMin() float64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *NumericRangeQuery) GetMax() float64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *NumericRangeQuery) GetMinInclusive() bool {
+	if m != nil {
+		return m.MinInclusive
+	}
+	return false
+}
+
+func (m *NumericRangeQuery) GetMaxInclusive() bool {
+	if m != nil {
+		return m.MaxInclusive
+	}
+	return false
+}
+
 type Query struct {
 	// Types that are valid to be assigned to Query:
 	//	*Query_Term
@@ -185,13 +236,14 @@ type Query struct {
 	//	*Query_Disjunction
 	//	*Query_All
 	//	*Query_Field
+	//	*Query_NumericRange
 	Query isQuery_Query `protobuf_oneof:"query"`
 }
 
 func (m *Query) Reset()                    { *m = Query{} }
 func (m *Query) String() string            { return proto.CompactTextString(m) }
 func (*Query) ProtoMessage()               {}
-func (*Query) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{7} }
+func (*Query) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{8} }
 
 type isQuery_Query interface {
 	isQuery_Query()
@@ -220,14 +272,18 @@ type Query_All struct {
 type Query_Field struct {
 	Field *FieldQuery `protobuf:"bytes,7,opt,name=field,oneof"`
 }
+type Query_NumericRange struct {
+	NumericRange *NumericRangeQuery `protobuf:"bytes,8,opt,name=numericRange,oneof"`
+}
 
-func (*Query_Term) isQuery_Query()        {}
-func (*Query_Regexp) isQuery_Query()      {}
-func (*Query_Negation) isQuery_Query()    {}
-func (*Query_Conjunction) isQuery_Query() {}
-func (*Query_Disjunction) isQuery_Query() {}
-func (*Query_All) isQuery_Query()         {}
-func (*Query_Field) isQuery_Query()       {}
+func (*Query_Term) isQuery_Query()         {}
+func (*Query_Regexp) isQuery_Query()       {}
+func (*Query_Negation) isQuery_Query()     {}
+func (*Query_Conjunction) isQuery_Query()  {}
+func (*Query_Disjunction) isQuery_Query()  {}
+func (*Query_All) isQuery_Query()          {}
+func (*Query_Field) isQuery_Query()        {}
+func (*Query_NumericRange) isQuery_Query() {}
 
 func (m *Query) GetQuery() isQuery_Query {
 	if m != nil {
@@ -285,6 +341,13 @@ func (m *Query) GetField() *FieldQuery {
 	return nil
 }
 
+func (m *Query) GetNumericRange() *NumericRangeQuery {
+	if x, ok := m.GetQuery().(*Query_NumericRange); ok {
+		return x.NumericRange
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Query) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Query_OneofMarshaler, _Query_OneofUnmarshaler, _Query_OneofSizer, []interface{}{
@@ -295,6 +358,7 @@ func (*Query) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error,
 		(*Query_Disjunction)(nil),
 		(*Query_All)(nil),
 		(*Query_Field)(nil),
+		(*Query_NumericRange)(nil),
 	}
 }
 
@@ -337,6 +401,11 @@ func _Query_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Field); err != nil {
 			return err
 		}
+	case *Query_NumericRange:
+		_ = b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.NumericRange); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Query.Query has unexpected type %T", x)
@@ -403,6 +472,14 @@ func _Query_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer)
 		err := b.DecodeMessage(msg)
 		m.Query = &Query_Field{msg}
 		return true, err
+	case 8: // query.numericRange
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(NumericRangeQuery)
+		err := b.DecodeMessage(msg)
+		m.Query = &Query_NumericRange{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -447,6 +524,11 @@ func _Query_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(7<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Query_NumericRange:
+		s := proto.Size(x.NumericRange)
+		n += proto.SizeVarint(8<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -462,6 +544,7 @@ func init() {
 	proto.RegisterType((*ConjunctionQuery)(nil), "query.ConjunctionQuery")
 	proto.RegisterType((*DisjunctionQuery)(nil), "query.DisjunctionQuery")
 	proto.RegisterType((*AllQuery)(nil), "query.AllQuery")
+	proto.RegisterType((*NumericRangeQuery)(nil), "query.NumericRangeQuery")
 	proto.RegisterType((*Query)(nil), "query.Query")
 }
 func (m *FieldQuery) Marshal() (dAtA []byte, err error) {
@@ -654,6 +737,62 @@ func (m *AllQuery) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *NumericRangeQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NumericRangeQuery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Field) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Field)))
+		i += copy(dAtA[i:], m.Field)
+	}
+	if m.Min != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Min))))
+		i += 8
+	}
+	if m.Max != 0 {
+		dAtA[i] = 0x19
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Max))))
+		i += 8
+	}
+	if m.MinInclusive {
+		dAtA[i] = 0x20
+		i++
+		if m.MinInclusive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.MaxInclusive {
+		dAtA[i] = 0x28
+		i++
+		if m.MaxInclusive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
 func (m *Query) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -777,6 +916,20 @@ func (m *Query_Field) MarshalTo(dAtA []byte) (int, error) {
 	}
 	return i, nil
 }
+func (m *Query_NumericRange) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.NumericRange != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(m.NumericRange.Size()))
+		n10, err := m.NumericRange.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	return i, nil
+}
 func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -864,6 +1017,28 @@ func (m *AllQuery) Size() (n int) {
 	return n
 }
 
+func (m *NumericRangeQuery) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Min != 0 {
+		n += 9
+	}
+	if m.Max != 0 {
+		n += 9
+	}
+	if m.MinInclusive {
+		n += 2
+	}
+	if m.MaxInclusive {
+		n += 2
+	}
+	return n
+}
+
 func (m *Query) Size() (n int) {
 	var l int
 	_ = l
@@ -936,6 +1111,15 @@ func (m *Query_Field) Size() (n int) {
 	}
 	return n
 }
+func (m *Query_NumericRange) Size() (n int) {
+	var l int
+	_ = l
+	if m.NumericRange != nil {
+		l = m.NumericRange.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
 
 func sovQuery(x uint64) (n int) {
 	for {
@@ -1550,6 +1734,149 @@ func (m *AllQuery) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *NumericRangeQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NumericRangeQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NumericRangeQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = append(m.Field[:0], dAtA[iNdEx:postIndex]...)
+			if m.Field == nil {
+				m.Field = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Min = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Max = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinInclusive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MinInclusive = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxInclusive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MaxInclusive = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Query) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1803,6 +2130,38 @@ func (m *Query) Unmarshal(dAtA []byte) error {
 			}
 			m.Query = &Query_Field{v}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumericRange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &NumericRangeQuery{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Query = &Query_NumericRange{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQuery(dAtA[iNdEx:])
@@ -1934,30 +2293,35 @@ func init() {
 }
 
 var fileDescriptorQuery = []byte{
-	// 389 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0xcd, 0x4a, 0xf3, 0x40,
-	0x14, 0x86, 0x93, 0xb6, 0x69, 0xfa, 0x9d, 0xf4, 0xc3, 0x3a, 0x14, 0x8d, 0x9b, 0x50, 0x22, 0x88,
-	0x82, 0x34, 0xd0, 0xe0, 0xc6, 0xae, 0x5a, 0x45, 0xb2, 0x12, 0x0c, 0xae, 0xdc, 0x35, 0xc9, 0x18,
-	0x23, 0xc9, 0xa4, 0x4e, 0x53, 0xa8, 0x77, 0xe1, 0x65, 0xb9, 0xf4, 0x12, 0xa4, 0x7a, 0x21, 0x32,
-	0x3f, 0x69, 0xda, 0x0a, 0x5d, 0xb8, 0x4a, 0xce, 0x9c, 0xf7, 0x81, 0x99, 0x87, 0x17, 0x46, 0x71,
-	0x52, 0x3c, 0xcd, 0x83, 0x7e, 0x98, 0x67, 0x4e, 0xe6, 0x46, 0x81, 0x93, 0xb9, 0xce, 0x8c, 0x86,
-	0x4e, 0xe6, 0x92, 0x84, 0x2c, 0x9c, 0x18, 0x13, 0x4c, 0x27, 0x05, 0x8e, 0x9c, 0x29, 0xcd, 0x8b,
-	0xdc, 0x79, 0x99, 0x63, 0xfa, 0x3a, 0x0d, 0xc4, 0xb7, 0xcf, 0xcf, 0x90, 0xc6, 0x07, 0xdb, 0x06,
-	0xb8, 0x49, 0x70, 0x1a, 0xdd, 0xb1, 0x09, 0x75, 0x41, 0x7b, 0x64, 0x93, 0xa9, 0xf6, 0xd4, 0xd3,
-	0xb6, 0x2f, 0x06, 0xfb, 0x02, 0xfe, 0xdd, 0x63, 0x9a, 0xed, 0x88, 0x20, 0x04, 0x8d, 0x02, 0xd3,
-	0xcc, 0xac, 0xf1, 0x43, 0xfe, 0x6f, 0x0f, 0xc1, 0xf0, 0x71, 0x8c, 0x17, 0xd3, 0x5d, 0xe0, 0x01,
-	0x34, 0x29, 0x0f, 0x49, 0x54, 0x4e, 0xb6, 0x0b, 0xff, 0x6f, 0x71, 0x3c, 0x29, 0x92, 0x9c, 0x08,
-	0xdc, 0x06, 0x71, 0x63, 0x8e, 0x1b, 0x83, 0x76, 0x5f, 0x3c, 0x86, 0x2f, 0x7d, 0xf9, 0x98, 0x4b,
-	0xe8, 0x5c, 0xe5, 0xe4, 0x79, 0x4e, 0xc2, 0x8a, 0x3b, 0x01, 0x9d, 0x2d, 0x13, 0x3c, 0x33, 0xd5,
-	0x5e, 0xfd, 0x17, 0x59, 0x2e, 0x19, 0x7b, 0x9d, 0xcc, 0xfe, 0xc6, 0x02, 0xb4, 0x46, 0x69, 0xca,
-	0x0f, 0xed, 0xef, 0x1a, 0x68, 0x25, 0x2d, 0x9c, 0x88, 0x0b, 0x77, 0x24, 0xba, 0x32, 0xe9, 0x29,
-	0xc2, 0x13, 0x3a, 0xdf, 0x50, 0x60, 0x0c, 0x90, 0x4c, 0xae, 0xc9, 0xf3, 0x94, 0x52, 0x0c, 0x1a,
-	0x40, 0x8b, 0x48, 0x31, 0x66, 0x9d, 0xe7, 0xbb, 0x32, 0xbf, 0xe1, 0xcb, 0x53, 0xfc, 0x55, 0x0e,
-	0x0d, 0xc1, 0x08, 0x2b, 0x2f, 0x66, 0x83, 0x63, 0x87, 0x12, 0xdb, 0x36, 0xe6, 0x29, 0xfe, 0x7a,
-	0x9a, 0xc1, 0x51, 0x25, 0xc6, 0xd4, 0x36, 0xe0, 0x6d, 0x65, 0x0c, 0x5e, 0x4b, 0xa3, 0x63, 0xa8,
-	0x4f, 0xd2, 0xd4, 0x6c, 0x72, 0x68, 0x4f, 0x42, 0xa5, 0x2b, 0x4f, 0xf1, 0xd9, 0x16, 0x9d, 0x95,
-	0xcd, 0xd0, 0x79, 0x6c, 0x5f, 0xc6, 0xaa, 0x5e, 0x7a, 0x8a, 0xac, 0xcb, 0x58, 0x97, 0x2d, 0x18,
-	0x1f, 0xbd, 0x2f, 0x2d, 0xf5, 0x63, 0x69, 0xa9, 0x9f, 0x4b, 0x4b, 0x7d, 0xfb, 0xb2, 0x94, 0x07,
-	0x5d, 0xb6, 0x3c, 0x68, 0xf2, 0x82, 0xbb, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x7d, 0x4a, 0xb7,
-	0x71, 0x25, 0x03, 0x00, 0x00,
+	// 473 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xdf, 0x8a, 0xd3, 0x40,
+	0x14, 0x87, 0x33, 0x66, 0xd3, 0xd6, 0x93, 0x8a, 0xdd, 0x61, 0xd1, 0xf1, 0xa6, 0x94, 0x11, 0x64,
+	0x05, 0x69, 0x20, 0xc1, 0x1b, 0x17, 0x84, 0x5d, 0x45, 0xe2, 0xcd, 0x82, 0x83, 0x57, 0xde, 0xa5,
+	0xe9, 0x18, 0x47, 0x32, 0x93, 0x9a, 0x3f, 0x12, 0xdf, 0xc2, 0x1b, 0xdf, 0xc0, 0x87, 0xf1, 0xd2,
+	0x47, 0x90, 0xfa, 0x22, 0x92, 0xc9, 0x64, 0x93, 0x54, 0xe8, 0xc5, 0x5e, 0x25, 0xe7, 0x9c, 0xdf,
+	0x07, 0x39, 0xf9, 0x66, 0xe0, 0x32, 0x11, 0xe5, 0xa7, 0x6a, 0xb3, 0x8e, 0x33, 0xe9, 0xc9, 0x60,
+	0xbb, 0xf1, 0x64, 0xe0, 0x15, 0x79, 0xec, 0xc9, 0x40, 0x09, 0x55, 0x7b, 0x09, 0x57, 0x3c, 0x8f,
+	0x4a, 0xbe, 0xf5, 0x76, 0x79, 0x56, 0x66, 0xde, 0x97, 0x8a, 0xe7, 0xdf, 0x76, 0x9b, 0xf6, 0xb9,
+	0xd6, 0x3d, 0xec, 0xe8, 0x82, 0x52, 0x80, 0x37, 0x82, 0xa7, 0xdb, 0x77, 0x4d, 0x85, 0xcf, 0xc0,
+	0xf9, 0xd8, 0x54, 0x04, 0xad, 0xd0, 0xf9, 0x9c, 0xb5, 0x05, 0x7d, 0x0e, 0x77, 0xdf, 0xf3, 0x5c,
+	0x1e, 0x89, 0x60, 0x0c, 0x27, 0x25, 0xcf, 0x25, 0xb9, 0xa3, 0x9b, 0xfa, 0x9d, 0x5e, 0x80, 0xcb,
+	0x78, 0xc2, 0xeb, 0xdd, 0x31, 0xf0, 0x01, 0x4c, 0x72, 0x1d, 0x32, 0xa8, 0xa9, 0x68, 0x00, 0xf7,
+	0xae, 0x79, 0x12, 0x95, 0x22, 0x53, 0x2d, 0x4e, 0xa1, 0xfd, 0x62, 0x8d, 0xbb, 0xfe, 0x7c, 0xdd,
+	0x2e, 0xa3, 0x87, 0xcc, 0x2c, 0xf3, 0x02, 0x16, 0xaf, 0x32, 0xf5, 0xb9, 0x52, 0x71, 0xcf, 0x3d,
+	0x81, 0x69, 0x33, 0x14, 0xbc, 0x20, 0x68, 0x65, 0xff, 0x47, 0x76, 0xc3, 0x86, 0x7d, 0x2d, 0x8a,
+	0xdb, 0xb1, 0x00, 0xb3, 0xcb, 0x34, 0xd5, 0x4d, 0xfa, 0x03, 0xc1, 0xe9, 0x75, 0x25, 0x79, 0x2e,
+	0x62, 0x16, 0xa9, 0x84, 0x1f, 0x5b, 0x7e, 0x01, 0xb6, 0x14, 0x4a, 0x6f, 0x8e, 0x58, 0xf3, 0xaa,
+	0x3b, 0x51, 0x4d, 0x6c, 0xd3, 0x89, 0x6a, 0x4c, 0x61, 0x2e, 0x85, 0x7a, 0xab, 0xe2, 0xb4, 0x2a,
+	0xc4, 0x57, 0x4e, 0x4e, 0x56, 0xe8, 0x7c, 0xc6, 0x46, 0x3d, 0x9d, 0x89, 0xea, 0x3e, 0xe3, 0x98,
+	0xcc, 0xa0, 0x47, 0x7f, 0xda, 0xe0, 0x74, 0x5b, 0xb5, 0xae, 0xda, 0x1f, 0xb9, 0x30, 0x2b, 0xdd,
+	0x18, 0x0e, 0xad, 0xd6, 0x1f, 0x7e, 0x36, 0x52, 0xe3, 0xfa, 0xd8, 0x24, 0x07, 0x52, 0x43, 0xab,
+	0x13, 0x86, 0x7d, 0x98, 0x29, 0x23, 0x4c, 0x7f, 0xbe, 0xeb, 0x9f, 0x99, 0xfc, 0xc8, 0x63, 0x68,
+	0xb1, 0x9b, 0x1c, 0xbe, 0x00, 0x37, 0xee, 0x7d, 0xe9, 0xd5, 0x5c, 0xff, 0xa1, 0xc1, 0x0e, 0x4d,
+	0x86, 0x16, 0x1b, 0xa6, 0x1b, 0x78, 0xdb, 0x0b, 0x23, 0xce, 0x08, 0x3e, 0x54, 0xd9, 0xc0, 0x83,
+	0x34, 0x7e, 0x0c, 0x76, 0x94, 0xa6, 0x64, 0xa2, 0xa1, 0xfb, 0x06, 0xea, 0x1c, 0x86, 0x16, 0x6b,
+	0xa6, 0xf8, 0x69, 0x27, 0x6d, 0xaa, 0x63, 0xa7, 0x26, 0xd6, 0xdf, 0x97, 0xd0, 0xea, 0x4c, 0xbe,
+	0x84, 0xb9, 0x1a, 0x48, 0x27, 0x33, 0x4d, 0x90, 0xee, 0x0f, 0x1c, 0x9e, 0x87, 0xd0, 0x62, 0xa3,
+	0xfc, 0xd5, 0xd4, 0x9c, 0xee, 0xab, 0x47, 0xbf, 0xf6, 0x4b, 0xf4, 0x7b, 0xbf, 0x44, 0x7f, 0xf6,
+	0x4b, 0xf4, 0xfd, 0xef, 0xd2, 0xfa, 0x30, 0x35, 0xb7, 0x77, 0x33, 0xd1, 0x17, 0x37, 0xf8, 0x37,
+	0x00, 0xf3, 0x89, 0x8b, 0xbe, 0xfd, 0x03, 0x00, 0x00,
 }
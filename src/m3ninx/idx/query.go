@@ -21,6 +21,7 @@
 package idx
 
 import (
+	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/search"
 	"github.com/m3db/m3/src/m3ninx/search/query"
 )
@@ -94,6 +95,14 @@ func MustCreateRegexpQuery(field, regexp []byte) Query {
 	}
 }
 
+// NewNumericRangeQuery returns a new query for finding documents whose field value
+// parses as a number within the given range.
+func NewNumericRangeQuery(field []byte, nr index.NumericRange) Query {
+	return Query{
+		query: query.NewNumericRangeQuery(field, nr),
+	}
+}
+
 // NewNegationQuery returns a new query for finding documents which don't match a given query.
 func NewNegationQuery(q Query) Query {
 	return Query{
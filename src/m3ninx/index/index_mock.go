@@ -145,6 +145,21 @@ func (mr *MockReaderMockRecorder) MatchField(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchField", reflect.TypeOf((*MockReader)(nil).MatchField), arg0)
 }
 
+// MatchNumericRange mocks base method.
+func (m *MockReader) MatchNumericRange(arg0 []byte, arg1 NumericRange) (postings.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchNumericRange", arg0, arg1)
+	ret0, _ := ret[0].(postings.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchNumericRange indicates an expected call of MatchNumericRange.
+func (mr *MockReaderMockRecorder) MatchNumericRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchNumericRange", reflect.TypeOf((*MockReader)(nil).MatchNumericRange), arg0, arg1)
+}
+
 // MatchRegexp mocks base method.
 func (m *MockReader) MatchRegexp(arg0 []byte, arg1 CompiledRegex) (postings.List, error) {
 	m.ctrl.T.Helper()
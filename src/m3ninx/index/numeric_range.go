@@ -0,0 +1,174 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	xunsafe "github.com/m3db/m3/src/x/unsafe"
+)
+
+var (
+	errNumericRangeInvalid = errors.New(
+		"numeric range must be in interval notation, e.g. [500,600) or (0.5,]")
+	errNumericRangeEmpty = errors.New("numeric range is empty")
+)
+
+// NumericRange is a range of numbers, terms which parse as a number within
+// the range match a numeric range.
+type NumericRange struct {
+	Min          float64
+	Max          float64
+	MinInclusive bool
+	MaxInclusive bool
+}
+
+// NewNumericRange returns a new numeric range, an infinite bound leaves the
+// range unbounded on that side.
+func NewNumericRange(
+	min float64,
+	minInclusive bool,
+	max float64,
+	maxInclusive bool,
+) (NumericRange, error) {
+	r := NumericRange{
+		Min:          min,
+		Max:          max,
+		MinInclusive: minInclusive,
+		MaxInclusive: maxInclusive,
+	}
+	if math.IsNaN(min) || math.IsNaN(max) || min > max ||
+		(min == max && (!minInclusive || !maxInclusive)) {
+		return NumericRange{}, errNumericRangeEmpty
+	}
+	return r, nil
+}
+
+// ParseNumericRange parses a numeric range in interval notation, i.e. a
+// square bracket for an inclusive bound and a parenthesis for an exclusive
+// bound, e.g. [500,600). An empty bound leaves the range unbounded on that
+// side, e.g. (0.5,].
+func ParseNumericRange(str string) (NumericRange, error) {
+	if len(str) < 3 {
+		return NumericRange{}, errNumericRangeInvalid
+	}
+
+	var minInclusive, maxInclusive bool
+	switch str[0] {
+	case '[':
+		minInclusive = true
+	case '(':
+	default:
+		return NumericRange{}, errNumericRangeInvalid
+	}
+	switch str[len(str)-1] {
+	case ']':
+		maxInclusive = true
+	case ')':
+	default:
+		return NumericRange{}, errNumericRangeInvalid
+	}
+
+	bounds := strings.Split(str[1:len(str)-1], ",")
+	if len(bounds) != 2 {
+		return NumericRange{}, errNumericRangeInvalid
+	}
+
+	min, err := parseNumericBound(bounds[0], math.Inf(-1))
+	if err != nil {
+		return NumericRange{}, err
+	}
+	max, err := parseNumericBound(bounds[1], math.Inf(1))
+	if err != nil {
+		return NumericRange{}, err
+	}
+
+	return NewNumericRange(min, minInclusive, max, maxInclusive)
+}
+
+func parseNumericBound(str string, unbounded float64) (float64, error) {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return unbounded, nil
+	}
+	v, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid numeric range bound %q: %w", str, err)
+	}
+	return v, nil
+}
+
+// Contains returns whether the range contains a number.
+func (r NumericRange) Contains(v float64) bool {
+	if v < r.Min || (v == r.Min && !r.MinInclusive) {
+		return false
+	}
+	if v > r.Max || (v == r.Max && !r.MaxInclusive) {
+		return false
+	}
+	return true
+}
+
+// ContainsTerm returns whether a term parses as a number within the range.
+func (r NumericRange) ContainsTerm(term []byte) bool {
+	v, ok := ParseNumericTerm(term)
+	return ok && r.Contains(v)
+}
+
+// String returns the range in interval notation.
+func (r NumericRange) String() string {
+	var str strings.Builder
+	if r.MinInclusive {
+		str.WriteRune('[')
+	} else {
+		str.WriteRune('(')
+	}
+	if !math.IsInf(r.Min, -1) {
+		str.WriteString(strconv.FormatFloat(r.Min, 'g', -1, 64))
+	}
+	str.WriteRune(',')
+	if !math.IsInf(r.Max, 1) {
+		str.WriteString(strconv.FormatFloat(r.Max, 'g', -1, 64))
+	}
+	if r.MaxInclusive {
+		str.WriteRune(']')
+	} else {
+		str.WriteRune(')')
+	}
+	return str.String()
+}
+
+// ParseNumericTerm parses a term as a number, it returns false if the term
+// is not a number.
+func ParseNumericTerm(term []byte) (float64, bool) {
+	if len(term) == 0 {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(xunsafe.String(term), 64)
+	if err != nil || math.IsNaN(v) {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumericRange(t *testing.T) {
+	tests := []struct {
+		str       string
+		expected  NumericRange
+		expectErr bool
+	}{
+		{
+			str:      "[500,600)",
+			expected: NumericRange{Min: 500, Max: 600, MinInclusive: true},
+		},
+		{
+			str:      "(0.5,]",
+			expected: NumericRange{Min: 0.5, Max: math.Inf(1), MaxInclusive: true},
+		},
+		{
+			str:      "(, -1e3]",
+			expected: NumericRange{Min: math.Inf(-1), Max: -1000, MaxInclusive: true},
+		},
+		{
+			str:      "[1,1]",
+			expected: NumericRange{Min: 1, Max: 1, MinInclusive: true, MaxInclusive: true},
+		},
+		{str: "", expectErr: true},
+		{str: "500,600", expectErr: true},
+		{str: "[500;600)", expectErr: true},
+		{str: "[500,600,700)", expectErr: true},
+		{str: "[a,600)", expectErr: true},
+		{str: "[600,500)", expectErr: true},
+		{str: "[1,1)", expectErr: true},
+		{str: "[NaN,1]", expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			nr, err := ParseNumericRange(test.str)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, nr)
+
+			// The string form parses back to the same range.
+			parsed, err := ParseNumericRange(nr.String())
+			require.NoError(t, err)
+			require.Equal(t, nr, parsed)
+		})
+	}
+}
+
+func TestNumericRangeContainsTerm(t *testing.T) {
+	nr, err := ParseNumericRange("[500,600)")
+	require.NoError(t, err)
+
+	for term, expected := range map[string]bool{
+		"500":    true,
+		"500.0":  true,
+		"503":    true,
+		"5.99e2": true,
+		"499.9":  false,
+		"600":    false,
+		"":       false,
+		"5xx":    false,
+		"NaN":    false,
+	} {
+		require.Equal(t, expected, nr.ContainsTerm([]byte(term)), term)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fst
+
+import (
+	"container/list"
+	"sync"
+)
+
+// maxCachedNumericTerms is the maximum number of numeric terms cached per
+// segment, which bounds the cache to 64KiB per segment.
+const maxCachedNumericTerms = 4096
+
+// numericTerm is a term which parses as a number along with the offset
+// of its postings list.
+type numericTerm struct {
+	value          float64
+	postingsOffset uint64
+}
+
+type numericTermsEntry struct {
+	field string
+	terms []numericTerm
+}
+
+// numericTermsCache is an LRU cache of the numeric terms of the fields of a
+// segment sorted by value, bounded by the total number of terms cached.
+type numericTermsCache struct {
+	sync.Mutex
+
+	maxTerms int
+	numTerms int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+func newNumericTermsCache(maxTerms int) *numericTermsCache {
+	return &numericTermsCache{
+		maxTerms: maxTerms,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached numeric terms of the field.
+func (c *numericTermsCache) get(field []byte) ([]numericTerm, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	el, ok := c.entries[string(field)]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*numericTermsEntry).terms, true
+}
+
+// put caches the numeric terms of the field, evicting the least recently
+// used fields to stay within the bound. Fields with more terms than the
+// bound are not cached.
+func (c *numericTermsCache) put(field []byte, terms []numericTerm) {
+	if len(terms) > c.maxTerms {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if _, ok := c.entries[string(field)]; ok {
+		return
+	}
+	for c.numTerms+len(terms) > c.maxTerms {
+		c.removeWithLock(c.order.Back())
+	}
+	entry := &numericTermsEntry{field: string(field), terms: terms}
+	c.entries[entry.field] = c.order.PushFront(entry)
+	c.numTerms += len(terms)
+}
+
+// reset removes all cached terms.
+func (c *numericTermsCache) reset() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+	c.numTerms = 0
+}
+
+func (c *numericTermsCache) removeWithLock(el *list.Element) {
+	entry := c.order.Remove(el).(*numericTermsEntry)
+	delete(c.entries, entry.field)
+	c.numTerms -= len(entry.terms)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fst
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumericTermsCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newNumericTermsCache(4)
+	terms := func(n int) []numericTerm {
+		return make([]numericTerm, n)
+	}
+
+	c.put([]byte("a"), terms(2))
+	c.put([]byte("b"), terms(2))
+	_, ok := c.get([]byte("a"))
+	require.True(t, ok)
+
+	// Caching c evicts b, the least recently used field.
+	c.put([]byte("c"), terms(1))
+	_, ok = c.get([]byte("b"))
+	require.False(t, ok)
+	_, ok = c.get([]byte("a"))
+	require.True(t, ok)
+	_, ok = c.get([]byte("c"))
+	require.True(t, ok)
+	require.Equal(t, 3, c.numTerms)
+
+	// Fields with more terms than the bound are not cached.
+	c.put([]byte("d"), terms(5))
+	_, ok = c.get([]byte("d"))
+	require.False(t, ok)
+	require.Equal(t, 3, c.numTerms)
+
+	c.reset()
+	_, ok = c.get([]byte("a"))
+	require.False(t, ok)
+	require.Equal(t, 0, c.numTerms)
+}
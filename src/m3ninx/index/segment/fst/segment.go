@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 
 	"github.com/m3db/m3/src/m3ninx/doc"
@@ -157,6 +158,8 @@ func NewSegment(data SegmentData, opts Options) (Segment, error) {
 		data:    data,
 		opts:    opts,
 		numDocs: metadata.NumDocs,
+
+		numericTerms: newNumericTermsCache(maxCachedNumericTerms),
 	}
 
 	// NB(r): The segment uses the context finalization to finalize
@@ -186,6 +189,10 @@ type fsSegment struct {
 	opts                  Options
 
 	numDocs int64
+
+	// numericTerms caches for the fields queried by numeric range their
+	// numeric terms sorted by value.
+	numericTerms *numericTermsCache
 }
 
 func (r *fsSegment) SegmentData(ctx context.Context) (SegmentData, error) {
@@ -280,6 +287,7 @@ func (r *fsSegment) Finalize() {
 	if r.data.Closer != nil {
 		r.data.Closer.Close()
 	}
+	r.numericTerms.reset()
 	r.finalized = true
 	r.Unlock()
 }
@@ -605,6 +613,115 @@ func (r *fsSegment) matchRegexpNotClosedMaybeFinalizedWithRLock(
 	return pl, nil
 }
 
+func (r *fsSegment) matchNumericRangeNotClosedMaybeFinalizedWithRLock(
+	field []byte,
+	nr index.NumericRange,
+) (postings.List, error) {
+	// NB(r): Not closed, but could be finalized (i.e. closed segment reader)
+	// calling match field after this segment is finalized.
+	if r.finalized {
+		return nil, errReaderFinalized
+	}
+
+	terms, err := r.numericTermsNotClosedMaybeFinalizedWithRLock(field)
+	if err != nil {
+		return nil, err
+	}
+
+	// Terms are sorted by value so the matching terms are a contiguous run.
+	start := sort.Search(len(terms), func(i int) bool {
+		v := terms[i].value
+		return v > nr.Min || (nr.MinInclusive && v == nr.Min)
+	})
+	var pls []postings.List
+	for i := start; i < len(terms) && nr.Contains(terms[i].value); i++ {
+		pl, err := r.retrievePostingsListWithRLock(terms[i].postingsOffset)
+		if err != nil {
+			return nil, err
+		}
+		pls = append(pls, pl)
+	}
+
+	if len(pls) == 0 {
+		return r.opts.PostingsListPool().Get(), nil
+	}
+
+	return roaring.Union(pls)
+}
+
+// numericTermsNotClosedMaybeFinalizedWithRLock returns the terms of the given
+// field which parse as numbers, sorted by value. Since the segment is
+// immutable the result is cached, within the bound of the cache.
+func (r *fsSegment) numericTermsNotClosedMaybeFinalizedWithRLock(
+	field []byte,
+) ([]numericTerm, error) {
+	if terms, ok := r.numericTerms.get(field); ok {
+		return terms, nil
+	}
+
+	termsFST, exists, err := r.retrieveTermsFSTWithRLock(field)
+	if err != nil {
+		return nil, err
+	}
+
+	var terms []numericTerm
+	if exists {
+		terms, err = collectNumericTerms(termsFST)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.numericTerms.put(field, terms)
+	return terms, nil
+}
+
+func collectNumericTerms(termsFST *vellum.FST) ([]numericTerm, error) {
+	var (
+		fstCloser     = x.NewSafeCloser(termsFST)
+		iter, iterErr = termsFST.Iterator(nil, nil)
+		iterCloser    = x.NewSafeCloser(iter)
+		terms         []numericTerm
+	)
+	defer func() {
+		iterCloser.Close()
+		fstCloser.Close()
+	}()
+
+	for {
+		if iterErr == vellum.ErrIteratorDone {
+			break
+		}
+
+		if iterErr != nil {
+			return nil, iterErr
+		}
+
+		term, postingsOffset := iter.Current()
+		if value, ok := index.ParseNumericTerm(term); ok {
+			terms = append(terms, numericTerm{
+				value:          value,
+				postingsOffset: postingsOffset,
+			})
+		}
+		iterErr = iter.Next()
+	}
+
+	sort.Slice(terms, func(i, j int) bool {
+		return terms[i].value < terms[j].value
+	})
+
+	if err := iterCloser.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := fstCloser.Close(); err != nil {
+		return nil, err
+	}
+
+	return terms, nil
+}
+
 func (r *fsSegment) matchAllNotClosedMaybeFinalizedWithRLock() (postings.MutableList, error) {
 	// NB(r): Not closed, but could be finalized (i.e. closed segment reader)
 	// calling match field after this segment is finalized.
@@ -986,6 +1103,21 @@ func (sr *fsSegmentReader) MatchRegexp(
 	return pl, err
 }
 
+func (sr *fsSegmentReader) MatchNumericRange(
+	field []byte,
+	nr index.NumericRange,
+) (postings.List, error) {
+	if sr.closed {
+		return nil, errReaderClosed
+	}
+	// NB(r): We are allowed to call match field after Close called on
+	// the segment but not after it is finalized.
+	sr.fsSegment.RLock()
+	pl, err := sr.fsSegment.matchNumericRangeNotClosedMaybeFinalizedWithRLock(field, nr)
+	sr.fsSegment.RUnlock()
+	return pl, err
+}
+
 func (sr *fsSegmentReader) MatchAll() (postings.List, error) {
 	if sr.closed {
 		return nil, errReaderClosed
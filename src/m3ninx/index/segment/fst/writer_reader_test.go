@@ -455,6 +455,58 @@ func TestPostingsListRegexAll(t *testing.T) {
 	}
 }
 
+func TestPostingsListNumericRange(t *testing.T) {
+	var docs []doc.Metadata
+	for _, code := range []string{"200", "404", "500", "503", "5.99e2", "600", "5xx"} {
+		docs = append(docs, doc.Metadata{
+			ID: []byte("status_" + code),
+			Fields: []doc.Field{
+				{
+					Name:  []byte("status"),
+					Value: []byte(code),
+				},
+			},
+		})
+	}
+
+	tests := []struct {
+		nr       string
+		expected []postings.ID
+	}{
+		{nr: "[500,600)", expected: []postings.ID{2, 3, 4}},
+		{nr: "[500,600]", expected: []postings.ID{2, 3, 4, 5}},
+		{nr: "(500,]", expected: []postings.ID{3, 4, 5}},
+		{nr: "(,404]", expected: []postings.ID{0, 1}},
+		{nr: "(404,500)", expected: nil},
+	}
+
+	for _, tc := range newTestCases(t, docs) {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, test := range tests {
+				nr, err := index.ParseNumericRange(test.nr)
+				require.NoError(t, err)
+
+				reader, err := tc.expected.Reader()
+				require.NoError(t, err)
+				expPl, err := reader.MatchNumericRange([]byte("status"), nr)
+				require.NoError(t, err)
+				assertPostingsList(t, expPl, test.expected)
+
+				obsReader, err := tc.observed.Reader()
+				require.NoError(t, err)
+				obsPl, err := obsReader.MatchNumericRange([]byte("status"), nr)
+				require.NoError(t, err)
+				require.True(t, expPl.Equal(obsPl), test.nr)
+
+				// Unknown fields match nothing.
+				obsPl, err = obsReader.MatchNumericRange([]byte("unknown"), nr)
+				require.NoError(t, err)
+				require.Equal(t, 0, obsPl.Len())
+			}
+		})
+	}
+}
+
 func TestSegmentDocs(t *testing.T) {
 	for _, test := range testDocuments {
 		t.Run(test.name, func(t *testing.T) {
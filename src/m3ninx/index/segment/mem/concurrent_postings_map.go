@@ -24,6 +24,7 @@ import (
 	"regexp"
 	"sync"
 
+	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/postings"
 	"github.com/m3db/m3/src/m3ninx/postings/roaring"
 )
@@ -121,3 +122,24 @@ func (m *concurrentPostingsMap) GetRegex(re *regexp.Regexp) (postings.List, bool
 	result, _ := roaring.Union(lists)
 	return result, true
 }
+
+// GetNumericRange returns the union of the postings lists whose keys parse as
+// numbers within the provided range.
+func (m *concurrentPostingsMap) GetNumericRange(nr index.NumericRange) (postings.List, bool) {
+	var lists []postings.List
+
+	m.RLock()
+	for _, mapEntry := range m.postingsMap.Iter() {
+		if nr.ContainsTerm(mapEntry.Key()) {
+			lists = append(lists, mapEntry.Value())
+		}
+	}
+	m.RUnlock()
+
+	if len(lists) == 0 {
+		return nil, false
+	}
+
+	result, _ := roaring.Union(lists)
+	return result, true
+}
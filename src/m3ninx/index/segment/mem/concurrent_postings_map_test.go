@@ -25,6 +25,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/m3db/m3/src/m3ninx/index"
 	sgmt "github.com/m3db/m3/src/m3ninx/index/segment"
 
 	"github.com/stretchr/testify/require"
@@ -60,6 +61,29 @@ func TestConcurrentPostingsMap(t *testing.T) {
 	require.False(t, ok)
 }
 
+func TestConcurrentPostingsMapNumericRange(t *testing.T) {
+	opts := NewOptions()
+	pm := newConcurrentPostingsMap(opts)
+
+	pm.Add([]byte("200"), 1)
+	pm.Add([]byte("500"), 2)
+	pm.Add([]byte("503"), 3)
+	pm.Add([]byte("5xx"), 4)
+
+	nr, err := index.ParseNumericRange("[500,600)")
+	require.NoError(t, err)
+	pl, ok := pm.GetNumericRange(nr)
+	require.True(t, ok)
+	require.Equal(t, 2, pl.Len())
+	require.True(t, pl.Contains(2))
+	require.True(t, pl.Contains(3))
+
+	nr, err = index.ParseNumericRange("(600,]")
+	require.NoError(t, err)
+	_, ok = pm.GetNumericRange(nr)
+	require.False(t, ok)
+}
+
 func TestConcurrentPostingsMapKeys(t *testing.T) {
 	opts := NewOptions()
 	pm := newConcurrentPostingsMap(opts)
@@ -113,6 +113,17 @@ func (r *reader) MatchRegexp(field []byte, compiled index.CompiledRegex) (postin
 	return r.segment.matchRegexp(field, compileRE)
 }
 
+func (r *reader) MatchNumericRange(field []byte, nr index.NumericRange) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errSegmentReaderClosed
+	}
+
+	// See MatchRegexp for why the returned postings list is not limited here.
+	return r.segment.matchNumericRange(field, nr)
+}
+
 func (r *reader) MatchAll() (postings.List, error) {
 	r.RLock()
 	defer r.RUnlock()
@@ -396,6 +396,16 @@ func (s *memSegment) matchRegexp(field []byte, compiled *re.Regexp) (postings.Li
 	return s.termsDict.MatchRegexp(field, compiled), nil
 }
 
+func (s *memSegment) matchNumericRange(field []byte, nr index.NumericRange) (postings.List, error) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+	if s.state.closed {
+		return nil, segment.ErrClosed
+	}
+
+	return s.termsDict.MatchNumericRange(field, nr), nil
+}
+
 func (s *memSegment) getDoc(id postings.ID) (doc.Metadata, error) {
 	s.state.RLock()
 	defer s.state.RUnlock()
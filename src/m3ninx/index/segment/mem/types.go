@@ -24,6 +24,7 @@ import (
 	re "regexp"
 
 	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index"
 	sgmt "github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/postings"
 )
@@ -49,6 +50,10 @@ type termsDictionary interface {
 	// given egular expression.
 	MatchRegexp(field []byte, compiled *re.Regexp) postings.List
 
+	// MatchNumericRange returns the postings list corresponding to documents
+	// whose field value parses as a number within the given range.
+	MatchNumericRange(field []byte, nr index.NumericRange) postings.List
+
 	// Fields returns the known fields.
 	Fields() sgmt.FieldsIterator
 
@@ -75,5 +80,6 @@ type ReadableSegment interface {
 	FieldsPostingsList() (sgmt.FieldsPostingsListIterator, error)
 	matchTerm(field, term []byte) (postings.List, error)
 	matchRegexp(field []byte, compiled *re.Regexp) (postings.List, error)
+	matchNumericRange(field []byte, nr index.NumericRange) (postings.List, error)
 	getDoc(id postings.ID) (doc.Metadata, error)
 }
@@ -315,6 +315,21 @@ func (mr *MockReaderMockRecorder) MatchField(field interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchField", reflect.TypeOf((*MockReader)(nil).MatchField), field)
 }
 
+// MatchNumericRange mocks base method.
+func (m *MockReader) MatchNumericRange(field []byte, r index.NumericRange) (postings.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchNumericRange", field, r)
+	ret0, _ := ret[0].(postings.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchNumericRange indicates an expected call of MatchNumericRange.
+func (mr *MockReaderMockRecorder) MatchNumericRange(field, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchNumericRange", reflect.TypeOf((*MockReader)(nil).MatchNumericRange), field, r)
+}
+
 // MatchRegexp mocks base method.
 func (m *MockReader) MatchRegexp(field []byte, c index.CompiledRegex) (postings.List, error) {
 	m.ctrl.T.Helper()
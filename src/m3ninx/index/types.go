@@ -73,6 +73,10 @@ type Readable interface {
 	// regular expression.
 	MatchRegexp(field []byte, c CompiledRegex) (postings.List, error)
 
+	// MatchNumericRange returns a postings list over all documents whose
+	// value for the given field parses as a number within the given range.
+	MatchNumericRange(field []byte, r NumericRange) (postings.List, error)
+
 	// MatchAll returns a postings list for all documents known to the Reader.
 	MatchAll() (postings.List, error)
 
@@ -57,6 +57,23 @@ func NewExecutor(rs index.Readers) search.Executor {
 	}
 }
 
+// NewExplainExecutor returns a new Executor which records a trace of the search
+// performed against each reader, the iterators it returns implement
+// search.SearchTracer. The names describe each reader and must be in the same
+// order as the readers.
+func NewExplainExecutor(rs index.Readers, names []string) search.Executor {
+	return &executor{
+		newIteratorFn: func(
+			ctx context.Context,
+			q search.Query,
+			rs index.Readers,
+		) (doc.QueryDocIterator, error) {
+			return newExplainIterator(ctx, q, rs, names)
+		},
+		readers: rs,
+	}
+}
+
 func (e *executor) Execute(ctx context.Context, q search.Query) (doc.QueryDocIterator, error) {
 	e.RLock()
 	defer e.RUnlock()
@@ -0,0 +1,117 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package executor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	"github.com/m3db/m3/src/m3ninx/search"
+)
+
+// tracingSearcher wraps a searcher and records each match made against the
+// readers it searches.
+type tracingSearcher struct {
+	searcher search.Searcher
+	invoked  bool
+	matches  []search.MatchTrace
+}
+
+var _ search.Searcher = &tracingSearcher{}
+
+func (s *tracingSearcher) Search(r index.Reader) (postings.List, error) {
+	s.invoked = true
+	reader := &tracingReader{Reader: r}
+	pl, err := s.searcher.Search(reader)
+	s.matches = append(s.matches, reader.matches...)
+	return pl, err
+}
+
+// tracingReader wraps a reader and records the matches made against it.
+type tracingReader struct {
+	index.Reader
+
+	matches []search.MatchTrace
+}
+
+var _ index.Reader = &tracingReader{}
+
+func (r *tracingReader) MatchField(field []byte) (postings.List, error) {
+	start := time.Now()
+	pl, err := r.Reader.MatchField(field)
+	r.record(fmt.Sprintf("field(%s)", field), pl, start)
+	return pl, err
+}
+
+func (r *tracingReader) MatchTerm(field, term []byte) (postings.List, error) {
+	start := time.Now()
+	pl, err := r.Reader.MatchTerm(field, term)
+	r.record(fmt.Sprintf("term(%s,%s)", field, term), pl, start)
+	return pl, err
+}
+
+func (r *tracingReader) MatchRegexp(
+	field []byte,
+	c index.CompiledRegex,
+) (postings.List, error) {
+	start := time.Now()
+	pl, err := r.Reader.MatchRegexp(field, c)
+	var re string
+	if c.Simple != nil {
+		re = c.Simple.String()
+	}
+	r.record(fmt.Sprintf("regexp(%s,%s)", field, re), pl, start)
+	return pl, err
+}
+
+func (r *tracingReader) MatchNumericRange(
+	field []byte,
+	nr index.NumericRange,
+) (postings.List, error) {
+	start := time.Now()
+	pl, err := r.Reader.MatchNumericRange(field, nr)
+	r.record(fmt.Sprintf("numeric_range(%s,%s)", field, nr.String()), pl, start)
+	return pl, err
+}
+
+func (r *tracingReader) MatchAll() (postings.List, error) {
+	start := time.Now()
+	pl, err := r.Reader.MatchAll()
+	r.record("all()", pl, start)
+	return pl, err
+}
+
+func (r *tracingReader) record(
+	match string,
+	pl postings.List,
+	start time.Time,
+) {
+	if pl == nil {
+		// Match failed, the error is surfaced by the search.
+		return
+	}
+	r.matches = append(r.matches, search.MatchTrace{
+		Match:    match,
+		Postings: pl.Len(),
+		Duration: time.Since(start),
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package executor
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	"github.com/m3db/m3/src/m3ninx/postings/roaring"
+	"github.com/m3db/m3/src/m3ninx/search"
+	"github.com/m3db/m3/src/m3ninx/search/searcher"
+	"github.com/m3db/m3/src/x/context"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// cachedReader is a read through reader which always serves searches from
+// its cache without invoking the searcher.
+type cachedReader struct {
+	*index.MockReader
+
+	pl postings.List
+}
+
+func (r *cachedReader) Search(_ search.Query, _ search.Searcher) (postings.List, error) {
+	return r.pl, nil
+}
+
+func TestExplainExecutor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		field = []byte("city")
+		term  = []byte("nyc")
+	)
+
+	firstPL := roaring.NewPostingsList()
+	require.NoError(t, firstPL.Insert(42))
+	require.NoError(t, firstPL.Insert(47))
+	secondPL := roaring.NewPostingsList()
+	require.NoError(t, secondPL.Insert(67))
+
+	firstDocIter := doc.NewMockIterator(mockCtrl)
+	firstDocIter.EXPECT().Next().Return(false)
+	firstDocIter.EXPECT().Err().Return(nil)
+	firstDocIter.EXPECT().Close().Return(nil)
+	secondDocIter := doc.NewMockIterator(mockCtrl)
+	secondDocIter.EXPECT().Next().Return(false)
+	secondDocIter.EXPECT().Err().Return(nil)
+	secondDocIter.EXPECT().Close().Return(nil)
+
+	firstReader := index.NewMockReader(mockCtrl)
+	firstReader.EXPECT().MatchTerm(field, term).Return(firstPL, nil)
+	firstReader.EXPECT().Docs(firstPL).Return(firstDocIter, nil)
+	firstReader.EXPECT().Close().Return(nil)
+
+	secondMockReader := index.NewMockReader(mockCtrl)
+	secondMockReader.EXPECT().Docs(secondPL).Return(secondDocIter, nil)
+	secondMockReader.EXPECT().Close().Return(nil)
+	secondReader := &cachedReader{MockReader: secondMockReader, pl: secondPL}
+
+	query := search.NewMockQuery(mockCtrl)
+	query.EXPECT().Searcher().Return(searcher.NewTermSearcher(field, term), nil)
+
+	e := NewExplainExecutor(index.Readers{firstReader, secondReader},
+		[]string{"mutable/0", "fst/0"})
+
+	iter, err := e.Execute(context.NewBackground(), query)
+	require.NoError(t, err)
+
+	tracer, ok := iter.(search.SearchTracer)
+	require.True(t, ok)
+	require.Nil(t, tracer.SearchTraces())
+
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Err())
+	require.NoError(t, iter.Close())
+
+	traces := tracer.SearchTraces()
+	require.Equal(t, 2, len(traces))
+
+	require.Equal(t, "mutable/0", traces[0].Segment)
+	require.False(t, traces[0].CacheHit)
+	require.Equal(t, 2, traces[0].Postings)
+	require.Equal(t, 1, len(traces[0].Matches))
+	require.Equal(t, "term(city,nyc)", traces[0].Matches[0].Match)
+	require.Equal(t, 2, traces[0].Matches[0].Postings)
+
+	require.Equal(t, "fst/0", traces[1].Segment)
+	require.True(t, traces[1].CacheHit)
+	require.Equal(t, 1, traces[1].Postings)
+	require.Equal(t, 0, len(traces[1].Matches))
+
+	require.NoError(t, e.Close())
+}
+
+func TestExplainExecutorRequiresNamePerReader(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	query := search.NewMockQuery(mockCtrl)
+	e := NewExplainExecutor(index.Readers{index.NewMockReader(mockCtrl)}, nil)
+
+	_, err := e.Execute(context.NewBackground(), query)
+	require.Error(t, err)
+}
@@ -21,6 +21,9 @@
 package executor
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/index"
@@ -45,9 +48,12 @@ type iterator struct {
 	searcher search.Searcher
 	readers  index.Readers
 	ctx      context.Context
+	explain  bool
+	names    []string
 
 	// immutable state after the first call to Next()
-	iters []doc.Iterator
+	iters  []doc.Iterator
+	traces []search.SearchTrace
 
 	// mutable state
 	idx     int
@@ -71,6 +77,32 @@ func newIterator(ctx context.Context, q search.Query, rs index.Readers) (doc.Que
 	}, nil
 }
 
+func newExplainIterator(
+	ctx context.Context,
+	q search.Query,
+	rs index.Readers,
+	names []string,
+) (doc.QueryDocIterator, error) {
+	if len(names) != len(rs) {
+		return nil, fmt.Errorf("explain requires a name per reader: readers=%d, names=%d",
+			len(rs), len(names))
+	}
+
+	s, err := q.Searcher()
+	if err != nil {
+		return nil, err
+	}
+
+	return &iterator{
+		ctx:      ctx,
+		query:    q,
+		searcher: s,
+		readers:  rs,
+		explain:  true,
+		names:    names,
+	}, nil
+}
+
 func (it *iterator) Done() bool {
 	return it.err != nil || it.done
 }
@@ -140,6 +172,12 @@ func (it *iterator) Err() error {
 	return it.err
 }
 
+// SearchTraces returns the trace of each segment search, only populated
+// by explain iterators once the first call to Next() has been made.
+func (it *iterator) SearchTraces() []search.SearchTrace {
+	return it.traces
+}
+
 func (it *iterator) Close() error {
 	if it.iters == nil {
 		return nil
@@ -154,6 +192,9 @@ func (it *iterator) Close() error {
 
 func (it *iterator) initIters() error {
 	it.iters = make([]doc.Iterator, len(it.readers))
+	if it.explain {
+		it.traces = make([]search.SearchTrace, 0, len(it.readers))
+	}
 	for i, reader := range it.readers {
 		_, sp := it.ctx.StartTraceSpan(tracepoint.SearchExecutorIndexSearch)
 
@@ -161,10 +202,10 @@ func (it *iterator) initIters() error {
 			pl  postings.List
 			err error
 		)
-		if readThrough, ok := reader.(search.ReadThroughSegmentSearcher); ok {
-			pl, err = readThrough.Search(it.query, it.searcher)
+		if it.explain {
+			pl, err = it.explainSearch(i, reader)
 		} else {
-			pl, err = it.searcher.Search(reader)
+			pl, err = searchReader(it.query, it.searcher, reader)
 		}
 		sp.Finish()
 		if err != nil {
@@ -180,3 +221,32 @@ func (it *iterator) initIters() error {
 	}
 	return nil
 }
+
+func (it *iterator) explainSearch(i int, reader index.Reader) (postings.List, error) {
+	var (
+		searcher = &tracingSearcher{searcher: it.searcher}
+		start    = time.Now()
+	)
+	pl, err := searchReader(it.query, searcher, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	it.traces = append(it.traces, search.SearchTrace{
+		Segment: it.names[i],
+		// NB: a read through segment only invokes the searcher on a miss of
+		// its search cache.
+		CacheHit: !searcher.invoked,
+		Postings: pl.Len(),
+		Duration: time.Since(start),
+		Matches:  searcher.matches,
+	})
+	return pl, nil
+}
+
+func searchReader(q search.Query, s search.Searcher, reader index.Reader) (postings.List, error) {
+	if readThrough, ok := reader.(search.ReadThroughSegmentSearcher); ok {
+		return readThrough.Search(q, s)
+	}
+	return s.Search(reader)
+}
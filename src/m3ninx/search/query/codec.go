@@ -25,6 +25,7 @@ import (
 	"fmt"
 
 	"github.com/m3db/m3/src/m3ninx/generated/proto/querypb"
+	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/search"
 )
 
@@ -64,6 +65,14 @@ func UnmarshalProto(q *querypb.Query) (search.Query, error) {
 	case *querypb.Query_Regexp:
 		return NewRegexpQuery(q.Regexp.Field, q.Regexp.Regexp)
 
+	case *querypb.Query_NumericRange:
+		nr, err := index.NewNumericRange(q.NumericRange.Min, q.NumericRange.MinInclusive,
+			q.NumericRange.Max, q.NumericRange.MaxInclusive)
+		if err != nil {
+			return nil, err
+		}
+		return NewNumericRangeQuery(q.NumericRange.Field, nr), nil
+
 	case *querypb.Query_Negation:
 		inner, err := UnmarshalProto(q.Negation.Query)
 		if err != nil {
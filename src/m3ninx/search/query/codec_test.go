@@ -49,6 +49,14 @@ func TestMarshal(t *testing.T) {
 			name:  "regexp query",
 			query: MustCreateRegexpQuery([]byte("fruit"), []byte(".*ple")),
 		},
+		{
+			name:  "numeric range query",
+			query: NewNumericRangeQuery([]byte("code"), mustParseNumericRange("[500,600)")),
+		},
+		{
+			name:  "unbounded numeric range query",
+			query: NewNumericRangeQuery([]byte("code"), mustParseNumericRange("(0.5,]")),
+		},
 		{
 			name:  "negation query",
 			query: NewNegationQuery(NewTermQuery([]byte("fruit"), []byte("apple"))),
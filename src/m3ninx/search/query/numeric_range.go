@@ -0,0 +1,101 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package query
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/m3db/m3/src/m3ninx/generated/proto/querypb"
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/search"
+	"github.com/m3db/m3/src/m3ninx/search/searcher"
+)
+
+// NumericRangeQuery finds documents whose field value parses as a number
+// within the given range.
+type NumericRangeQuery struct {
+	str   string
+	field []byte
+	nr    index.NumericRange
+}
+
+// NewNumericRangeQuery constructs a new query for the given numeric range.
+func NewNumericRangeQuery(field []byte, nr index.NumericRange) search.Query {
+	q := &NumericRangeQuery{
+		field: field,
+		nr:    nr,
+	}
+	// NB: Calculate string value up front so not allocated every
+	// time String() is called to determine the cache key.
+	q.str = q.string()
+	return q
+}
+
+// Searcher returns a searcher over the provided readers.
+func (q *NumericRangeQuery) Searcher() (search.Searcher, error) {
+	return searcher.NewNumericRangeSearcher(q.field, q.nr), nil
+}
+
+// Equal reports whether q is equivalent to o.
+func (q *NumericRangeQuery) Equal(o search.Query) bool {
+	o, ok := singular(o)
+	if !ok {
+		return false
+	}
+
+	inner, ok := o.(*NumericRangeQuery)
+	if !ok {
+		return false
+	}
+
+	return bytes.Equal(q.field, inner.field) && q.nr == inner.nr
+}
+
+// ToProto returns the Protobuf query struct corresponding to the numeric
+// range query.
+func (q *NumericRangeQuery) ToProto() *querypb.Query {
+	nr := querypb.NumericRangeQuery{
+		Field:        q.field,
+		Min:          q.nr.Min,
+		Max:          q.nr.Max,
+		MinInclusive: q.nr.MinInclusive,
+		MaxInclusive: q.nr.MaxInclusive,
+	}
+
+	return &querypb.Query{
+		Query: &querypb.Query_NumericRange{NumericRange: &nr},
+	}
+}
+
+func (q *NumericRangeQuery) String() string {
+	return q.str
+}
+
+func (q *NumericRangeQuery) string() string {
+	var str strings.Builder
+	str.WriteString("numeric_range(")
+	str.Write(q.field)
+	str.WriteRune(',')
+	str.WriteString(q.nr.String())
+	str.WriteRune(')')
+	return str.String()
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseNumericRange(str string) index.NumericRange {
+	nr, err := index.ParseNumericRange(str)
+	if err != nil {
+		panic(err)
+	}
+	return nr
+}
+
+func TestNumericRangeQuery(t *testing.T) {
+	q := NewNumericRangeQuery([]byte("code"), mustParseNumericRange("[500,600)"))
+	require.Equal(t, "numeric_range(code,[500,600))", q.String())
+
+	_, err := q.Searcher()
+	require.NoError(t, err)
+}
+
+func TestNumericRangeQueryEqual(t *testing.T) {
+	tests := []struct {
+		name        string
+		left, right search.Query
+		expected    bool
+	}{
+		{
+			name:     "same field and range",
+			left:     NewNumericRangeQuery([]byte("code"), mustParseNumericRange("[500,600)")),
+			right:    NewNumericRangeQuery([]byte("code"), mustParseNumericRange("[500,600)")),
+			expected: true,
+		},
+		{
+			name: "singular conjunction query",
+			left: NewNumericRangeQuery([]byte("code"), mustParseNumericRange("[500,600)")),
+			right: NewConjunctionQuery([]search.Query{
+				NewNumericRangeQuery([]byte("code"), mustParseNumericRange("[500,600)")),
+			}),
+			expected: true,
+		},
+		{
+			name:     "different field",
+			left:     NewNumericRangeQuery([]byte("code"), mustParseNumericRange("[500,600)")),
+			right:    NewNumericRangeQuery([]byte("status"), mustParseNumericRange("[500,600)")),
+			expected: false,
+		},
+		{
+			name:     "different bound",
+			left:     NewNumericRangeQuery([]byte("code"), mustParseNumericRange("[500,600)")),
+			right:    NewNumericRangeQuery([]byte("code"), mustParseNumericRange("[500,600]")),
+			expected: false,
+		},
+		{
+			name:     "different query type",
+			left:     NewNumericRangeQuery([]byte("code"), mustParseNumericRange("[500,600)")),
+			right:    NewTermQuery([]byte("code"), []byte("500")),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.left.Equal(test.right))
+		})
+	}
+}
@@ -243,3 +243,40 @@ func (mr *MockReadThroughSegmentSearcherMockRecorder) Search(query, searcher int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockReadThroughSegmentSearcher)(nil).Search), query, searcher)
 }
+
+// MockSearchTracer is a mock of SearchTracer interface.
+type MockSearchTracer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchTracerMockRecorder
+}
+
+// MockSearchTracerMockRecorder is the mock recorder for MockSearchTracer.
+type MockSearchTracerMockRecorder struct {
+	mock *MockSearchTracer
+}
+
+// NewMockSearchTracer creates a new mock instance.
+func NewMockSearchTracer(ctrl *gomock.Controller) *MockSearchTracer {
+	mock := &MockSearchTracer{ctrl: ctrl}
+	mock.recorder = &MockSearchTracerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchTracer) EXPECT() *MockSearchTracerMockRecorder {
+	return m.recorder
+}
+
+// SearchTraces mocks base method.
+func (m *MockSearchTracer) SearchTraces() []SearchTrace {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTraces")
+	ret0, _ := ret[0].([]SearchTrace)
+	return ret0
+}
+
+// SearchTraces indicates an expected call of SearchTraces.
+func (mr *MockSearchTracerMockRecorder) SearchTraces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTraces", reflect.TypeOf((*MockSearchTracer)(nil).SearchTraces))
+}
@@ -22,6 +22,7 @@ package search
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/generated/proto/querypb"
@@ -68,3 +69,35 @@ type Searchers []Searcher
 type ReadThroughSegmentSearcher interface {
 	Search(query Query, searcher Searcher) (postings.List, error)
 }
+
+// SearchTracer is implemented by document iterators that record a trace of
+// the segment searches they performed.
+type SearchTracer interface {
+	// SearchTraces returns the per segment search traces, these are only
+	// available once the segments have been searched.
+	SearchTraces() []SearchTrace
+}
+
+// SearchTrace describes the search of a single segment.
+type SearchTrace struct {
+	// Segment is a description of the segment searched.
+	Segment string `json:"segment"`
+	// CacheHit is true if the postings list was served from a search cache.
+	CacheHit bool `json:"cacheHit"`
+	// Postings is the size of the resulting postings list.
+	Postings int `json:"postings"`
+	// Duration is the time taken to search the segment.
+	Duration time.Duration `json:"duration"`
+	// Matches are the individual reader matches performed by the searchers.
+	Matches []MatchTrace `json:"matches,omitempty"`
+}
+
+// MatchTrace describes a single match performed against a segment reader.
+type MatchTrace struct {
+	// Match is a description of the match, e.g. term(city,nyc).
+	Match string `json:"match"`
+	// Postings is the size of the matched postings list.
+	Postings int `json:"postings"`
+	// Duration is the time taken to perform the match.
+	Duration time.Duration `json:"duration"`
+}
@@ -167,6 +167,7 @@ func testLocalType(t *testing.T, providedType string, placementExists bool) {
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
+						"lateWriteQuarantineEnabled": false,
 						"repairEnabled": false,
 						"retentionOptions": {
 							"retentionPeriodNanos": "86400000000000",
@@ -182,6 +183,9 @@ func testLocalType(t *testing.T, providedType string, placementExists bool) {
 							"enabled": true,
 							"blockSizeNanos": "3600000000000"
 						},
+						"retentionRules": [],
+						"rollupOptions": null,
+						"tieringOptions": null,
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
@@ -343,6 +347,7 @@ func TestLocalTypeWithNumShards(t *testing.T) {
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
+						"lateWriteQuarantineEnabled": false,
 						"repairEnabled": false,
 						"retentionOptions": {
 							"retentionPeriodNanos": "86400000000000",
@@ -358,6 +363,9 @@ func TestLocalTypeWithNumShards(t *testing.T) {
 							"enabled": true,
 							"blockSizeNanos": "3600000000000"
 						},
+						"retentionRules": [],
+						"rollupOptions": null,
+						"tieringOptions": null,
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
@@ -472,6 +480,7 @@ func TestLocalWithBlockSizeNanos(t *testing.T) {
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
+						"lateWriteQuarantineEnabled": false,
 						"repairEnabled": false,
 						"retentionOptions": {
 							"retentionPeriodNanos": "86400000000000",
@@ -487,6 +496,9 @@ func TestLocalWithBlockSizeNanos(t *testing.T) {
 							"enabled": true,
 							"blockSizeNanos": "10800000000000"
 						},
+						"retentionRules": [],
+						"rollupOptions": null,
+						"tieringOptions": null,
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
@@ -606,6 +618,7 @@ func TestLocalWithBlockSizeExpectedSeriesDatapointsPerHour(t *testing.T) {
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
+						"lateWriteQuarantineEnabled": false,
 						"repairEnabled": false,
 						"retentionOptions": {
 							"retentionPeriodNanos": "86400000000000",
@@ -621,6 +634,9 @@ func TestLocalWithBlockSizeExpectedSeriesDatapointsPerHour(t *testing.T) {
 							"enabled": true,
 							"blockSizeNanos": "%d"
 						},
+						"retentionRules": [],
+						"rollupOptions": null,
+						"tieringOptions": null,
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
@@ -870,6 +886,7 @@ func testClusterTypeHosts(t *testing.T, placementExists bool) {
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
+						"lateWriteQuarantineEnabled": false,
 						"repairEnabled": false,
 						"retentionOptions": {
 							"retentionPeriodNanos": "86400000000000",
@@ -885,6 +902,9 @@ func testClusterTypeHosts(t *testing.T, placementExists bool) {
 							"enabled": true,
 							"blockSizeNanos": "3600000000000"
 						},
+						"retentionRules": [],
+						"rollupOptions": null,
+						"tieringOptions": null,
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
@@ -1027,6 +1047,7 @@ func TestClusterTypeHostsWithIsolationGroup(t *testing.T) {
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
+						"lateWriteQuarantineEnabled": false,
 						"repairEnabled": false,
 						"retentionOptions": {
 							"retentionPeriodNanos": "86400000000000",
@@ -1042,6 +1063,9 @@ func TestClusterTypeHostsWithIsolationGroup(t *testing.T) {
 							"enabled": true,
 							"blockSizeNanos": "3600000000000"
 						},
+						"retentionRules": [],
+						"rollupOptions": null,
+						"tieringOptions": null,
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
@@ -1246,6 +1270,7 @@ func TestLocalTypeWithAggregatedNamespace(t *testing.T) {
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
+						"lateWriteQuarantineEnabled": false,
 						"repairEnabled": false,
 						"retentionOptions": {
 							"retentionPeriodNanos": "86400000000000",
@@ -1261,6 +1286,9 @@ func TestLocalTypeWithAggregatedNamespace(t *testing.T) {
 							"enabled": true,
 							"blockSizeNanos": "3600000000000"
 						},
+						"retentionRules": [],
+						"rollupOptions": null,
+						"tieringOptions": null,
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
@@ -1288,6 +1316,7 @@ func TestLocalTypeWithAggregatedNamespace(t *testing.T) {
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
+						"lateWriteQuarantineEnabled": false,
 						"repairEnabled": false,
 						"retentionOptions": {
 							"retentionPeriodNanos": "8784000000000000",
@@ -1303,6 +1332,9 @@ func TestLocalTypeWithAggregatedNamespace(t *testing.T) {
 							"enabled": true,
 							"blockSizeNanos": "86400000000000"
 						},
+						"retentionRules": [],
+						"rollupOptions": null,
+						"tieringOptions": null,
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
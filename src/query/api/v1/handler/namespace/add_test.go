@@ -126,13 +126,14 @@ func TestNamespaceAddHandler(t *testing.T) {
 			"registry": xjson.Map{
 				"namespaces": xjson.Map{
 					"testNamespace": xjson.Map{
-						"aggregationOptions":    nil,
-						"bootstrapEnabled":      true,
-						"cacheBlocksOnRetrieve": false,
-						"flushEnabled":          true,
-						"writesToCommitLog":     true,
-						"cleanupEnabled":        true,
-						"repairEnabled":         true,
+						"aggregationOptions":         nil,
+						"bootstrapEnabled":           true,
+						"cacheBlocksOnRetrieve":      false,
+						"flushEnabled":               true,
+						"writesToCommitLog":          true,
+						"cleanupEnabled":             true,
+						"lateWriteQuarantineEnabled": false,
+						"repairEnabled":              true,
 						"retentionOptions": xjson.Map{
 							"retentionPeriodNanos":                     "172800000000000",
 							"blockSizeNanos":                           "7200000000000",
@@ -148,6 +149,9 @@ func TestNamespaceAddHandler(t *testing.T) {
 							"enabled":        true,
 							"blockSizeNanos": "7200000000000",
 						},
+						"retentionRules":    xjson.Array{},
+						"rollupOptions":     nil,
+						"tieringOptions":    nil,
 						"runtimeOptions":    nil,
 						"schemaOptions":     nil,
 						"coldWritesEnabled": false,
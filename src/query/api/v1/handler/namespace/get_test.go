@@ -122,14 +122,15 @@ func TestNamespaceGetHandler(t *testing.T) {
 			"registry": xjson.Map{
 				"namespaces": xjson.Map{
 					"test": xjson.Map{
-						"aggregationOptions":    nil,
-						"bootstrapEnabled":      true,
-						"cacheBlocksOnRetrieve": nil,
-						"cleanupEnabled":        false,
-						"coldWritesEnabled":     false,
-						"flushEnabled":          true,
-						"indexOptions":          nil,
-						"repairEnabled":         false,
+						"aggregationOptions":         nil,
+						"bootstrapEnabled":           true,
+						"cacheBlocksOnRetrieve":      nil,
+						"cleanupEnabled":             false,
+						"coldWritesEnabled":          false,
+						"flushEnabled":               true,
+						"indexOptions":               nil,
+						"lateWriteQuarantineEnabled": false,
+						"repairEnabled":              false,
 						"retentionOptions": xjson.Map{
 							"blockDataExpiry":                          true,
 							"blockDataExpiryAfterNotAccessPeriodNanos": "3600000000000",
@@ -139,6 +140,9 @@ func TestNamespaceGetHandler(t *testing.T) {
 							"futureRetentionPeriodNanos":               "0",
 							"retentionPeriodNanos":                     "172800000000000",
 						},
+						"retentionRules":    xjson.Array{},
+						"rollupOptions":     nil,
+						"tieringOptions":    nil,
 						"runtimeOptions":    nil,
 						"schemaOptions":     nil,
 						"snapshotEnabled":   true,
@@ -207,14 +211,15 @@ func TestNamespaceGetHandlerWithDebug(t *testing.T) {
 			"registry": xjson.Map{
 				"namespaces": xjson.Map{
 					"test": xjson.Map{
-						"aggregationOptions":    nil,
-						"bootstrapEnabled":      true,
-						"cacheBlocksOnRetrieve": nil,
-						"cleanupEnabled":        false,
-						"coldWritesEnabled":     false,
-						"flushEnabled":          true,
-						"indexOptions":          nil,
-						"repairEnabled":         false,
+						"aggregationOptions":         nil,
+						"bootstrapEnabled":           true,
+						"cacheBlocksOnRetrieve":      nil,
+						"cleanupEnabled":             false,
+						"coldWritesEnabled":          false,
+						"flushEnabled":               true,
+						"indexOptions":               nil,
+						"lateWriteQuarantineEnabled": false,
+						"repairEnabled":              false,
 						"retentionOptions": xjson.Map{
 							"blockDataExpiry": true,
 							"blockDataExpiryAfterNotAccessPeriodDuration": "1h0m0s",
@@ -224,6 +229,9 @@ func TestNamespaceGetHandlerWithDebug(t *testing.T) {
 							"futureRetentionPeriodDuration":               "0s",
 							"retentionPeriodDuration":                     "48h0m0s",
 						},
+						"retentionRules":    xjson.Array{},
+						"rollupOptions":     nil,
+						"tieringOptions":    nil,
 						"runtimeOptions":    nil,
 						"schemaOptions":     nil,
 						"stagingState":      xjson.Map{"status": "UNKNOWN"},
@@ -175,12 +175,13 @@ func TestNamespaceUpdateHandler(t *testing.T) {
 								},
 							},
 						},
-						"bootstrapEnabled":      true,
-						"cacheBlocksOnRetrieve": true,
-						"flushEnabled":          true,
-						"writesToCommitLog":     true,
-						"cleanupEnabled":        false,
-						"repairEnabled":         false,
+						"bootstrapEnabled":           true,
+						"cacheBlocksOnRetrieve":      true,
+						"flushEnabled":               true,
+						"writesToCommitLog":          true,
+						"cleanupEnabled":             false,
+						"lateWriteQuarantineEnabled": false,
+						"repairEnabled":              false,
 						"retentionOptions": xjson.Map{
 							"retentionPeriodNanos":                     "345600000000000",
 							"blockSizeNanos":                           "7200000000000",
@@ -195,6 +196,9 @@ func TestNamespaceUpdateHandler(t *testing.T) {
 							"enabled":        false,
 							"blockSizeNanos": "7200000000000",
 						},
+						"retentionRules": xjson.Array{},
+						"rollupOptions":  nil,
+						"tieringOptions": nil,
 						"runtimeOptions": xjson.Map{
 							"flushIndexingPerCPUConcurrency": nil,
 							"writeIndexingPerCPUConcurrency": 16,
@@ -235,13 +239,14 @@ func TestNamespaceUpdateHandler(t *testing.T) {
 			"registry": xjson.Map{
 				"namespaces": xjson.Map{
 					"testNamespace": xjson.Map{
-						"aggregationOptions":    nil,
-						"bootstrapEnabled":      true,
-						"cacheBlocksOnRetrieve": true,
-						"flushEnabled":          true,
-						"writesToCommitLog":     true,
-						"cleanupEnabled":        false,
-						"repairEnabled":         false,
+						"aggregationOptions":         nil,
+						"bootstrapEnabled":           true,
+						"cacheBlocksOnRetrieve":      true,
+						"flushEnabled":               true,
+						"writesToCommitLog":          true,
+						"cleanupEnabled":             false,
+						"lateWriteQuarantineEnabled": false,
+						"repairEnabled":              false,
 						"retentionOptions": xjson.Map{
 							"retentionPeriodNanos":                     "172800000000000",
 							"blockSizeNanos":                           "7200000000000",
@@ -256,6 +261,9 @@ func TestNamespaceUpdateHandler(t *testing.T) {
 							"enabled":        false,
 							"blockSizeNanos": "7200000000000",
 						},
+						"retentionRules":    xjson.Array{},
+						"rollupOptions":     nil,
+						"tieringOptions":    nil,
 						"runtimeOptions":    nil,
 						"schemaOptions":     nil,
 						"stagingState":      xjson.Map{"status": "UNKNOWN"},
@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
 	xhttp "github.com/m3db/m3/src/x/net/http"
 
 	jsoniter "github.com/json-iterator/go"
@@ -65,10 +66,18 @@ type response struct {
 	ErrorType errorType   `json:"errorType,omitempty"`
 	Error     string      `json:"error,omitempty"`
 	Warnings  []string    `json:"warnings,omitempty"`
+	// Debug is not part of the prometheus format, it is only set if debug
+	// metadata of the query was requested.
+	Debug *native.QueryDebug `json:"debug,omitempty"`
 }
 
 // Respond responds with HTTP OK status code and writes response JSON to response body.
-func Respond(w http.ResponseWriter, data interface{}, warnings promstorage.Warnings) error {
+func Respond(
+	w http.ResponseWriter,
+	data interface{},
+	warnings promstorage.Warnings,
+	debug *native.QueryDebug,
+) error {
 	statusMessage := statusSuccess
 	var warningStrings []string
 	for _, warning := range warnings {
@@ -80,5 +89,6 @@ func Respond(w http.ResponseWriter, data interface{}, warnings promstorage.Warni
 		Status:   statusMessage,
 		Data:     data,
 		Warnings: warningStrings,
+		Debug:    debug,
 	})
 }
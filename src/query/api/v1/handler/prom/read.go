@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/query/block"
 	queryerrors "github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/querylog"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/prometheus"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -38,6 +39,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	errs "github.com/pkg/errors"
 	"github.com/prometheus/prometheus/promql"
@@ -119,6 +121,7 @@ func newReadHandler(
 }
 
 func (h *readHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
 	ctx := r.Context()
 	ctx, request, err := native.ParseRequest(ctx, r, h.opts.instant, h.hOpts)
 	if err != nil {
@@ -155,8 +158,17 @@ func (h *readHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer qry.Close()
+	parseDuration := time.Since(start)
 
+	execStart := time.Now()
 	res := qry.Exec(ctx)
+	timings := querylog.Timings{
+		Parse:   parseDuration,
+		Execute: time.Since(execStart),
+		Total:   time.Since(start),
+	}
+	h.hOpts.QueryLogger().Log(native.NewQueryLogEntry(r, request, h.opts.instant,
+		resultMetadata, timings, res.Err))
 	if res.Err != nil {
 		h.logger.Error("error executing query",
 			zap.Error(res.Err), zap.String("query", params.Query),
@@ -244,7 +256,7 @@ func (h *readHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if err := Respond(w, &QueryData{
 		Result:     res.Value,
 		ResultType: res.Value.Type(),
-	}, res.Warnings); err != nil {
+	}, res.Warnings, native.NewQueryDebug(resultMetadata)); err != nil {
 		h.logger.Error("error writing prom response",
 			zap.Error(err),
 			zap.String("query", params.Query),
@@ -57,6 +57,7 @@ const (
 
 	requireExhaustiveParam = "requireExhaustive"
 	requireNoWaitParam     = "requireNoWait"
+	indexExplainParam      = "indexExplain"
 	maxInt64               = float64(math.MaxInt64)
 	minInt64               = float64(math.MinInt64)
 	maxTimeout             = 10 * time.Minute
@@ -283,6 +284,25 @@ func ParseRequireNoWait(req *http.Request) (bool, error) {
 	return false, nil
 }
 
+// ParseIndexExplain parses whether an explain of the index query was
+// requested from header or query string.
+func ParseIndexExplain(req *http.Request) (bool, error) {
+	str := req.Header.Get(headers.IndexExplainHeader)
+	if str == "" {
+		str = req.FormValue(indexExplainParam)
+	}
+	if str == "" {
+		return false, nil
+	}
+
+	v, err := strconv.ParseBool(str)
+	if err != nil {
+		return false, fmt.Errorf(
+			"could not parse index explain: input=%s, err=%w", str, err)
+	}
+	return v, nil
+}
+
 // NewFetchOptions parses an http request into fetch options.
 func (b fetchOptionsBuilder) NewFetchOptions(
 	ctx context.Context,
@@ -301,9 +321,12 @@ func (b fetchOptionsBuilder) newFetchOptions(
 	req *http.Request,
 ) (context.Context, *storage.FetchOptions, error) {
 	fetchOpts := storage.NewFetchOptions()
+	fetchOpts.Stats = storage.NewQueryStats()
 
 	if source := req.Header.Get(headers.SourceHeader); len(source) > 0 {
 		fetchOpts.Source = []byte(source)
+	} else if tenant := req.Header.Get(headers.TenantHeader); len(tenant) > 0 {
+		fetchOpts.Source = []byte(tenant)
 	}
 
 	seriesLimit, err := ParseValue(req, headers.LimitMaxSeriesHeader,
@@ -381,6 +404,13 @@ func (b fetchOptionsBuilder) newFetchOptions(
 
 	fetchOpts.RequireNoWait = requireNoWait
 
+	indexExplain, err := ParseIndexExplain(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fetchOpts.IndexExplain = indexExplain
+
 	readConsistencyLevel, err := ParseReadConsistencyLevel(req, headers.ReadConsistencyLevelHeader,
 		"readConsistencyLevel")
 	if err != nil {
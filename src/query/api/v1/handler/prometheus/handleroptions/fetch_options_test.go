@@ -65,6 +65,7 @@ func TestFetchOptionsBuilder(t *testing.T) {
 		expectedLookback                      *expectedLookback
 		expectedReadConsistencyLevel          *topology.ReadConsistencyLevel
 		expectedIterateEqualTimestampStrategy *encoding.IterateEqualTimestampStrategy
+		expectedSource                        []byte
 		expectedErr                           bool
 	}{
 		{
@@ -315,6 +316,21 @@ func TestFetchOptionsBuilder(t *testing.T) {
 			expectedReadConsistencyLevel:          &topology.ValidReadConsistencyLevels()[5],
 			expectedIterateEqualTimestampStrategy: &encoding.ValidIterateEqualTimestampStrategies()[2],
 		},
+		{
+			name: "source",
+			headers: map[string]string{
+				headers.SourceHeader: "foo",
+				headers.TenantHeader: "bar",
+			},
+			expectedSource: []byte("foo"),
+		},
+		{
+			name: "tenant as source",
+			headers: map[string]string{
+				headers.TenantHeader: "bar",
+			},
+			expectedSource: []byte("bar"),
+		},
 	}
 
 	for _, test := range tests {
@@ -365,6 +381,7 @@ func TestFetchOptionsBuilder(t *testing.T) {
 					require.NotNil(t, opts.IterateEqualTimestampStrategy)
 					require.Equal(t, *test.expectedIterateEqualTimestampStrategy, *opts.IterateEqualTimestampStrategy)
 				}
+				require.Equal(t, test.expectedSource, opts.Source)
 				require.Equal(t, 10*time.Second, opts.Timeout)
 				// Check context has deadline and headers from
 				// the request.
@@ -532,6 +549,29 @@ func stripSpace(str string) string {
 	return regexp.MustCompile(`\s+`).ReplaceAllString(str, "")
 }
 
+func TestParseIndexExplain(t *testing.T) {
+	req := httptest.NewRequest("GET", "/read", nil)
+	explain, err := ParseIndexExplain(req)
+	require.NoError(t, err)
+	assert.False(t, explain)
+
+	req = httptest.NewRequest("GET", "/read?indexExplain=true", nil)
+	explain, err = ParseIndexExplain(req)
+	require.NoError(t, err)
+	assert.True(t, explain)
+
+	req = httptest.NewRequest("GET", "/read", nil)
+	req.Header.Set(headers.IndexExplainHeader, "true")
+	explain, err = ParseIndexExplain(req)
+	require.NoError(t, err)
+	assert.True(t, explain)
+
+	req = httptest.NewRequest("GET", "/read", nil)
+	req.Header.Set(headers.IndexExplainHeader, "foo")
+	_, err = ParseIndexExplain(req)
+	require.Error(t, err)
+}
+
 func TestParseRequestTimeout(t *testing.T) {
 	req := httptest.NewRequest("GET", "/read?timeout=2m", nil)
 	dur, err := ParseRequestTimeout(req, time.Second)
@@ -241,6 +241,39 @@ func TestAddDBResultResponseHeadersMetadataByNameMaxConfig(t *testing.T) {
 	assert.Empty(t, recorder.Header().Get(headers.MetricStats))
 }
 
+func TestAddDBResultResponseHeadersQueryStats(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	fetchOpts := storage.NewFetchOptions()
+	fetchOpts.Stats = storage.NewQueryStats()
+	require.NoError(t, AddDBResultResponseHeaders(recorder, block.NewResultMetadata(), fetchOpts))
+	assert.Equal(t, "", recorder.Header().Get(headers.QueryStatsHeader))
+
+	fetchOpts.Stats.Add("default", storage.NamespaceQueryStats{
+		SeriesMatched: 2,
+		DocsRead:      3,
+		BytesRead:  100,
+		BlocksFetched: 4,
+	})
+	fetchOpts.Stats.Add("default", storage.NamespaceQueryStats{DatapointsDecoded: 10})
+	require.NoError(t, AddDBResultResponseHeaders(recorder, block.NewResultMetadata(), fetchOpts))
+	assert.JSONEq(t, `{
+		"seriesMatched": 2,
+		"docsRead": 3,
+		"bytesRead": 100,
+		"blocksFetched": 4,
+		"datapointsDecoded": 10,
+		"namespaces": {
+			"default": {
+				"seriesMatched": 2,
+				"docsRead": 3,
+				"bytesRead": 100,
+				"blocksFetched": 4,
+				"datapointsDecoded": 10
+			}
+		}
+	}`, recorder.Header().Get(headers.QueryStatsHeader))
+}
+
 func TestAddReturnedLimitResponseHeaders(t *testing.T) {
 	recorder := httptest.NewRecorder()
 	require.NoError(t, AddReturnedLimitResponseHeaders(recorder, &ReturnedDataLimited{
@@ -137,6 +137,14 @@ func AddDBResultResponseHeaders(
 		w.Header().Add(headers.FetchedMetadataCount, fmt.Sprint(meta.FetchedMetadataCount))
 	}
 
+	if fetchOpts != nil && len(fetchOpts.Stats.Namespaces()) > 0 {
+		js, err := json.Marshal(fetchOpts.Stats)
+		if err != nil {
+			return err
+		}
+		w.Header().Add(headers.QueryStatsHeader, string(js))
+	}
+
 	if waiting.WaitedAny() {
 		s, err := json.Marshal(waiting)
 		if err != nil {
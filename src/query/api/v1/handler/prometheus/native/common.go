@@ -27,6 +27,8 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/search"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/block"
@@ -329,6 +331,8 @@ func RenderResultsJSON(
 	jw.EndArray()
 	jw.EndObject()
 
+	renderDebugJSON(jw, result.Meta)
+
 	jw.EndObject()
 	return RenderResultsResult{
 		Series:                 seriesRendered,
@@ -429,6 +433,8 @@ func renderResultsInstantaneousJSON(
 
 	jw.EndObject()
 
+	renderDebugJSON(jw, result.Meta)
+
 	jw.EndObject()
 
 	return RenderResultsResult{
@@ -440,3 +446,91 @@ func renderResultsInstantaneousJSON(
 		TotalSeries: len(series),
 	}
 }
+
+// QueryDebug is the debug section of a query response, it is only rendered
+// if debug metadata of the query was requested.
+type QueryDebug struct {
+	// IndexExplain are the traces of the index query from each host,
+	// requested with the indexExplain param or the M3-Index-Explain header.
+	IndexExplain []index.QueryExplain `json:"indexExplain,omitempty"`
+}
+
+// NewQueryDebug returns the debug section of a query response for the result
+// metadata, or nil if there is no debug metadata.
+func NewQueryDebug(meta block.ResultMetadata) *QueryDebug {
+	if len(meta.IndexExplain) == 0 {
+		return nil
+	}
+	return &QueryDebug{IndexExplain: meta.IndexExplain}
+}
+
+// renderDebugJSON renders the debug section of a query response in the same
+// format as QueryDebug is marshalled, durations are in nanoseconds.
+func renderDebugJSON(jw json.Writer, meta block.ResultMetadata) {
+	debug := NewQueryDebug(meta)
+	if debug == nil {
+		return
+	}
+
+	jw.BeginObjectField("debug")
+	jw.BeginObject()
+	jw.BeginObjectField("indexExplain")
+	jw.BeginArray()
+	for _, explain := range debug.IndexExplain {
+		jw.BeginObject()
+		if explain.Host != "" {
+			jw.BeginObjectField("host")
+			jw.WriteString(explain.Host)
+		}
+		jw.BeginObjectField("blocks")
+		jw.BeginArray()
+		for _, b := range explain.Blocks {
+			jw.BeginObject()
+			jw.BeginObjectField("blockStart")
+			jw.WriteInt(int(b.BlockStart))
+			jw.BeginObjectField("waitTime")
+			jw.WriteInt(int(b.WaitTime))
+			jw.BeginObjectField("processingTime")
+			jw.WriteInt(int(b.ProcessingTime))
+			jw.BeginObjectField("segments")
+			jw.BeginArray()
+			for _, segment := range b.Segments {
+				renderSearchTraceJSON(jw, segment)
+			}
+			jw.EndArray()
+			jw.EndObject()
+		}
+		jw.EndArray()
+		jw.EndObject()
+	}
+	jw.EndArray()
+	jw.EndObject()
+}
+
+func renderSearchTraceJSON(jw json.Writer, trace search.SearchTrace) {
+	jw.BeginObject()
+	jw.BeginObjectField("segment")
+	jw.WriteString(trace.Segment)
+	jw.BeginObjectField("cacheHit")
+	jw.WriteBool(trace.CacheHit)
+	jw.BeginObjectField("postings")
+	jw.WriteInt(trace.Postings)
+	jw.BeginObjectField("duration")
+	jw.WriteInt(int(trace.Duration))
+	if len(trace.Matches) > 0 {
+		jw.BeginObjectField("matches")
+		jw.BeginArray()
+		for _, match := range trace.Matches {
+			jw.BeginObject()
+			jw.BeginObjectField("match")
+			jw.WriteString(match.Match)
+			jw.BeginObjectField("postings")
+			jw.WriteInt(match.Postings)
+			jw.BeginObjectField("duration")
+			jw.WriteInt(int(match.Duration))
+			jw.EndObject()
+		}
+		jw.EndArray()
+	}
+	jw.EndObject()
+}
@@ -22,6 +22,7 @@ package native
 
 import (
 	"bytes"
+	stdjson "encoding/json"
 	"math"
 	"net/http"
 	"net/http/httptest"
@@ -30,6 +31,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/search"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor"
@@ -648,6 +651,68 @@ func TestRenderInstantaneousResultsJSONScalar(t *testing.T) {
 	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
 }
 
+func TestRenderResultsJSONIndexExplain(t *testing.T) {
+	start := xtime.FromSeconds(1535948880)
+	series := []*ts.Series{
+		ts.NewSeries([]byte("foo"),
+			ts.NewFixedStepValues(10*time.Second, 1, 5, start),
+			test.TagSliceToTags([]models.Tag{{Name: []byte("a"), Value: []byte("b")}})),
+	}
+	meta := block.NewResultMetadata()
+	meta.IndexExplain = []index.QueryExplain{
+		{
+			Host: "testhost0",
+			Blocks: []index.BlockExplain{
+				{
+					BlockStart:     start,
+					WaitTime:       time.Microsecond,
+					ProcessingTime: time.Millisecond,
+					Segments: []search.SearchTrace{
+						{
+							Segment:  "fst/0",
+							CacheHit: true,
+							Postings: 1,
+							Duration: time.Microsecond,
+							Matches: []search.MatchTrace{
+								{Match: "term(a,b)", Postings: 1, Duration: time.Microsecond},
+							},
+						},
+						{Segment: "mutable/0"},
+					},
+				},
+			},
+		},
+		{Host: "testhost1", Blocks: []index.BlockExplain{}},
+	}
+	readResult := ReadResult{Series: series, Meta: meta}
+
+	for _, render := range []func(json.Writer, ReadResult, RenderResultsOptions) RenderResultsResult{
+		RenderResultsJSON,
+		renderResultsInstantaneousJSON,
+	} {
+		buffer := bytes.NewBuffer(nil)
+		jw := json.NewWriter(buffer)
+		render(jw, readResult, RenderResultsOptions{Start: start, End: start})
+		require.NoError(t, jw.Close())
+
+		var actual struct {
+			Status string     `json:"status"`
+			Debug  QueryDebug `json:"debug"`
+		}
+		require.NoError(t, stdjson.Unmarshal(buffer.Bytes(), &actual))
+		assert.Equal(t, "success", actual.Status)
+		assert.Equal(t, meta.IndexExplain, actual.Debug.IndexExplain)
+	}
+
+	// The debug section is only rendered if requested.
+	buffer := bytes.NewBuffer(nil)
+	jw := json.NewWriter(buffer)
+	RenderResultsJSON(jw, ReadResult{Series: series, Meta: block.NewResultMetadata()},
+		RenderResultsOptions{Start: start, End: start})
+	require.NoError(t, jw.Close())
+	assert.NotContains(t, buffer.String(), "debug")
+}
+
 func TestSanitizeSeries(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
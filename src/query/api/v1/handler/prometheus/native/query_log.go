@@ -0,0 +1,81 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/querylog"
+	"github.com/m3db/m3/src/x/headers"
+)
+
+// NewQueryLogEntry returns the query log entry of a parsed query request
+// given the metadata of its result and the error it failed with, if any.
+// The parse, execute and total timings are measured by the caller, the fetch
+// and decode timings are the wall clock times taken from the query stats of
+// the fetch options and the wall clock time spent either fetching or decoding
+// is subtracted from the execute timing.
+func NewQueryLogEntry(
+	r *http.Request,
+	parsed ParsedOptions,
+	instant bool,
+	meta block.ResultMetadata,
+	timings querylog.Timings,
+	err error,
+) querylog.Entry {
+	fetchOpts := parsed.FetchOpts
+	stats := fetchOpts.Stats
+
+	timings.Fetch = stats.FetchDuration()
+	timings.Decode = stats.DecodeDuration()
+	timings.Execute -= stats.StorageDuration()
+	if timings.Execute < 0 {
+		timings.Execute = 0
+	}
+
+	entry := querylog.Entry{
+		Path:       r.URL.Path,
+		Query:      parsed.Params.Query,
+		Instant:    instant,
+		Start:      parsed.Params.Start.ToTime(),
+		End:        parsed.Params.End.ToTime(),
+		Step:       parsed.Params.Step,
+		Namespaces: meta.GetNamespaces(),
+		Source:     r.Header.Get(headers.SourceHeader),
+		Limits: querylog.Limits{
+			SeriesLimit:             fetchOpts.SeriesLimit,
+			DocsLimit:               fetchOpts.DocsLimit,
+			ReturnedSeriesLimit:     fetchOpts.ReturnedSeriesLimit,
+			ReturnedDatapointsLimit: fetchOpts.ReturnedDatapointsLimit,
+			RequireExhaustive:       fetchOpts.RequireExhaustive,
+		},
+		Timings: timings,
+		Stats:   querylog.NewStats(stats),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Limits.Limited = !meta.Exhaustive
+	}
+
+	return entry
+}
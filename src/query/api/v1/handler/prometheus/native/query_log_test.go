@@ -0,0 +1,103 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/querylog"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/headers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQueryLogEntry(t *testing.T) {
+	req, _ := http.NewRequest("GET", PromReadURL, nil)
+	req.URL.RawQuery = defaultParams().Encode()
+	req.Header.Set(headers.SourceHeader, "dashboards")
+
+	params, err := testParseParams(req)
+	require.NoError(t, err)
+
+	fetchOpts := storage.NewFetchOptions()
+	fetchOpts.SeriesLimit = 10
+	fetchOpts.RequireExhaustive = true
+	fetchOpts.Stats = storage.NewQueryStats()
+	fetchOpts.Stats.Add("default", storage.NamespaceQueryStats{SeriesMatched: 4})
+	// Concurrent fetches from 1s to 3s and from 2s to 4s, overlapping a
+	// decode from 3s to 5s.
+	start := time.Unix(1600000000, 0)
+	fetchOpts.Stats.FetchStarted(start.Add(time.Second))
+	fetchOpts.Stats.FetchStarted(start.Add(2 * time.Second))
+	fetchOpts.Stats.DecodeStarted(start.Add(3 * time.Second))
+	fetchOpts.Stats.FetchFinished(start.Add(3 * time.Second))
+	fetchOpts.Stats.FetchFinished(start.Add(4 * time.Second))
+	fetchOpts.Stats.DecodeFinished(start.Add(5 * time.Second))
+	parsed := ParsedOptions{FetchOpts: fetchOpts, Params: params}
+
+	meta := block.NewResultMetadata()
+	meta.AddNamespace("default")
+	meta.Exhaustive = false
+
+	entry := NewQueryLogEntry(req, parsed, false, meta, querylog.Timings{
+		Parse:   time.Millisecond,
+		Execute: 5 * time.Second,
+		Total:   6 * time.Second,
+	}, nil)
+	assert.Equal(t, PromReadURL, entry.Path)
+	assert.Equal(t, promQuery, entry.Query)
+	assert.Equal(t, params.Start.ToTime(), entry.Start)
+	assert.Equal(t, params.End.ToTime(), entry.End)
+	assert.Equal(t, 10*time.Second, entry.Step)
+	assert.Equal(t, []string{"default"}, entry.Namespaces)
+	assert.Equal(t, "dashboards", entry.Source)
+	assert.Equal(t, querylog.Limits{
+		SeriesLimit:       10,
+		RequireExhaustive: true,
+		Limited:           true,
+	}, entry.Limits)
+	assert.Equal(t, querylog.Timings{
+		Parse:   time.Millisecond,
+		Fetch:   3 * time.Second,
+		Decode:  2 * time.Second,
+		Execute: time.Second,
+		Total:   6 * time.Second,
+	}, entry.Timings)
+	assert.Equal(t, querylog.Stats{
+		NamespaceQueryStats: storage.NamespaceQueryStats{SeriesMatched: 4},
+		Namespaces: map[string]storage.NamespaceQueryStats{
+			"default": {SeriesMatched: 4},
+		},
+	}, entry.Stats)
+	assert.Empty(t, entry.Error)
+
+	entry = NewQueryLogEntry(req, parsed, false, block.ResultMetadata{},
+		querylog.Timings{Execute: time.Second}, errors.New("boom"))
+	assert.Equal(t, time.Duration(0), entry.Timings.Execute)
+	assert.False(t, entry.Limits.Limited)
+	assert.Equal(t, "boom", entry.Error)
+}
@@ -22,11 +22,13 @@ package native
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/api/v1/route"
 	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/querylog"
 	"github.com/m3db/m3/src/query/util/json"
 	"github.com/m3db/m3/src/query/util/logging"
 	xhttp "github.com/m3db/m3/src/x/net/http"
@@ -58,6 +60,9 @@ const (
 
 	// M3QueryReadInstantURL is the URL for native instantaneous m3 query read handler.
 	M3QueryReadInstantURL = "/m3query" + PromReadInstantURL
+
+	// M3QLReadURL is the URL for the native M3QL read handler.
+	M3QLReadURL = route.Prefix + "/m3ql/query_range"
 )
 
 var (
@@ -72,38 +77,53 @@ var (
 		http.MethodGet,
 		http.MethodPost,
 	}
+
+	// M3QLReadHTTPMethods are the HTTP methods for the M3QL read handler.
+	M3QLReadHTTPMethods = []string{
+		http.MethodGet,
+		http.MethodPost,
+	}
 )
 
 // promReadHandler represents a handler for prometheus read endpoint.
 type promReadHandler struct {
 	instant         bool
+	parseQuery      parseQueryFn
 	promReadMetrics promReadMetrics
 	opts            options.HandlerOptions
 }
 
 // NewPromReadHandler returns a new prometheus-compatible read handler.
 func NewPromReadHandler(opts options.HandlerOptions) http.Handler {
-	return newHandler(opts, false)
+	return newHandler(opts, "native-read", false, parsePromQL)
 }
 
 // NewPromReadInstantHandler returns a new pro instance of handler.
 func NewPromReadInstantHandler(opts options.HandlerOptions) http.Handler {
-	return newHandler(opts, true)
+	return newHandler(opts, "native-instant-read", true, parsePromQL)
 }
 
-// newHandler returns a new pro instance of handler.
-func newHandler(opts options.HandlerOptions, instant bool) http.Handler {
-	name := "native-read"
-	if instant {
-		name = "native-instant-read"
-	}
+// NewM3QLReadHandler returns a new read handler for M3QL range queries,
+// which otherwise accepts the same parameters and headers as the native
+// prometheus-compatible read handler.
+func NewM3QLReadHandler(opts options.HandlerOptions) http.Handler {
+	return newHandler(opts, "m3ql-read", false, parseM3QL)
+}
 
+// newHandler returns a new pro instance of handler.
+func newHandler(
+	opts options.HandlerOptions,
+	name string,
+	instant bool,
+	parseQuery parseQueryFn,
+) http.Handler {
 	taggedScope := opts.InstrumentOpts().MetricsScope().
 		Tagged(map[string]string{"handler": name})
 	h := &promReadHandler{
 		promReadMetrics: newPromReadMetrics(taggedScope),
 		opts:            opts,
 		instant:         instant,
+		parseQuery:      parseQuery,
 	}
 	return h
 }
@@ -115,6 +135,7 @@ func (h *promReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	iOpts := h.opts.InstrumentOpts()
 	logger := logging.WithContext(r.Context(), iOpts)
 
+	start := time.Now()
 	ctx, parsedOptions, rErr := ParseRequest(r.Context(), r, h.instant, h.opts)
 	if rErr != nil {
 		h.promReadMetrics.incError(rErr)
@@ -122,6 +143,7 @@ func (h *promReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		xhttp.WriteError(w, rErr)
 		return
 	}
+	parseDuration := time.Since(start)
 	ctx = logging.NewContext(ctx,
 		iOpts,
 		zap.String("query", parsedOptions.Params.Query),
@@ -132,7 +154,17 @@ func (h *promReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		zap.Duration("fetchTimeout", parsedOptions.FetchOpts.Timeout),
 	)
 
-	result, err := read(ctx, parsedOptions, h.opts)
+	execStart := time.Now()
+	result, err := read(ctx, parsedOptions, h.opts, h.parseQuery)
+	// NB: the query itself is parsed as it is read, so count the time spent
+	// parsing it as parsing rather than executing.
+	timings := querylog.Timings{
+		Parse:   parseDuration + result.ParseDuration,
+		Execute: time.Since(execStart) - result.ParseDuration,
+		Total:   time.Since(start),
+	}
+	h.opts.QueryLogger().Log(NewQueryLogEntry(r, parsedOptions, h.instant,
+		result.Meta, timings, err))
 	if err != nil {
 		sp := xopentracing.SpanFromContextOrNoop(ctx)
 		sp.LogFields(opentracinglog.Error(err))
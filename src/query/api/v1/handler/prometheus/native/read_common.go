@@ -24,12 +24,15 @@ import (
 	"context"
 	"math"
 	"net/http"
+	"time"
 
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/parser/m3ql"
 	"github.com/m3db/m3/src/query/parser/promql"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/ts"
@@ -106,6 +109,9 @@ type ReadResult struct {
 	Series    []*ts.Series
 	Meta      block.ResultMetadata
 	BlockType block.BlockType
+	// ParseDuration is the time spent parsing the query, set even if the
+	// query then failed.
+	ParseDuration time.Duration
 }
 
 // ParseRequest parses the given request.
@@ -175,6 +181,32 @@ func parseRequest(
 	}, nil
 }
 
+// parseQueryFn parses a query into a DAG for the engine to execute.
+type parseQueryFn func(
+	query string,
+	step time.Duration,
+	tagOpts models.TagOptions,
+	parseOpts promql.ParseOptions,
+) (parser.Parser, error)
+
+func parsePromQL(
+	query string,
+	step time.Duration,
+	tagOpts models.TagOptions,
+	parseOpts promql.ParseOptions,
+) (parser.Parser, error) {
+	return promql.Parse(query, step, tagOpts, parseOpts)
+}
+
+func parseM3QL(
+	query string,
+	step time.Duration,
+	tagOpts models.TagOptions,
+	_ promql.ParseOptions,
+) (parser.Parser, error) {
+	return m3ql.Parse(query, step, tagOpts)
+}
+
 // ParsedOptions are parsed options for the query.
 type ParsedOptions struct {
 	QueryOpts *executor.QueryOptions
@@ -186,6 +218,7 @@ func read(
 	ctx context.Context,
 	parsed ParsedOptions,
 	handlerOpts options.HandlerOptions,
+	parseQuery parseQueryFn,
 ) (ReadResult, error) {
 	var (
 		opts      = parsed.QueryOpts
@@ -209,14 +242,15 @@ func read(
 		BlockType: block.BlockEmpty,
 	}
 
-	// TODO: Capture timing
+	parseStart := time.Now()
 	parseOpts := engine.Options().ParseOptions()
-	parser, err := promql.Parse(params.Query, params.Step, tagOpts, parseOpts)
+	queryParser, err := parseQuery(params.Query, params.Step, tagOpts, parseOpts)
+	emptyResult.ParseDuration = time.Since(parseStart)
 	if err != nil {
 		return emptyResult, xerrors.NewInvalidParamsError(err)
 	}
 
-	bl, err := engine.ExecuteExpr(ctx, parser, opts, fetchOpts, params)
+	bl, err := engine.ExecuteExpr(ctx, queryParser, opts, fetchOpts, params)
 	if err != nil {
 		return emptyResult, err
 	}
@@ -273,9 +307,10 @@ func read(
 	blockType := bl.Info().Type()
 
 	return ReadResult{
-		Series:    seriesList,
-		Meta:      resultMeta,
-		BlockType: blockType,
+		Series:        seriesList,
+		Meta:          resultMeta,
+		BlockType:     blockType,
+		ParseDuration: emptyResult.ParseDuration,
 	}, nil
 }
 
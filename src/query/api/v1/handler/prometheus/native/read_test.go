@@ -33,9 +33,11 @@ import (
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/parser/promql"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/query/test"
+	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/instrument"
 	xtest "github.com/m3db/m3/src/x/test"
 
@@ -115,7 +117,7 @@ func TestPromReadHandlerWithTimeout(t *testing.T) {
 		Params:    r,
 	}
 
-	_, err := read(ctx, parsed, promRead.opts)
+	_, err := read(ctx, parsed, promRead.opts, promRead.parseQuery)
 	require.Error(t, err)
 	require.Equal(t,
 		"context deadline exceeded",
@@ -153,7 +155,7 @@ func testPromReadHandlerRead(t *testing.T, resultMeta block.ResultMetadata) {
 		Params:    r,
 	}
 
-	result, err := read(ctx, parsed, promRead.opts)
+	result, err := read(ctx, parsed, promRead.opts, promRead.parseQuery)
 	require.NoError(t, err)
 	seriesList := result.Series
 
@@ -166,6 +168,101 @@ func testPromReadHandlerRead(t *testing.T, resultMeta block.ResultMetadata) {
 	}
 }
 
+func TestM3QLReadHandlerRead(t *testing.T) {
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+
+	setup := newTestSetup(t, nil)
+	m3qlRead := setup.Handlers.m3qlRead
+
+	seriesMeta := test.NewSeriesMeta("dummy", len(values))
+	m := block.Metadata{
+		Bounds:         bounds,
+		Tags:           models.NewTags(0, models.NewTagOptions()),
+		ResultMetadata: block.NewResultMetadata(),
+	}
+
+	b := test.NewBlockFromValuesWithMetaAndSeriesMeta(m, seriesMeta, values)
+	setup.Storage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	params := defaultParams()
+	params.Set(QueryParam, "fetch name:dummy | scale 2")
+	req, _ := http.NewRequest("GET", M3QLReadURL, nil)
+	req.URL.RawQuery = params.Encode()
+
+	r, parseErr := testParseParams(req)
+	require.Nil(t, parseErr)
+	parsed := ParsedOptions{
+		QueryOpts: setup.QueryOpts,
+		FetchOpts: setup.FetchOpts,
+		Params:    r,
+	}
+
+	result, err := read(req.Context(), parsed, m3qlRead.opts, m3qlRead.parseQuery)
+	require.NoError(t, err)
+	require.Len(t, result.Series, 2)
+
+	s := result.Series[0]
+	assert.Equal(t, 5, s.Values().Len())
+	for i := 0; i < s.Values().Len(); i++ {
+		assert.Equal(t, 2*float64(i), s.Values().ValueAt(i))
+	}
+
+	// PromQL queries are not valid M3QL.
+	r.Query = promQuery
+	parsed.Params = r
+	_, err = read(req.Context(), parsed, m3qlRead.opts, m3qlRead.parseQuery)
+	require.Error(t, err)
+	assert.True(t, xerrors.IsInvalidParams(err))
+}
+
+func TestReadMeasuresQueryParse(t *testing.T) {
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+
+	setup := newTestSetup(t, nil)
+	promRead := setup.Handlers.read
+
+	seriesMeta := test.NewSeriesMeta("dummy", len(values))
+	m := block.Metadata{
+		Bounds:         bounds,
+		Tags:           models.NewTags(0, models.NewTagOptions()),
+		ResultMetadata: block.NewResultMetadata(),
+	}
+
+	b := test.NewBlockFromValuesWithMetaAndSeriesMeta(m, seriesMeta, values)
+	setup.Storage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	req, _ := http.NewRequest("GET", PromReadURL, nil)
+	req.URL.RawQuery = defaultParams().Encode()
+
+	r, parseErr := testParseParams(req)
+	require.Nil(t, parseErr)
+	parsed := ParsedOptions{
+		QueryOpts: setup.QueryOpts,
+		FetchOpts: setup.FetchOpts,
+		Params:    r,
+	}
+
+	slowParse := func(
+		query string,
+		step time.Duration,
+		tagOpts models.TagOptions,
+		parseOpts promql.ParseOptions,
+	) (parser.Parser, error) {
+		time.Sleep(10 * time.Millisecond)
+		return promRead.parseQuery(query, step, tagOpts, parseOpts)
+	}
+	result, err := read(req.Context(), parsed, promRead.opts, slowParse)
+	require.NoError(t, err)
+	assert.True(t, result.ParseDuration >= 10*time.Millisecond)
+
+	// The parse is measured even if the query fails to parse.
+	r.Query = "("
+	parsed.Params = r
+	result, err = read(req.Context(), parsed, promRead.opts, slowParse)
+	require.Error(t, err)
+	assert.True(t, result.ParseDuration >= 10*time.Millisecond)
+}
+
 type testSetup struct {
 	Storage   mock.Storage
 	Handlers  testSetupHandlers
@@ -177,6 +274,7 @@ type testSetup struct {
 type testSetupHandlers struct {
 	read        *promReadHandler
 	instantRead *promReadHandler
+	m3qlRead    *promReadHandler
 }
 
 func newTestSetup(
@@ -218,12 +316,14 @@ func newTestSetup(
 
 	read := NewPromReadHandler(opts).(*promReadHandler)
 	instantRead := NewPromReadInstantHandler(opts).(*promReadHandler)
+	m3qlRead := NewM3QLReadHandler(opts).(*promReadHandler)
 
 	return &testSetup{
 		Storage: mockStorage,
 		Handlers: testSetupHandlers{
 			read:        read,
 			instantRead: instantRead,
+			m3qlRead:    m3qlRead,
 		},
 		QueryOpts: &executor.QueryOptions{},
 		FetchOpts: storage.NewFetchOptions(),
@@ -0,0 +1,241 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromCardinalityURL is the url for the prometheus TSDB status handler.
+	PromCardinalityURL = route.Prefix + "/status/tsdb"
+
+	// PromCardinalityHTTPMethod is the HTTP method used with this resource.
+	PromCardinalityHTTPMethod = http.MethodGet
+
+	cardinalityNamespaceParam = "namespace"
+	// NB: the limit parameter used by the prometheus endpoint is already
+	// the series limit of the fetch options.
+	cardinalityTopNParam   = "topN"
+	defaultCardinalityTopN = 10
+
+	// cardinalityLowerBoundsWarning is returned with every response since
+	// the statistics cannot be computed exactly from the dbnode indexes.
+	cardinalityLowerBoundsWarning = "cardinality values are lower bounds: " +
+		"series are counted once per index block they are in and only by " +
+		"the hosts their shard is available on, and distinct label values " +
+		"once per host they are indexed on"
+)
+
+var (
+	errCardinalityNoClusters = errors.New(
+		"coordinator is not connected to dbnodes, cannot compute cardinality")
+	errCardinalityNoNamespace = errors.New(
+		"unaggregated namespace is not initialized")
+)
+
+// PromCardinalityHandler represents a handler for the prometheus TSDB status
+// endpoint, reporting the cardinality of the series of a cluster namespace
+// computed from the dbnode indexes. The values reported are lower bounds of
+// the cardinality, which is flagged with a warning in every response.
+type PromCardinalityHandler struct {
+	clusters            m3.Clusters
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	tagOptions          models.TagOptions
+	parseOpts           promql.ParseOptions
+	instrumentOpts      instrument.Options
+}
+
+type cardinalityResponse struct {
+	Status   string                 `json:"status"`
+	Data     index.CardinalityStats `json:"data"`
+	Warnings []string               `json:"warnings"`
+}
+
+// NewPromCardinalityHandler returns a new instance of handler.
+func NewPromCardinalityHandler(opts options.HandlerOptions) http.Handler {
+	return &PromCardinalityHandler{
+		clusters:            opts.Clusters(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		tagOptions:          opts.TagOptions(),
+		parseOpts:           promql.NewParseOptions().SetNowFn(opts.NowFn()),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromCardinalityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	ctx, fetchOpts, rErr := h.fetchOptionsBuilder.NewFetchOptions(r.Context(), r)
+	if rErr != nil {
+		xhttp.WriteError(w, rErr)
+		return
+	}
+
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+
+	if h.clusters == nil {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(errCardinalityNoClusters))
+		return
+	}
+
+	ns, err := h.clusterNamespace(r)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	session, ok := ns.Session().(client.AdminSession)
+	if !ok {
+		err := fmt.Errorf("session for namespace %s does not support cardinality",
+			ns.NamespaceID().String())
+		logger.Error("unable to compute cardinality", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	query, opts, err := h.parseRequest(r, fetchOpts)
+	if err != nil {
+		logger.Error("unable to parse cardinality request", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	result, err := session.Cardinality(ns.NamespaceID(), query, opts)
+	if err != nil {
+		logger.Error("unable to compute cardinality",
+			zap.String("namespace", ns.NamespaceID().String()),
+			zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	meta := block.NewResultMetadata()
+	meta.Exhaustive = result.Exhaustive
+	if err := handleroptions.AddDBResultResponseHeaders(w, meta, fetchOpts); err != nil {
+		logger.Error("error writing database limit headers", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, cardinalityResponse{
+		Status:   "success",
+		Data:     result.Stats,
+		Warnings: []string{cardinalityLowerBoundsWarning},
+	}, logger)
+}
+
+// clusterNamespace returns the namespace named by the request, defaulting
+// to the unaggregated namespace.
+func (h *PromCardinalityHandler) clusterNamespace(r *http.Request) (m3.ClusterNamespace, error) {
+	name := r.FormValue(cardinalityNamespaceParam)
+	if name == "" {
+		ns, ok := h.clusters.UnaggregatedClusterNamespace()
+		if !ok {
+			return nil, xerrors.NewInvalidParamsError(errCardinalityNoNamespace)
+		}
+		return ns, nil
+	}
+
+	for _, ns := range h.clusters.ClusterNamespaces() {
+		if ns.NamespaceID().String() == name {
+			return ns, nil
+		}
+	}
+	return nil, xerrors.NewInvalidParamsError(
+		fmt.Errorf("unknown namespace: %s", name))
+}
+
+func (h *PromCardinalityHandler) parseRequest(
+	r *http.Request,
+	fetchOpts *storage.FetchOptions,
+) (index.Query, index.CardinalityOptions, error) {
+	start, end, err := prometheus.ParseStartAndEnd(r, h.parseOpts)
+	if err != nil {
+		return index.Query{}, index.CardinalityOptions{}, err
+	}
+
+	topN := defaultCardinalityTopN
+	if str := r.FormValue(cardinalityTopNParam); str != "" {
+		topN, err = strconv.Atoi(str)
+		if err != nil || topN < 1 {
+			return index.Query{}, index.CardinalityOptions{}, xerrors.NewInvalidParamsError(
+				fmt.Errorf("invalid %s: %s", cardinalityTopNParam, str))
+		}
+	}
+
+	fetchQuery := &storage.FetchQuery{
+		Start: start,
+		End:   end,
+	}
+	matchers, ok, err := prometheus.ParseMatch(r, h.parseOpts, h.tagOptions)
+	if err != nil {
+		return index.Query{}, index.CardinalityOptions{}, xerrors.NewInvalidParamsError(err)
+	}
+	if ok {
+		if n := len(matchers); n != 1 {
+			return index.Query{}, index.CardinalityOptions{}, xerrors.NewInvalidParamsError(
+				fmt.Errorf("only single series selector allowed: actual=%d", n))
+		}
+		fetchQuery.Raw = fmt.Sprintf("match[]=%s", matchers[0].Match)
+		fetchQuery.TagMatchers = matchers[0].Matchers
+	}
+
+	query, err := storage.FetchQueryToM3Query(fetchQuery, fetchOpts)
+	if err != nil {
+		return index.Query{}, index.CardinalityOptions{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	return query, index.CardinalityOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive:    xtime.ToUnixNano(start),
+			EndExclusive:      xtime.ToUnixNano(end),
+			SeriesLimit:       fetchOpts.SeriesLimit,
+			DocsLimit:         fetchOpts.DocsLimit,
+			RequireExhaustive: fetchOpts.RequireExhaustive,
+			Source:            fetchOpts.Source,
+		},
+		TopN:          topN,
+		MetricNameTag: h.tagOptions.MetricName(),
+	}, nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/x/headers"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCardinalityHandler(t *testing.T, clusters m3.Clusters, now time.Time) http.Handler {
+	fetchOptsBuilder, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{
+			Limits: handleroptions.FetchOptionsBuilderLimitsOptions{
+				SeriesLimit: 100,
+			},
+			Timeout: 15 * time.Second,
+		})
+	require.NoError(t, err)
+
+	opts := options.EmptyHandlerOptions().
+		SetClusters(clusters).
+		SetFetchOptionsBuilder(fetchOptsBuilder).
+		SetTagOptions(models.NewTagOptions()).
+		SetNowFn(func() time.Time { return now })
+	return NewPromCardinalityHandler(opts)
+}
+
+func TestPromCardinalityHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		now     = time.Unix(1000, 0)
+		start   = time.Unix(100, 0)
+		session = client.NewMockAdminSession(ctrl)
+		ns      = m3.NewMockClusterNamespace(ctrl)
+	)
+	ns.EXPECT().NamespaceID().Return(ident.StringID("default")).AnyTimes()
+	ns.EXPECT().Session().Return(session)
+
+	clusters := m3.NewMockClusters(ctrl)
+	clusters.EXPECT().UnaggregatedClusterNamespace().Return(ns, true)
+
+	session.EXPECT().
+		Cardinality(ident.NewIDMatcher("default"), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ ident.ID,
+			q index.Query,
+			opts index.CardinalityOptions,
+		) (index.CardinalityQueryResult, error) {
+			assert.Equal(t, `conjunction(term(__name__,up), term(job,foo))`, q.String())
+			assert.Equal(t, xtime.ToUnixNano(start), opts.StartInclusive)
+			assert.Equal(t, xtime.ToUnixNano(now), opts.EndExclusive)
+			assert.Equal(t, 100, opts.SeriesLimit)
+			assert.Equal(t, 5, opts.TopN)
+			assert.Equal(t, []byte("__name__"), opts.MetricNameTag)
+			return index.CardinalityQueryResult{
+				Stats: index.CardinalityStats{
+					SeriesCountByMetricName: []index.CardinalityEntry{
+						{Name: "up", Value: 2},
+					},
+					LabelValueCountByLabelName: []index.CardinalityEntry{
+						{Name: "instance", Value: 2},
+					},
+					SeriesCountByLabelValuePair: []index.CardinalityEntry{
+						{Name: "job=foo", Value: 2},
+					},
+				},
+				Exhaustive: false,
+			}, nil
+		})
+
+	handler := newCardinalityHandler(t, clusters, now)
+
+	req := httptest.NewRequest(http.MethodGet,
+		PromCardinalityURL+`?match[]=up{job="foo"}&start=100&topN=5`, nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	assert.Equal(t, headers.LimitHeaderSeriesLimitApplied,
+		recorder.Header().Get(headers.LimitHeader))
+	assert.JSONEq(t, `{
+		"status": "success",
+		"data": {
+			"seriesCountByMetricName": [{"name": "up", "value": 2}],
+			"labelValueCountByLabelName": [{"name": "instance", "value": 2}],
+			"seriesCountByLabelValuePair": [{"name": "job=foo", "value": 2}]
+		},
+		"warnings": [
+			"cardinality values are lower bounds: series are counted once per index block they are in and only by the hosts their shard is available on, and distinct label values once per host they are indexed on"
+		]
+	}`, recorder.Body.String())
+}
+
+func TestPromCardinalityHandlerUnknownNamespace(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	ns := m3.NewMockClusterNamespace(ctrl)
+	ns.EXPECT().NamespaceID().Return(ident.StringID("default")).AnyTimes()
+
+	clusters := m3.NewMockClusters(ctrl)
+	clusters.EXPECT().ClusterNamespaces().Return(m3.ClusterNamespaces{ns})
+
+	handler := newCardinalityHandler(t, clusters, time.Unix(1000, 0))
+
+	req := httptest.NewRequest(http.MethodGet,
+		PromCardinalityURL+"?namespace=unknown", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "unknown namespace")
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromDeleteSeriesURL is the url for the prometheus delete series handler.
+	PromDeleteSeriesURL = route.Prefix + "/admin/tsdb/delete_series"
+)
+
+var (
+	// PromDeleteSeriesHTTPMethods are the HTTP methods for this handler.
+	PromDeleteSeriesHTTPMethods = []string{http.MethodPost, http.MethodPut}
+
+	errDeleteSeriesNoClusters = errors.New(
+		"coordinator is not connected to dbnodes, cannot delete series")
+	errDeleteSeriesNoMatchers = errors.New(
+		"no match[] parameter provided, at least one series selector is required")
+)
+
+// PromDeleteSeriesHandler represents a handler for the prometheus delete
+// series endpoint, deleting data of the matched series from all cluster
+// namespaces.
+type PromDeleteSeriesHandler struct {
+	clusters       m3.Clusters
+	tagOptions     models.TagOptions
+	parseOpts      promql.ParseOptions
+	instrumentOpts instrument.Options
+}
+
+// NewPromDeleteSeriesHandler returns a new instance of handler.
+func NewPromDeleteSeriesHandler(opts options.HandlerOptions) http.Handler {
+	return &PromDeleteSeriesHandler{
+		clusters:       opts.Clusters(),
+		tagOptions:     opts.TagOptions(),
+		parseOpts:      promql.NewParseOptions().SetNowFn(opts.NowFn()),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromDeleteSeriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+
+	if h.clusters == nil {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(errDeleteSeriesNoClusters))
+		return
+	}
+
+	queries, err := h.parseRequest(r)
+	if err != nil {
+		logger.Error("unable to parse delete series request", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	var numSeries int64
+	for _, ns := range h.clusters.ClusterNamespaces() {
+		session, ok := ns.Session().(client.AdminSession)
+		if !ok {
+			err := fmt.Errorf("session for namespace %s does not support deletes",
+				ns.NamespaceID().String())
+			logger.Error("unable to delete series", zap.Error(err))
+			xhttp.WriteError(w, err)
+			return
+		}
+
+		for _, query := range queries {
+			m3Query, err := storage.FetchQueryToM3Query(query, nil)
+			if err != nil {
+				xhttp.WriteError(w, xerrors.NewInvalidParamsError(err))
+				return
+			}
+
+			n, err := session.DeleteTagged(ns.NamespaceID(), m3Query,
+				xtime.ToUnixNano(query.Start), xtime.ToUnixNano(query.End))
+			if err != nil {
+				logger.Error("unable to delete series",
+					zap.String("namespace", ns.NamespaceID().String()),
+					zap.String("match", query.Raw),
+					zap.Error(err))
+				xhttp.WriteError(w, err)
+				return
+			}
+			numSeries += n
+		}
+	}
+
+	logger.Info("deleted series",
+		zap.Int("selectors", len(queries)),
+		zap.Int64("numSeries", numSeries))
+
+	// Match the prometheus API which responds with no content on success.
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PromDeleteSeriesHandler) parseRequest(r *http.Request) ([]*storage.FetchQuery, error) {
+	matchers, ok, err := prometheus.ParseMatch(r, h.parseOpts, h.tagOptions)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, xerrors.NewInvalidParamsError(errDeleteSeriesNoMatchers)
+	}
+
+	start, end, err := prometheus.ParseStartAndEnd(r, h.parseOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]*storage.FetchQuery, 0, len(matchers))
+	for _, m := range matchers {
+		queries = append(queries, &storage.FetchQuery{
+			Raw:         fmt.Sprintf("match[]=%s", m.Match),
+			TagMatchers: m.Matchers,
+			Start:       start,
+			End:         end,
+		})
+	}
+	return queries, nil
+}
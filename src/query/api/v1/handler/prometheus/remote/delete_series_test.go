@@ -0,0 +1,102 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeleteSeriesRequest(form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, PromDeleteSeriesURL,
+		strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestPromDeleteSeriesHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		now     = time.Unix(1000, 0)
+		start   = time.Unix(100, 0)
+		session = client.NewMockAdminSession(ctrl)
+		ns      = m3.NewMockClusterNamespace(ctrl)
+	)
+	ns.EXPECT().NamespaceID().Return(ident.StringID("default")).AnyTimes()
+	ns.EXPECT().Session().Return(session)
+
+	clusters := m3.NewMockClusters(ctrl)
+	clusters.EXPECT().ClusterNamespaces().Return(m3.ClusterNamespaces{ns})
+
+	session.EXPECT().
+		DeleteTagged(ident.NewIDMatcher("default"), gomock.Any(),
+			xtime.ToUnixNano(start), xtime.ToUnixNano(now)).
+		Return(int64(2), nil).
+		Times(2)
+
+	opts := options.EmptyHandlerOptions().
+		SetClusters(clusters).
+		SetTagOptions(models.NewTagOptions()).
+		SetNowFn(func() time.Time { return now })
+	handler := NewPromDeleteSeriesHandler(opts)
+
+	form := url.Values{}
+	form.Add("match[]", `up{job="foo"}`)
+	form.Add("match[]", `up{job="bar"}`)
+	form.Set("start", "100")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newDeleteSeriesRequest(form))
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+func TestPromDeleteSeriesHandlerNoMatchers(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	opts := options.EmptyHandlerOptions().
+		SetClusters(m3.NewMockClusters(ctrl)).
+		SetTagOptions(models.NewTagOptions())
+	handler := NewPromDeleteSeriesHandler(opts)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newDeleteSeriesRequest(url.Values{}))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "match[]")
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"net/http"
+
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+// IndexExplainURL is the url for the index query explain handler.
+const IndexExplainURL = route.Prefix + "/debug/index/explain"
+
+// IndexExplainHTTPMethods are the HTTP methods for this handler.
+var IndexExplainHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+// IndexExplainHandler represents a handler for the index query explain
+// endpoint, which runs the index query of series matchers and returns the
+// trace of the index query performed by each host.
+type IndexExplainHandler struct {
+	storage             storage.Storage
+	tagOptions          models.TagOptions
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	instrumentOpts      instrument.Options
+	parseOpts           promql.ParseOptions
+}
+
+type indexExplainResponse struct {
+	Status string               `json:"status"`
+	Data   []index.QueryExplain `json:"data"`
+}
+
+// NewIndexExplainHandler returns a new instance of handler.
+func NewIndexExplainHandler(opts options.HandlerOptions) http.Handler {
+	return &IndexExplainHandler{
+		storage:             opts.Storage(),
+		tagOptions:          opts.TagOptions(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		instrumentOpts:      opts.InstrumentOpts(),
+		parseOpts:           promql.NewParseOptions().SetNowFn(opts.NowFn()),
+	}
+}
+
+func (h *IndexExplainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	ctx, opts, rErr := h.fetchOptionsBuilder.NewFetchOptions(r.Context(), r)
+	if rErr != nil {
+		xhttp.WriteError(w, rErr)
+		return
+	}
+
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+
+	queries, err := prometheus.ParseSeriesMatchQuery(r, h.parseOpts, h.tagOptions)
+	if err != nil {
+		logger.Error("unable to parse series match values to query", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	opts.IndexExplain = true
+	meta := block.NewResultMetadata()
+	for _, query := range queries {
+		result, err := h.storage.SearchSeries(ctx, query, opts)
+		if err != nil {
+			logger.Error("unable to explain index query", zap.Error(err))
+			xhttp.WriteError(w, err)
+			return
+		}
+
+		meta = meta.CombineMetadata(result.Metadata)
+	}
+
+	if err := handleroptions.AddDBResultResponseHeaders(w, meta, opts); err != nil {
+		logger.Error("error writing database limit headers", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, indexExplainResponse{
+		Status: "success",
+		Data:   meta.IndexExplain,
+	}, logger)
+}
@@ -27,6 +27,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -46,6 +47,7 @@ import (
 	"github.com/m3db/m3/src/query/util"
 	"github.com/m3db/m3/src/query/util/logging"
 	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/headers"
 	xhttp "github.com/m3db/m3/src/x/net/http"
 	xtime "github.com/m3db/m3/src/x/time"
 
@@ -423,6 +425,15 @@ func parseRequest(
 		return nil, nil, nil, rErr
 	}
 
+	if str := r.Header.Get(headers.NativeHistogramsHeader); str != "" {
+		nativeHistograms, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, nil, nil, xerrors.NewInvalidParamsError(fmt.Errorf(
+				"could not parse native histograms: input=%s, err=%w", str, err))
+		}
+		fetchOpts.NativeHistograms = nativeHistograms
+	}
+
 	return ctx, req, fetchOpts, nil
 }
 
@@ -46,6 +46,8 @@ import (
 	"github.com/m3db/m3/src/query/test"
 	"github.com/m3db/m3/src/query/test/m3"
 	xclock "github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/headers"
 	"github.com/m3db/m3/src/x/instrument"
 	xhttp "github.com/m3db/m3/src/x/net/http"
 	xtest "github.com/m3db/m3/src/x/test"
@@ -189,6 +191,31 @@ func TestPromReadParsing(t *testing.T) {
 	fmt.Println(fetchOpts)
 }
 
+func TestPromReadParsingNativeHistograms(t *testing.T) {
+	fetchOptsBuilder, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{Timeout: 15 * time.Second})
+	require.NoError(t, err)
+	opts := options.EmptyHandlerOptions().
+		SetFetchOptionsBuilder(fetchOptsBuilder)
+
+	req := httptest.NewRequest("POST", PromReadURL, test.GeneratePromReadBody(t))
+	_, _, fetchOpts, err := ParseRequest(context.Background(), req, opts)
+	require.NoError(t, err)
+	assert.False(t, fetchOpts.NativeHistograms)
+
+	req = httptest.NewRequest("POST", PromReadURL, test.GeneratePromReadBody(t))
+	req.Header.Set(headers.NativeHistogramsHeader, "true")
+	_, _, fetchOpts, err = ParseRequest(context.Background(), req, opts)
+	require.NoError(t, err)
+	assert.True(t, fetchOpts.NativeHistograms)
+
+	req = httptest.NewRequest("POST", PromReadURL, test.GeneratePromReadBody(t))
+	req.Header.Set(headers.NativeHistogramsHeader, "foo")
+	_, _, _, err = ParseRequest(context.Background(), req, opts)
+	require.Error(t, err)
+	assert.True(t, xerrors.IsInvalidParams(err))
+}
+
 func TestPromReadParsingBad(t *testing.T) {
 	req := httptest.NewRequest("POST", PromReadURL, strings.NewReader("bad body"))
 	_, _, _, err := ParseRequest(context.Background(), req, options.EmptyHandlerOptions())
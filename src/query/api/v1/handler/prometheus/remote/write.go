@@ -377,6 +377,10 @@ func (h *PromWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			age := now.Sub(storage.PromTimestampToTime(sample.Timestamp))
 			h.metrics.ingestLatency.RecordDuration(age)
 		}
+		for _, hist := range series.Histograms {
+			age := now.Sub(storage.PromTimestampToTime(hist.Timestamp))
+			h.metrics.ingestLatency.RecordDuration(age)
+		}
 	}
 
 	if batchErr != nil {
@@ -573,9 +577,18 @@ func (h *PromWriteHandler) parseRequest(
 					j++
 				}
 			}
-			h.metrics.writeRejectTooOld.Inc(int64(len(ts.Samples) - j))
-			if j > 0 {
+			k := 0
+			for _, hist := range ts.Histograms {
+				t = hist.Timestamp
+				if time.UnixMilli(hist.Timestamp).After(thresholdTime) {
+					ts.Histograms[k] = hist
+					k++
+				}
+			}
+			h.metrics.writeRejectTooOld.Inc(int64(len(ts.Samples) - j + len(ts.Histograms) - k))
+			if j > 0 || k > 0 {
 				ts.Samples = ts.Samples[:j]
+				ts.Histograms = ts.Histograms[:k]
 				req.Timeseries[i] = ts
 				i++
 			} else if rand.Float32() < h.remoteWriteOpts.errorSamplingRate {
@@ -709,6 +722,7 @@ func newPromTSIter(
 		tags             = make([]models.Tags, 0, len(timeseries))
 		datapoints       = make([]ts.Datapoints, 0, len(timeseries))
 		seriesAttributes = make([]ts.SeriesAttributes, 0, len(timeseries))
+		histograms       [][]byte
 	)
 
 	graphiteTagOpts := tagOpts.SetIDSchemeType(models.TypeGraphite)
@@ -724,9 +738,31 @@ func newPromTSIter(
 			opts = graphiteTagOpts
 		}
 
-		seriesAttributes = append(seriesAttributes, attributes)
-		tags = append(tags, storage.PromLabelsToM3Tags(promTS.Labels, opts))
-		datapoints = append(datapoints, storage.PromSamplesToM3Datapoints(promTS.Samples))
+		seriesTags := storage.PromLabelsToM3Tags(promTS.Labels, opts)
+		if len(promTS.Samples) > 0 || len(promTS.Histograms) == 0 {
+			seriesAttributes = append(seriesAttributes, attributes)
+			tags = append(tags, seriesTags)
+			datapoints = append(datapoints, storage.PromSamplesToM3Datapoints(promTS.Samples))
+			if histograms != nil {
+				histograms = append(histograms, nil)
+			}
+		}
+
+		// NB: every native histogram sample carries its own annotation so
+		// each one is emitted as a separate single datapoint value.
+		for _, h := range promTS.Histograms {
+			dp, marshaled, err := storage.PromHistogramToM3(h)
+			if err != nil {
+				return nil, xerrors.NewInvalidParamsError(err)
+			}
+			if histograms == nil {
+				histograms = make([][]byte, len(tags), len(tags)+len(promTS.Histograms))
+			}
+			seriesAttributes = append(seriesAttributes, attributes)
+			tags = append(tags, seriesTags)
+			datapoints = append(datapoints, ts.Datapoints{dp})
+			histograms = append(histograms, marshaled)
+		}
 	}
 
 	return &promTSIter{
@@ -734,6 +770,7 @@ func newPromTSIter(
 		idx:              -1,
 		tags:             tags,
 		datapoints:       datapoints,
+		histograms:       histograms,
 		storeMetricsType: storeMetricsType,
 	}, nil
 }
@@ -745,11 +782,16 @@ type promTSIter struct {
 	tags       []models.Tags
 	datapoints []ts.Datapoints
 	metadatas  []ts.Metadata
+	histograms [][]byte
 	annotation []byte
 
 	storeMetricsType bool
 }
 
+func (i *promTSIter) isHistogram(idx int) bool {
+	return idx < len(i.histograms) && i.histograms[idx] != nil
+}
+
 func (i *promTSIter) Next() bool {
 	if i.err != nil {
 		return false
@@ -760,7 +802,13 @@ func (i *promTSIter) Next() bool {
 		return false
 	}
 
+	if i.isHistogram(i.idx) {
+		i.annotation = i.histograms[i.idx]
+		return true
+	}
+
 	if !i.storeMetricsType {
+		i.annotation = nil
 		return true
 	}
 
@@ -35,6 +35,7 @@ import (
 
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
@@ -452,6 +453,97 @@ func TestPromWriteDisabledMetricsTypes(t *testing.T) {
 	require.NoError(t, capturedIter.Error())
 }
 
+func TestPromWriteNativeHistograms(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var capturedIter ingest.DownsampleAndWriteIter
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, iter ingest.DownsampleAndWriteIter, _ ingest.WriteOptions) ingest.BatchError {
+			capturedIter = iter
+			return nil
+		})
+
+	opts := makeOptions(mockDownsamplerAndWriter).SetStoreMetricsType(false)
+
+	promHistogram := func(count uint64, timestamp int64) prompb.Histogram {
+		return prompb.Histogram{
+			Count:          &prompb.Histogram_CountInt{CountInt: count},
+			ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 0},
+			PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 1}},
+			PositiveDeltas: []int64{int64(count)},
+			Timestamp:      timestamp,
+		}
+	}
+	promReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: []byte("__name__"), Value: []byte("foo")}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{{Name: []byte("__name__"), Value: []byte("bar")}},
+				Histograms: []prompb.Histogram{
+					promHistogram(2, 1000),
+					promHistogram(3, 2000),
+				},
+			},
+		},
+	}
+
+	executeWriteRequest(t, opts, promReq)
+
+	verifyIterValueNoAnnotation(t, capturedIter)
+	for _, count := range []uint64{2, 3} {
+		require.True(t, capturedIter.Next())
+		value := capturedIter.Current()
+		name, ok := value.Tags.Name()
+		require.True(t, ok)
+		assert.Equal(t, "bar", string(name))
+		require.Equal(t, 1, len(value.Datapoints))
+		assert.Equal(t, float64(count), value.Datapoints[0].Value)
+		require.True(t, histogram.IsHistogram(value.Annotation))
+
+		var h histogram.Histogram
+		require.NoError(t, h.Unmarshal(value.Annotation))
+		assert.Equal(t, count, h.Count)
+		assert.Equal(t, []uint64{count}, h.PositiveBuckets)
+	}
+
+	require.False(t, capturedIter.Next())
+	require.NoError(t, capturedIter.Error())
+}
+
+func TestPromWriteFloatHistogramsRejected(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	opts := makeOptions(ingest.NewMockDownsamplerAndWriter(ctrl))
+	handler, err := NewPromWriteHandler(opts)
+	require.NoError(t, err)
+
+	promReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{{Name: []byte("__name__"), Value: []byte("bar")}},
+				Histograms: []prompb.Histogram{
+					{Count: &prompb.Histogram_CountFloat{CountFloat: 1}},
+				},
+			},
+		},
+	}
+	promReqBody := test.GeneratePromWriteRequestBody(t, promReq)
+	req := httptest.NewRequest(PromWriteHTTPMethod, PromWriteURL, promReqBody)
+
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, req)
+	resp := writer.Result()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
 func TestPromWriteLiteralIsTooLongError(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
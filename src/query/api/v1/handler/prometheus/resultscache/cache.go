@@ -0,0 +1,79 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"context"
+	"time"
+
+	xcache "github.com/m3db/m3/src/x/cache"
+
+	"github.com/uber-go/tally"
+)
+
+// Cache stores the encoded extents of range query results. Implementations
+// backed by a shared store allow coordinators to share cached results.
+type Cache interface {
+	// Fetch returns the value stored for a key and whether it was found.
+	Fetch(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Store stores the value of a key.
+	Store(ctx context.Context, key string, value []byte) error
+}
+
+// InMemoryOptions are the options of an in-memory cache.
+type InMemoryOptions struct {
+	// MaxEntries is the maximum number of entries held, least recently used
+	// entries are evicted first.
+	MaxEntries int
+	// TTL is how long an entry is held for.
+	TTL time.Duration
+	// Metrics is the scope to emit cache metrics to.
+	Metrics tally.Scope
+}
+
+type inMemoryCache struct {
+	lru *xcache.LRU
+}
+
+// NewInMemoryCache returns a cache held in the memory of the process.
+func NewInMemoryCache(opts InMemoryOptions) Cache {
+	return &inMemoryCache{
+		lru: xcache.NewLRU(&xcache.LRUOptions{
+			MaxEntries: opts.MaxEntries,
+			TTL:        opts.TTL,
+			Metrics:    opts.Metrics,
+		}),
+	}
+}
+
+func (c *inMemoryCache) Fetch(_ context.Context, key string) ([]byte, bool, error) {
+	value, ok := c.lru.TryGet(key)
+	if !ok {
+		return nil, false, nil
+	}
+	return value.([]byte), true, nil
+}
+
+func (c *inMemoryCache) Store(_ context.Context, key string, value []byte) error {
+	c.lru.Put(key, value)
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Configuration configures caching the results of range queries.
+type Configuration struct {
+	// Enabled enables caching the results of range queries.
+	Enabled bool `yaml:"enabled"`
+
+	// SplitInterval is the interval range queries are split by, the result
+	// of each split is cached separately. Defaults to a day.
+	SplitInterval *time.Duration `yaml:"splitInterval"`
+
+	// MaxFreshness is how far from now results are not cached since data
+	// may still arrive for recent timestamps. Defaults to ten minutes.
+	MaxFreshness *time.Duration `yaml:"maxFreshness"`
+
+	// MaxConcurrentSplits is the max number of splits that are executed at
+	// once across all range queries of an engine. Defaults to sixteen.
+	MaxConcurrentSplits *int `yaml:"maxConcurrentSplits"`
+
+	// InMemory configures the in-memory cache of results.
+	InMemory InMemoryConfiguration `yaml:"inMemory"`
+}
+
+// InMemoryConfiguration configures an in-memory cache of results.
+type InMemoryConfiguration struct {
+	// MaxEntries is the maximum number of cached splits.
+	MaxEntries int `yaml:"maxEntries"`
+
+	// TTL is how long a cached split is held for.
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// NewOptions returns the options of the configured results cache, using
+// the given cache if set rather than an in-memory cache.
+func (c Configuration) NewOptions(cache Cache, iOpts instrument.Options) Options {
+	if cache == nil {
+		cache = NewInMemoryCache(InMemoryOptions{
+			MaxEntries: c.InMemory.MaxEntries,
+			TTL:        c.InMemory.TTL,
+			Metrics:    iOpts.MetricsScope().SubScope("results-cache"),
+		})
+	}
+	opts := NewOptions(cache, iOpts)
+	if c.SplitInterval != nil {
+		opts.SplitInterval = *c.SplitInterval
+	}
+	if c.MaxFreshness != nil {
+		opts.MaxFreshness = *c.MaxFreshness
+	}
+	if c.MaxConcurrentSplits != nil {
+		opts.MaxConcurrentSplits = *c.MaxConcurrentSplits
+	}
+	return opts
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	statusSuccess = "success"
+	matrixType    = "matrix"
+)
+
+var errNotMatrixResult = errors.New("response is not a successful matrix result")
+
+// promResponse is the subset of a Prometheus range query response that can
+// be cached.
+type promResponse struct {
+	Status   string   `json:"status"`
+	Data     promData `json:"data"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type promData struct {
+	ResultType string   `json:"resultType"`
+	Result     []series `json:"result"`
+}
+
+type series struct {
+	Metric map[string]string `json:"metric"`
+	Values []sample          `json:"values"`
+}
+
+// sample is a value at a timestamp in milliseconds, the value is kept as
+// rendered so that it is returned exactly as computed.
+type sample struct {
+	T int64
+	V string
+}
+
+func (s sample) timestamp() string {
+	return strconv.FormatFloat(float64(s.T)/1000, 'f', -1, 64)
+}
+
+func (s sample) MarshalJSON() ([]byte, error) {
+	v, err := json.Marshal(s.V)
+	if err != nil {
+		return nil, err
+	}
+	return []byte("[" + s.timestamp() + "," + string(v) + "]"), nil
+}
+
+func (s *sample) UnmarshalJSON(b []byte) error {
+	var values [2]json.RawMessage
+	if err := json.Unmarshal(b, &values); err != nil {
+		return err
+	}
+	ts, err := strconv.ParseFloat(string(values[0]), 64)
+	if err != nil {
+		return fmt.Errorf("invalid sample timestamp: %w", err)
+	}
+	s.T = int64(math.Round(ts * 1000))
+	return json.Unmarshal(values[1], &s.V)
+}
+
+// extent is the result of a query for the evaluation timestamps in
+// [Start, End] in milliseconds.
+type extent struct {
+	Start  int64    `json:"start"`
+	End    int64    `json:"end"`
+	Series []series `json:"series"`
+}
+
+func parseMatrixResponse(b []byte) (promResponse, error) {
+	var resp promResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return promResponse{}, err
+	}
+	if resp.Status != statusSuccess || resp.Data.ResultType != matrixType {
+		return promResponse{}, errNotMatrixResult
+	}
+	return resp, nil
+}
+
+// between returns the series with only the samples in [start, end].
+func between(in []series, start, end int64) []series {
+	out := make([]series, 0, len(in))
+	for _, s := range in {
+		lo := sort.Search(len(s.Values), func(i int) bool {
+			return s.Values[i].T >= start
+		})
+		hi := sort.Search(len(s.Values), func(i int) bool {
+			return s.Values[i].T > end
+		})
+		if lo == hi {
+			continue
+		}
+		out = append(out, series{Metric: s.Metric, Values: s.Values[lo:hi]})
+	}
+	return out
+}
+
+// mergeSeries merges series of consecutive time ranges in order, series with
+// the same labels are concatenated and the result is sorted by labels.
+func mergeSeries(ranges ...[]series) []series {
+	var (
+		byID = make(map[string]int)
+		out  = make([]series, 0)
+		ids  []string
+	)
+	for _, r := range ranges {
+		for _, s := range r {
+			id := seriesID(s.Metric)
+			idx, ok := byID[id]
+			if !ok {
+				byID[id] = len(out)
+				out = append(out, series{Metric: s.Metric})
+				ids = append(ids, id)
+				idx = len(out) - 1
+			}
+			out[idx].Values = append(out[idx].Values, s.Values...)
+		}
+	}
+	sort.Sort(seriesByID{series: out, ids: ids})
+	return out
+}
+
+func seriesID(metric map[string]string) string {
+	names := make([]string, 0, len(metric))
+	for name := range metric {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(0xff)
+		b.WriteString(metric[name])
+		b.WriteByte(0xff)
+	}
+	return b.String()
+}
+
+type seriesByID struct {
+	series []series
+	ids    []string
+}
+
+func (s seriesByID) Len() int           { return len(s.series) }
+func (s seriesByID) Less(i, j int) bool { return s.ids[i] < s.ids[j] }
+func (s seriesByID) Swap(i, j int) {
+	s.series[i], s.series[j] = s.series[j], s.series[i]
+	s.ids[i], s.ids[j] = s.ids[j], s.ids[i]
+}
@@ -0,0 +1,601 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package resultscache serves range queries from cached results, splitting
+// queries by interval and only executing the parts that are not cached.
+package resultscache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/headers"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xsync "github.com/m3db/m3/src/x/sync"
+
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	queryParam   = "query"
+	startParam   = "start"
+	endParam     = "end"
+	timeoutParam = handleroptions.TimeoutParam
+
+	defaultSplitInterval       = 24 * time.Hour
+	defaultMaxFreshness        = 10 * time.Minute
+	defaultMaxConcurrentSplits = 16
+)
+
+var (
+	errNoCache                    = errors.New("no results cache set")
+	errInvalidSplitInterval       = errors.New("results cache split interval must be positive")
+	errInvalidMaxFreshness        = errors.New("results cache max freshness must not be negative")
+	errInvalidMaxConcurrentSplits = errors.New("results cache max concurrent splits must be positive")
+
+	// keyHeaders are the request headers that change the results of a query,
+	// such as by selecting the namespaces or engine used to execute it or by
+	// rewriting or limiting the series returned, and so are part of the
+	// cache key.
+	keyHeaders = []string{
+		headers.EngineHeaderName,
+		headers.MetricsTypeHeader,
+		headers.MetricsStoragePolicyHeader,
+		headers.MetricsRestrictByStoragePoliciesHeader,
+		headers.RestrictByTagsJSONHeader,
+		headers.MapTagsByJSONHeader,
+		headers.RelatedQueriesHeader,
+		headers.ReadConsistencyLevelHeader,
+		headers.IterateEqualTimestampStrategyHeader,
+		headers.LimitMaxSeriesHeader,
+		headers.LimitInstanceMultipleHeader,
+		headers.LimitMaxDocsHeader,
+		headers.LimitMaxReturnedSeriesHeader,
+		headers.LimitMaxReturnedDatapointsHeader,
+		headers.LimitRequireExhaustiveHeader,
+	}
+)
+
+// Options are the options of a results cache handler.
+type Options struct {
+	// Cache stores the results.
+	Cache Cache
+	// SplitInterval is the interval range queries are split by, the result
+	// of each split is cached separately.
+	SplitInterval time.Duration
+	// MaxFreshness is how far from now results are not cached since data
+	// may still arrive for recent timestamps.
+	MaxFreshness time.Duration
+	// MaxConcurrentSplits is the max number of splits that are executed at
+	// once by a handler across all of the queries it serves.
+	MaxConcurrentSplits int
+	// NowFn is the function that returns the current time.
+	NowFn clock.NowFn
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+// NewOptions returns options with the default split interval and freshness
+// for a cache.
+func NewOptions(cache Cache, iOpts instrument.Options) Options {
+	return Options{
+		Cache:               cache,
+		SplitInterval:       defaultSplitInterval,
+		MaxFreshness:        defaultMaxFreshness,
+		MaxConcurrentSplits: defaultMaxConcurrentSplits,
+		NowFn:               time.Now,
+		InstrumentOptions:   iOpts,
+	}
+}
+
+// Validate validates the options.
+func (o Options) Validate() error {
+	if o.Cache == nil {
+		return errNoCache
+	}
+	if o.SplitInterval <= 0 {
+		return errInvalidSplitInterval
+	}
+	if o.MaxFreshness < 0 {
+		return errInvalidMaxFreshness
+	}
+	if o.MaxConcurrentSplits <= 0 {
+		return errInvalidMaxConcurrentSplits
+	}
+	return nil
+}
+
+type handlerMetrics struct {
+	bypassed         tally.Counter
+	splitHits        tally.Counter
+	splitPartialHits tally.Counter
+	splitMisses      tally.Counter
+	cacheErrors      tally.Counter
+}
+
+func newHandlerMetrics(scope tally.Scope) handlerMetrics {
+	return handlerMetrics{
+		bypassed:         scope.Counter("bypassed"),
+		splitHits:        scope.Tagged(map[string]string{"result": "hit"}).Counter("splits"),
+		splitPartialHits: scope.Tagged(map[string]string{"result": "partial-hit"}).Counter("splits"),
+		splitMisses:      scope.Tagged(map[string]string{"result": "miss"}).Counter("splits"),
+		cacheErrors:      scope.Counter("cache-errors"),
+	}
+}
+
+type handler struct {
+	name    string
+	next    http.Handler
+	opts    Options
+	workers xsync.WorkerPool
+	logger  *zap.Logger
+	metrics handlerMetrics
+}
+
+// NewHandler returns a handler that serves range queries from cached results
+// and only executes the parts of queries that are not cached with next. The
+// name separates the results of handlers that execute queries differently.
+func NewHandler(name string, next http.Handler, opts Options) (http.Handler, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	iOpts := opts.InstrumentOptions
+	if iOpts == nil {
+		iOpts = instrument.NewOptions()
+	}
+	if opts.NowFn == nil {
+		opts.NowFn = time.Now
+	}
+	scope := iOpts.MetricsScope().SubScope("results-cache").
+		Tagged(map[string]string{"handler": name})
+	// The pool is shared by all requests so that the splits executed at once
+	// are bounded across requests rather than per request.
+	workers := xsync.NewWorkerPool(opts.MaxConcurrentSplits)
+	workers.Init()
+	return &handler{
+		name:    name,
+		next:    next,
+		opts:    opts,
+		workers: workers,
+		logger:  iOpts.Logger(),
+		metrics: newHandlerMetrics(scope),
+	}, nil
+}
+
+// rangeQuery is a range query with times in milliseconds, end is the last
+// evaluation timestamp.
+type rangeQuery struct {
+	key   string
+	start int64
+	end   int64
+	step  int64
+}
+
+// evalAtOrAfter returns the first evaluation timestamp at or after t.
+func (q rangeQuery) evalAtOrAfter(t int64) int64 {
+	if t <= q.start {
+		return q.start
+	}
+	return q.start + (t-q.start+q.step-1)/q.step*q.step
+}
+
+// evalAtOrBefore returns the last evaluation timestamp at or before t.
+func (q rangeQuery) evalAtOrBefore(t int64) int64 {
+	if t < q.start {
+		return q.start - q.step
+	}
+	return q.start + (t-q.start)/q.step*q.step
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q, ok := h.parseQuery(r)
+	if !ok {
+		h.metrics.bypassed.Inc(1)
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	var (
+		interval = h.opts.SplitInterval.Milliseconds()
+		cutoff   = h.opts.NowFn().Add(-h.opts.MaxFreshness).UnixNano() / int64(time.Millisecond)
+		splits   = make([]split, 0, (q.end-q.start)/interval+1)
+	)
+	for splitStart := q.start - q.start%interval; splitStart <= q.end; splitStart += interval {
+		start := q.evalAtOrAfter(splitStart)
+		end := q.evalAtOrBefore(splitStart + interval - 1)
+		if end > q.end {
+			end = q.end
+		}
+		if start > end {
+			// No evaluation timestamps fall within this split.
+			continue
+		}
+		splits = append(splits, split{
+			key:   q.key + ":" + fmt.Sprint(splitStart),
+			start: start,
+			end:   end,
+		})
+	}
+
+	var (
+		results = make([]splitResult, len(splits))
+		errs    = make([]error, len(splits))
+		wg      sync.WaitGroup
+	)
+	for i := range splits {
+		i := i
+		wg.Add(1)
+		h.workers.Go(func() {
+			defer wg.Done()
+			results[i], errs[i] = h.serveSplit(r, q, splits[i], cutoff)
+		})
+	}
+	wg.Wait()
+
+	var (
+		merged   = make([][]series, 0, len(results))
+		warnings []string
+		header   = newHeaderMerger()
+	)
+	for i, result := range results {
+		if err := errs[i]; err != nil {
+			xhttp.WriteError(w, err)
+			return
+		}
+		if result.failed != nil {
+			// Return the failed response as is, for instance a query error.
+			result.failed.writeTo(w)
+			return
+		}
+		merged = append(merged, result.series)
+		warnings = appendUnique(warnings, result.warnings...)
+		if result.header != nil {
+			if err := header.add(result.header); err != nil {
+				xhttp.WriteError(w, err)
+				return
+			}
+		}
+	}
+
+	result := mergeSeries(merged...)
+	if err := header.writeTo(w, len(result)); err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+	xhttp.WriteJSONResponse(w, promResponse{
+		Status: statusSuccess,
+		Data: promData{
+			ResultType: matrixType,
+			Result:     result,
+		},
+		Warnings: warnings,
+	}, h.logger)
+}
+
+// split is the part of a range query with evaluation timestamps in
+// [start, end] that is cached under key.
+type split struct {
+	key        string
+	start, end int64
+}
+
+type splitResult struct {
+	series   []series
+	warnings []string
+	// header is the header of the response of the executed query, if any.
+	header http.Header
+	// failed is the response of the executed query if it did not succeed.
+	failed *bufferedResponse
+}
+
+func (h *handler) serveSplit(
+	r *http.Request,
+	q rangeQuery,
+	s split,
+	cutoff int64,
+) (splitResult, error) {
+	var (
+		ctx         = r.Context()
+		key         = s.key
+		start, end  = s.start, s.end
+		cached      []series
+		fetchStart  = start
+		extentStart = start
+	)
+	if ext, ok := h.fetchExtent(ctx, key); ok && ext.Start <= start && ext.End >= start {
+		if ext.End >= end {
+			h.metrics.splitHits.Inc(1)
+			return splitResult{series: between(ext.Series, start, end)}, nil
+		}
+		h.metrics.splitPartialHits.Inc(1)
+		// Only execute the query for the tail that is not cached.
+		cached = ext.Series
+		extentStart = ext.Start
+		fetchStart = ext.End + q.step
+	} else {
+		h.metrics.splitMisses.Inc(1)
+	}
+
+	resp := h.execute(r, fetchStart, end)
+	if resp.code != http.StatusOK {
+		return splitResult{failed: resp}, nil
+	}
+	parsed, err := parseMatrixResponse(resp.body.Bytes())
+	if err != nil {
+		return splitResult{}, fmt.Errorf("could not parse range query result: %w", err)
+	}
+
+	merged := mergeSeries(cached, parsed.Data.Result)
+	extentEnd := q.evalAtOrBefore(cutoff)
+	if extentEnd > end {
+		extentEnd = end
+	}
+	// Results that are limited or have warnings may be incomplete.
+	cacheable := len(parsed.Warnings) == 0 && !limited(resp.header)
+	if cacheable && extentEnd >= fetchStart {
+		h.storeExtent(ctx, key, extent{
+			Start:  extentStart,
+			End:    extentEnd,
+			Series: between(merged, extentStart, extentEnd),
+		})
+	}
+	return splitResult{
+		series:   between(merged, start, end),
+		warnings: parsed.Warnings,
+		header:   resp.header,
+	}, nil
+}
+
+func (h *handler) fetchExtent(ctx context.Context, key string) (extent, bool) {
+	b, ok, err := h.opts.Cache.Fetch(ctx, key)
+	if err != nil {
+		h.metrics.cacheErrors.Inc(1)
+		h.logger.Warn("could not fetch cached results", zap.Error(err))
+		return extent{}, false
+	}
+	if !ok {
+		return extent{}, false
+	}
+	var ext extent
+	if err := json.Unmarshal(b, &ext); err != nil {
+		h.metrics.cacheErrors.Inc(1)
+		h.logger.Warn("could not decode cached results", zap.Error(err))
+		return extent{}, false
+	}
+	return ext, true
+}
+
+func (h *handler) storeExtent(ctx context.Context, key string, ext extent) {
+	b, err := json.Marshal(ext)
+	if err == nil {
+		err = h.opts.Cache.Store(ctx, key, b)
+	}
+	if err != nil {
+		h.metrics.cacheErrors.Inc(1)
+		h.logger.Warn("could not store results", zap.Error(err))
+	}
+}
+
+// execute executes the query for the evaluation timestamps in [start, end].
+func (h *handler) execute(r *http.Request, start, end int64) *bufferedResponse {
+	form := make(url.Values, len(r.Form))
+	for name, values := range r.Form {
+		form[name] = values
+	}
+	form.Set(startParam, formatTimestamp(start))
+	form.Set(endParam, formatTimestamp(end))
+
+	req := r.Clone(r.Context())
+	req.Method = http.MethodGet
+	req.Header.Del("Content-Type")
+	req.Body = http.NoBody
+	req.ContentLength = 0
+	req.URL.RawQuery = form.Encode()
+	req.Form = form
+	req.PostForm = url.Values{}
+
+	resp := newBufferedResponse()
+	h.next.ServeHTTP(resp, req)
+	return resp
+}
+
+// parseQuery returns the range query of the request and whether the results
+// of the query can be cached.
+func (h *handler) parseQuery(r *http.Request) (rangeQuery, bool) {
+	cacheControl := r.Header.Get("Cache-Control")
+	if strings.Contains(cacheControl, "no-store") || strings.Contains(cacheControl, "no-cache") {
+		return rangeQuery{}, false
+	}
+	if err := r.ParseForm(); err != nil {
+		return rangeQuery{}, false
+	}
+	if explain, err := handleroptions.ParseIndexExplain(r); err != nil || explain {
+		// Index query traces are only returned by queries that are executed
+		// as a whole.
+		return rangeQuery{}, false
+	}
+
+	expr, err := parser.ParseExpr(r.Form.Get(queryParam))
+	if err != nil || usesStartOrEnd(expr) {
+		// Results relative to the start or end of a query differ by range.
+		return rangeQuery{}, false
+	}
+
+	now := h.opts.NowFn()
+	start, err := prometheus.ParseTime(r, startParam, now)
+	if err != nil {
+		return rangeQuery{}, false
+	}
+	end, err := prometheus.ParseTime(r, endParam, now)
+	if err != nil {
+		return rangeQuery{}, false
+	}
+	step, err := handleroptions.ParseDuration(r, handleroptions.StepParam)
+	if err != nil || step < time.Millisecond || end.Before(start) {
+		return rangeQuery{}, false
+	}
+
+	q := rangeQuery{
+		start: start.UnixNano() / int64(time.Millisecond),
+		end:   end.UnixNano() / int64(time.Millisecond),
+		step:  step.Milliseconds(),
+	}
+	q.end = q.evalAtOrBefore(q.end)
+
+	// Evaluation timestamps are only shared by queries with the same offset
+	// from a multiple of the step.
+	offset := q.start % q.step
+	if offset < 0 {
+		offset += q.step
+	}
+
+	var key strings.Builder
+	fmt.Fprintf(&key, "%s\n%s\n%d\n%d\n", h.name, expr.String(), q.step, offset)
+	params := make([]string, 0, len(r.Form))
+	for name := range r.Form {
+		switch name {
+		case queryParam, startParam, endParam, handleroptions.StepParam, timeoutParam:
+			continue
+		}
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	for _, name := range params {
+		fmt.Fprintf(&key, "%s=%s\n", name, strings.Join(r.Form[name], ","))
+	}
+	for _, name := range keyHeaders {
+		fmt.Fprintf(&key, "%s:%s\n", name, r.Header.Get(name))
+	}
+
+	hash := sha256.Sum256([]byte(key.String()))
+	q.key = hex.EncodeToString(hash[:])
+	return q, true
+}
+
+// limited returns whether the response with the header was limited in any
+// way and so may not be complete.
+func limited(header http.Header) bool {
+	if header.Get(headers.LimitHeader) != "" {
+		return true
+	}
+	for _, value := range header.Values(headers.ReturnedDataLimitedHeader) {
+		var returned handleroptions.ReturnedDataLimited
+		if err := json.Unmarshal([]byte(value), &returned); err != nil || returned.Limited {
+			return true
+		}
+	}
+	for _, value := range header.Values(headers.ReturnedMetadataLimitedHeader) {
+		var returned handleroptions.ReturnedMetadataLimited
+		if err := json.Unmarshal([]byte(value), &returned); err != nil || returned.Limited {
+			return true
+		}
+	}
+	return false
+}
+
+func usesStartOrEnd(expr parser.Expr) bool {
+	var found bool
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		switch n := node.(type) {
+		case *parser.VectorSelector:
+			found = found || n.StartOrEnd != 0
+		case *parser.SubqueryExpr:
+			found = found || n.StartOrEnd != 0
+		}
+		return nil
+	})
+	return found
+}
+
+// splitHeaderValues returns the values of a comma separated header.
+func splitHeaderValues(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// appendUnique appends the values that are not already in dst.
+func appendUnique(dst []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range dst {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, value)
+		}
+	}
+	return dst
+}
+
+func formatTimestamp(ms int64) string {
+	return sample{T: ms}.timestamp()
+}
+
+type bufferedResponse struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func newBufferedResponse() *bufferedResponse {
+	return &bufferedResponse{header: make(http.Header), code: http.StatusOK}
+}
+
+func (r *bufferedResponse) Header() http.Header {
+	return r.header
+}
+
+func (r *bufferedResponse) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *bufferedResponse) WriteHeader(code int) {
+	r.code = code
+}
+
+func (r *bufferedResponse) writeTo(w http.ResponseWriter) {
+	for name, values := range r.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(r.code)
+	_, _ = w.Write(r.body.Bytes())
+}
@@ -0,0 +1,393 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/headers"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type executedRange struct {
+	start, end int64
+}
+
+// newTestNextHandler returns a handler that returns one series with the
+// value of each evaluation timestamp being the timestamp in seconds.
+func newTestNextHandler(t *testing.T, executed *[]executedRange) http.Handler {
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		switch r.Form.Get(queryParam) {
+		case "bad":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","error":"bad query"}`))
+			return
+		case "garbage":
+			_, _ = w.Write([]byte(`not json`))
+			return
+		}
+
+		start, err := strconv.ParseFloat(r.Form.Get(startParam), 64)
+		require.NoError(t, err)
+		end, err := strconv.ParseFloat(r.Form.Get(endParam), 64)
+		require.NoError(t, err)
+		step, err := time.ParseDuration(r.Form.Get("step"))
+		require.NoError(t, err)
+
+		startMs, endMs := int64(start*1000), int64(end*1000)
+		mu.Lock()
+		*executed = append(*executed, executedRange{start: startMs, end: endMs})
+		mu.Unlock()
+
+		var warnings []string
+		switch r.Form.Get(queryParam) {
+		case "returned-limited":
+			w.Header().Set(headers.ReturnedDataLimitedHeader,
+				`{"Series":1,"Datapoints":1,"TotalSeries":2,"Limited":true}`)
+		case "limited":
+			// Each split is limited and warned about both in the same way and
+			// differently to the other splits.
+			split := fmt.Sprint(startMs)
+			warnings = []string{"warning", "warning-" + split}
+			w.Header().Set(headers.LimitHeader, "limit,limit-"+split)
+		case "costed":
+			w.Header().Set(headers.FetchedSeriesCount, "2")
+			w.Header().Set(headers.QueryStatsHeader,
+				`{"seriesMatched":2,"namespaces":{"default":{"seriesMatched":2}}}`)
+			w.Header().Set(headers.WaitedHeader, `{"waitedIndex":1,"waitedSeriesRead":0}`)
+			limited := startMs == 1000*24*3600*1000
+			w.Header().Set(headers.ReturnedDataLimitedHeader, fmt.Sprintf(
+				`{"Series":1,"Datapoints":24,"TotalSeries":1,"Limited":%v}`, limited))
+		default:
+			w.Header().Set(headers.ReturnedDataLimitedHeader,
+				`{"Series":1,"Datapoints":1,"TotalSeries":1,"Limited":false}`)
+		}
+
+		s := series{Metric: map[string]string{"__name__": "up"}}
+		for ts := startMs; ts <= endMs; ts += step.Milliseconds() {
+			s.Values = append(s.Values, sample{T: ts, V: fmt.Sprint(ts / 1000)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(promResponse{
+			Status:   statusSuccess,
+			Data:     promData{ResultType: matrixType, Result: []series{s}},
+			Warnings: warnings,
+		}))
+	})
+}
+
+func newTestRequest(query string, start, end time.Time, step time.Duration) *http.Request {
+	values := url.Values{}
+	values.Set(queryParam, query)
+	values.Set(startParam, strconv.FormatInt(start.Unix(), 10))
+	values.Set(endParam, strconv.FormatInt(end.Unix(), 10))
+	values.Set("step", step.String())
+	return httptest.NewRequest(http.MethodGet, "/api/v1/query_range?"+values.Encode(), nil)
+}
+
+func serveTestRequest(t *testing.T, h http.Handler, r *http.Request) promResponse {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	resp, err := parseMatrixResponse(w.Body.Bytes())
+	require.NoError(t, err)
+	return resp
+}
+
+func TestHandlerCachesSplits(t *testing.T) {
+	var (
+		day      = 24 * time.Hour
+		start    = time.Unix(0, 0).Add(1000 * day).Add(6 * time.Hour)
+		end      = start.Add(3 * day)
+		now      = end
+		executed []executedRange
+	)
+	opts := NewOptions(NewInMemoryCache(InMemoryOptions{}), instrument.NewOptions())
+	opts.NowFn = func() time.Time { return now }
+	h, err := NewHandler("test", newTestNextHandler(t, &executed), opts)
+	require.NoError(t, err)
+
+	resp := serveTestRequest(t, h, newTestRequest("up", start, end, time.Hour))
+	require.Equal(t, 1, len(resp.Data.Result))
+	values := resp.Data.Result[0].Values
+	require.Equal(t, 73, len(values))
+	for i, v := range values {
+		ts := start.Add(time.Duration(i) * time.Hour).Unix()
+		assert.Equal(t, ts*1000, v.T)
+		assert.Equal(t, fmt.Sprint(ts), v.V)
+	}
+	// One query per day the range spans.
+	require.Equal(t, 4, len(executed))
+
+	// Repeating the query an hour later only executes the tail that is more
+	// recent than the max freshness when it was first executed.
+	executed = executed[:0]
+	now = now.Add(time.Hour)
+	resp = serveTestRequest(t, h, newTestRequest("up", start.Add(time.Hour), end.Add(time.Hour), time.Hour))
+	require.Equal(t, 73, len(resp.Data.Result[0].Values))
+	assert.Equal(t, start.Add(time.Hour).Unix()*1000, resp.Data.Result[0].Values[0].T)
+	assert.Equal(t, end.Add(time.Hour).Unix()*1000, resp.Data.Result[0].Values[72].T)
+	require.Equal(t, []executedRange{
+		{
+			start: end.Add(-opts.MaxFreshness).Truncate(time.Hour).Add(time.Hour).Unix() * 1000,
+			end:   end.Add(time.Hour).Unix() * 1000,
+		},
+	}, executed)
+}
+
+func TestHandlerKeysByStepOffset(t *testing.T) {
+	var (
+		start    = time.Unix(0, 0).Add(1000 * 24 * time.Hour)
+		end      = start.Add(2 * time.Hour)
+		executed []executedRange
+	)
+	opts := NewOptions(NewInMemoryCache(InMemoryOptions{}), instrument.NewOptions())
+	opts.NowFn = func() time.Time { return end.Add(time.Hour) }
+	h, err := NewHandler("test", newTestNextHandler(t, &executed), opts)
+	require.NoError(t, err)
+
+	serveTestRequest(t, h, newTestRequest("up", start, end, time.Hour))
+	serveTestRequest(t, h, newTestRequest("up", start, end, time.Hour))
+	require.Equal(t, 1, len(executed))
+
+	// Evaluation timestamps at a different offset are not shared.
+	resp := serveTestRequest(t, h, newTestRequest("up", start.Add(time.Minute), end, time.Hour))
+	require.Equal(t, 2, len(executed))
+	require.Equal(t, 2, len(resp.Data.Result[0].Values))
+	assert.Equal(t, start.Add(time.Minute).Unix()*1000, resp.Data.Result[0].Values[0].T)
+}
+
+func TestHandlerBypassesAndPassesThroughErrors(t *testing.T) {
+	var (
+		start    = time.Unix(0, 0).Add(1000 * 24 * time.Hour)
+		end      = start.Add(2 * time.Hour)
+		executed []executedRange
+	)
+	opts := NewOptions(NewInMemoryCache(InMemoryOptions{}), instrument.NewOptions())
+	opts.NowFn = func() time.Time { return end.Add(time.Hour) }
+	h, err := NewHandler("test", newTestNextHandler(t, &executed), opts)
+	require.NoError(t, err)
+
+	// Queries relative to the start or end of the range are not cached.
+	serveTestRequest(t, h, newTestRequest("up @ start()", start, end, time.Hour))
+	serveTestRequest(t, h, newTestRequest("up @ start()", start, end, time.Hour))
+	require.Equal(t, 2, len(executed))
+
+	// Requests asking not to use the cache are not cached.
+	r := newTestRequest("up", start, end, time.Hour)
+	r.Header.Set("Cache-Control", "no-cache")
+	serveTestRequest(t, h, r)
+	require.Equal(t, 3, len(executed))
+
+	// Requests for index query traces are not cached.
+	r = newTestRequest("up", start, end, time.Hour)
+	r.Header.Set(headers.IndexExplainHeader, "true")
+	serveTestRequest(t, h, r)
+	serveTestRequest(t, h, r)
+	require.Equal(t, 5, len(executed))
+
+	// Query errors are returned as is.
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newTestRequest("bad", start, end, time.Hour))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `{"status":"error","error":"bad query"}`, w.Body.String())
+
+	// Results that cannot be parsed are errors.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newTestRequest("garbage", start, end, time.Hour))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "could not parse range query result")
+}
+
+func TestHandlerMergesWarningsAndLimitsOfSplits(t *testing.T) {
+	var (
+		day      = 24 * time.Hour
+		start    = time.Unix(0, 0).Add(1000 * day)
+		end      = start.Add(day + time.Hour)
+		executed []executedRange
+	)
+	opts := NewOptions(NewInMemoryCache(InMemoryOptions{}), instrument.NewOptions())
+	opts.NowFn = func() time.Time { return end.Add(day) }
+	h, err := NewHandler("test", newTestNextHandler(t, &executed), opts)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newTestRequest("limited", start, end, time.Hour))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	resp, err := parseMatrixResponse(w.Body.Bytes())
+	require.NoError(t, err)
+
+	var (
+		first  = fmt.Sprint(start.Unix() * 1000)
+		second = fmt.Sprint(start.Add(day).Unix() * 1000)
+	)
+	assert.Equal(t, []string{"warning", "warning-" + first, "warning-" + second},
+		resp.Warnings)
+	assert.Equal(t, "limit,limit-"+first+",limit-"+second,
+		w.Header().Get(headers.LimitHeader))
+
+	// Limited results are not cached.
+	serveTestRequest(t, h, newTestRequest("limited", start, end, time.Hour))
+	require.Equal(t, 4, len(executed))
+
+	// Neither are results that had returned data limited.
+	executed = executed[:0]
+	serveTestRequest(t, h, newTestRequest("returned-limited", start, end, time.Hour))
+	serveTestRequest(t, h, newTestRequest("returned-limited", start, end, time.Hour))
+	require.Equal(t, 4, len(executed))
+}
+
+func TestHandlerMergesCostsOfSplits(t *testing.T) {
+	var (
+		day      = 24 * time.Hour
+		start    = time.Unix(0, 0).Add(1000 * day)
+		end      = start.Add(day + time.Hour)
+		executed []executedRange
+	)
+	opts := NewOptions(NewInMemoryCache(InMemoryOptions{}), instrument.NewOptions())
+	opts.NowFn = func() time.Time { return end.Add(day) }
+	h, err := NewHandler("test", newTestNextHandler(t, &executed), opts)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newTestRequest("costed", start, end, time.Hour))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, 2, len(executed))
+
+	assert.Equal(t, "4", w.Header().Get(headers.FetchedSeriesCount))
+	var stats struct {
+		storage.NamespaceQueryStats
+		Namespaces map[string]storage.NamespaceQueryStats `json:"namespaces"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(w.Header().Get(headers.QueryStatsHeader)), &stats))
+	assert.Equal(t, 4, stats.SeriesMatched)
+	assert.Equal(t, map[string]storage.NamespaceQueryStats{
+		"default": {SeriesMatched: 4},
+	}, stats.Namespaces)
+	assert.JSONEq(t, `{"waitedIndex":2,"waitedSeriesRead":0}`, w.Header().Get(headers.WaitedHeader))
+	// Only the first split was limited, the merged result still is.
+	assert.JSONEq(t, `{"Series":1,"Datapoints":48,"TotalSeries":1,"Limited":true}`,
+		w.Header().Get(headers.ReturnedDataLimitedHeader))
+}
+
+func TestHandlerBoundsConcurrentSplits(t *testing.T) {
+	var (
+		day         = 24 * time.Hour
+		start       = time.Unix(0, 0).Add(1000 * day)
+		end         = start.Add(7 * day)
+		executed    []executedRange
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+	)
+	next := newTestNextHandler(t, &executed)
+	opts := NewOptions(NewInMemoryCache(InMemoryOptions{}), instrument.NewOptions())
+	opts.NowFn = func() time.Time { return end.Add(day) }
+	opts.MaxConcurrentSplits = 2
+	h, err := NewHandler("test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		next.ServeHTTP(w, r)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}), opts)
+	require.NoError(t, err)
+
+	resp := serveTestRequest(t, h, newTestRequest("up", start, end, time.Hour))
+	require.Equal(t, 8, len(executed))
+	require.Equal(t, 2, maxInFlight)
+	require.Equal(t, 7*24+1, len(resp.Data.Result[0].Values))
+}
+
+func TestMergeSeries(t *testing.T) {
+	a := map[string]string{"__name__": "up", "job": "a"}
+	b := map[string]string{"__name__": "up", "job": "b"}
+	merged := mergeSeries(
+		[]series{
+			{Metric: b, Values: []sample{{T: 1000, V: "1"}}},
+			{Metric: a, Values: []sample{{T: 1000, V: "2"}}},
+		},
+		[]series{
+			{Metric: a, Values: []sample{{T: 2000, V: "3"}}},
+		},
+	)
+	require.Equal(t, []series{
+		{Metric: a, Values: []sample{{T: 1000, V: "2"}, {T: 2000, V: "3"}}},
+		{Metric: b, Values: []sample{{T: 1000, V: "1"}}},
+	}, merged)
+
+	data, err := json.Marshal(merged[0].Values)
+	require.NoError(t, err)
+	assert.Equal(t, `[[1,"2"],[2,"3"]]`, string(data))
+
+	var decoded []sample
+	require.NoError(t, json.Unmarshal([]byte(`[[1.5,"2"]]`), &decoded))
+	assert.Equal(t, []sample{{T: 1500, V: "2"}}, decoded)
+}
+
+func TestHandlerKeysByResultHeaders(t *testing.T) {
+	var (
+		start    = time.Unix(0, 0).Add(1000 * 24 * time.Hour)
+		end      = start.Add(2 * time.Hour)
+		executed []executedRange
+	)
+	opts := NewOptions(NewInMemoryCache(InMemoryOptions{}), instrument.NewOptions())
+	opts.NowFn = func() time.Time { return end.Add(time.Hour) }
+	h, err := NewHandler("test", newTestNextHandler(t, &executed), opts)
+	require.NoError(t, err)
+
+	serveTestRequest(t, h, newTestRequest("up", start, end, time.Hour))
+	require.Equal(t, 1, len(executed))
+
+	// Requests that rewrite the tags of results do not share results.
+	r := newTestRequest("up", start, end, time.Hour)
+	r.Header.Set(headers.MapTagsByJSONHeader, `{"tagMappers":[{"write":{"tag":"a","value":"b"}}]}`)
+	serveTestRequest(t, h, r)
+	require.Equal(t, 2, len(executed))
+
+	r = newTestRequest("up", start, end, time.Hour)
+	r.Header.Set(headers.MapTagsByJSONHeader, `{"tagMappers":[{"write":{"tag":"a","value":"b"}}]}`)
+	serveTestRequest(t, h, r)
+	require.Equal(t, 2, len(executed))
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/headers"
+)
+
+// countHeaders are the response headers with counts of the data fetched by
+// a query that are summed across splits.
+var countHeaders = []string{
+	headers.FetchedSeriesCount,
+	headers.FetchedSeriesNoSamplesCount,
+	headers.FetchedSeriesWithSamplesCount,
+	headers.FetchedAggregatedSeriesCount,
+	headers.FetchedUnaggregatedSeriesCount,
+	headers.FetchedResponsesHeader,
+	headers.FetchedBytesEstimateHeader,
+	headers.FetchedMetadataCount,
+}
+
+// headerMerger merges the response headers of the executed splits of a query
+// so that the merged response reports the cost and limits of every split
+// rather than of only one of them.
+type headerMerger struct {
+	last       http.Header
+	limited    []string
+	namespaces []string
+	counts     map[string]int
+	stats      *storage.QueryStats
+	returned   *handleroptions.ReturnedDataLimited
+	waited     handleroptions.Waiting
+}
+
+func newHeaderMerger() *headerMerger {
+	return &headerMerger{counts: make(map[string]int)}
+}
+
+// add adds the response headers of a split.
+func (m *headerMerger) add(header http.Header) error {
+	m.last = header
+	m.limited = appendUnique(m.limited, splitHeaderValues(header.Get(headers.LimitHeader))...)
+	m.namespaces = appendUnique(m.namespaces,
+		splitHeaderValues(header.Get(headers.NamespacesHeader))...)
+
+	for _, name := range countHeaders {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s header: %w", name, err)
+		}
+		m.counts[name] += count
+	}
+
+	if value := header.Get(headers.QueryStatsHeader); value != "" {
+		var stats struct {
+			Namespaces map[string]storage.NamespaceQueryStats `json:"namespaces"`
+		}
+		if err := json.Unmarshal([]byte(value), &stats); err != nil {
+			return fmt.Errorf("invalid %s header: %w", headers.QueryStatsHeader, err)
+		}
+		if m.stats == nil {
+			m.stats = storage.NewQueryStats()
+		}
+		for namespace, nsStats := range stats.Namespaces {
+			m.stats.Add(namespace, nsStats)
+		}
+	}
+
+	for _, value := range header.Values(headers.ReturnedDataLimitedHeader) {
+		var returned handleroptions.ReturnedDataLimited
+		if err := json.Unmarshal([]byte(value), &returned); err != nil {
+			return fmt.Errorf("invalid %s header: %w", headers.ReturnedDataLimitedHeader, err)
+		}
+		if m.returned == nil {
+			m.returned = &handleroptions.ReturnedDataLimited{}
+		}
+		m.returned.Limited = m.returned.Limited || returned.Limited
+		m.returned.Datapoints += returned.Datapoints
+		if returned.TotalSeries > m.returned.TotalSeries {
+			m.returned.TotalSeries = returned.TotalSeries
+		}
+	}
+
+	if value := header.Get(headers.WaitedHeader); value != "" {
+		var waited handleroptions.Waiting
+		if err := json.Unmarshal([]byte(value), &waited); err != nil {
+			return fmt.Errorf("invalid %s header: %w", headers.WaitedHeader, err)
+		}
+		m.waited.WaitedIndex += waited.WaitedIndex
+		m.waited.WaitedSeriesRead += waited.WaitedSeriesRead
+	}
+	return nil
+}
+
+// writeTo writes the merged headers of a response with the number of
+// series returned.
+func (m *headerMerger) writeTo(w http.ResponseWriter, returnedSeries int) error {
+	// Headers that are not merged, such as the content type, are the same
+	// for every split.
+	for name, values := range m.last {
+		if name == "Content-Length" {
+			continue
+		}
+		w.Header()[name] = values
+	}
+
+	header := w.Header()
+	header.Del(headers.LimitHeader)
+	if len(m.limited) > 0 {
+		header.Set(headers.LimitHeader, strings.Join(m.limited, ","))
+	}
+	header.Del(headers.NamespacesHeader)
+	if len(m.namespaces) > 0 {
+		header.Set(headers.NamespacesHeader, strings.Join(m.namespaces, ","))
+	}
+	for _, name := range countHeaders {
+		header.Del(name)
+		if count := m.counts[name]; count > 0 {
+			header.Set(name, strconv.Itoa(count))
+		}
+	}
+
+	header.Del(headers.QueryStatsHeader)
+	if m.stats != nil {
+		b, err := json.Marshal(m.stats)
+		if err != nil {
+			return err
+		}
+		header.Set(headers.QueryStatsHeader, string(b))
+	}
+
+	header.Del(headers.ReturnedDataLimitedHeader)
+	if returned := m.returned; returned != nil {
+		// Splits return the same series for different timestamps, so the
+		// series returned are those of the merged result.
+		returned.Series = returnedSeries
+		if returned.TotalSeries < returned.Series {
+			returned.TotalSeries = returned.Series
+		}
+		b, err := json.Marshal(returned)
+		if err != nil {
+			return err
+		}
+		header.Set(headers.ReturnedDataLimitedHeader, string(b))
+	}
+
+	header.Del(headers.WaitedHeader)
+	if m.waited.WaitedAny() {
+		b, err := json.Marshal(m.waited)
+		if err != nil {
+			return err
+		}
+		header.Set(headers.WaitedHeader, string(b))
+	}
+	return nil
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/handler/prom"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/resultscache"
 	"github.com/m3db/m3/src/query/api/v1/handler/topic"
 	"github.com/m3db/m3/src/query/api/v1/middleware"
 	"github.com/m3db/m3/src/query/api/v1/options"
@@ -158,10 +159,29 @@ func (h *Handler) RegisterRoutes() error {
 	nativePromReadHandler := native.NewPromReadHandler(nativeSourceOpts)
 	nativePromReadInstantHandler := native.NewPromReadInstantHandler(nativeSourceOpts)
 
+	var (
+		promqlRangeHandler http.Handler = promqlQueryHandler
+		nativeRangeHandler http.Handler = nativePromReadHandler
+	)
+	if cacheCfg := h.options.Config().Query.ResultsCache; cacheCfg.Enabled {
+		cacheOpts := cacheCfg.NewOptions(h.options.ResultsCache(),
+			nativeSourceOpts.InstrumentOpts())
+		promqlRangeHandler, err = resultscache.NewHandler("prometheus",
+			promqlQueryHandler, cacheOpts)
+		if err != nil {
+			return err
+		}
+		nativeRangeHandler, err = resultscache.NewHandler("m3query",
+			nativePromReadHandler, cacheOpts)
+		if err != nil {
+			return err
+		}
+	}
+
 	h.options.QueryRouter().Setup(options.QueryRouterOptions{
 		DefaultQueryEngine: h.options.DefaultQueryEngine(),
-		PromqlHandler:      promqlQueryHandler.ServeHTTP,
-		M3QueryHandler:     nativePromReadHandler.ServeHTTP,
+		PromqlHandler:      promqlRangeHandler.ServeHTTP,
+		M3QueryHandler:     nativeRangeHandler.ServeHTTP,
 	})
 
 	h.options.InstantQueryRouter().Setup(options.QueryRouterOptions{
@@ -191,7 +211,7 @@ func (h *Handler) RegisterRoutes() error {
 	// Prometheus endpoints.
 	if err := h.registry.Register(queryhttp.RegisterOptions{
 		Path:               "/prometheus" + native.PromReadURL,
-		Handler:            promqlQueryHandler,
+		Handler:            promqlRangeHandler,
 		Methods:            native.PromReadHTTPMethods,
 		MiddlewareOverride: native.WithRangeQueryParamsAndRangeRewriting,
 	}); err != nil {
@@ -209,7 +229,7 @@ func (h *Handler) RegisterRoutes() error {
 	// M3Query endpoints.
 	if err := h.registry.Register(queryhttp.RegisterOptions{
 		Path:               "/m3query" + native.PromReadURL,
-		Handler:            nativePromReadHandler,
+		Handler:            nativeRangeHandler,
 		Methods:            native.PromReadHTTPMethods,
 		MiddlewareOverride: native.WithRangeQueryParamsAndRangeRewriting,
 	}); err != nil {
@@ -224,6 +244,16 @@ func (h *Handler) RegisterRoutes() error {
 		return err
 	}
 
+	// M3QL endpoints.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:               native.M3QLReadURL,
+		Handler:            native.NewM3QLReadHandler(nativeSourceOpts),
+		Methods:            native.M3QLReadHTTPMethods,
+		MiddlewareOverride: native.WithQueryParams,
+	}); err != nil {
+		return err
+	}
+
 	// Prometheus remote read and write endpoints.
 	if err := h.registry.Register(queryhttp.RegisterOptions{
 		Path:    remote.PromReadURL,
@@ -332,6 +362,33 @@ func (h *Handler) RegisterRoutes() error {
 		return err
 	}
 
+	// Index query explain endpoint.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    remote.IndexExplainURL,
+		Handler: remote.NewIndexExplainHandler(h.options),
+		Methods: remote.IndexExplainHTTPMethods,
+	}); err != nil {
+		return err
+	}
+
+	// Series delete endpoint.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    remote.PromDeleteSeriesURL,
+		Handler: remote.NewPromDeleteSeriesHandler(h.options),
+		Methods: remote.PromDeleteSeriesHTTPMethods,
+	}); err != nil {
+		return err
+	}
+
+	// Cardinality endpoint.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    remote.PromCardinalityURL,
+		Handler: remote.NewPromCardinalityHandler(h.options),
+		Methods: methods(remote.PromCardinalityHTTPMethod),
+	}); err != nil {
+		return err
+	}
+
 	// Graphite routable endpoints.
 	h.options.GraphiteRenderRouter().Setup(options.GraphiteRenderRouterOptions{
 		RenderHandler: graphite.NewRenderHandler(h.options).ServeHTTP,
@@ -201,6 +201,20 @@ func TestPromNativeReadGet(t *testing.T) {
 	}
 }
 
+func TestM3QLReadGet(t *testing.T) {
+	req := httptest.NewRequest("GET", native.M3QLReadURL, nil)
+	res := httptest.NewRecorder()
+	ctrl := gomock.NewController(t)
+	storage, _ := m3.NewStorageAndSession(t, ctrl)
+
+	h, err := setupHandler(storage)
+	require.NoError(t, err, "unable to setup handler")
+	err = h.RegisterRoutes()
+	require.NoError(t, err, "unable to register routes")
+	h.Router().ServeHTTP(res, req)
+	require.Equal(t, http.StatusBadRequest, res.Code, "Empty request")
+}
+
 func TestPromNativeReadPost(t *testing.T) {
 	tests := []struct {
 		routePrefix string
@@ -35,11 +35,13 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	dbnamespace "github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/resultscache"
 	"github.com/m3db/m3/src/query/api/v1/middleware"
 	"github.com/m3db/m3/src/query/api/v1/validators"
 	"github.com/m3db/m3/src/query/executor"
 	graphite "github.com/m3db/m3/src/query/graphite/storage"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/querylog"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3"
 	"github.com/m3db/m3/src/query/ts"
@@ -282,6 +284,17 @@ type HandlerOptions interface {
 	DefaultLookback() time.Duration
 	// SetDefaultLookback sets the default value of lookback duration.
 	SetDefaultLookback(value time.Duration) HandlerOptions
+
+	// ResultsCache returns the cache of range query results, if not set and
+	// the results cache is enabled then results are cached in memory.
+	ResultsCache() resultscache.Cache
+	// SetResultsCache sets the cache of range query results.
+	SetResultsCache(value resultscache.Cache) HandlerOptions
+
+	// QueryLogger returns the logger of slow and sampled queries.
+	QueryLogger() querylog.Logger
+	// SetQueryLogger sets the logger of slow and sampled queries.
+	SetQueryLogger(value querylog.Logger) HandlerOptions
 }
 
 // HandlerOptions represents handler options.
@@ -316,6 +329,8 @@ type handlerOptions struct {
 	graphiteRenderRouter              GraphiteRenderRouter
 	graphiteFindRouter                GraphiteFindRouter
 	defaultLookback                   time.Duration
+	resultsCache                      resultscache.Cache
+	queryLogger                       querylog.Logger
 }
 
 // EmptyHandlerOptions returns  default handler options.
@@ -324,6 +339,7 @@ func EmptyHandlerOptions() HandlerOptions {
 		instrumentOpts: instrument.NewOptions(),
 		nowFn:          time.Now,
 		m3dbOpts:       m3.NewOptions(encoding.NewOptions()),
+		queryLogger:    querylog.NewNoopLogger(),
 	}
 }
 
@@ -386,6 +402,7 @@ func NewHandlerOptions(
 		graphiteRenderRouter:              graphiteRenderRouter,
 		graphiteFindRouter:                graphiteFindRouter,
 		defaultLookback:                   defaultLookback,
+		queryLogger:                       querylog.NewNoopLogger(),
 	}, nil
 }
 
@@ -713,5 +730,25 @@ func (o *handlerOptions) SetDefaultLookback(value time.Duration) HandlerOptions
 	return &opts
 }
 
+func (o *handlerOptions) ResultsCache() resultscache.Cache {
+	return o.resultsCache
+}
+
+func (o *handlerOptions) SetResultsCache(value resultscache.Cache) HandlerOptions {
+	opts := *o
+	opts.resultsCache = value
+	return &opts
+}
+
+func (o *handlerOptions) QueryLogger() querylog.Logger {
+	return o.queryLogger
+}
+
+func (o *handlerOptions) SetQueryLogger(value querylog.Logger) HandlerOptions {
+	opts := *o
+	opts.queryLogger = value
+	return &opts
+}
+
 // KVStoreProtoParser parses protobuf messages based off specific keys.
 type KVStoreProtoParser func(key string) (protoiface.MessageV1, error)
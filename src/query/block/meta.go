@@ -26,6 +26,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/models"
 )
 
@@ -139,6 +140,9 @@ type ResultMetadata struct {
 	// FetchedMetadataCount is the total amount of metadata that was fetched to compute
 	// this result.
 	FetchedMetadataCount int
+	// IndexExplain are the traces of the index query from each host, only
+	// set if requested. These are diagnostic and not considered by Equals.
+	IndexExplain []index.QueryExplain
 	// MetricNames is the set of unique metric tag name values across all series in this result.
 	// External users must access via `ByName(name)`.
 	metadataByName map[string]*ResultMetricMetadata
@@ -225,6 +229,20 @@ func NewResultMetadata() ResultMetadata {
 	}
 }
 
+func combineIndexExplain(a, b []index.QueryExplain) []index.QueryExplain {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+
+	combined := make([]index.QueryExplain, 0, len(a)+len(b))
+	combined = append(combined, a...)
+	combined = append(combined, b...)
+	return combined
+}
+
 func combineResolutions(a, b []time.Duration) []time.Duration {
 	if len(a) == 0 {
 		if len(b) != 0 {
@@ -351,6 +369,7 @@ func (m ResultMetadata) CombineMetadata(other ResultMetadata) ResultMetadata {
 		FetchedSeriesCount:   m.FetchedSeriesCount + other.FetchedSeriesCount,
 		metadataByName:       combineMetricMetadata(m.metadataByName, other.metadataByName),
 		FetchedMetadataCount: m.FetchedMetadataCount + other.FetchedMetadataCount,
+		IndexExplain:         combineIndexExplain(m.IndexExplain, other.IndexExplain),
 	}
 }
 
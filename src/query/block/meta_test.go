@@ -25,6 +25,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/models"
 
 	"github.com/stretchr/testify/assert"
@@ -201,6 +202,23 @@ func TestMergeResolutions(t *testing.T) {
 	assert.Equal(t, []time.Duration{1, 2, 3, 4, 5, 6}, merge.Resolutions)
 }
 
+func TestMergeIndexExplain(t *testing.T) {
+	r := ResultMetadata{}
+	rTwo := ResultMetadata{}
+	assert.Nil(t, r.CombineMetadata(rTwo).IndexExplain)
+
+	a := index.QueryExplain{Host: "a"}
+	b := index.QueryExplain{Host: "b"}
+	r.IndexExplain = []index.QueryExplain{a}
+	assert.Equal(t, []index.QueryExplain{a}, r.CombineMetadata(rTwo).IndexExplain)
+	assert.Equal(t, []index.QueryExplain{a}, rTwo.CombineMetadata(r).IndexExplain)
+
+	rTwo.IndexExplain = []index.QueryExplain{b}
+	assert.Equal(t, []index.QueryExplain{a, b}, r.CombineMetadata(rTwo).IndexExplain)
+	assert.Equal(t, 1, len(r.IndexExplain))
+	assert.Equal(t, 1, len(rTwo.IndexExplain))
+}
+
 func TestVerifyTemporalRange(t *testing.T) {
 	r := ResultMetadata{
 		Exhaustive:  true,
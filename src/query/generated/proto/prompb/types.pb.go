@@ -124,6 +124,33 @@ func (x Source) String() string {
 }
 func (Source) EnumDescriptor() ([]byte, []int) { return fileDescriptorTypes, []int{2} }
 
+type Histogram_ResetHint int32
+
+const (
+	Histogram_UNKNOWN Histogram_ResetHint = 0
+	Histogram_YES     Histogram_ResetHint = 1
+	Histogram_NO      Histogram_ResetHint = 2
+	Histogram_GAUGE   Histogram_ResetHint = 3
+)
+
+var Histogram_ResetHint_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "YES",
+	2: "NO",
+	3: "GAUGE",
+}
+var Histogram_ResetHint_value = map[string]int32{
+	"UNKNOWN": 0,
+	"YES":     1,
+	"NO":      2,
+	"GAUGE":   3,
+}
+
+func (x Histogram_ResetHint) String() string {
+	return proto.EnumName(Histogram_ResetHint_name, int32(x))
+}
+func (Histogram_ResetHint) EnumDescriptor() ([]byte, []int) { return fileDescriptorTypes, []int{2, 0} }
+
 type LabelMatcher_Type int32
 
 const (
@@ -149,7 +176,7 @@ var LabelMatcher_Type_value = map[string]int32{
 func (x LabelMatcher_Type) String() string {
 	return proto.EnumName(LabelMatcher_Type_name, int32(x))
 }
-func (LabelMatcher_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptorTypes, []int{4, 0} }
+func (LabelMatcher_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptorTypes, []int{6, 0} }
 
 type Sample struct {
 	Value     float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
@@ -176,15 +203,17 @@ func (m *Sample) GetTimestamp() int64 {
 }
 
 type TimeSeries struct {
-	Labels  []Label  `protobuf:"bytes,1,rep,name=labels" json:"labels"`
-	Samples []Sample `protobuf:"bytes,2,rep,name=samples" json:"samples"`
-	Unit    string   `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
-	Help    string   `protobuf:"bytes,5,opt,name=help,proto3" json:"help,omitempty"`
+	Labels     []Label     `protobuf:"bytes,1,rep,name=labels" json:"labels"`
+	Samples    []Sample    `protobuf:"bytes,2,rep,name=samples" json:"samples"`
+	Histograms []Histogram `protobuf:"bytes,4,rep,name=histograms" json:"histograms"`
+	Help       string      `protobuf:"bytes,5,opt,name=help,proto3" json:"help,omitempty"`
 	// NB: These are custom fields that M3 uses. They start at 101 so that they
 	// should never clash with prometheus fields.
 	M3Type M3Type     `protobuf:"varint,101,opt,name=m3_type,json=m3Type,proto3,enum=m3prometheus.M3Type" json:"m3_type,omitempty"`
 	Source Source     `protobuf:"varint,102,opt,name=source,proto3,enum=m3prometheus.Source" json:"source,omitempty"`
 	Type   MetricType `protobuf:"varint,103,opt,name=type,proto3,enum=m3prometheus.MetricType" json:"type,omitempty"`
+	// NB: unit used to be field 4 which clashes with native histograms.
+	Unit string `protobuf:"bytes,104,opt,name=unit,proto3" json:"unit,omitempty"`
 }
 
 func (m *TimeSeries) Reset()                    { *m = TimeSeries{} }
@@ -206,11 +235,11 @@ func (m *TimeSeries) GetSamples() []Sample {
 	return nil
 }
 
-func (m *TimeSeries) GetUnit() string {
+func (m *TimeSeries) GetHistograms() []Histogram {
 	if m != nil {
-		return m.Unit
+		return m.Histograms
 	}
-	return ""
+	return nil
 }
 
 func (m *TimeSeries) GetHelp() string {
@@ -241,6 +270,336 @@ func (m *TimeSeries) GetType() MetricType {
 	return MetricType_UNKNOWN
 }
 
+func (m *TimeSeries) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+// A native histogram, also known as a sparse histogram. Mirrors the
+// Prometheus remote write definition field for field.
+type Histogram struct {
+	// Types that are valid to be assigned to Count:
+	//	*Histogram_CountInt
+	//	*Histogram_CountFloat
+	Count isHistogram_Count `protobuf_oneof:"count"`
+	Sum   float64           `protobuf:"fixed64,3,opt,name=sum,proto3" json:"sum,omitempty"`
+	// The schema defines the bucket schema. Currently, valid numbers
+	// are -4 <= n <= 8. They are all for base-2 bucket schemas, where 1
+	// is a bucket boundary in each case, and then each power of two is
+	// divided into 2^n logarithmic buckets. Or in other words, each
+	// bucket boundary is the previous boundary times 2^(2^-n).
+	Schema        int32   `protobuf:"zigzag32,4,opt,name=schema,proto3" json:"schema,omitempty"`
+	ZeroThreshold float64 `protobuf:"fixed64,5,opt,name=zero_threshold,json=zeroThreshold,proto3" json:"zero_threshold,omitempty"`
+	// Types that are valid to be assigned to ZeroCount:
+	//	*Histogram_ZeroCountInt
+	//	*Histogram_ZeroCountFloat
+	ZeroCount isHistogram_ZeroCount `protobuf_oneof:"zero_count"`
+	// Negative Buckets.
+	NegativeSpans []BucketSpan `protobuf:"bytes,8,rep,name=negative_spans,json=negativeSpans" json:"negative_spans"`
+	// Use either "negative_deltas" or "negative_counts", the former for
+	// regular histograms with integer counts, the latter for float
+	// histograms.
+	NegativeDeltas []int64   `protobuf:"zigzag64,9,rep,packed,name=negative_deltas,json=negativeDeltas" json:"negative_deltas,omitempty"`
+	NegativeCounts []float64 `protobuf:"fixed64,10,rep,packed,name=negative_counts,json=negativeCounts" json:"negative_counts,omitempty"`
+	// Positive Buckets.
+	PositiveSpans []BucketSpan `protobuf:"bytes,11,rep,name=positive_spans,json=positiveSpans" json:"positive_spans"`
+	// Use either "positive_deltas" or "positive_counts", the former for
+	// regular histograms with integer counts, the latter for float
+	// histograms.
+	PositiveDeltas []int64             `protobuf:"zigzag64,12,rep,packed,name=positive_deltas,json=positiveDeltas" json:"positive_deltas,omitempty"`
+	PositiveCounts []float64           `protobuf:"fixed64,13,rep,packed,name=positive_counts,json=positiveCounts" json:"positive_counts,omitempty"`
+	ResetHint      Histogram_ResetHint `protobuf:"varint,14,opt,name=reset_hint,json=resetHint,proto3,enum=m3prometheus.Histogram_ResetHint" json:"reset_hint,omitempty"`
+	// timestamp is in ms format.
+	Timestamp int64 `protobuf:"varint,15,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (m *Histogram) Reset()                    { *m = Histogram{} }
+func (m *Histogram) String() string            { return proto.CompactTextString(m) }
+func (*Histogram) ProtoMessage()               {}
+func (*Histogram) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{2} }
+
+type isHistogram_Count interface {
+	isHistogram_Count()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+type isHistogram_ZeroCount interface {
+	isHistogram_ZeroCount()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type Histogram_CountInt struct {
+	CountInt uint64 `protobuf:"varint,1,opt,name=count_int,json=countInt,proto3,oneof"`
+}
+type Histogram_CountFloat struct {
+	CountFloat float64 `protobuf:"fixed64,2,opt,name=count_float,json=countFloat,proto3,oneof"`
+}
+type Histogram_ZeroCountInt struct {
+	ZeroCountInt uint64 `protobuf:"varint,6,opt,name=zero_count_int,json=zeroCountInt,proto3,oneof"`
+}
+type Histogram_ZeroCountFloat struct {
+	ZeroCountFloat float64 `protobuf:"fixed64,7,opt,name=zero_count_float,json=zeroCountFloat,proto3,oneof"`
+}
+
+func (*Histogram_CountInt) isHistogram_Count()           {}
+func (*Histogram_CountFloat) isHistogram_Count()         {}
+func (*Histogram_ZeroCountInt) isHistogram_ZeroCount()   {}
+func (*Histogram_ZeroCountFloat) isHistogram_ZeroCount() {}
+
+func (m *Histogram) GetCount() isHistogram_Count {
+	if m != nil {
+		return m.Count
+	}
+	return nil
+}
+func (m *Histogram) GetZeroCount() isHistogram_ZeroCount {
+	if m != nil {
+		return m.ZeroCount
+	}
+	return nil
+}
+
+func (m *Histogram) GetCountInt() uint64 {
+	if x, ok := m.GetCount().(*Histogram_CountInt); ok {
+		return x.CountInt
+	}
+	return 0
+}
+
+func (m *Histogram) GetCountFloat() float64 {
+	if x, ok := m.GetCount().(*Histogram_CountFloat); ok {
+		return x.CountFloat
+	}
+	return 0
+}
+
+func (m *Histogram) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *Histogram) GetSchema() int32 {
+	if m != nil {
+		return m.Schema
+	}
+	return 0
+}
+
+func (m *Histogram) GetZeroThreshold() float64 {
+	if m != nil {
+		return m.ZeroThreshold
+	}
+	return 0
+}
+
+func (m *Histogram) GetZeroCountInt() uint64 {
+	if x, ok := m.GetZeroCount().(*Histogram_ZeroCountInt); ok {
+		return x.ZeroCountInt
+	}
+	return 0
+}
+
+func (m *Histogram) GetZeroCountFloat() float64 {
+	if x, ok := m.GetZeroCount().(*Histogram_ZeroCountFloat); ok {
+		return x.ZeroCountFloat
+	}
+	return 0
+}
+
+func (m *Histogram) GetNegativeSpans() []BucketSpan {
+	if m != nil {
+		return m.NegativeSpans
+	}
+	return nil
+}
+
+func (m *Histogram) GetNegativeDeltas() []int64 {
+	if m != nil {
+		return m.NegativeDeltas
+	}
+	return nil
+}
+
+func (m *Histogram) GetNegativeCounts() []float64 {
+	if m != nil {
+		return m.NegativeCounts
+	}
+	return nil
+}
+
+func (m *Histogram) GetPositiveSpans() []BucketSpan {
+	if m != nil {
+		return m.PositiveSpans
+	}
+	return nil
+}
+
+func (m *Histogram) GetPositiveDeltas() []int64 {
+	if m != nil {
+		return m.PositiveDeltas
+	}
+	return nil
+}
+
+func (m *Histogram) GetPositiveCounts() []float64 {
+	if m != nil {
+		return m.PositiveCounts
+	}
+	return nil
+}
+
+func (m *Histogram) GetResetHint() Histogram_ResetHint {
+	if m != nil {
+		return m.ResetHint
+	}
+	return Histogram_UNKNOWN
+}
+
+func (m *Histogram) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Histogram) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Histogram_OneofMarshaler, _Histogram_OneofUnmarshaler, _Histogram_OneofSizer, []interface{}{
+		(*Histogram_CountInt)(nil),
+		(*Histogram_CountFloat)(nil),
+		(*Histogram_ZeroCountInt)(nil),
+		(*Histogram_ZeroCountFloat)(nil),
+	}
+}
+
+func _Histogram_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Histogram)
+	// count
+	switch x := m.Count.(type) {
+	case *Histogram_CountInt:
+		_ = b.EncodeVarint(1<<3 | proto.WireVarint)
+		_ = b.EncodeVarint(uint64(x.CountInt))
+	case *Histogram_CountFloat:
+		_ = b.EncodeVarint(2<<3 | proto.WireFixed64)
+		_ = b.EncodeFixed64(math.Float64bits(x.CountFloat))
+	case nil:
+	default:
+		return fmt.Errorf("Histogram.Count has unexpected type %T", x)
+	}
+	// zero_count
+	switch x := m.ZeroCount.(type) {
+	case *Histogram_ZeroCountInt:
+		_ = b.EncodeVarint(6<<3 | proto.WireVarint)
+		_ = b.EncodeVarint(uint64(x.ZeroCountInt))
+	case *Histogram_ZeroCountFloat:
+		_ = b.EncodeVarint(7<<3 | proto.WireFixed64)
+		_ = b.EncodeFixed64(math.Float64bits(x.ZeroCountFloat))
+	case nil:
+	default:
+		return fmt.Errorf("Histogram.ZeroCount has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Histogram_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Histogram)
+	switch tag {
+	case 1: // count.count_int
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Count = &Histogram_CountInt{x}
+		return true, err
+	case 2: // count.count_float
+		if wire != proto.WireFixed64 {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeFixed64()
+		m.Count = &Histogram_CountFloat{math.Float64frombits(x)}
+		return true, err
+	case 6: // zero_count.zero_count_int
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.ZeroCount = &Histogram_ZeroCountInt{x}
+		return true, err
+	case 7: // zero_count.zero_count_float
+		if wire != proto.WireFixed64 {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeFixed64()
+		m.ZeroCount = &Histogram_ZeroCountFloat{math.Float64frombits(x)}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Histogram_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Histogram)
+	// count
+	switch x := m.Count.(type) {
+	case *Histogram_CountInt:
+		n += proto.SizeVarint(1<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.CountInt))
+	case *Histogram_CountFloat:
+		n += proto.SizeVarint(2<<3 | proto.WireFixed64)
+		n += 8
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	// zero_count
+	switch x := m.ZeroCount.(type) {
+	case *Histogram_ZeroCountInt:
+		n += proto.SizeVarint(6<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.ZeroCountInt))
+	case *Histogram_ZeroCountFloat:
+		n += proto.SizeVarint(7<<3 | proto.WireFixed64)
+		n += 8
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// A BucketSpan defines a number of consecutive buckets with their
+// offset. Logically, it would be more straightforward to include the
+// bucket counts in the Span. However, the protobuf representation is
+// more compact in the way the data is structured here (with all the
+// buckets in a single array separate from the Spans).
+type BucketSpan struct {
+	Offset int32  `protobuf:"zigzag32,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length uint32 `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+}
+
+func (m *BucketSpan) Reset()                    { *m = BucketSpan{} }
+func (m *BucketSpan) String() string            { return proto.CompactTextString(m) }
+func (*BucketSpan) ProtoMessage()               {}
+func (*BucketSpan) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{3} }
+
+func (m *BucketSpan) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *BucketSpan) GetLength() uint32 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
 type Label struct {
 	Name  []byte `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -249,7 +608,7 @@ type Label struct {
 func (m *Label) Reset()                    { *m = Label{} }
 func (m *Label) String() string            { return proto.CompactTextString(m) }
 func (*Label) ProtoMessage()               {}
-func (*Label) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{2} }
+func (*Label) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{4} }
 
 func (m *Label) GetName() []byte {
 	if m != nil {
@@ -272,7 +631,7 @@ type Labels struct {
 func (m *Labels) Reset()                    { *m = Labels{} }
 func (m *Labels) String() string            { return proto.CompactTextString(m) }
 func (*Labels) ProtoMessage()               {}
-func (*Labels) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{3} }
+func (*Labels) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{5} }
 
 func (m *Labels) GetLabels() []Label {
 	if m != nil {
@@ -291,7 +650,7 @@ type LabelMatcher struct {
 func (m *LabelMatcher) Reset()                    { *m = LabelMatcher{} }
 func (m *LabelMatcher) String() string            { return proto.CompactTextString(m) }
 func (*LabelMatcher) ProtoMessage()               {}
-func (*LabelMatcher) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{4} }
+func (*LabelMatcher) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{6} }
 
 func (m *LabelMatcher) GetType() LabelMatcher_Type {
 	if m != nil {
@@ -317,12 +676,15 @@ func (m *LabelMatcher) GetValue() []byte {
 func init() {
 	proto.RegisterType((*Sample)(nil), "m3prometheus.Sample")
 	proto.RegisterType((*TimeSeries)(nil), "m3prometheus.TimeSeries")
+	proto.RegisterType((*Histogram)(nil), "m3prometheus.Histogram")
+	proto.RegisterType((*BucketSpan)(nil), "m3prometheus.BucketSpan")
 	proto.RegisterType((*Label)(nil), "m3prometheus.Label")
 	proto.RegisterType((*Labels)(nil), "m3prometheus.Labels")
 	proto.RegisterType((*LabelMatcher)(nil), "m3prometheus.LabelMatcher")
 	proto.RegisterEnum("m3prometheus.MetricType", MetricType_name, MetricType_value)
 	proto.RegisterEnum("m3prometheus.M3Type", M3Type_name, M3Type_value)
 	proto.RegisterEnum("m3prometheus.Source", Source_name, Source_value)
+	proto.RegisterEnum("m3prometheus.Histogram_ResetHint", Histogram_ResetHint_name, Histogram_ResetHint_value)
 	proto.RegisterEnum("m3prometheus.LabelMatcher_Type", LabelMatcher_Type_name, LabelMatcher_Type_value)
 }
 func (m *Sample) Marshal() (dAtA []byte, err error) {
@@ -393,11 +755,17 @@ func (m *TimeSeries) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	if len(m.Unit) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Unit)))
-		i += copy(dAtA[i:], m.Unit)
+	if len(m.Histograms) > 0 {
+		for _, msg := range m.Histograms {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	if len(m.Help) > 0 {
 		dAtA[i] = 0x2a
@@ -426,10 +794,18 @@ func (m *TimeSeries) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
 	}
+	if len(m.Unit) > 0 {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Unit)))
+		i += copy(dAtA[i:], m.Unit)
+	}
 	return i, nil
 }
 
-func (m *Label) Marshal() (dAtA []byte, err error) {
+func (m *Histogram) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -439,27 +815,166 @@ func (m *Label) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Label) MarshalTo(dAtA []byte) (int, error) {
+func (m *Histogram) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
+	if m.Count != nil {
+		nn1, err := m.Count.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn1
+	}
+	if m.Sum != 0 {
+		dAtA[i] = 0x19
 		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Sum))))
+		i += 8
 	}
-	if len(m.Value) > 0 {
-		dAtA[i] = 0x12
+	if m.Schema != 0 {
+		dAtA[i] = 0x20
 		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
-		i += copy(dAtA[i:], m.Value)
+		i = encodeVarintTypes(dAtA, i, uint64((uint32(m.Schema)<<1)^uint32((m.Schema>>31))))
+	}
+	if m.ZeroThreshold != 0 {
+		dAtA[i] = 0x29
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ZeroThreshold))))
+		i += 8
+	}
+	if m.ZeroCount != nil {
+		nn2, err := m.ZeroCount.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn2
+	}
+	if len(m.NegativeSpans) > 0 {
+		for _, msg := range m.NegativeSpans {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.NegativeDeltas) > 0 {
+		var j3 int
+		dAtA5 := make([]byte, len(m.NegativeDeltas)*10)
+		for _, num := range m.NegativeDeltas {
+			x4 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x4 >= 1<<7 {
+				dAtA5[j3] = uint8(uint64(x4)&0x7f | 0x80)
+				j3++
+				x4 >>= 7
+			}
+			dAtA5[j3] = uint8(x4)
+			j3++
+		}
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(j3))
+		i += copy(dAtA[i:], dAtA5[:j3])
+	}
+	if len(m.NegativeCounts) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.NegativeCounts)*8))
+		for _, num := range m.NegativeCounts {
+			f6 := math.Float64bits(float64(num))
+			binary.LittleEndian.PutUint64(dAtA[i:], uint64(f6))
+			i += 8
+		}
+	}
+	if len(m.PositiveSpans) > 0 {
+		for _, msg := range m.PositiveSpans {
+			dAtA[i] = 0x5a
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.PositiveDeltas) > 0 {
+		var j7 int
+		dAtA9 := make([]byte, len(m.PositiveDeltas)*10)
+		for _, num := range m.PositiveDeltas {
+			x8 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x8 >= 1<<7 {
+				dAtA9[j7] = uint8(uint64(x8)&0x7f | 0x80)
+				j7++
+				x8 >>= 7
+			}
+			dAtA9[j7] = uint8(x8)
+			j7++
+		}
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(j7))
+		i += copy(dAtA[i:], dAtA9[:j7])
+	}
+	if len(m.PositiveCounts) > 0 {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.PositiveCounts)*8))
+		for _, num := range m.PositiveCounts {
+			f10 := math.Float64bits(float64(num))
+			binary.LittleEndian.PutUint64(dAtA[i:], uint64(f10))
+			i += 8
+		}
+	}
+	if m.ResetHint != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ResetHint))
+	}
+	if m.Timestamp != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Timestamp))
 	}
 	return i, nil
 }
 
-func (m *Labels) Marshal() (dAtA []byte, err error) {
+func (m *Histogram_CountInt) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x8
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.CountInt))
+	return i, nil
+}
+func (m *Histogram_CountFloat) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x11
+	i++
+	binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.CountFloat))))
+	i += 8
+	return i, nil
+}
+func (m *Histogram_ZeroCountInt) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x30
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.ZeroCountInt))
+	return i, nil
+}
+func (m *Histogram_ZeroCountFloat) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x39
+	i++
+	binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ZeroCountFloat))))
+	i += 8
+	return i, nil
+}
+func (m *BucketSpan) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -469,27 +984,25 @@ func (m *Labels) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Labels) MarshalTo(dAtA []byte) (int, error) {
+func (m *BucketSpan) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Labels) > 0 {
-		for _, msg := range m.Labels {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if m.Offset != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64((uint32(m.Offset)<<1)^uint32((m.Offset>>31))))
+	}
+	if m.Length != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Length))
 	}
 	return i, nil
 }
 
-func (m *LabelMatcher) Marshal() (dAtA []byte, err error) {
+func (m *Label) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -499,18 +1012,78 @@ func (m *LabelMatcher) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LabelMatcher) MarshalTo(dAtA []byte) (int, error) {
+func (m *Label) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Type != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
-	}
 	if len(m.Name) > 0 {
-		dAtA[i] = 0x12
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	return i, nil
+}
+
+func (m *Labels) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Labels) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, msg := range m.Labels {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *LabelMatcher) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelMatcher) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Type != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
 		i++
 		i = encodeVarintTypes(dAtA, i, uint64(len(m.Name)))
 		i += copy(dAtA[i:], m.Name)
@@ -560,9 +1133,11 @@ func (m *TimeSeries) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
-	l = len(m.Unit)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if len(m.Histograms) > 0 {
+		for _, e := range m.Histograms {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
 	}
 	l = len(m.Help)
 	if l > 0 {
@@ -577,6 +1152,105 @@ func (m *TimeSeries) Size() (n int) {
 	if m.Type != 0 {
 		n += 2 + sovTypes(uint64(m.Type))
 	}
+	l = len(m.Unit)
+	if l > 0 {
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *Histogram) Size() (n int) {
+	var l int
+	_ = l
+	if m.Count != nil {
+		n += m.Count.Size()
+	}
+	if m.Sum != 0 {
+		n += 9
+	}
+	if m.Schema != 0 {
+		n += 1 + sozTypes(uint64(m.Schema))
+	}
+	if m.ZeroThreshold != 0 {
+		n += 9
+	}
+	if m.ZeroCount != nil {
+		n += m.ZeroCount.Size()
+	}
+	if len(m.NegativeSpans) > 0 {
+		for _, e := range m.NegativeSpans {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.NegativeDeltas) > 0 {
+		l = 0
+		for _, e := range m.NegativeDeltas {
+			l += sozTypes(uint64(e))
+		}
+		n += 1 + sovTypes(uint64(l)) + l
+	}
+	if len(m.NegativeCounts) > 0 {
+		n += 1 + sovTypes(uint64(len(m.NegativeCounts)*8)) + len(m.NegativeCounts)*8
+	}
+	if len(m.PositiveSpans) > 0 {
+		for _, e := range m.PositiveSpans {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.PositiveDeltas) > 0 {
+		l = 0
+		for _, e := range m.PositiveDeltas {
+			l += sozTypes(uint64(e))
+		}
+		n += 1 + sovTypes(uint64(l)) + l
+	}
+	if len(m.PositiveCounts) > 0 {
+		n += 1 + sovTypes(uint64(len(m.PositiveCounts)*8)) + len(m.PositiveCounts)*8
+	}
+	if m.ResetHint != 0 {
+		n += 1 + sovTypes(uint64(m.ResetHint))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovTypes(uint64(m.Timestamp))
+	}
+	return n
+}
+
+func (m *Histogram_CountInt) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovTypes(uint64(m.CountInt))
+	return n
+}
+func (m *Histogram_CountFloat) Size() (n int) {
+	var l int
+	_ = l
+	n += 9
+	return n
+}
+func (m *Histogram_ZeroCountInt) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovTypes(uint64(m.ZeroCountInt))
+	return n
+}
+func (m *Histogram_ZeroCountFloat) Size() (n int) {
+	var l int
+	_ = l
+	n += 9
+	return n
+}
+func (m *BucketSpan) Size() (n int) {
+	var l int
+	_ = l
+	if m.Offset != 0 {
+		n += 1 + sozTypes(uint64(m.Offset))
+	}
+	if m.Length != 0 {
+		n += 1 + sovTypes(uint64(m.Length))
+	}
 	return n
 }
 
@@ -809,9 +1483,9 @@ func (m *TimeSeries) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Unit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Histograms", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -821,20 +1495,22 @@ func (m *TimeSeries) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Unit = string(dAtA[iNdEx:postIndex])
+			m.Histograms = append(m.Histograms, Histogram{})
+			if err := m.Histograms[len(m.Histograms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
@@ -922,6 +1598,600 @@ func (m *TimeSeries) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 104:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Unit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Histogram) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Histogram: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Histogram: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CountInt", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Count = &Histogram_CountInt{v}
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CountFloat", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Count = &Histogram_CountFloat{float64(math.Float64frombits(v))}
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sum", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Sum = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schema", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			v = int32((uint32(v) >> 1) ^ uint32(((v&1)<<31)>>31))
+			m.Schema = v
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ZeroThreshold", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.ZeroThreshold = float64(math.Float64frombits(v))
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ZeroCountInt", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ZeroCount = &Histogram_ZeroCountInt{v}
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ZeroCountFloat", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.ZeroCount = &Histogram_ZeroCountFloat{float64(math.Float64frombits(v))}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NegativeSpans", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NegativeSpans = append(m.NegativeSpans, BucketSpan{})
+			if err := m.NegativeSpans[len(m.NegativeSpans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
+				m.NegativeDeltas = append(m.NegativeDeltas, int64(v))
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
+					m.NegativeDeltas = append(m.NegativeDeltas, int64(v))
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NegativeDeltas", wireType)
+			}
+		case 10:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.NegativeCounts = append(m.NegativeCounts, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.NegativeCounts = append(m.NegativeCounts, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NegativeCounts", wireType)
+			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PositiveSpans", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PositiveSpans = append(m.PositiveSpans, BucketSpan{})
+			if err := m.PositiveSpans[len(m.PositiveSpans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
+				m.PositiveDeltas = append(m.PositiveDeltas, int64(v))
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
+					m.PositiveDeltas = append(m.PositiveDeltas, int64(v))
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field PositiveDeltas", wireType)
+			}
+		case 13:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.PositiveCounts = append(m.PositiveCounts, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.PositiveCounts = append(m.PositiveCounts, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field PositiveCounts", wireType)
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResetHint", wireType)
+			}
+			m.ResetHint = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResetHint |= (Histogram_ResetHint(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BucketSpan) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BucketSpan: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BucketSpan: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			v = int32((uint32(v) >> 1) ^ uint32(((v&1)<<31)>>31))
+			m.Offset = v
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+			}
+			m.Length = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Length |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1377,43 +2647,65 @@ func init() {
 }
 
 var fileDescriptorTypes = []byte{
-	// 603 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xcd, 0x6e, 0xd3, 0x4c,
-	0x14, 0xcd, 0x38, 0x8e, 0xd3, 0xdc, 0xe6, 0xeb, 0x37, 0x9a, 0x76, 0x61, 0x21, 0x94, 0x46, 0x59,
-	0x45, 0x55, 0x1b, 0xab, 0xb8, 0x0b, 0x24, 0x90, 0x50, 0x5a, 0x0d, 0x69, 0x44, 0xed, 0xb4, 0x63,
-	0x47, 0x08, 0x36, 0x91, 0x93, 0x4e, 0x93, 0x48, 0x99, 0xda, 0xf8, 0x07, 0xa9, 0x3c, 0x05, 0x3b,
-	0x5e, 0x81, 0x47, 0xe9, 0x92, 0x27, 0x40, 0xa8, 0xbc, 0x08, 0x9a, 0x99, 0x80, 0x4b, 0xd5, 0x0d,
-	0x9b, 0xe4, 0xde, 0x73, 0xcf, 0x3d, 0x73, 0x7c, 0x46, 0x03, 0xaf, 0xe6, 0xcb, 0x7c, 0x51, 0x4c,
-	0x7b, 0xb3, 0x58, 0x38, 0xc2, 0xbd, 0x9c, 0x3a, 0xc2, 0x75, 0xb2, 0x74, 0xe6, 0x7c, 0x28, 0x78,
-	0x7a, 0xe3, 0xcc, 0xf9, 0x35, 0x4f, 0xa3, 0x9c, 0x5f, 0x3a, 0x49, 0x1a, 0xe7, 0xb1, 0xfc, 0x15,
-	0xc9, 0xd4, 0xc9, 0x6f, 0x12, 0x9e, 0xf5, 0x14, 0x44, 0x9a, 0xc2, 0x95, 0x28, 0xcf, 0x17, 0xbc,
-	0xc8, 0x9e, 0x1c, 0xdc, 0x93, 0x9b, 0xc7, 0xf3, 0x58, 0xef, 0x4d, 0x8b, 0x2b, 0xd5, 0x69, 0x11,
-	0x59, 0xe9, 0xe5, 0xce, 0x4b, 0xb0, 0x82, 0x48, 0x24, 0x2b, 0x4e, 0x76, 0xa0, 0xf6, 0x31, 0x5a,
-	0x15, 0xdc, 0x46, 0x6d, 0xd4, 0x45, 0x4c, 0x37, 0xe4, 0x29, 0x34, 0xf2, 0xa5, 0xe0, 0x59, 0x1e,
-	0x89, 0xc4, 0x36, 0xda, 0xa8, 0x5b, 0x65, 0x25, 0xd0, 0xf9, 0x6a, 0x00, 0x84, 0x4b, 0xc1, 0x03,
-	0x9e, 0x2e, 0x79, 0x46, 0x0e, 0xc1, 0x5a, 0x45, 0x53, 0xbe, 0xca, 0x6c, 0xd4, 0xae, 0x76, 0x37,
-	0x9f, 0x6d, 0xf7, 0xee, 0x5b, 0xeb, 0x9d, 0xc9, 0xd9, 0xb1, 0x79, 0xfb, 0x7d, 0xb7, 0xc2, 0xd6,
-	0x44, 0x72, 0x04, 0xf5, 0x4c, 0x9d, 0x9f, 0xd9, 0x86, 0xda, 0xd9, 0xf9, 0x7b, 0x47, 0x9b, 0x5b,
-	0x2f, 0xfd, 0xa6, 0x12, 0x02, 0x66, 0x71, 0xbd, 0xcc, 0x6d, 0xb3, 0x8d, 0xba, 0x0d, 0xa6, 0x6a,
-	0x89, 0x2d, 0xf8, 0x2a, 0xb1, 0x6b, 0x1a, 0x93, 0x35, 0x39, 0x80, 0xba, 0x70, 0x27, 0x32, 0x2c,
-	0x9b, 0xb7, 0x51, 0x77, 0xeb, 0xa1, 0xba, 0xe7, 0x86, 0x37, 0x09, 0x67, 0x96, 0x50, 0xff, 0x64,
-	0x1f, 0xac, 0x2c, 0x2e, 0xd2, 0x19, 0xb7, 0xaf, 0x1e, 0x63, 0x07, 0x6a, 0xc6, 0xd6, 0x1c, 0xb2,
-	0x0f, 0xa6, 0x52, 0x9e, 0x2b, 0xae, 0xfd, 0x40, 0x99, 0xe7, 0xe9, 0x72, 0xa6, 0xd4, 0x15, 0xab,
-	0x73, 0x08, 0x35, 0xf5, 0xfd, 0xd2, 0xe7, 0x75, 0x24, 0x74, 0xcc, 0x4d, 0xa6, 0xea, 0x32, 0x7b,
-	0x43, 0x81, 0xba, 0xe9, 0xbc, 0x00, 0xeb, 0x4c, 0xa7, 0xf4, 0xef, 0xc1, 0x76, 0xbe, 0x20, 0x68,
-	0x2a, 0xdc, 0x8b, 0xf2, 0xd9, 0x82, 0xa7, 0xc4, 0x5d, 0xdb, 0x45, 0xca, 0xee, 0xee, 0x23, 0x0a,
-	0x6b, 0x66, 0xaf, 0x74, 0xfd, 0xc7, 0xac, 0xf1, 0x98, 0xd9, 0xea, 0x7d, 0xb3, 0x5d, 0x30, 0x55,
-	0x86, 0x16, 0x18, 0xf4, 0x02, 0x57, 0x48, 0x1d, 0xaa, 0x3e, 0xbd, 0xc0, 0x48, 0x02, 0x8c, 0x62,
-	0x43, 0x01, 0x8c, 0xe2, 0xea, 0xde, 0x27, 0x80, 0x32, 0x1d, 0xb2, 0x09, 0xf5, 0xb1, 0xff, 0xc6,
-	0x1f, 0xbd, 0xf5, 0x71, 0x45, 0x36, 0x27, 0xa3, 0xb1, 0x1f, 0x52, 0x86, 0x11, 0x69, 0x40, 0x6d,
-	0xd0, 0x1f, 0x0f, 0xe4, 0xee, 0x7f, 0xd0, 0x38, 0x1d, 0x06, 0xe1, 0x68, 0xc0, 0xfa, 0x1e, 0xae,
-	0x92, 0x6d, 0xf8, 0x5f, 0x4d, 0x26, 0x25, 0x68, 0xca, 0xdd, 0x60, 0xec, 0x79, 0x7d, 0xf6, 0x0e,
-	0xd7, 0xc8, 0x06, 0x98, 0x43, 0xff, 0xf5, 0x08, 0x5b, 0xa4, 0x09, 0x1b, 0x41, 0xd8, 0x0f, 0x69,
-	0x40, 0x43, 0x5c, 0xdf, 0x3b, 0x02, 0x4b, 0xdf, 0xb9, 0xc4, 0x3d, 0x77, 0xa2, 0x0f, 0xa8, 0x90,
-	0x2d, 0x00, 0xcf, 0x9d, 0x94, 0x67, 0xeb, 0x69, 0x38, 0xf4, 0x28, 0xc3, 0xc6, 0xde, 0x73, 0xb0,
-	0xf4, 0xdd, 0x4b, 0xde, 0x39, 0x1b, 0x79, 0x34, 0x3c, 0xa5, 0xe3, 0x00, 0x57, 0x24, 0x6f, 0xc0,
-	0xfa, 0xe7, 0xa7, 0xc3, 0x90, 0x62, 0x44, 0x30, 0x34, 0x47, 0xe7, 0xd4, 0x9f, 0x78, 0x34, 0x64,
-	0xc3, 0x93, 0x00, 0x1b, 0xc7, 0xf6, 0xed, 0x5d, 0x0b, 0x7d, 0xbb, 0x6b, 0xa1, 0x1f, 0x77, 0x2d,
-	0xf4, 0xf9, 0x67, 0xab, 0xf2, 0xde, 0xd2, 0x2f, 0x78, 0x6a, 0xa9, 0xf7, 0xe7, 0xfe, 0x0a, 0x00,
-	0x00, 0xff, 0xff, 0x4a, 0x50, 0x1d, 0x3f, 0xff, 0x03, 0x00, 0x00,
+	// 954 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x95, 0xdf, 0x6e, 0xe3, 0x44,
+	0x14, 0xc6, 0x33, 0x76, 0xe2, 0x34, 0xa7, 0x69, 0xea, 0x9d, 0x5d, 0x81, 0x85, 0xa0, 0x9b, 0x8d,
+	0x04, 0x44, 0xd5, 0x6e, 0xa2, 0x25, 0xbd, 0x40, 0x62, 0x11, 0xb4, 0xc5, 0xdb, 0x44, 0xac, 0x93,
+	0xee, 0xd8, 0x15, 0x5a, 0x6e, 0x22, 0x27, 0x9d, 0xc4, 0x16, 0xf1, 0x1f, 0x3c, 0xe3, 0x95, 0xba,
+	0x4f, 0xc1, 0x1d, 0xaf, 0xb4, 0x97, 0xf0, 0x02, 0x08, 0x95, 0x0b, 0x5e, 0x03, 0xcd, 0x8c, 0x13,
+	0x27, 0x55, 0x91, 0xe0, 0xa6, 0x9d, 0xf9, 0xe6, 0x3b, 0xc7, 0xbf, 0x9c, 0xe3, 0x39, 0x86, 0x6f,
+	0x96, 0x21, 0x0f, 0xf2, 0x59, 0x6f, 0x9e, 0x44, 0xfd, 0x68, 0x70, 0x3d, 0xeb, 0x47, 0x83, 0x3e,
+	0xcb, 0xe6, 0xfd, 0x9f, 0x73, 0x9a, 0xdd, 0xf4, 0x97, 0x34, 0xa6, 0x99, 0xcf, 0xe9, 0x75, 0x3f,
+	0xcd, 0x12, 0x9e, 0x88, 0xbf, 0x51, 0x3a, 0xeb, 0xf3, 0x9b, 0x94, 0xb2, 0x9e, 0x94, 0x70, 0x33,
+	0x1a, 0x08, 0x95, 0xf2, 0x80, 0xe6, 0xec, 0xa3, 0x67, 0x5b, 0xe9, 0x96, 0xc9, 0x32, 0x51, 0x71,
+	0xb3, 0x7c, 0x21, 0x77, 0x2a, 0x89, 0x58, 0xa9, 0xe0, 0xce, 0x0b, 0x30, 0x5c, 0x3f, 0x4a, 0x57,
+	0x14, 0x3f, 0x82, 0xda, 0x5b, 0x7f, 0x95, 0x53, 0x0b, 0xb5, 0x51, 0x17, 0x11, 0xb5, 0xc1, 0x1f,
+	0x43, 0x83, 0x87, 0x11, 0x65, 0xdc, 0x8f, 0x52, 0x4b, 0x6b, 0xa3, 0xae, 0x4e, 0x4a, 0xa1, 0xf3,
+	0xb7, 0x06, 0xe0, 0x85, 0x11, 0x75, 0x69, 0x16, 0x52, 0x86, 0x9f, 0x83, 0xb1, 0xf2, 0x67, 0x74,
+	0xc5, 0x2c, 0xd4, 0xd6, 0xbb, 0xfb, 0x5f, 0x3c, 0xec, 0x6d, 0xa3, 0xf5, 0x5e, 0x89, 0xb3, 0xb3,
+	0xea, 0xfb, 0x3f, 0x1e, 0x57, 0x48, 0x61, 0xc4, 0x27, 0x50, 0x67, 0xf2, 0xf9, 0xcc, 0xd2, 0x64,
+	0xcc, 0xa3, 0xdd, 0x18, 0x05, 0x57, 0x04, 0xad, 0xad, 0xf8, 0x6b, 0x80, 0x20, 0x64, 0x3c, 0x59,
+	0x66, 0x7e, 0xc4, 0xac, 0xaa, 0x0c, 0xfc, 0x70, 0x37, 0x70, 0xb8, 0x3e, 0x2f, 0x62, 0xb7, 0x02,
+	0x30, 0x86, 0x6a, 0x40, 0x57, 0xa9, 0x55, 0x6b, 0xa3, 0x6e, 0x83, 0xc8, 0x35, 0x7e, 0x06, 0xf5,
+	0x68, 0x30, 0x15, 0x75, 0xb5, 0x68, 0x1b, 0x75, 0x5b, 0x77, 0x41, 0x9c, 0x81, 0x77, 0x93, 0x52,
+	0x62, 0x44, 0xf2, 0x3f, 0x7e, 0x0a, 0x06, 0x4b, 0xf2, 0x6c, 0x4e, 0xad, 0xc5, 0x7d, 0x6e, 0x57,
+	0x9e, 0x91, 0xc2, 0x83, 0x9f, 0x42, 0x55, 0x66, 0x5e, 0x4a, 0xaf, 0x75, 0x27, 0x33, 0xe5, 0x59,
+	0x38, 0x97, 0xd9, 0xa5, 0x4b, 0xe0, 0xe5, 0x71, 0xc8, 0xad, 0x40, 0xe1, 0x89, 0x75, 0xe7, 0xf7,
+	0x1a, 0x34, 0x36, 0x3f, 0x09, 0x7f, 0x02, 0x8d, 0x79, 0x92, 0xc7, 0x7c, 0x1a, 0xc6, 0x5c, 0xf6,
+	0xab, 0x3a, 0xac, 0x90, 0x3d, 0x29, 0x8d, 0x62, 0x8e, 0x9f, 0xc0, 0xbe, 0x3a, 0x5e, 0xac, 0x12,
+	0x9f, 0xcb, 0xb6, 0xa1, 0x61, 0x85, 0x80, 0x14, 0x5f, 0x0a, 0x0d, 0x9b, 0xa0, 0xb3, 0x3c, 0xb2,
+	0x74, 0xd9, 0x6b, 0xb1, 0xc4, 0x1f, 0x80, 0xc1, 0xe6, 0x01, 0x8d, 0x7c, 0xab, 0xda, 0x46, 0xdd,
+	0x07, 0xa4, 0xd8, 0xe1, 0x4f, 0xa1, 0xf5, 0x8e, 0x66, 0xc9, 0x94, 0x07, 0x19, 0x65, 0x41, 0xb2,
+	0xba, 0x96, 0x65, 0x43, 0xe4, 0x40, 0xa8, 0xde, 0x5a, 0xc4, 0x9f, 0x15, 0xb6, 0x92, 0xcb, 0x90,
+	0x5c, 0x88, 0x34, 0x85, 0x7e, 0xbe, 0x66, 0x3b, 0x06, 0x73, 0xcb, 0xa7, 0x00, 0xeb, 0x12, 0x10,
+	0x91, 0xd6, 0xc6, 0xa9, 0x20, 0x6d, 0x68, 0xc5, 0x74, 0xe9, 0xf3, 0xf0, 0x2d, 0x9d, 0xb2, 0xd4,
+	0x8f, 0x99, 0xb5, 0x27, 0x5b, 0x7d, 0xa7, 0x80, 0x67, 0xf9, 0xfc, 0x27, 0xca, 0xdd, 0xd4, 0x8f,
+	0x8b, 0x5e, 0x1f, 0xac, 0xa3, 0x84, 0xc6, 0xf0, 0xe7, 0x70, 0xb8, 0x49, 0x73, 0x4d, 0x57, 0xdc,
+	0x67, 0x56, 0xa3, 0xad, 0x77, 0x31, 0xd9, 0x64, 0xff, 0x4e, 0xaa, 0x3b, 0x46, 0xc9, 0xc7, 0x2c,
+	0x68, 0xeb, 0x5d, 0x54, 0x1a, 0x25, 0x1c, 0x13, 0x60, 0x69, 0xc2, 0xc2, 0x2d, 0xb0, 0xfd, 0xff,
+	0x06, 0xb6, 0x8e, 0xda, 0x80, 0x6d, 0xd2, 0x14, 0x60, 0x4d, 0x05, 0xb6, 0x96, 0x4b, 0xb0, 0x8d,
+	0xb1, 0x00, 0x3b, 0x50, 0x60, 0x6b, 0xb9, 0x00, 0xfb, 0x16, 0x20, 0xa3, 0x8c, 0xf2, 0x69, 0x20,
+	0x3a, 0xd0, 0x92, 0xaf, 0xdb, 0x93, 0x7f, 0xb9, 0x18, 0x3d, 0x22, 0x9c, 0xc3, 0x30, 0xe6, 0xa4,
+	0x91, 0xad, 0x97, 0xbb, 0x17, 0xfe, 0xf0, 0xee, 0x85, 0x3f, 0x81, 0xc6, 0x26, 0x0a, 0xef, 0x43,
+	0xfd, 0x6a, 0xfc, 0xfd, 0x78, 0xf2, 0xc3, 0xd8, 0xac, 0xe0, 0x3a, 0xe8, 0x6f, 0x6c, 0xd7, 0x44,
+	0xd8, 0x00, 0x6d, 0x3c, 0x31, 0x35, 0xdc, 0x80, 0xda, 0xc5, 0xe9, 0xd5, 0x85, 0x6d, 0xea, 0x67,
+	0x75, 0xa8, 0x49, 0xea, 0xb3, 0x26, 0x40, 0xd9, 0xfc, 0xce, 0x0b, 0x80, 0xb2, 0x42, 0xe2, 0xfd,
+	0x4b, 0x16, 0x0b, 0x46, 0xd5, 0x0b, 0xfd, 0x80, 0x14, 0x3b, 0xa1, 0xaf, 0x68, 0xbc, 0xe4, 0x81,
+	0x7c, 0x8f, 0x0f, 0x48, 0xb1, 0xeb, 0x3c, 0x87, 0x9a, 0x1c, 0x28, 0xe2, 0xba, 0xc4, 0x7e, 0xa4,
+	0xe6, 0x56, 0x93, 0xc8, 0x75, 0x39, 0xcc, 0x34, 0x29, 0xaa, 0x4d, 0xe7, 0x2b, 0x30, 0x5e, 0xa9,
+	0xb1, 0xf3, 0xff, 0x27, 0x55, 0xe7, 0x57, 0x04, 0x4d, 0xa9, 0x3b, 0x3e, 0x9f, 0x07, 0x34, 0xc3,
+	0x83, 0xe2, 0x52, 0x23, 0x59, 0xe5, 0xc7, 0xf7, 0x64, 0x28, 0x9c, 0xbd, 0xdd, 0xbb, 0x2d, 0x61,
+	0xb5, 0xfb, 0x60, 0xf5, 0x6d, 0xd8, 0x2e, 0x54, 0x45, 0x9c, 0xa8, 0xa7, 0xfd, 0x5a, 0x15, 0x78,
+	0x6c, 0xbf, 0x56, 0x05, 0x26, 0xb6, 0xa9, 0x49, 0x81, 0xd8, 0xa6, 0x7e, 0xfc, 0x0e, 0xa0, 0x9c,
+	0x21, 0xbb, 0x5d, 0xd9, 0x87, 0xfa, 0xf9, 0xe4, 0x6a, 0xec, 0xd9, 0xc4, 0x44, 0x65, 0x47, 0x34,
+	0x7c, 0x00, 0x8d, 0xe1, 0xc8, 0xf5, 0x26, 0x17, 0xe4, 0xd4, 0x31, 0x75, 0xfc, 0x10, 0x0e, 0xe5,
+	0xc9, 0xb4, 0x14, 0xab, 0x22, 0xd6, 0xbd, 0x72, 0x9c, 0x53, 0xf2, 0xc6, 0xac, 0xe1, 0x3d, 0xa8,
+	0x8e, 0xc6, 0x2f, 0x27, 0xa6, 0x81, 0x9b, 0xb0, 0xe7, 0x7a, 0xa7, 0x9e, 0xed, 0xda, 0x9e, 0x59,
+	0x3f, 0x3e, 0x01, 0x43, 0x4d, 0x46, 0xa1, 0x3b, 0x83, 0xa9, 0x7a, 0x40, 0x05, 0xb7, 0x00, 0x9c,
+	0xc1, 0xb4, 0x7c, 0xb6, 0x3a, 0xf5, 0x46, 0x8e, 0x4d, 0x4c, 0xed, 0xf8, 0x4b, 0x30, 0xd4, 0x84,
+	0x14, 0xbe, 0x4b, 0x32, 0x71, 0x6c, 0x6f, 0x68, 0x5f, 0xb9, 0x66, 0x45, 0xf8, 0x2e, 0xc8, 0xe9,
+	0xe5, 0x70, 0xe4, 0xd9, 0x26, 0xc2, 0x26, 0x34, 0x27, 0x97, 0xf6, 0x78, 0xea, 0xd8, 0x1e, 0x19,
+	0x9d, 0xbb, 0xa6, 0x76, 0x66, 0xbd, 0xbf, 0x3d, 0x42, 0xbf, 0xdd, 0x1e, 0xa1, 0x3f, 0x6f, 0x8f,
+	0xd0, 0x2f, 0x7f, 0x1d, 0x55, 0x7e, 0x34, 0xd4, 0x27, 0x71, 0x66, 0xc8, 0x0f, 0xda, 0xe0, 0x9f,
+	0x01, 0x00, 0xe9, 0xb1, 0x7c, 0xe9, 0x50, 0x07, 0x00, 0x00,
 }
@@ -101,6 +101,11 @@ const (
 	// ALL supercedes other matcher types
 	// and does no filtering.
 	MatcherType_ALL MatcherType = 6
+	// NUMERICRANGE and NOTNUMERICRANGE match values
+	// parsed as numbers against a range in interval
+	// notation, e.g. [500,600).
+	MatcherType_NUMERICRANGE    MatcherType = 7
+	MatcherType_NOTNUMERICRANGE MatcherType = 8
 )
 
 var MatcherType_name = map[int32]string{
@@ -111,15 +116,19 @@ var MatcherType_name = map[int32]string{
 	4: "EXISTS",
 	5: "NOTEXISTS",
 	6: "ALL",
+	7: "NUMERICRANGE",
+	8: "NOTNUMERICRANGE",
 }
 var MatcherType_value = map[string]int32{
 	"EQUAL":     0,
 	"NOTEQUAL":  1,
 	"REGEXP":    2,
 	"NOTREGEXP": 3,
-	"EXISTS":    4,
-	"NOTEXISTS": 5,
-	"ALL":       6,
+	"EXISTS":          4,
+	"NOTEXISTS":       5,
+	"ALL":             6,
+	"NUMERICRANGE":    7,
+	"NOTNUMERICRANGE": 8,
 }
 
 func (x MatcherType) String() string {
@@ -235,8 +244,10 @@ type FetchRequest struct {
 	End   int64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
 	// Types that are valid to be assigned to Matchers:
 	//	*FetchRequest_TagMatchers
-	Matchers isFetchRequest_Matchers `protobuf_oneof:"matchers"`
-	Options  *FetchOptions           `protobuf:"bytes,4,opt,name=options" json:"options,omitempty"`
+	Matchers          isFetchRequest_Matchers `protobuf_oneof:"matchers"`
+	Options           *FetchOptions           `protobuf:"bytes,4,opt,name=options" json:"options,omitempty"`
+	Resolution        int64                   `protobuf:"varint,5,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	RollupAggregation int32                   `protobuf:"varint,6,opt,name=rollupAggregation,proto3" json:"rollupAggregation,omitempty"`
 }
 
 func (m *FetchRequest) Reset()                    { *m = FetchRequest{} }
@@ -291,6 +302,20 @@ func (m *FetchRequest) GetOptions() *FetchOptions {
 	return nil
 }
 
+func (m *FetchRequest) GetResolution() int64 {
+	if m != nil {
+		return m.Resolution
+	}
+	return 0
+}
+
+func (m *FetchRequest) GetRollupAggregation() int32 {
+	if m != nil {
+		return m.RollupAggregation
+	}
+	return 0
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*FetchRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _FetchRequest_OneofMarshaler, _FetchRequest_OneofUnmarshaler, _FetchRequest_OneofSizer, []interface{}{
@@ -2010,6 +2035,16 @@ func (m *FetchRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n2
 	}
+	if m.Resolution != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(m.Resolution))
+	}
+	if m.RollupAggregation != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(m.RollupAggregation))
+	}
 	return i, nil
 }
 
@@ -3282,6 +3317,12 @@ func (m *FetchRequest) Size() (n int) {
 		l = m.Options.Size()
 		n += 1 + l + sovQuery(uint64(l))
 	}
+	if m.Resolution != 0 {
+		n += 1 + sovQuery(uint64(m.Resolution))
+	}
+	if m.RollupAggregation != 0 {
+		n += 1 + sovQuery(uint64(m.RollupAggregation))
+	}
 	return n
 }
 
@@ -4130,6 +4171,44 @@ func (m *FetchRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resolution", wireType)
+			}
+			m.Resolution = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Resolution |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RollupAggregation", wireType)
+			}
+			m.RollupAggregation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RollupAggregation |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQuery(dAtA[iNdEx:])
@@ -7854,110 +7933,114 @@ func init() {
 }
 
 var fileDescriptorQuery = []byte{
-	// 1679 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xdb, 0x72, 0x1b, 0x49,
-	0x19, 0xd6, 0x68, 0xac, 0xd3, 0xaf, 0x43, 0xe4, 0xb6, 0xd9, 0x28, 0x26, 0x18, 0xd5, 0x00, 0x8b,
-	0xf1, 0x06, 0x2b, 0x91, 0xb3, 0x2c, 0x4b, 0x15, 0x07, 0x39, 0x52, 0x6c, 0xd7, 0xda, 0x92, 0xb7,
-	0x35, 0x26, 0x81, 0x82, 0x32, 0xad, 0x51, 0x67, 0x3c, 0x65, 0xcd, 0x61, 0xe7, 0xb0, 0xac, 0xb7,
-	0xb8, 0xe0, 0x9e, 0x1b, 0x8a, 0xe2, 0x09, 0xa0, 0xe0, 0x09, 0xf6, 0x11, 0xb8, 0xe0, 0x92, 0x47,
-	0xa0, 0xc2, 0x0d, 0x8f, 0xb1, 0xd5, 0x3d, 0x3d, 0x27, 0xcd, 0xb8, 0x92, 0xca, 0xdd, 0xfc, 0xe7,
-	0xfe, 0xff, 0xfe, 0xfa, 0xeb, 0x96, 0xe0, 0x67, 0xba, 0xe1, 0x5f, 0x07, 0x8b, 0x03, 0xcd, 0x36,
-	0x07, 0xe6, 0xe1, 0x72, 0x31, 0x30, 0x0f, 0x07, 0x9e, 0xab, 0x0d, 0x3e, 0x0b, 0xa8, 0x7b, 0x3b,
-	0xd0, 0xa9, 0x45, 0x5d, 0xe2, 0xd3, 0xe5, 0xc0, 0x71, 0x6d, 0xdf, 0x1e, 0xb8, 0x8e, 0xe6, 0x2c,
-	0x42, 0xdb, 0x01, 0xd7, 0x20, 0xd9, 0x75, 0xb4, 0x9d, 0xf1, 0x1d, 0x49, 0x4c, 0xea, 0xbb, 0x86,
-	0xe6, 0xe5, 0xd2, 0x38, 0xf6, 0xca, 0xd0, 0x6e, 0x9d, 0x85, 0xf8, 0x08, 0x53, 0x29, 0xf7, 0xa0,
-	0x7d, 0x42, 0xc9, 0xca, 0xbf, 0xc6, 0xf4, 0xb3, 0x80, 0x7a, 0xbe, 0xf2, 0x0a, 0x3a, 0x91, 0xc2,
-	0x73, 0x6c, 0xcb, 0xa3, 0xe8, 0x7d, 0xe8, 0x04, 0x8e, 0x6f, 0x98, 0x74, 0x1c, 0xb8, 0xc4, 0x37,
-	0x6c, 0xab, 0x27, 0xf5, 0xa5, 0xbd, 0x06, 0x5e, 0xd3, 0xa2, 0x47, 0xb0, 0x19, 0x6a, 0xa6, 0xc4,
-	0xb2, 0x3d, 0xaa, 0xd9, 0xd6, 0xd2, 0xeb, 0x95, 0xfb, 0xd2, 0x9e, 0x8c, 0xf3, 0x06, 0xe5, 0x1f,
-	0x12, 0xb4, 0x9e, 0x53, 0x5f, 0x8b, 0x0a, 0xa3, 0x6d, 0xa8, 0x78, 0x3e, 0x71, 0x7d, 0x9e, 0x5d,
-	0xc6, 0xa1, 0x80, 0xba, 0x20, 0x53, 0x6b, 0x29, 0xd2, 0xb0, 0x4f, 0xf4, 0x14, 0x9a, 0x3e, 0xd1,
-	0xcf, 0x89, 0xaf, 0x5d, 0x53, 0xd7, 0xeb, 0xc9, 0x7d, 0x69, 0xaf, 0x39, 0xec, 0x1e, 0xb8, 0x8e,
-	0x76, 0xa0, 0x26, 0xfa, 0x93, 0x12, 0x4e, 0xbb, 0xa1, 0x0f, 0xa0, 0x66, 0x3b, 0x6c, 0x99, 0x5e,
-	0x6f, 0x83, 0x47, 0x6c, 0xf2, 0x08, 0xbe, 0x82, 0x59, 0x68, 0xc0, 0x91, 0xc7, 0x11, 0x40, 0xdd,
-	0x14, 0x81, 0xca, 0x2f, 0xa0, 0x99, 0x4a, 0x8b, 0x9e, 0x64, 0xab, 0x4b, 0x7d, 0x79, 0xaf, 0x39,
-	0xbc, 0xb7, 0x56, 0x3d, 0x53, 0x5a, 0xf9, 0x0d, 0x40, 0x62, 0x42, 0x08, 0x36, 0x2c, 0x62, 0x52,
-	0xde, 0x65, 0x0b, 0xf3, 0x6f, 0xd6, 0xfa, 0xe7, 0x64, 0x15, 0x50, 0xde, 0x66, 0x0b, 0x87, 0x02,
-	0xfa, 0x2e, 0x6c, 0xf8, 0xb7, 0x0e, 0xe5, 0x1d, 0x76, 0x44, 0x87, 0x22, 0x8b, 0x7a, 0xeb, 0x50,
-	0xcc, 0xad, 0xca, 0x1f, 0x65, 0x31, 0x47, 0xd1, 0x05, 0x4b, 0xb6, 0x32, 0x4c, 0x23, 0x9e, 0x23,
-	0x17, 0xd0, 0x87, 0x50, 0x77, 0xa9, 0xc7, 0x90, 0xe1, 0xf3, 0x2a, 0xcd, 0xe1, 0x03, 0x9e, 0x10,
-	0x0b, 0xe5, 0xa7, 0x0c, 0x5e, 0xd1, 0x20, 0x62, 0x57, 0xb4, 0x0f, 0xdd, 0x95, 0x6d, 0xdf, 0x2c,
-	0x88, 0x76, 0x13, 0xef, 0xbe, 0xcc, 0xf3, 0xe6, 0xf4, 0xe8, 0x43, 0x68, 0x05, 0x16, 0xd1, 0x75,
-	0x97, 0xea, 0x0c, 0x76, 0x7c, 0xce, 0x9d, 0x68, 0xce, 0xc4, 0xb2, 0x03, 0x3f, 0xcc, 0x8f, 0x33,
-	0x6e, 0xe8, 0x09, 0x40, 0x2a, 0xa8, 0x72, 0x57, 0x50, 0xca, 0x09, 0x3d, 0x83, 0xad, 0x44, 0x62,
-	0x76, 0xd3, 0xf8, 0x92, 0x2e, 0x7b, 0xd5, 0xbb, 0x62, 0x8b, 0xbc, 0xd1, 0x63, 0xd8, 0x34, 0x2c,
-	0x6d, 0x15, 0x2c, 0x29, 0xa6, 0x9e, 0xbd, 0x0a, 0x78, 0x6f, 0xb5, 0xbe, 0xb4, 0x57, 0x3f, 0x2a,
-	0xf7, 0x24, 0x9c, 0x37, 0xa2, 0xf7, 0xa0, 0xea, 0xd9, 0x81, 0xab, 0xd1, 0x5e, 0x9d, 0xef, 0x93,
-	0x90, 0x94, 0xbf, 0x49, 0xb0, 0x5d, 0x34, 0x47, 0x34, 0x86, 0x4d, 0x37, 0xad, 0x57, 0xa3, 0xed,
-	0x6c, 0x0e, 0xdf, 0xcb, 0x4f, 0x9f, 0x6f, 0x6a, 0x3e, 0x20, 0x9f, 0x85, 0xe8, 0x11, 0x88, 0x8b,
-	0xb2, 0x10, 0xdd, 0xc3, 0xf9, 0x00, 0xe5, 0xaf, 0x12, 0x6c, 0xe6, 0xca, 0xa1, 0x21, 0x34, 0x05,
-	0x5f, 0xf0, 0xb5, 0x49, 0x69, 0xa8, 0x25, 0x7a, 0x9c, 0x76, 0x42, 0x9f, 0xc0, 0xb6, 0x10, 0xe7,
-	0xbe, 0xed, 0x12, 0x9d, 0x5e, 0x70, 0x42, 0x11, 0xb0, 0xba, 0x7f, 0x10, 0x11, 0xcd, 0x41, 0xc6,
-	0x8c, 0x0b, 0x83, 0x94, 0x17, 0xeb, 0xab, 0x22, 0xba, 0x87, 0x1e, 0xa5, 0xc0, 0x2a, 0x15, 0x9f,
-	0xef, 0x14, 0x46, 0x39, 0x71, 0xb8, 0x86, 0xd3, 0x2b, 0xf7, 0x65, 0x76, 0x7a, 0xb8, 0xa0, 0xfc,
-	0x16, 0xda, 0x82, 0x5e, 0x04, 0x8d, 0x7d, 0x07, 0xaa, 0x1e, 0x75, 0x0d, 0x1a, 0x1d, 0xda, 0x26,
-	0x4f, 0x39, 0xe7, 0x2a, 0x2c, 0x4c, 0xe8, 0xfb, 0xb0, 0x61, 0x52, 0x9f, 0x88, 0x5e, 0xb6, 0xa2,
-	0xf1, 0x06, 0x2b, 0xff, 0x9c, 0xfa, 0x64, 0x49, 0x7c, 0x82, 0xb9, 0x83, 0xf2, 0x95, 0x04, 0xd5,
-	0x79, 0x36, 0x46, 0x4a, 0xc5, 0x84, 0xa6, 0x6c, 0x0c, 0xfa, 0x29, 0xb4, 0x96, 0x54, 0xb3, 0x4d,
-	0xc7, 0xa5, 0x9e, 0x47, 0x97, 0xf1, 0xc0, 0x58, 0xc0, 0x38, 0x65, 0x08, 0x83, 0x4f, 0x4a, 0x38,
-	0xe3, 0x8e, 0x3e, 0x06, 0x48, 0x05, 0xcb, 0xa9, 0xe0, 0xf3, 0xc3, 0x67, 0xf9, 0xe0, 0x94, 0xf3,
-	0x51, 0x4d, 0x10, 0x8c, 0xf2, 0x12, 0x3a, 0xd9, 0xa5, 0xa1, 0x0e, 0x94, 0x8d, 0xa5, 0x60, 0xa3,
-	0xb2, 0xb1, 0x44, 0x0f, 0xa1, 0xc1, 0x99, 0x57, 0x35, 0x4c, 0x2a, 0x68, 0x37, 0x51, 0xa0, 0x1e,
-	0xd4, 0xa8, 0xb5, 0xe4, 0xb6, 0x90, 0x06, 0x22, 0x51, 0x59, 0x00, 0xca, 0xf7, 0x80, 0x0e, 0x00,
-	0x58, 0x15, 0xc7, 0x36, 0x2c, 0x3f, 0x1a, 0x7c, 0x27, 0x6c, 0x38, 0x52, 0xe3, 0x94, 0x07, 0x7a,
-	0x08, 0x1b, 0x3e, 0x83, 0x77, 0x99, 0x7b, 0xd6, 0xa3, 0x5d, 0xc7, 0x5c, 0xab, 0xfc, 0x1c, 0x1a,
-	0x71, 0x18, 0x5b, 0x28, 0xbb, 0x53, 0x3c, 0x9f, 0x98, 0x8e, 0xe0, 0xba, 0x44, 0x91, 0xa5, 0x54,
-	0x49, 0x50, 0xaa, 0x32, 0x00, 0x59, 0x25, 0xfa, 0xdb, 0x73, 0xb0, 0xf2, 0x05, 0xa0, 0xfc, 0x70,
-	0xd9, 0x8d, 0x98, 0x74, 0xca, 0x8f, 0x63, 0x98, 0x69, 0x4d, 0x8b, 0x7e, 0xc2, 0x70, 0xec, 0xac,
-	0x0c, 0x8d, 0x44, 0x1d, 0xed, 0xe6, 0xf6, 0xeb, 0x97, 0xac, 0x8e, 0x87, 0x43, 0x37, 0x1c, 0xfb,
-	0x2b, 0x27, 0xf0, 0xe0, 0x4e, 0x37, 0xf4, 0x01, 0xd4, 0x3d, 0xaa, 0x9b, 0x34, 0x19, 0xea, 0x3d,
-	0x91, 0x78, 0x2e, 0xd4, 0x38, 0x76, 0x50, 0x7e, 0x07, 0x90, 0xe8, 0xd1, 0xfb, 0x50, 0x35, 0xa9,
-	0xab, 0xd3, 0xa5, 0xc0, 0x6b, 0x27, 0x1b, 0x88, 0x85, 0x15, 0xed, 0x43, 0x3d, 0xb0, 0x84, 0x67,
-	0x39, 0xb5, 0x6f, 0x89, 0x67, 0x6c, 0x57, 0xfe, 0x24, 0x41, 0x23, 0xd6, 0xb3, 0xe9, 0x5e, 0x53,
-	0x12, 0x61, 0x8a, 0x7f, 0x33, 0x9d, 0x4f, 0x8c, 0x95, 0x18, 0x2e, 0xff, 0xce, 0x22, 0x4d, 0x5e,
-	0x47, 0xda, 0x43, 0x68, 0x2c, 0x56, 0xb6, 0x76, 0x33, 0x37, 0xbe, 0xa4, 0x9c, 0xed, 0x64, 0x9c,
-	0x28, 0xd0, 0x0e, 0xd4, 0xb5, 0x6b, 0xaa, 0xdd, 0x78, 0x81, 0xc9, 0xaf, 0x8c, 0x36, 0x8e, 0x65,
-	0xe5, 0x9f, 0x12, 0xb4, 0xe7, 0x94, 0xb8, 0xc9, 0xd3, 0xe2, 0xe9, 0xfa, 0xa5, 0xfd, 0x56, 0x4f,
-	0x86, 0xf8, 0x41, 0x52, 0x2e, 0x78, 0x90, 0xc8, 0xc9, 0x83, 0xe4, 0x9d, 0x9f, 0x16, 0xc7, 0xd0,
-	0x3e, 0x3f, 0x54, 0x89, 0x7e, 0xe1, 0xda, 0x0e, 0x75, 0xfd, 0xdb, 0xdc, 0x59, 0xcc, 0xe3, 0xac,
-	0x5c, 0x84, 0x33, 0x65, 0x02, 0xf7, 0xd2, 0x89, 0x18, 0x44, 0x87, 0x00, 0x4e, 0x2c, 0x09, 0x8c,
-	0x20, 0xb1, 0x81, 0xa9, 0x92, 0x38, 0xe5, 0xa5, 0x7c, 0xc4, 0x9f, 0x3a, 0xf1, 0x6a, 0xba, 0x20,
-	0xdf, 0xd0, 0x5b, 0xb1, 0x1c, 0xf6, 0xc9, 0x2e, 0x40, 0x7e, 0x2c, 0xa2, 0x75, 0x08, 0x49, 0x19,
-	0x41, 0x3b, 0x5b, 0xfd, 0x71, 0x41, 0xf5, 0x78, 0xde, 0x85, 0xb5, 0xbf, 0x92, 0x18, 0x33, 0x85,
-	0x9b, 0x26, 0x08, 0xfb, 0xc7, 0x6b, 0x74, 0x19, 0x6e, 0x1b, 0x5a, 0x4b, 0x53, 0xc4, 0x94, 0x3f,
-	0xca, 0x30, 0x65, 0x48, 0xb3, 0xdb, 0xb9, 0xe6, 0x73, 0x34, 0x19, 0x33, 0xb9, 0xfc, 0x06, 0xf6,
-	0x4f, 0xf8, 0xf4, 0x5f, 0x12, 0xec, 0xb0, 0x43, 0xba, 0xa2, 0x3e, 0xe5, 0x37, 0x6f, 0x88, 0xb8,
-	0xe8, 0x01, 0xf0, 0x03, 0xf1, 0x84, 0x0b, 0xef, 0xd5, 0x6f, 0xf0, 0x84, 0x69, 0xf7, 0xe4, 0x1d,
-	0xc7, 0xf6, 0xfa, 0x95, 0xb1, 0xf2, 0xa9, 0x3b, 0x25, 0x26, 0x55, 0x23, 0x0e, 0x6c, 0xe1, 0x35,
-	0x6d, 0x82, 0x4a, 0xb9, 0x00, 0x95, 0x1b, 0x85, 0xa8, 0xac, 0xbc, 0x09, 0x95, 0xca, 0x5f, 0x24,
-	0xd8, 0x2a, 0x68, 0xe3, 0x1d, 0x0f, 0xce, 0xc7, 0x49, 0xe9, 0x70, 0xf6, 0xdf, 0xce, 0x35, 0x9e,
-	0x9d, 0x53, 0xf1, 0xf1, 0xe8, 0x43, 0x5d, 0x25, 0x3a, 0x6b, 0x9c, 0x77, 0xcd, 0x58, 0x3a, 0xc4,
-	0x52, 0x0b, 0x87, 0x82, 0xf2, 0x94, 0x7b, 0x70, 0x6a, 0x7c, 0x03, 0x5a, 0xe5, 0x14, 0x5a, 0x87,
-	0xd0, 0x88, 0xa2, 0x3c, 0xf4, 0xbd, 0xd8, 0x29, 0x44, 0x69, 0x3b, 0x6a, 0x8e, 0xdb, 0xe3, 0x98,
-	0xbf, 0x4b, 0xb0, 0x9d, 0x5d, 0xbf, 0x00, 0xe9, 0x3e, 0xd4, 0x96, 0xf4, 0x15, 0x09, 0x56, 0x7e,
-	0x86, 0x4f, 0xe3, 0x02, 0x27, 0x25, 0x1c, 0x39, 0xa0, 0x1f, 0x42, 0x83, 0xaf, 0x7b, 0x66, 0xad,
-	0xa2, 0xd7, 0x52, 0x5c, 0x8e, 0xb7, 0x79, 0x52, 0xc2, 0x89, 0xc7, 0x3b, 0xa0, 0xf1, 0x0f, 0xd0,
-	0xc9, 0x3a, 0xa0, 0x5d, 0x00, 0xfa, 0xc5, 0x35, 0x09, 0x3c, 0xdf, 0xf8, 0x3c, 0x84, 0x61, 0x1d,
-	0xa7, 0x34, 0x68, 0x0f, 0xea, 0xbf, 0x27, 0xae, 0x65, 0x58, 0xf1, 0x9d, 0xdb, 0xe2, 0x75, 0x5e,
-	0x84, 0x4a, 0x1c, 0x5b, 0x51, 0x1f, 0x9a, 0x6e, 0xfc, 0x14, 0x66, 0x3f, 0xbb, 0xe4, 0x3d, 0x19,
-	0xa7, 0x55, 0xca, 0x47, 0x50, 0x13, 0x61, 0x85, 0x17, 0x6c, 0x0f, 0x6a, 0x26, 0xf5, 0x3c, 0xa2,
-	0x47, 0x57, 0x6c, 0x24, 0xee, 0x53, 0x68, 0xa6, 0x7e, 0xd7, 0xa0, 0x06, 0x54, 0x26, 0x9f, 0x5e,
-	0x8e, 0xce, 0xba, 0x25, 0xd4, 0x82, 0xfa, 0x74, 0xa6, 0x86, 0x92, 0x84, 0x00, 0xaa, 0x78, 0x72,
-	0x3c, 0x79, 0x79, 0xd1, 0x2d, 0xa3, 0x36, 0x34, 0xa6, 0x33, 0x55, 0x88, 0x32, 0x33, 0x4d, 0x5e,
-	0x9e, 0xce, 0xd5, 0x79, 0x77, 0x43, 0x98, 0x84, 0x58, 0x41, 0x35, 0x90, 0x47, 0x67, 0x67, 0xdd,
-	0xea, 0xbe, 0x06, 0xcd, 0xd4, 0x9b, 0x16, 0xf5, 0x60, 0xfb, 0x72, 0xfa, 0xc9, 0x74, 0xf6, 0x62,
-	0x7a, 0x75, 0x3e, 0x51, 0xf1, 0xe9, 0xb3, 0xf9, 0x95, 0xfa, 0xab, 0x8b, 0x49, 0xb7, 0x84, 0xbe,
-	0x05, 0x0f, 0x2e, 0xa7, 0xa3, 0xe3, 0x63, 0x3c, 0x39, 0x1e, 0xa9, 0x93, 0x71, 0xd6, 0x2c, 0xa1,
-	0x6f, 0xc2, 0xfd, 0xbb, 0x8c, 0xe5, 0xfd, 0x53, 0x68, 0xa5, 0x7f, 0x7a, 0x20, 0x04, 0x9d, 0xf1,
-	0xe4, 0xf9, 0xe8, 0xf2, 0x4c, 0xbd, 0x9a, 0x5d, 0xa8, 0xa7, 0xb3, 0x69, 0xb7, 0x84, 0x36, 0xa1,
-	0xfd, 0x7c, 0x86, 0x9f, 0x4d, 0xae, 0x26, 0xd3, 0xd1, 0xd1, 0xd9, 0x64, 0xdc, 0x95, 0x98, 0x5b,
-	0xa8, 0x1a, 0x9f, 0xce, 0x43, 0x5d, 0x79, 0xff, 0x11, 0x74, 0xd7, 0xb9, 0x02, 0x35, 0xa1, 0x26,
-	0xd2, 0x75, 0x4b, 0x4c, 0x50, 0x47, 0xc7, 0xd3, 0xd1, 0xf9, 0xa4, 0x2b, 0x0d, 0xff, 0x2f, 0x41,
-	0x85, 0xbf, 0xa0, 0xd1, 0x13, 0xa8, 0x86, 0xbf, 0xe0, 0x51, 0xc8, 0x95, 0x99, 0xdf, 0xf7, 0x3b,
-	0x5b, 0x19, 0x9d, 0x40, 0xf1, 0x63, 0xa8, 0x70, 0x62, 0x40, 0x29, 0x92, 0x88, 0x02, 0x50, 0x5a,
-	0x15, 0xfa, 0x3f, 0x96, 0xd0, 0x21, 0x7b, 0xfe, 0x32, 0xba, 0x16, 0x45, 0x32, 0x17, 0xee, 0xce,
-	0x56, 0x46, 0x17, 0x07, 0x4d, 0xa0, 0x95, 0xee, 0x08, 0xf5, 0xee, 0xe2, 0x85, 0x9d, 0x07, 0x05,
-	0x96, 0x28, 0xcd, 0xd1, 0xfd, 0x7f, 0xbf, 0xde, 0x95, 0xfe, 0xf3, 0x7a, 0x57, 0xfa, 0xef, 0xeb,
-	0x5d, 0xe9, 0xcf, 0xff, 0xdb, 0x2d, 0xfd, 0xba, 0xc2, 0xff, 0x23, 0x59, 0x54, 0xf9, 0x7f, 0x1a,
-	0x87, 0x5f, 0x07, 0x00, 0x00, 0xff, 0xff, 0xf9, 0x47, 0xd8, 0x5c, 0x60, 0x11, 0x00, 0x00,
+	// 1732 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xdb, 0x72, 0x1c, 0x47,
+	0x19, 0xde, 0xd9, 0xd1, 0x9e, 0xfe, 0x3d, 0x78, 0xd5, 0x12, 0xf1, 0x5a, 0x18, 0xb1, 0x35, 0x40,
+	0x10, 0x8a, 0x91, 0x6c, 0xc9, 0x21, 0x84, 0x2a, 0x0e, 0x2b, 0x69, 0x2c, 0xa9, 0x22, 0xed, 0x2a,
+	0xbd, 0x23, 0x6c, 0x28, 0x28, 0xd3, 0x9a, 0x6d, 0x8f, 0xa6, 0x34, 0xa7, 0xcc, 0x21, 0x44, 0x29,
+	0x2e, 0xb8, 0x87, 0x0b, 0x8a, 0xe2, 0x09, 0xa0, 0x8a, 0x27, 0xc8, 0x23, 0x70, 0xc1, 0x25, 0x8f,
+	0x40, 0x99, 0x1b, 0xee, 0x79, 0x01, 0xaa, 0x7b, 0x7a, 0x4e, 0x3b, 0xa3, 0x72, 0xe2, 0xbb, 0xf9,
+	0xcf, 0xfd, 0xff, 0xfd, 0xf5, 0xd7, 0xbd, 0x0b, 0x3f, 0x31, 0xcc, 0xf0, 0x3a, 0xba, 0xda, 0xd1,
+	0x5d, 0x7b, 0xd7, 0xde, 0x5f, 0x5c, 0xed, 0xda, 0xfb, 0xbb, 0x81, 0xaf, 0xef, 0x7e, 0x12, 0x51,
+	0xff, 0x76, 0xd7, 0xa0, 0x0e, 0xf5, 0x49, 0x48, 0x17, 0xbb, 0x9e, 0xef, 0x86, 0xee, 0xae, 0xef,
+	0xe9, 0xde, 0x55, 0x6c, 0xdb, 0xe1, 0x1a, 0x24, 0xfb, 0x9e, 0xbe, 0x71, 0x74, 0x47, 0x12, 0x9b,
+	0x86, 0xbe, 0xa9, 0x07, 0xa5, 0x34, 0x9e, 0x6b, 0x99, 0xfa, 0xad, 0x77, 0x25, 0x3e, 0xe2, 0x54,
+	0xca, 0x3d, 0xe8, 0x9f, 0x50, 0x62, 0x85, 0xd7, 0x98, 0x7e, 0x12, 0xd1, 0x20, 0x54, 0x5e, 0xc1,
+	0x20, 0x51, 0x04, 0x9e, 0xeb, 0x04, 0x14, 0xbd, 0x0b, 0x83, 0xc8, 0x0b, 0x4d, 0x9b, 0x1e, 0x45,
+	0x3e, 0x09, 0x4d, 0xd7, 0x19, 0x49, 0x63, 0x69, 0xab, 0x83, 0x97, 0xb4, 0xe8, 0x11, 0xac, 0xc6,
+	0x9a, 0x29, 0x71, 0xdc, 0x80, 0xea, 0xae, 0xb3, 0x08, 0x46, 0xf5, 0xb1, 0xb4, 0x25, 0xe3, 0xb2,
+	0x41, 0xf9, 0x9f, 0x04, 0xbd, 0x67, 0x34, 0xd4, 0x93, 0xc2, 0x68, 0x1d, 0x1a, 0x41, 0x48, 0xfc,
+	0x90, 0x67, 0x97, 0x71, 0x2c, 0xa0, 0x21, 0xc8, 0xd4, 0x59, 0x88, 0x34, 0xec, 0x13, 0x3d, 0x85,
+	0x6e, 0x48, 0x8c, 0x73, 0x12, 0xea, 0xd7, 0xd4, 0x0f, 0x46, 0xf2, 0x58, 0xda, 0xea, 0xee, 0x0d,
+	0x77, 0x7c, 0x4f, 0xdf, 0xd1, 0x32, 0xfd, 0x49, 0x0d, 0xe7, 0xdd, 0xd0, 0x7b, 0xd0, 0x72, 0x3d,
+	0xb6, 0xcc, 0x60, 0xb4, 0xc2, 0x23, 0x56, 0x79, 0x04, 0x5f, 0xc1, 0x2c, 0x36, 0xe0, 0xc4, 0x03,
+	0x6d, 0x02, 0xf8, 0x34, 0x70, 0xad, 0x88, 0x77, 0xdb, 0xe0, 0xb5, 0x73, 0x1a, 0xd6, 0xa9, 0xef,
+	0x5a, 0x56, 0xe4, 0x4d, 0x0c, 0xc3, 0xa7, 0x46, 0x3c, 0x94, 0xe6, 0x58, 0xda, 0x6a, 0xe0, 0xb2,
+	0xe1, 0x00, 0xa0, 0x6d, 0x8b, 0x65, 0x28, 0x3f, 0x83, 0x6e, 0x6e, 0x91, 0xe8, 0x49, 0xb1, 0x17,
+	0x69, 0x2c, 0x6f, 0x75, 0xf7, 0xee, 0x2d, 0xf5, 0x52, 0x68, 0x44, 0xf9, 0x15, 0x40, 0x66, 0x42,
+	0x08, 0x56, 0x1c, 0x62, 0x53, 0x3e, 0xb3, 0x1e, 0xe6, 0xdf, 0x6c, 0x90, 0x9f, 0x12, 0x2b, 0xa2,
+	0x7c, 0x68, 0x3d, 0x1c, 0x0b, 0xe8, 0xdb, 0xb0, 0x12, 0xde, 0x7a, 0x94, 0xcf, 0x6b, 0x20, 0xe6,
+	0x25, 0xb2, 0x68, 0xb7, 0x1e, 0xc5, 0xdc, 0xaa, 0xfc, 0x5e, 0x86, 0x5e, 0x7e, 0x26, 0x2c, 0x99,
+	0x65, 0xda, 0x66, 0xba, 0x2b, 0x5c, 0x40, 0xef, 0x43, 0xdb, 0xa7, 0x01, 0xc3, 0x59, 0xc8, 0xab,
+	0x74, 0xf7, 0x1e, 0xf0, 0x84, 0x58, 0x28, 0x3f, 0x66, 0x60, 0x4d, 0xc6, 0x9a, 0xba, 0xa2, 0x6d,
+	0x18, 0x5a, 0xae, 0x7b, 0x73, 0x45, 0xf4, 0x9b, 0x14, 0x4b, 0x32, 0xcf, 0x5b, 0xd2, 0xa3, 0xf7,
+	0xa1, 0x17, 0x39, 0x44, 0x8c, 0x91, 0x2e, 0xf8, 0xae, 0x0d, 0x92, 0x5d, 0x23, 0x8e, 0x1b, 0x85,
+	0x71, 0x7e, 0x5c, 0x70, 0x43, 0x4f, 0x00, 0x72, 0x41, 0x8d, 0xbb, 0x82, 0x72, 0x4e, 0xe8, 0x10,
+	0xd6, 0x32, 0x89, 0xd9, 0x6d, 0xf3, 0x73, 0xba, 0x18, 0x35, 0xef, 0x8a, 0xad, 0xf2, 0x46, 0x8f,
+	0x61, 0xd5, 0x74, 0x74, 0x2b, 0x5a, 0x50, 0x9c, 0x21, 0xa7, 0x35, 0x96, 0xb6, 0xda, 0x07, 0xf5,
+	0x91, 0x84, 0xcb, 0x46, 0xf4, 0x0e, 0x34, 0x03, 0x37, 0xf2, 0x75, 0x3a, 0x6a, 0xf3, 0x7d, 0x12,
+	0x92, 0xf2, 0x57, 0x09, 0xd6, 0xab, 0xe6, 0x88, 0x8e, 0x60, 0xd5, 0xcf, 0xeb, 0xb5, 0x64, 0x3b,
+	0xbb, 0x7b, 0xef, 0x94, 0xa7, 0xcf, 0x37, 0xb5, 0x1c, 0x50, 0xce, 0x42, 0x8c, 0xe4, 0x48, 0x54,
+	0x65, 0x21, 0x46, 0x80, 0xcb, 0x01, 0xca, 0x5f, 0x24, 0x58, 0x2d, 0x95, 0x43, 0x7b, 0xd0, 0x15,
+	0xec, 0xc3, 0xd7, 0x26, 0xe5, 0xa1, 0x96, 0xe9, 0x71, 0xde, 0x09, 0x7d, 0x04, 0xeb, 0x42, 0x9c,
+	0x87, 0xae, 0x4f, 0x0c, 0x7a, 0xc1, 0xe9, 0x49, 0xc0, 0xea, 0xfe, 0x4e, 0x42, 0x5b, 0x3b, 0x05,
+	0x33, 0xae, 0x0c, 0x52, 0x9e, 0x2f, 0xaf, 0x8a, 0x18, 0x01, 0x7a, 0x94, 0x03, 0xab, 0x54, 0xcd,
+	0x16, 0x39, 0x8c, 0x72, 0x1a, 0xf2, 0x4d, 0x6f, 0x54, 0x1f, 0xcb, 0xec, 0xf4, 0x70, 0x41, 0xf9,
+	0x35, 0xf4, 0x05, 0x59, 0x09, 0x52, 0xfc, 0x16, 0x34, 0x03, 0xea, 0x9b, 0x34, 0x39, 0xb4, 0x5d,
+	0x9e, 0x72, 0xce, 0x55, 0x58, 0x98, 0xd0, 0x77, 0x61, 0xc5, 0xa6, 0x21, 0x11, 0xbd, 0xac, 0x25,
+	0xe3, 0x8d, 0xac, 0xf0, 0x9c, 0x86, 0x64, 0x41, 0x42, 0x82, 0xb9, 0x83, 0xf2, 0x85, 0x04, 0xcd,
+	0x79, 0x31, 0x46, 0xca, 0xc5, 0xc4, 0xa6, 0x62, 0x0c, 0xfa, 0x31, 0xf4, 0x16, 0x54, 0x77, 0x6d,
+	0xcf, 0xa7, 0x41, 0x40, 0x17, 0xe9, 0xc0, 0x58, 0xc0, 0x51, 0xce, 0x10, 0x07, 0x9f, 0xd4, 0x70,
+	0xc1, 0x1d, 0x7d, 0x08, 0x90, 0x0b, 0x96, 0x73, 0xc1, 0xe7, 0xfb, 0x87, 0xe5, 0xe0, 0x9c, 0xf3,
+	0x41, 0x4b, 0x10, 0x8c, 0xf2, 0x02, 0x06, 0xc5, 0xa5, 0xa1, 0x01, 0xd4, 0xcd, 0x85, 0x60, 0xa3,
+	0xba, 0xb9, 0x40, 0x0f, 0xa1, 0xc3, 0x79, 0x5c, 0x33, 0x6d, 0x2a, 0x48, 0x3c, 0x53, 0xa0, 0x11,
+	0xb4, 0xa8, 0xb3, 0xe0, 0xb6, 0x98, 0x06, 0x12, 0x51, 0xb9, 0x02, 0x54, 0xee, 0x01, 0xed, 0x00,
+	0xb0, 0x2a, 0x9e, 0x6b, 0x3a, 0x61, 0x32, 0xf8, 0x41, 0xdc, 0x70, 0xa2, 0xc6, 0x39, 0x0f, 0xf4,
+	0x10, 0x56, 0x42, 0x06, 0xef, 0x3a, 0xf7, 0x6c, 0x27, 0xbb, 0x8e, 0xb9, 0x56, 0xf9, 0x29, 0x74,
+	0xd2, 0x30, 0xb6, 0x50, 0x76, 0x43, 0x05, 0x21, 0xb1, 0x3d, 0xc1, 0x75, 0x99, 0xa2, 0x48, 0xa9,
+	0x92, 0xa0, 0x54, 0x65, 0x17, 0x64, 0x8d, 0x18, 0x5f, 0x9e, 0x83, 0x95, 0xcf, 0x00, 0x95, 0x87,
+	0xcb, 0xee, 0xd7, 0xac, 0x53, 0x7e, 0x1c, 0xe3, 0x4c, 0x4b, 0x5a, 0xf4, 0x23, 0x86, 0x63, 0xcf,
+	0x32, 0x75, 0x92, 0x74, 0xb4, 0x59, 0xda, 0xaf, 0x9f, 0xb3, 0x3a, 0x01, 0x8e, 0xdd, 0x70, 0xea,
+	0xaf, 0x9c, 0xc0, 0x83, 0x3b, 0xdd, 0xd0, 0x7b, 0xd0, 0x0e, 0xa8, 0x61, 0x53, 0x27, 0x2c, 0x5e,
+	0x41, 0xe7, 0xfb, 0x73, 0xa1, 0xc6, 0xa9, 0x83, 0xf2, 0x1b, 0x80, 0x4c, 0x8f, 0xde, 0x85, 0xa6,
+	0x4d, 0x7d, 0x83, 0x2e, 0x04, 0x5e, 0x07, 0xc5, 0x40, 0x2c, 0xac, 0x68, 0x1b, 0xda, 0x91, 0x23,
+	0x3c, 0xeb, 0x63, 0xb9, 0xc2, 0x33, 0xb5, 0x2b, 0x7f, 0x90, 0xa0, 0x93, 0xea, 0xd9, 0x74, 0xaf,
+	0x29, 0x49, 0x30, 0xc5, 0xbf, 0x99, 0x2e, 0x24, 0xa6, 0x25, 0x86, 0xcb, 0xbf, 0x8b, 0x48, 0x93,
+	0x97, 0x91, 0xf6, 0x10, 0x3a, 0x57, 0x96, 0xab, 0xdf, 0xcc, 0xcd, 0xcf, 0x29, 0x67, 0x3b, 0x19,
+	0x67, 0x0a, 0xb4, 0x01, 0x6d, 0xfd, 0x9a, 0xea, 0x37, 0x41, 0x64, 0xf3, 0x2b, 0xa3, 0x8f, 0x53,
+	0x59, 0xf9, 0xbb, 0x04, 0xfd, 0x39, 0x25, 0x7e, 0xf6, 0x50, 0x79, 0xba, 0x7c, 0x69, 0x7f, 0xa9,
+	0x07, 0x48, 0xfa, 0xbc, 0xa9, 0x57, 0x3c, 0x6f, 0xe4, 0xec, 0x79, 0xf3, 0x55, 0x1e, 0x2a, 0x85,
+	0xa7, 0xc5, 0x31, 0xf4, 0xcf, 0xf7, 0x35, 0x62, 0x5c, 0xf8, 0xae, 0x47, 0xfd, 0xf0, 0xb6, 0x74,
+	0x16, 0xcb, 0x38, 0xab, 0x57, 0xe1, 0x4c, 0x51, 0xe1, 0x5e, 0x3e, 0x11, 0x83, 0xe8, 0x1e, 0x80,
+	0x97, 0x4a, 0x02, 0x23, 0x48, 0x6c, 0x60, 0xae, 0x24, 0xce, 0x79, 0x29, 0x1f, 0x40, 0x37, 0x67,
+	0x62, 0x9d, 0xde, 0xd0, 0x5b, 0xb1, 0x1c, 0xf6, 0xc9, 0x2e, 0x40, 0x7e, 0x2c, 0x92, 0x75, 0x08,
+	0x49, 0x99, 0x40, 0xbf, 0x58, 0xfd, 0x71, 0x45, 0xf5, 0x74, 0xde, 0x95, 0xb5, 0xbf, 0x90, 0x60,
+	0x90, 0x6c, 0x9a, 0x20, 0xec, 0x1f, 0x2e, 0xd1, 0x65, 0xbc, 0x6d, 0x68, 0x29, 0x4d, 0x15, 0x53,
+	0xfe, 0xa0, 0xc0, 0x94, 0x31, 0xcd, 0xae, 0x97, 0x9a, 0x2f, 0xd1, 0x64, 0xca, 0xe4, 0xf2, 0x1b,
+	0xd8, 0x3f, 0xe3, 0xd3, 0x7f, 0x48, 0xb0, 0xc1, 0x0e, 0xa9, 0x45, 0x43, 0xca, 0x6f, 0xde, 0x18,
+	0x71, 0xc9, 0x03, 0xe0, 0x7b, 0xe2, 0x09, 0x17, 0xdf, 0xab, 0x5f, 0xe3, 0x09, 0xf3, 0xee, 0xd9,
+	0x3b, 0x8e, 0xed, 0xf5, 0x2b, 0xd3, 0x0a, 0xa9, 0x3f, 0x25, 0x36, 0xd5, 0x12, 0x0e, 0xec, 0xe1,
+	0x25, 0x6d, 0x86, 0x4a, 0xb9, 0x02, 0x95, 0x2b, 0x95, 0xa8, 0x6c, 0xbc, 0x09, 0x95, 0xca, 0x9f,
+	0x25, 0x58, 0xab, 0x68, 0xe3, 0x2d, 0x0f, 0xce, 0x87, 0x59, 0xe9, 0x78, 0xf6, 0xdf, 0x2c, 0x35,
+	0x5e, 0x9c, 0x53, 0xf5, 0xf1, 0x18, 0x43, 0x5b, 0x23, 0x06, 0x6b, 0x9c, 0x77, 0xcd, 0x58, 0x3a,
+	0xc6, 0x52, 0x0f, 0xc7, 0x82, 0xf2, 0x94, 0x7b, 0x70, 0x6a, 0x7c, 0x03, 0x5a, 0xe5, 0x1c, 0x5a,
+	0xf7, 0xa0, 0x93, 0x44, 0x05, 0xe8, 0x3b, 0xa9, 0x53, 0x8c, 0xd2, 0x7e, 0xd2, 0x1c, 0xb7, 0xa7,
+	0x31, 0x7f, 0x93, 0x60, 0xbd, 0xb8, 0x7e, 0x01, 0xd2, 0x6d, 0x68, 0x2d, 0xe8, 0x2b, 0x12, 0x59,
+	0x61, 0x81, 0x4f, 0xd3, 0x02, 0x27, 0x35, 0x9c, 0x38, 0xa0, 0xef, 0x43, 0x87, 0xaf, 0x7b, 0xe6,
+	0x58, 0xc9, 0x6b, 0x29, 0x2d, 0xc7, 0xdb, 0x3c, 0xa9, 0xe1, 0xcc, 0xe3, 0x2d, 0xd0, 0xf8, 0x3b,
+	0x18, 0x14, 0x1d, 0xd8, 0xef, 0x22, 0xfa, 0xd9, 0x35, 0x89, 0x82, 0xd0, 0xfc, 0x34, 0x86, 0x61,
+	0x1b, 0xe7, 0x34, 0x68, 0x0b, 0xda, 0xbf, 0x25, 0xbe, 0x63, 0x3a, 0xe9, 0x9d, 0xdb, 0xe3, 0x75,
+	0x9e, 0xc7, 0x4a, 0x9c, 0x5a, 0xd1, 0x18, 0xba, 0xd9, 0xef, 0x29, 0xf6, 0x23, 0x4e, 0xde, 0x92,
+	0x71, 0x5e, 0xa5, 0x7c, 0x00, 0x2d, 0x11, 0x56, 0x79, 0xc1, 0x8e, 0xa0, 0x65, 0xd3, 0x20, 0x20,
+	0x46, 0x72, 0xc5, 0x26, 0xe2, 0xf6, 0x1f, 0x25, 0xe8, 0xe6, 0x7e, 0xd8, 0xa0, 0x0e, 0x34, 0xd4,
+	0x8f, 0x2f, 0x27, 0x67, 0xc3, 0x1a, 0xea, 0x41, 0x7b, 0x3a, 0xd3, 0x62, 0x49, 0x42, 0x00, 0x4d,
+	0xac, 0x1e, 0xab, 0x2f, 0x2e, 0x86, 0x75, 0xd4, 0x87, 0xce, 0x74, 0xa6, 0x09, 0x51, 0x66, 0x26,
+	0xf5, 0xc5, 0xe9, 0x5c, 0x9b, 0x0f, 0x57, 0x84, 0x49, 0x88, 0x0d, 0xd4, 0x02, 0x79, 0x72, 0x76,
+	0x36, 0x6c, 0xa2, 0x21, 0xf4, 0xa6, 0x97, 0xe7, 0x2a, 0x3e, 0x3d, 0xc4, 0x93, 0xe9, 0xb1, 0x3a,
+	0x6c, 0xa1, 0x35, 0xb8, 0x37, 0x9d, 0x69, 0x05, 0x65, 0x7b, 0x5b, 0x87, 0x6e, 0xee, 0xed, 0x8b,
+	0x46, 0xb0, 0x7e, 0x39, 0xfd, 0x68, 0x3a, 0x7b, 0x3e, 0x7d, 0x79, 0xae, 0x6a, 0xf8, 0xf4, 0x70,
+	0xfe, 0x52, 0xfb, 0xc5, 0x85, 0x3a, 0xac, 0xa1, 0x6f, 0xc0, 0x83, 0xcb, 0xe9, 0xe4, 0xf8, 0x18,
+	0xab, 0xc7, 0x13, 0x4d, 0x3d, 0x2a, 0x9a, 0x25, 0xf4, 0x75, 0xb8, 0x7f, 0x97, 0xb1, 0xbe, 0x7d,
+	0x0a, 0xbd, 0xfc, 0x4f, 0x14, 0x84, 0x60, 0x70, 0xa4, 0x3e, 0x9b, 0x5c, 0x9e, 0x69, 0x2f, 0x67,
+	0x17, 0xda, 0xe9, 0x6c, 0x3a, 0xac, 0xa1, 0x55, 0xe8, 0x3f, 0x9b, 0xe1, 0x43, 0xf5, 0xa5, 0x3a,
+	0x9d, 0x1c, 0x9c, 0xa9, 0x47, 0x43, 0x89, 0xb9, 0xc5, 0xaa, 0xa3, 0xd3, 0x79, 0xac, 0xab, 0x6f,
+	0x3f, 0x82, 0xe1, 0x32, 0xa7, 0xa0, 0x2e, 0xb4, 0x44, 0xba, 0x61, 0x8d, 0x09, 0xda, 0xe4, 0x78,
+	0x3a, 0x39, 0x57, 0x87, 0xd2, 0xde, 0x7f, 0x25, 0x68, 0xf0, 0x97, 0x36, 0x7a, 0x02, 0xcd, 0xf8,
+	0x7f, 0x03, 0x14, 0x73, 0x6a, 0xe1, 0x5f, 0x85, 0x8d, 0xb5, 0x82, 0x4e, 0xa0, 0xfd, 0x31, 0x34,
+	0x38, 0x81, 0xa0, 0x1c, 0x99, 0x24, 0x01, 0x28, 0xaf, 0x8a, 0xfd, 0x1f, 0x4b, 0x68, 0x1f, 0x9a,
+	0x31, 0xad, 0x8b, 0x22, 0x85, 0x8b, 0x79, 0x63, 0xad, 0xa0, 0x4b, 0x83, 0x54, 0xe8, 0xe5, 0x3b,
+	0x42, 0xa3, 0xbb, 0xf8, 0x63, 0xe3, 0x41, 0x85, 0x25, 0x49, 0x73, 0x70, 0xff, 0x9f, 0xaf, 0x37,
+	0xa5, 0x7f, 0xbd, 0xde, 0x94, 0xfe, 0xfd, 0x7a, 0x53, 0xfa, 0xd3, 0x7f, 0x36, 0x6b, 0xbf, 0x6c,
+	0xf0, 0x7f, 0x66, 0xae, 0x9a, 0xfc, 0x9f, 0x94, 0xfd, 0xff, 0x0f, 0x00, 0xd3, 0x39, 0x32, 0xeb,
+	0xd6, 0x11, 0x00, 0x00,
 }
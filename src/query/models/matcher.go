@@ -28,6 +28,10 @@ import (
 	"strings"
 )
 
+// NumericRangeValuePrefix is the value prefix which opts an equality matcher
+// into matching by numeric range, e.g. {status_code="__range__[500,600)"}.
+const NumericRangeValuePrefix = "__range__"
+
 func (m MatchType) String() string {
 	switch m {
 	case MatchEqual:
@@ -44,6 +48,10 @@ func (m MatchType) String() string {
 		return "!-"
 	case MatchAll:
 		return "*"
+	case MatchNumericRange:
+		return "=" + NumericRangeValuePrefix
+	case MatchNotNumericRange:
+		return "!=" + NumericRangeValuePrefix
 	default:
 		return "unknown match type"
 	}
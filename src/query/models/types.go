@@ -154,6 +154,8 @@ const (
 	MatchField
 	MatchNotField
 	MatchAll
+	MatchNumericRange
+	MatchNotNumericRange
 )
 
 // Matcher models the matching of a label.
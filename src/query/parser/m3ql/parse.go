@@ -0,0 +1,505 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3ql
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/functions/binary"
+	"github.com/m3db/m3/src/query/functions/linear"
+	"github.com/m3db/m3/src/query/functions/scalar"
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// FetchType fetches the series matching its tag arguments.
+	FetchType = "fetch"
+	// ScaleType multiplies every value by its argument.
+	ScaleType = "scale"
+	// PerSecondType is the per second rate of change of counters.
+	PerSecondType = "perSecond"
+	// RemoveAboveValueType removes values above its argument.
+	RemoveAboveValueType = "removeAboveValue"
+	// RemoveBelowValueType removes values below its argument.
+	RemoveBelowValueType = "removeBelowValue"
+
+	// nameTag is the fetch keyword that matches on metric names.
+	nameTag = "name"
+	// perSecondSteps is the default number of steps perSecond looks back
+	// over for the two most recent datapoints.
+	perSecondSteps = 2
+)
+
+var errEmptyQuery = errors.New("query is empty")
+
+type m3qlParser struct {
+	query    string
+	script   script
+	stepSize time.Duration
+	tagOpts  models.TagOptions
+}
+
+// Parse takes an M3QL string and parses it into a DAG.
+func Parse(
+	q string,
+	stepSize time.Duration,
+	tagOpts models.TagOptions,
+) (parser.Parser, error) {
+	if strings.TrimSpace(q) == "" {
+		return nil, errEmptyQuery
+	}
+
+	builder := newASTBuilder()
+	p := &m3ql{
+		Buffer:        q,
+		scriptBuilder: builder,
+	}
+
+	p.Init()
+	if err := p.Parse(); err != nil {
+		return nil, err
+	}
+
+	p.Execute()
+	if builder.err != nil {
+		return nil, builder.err
+	}
+
+	return &m3qlParser{
+		query:    q,
+		script:   builder.script,
+		stepSize: stepSize,
+		tagOpts:  tagOpts,
+	}, nil
+}
+
+func (p *m3qlParser) DAG() (parser.Nodes, parser.Edges, error) {
+	state := &parseState{
+		stepSize:  p.stepSize,
+		tagOpts:   p.tagOpts,
+		macros:    p.script.macros,
+		expanding: make(map[string]struct{}),
+	}
+
+	if err := state.walkPipeline(p.script.pipeline); err != nil {
+		return nil, nil, err
+	}
+
+	return state.transforms, state.edges, nil
+}
+
+func (p *m3qlParser) String() string {
+	return p.query
+}
+
+type parseState struct {
+	stepSize   time.Duration
+	tagOpts    models.TagOptions
+	macros     map[string]*pipeline
+	expanding  map[string]struct{}
+	edges      parser.Edges
+	transforms parser.Nodes
+}
+
+func (p *parseState) lastTransformID() parser.NodeID {
+	return p.transforms[len(p.transforms)-1].ID
+}
+
+// addTransform adds the operation as the next transform of the DAG, as a
+// child of each of the given parents.
+func (p *parseState) addTransform(
+	op parser.Params,
+	parents ...parser.NodeID,
+) parser.NodeID {
+	opTransform := parser.NewTransformFromOperation(op, len(p.transforms))
+	for _, parent := range parents {
+		p.edges = append(p.edges, parser.Edge{
+			ParentID: parent,
+			ChildID:  opTransform.ID,
+		})
+	}
+
+	p.transforms = append(p.transforms, opTransform)
+	return opTransform.ID
+}
+
+func (p *parseState) walkPipeline(pl *pipeline) error {
+	for i, expr := range pl.expressions {
+		var err error
+		if i == 0 {
+			err = p.walkSource(expr)
+		} else {
+			err = p.walkFunction(expr)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkSource walks the first expression of a pipeline, which produces the
+// series the rest of the pipeline operates on.
+func (p *parseState) walkSource(expr *expression) error {
+	if expr.pipeline != nil {
+		return p.walkPipeline(expr.pipeline)
+	}
+
+	if macro, ok := p.macros[expr.name]; ok {
+		if len(expr.arguments) != 0 {
+			return fmt.Errorf("macro %s does not take arguments", expr.name)
+		}
+
+		if _, ok := p.expanding[expr.name]; ok {
+			return fmt.Errorf("macro %s references itself", expr.name)
+		}
+
+		p.expanding[expr.name] = struct{}{}
+		defer delete(p.expanding, expr.name)
+		return p.walkPipeline(macro)
+	}
+
+	if expr.name != FetchType {
+		return fmt.Errorf("pipeline must begin with %s, a macro or a nested "+
+			"pipeline, received: %s", FetchType, expr.name)
+	}
+
+	op, err := newFetchOp(expr.arguments, p.tagOpts)
+	if err != nil {
+		return err
+	}
+
+	p.addTransform(op)
+	return nil
+}
+
+// walkFunction walks an expression that applies to the output of the
+// expressions before it.
+func (p *parseState) walkFunction(expr *expression) error {
+	if expr.pipeline != nil {
+		return errors.New("nested pipelines are only supported at the " +
+			"beginning of a pipeline")
+	}
+
+	switch expr.name {
+	case FetchType:
+		return fmt.Errorf("%s must begin a pipeline", FetchType)
+
+	case aggregation.SumType, aggregation.MinType, aggregation.MaxType,
+		aggregation.AverageType, aggregation.CountType,
+		aggregation.StandardDeviationType, aggregation.StandardVarianceType:
+		tags, err := stringArguments(expr)
+		if err != nil {
+			return err
+		}
+
+		matchingTags := make([][]byte, 0, len(tags))
+		for _, tag := range tags {
+			matchingTags = append(matchingTags, []byte(tag))
+		}
+
+		op, err := aggregation.NewAggregationOp(expr.name, aggregation.NodeParams{
+			MatchingTags: matchingTags,
+		})
+		if err != nil {
+			return err
+		}
+
+		p.addTransform(op, p.lastTransformID())
+		return nil
+
+	case linear.AbsType, linear.CeilType, linear.ExpType,
+		linear.FloorType, linear.LnType, linear.Log10Type,
+		linear.Log2Type, linear.SqrtType:
+		if len(expr.arguments) != 0 {
+			return fmt.Errorf("%s does not take arguments", expr.name)
+		}
+
+		op, err := linear.NewMathOp(expr.name)
+		if err != nil {
+			return err
+		}
+
+		p.addTransform(op, p.lastTransformID())
+		return nil
+
+	case ScaleType:
+		return p.addScalarBinaryTransform(expr, binary.MultiplyType)
+
+	case RemoveAboveValueType:
+		return p.addScalarBinaryTransform(expr, binary.LesserEqType)
+
+	case RemoveBelowValueType:
+		return p.addScalarBinaryTransform(expr, binary.GreaterEqType)
+
+	case binary.EqType, binary.NotEqType, binary.GreaterType,
+		binary.LesserType, binary.GreaterEqType, binary.LesserEqType:
+		return p.addScalarBinaryTransform(expr, expr.name)
+
+	case PerSecondType:
+		return p.addPerSecondTransform(expr)
+
+	default:
+		return fmt.Errorf("function not supported: %s", expr.name)
+	}
+}
+
+// addScalarBinaryTransform applies the binary operation between each series
+// and the single numeric argument of the expression. Comparisons filter out
+// the values that do not satisfy them.
+func (p *parseState) addScalarBinaryTransform(
+	expr *expression,
+	opType string,
+) error {
+	if len(expr.arguments) != 1 ||
+		expr.arguments[0].kind != numericArgument ||
+		expr.arguments[0].keyword != "" {
+		return fmt.Errorf("%s takes a single numeric argument", expr.name)
+	}
+
+	val, err := strconv.ParseFloat(expr.arguments[0].value, 64)
+	if err != nil {
+		return err
+	}
+
+	scalarOp, err := scalar.NewScalarOp(val, p.tagOpts)
+	if err != nil {
+		return err
+	}
+
+	lhsID := p.lastTransformID()
+	rhsID := p.addTransform(scalarOp)
+	op, err := binary.NewOp(opType, binary.NodeParams{
+		LNode: lhsID,
+		RNode: rhsID,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.addTransform(op, lhsID, rhsID)
+	return nil
+}
+
+// addPerSecondTransform computes the per second rate between the two most
+// recent datapoints within the window of each step, which defaults to two
+// steps. As this operates on raw datapoints it must directly follow a fetch.
+func (p *parseState) addPerSecondTransform(expr *expression) error {
+	window := perSecondSteps * p.stepSize
+	switch {
+	case len(expr.arguments) == 1 && expr.arguments[0].keyword == "":
+		d, err := xtime.ParseExtendedDuration(expr.arguments[0].value)
+		if err != nil {
+			return fmt.Errorf("invalid %s window: %v", expr.name, err)
+		}
+
+		window = d
+	case len(expr.arguments) != 0:
+		return fmt.Errorf("%s takes at most a single window argument", expr.name)
+	}
+
+	if window <= 0 {
+		return fmt.Errorf("%s window must be positive, received: %v",
+			expr.name, window)
+	}
+
+	last := len(p.transforms) - 1
+	fetchOp, ok := p.transforms[last].Op.(functions.FetchOp)
+	if !ok {
+		return fmt.Errorf("%s must directly follow %s", expr.name, FetchType)
+	}
+
+	fetchOp.Range = window
+	p.transforms[last].Op = fetchOp
+
+	op, err := temporal.NewRateOp([]interface{}{window}, temporal.IRateType)
+	if err != nil {
+		return err
+	}
+
+	p.addTransform(op, p.lastTransformID())
+	return nil
+}
+
+// newFetchOp creates a fetch of the series matching every tag argument.
+// Values are matched exactly when given as string literals, and as globs
+// otherwise.
+func newFetchOp(
+	args []argument,
+	tagOpts models.TagOptions,
+) (parser.Params, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%s requires at least one tag argument", FetchType)
+	}
+
+	var (
+		name     string
+		matchers = make(models.Matchers, 0, len(args))
+	)
+	for _, arg := range args {
+		if arg.keyword == "" {
+			return nil, fmt.Errorf("%s arguments must be tag:value pairs, "+
+				"received: %s", FetchType, arg.value)
+		}
+
+		if arg.kind == pipelineArgument {
+			return nil, fmt.Errorf("%s argument %s cannot be a pipeline",
+				FetchType, arg.keyword)
+		}
+
+		tagName := []byte(arg.keyword)
+		if arg.keyword == nameTag {
+			tagName = tagOpts.MetricName()
+			name = arg.value
+		}
+
+		matcher, err := newTagMatcher(tagName, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	return functions.FetchOp{
+		Name:     name,
+		Matchers: matchers,
+	}, nil
+}
+
+func newTagMatcher(name []byte, arg argument) (models.Matcher, error) {
+	if arg.kind != patternArgument {
+		return models.NewMatcher(models.MatchEqual, name, []byte(arg.value))
+	}
+
+	if arg.value == "*" {
+		return models.NewMatcher(models.MatchField, name, nil)
+	}
+
+	pattern, isGlob, err := globToRegexPattern(arg.value)
+	if err != nil {
+		return models.Matcher{}, err
+	}
+
+	if !isGlob {
+		return models.NewMatcher(models.MatchEqual, name, []byte(arg.value))
+	}
+
+	return models.NewMatcher(models.MatchRegexp, name, []byte(pattern))
+}
+
+// globToRegexPattern converts a glob, which may contain wildcards, single
+// character matches, alternatives in braces and character classes in
+// brackets, into a regex pattern. It returns whether the glob contained any
+// of these.
+func globToRegexPattern(glob string) (string, bool, error) {
+	var (
+		sb      strings.Builder
+		isGlob  bool
+		inGroup bool
+		inClass bool
+	)
+	for i, r := range glob {
+		if inClass {
+			switch r {
+			case ']':
+				inClass = false
+				sb.WriteRune(r)
+			case '\\':
+				sb.WriteString(`\\`)
+			default:
+				sb.WriteRune(r)
+			}
+			continue
+		}
+
+		switch r {
+		case '*':
+			isGlob = true
+			sb.WriteString(".*")
+		case '?':
+			isGlob = true
+			sb.WriteRune('.')
+		case '{':
+			if inGroup {
+				return "", false, fmt.Errorf("nested alternatives at %d in "+
+					"pattern: %s", i, glob)
+			}
+			isGlob, inGroup = true, true
+			sb.WriteRune('(')
+		case '}':
+			if !inGroup {
+				return "", false, fmt.Errorf("unbalanced '}' at %d in "+
+					"pattern: %s", i, glob)
+			}
+			inGroup = false
+			sb.WriteRune(')')
+		case ',':
+			if inGroup {
+				sb.WriteRune('|')
+			} else {
+				sb.WriteRune(r)
+			}
+		case '[':
+			isGlob, inClass = true, true
+			sb.WriteRune(r)
+		case ']':
+			return "", false, fmt.Errorf("unbalanced ']' at %d in pattern: %s",
+				i, glob)
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	if inGroup || inClass {
+		return "", false, fmt.Errorf("unterminated group in pattern: %s", glob)
+	}
+
+	return sb.String(), isGlob, nil
+}
+
+// stringArguments returns the values of the positional pattern and string
+// literal arguments of the expression.
+func stringArguments(expr *expression) ([]string, error) {
+	values := make([]string, 0, len(expr.arguments))
+	for _, arg := range expr.arguments {
+		if arg.keyword != "" ||
+			(arg.kind != patternArgument && arg.kind != stringLiteralArgument) {
+			return nil, fmt.Errorf("%s arguments must be tag names", expr.name)
+		}
+
+		values = append(values, arg.value)
+	}
+
+	return values, nil
+}
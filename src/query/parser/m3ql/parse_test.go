@@ -0,0 +1,144 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3ql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/functions/binary"
+	"github.com/m3db/m3/src/query/functions/linear"
+	"github.com/m3db/m3/src/query/functions/scalar"
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseDAG(t *testing.T, q string) (parser.Nodes, parser.Edges) {
+	p, err := Parse(q, time.Minute, models.NewTagOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	return transforms, edges
+}
+
+func TestDAGWithFetch(t *testing.T) {
+	transforms, edges := parseDAG(t,
+		`fetch name:http.requests service:{api,web}-* env:"prod*" dc:*`)
+	require.Len(t, transforms, 1)
+	assert.Len(t, edges, 0)
+
+	op, ok := transforms[0].Op.(functions.FetchOp)
+	require.True(t, ok)
+	assert.Equal(t, "http.requests", op.Name)
+	require.Len(t, op.Matchers, 4)
+
+	expected := []struct {
+		matchType models.MatchType
+		name      string
+		value     string
+	}{
+		{models.MatchEqual, "__name__", "http.requests"},
+		{models.MatchRegexp, "service", "(api|web)-.*"},
+		{models.MatchEqual, "env", "prod*"},
+		{models.MatchField, "dc", ""},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.matchType, op.Matchers[i].Type)
+		assert.Equal(t, e.name, string(op.Matchers[i].Name))
+		assert.Equal(t, e.value, string(op.Matchers[i].Value))
+	}
+}
+
+func TestDAGWithPipeline(t *testing.T) {
+	transforms, edges := parseDAG(t,
+		"fetch name:requests | perSecond | sum service dc | scale 60 | abs")
+	require.Len(t, transforms, 6)
+	assert.Equal(t, functions.FetchType, transforms[0].Op.OpType())
+	assert.Equal(t, 2*time.Minute, transforms[0].Op.(functions.FetchOp).Range)
+	assert.Equal(t, temporal.IRateType, transforms[1].Op.OpType())
+	assert.Equal(t, aggregation.SumType, transforms[2].Op.OpType())
+	assert.Equal(t, scalar.ScalarType, transforms[3].Op.OpType())
+	assert.Equal(t, binary.MultiplyType, transforms[4].Op.OpType())
+	assert.Equal(t, linear.AbsType, transforms[5].Op.OpType())
+
+	assert.Equal(t, parser.Edges{
+		{ParentID: "0", ChildID: "1"},
+		{ParentID: "1", ChildID: "2"},
+		{ParentID: "2", ChildID: "4"},
+		{ParentID: "3", ChildID: "4"},
+		{ParentID: "4", ChildID: "5"},
+	}, edges)
+}
+
+func TestDAGWithComparisons(t *testing.T) {
+	transforms, edges := parseDAG(t,
+		"fetch name:latency | >= 5 | removeAboveValue 10")
+	require.Len(t, transforms, 5)
+	assert.Equal(t, binary.GreaterEqType, transforms[2].Op.OpType())
+	assert.Equal(t, binary.LesserEqType, transforms[4].Op.OpType())
+	assert.Len(t, edges, 4)
+}
+
+func TestDAGWithMacrosAndNesting(t *testing.T) {
+	transforms, edges := parseDAG(t,
+		"reqs = fetch name:requests | perSecond 5m; (reqs | sum) | count")
+	require.Len(t, transforms, 4)
+	assert.Equal(t, 5*time.Minute, transforms[0].Op.(functions.FetchOp).Range)
+	assert.Equal(t, temporal.IRateType, transforms[1].Op.OpType())
+	assert.Equal(t, aggregation.SumType, transforms[2].Op.OpType())
+	assert.Equal(t, aggregation.CountType, transforms[3].Op.OpType())
+	assert.Len(t, edges, 3)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "empty", query: " "},
+		{name: "syntax", query: "fetch name:foo |"},
+		{name: "no fetch", query: "sum"},
+		{name: "positional fetch argument", query: "fetch foo"},
+		{name: "fetch mid pipeline", query: "fetch name:foo | fetch name:bar"},
+		{name: "unknown function", query: "fetch name:foo | unknown"},
+		{name: "scale without value", query: "fetch name:foo | scale"},
+		{name: "perSecond after aggregation", query: "fetch name:foo | sum | perSecond"},
+		{name: "recursive macro", query: "a = a | sum; a"},
+		{name: "nested argument", query: "fetch name:foo | sum (fetch name:bar)"},
+		{name: "bad glob", query: "fetch name:foo{bar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse(tt.query, time.Minute, models.NewTagOptions())
+			if err == nil {
+				_, _, err = p.DAG()
+			}
+			assert.Error(t, err)
+		})
+	}
+}
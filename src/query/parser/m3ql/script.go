@@ -0,0 +1,198 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3ql
+
+import (
+	"errors"
+	"fmt"
+)
+
+type argumentType int
+
+const (
+	booleanArgument argumentType = iota
+	numericArgument
+	patternArgument
+	stringLiteralArgument
+	pipelineArgument
+)
+
+func (t argumentType) String() string {
+	switch t {
+	case booleanArgument:
+		return "boolean"
+	case numericArgument:
+		return "number"
+	case patternArgument:
+		return "pattern"
+	case stringLiteralArgument:
+		return "string literal"
+	case pipelineArgument:
+		return "pipeline"
+	default:
+		return "unknown"
+	}
+}
+
+// script is a parsed M3QL query, consisting of a pipeline and any macros it
+// references.
+type script struct {
+	macros   map[string]*pipeline
+	pipeline *pipeline
+}
+
+// pipeline is a series of expressions, each applied to the output of the
+// previous one.
+type pipeline struct {
+	expressions []*expression
+}
+
+// expression is either a function call with its arguments, or a nested
+// pipeline.
+type expression struct {
+	name      string
+	arguments []argument
+	pipeline  *pipeline
+	// depth is the depth of the pipeline the expression belongs to.
+	depth int
+}
+
+// argument is a single, optionally named, argument of a function call.
+type argument struct {
+	keyword  string
+	kind     argumentType
+	value    string
+	pipeline *pipeline
+}
+
+// astBuilder implements scriptBuilder to build a script from the actions of
+// the parser.
+type astBuilder struct {
+	script      script
+	macro       string
+	keyword     string
+	pipelines   []*pipeline
+	expressions []*expression
+	err         error
+}
+
+var _ scriptBuilder = (*astBuilder)(nil)
+
+func newASTBuilder() *astBuilder {
+	return &astBuilder{
+		script: script{macros: make(map[string]*pipeline)},
+	}
+}
+
+func (b *astBuilder) newMacro(name string) {
+	if _, ok := b.script.macros[name]; ok {
+		b.setErr(fmt.Errorf("macro %s is defined more than once", name))
+	}
+	b.macro = name
+}
+
+func (b *astBuilder) newPipeline() {
+	b.pipelines = append(b.pipelines, &pipeline{})
+}
+
+func (b *astBuilder) endPipeline() {
+	p := b.pipelines[len(b.pipelines)-1]
+	b.pipelines = b.pipelines[:len(b.pipelines)-1]
+
+	depth := len(b.pipelines)
+	if depth == 0 {
+		if b.macro != "" {
+			b.script.macros[b.macro] = p
+			b.macro = ""
+			return
+		}
+
+		b.script.pipeline = p
+		return
+	}
+
+	// A nested pipeline is an argument if the function call it follows in
+	// the enclosing pipeline is still open, otherwise it is an expression of
+	// the enclosing pipeline in its own right.
+	if n := len(b.expressions); n > 0 && b.expressions[n-1].depth == depth {
+		b.addArgument(pipelineArgument, "", p)
+		return
+	}
+
+	enclosing := b.pipelines[depth-1]
+	enclosing.expressions = append(enclosing.expressions, &expression{
+		pipeline: p,
+		depth:    depth,
+	})
+}
+
+func (b *astBuilder) newExpression(name string) {
+	e := &expression{name: name, depth: len(b.pipelines)}
+	p := b.pipelines[len(b.pipelines)-1]
+	p.expressions = append(p.expressions, e)
+	b.expressions = append(b.expressions, e)
+}
+
+func (b *astBuilder) endExpression() {
+	b.expressions = b.expressions[:len(b.expressions)-1]
+}
+
+func (b *astBuilder) newBooleanArgument(value string) {
+	b.addArgument(booleanArgument, value, nil)
+}
+
+func (b *astBuilder) newNumericArgument(value string) {
+	b.addArgument(numericArgument, value, nil)
+}
+
+func (b *astBuilder) newPatternArgument(value string) {
+	b.addArgument(patternArgument, value, nil)
+}
+
+func (b *astBuilder) newStringLiteralArgument(value string) {
+	b.addArgument(stringLiteralArgument, value, nil)
+}
+
+func (b *astBuilder) newKeywordArgument(keyword string) {
+	b.keyword = keyword
+}
+
+func (b *astBuilder) addArgument(kind argumentType, value string, p *pipeline) {
+	if len(b.expressions) == 0 {
+		b.setErr(errors.New("argument found outside of a function call"))
+		return
+	}
+
+	e := b.expressions[len(b.expressions)-1]
+	e.arguments = append(e.arguments, argument{
+		keyword:  b.keyword,
+		kind:     kind,
+		value:    value,
+		pipeline: p,
+	})
+	b.keyword = ""
+}
+
+func (b *astBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
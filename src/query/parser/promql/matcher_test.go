@@ -114,6 +114,46 @@ func TestLabelMatchesToModelMatcher(t *testing.T) {
 	}
 }
 
+func TestLabelMatchesToModelMatcherNumericRange(t *testing.T) {
+	opts := models.NewTagOptions()
+
+	matchers, err := LabelMatchersToModelMatcher([]*labels.Matcher{
+		{
+			Type:  labels.MatchEqual,
+			Name:  "code",
+			Value: "__range__[500,600)",
+		},
+		{
+			Type:  labels.MatchNotEqual,
+			Name:  "code",
+			Value: "__range__(,200]",
+		},
+		{
+			// NB: regexp matchers do not opt in to numeric ranges.
+			Type:  labels.MatchRegexp,
+			Name:  "code",
+			Value: "__range__.*",
+		},
+	}, opts)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(matchers))
+
+	assert.Equal(t, models.MatchNumericRange, matchers[0].Type)
+	assert.Equal(t, "[500,600)", string(matchers[0].Value))
+	assert.Equal(t, models.MatchNotNumericRange, matchers[1].Type)
+	assert.Equal(t, "(,200]", string(matchers[1].Value))
+	assert.Equal(t, models.MatchRegexp, matchers[2].Type)
+
+	_, err = LabelMatchersToModelMatcher([]*labels.Matcher{
+		{
+			Type:  labels.MatchEqual,
+			Name:  "code",
+			Value: "__range__[600,500)",
+		},
+	}, opts)
+	require.Error(t, err)
+}
+
 func TestSanitizeRegex(t *testing.T) {
 	tests := []struct {
 		data, expected string
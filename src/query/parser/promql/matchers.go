@@ -21,8 +21,10 @@
 package promql
 
 import (
+	"bytes"
 	"fmt"
 
+	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/functions"
 	"github.com/m3db/m3/src/query/functions/aggregation"
@@ -371,6 +373,8 @@ const (
 	endGroup    = byte(']')
 )
 
+var numericRangeValuePrefix = []byte(models.NumericRangeValuePrefix)
+
 func sanitizeRegex(value []byte) []byte {
 	lIndex := 0
 	rIndex := len(value)
@@ -444,6 +448,22 @@ func LabelMatchersToModelMatcher(
 			}
 		}
 
+		// NB: equality matchers whose value is prefixed with the numeric range
+		// prefix opt in to matching by numeric range, e.g.
+		// `{status_code="__range__[500,600)"}`.
+		if bytes.HasPrefix(value, numericRangeValuePrefix) &&
+			(matchType == models.MatchEqual || matchType == models.MatchNotEqual) {
+			if matchType == models.MatchEqual {
+				matchType = models.MatchNumericRange
+			} else {
+				matchType = models.MatchNotNumericRange
+			}
+			value = value[len(numericRangeValuePrefix):]
+			if _, err := index.ParseNumericRange(string(value)); err != nil {
+				return nil, err
+			}
+		}
+
 		if matchType == models.MatchRegexp || matchType == models.MatchNotRegexp {
 			// NB: special case here since tags such as `{foo=~"$bar"}` are valid in
 			// prometheus regex patterns, but invalid with m3 index queries. Simplify
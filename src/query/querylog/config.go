@@ -0,0 +1,101 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package querylog
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/m3db/m3/src/x/instrument"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	stdoutPath = "stdout"
+	stderrPath = "stderr"
+)
+
+// Configuration configures the query log.
+type Configuration struct {
+	// Path is the file the query log is written to, rotated once it reaches
+	// the max size, or either stdout or stderr.
+	Path string `yaml:"path" validate:"nonzero"`
+
+	// MaxSizeMB is the size in megabytes the file is rotated at, defaults
+	// to 100 megabytes.
+	MaxSizeMB int `yaml:"maxSizeMB"`
+
+	// MaxBackups is the number of rotated files kept, all are kept if zero.
+	MaxBackups int `yaml:"maxBackups"`
+
+	// MaxAgeDays is the number of days rotated files are kept, they are
+	// kept regardless of age if zero.
+	MaxAgeDays int `yaml:"maxAgeDays"`
+
+	// Compress is whether rotated files are compressed with gzip.
+	Compress bool `yaml:"compress"`
+
+	// SlowQueryThreshold is the total time after which queries are logged,
+	// slow queries are not logged if zero.
+	SlowQueryThreshold time.Duration `yaml:"slowQueryThreshold"`
+
+	// SampleRate is the fraction of the queries that are not slow that are
+	// logged, between 0 and 1. Set it to 1 to log the cost of every query,
+	// for instance to bill tenants by source.
+	SampleRate float64 `yaml:"sampleRate"`
+}
+
+// NewLogger returns a new query logger writing to the configured sink.
+func (c Configuration) NewLogger(iOpts instrument.Options) (Logger, error) {
+	return NewLogger(Options{
+		Writer:             c.newWriter(),
+		SlowQueryThreshold: c.SlowQueryThreshold,
+		SampleRate:         c.SampleRate,
+		InstrumentOptions:  iOpts,
+	})
+}
+
+func (c Configuration) newWriter() io.WriteCloser {
+	switch c.Path {
+	case stdoutPath:
+		return nopCloser{os.Stdout}
+	case stderrPath:
+		return nopCloser{os.Stderr}
+	}
+	return &lumberjack.Logger{
+		Filename:   c.Path,
+		MaxSize:    c.MaxSizeMB,
+		MaxBackups: c.MaxBackups,
+		MaxAge:     c.MaxAgeDays,
+		Compress:   c.Compress,
+	}
+}
+
+// nopCloser does not close the standard streams when the log is closed.
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
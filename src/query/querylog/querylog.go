@@ -0,0 +1,281 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package querylog writes structured JSON line entries for queries that were
+// slow or sampled, describing what each query asked for, what it cost and
+// where its time was spent, and replays logged queries against a coordinator.
+package querylog
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/uber-go/tally"
+)
+
+var (
+	errNoWriter          = errors.New("no query log writer set")
+	errInvalidSampleRate = errors.New("query log sample rate must be between 0 and 1")
+)
+
+// Reason is the reason a query was logged.
+type Reason string
+
+const (
+	// ReasonSlow is the reason of queries logged for taking at least the
+	// slow query threshold.
+	ReasonSlow Reason = "slow"
+	// ReasonSampled is the reason of queries logged for being sampled.
+	ReasonSampled Reason = "sampled"
+)
+
+// Entry is the query log entry of a query.
+type Entry struct {
+	// Time is when the query finished.
+	Time time.Time `json:"time"`
+	// Reason is why the query was logged.
+	Reason Reason `json:"reason"`
+	// Path is the path of the endpoint that served the query.
+	Path string `json:"path"`
+	// Query is the query.
+	Query string `json:"query"`
+	// Instant is whether the query is an instant query.
+	Instant bool `json:"instant"`
+	// Start is the start of the query range.
+	Start time.Time `json:"start"`
+	// End is the end of the query range.
+	End time.Time `json:"end"`
+	// Step is the step of the query.
+	Step time.Duration `json:"step"`
+	// Namespaces are the namespaces resolved for the query.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Source is the source of the query, from the M3-Source header.
+	Source string `json:"source,omitempty"`
+	// Limits are the limits applied to the query.
+	Limits Limits `json:"limits"`
+	// Timings are the time spent in each phase of the query.
+	Timings Timings `json:"timings"`
+	// Stats is the cost of the fetches performed for the query.
+	Stats Stats `json:"stats"`
+	// Error is the error the query failed with, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Limits are the limits applied to a query and whether they limited its
+// results.
+type Limits struct {
+	// SeriesLimit is the maximum number of series fetched.
+	SeriesLimit int `json:"seriesLimit"`
+	// DocsLimit is the maximum number of index documents read.
+	DocsLimit int `json:"docsLimit"`
+	// ReturnedSeriesLimit is the maximum number of series returned.
+	ReturnedSeriesLimit int `json:"returnedSeriesLimit"`
+	// ReturnedDatapointsLimit is the maximum number of datapoints returned.
+	ReturnedDatapointsLimit int `json:"returnedDatapointsLimit"`
+	// RequireExhaustive is whether the query fails if limited.
+	RequireExhaustive bool `json:"requireExhaustive"`
+	// Limited is whether the fetched results were limited.
+	Limited bool `json:"limited"`
+}
+
+// Stats is the cost of the fetches performed for a query in total and for
+// each namespace, the same as returned in the M3-Query-Stats header.
+type Stats struct {
+	storage.NamespaceQueryStats
+	// Namespaces is the cost of the fetches from each namespace.
+	Namespaces map[string]storage.NamespaceQueryStats `json:"namespaces,omitempty"`
+}
+
+// NewStats returns the stats of a query given the stats accumulated while
+// fetching its series.
+func NewStats(stats *storage.QueryStats) Stats {
+	return Stats{
+		NamespaceQueryStats: stats.Total(),
+		Namespaces:          stats.Namespaces(),
+	}
+}
+
+// Timings are the time spent in each phase of a query.
+type Timings struct {
+	// Parse is the time spent parsing the request.
+	Parse time.Duration `json:"parse"`
+	// Fetch is the wall clock time spent fetching series from storage,
+	// counting concurrent fetches once.
+	Fetch time.Duration `json:"fetch"`
+	// Decode is the wall clock time spent decoding the fetched series,
+	// which may overlap with fetching.
+	Decode time.Duration `json:"decode"`
+	// Execute is the wall clock time spent executing the query while
+	// neither fetching nor decoding.
+	Execute time.Duration `json:"execute"`
+	// Total is the total time spent parsing and executing the query.
+	Total time.Duration `json:"total"`
+}
+
+// Logger logs the entries of slow and sampled queries.
+type Logger interface {
+	// Log logs the entry of a query if it was slow or is sampled.
+	Log(entry Entry)
+
+	// Close closes the query log.
+	Close() error
+}
+
+// Options are the options for the query logger.
+type Options struct {
+	// Writer is written the JSON line of each logged entry.
+	Writer io.WriteCloser
+	// SlowQueryThreshold is the total time after which queries are logged,
+	// slow queries are not logged if zero.
+	SlowQueryThreshold time.Duration
+	// SampleRate is the fraction of the queries that are not slow that are
+	// logged.
+	SampleRate float64
+	// RandFn returns a random number in [0, 1) to sample queries with.
+	RandFn func() float64
+	// NowFn is the function used to timestamp entries without a time.
+	NowFn clock.NowFn
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+// Validate validates the options.
+func (o Options) Validate() error {
+	if o.Writer == nil {
+		return errNoWriter
+	}
+	if o.SampleRate < 0 || o.SampleRate > 1 {
+		return errInvalidSampleRate
+	}
+	return nil
+}
+
+type loggerMetrics struct {
+	slow        tally.Counter
+	sampled     tally.Counter
+	skipped     tally.Counter
+	writeErrors tally.Counter
+}
+
+func newLoggerMetrics(scope tally.Scope) loggerMetrics {
+	return loggerMetrics{
+		slow:        scope.Tagged(map[string]string{"reason": string(ReasonSlow)}).Counter("logged"),
+		sampled:     scope.Tagged(map[string]string{"reason": string(ReasonSampled)}).Counter("logged"),
+		skipped:     scope.Counter("skipped"),
+		writeErrors: scope.Counter("write-errors"),
+	}
+}
+
+type logger struct {
+	sync.Mutex
+
+	writer             io.WriteCloser
+	encoder            *json.Encoder
+	slowQueryThreshold time.Duration
+	sampleRate         float64
+	randFn             func() float64
+	nowFn              clock.NowFn
+	metrics            loggerMetrics
+}
+
+// NewLogger returns a new query logger.
+func NewLogger(opts Options) (Logger, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.RandFn == nil {
+		opts.RandFn = rand.Float64
+	}
+	if opts.NowFn == nil {
+		opts.NowFn = time.Now
+	}
+	if opts.InstrumentOptions == nil {
+		opts.InstrumentOptions = instrument.NewOptions()
+	}
+
+	return &logger{
+		writer:             opts.Writer,
+		encoder:            json.NewEncoder(opts.Writer),
+		slowQueryThreshold: opts.SlowQueryThreshold,
+		sampleRate:         opts.SampleRate,
+		randFn:             opts.RandFn,
+		nowFn:              opts.NowFn,
+		metrics: newLoggerMetrics(
+			opts.InstrumentOptions.MetricsScope().SubScope("query-log")),
+	}, nil
+}
+
+func (l *logger) Log(entry Entry) {
+	switch {
+	case l.slowQueryThreshold > 0 && entry.Timings.Total >= l.slowQueryThreshold:
+		entry.Reason = ReasonSlow
+	case l.sampleRate > 0 && l.randFn() < l.sampleRate:
+		entry.Reason = ReasonSampled
+	default:
+		l.metrics.skipped.Inc(1)
+		return
+	}
+
+	if entry.Time.IsZero() {
+		entry.Time = l.nowFn()
+	}
+
+	l.Lock()
+	err := l.encoder.Encode(entry)
+	l.Unlock()
+	if err != nil {
+		l.metrics.writeErrors.Inc(1)
+		return
+	}
+
+	if entry.Reason == ReasonSlow {
+		l.metrics.slow.Inc(1)
+	} else {
+		l.metrics.sampled.Inc(1)
+	}
+}
+
+func (l *logger) Close() error {
+	l.Lock()
+	defer l.Unlock()
+	return l.writer.Close()
+}
+
+type noopLogger struct{}
+
+// NewNoopLogger returns a query logger that logs nothing.
+func NewNoopLogger() Logger {
+	return noopLogger{}
+}
+
+func (noopLogger) Log(Entry) {}
+
+func (noopLogger) Close() error {
+	return nil
+}
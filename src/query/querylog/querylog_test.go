@@ -0,0 +1,153 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package querylog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+type testWriter struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (w *testWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func newTestLogger(
+	t *testing.T,
+	rand float64,
+) (Logger, *testWriter, tally.TestScope) {
+	var (
+		writer = &testWriter{}
+		scope  = tally.NewTestScope("", nil)
+		now    = time.Unix(1600000000, 0).UTC()
+	)
+	logger, err := NewLogger(Options{
+		Writer:             writer,
+		SlowQueryThreshold: time.Second,
+		SampleRate:         0.1,
+		RandFn:             func() float64 { return rand },
+		NowFn:              func() time.Time { return now },
+		InstrumentOptions:  instrument.NewOptions().SetMetricsScope(scope),
+	})
+	require.NoError(t, err)
+	return logger, writer, scope
+}
+
+func testEntry(total time.Duration) Entry {
+	return Entry{
+		Path:       "/api/v1/query_range",
+		Query:      "up",
+		Start:      time.Unix(1599996400, 0).UTC(),
+		End:        time.Unix(1600000000, 0).UTC(),
+		Step:       time.Minute,
+		Namespaces: []string{"default"},
+		Source:     "dashboards",
+		Timings:    Timings{Total: total},
+		Stats: Stats{
+			NamespaceQueryStats: storage.NamespaceQueryStats{SeriesMatched: 3},
+			Namespaces: map[string]storage.NamespaceQueryStats{
+				"default": {SeriesMatched: 3},
+			},
+		},
+	}
+}
+
+func counterValue(scope tally.TestScope, key string) int64 {
+	counter, ok := scope.Snapshot().Counters()[key]
+	if !ok {
+		return 0
+	}
+	return counter.Value()
+}
+
+func TestOptionsValidate(t *testing.T) {
+	assert.Equal(t, errNoWriter, Options{}.Validate())
+	assert.Equal(t, errInvalidSampleRate,
+		Options{Writer: &testWriter{}, SampleRate: 1.5}.Validate())
+	assert.NoError(t, Options{Writer: &testWriter{}, SampleRate: 1}.Validate())
+}
+
+func TestLoggerLogsSlowQueries(t *testing.T) {
+	logger, writer, scope := newTestLogger(t, 0.5)
+
+	logger.Log(testEntry(2 * time.Second))
+	logger.Log(testEntry(time.Millisecond))
+
+	entries, err := ReadEntries(&writer.Buffer)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	expected := testEntry(2 * time.Second)
+	expected.Reason = ReasonSlow
+	expected.Time = time.Unix(1600000000, 0).UTC()
+	assert.Equal(t, expected, entries[0])
+
+	assert.Equal(t, int64(1), counterValue(scope, "query-log.logged+reason=slow"))
+	assert.Equal(t, int64(1), counterValue(scope, "query-log.skipped+"))
+}
+
+func TestLoggerLogsSampledQueries(t *testing.T) {
+	logger, writer, scope := newTestLogger(t, 0.05)
+
+	logger.Log(testEntry(time.Millisecond))
+
+	entries, err := ReadEntries(&writer.Buffer)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, ReasonSampled, entries[0].Reason)
+	assert.Equal(t, int64(1), counterValue(scope, "query-log.logged+reason=sampled"))
+
+	require.NoError(t, logger.Close())
+	assert.True(t, writer.closed)
+}
+
+func TestLoggerJSON(t *testing.T) {
+	logger, writer, _ := newTestLogger(t, 0.5)
+
+	entry := testEntry(2 * time.Second)
+	entry.Error = "boom"
+	logger.Log(entry)
+
+	assert.Equal(t, `{"time":"2020-09-13T12:26:40Z","reason":"slow",`+
+		`"path":"/api/v1/query_range","query":"up","instant":false,`+
+		`"start":"2020-09-13T11:26:40Z","end":"2020-09-13T12:26:40Z",`+
+		`"step":60000000000,"namespaces":["default"],"source":"dashboards",`+
+		`"limits":{"seriesLimit":0,"docsLimit":0,"returnedSeriesLimit":0,`+
+		`"returnedDatapointsLimit":0,"requireExhaustive":false,"limited":false},`+
+		`"timings":{"parse":0,"fetch":0,"decode":0,"execute":0,"total":2000000000},`+
+		`"stats":{"seriesMatched":3,"docsRead":0,"bytesRead":0,"blocksFetched":0,`+
+		`"datapointsDecoded":0,"namespaces":{"default":{"seriesMatched":3,"docsRead":0,`+
+		`"bytesRead":0,"blocksFetched":0,"datapointsDecoded":0}}},"error":"boom"}`+"\n",
+		writer.String())
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package querylog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/headers"
+)
+
+const (
+	defaultRangeQueryPath   = "/api/v1/query_range"
+	defaultInstantQueryPath = "/api/v1/query"
+	maxEntrySize            = 16 * 1024 * 1024
+)
+
+var errNoURL = errors.New("no coordinator URL set")
+
+// ReadEntries reads the entries of a query log.
+func ReadEntries(r io.Reader) ([]Entry, error) {
+	var (
+		entries []Entry
+		scanner = bufio.NewScanner(r)
+	)
+	scanner.Buffer(nil, maxEntrySize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid query log entry on line %d: %w",
+				line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// ReplayOptions are the options for replaying logged queries.
+type ReplayOptions struct {
+	// URL is the base URL of the coordinator, e.g. http://localhost:7201.
+	URL string
+	// Client is the client queries are issued with.
+	Client *http.Client
+	// Concurrency is the number of queries issued concurrently.
+	Concurrency int
+	// QPS is the rate queries are issued at, unlimited if zero.
+	QPS float64
+	// ShiftToNow shifts the range of the queries to end when they are
+	// replayed, keeping the duration of their range.
+	ShiftToNow bool
+	// NowFn is the function used to shift the range of the queries.
+	NowFn clock.NowFn
+}
+
+// ReplayResult is the result of replaying logged queries.
+type ReplayResult struct {
+	// Queries is the number of queries issued.
+	Queries int
+	// Errors is the number of queries that failed.
+	Errors int
+	// Latencies are the latencies of the queries that succeeded.
+	Latencies []time.Duration
+}
+
+// Replay reissues the logged queries against a coordinator.
+func Replay(
+	ctx context.Context,
+	entries []Entry,
+	opts ReplayOptions,
+) (ReplayResult, error) {
+	if opts.URL == "" {
+		return ReplayResult{}, errNoURL
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.NowFn == nil {
+		opts.NowFn = time.Now
+	}
+
+	var (
+		result ReplayResult
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		workCh = make(chan Entry)
+	)
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entry := range workCh {
+				latency, err := replayEntry(ctx, entry, opts)
+				mu.Lock()
+				result.Queries++
+				if err != nil {
+					result.Errors++
+				} else {
+					result.Latencies = append(result.Latencies, latency)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var tickCh <-chan time.Time
+	if opts.QPS > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.QPS))
+		defer ticker.Stop()
+		tickCh = ticker.C
+	}
+
+	var err error
+dispatch:
+	for _, entry := range entries {
+		if tickCh != nil {
+			select {
+			case <-tickCh:
+			case <-ctx.Done():
+				err = ctx.Err()
+				break dispatch
+			}
+		}
+		select {
+		case workCh <- entry:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break dispatch
+		}
+	}
+	close(workCh)
+	wg.Wait()
+
+	return result, err
+}
+
+func replayEntry(
+	ctx context.Context,
+	entry Entry,
+	opts ReplayOptions,
+) (time.Duration, error) {
+	req, err := NewReplayRequest(ctx, opts.URL, entry, opts.ShiftToNow,
+		opts.NowFn())
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	resp, err := opts.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return 0, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return 0, fmt.Errorf("query failed with status %d", resp.StatusCode)
+	}
+	return time.Since(start), nil
+}
+
+// NewReplayRequest returns the request that reissues a logged query against
+// the coordinator at the base URL, with its range shifted to end at now if
+// shiftToNow is set.
+func NewReplayRequest(
+	ctx context.Context,
+	baseURL string,
+	entry Entry,
+	shiftToNow bool,
+	now time.Time,
+) (*http.Request, error) {
+	start, end := entry.Start, entry.End
+	if shiftToNow {
+		shift := now.Sub(end)
+		start, end = start.Add(shift), end.Add(shift)
+	}
+
+	path := entry.Path
+	params := url.Values{}
+	params.Set("query", entry.Query)
+	if entry.Instant {
+		if path == "" {
+			path = defaultInstantQueryPath
+		}
+		params.Set("time", formatTime(end))
+	} else {
+		if path == "" {
+			path = defaultRangeQueryPath
+		}
+		params.Set("start", formatTime(start))
+		params.Set("end", formatTime(end))
+		params.Set("step", strconv.FormatFloat(entry.Step.Seconds(), 'f', -1, 64))
+	}
+
+	u := strings.TrimSuffix(baseURL, "/") + path + "?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Source != "" {
+		req.Header.Set(headers.SourceHeader, entry.Source)
+	}
+	return req, nil
+}
+
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', -1, 64)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package querylog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/x/headers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadEntries(t *testing.T) {
+	log := `{"query":"up","instant":true}
+
+{"query":"rate(foo[1m])","step":15000000000}
+`
+	entries, err := ReadEntries(strings.NewReader(log))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "up", entries[0].Query)
+	assert.True(t, entries[0].Instant)
+	assert.Equal(t, "rate(foo[1m])", entries[1].Query)
+	assert.Equal(t, 15*time.Second, entries[1].Step)
+
+	_, err = ReadEntries(strings.NewReader(log + "{\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 4")
+}
+
+func TestNewReplayRequest(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		now   = time.Unix(1600007200, 0)
+		entry = testEntry(time.Second)
+	)
+
+	req, err := NewReplayRequest(ctx, "http://coordinator:7201/", entry, false, now)
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, "coordinator:7201", req.URL.Host)
+	assert.Equal(t, "/api/v1/query_range", req.URL.Path)
+	assert.Equal(t, "up", req.URL.Query().Get("query"))
+	assert.Equal(t, "1599996400", req.URL.Query().Get("start"))
+	assert.Equal(t, "1600000000", req.URL.Query().Get("end"))
+	assert.Equal(t, "60", req.URL.Query().Get("step"))
+	assert.Equal(t, "dashboards", req.Header.Get(headers.SourceHeader))
+
+	req, err = NewReplayRequest(ctx, "http://coordinator:7201", entry, true, now)
+	require.NoError(t, err)
+	assert.Equal(t, "1600003600", req.URL.Query().Get("start"))
+	assert.Equal(t, "1600007200", req.URL.Query().Get("end"))
+
+	entry.Path = ""
+	entry.Instant = true
+	entry.Source = ""
+	req, err = NewReplayRequest(ctx, "http://coordinator:7201", entry, false, now)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/query", req.URL.Path)
+	assert.Equal(t, "1600000000", req.URL.Query().Get("time"))
+	assert.Equal(t, "", req.URL.Query().Get("start"))
+	assert.Equal(t, "", req.Header.Get(headers.SourceHeader))
+}
+
+func TestReplay(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		queries []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query().Get("query")
+			mu.Lock()
+			queries = append(queries, query)
+			mu.Unlock()
+			if query == "bad" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+	defer server.Close()
+
+	good, bad := testEntry(time.Second), testEntry(time.Second)
+	bad.Query = "bad"
+	entries := []Entry{good, bad, good}
+
+	result, err := Replay(context.Background(), entries, ReplayOptions{
+		URL:         server.URL,
+		Concurrency: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Queries)
+	assert.Equal(t, 1, result.Errors)
+	assert.Len(t, result.Latencies, 2)
+	assert.Len(t, queries, 3)
+
+	_, err = Replay(context.Background(), entries, ReplayOptions{})
+	assert.Equal(t, errNoURL, err)
+}
@@ -27,6 +27,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/block"
@@ -118,7 +119,9 @@ func encodeFetchRequest(
 		Matchers: &rpc.FetchRequest_TagMatchers{
 			TagMatchers: matchers,
 		},
-		Options: opts,
+		Options:           opts,
+		Resolution:        int64(query.Resolution),
+		RollupAggregation: int32(query.RollupAggregation),
 	}, nil
 }
 
@@ -290,6 +293,10 @@ func encodeMatcherTypeToProto(t models.MatchType) (rpc.MatcherType, error) {
 		return rpc.MatcherType_NOTEXISTS, nil
 	case models.MatchAll:
 		return rpc.MatcherType_ALL, nil
+	case models.MatchNumericRange:
+		return rpc.MatcherType_NUMERICRANGE, nil
+	case models.MatchNotNumericRange:
+		return rpc.MatcherType_NOTNUMERICRANGE, nil
 	default:
 		return 0, fmt.Errorf("unknown matcher type for proto encoding")
 	}
@@ -347,10 +354,17 @@ func decodeFetchRequest(
 		return nil, err
 	}
 
+	aggregation := rollup.Aggregation(req.RollupAggregation)
+	if err := aggregation.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &storage.FetchQuery{
-		TagMatchers: tags,
-		Start:       toTime(req.Start),
-		End:         toTime(req.End),
+		TagMatchers:       tags,
+		Start:             toTime(req.Start),
+		End:               toTime(req.End),
+		Resolution:        time.Duration(req.Resolution),
+		RollupAggregation: aggregation,
 	}, nil
 }
 
@@ -29,6 +29,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
 	"github.com/m3db/m3/src/metrics/generated/proto/policypb"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
@@ -107,6 +108,8 @@ func readQueriesAreEqual(t *testing.T, this, other *storage.FetchQuery) {
 	assert.True(t, this.End.Equal(other.End))
 	assert.Equal(t, len(this.TagMatchers), len(other.TagMatchers))
 	assert.Equal(t, 2, len(other.TagMatchers))
+	assert.Equal(t, this.Resolution, other.Resolution)
+	assert.Equal(t, this.RollupAggregation, other.RollupAggregation)
 	for i, matcher := range this.TagMatchers {
 		assert.Equal(t, matcher.Type, other.TagMatchers[i].Type)
 		assert.Equal(t, matcher.Name, other.TagMatchers[i].Name)
@@ -123,9 +126,11 @@ func createStorageFetchQuery(t *testing.T) (*storage.FetchQuery, time.Time, time
 
 	matchers := []models.Matcher{m0, m1}
 	return &storage.FetchQuery{
-		TagMatchers: matchers,
-		Start:       start,
-		End:         end,
+		TagMatchers:       matchers,
+		Start:             start,
+		End:               end,
+		Resolution:        time.Minute,
+		RollupAggregation: rollup.Max,
 	}, start, end
 }
 
@@ -147,6 +152,8 @@ func TestEncodeFetchMessage(t *testing.T) {
 	require.NotNil(t, grpcQ)
 	assert.Equal(t, fromTime(start), grpcQ.GetStart())
 	assert.Equal(t, fromTime(end), grpcQ.GetEnd())
+	assert.Equal(t, int64(time.Minute), grpcQ.GetResolution())
+	assert.Equal(t, int32(rollup.Max), grpcQ.GetRollupAggregation())
 	mRPC := grpcQ.GetTagMatchers().GetTagMatchers()
 	assert.Equal(t, 2, len(mRPC))
 	assert.Equal(t, name0, mRPC[0].GetName())
@@ -207,6 +214,22 @@ func TestEncodeDecodeFetchQuery(t *testing.T) {
 	assert.Equal(t, gq, gqr)
 }
 
+func TestEncodeDecodeNumericRangeMatchers(t *testing.T) {
+	matchers := models.Matchers{
+		mustNewMatcher(models.MatchNumericRange, "status_code", "[500,600)"),
+		mustNewMatcher(models.MatchNotNumericRange, "latency", "(0.5,]"),
+	}
+
+	encoded, err := encodeTagMatchers(matchers)
+	require.NoError(t, err)
+	require.Equal(t, rpc.MatcherType_NUMERICRANGE, encoded.TagMatchers[0].Type)
+	require.Equal(t, rpc.MatcherType_NOTNUMERICRANGE, encoded.TagMatchers[1].Type)
+
+	decoded, err := decodeTagMatchers(encoded)
+	require.NoError(t, err)
+	require.Equal(t, matchers.String(), decoded.String())
+}
+
 func TestEncodeMetadata(t *testing.T) {
 	headers := make(http.Header)
 	headers.Add("Foo", "bar")
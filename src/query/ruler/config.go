@@ -0,0 +1,139 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/prometheus/prometheus/promql"
+)
+
+// Configuration configures the ruler.
+type Configuration struct {
+	// RuleFiles are the Prometheus rule group files to load, each may be a
+	// glob matching multiple files.
+	RuleFiles []string `yaml:"ruleFiles"`
+
+	// EvaluationInterval is the evaluation interval of groups that do not
+	// set one, defaults to one minute.
+	EvaluationInterval *time.Duration `yaml:"evaluationInterval"`
+
+	// ExternalURL is made available to alert templates as $externalURL.
+	ExternalURL string `yaml:"externalURL"`
+
+	// Sharding shards the rule groups between coordinators, every group is
+	// evaluated if not set. The rulers of coordinators do not coordinate, so
+	// either only one coordinator should configure a ruler or each should be
+	// assigned a different instance.
+	Sharding *ShardingConfiguration `yaml:"sharding"`
+
+	// Alertmanager configures where alerts are sent, alerts are only
+	// tracked if not set.
+	Alertmanager *AlertmanagerConfiguration `yaml:"alertmanager"`
+}
+
+// AlertmanagerConfiguration configures the Alertmanager-compatible webhook
+// alerts are sent to.
+type AlertmanagerConfiguration struct {
+	// URL is the URL alerts are posted to, e.g.
+	// http://alertmanager:9093/api/v2/alerts.
+	URL string `yaml:"url" validate:"nonzero"`
+
+	// Timeout is the timeout for sending alerts.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// ShardingConfiguration assigns each rule group to one of a number of
+// coordinators by the hash of the name of the group.
+type ShardingConfiguration struct {
+	// NumInstances is the number of coordinators groups are sharded between.
+	NumInstances int `yaml:"numInstances" validate:"min=1"`
+
+	// Instance is the index of this coordinator, from zero.
+	Instance int `yaml:"instance" validate:"min=0"`
+}
+
+// NewRuler loads the rule files and returns a ruler that evaluates them.
+func (c Configuration) NewRuler(
+	engine *promql.Engine,
+	store storage.Storage,
+	fetchOpts *storage.FetchOptions,
+	tagOpts models.TagOptions,
+	iOpts instrument.Options,
+) (*Ruler, error) {
+	groups, err := LoadRuleGroups(c.RuleFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := Options{
+		Engine:            engine,
+		Storage:           store,
+		FetchOptions:      fetchOpts,
+		TagOptions:        tagOpts,
+		ExternalURL:       c.ExternalURL,
+		InstrumentOptions: iOpts,
+	}
+	if c.EvaluationInterval != nil {
+		opts.EvaluationInterval = *c.EvaluationInterval
+	}
+	if c.Sharding != nil {
+		opts.NumInstances = c.Sharding.NumInstances
+		opts.Instance = c.Sharding.Instance
+	}
+	if c.Alertmanager != nil {
+		opts.Notifier = NewWebhookNotifier(c.Alertmanager.URL,
+			c.Alertmanager.Timeout)
+	}
+
+	return NewRuler(groups, opts)
+}
+
+// LoadRuleGroups loads the rule groups from the Prometheus rule files
+// matching the globs.
+func LoadRuleGroups(globs []string) ([]rulefmt.RuleGroup, error) {
+	var groups []rulefmt.RuleGroup
+	for _, glob := range globs {
+		files, err := filepath.Glob(glob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule files glob %s: %w", glob, err)
+		}
+
+		for _, file := range files {
+			ruleGroups, errs := rulefmt.ParseFile(file)
+			if len(errs) > 0 {
+				return nil, fmt.Errorf("could not load rule file %s: %w",
+					file, errs[0])
+			}
+
+			groups = append(groups, ruleGroups.Groups...)
+		}
+	}
+
+	return groups, nil
+}
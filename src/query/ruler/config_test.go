@@ -0,0 +1,51 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRuleGroups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ruler")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "requests.yml"),
+		[]byte(testRules), 0600))
+	groups, err := LoadRuleGroups([]string{filepath.Join(dir, "*.yml")})
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "requests", groups[0].Name)
+	assert.Len(t, groups[0].Rules, 2)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "invalid.yml"),
+		[]byte("groups:\n  - name: invalid\n    rules:\n      - record: foo\n        expr: sum(\n"),
+		0600))
+	_, err = LoadRuleGroups([]string{filepath.Join(dir, "*.yml")})
+	require.Error(t, err)
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+type groupMetrics struct {
+	evaluations        tally.Counter
+	evaluationFailures tally.Counter
+	evaluationLatency  tally.Timer
+	samplesWritten     tally.Counter
+	writeErrors        tally.Counter
+	alertsSent         tally.Counter
+	alertSendErrors    tally.Counter
+}
+
+func newGroupMetrics(scope tally.Scope) groupMetrics {
+	return groupMetrics{
+		evaluations:        scope.Counter("evaluations"),
+		evaluationFailures: scope.Counter("evaluation-failures"),
+		evaluationLatency:  scope.Timer("evaluation-latency"),
+		samplesWritten:     scope.Counter("samples-written"),
+		writeErrors:        scope.Counter("write-errors"),
+		alertsSent:         scope.Counter("alerts-sent"),
+		alertSendErrors:    scope.Counter("alert-send-errors"),
+	}
+}
+
+// group is a group of rules evaluated in order on a shared interval.
+type group struct {
+	name     string
+	interval time.Duration
+	// rules holds the rules in the order they are evaluated in, so that
+	// recording rules can depend on the rules before them.
+	rules   []interface{}
+	opts    Options
+	query   queryFn
+	logger  *zap.Logger
+	metrics groupMetrics
+}
+
+func newGroup(
+	rg rulefmt.RuleGroup,
+	defaultInterval time.Duration,
+	opts Options,
+	query queryFn,
+) (*group, error) {
+	interval := time.Duration(rg.Interval)
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	scope := opts.InstrumentOptions.MetricsScope().
+		Tagged(map[string]string{"rule-group": rg.Name})
+	g := &group{
+		name:     rg.Name,
+		interval: interval,
+		opts:     opts,
+		query:    query,
+		logger: opts.InstrumentOptions.Logger().
+			With(zap.String("ruleGroup", rg.Name)),
+		metrics: newGroupMetrics(scope),
+	}
+
+	for _, r := range rg.Rules {
+		if r.Record.Value != "" {
+			rule, err := newRecordingRule(r)
+			if err != nil {
+				return nil, fmt.Errorf("group %s: %w", rg.Name, err)
+			}
+
+			g.rules = append(g.rules, rule)
+			continue
+		}
+
+		rule, err := newAlertingRule(r, opts.ExternalURL)
+		if err != nil {
+			return nil, fmt.Errorf("group %s: %w", rg.Name, err)
+		}
+
+		g.rules = append(g.rules, rule)
+	}
+
+	return g, nil
+}
+
+// run evaluates the group every interval until the done channel is closed.
+func (g *group) run(doneCh <-chan struct{}) {
+	for {
+		ts := g.evalTimestamp(g.opts.NowFn())
+		g.eval(ts)
+
+		// Evaluations that overrun the interval skip the timestamps missed.
+		timer := time.NewTimer(ts.Add(g.interval).Sub(g.opts.NowFn()))
+		select {
+		case <-doneCh:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// evalTimestamp returns the timestamp to evaluate the group at, which is
+// aligned to the interval so that it is the same for every coordinator.
+func (g *group) evalTimestamp(now time.Time) time.Time {
+	return now.Truncate(g.interval)
+}
+
+// eval evaluates every rule of the group at the timestamp, writing the
+// recorded series and sending the alerts. Failing rules are logged and do
+// not stop the remaining rules from being evaluated.
+func (g *group) eval(ts time.Time) {
+	g.metrics.evaluations.Inc(1)
+	sw := g.metrics.evaluationLatency.Start()
+	defer sw.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), g.interval)
+	defer cancel()
+
+	var alerts []Alert
+	for _, r := range g.rules {
+		switch rule := r.(type) {
+		case *recordingRule:
+			vector, err := rule.eval(ctx, ts, g.query)
+			if err != nil {
+				g.metrics.evaluationFailures.Inc(1)
+				g.logger.Error("could not evaluate recording rule",
+					zap.String("rule", rule.name), zap.Error(err))
+				continue
+			}
+
+			if err := g.write(ctx, vector); err != nil {
+				g.metrics.writeErrors.Inc(1)
+				g.logger.Error("could not write recorded series",
+					zap.String("rule", rule.name), zap.Error(err))
+			}
+
+		case *alertingRule:
+			ruleAlerts, err := rule.eval(ctx, ts, g.interval, g.query)
+			if err != nil {
+				g.metrics.evaluationFailures.Inc(1)
+				g.logger.Error("could not evaluate alerting rule",
+					zap.String("rule", rule.name), zap.Error(err))
+				continue
+			}
+
+			alerts = append(alerts, ruleAlerts...)
+		}
+	}
+
+	if len(alerts) == 0 || g.opts.Notifier == nil {
+		return
+	}
+
+	if err := g.opts.Notifier.Send(ctx, alerts); err != nil {
+		g.metrics.alertSendErrors.Inc(1)
+		g.logger.Error("could not send alerts",
+			zap.Int("alerts", len(alerts)), zap.Error(err))
+		return
+	}
+
+	g.metrics.alertsSent.Inc(int64(len(alerts)))
+}
+
+func (g *group) write(ctx context.Context, vector promql.Vector) error {
+	multiErr := xerrors.NewMultiError()
+	for _, sample := range vector {
+		tags := models.NewTags(len(sample.Metric), g.opts.TagOptions)
+		for _, l := range sample.Metric {
+			tags = tags.AddTag(models.Tag{
+				Name:  []byte(l.Name),
+				Value: []byte(l.Value),
+			})
+		}
+
+		query, err := storage.NewWriteQuery(storage.WriteQueryOptions{
+			Tags: tags,
+			Datapoints: ts.Datapoints{
+				{
+					Timestamp: xtime.UnixNano(sample.T * int64(time.Millisecond)),
+					Value:     sample.V,
+				},
+			},
+			Unit: xtime.Millisecond,
+			Attributes: storagemetadata.Attributes{
+				MetricsType: storagemetadata.UnaggregatedMetricsType,
+			},
+		})
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		if err := g.opts.Storage.Write(ctx, query); err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		g.metrics.samplesWritten.Inc(1)
+	}
+
+	return multiErr.FinalError()
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/template"
+)
+
+const (
+	// alertNameLabel is the label holding the name of the alerting rule.
+	alertNameLabel = "alertname"
+	// resendMultiple is how many evaluation intervals a firing alert is
+	// valid for without being sent again.
+	resendMultiple = 4
+	// templateDefs makes the Prometheus template variables available to
+	// label and annotation templates.
+	templateDefs = "{{$labels := .Labels}}{{$externalLabels := .ExternalLabels}}" +
+		"{{$externalURL := .ExternalURL}}{{$value := .Value}}"
+)
+
+// queryFn evaluates an instant query.
+type queryFn func(ctx context.Context, q string, ts time.Time) (promql.Vector, error)
+
+// recordingRule records the result of its expression as a new series.
+type recordingRule struct {
+	name   string
+	expr   parser.Expr
+	labels labels.Labels
+}
+
+func newRecordingRule(r rulefmt.RuleNode) (*recordingRule, error) {
+	expr, err := parser.ParseExpr(r.Expr.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression for rule %s: %w",
+			r.Record.Value, err)
+	}
+
+	return &recordingRule{
+		name:   r.Record.Value,
+		expr:   expr,
+		labels: labels.FromMap(r.Labels),
+	}, nil
+}
+
+// eval returns the samples to record at the timestamp.
+func (r *recordingRule) eval(
+	ctx context.Context,
+	ts time.Time,
+	query queryFn,
+) (promql.Vector, error) {
+	vector, err := query(ctx, r.expr.String(), ts)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint64]struct{}, len(vector))
+	for i, sample := range vector {
+		lb := labels.NewBuilder(sample.Metric).Set(labels.MetricName, r.name)
+		for _, l := range r.labels {
+			lb.Set(l.Name, l.Value)
+		}
+
+		sample.Metric = lb.Labels()
+		h := sample.Metric.Hash()
+		if _, ok := seen[h]; ok {
+			return nil, fmt.Errorf("rule %s produced duplicate series: %s",
+				r.name, sample.Metric)
+		}
+
+		seen[h] = struct{}{}
+		vector[i] = sample
+	}
+
+	return vector, nil
+}
+
+type alertState int
+
+const (
+	alertPending alertState = iota
+	alertFiring
+	alertResolved
+)
+
+type activeAlert struct {
+	state       alertState
+	labels      labels.Labels
+	annotations labels.Labels
+	activeAt    time.Time
+	firedAt     time.Time
+	resolvedAt  time.Time
+}
+
+// alertingRule fires an alert for every series its expression returns for
+// at least the hold duration.
+type alertingRule struct {
+	name         string
+	expr         parser.Expr
+	holdDuration time.Duration
+	labels       labels.Labels
+	annotations  labels.Labels
+	externalURL  string
+
+	active map[uint64]*activeAlert
+}
+
+func newAlertingRule(r rulefmt.RuleNode, externalURL string) (*alertingRule, error) {
+	expr, err := parser.ParseExpr(r.Expr.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression for alert %s: %w",
+			r.Alert.Value, err)
+	}
+
+	return &alertingRule{
+		name:         r.Alert.Value,
+		expr:         expr,
+		holdDuration: time.Duration(r.For),
+		labels:       labels.FromMap(r.Labels),
+		annotations:  labels.FromMap(r.Annotations),
+		externalURL:  externalURL,
+		active:       make(map[uint64]*activeAlert),
+	}, nil
+}
+
+// eval updates the state of the alerts of the rule at the timestamp, and
+// returns the firing and newly resolved alerts that should be sent.
+func (r *alertingRule) eval(
+	ctx context.Context,
+	ts time.Time,
+	interval time.Duration,
+	query queryFn,
+) ([]Alert, error) {
+	vector, err := query(ctx, r.expr.String(), ts)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint64]struct{}, len(vector))
+	for _, sample := range vector {
+		data := template.AlertTemplateData(sample.Metric.Map(), nil,
+			r.externalURL, sample.V)
+		expand := func(text string) string {
+			return r.expandTemplate(ctx, text, data, ts, query)
+		}
+
+		lb := labels.NewBuilder(sample.Metric).Del(labels.MetricName)
+		for _, l := range r.labels {
+			lb.Set(l.Name, expand(l.Value))
+		}
+		lb.Set(alertNameLabel, r.name)
+		alertLabels := lb.Labels()
+
+		annotations := make(labels.Labels, 0, len(r.annotations))
+		for _, a := range r.annotations {
+			annotations = append(annotations, labels.Label{
+				Name:  a.Name,
+				Value: expand(a.Value),
+			})
+		}
+
+		h := alertLabels.Hash()
+		if _, ok := seen[h]; ok {
+			return nil, fmt.Errorf("alert %s produced duplicate alerts: %s",
+				r.name, alertLabels)
+		}
+		seen[h] = struct{}{}
+
+		if a, ok := r.active[h]; ok && a.state != alertResolved {
+			a.annotations = annotations
+			continue
+		}
+
+		r.active[h] = &activeAlert{
+			state:       alertPending,
+			labels:      alertLabels,
+			annotations: annotations,
+			activeAt:    ts,
+		}
+	}
+
+	var alerts []Alert
+	for h, a := range r.active {
+		if _, ok := seen[h]; !ok {
+			if a.state != alertFiring {
+				// Pending alerts are dropped, and resolved alerts have been
+				// sent already.
+				delete(r.active, h)
+				continue
+			}
+
+			a.state = alertResolved
+			a.resolvedAt = ts
+			alerts = append(alerts, a.toAlert(a.resolvedAt))
+			continue
+		}
+
+		if a.state == alertPending && ts.Sub(a.activeAt) >= r.holdDuration {
+			a.state = alertFiring
+			a.firedAt = ts
+		}
+
+		if a.state == alertFiring {
+			validUntil := ts.Add(resendMultiple * interval)
+			alerts = append(alerts, a.toAlert(validUntil))
+		}
+	}
+
+	return alerts, nil
+}
+
+func (r *alertingRule) expandTemplate(
+	ctx context.Context,
+	text string,
+	data interface{},
+	ts time.Time,
+	query queryFn,
+) string {
+	var externalURL *url.URL
+	if r.externalURL != "" {
+		externalURL, _ = url.Parse(r.externalURL)
+	}
+
+	expander := template.NewTemplateExpander(ctx, templateDefs+text,
+		"__alert_"+r.name, data, model.TimeFromUnixNano(ts.UnixNano()),
+		template.QueryFunc(query), externalURL, nil)
+	result, err := expander.Expand()
+	if err != nil {
+		return fmt.Sprintf("<error expanding template: %v>", err)
+	}
+
+	return result
+}
+
+func (a *activeAlert) toAlert(endsAt time.Time) Alert {
+	alert := Alert{
+		Labels:   a.labels.Map(),
+		StartsAt: a.firedAt,
+		EndsAt:   endsAt,
+	}
+	if len(a.annotations) > 0 {
+		alert.Annotations = a.annotations.Map()
+	}
+
+	return alert
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ruler evaluates Prometheus recording and alerting rules against
+// the coordinator's storage, writing recorded series back to the storage
+// and sending alerts to an Alertmanager-compatible webhook.
+//
+// There is no coordination between the rulers of different coordinators, so
+// either a single coordinator should run the ruler or the rule groups should
+// be sharded between the coordinators that do, otherwise each of them sends
+// the same alerts. Groups are evaluated at timestamps aligned to their
+// interval so that a group evaluated by more than one coordinator writes the
+// same samples rather than samples at slightly different timestamps.
+package ruler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/prometheus"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/prometheus/prometheus/promql"
+	promstorage "github.com/prometheus/prometheus/storage"
+)
+
+const defaultEvaluationInterval = time.Minute
+
+var (
+	errNoEngine             = errors.New("no engine set")
+	errNoStorage            = errors.New("no storage set")
+	errInvalidNumInstances  = errors.New("number of ruler instances must not be negative")
+	errInvalidInstanceIndex = errors.New("ruler instance must be less than the number of instances")
+)
+
+// Options are the options for the ruler.
+type Options struct {
+	// Engine evaluates the rule expressions.
+	Engine *promql.Engine
+	// Storage is queried by rule expressions and written to by recording rules.
+	Storage storage.Storage
+	// FetchOptions are the options for the queries of rule expressions.
+	FetchOptions *storage.FetchOptions
+	// TagOptions are the options for the tags of recorded series.
+	TagOptions models.TagOptions
+	// Notifier sends the alerts, alerts are only tracked if not set.
+	Notifier Notifier
+	// ExternalURL is made available to alert templates as $externalURL.
+	ExternalURL string
+	// EvaluationInterval is the interval of groups that do not set one.
+	EvaluationInterval time.Duration
+	// NumInstances is the number of rulers the rule groups are sharded
+	// between by name, all groups are evaluated if zero.
+	NumInstances int
+	// Instance is the index of this ruler out of NumInstances, only the
+	// groups assigned to it are evaluated.
+	Instance int
+	// NowFn is the function used to determine evaluation timestamps.
+	NowFn clock.NowFn
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+// Validate validates the options.
+func (o Options) Validate() error {
+	if o.Engine == nil {
+		return errNoEngine
+	}
+	if o.Storage == nil {
+		return errNoStorage
+	}
+	if o.NumInstances < 0 {
+		return errInvalidNumInstances
+	}
+	if o.NumInstances > 0 && (o.Instance < 0 || o.Instance >= o.NumInstances) {
+		return errInvalidInstanceIndex
+	}
+	return nil
+}
+
+// Ruler evaluates groups of rules, each on its own interval.
+type Ruler struct {
+	groups []*group
+
+	wg     sync.WaitGroup
+	doneCh chan struct{}
+	once   sync.Once
+}
+
+// NewRuler returns a new ruler for the rule groups, every group is validated
+// although only the groups assigned to the instance are evaluated.
+func NewRuler(groups []rulefmt.RuleGroup, opts Options) (*Ruler, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.FetchOptions == nil {
+		opts.FetchOptions = storage.NewFetchOptions()
+	}
+	if opts.TagOptions == nil {
+		opts.TagOptions = models.NewTagOptions()
+	}
+	if opts.EvaluationInterval <= 0 {
+		opts.EvaluationInterval = defaultEvaluationInterval
+	}
+	if opts.NowFn == nil {
+		opts.NowFn = time.Now
+	}
+	if opts.InstrumentOptions == nil {
+		opts.InstrumentOptions = instrument.NewOptions()
+	}
+
+	opts.InstrumentOptions = opts.InstrumentOptions.SetMetricsScope(
+		opts.InstrumentOptions.MetricsScope().SubScope("ruler"))
+
+	query := newQueryFn(opts)
+	r := &Ruler{doneCh: make(chan struct{})}
+	names := make(map[string]struct{}, len(groups))
+	for _, rg := range groups {
+		if _, ok := names[rg.Name]; ok {
+			return nil, fmt.Errorf("rule group %s is defined more than once",
+				rg.Name)
+		}
+		names[rg.Name] = struct{}{}
+
+		g, err := newGroup(rg, opts.EvaluationInterval, opts, query)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ownsGroup(rg.Name, opts) {
+			continue
+		}
+		r.groups = append(r.groups, g)
+	}
+
+	return r, nil
+}
+
+// Start starts evaluating the rule groups.
+func (r *Ruler) Start() {
+	for _, g := range r.groups {
+		g := g
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			g.run(r.doneCh)
+		}()
+	}
+}
+
+// Close stops evaluating the rule groups and waits for any in progress
+// evaluations to complete.
+func (r *Ruler) Close() error {
+	r.once.Do(func() {
+		close(r.doneCh)
+	})
+	r.wg.Wait()
+	return nil
+}
+
+// ownsGroup returns whether the group is evaluated by the instance.
+func ownsGroup(name string, opts Options) bool {
+	if opts.NumInstances <= 1 {
+		return true
+	}
+	return xxhash.Sum64String(name)%uint64(opts.NumInstances) == uint64(opts.Instance)
+}
+
+func newQueryFn(opts Options) queryFn {
+	queryable := prometheus.NewPrometheusQueryable(prometheus.PrometheusOptions{
+		Storage:           opts.Storage,
+		InstrumentOptions: opts.InstrumentOptions,
+	})
+
+	return func(ctx context.Context, q string, ts time.Time) (promql.Vector, error) {
+		return instantQuery(ctx, opts.Engine, queryable, opts.FetchOptions, q, ts)
+	}
+}
+
+func instantQuery(
+	ctx context.Context,
+	engine *promql.Engine,
+	queryable promstorage.Queryable,
+	fetchOpts *storage.FetchOptions,
+	q string,
+	ts time.Time,
+) (promql.Vector, error) {
+	// NB: the queryable reads the fetch options and the result metadata
+	// receive function from the context, as the query handlers do.
+	ctx = context.WithValue(ctx, prometheus.FetchOptionsContextKey, fetchOpts)
+	ctx = context.WithValue(ctx, prometheus.BlockResultMetadataFnKey,
+		func(block.ResultMetadata) {})
+
+	query, err := engine.NewInstantQuery(queryable, q, ts)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	res := query.Exec(ctx)
+	if res.Err != nil {
+		return nil, res.Err
+	}
+
+	switch v := res.Value.(type) {
+	case promql.Vector:
+		return v, nil
+	case promql.Scalar:
+		return promql.Vector{{Point: promql.Point{T: v.T, V: v.V}}}, nil
+	default:
+		return nil, fmt.Errorf("rule result must be a vector or scalar, "+
+			"received: %s", res.Value.Type())
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/storage"
+	xtest "github.com/m3db/m3/src/x/test"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRules = `
+groups:
+  - name: requests
+    interval: 1m
+    rules:
+      - record: job:requests:sum
+        expr: sum by (job) (requests)
+        labels:
+          source: ruler
+      - alert: HighRequests
+        expr: sum by (job) (requests) > 5
+        for: 2m
+        labels:
+          severity: page
+        annotations:
+          summary: "{{ $labels.job }} has {{ $value }} requests"
+`
+
+type testAlertmanager struct {
+	sync.Mutex
+	alerts [][]Alert
+}
+
+func (a *testAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var alerts []Alert
+	if err := json.NewDecoder(r.Body).Decode(&alerts); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	a.Lock()
+	a.alerts = append(a.alerts, alerts)
+	a.Unlock()
+}
+
+func (a *testAlertmanager) received() [][]Alert {
+	a.Lock()
+	defer a.Unlock()
+	return a.alerts
+}
+
+func newTestSeries(name, job, instance string, ts time.Time, value float64) *prompb.TimeSeries {
+	return &prompb.TimeSeries{
+		Labels: []prompb.Label{
+			{Name: []byte("__name__"), Value: []byte(name)},
+			{Name: []byte("instance"), Value: []byte(instance)},
+			{Name: []byte("job"), Value: []byte(job)},
+		},
+		Samples: []prompb.Sample{
+			{Timestamp: ts.UnixNano() / int64(time.Millisecond), Value: value},
+		},
+	}
+}
+
+func TestRulerRecordsAndAlerts(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		start    = time.Unix(1600000000, 0)
+		valueA   = 4.0
+		writesMu sync.Mutex
+		writes   []*storage.WriteQuery
+	)
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().FetchProm(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			q *storage.FetchQuery,
+			_ *storage.FetchOptions,
+		) (storage.PromResult, error) {
+			ts := q.End.Add(-10 * time.Second)
+			return storage.PromResult{
+				PromResult: &prompb.QueryResult{
+					Timeseries: []*prompb.TimeSeries{
+						newTestSeries("requests", "a", "1", ts, valueA),
+						newTestSeries("requests", "a", "2", ts, 3),
+						newTestSeries("requests", "b", "1", ts, 1),
+					},
+				},
+			}, nil
+		}).AnyTimes()
+	store.EXPECT().Write(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, q *storage.WriteQuery) error {
+			writesMu.Lock()
+			writes = append(writes, q)
+			writesMu.Unlock()
+			return nil
+		}).AnyTimes()
+
+	alertmanager := &testAlertmanager{}
+	server := httptest.NewServer(alertmanager)
+	defer server.Close()
+
+	groups, errs := rulefmt.Parse([]byte(testRules))
+	require.Empty(t, errs)
+
+	r, err := NewRuler(groups.Groups, Options{
+		Engine: promql.NewEngine(promql.EngineOpts{
+			MaxSamples:    100,
+			Timeout:       time.Minute,
+			LookbackDelta: 5 * time.Minute,
+		}),
+		Storage:  store,
+		Notifier: NewWebhookNotifier(server.URL, time.Second),
+	})
+	require.NoError(t, err)
+	require.Len(t, r.groups, 1)
+	g := r.groups[0]
+	assert.Equal(t, time.Minute, g.interval)
+
+	// The alert is pending until it has been active for its hold duration.
+	g.eval(start)
+	g.eval(start.Add(time.Minute))
+	assert.Len(t, alertmanager.received(), 0)
+
+	require.Len(t, writes, 4)
+	recorded := make(map[string]float64)
+	for _, w := range writes[:2] {
+		id := string(w.Tags().ID())
+		recorded[id] = w.Datapoints()[0].Value
+		assert.Equal(t, start, w.Datapoints()[0].Timestamp.ToTime())
+		name, ok := w.Tags().Name()
+		require.True(t, ok)
+		assert.Equal(t, "job:requests:sum", string(name))
+		source, ok := w.Tags().Get([]byte("source"))
+		require.True(t, ok)
+		assert.Equal(t, "ruler", string(source))
+	}
+	values := make([]float64, 0, len(recorded))
+	for _, v := range recorded {
+		values = append(values, v)
+	}
+	sort.Float64s(values)
+	assert.Equal(t, []float64{1, 7}, values)
+
+	firedAt := start.Add(2 * time.Minute)
+	g.eval(firedAt)
+	received := alertmanager.received()
+	require.Len(t, received, 1)
+	require.Len(t, received[0], 1)
+	alert := received[0][0]
+	assert.Equal(t, map[string]string{
+		"alertname": "HighRequests",
+		"job":       "a",
+		"severity":  "page",
+	}, alert.Labels)
+	assert.Equal(t, map[string]string{"summary": "a has 7 requests"},
+		alert.Annotations)
+	assert.True(t, firedAt.Equal(alert.StartsAt))
+	assert.True(t, firedAt.Add(4*time.Minute).Equal(alert.EndsAt))
+
+	// Once the expression no longer returns the series the alert is resolved
+	// and sent once more.
+	valueA = 1
+	resolvedAt := start.Add(3 * time.Minute)
+	g.eval(resolvedAt)
+	g.eval(start.Add(4 * time.Minute))
+	received = alertmanager.received()
+	require.Len(t, received, 2)
+	require.Len(t, received[1], 1)
+	assert.True(t, firedAt.Equal(received[1][0].StartsAt))
+	assert.True(t, resolvedAt.Equal(received[1][0].EndsAt))
+}
+
+func TestNewRulerValidation(t *testing.T) {
+	_, err := NewRuler(nil, Options{})
+	require.Equal(t, errNoEngine, err)
+
+	groups, errs := rulefmt.Parse([]byte(testRules))
+	require.Empty(t, errs)
+
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	_, err = NewRuler(append(groups.Groups, groups.Groups...), Options{
+		Engine:  promql.NewEngine(promql.EngineOpts{}),
+		Storage: storage.NewMockStorage(ctrl),
+	})
+	require.Error(t, err)
+}
+
+func TestRulerShardsGroups(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var groups []rulefmt.RuleGroup
+	for i := 0; i < 16; i++ {
+		parsed, errs := rulefmt.Parse([]byte(testRules))
+		require.Empty(t, errs)
+		rg := parsed.Groups[0]
+		rg.Name = fmt.Sprintf("requests-%d", i)
+		groups = append(groups, rg)
+	}
+
+	// Each group is evaluated by exactly one instance.
+	owners := make(map[string]int)
+	for instance := 0; instance < 3; instance++ {
+		r, err := NewRuler(groups, Options{
+			Engine:       promql.NewEngine(promql.EngineOpts{}),
+			Storage:      storage.NewMockStorage(ctrl),
+			NumInstances: 3,
+			Instance:     instance,
+		})
+		require.NoError(t, err)
+		require.True(t, len(r.groups) < len(groups))
+		for _, g := range r.groups {
+			_, ok := owners[g.name]
+			require.False(t, ok, g.name)
+			owners[g.name] = instance
+		}
+	}
+	require.Len(t, owners, len(groups))
+
+	_, err := NewRuler(groups, Options{
+		Engine:       promql.NewEngine(promql.EngineOpts{}),
+		Storage:      storage.NewMockStorage(ctrl),
+		NumInstances: 3,
+		Instance:     3,
+	})
+	require.Equal(t, errInvalidInstanceIndex, err)
+}
+
+func TestGroupEvalTimestampAlignedToInterval(t *testing.T) {
+	g := &group{interval: time.Minute}
+	start := time.Unix(1600000000, 0)
+	assert.True(t, time.Unix(1599999960, 0).Equal(g.evalTimestamp(start)))
+	assert.True(t, time.Unix(1599999960, 0).Equal(g.evalTimestamp(start.Add(19*time.Second))))
+	assert.True(t, time.Unix(1600000020, 0).Equal(g.evalTimestamp(start.Add(20*time.Second))))
+}
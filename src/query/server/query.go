@@ -411,7 +411,11 @@ func Run(runOpts RunOptions) RunResult {
 		SetReadWorkerPool(readWorkerPool).
 		SetWriteWorkerPool(writeWorkerPool).
 		SetSeriesConsolidationMatchOptions(matchOptions).
-		SetPromConvertOptions(promConvertOptions)
+		SetPromConvertOptions(promConvertOptions).
+		SetFetchTaggedPageSize(cfg.Query.FetchPageSize)
+	if v := cfg.Query.FetchPagesMaxBytes; v != nil {
+		tsdbOpts = tsdbOpts.SetFetchTaggedPagesMaxBytes(*v)
+	}
 
 	if runOpts.ApplyCustomTSDBOptions != nil {
 		tsdbOpts, err = runOpts.ApplyCustomTSDBOptions(tsdbOpts, instrumentOptions)
@@ -704,6 +708,15 @@ func Run(runOpts RunOptions) RunResult {
 		logger.Fatal("unable to set up handler options", zap.Error(err))
 	}
 
+	if cfg.Query.Log != nil {
+		queryLogger, err := cfg.Query.Log.NewLogger(instrumentOptions)
+		if err != nil {
+			logger.Fatal("unable to create query log", zap.Error(err))
+		}
+		defer queryLogger.Close()
+		handlerOptions = handlerOptions.SetQueryLogger(queryLogger)
+	}
+
 	var customHandlerOpts options.CustomHandlerOptions
 	if runOpts.CustomHandlerOptions != nil {
 		customHandlerOpts, err = runOpts.CustomHandlerOptions(instrumentOptions)
@@ -795,6 +808,24 @@ func Run(runOpts RunOptions) RunResult {
 		defer server.Close()
 	}
 
+	if cfg.Ruler != nil {
+		rulerFetchOpts := storage.NewFetchOptions()
+		rulerFetchOpts.SeriesLimit = fetchOptsBuilderLimitsOpts.SeriesLimit
+		rulerFetchOpts.DocsLimit = fetchOptsBuilderLimitsOpts.DocsLimit
+		rulerFetchOpts.RequireExhaustive = fetchOptsBuilderLimitsOpts.RequireExhaustive
+		rulerFetchOpts.Timeout = timeout
+
+		r, err := cfg.Ruler.NewRuler(defaultPrometheusEngine, backendStorage,
+			rulerFetchOpts, tagOptions, instrumentOptions)
+		if err != nil {
+			logger.Fatal("unable to create ruler", zap.Error(err))
+		}
+
+		logger.Info("starting ruler")
+		r.Start()
+		defer r.Close()
+	}
+
 	// Stop our async watch and now block waiting for the interrupt.
 	intWatchCancel()
 	select {
@@ -22,10 +22,12 @@ package storage
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"sort"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
@@ -54,6 +56,9 @@ var (
 	openMetricsSumSuffix = []byte("_sum")
 	// The suffix of created metric name in Open Metrics Counter/Histogram/Summary metric families.
 	openMetricsCreatedSuffix = []byte("_created")
+
+	errFloatHistogramsNotSupported = errors.New("float native histograms are not supported")
+	errNegativeBucketCount         = errors.New("native histogram has a negative bucket count")
 )
 
 // PromLabelsToM3Tags converts Prometheus labels to M3 tags
@@ -312,6 +317,127 @@ func PromSamplesToM3Datapoints(samples []prompb.Sample) ts.Datapoints {
 	return datapoints
 }
 
+// PromHistogramToM3 converts a Prometheus native histogram to an M3
+// datapoint and the marshaled histogram that is written as its annotation.
+func PromHistogramToM3(h prompb.Histogram) (ts.Datapoint, []byte, error) {
+	count, ok := h.Count.(*prompb.Histogram_CountInt)
+	if !ok && h.Count != nil {
+		return ts.Datapoint{}, nil, errFloatHistogramsNotSupported
+	}
+	zeroCount, ok := h.ZeroCount.(*prompb.Histogram_ZeroCountInt)
+	if !ok && h.ZeroCount != nil {
+		return ts.Datapoint{}, nil, errFloatHistogramsNotSupported
+	}
+	if len(h.PositiveCounts) > 0 || len(h.NegativeCounts) > 0 {
+		return ts.Datapoint{}, nil, errFloatHistogramsNotSupported
+	}
+
+	result := histogram.Histogram{
+		CounterResetHint: histogram.CounterResetHint(h.ResetHint),
+		Schema:           h.Schema,
+		ZeroThreshold:    h.ZeroThreshold,
+		Sum:              h.Sum,
+		PositiveSpans:    promSpansToM3(h.PositiveSpans),
+		NegativeSpans:    promSpansToM3(h.NegativeSpans),
+	}
+	if count != nil {
+		result.Count = count.CountInt
+	}
+	if zeroCount != nil {
+		result.ZeroCount = zeroCount.ZeroCountInt
+	}
+
+	var err error
+	if result.PositiveBuckets, err = promDeltasToCounts(h.PositiveDeltas); err != nil {
+		return ts.Datapoint{}, nil, err
+	}
+	if result.NegativeBuckets, err = promDeltasToCounts(h.NegativeDeltas); err != nil {
+		return ts.Datapoint{}, nil, err
+	}
+	if err := result.Validate(); err != nil {
+		return ts.Datapoint{}, nil, err
+	}
+
+	dp := ts.Datapoint{
+		Timestamp: promTimestampToUnixNanos(h.Timestamp),
+		Value:     float64(result.Count),
+	}
+	return dp, result.Marshal(), nil
+}
+
+func promSpansToM3(spans []prompb.BucketSpan) []histogram.Span {
+	if len(spans) == 0 {
+		return nil
+	}
+	result := make([]histogram.Span, 0, len(spans))
+	for _, s := range spans {
+		result = append(result, histogram.Span{Offset: s.Offset, Length: s.Length})
+	}
+	return result
+}
+
+func promDeltasToCounts(deltas []int64) ([]uint64, error) {
+	if len(deltas) == 0 {
+		return nil, nil
+	}
+	var (
+		counts = make([]uint64, 0, len(deltas))
+		curr   int64
+	)
+	for _, d := range deltas {
+		curr += d
+		if curr < 0 {
+			return nil, errNegativeBucketCount
+		}
+		counts = append(counts, uint64(curr))
+	}
+	return counts, nil
+}
+
+// M3HistogramToProm converts a histogram read back from its M3 annotation to
+// a Prometheus native histogram at the given time.
+func M3HistogramToProm(t xtime.UnixNano, h *histogram.Histogram) prompb.Histogram {
+	return prompb.Histogram{
+		Count:          &prompb.Histogram_CountInt{CountInt: h.Count},
+		Sum:            h.Sum,
+		Schema:         h.Schema,
+		ZeroThreshold:  h.ZeroThreshold,
+		ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: h.ZeroCount},
+		NegativeSpans:  m3SpansToProm(h.NegativeSpans),
+		NegativeDeltas: m3CountsToDeltas(h.NegativeBuckets),
+		PositiveSpans:  m3SpansToProm(h.PositiveSpans),
+		PositiveDeltas: m3CountsToDeltas(h.PositiveBuckets),
+		ResetHint:      prompb.Histogram_ResetHint(h.CounterResetHint),
+		Timestamp:      TimeToPromTimestamp(t),
+	}
+}
+
+func m3SpansToProm(spans []histogram.Span) []prompb.BucketSpan {
+	if len(spans) == 0 {
+		return nil
+	}
+	result := make([]prompb.BucketSpan, 0, len(spans))
+	for _, s := range spans {
+		result = append(result, prompb.BucketSpan{Offset: s.Offset, Length: s.Length})
+	}
+	return result
+}
+
+func m3CountsToDeltas(counts []uint64) []int64 {
+	if len(counts) == 0 {
+		return nil
+	}
+	var (
+		deltas = make([]int64, 0, len(counts))
+		prev   int64
+	)
+	for _, c := range counts {
+		deltas = append(deltas, int64(c)-prev)
+		prev = int64(c)
+	}
+	return deltas
+}
+
 // PromReadQueryToM3 converts a prometheus read query to m3 read query
 func PromReadQueryToM3(query *prompb.Query) (*FetchQuery, error) {
 	tagMatchers, err := PromMatchersToM3(query.Matchers)
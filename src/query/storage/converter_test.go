@@ -26,6 +26,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
@@ -576,3 +577,86 @@ type promMetricTypeWithBool struct {
 	metricType        ts.PromMetricType
 	handleValueResets bool
 }
+
+func TestPromHistogramToM3(t *testing.T) {
+	h := prompb.Histogram{
+		Count:         &prompb.Histogram_CountInt{CountInt: 10},
+		Sum:           12.5,
+		Schema:        1,
+		ZeroThreshold: 0.001,
+		ZeroCount:     &prompb.Histogram_ZeroCountInt{ZeroCountInt: 2},
+		PositiveSpans: []prompb.BucketSpan{{Offset: 0, Length: 2}, {Offset: 1, Length: 1}},
+		// Absolute counts of 3, 1, 2.
+		PositiveDeltas: []int64{3, -2, 1},
+		NegativeSpans:  []prompb.BucketSpan{{Offset: 1, Length: 1}},
+		NegativeDeltas: []int64{2},
+		ResetHint:      prompb.Histogram_NO,
+		Timestamp:      1000,
+	}
+
+	dp, b, err := PromHistogramToM3(h)
+	require.NoError(t, err)
+	assert.Equal(t, float64(10), dp.Value)
+	assert.Equal(t, xtime.UnixNano(time.Second), dp.Timestamp)
+	require.True(t, histogram.IsHistogram(b))
+
+	var decoded histogram.Histogram
+	require.NoError(t, decoded.Unmarshal(b))
+	assert.Equal(t, histogram.Histogram{
+		CounterResetHint: histogram.NotCounterReset,
+		Schema:           1,
+		ZeroThreshold:    0.001,
+		ZeroCount:        2,
+		Count:            10,
+		Sum:              12.5,
+		PositiveSpans:    []histogram.Span{{Offset: 0, Length: 2}, {Offset: 1, Length: 1}},
+		NegativeSpans:    []histogram.Span{{Offset: 1, Length: 1}},
+		PositiveBuckets:  []uint64{3, 1, 2},
+		NegativeBuckets:  []uint64{2},
+	}, decoded)
+}
+
+func TestPromHistogramToM3Errors(t *testing.T) {
+	_, _, err := PromHistogramToM3(prompb.Histogram{
+		Count: &prompb.Histogram_CountFloat{CountFloat: 1},
+	})
+	assert.Equal(t, errFloatHistogramsNotSupported, err)
+
+	_, _, err = PromHistogramToM3(prompb.Histogram{
+		Count:          &prompb.Histogram_CountInt{CountInt: 1},
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}},
+		PositiveDeltas: []int64{1, -2},
+	})
+	assert.Equal(t, errNegativeBucketCount, err)
+
+	// Spans describing more buckets than are present are rejected.
+	_, _, err = PromHistogramToM3(prompb.Histogram{
+		Count:          &prompb.Histogram_CountInt{CountInt: 1},
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}},
+		PositiveDeltas: []int64{1},
+	})
+	assert.Error(t, err)
+}
+
+func TestM3HistogramToPromRoundTrip(t *testing.T) {
+	h := prompb.Histogram{
+		Count:          &prompb.Histogram_CountInt{CountInt: 10},
+		Sum:            12.5,
+		Schema:         1,
+		ZeroThreshold:  0.001,
+		ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 2},
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}, {Offset: 1, Length: 1}},
+		PositiveDeltas: []int64{3, -2, 1},
+		NegativeSpans:  []prompb.BucketSpan{{Offset: 1, Length: 1}},
+		NegativeDeltas: []int64{2},
+		ResetHint:      prompb.Histogram_NO,
+		Timestamp:      1000,
+	}
+
+	dp, b, err := PromHistogramToM3(h)
+	require.NoError(t, err)
+
+	var decoded histogram.Histogram
+	require.NoError(t, decoded.Unmarshal(b))
+	assert.Equal(t, h, M3HistogramToProm(dp.Timestamp, &decoded))
+}
@@ -101,3 +101,16 @@ func (o *FetchOptions) Clone() *FetchOptions {
 	result := *o
 	return &result
 }
+
+// nativeHistograms returns whether native histograms are returned as is.
+func (o *FetchOptions) nativeHistograms() bool {
+	return o != nil && o.NativeHistograms
+}
+
+// queryStats returns the query stats accumulator of the fetch options, if any.
+func (o *FetchOptions) queryStats() *QueryStats {
+	if o == nil {
+		return nil
+	}
+	return o.Stats
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/m3ninx/idx"
+	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -92,6 +93,9 @@ func FetchOptionsToM3Options(
 		Source:                        fetchOptions.Source,
 		StartInclusive:                xtime.ToUnixNano(start),
 		EndExclusive:                  xtime.ToUnixNano(end),
+		Resolution:                    fetchQuery.Resolution,
+		RollupAggregation:             fetchQuery.RollupAggregation,
+		Explain:                       fetchOptions.IndexExplain,
 	}, nil
 }
 
@@ -328,6 +332,23 @@ func matcherToQuery(matcher models.Matcher) (idx.Query, error) {
 
 		return query, nil
 
+	case models.MatchNotNumericRange:
+		negate = true
+		fallthrough
+
+	case models.MatchNumericRange:
+		nr, err := m3ninxindex.ParseNumericRange(string(matcher.Value))
+		if err != nil {
+			return idx.Query{}, err
+		}
+
+		query := idx.NewNumericRangeQuery(matcher.Name, nr)
+		if negate {
+			query = idx.NewNegationQuery(query)
+		}
+
+		return query, nil
+
 	case models.MatchAll:
 		return idx.NewAllQuery(), nil
 
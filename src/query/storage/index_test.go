@@ -178,6 +178,28 @@ func TestFetchQueryToM3Query(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "numeric range match",
+			expected: "numeric_range(t1,[500,600))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchNumericRange,
+					Name:  []byte("t1"),
+					Value: []byte("[500,600)"),
+				},
+			},
+		},
+		{
+			name:     "numeric range match negated",
+			expected: "negation(numeric_range(t1,(0.5,]))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchNotNumericRange,
+					Name:  []byte("t1"),
+					Value: []byte("(0.5,]"),
+				},
+			},
+		},
 		{
 			name:     "all matchers",
 			expected: "all()",
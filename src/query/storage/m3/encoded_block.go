@@ -112,9 +112,9 @@ func newEncodedBlock(
 }
 
 func (b *encodedBlock) SeriesIter() (block.SeriesIter, error) {
-	return NewEncodedSeriesIter(
+	return newEncodedSeriesIter(
 		b.meta, b.seriesMetas, b.seriesBlockIterators,
-		b.options.Instrumented(),
+		b.options.Instrumented(), b.options.QueryStats(),
 	), nil
 }
 
@@ -26,6 +26,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util"
 )
@@ -37,12 +38,23 @@ func NewEncodedSeriesIter(
 	seriesIters []encoding.SeriesIterator,
 	instrumented bool,
 ) block.SeriesIter {
+	return newEncodedSeriesIter(meta, seriesMetas, seriesIters, instrumented, nil)
+}
+
+func newEncodedSeriesIter(
+	meta block.Metadata,
+	seriesMetas []block.SeriesMeta,
+	seriesIters []encoding.SeriesIterator,
+	instrumented bool,
+	stats *storage.QueryStats,
+) *encodedSeriesIter {
 	return &encodedSeriesIter{
 		idx:          -1,
 		meta:         meta,
 		seriesMeta:   seriesMetas,
 		seriesIters:  seriesIters,
 		instrumented: instrumented,
+		stats:        stats,
 	}
 }
 
@@ -55,6 +67,7 @@ type encodedSeriesIter struct {
 	seriesMeta   []block.SeriesMeta
 	seriesIters  []encoding.SeriesIterator
 	instrumented bool
+	stats        *storage.QueryStats
 }
 
 func (it *encodedSeriesIter) Current() block.UnconsolidatedSeries {
@@ -87,8 +100,9 @@ func (it *encodedSeriesIter) Next() bool {
 		decodeDuration time.Duration
 		decodeStart    time.Time
 	)
-	if it.instrumented {
+	if it.instrumented || it.stats != nil {
 		decodeStart = time.Now()
+		it.stats.DecodeStarted(decodeStart)
 	}
 
 	for iter.Next() {
@@ -100,14 +114,16 @@ func (it *encodedSeriesIter) Next() bool {
 			})
 	}
 
-	if it.instrumented {
+	if it.instrumented || it.stats != nil {
 		decodeDuration = time.Since(decodeStart)
+		it.stats.DecodeFinished(decodeStart.Add(decodeDuration))
 	}
 
 	if it.err = iter.Err(); it.err != nil {
 		return false
 	}
 
+	addDecodedStats(it.stats, iter, len(it.datapoints))
 	it.series = block.NewUnconsolidatedSeries(
 		it.datapoints,
 		it.seriesMeta[it.idx],
@@ -183,9 +199,9 @@ func iteratorBatchingFn(
 			end = iterCount
 		}
 
-		iter := NewEncodedSeriesIter(
+		iter := newEncodedSeriesIter(
 			meta, seriesMetas[start:end], seriesBlockIterators[start:end],
-			opts.Instrumented(),
+			opts.Instrumented(), opts.QueryStats(),
 		)
 
 		iters = append(iters, block.SeriesIterBatch{
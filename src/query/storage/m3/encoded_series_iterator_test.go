@@ -30,6 +30,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/test/compare"
 	"github.com/m3db/m3/src/query/ts"
 )
@@ -78,6 +79,48 @@ func TestSeriesIterator(t *testing.T) {
 	}
 }
 
+func TestSeriesIteratorQueryStats(t *testing.T) {
+	stats := storage.NewQueryStats()
+	opts := NewOptions(encoding.NewOptions()).
+		SetLookbackDuration(1 * time.Minute).
+		SetSplitSeriesByBlock(false).
+		SetQueryStats(stats)
+	require.NoError(t, opts.Validate())
+	blocks, _ := generateBlocks(t, time.Minute, opts)
+	require.Equal(t, 1, len(blocks))
+
+	iters, err := blocks[0].SeriesIter()
+	require.NoError(t, err)
+	for iters.Next() {
+	}
+	require.NoError(t, iters.Err())
+	require.Equal(t, map[string]storage.NamespaceQueryStats{
+		"namespace": {DatapointsDecoded: 18},
+	}, stats.Namespaces())
+}
+
+func TestStepIteratorQueryStats(t *testing.T) {
+	stats := storage.NewQueryStats()
+	opts := NewOptions(encoding.NewOptions()).
+		SetLookbackDuration(1 * time.Minute).
+		SetSplitSeriesByBlock(false).
+		SetQueryStats(stats)
+	require.NoError(t, opts.Validate())
+	blocks, _ := generateBlocks(t, time.Minute, opts)
+	require.Equal(t, 1, len(blocks))
+
+	iters, err := blocks[0].StepIter()
+	require.NoError(t, err)
+	for iters.Next() {
+		// Stats are only added once every series has been decoded.
+		require.Equal(t, 0, len(stats.Namespaces()))
+	}
+	require.NoError(t, iters.Err())
+	require.Equal(t, map[string]storage.NamespaceQueryStats{
+		"namespace": {DatapointsDecoded: 18},
+	}, stats.Namespaces())
+}
+
 func verifySingleMeta(
 	t *testing.T,
 	i int,
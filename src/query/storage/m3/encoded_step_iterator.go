@@ -72,6 +72,8 @@ func (b *encodedBlock) StepIter() (
 			seriesIters:      iters,
 			seriesCollectors: seriesCollectors,
 
+			stats: b.options.QueryStats(),
+
 			workerPool: b.options.ReadWorkerPool(),
 		},
 	}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	xsync "github.com/m3db/m3/src/x/sync"
@@ -49,6 +50,9 @@ type encodedStepIterWithCollector struct {
 	seriesPeek       []peekValue
 	seriesIters      []encoding.SeriesIterator
 
+	// stats are added to once every series has been decoded.
+	stats *storage.QueryStats
+
 	updateFn updateFn
 
 	workerPool xsync.PooledWorkerPool
@@ -98,6 +102,7 @@ func nextForStep(
 	// a value, set the next peek value.
 	for iter.Next() {
 		dp, _, _ := iter.Current()
+		peek.decoded++
 
 		// If this datapoint is before the current timestamp, add it as a
 		// consolidation candidate.
@@ -202,6 +207,10 @@ func (it *encodedStepIterWithCollector) Next() bool {
 		}
 
 		if steps > 0 {
+			if it.stats != nil {
+				it.stats.DecodeStarted(time.Now())
+			}
+
 			// NB: If no reader worker pool configured, use sequential iteration.
 			if it.workerPool == nil {
 				it.err = it.nextSequential(steps)
@@ -209,6 +218,10 @@ func (it *encodedStepIterWithCollector) Next() bool {
 				it.err = it.nextParallel(steps)
 			}
 
+			if it.stats != nil {
+				it.stats.DecodeFinished(time.Now())
+			}
+
 			bufferedDuration := time.Duration(steps) * it.meta.Bounds.StepSize
 			it.bufferTime = it.bufferTime.Add(bufferedDuration)
 		}
@@ -226,6 +239,9 @@ func (it *encodedStepIterWithCollector) Next() bool {
 
 	if !next {
 		it.finished = true
+		for i, iter := range it.seriesIters {
+			addDecodedStats(it.stats, iter, it.seriesPeek[i].decoded)
+		}
 	}
 
 	return next
@@ -50,6 +50,9 @@ var (
 		return tags, nil
 	}
 	defaultRateLimiter = &noopRateLimiter{}
+	// defaultFetchTaggedPagesMaxBytes bounds the pages fetched for a query
+	// from a namespace since every page is held until the query completes.
+	defaultFetchTaggedPagesMaxBytes = 1 << 30
 )
 
 type dynamicClusterOptions struct {
@@ -144,6 +147,9 @@ type encodedBlockOptions struct {
 	blockSeriesProcessor          BlockSeriesProcessor
 	adminOptions                  []client.CustomAdminOption
 	promConvertOptions            storage.PromConvertOptions
+	fetchTaggedPageSize           int
+	fetchTaggedPagesMaxBytes      int
+	queryStats                    *storage.QueryStats
 	instrumented                  bool
 }
 
@@ -163,9 +169,10 @@ func newOptions(
 		queryConsolidatorMatchOptions: consolidators.MatchOptions{
 			MatchType: consolidators.MatchIDs,
 		},
-		rateLimiter:        defaultRateLimiter,
-		tagsTransform:      defaultTagsTransform,
-		promConvertOptions: storage.NewPromConvertOptions(),
+		rateLimiter:              defaultRateLimiter,
+		tagsTransform:            defaultTagsTransform,
+		promConvertOptions:       storage.NewPromConvertOptions(),
+		fetchTaggedPagesMaxBytes: defaultFetchTaggedPagesMaxBytes,
 	}
 }
 
@@ -341,11 +348,49 @@ func (o *encodedBlockOptions) PromConvertOptions() storage.PromConvertOptions {
 	return o.promConvertOptions
 }
 
+func (o *encodedBlockOptions) SetFetchTaggedPageSize(value int) Options {
+	opts := *o
+	opts.fetchTaggedPageSize = value
+	return &opts
+}
+
+func (o *encodedBlockOptions) FetchTaggedPageSize() int {
+	return o.fetchTaggedPageSize
+}
+
+func (o *encodedBlockOptions) SetFetchTaggedPagesMaxBytes(value int) Options {
+	opts := *o
+	opts.fetchTaggedPagesMaxBytes = value
+	return &opts
+}
+
+func (o *encodedBlockOptions) FetchTaggedPagesMaxBytes() int {
+	return o.fetchTaggedPagesMaxBytes
+}
+
+func (o *encodedBlockOptions) SetQueryStats(value *storage.QueryStats) Options {
+	opts := *o
+	opts.queryStats = value
+	return &opts
+}
+
+func (o *encodedBlockOptions) QueryStats() *storage.QueryStats {
+	return o.queryStats
+}
+
 func (o *encodedBlockOptions) Validate() error {
 	if o.lookbackDuration < 0 {
 		return errors.New("unable to validate block options; negative lookback")
 	}
 
+	if o.fetchTaggedPageSize < 0 {
+		return errors.New("unable to validate block options; negative fetch tagged page size")
+	}
+
+	if o.fetchTaggedPagesMaxBytes < 0 {
+		return errors.New("unable to validate block options; negative fetch tagged pages max bytes")
+	}
+
 	if err := o.tagOptions.Validate(); err != nil {
 		return fmt.Errorf("unable to validate tag options, err: %w", err)
 	}
@@ -30,6 +30,7 @@ import (
 
 	coordmodel "github.com/m3db/m3/src/cmd/services/m3coordinator/model"
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
@@ -42,6 +43,7 @@ import (
 	"github.com/m3db/m3/src/query/ts"
 	xcontext "github.com/m3db/m3/src/x/context"
 	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/headers"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -239,6 +241,9 @@ func FetchResultToBlockResult(
 		opts = opts.
 			SetSplitSeriesByBlock(true)
 	}
+	if options != nil && options.Stats != nil {
+		opts = opts.SetQueryStats(options.Stats)
+	}
 
 	start := query.Start
 	bounds := models.Bounds{
@@ -434,6 +439,7 @@ func (s *m3storage) fetchCompressed(
 		RequireExhaustive: queryOptions.InstanceMultiple > 0 && options.RequireExhaustive,
 	}
 	result := consolidators.NewMultiFetchResult(fanout, matchOpts, tagOpts, limitOpts)
+	options.Stats.FetchStarted(s.nowFn())
 	for _, namespace := range namespaces {
 		namespace := namespace // Capture var
 
@@ -447,7 +453,24 @@ func (s *m3storage) fetchCompressed(
 			session := namespace.Session()
 			namespaceID := namespace.NamespaceID()
 			narrowedQueryOpts := narrowQueryOpts(queryOptions, namespace)
+			if pageSize := s.opts.FetchTaggedPageSize(); pageSize > 0 {
+				narrowedQueryOpts.PageSize = pageSize
+				numSeries, numPages := fetchTaggedPages(ctx, namespace, m3query,
+					narrowedQueryOpts, s.opts.FetchTaggedPagesMaxBytes(), result, options.Stats)
+				if sampled {
+					span.LogFields(
+						log.String("namespace", namespaceID.String()),
+						log.Int("series", numSeries),
+						log.Int("pages", numPages),
+					)
+				}
+				return
+			}
+
 			iters, metadata, err := session.FetchTagged(ctx, namespaceID, m3query, narrowedQueryOpts)
+			if err == nil {
+				addFetchStats(options.Stats, namespace, iters, metadata)
+			}
 			if err == nil && sampled {
 				span.LogFields(
 					log.String("namespace", namespaceID.String()),
@@ -458,25 +481,12 @@ func (s *m3storage) fetchCompressed(
 				)
 			}
 
-			blockMeta := block.NewResultMetadata()
-			blockMeta.AddNamespace(namespaceID.String())
-			blockMeta.FetchedResponses = metadata.Responses
-			blockMeta.FetchedBytesEstimate = metadata.EstimateTotalBytes
-			blockMeta.Exhaustive = metadata.Exhaustive
-			blockMeta.WaitedIndex = metadata.WaitedIndex
-			blockMeta.WaitedSeriesRead = metadata.WaitedSeriesRead
-			// Ignore error from getting iterator pools, since operation
-			// will not be dramatically impacted if pools is nil
-			result.Add(consolidators.MultiFetchResults{
-				SeriesIterators: iters,
-				Metadata:        blockMeta,
-				Attrs:           namespace.Options().Attributes(),
-				Err:             err,
-			})
+			result.Add(newMultiFetchResults(namespace, iters, metadata, err))
 		}()
 	}
 
 	wg.Wait()
+	options.Stats.FetchFinished(s.nowFn())
 
 	// Check if the query was interrupted.
 	select {
@@ -488,6 +498,108 @@ func (s *m3storage) fetchCompressed(
 	return result, m3query, err
 }
 
+// fetchTaggedPages fetches the series matching a query from a namespace a
+// page at a time, adding each page to the result as soon as it is received
+// rather than holding every series of the namespace in a single response.
+// Every page is held until the query completes, so paging stops and the
+// result is marked as not exhaustive and warns of the truncation once the
+// pages exceed maxBytes.
+func fetchTaggedPages(
+	ctx context.Context,
+	namespace ClusterNamespace,
+	query index.Query,
+	opts index.QueryOptions,
+	maxBytes int,
+	result consolidators.MultiFetchResult,
+	stats *storage.QueryStats,
+) (int, int) {
+	iter, err := namespace.Session().FetchTaggedPages(ctx,
+		namespace.NamespaceID(), query, opts)
+	if err != nil {
+		result.Add(newMultiFetchResults(namespace, nil, client.FetchResponseMetadata{}, err))
+		return 0, 0
+	}
+
+	var numSeries, numPages, numBytes int
+	for iter.Next() {
+		iters, metadata := iter.Current()
+		numSeries += iters.Len()
+		numPages++
+		numBytes += metadata.EstimateTotalBytes
+		limited := maxBytes > 0 && numBytes >= maxBytes && metadata.NextPageToken != nil
+		if limited {
+			metadata.Exhaustive = false
+		}
+		addFetchStats(stats, namespace, iters, metadata)
+		pageResult := newMultiFetchResults(namespace, iters, metadata, nil)
+		if limited {
+			pageResult.Metadata.AddWarning(namespace.NamespaceID().String(),
+				headers.LimitHeaderFetchedBytesLimitApplied)
+		}
+		result.Add(pageResult)
+		if limited {
+			return numSeries, numPages
+		}
+	}
+	if err := iter.Err(); err != nil {
+		result.Add(newMultiFetchResults(namespace, nil, client.FetchResponseMetadata{}, err))
+	}
+	return numSeries, numPages
+}
+
+// addDecodedStats adds the datapoints decoded from a series to the query stats.
+func addDecodedStats(
+	stats *storage.QueryStats,
+	iter encoding.SeriesIterator,
+	decoded int,
+) {
+	if stats == nil || decoded == 0 || iter.Namespace() == nil {
+		return
+	}
+	stats.Add(iter.Namespace().String(), storage.NamespaceQueryStats{
+		DatapointsDecoded: decoded,
+	})
+}
+
+// addFetchStats adds the cost of a fetch from a namespace to the query stats.
+func addFetchStats(
+	stats *storage.QueryStats,
+	namespace ClusterNamespace,
+	iters encoding.SeriesIterators,
+	metadata client.FetchResponseMetadata,
+) {
+	stats.Add(namespace.NamespaceID().String(), storage.NamespaceQueryStats{
+		SeriesMatched: iters.Len(),
+		DocsRead:      metadata.DocsRead,
+		BytesRead:     metadata.BytesRead,
+		BlocksFetched: metadata.BlocksRead,
+	})
+}
+
+func newMultiFetchResults(
+	namespace ClusterNamespace,
+	iters encoding.SeriesIterators,
+	metadata client.FetchResponseMetadata,
+	err error,
+) consolidators.MultiFetchResults {
+	blockMeta := block.NewResultMetadata()
+	blockMeta.AddNamespace(namespace.NamespaceID().String())
+	blockMeta.FetchedResponses = metadata.Responses
+	blockMeta.FetchedBytesEstimate = metadata.EstimateTotalBytes
+	blockMeta.Exhaustive = metadata.Exhaustive
+	blockMeta.WaitedIndex = metadata.WaitedIndex
+	blockMeta.WaitedSeriesRead = metadata.WaitedSeriesRead
+	blockMeta.IndexExplain = metadata.IndexExplain
+	// Ignore error from getting iterator pools, since operation
+	// will not be dramatically impacted if pools is nil
+	return consolidators.MultiFetchResults{
+		SeriesIterators: iters,
+		Metadata:        blockMeta,
+		Attrs:           namespace.Options().Attributes(),
+		Err:             err,
+	}
+}
+
 func (s *m3storage) SearchSeries(
 	ctx context.Context,
 	query *storage.FetchQuery,
@@ -669,6 +781,7 @@ func (s *m3storage) CompleteTags(
 			blockMeta.Exhaustive = metadata.Exhaustive
 			blockMeta.WaitedIndex = metadata.WaitedIndex
 			blockMeta.WaitedSeriesRead = metadata.WaitedSeriesRead
+			blockMeta.IndexExplain = metadata.IndexExplain
 			result := &consolidators.CompleteTagsResult{
 				CompleteNameOnly: query.CompleteNameOnly,
 				CompletedTags:    completedTags,
@@ -778,6 +891,7 @@ func (s *m3storage) SearchCompressed(
 			blockMeta.Exhaustive = metadata.Exhaustive
 			blockMeta.WaitedIndex = metadata.WaitedIndex
 			blockMeta.WaitedSeriesRead = metadata.WaitedSeriesRead
+			blockMeta.IndexExplain = metadata.IndexExplain
 			result.Add(iter, blockMeta, err)
 			wg.Done()
 		}()
@@ -22,6 +22,7 @@ package m3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
@@ -404,6 +405,159 @@ func TestLocalRead(t *testing.T) {
 	assertFetchResult(t, results, testTags)
 }
 
+func TestLocalReadQueryStats(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store, sessions := setup(t, ctrl)
+	testTags := seriesiter.GenerateTag()
+
+	session := sessions.unaggregated1MonthRetention
+	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2),
+			client.FetchResponseMetadata{
+				Exhaustive: true,
+				DocsRead:   3,
+				BytesRead:  100,
+				BlocksRead: 2,
+			}, nil)
+
+	fetchOpts := buildFetchOpts()
+	fetchOpts.Stats = storage.NewQueryStats()
+	results, err := store.FetchProm(context.TODO(), newFetchReq(), fetchOpts)
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTags)
+
+	assert.Equal(t, storage.NamespaceQueryStats{
+		SeriesMatched: 1,
+		DocsRead:      3,
+		BytesRead:     100,
+		BlocksFetched: 2,
+	}, fetchOpts.Stats.Namespaces()["metrics_unaggregated"])
+	assert.Equal(t, 2, fetchOpts.Stats.Total().DatapointsDecoded)
+}
+
+func TestLocalReadPaged(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockSession(ctrl)
+	clusters, err := NewClusters(UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics_unaggregated"),
+		Session:     session,
+		Retention:   test1MonthRetention,
+	})
+	require.NoError(t, err)
+
+	opts := NewOptions(encoding.NewOptions()).
+		SetLookbackDuration(time.Minute).
+		SetTagOptions(models.NewTagOptions().SetMetricName([]byte("name"))).
+		SetFetchTaggedPageSize(2)
+	store, err := NewStorage(clusters, opts, instrument.NewTestOptions(t))
+	require.NoError(t, err)
+
+	testTags := seriesiter.GenerateTag()
+	pageMetadata := client.FetchResponseMetadata{Exhaustive: true, Responses: 1}
+	pages := client.NewMockFetchTaggedPagesIterator(ctrl)
+	gomock.InOrder(
+		pages.EXPECT().Next().Return(true),
+		pages.EXPECT().Current().
+			Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2), pageMetadata),
+		pages.EXPECT().Next().Return(true),
+		pages.EXPECT().Current().
+			Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2), pageMetadata),
+		pages.EXPECT().Next().Return(false),
+		pages.EXPECT().Err().Return(nil),
+	)
+	session.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context, _ ident.ID, _ index.Query, opts index.QueryOptions,
+		) (client.FetchTaggedPagesIterator, error) {
+			require.Equal(t, 2, opts.PageSize)
+			return pages, nil
+		})
+
+	results, err := store.FetchProm(context.TODO(), newFetchReq(), buildFetchOpts())
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTags)
+	require.Equal(t, 2, results.Metadata.FetchedResponses)
+	require.True(t, results.Metadata.Exhaustive)
+}
+
+func TestLocalReadPagedMaxBytes(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockSession(ctrl)
+	clusters, err := NewClusters(UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics_unaggregated"),
+		Session:     session,
+		Retention:   test1MonthRetention,
+	})
+	require.NoError(t, err)
+
+	opts := NewOptions(encoding.NewOptions()).
+		SetLookbackDuration(time.Minute).
+		SetTagOptions(models.NewTagOptions().SetMetricName([]byte("name"))).
+		SetFetchTaggedPageSize(2).
+		SetFetchTaggedPagesMaxBytes(100)
+	store, err := NewStorage(clusters, opts, instrument.NewTestOptions(t))
+	require.NoError(t, err)
+
+	// The first page exceeds the max bytes so the next page is not fetched.
+	testTags := seriesiter.GenerateTag()
+	pages := client.NewMockFetchTaggedPagesIterator(ctrl)
+	gomock.InOrder(
+		pages.EXPECT().Next().Return(true),
+		pages.EXPECT().Current().
+			Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2),
+				client.FetchResponseMetadata{
+					Exhaustive:         true,
+					Responses:          1,
+					EstimateTotalBytes: 100,
+					NextPageToken:      []byte("next"),
+				}),
+	)
+	session.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pages, nil)
+
+	results, err := store.FetchProm(context.TODO(), newFetchReq(), buildFetchOpts())
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTags)
+	require.False(t, results.Metadata.Exhaustive)
+	require.Contains(t, results.Metadata.WarningStrings(),
+		"metrics_unaggregated_max_fetch_bytes_limit_applied")
+}
+
+func TestLocalReadPagedError(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockSession(ctrl)
+	clusters, err := NewClusters(UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics_unaggregated"),
+		Session:     session,
+		Retention:   test1MonthRetention,
+	})
+	require.NoError(t, err)
+
+	opts := NewOptions(encoding.NewOptions()).
+		SetLookbackDuration(time.Minute).
+		SetFetchTaggedPageSize(2)
+	store, err := NewStorage(clusters, opts, instrument.NewTestOptions(t))
+	require.NoError(t, err)
+
+	pageErr := errors.New("page error")
+	pages := client.NewMockFetchTaggedPagesIterator(ctrl)
+	pages.EXPECT().Next().Return(false)
+	pages.EXPECT().Err().Return(pageErr)
+	session.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pages, nil)
+
+	_, err = store.FetchProm(context.TODO(), newFetchReq(), buildFetchOpts())
+	require.Error(t, err)
+}
+
 func TestLocalReadExceedsRetention(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
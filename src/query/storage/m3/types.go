@@ -215,6 +215,23 @@ type Options interface {
 	// PromConvertOptions returns options for converting raw series iterators
 	// to a Prometheus-compatible result.
 	PromConvertOptions() storage.PromConvertOptions
+	// SetFetchTaggedPageSize sets the number of series fetched at a time from
+	// the database nodes, zero fetches all series in a single response.
+	SetFetchTaggedPageSize(value int) Options
+	// FetchTaggedPageSize returns the number of series fetched at a time from
+	// the database nodes, zero fetches all series in a single response.
+	FetchTaggedPageSize() int
+	// SetFetchTaggedPagesMaxBytes sets the max estimated bytes of the pages
+	// fetched for a query from a namespace, zero is unlimited.
+	SetFetchTaggedPagesMaxBytes(value int) Options
+	// FetchTaggedPagesMaxBytes returns the max estimated bytes of the pages
+	// fetched for a query from a namespace, zero is unlimited.
+	FetchTaggedPagesMaxBytes() int
+	// SetQueryStats sets the stats of the query the blocks are converted
+	// for, the datapoints decoded from the blocks are added to them.
+	SetQueryStats(value *storage.QueryStats) Options
+	// QueryStats returns the stats of the query the blocks are converted for.
+	QueryStats() *storage.QueryStats
 	// Validate ensures that the given block options are valid.
 	Validate() error
 }
@@ -268,6 +285,8 @@ type peekValue struct {
 	started  bool
 	finished bool
 	point    ts.Datapoint
+	// decoded is the number of datapoints decoded from the series.
+	decoded int
 }
 
 // TagsTransform transforms a set of tags.
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/query/block"
@@ -44,8 +45,13 @@ func iteratorToPromResult(
 	tags models.Tags,
 	maxResolution time.Duration,
 	promConvertOptions PromConvertOptions,
-) (*prompb.TimeSeries, error) {
+	nativeHistograms bool,
+	stats *QueryStats,
+) ([]*prompb.TimeSeries, error) {
 	var (
+		histograms = histogramSeriesBuilder{native: nativeHistograms}
+		decoded    int
+
 		resolution          = xtime.UnixNano(maxResolution)
 		resolutionThreshold = promConvertOptions.ResolutionThresholdForCounterNormalization()
 
@@ -63,7 +69,14 @@ func iteratorToPromResult(
 	)
 
 	for iter.Next() {
-		dp, _, _ := iter.Current()
+		decoded++
+		dp, _, ant := iter.Current()
+		if histogram.IsHistogram(ant) {
+			if err := histograms.add(dp.TimestampNanos, ant); err != nil {
+				return nil, err
+			}
+			continue
+		}
 
 		if valueDecreaseTolerance > 0 && dp.TimestampNanos.Before(valueDecreaseToleranceUntil) {
 			if !firstDP && dp.Value < prevDP.Value && dp.Value > prevDP.Value*(1-valueDecreaseTolerance) {
@@ -73,7 +86,7 @@ func iteratorToPromResult(
 
 		if firstDP && maxResolution >= resolutionThreshold {
 			firstAnnotation := iter.FirstAnnotation()
-			if len(firstAnnotation) > 0 {
+			if len(firstAnnotation) > 0 && !histogram.IsHistogram(firstAnnotation) {
 				if err := annotationPayload.Unmarshal(firstAnnotation); err != nil {
 					return nil, err
 				}
@@ -110,6 +123,12 @@ func iteratorToPromResult(
 		return nil, err
 	}
 
+	if stats != nil && iter.Namespace() != nil {
+		stats.Add(iter.Namespace().String(), NamespaceQueryStats{
+			DatapointsDecoded: decoded,
+		})
+	}
+
 	if handleResets {
 		samples = append(samples, prompb.Sample{
 			Timestamp: TimeToPromTimestamp(prevDP.TimestampNanos),
@@ -117,10 +136,29 @@ func iteratorToPromResult(
 		})
 	}
 
-	return &prompb.TimeSeries{
-		Labels:  TagsToPromLabels(tags),
-		Samples: samples,
-	}, nil
+	labels := TagsToPromLabels(tags)
+	result := []*prompb.TimeSeries{{
+		Labels:     labels,
+		Samples:    samples,
+		Histograms: histograms.histograms,
+	}}
+	if len(histograms.samples) > 0 {
+		result = append(result, histograms.bucketSeries(labels)...)
+	}
+	return result, nil
+}
+
+func hasSamples(series *prompb.TimeSeries) bool {
+	return len(series.GetSamples()) > 0 || len(series.GetHistograms()) > 0
+}
+
+func anyHasSamples(seriesList []*prompb.TimeSeries) bool {
+	for _, series := range seriesList {
+		if hasSamples(series) {
+			return true
+		}
+	}
+	return false
 }
 
 // Fall back to sequential decompression if unable to decompress concurrently.
@@ -134,24 +172,28 @@ func toPromSequentially(
 	meta := block.NewResultMetadata()
 	count := fetchResult.Count()
 	seriesList := make([]*prompb.TimeSeries, 0, count)
+	stats := fetchOptions.queryStats()
 	for i := 0; i < count; i++ {
 		iter, tags, err := fetchResult.IterTagsAtIndex(i, tagOptions)
 		if err != nil {
 			return PromResult{}, err
 		}
 
-		series, err := iteratorToPromResult(iter, tags, maxResolution, promConvertOptions)
+		series, err := iteratorToPromResult(iter, tags, maxResolution,
+			promConvertOptions, fetchOptions.nativeHistograms(), stats)
 		if err != nil {
 			return PromResult{}, err
 		}
 
-		if len(series.GetSamples()) > 0 {
-			seriesList = append(seriesList, series)
+		for _, s := range series {
+			if hasSamples(s) {
+				seriesList = append(seriesList, s)
+			}
 		}
 
 		if fetchOptions != nil && fetchOptions.MaxMetricMetadataStats > 0 {
 			name, _ := tags.Get(promDefaultName)
-			if len(series.GetSamples()) > 0 {
+			if anyHasSamples(series) {
 				meta.ByName(name).WithSamples++
 			} else {
 				meta.ByName(name).NoSamples++
@@ -178,13 +220,14 @@ func toPromConcurrently(
 ) (PromResult, error) {
 	count := fetchResult.Count()
 	var (
-		seriesList = make([]*prompb.TimeSeries, count)
+		seriesList = make([][]*prompb.TimeSeries, count)
 
 		wg       sync.WaitGroup
 		multiErr xerrors.MultiError
 		mu       sync.Mutex
 	)
 
+	stats := fetchOptions.queryStats()
 	fastWorkerPool := readWorkerPool.FastContextCheck(100)
 	for i := 0; i < count; i++ {
 		i := i
@@ -200,7 +243,8 @@ func toPromConcurrently(
 		wg.Add(1)
 		available := fastWorkerPool.GoWithContext(ctx, func() {
 			defer wg.Done()
-			series, err := iteratorToPromResult(iter, tags, maxResolution, promConvertOptions)
+			series, err := iteratorToPromResult(iter, tags, maxResolution,
+				promConvertOptions, fetchOptions.nativeHistograms(), stats)
 			if err != nil {
 				mu.Lock()
 				multiErr = multiErr.Add(err)
@@ -225,15 +269,17 @@ func toPromConcurrently(
 
 	// Filter out empty series inplace.
 	meta := block.NewResultMetadata()
-	filteredList := seriesList[:0]
+	filteredList := make([]*prompb.TimeSeries, 0, count)
 	for _, series := range seriesList {
-		if len(series.GetSamples()) > 0 {
-			filteredList = append(filteredList, series)
+		for _, s := range series {
+			if hasSamples(s) {
+				filteredList = append(filteredList, s)
+			}
 		}
 
-		if fetchOptions != nil && fetchOptions.MaxMetricMetadataStats > 0 {
-			name := metricNameFromLabels(series.Labels)
-			if len(series.GetSamples()) > 0 {
+		if fetchOptions != nil && fetchOptions.MaxMetricMetadataStats > 0 && len(series) > 0 {
+			name := metricNameFromLabels(series[0].Labels)
+			if anyHasSamples(series) {
 				meta.ByName(name).WithSamples++
 			} else {
 				meta.ByName(name).NoSamples++
@@ -289,8 +335,11 @@ func SeriesIteratorsToPromResult(
 		}
 	}
 
+	stats := fetchOptions.queryStats()
+	stats.DecodeStarted(time.Now())
 	promResult, err := seriesIteratorsToPromResult(ctx, fetchResult,
 		readWorkerPool, tagOptions, maxResolution, promConvertOptions, fetchOptions)
+	stats.DecodeFinished(time.Now())
 	// Combine the fetchResult metadata into any metadata that was already
 	// computed for this promResult.
 	promResult.Metadata = promResult.Metadata.CombineMetadata(fetchResult.Metadata)
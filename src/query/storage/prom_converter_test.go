@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
 	dts "github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/query/block"
@@ -86,9 +87,12 @@ func verifyExpandPromSeries(
 		Warnings:   []block.Warning{{Name: "foo", Message: "bar"}},
 	}
 
+	fetchOpts := buildFetchOpts()
+	fetchOpts.Stats = NewQueryStats()
 	results, err := SeriesIteratorsToPromResult(
-		context.Background(), fetchResult, pools, nil, NewPromConvertOptions(), buildFetchOpts())
+		context.Background(), fetchResult, pools, nil, NewPromConvertOptions(), fetchOpts)
 	assert.NoError(t, err)
+	assert.Equal(t, 2*num, fetchOpts.Stats.Namespaces()["foo"].DatapointsDecoded)
 
 	require.NotNil(t, results)
 	ts := results.PromResult.GetTimeseries()
@@ -316,6 +320,56 @@ func TestDecodeIteratorsWithEmptySeries(t *testing.T) {
 	verifyResult(t, res)
 }
 
+func TestIteratorToPromResultHistograms(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	h := histogram.Histogram{
+		Count:           3,
+		ZeroCount:       1,
+		Sum:             2.5,
+		PositiveSpans:   []histogram.Span{{Offset: 0, Length: 1}},
+		PositiveBuckets: []uint64{2},
+	}
+	at := xtime.UnixNano(1e9)
+	tags := models.NewTags(1, nil).AddTag(models.Tag{
+		Name: []byte("__name__"), Value: []byte("latency"),
+	})
+
+	buildIter := func() encoding.SeriesIterator {
+		iter := encoding.NewMockSeriesIterator(ctrl)
+		gomock.InOrder(
+			iter.EXPECT().Next().Return(true),
+			iter.EXPECT().Current().Return(
+				dts.Datapoint{TimestampNanos: at, Value: 3}, xtime.Second, h.Marshal()),
+			iter.EXPECT().Next().Return(false),
+			iter.EXPECT().Err().Return(nil),
+		)
+		return iter
+	}
+
+	// Native histograms are returned on the series itself.
+	result, err := iteratorToPromResult(buildIter(), tags, 0,
+		NewPromConvertOptions(), true, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result))
+	assert.Empty(t, result[0].Samples)
+	assert.Equal(t, []prompb.Histogram{M3HistogramToProm(at, &h)},
+		result[0].Histograms)
+	assert.True(t, hasSamples(result[0]))
+
+	// Otherwise they are expanded into classic bucket series.
+	result, err = iteratorToPromResult(buildIter(), tags, 0,
+		NewPromConvertOptions(), false, nil)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(result))
+	assert.False(t, hasSamples(result[0]))
+	for _, series := range result[1:] {
+		assert.Empty(t, series.Histograms)
+		require.Equal(t, 1, len(series.Samples))
+	}
+}
+
 func TestSeriesIteratorsToPromResultNormalizeLowResCounters(t *testing.T) {
 	var (
 		t0   = xtime.Now().Truncate(time.Hour)
@@ -0,0 +1,134 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+type histogramSample struct {
+	timestamp int64
+	buckets   []histogram.Bucket
+}
+
+// histogramSeriesBuilder accumulates the native histogram samples of a single
+// series. Clients that accept native histograms are returned them as is,
+// otherwise they are expanded into classic cumulative bucket series, one per
+// upper bound with an "le" label, which histogram_quantile can operate on.
+type histogramSeriesBuilder struct {
+	native     bool
+	decoded    histogram.Histogram
+	samples    []histogramSample
+	histograms []prompb.Histogram
+}
+
+func (b *histogramSeriesBuilder) add(t xtime.UnixNano, annotation []byte) error {
+	if err := b.decoded.Unmarshal(annotation); err != nil {
+		return err
+	}
+	if b.native {
+		b.histograms = append(b.histograms, M3HistogramToProm(t, &b.decoded))
+		return nil
+	}
+	b.samples = append(b.samples, histogramSample{
+		timestamp: TimeToPromTimestamp(t),
+		buckets:   b.decoded.CumulativeBuckets(nil),
+	})
+	return nil
+}
+
+func (b *histogramSeriesBuilder) empty() bool {
+	return len(b.samples) == 0 && len(b.histograms) == 0
+}
+
+// bucketSeries returns one series per distinct bucket upper bound seen across
+// all samples. Samples whose layout does not contain a given bound report the
+// cumulative count of their closest lower bound, which keeps every bucket
+// series monotonic in le.
+func (b *histogramSeriesBuilder) bucketSeries(labels []prompb.Label) []*prompb.TimeSeries {
+	boundsSet := make(map[float64]struct{})
+	for _, s := range b.samples {
+		for _, bucket := range s.buckets {
+			boundsSet[bucket.UpperBound] = struct{}{}
+		}
+	}
+	bounds := make([]float64, 0, len(boundsSet))
+	for bound := range boundsSet {
+		bounds = append(bounds, bound)
+	}
+	sort.Float64s(bounds)
+
+	result := make([]*prompb.TimeSeries, 0, len(bounds))
+	for _, bound := range bounds {
+		samples := make([]prompb.Sample, 0, len(b.samples))
+		for _, s := range b.samples {
+			samples = append(samples, prompb.Sample{
+				Timestamp: s.timestamp,
+				Value:     float64(cumulativeCountAt(s.buckets, bound)),
+			})
+		}
+		result = append(result, &prompb.TimeSeries{
+			Labels:  withBucketLabel(labels, bound),
+			Samples: samples,
+		})
+	}
+	return result
+}
+
+// cumulativeCountAt returns the cumulative count of the bucket with the
+// largest upper bound less than or equal to bound.
+func cumulativeCountAt(buckets []histogram.Bucket, bound float64) uint64 {
+	idx := sort.Search(len(buckets), func(i int) bool {
+		return buckets[i].UpperBound > bound
+	})
+	if idx == 0 {
+		return 0
+	}
+	return buckets[idx-1].Count
+}
+
+func withBucketLabel(labels []prompb.Label, bound float64) []prompb.Label {
+	value := []byte(formatBucketBound(bound))
+	result := make([]prompb.Label, 0, len(labels)+1)
+	for _, l := range labels {
+		if bytes.Equal(l.Name, promDefaultBucketName) {
+			continue
+		}
+		result = append(result, l)
+	}
+	result = append(result, prompb.Label{Name: promDefaultBucketName, Value: value})
+	sort.Sort(sortableLabels(result))
+	return result
+}
+
+func formatBucketBound(bound float64) string {
+	if math.IsInf(bound, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(bound, 'g', -1, 64)
+}
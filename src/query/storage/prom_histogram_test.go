@@ -0,0 +1,104 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramSeriesBuilder(t *testing.T) {
+	first := histogram.Histogram{
+		Count:           3,
+		ZeroCount:       1,
+		PositiveSpans:   []histogram.Span{{Offset: 0, Length: 1}},
+		PositiveBuckets: []uint64{2},
+	}
+	// The second sample adds a bucket with an upper bound of 2.
+	second := histogram.Histogram{
+		Count:           6,
+		ZeroCount:       1,
+		PositiveSpans:   []histogram.Span{{Offset: 0, Length: 2}},
+		PositiveBuckets: []uint64{3, 2},
+	}
+
+	var b histogramSeriesBuilder
+	assert.True(t, b.empty())
+	require.NoError(t, b.add(xtime.UnixNano(1e9), first.Marshal()))
+	require.NoError(t, b.add(xtime.UnixNano(2e9), second.Marshal()))
+	assert.False(t, b.empty())
+	assert.Error(t, b.add(xtime.UnixNano(3e9), []byte("foo")))
+
+	series := b.bucketSeries([]prompb.Label{
+		{Name: promDefaultName, Value: []byte("latency_bucket")},
+		{Name: []byte("zone"), Value: []byte("a")},
+	})
+
+	type bucket struct {
+		le     string
+		values []float64
+	}
+	expected := []bucket{
+		{le: "0", values: []float64{1, 1}},
+		{le: "1", values: []float64{3, 4}},
+		{le: "2", values: []float64{3, 6}},
+		{le: "+Inf", values: []float64{3, 6}},
+	}
+	require.Equal(t, len(expected), len(series))
+	for i, s := range series {
+		require.Equal(t, 3, len(s.Labels))
+		assert.Equal(t, promDefaultName, s.Labels[0].Name)
+		assert.Equal(t, promDefaultBucketName, s.Labels[1].Name)
+		assert.Equal(t, expected[i].le, string(s.Labels[1].Value))
+		assert.Equal(t, []byte("zone"), s.Labels[2].Name)
+
+		require.Equal(t, 2, len(s.Samples))
+		assert.Equal(t, int64(1000), s.Samples[0].Timestamp)
+		assert.Equal(t, int64(2000), s.Samples[1].Timestamp)
+		assert.Equal(t, expected[i].values[0], s.Samples[0].Value)
+		assert.Equal(t, expected[i].values[1], s.Samples[1].Value)
+	}
+}
+
+func TestHistogramSeriesBuilderNative(t *testing.T) {
+	h := histogram.Histogram{
+		Count:           3,
+		ZeroCount:       1,
+		Sum:             2.5,
+		PositiveSpans:   []histogram.Span{{Offset: 0, Length: 1}},
+		PositiveBuckets: []uint64{2},
+	}
+
+	b := histogramSeriesBuilder{native: true}
+	require.NoError(t, b.add(xtime.UnixNano(1e9), h.Marshal()))
+	assert.False(t, b.empty())
+	assert.Empty(t, b.samples)
+
+	require.Equal(t, 1, len(b.histograms))
+	assert.Equal(t, M3HistogramToProm(xtime.UnixNano(1e9), &h), b.histograms[0])
+	assert.Equal(t, int64(1000), b.histograms[0].Timestamp)
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/uber-go/tally"
 	"go.uber.org/zap"
 
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
@@ -41,6 +42,10 @@ import (
 	"github.com/m3db/m3/src/x/instrument"
 )
 
+// datapointsPerWindow is the minimum number of datapoints at the hinted
+// resolution within each step or range vector window.
+const datapointsPerWindow = 5
+
 type prometheusQueryable struct {
 	storage storage.Storage
 	scope   tally.Scope
@@ -112,6 +117,43 @@ func newQuerier(
 	}
 }
 
+// rangeRollupAggregations are the rollup aggregations that range vector
+// functions can be computed from without changing their result. The counter
+// rollup keeps the increases and resets of counters, delta and the other
+// gauge functions are not computed from it since the values it keeps before
+// resets are raised by the increases up to them.
+var rangeRollupAggregations = map[string]rollup.Aggregation{
+	"avg_over_time": rollup.Avg,
+	"min_over_time": rollup.Min,
+	"max_over_time": rollup.Max,
+	"sum_over_time": rollup.Sum,
+	"rate":          rollup.Counter,
+	"irate":         rollup.Counter,
+	"increase":      rollup.Counter,
+	"resets":        rollup.Counter,
+}
+
+// rollupFromHints returns the coarsest resolution that still leaves enough
+// datapoints within each step and range vector window of a select, and the
+// rollup aggregation fitting the function applied to it. Range vectors of
+// any other function, such as delta or count_over_time, need raw datapoints
+// so no resolution is returned for them.
+func rollupFromHints(hints *promstorage.SelectHints) (time.Duration, rollup.Aggregation) {
+	aggregation := rollup.Avg
+	if hints.Range > 0 {
+		var ok bool
+		aggregation, ok = rangeRollupAggregations[hints.Func]
+		if !ok {
+			return 0, rollup.Avg
+		}
+	}
+	window := hints.Step
+	if hints.Range > 0 && (window <= 0 || hints.Range < window) {
+		window = hints.Range
+	}
+	return time.Duration(window) * time.Millisecond / datapointsPerWindow, aggregation
+}
+
 func (q *querier) Select(
 	sortSeries bool,
 	hints *promstorage.SelectHints,
@@ -122,11 +164,14 @@ func (q *querier) Select(
 		return promstorage.ErrSeriesSet(err)
 	}
 
+	resolution, aggregation := rollupFromHints(hints)
 	query := &storage.FetchQuery{
-		TagMatchers: matchers,
-		Start:       time.Unix(0, hints.Start*int64(time.Millisecond)),
-		End:         time.Unix(0, hints.End*int64(time.Millisecond)),
-		Interval:    time.Duration(hints.Step) * time.Millisecond,
+		TagMatchers:       matchers,
+		Start:             time.Unix(0, hints.Start*int64(time.Millisecond)),
+		End:               time.Unix(0, hints.End*int64(time.Millisecond)),
+		Interval:          time.Duration(hints.Step) * time.Millisecond,
+		Resolution:        resolution,
+		RollupAggregation: aggregation,
 	}
 
 	// NB (@shreyas): The fetch options builder sets it up from the request
@@ -28,6 +28,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
@@ -92,6 +93,7 @@ func TestSelectWithMetaInContext(t *testing.T) {
 		Start:       start,
 		End:         end,
 		Interval:    time.Duration(step),
+		Resolution:  time.Duration(step) / datapointsPerWindow,
 	}
 
 	meta := block.NewResultMetadata()
@@ -163,3 +165,111 @@ func TestSelectWithMetaInContext(t *testing.T) {
 	// NB: assert warnings on context were propagated.
 	assert.Equal(t, []string{"warn_warning"}, res.WarningStrings())
 }
+
+func TestRollupFromHints(t *testing.T) {
+	tests := []struct {
+		name                string
+		hints               promstorage.SelectHints
+		expectedResolution  time.Duration
+		expectedAggregation rollup.Aggregation
+	}{
+		{
+			name:                "instant selector",
+			hints:               promstorage.SelectHints{},
+			expectedResolution:  0,
+			expectedAggregation: rollup.Avg,
+		},
+		{
+			name:                "step",
+			hints:               promstorage.SelectHints{Step: time.Hour.Milliseconds()},
+			expectedResolution:  12 * time.Minute,
+			expectedAggregation: rollup.Avg,
+		},
+		{
+			name: "range shorter than step",
+			hints: promstorage.SelectHints{
+				Func:  "avg_over_time",
+				Step:  time.Hour.Milliseconds(),
+				Range: (5 * time.Minute).Milliseconds(),
+			},
+			expectedResolution:  time.Minute,
+			expectedAggregation: rollup.Avg,
+		},
+		{
+			name: "range longer than step",
+			hints: promstorage.SelectHints{
+				Func:  "max_over_time",
+				Step:  (5 * time.Minute).Milliseconds(),
+				Range: time.Hour.Milliseconds(),
+			},
+			expectedResolution:  time.Minute,
+			expectedAggregation: rollup.Max,
+		},
+		{
+			name: "range without step",
+			hints: promstorage.SelectHints{
+				Func:  "min_over_time",
+				Range: (10 * time.Minute).Milliseconds(),
+			},
+			expectedResolution:  2 * time.Minute,
+			expectedAggregation: rollup.Min,
+		},
+		{
+			name: "sum over time",
+			hints: promstorage.SelectHints{
+				Func:  "sum_over_time",
+				Step:  time.Hour.Milliseconds(),
+				Range: time.Hour.Milliseconds(),
+			},
+			expectedResolution:  12 * time.Minute,
+			expectedAggregation: rollup.Sum,
+		},
+		{
+			name: "rate",
+			hints: promstorage.SelectHints{
+				Func:  "rate",
+				Step:  time.Hour.Milliseconds(),
+				Range: time.Hour.Milliseconds(),
+			},
+			expectedResolution:  12 * time.Minute,
+			expectedAggregation: rollup.Counter,
+		},
+		{
+			name: "increase",
+			hints: promstorage.SelectHints{
+				Func:  "increase",
+				Step:  time.Hour.Milliseconds(),
+				Range: (10 * time.Minute).Milliseconds(),
+			},
+			expectedResolution:  2 * time.Minute,
+			expectedAggregation: rollup.Counter,
+		},
+		{
+			name: "delta needs raw datapoints",
+			hints: promstorage.SelectHints{
+				Func:  "delta",
+				Step:  time.Hour.Milliseconds(),
+				Range: time.Hour.Milliseconds(),
+			},
+			expectedResolution:  0,
+			expectedAggregation: rollup.Avg,
+		},
+		{
+			name: "count over time needs raw datapoints",
+			hints: promstorage.SelectHints{
+				Func:  "count_over_time",
+				Step:  time.Hour.Milliseconds(),
+				Range: time.Hour.Milliseconds(),
+			},
+			expectedResolution:  0,
+			expectedAggregation: rollup.Avg,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution, aggregation := rollupFromHints(&tt.hints)
+			assert.Equal(t, tt.expectedResolution, resolution)
+			assert.Equal(t, tt.expectedAggregation, aggregation)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// QueryStats accumulates the cost of the fetches performed to serve a query
+// broken down by namespace. It is safe for concurrent use and a nil
+// QueryStats ignores any stats added to it.
+type QueryStats struct {
+	sync.Mutex
+	namespaces map[string]NamespaceQueryStats
+	fetch      wallClock
+	decode     wallClock
+	storage    wallClock
+}
+
+// wallClock measures the wall clock time during which at least one of a
+// number of possibly concurrent operations was in progress, so that
+// concurrent operations are not counted more than once.
+type wallClock struct {
+	inProgress int
+	start      time.Time
+	elapsed    time.Duration
+}
+
+func (c *wallClock) started(t time.Time) {
+	if c.inProgress == 0 {
+		c.start = t
+	}
+	c.inProgress++
+}
+
+func (c *wallClock) finished(t time.Time) {
+	if c.inProgress == 0 {
+		return
+	}
+	c.inProgress--
+	if c.inProgress == 0 {
+		c.elapsed += t.Sub(c.start)
+	}
+}
+
+// NamespaceQueryStats is the cost of the fetches from a namespace.
+type NamespaceQueryStats struct {
+	// SeriesMatched is the number of series matched by the query.
+	SeriesMatched int `json:"seriesMatched"`
+	// DocsRead is the number of index documents read, summed across the
+	// replicas of each shard queried.
+	DocsRead int `json:"docsRead"`
+	// BytesRead is the number of bytes read from disk, summed across the
+	// replicas of each shard queried. Blocks served from memory are not
+	// counted.
+	BytesRead int `json:"bytesRead"`
+	// BlocksFetched is the number of blocks fetched, whether the blocks were
+	// read from disk or from memory.
+	BlocksFetched int `json:"blocksFetched"`
+	// DatapointsDecoded is the number of datapoints decoded.
+	DatapointsDecoded int `json:"datapointsDecoded"`
+}
+
+func (s NamespaceQueryStats) add(other NamespaceQueryStats) NamespaceQueryStats {
+	s.SeriesMatched += other.SeriesMatched
+	s.DocsRead += other.DocsRead
+	s.BytesRead += other.BytesRead
+	s.BlocksFetched += other.BlocksFetched
+	s.DatapointsDecoded += other.DatapointsDecoded
+	return s
+}
+
+// NewQueryStats returns a new query stats accumulator.
+func NewQueryStats() *QueryStats {
+	return &QueryStats{
+		namespaces: make(map[string]NamespaceQueryStats),
+	}
+}
+
+// Add adds the cost of a fetch from a namespace.
+func (s *QueryStats) Add(namespace string, stats NamespaceQueryStats) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	s.namespaces[namespace] = s.namespaces[namespace].add(stats)
+	s.Unlock()
+}
+
+// FetchStarted marks the start of a fetch of series from storage.
+func (s *QueryStats) FetchStarted(t time.Time) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	s.fetch.started(t)
+	s.storage.started(t)
+	s.Unlock()
+}
+
+// FetchFinished marks the end of a fetch of series from storage.
+func (s *QueryStats) FetchFinished(t time.Time) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	s.fetch.finished(t)
+	s.storage.finished(t)
+	s.Unlock()
+}
+
+// DecodeStarted marks the start of decoding fetched series.
+func (s *QueryStats) DecodeStarted(t time.Time) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	s.decode.started(t)
+	s.storage.started(t)
+	s.Unlock()
+}
+
+// DecodeFinished marks the end of decoding fetched series.
+func (s *QueryStats) DecodeFinished(t time.Time) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	s.decode.finished(t)
+	s.storage.finished(t)
+	s.Unlock()
+}
+
+// FetchDuration returns the wall clock time during which series were being
+// fetched from storage.
+func (s *QueryStats) FetchDuration() time.Duration {
+	if s == nil {
+		return 0
+	}
+	s.Lock()
+	defer s.Unlock()
+	return s.fetch.elapsed
+}
+
+// DecodeDuration returns the wall clock time during which fetched series
+// were being decoded.
+func (s *QueryStats) DecodeDuration() time.Duration {
+	if s == nil {
+		return 0
+	}
+	s.Lock()
+	defer s.Unlock()
+	return s.decode.elapsed
+}
+
+// StorageDuration returns the wall clock time during which series were
+// being either fetched or decoded, which is less than the sum of the fetch
+// and decode durations when fetches and decodes overlap.
+func (s *QueryStats) StorageDuration() time.Duration {
+	if s == nil {
+		return 0
+	}
+	s.Lock()
+	defer s.Unlock()
+	return s.storage.elapsed
+}
+
+// Namespaces returns the cost of the fetches from each namespace.
+func (s *QueryStats) Namespaces() map[string]NamespaceQueryStats {
+	if s == nil {
+		return nil
+	}
+	s.Lock()
+	defer s.Unlock()
+	result := make(map[string]NamespaceQueryStats, len(s.namespaces))
+	for namespace, stats := range s.namespaces {
+		result[namespace] = stats
+	}
+	return result
+}
+
+// Total returns the cost of the fetches from all namespaces.
+func (s *QueryStats) Total() NamespaceQueryStats {
+	var total NamespaceQueryStats
+	for _, stats := range s.Namespaces() {
+		total = total.add(stats)
+	}
+	return total
+}
+
+// MarshalJSON returns the total cost along with the cost of each namespace.
+func (s *QueryStats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		NamespaceQueryStats
+		Namespaces map[string]NamespaceQueryStats `json:"namespaces"`
+	}{
+		NamespaceQueryStats: s.Total(),
+		Namespaces:          s.Namespaces(),
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryStatsAdd(t *testing.T) {
+	stats := NewQueryStats()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stats.Add("raw", NamespaceQueryStats{
+				SeriesMatched: 1,
+				DocsRead:      2,
+				BytesRead:     3,
+				BlocksFetched: 4,
+			})
+		}()
+	}
+	wg.Wait()
+	stats.Add("agg", NamespaceQueryStats{DatapointsDecoded: 5})
+
+	assert.Equal(t, map[string]NamespaceQueryStats{
+		"raw": {SeriesMatched: 10, DocsRead: 20, BytesRead: 30, BlocksFetched: 40},
+		"agg": {DatapointsDecoded: 5},
+	}, stats.Namespaces())
+	assert.Equal(t, NamespaceQueryStats{
+		SeriesMatched:     10,
+		DocsRead:          20,
+		BytesRead:         30,
+		BlocksFetched:     40,
+		DatapointsDecoded: 5,
+	}, stats.Total())
+}
+
+func TestQueryStatsDurations(t *testing.T) {
+	var (
+		stats = NewQueryStats()
+		start = time.Unix(1600000000, 0)
+	)
+
+	// Two concurrent fetches from 0s to 3s and from 1s to 4s, the second of
+	// which overlaps a decode from 2s to 6s.
+	stats.FetchStarted(start)
+	stats.FetchStarted(start.Add(time.Second))
+	stats.DecodeStarted(start.Add(2 * time.Second))
+	stats.FetchFinished(start.Add(3 * time.Second))
+	stats.FetchFinished(start.Add(4 * time.Second))
+	stats.DecodeFinished(start.Add(6 * time.Second))
+
+	// A later decode from 10s to 11s.
+	stats.DecodeStarted(start.Add(10 * time.Second))
+	stats.DecodeFinished(start.Add(11 * time.Second))
+
+	assert.Equal(t, 4*time.Second, stats.FetchDuration())
+	assert.Equal(t, 5*time.Second, stats.DecodeDuration())
+	assert.Equal(t, 7*time.Second, stats.StorageDuration())
+}
+
+func TestQueryStatsNil(t *testing.T) {
+	var stats *QueryStats
+	stats.Add("raw", NamespaceQueryStats{SeriesMatched: 1})
+	stats.FetchStarted(time.Now())
+	stats.FetchFinished(time.Now())
+	assert.Nil(t, stats.Namespaces())
+	assert.Equal(t, NamespaceQueryStats{}, stats.Total())
+	assert.Equal(t, time.Duration(0), stats.FetchDuration())
+}
+
+func TestQueryStatsMarshalJSON(t *testing.T) {
+	stats := NewQueryStats()
+	stats.Add("raw", NamespaceQueryStats{SeriesMatched: 1, DatapointsDecoded: 2})
+
+	js, err := json.Marshal(stats)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"seriesMatched": 1,
+		"docsRead": 0,
+		"bytesRead": 0,
+		"blocksFetched": 0,
+		"datapointsDecoded": 2,
+		"namespaces": {
+			"raw": {
+				"seriesMatched": 1,
+				"docsRead": 0,
+				"bytesRead": 0,
+				"blocksFetched": 0,
+				"datapointsDecoded": 2
+			}
+		}
+	}`, string(js))
+}
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/persist/fs/rollup"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/block"
@@ -104,6 +105,12 @@ type FetchQuery struct {
 	Start       time.Time       `json:"start"`
 	End         time.Time       `json:"end"`
 	Interval    time.Duration   `json:"interval"`
+	// Resolution is an optional hint of the coarsest resolution the query
+	// needs, allowing storage to serve rollups instead of raw datapoints.
+	Resolution time.Duration `json:"resolution"`
+	// RollupAggregation is the aggregation of the rollups served when the
+	// resolution allows, it should fit the function applied to the series.
+	RollupAggregation rollup.Aggregation `json:"rollupAggregation"`
 }
 
 // FetchOptions represents the options for fetch query.
@@ -130,6 +137,8 @@ type FetchOptions struct {
 	RequireNoWait bool
 	// MaxMetricMetadataStats is the maximum number of metric metadata stats to return.
 	MaxMetricMetadataStats int
+	// IndexExplain requests a trace of the index query from each host.
+	IndexExplain bool
 	// BlockType is the block type that the fetch function returns.
 	BlockType models.FetchedBlockType
 	// FanoutOptions are the options for the fetch namespace fanout.
@@ -151,6 +160,13 @@ type FetchOptions struct {
 	IterateEqualTimestampStrategy *encoding.IterateEqualTimestampStrategy
 	// Source is the source for the query.
 	Source []byte
+	// NativeHistograms returns native histograms as is in Prometheus results
+	// rather than expanding them into classic bucket series, for clients
+	// that accept them.
+	NativeHistograms bool
+	// Stats accumulates the cost of the fetches performed for the query,
+	// shared between the clones of the fetch options.
+	Stats *QueryStats
 
 	RelatedQueryOptions *RelatedQueryOptions
 }
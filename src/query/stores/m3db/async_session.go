@@ -178,6 +178,23 @@ func (s *AsyncSession) FetchTagged(
 	return s.session.FetchTagged(ctx, namespace, q, opts)
 }
 
+// FetchTaggedPages resolves the provided query to known IDs, and fetches
+// the data for them a page of series at a time.
+func (s *AsyncSession) FetchTaggedPages(
+	ctx context.Context,
+	namespace ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+) (client.FetchTaggedPagesIterator, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.session.FetchTaggedPages(ctx, namespace, q, opts)
+}
+
 // FetchTaggedIDs resolves the provided query to known IDs.
 func (s *AsyncSession) FetchTaggedIDs(
 	ctx context.Context,
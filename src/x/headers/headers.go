@@ -62,6 +62,16 @@ const (
 	// SourceHeader tracks bytes and docs read for the given source, if provided.
 	SourceHeader = M3HeaderPrefix + "Source"
 
+	// TenantHeader is the tenant of the request, it is used as the source of
+	// fetches if the source header is not provided so that the tenant is
+	// subject to the per source query limits of each dbnode.
+	TenantHeader = M3HeaderPrefix + "Tenant"
+
+	// NativeHistogramsHeader is set to "true" by remote read clients that
+	// accept native histograms, which are otherwise expanded into classic
+	// cumulative bucket series with an "le" label.
+	NativeHistogramsHeader = M3HeaderPrefix + "Native-Histograms"
+
 	// DefaultWriteType is the default write type.
 	DefaultWriteType = "default"
 
@@ -134,6 +144,10 @@ const (
 	// the number of metric metadata stats returned in M3-Metric-Stats.
 	LimitMaxMetricMetadataStatsHeader = M3HeaderPrefix + "Limit-Max-Metric-Metadata-Stats"
 
+	// IndexExplainHeader is the M3 header that requests a trace of the index
+	// query be returned in the debug section of the query response.
+	IndexExplainHeader = M3HeaderPrefix + "Index-Explain"
+
 	// UnaggregatedStoragePolicy specifies the unaggregated storage policy.
 	UnaggregatedStoragePolicy = "unaggregated"
 
@@ -172,6 +186,10 @@ const (
 	// are maxed.
 	LimitHeaderSeriesLimitApplied = "max_fetch_series_limit_applied"
 
+	// LimitHeaderFetchedBytesLimitApplied is the header applied when paged
+	// fetch results are truncated at their max bytes.
+	LimitHeaderFetchedBytesLimitApplied = "max_fetch_bytes_limit_applied"
+
 	// WaitedHeader is the header added when permits had to be waited for.
 	WaitedHeader = M3HeaderPrefix + "Waited"
 
@@ -215,6 +233,10 @@ const (
 	// metadata that was fetched by the query, before computation.
 	FetchedMetadataCount = M3HeaderPrefix + "Metadata-Count"
 
+	// QueryStatsHeader is the header added with the JSON cost of the fetches
+	// performed by the query, in total and by namespace.
+	QueryStatsHeader = M3HeaderPrefix + "Query-Stats"
+
 	// RenderFormat is used to switch result format for query results rendering.
 	RenderFormat = M3HeaderPrefix + "Render-Format"
 